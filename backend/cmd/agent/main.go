@@ -0,0 +1,56 @@
+// Command agent runs backups of databases that Databasus cannot reach. It connects out to
+// Databasus, receives dump jobs and streams their output back, nothing listens on the
+// agent host. It is configured by environment:
+//
+//	DATABASUS_URL          Databasus URL, e.g. https://databasus.example.com
+//	DATABASUS_AGENT_TOKEN  token printed once on creation of the agent
+//	PG_DUMP_PATH           pg_dump binary, "pg_dump" from PATH by default
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"databasus-backend/internal/agent"
+	"databasus-backend/internal/util/logger"
+)
+
+// version is set on build with -ldflags "-X main.version=..."
+var version = "dev"
+
+func main() {
+	log := logger.GetLogger()
+
+	databasusURL := os.Getenv("DATABASUS_URL")
+	token := os.Getenv("DATABASUS_AGENT_TOKEN")
+	if databasusURL == "" || token == "" {
+		log.Error("DATABASUS_URL and DATABASUS_AGENT_TOKEN are required")
+		os.Exit(1)
+	}
+
+	pgDumpPath := os.Getenv("PG_DUMP_PATH")
+	if pgDumpPath == "" {
+		pgDumpPath = "pg_dump"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("Agent started", "version", version, "databasusUrl", databasusURL)
+
+	agent.New(
+		agent.Config{
+			DatabasusURL: databasusURL,
+			Token:        token,
+			Version:      version,
+			Tools:        map[string]string{"pg_dump": pgDumpPath},
+		},
+		&http.Client{},
+		log,
+	).Run(ctx)
+
+	log.Info("Agent stopped")
+}
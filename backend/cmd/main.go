@@ -13,30 +13,74 @@ import (
 	"time"
 
 	"databasus-backend/internal/config"
+	"databasus-backend/internal/features/agents"
 	"databasus-backend/internal/features/audit_logs"
 	"databasus-backend/internal/features/backups/backups"
+	backups_adoption "databasus-backend/internal/features/backups/backups/adoption"
 	"databasus-backend/internal/features/backups/backups/backuping"
 	backups_download "databasus-backend/internal/features/backups/backups/download"
+	backups_badges "databasus-backend/internal/features/backups/badges"
+	backups_calendars "databasus-backend/internal/features/backups/calendars"
 	backups_config "databasus-backend/internal/features/backups/config"
+	backups_grafana "databasus-backend/internal/features/backups/grafana"
+	backups_manifests "databasus-backend/internal/features/backups/manifests"
+	backups_runbooks "databasus-backend/internal/features/backups/runbooks"
+	backups_status_pages "databasus-backend/internal/features/backups/status_pages"
+	backups_triggers "databasus-backend/internal/features/backups/triggers"
+	billing_subscriptions "databasus-backend/internal/features/billing/subscriptions"
+	billing_usage "databasus-backend/internal/features/billing/usage"
+	"databasus-backend/internal/features/chatops"
+	"databasus-backend/internal/features/client_certificates"
+	"databasus-backend/internal/features/comments"
+	"databasus-backend/internal/features/credential_expiry"
 	"databasus-backend/internal/features/databases"
+	databases_templates "databasus-backend/internal/features/databases/templates"
 	"databasus-backend/internal/features/disk"
 	"databasus-backend/internal/features/encryption/secrets"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/feature_flags"
+	"databasus-backend/internal/features/graphql"
 	healthcheck_attempt "databasus-backend/internal/features/healthcheck/attempt"
 	healthcheck_config "databasus-backend/internal/features/healthcheck/config"
+	"databasus-backend/internal/features/imports"
+	"databasus-backend/internal/features/localization"
+	"databasus-backend/internal/features/masking"
 	"databasus-backend/internal/features/notifiers"
+	notifiers_broadcasts "databasus-backend/internal/features/notifiers/broadcasts"
+	notifiers_security_events "databasus-backend/internal/features/notifiers/security_events"
+	notifiers_tickets "databasus-backend/internal/features/notifiers/tickets"
+	ownership_orphans "databasus-backend/internal/features/ownership/orphans"
+	"databasus-backend/internal/features/preferences"
 	"databasus-backend/internal/features/restores"
+	restores_plans "databasus-backend/internal/features/restores/plans"
+	restores_refreshes "databasus-backend/internal/features/restores/refreshes"
 	"databasus-backend/internal/features/restores/restoring"
+	"databasus-backend/internal/features/saved_views"
 	"databasus-backend/internal/features/storages"
+	storages_impact "databasus-backend/internal/features/storages/impact"
+	storages_mirrors "databasus-backend/internal/features/storages/mirrors"
+	storages_usage "databasus-backend/internal/features/storages/usage"
+	system_debug "databasus-backend/internal/features/system/debug"
 	system_healthcheck "databasus-backend/internal/features/system/healthcheck"
+	system_leader "databasus-backend/internal/features/system/leader"
+	system_metadata_backup "databasus-backend/internal/features/system/metadata_backup"
+	system_migrations "databasus-backend/internal/features/system/migrations"
+	system_settings "databasus-backend/internal/features/system/settings"
+	system_tls "databasus-backend/internal/features/system/tls"
+	system_version "databasus-backend/internal/features/system/version"
 	task_cancellation "databasus-backend/internal/features/tasks/cancellation"
 	users_controllers "databasus-backend/internal/features/users/controllers"
 	users_middleware "databasus-backend/internal/features/users/middleware"
 	users_services "databasus-backend/internal/features/users/services"
 	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	"databasus-backend/internal/util/apiversion"
 	cache_utils "databasus-backend/internal/util/cache"
 	env_utils "databasus-backend/internal/util/env"
+	"databasus-backend/internal/util/errortracking"
 	files_utils "databasus-backend/internal/util/files"
 	"databasus-backend/internal/util/logger"
+	network_utils "databasus-backend/internal/util/network"
+	proxy_utils "databasus-backend/internal/util/proxy"
 	_ "databasus-backend/swagger" // swagger docs
 
 	"github.com/gin-contrib/cors"
@@ -46,6 +90,23 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+var (
+	newPasswordFlag = flag.String("new-password", "", "Set a new password for the user")
+	emailFlag       = flag.String("email", "", "Email of the user to reset password")
+	// Path to a dump made by metadata backup, see docs/metadata-backup.md
+	restoreMetadataFromFlag = flag.String(
+		"restore-metadata-from",
+		"",
+		"Restore internal database from a metadata backup file and exit",
+	)
+	migrateOnlyFlag          = flag.Bool("migrate-only", false, "Run database migrations and exit")
+	rollbackMigrationsToFlag = flag.Int64(
+		"rollback-migrations-to",
+		-1,
+		"Roll back database migrations down to the version and exit",
+	)
+)
+
 // @title Databasus Backend API
 // @version 1.0
 // @description API for Databasus
@@ -54,12 +115,22 @@ import (
 // @host localhost:4005
 // @BasePath /api/v1
 // @schemes http
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
 	log := logger.GetLogger()
 
 	cache_utils.TestCacheConnection()
 
-	if config.GetEnv().IsPrimaryNode {
+	proxy_utils.ConfigureGlobalProxy(
+		config.GetEnv().OutboundProxyURL,
+		config.GetEnv().OutboundNoProxy,
+	)
+
+	// With leader election other primary nodes keep running and use the cache (including the
+	// leader lock), so it is not cleared on start of any single node
+	if config.GetEnv().IsPrimaryNode && !config.GetEnv().IsLeaderElectionEnabled {
 		log.Info("Clearing cache...")
 
 		err := cache_utils.ClearAllCache()
@@ -69,12 +140,22 @@ func main() {
 		}
 	}
 
-	if config.GetEnv().IsPrimaryNode {
+	handleMetadataRestore(log)
+	handleMigrationCommands(log)
+
+	isLeader := config.GetEnv().IsPrimaryNode
+	if isLeader && config.GetEnv().IsLeaderElectionEnabled {
+		isLeader = acquireLeadershipOnStartup(log)
+	}
+
+	if isLeader && !config.GetEnv().IsAutoMigrationDisabled {
 		runMigrations(log)
 	} else {
-		log.Info("Skipping migrations (IS_PRIMARY_NODE is false)")
+		log.Info("Skipping migrations (node is not the leader or auto migration is disabled)")
 	}
 
+	checkSchemaCompatibility(log)
+
 	// create directories that used for backups and restore
 	err := files_utils.EnsureDirectories([]string{
 		config.GetEnv().TempFolder,
@@ -114,9 +195,14 @@ func main() {
 		),
 	))
 
+	ginApp.Use(errortracking.GinMiddleware())
+
+	setUpTrustedProxies(log, ginApp)
 	enableCors(ginApp)
+	ginApp.Use(users_middleware.SecurityHeadersMiddleware(users_services.GetSettingsService()))
 	setUpRoutes(ginApp)
 	setUpDependencies()
+	setUpSettingsReload(log)
 
 	runBackgroundTasks(log)
 
@@ -128,23 +214,42 @@ func main() {
 func handlePasswordReset(log *slog.Logger) {
 	audit_logs.SetupDependencies()
 
-	newPassword := flag.String("new-password", "", "Set a new password for the user")
-	email := flag.String("email", "", "Email of the user to reset password")
-
 	flag.Parse()
 
-	if *newPassword == "" {
+	if *newPasswordFlag == "" {
 		return
 	}
 
 	log.Info("Found reset password command - reseting password...")
 
-	if *email == "" {
+	if *emailFlag == "" {
 		log.Info("No email provided, please provide an email via --email=\"some@email.com\" flag")
 		os.Exit(1)
 	}
 
-	resetPassword(*email, *newPassword, log)
+	resetPassword(*emailFlag, *newPasswordFlag, log)
+}
+
+// handleMetadataRestore runs before migrations: the dump carries its own schema version,
+// so migrations afterwards bring an older dump up to the current release
+func handleMetadataRestore(log *slog.Logger) {
+	flag.Parse()
+
+	if *restoreMetadataFromFlag == "" {
+		return
+	}
+
+	log.Info("Found restore metadata command - restoring internal database...")
+
+	err := system_metadata_backup.GetMetadataBackupService().
+		RestoreFromFile(*restoreMetadataFromFlag)
+	if err != nil {
+		log.Error("Failed to restore metadata backup", "error", err)
+		os.Exit(1)
+	}
+
+	log.Info("Metadata backup restored successfully, start the app without the flag")
+	os.Exit(0)
 }
 
 func resetPassword(email string, newPassword string, log *slog.Logger) {
@@ -169,13 +274,34 @@ func startServerWithGracefulShutdown(log *slog.Logger, app *gin.Engine) {
 		host = "127.0.0.1"
 	}
 
+	serverTLSService := system_tls.GetServerTLSService()
+
+	tlsConfig, err := serverTLSService.BuildServerTLSConfig()
+	if err != nil {
+		log.Error("Failed to set up API TLS", "error", err)
+		os.Exit(1)
+	}
+
+	tlsCtx, cancelTLS := context.WithCancel(context.Background())
+	defer cancelTLS()
+	go serverTLSService.Run(tlsCtx)
+
 	srv := &http.Server{
-		Addr:    host + ":4005",
-		Handler: app,
+		Addr:      host + ":4005",
+		Handler:   app,
+		TLSConfig: tlsConfig,
 	}
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if srv.TLSConfig != nil {
+			// certificates are already loaded into TLSConfig
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+
+		if err != nil && err != http.ErrServerClosed {
 			log.Error("listen:", "error", err)
 		}
 	}()
@@ -188,6 +314,10 @@ func startServerWithGracefulShutdown(log *slog.Logger, app *gin.Engine) {
 	// Gracefully shutdown VictoriaLogs writer
 	logger.ShutdownVictoriaLogs(5 * time.Second)
 
+	storages.GetStorageService().ShutdownStoragePlugins()
+
+	errortracking.GetErrorTracker().Flush(5 * time.Second)
+
 	// The context is used to inform the server it has 10 seconds to finish
 	// the request it is currently handling
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -200,52 +330,141 @@ func startServerWithGracefulShutdown(log *slog.Logger, app *gin.Engine) {
 }
 
 func setUpRoutes(r *gin.Engine) {
-	v1 := r.Group("/api/v1")
+	v1 := r.Group(apiversion.V1Prefix)
+	v1.Use(apiversion.Deprecated(config.GetEnv().APIV1SunsetDate))
 
 	// Mount Swagger UI
 	v1.GET("/docs/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	v1.StaticFile("/docs/openapi.json", "./swagger/openapi.json")
+
+	setUpVersionedRoutes(v1, false)
 
+	// v2 differs from v1 only in the error envelope and split create/update routes
+	v2 := r.Group(apiversion.V2Prefix)
+	v2.Use(apiversion.WithErrorEnvelope())
+
+	setUpVersionedRoutes(v2, true)
+}
+
+func setUpVersionedRoutes(api *gin.RouterGroup, isV2 bool) {
 	// Public routes (only user auth routes and healthcheck should be public)
 	userController := users_controllers.GetUserController()
-	userController.RegisterRoutes(v1)
-	system_healthcheck.GetHealthcheckController().RegisterRoutes(v1)
-	backups.GetBackupController().RegisterPublicRoutes(v1)
+	userController.RegisterRoutes(api)
+	system_healthcheck.GetHealthcheckController().RegisterRoutes(api)
+	users_controllers.GetBrandingController().RegisterPublicRoutes(api)
+	backups.GetBackupController().RegisterPublicRoutes(api)
+	backups_status_pages.GetStatusPageController().RegisterPublicRoutes(api)
+	backups_calendars.GetCalendarFeedController().RegisterPublicRoutes(api)
+	backups_badges.GetBackupBadgeController().RegisterPublicRoutes(api)
+	chatops.GetChatopsController().RegisterPublicRoutes(api)
+	backups_triggers.GetBackupTriggerController().RegisterPublicRoutes(api)
+	billing_subscriptions.GetSubscriptionController().RegisterPublicRoutes(api)
+	notifiers.GetNotifierController().RegisterPublicRoutes(api)
+	// Agent routes authenticate by agent token
+	agents.GetAgentController().RegisterAgentRoutes(api)
 
 	// Setup auth middleware
 	userService := users_services.GetUserService()
 	authMiddleware := users_middleware.AuthMiddleware(userService)
 
 	// Protected routes
-	protected := v1.Group("")
+	protected := api.Group("")
 	protected.Use(authMiddleware)
 
 	userController.RegisterProtectedRoutes(protected)
 	workspaces_controllers.GetWorkspaceController().RegisterRoutes(protected)
 	workspaces_controllers.GetMembershipController().RegisterRoutes(protected)
 	disk.GetDiskController().RegisterRoutes(protected)
-	notifiers.GetNotifierController().RegisterRoutes(protected)
-	storages.GetStorageController().RegisterRoutes(protected)
-	databases.GetDatabaseController().RegisterRoutes(protected)
+	agents.GetAgentController().RegisterRoutes(protected)
+
+	if isV2 {
+		notifiers.GetNotifierController().RegisterRoutesV2(protected)
+		storages.GetStorageController().RegisterRoutesV2(protected)
+		databases.GetDatabaseController().RegisterRoutesV2(protected)
+	} else {
+		notifiers.GetNotifierController().RegisterRoutes(protected)
+		storages.GetStorageController().RegisterRoutes(protected)
+		databases.GetDatabaseController().RegisterRoutes(protected)
+	}
+
 	backups.GetBackupController().RegisterRoutes(protected)
+	backups_adoption.GetBackupAdoptionController().RegisterRoutes(protected)
+	databases_templates.GetConnectionTemplateController().RegisterRoutes(protected)
+	storages_impact.GetStorageImpactController().RegisterRoutes(protected)
+	storages_usage.GetStorageUsageController().RegisterRoutes(protected)
+	storages_mirrors.GetStorageMirrorController().RegisterRoutes(protected)
+	credential_expiry.GetCredentialExpiryController().RegisterRoutes(protected)
+	comments.GetCommentController().RegisterRoutes(protected)
+	ownership_orphans.GetOrphanedResourceController().RegisterRoutes(protected)
+	saved_views.GetSavedViewController().RegisterRoutes(protected)
+	preferences.GetPreferencesController().RegisterRoutes(protected)
 	restores.GetRestoreController().RegisterRoutes(protected)
+	masking.GetMaskingController().RegisterRoutes(protected)
+	notifiers_broadcasts.GetBroadcastController().RegisterRoutes(protected)
+	notifiers_security_events.GetSecurityEventController().RegisterRoutes(protected)
+	notifiers_tickets.GetTicketController().RegisterRoutes(protected)
+	restores_refreshes.GetRefreshController().RegisterRoutes(protected)
+	restores_plans.GetRestorePlanController().RegisterRoutes(protected)
 	healthcheck_config.GetHealthcheckConfigController().RegisterRoutes(protected)
 	healthcheck_attempt.GetHealthcheckAttemptController().RegisterRoutes(protected)
 	backups_config.GetBackupConfigController().RegisterRoutes(protected)
+	backups_status_pages.GetStatusPageController().RegisterRoutes(protected)
+	backups_grafana.GetGrafanaController().RegisterRoutes(protected)
+	backups_runbooks.GetRunbookController().RegisterRoutes(protected)
+	backups_calendars.GetCalendarFeedController().RegisterRoutes(protected)
+	backups_triggers.GetBackupTriggerController().RegisterRoutes(protected)
+	backups_badges.GetBackupBadgeController().RegisterRoutes(protected)
+	chatops.GetChatopsController().RegisterRoutes(protected)
+	imports.GetImportController().RegisterRoutes(protected)
 	audit_logs.GetAuditLogController().RegisterRoutes(protected)
 	users_controllers.GetManagementController().RegisterRoutes(protected)
 	users_controllers.GetSettingsController().RegisterRoutes(protected)
+	users_controllers.GetBrandingController().RegisterRoutes(protected)
+	client_certificates.GetClientCertificateController().RegisterRoutes(protected)
+	billing_usage.GetUsageController().RegisterRoutes(protected)
+	billing_subscriptions.GetSubscriptionController().RegisterRoutes(protected)
+	localization.GetLocalizationController().RegisterRoutes(protected)
+	feature_flags.GetFeatureFlagController().RegisterRoutes(protected)
+	system_version.GetVersionController().RegisterRoutes(protected)
+	encryption_signing.GetSigningController().RegisterRoutes(protected)
+	system_metadata_backup.GetMetadataBackupController().RegisterRoutes(protected)
+	system_settings.GetSettingsController().RegisterRoutes(protected)
+	system_debug.GetDebugController().RegisterRoutes(protected)
+
+	if config.GetEnv().IsGraphQLEnabled {
+		graphql.GetGraphQLController().RegisterRoutes(protected)
+	}
 }
 
 func setUpDependencies() {
 	databases.SetupDependencies()
 	backups.SetupDependencies()
+	backups_runbooks.SetupDependencies()
 	restores.SetupDependencies()
+	masking.SetupDependencies()
 	healthcheck_config.SetupDependencies()
 	audit_logs.SetupDependencies()
 	notifiers.SetupDependencies()
+	notifiers_security_events.SetupDependencies()
+	client_certificates.SetupDependencies()
 	storages.SetupDependencies()
+	storages_usage.SetupDependencies()
+	storages_mirrors.SetupDependencies()
 	backups_config.SetupDependencies()
 	task_cancellation.SetupDependencies()
+	billing_subscriptions.SetupDependencies()
+	localization.SetupDependencies()
+	agents.SetupDependencies()
+}
+
+// setUpSettingsReload reloads settings on SIGHUP of this node and on reload requests
+// handled by any other node
+func setUpSettingsReload(log *slog.Logger) {
+	if err := system_settings.GetSettingsService().SubscribeForReloads(); err != nil {
+		log.Error("Failed to subscribe for settings reloads", "error", err)
+	}
+
+	config.StartListeningForReloadSignal()
 }
 
 func runBackgroundTasks(log *slog.Logger) {
@@ -269,62 +488,155 @@ func runBackgroundTasks(log *slog.Logger) {
 	}
 
 	if config.GetEnv().IsPrimaryNode {
-		log.Info("Starting primary node background tasks...")
+		if config.GetEnv().IsLeaderElectionEnabled {
+			go runWithPanicLogging(log, "leader election", func() {
+				system_leader.GetLeaderElector().Run(
+					ctx,
+					func() { runPrimaryBackgroundTasks(ctx, log) },
+					func() {
+						// Schedulers cannot be stopped and started again in the same process
+						log.Error("Leadership lost, exiting to rejoin as a candidate")
+						os.Exit(1)
+					},
+				)
+			})
+		} else {
+			runPrimaryBackgroundTasks(ctx, log)
+		}
+	} else {
+		log.Info("Skipping primary node tasks as not primary node")
+	}
 
-		go runWithPanicLogging(log, "backup background service", func() {
-			backuping.GetBackupsScheduler().Run(ctx)
-		})
+	if config.GetEnv().IsProcessingNode {
+		log.Info("Starting backup node background tasks...")
 
-		go runWithPanicLogging(log, "backup cleaner background service", func() {
-			backuping.GetBackupCleaner().Run(ctx)
+		go runWithPanicLogging(log, "backup node", func() {
+			backuping.GetBackuperNode().Run(ctx)
 		})
 
-		go runWithPanicLogging(log, "restore background service", func() {
-			restoring.GetRestoresScheduler().Run(ctx)
+		go runWithPanicLogging(log, "restore node", func() {
+			restoring.GetRestorerNode().Run(ctx)
 		})
+	} else {
+		log.Info("Skipping backup/restore node tasks as not backup node")
+	}
+}
 
-		go runWithPanicLogging(log, "healthcheck attempt background service", func() {
-			healthcheck_attempt.GetHealthcheckAttemptBackgroundService().Run(ctx)
-		})
+func runPrimaryBackgroundTasks(ctx context.Context, log *slog.Logger) {
+	log.Info("Starting primary node background tasks...")
 
-		go runWithPanicLogging(log, "audit log cleanup background service", func() {
-			audit_logs.GetAuditLogBackgroundService().Run(ctx)
-		})
+	go runWithPanicLogging(log, "backup background service", func() {
+		backuping.GetBackupsScheduler().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "backup cleaner background service", func() {
+		backuping.GetBackupCleaner().Run(ctx)
+	})
 
-		go runWithPanicLogging(log, "download token cleanup background service", func() {
-			backups_download.GetDownloadTokenBackgroundService().Run(ctx)
+	go runWithPanicLogging(log, "restore background service", func() {
+		restoring.GetRestoresScheduler().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "refresh background service", func() {
+		restores_refreshes.GetRefreshBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "restore plan background service", func() {
+		restores_plans.GetRestorePlanBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "ticket background service", func() {
+		notifiers_tickets.GetTicketBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "Grafana background service", func() {
+		backups_grafana.GetGrafanaBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "backup manifests background service", func() {
+		backups_manifests.GetManifestBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "storage usage background service", func() {
+		storages_usage.GetStorageUsageBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "storage mirror background service", func() {
+		storages_mirrors.GetStorageMirrorBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "healthcheck attempt background service", func() {
+		healthcheck_attempt.GetHealthcheckAttemptBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "notifier health check background service", func() {
+		notifiers.GetNotifierHealthCheckBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "credential expiry reminders background service", func() {
+		credential_expiry.GetCredentialExpiryBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "audit log cleanup background service", func() {
+		audit_logs.GetAuditLogBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "download token cleanup background service", func() {
+		backups_download.GetDownloadTokenBackgroundService().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "backup nodes registry background service", func() {
+		backuping.GetBackupNodesRegistry().Run(ctx)
+	})
+
+	go runWithPanicLogging(log, "restore nodes registry background service", func() {
+		restoring.GetRestoreNodesRegistry().Run(ctx)
+	})
+
+	if !config.GetEnv().IsUpdateCheckDisabled {
+		go runWithPanicLogging(log, "update checker background service", func() {
+			system_version.GetVersionBackgroundService().Run(ctx)
 		})
+	}
 
-		go runWithPanicLogging(log, "backup nodes registry background service", func() {
-			backuping.GetBackupNodesRegistry().Run(ctx)
+	if config.GetEnv().MetadataBackupStorageID != "" {
+		go runWithPanicLogging(log, "metadata backup background service", func() {
+			system_metadata_backup.GetMetadataBackupBackgroundService().Run(ctx)
 		})
+	}
 
-		go runWithPanicLogging(log, "restore nodes registry background service", func() {
-			restoring.GetRestoreNodesRegistry().Run(ctx)
+	if config.GetEnv().IsCloud {
+		go runWithPanicLogging(log, "usage metering background service", func() {
+			billing_usage.GetUsageBackgroundService().Run(ctx)
 		})
-	} else {
-		log.Info("Skipping primary node tasks as not primary node")
 	}
+}
 
-	if config.GetEnv().IsProcessingNode {
-		log.Info("Starting backup node background tasks...")
+func acquireLeadershipOnStartup(log *slog.Logger) bool {
+	leaderElector := system_leader.GetLeaderElector()
 
-		go runWithPanicLogging(log, "backup node", func() {
-			backuping.GetBackuperNode().Run(ctx)
-		})
+	isAcquired, err := leaderElector.AcquireOnStartup()
+	if err != nil {
+		log.Error("Failed to acquire leadership", "error", err)
+		os.Exit(1)
+	}
 
-		go runWithPanicLogging(log, "restore node", func() {
-			restoring.GetRestorerNode().Run(ctx)
-		})
+	if isAcquired {
+		log.Info("Node is elected as the leader", "nodeId", leaderElector.GetNodeID())
 	} else {
-		log.Info("Skipping backup/restore node tasks as not backup node")
+		log.Info("Another node is the leader, running as a candidate")
 	}
+
+	return isAcquired
 }
 
 func runWithPanicLogging(log *slog.Logger, serviceName string, fn func()) {
 	defer func() {
 		if r := recover(); r != nil {
 			log.Error("Panic in "+serviceName, "error", r)
+			errortracking.
+				GetErrorTracker().
+				CapturePanic(r, map[string]string{"service": serviceName})
 		}
 	}()
 	fn()
@@ -361,20 +673,55 @@ func generateSwaggerDocs(log *slog.Logger) {
 func runMigrations(log *slog.Logger) {
 	log.Info("Running database migrations...")
 
-	cmd := exec.Command("goose", "-dir", "./migrations", "up")
-	cmd.Env = append(
-		os.Environ(),
-		"GOOSE_DRIVER=postgres",
-		"GOOSE_DBSTRING="+config.GetEnv().DatabaseDsn,
-	)
+	if err := system_migrations.GetMigrationService().Migrate(); err != nil {
+		log.Error("Failed to run migrations", "error", err)
+		os.Exit(1)
+	}
+}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		log.Error("Failed to run migrations", "error", err, "output", string(output))
+// handleMigrationCommands lets clustered deployments upgrade the schema as a separate step
+// (e.g. a Kubernetes job) before rolling out nodes with IS_AUTO_MIGRATION_DISABLED
+func handleMigrationCommands(log *slog.Logger) {
+	flag.Parse()
+
+	if *rollbackMigrationsToFlag >= 0 {
+		log.Info("Found rollback migrations command", "version", *rollbackMigrationsToFlag)
+
+		err := system_migrations.GetMigrationService().RollbackTo(*rollbackMigrationsToFlag)
+		if err != nil {
+			log.Error("Failed to roll back migrations", "error", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	if *migrateOnlyFlag {
+		runMigrations(log)
+		os.Exit(0)
+	}
+}
+
+func checkSchemaCompatibility(log *slog.Logger) {
+	if err := system_migrations.GetMigrationService().WaitForSchemaCompatibility(); err != nil {
+		log.Error("Database schema is not compatible with this binary", "error", err)
 		os.Exit(1)
 	}
+}
 
-	log.Info("Database migrations completed successfully", "output", string(output))
+// setUpTrustedProxies makes ClientIP, which rate limits, the debug endpoints gate and audit
+// logs rely on, follow forwarding headers of TRUSTED_PROXIES only
+func setUpTrustedProxies(log *slog.Logger, ginApp *gin.Engine) {
+	env := config.GetEnv()
+
+	if err := network_utils.ConfigureClientIP(
+		ginApp,
+		env.TrustedProxies,
+		env.ClientIPHeaders,
+	); err != nil {
+		log.Error("Failed to set trusted proxies", "error", err)
+		os.Exit(1)
+	}
 }
 
 func enableCors(ginApp *gin.Engine) {
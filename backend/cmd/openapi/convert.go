@@ -0,0 +1,449 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	errorResponseSchema = "ErrorResponse"
+	bearerAuthScheme    = "BearerAuth"
+)
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// convertSwagger turns the Swagger 2.0 document produced by swag into OpenAPI 3.1. Besides
+// the format change it fills what swag comments leave implicit: every operation gets an
+// operationId, JWT header parameters become the BearerAuth security scheme and bare error
+// responses reference the {"error": "..."} envelope all controllers answer with
+func convertSwagger(swagger map[string]any, version string) (map[string]any, error) {
+	if swagger["swagger"] != "2.0" {
+		return nil, fmt.Errorf("expected Swagger 2.0 document, got %v", swagger["swagger"])
+	}
+
+	info := getMap(swagger, "info")
+	if version != "" {
+		info["version"] = version
+	}
+
+	schemas := map[string]any{}
+	for name, definition := range getMap(swagger, "definitions") {
+		schemas[name] = convertSchema(definition)
+	}
+
+	schemas[errorResponseSchema] = map[string]any{
+		"type":     "object",
+		"required": []any{"error"},
+		"properties": map[string]any{
+			"error": map[string]any{"type": "string"},
+		},
+	}
+
+	paths, err := convertPaths(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info":    info,
+		"servers": []any{map[string]any{"url": getString(swagger, "basePath")}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				bearerAuthScheme: map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}, nil
+}
+
+func convertPaths(swagger map[string]any) (map[string]any, error) {
+	paths := map[string]any{}
+	operationIDs := map[string]string{}
+
+	consumes := getStrings(swagger, "consumes")
+	produces := getStrings(swagger, "produces")
+
+	for _, path := range sortedKeys(getMap(swagger, "paths")) {
+		pathItem := getMap(getMap(swagger, "paths"), path)
+		convertedPathItem := map[string]any{}
+
+		for _, method := range httpMethods {
+			operation, isFound := pathItem[method].(map[string]any)
+			if !isFound {
+				continue
+			}
+
+			converted := convertOperation(method, path, operation, consumes, produces)
+
+			operationID := getString(converted, "operationId")
+			if previousPath, isDuplicate := operationIDs[operationID]; isDuplicate {
+				return nil, fmt.Errorf(
+					"operationId %s is used by %s and %s %s",
+					operationID,
+					previousPath,
+					strings.ToUpper(method),
+					path,
+				)
+			}
+			operationIDs[operationID] = strings.ToUpper(method) + " " + path
+
+			convertedPathItem[method] = converted
+		}
+
+		paths[path] = convertedPathItem
+	}
+
+	return paths, nil
+}
+
+func convertOperation(
+	method string,
+	path string,
+	operation map[string]any,
+	defaultConsumes []string,
+	defaultProduces []string,
+) map[string]any {
+	converted := map[string]any{}
+	for _, key := range []string{"summary", "description", "tags", "deprecated"} {
+		if operationValue, isFound := operation[key]; isFound {
+			converted[key] = operationValue
+		}
+	}
+
+	converted["operationId"] = getString(operation, "operationId")
+	if converted["operationId"] == "" {
+		converted["operationId"] = buildOperationID(method, path)
+	}
+
+	consumes := getStrings(operation, "consumes")
+	if len(consumes) == 0 {
+		consumes = defaultConsumes
+	}
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+
+	produces := getStrings(operation, "produces")
+	if len(produces) == 0 {
+		produces = defaultProduces
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	isAuthenticated := len(getSlice(operation, "security")) > 0
+	parameters := make([]any, 0)
+	formProperties := map[string]any{}
+	formRequired := make([]any, 0)
+
+	for _, rawParameter := range getSlice(operation, "parameters") {
+		parameter, _ := rawParameter.(map[string]any)
+		if parameter == nil {
+			continue
+		}
+
+		name := getString(parameter, "name")
+		in := getString(parameter, "in")
+
+		switch {
+		case in == "header" && strings.EqualFold(name, "Authorization"):
+			isAuthenticated = true
+		case in == "body":
+			requestBody := map[string]any{
+				"required": parameter["required"] == true,
+				"content":  buildContent(consumes, convertSchema(parameter["schema"])),
+			}
+			if description := getString(parameter, "description"); description != "" {
+				requestBody["description"] = description
+			}
+
+			converted["requestBody"] = requestBody
+		case in == "formData":
+			formProperties[name] = buildParameterSchema(parameter)
+			if parameter["required"] == true {
+				formRequired = append(formRequired, name)
+			}
+		default:
+			parameters = append(parameters, convertParameter(parameter))
+		}
+	}
+
+	if len(formProperties) > 0 {
+		formSchema := map[string]any{"type": "object", "properties": formProperties}
+		if len(formRequired) > 0 {
+			formSchema["required"] = formRequired
+		}
+
+		converted["requestBody"] = map[string]any{
+			"required": len(formRequired) > 0,
+			"content":  buildContent([]string{"multipart/form-data"}, formSchema),
+		}
+	}
+
+	if len(parameters) > 0 {
+		converted["parameters"] = parameters
+	}
+
+	if isAuthenticated {
+		converted["security"] = []any{map[string]any{bearerAuthScheme: []any{}}}
+	}
+
+	converted["responses"] = convertResponses(getMap(operation, "responses"), produces)
+
+	return converted
+}
+
+func convertResponses(responses map[string]any, produces []string) map[string]any {
+	converted := map[string]any{}
+
+	for _, code := range sortedKeys(responses) {
+		response, _ := responses[code].(map[string]any)
+		if response == nil {
+			response = map[string]any{}
+		}
+
+		description := getString(response, "description")
+		if description == "" {
+			description = getStatusText(code)
+		}
+
+		convertedResponse := map[string]any{"description": description}
+
+		schema := response["schema"]
+		if isErrorCode(code) && (schema == nil || isStringMapSchema(schema)) {
+			schema = map[string]any{"$ref": "#/components/schemas/" + errorResponseSchema}
+		}
+
+		if schema != nil {
+			convertedResponse["content"] = buildContent(produces, convertSchema(schema))
+		}
+
+		if headers := getMap(response, "headers"); len(headers) > 0 {
+			convertedHeaders := map[string]any{}
+			for name, header := range headers {
+				headerMap, _ := header.(map[string]any)
+				convertedHeaders[name] = map[string]any{
+					"description": getString(headerMap, "description"),
+					"schema":      buildParameterSchema(headerMap),
+				}
+			}
+
+			convertedResponse["headers"] = convertedHeaders
+		}
+
+		converted[code] = convertedResponse
+	}
+
+	if len(converted) == 0 {
+		converted["200"] = map[string]any{"description": "OK"}
+	}
+
+	return converted
+}
+
+func convertParameter(parameter map[string]any) map[string]any {
+	converted := map[string]any{
+		"name":     getString(parameter, "name"),
+		"in":       getString(parameter, "in"),
+		"required": parameter["required"] == true || getString(parameter, "in") == "path",
+		"schema":   buildParameterSchema(parameter),
+	}
+
+	if description := getString(parameter, "description"); description != "" {
+		converted["description"] = description
+	}
+
+	return converted
+}
+
+// Swagger 2.0 keeps type and format of non-body parameters on the parameter itself
+func buildParameterSchema(parameter map[string]any) map[string]any {
+	schema := map[string]any{}
+
+	for _, key := range []string{"type", "format", "enum", "default", "minimum", "maximum"} {
+		if parameterValue, isFound := parameter[key]; isFound {
+			schema[key] = parameterValue
+		}
+	}
+
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+
+	if items, isFound := parameter["items"].(map[string]any); isFound {
+		schema["items"] = buildParameterSchema(items)
+	}
+
+	return schema
+}
+
+// convertSchema rewrites references and the Swagger only keywords, the rest of JSON Schema
+// is the same in both versions
+func convertSchema(schema any) any {
+	switch typedSchema := schema.(type) {
+	case map[string]any:
+		converted := make(map[string]any, len(typedSchema))
+
+		for key, schemaValue := range typedSchema {
+			switch key {
+			case "$ref":
+				ref, _ := schemaValue.(string)
+				converted[key] = strings.Replace(
+					ref,
+					"#/definitions/",
+					"#/components/schemas/",
+					1,
+				)
+			case "x-nullable":
+				continue
+			case "type":
+				if schemaValue == "file" {
+					converted["type"] = "string"
+					converted["format"] = "binary"
+					continue
+				}
+
+				converted[key] = schemaValue
+			default:
+				converted[key] = convertSchema(schemaValue)
+			}
+		}
+
+		if typedSchema["x-nullable"] == true {
+			if schemaType, isString := converted["type"].(string); isString {
+				converted["type"] = []any{schemaType, "null"}
+			}
+		}
+
+		return converted
+	case []any:
+		converted := make([]any, 0, len(typedSchema))
+		for _, item := range typedSchema {
+			converted = append(converted, convertSchema(item))
+		}
+
+		return converted
+	default:
+		return schema
+	}
+}
+
+func buildContent(mediaTypes []string, schema any) map[string]any {
+	content := map[string]any{}
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]any{"schema": schema}
+	}
+
+	return content
+}
+
+// buildOperationID derives names like getStorages, getStoragesById and
+// postStoragesByIdTest, which SDK generators turn into method names
+func buildOperationID(method string, path string) string {
+	var operationID strings.Builder
+	operationID.WriteString(method)
+
+	for segment := range strings.SplitSeq(strings.Trim(path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			operationID.WriteString("By")
+			segment = strings.Trim(segment, "{}")
+		}
+
+		operationID.WriteString(toPascalCase(segment))
+	}
+
+	return operationID.String()
+}
+
+func toPascalCase(text string) string {
+	var result strings.Builder
+	isUpperNext := true
+
+	for _, current := range text {
+		if !unicode.IsLetter(current) && !unicode.IsDigit(current) {
+			isUpperNext = true
+			continue
+		}
+
+		if isUpperNext {
+			result.WriteRune(unicode.ToUpper(current))
+			isUpperNext = false
+			continue
+		}
+
+		result.WriteRune(current)
+	}
+
+	return result.String()
+}
+
+func isErrorCode(code string) bool {
+	return strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5")
+}
+
+// swag renders `{object} map[string]string` as an object with string values
+func isStringMapSchema(schema any) bool {
+	schemaMap, _ := schema.(map[string]any)
+	additionalProperties, _ := schemaMap["additionalProperties"].(map[string]any)
+
+	return schemaMap["type"] == "object" && additionalProperties["type"] == "string"
+}
+
+func getStatusText(code string) string {
+	var statusCode int
+	if _, err := fmt.Sscanf(code, "%d", &statusCode); err == nil {
+		if text := http.StatusText(statusCode); text != "" {
+			return text
+		}
+	}
+
+	return "Response"
+}
+
+func getMap(source map[string]any, key string) map[string]any {
+	if nested, isMap := source[key].(map[string]any); isMap {
+		return nested
+	}
+
+	return map[string]any{}
+}
+
+func getSlice(source map[string]any, key string) []any {
+	nested, _ := source[key].([]any)
+	return nested
+}
+
+func getString(source map[string]any, key string) string {
+	text, _ := source[key].(string)
+	return text
+}
+
+func getStrings(source map[string]any, key string) []string {
+	values := make([]string, 0)
+	for _, item := range getSlice(source, key) {
+		if text, isString := item.(string); isString {
+			values = append(values, text)
+		}
+	}
+
+	return values
+}
+
+func sortedKeys(source map[string]any) []string {
+	return slices.Sorted(maps.Keys(source))
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// generateGoClient renders schemas and operation methods for pkg/client. The transport
+// (Client, APIError, request helpers) is hand written in pkg/client/client.go
+func generateGoClient(document map[string]any) ([]byte, error) {
+	generator := &goGenerator{imports: map[string]bool{"context": true}}
+
+	var body strings.Builder
+
+	schemas := getMap(getMap(document, "components"), "schemas")
+	for _, name := range sortedKeys(schemas) {
+		schema, _ := schemas[name].(map[string]any)
+		body.WriteString(generator.renderSchema(getSchemaTypeName(name), schema))
+	}
+
+	for _, operation := range collectOperations(document) {
+		body.WriteString(generator.renderOperation(operation))
+	}
+
+	var source strings.Builder
+	source.WriteString(
+		"// Code generated by cmd/openapi from the OpenAPI document. DO NOT EDIT.\n\n",
+	)
+	source.WriteString("package client\n\n")
+
+	imports := make([]string, 0, len(generator.imports))
+	for importPath := range generator.imports {
+		imports = append(imports, importPath)
+	}
+	slices.Sort(imports)
+
+	source.WriteString("import (\n")
+	for _, importPath := range imports {
+		source.WriteString("\t" + strconv.Quote(importPath) + "\n")
+	}
+	source.WriteString(")\n\n")
+	source.WriteString(body.String())
+
+	formatted, err := format.Source([]byte(source.String()))
+	if err != nil {
+		return nil, fmt.Errorf("generated Go client is invalid: %w", err)
+	}
+
+	return formatted, nil
+}
+
+type goGenerator struct {
+	imports map[string]bool
+}
+
+func (g *goGenerator) renderSchema(typeName string, schema map[string]any) string {
+	var source strings.Builder
+
+	if description := getString(schema, "description"); description != "" {
+		source.WriteString(formatGoComment(typeName + " " + description))
+	}
+
+	enumValues := getSlice(schema, "enum")
+	if getSchemaType(schema) == "string" && len(enumValues) > 0 {
+		fmt.Fprintf(&source, "type %s string\n\nconst (\n", typeName)
+
+		usedNames := map[string]bool{}
+		for _, enumValue := range enumValues {
+			text := fmt.Sprint(enumValue)
+
+			constName := typeName + toPascalCase(strings.ToLower(text))
+			if constName == typeName || usedNames[constName] {
+				continue
+			}
+			usedNames[constName] = true
+
+			fmt.Fprintf(&source, "\t%s %s = %s\n", constName, typeName, strconv.Quote(text))
+		}
+
+		source.WriteString(")\n\n")
+
+		return source.String()
+	}
+
+	properties := getMap(schema, "properties")
+	if getSchemaType(schema) != "object" || len(properties) == 0 {
+		fmt.Fprintf(&source, "type %s = %s\n\n", typeName, g.goType(schema, true))
+		return source.String()
+	}
+
+	required := map[string]bool{}
+	for _, name := range getStrings(schema, "required") {
+		required[name] = true
+	}
+
+	fmt.Fprintf(&source, "type %s struct {\n", typeName)
+
+	usedFields := map[string]bool{}
+	for _, propertyName := range sortedKeys(properties) {
+		property, _ := properties[propertyName].(map[string]any)
+
+		fieldName := toPascalCase(propertyName)
+		if fieldName == "" {
+			continue
+		}
+		if fieldName[0] >= '0' && fieldName[0] <= '9' {
+			fieldName = "Field" + fieldName
+		}
+		for usedFields[fieldName] {
+			fieldName += "_"
+		}
+		usedFields[fieldName] = true
+
+		tag := propertyName
+		if !required[propertyName] {
+			tag += ",omitempty"
+		}
+
+		if description := getString(property, "description"); description != "" {
+			source.WriteString(formatGoComment(description))
+		}
+
+		fmt.Fprintf(
+			&source,
+			"\t%s %s `json:%s`\n",
+			fieldName,
+			g.goType(property, required[propertyName]),
+			strconv.Quote(tag),
+		)
+	}
+
+	source.WriteString("}\n\n")
+
+	return source.String()
+}
+
+func (g *goGenerator) renderOperation(operation sdkOperation) string {
+	methodName := toPascalCase(operation.ID)
+
+	arguments := []string{"ctx context.Context"}
+	pathParams := make([]string, 0, len(operation.PathParams))
+
+	for _, parameter := range operation.PathParams {
+		argumentName := toGoIdentifier(toCamelCase(parameter.Name))
+		schema, _ := parameter.Schema.(map[string]any)
+
+		arguments = append(arguments, argumentName+" "+g.goType(schema, true))
+		pathParams = append(pathParams, fmt.Sprintf("%q: %s", parameter.Name, argumentName))
+	}
+
+	var source strings.Builder
+
+	paramsTypeName := methodName + "Params"
+	if len(operation.QueryParams) > 0 {
+		fmt.Fprintf(&source, "type %s struct {\n", paramsTypeName)
+		for _, parameter := range operation.QueryParams {
+			schema, _ := parameter.Schema.(map[string]any)
+			fmt.Fprintf(
+				&source,
+				"\t%s %s\n",
+				toPascalCase(parameter.Name),
+				g.goType(schema, parameter.IsRequired),
+			)
+		}
+		source.WriteString("}\n\n")
+
+		arguments = append(arguments, "params *"+paramsTypeName)
+	}
+
+	switch {
+	case operation.IsBodyJSON:
+		bodyType := "any"
+		if schema, isMap := operation.BodySchema.(map[string]any); isMap {
+			bodyType = g.goType(schema, false)
+		}
+
+		arguments = append(arguments, "body "+bodyType)
+	case operation.BodyMediaType != "":
+		g.imports["io"] = true
+		arguments = append(arguments, "body io.Reader", "contentType string")
+	}
+
+	resultType := ""
+	switch {
+	case operation.IsBinaryResponse:
+		g.imports["io"] = true
+		resultType = "io.ReadCloser"
+	case operation.ResponseSchema != nil:
+		schema, _ := operation.ResponseSchema.(map[string]any)
+		resultType = g.goType(schema, true)
+	}
+
+	comment := fmt.Sprintf("%s calls %s %s", methodName, operation.Method, operation.Path)
+	if operation.Summary != "" {
+		comment += ". " + operation.Summary
+	}
+	source.WriteString(formatGoComment(comment))
+
+	returns := "error"
+	if resultType != "" {
+		returns = "(" + resultType + ", error)"
+	}
+
+	fmt.Fprintf(
+		&source,
+		"func (c *Client) %s(%s) %s {\n",
+		methodName,
+		strings.Join(arguments, ", "),
+		returns,
+	)
+
+	requestPath := strconv.Quote(operation.Path)
+	if len(pathParams) > 0 {
+		requestPath = fmt.Sprintf(
+			"buildPath(%s, map[string]any{%s})",
+			strconv.Quote(operation.Path),
+			strings.Join(pathParams, ", "),
+		)
+	}
+
+	fmt.Fprintf(
+		&source,
+		"\treq := request{method: %q, path: %s}\n",
+		operation.Method,
+		requestPath,
+	)
+
+	if len(operation.QueryParams) > 0 {
+		g.imports["net/url"] = true
+
+		source.WriteString("\treq.query = url.Values{}\n\tif params != nil {\n")
+		for _, parameter := range operation.QueryParams {
+			fmt.Fprintf(
+				&source,
+				"\t\tsetQuery(req.query, %q, params.%s)\n",
+				parameter.Name,
+				toPascalCase(parameter.Name),
+			)
+		}
+		source.WriteString("\t}\n")
+	}
+
+	switch {
+	case operation.IsBodyJSON:
+		source.WriteString("\treq.jsonBody = body\n")
+	case operation.BodyMediaType != "":
+		source.WriteString("\treq.body = body\n\treq.contentType = contentType\n")
+	}
+
+	switch {
+	case operation.IsBinaryResponse:
+		source.WriteString("\treturn c.stream(ctx, req)\n")
+	case resultType != "":
+		resultValue := strings.TrimPrefix(resultType, "*")
+		if strings.HasPrefix(resultType, "*") {
+			fmt.Fprintf(&source, "\tresult := new(%s)\n", resultValue)
+			source.WriteString("\tif err := c.do(ctx, req, result); err != nil {\n")
+		} else {
+			fmt.Fprintf(&source, "\tvar result %s\n", resultType)
+			source.WriteString("\tif err := c.do(ctx, req, &result); err != nil {\n")
+		}
+
+		if strings.HasPrefix(resultType, "*") || strings.HasPrefix(resultType, "[]") ||
+			strings.HasPrefix(resultType, "map[") || resultType == "any" {
+			source.WriteString("\t\treturn nil, err\n")
+		} else {
+			fmt.Fprintf(&source, "\t\treturn result, err\n")
+		}
+
+		source.WriteString("\t}\n\treturn result, nil\n")
+	default:
+		source.WriteString("\treturn c.do(ctx, req, nil)\n")
+	}
+
+	source.WriteString("}\n\n")
+
+	return source.String()
+}
+
+// goType maps JSON Schema to Go. Optional scalars and all referenced objects are pointers,
+// so zero values are not sent and recursive schemas compile
+func (g *goGenerator) goType(schema map[string]any, isRequired bool) string {
+	if schema == nil {
+		return "any"
+	}
+
+	if typeName, isRef := getRefTypeName(schema); isRef {
+		return "*" + typeName
+	}
+
+	pointer := ""
+	if !isRequired {
+		pointer = "*"
+	}
+
+	switch getSchemaType(schema) {
+	case "string":
+		switch getString(schema, "format") {
+		case "binary":
+			return "[]byte"
+		case "date-time":
+			g.imports["time"] = true
+			return pointer + "time.Time"
+		}
+
+		return pointer + "string"
+	case "integer":
+		return pointer + "int64"
+	case "number":
+		return pointer + "float64"
+	case "boolean":
+		return pointer + "bool"
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		return "[]" + g.goType(items, true)
+	case "object":
+		if additionalProperties, isMap := schema["additionalProperties"].(map[string]any); isMap {
+			return "map[string]" + g.goType(additionalProperties, true)
+		}
+
+		return "map[string]any"
+	}
+
+	return "any"
+}
+
+func formatGoComment(text string) string {
+	var comment strings.Builder
+
+	for line := range strings.SplitSeq(strings.TrimSpace(text), "\n") {
+		comment.WriteString("// " + strings.TrimSpace(line) + "\n")
+	}
+
+	return comment.String()
+}
+
+var goKeywords = []string{
+	"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough",
+	"for", "func", "go", "goto", "if", "import", "interface", "map", "package", "range",
+	"return", "select", "struct", "switch", "type", "var", "ctx", "params", "body", "req",
+	"result", "contentType", "err", "c",
+}
+
+func toGoIdentifier(name string) string {
+	if name == "" || slices.Contains(goKeywords, name) {
+		return name + "Value"
+	}
+
+	return name
+}
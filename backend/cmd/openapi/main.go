@@ -0,0 +1,89 @@
+// Command openapi converts the Swagger 2.0 document generated by swag into OpenAPI 3.1 and
+// generates Go and TypeScript clients from it. It runs at build time after `swag init`:
+//
+//	go run ./cmd/openapi -version 1.2.0
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	input := flag.String("in", "swagger/swagger.json", "Swagger 2.0 document generated by swag")
+	output := flag.String("out", "swagger/openapi.json", "OpenAPI 3.1 document to write")
+	goClient := flag.String("go-client", "pkg/client/operations.gen.go", "Go client to write")
+	tsClient := flag.String(
+		"ts-client",
+		"pkg/client/typescript/client.gen.ts",
+		"TypeScript client to write",
+	)
+	version := flag.String("version", "", "API version, defaults to @version of main.go")
+	flag.Parse()
+
+	if err := run(*input, *output, *goClient, *tsClient, *version); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output, goClient, tsClient, version string) error {
+	swaggerJSON, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read swagger document, run `swag init` first: %w", err)
+	}
+
+	var swagger map[string]any
+	if err := json.Unmarshal(swaggerJSON, &swagger); err != nil {
+		return fmt.Errorf("failed to parse swagger document: %w", err)
+	}
+
+	document, err := convertSwagger(swagger, version)
+	if err != nil {
+		return err
+	}
+
+	documentJSON, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(output, append(documentJSON, '\n')); err != nil {
+		return err
+	}
+
+	if goClient != "" {
+		source, err := generateGoClient(document)
+		if err != nil {
+			return err
+		}
+
+		if err := writeFile(goClient, source); err != nil {
+			return err
+		}
+	}
+
+	if tsClient != "" {
+		source, err := generateTypeScriptClient(document)
+		if err != nil {
+			return err
+		}
+
+		if err := writeFile(tsClient, source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
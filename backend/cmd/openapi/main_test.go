@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConvertSwagger_WhenSwaggerIsValid_ReturnsOpenAPIDocument(t *testing.T) {
+	document := convertTestSwagger(t)
+
+	assert.Equal(t, "3.1.0", document["openapi"])
+	assert.Equal(t, "2.3.0", getMap(document, "info")["version"])
+	assert.Equal(t, []any{map[string]any{"url": "/api/v1"}}, document["servers"])
+
+	paths := getMap(document, "paths")
+
+	getStorages := getMap(getMap(paths, "/storages"), "get")
+	assert.Equal(t, "getStorages", getStorages["operationId"])
+	assert.Equal(t, []any{map[string]any{"BearerAuth": []any{}}}, getStorages["security"])
+	assert.Len(t, getSlice(getStorages, "parameters"), 2, "JWT header is replaced by security")
+
+	errorRef := map[string]any{
+		"application/json": map[string]any{
+			"schema": map[string]any{"$ref": "#/components/schemas/ErrorResponse"},
+		},
+	}
+	responses := getMap(getStorages, "responses")
+	assert.Equal(t, errorRef, getMap(responses, "400")["content"])
+	assert.Equal(t, errorRef, getMap(responses, "401")["content"])
+	assert.Equal(
+		t,
+		"#/components/schemas/storages.StorageResponse",
+		getMap(getMap(getMap(getMap(getMap(responses, "200"), "content"), "application/json"),
+			"schema"), "items")["$ref"],
+	)
+
+	deleteStorage := getMap(getMap(paths, "/storages/{id}"), "delete")
+	assert.Equal(t, "deleteStoragesById", deleteStorage["operationId"])
+	assert.Equal(t, "Forbidden", getMap(getMap(deleteStorage, "responses"), "403")["description"])
+
+	upload := getMap(getMap(paths, "/restores/upload"), "post")
+	formSchema := getMap(
+		getMap(getMap(getMap(upload, "requestBody"), "content"), "multipart/form-data"),
+		"schema",
+	)
+	assert.Equal(
+		t,
+		map[string]any{"type": "string", "format": "binary"},
+		getMap(formSchema, "properties")["file"],
+	)
+
+	schemas := getMap(getMap(document, "components"), "schemas")
+	storageProperties := getMap(getMap(schemas, "storages.StorageResponse"), "properties")
+	assert.Equal(t, []any{"string", "null"}, getMap(storageProperties, "lastSaveError")["type"])
+	assert.Contains(t, schemas, "ErrorResponse")
+}
+
+func Test_ConvertSwagger_WhenOperationIDsCollide_ReturnsError(t *testing.T) {
+	swagger := map[string]any{
+		"swagger": "2.0",
+		"paths": map[string]any{
+			"/a":  map[string]any{"get": map[string]any{"operationId": "same"}},
+			"/ab": map[string]any{"get": map[string]any{"operationId": "same"}},
+		},
+	}
+
+	_, err := convertSwagger(swagger, "")
+	assert.ErrorContains(t, err, "operationId same is used by GET /a and GET /ab")
+}
+
+func Test_GenerateGoClient_WhenDocumentIsConverted_ClientCompiles(t *testing.T) {
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain is not available")
+	}
+
+	source, err := generateGoClient(convertTestSwagger(t))
+	require.NoError(t, err)
+
+	generated := string(source)
+	assert.Contains(t, generated, "type StoragesStorageType string")
+	assert.Contains(
+		t,
+		generated,
+		`StoragesStorageTypeGoogleDrive StoragesStorageType = "GOOGLE_DRIVE"`,
+	)
+	assert.Contains(
+		t,
+		generated,
+		"func (c *Client) GetStorages(ctx context.Context, params *GetStoragesParams) "+
+			"([]*StoragesStorageResponse, error)",
+	)
+	assert.Contains(
+		t,
+		generated,
+		"func (c *Client) GetBackupsByIdFile(ctx context.Context, id string) (io.ReadCloser, error)",
+	)
+
+	moduleDir := t.TempDir()
+	runtime, err := os.ReadFile(filepath.Join("..", "..", "pkg", "client", "client.go"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(
+		filepath.Join(moduleDir, "go.mod"),
+		[]byte("module databasus-client-test\n\ngo 1.24\n"),
+		0o644,
+	))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "client.go"), runtime, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "operations.gen.go"), source, 0o644))
+
+	cmd := exec.Command(goBinary, "vet", "./...")
+	cmd.Dir = moduleDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
+
+func Test_GenerateTypeScriptClient_WhenDocumentIsConverted_RendersTypedMethods(t *testing.T) {
+	source, err := generateTypeScriptClient(convertTestSwagger(t))
+	require.NoError(t, err)
+
+	generated := string(source)
+	assert.Contains(
+		t,
+		generated,
+		`export type StoragesStorageType = "LOCAL" | "S3" | "GOOGLE_DRIVE";`,
+	)
+	assert.Contains(t, generated, "  lastSaveError?: string | null;\n")
+	assert.Contains(t, generated, "  workspace_id: string;\n")
+	assert.Contains(
+		t,
+		generated,
+		"getStorages(params?: GetStoragesParams): Promise<Array<StoragesStorageResponse>>",
+	)
+	assert.Contains(
+		t,
+		generated,
+		"`/storages/${encodeURIComponent(String(id))}`",
+	)
+	assert.Contains(t, generated, "async getBackupsByIdFile(id: string): Promise<Blob>")
+	assert.Contains(t, generated, "postRestoresUpload(body: FormData): Promise<void>")
+	assert.Equal(t, strings.Count(generated, "{"), strings.Count(generated, "}"))
+}
+
+func convertTestSwagger(t *testing.T) map[string]any {
+	t.Helper()
+
+	swaggerJSON, err := os.ReadFile(filepath.Join("testdata", "swagger.json"))
+	require.NoError(t, err)
+
+	var swagger map[string]any
+	require.NoError(t, json.Unmarshal(swaggerJSON, &swagger))
+
+	document, err := convertSwagger(swagger, "2.3.0")
+	require.NoError(t, err)
+
+	return document
+}
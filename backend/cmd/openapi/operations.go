@@ -0,0 +1,189 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// sdkOperation is an OpenAPI operation flattened for SDK generators
+type sdkOperation struct {
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	PathParams  []sdkParameter
+	QueryParams []sdkParameter
+
+	BodySchema    any
+	BodyMediaType string
+	IsBodyJSON    bool
+
+	ResponseSchema   any
+	IsBinaryResponse bool
+}
+
+type sdkParameter struct {
+	Name       string
+	Schema     any
+	IsRequired bool
+}
+
+func collectOperations(document map[string]any) []sdkOperation {
+	operations := make([]sdkOperation, 0)
+
+	paths := getMap(document, "paths")
+	for _, path := range sortedKeys(paths) {
+		pathItem := getMap(paths, path)
+
+		for _, method := range httpMethods {
+			operation, isFound := pathItem[method].(map[string]any)
+			if !isFound {
+				continue
+			}
+
+			operations = append(operations, buildSDKOperation(method, path, operation))
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].ID < operations[j].ID
+	})
+
+	return operations
+}
+
+func buildSDKOperation(method string, path string, operation map[string]any) sdkOperation {
+	sdkOp := sdkOperation{
+		ID:      getString(operation, "operationId"),
+		Method:  strings.ToUpper(method),
+		Path:    path,
+		Summary: getString(operation, "summary"),
+	}
+
+	for _, rawParameter := range getSlice(operation, "parameters") {
+		parameter, _ := rawParameter.(map[string]any)
+
+		sdkParam := sdkParameter{
+			Name:       getString(parameter, "name"),
+			Schema:     parameter["schema"],
+			IsRequired: parameter["required"] == true,
+		}
+
+		switch getString(parameter, "in") {
+		case "path":
+			sdkOp.PathParams = append(sdkOp.PathParams, sdkParam)
+		case "query":
+			sdkOp.QueryParams = append(sdkOp.QueryParams, sdkParam)
+		}
+	}
+
+	// Path parameters follow their order in the URL, so positional SDK arguments read
+	// naturally: getWorkspacesByIdMembers(workspaceId, ...)
+	sort.SliceStable(sdkOp.PathParams, func(i, j int) bool {
+		return strings.Index(path, "{"+sdkOp.PathParams[i].Name+"}") <
+			strings.Index(path, "{"+sdkOp.PathParams[j].Name+"}")
+	})
+
+	if requestBody := getMap(operation, "requestBody"); len(requestBody) > 0 {
+		content := getMap(requestBody, "content")
+		mediaTypes := sortedKeys(content)
+
+		for _, mediaType := range mediaTypes {
+			if mediaType == "application/json" {
+				sdkOp.IsBodyJSON = true
+				sdkOp.BodyMediaType = mediaType
+				sdkOp.BodySchema = getMap(content, mediaType)["schema"]
+			}
+		}
+
+		if !sdkOp.IsBodyJSON && len(mediaTypes) > 0 {
+			sdkOp.BodyMediaType = mediaTypes[0]
+		}
+	}
+
+	responses := getMap(operation, "responses")
+	for _, code := range []string{"200", "201", "202"} {
+		response := getMap(responses, code)
+		if len(response) == 0 {
+			continue
+		}
+
+		content := getMap(response, "content")
+		if jsonContent := getMap(content, "application/json"); len(jsonContent) > 0 {
+			sdkOp.ResponseSchema = jsonContent["schema"]
+			if isBinarySchema(sdkOp.ResponseSchema) {
+				sdkOp.ResponseSchema = nil
+				sdkOp.IsBinaryResponse = true
+			}
+		} else if len(content) > 0 {
+			sdkOp.IsBinaryResponse = true
+		}
+
+		break
+	}
+
+	return sdkOp
+}
+
+func isBinarySchema(schema any) bool {
+	schemaMap, _ := schema.(map[string]any)
+	return schemaMap["format"] == "binary"
+}
+
+// getSchemaTypeName maps component names produced by swag (storages.StorageResponse,
+// github_com_..._enums.UserRole) to exported identifiers
+func getSchemaTypeName(componentName string) string {
+	name := toPascalCase(componentName)
+	if name == "" {
+		return "Schema"
+	}
+
+	if name[0] >= '0' && name[0] <= '9' {
+		return "Schema" + name
+	}
+
+	return name
+}
+
+func getRefTypeName(schema map[string]any) (string, bool) {
+	ref := getString(schema, "$ref")
+	if ref == "" {
+		allOf := getSlice(schema, "allOf")
+		if len(allOf) == 1 {
+			if allOfSchema, isMap := allOf[0].(map[string]any); isMap {
+				ref = getString(allOfSchema, "$ref")
+			}
+		}
+	}
+
+	if !strings.HasPrefix(ref, "#/components/schemas/") {
+		return "", false
+	}
+
+	return getSchemaTypeName(strings.TrimPrefix(ref, "#/components/schemas/")), true
+}
+
+// getSchemaType returns the first non null type, OpenAPI 3.1 allows type lists
+func getSchemaType(schema map[string]any) string {
+	switch schemaType := schema["type"].(type) {
+	case string:
+		return schemaType
+	case []any:
+		for _, item := range schemaType {
+			if text, isString := item.(string); isString && text != "null" {
+				return text
+			}
+		}
+	}
+
+	return ""
+}
+
+func toCamelCase(text string) string {
+	pascal := toPascalCase(text)
+	if pascal == "" {
+		return pascal
+	}
+
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const typescriptRuntime = `export class DatabasusApiError extends Error {
+  constructor(
+    public readonly status: number,
+    message: string,
+  ) {
+    super(message);
+  }
+}
+
+export interface DatabasusClientOptions {
+  // URL with API version, e.g. https://databasus.example.com/api/v1
+  baseUrl: string;
+  token?: string;
+  fetch?: typeof fetch;
+}
+
+type QueryValue = string | number | boolean | undefined | null | Array<string | number | boolean>;
+
+export class DatabasusClient {
+  private readonly baseUrl: string;
+  private readonly fetchImpl: typeof fetch;
+
+  constructor(private readonly options: DatabasusClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/$/, '');
+    this.fetchImpl = options.fetch ?? fetch.bind(globalThis);
+  }
+
+  private async send(
+    method: string,
+    path: string,
+    query?: Record<string, QueryValue>,
+    body?: unknown,
+    contentType?: string,
+  ): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [name, value] of Object.entries(query ?? {})) {
+      if (value === undefined || value === null) continue;
+      for (const item of Array.isArray(value) ? value : [value]) {
+        url.searchParams.append(name, String(item));
+      }
+    }
+
+    const headers: Record<string, string> = {};
+    if (this.options.token) headers.Authorization = 'Bearer ' + this.options.token;
+
+    let requestBody: BodyInit | undefined;
+    if (body !== undefined && contentType === undefined) {
+      headers['Content-Type'] = 'application/json';
+      requestBody = JSON.stringify(body);
+    } else if (body !== undefined) {
+      if (contentType !== 'multipart/form-data') headers['Content-Type'] = contentType!;
+      requestBody = body as BodyInit;
+    }
+
+    const response = await this.fetchImpl(url, { method, headers, body: requestBody });
+    if (!response.ok) {
+      const text = await response.text();
+      let message = text;
+      try {
+        message = JSON.parse(text).error ?? text;
+      } catch {
+        // not a JSON error envelope
+      }
+      throw new DatabasusApiError(response.status, message);
+    }
+
+    return response;
+  }
+
+  private async request<T>(
+    method: string,
+    path: string,
+    query?: Record<string, QueryValue>,
+    body?: unknown,
+    contentType?: string,
+  ): Promise<T> {
+    const response = await this.send(method, path, query, body, contentType);
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+`
+
+// generateTypeScriptClient renders a dependency free client based on fetch, so it works
+// in browsers, Node.js 18+ and Deno
+func generateTypeScriptClient(document map[string]any) ([]byte, error) {
+	var source strings.Builder
+
+	source.WriteString(
+		"// Code generated by cmd/openapi from the OpenAPI document. DO NOT EDIT.\n\n",
+	)
+	source.WriteString("/* eslint-disable */\n\n")
+
+	schemas := getMap(getMap(document, "components"), "schemas")
+	for _, name := range sortedKeys(schemas) {
+		schema, _ := schemas[name].(map[string]any)
+		source.WriteString(renderTypeScriptSchema(getSchemaTypeName(name), schema))
+	}
+
+	operations := collectOperations(document)
+	for _, operation := range operations {
+		if len(operation.QueryParams) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&source, "export interface %sParams {\n", toPascalCase(operation.ID))
+		for _, parameter := range operation.QueryParams {
+			schema, _ := parameter.Schema.(map[string]any)
+
+			optional := "?"
+			if parameter.IsRequired {
+				optional = ""
+			}
+
+			fmt.Fprintf(
+				&source,
+				"  %s%s: %s;\n",
+				formatTypeScriptKey(parameter.Name),
+				optional,
+				typeScriptType(schema),
+			)
+		}
+		source.WriteString("}\n\n")
+	}
+
+	source.WriteString(typescriptRuntime)
+
+	for _, operation := range operations {
+		source.WriteString(renderTypeScriptOperation(operation))
+	}
+
+	source.WriteString("}\n")
+
+	return []byte(source.String()), nil
+}
+
+func renderTypeScriptSchema(typeName string, schema map[string]any) string {
+	var source strings.Builder
+
+	if description := getString(schema, "description"); description != "" {
+		source.WriteString(formatTypeScriptComment(description, ""))
+	}
+
+	properties := getMap(schema, "properties")
+	if getSchemaType(schema) != "object" || len(properties) == 0 {
+		fmt.Fprintf(&source, "export type %s = %s;\n\n", typeName, typeScriptType(schema))
+		return source.String()
+	}
+
+	required := map[string]bool{}
+	for _, name := range getStrings(schema, "required") {
+		required[name] = true
+	}
+
+	fmt.Fprintf(&source, "export interface %s {\n", typeName)
+
+	for _, propertyName := range sortedKeys(properties) {
+		property, _ := properties[propertyName].(map[string]any)
+
+		if description := getString(property, "description"); description != "" {
+			source.WriteString(formatTypeScriptComment(description, "  "))
+		}
+
+		optional := "?"
+		if required[propertyName] {
+			optional = ""
+		}
+
+		fmt.Fprintf(
+			&source,
+			"  %s%s: %s;\n",
+			formatTypeScriptKey(propertyName),
+			optional,
+			typeScriptType(property),
+		)
+	}
+
+	source.WriteString("}\n\n")
+
+	return source.String()
+}
+
+func renderTypeScriptOperation(operation sdkOperation) string {
+	arguments := make([]string, 0)
+	path := strconv.Quote(operation.Path)
+
+	if len(operation.PathParams) > 0 {
+		path = "`" + operation.Path + "`"
+
+		for _, parameter := range operation.PathParams {
+			argumentName := toCamelCase(parameter.Name)
+			schema, _ := parameter.Schema.(map[string]any)
+
+			arguments = append(arguments, argumentName+": "+typeScriptType(schema))
+			path = strings.ReplaceAll(
+				path,
+				"{"+parameter.Name+"}",
+				"${encodeURIComponent(String("+argumentName+"))}",
+			)
+		}
+	}
+
+	query := "undefined"
+	if len(operation.QueryParams) > 0 {
+		arguments = append(arguments, "params?: "+toPascalCase(operation.ID)+"Params")
+		query = "params as unknown as Record<string, QueryValue> | undefined"
+	}
+
+	body := "undefined"
+	contentType := ""
+
+	switch {
+	case operation.IsBodyJSON:
+		schema, _ := operation.BodySchema.(map[string]any)
+		arguments = append(arguments, "body: "+typeScriptType(schema))
+		body = "body"
+	case operation.BodyMediaType == "multipart/form-data":
+		arguments = append(arguments, "body: FormData")
+		body = "body"
+		contentType = ", 'multipart/form-data'"
+	case operation.BodyMediaType != "":
+		arguments = append(arguments, "body: BodyInit")
+		body = "body"
+		contentType = ", " + strconv.Quote(operation.BodyMediaType)
+	}
+
+	var source strings.Builder
+
+	comment := operation.Method + " " + operation.Path
+	if operation.Summary != "" {
+		comment = operation.Summary + " (" + comment + ")"
+	}
+	source.WriteString("\n" + formatTypeScriptComment(comment, "  "))
+
+	methodName := toCamelCase(operation.ID)
+	call := fmt.Sprintf("%q, %s, %s, %s%s", operation.Method, path, query, body, contentType)
+
+	switch {
+	case operation.IsBinaryResponse:
+		fmt.Fprintf(
+			&source,
+			"  async %s(%s): Promise<Blob> {\n    const response = await this.send(%s);\n"+
+				"    return response.blob();\n  }\n",
+			methodName,
+			strings.Join(arguments, ", "),
+			call,
+		)
+	default:
+		resultType := "void"
+		if schema, isMap := operation.ResponseSchema.(map[string]any); isMap {
+			resultType = typeScriptType(schema)
+		}
+
+		fmt.Fprintf(
+			&source,
+			"  %s(%s): Promise<%s> {\n    return this.request<%s>(%s);\n  }\n",
+			methodName,
+			strings.Join(arguments, ", "),
+			resultType,
+			resultType,
+			call,
+		)
+	}
+
+	return source.String()
+}
+
+func typeScriptType(schema map[string]any) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	if typeName, isRef := getRefTypeName(schema); isRef {
+		return typeName
+	}
+
+	var tsType string
+
+	switch getSchemaType(schema) {
+	case "string":
+		tsType = "string"
+
+		if enumValues := getSlice(schema, "enum"); len(enumValues) > 0 {
+			literals := make([]string, 0, len(enumValues))
+			for _, enumValue := range enumValues {
+				literals = append(literals, strconv.Quote(fmt.Sprint(enumValue)))
+			}
+
+			tsType = strings.Join(literals, " | ")
+		}
+
+		if getString(schema, "format") == "binary" {
+			tsType = "Blob"
+		}
+	case "integer", "number":
+		tsType = "number"
+	case "boolean":
+		tsType = "boolean"
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		tsType = "Array<" + typeScriptType(items) + ">"
+	case "object":
+		tsType = "Record<string, unknown>"
+		if additionalProperties, isMap := schema["additionalProperties"].(map[string]any); isMap {
+			tsType = "Record<string, " + typeScriptType(additionalProperties) + ">"
+		}
+	default:
+		tsType = "unknown"
+	}
+
+	if schemaTypes, isList := schema["type"].([]any); isList {
+		for _, item := range schemaTypes {
+			if item == "null" {
+				return tsType + " | null"
+			}
+		}
+	}
+
+	return tsType
+}
+
+func formatTypeScriptKey(name string) string {
+	for index, current := range name {
+		isValid := current == '_' || current == '$' ||
+			(current >= 'a' && current <= 'z') || (current >= 'A' && current <= 'Z') ||
+			(index > 0 && current >= '0' && current <= '9')
+
+		if !isValid {
+			return strconv.Quote(name)
+		}
+	}
+
+	return name
+}
+
+func formatTypeScriptComment(text string, indent string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "*/", "* /")
+
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		return indent + "/** " + lines[0] + " */\n"
+	}
+
+	var comment strings.Builder
+	comment.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		comment.WriteString(indent + " * " + strings.TrimSpace(line) + "\n")
+	}
+	comment.WriteString(indent + " */\n")
+
+	return comment.String()
+}
@@ -0,0 +1,67 @@
+// Command operator reconciles Storage, Notifier and DatabaseBackup custom resources
+// (databasus.com/v1alpha1) against Databasus API. It is configured by environment:
+//
+//	DATABASUS_URL       Databasus URL, e.g. http://databasus:4005
+//	DATABASUS_EMAIL     user the operator signs in as, needs manage rights in workspaces
+//	DATABASUS_PASSWORD  password of the user
+//	WATCH_NAMESPACE     namespace to watch, all namespaces if empty
+//	RESYNC_INTERVAL     full resync interval, 30s by default
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"databasus-backend/internal/operator"
+	"databasus-backend/internal/util/logger"
+)
+
+func main() {
+	log := logger.GetLogger()
+
+	databasusURL := os.Getenv("DATABASUS_URL")
+	email := os.Getenv("DATABASUS_EMAIL")
+	password := os.Getenv("DATABASUS_PASSWORD")
+	if databasusURL == "" || email == "" || password == "" {
+		log.Error("DATABASUS_URL, DATABASUS_EMAIL and DATABASUS_PASSWORD are required")
+		os.Exit(1)
+	}
+
+	resyncInterval := 30 * time.Second
+	if rawInterval := os.Getenv("RESYNC_INTERVAL"); rawInterval != "" {
+		parsedInterval, err := time.ParseDuration(rawInterval)
+		if err != nil || parsedInterval <= 0 {
+			log.Error("RESYNC_INTERVAL must be a positive duration, e.g. 30s", "value", rawInterval)
+			os.Exit(1)
+		}
+
+		resyncInterval = parsedInterval
+	}
+
+	kube, err := operator.NewInClusterKubeClient()
+	if err != nil {
+		log.Error("Failed to create Kubernetes client", "error", err)
+		os.Exit(1)
+	}
+
+	api := operator.NewAPIClient(
+		databasusURL,
+		email,
+		password,
+		&http.Client{Timeout: 60 * time.Second},
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	namespace := os.Getenv("WATCH_NAMESPACE")
+	log.Info("Operator started", "namespace", namespace, "resyncInterval", resyncInterval)
+
+	operator.NewReconciler(kube, api, namespace, log).Run(ctx, resyncInterval)
+
+	log.Info("Operator stopped")
+}
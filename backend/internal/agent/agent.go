@@ -0,0 +1,263 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	minReconnectDelay = 1 * time.Second
+	maxReconnectDelay = 30 * time.Second
+	// streamIdleTimeout is a few keepalive intervals of Databasus, a silent stream means
+	// the connection is dead without TCP noticing it
+	streamIdleTimeout = 1 * time.Minute
+)
+
+type Config struct {
+	// DatabasusURL is URL of Databasus without API path, e.g. https://databasus.example.com
+	DatabasusURL string
+	Token        string
+	Version      string
+	// Tools maps tool names of jobs to binaries on this host, jobs for other tools are
+	// rejected. Databasus never decides which binary runs here
+	Tools map[string]string
+}
+
+// Agent keeps an outbound connection to Databasus, runs dump jobs it receives and
+// uploads their output back. Nothing listens on the agent host
+type Agent struct {
+	config     Config
+	apiURL     string
+	httpClient *http.Client
+	logger     *slog.Logger
+
+	mu          sync.Mutex
+	runningJobs map[string]context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+type Job struct {
+	BackupID string   `json:"backupId"`
+	Tool     string   `json:"tool"`
+	Args     []string `json:"args"`
+	Env      []string `json:"env"`
+}
+
+type cancelMessage struct {
+	BackupID string `json:"backupId"`
+}
+
+// New expects an http.Client without overall timeout, the job stream and uploads are
+// long-lived requests
+func New(config Config, httpClient *http.Client, logger *slog.Logger) *Agent {
+	return &Agent{
+		config:      config,
+		apiURL:      strings.TrimSuffix(config.DatabasusURL, "/") + "/api/v2",
+		httpClient:  httpClient,
+		logger:      logger,
+		runningJobs: map[string]context.CancelFunc{},
+	}
+}
+
+// Run reconnects with backoff until ctx is cancelled, then waits for running jobs that
+// are cancelled along with it
+func (a *Agent) Run(ctx context.Context) {
+	delay := minReconnectDelay
+
+	for ctx.Err() == nil {
+		connectedAt := time.Now()
+
+		err := a.listen(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+
+		// A stream that stayed up for a while is a normal disconnect, e.g. a deploy of
+		// Databasus, not a reason to back off further
+		if time.Since(connectedAt) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+
+		a.logger.Warn("Disconnected from Databasus, reconnecting", "error", err, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, maxReconnectDelay)
+	}
+
+	a.wg.Wait()
+}
+
+func (a *Agent) listen(ctx context.Context) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(
+		streamCtx,
+		http.MethodGet,
+		a.apiURL+"/agents/connect",
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	a.authorize(request)
+	request.Header.Set("Accept", "text/event-stream")
+
+	response, err := a.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return readError(response)
+	}
+
+	a.logger.Info("Connected to Databasus, waiting for jobs")
+
+	// Any event, including keepalive, proves the stream is alive
+	watchdog := time.AfterFunc(streamIdleTimeout, cancel)
+	defer watchdog.Stop()
+
+	return readEvents(response.Body, func(event, data string) {
+		watchdog.Reset(streamIdleTimeout)
+		a.handleEvent(ctx, event, data)
+	})
+}
+
+func (a *Agent) handleEvent(ctx context.Context, event, data string) {
+	switch event {
+	case "job":
+		var job Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			a.logger.Error("Failed to parse job", "error", err)
+			return
+		}
+
+		a.startJob(ctx, job)
+
+	case "cancel":
+		var message cancelMessage
+		if err := json.Unmarshal([]byte(data), &message); err != nil {
+			a.logger.Error("Failed to parse cancel message", "error", err)
+			return
+		}
+
+		a.mu.Lock()
+		cancel, isRunning := a.runningJobs[message.BackupID]
+		a.mu.Unlock()
+
+		if isRunning {
+			a.logger.Info("Cancelling job", "backupId", message.BackupID)
+			cancel()
+		}
+	}
+}
+
+func (a *Agent) startJob(ctx context.Context, job Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+
+	a.mu.Lock()
+	if _, isRunning := a.runningJobs[job.BackupID]; isRunning {
+		a.mu.Unlock()
+		cancel()
+		return
+	}
+	a.runningJobs[job.BackupID] = cancel
+	a.mu.Unlock()
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer func() {
+			a.mu.Lock()
+			delete(a.runningJobs, job.BackupID)
+			a.mu.Unlock()
+			cancel()
+		}()
+
+		a.runJob(jobCtx, job)
+	}()
+}
+
+func (a *Agent) authorize(request *http.Request) {
+	request.Header.Set("Authorization", "Bearer "+a.config.Token)
+	request.Header.Set("X-Agent-Version", a.config.Version)
+}
+
+// readEvents parses a Server-Sent Events stream. Only event and data fields are used,
+// data of multiple lines is joined with newlines as the format defines
+func readEvents(reader io.Reader, handler func(event, data string)) error {
+	scanner := bufio.NewScanner(reader)
+	// Jobs carry dump arguments, larger than the default limit for long schema lists
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	event := ""
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if len(data) > 0 || event != "" {
+				handler(event, strings.Join(data, "\n"))
+			}
+
+			event = ""
+			data = nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return errors.New("stream closed by Databasus")
+}
+
+func readError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+
+	// v2 wraps errors into {"error": {"message": ...}}
+	var envelope struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &envelope) == nil && envelope.Error.Message != "" {
+		message = envelope.Error.Message
+	}
+
+	return fmt.Errorf("databasus responded with %d: %s", response.StatusCode, message)
+}
@@ -0,0 +1,177 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run_WhenJobIsReceived_UploadsToolOutputAndReportsResult(t *testing.T) {
+	server := newFakeDatabasus(t, Job{
+		BackupID: "backup-1",
+		Tool:     "pg_dump",
+		Args:     []string{"-c", `printf "dump of $DB_NAME"; echo "done" >&2`},
+		Env:      []string{"DB_NAME=orders"},
+	})
+
+	completed := runTestAgent(t, server, map[string]string{"pg_dump": "/bin/sh"})
+
+	assert.Equal(t, "dump of orders", server.getUploaded("backup-1"))
+	assert.Equal(t, completeRequest{ToolOutput: "done\n"}, completed)
+	assert.Equal(t, "Bearer dbsagent_test", server.getAuthorization())
+}
+
+func Test_Run_WhenToolFails_ReportsErrorWithToolOutput(t *testing.T) {
+	server := newFakeDatabasus(t, Job{
+		BackupID: "backup-1",
+		Tool:     "pg_dump",
+		Args:     []string{"-c", `echo "connection refused" >&2; exit 1`},
+	})
+
+	completed := runTestAgent(t, server, map[string]string{"pg_dump": "/bin/sh"})
+
+	assert.Equal(t, "pg_dump failed: exit status 1", completed.Error)
+	assert.Equal(t, "connection refused\n", completed.ToolOutput)
+}
+
+func Test_Run_WhenToolIsNotAllowed_ReportsErrorWithoutRunningIt(t *testing.T) {
+	server := newFakeDatabasus(t, Job{BackupID: "backup-1", Tool: "sh"})
+
+	completed := runTestAgent(t, server, map[string]string{"pg_dump": "/bin/sh"})
+
+	assert.Equal(t, `tool "sh" is not allowed on this agent`, completed.Error)
+	assert.Empty(t, server.getUploaded("backup-1"))
+}
+
+func Test_ReadEvents_WhenFieldsHaveNoSpaceAfterColon_ParsesEvents(t *testing.T) {
+	stream := "event:job\ndata:{\"a\":1}\n\n: comment\nevent: cancel\ndata: 1\ndata: 2\n\n"
+
+	var events []string
+	err := readEvents(strings.NewReader(stream), func(event, data string) {
+		events = append(events, event+"="+data)
+	})
+
+	assert.EqualError(t, err, "stream closed by Databasus")
+	assert.Equal(t, []string{`job={"a":1}`, "cancel=1\n2"}, events)
+}
+
+type fakeDatabasus struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	uploaded      map[string]string
+	authorization string
+	completed     chan completeRequest
+}
+
+func newFakeDatabasus(t *testing.T, job Job) *fakeDatabasus {
+	server := &fakeDatabasus{
+		uploaded:  map[string]string{},
+		completed: make(chan completeRequest, 1),
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v2/agents/connect", func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.authorization = r.Header.Get("Authorization")
+		server.mu.Unlock()
+
+		encodedJob, _ := json.Marshal(job)
+
+		// Same framing as gin SSEvent, without a space after the colon
+		_, _ = fmt.Fprintf(w, "event:job\ndata:%s\n\n", encodedJob)
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})
+
+	mux.HandleFunc(
+		"POST /api/v2/agents/jobs/{backupId}/upload",
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			server.mu.Lock()
+			server.uploaded[r.PathValue("backupId")] = string(body)
+			server.mu.Unlock()
+		},
+	)
+
+	mux.HandleFunc(
+		"POST /api/v2/agents/jobs/{backupId}/complete",
+		func(w http.ResponseWriter, r *http.Request) {
+			var request completeRequest
+			_ = json.NewDecoder(r.Body).Decode(&request)
+
+			server.completed <- request
+		},
+	)
+
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *fakeDatabasus) getUploaded(backupID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.uploaded[backupID]
+}
+
+func (s *fakeDatabasus) getAuthorization() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.authorization
+}
+
+func runTestAgent(
+	t *testing.T,
+	server *fakeDatabasus,
+	tools map[string]string,
+) completeRequest {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	testAgent := New(
+		Config{
+			DatabasusURL: server.URL,
+			Token:        "dbsagent_test",
+			Version:      "test",
+			Tools:        tools,
+		},
+		server.Client(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	stopped := make(chan struct{})
+	go func() {
+		testAgent.Run(ctx)
+		close(stopped)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+
+	select {
+	case completed := <-server.completed:
+		return completed
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "agent did not complete the job")
+		return completeRequest{}
+	}
+}
@@ -0,0 +1,168 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// maxToolOutputBytes matches the tail Databasus keeps in the backup run log
+const maxToolOutputBytes = 16 * 1024
+
+type completeRequest struct {
+	Error      string `json:"error"`
+	ToolOutput string `json:"toolOutput"`
+}
+
+// runJob pipes stdout of the tool into the upload request, so the dump is never stored
+// on the agent host. The exit status is known only after the upload, it is reported
+// by a separate request
+func (a *Agent) runJob(ctx context.Context, job Job) {
+	logger := a.logger.With("backupId", job.BackupID, "tool", job.Tool)
+	logger.Info("Job received")
+
+	toolOutput, jobErr := a.dumpAndUpload(ctx, job, logger)
+
+	result := completeRequest{ToolOutput: toolOutput}
+	if jobErr != nil {
+		result.Error = jobErr.Error()
+		logger.Error("Job failed", "error", jobErr)
+	} else {
+		logger.Info("Job finished")
+	}
+
+	// The job context may be cancelled already, the result must be delivered anyway
+	completeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	if err := a.complete(completeCtx, job.BackupID, result); err != nil {
+		logger.Error("Failed to report job result", "error", err)
+	}
+}
+
+func (a *Agent) dumpAndUpload(ctx context.Context, job Job, logger *slog.Logger) (string, error) {
+	toolPath, isAllowed := a.config.Tools[job.Tool]
+	if !isAllowed {
+		return "", fmt.Errorf("tool %q is not allowed on this agent", job.Tool)
+	}
+
+	dumpCtx, cancelDump := context.WithCancel(ctx)
+	defer cancelDump()
+
+	cmd := exec.CommandContext(dumpCtx, toolPath, job.Args...)
+	cmd.Env = append(os.Environ(), job.Env...)
+
+	stderr := &tailBuffer{limit: maxToolOutputBytes}
+	cmd.Stderr = stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start %s: %w", toolPath, err)
+	}
+
+	logger.Info("Dump started", "pid", cmd.Process.Pid)
+
+	uploadErr := a.upload(dumpCtx, job.BackupID, stdout)
+	if uploadErr != nil {
+		// Nobody reads the output anymore, the tool would block on a full pipe
+		cancelDump()
+	}
+
+	waitErr := cmd.Wait()
+
+	switch {
+	case ctx.Err() != nil:
+		return stderr.String(), errors.New("job cancelled")
+	case uploadErr != nil:
+		return stderr.String(), fmt.Errorf("upload failed: %w", uploadErr)
+	case waitErr != nil:
+		return stderr.String(), fmt.Errorf("%s failed: %w", job.Tool, waitErr)
+	}
+
+	return stderr.String(), nil
+}
+
+func (a *Agent) upload(ctx context.Context, backupID string, body io.Reader) error {
+	return a.post(
+		ctx,
+		"/agents/jobs/"+backupID+"/upload",
+		"application/octet-stream",
+		body,
+	)
+}
+
+func (a *Agent) complete(ctx context.Context, backupID string, result completeRequest) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return a.post(
+		ctx,
+		"/agents/jobs/"+backupID+"/complete",
+		"application/json",
+		bytes.NewReader(body),
+	)
+}
+
+func (a *Agent) post(ctx context.Context, path, contentType string, body io.Reader) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, a.apiURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	a.authorize(request)
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := a.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return readError(response)
+	}
+
+	return nil
+}
+
+// tailBuffer keeps the last limit bytes written, pg_dump reports the failure reason at
+// the end of stderr
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return string(b.data)
+}
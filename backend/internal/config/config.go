@@ -4,10 +4,14 @@ import (
 	env_utils "databasus-backend/internal/util/env"
 	"databasus-backend/internal/util/logger"
 	"databasus-backend/internal/util/tools"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -27,9 +31,18 @@ type EnvVariables struct {
 	MysqlInstallDir      string            `env:"MYSQL_INSTALL_DIR"`
 	MariadbInstallDir    string            `env:"MARIADB_INSTALL_DIR"`
 	MongodbInstallDir    string            `env:"MONGODB_INSTALL_DIR"`
+	InfluxdbInstallDir   string            `env:"INFLUXDB_INSTALL_DIR"`
 
 	// Internal database
 	DatabaseDsn string `env:"DATABASE_DSN"    required:"true"`
+	// Replica for lag-tolerant reads, the main database is used when empty
+	DatabaseReadDsn                string `env:"DATABASE_READ_DSN"`
+	DatabaseMaxOpenConns           int    `env:"DATABASE_MAX_OPEN_CONNS"`
+	DatabaseMaxIdleConns           int    `env:"DATABASE_MAX_IDLE_CONNS"`
+	DatabaseConnMaxLifetimeSeconds int    `env:"DATABASE_CONN_MAX_LIFETIME_SECONDS"`
+	DatabaseConnMaxIdleTimeSeconds int    `env:"DATABASE_CONN_MAX_IDLE_TIME_SECONDS"`
+	// Migrations are run by a separate --migrate-only step, nodes only check the schema
+	IsAutoMigrationDisabled bool `env:"IS_AUTO_MIGRATION_DISABLED"`
 	// Internal Valkey
 	ValkeyHost     string `env:"VALKEY_HOST"     required:"true"`
 	ValkeyPort     string `env:"VALKEY_PORT"     required:"true"`
@@ -47,6 +60,9 @@ type EnvVariables struct {
 	IsPrimaryNode            bool `env:"IS_PRIMARY_NODE"`
 	IsProcessingNode         bool `env:"IS_PROCESSING_NODE"`
 	NodeNetworkThroughputMBs int  `env:"NODE_NETWORK_THROUGHPUT_MBPS"`
+	// Several primary nodes elect one leader to run schedulers, others only serve API
+	IsLeaderElectionEnabled bool `env:"IS_LEADER_ELECTION_ENABLED"`
+	LeaderLockTTLSeconds    int  `env:"LEADER_LOCK_TTL_SECONDS"`
 
 	DataFolder    string
 	TempFolder    string
@@ -115,14 +131,94 @@ type EnvVariables struct {
 	TestSupabasePassword string `env:"TEST_SUPABASE_PASSWORD"`
 	TestSupabaseDatabase string `env:"TEST_SUPABASE_DATABASE"`
 
-	// SMTP configuration (optional)
-	SMTPHost     string `env:"SMTP_HOST"`
-	SMTPPort     int    `env:"SMTP_PORT"`
-	SMTPUser     string `env:"SMTP_USER"`
-	SMTPPassword string `env:"SMTP_PASSWORD"`
-
 	// Application URL (optional) - used for email links
 	DatabasusURL string `env:"DATABASUS_URL"`
+
+	// Stripe billing (cloud mode only)
+	StripeWebhookSecret   string `env:"STRIPE_WEBHOOK_SECRET"`
+	StripePriceIDStarter  string `env:"STRIPE_PRICE_ID_STARTER"`
+	StripePriceIDPro      string `env:"STRIPE_PRICE_ID_PRO"`
+	StripePriceIDBusiness string `env:"STRIPE_PRICE_ID_BUSINESS"`
+
+	// Feature flags enabled for all workspaces, comma separated. Workspace
+	// overrides stored in DB take precedence
+	EnabledFeatureFlags []string `env:"ENABLED_FEATURE_FLAGS" env-separator:","`
+
+	// Version and update checks. APP_VERSION is set by Docker image build
+	AppVersion            string `env:"APP_VERSION"`
+	ReleaseFeedURL        string `env:"RELEASE_FEED_URL"`
+	IsUpdateCheckDisabled bool   `env:"IS_UPDATE_CHECK_DISABLED"`
+
+	// Admin debug endpoints (pprof, runtime stats) are served only to loopback clients,
+	// e.g. via `kubectl port-forward` or SSH tunnel
+	IsDebugEndpointsLocalhostOnly bool `env:"IS_DEBUG_ENDPOINTS_LOCALHOST_ONLY"`
+
+	// Read-only GraphQL facade at /api/v1/graphql for dashboards, disabled by default
+	IsGraphQLEnabled bool `env:"IS_GRAPHQL_ENABLED"`
+
+	// Date /api/v1 is planned to be removed, sent in Sunset header of v1 responses.
+	// Format is 2006-01-02, the header is omitted if empty
+	APIV1SunsetDate time.Time `env:"API_V1_SUNSET_DATE" env-layout:"2006-01-02"`
+
+	// Sentry compatible error tracking, disabled if DSN is empty. Environment
+	// defaults to ENV_MODE
+	SentryDSN         string `env:"SENTRY_DSN"`
+	SentryEnvironment string `env:"SENTRY_ENVIRONMENT"`
+
+	// Directory with storage plugin executables, plugins are disabled if empty
+	StoragePluginsDir string `env:"STORAGE_PLUGINS_DIR"`
+
+	// Directories filesystem sources may back up and restore into, comma separated, e.g.
+	// Docker volumes mounted into the container. Filesystem sources are disabled if empty
+	FilesystemBackupRoots []string `env:"FILESYSTEM_BACKUP_ROOTS" env-separator:","`
+
+	// Default limits of backups and restores in minutes, databases may override them.
+	// Hang timeout aborts and retries backups with no bytes progressed for that long,
+	// 0 disables a limit
+	BackupTimeoutMinutes     int `env:"BACKUP_TIMEOUT_MINUTES"`
+	RestoreTimeoutMinutes    int `env:"RESTORE_TIMEOUT_MINUTES"`
+	BackupHangTimeoutMinutes int `env:"BACKUP_HANG_TIMEOUT_MINUTES"`
+
+	// Proxy of outbound connections of S3, Azure Blob, webhook, Slack and SMTP clients,
+	// http://, https:// or socks5:// URL. Storages and notifiers may override it. Hosts in
+	// OUTBOUND_NO_PROXY, comma separated like NO_PROXY, are connected directly
+	OutboundProxyURL string   `env:"OUTBOUND_PROXY_URL"`
+	OutboundNoProxy  []string `env:"OUTBOUND_NO_PROXY"  env-separator:","`
+
+	// Load balancers and reverse proxies, comma separated IPs or CIDRs, whose forwarding
+	// headers are believed for the client IP. Headers of other peers are ignored, so
+	// clients cannot spoof their IP. CLIENT_IP_HEADERS are checked in order
+	TrustedProxies  []string `env:"TRUSTED_PROXIES"   env-separator:","`
+	ClientIPHeaders []string `env:"CLIENT_IP_HEADERS" env-separator:","`
+
+	// The API is served over HTTPS when a certificate and key are set. A client CA enables
+	// mutual TLS: clients presenting a certificate issued by it and registered for a user
+	// are signed in as that user without a JWT
+	APITLSCertFile      string `env:"API_TLS_CERT_FILE"`
+	APITLSKeyFile       string `env:"API_TLS_KEY_FILE"`
+	APIMTLSClientCAFile string `env:"API_MTLS_CLIENT_CA_FILE"`
+
+	// Built-in HTTPS with certificates of an ACME CA like Let's Encrypt, obtained and renewed
+	// for the domains instead of API_TLS_CERT_FILE. DNS-01 runs the hook command to publish
+	// TXT records, it is required for wildcard domains
+	ACMEDomains        []string `env:"ACME_DOMAINS"          env-separator:","`
+	ACMEEmail          string   `env:"ACME_EMAIL"`
+	ACMEDirectoryURL   string   `env:"ACME_DIRECTORY_URL"`
+	ACMEChallenge      string   `env:"ACME_CHALLENGE"`
+	ACMEHTTPPort       int      `env:"ACME_HTTP_PORT"`
+	ACMEDNSHookCommand string   `env:"ACME_DNS_HOOK_COMMAND"`
+	ACMECacheFolder    string
+
+	// Slash commands of Slack and Mattermost. Slack requests are verified with the signing
+	// secret of the app, Mattermost requests with the token of the slash command. Commands of
+	// a platform are rejected while its secret is empty
+	ChatopsSlackSigningSecret string `env:"CHATOPS_SLACK_SIGNING_SECRET"`
+	ChatopsMattermostToken    string `env:"CHATOPS_MATTERMOST_TOKEN"`
+
+	// Self-backup of the internal database to a system storage, disabled if storage is empty
+	MetadataBackupStorageID     string `env:"METADATA_BACKUP_STORAGE_ID"`
+	MetadataBackupIntervalHours int    `env:"METADATA_BACKUP_INTERVAL_HOURS"`
+	MetadataBackupKeepCount     int    `env:"METADATA_BACKUP_KEEP_COUNT"`
 }
 
 var (
@@ -162,11 +258,14 @@ func loadEnvVariables() {
 		filepath.Join(backendRoot, ".env"),
 	}
 
+	rememberProcessEnvKeys()
+
 	var loaded bool
 	for _, path := range envPaths {
 		log.Info("Trying to load .env", "path", path)
 		if err := godotenv.Load(path); err == nil {
 			log.Info("Successfully loaded .env", "path", path)
+			loadedEnvPath = path
 			loaded = true
 			break
 		}
@@ -183,6 +282,13 @@ func loadEnvVariables() {
 		os.Exit(1)
 	}
 
+	settings, err := readReloadableSettings()
+	if err != nil {
+		log.Error("Configuration could not be loaded", "error", err)
+		os.Exit(1)
+	}
+	reloadableSettings.Store(settings)
+
 	// Set default value for ShowDbInstallationVerificationLogs if not defined
 	if os.Getenv("SHOW_DB_INSTALLATION_VERIFICATION_LOGS") == "" {
 		env.ShowDbInstallationVerificationLogs = true
@@ -198,6 +304,81 @@ func loadEnvVariables() {
 		env.IsCloud = false
 	}
 
+	if env.AppVersion == "" {
+		env.AppVersion = "dev"
+	}
+
+	if env.ReleaseFeedURL == "" {
+		env.ReleaseFeedURL = "https://api.github.com/repos/databasus/databasus/releases"
+	}
+
+	if env.OutboundProxyURL != "" {
+		proxyURL, err := url.Parse(env.OutboundProxyURL)
+		if err != nil || proxyURL.Host == "" ||
+			!slices.Contains([]string{"http", "https", "socks5", "socks5h"}, proxyURL.Scheme) {
+			log.Error("OUTBOUND_PROXY_URL must be an http://, https:// or socks5:// URL")
+			os.Exit(1)
+		}
+	}
+
+	for _, proxy := range env.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				log.Error("TRUSTED_PROXIES must contain IPs or CIDRs", "value", proxy)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if len(env.ClientIPHeaders) == 0 {
+		env.ClientIPHeaders = []string{"X-Forwarded-For", "X-Real-IP"}
+	}
+
+	if (env.APITLSCertFile == "") != (env.APITLSKeyFile == "") {
+		log.Error("API_TLS_CERT_FILE and API_TLS_KEY_FILE must be set together")
+		os.Exit(1)
+	}
+
+	if len(env.ACMEDomains) > 0 {
+		if env.APITLSCertFile != "" {
+			log.Error("ACME_DOMAINS cannot be combined with API_TLS_CERT_FILE")
+			os.Exit(1)
+		}
+
+		if env.ACMEDirectoryURL == "" {
+			env.ACMEDirectoryURL = "https://acme-v02.api.letsencrypt.org/directory"
+		}
+		if env.ACMEChallenge == "" {
+			env.ACMEChallenge = "HTTP-01"
+		}
+		if env.ACMEHTTPPort == 0 {
+			env.ACMEHTTPPort = 80
+		}
+
+		switch env.ACMEChallenge {
+		case "HTTP-01":
+			for _, domain := range env.ACMEDomains {
+				if strings.HasPrefix(domain, "*.") {
+					log.Error("wildcard ACME_DOMAINS require ACME_CHALLENGE=DNS-01")
+					os.Exit(1)
+				}
+			}
+		case "DNS-01":
+			if env.ACMEDNSHookCommand == "" {
+				log.Error("ACME_CHALLENGE=DNS-01 requires ACME_DNS_HOOK_COMMAND")
+				os.Exit(1)
+			}
+		default:
+			log.Error("ACME_CHALLENGE must be HTTP-01 or DNS-01")
+			os.Exit(1)
+		}
+	}
+
+	if env.APIMTLSClientCAFile != "" && env.APITLSCertFile == "" && len(env.ACMEDomains) == 0 {
+		log.Error("API_MTLS_CLIENT_CA_FILE requires API_TLS_CERT_FILE or ACME_DOMAINS")
+		os.Exit(1)
+	}
+
 	for _, arg := range os.Args {
 		if strings.Contains(arg, "test") {
 			env.IsTesting = true
@@ -218,6 +399,28 @@ func loadEnvVariables() {
 		os.Exit(1)
 	}
 
+	if env.DatabaseMaxOpenConns == 0 {
+		env.DatabaseMaxOpenConns = 10
+	}
+	if env.DatabaseMaxIdleConns == 0 {
+		env.DatabaseMaxIdleConns = env.DatabaseMaxOpenConns
+	}
+	// Bounded lifetime makes connections to a demoted primary go away after failover
+	if env.DatabaseConnMaxLifetimeSeconds == 0 {
+		env.DatabaseConnMaxLifetimeSeconds = 300
+	}
+
+	if env.LeaderLockTTLSeconds == 0 {
+		env.LeaderLockTTLSeconds = 15
+	}
+
+	if env.MetadataBackupIntervalHours == 0 {
+		env.MetadataBackupIntervalHours = 24
+	}
+	if env.MetadataBackupKeepCount == 0 {
+		env.MetadataBackupKeepCount = 7
+	}
+
 	if env.EnvMode == "" {
 		log.Error("ENV_MODE is empty")
 		os.Exit(1)
@@ -260,6 +463,14 @@ func loadEnvVariables() {
 		env.ShowDbInstallationVerificationLogs,
 	)
 
+	env.InfluxdbInstallDir = filepath.Join(backendRoot, "tools", "influxdb")
+	tools.VerifyInfluxdbInstallation(
+		log,
+		env.EnvMode,
+		env.InfluxdbInstallDir,
+		env.ShowDbInstallationVerificationLogs,
+	)
+
 	if env.NodeNetworkThroughputMBs == 0 {
 		env.NodeNetworkThroughputMBs = 125 // 1 Gbit/s
 	}
@@ -309,6 +520,7 @@ func loadEnvVariables() {
 	env.DataFolder = filepath.Join(filepath.Dir(backendRoot), "databasus-data", "backups")
 	env.TempFolder = filepath.Join(filepath.Dir(backendRoot), "databasus-data", "temp")
 	env.SecretKeyPath = filepath.Join(filepath.Dir(backendRoot), "databasus-data", "secret.key")
+	env.ACMECacheFolder = filepath.Join(filepath.Dir(backendRoot), "databasus-data", "acme")
 
 	if env.IsTesting {
 		if env.TestPostgres12Port == "" {
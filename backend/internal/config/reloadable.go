@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"databasus-backend/internal/util/logger"
+
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/joho/godotenv"
+)
+
+// ReloadableSettings are read again from .env on SIGHUP or via the reload endpoint, without
+// restarting the node. Everything structural (DSNs, node roles, folders) stays in
+// EnvVariables and needs a restart. Read them on each use instead of caching in structs
+type ReloadableSettings struct {
+	LogLevel string `env:"LOG_LEVEL"`
+
+	SignInRateLimitPerMinute      int `env:"SIGN_IN_RATE_LIMIT_PER_MINUTE"`
+	PasswordResetRateLimitPerHour int `env:"PASSWORD_RESET_RATE_LIMIT_PER_HOUR"`
+	// PasswordResetTokenLifetimeMinutes applies to both the emailed code and the reset link
+	PasswordResetTokenLifetimeMinutes int `env:"PASSWORD_RESET_TOKEN_LIFETIME_MINUTES"`
+
+	HealthcheckMaxDiskUsagePercent int `env:"HEALTHCHECK_MAX_DISK_USAGE_PERCENT"`
+
+	// SMTP configuration (optional)
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     int    `env:"SMTP_PORT"`
+	SMTPUser     string `env:"SMTP_USER"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+}
+
+var (
+	reloadableSettings atomic.Pointer[ReloadableSettings]
+	loadedEnvPath      string
+	// Variables set by the process environment (docker -e, k8s) win over .env as on startup
+	processEnvKeys map[string]bool
+)
+
+func GetReloadableSettings() *ReloadableSettings {
+	once.Do(loadEnvVariables)
+	return reloadableSettings.Load()
+}
+
+func ReloadSettings() (*ReloadableSettings, error) {
+	once.Do(loadEnvVariables)
+
+	fileEnv, err := godotenv.Read(loadedEnvPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", loadedEnvPath, err)
+	}
+
+	for key, value := range fileEnv {
+		if !processEnvKeys[key] {
+			if err := os.Setenv(key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	settings, err := readReloadableSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	reloadableSettings.Store(settings)
+	log.Info("Settings reloaded", "path", loadedEnvPath, "logLevel", settings.LogLevel)
+
+	return settings, nil
+}
+
+func (s *ReloadableSettings) IsSMTPConfigured() bool {
+	return s.SMTPHost != "" && s.SMTPPort != 0
+}
+
+func readReloadableSettings() (*ReloadableSettings, error) {
+	var settings ReloadableSettings
+
+	// Variables removed from .env stay in the process environment, to reset a value set it
+	// to empty in .env instead of deleting the line
+	if err := cleanenv.ReadEnv(&settings); err != nil {
+		return nil, err
+	}
+
+	if settings.LogLevel == "" {
+		settings.LogLevel = "info"
+	}
+	if settings.SignInRateLimitPerMinute == 0 {
+		settings.SignInRateLimitPerMinute = 10
+	}
+	if settings.PasswordResetRateLimitPerHour == 0 {
+		settings.PasswordResetRateLimitPerHour = 3
+	}
+	if settings.PasswordResetTokenLifetimeMinutes == 0 {
+		settings.PasswordResetTokenLifetimeMinutes = 60
+	}
+	if settings.HealthcheckMaxDiskUsagePercent == 0 {
+		settings.HealthcheckMaxDiskUsagePercent = 95
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(settings.LogLevel))); err != nil {
+		return nil, fmt.Errorf("invalid LOG_LEVEL %q: %w", settings.LogLevel, err)
+	}
+	logger.SetLevel(level)
+
+	return &settings, nil
+}
+
+func rememberProcessEnvKeys() {
+	processEnvKeys = make(map[string]bool)
+
+	for _, variable := range os.Environ() {
+		key, _, _ := strings.Cut(variable, "=")
+		processEnvKeys[key] = true
+	}
+}
@@ -21,3 +21,19 @@ func StartListeningForShutdownSignal() {
 func IsShouldShutdown() bool {
 	return isShutDownSignalReceived
 }
+
+// StartListeningForReloadSignal reloads settings of this node on SIGHUP
+func StartListeningForReloadSignal() {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		for range hangup {
+			log.Info("SIGHUP received, reloading settings")
+
+			if _, err := ReloadSettings(); err != nil {
+				log.Error("Failed to reload settings", "error", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,334 @@
+package agents
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+)
+
+const (
+	agentVersionHeader = "X-Agent-Version"
+	// agentKeepaliveInterval keeps idle connections open through proxies that close
+	// silent streams, usually after 60 seconds
+	agentKeepaliveInterval = 15 * time.Second
+)
+
+type AgentController struct {
+	agentService    *AgentService
+	agentJobService *AgentJobService
+	agentJobBus     *AgentJobBus
+}
+
+func (c *AgentController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/agents", c.CreateAgent)
+	router.GET("/agents", c.GetAgents)
+	router.DELETE("/agents/:id", c.DeleteAgent)
+}
+
+// RegisterAgentRoutes registers routes called by agents. They are authenticated by agent
+// token instead of user JWT, so they go to the public router group
+func (c *AgentController) RegisterAgentRoutes(router *gin.RouterGroup) {
+	router.GET("/agents/connect", c.Connect)
+	router.POST("/agents/jobs/:backupId/upload", c.UploadBackup)
+	router.POST("/agents/jobs/:backupId/complete", c.CompleteJob)
+}
+
+// CreateAgent
+// @Summary Create an agent
+// @Description Create an agent for databases reachable only from a private network. The
+// @Description token is returned only once
+// @Tags agents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateAgentRequest true "Agent data"
+// @Success 200 {object} CreateAgentResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /agents [post]
+func (c *AgentController) CreateAgent(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CreateAgentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.agentService.CreateAgent(user, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageAgents) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetAgents
+// @Summary Get agents
+// @Description Get agents of a workspace with their connection state
+// @Tags agents
+// @Produce json
+// @Security BearerAuth
+// @Param workspace_id query string true "Workspace ID"
+// @Success 200 {array} Agent
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /agents [get]
+func (c *AgentController) GetAgents(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Query("workspace_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace_id"})
+		return
+	}
+
+	agents, err := c.agentService.GetAgents(user, workspaceID)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewAgents) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, agents)
+}
+
+// DeleteAgent
+// @Summary Delete an agent
+// @Description Delete an agent not used by any database
+// @Tags agents
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Agent ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Router /agents/{id} [delete]
+func (c *AgentController) DeleteAgent(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid agent ID"})
+		return
+	}
+
+	if err := c.agentService.DeleteAgent(user, id); err != nil {
+		switch {
+		case errors.Is(err, ErrInsufficientPermissionsToManageAgents):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, ErrAgentInUse):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "agent not found"})
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "agent deleted successfully"})
+}
+
+// Connect
+// @Summary Receive backup jobs (agent)
+// @Description Persistent Server-Sent Events stream an agent keeps open to receive jobs.
+// @Description "job" events carry AgentJob, "cancel" events carry the ID of a backup to stop
+// @Tags agents
+// @Produce text/event-stream
+// @Param Authorization header string true "Bearer agent token"
+// @Success 200 {object} AgentJob
+// @Failure 401
+// @Router /agents/connect [get]
+func (c *AgentController) Connect(ctx *gin.Context) {
+	agent, ok := c.authenticateAgent(ctx)
+	if !ok {
+		return
+	}
+
+	requestCtx := ctx.Request.Context()
+	messages := make(chan AgentJobMessage, 16)
+
+	stopFollowing, err := c.agentJobBus.FollowJobs(
+		requestCtx,
+		agent.ID,
+		func(message AgentJobMessage) {
+			select {
+			case messages <- message:
+			case <-requestCtx.Done():
+			}
+		},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer stopFollowing()
+
+	version := ctx.GetHeader(agentVersionHeader)
+	c.agentService.MarkAgentSeen(agent, version)
+
+	c.agentService.logger.Info("Agent connected", "agentId", agent.ID, "version", version)
+	defer c.agentService.logger.Info("Agent disconnected", "agentId", agent.ID)
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Disables response buffering in nginx, otherwise jobs arrive only with next keepalive
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	ticker := time.NewTicker(agentKeepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-requestCtx.Done():
+			return
+		case message := <-messages:
+			switch message.Type {
+			case AgentJobMessageTypeJob:
+				ctx.SSEvent(string(message.Type), message.Job)
+			case AgentJobMessageTypeCancel:
+				ctx.SSEvent(string(message.Type), gin.H{"backupId": message.BackupID})
+			}
+			ctx.Writer.Flush()
+		case <-ticker.C:
+			ctx.SSEvent("keepalive", gin.H{})
+			ctx.Writer.Flush()
+
+			c.agentService.MarkAgentSeen(agent, version)
+		}
+	}
+}
+
+// UploadBackup
+// @Summary Upload a dump (agent)
+// @Description Stream output of the dump tool as the request body. Databasus encrypts it
+// @Description per backup config and saves it to the storage of the backup
+// @Tags agents
+// @Accept application/octet-stream
+// @Produce json
+// @Param Authorization header string true "Bearer agent token"
+// @Param backupId path string true "Backup ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Router /agents/jobs/{backupId}/upload [post]
+func (c *AgentController) UploadBackup(ctx *gin.Context) {
+	agent, ok := c.authenticateAgent(ctx)
+	if !ok {
+		return
+	}
+
+	backupID, err := uuid.Parse(ctx.Param("backupId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	if err := c.agentJobService.UploadBackup(
+		ctx.Request.Context(),
+		agent,
+		backupID,
+		ctx.Request.Body,
+	); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "backup uploaded successfully"})
+}
+
+// CompleteJob
+// @Summary Complete a job (agent)
+// @Description Report exit of the dump tool after the upload. A non-empty error fails the
+// @Description backup
+// @Tags agents
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer agent token"
+// @Param backupId path string true "Backup ID"
+// @Param request body CompleteAgentJobRequest true "Result of the dump tool"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Router /agents/jobs/{backupId}/complete [post]
+func (c *AgentController) CompleteJob(ctx *gin.Context) {
+	agent, ok := c.authenticateAgent(ctx)
+	if !ok {
+		return
+	}
+
+	backupID, err := uuid.Parse(ctx.Param("backupId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	var request CompleteAgentJobRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.agentJobService.CompleteJob(agent, backupID, &request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "job completed"})
+}
+
+func (c *AgentController) authenticateAgent(ctx *gin.Context) (*Agent, bool) {
+	token, isBearer := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if !isBearer {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "agent token is required"})
+		return nil, false
+	}
+
+	agent, err := c.agentService.AuthenticateAgent(strings.TrimSpace(token))
+	if err != nil {
+		if errors.Is(err, ErrInvalidAgentToken) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return nil, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return agent, true
+}
@@ -0,0 +1,145 @@
+package agents
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func Test_CreateAgent_ReturnsTokenOnceAndAgentCanAuthenticate(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetAgentController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Agents test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	var created CreateAgentResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/agents",
+		"Bearer "+owner.Token,
+		CreateAgentRequest{WorkspaceID: workspace.ID, Name: "office"},
+		http.StatusOK,
+		&created,
+	)
+
+	assert.True(t, strings.HasPrefix(created.Token, "dbsagent_"))
+	assert.Equal(t, "office", created.Agent.Name)
+
+	var agents []Agent
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/agents?workspace_id="+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&agents,
+	)
+
+	assert.Len(t, agents, 1)
+	assert.False(t, agents[0].IsConnected)
+
+	authenticated, err := GetAgentService().AuthenticateAgent(created.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, created.Agent.ID, authenticated.ID)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/agents/"+created.Agent.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+
+	_, err = GetAgentService().AuthenticateAgent(created.Token)
+	assert.ErrorIs(t, err, ErrInvalidAgentToken)
+}
+
+func Test_CreateAgent_WhenUserIsNotWorkspaceMember_ReturnsForbidden(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	stranger := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetAgentController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Agents test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/agents",
+		"Bearer "+stranger.Token,
+		CreateAgentRequest{WorkspaceID: workspace.ID, Name: "office"},
+		http.StatusForbidden,
+	)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/agents?workspace_id="+workspace.ID.String(),
+		"Bearer "+stranger.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_CompleteJob_WhenAgentTokenIsInvalid_ReturnsUnauthorized(t *testing.T) {
+	router := createAgentRoutesTestRouter()
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/agents/jobs/"+uuid.New().String()+"/complete",
+		"Bearer dbsagent_invalid",
+		CompleteAgentJobRequest{},
+		http.StatusUnauthorized,
+	)
+}
+
+func Test_CompleteJob_WhenBackupIsNotAssignedToAgent_ReturnsBadRequest(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createAgentRoutesTestRouter()
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Agents test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	var created CreateAgentResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		workspaces_testing.CreateTestRouter(GetAgentController()),
+		"/api/v1/agents",
+		"Bearer "+owner.Token,
+		CreateAgentRequest{WorkspaceID: workspace.ID, Name: "office"},
+		http.StatusOK,
+		&created,
+	)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/agents/jobs/"+uuid.New().String()+"/complete",
+		"Bearer "+created.Token,
+		CompleteAgentJobRequest{},
+		http.StatusBadRequest,
+	)
+}
+
+func createAgentRoutesTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	GetAgentController().RegisterAgentRoutes(router.Group("/api/v1"))
+
+	return router
+}
@@ -0,0 +1,80 @@
+package agents
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var agentRepository = &AgentRepository{}
+
+var agentService = &AgentService{
+	agentRepository,
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+
+var agentJobBus = &AgentJobBus{
+	client:  cache_utils.GetValkeyClient(),
+	logger:  logger.GetLogger(),
+	timeout: cache_utils.DefaultCacheTimeout,
+}
+
+var agentJobService = &AgentJobService{
+	agentJobBus,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	backups_config.GetBackupConfigService(),
+	storages.GetStorageService(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+
+var agentController = &AgentController{
+	agentService,
+	agentJobService,
+	agentJobBus,
+}
+
+func GetAgentService() *AgentService {
+	return agentService
+}
+
+func GetAgentJobBus() *AgentJobBus {
+	return agentJobBus
+}
+
+func GetAgentController() *AgentController {
+	return agentController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		databases.GetDatabaseService().SetAgentChecker(agentService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
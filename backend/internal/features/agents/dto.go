@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"github.com/google/uuid"
+
+	common "databasus-backend/internal/features/backups/backups/common"
+)
+
+type CreateAgentRequest struct {
+	WorkspaceID uuid.UUID `json:"workspaceId" binding:"required"`
+	Name        string    `json:"name"        binding:"required"`
+}
+
+type CreateAgentResponse struct {
+	Agent *Agent `json:"agent"`
+	// Token is returned only once, use it as DATABASUS_AGENT_TOKEN of the agent
+	Token string `json:"token"`
+}
+
+type AgentJobMessageType string
+
+const (
+	AgentJobMessageTypeJob    AgentJobMessageType = "job"
+	AgentJobMessageTypeCancel AgentJobMessageType = "cancel"
+)
+
+// AgentJob is a dump the agent runs and uploads back. Tool is a name of the dump tool,
+// the agent maps it to a binary it trusts instead of executing arbitrary paths
+type AgentJob struct {
+	BackupID uuid.UUID `json:"backupId"`
+	Tool     string    `json:"tool"`
+	Args     []string  `json:"args"`
+	Env      []string  `json:"env"`
+}
+
+// AgentJobMessage is relayed to whichever node holds the agent connection. Type is also
+// the name of SSE event the agent receives
+type AgentJobMessage struct {
+	Type     AgentJobMessageType `json:"type"`
+	BackupID uuid.UUID           `json:"backupId"`
+	Job      *AgentJob           `json:"job,omitempty"`
+}
+
+type AgentJobEventType string
+
+const (
+	AgentJobEventStarted  AgentJobEventType = "STARTED"
+	AgentJobEventProgress AgentJobEventType = "PROGRESS"
+	AgentJobEventUploaded AgentJobEventType = "UPLOADED"
+	AgentJobEventFinished AgentJobEventType = "FINISHED"
+)
+
+// AgentJobEvent is relayed from the node receiving agent requests to the node running
+// the backup
+type AgentJobEvent struct {
+	Type         AgentJobEventType      `json:"type"`
+	CompletedMBs float64                `json:"completedMBs"`
+	Metadata     *common.BackupMetadata `json:"metadata,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+	ToolOutput   string                 `json:"toolOutput,omitempty"`
+}
+
+type CompleteAgentJobRequest struct {
+	// Error is empty when the dump tool exited successfully
+	Error      string `json:"error"`
+	ToolOutput string `json:"toolOutput"`
+}
@@ -0,0 +1,18 @@
+package agents
+
+import "errors"
+
+var (
+	ErrInsufficientPermissionsToManageAgents = errors.New(
+		"insufficient permissions to manage agents in this workspace",
+	)
+	ErrInsufficientPermissionsToViewAgents = errors.New(
+		"insufficient permissions to view agents in this workspace",
+	)
+	ErrInvalidAgentToken = errors.New("invalid agent token")
+	ErrAgentInUse        = errors.New(
+		"agent is used by databases, move them off the agent before deleting it",
+	)
+	ErrAgentNotInWorkspace = errors.New("agent does not belong to this workspace")
+	ErrBackupNotAssigned   = errors.New("backup is not assigned to this agent")
+)
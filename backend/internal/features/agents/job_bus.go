@@ -0,0 +1,102 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+
+	cache_utils "databasus-backend/internal/util/cache"
+)
+
+const (
+	agentJobsChannelPrefix   = "agent:jobs:"
+	agentEventsChannelPrefix = "agent:job_events:"
+)
+
+// AgentJobBus relays jobs from the node running a backup to the node holding the agent
+// connection, and events of the job back. Agent requests may land on any node behind a
+// load balancer, so nothing is delivered in-process.
+//
+// Pub/sub does not replay messages, the node running the backup subscribes to events
+// before it sends the job, and treats a job not started in time as agent being offline
+type AgentJobBus struct {
+	client  valkey.Client
+	logger  *slog.Logger
+	timeout time.Duration
+}
+
+func (b *AgentJobBus) SendJob(agentID uuid.UUID, message AgentJobMessage) error {
+	return b.publish(agentJobsChannelPrefix+agentID.String(), message)
+}
+
+func (b *AgentJobBus) PublishEvent(backupID uuid.UUID, event AgentJobEvent) error {
+	return b.publish(agentEventsChannelPrefix+backupID.String(), event)
+}
+
+// FollowJobs delivers jobs of the agent until the returned function is called. Each
+// follower gets its own pub/sub manager, the shared one allows a single subscription
+// per channel
+func (b *AgentJobBus) FollowJobs(
+	ctx context.Context,
+	agentID uuid.UUID,
+	handler func(message AgentJobMessage),
+) (func(), error) {
+	return follow(ctx, b, agentJobsChannelPrefix+agentID.String(), handler)
+}
+
+func (b *AgentJobBus) FollowEvents(
+	ctx context.Context,
+	backupID uuid.UUID,
+	handler func(event AgentJobEvent),
+) (func(), error) {
+	return follow(ctx, b, agentEventsChannelPrefix+backupID.String(), handler)
+}
+
+func (b *AgentJobBus) publish(channel string, payload any) error {
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	result := b.client.Do(
+		ctx,
+		b.client.B().Publish().Channel(channel).Message(string(message)).Build(),
+	)
+	if err := result.Error(); err != nil {
+		return fmt.Errorf("failed to publish agent message to %s: %w", channel, err)
+	}
+
+	return nil
+}
+
+func follow[T any](
+	ctx context.Context,
+	b *AgentJobBus,
+	channel string,
+	handler func(message T),
+) (func(), error) {
+	pubsub := cache_utils.NewPubSubManager()
+
+	err := pubsub.Subscribe(ctx, channel, func(rawMessage string) {
+		var message T
+		if err := json.Unmarshal([]byte(rawMessage), &message); err != nil {
+			b.logger.Warn("Failed to unmarshal agent message", "channel", channel, "error", err)
+			return
+		}
+
+		handler(message)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() { _ = pubsub.Close() }, nil
+}
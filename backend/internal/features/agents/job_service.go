@@ -0,0 +1,271 @@
+package agents
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	common "databasus-backend/internal/features/backups/backups/common"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+const (
+	uploadCopyBufferSize = 8 * 1024 * 1024
+	// uploadProgressInterval also works as a heartbeat for the node running the backup
+	// while the dump tool produces no output, e.g. on a large table with a slow query
+	uploadProgressInterval = 10 * time.Second
+)
+
+// AgentJobService receives dumps uploaded by agents. Storage credentials and the master
+// key never leave Databasus: the node receiving the upload encrypts and saves the stream
+// the same way backup nodes do for their own dumps
+type AgentJobService struct {
+	agentJobBus         *AgentJobBus
+	backupRepository    *backups_core.BackupRepository
+	databaseService     *databases.DatabaseService
+	backupConfigService *backups_config.BackupConfigService
+	storageService      *storages.StorageService
+	secretKeyService    *encryption_secrets.SecretKeyService
+	fieldEncryptor      encryption.FieldEncryptor
+	logger              *slog.Logger
+}
+
+func (s *AgentJobService) UploadBackup(
+	ctx context.Context,
+	agent *Agent,
+	backupID uuid.UUID,
+	body io.Reader,
+) error {
+	backup, err := s.getAgentBackup(agent, backupID)
+	if err != nil {
+		return err
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(backup.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	storage, err := s.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return err
+	}
+	storage.BindFileName(backup.ID, backup.FileName)
+
+	s.publishEvent(backupID, AgentJobEvent{Type: AgentJobEventStarted})
+
+	storageReader, storageWriter := io.Pipe()
+
+	finalWriter, encryptionWriter, backupMetadata, err := s.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		storageWriter,
+	)
+	if err != nil {
+		return err
+	}
+
+	saveErrCh := make(chan error, 1)
+	go func() {
+		saveErrCh <- storage.SaveFile(ctx, s.fieldEncryptor, s.logger, backupID, storageReader)
+	}()
+
+	var bytesWritten atomic.Int64
+
+	stopProgress := make(chan struct{})
+	defer close(stopProgress)
+	go s.reportProgress(backupID, &bytesWritten, stopProgress)
+
+	copyErr := s.copyCounting(finalWriter, body, &bytesWritten)
+	if copyErr == nil && encryptionWriter != nil {
+		copyErr = encryptionWriter.Close()
+	}
+
+	if copyErr != nil {
+		storageWriter.CloseWithError(copyErr)
+		<-saveErrCh
+		return fmt.Errorf("failed to receive backup from agent: %w", copyErr)
+	}
+
+	if err := storageWriter.Close(); err != nil {
+		<-saveErrCh
+		return err
+	}
+
+	if err := <-saveErrCh; err != nil {
+		return fmt.Errorf("failed to save backup to storage: %w", err)
+	}
+
+	s.publishEvent(backupID, AgentJobEvent{
+		Type:         AgentJobEventUploaded,
+		CompletedMBs: float64(bytesWritten.Load()) / (1024 * 1024),
+		Metadata:     &backupMetadata,
+	})
+
+	s.logger.Info(
+		"Backup uploaded by agent",
+		"agentId",
+		agent.ID,
+		"backupId",
+		backupID,
+		"bytes",
+		bytesWritten.Load(),
+	)
+
+	return nil
+}
+
+// CompleteJob reports the exit of the dump tool. It comes after the upload, because the
+// agent learns the exit code only when the tool closes its output
+func (s *AgentJobService) CompleteJob(
+	agent *Agent,
+	backupID uuid.UUID,
+	request *CompleteAgentJobRequest,
+) error {
+	if _, err := s.getAgentBackup(agent, backupID); err != nil {
+		return err
+	}
+
+	return s.agentJobBus.PublishEvent(backupID, AgentJobEvent{
+		Type:       AgentJobEventFinished,
+		Error:      request.Error,
+		ToolOutput: request.ToolOutput,
+	})
+}
+
+func (s *AgentJobService) getAgentBackup(
+	agent *Agent,
+	backupID uuid.UUID,
+) (*backups_core.Backup, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, ErrBackupNotAssigned
+	}
+
+	if backup.Status != backups_core.BackupStatusInProgress {
+		return nil, fmt.Errorf("backup is not in progress, current status: %s", backup.Status)
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.AgentID == nil || *database.AgentID != agent.ID {
+		return nil, ErrBackupNotAssigned
+	}
+
+	return backup, nil
+}
+
+func (s *AgentJobService) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storageWriter io.Writer,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	metadata := common.BackupMetadata{}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		metadata.Encryption = backups_config.BackupEncryptionNone
+		return storageWriter, nil, metadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, metadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, metadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := s.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, metadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encWriter, err := backup_encryption.NewEncryptionWriter(
+		storageWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, metadata, fmt.Errorf("failed to create encrypting writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+	metadata.EncryptionSalt = &saltBase64
+	metadata.EncryptionIV = &nonceBase64
+	metadata.Encryption = backups_config.BackupEncryptionEncrypted
+
+	return encWriter, encWriter, metadata, nil
+}
+
+func (s *AgentJobService) copyCounting(
+	dst io.Writer,
+	src io.Reader,
+	bytesWritten *atomic.Int64,
+) error {
+	buf := make([]byte, uploadCopyBufferSize)
+
+	for {
+		bytesRead, readErr := src.Read(buf)
+		if bytesRead > 0 {
+			if _, err := dst.Write(buf[:bytesRead]); err != nil {
+				return err
+			}
+
+			bytesWritten.Add(int64(bytesRead))
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+func (s *AgentJobService) reportProgress(
+	backupID uuid.UUID,
+	bytesWritten *atomic.Int64,
+	stop <-chan struct{},
+) {
+	ticker := time.NewTicker(uploadProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.publishEvent(backupID, AgentJobEvent{
+				Type:         AgentJobEventProgress,
+				CompletedMBs: float64(bytesWritten.Load()) / (1024 * 1024),
+			})
+		}
+	}
+}
+
+func (s *AgentJobService) publishEvent(backupID uuid.UUID, event AgentJobEvent) {
+	if err := s.agentJobBus.PublishEvent(backupID, event); err != nil {
+		s.logger.Error("Failed to publish agent job event", "backupId", backupID, "error", err)
+	}
+}
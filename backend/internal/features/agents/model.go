@@ -0,0 +1,32 @@
+package agents
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// agentConnectedThreshold is larger than keepalive interval of the connection stream, so
+// a single delayed keepalive does not show the agent as disconnected
+const agentConnectedThreshold = 2 * time.Minute
+
+type Agent struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Name        string    `json:"name"        gorm:"column:name;type:text;not null"`
+	// TokenHash is sha256 of the agent token, the token itself is shown only on creation
+	TokenHash  string     `json:"-"          gorm:"column:token_hash;type:text;not null"`
+	Version    *string    `json:"version"    gorm:"column:version;type:text"`
+	LastSeenAt *time.Time `json:"lastSeenAt" gorm:"column:last_seen_at;type:timestamp with time zone"`
+	CreatedAt  time.Time  `json:"createdAt"  gorm:"column:created_at;type:timestamp with time zone;not null"`
+
+	IsConnected bool `json:"isConnected" gorm:"-"`
+}
+
+func (Agent) TableName() string {
+	return "agents"
+}
+
+func (a *Agent) populateIsConnected(now time.Time) {
+	a.IsConnected = a.LastSeenAt != nil && a.LastSeenAt.After(now.Add(-agentConnectedThreshold))
+}
@@ -0,0 +1,76 @@
+package agents
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/storage"
+)
+
+type AgentRepository struct{}
+
+func (r *AgentRepository) Save(agent *Agent) error {
+	if agent.ID == uuid.Nil {
+		agent.ID = uuid.New()
+	}
+
+	return storage.GetDb().Save(agent).Error
+}
+
+func (r *AgentRepository) FindByID(id uuid.UUID) (*Agent, error) {
+	var agent Agent
+
+	if err := storage.GetDb().Where("id = ?", id).First(&agent).Error; err != nil {
+		return nil, err
+	}
+
+	return &agent, nil
+}
+
+func (r *AgentRepository) FindByTokenHash(tokenHash string) (*Agent, error) {
+	var agent Agent
+
+	if err := storage.GetDb().Where("token_hash = ?", tokenHash).First(&agent).Error; err != nil {
+		return nil, err
+	}
+
+	return &agent, nil
+}
+
+func (r *AgentRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Agent, error) {
+	agents := make([]*Agent, 0)
+
+	if err := storage.GetDb().
+		Where("workspace_id = ?", workspaceID).
+		Order("name ASC").
+		Find(&agents).Error; err != nil {
+		return nil, err
+	}
+
+	return agents, nil
+}
+
+// UpdateLastSeen does not go through Save, the keepalive must not overwrite a rename
+// done meanwhile
+func (r *AgentRepository) UpdateLastSeen(id uuid.UUID, version *string, seenAt time.Time) error {
+	return storage.GetDb().
+		Model(&Agent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"last_seen_at": seenAt, "version": version}).Error
+}
+
+func (r *AgentRepository) CountDatabasesUsingAgent(id uuid.UUID) (int64, error) {
+	var count int64
+
+	err := storage.GetDb().
+		Table("databases").
+		Where("agent_id = ?", id).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (r *AgentRepository) Delete(agent *Agent) error {
+	return storage.GetDb().Delete(agent).Error
+}
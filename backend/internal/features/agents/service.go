@@ -0,0 +1,197 @@
+package agents
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+// agentTokenPrefix makes leaked tokens recognizable by secret scanners
+const agentTokenPrefix = "dbsagent_"
+
+type AgentService struct {
+	agentRepository  *AgentRepository
+	workspaceService *workspaces_services.WorkspaceService
+	auditLogService  *audit_logs.AuditLogService
+	logger           *slog.Logger
+}
+
+func (s *AgentService) CreateAgent(
+	user *users_models.User,
+	request *CreateAgentRequest,
+) (*CreateAgentResponse, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(request.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageAgents
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	token, err := generateAgentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	agent := &Agent{
+		WorkspaceID: request.WorkspaceID,
+		Name:        name,
+		TokenHash:   hashAgentToken(token),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.agentRepository.Save(agent); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Agent created: %s", agent.Name),
+		&user.ID,
+		&agent.WorkspaceID,
+	)
+
+	return &CreateAgentResponse{Agent: agent, Token: token}, nil
+}
+
+func (s *AgentService) GetAgents(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*Agent, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewAgents
+	}
+
+	agents, err := s.agentRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, agent := range agents {
+		agent.populateIsConnected(now)
+	}
+
+	return agents, nil
+}
+
+func (s *AgentService) DeleteAgent(user *users_models.User, agentID uuid.UUID) error {
+	agent, err := s.agentRepository.FindByID(agentID)
+	if err != nil {
+		return err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(agent.WorkspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrInsufficientPermissionsToManageAgents
+	}
+
+	databasesCount, err := s.agentRepository.CountDatabasesUsingAgent(agent.ID)
+	if err != nil {
+		return err
+	}
+	if databasesCount > 0 {
+		return ErrAgentInUse
+	}
+
+	if err := s.agentRepository.Delete(agent); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Agent deleted: %s", agent.Name),
+		&user.ID,
+		&agent.WorkspaceID,
+	)
+
+	return nil
+}
+
+func (s *AgentService) AuthenticateAgent(token string) (*Agent, error) {
+	if !strings.HasPrefix(token, agentTokenPrefix) {
+		return nil, ErrInvalidAgentToken
+	}
+
+	agent, err := s.agentRepository.FindByTokenHash(hashAgentToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAgentToken
+		}
+
+		return nil, err
+	}
+
+	return agent, nil
+}
+
+func (s *AgentService) MarkAgentSeen(agent *Agent, version string) {
+	var agentVersion *string
+	if version != "" {
+		agentVersion = &version
+	}
+
+	if err := s.agentRepository.UpdateLastSeen(
+		agent.ID,
+		agentVersion,
+		time.Now().UTC(),
+	); err != nil {
+		s.logger.Error("Failed to update agent last seen time", "agentId", agent.ID, "error", err)
+	}
+}
+
+// CheckCanUseAgent is called by databases service before a database is attached to agent
+func (s *AgentService) CheckCanUseAgent(workspaceID uuid.UUID, agentID uuid.UUID) error {
+	agent, err := s.agentRepository.FindByID(agentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAgentNotInWorkspace
+		}
+
+		return err
+	}
+
+	if agent.WorkspaceID != workspaceID {
+		return ErrAgentNotInWorkspace
+	}
+
+	return nil
+}
+
+func generateAgentToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate agent token: %w", err)
+	}
+
+	return agentTokenPrefix + hex.EncodeToString(randomBytes), nil
+}
+
+// hashAgentToken uses plain sha256, tokens are random and long enough that slow hashing
+// would only slow down every agent request
+func hashAgentToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -47,6 +47,7 @@ func SetupDependencies() {
 		users_services.GetUserService().SetAuditLogWriter(auditLogService)
 		users_services.GetSettingsService().SetAuditLogWriter(auditLogService)
 		users_services.GetManagementService().SetAuditLogWriter(auditLogService)
+		users_services.GetBrandingService().SetAuditLogWriter(auditLogService)
 
 		isSetup.Store(true)
 	})
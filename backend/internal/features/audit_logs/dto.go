@@ -24,6 +24,9 @@ type AuditLogDTO struct {
 	UserID        *uuid.UUID `json:"userId"        gorm:"column:user_id"`
 	WorkspaceID   *uuid.UUID `json:"workspaceId"   gorm:"column:workspace_id"`
 	Message       string     `json:"message"       gorm:"column:message"`
+	ChangeReason  *string    `json:"changeReason"  gorm:"column:change_reason"`
+	IPAddress     *string    `json:"ipAddress"     gorm:"column:ip_address"`
+	ReadCount     int        `json:"readCount"     gorm:"column:read_count"`
 	CreatedAt     time.Time  `json:"createdAt"     gorm:"column:created_at"`
 	UserEmail     *string    `json:"userEmail"     gorm:"column:user_email"`
 	UserName      *string    `json:"userName"      gorm:"column:user_name"`
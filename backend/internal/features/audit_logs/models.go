@@ -12,6 +12,17 @@ type AuditLog struct {
 	WorkspaceID *uuid.UUID `json:"workspaceId" gorm:"column:workspace_id"`
 	Message     string     `json:"message"     gorm:"column:message"`
 	CreatedAt   time.Time  `json:"createdAt"   gorm:"column:created_at"`
+
+	// ChangeReason is given by the user editing a resource of the workspace
+	ChangeReason *string `json:"changeReason" gorm:"column:change_reason"`
+
+	// IPAddress is the client address of requests made on behalf of the user, e.g. sign-ins
+	IPAddress *string `json:"ipAddress" gorm:"column:ip_address"`
+
+	// ReadResourceID is set for reads of resources holding credentials. Further reads of the
+	// resource by the same user within the aggregation window only increase ReadCount
+	ReadResourceID *uuid.UUID `json:"-"         gorm:"column:read_resource_id"`
+	ReadCount      int        `json:"readCount" gorm:"column:read_count"`
 }
 
 func (AuditLog) TableName() string {
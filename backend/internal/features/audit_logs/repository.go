@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type AuditLogRepository struct{}
@@ -17,6 +18,29 @@ func (r *AuditLogRepository) Create(auditLog *AuditLog) error {
 	return storage.GetDb().Create(auditLog).Error
 }
 
+// IncrementRecentRead adds a read to the entry of the same read since the given time, it
+// returns false when there is no such entry
+func (r *AuditLogRepository) IncrementRecentRead(
+	userID uuid.UUID,
+	resourceID uuid.UUID,
+	since time.Time,
+) (bool, error) {
+	result := storage.GetDb().
+		Model(&AuditLog{}).
+		Where(
+			"user_id = ? AND read_resource_id = ? AND created_at > ?",
+			userID,
+			resourceID,
+			since,
+		).
+		UpdateColumn("read_count", gorm.Expr("read_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 func (r *AuditLogRepository) GetGlobal(
 	limit, offset int,
 	beforeDate *time.Time,
@@ -29,6 +53,9 @@ func (r *AuditLogRepository) GetGlobal(
 			al.user_id,
 			al.workspace_id,
 			al.message,
+			al.change_reason,
+			al.ip_address,
+			al.read_count,
 			al.created_at,
 			u.email as user_email,
 			u.name as user_name,
@@ -47,7 +74,7 @@ func (r *AuditLogRepository) GetGlobal(
 	sql += " ORDER BY al.created_at DESC LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
-	err := storage.GetDb().Raw(sql, args...).Scan(&auditLogs).Error
+	err := storage.GetReadDb().Raw(sql, args...).Scan(&auditLogs).Error
 
 	return auditLogs, err
 }
@@ -65,6 +92,9 @@ func (r *AuditLogRepository) GetByUser(
 			al.user_id,
 			al.workspace_id,
 			al.message,
+			al.change_reason,
+			al.ip_address,
+			al.read_count,
 			al.created_at,
 			u.email as user_email,
 			u.name as user_name,
@@ -84,7 +114,7 @@ func (r *AuditLogRepository) GetByUser(
 	sql += " ORDER BY al.created_at DESC LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
-	err := storage.GetDb().Raw(sql, args...).Scan(&auditLogs).Error
+	err := storage.GetReadDb().Raw(sql, args...).Scan(&auditLogs).Error
 
 	return auditLogs, err
 }
@@ -102,6 +132,9 @@ func (r *AuditLogRepository) GetByWorkspace(
 			al.user_id,
 			al.workspace_id,
 			al.message,
+			al.change_reason,
+			al.ip_address,
+			al.read_count,
 			al.created_at,
 			u.email as user_email,
 			u.name as user_name,
@@ -121,14 +154,14 @@ func (r *AuditLogRepository) GetByWorkspace(
 	sql += " ORDER BY al.created_at DESC LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
-	err := storage.GetDb().Raw(sql, args...).Scan(&auditLogs).Error
+	err := storage.GetReadDb().Raw(sql, args...).Scan(&auditLogs).Error
 
 	return auditLogs, err
 }
 
 func (r *AuditLogRepository) CountGlobal(beforeDate *time.Time) (int64, error) {
 	var count int64
-	query := storage.GetDb().Model(&AuditLog{})
+	query := storage.GetReadDb().Model(&AuditLog{})
 
 	if beforeDate != nil {
 		query = query.Where("created_at < ?", *beforeDate)
@@ -10,6 +10,8 @@ import (
 	"github.com/google/uuid"
 )
 
+const readAuditAggregationWindow = time.Hour
+
 type AuditLogService struct {
 	auditLogRepository *AuditLogRepository
 	logger             *slog.Logger
@@ -34,6 +36,92 @@ func (s *AuditLogService) WriteAuditLog(
 	}
 }
 
+// WriteChangeAuditLog records an edit with the reason given for it, an empty reason is
+// not stored
+func (s *AuditLogService) WriteChangeAuditLog(
+	message string,
+	changeReason string,
+	userID *uuid.UUID,
+	workspaceID *uuid.UUID,
+) {
+	auditLog := &AuditLog{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Message:     message,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if changeReason != "" {
+		auditLog.ChangeReason = &changeReason
+	}
+
+	if err := s.auditLogRepository.Create(auditLog); err != nil {
+		s.logger.Error("failed to create audit log", "error", err)
+	}
+}
+
+// WriteClientAuditLog records an action with the client IP it came from, an empty IP is
+// not stored
+func (s *AuditLogService) WriteClientAuditLog(
+	message string,
+	ipAddress string,
+	userID *uuid.UUID,
+	workspaceID *uuid.UUID,
+) {
+	auditLog := &AuditLog{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Message:     message,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if ipAddress != "" {
+		auditLog.IPAddress = &ipAddress
+	}
+
+	if err := s.auditLogRepository.Create(auditLog); err != nil {
+		s.logger.Error("failed to create audit log", "error", err)
+	}
+}
+
+// WriteReadAuditLog records a read of a storage, notifier or database. Reads are aggregated
+// per user and resource over an hour, so opening a resource page repeatedly adds one entry
+func (s *AuditLogService) WriteReadAuditLog(
+	message string,
+	resourceID uuid.UUID,
+	userID uuid.UUID,
+	workspaceID *uuid.UUID,
+) {
+	now := time.Now().UTC()
+
+	isAggregated, err := s.auditLogRepository.IncrementRecentRead(
+		userID,
+		resourceID,
+		now.Add(-readAuditAggregationWindow),
+	)
+	if err != nil {
+		s.logger.Error("failed to aggregate read audit log", "error", err)
+		return
+	}
+
+	if isAggregated {
+		return
+	}
+
+	auditLog := &AuditLog{
+		UserID:         &userID,
+		WorkspaceID:    workspaceID,
+		Message:        message,
+		CreatedAt:      now,
+		ReadResourceID: &resourceID,
+		ReadCount:      1,
+	}
+
+	if err := s.auditLogRepository.Create(auditLog); err != nil {
+		s.logger.Error("failed to create audit log", "error", err)
+	}
+}
+
 func (s *AuditLogService) CreateAuditLog(auditLog *AuditLog) error {
 	return s.auditLogRepository.Create(auditLog)
 }
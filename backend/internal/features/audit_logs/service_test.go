@@ -70,6 +70,32 @@ func Test_AuditLogs_WorkspaceSpecificLogs(t *testing.T) {
 	}
 }
 
+func Test_WriteReadAuditLog_RepeatedReads_AggregatedIntoOneEntry(t *testing.T) {
+	service := GetAuditLogService()
+	user := users_testing.CreateTestUser(user_enums.UserRoleAdmin)
+	workspaceID := uuid.New()
+	storageID, notifierID := uuid.New(), uuid.New()
+
+	service.WriteReadAuditLog("Storage viewed: S3", storageID, user.UserID, &workspaceID)
+	service.WriteReadAuditLog("Storage viewed: S3", storageID, user.UserID, &workspaceID)
+	service.WriteReadAuditLog("Storage viewed: S3", storageID, user.UserID, &workspaceID)
+	service.WriteReadAuditLog("Notifier viewed: Slack", notifierID, user.UserID, &workspaceID)
+
+	response, err := service.GetWorkspaceAuditLogs(
+		workspaceID,
+		&GetAuditLogsRequest{Limit: 10},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(response.AuditLogs))
+
+	readCounts := map[string]int{}
+	for _, log := range response.AuditLogs {
+		readCounts[log.Message] = log.ReadCount
+	}
+	assert.Equal(t, 3, readCounts["Storage viewed: S3"])
+	assert.Equal(t, 1, readCounts["Notifier viewed: Slack"])
+}
+
 func createAuditLog(service *AuditLogService, message string, userID, workspaceID *uuid.UUID) {
 	service.WriteAuditLog(message, userID, workspaceID)
 }
@@ -0,0 +1,106 @@
+package backups_adoption
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BackupAdoptionController struct {
+	backupAdoptionService *BackupAdoptionService
+}
+
+func (c *BackupAdoptionController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/backups/adoption/database/:databaseId/scan", c.ScanBackups)
+	router.POST("/backups/adoption/database/:databaseId/adopt", c.AdoptBackups)
+}
+
+// ScanBackups
+// @Summary Scan storage for existing backups
+// @Description List files of the storage matching the pattern, e.g. dumps written before Databasus. Nothing is changed, adopted files are marked
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Param request body AdoptBackupsRequest true "Storage, directory and pattern"
+// @Success 200 {object} ScanBackupsResponse
+// @Failure 400 {object} map[string]string "Invalid request or storage does not support listing"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Router /backups/adoption/database/{databaseId}/scan [post]
+func (c *BackupAdoptionController) ScanBackups(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request AdoptBackupsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.backupAdoptionService.ScanBackups(user, databaseID, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// AdoptBackups
+// @Summary Adopt existing backups
+// @Description Create backups of the database from matching files not adopted yet, with their date, size and checksum. Files are copied to Databasus names in the background, so backups stay in progress until copied. Original files are kept
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Param request body AdoptBackupsRequest true "Storage, directory and pattern"
+// @Success 200 {object} AdoptBackupsResponse
+// @Failure 400 {object} map[string]string "Invalid request or storage does not support listing"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backups/adoption/database/{databaseId}/adopt [post]
+func (c *BackupAdoptionController) AdoptBackups(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request AdoptBackupsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.backupAdoptionService.AdoptBackups(user, databaseID, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToAdoptBackups) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
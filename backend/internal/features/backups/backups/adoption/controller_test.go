@@ -0,0 +1,91 @@
+package backups_adoption
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetBackupAdoptionController(),
+	)
+}
+
+func Test_AdoptBackups_WhenStorageCannotListFiles_ErrorReturned(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	response := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backups/adoption/database/"+database.ID.String()+"/scan",
+		"Bearer "+owner.Token,
+		AdoptBackupsRequest{StorageID: &storage.ID, Pattern: "*.dump"},
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(response.Body), storages.ErrFileListingNotSupported.Error())
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backups/adoption/database/"+database.ID.String()+"/scan",
+		"Bearer "+owner.Token,
+		AdoptBackupsRequest{StorageID: &storage.ID, Pattern: "[*.dump"},
+		http.StatusBadRequest,
+	)
+}
+
+func Test_AdoptBackups_WhenUserIsViewer_ReturnsForbidden(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backups/adoption/database/"+database.ID.String()+"/adopt",
+		"Bearer "+viewer.Token,
+		AdoptBackupsRequest{StorageID: &storage.ID, Pattern: "*.dump"},
+		http.StatusForbidden,
+	)
+}
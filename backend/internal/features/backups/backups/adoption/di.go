@@ -0,0 +1,36 @@
+package backups_adoption
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var backupAdoptionService = &BackupAdoptionService{
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	backups_config.GetBackupConfigService(),
+	storages.GetStorageService(),
+	encryption_signing.GetSigningService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var backupAdoptionController = &BackupAdoptionController{
+	backupAdoptionService,
+}
+
+func GetBackupAdoptionService() *BackupAdoptionService {
+	return backupAdoptionService
+}
+
+func GetBackupAdoptionController() *BackupAdoptionController {
+	return backupAdoptionController
+}
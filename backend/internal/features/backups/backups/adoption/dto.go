@@ -0,0 +1,30 @@
+package backups_adoption
+
+import (
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/storages"
+
+	"github.com/google/uuid"
+)
+
+type AdoptBackupsRequest struct {
+	// StorageID defaults to the storage of the backup config of the database
+	StorageID *uuid.UUID `json:"storageId"`
+	Directory string     `json:"directory"`
+	// Pattern is matched against names relative to the directory, e.g. "orders-*.dump"
+	Pattern string `json:"pattern" binding:"required"`
+}
+
+type ScannedFile struct {
+	storages.StoredFile
+	IsAdopted bool `json:"isAdopted"`
+}
+
+type ScanBackupsResponse struct {
+	StorageID uuid.UUID     `json:"storageId"`
+	Files     []ScannedFile `json:"files"`
+}
+
+type AdoptBackupsResponse struct {
+	Backups []*backups_core.Backup `json:"backups"`
+}
@@ -0,0 +1,299 @@
+package backups_adoption
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	util_encryption "databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// maxScannedFiles bounds how many files of the directory are listed, directories with
+// more files are scanned by narrower directories
+const maxScannedFiles = 10000
+
+var (
+	ErrInsufficientPermissionsToAdoptBackups = errors.New(
+		"insufficient permissions to adopt backups of this database",
+	)
+	ErrStorageNotSet         = errors.New("storage is not set for the database")
+	ErrStorageNotInWorkspace = errors.New(
+		"storage does not belong to the same workspace as the database",
+	)
+)
+
+type BackupAdoptionService struct {
+	backupRepository    *backups_core.BackupRepository
+	databaseService     *databases.DatabaseService
+	backupConfigService *backups_config.BackupConfigService
+	storageService      *storages.StorageService
+	signingService      *encryption_signing.SigningService
+	workspaceService    *workspaces_services.WorkspaceService
+	auditLogService     *audit_logs.AuditLogService
+	fieldEncryptor      util_encryption.FieldEncryptor
+	logger              *slog.Logger
+}
+
+// ScanBackups lists files of the storage matching the pattern without changing anything
+func (s *BackupAdoptionService) ScanBackups(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	request *AdoptBackupsRequest,
+) (*ScanBackupsResponse, error) {
+	database, err := s.databaseService.GetDatabase(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := s.getStorage(database, request.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.scanFiles(database.ID, storage, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScanBackupsResponse{StorageID: storage.ID, Files: files}, nil
+}
+
+// AdoptBackups creates a backup for each matching file not adopted yet and copies the
+// files to the names Databasus reads in the background. Backups stay in progress until
+// their file is copied, the original files are kept
+func (s *BackupAdoptionService) AdoptBackups(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	request *AdoptBackupsRequest,
+) (*AdoptBackupsResponse, error) {
+	database, err := s.databaseService.GetDatabase(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToAdoptBackups
+	}
+
+	storage, err := s.getStorage(database, request.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.scanFiles(database.ID, storage, request)
+	if err != nil {
+		return nil, err
+	}
+
+	adoptedBackups := make([]*backups_core.Backup, 0)
+	for _, file := range files {
+		if file.IsAdopted {
+			continue
+		}
+
+		fileName := file.Name
+		backup := &backups_core.Backup{
+			DatabaseID:      database.ID,
+			StorageID:       storage.ID,
+			Status:          backups_core.BackupStatusInProgress,
+			Encryption:      backups_config.BackupEncryptionNone,
+			IsSkipRetry:     true,
+			AdoptedFromFile: &fileName,
+			CreatedAt:       file.ModifiedAt,
+		}
+
+		if err := s.backupRepository.Save(backup); err != nil {
+			return nil, err
+		}
+
+		adoptedBackups = append(adoptedBackups, backup)
+	}
+
+	if len(adoptedBackups) > 0 {
+		go s.copyFiles(storage, adoptedBackups)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Adopted %d existing backups of database %s from storage %s",
+			len(adoptedBackups),
+			database.Name,
+			storage.Name,
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return &AdoptBackupsResponse{Backups: adoptedBackups}, nil
+}
+
+func (s *BackupAdoptionService) getStorage(
+	database *databases.Database,
+	storageID *uuid.UUID,
+) (*storages.Storage, error) {
+	if storageID == nil {
+		backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		storageID = backupConfig.StorageID
+	}
+
+	if storageID == nil {
+		return nil, ErrStorageNotSet
+	}
+
+	storage, err := s.storageService.GetStorageByID(*storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !storage.IsVisibleToWorkspace(*database.WorkspaceID) {
+		return nil, ErrStorageNotInWorkspace
+	}
+
+	return storage, nil
+}
+
+func (s *BackupAdoptionService) scanFiles(
+	databaseID uuid.UUID,
+	storage *storages.Storage,
+	request *AdoptBackupsRequest,
+) ([]ScannedFile, error) {
+	if _, err := path.Match(request.Pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	storedFiles, err := storage.ListFiles(s.fieldEncryptor, request.Directory, maxScannedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	adoptedFileNames, err := s.backupRepository.FindAdoptedFileNames(databaseID, storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	directoryPrefix := ""
+	if directory := strings.Trim(request.Directory, "/"); directory != "" {
+		directoryPrefix = directory + "/"
+	}
+
+	files := make([]ScannedFile, 0)
+	for _, storedFile := range storedFiles {
+		isMatched, _ := path.Match(
+			request.Pattern,
+			strings.TrimPrefix(storedFile.Name, directoryPrefix),
+		)
+		if !isMatched {
+			continue
+		}
+
+		files = append(files, ScannedFile{
+			StoredFile: storedFile,
+			IsAdopted:  slices.Contains(adoptedFileNames, storedFile.Name),
+		})
+	}
+
+	return files, nil
+}
+
+func (s *BackupAdoptionService) copyFiles(
+	storage *storages.Storage,
+	backups []*backups_core.Backup,
+) {
+	for _, backup := range backups {
+		startedAt := time.Now().UTC()
+		sizeBytes, checksum, err := s.copyFile(storage, backup)
+
+		backup.BackupDurationMs = time.Since(startedAt).Milliseconds()
+
+		if err != nil {
+			failMessage := err.Error()
+			backup.Status = backups_core.BackupStatusFailed
+			backup.FailMessage = &failMessage
+
+			s.logger.Error(
+				"failed to adopt backup",
+				"backupId", backup.ID,
+				"fileName", *backup.AdoptedFromFile,
+				"error", err,
+			)
+		} else {
+			backup.Status = backups_core.BackupStatusCompleted
+			backup.BackupSizeMb = float64(sizeBytes) / (1024 * 1024)
+			backup.Checksum = &checksum
+
+			signature, err := s.signingService.Sign(backup.GetSignatureMessage())
+			if err != nil {
+				s.logger.Error("failed to sign adopted backup", "backupId", backup.ID, "error", err)
+			} else {
+				backup.Signature = &signature
+			}
+		}
+
+		if err := s.backupRepository.Save(backup); err != nil {
+			s.logger.Error("failed to save adopted backup", "backupId", backup.ID, "error", err)
+		}
+	}
+}
+
+func (s *BackupAdoptionService) copyFile(
+	storage *storages.Storage,
+	backup *backups_core.Backup,
+) (int64, string, error) {
+	file, err := storage.GetFileByName(s.fieldEncryptor, *backup.AdoptedFromFile)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	counter := &hashingWriter{hash: sha256.New()}
+
+	if err := storage.SaveFile(
+		context.Background(),
+		s.fieldEncryptor,
+		s.logger,
+		backup.ID,
+		io.TeeReader(file, counter),
+	); err != nil {
+		return 0, "", fmt.Errorf("failed to copy file: %w", err)
+	}
+
+	return counter.sizeBytes, hex.EncodeToString(counter.hash.Sum(nil)), nil
+}
+
+// hashingWriter hashes and counts bytes of the copied file
+type hashingWriter struct {
+	hash      hash.Hash
+	sizeBytes int64
+}
+
+func (w *hashingWriter) Write(p []byte) (int, error) {
+	w.sizeBytes += int64(len(p))
+	return w.hash.Write(p)
+}
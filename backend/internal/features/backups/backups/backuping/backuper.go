@@ -14,13 +14,19 @@ import (
 	"github.com/google/uuid"
 
 	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
 	tasks_cancellation "databasus-backend/internal/features/tasks/cancellation"
+	task_watchdog "databasus-backend/internal/features/tasks/watchdog"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	util_encryption "databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/errortracking"
+	"databasus-backend/internal/util/i18n"
 )
 
 const (
@@ -29,18 +35,24 @@ const (
 )
 
 type BackuperNode struct {
-	databaseService     *databases.DatabaseService
-	fieldEncryptor      util_encryption.FieldEncryptor
-	workspaceService    *workspaces_services.WorkspaceService
-	backupRepository    *backups_core.BackupRepository
-	backupConfigService *backups_config.BackupConfigService
-	storageService      *storages.StorageService
-	notificationSender  backups_core.NotificationSender
-	backupCancelManager *tasks_cancellation.TaskCancelManager
-	backupNodesRegistry *BackupNodesRegistry
-	logger              *slog.Logger
-	createBackupUseCase backups_core.CreateBackupUsecase
-	nodeID              uuid.UUID
+	databaseService        *databases.DatabaseService
+	fieldEncryptor         util_encryption.FieldEncryptor
+	workspaceService       *workspaces_services.WorkspaceService
+	backupRepository       *backups_core.BackupRepository
+	backupRunLogRepository *backups_core.BackupRunLogRepository
+	backupConfigService    *backups_config.BackupConfigService
+	storageService         *storages.StorageService
+	signingService         *encryption_signing.SigningService
+	notificationSender     backups_core.NotificationSender
+	ownerNotifier          backups_core.BackupFailureOwnerNotifier
+	completedListeners     []backups_core.BackupCompletedListener
+	storageSelector        backups_core.BackupStorageSelector
+	backupCancelManager    *tasks_cancellation.TaskCancelManager
+	backupNodesRegistry    *BackupNodesRegistry
+	backupLogRelay         *BackupLogRelay
+	logger                 *slog.Logger
+	createBackupUseCase    backups_core.CreateBackupUsecase
+	nodeID                 uuid.UUID
 
 	lastHeartbeat time.Time
 
@@ -121,6 +133,16 @@ func (n *BackuperNode) Run(ctx context.Context) {
 	}
 }
 
+func (n *BackuperNode) AddBackupCompletedListener(
+	listener backups_core.BackupCompletedListener,
+) {
+	n.completedListeners = append(n.completedListeners, listener)
+}
+
+func (n *BackuperNode) SetBackupStorageSelector(selector backups_core.BackupStorageSelector) {
+	n.storageSelector = selector
+}
+
 func (n *BackuperNode) IsBackuperRunning() bool {
 	return n.lastHeartbeat.After(time.Now().UTC().Add(-backuperHeathcheckThreshold))
 }
@@ -157,15 +179,48 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 		return
 	}
 
+	if n.storageSelector != nil {
+		selectedStorage := n.storageSelector.SelectBackupStorage(n.nodeID, database, storage)
+		if selectedStorage.ID != storage.ID {
+			backup.StorageID = selectedStorage.ID
+			if err := n.backupRepository.Save(backup); err != nil {
+				n.logger.Error(
+					"Failed to save selected storage",
+					"backupId", backup.ID,
+					"error", err,
+				)
+				return
+			}
+
+			storage = selectedStorage
+		}
+	}
+
+	if err := n.nameBackupFile(backup, backupConfig, database, storage); err != nil {
+		n.logger.Error("Failed to name backup file", "backupId", backup.ID, "error", err)
+		return
+	}
+
 	start := time.Now().UTC()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	n.backupCancelManager.RegisterTask(backup.ID, cancel)
 	defer n.backupCancelManager.UnregisterTask(backup.ID)
+	defer cancel()
+
+	watchdog := task_watchdog.NewTaskWatchdog(
+		backupConfig.GetBackupTimeout(),
+		backupConfig.GetHangTimeout(),
+	)
+	go watchdog.Watch(ctx, cancel)
 
 	backupProgressListener := func(
 		completedMBs float64,
 	) {
+		if completedMBs > backup.BackupSizeMb {
+			watchdog.ReportProgress()
+		}
+
 		backup.BackupSizeMb = completedMBs
 		backup.BackupDurationMs = time.Since(start).Milliseconds()
 
@@ -194,14 +249,34 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 		}
 	}
 
-	backupMetadata, err := n.createBackupUseCase.Execute(
-		ctx,
-		backup.ID,
-		backupConfig,
-		database,
-		storage,
-		backupProgressListener,
-	)
+	runRecorder := common.NewBackupRunRecorder()
+	runRecorder.SetLogListener(func(line string) {
+		n.backupLogRelay.PublishLine(backup.ID, line)
+	})
+
+	restoreHost := func() {}
+	err = database.ApplyFreshCredentials(n.fieldEncryptor)
+	if err == nil {
+		restoreHost, err = database.ApplyDNSOverride(ctx)
+	}
+
+	var backupMetadata *common.BackupMetadata
+	if err == nil {
+		backupMetadata, err = n.createBackupUseCase.Execute(
+			ctx,
+			backup.ID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+	}
+	restoreHost()
+
+	n.saveRunLog(backup.ID, runRecorder)
+	n.backupLogRelay.PublishEnd(backup.ID)
+
 	if err != nil {
 		// Check if backup was already marked as failed by progress listener (e.g., size limit exceeded)
 		// If so, skip error handling to avoid overwriting the status
@@ -226,6 +301,12 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 			return
 		}
 
+		// Killed by the watchdog, the failure is retried unless the backup timed out
+		if abortErr := watchdog.GetAbortError(); abortErr != nil {
+			err = fmt.Errorf("backup aborted: %w", abortErr)
+			backup.IsSkipRetry = errors.Is(abortErr, task_watchdog.ErrTaskTimedOut)
+		}
+
 		errMsg := err.Error()
 
 		// Log detailed error information for debugging
@@ -263,6 +344,7 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 			// Delete partial backup from storage
 			storage, storageErr := n.storageService.GetStorageByID(backup.StorageID)
 			if storageErr == nil {
+				storage.BindFileName(backup.ID, backup.FileName)
 				if deleteErr := storage.DeleteFile(n.fieldEncryptor, backup.ID); deleteErr != nil {
 					n.logger.Error(
 						"Failed to delete partial backup file",
@@ -277,6 +359,16 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 			return
 		}
 
+		// Backups interrupted by shutdown are expected and would only flood the tracker
+		if !isShutdown {
+			errortracking.GetErrorTracker().CaptureError(err, map[string]string{
+				"backup_id":     backup.ID.String(),
+				"database_id":   databaseID.String(),
+				"database_type": string(database.Type),
+				"storage_type":  string(storage.Type),
+			})
+		}
+
 		backup.FailMessage = &errMsg
 		backup.Status = backups_core.BackupStatusFailed
 		backup.BackupDurationMs = time.Since(start).Milliseconds()
@@ -316,11 +408,20 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 		backup.Encryption = backupMetadata.Encryption
 	}
 
+	if contentHash := storage.GetSavedContentHash(backup.ID); contentHash != nil {
+		backup.Checksum = contentHash
+		n.signBackup(backup)
+	}
+
 	if err := n.backupRepository.Save(backup); err != nil {
 		n.logger.Error("Failed to save backup", "error", err)
 		return
 	}
 
+	for _, listener := range n.completedListeners {
+		listener.OnBackupCompleted(backup)
+	}
+
 	// Update database last backup time
 	now := time.Now().UTC()
 	if updateErr := n.databaseService.SetLastBackupTime(databaseID, now); updateErr != nil {
@@ -345,6 +446,18 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 	)
 }
 
+// signBackup signs the file of the backup. Backups are kept when signing fails, restores
+// check only the backups having a signature
+func (n *BackuperNode) signBackup(backup *backups_core.Backup) {
+	signature, err := n.signingService.Sign(backup.GetSignatureMessage())
+	if err != nil {
+		n.logger.Error("Failed to sign backup", "backupId", backup.ID, "error", err)
+		return
+	}
+
+	backup.Signature = &signature
+}
+
 func (n *BackuperNode) SendBackupNotification(
 	backupConfig *backups_config.BackupConfig,
 	backup *backups_core.Backup,
@@ -369,22 +482,18 @@ func (n *BackuperNode) SendBackupNotification(
 			continue
 		}
 
-		title := ""
-		switch notificationType {
-		case backups_config.NotificationBackupFailed:
-			title = fmt.Sprintf(
-				"❌ Backup failed for database \"%s\" (workspace \"%s\")",
-				database.Name,
-				workspace.Name,
-			)
-		case backups_config.NotificationBackupSuccess:
-			title = fmt.Sprintf(
-				"✅ Backup completed for database \"%s\" (workspace \"%s\")",
-				database.Name,
-				workspace.Name,
-			)
+		titleKey := i18n.MessageBackupSuccessTitle
+		eventType := notifiers.NotificationEventBackupSuccess
+		if notificationType == backups_config.NotificationBackupFailed {
+			titleKey = i18n.MessageBackupFailedTitle
+			eventType = notifiers.NotificationEventBackupFailed
 		}
 
+		title := i18n.Translate(notifier.Locale, titleKey, map[string]string{
+			"database":  database.Name,
+			"workspace": workspace.Name,
+		})
+
 		message := ""
 		if errorMessage != nil {
 			message = *errorMessage
@@ -404,19 +513,40 @@ func (n *BackuperNode) SendBackupNotification(
 			seconds := (totalMs % (1000 * 60)) / 1000
 			durationStr := fmt.Sprintf("%dm %ds", minutes, seconds)
 
-			message = fmt.Sprintf(
-				"Backup completed successfully in %s.\nCompressed backup size: %s",
-				durationStr,
-				sizeStr,
+			message = i18n.Translate(
+				notifier.Locale,
+				i18n.MessageBackupSuccessMessage,
+				map[string]string{
+					"duration": durationStr,
+					"size":     sizeStr,
+				},
 			)
 		}
 
-		n.notificationSender.SendNotification(
+		n.notificationSender.SendEventNotification(
 			&notifier,
+			eventType,
 			title,
 			message,
 		)
 	}
+
+	if notificationType == backups_config.NotificationBackupFailed &&
+		database.OwnerUserID != nil {
+		message := ""
+		if errorMessage != nil {
+			message = *errorMessage
+		}
+
+		if err := n.ownerNotifier.NotifyOwnerAboutBackupFailure(
+			*database.OwnerUserID,
+			database.Name,
+			workspace.Name,
+			message,
+		); err != nil {
+			n.logger.Error("Failed to notify database owner about backup failure", "error", err)
+		}
+	}
 }
 
 func (n *BackuperNode) sendHeartbeat(backupNode *BackupNode) {
@@ -425,3 +555,65 @@ func (n *BackuperNode) sendHeartbeat(backupNode *BackupNode) {
 		n.logger.Error("Failed to send heartbeat", "error", err)
 	}
 }
+
+// saveRunLog persists telemetry of cancelled and failed runs as well, because those are
+// the runs someone actually needs to diagnose
+func (n *BackuperNode) saveRunLog(backupID uuid.UUID, runRecorder *common.BackupRunRecorder) {
+	runLog := &backups_core.BackupRunLog{
+		BackupID:       backupID,
+		Phases:         runRecorder.GetPhases(),
+		ToolOutputTail: runRecorder.GetToolOutputTail(),
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	if err := n.backupRunLogRepository.Save(runLog); err != nil {
+		n.logger.Error("Failed to save backup run log", "backupId", backupID, "error", err)
+	}
+}
+
+// nameBackupFile renders the naming template of the backup config or storage into the file
+// name of the backup. Agents uploading the dump read the name from the saved backup
+func (n *BackuperNode) nameBackupFile(
+	backup *backups_core.Backup,
+	backupConfig *backups_config.BackupConfig,
+	database *databases.Database,
+	storage *storages.Storage,
+) error {
+	template := backupConfig.GetFileNameTemplate(storage)
+	if template == "" {
+		return nil
+	}
+
+	workspaceName := ""
+	if database.WorkspaceID != nil {
+		workspace, err := n.workspaceService.GetWorkspaceByID(*database.WorkspaceID)
+		if err != nil {
+			return err
+		}
+
+		workspaceName = workspace.Name
+	}
+
+	backupType := "scheduled"
+	if backup.IsManual {
+		backupType = "manual"
+	}
+
+	fileName := storages.RenderFileName(template, storages.FileNameVariables{
+		Workspace:  workspaceName,
+		Database:   database.Name,
+		Engine:     strings.ToLower(string(database.Type)),
+		BackupType: backupType,
+		CreatedAt:  backup.CreatedAt,
+		FileID:     backup.ID,
+	})
+
+	backup.FileName = &fileName
+	if err := n.backupRepository.Save(backup); err != nil {
+		return err
+	}
+
+	storage.BindFileName(backup.ID, backup.FileName)
+
+	return nil
+}
@@ -60,8 +60,9 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Set up expectations
-		mockNotificationSender.On("SendNotification",
+		mockNotificationSender.On("SendEventNotification",
 			mock.Anything,
+			notifiers.NotificationEventBackupFailed,
 			mock.MatchedBy(func(title string) bool {
 				return strings.Contains(title, "❌ Backup failed")
 			}),
@@ -93,8 +94,9 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Set up expectations
-		mockNotificationSender.On("SendNotification",
+		mockNotificationSender.On("SendEventNotification",
 			mock.Anything,
+			notifiers.NotificationEventBackupSuccess,
 			mock.MatchedBy(func(title string) bool {
 				return strings.Contains(title, "✅ Backup completed")
 			}),
@@ -130,14 +132,15 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 		var capturedTitle string
 		var capturedMessage string
 
-		mockNotificationSender.On("SendNotification",
+		mockNotificationSender.On("SendEventNotification",
+			mock.Anything,
 			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 		).Run(func(args mock.Arguments) {
 			capturedNotifier = args.Get(0).(*notifiers.Notifier)
-			capturedTitle = args.Get(1).(string)
-			capturedMessage = args.Get(2).(string)
+			capturedTitle = args.Get(2).(string)
+			capturedMessage = args.Get(3).(string)
 		}).Once()
 
 		backuperNode.MakeBackup(backup.ID, true)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -68,18 +70,44 @@ func (c *BackupCleaner) Run(ctx context.Context) {
 }
 
 func (c *BackupCleaner) DeleteBackup(backup *backups_core.Backup) error {
-	for _, listener := range c.backupRemoveListeners {
-		if err := listener.OnBeforeBackupRemove(backup); err != nil {
+	return c.DeleteBackups([]*backups_core.Backup{backup})
+}
+
+// DeleteBackups loads storages of all backups with one query instead of one per backup,
+// which matters when a database with hundreds of backups is removed. It stops on the
+// first error, like deleting backups one by one did
+func (c *BackupCleaner) DeleteBackups(backups []*backups_core.Backup) error {
+	storagesByID, err := c.getBackupsStorages(backups)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups {
+		if err := c.deleteBackup(backup, storagesByID[backup.StorageID]); err != nil {
 			return err
 		}
 	}
 
-	storage, err := c.storageService.GetStorageByID(backup.StorageID)
-	if err != nil {
-		return err
+	return nil
+}
+
+func (c *BackupCleaner) AddBackupRemoveListener(listener backups_core.BackupRemoveListener) {
+	c.backupRemoveListeners = append(c.backupRemoveListeners, listener)
+}
+
+func (c *BackupCleaner) deleteBackup(backup *backups_core.Backup, storage *storages.Storage) error {
+	if storage == nil {
+		return fmt.Errorf("storage %s of backup %s not found", backup.StorageID, backup.ID)
+	}
+
+	for _, listener := range c.backupRemoveListeners {
+		if err := listener.OnBeforeBackupRemove(backup); err != nil {
+			return err
+		}
 	}
 
-	err = storage.DeleteFile(c.fieldEncryptor, backup.ID)
+	storage.BindFileName(backup.ID, backup.FileName)
+	err := storage.DeleteFile(c.fieldEncryptor, backup.ID)
 	if err != nil {
 		// we do not return error here, because sometimes clean up performed
 		// before unavailable storage removal or change - therefore we should
@@ -91,10 +119,6 @@ func (c *BackupCleaner) DeleteBackup(backup *backups_core.Backup) error {
 	return c.backupRepository.DeleteByID(backup.ID)
 }
 
-func (c *BackupCleaner) AddBackupRemoveListener(listener backups_core.BackupRemoveListener) {
-	c.backupRemoveListeners = append(c.backupRemoveListeners, listener)
-}
-
 func (c *BackupCleaner) cleanOldBackups() error {
 	enabledBackupConfigs, err := c.backupConfigService.GetBackupConfigsWithEnabledBackups()
 	if err != nil {
@@ -126,8 +150,19 @@ func (c *BackupCleaner) cleanOldBackups() error {
 			continue
 		}
 
+		storagesByID, err := c.getBackupsStorages(oldBackups)
+		if err != nil {
+			c.logger.Error("Failed to get storages of old backups", "error", err)
+			continue
+		}
+
+		now := time.Now().UTC()
 		for _, backup := range oldBackups {
-			if err := c.DeleteBackup(backup); err != nil {
+			if backupConfig.IsBackupHeld(backup.Tags, backup.CreatedAt, now) {
+				continue
+			}
+
+			if err := c.deleteBackup(backup, storagesByID[backup.StorageID]); err != nil {
 				c.logger.Error("Failed to delete old backup", "backupId", backup.ID, "error", err)
 				continue
 			}
@@ -156,10 +191,7 @@ func (c *BackupCleaner) cleanExceededBackups() error {
 			continue
 		}
 
-		if err := c.cleanExceededBackupsForDatabase(
-			backupConfig.DatabaseID,
-			backupConfig.MaxBackupsTotalSizeMB,
-		); err != nil {
+		if err := c.cleanExceededBackupsForDatabase(backupConfig); err != nil {
 			c.logger.Error(
 				"Failed to clean exceeded backups for database",
 				"databaseId",
@@ -174,10 +206,14 @@ func (c *BackupCleaner) cleanExceededBackups() error {
 	return nil
 }
 
+// cleanExceededBackupsForDatabase deletes the oldest backups until the total size fits the
+// limit. Backups under retention holds are kept even if the limit stays exceeded
 func (c *BackupCleaner) cleanExceededBackupsForDatabase(
-	databaseID uuid.UUID,
-	limitperDbMB int64,
+	backupConfig *backups_config.BackupConfig,
 ) error {
+	databaseID := backupConfig.DatabaseID
+	limitperDbMB := backupConfig.MaxBackupsTotalSizeMB
+
 	for {
 		backupsTotalSizeMB, err := c.backupRepository.GetTotalSizeByDatabase(databaseID)
 		if err != nil {
@@ -188,15 +224,12 @@ func (c *BackupCleaner) cleanExceededBackupsForDatabase(
 			break
 		}
 
-		oldestBackups, err := c.backupRepository.FindOldestByDatabaseExcludingInProgress(
-			databaseID,
-			1,
-		)
+		oldestBackup, err := c.findOldestNotHeldBackup(backupConfig)
 		if err != nil {
 			return err
 		}
 
-		if len(oldestBackups) == 0 {
+		if oldestBackup == nil {
 			c.logger.Warn(
 				"No backups to delete but still over limit",
 				"databaseId",
@@ -209,7 +242,7 @@ func (c *BackupCleaner) cleanExceededBackupsForDatabase(
 			break
 		}
 
-		backup := oldestBackups[0]
+		backup := oldestBackup
 		if err := c.DeleteBackup(backup); err != nil {
 			c.logger.Error(
 				"Failed to delete exceeded backup",
@@ -240,3 +273,49 @@ func (c *BackupCleaner) cleanExceededBackupsForDatabase(
 
 	return nil
 }
+
+func (c *BackupCleaner) findOldestNotHeldBackup(
+	backupConfig *backups_config.BackupConfig,
+) (*backups_core.Backup, error) {
+	if len(backupConfig.RetentionHolds) == 0 {
+		oldestBackups, err := c.backupRepository.FindOldestByDatabaseExcludingInProgress(
+			backupConfig.DatabaseID,
+			1,
+		)
+		if err != nil || len(oldestBackups) == 0 {
+			return nil, err
+		}
+
+		return oldestBackups[0], nil
+	}
+
+	oldestBackups, err := c.backupRepository.FindOldestByDatabaseExcludingInProgress(
+		backupConfig.DatabaseID,
+		math.MaxInt32,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, backup := range oldestBackups {
+		if !backupConfig.IsBackupHeld(backup.Tags, backup.CreatedAt, now) {
+			return backup, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *BackupCleaner) getBackupsStorages(
+	backups []*backups_core.Backup,
+) (map[uuid.UUID]*storages.Storage, error) {
+	storageIDs := make([]uuid.UUID, 0, 1)
+	for _, backup := range backups {
+		if !slices.Contains(storageIDs, backup.StorageID) {
+			storageIDs = append(storageIDs, backup.StorageID)
+		}
+	}
+
+	return c.storageService.GetStoragesByIDs(storageIDs)
+}
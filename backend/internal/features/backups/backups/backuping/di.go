@@ -11,9 +11,11 @@ import (
 	"databasus-backend/internal/features/backups/backups/usecases"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
 	tasks_cancellation "databasus-backend/internal/features/tasks/cancellation"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	cache_utils "databasus-backend/internal/util/cache"
 	"databasus-backend/internal/util/encryption"
@@ -22,6 +24,10 @@ import (
 
 var backupRepository = &backups_core.BackupRepository{}
 
+var backupDeadLetterRepository = &backups_core.BackupDeadLetterRepository{}
+
+var backupRunLogRepository = &backups_core.BackupRunLogRepository{}
+
 var taskCancelManager = tasks_cancellation.GetTaskCancelManager()
 
 var backupCleaner = &BackupCleaner{
@@ -45,39 +51,51 @@ var backupNodesRegistry = &BackupNodesRegistry{
 	hasRun:            atomic.Bool{},
 }
 
+var backupLogRelay = &BackupLogRelay{
+	client:  cache_utils.GetValkeyClient(),
+	logger:  logger.GetLogger(),
+	timeout: cache_utils.DefaultCacheTimeout,
+}
+
 func getNodeID() uuid.UUID {
 	return uuid.New()
 }
 
 var backuperNode = &BackuperNode{
-	databaseService:     databases.GetDatabaseService(),
-	fieldEncryptor:      encryption.GetFieldEncryptor(),
-	workspaceService:    workspaces_services.GetWorkspaceService(),
-	backupRepository:    backupRepository,
-	backupConfigService: backups_config.GetBackupConfigService(),
-	storageService:      storages.GetStorageService(),
-	notificationSender:  notifiers.GetNotifierService(),
-	backupCancelManager: taskCancelManager,
-	backupNodesRegistry: backupNodesRegistry,
-	logger:              logger.GetLogger(),
-	createBackupUseCase: usecases.GetCreateBackupUsecase(),
-	nodeID:              getNodeID(),
-	lastHeartbeat:       time.Time{},
-	runOnce:             sync.Once{},
-	hasRun:              atomic.Bool{},
+	databaseService:        databases.GetDatabaseService(),
+	fieldEncryptor:         encryption.GetFieldEncryptor(),
+	workspaceService:       workspaces_services.GetWorkspaceService(),
+	backupRepository:       backupRepository,
+	backupRunLogRepository: backupRunLogRepository,
+	backupConfigService:    backups_config.GetBackupConfigService(),
+	storageService:         storages.GetStorageService(),
+	signingService:         encryption_signing.GetSigningService(),
+	notificationSender:     notifiers.GetNotifierService(),
+	ownerNotifier:          users_services.GetUserService(),
+	completedListeners:     []backups_core.BackupCompletedListener{},
+	backupCancelManager:    taskCancelManager,
+	backupNodesRegistry:    backupNodesRegistry,
+	backupLogRelay:         backupLogRelay,
+	logger:                 logger.GetLogger(),
+	createBackupUseCase:    usecases.GetCreateBackupUsecase(),
+	nodeID:                 getNodeID(),
+	lastHeartbeat:          time.Time{},
+	runOnce:                sync.Once{},
+	hasRun:                 atomic.Bool{},
 }
 
 var backupsScheduler = &BackupsScheduler{
-	backupRepository:      backupRepository,
-	backupConfigService:   backups_config.GetBackupConfigService(),
-	taskCancelManager:     taskCancelManager,
-	backupNodesRegistry:   backupNodesRegistry,
-	lastBackupTime:        time.Now().UTC(),
-	logger:                logger.GetLogger(),
-	backupToNodeRelations: make(map[uuid.UUID]BackupToNodeRelation),
-	backuperNode:          backuperNode,
-	runOnce:               sync.Once{},
-	hasRun:                atomic.Bool{},
+	backupRepository:           backupRepository,
+	backupDeadLetterRepository: backupDeadLetterRepository,
+	backupConfigService:        backups_config.GetBackupConfigService(),
+	taskCancelManager:          taskCancelManager,
+	backupNodesRegistry:        backupNodesRegistry,
+	lastBackupTime:             time.Now().UTC(),
+	logger:                     logger.GetLogger(),
+	backupToNodeRelations:      make(map[uuid.UUID]BackupToNodeRelation),
+	backuperNode:               backuperNode,
+	runOnce:                    sync.Once{},
+	hasRun:                     atomic.Bool{},
 }
 
 func GetBackupsScheduler() *BackupsScheduler {
@@ -92,6 +110,10 @@ func GetBackupNodesRegistry() *BackupNodesRegistry {
 	return backupNodesRegistry
 }
 
+func GetBackupLogRelay() *BackupLogRelay {
+	return backupLogRelay
+}
+
 func GetBackupCleaner() *BackupCleaner {
 	return backupCleaner
 }
@@ -32,3 +32,19 @@ type BackupCompletionMessage struct {
 	NodeID   uuid.UUID `json:"nodeId"`
 	BackupID uuid.UUID `json:"backupId"`
 }
+
+// ScheduleLoadBucket counts backups projected to start and to be running within the bucket
+type ScheduleLoadBucket struct {
+	StartsAt        time.Time `json:"startsAt"`
+	StartingBackups int       `json:"startingBackups"`
+	RunningBackups  int       `json:"runningBackups"`
+	BackupsPerNode  float64   `json:"backupsPerNode"`
+}
+
+type ScheduleLoadProjection struct {
+	From               time.Time            `json:"from"`
+	To                 time.Time            `json:"to"`
+	NodesCount         int                  `json:"nodesCount"`
+	PeakRunningBackups int                  `json:"peakRunningBackups"`
+	Buckets            []ScheduleLoadBucket `json:"buckets"`
+}
@@ -0,0 +1,139 @@
+package backuping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+
+	cache_utils "databasus-backend/internal/util/cache"
+)
+
+const (
+	backupLogChannelPrefix = "backup:logs:"
+	backupLogHistoryPrefix = "backup:logs:history:"
+	backupLogSeqPrefix     = "backup:logs:seq:"
+	backupLogMaxHistory    = 1000
+	backupLogHistoryExpiry = 24 * time.Hour
+)
+
+type BackupLogEntry struct {
+	Seq   int64     `json:"seq"`
+	Line  string    `json:"line"`
+	Time  time.Time `json:"time"`
+	IsEnd bool      `json:"isEnd"`
+}
+
+// BackupLogRelay moves live logs from the node running a backup to whichever node serves
+// the API request following it. History is kept next to the channel because pub/sub does
+// not replay anything to subscribers that join mid-run
+type BackupLogRelay struct {
+	client  valkey.Client
+	logger  *slog.Logger
+	timeout time.Duration
+}
+
+func (r *BackupLogRelay) PublishLine(backupID uuid.UUID, line string) {
+	r.publish(backupID, line, false)
+}
+
+func (r *BackupLogRelay) PublishEnd(backupID uuid.UUID) {
+	r.publish(backupID, "", true)
+}
+
+func (r *BackupLogRelay) GetHistory(backupID uuid.UUID) ([]BackupLogEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	messages, err := r.client.Do(
+		ctx,
+		r.client.B().Lrange().Key(backupLogHistoryPrefix+backupID.String()).Start(0).Stop(-1).Build(),
+	).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup log history: %w", err)
+	}
+
+	entries := make([]BackupLogEntry, 0, len(messages))
+	for _, message := range messages {
+		var entry BackupLogEntry
+		if err := json.Unmarshal([]byte(message), &entry); err != nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Follow delivers new entries until ctx is cancelled. Each follower gets its own pub/sub
+// manager because the shared one allows only a single subscription per channel
+func (r *BackupLogRelay) Follow(
+	ctx context.Context,
+	backupID uuid.UUID,
+	handler func(entry BackupLogEntry),
+) (func(), error) {
+	pubsub := cache_utils.NewPubSubManager()
+
+	err := pubsub.Subscribe(ctx, backupLogChannelPrefix+backupID.String(), func(message string) {
+		var entry BackupLogEntry
+		if err := json.Unmarshal([]byte(message), &entry); err != nil {
+			r.logger.Warn("Failed to unmarshal backup log entry", "error", err)
+			return
+		}
+
+		handler(entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() { _ = pubsub.Close() }, nil
+}
+
+func (r *BackupLogRelay) publish(backupID uuid.UUID, line string, isEnd bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	seqKey := backupLogSeqPrefix + backupID.String()
+	historyKey := backupLogHistoryPrefix + backupID.String()
+
+	seq, err := r.client.Do(ctx, r.client.B().Incr().Key(seqKey).Build()).AsInt64()
+	if err != nil {
+		r.logger.Error("Failed to allocate backup log sequence", "backupId", backupID, "error", err)
+		return
+	}
+
+	message, err := json.Marshal(BackupLogEntry{
+		Seq:   seq,
+		Line:  line,
+		Time:  time.Now().UTC(),
+		IsEnd: isEnd,
+	})
+	if err != nil {
+		return
+	}
+
+	expirySeconds := int64(backupLogHistoryExpiry.Seconds())
+
+	for _, result := range r.client.DoMulti(
+		ctx,
+		r.client.B().Rpush().Key(historyKey).Element(string(message)).Build(),
+		r.client.B().Ltrim().Key(historyKey).Start(-backupLogMaxHistory).Stop(-1).Build(),
+		r.client.B().Expire().Key(historyKey).Seconds(expirySeconds).Build(),
+		r.client.B().Expire().Key(seqKey).Seconds(expirySeconds).Build(),
+		r.client.B().Publish().
+			Channel(backupLogChannelPrefix+backupID.String()).
+			Message(string(message)).
+			Build(),
+	) {
+		if err := result.Error(); err != nil {
+			r.logger.Error("Failed to relay backup log line", "backupId", backupID, "error", err)
+			return
+		}
+	}
+}
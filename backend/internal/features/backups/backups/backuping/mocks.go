@@ -20,12 +20,13 @@ type MockNotificationSender struct {
 	mock.Mock
 }
 
-func (m *MockNotificationSender) SendNotification(
+func (m *MockNotificationSender) SendEventNotification(
 	notifier *notifiers.Notifier,
+	eventType notifiers.NotificationEventType,
 	title string,
 	message string,
 ) {
-	m.Called(notifier, title, message)
+	m.Called(notifier, eventType, title, message)
 }
 
 type CreateFailedBackupUsecase struct{}
@@ -37,6 +38,7 @@ func (uc *CreateFailedBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	backupProgressListener(10)
 	return nil, errors.New("backup failed")
@@ -51,6 +53,7 @@ func (uc *CreateSuccessBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	backupProgressListener(10)
 	return &common.BackupMetadata{
@@ -70,6 +73,7 @@ func (uc *CreateLargeBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	backupProgressListener(10000)
 	return &common.BackupMetadata{
@@ -89,6 +93,7 @@ func (uc *CreateProgressiveBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	// Simulate progressive backup that grows beyond limit
 	backupProgressListener(1)
@@ -129,6 +134,7 @@ func (uc *CreateMediumBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	backupProgressListener(50)
 	return &common.BackupMetadata{
@@ -157,6 +163,7 @@ func (m *MockTrackingBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	m.callCount.Add(1)
 
@@ -0,0 +1,138 @@
+package backuping
+
+import (
+	"fmt"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+
+	"github.com/google/uuid"
+)
+
+const (
+	projectionPeriod         = 24 * time.Hour
+	projectionBucketDuration = 30 * time.Minute
+
+	// defaultProjectedDuration is used for databases without a finished backup yet
+	defaultProjectedDuration = 5 * time.Minute
+)
+
+// ProjectedRun is a scheduled backup of the database expected to run between the times
+type ProjectedRun struct {
+	DatabaseID uuid.UUID
+	StartsAt   time.Time
+	EndsAt     time.Time
+}
+
+// ProjectLoad buckets backups projected over the next 24h by the time they start and run
+func (s *BackupsScheduler) ProjectLoad(now time.Time) (*ScheduleLoadProjection, error) {
+	enabledBackupConfigs, err := s.backupConfigService.GetBackupConfigsWithEnabledBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.backupNodesRegistry.GetAvailableNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available nodes: %w", err)
+	}
+
+	projectionEnd := now.Add(projectionPeriod)
+
+	runs, err := s.ProjectRuns(enabledBackupConfigs, now, projectionEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := &ScheduleLoadProjection{
+		From:       now,
+		To:         projectionEnd,
+		NodesCount: len(nodes),
+		Buckets:    make([]ScheduleLoadBucket, 0),
+	}
+
+	for i := range int(projectionPeriod / projectionBucketDuration) {
+		bucketStart := now.Add(time.Duration(i) * projectionBucketDuration)
+		bucketEnd := bucketStart.Add(projectionBucketDuration)
+		bucket := ScheduleLoadBucket{StartsAt: bucketStart}
+
+		for _, run := range runs {
+			if !run.StartsAt.Before(bucketStart) && run.StartsAt.Before(bucketEnd) {
+				bucket.StartingBackups++
+			}
+
+			if run.StartsAt.Before(bucketEnd) && run.EndsAt.After(bucketStart) {
+				bucket.RunningBackups++
+			}
+		}
+
+		if len(nodes) > 0 {
+			bucket.BackupsPerNode = float64(bucket.RunningBackups) / float64(len(nodes))
+		}
+
+		projection.PeakRunningBackups = max(projection.PeakRunningBackups, bucket.RunningBackups)
+		projection.Buckets = append(projection.Buckets, bucket)
+	}
+
+	return projection, nil
+}
+
+// ProjectRuns replays the scheduler over the period with its own tick. Each backup is
+// expected to take as long as the last one of its database, retries are not projected
+func (s *BackupsScheduler) ProjectRuns(
+	backupConfigs []*backups_config.BackupConfig,
+	from time.Time,
+	to time.Time,
+) ([]ProjectedRun, error) {
+	databaseIDs := make([]uuid.UUID, 0, len(backupConfigs))
+	for _, backupConfig := range backupConfigs {
+		databaseIDs = append(databaseIDs, backupConfig.DatabaseID)
+	}
+
+	lastBackups, err := s.backupRepository.FindLastByDatabaseIDs(databaseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last backups: %w", err)
+	}
+
+	runs := make([]ProjectedRun, 0)
+
+	for _, backupConfig := range backupConfigs {
+		if backupConfig.BackupInterval == nil {
+			continue
+		}
+
+		lastBackup := lastBackups[backupConfig.DatabaseID]
+		duration := getProjectedDuration(lastBackup)
+
+		var lastBackupTime *time.Time
+		if lastBackup != nil {
+			lastBackupTime = &lastBackup.CreatedAt
+		}
+
+		for tick := from; tick.Before(to); tick = tick.Add(schedulerTickerInterval) {
+			if backupConfig.IsSchedulePausedAt(tick) ||
+				!backupConfig.ShouldTriggerBackup(tick, lastBackupTime) {
+				continue
+			}
+
+			runs = append(runs, ProjectedRun{
+				DatabaseID: backupConfig.DatabaseID,
+				StartsAt:   tick,
+				EndsAt:     tick.Add(duration),
+			})
+
+			startedAt := tick
+			lastBackupTime = &startedAt
+		}
+	}
+
+	return runs, nil
+}
+
+func getProjectedDuration(lastBackup *backups_core.Backup) time.Duration {
+	if lastBackup == nil || lastBackup.BackupDurationMs <= 0 {
+		return defaultProjectedDuration
+	}
+
+	return time.Duration(lastBackup.BackupDurationMs) * time.Millisecond
+}
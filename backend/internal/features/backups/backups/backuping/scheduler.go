@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,10 +24,11 @@ const (
 )
 
 type BackupsScheduler struct {
-	backupRepository    *backups_core.BackupRepository
-	backupConfigService *backups_config.BackupConfigService
-	taskCancelManager   *task_cancellation.TaskCancelManager
-	backupNodesRegistry *BackupNodesRegistry
+	backupRepository           *backups_core.BackupRepository
+	backupDeadLetterRepository *backups_core.BackupDeadLetterRepository
+	backupConfigService        *backups_config.BackupConfigService
+	taskCancelManager          *task_cancellation.TaskCancelManager
+	backupNodesRegistry        *BackupNodesRegistry
 
 	lastBackupTime time.Time
 	logger         *slog.Logger
@@ -34,6 +36,9 @@ type BackupsScheduler struct {
 	backupToNodeRelations map[uuid.UUID]BackupToNodeRelation
 	backuperNode          *BackuperNode
 
+	backupQuotaCheckers    []backups_core.BackupQuotaChecker
+	backupPriorityProvider backups_core.BackupPriorityProvider
+
 	runOnce sync.Once
 	hasRun  atomic.Bool
 }
@@ -114,6 +119,24 @@ func (s *BackupsScheduler) IsBackupNodesAvailable() bool {
 }
 
 func (s *BackupsScheduler) StartBackup(databaseID uuid.UUID, isCallNotifier bool) {
+	s.startBackup(databaseID, isCallNotifier, nil, false)
+}
+
+// StartBackupWithTags starts a manual backup tagged for retention holds of the backup config
+func (s *BackupsScheduler) StartBackupWithTags(
+	databaseID uuid.UUID,
+	isCallNotifier bool,
+	tags []string,
+) {
+	s.startBackup(databaseID, isCallNotifier, tags, true)
+}
+
+func (s *BackupsScheduler) startBackup(
+	databaseID uuid.UUID,
+	isCallNotifier bool,
+	tags []string,
+	isManual bool,
+) {
 	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
 	if err != nil {
 		s.logger.Error("Failed to get backup config by database ID", "error", err)
@@ -152,6 +175,13 @@ func (s *BackupsScheduler) StartBackup(databaseID uuid.UUID, isCallNotifier bool
 		return
 	}
 
+	for _, quotaChecker := range s.backupQuotaCheckers {
+		if err := quotaChecker.CheckCanStartBackup(databaseID); err != nil {
+			s.failBackupOnQuotaExceeded(backupConfig, err)
+			return
+		}
+	}
+
 	leastBusyNodeID, err := s.calculateLeastBusyNode()
 	if err != nil {
 		s.logger.Error(
@@ -170,6 +200,8 @@ func (s *BackupsScheduler) StartBackup(databaseID uuid.UUID, isCallNotifier bool
 		StorageID:    *backupConfig.StorageID,
 		Status:       backups_core.BackupStatusInProgress,
 		BackupSizeMb: 0,
+		Tags:         tags,
+		IsManual:     isManual,
 		CreatedAt:    time.Now().UTC(),
 	}
 
@@ -240,6 +272,16 @@ func (s *BackupsScheduler) StartBackup(databaseID uuid.UUID, isCallNotifier bool
 	)
 }
 
+func (s *BackupsScheduler) AddBackupQuotaChecker(checker backups_core.BackupQuotaChecker) {
+	s.backupQuotaCheckers = append(s.backupQuotaCheckers, checker)
+}
+
+func (s *BackupsScheduler) SetBackupPriorityProvider(
+	provider backups_core.BackupPriorityProvider,
+) {
+	s.backupPriorityProvider = provider
+}
+
 // GetRemainedBackupTryCount returns the number of remaining backup tries for a given backup.
 // If the backup is not failed or the backup config does not allow retries, it returns 0.
 // If the backup is failed and the backup config allows retries, it returns the number of remaining tries.
@@ -289,29 +331,61 @@ func (s *BackupsScheduler) GetRemainedBackupTryCount(lastBackup *backups_core.Ba
 	return maxFailedTriesCount - len(lastFailedBackups)
 }
 
+// sortByPriority moves configs with higher priority first, so their due backups are started
+// before others on the same tick and take free slots of concurrency groups first
+func (s *BackupsScheduler) sortByPriority(
+	backupConfigs []*backups_config.BackupConfig,
+	databaseIDs []uuid.UUID,
+) error {
+	priorities, err := s.backupPriorityProvider.GetBackupPriorities(databaseIDs)
+	if err != nil {
+		return err
+	}
+
+	slices.SortStableFunc(backupConfigs, func(a, b *backups_config.BackupConfig) int {
+		return priorities[b.DatabaseID] - priorities[a.DatabaseID]
+	})
+
+	return nil
+}
+
 func (s *BackupsScheduler) runPendingBackups() error {
 	enabledBackupConfigs, err := s.backupConfigService.GetBackupConfigsWithEnabledBackups()
 	if err != nil {
 		return err
 	}
 
+	databaseIDs := make([]uuid.UUID, 0, len(enabledBackupConfigs))
 	for _, backupConfig := range enabledBackupConfigs {
-		if backupConfig.BackupInterval == nil {
-			continue
+		databaseIDs = append(databaseIDs, backupConfig.DatabaseID)
+	}
+
+	// One query for all databases, the scheduler runs every tick over every enabled config
+	lastBackups, err := s.backupRepository.FindLastByDatabaseIDs(databaseIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get last backups: %w", err)
+	}
+
+	if s.backupPriorityProvider != nil {
+		if err := s.sortByPriority(enabledBackupConfigs, databaseIDs); err != nil {
+			s.logger.Error("Failed to get backup priorities", "error", err)
 		}
+	}
 
-		lastBackup, err := s.backupRepository.FindLastByDatabaseID(backupConfig.DatabaseID)
-		if err != nil {
-			s.logger.Error(
-				"Failed to get last backup for database",
-				"databaseId",
-				backupConfig.DatabaseID,
-				"error",
-				err,
-			)
+	concurrencyGroupSlots, err := s.getConcurrencyGroupSlots(enabledBackupConfigs)
+	if err != nil {
+		return fmt.Errorf("failed to get concurrency group slots: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	for _, backupConfig := range enabledBackupConfigs {
+		if backupConfig.BackupInterval == nil || backupConfig.IsSchedulePausedAt(now) {
 			continue
 		}
 
+		lastBackup := lastBackups[backupConfig.DatabaseID]
+
 		var lastBackupTime *time.Time
 		if lastBackup != nil {
 			lastBackupTime = &lastBackup.CreatedAt
@@ -319,8 +393,28 @@ func (s *BackupsScheduler) runPendingBackups() error {
 
 		remainedBackupTryCount := s.GetRemainedBackupTryCount(lastBackup)
 
-		if backupConfig.BackupInterval.ShouldTriggerBackup(time.Now().UTC(), lastBackupTime) ||
+		if s.isRetriesExhausted(lastBackup, remainedBackupTryCount) {
+			s.moveToDeadLetters(lastBackup, backupConfig)
+		}
+
+		if backupConfig.ShouldTriggerBackup(now, lastBackupTime) ||
 			remainedBackupTryCount > 0 {
+			// A full group waits, the backup stays due and is started on a later tick
+			if groupID := backupConfig.ConcurrencyGroupID; groupID != nil {
+				if concurrencyGroupSlots[*groupID] <= 0 {
+					s.logger.Debug(
+						"Concurrency group is full, delaying scheduled backup",
+						"databaseId",
+						backupConfig.DatabaseID,
+						"concurrencyGroupId",
+						*groupID,
+					)
+					continue
+				}
+
+				concurrencyGroupSlots[*groupID]--
+			}
+
 			s.logger.Info(
 				"Triggering scheduled backup",
 				"databaseId",
@@ -337,6 +431,107 @@ func (s *BackupsScheduler) runPendingBackups() error {
 	return nil
 }
 
+func (s *BackupsScheduler) getConcurrencyGroupSlots(
+	backupConfigs []*backups_config.BackupConfig,
+) (map[uuid.UUID]int, error) {
+	groupIDs := make([]uuid.UUID, 0)
+	for _, backupConfig := range backupConfigs {
+		if backupConfig.ConcurrencyGroupID != nil &&
+			!slices.Contains(groupIDs, *backupConfig.ConcurrencyGroupID) {
+			groupIDs = append(groupIDs, *backupConfig.ConcurrencyGroupID)
+		}
+	}
+
+	if len(groupIDs) == 0 {
+		return map[uuid.UUID]int{}, nil
+	}
+
+	inProgressBackups, err := s.backupRepository.FindByStatus(backups_core.BackupStatusInProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	inProgressDatabaseIDs := make([]uuid.UUID, 0, len(inProgressBackups))
+	for _, backup := range inProgressBackups {
+		inProgressDatabaseIDs = append(inProgressDatabaseIDs, backup.DatabaseID)
+	}
+
+	return s.backupConfigService.GetConcurrencyGroupSlots(groupIDs, inProgressDatabaseIDs)
+}
+
+// isRetriesExhausted reports failures the scheduler will no longer retry on its own. Skip-retry
+// backups are excluded because they failed on purpose (cancellation, quota) rather than on error
+func (s *BackupsScheduler) isRetriesExhausted(
+	lastBackup *backups_core.Backup,
+	remainedBackupTryCount int,
+) bool {
+	return lastBackup != nil &&
+		lastBackup.Status == backups_core.BackupStatusFailed &&
+		!lastBackup.IsSkipRetry &&
+		remainedBackupTryCount <= 0
+}
+
+func (s *BackupsScheduler) moveToDeadLetters(
+	lastBackup *backups_core.Backup,
+	backupConfig *backups_config.BackupConfig,
+) {
+	attemptsLimit := 1
+	if backupConfig.IsRetryIfFailed && backupConfig.MaxFailedTriesCount > 1 {
+		attemptsLimit = backupConfig.MaxFailedTriesCount
+	}
+
+	lastBackups, err := s.backupRepository.FindByDatabaseIDWithLimit(
+		lastBackup.DatabaseID,
+		attemptsLimit,
+	)
+	if err != nil {
+		s.logger.Error("Failed to find last backups for dead letter", "error", err)
+		return
+	}
+
+	attempts := make([]backups_core.BackupDeadLetterAttempt, 0, len(lastBackups))
+	for _, backup := range lastBackups {
+		if backup.Status != backups_core.BackupStatusFailed {
+			break
+		}
+
+		failMessage := ""
+		if backup.FailMessage != nil {
+			failMessage = *backup.FailMessage
+		}
+
+		attempts = append(attempts, backups_core.BackupDeadLetterAttempt{
+			BackupID:    backup.ID,
+			FailMessage: failMessage,
+			CreatedAt:   backup.CreatedAt,
+		})
+	}
+
+	failMessage := "backup failed"
+	if lastBackup.FailMessage != nil {
+		failMessage = *lastBackup.FailMessage
+	}
+
+	deadLetter := &backups_core.BackupDeadLetter{
+		BackupID:      &lastBackup.ID,
+		DatabaseID:    lastBackup.DatabaseID,
+		FailMessage:   failMessage,
+		Attempts:      attempts,
+		AttemptsCount: len(attempts),
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	if err := s.backupDeadLetterRepository.CreateIfAbsent(deadLetter); err != nil {
+		s.logger.Error(
+			"Failed to move backup to dead letters",
+			"backupId",
+			lastBackup.ID,
+			"error",
+			err,
+		)
+	}
+}
+
 func (s *BackupsScheduler) failBackupsInProgress() error {
 	backupsInProgress, err := s.backupRepository.FindByStatus(backups_core.BackupStatusInProgress)
 	if err != nil {
@@ -513,21 +708,19 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 			len(relation.BackupsIDs),
 		)
 
-		for _, backupID := range relation.BackupsIDs {
-			backup, err := s.backupRepository.FindByID(backupID)
-			if err != nil {
-				s.logger.Error(
-					"Failed to find backup for dead node",
-					"nodeId",
-					nodeID,
-					"backupId",
-					backupID,
-					"error",
-					err,
-				)
-				continue
-			}
+		backups, err := s.backupRepository.FindByIDs(relation.BackupsIDs)
+		if err != nil {
+			s.logger.Error(
+				"Failed to find backups for dead node",
+				"nodeId",
+				nodeID,
+				"error",
+				err,
+			)
+			continue
+		}
 
+		for _, backup := range backups {
 			failMessage := "Backup failed due to node unavailability"
 			backup.FailMessage = &failMessage
 			backup.Status = backups_core.BackupStatusFailed
@@ -539,7 +732,7 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 					"nodeId",
 					nodeID,
 					"backupId",
-					backupID,
+					backup.ID,
 					"error",
 					err,
 				)
@@ -552,7 +745,7 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 					"nodeId",
 					nodeID,
 					"backupId",
-					backupID,
+					backup.ID,
 					"error",
 					err,
 				)
@@ -563,7 +756,7 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 				"nodeId",
 				nodeID,
 				"backupId",
-				backupID,
+				backup.ID,
 			)
 		}
 
@@ -572,3 +765,41 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 
 	return nil
 }
+
+// failBackupOnQuotaExceeded stores a failed backup instead of silently
+// skipping it, so users see why backups stopped. Retries are skipped
+// because they would hit the same quota again
+func (s *BackupsScheduler) failBackupOnQuotaExceeded(
+	backupConfig *backups_config.BackupConfig,
+	quotaErr error,
+) {
+	failMessage := quotaErr.Error()
+
+	backup := &backups_core.Backup{
+		DatabaseID:  backupConfig.DatabaseID,
+		StorageID:   *backupConfig.StorageID,
+		Status:      backups_core.BackupStatusFailed,
+		FailMessage: &failMessage,
+		IsSkipRetry: true,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.backupRepository.Save(backup); err != nil {
+		s.logger.Error(
+			"Failed to save backup failed by quota",
+			"databaseId",
+			backupConfig.DatabaseID,
+			"error",
+			err,
+		)
+		return
+	}
+
+	s.logger.Warn(
+		"Backup skipped due to exceeded quota",
+		"databaseId",
+		backupConfig.DatabaseID,
+		"reason",
+		failMessage,
+	)
+}
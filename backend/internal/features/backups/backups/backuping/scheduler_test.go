@@ -12,6 +12,7 @@ import (
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
 	cache_utils "databasus-backend/internal/util/cache"
 	"databasus-backend/internal/util/period"
+	test_utils "databasus-backend/internal/util/testing"
 	"testing"
 	"time"
 
@@ -439,6 +440,97 @@ func Test_RunPendingBackups_WhenBackupsDisabled_SkipsBackup(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 }
 
+func Test_RunPendingBackups_WhenConcurrencyGroupIsFull_DelaysBackup(t *testing.T) {
+	cache_utils.ClearAllCache()
+	backuperNode := CreateTestBackuperNode()
+	cancel := StartBackuperNodeForTest(t, backuperNode)
+	defer StopBackuperNodeForTest(t, cancel, backuperNode)
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	runningDatabase := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	dueDatabase := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		for _, database := range []*databases.Database{runningDatabase, dueDatabase} {
+			backups, _ := backupRepository.FindByDatabaseID(database.ID)
+			for _, backup := range backups {
+				backupRepository.DeleteByID(backup.ID)
+			}
+
+			databases.RemoveTestDatabase(database)
+		}
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		notifiers.RemoveTestNotifier(notifier)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	group := &backups_config.ConcurrencyGroup{
+		ID:                   uuid.New(),
+		WorkspaceID:          workspace.ID,
+		Name:                 "db-host-1",
+		MaxConcurrentBackups: 1,
+		CreatedAt:            time.Now().UTC(),
+	}
+	err := (&backups_config.BackupConfigRepository{}).SaveConcurrencyGroup(group)
+	assert.NoError(t, err)
+
+	for _, database := range []*databases.Database{runningDatabase, dueDatabase} {
+		backupConfig, err := backups_config.GetBackupConfigService().
+			GetBackupConfigByDbId(database.ID)
+		assert.NoError(t, err)
+
+		timeOfDay := "04:00"
+		backupConfig.BackupInterval = &intervals.Interval{
+			Interval:  intervals.IntervalDaily,
+			TimeOfDay: &timeOfDay,
+		}
+		backupConfig.IsBackupsEnabled = true
+		backupConfig.StorePeriod = period.PeriodWeek
+		backupConfig.Storage = storage
+		backupConfig.StorageID = &storage.ID
+		backupConfig.ConcurrencyGroupID = &group.ID
+
+		_, err = backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+		assert.NoError(t, err)
+	}
+
+	// the only slot of the group is taken by the running backup
+	backupRepository.Save(&backups_core.Backup{
+		DatabaseID: runningDatabase.ID,
+		StorageID:  storage.ID,
+
+		Status: backups_core.BackupStatusInProgress,
+
+		CreatedAt: time.Now().UTC(),
+	})
+
+	// add old backup that would trigger new backup without the group
+	backupRepository.Save(&backups_core.Backup{
+		DatabaseID: dueDatabase.ID,
+		StorageID:  storage.ID,
+
+		Status: backups_core.BackupStatusCompleted,
+
+		CreatedAt: time.Now().UTC().Add(-24 * time.Hour),
+	})
+
+	GetBackupsScheduler().runPendingBackups()
+
+	time.Sleep(100 * time.Millisecond)
+
+	backups, err := backupRepository.FindByDatabaseID(dueDatabase.ID)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+
+	// Wait for any cleanup operations to complete before defer cleanup runs
+	time.Sleep(200 * time.Millisecond)
+}
+
 func Test_CheckDeadNodesAndFailBackups_WhenNodeDies_FailsBackupAndCleansUpRegistry(t *testing.T) {
 	cache_utils.ClearAllCache()
 
@@ -1319,3 +1411,179 @@ func Test_StartBackup_When2BackupsStartedForDifferentDatabases_BothUseCasesAreCa
 
 	time.Sleep(200 * time.Millisecond)
 }
+
+func Test_FindLastByDatabaseIDs_For3Databases_ReturnsNewestBackupsInOneQuery(t *testing.T) {
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+
+	testDatabases := make([]*databases.Database, 0, 3)
+	databaseIDs := make([]uuid.UUID, 0, 3)
+	newestBackupIDs := map[uuid.UUID]uuid.UUID{}
+
+	for range 3 {
+		database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+		testDatabases = append(testDatabases, database)
+		databaseIDs = append(databaseIDs, database.ID)
+
+		for hoursAgo := range 3 {
+			backup := &backups_core.Backup{
+				DatabaseID: database.ID,
+				StorageID:  storage.ID,
+				Status:     backups_core.BackupStatusCompleted,
+				CreatedAt:  time.Now().UTC().Add(-time.Duration(hoursAgo) * time.Hour),
+			}
+			backupRepository.Save(backup)
+
+			if hoursAgo == 0 {
+				newestBackupIDs[database.ID] = backup.ID
+			}
+		}
+	}
+
+	defer func() {
+		backups, _ := backupRepository.FindByStorageID(storage.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		for _, database := range testDatabases {
+			databases.RemoveTestDatabase(database)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		notifiers.RemoveTestNotifier(notifier)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var lastBackups map[uuid.UUID]*backups_core.Backup
+	queriesCount := test_utils.CountQueries(func() {
+		var err error
+		lastBackups, err = backupRepository.FindLastByDatabaseIDs(databaseIDs)
+		assert.NoError(t, err)
+	})
+
+	assert.Equal(t, int64(1), queriesCount)
+	assert.Len(t, lastBackups, len(databaseIDs))
+
+	for databaseID, backupID := range newestBackupIDs {
+		assert.Equal(t, backupID, lastBackups[databaseID].ID)
+	}
+}
+
+// runPendingBackups used to load the last backup of every database separately. Compare
+// queries/op of both sub-benchmarks:
+// go test ./internal/features/backups/backups/backuping -run ^$ -bench LastBackups
+func Benchmark_FindLastBackups_For50Databases(b *testing.B) {
+	const databasesCount = 50
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Benchmark Workspace", user, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+
+	testDatabases := make([]*databases.Database, 0, databasesCount)
+	databaseIDs := make([]uuid.UUID, 0, databasesCount)
+
+	for range databasesCount {
+		database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+		testDatabases = append(testDatabases, database)
+		databaseIDs = append(databaseIDs, database.ID)
+
+		for hoursAgo := range 3 {
+			backupRepository.Save(&backups_core.Backup{
+				DatabaseID: database.ID,
+				StorageID:  storage.ID,
+				Status:     backups_core.BackupStatusCompleted,
+				CreatedAt:  time.Now().UTC().Add(-time.Duration(hoursAgo) * time.Hour),
+			})
+		}
+	}
+
+	defer func() {
+		backups, _ := backupRepository.FindByStorageID(storage.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		for _, database := range testDatabases {
+			databases.RemoveTestDatabase(database)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		notifiers.RemoveTestNotifier(notifier)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	b.Run("OneQueryPerDatabase", func(b *testing.B) {
+		var queriesCount int64
+
+		for range b.N {
+			queriesCount += test_utils.CountQueries(func() {
+				for _, databaseID := range databaseIDs {
+					if _, err := backupRepository.FindLastByDatabaseID(databaseID); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+
+		b.ReportMetric(float64(queriesCount)/float64(b.N), "queries/op")
+	})
+
+	b.Run("BatchQuery", func(b *testing.B) {
+		var queriesCount int64
+
+		for range b.N {
+			queriesCount += test_utils.CountQueries(func() {
+				lastBackups, err := backupRepository.FindLastByDatabaseIDs(databaseIDs)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if len(lastBackups) != databasesCount {
+					b.Fatalf("expected %d last backups, got %d", databasesCount, len(lastBackups))
+				}
+			})
+		}
+
+		b.ReportMetric(float64(queriesCount)/float64(b.N), "queries/op")
+	})
+}
+
+type testBackupPriorityProvider struct {
+	priorities map[uuid.UUID]int
+}
+
+func (p *testBackupPriorityProvider) GetBackupPriorities(
+	_ []uuid.UUID,
+) (map[uuid.UUID]int, error) {
+	return p.priorities, nil
+}
+
+func Test_SortByPriority_WithPriorityProvider_StartsHigherPrioritiesFirst(t *testing.T) {
+	freeConfig := &backups_config.BackupConfig{DatabaseID: uuid.New()}
+	proConfig := &backups_config.BackupConfig{DatabaseID: uuid.New()}
+	otherFreeConfig := &backups_config.BackupConfig{DatabaseID: uuid.New()}
+
+	scheduler := &BackupsScheduler{
+		backupPriorityProvider: &testBackupPriorityProvider{
+			priorities: map[uuid.UUID]int{proConfig.DatabaseID: 2},
+		},
+	}
+
+	backupConfigs := []*backups_config.BackupConfig{freeConfig, proConfig, otherFreeConfig}
+	err := scheduler.sortByPriority(backupConfigs, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*backups_config.BackupConfig{proConfig, freeConfig, otherFreeConfig},
+		backupConfigs,
+	)
+}
@@ -12,8 +12,10 @@ import (
 	"databasus-backend/internal/features/backups/backups/usecases"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
@@ -37,56 +39,65 @@ func CreateTestRouter() *gin.Engine {
 
 func CreateTestBackuperNode() *BackuperNode {
 	return &BackuperNode{
-		databaseService:     databases.GetDatabaseService(),
-		fieldEncryptor:      encryption.GetFieldEncryptor(),
-		workspaceService:    workspaces_services.GetWorkspaceService(),
-		backupRepository:    backupRepository,
-		backupConfigService: backups_config.GetBackupConfigService(),
-		storageService:      storages.GetStorageService(),
-		notificationSender:  notifiers.GetNotifierService(),
-		backupCancelManager: taskCancelManager,
-		backupNodesRegistry: backupNodesRegistry,
-		logger:              logger.GetLogger(),
-		createBackupUseCase: usecases.GetCreateBackupUsecase(),
-		nodeID:              uuid.New(),
-		lastHeartbeat:       time.Time{},
-		runOnce:             sync.Once{},
-		hasRun:              atomic.Bool{},
+		databaseService:        databases.GetDatabaseService(),
+		fieldEncryptor:         encryption.GetFieldEncryptor(),
+		workspaceService:       workspaces_services.GetWorkspaceService(),
+		backupRepository:       backupRepository,
+		backupRunLogRepository: backupRunLogRepository,
+		backupConfigService:    backups_config.GetBackupConfigService(),
+		storageService:         storages.GetStorageService(),
+		signingService:         encryption_signing.GetSigningService(),
+		notificationSender:     notifiers.GetNotifierService(),
+		ownerNotifier:          users_services.GetUserService(),
+		backupCancelManager:    taskCancelManager,
+		backupNodesRegistry:    backupNodesRegistry,
+		backupLogRelay:         backupLogRelay,
+		logger:                 logger.GetLogger(),
+		createBackupUseCase:    usecases.GetCreateBackupUsecase(),
+		nodeID:                 uuid.New(),
+		lastHeartbeat:          time.Time{},
+		runOnce:                sync.Once{},
+		hasRun:                 atomic.Bool{},
 	}
 }
 
 func CreateTestBackuperNodeWithUseCase(useCase backups_core.CreateBackupUsecase) *BackuperNode {
 	return &BackuperNode{
-		databaseService:     databases.GetDatabaseService(),
-		fieldEncryptor:      encryption.GetFieldEncryptor(),
-		workspaceService:    workspaces_services.GetWorkspaceService(),
-		backupRepository:    backupRepository,
-		backupConfigService: backups_config.GetBackupConfigService(),
-		storageService:      storages.GetStorageService(),
-		notificationSender:  notifiers.GetNotifierService(),
-		backupCancelManager: taskCancelManager,
-		backupNodesRegistry: backupNodesRegistry,
-		logger:              logger.GetLogger(),
-		createBackupUseCase: useCase,
-		nodeID:              uuid.New(),
-		lastHeartbeat:       time.Time{},
-		runOnce:             sync.Once{},
-		hasRun:              atomic.Bool{},
+		databaseService:        databases.GetDatabaseService(),
+		fieldEncryptor:         encryption.GetFieldEncryptor(),
+		workspaceService:       workspaces_services.GetWorkspaceService(),
+		backupRepository:       backupRepository,
+		backupRunLogRepository: backupRunLogRepository,
+		backupConfigService:    backups_config.GetBackupConfigService(),
+		storageService:         storages.GetStorageService(),
+		signingService:         encryption_signing.GetSigningService(),
+		notificationSender:     notifiers.GetNotifierService(),
+		ownerNotifier:          users_services.GetUserService(),
+		backupCancelManager:    taskCancelManager,
+		backupNodesRegistry:    backupNodesRegistry,
+		backupLogRelay:         backupLogRelay,
+		logger:                 logger.GetLogger(),
+		createBackupUseCase:    useCase,
+		nodeID:                 uuid.New(),
+		lastHeartbeat:          time.Time{},
+		runOnce:                sync.Once{},
+		hasRun:                 atomic.Bool{},
 	}
 }
 
 func CreateTestScheduler() *BackupsScheduler {
 	return &BackupsScheduler{
-		backupRepository:      backupRepository,
-		backupConfigService:   backups_config.GetBackupConfigService(),
-		taskCancelManager:     taskCancelManager,
-		backupNodesRegistry:   backupNodesRegistry,
-		lastBackupTime:        time.Now().UTC(),
-		logger:                logger.GetLogger(),
-		backupToNodeRelations: make(map[uuid.UUID]BackupToNodeRelation),
-		backuperNode:          CreateTestBackuperNode(),
-		runOnce:               sync.Once{},
-		hasRun:                atomic.Bool{},
+		backupRepository:           backupRepository,
+		backupDeadLetterRepository: backupDeadLetterRepository,
+		backupConfigService:        backups_config.GetBackupConfigService(),
+		taskCancelManager:          taskCancelManager,
+		backupNodesRegistry:        backupNodesRegistry,
+		lastBackupTime:             time.Now().UTC(),
+		logger:                     logger.GetLogger(),
+		backupToNodeRelations:      make(map[uuid.UUID]BackupToNodeRelation),
+		backuperNode:               CreateTestBackuperNode(),
+		runOnce:                    sync.Once{},
+		hasRun:                     atomic.Bool{},
 	}
 }
 
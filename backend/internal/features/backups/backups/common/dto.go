@@ -15,3 +15,12 @@ type BackupMetadata struct {
 	Encryption     backups_config.BackupEncryption
 	Type           BackupType
 }
+
+// RemoteDumpCommand is a dump tool invocation executed outside of backup nodes, e.g. by
+// an agent. Secrets are masked in the tool output the remote side sends back
+type RemoteDumpCommand struct {
+	Tool    string
+	Args    []string
+	Env     []string
+	Secrets []string
+}
@@ -0,0 +1,311 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+type BackupPhase string
+
+const (
+	BackupPhaseConnect  BackupPhase = "CONNECT"
+	BackupPhaseDump     BackupPhase = "DUMP"
+	BackupPhaseCompress BackupPhase = "COMPRESS"
+	BackupPhaseEncrypt  BackupPhase = "ENCRYPT"
+	BackupPhaseUpload   BackupPhase = "UPLOAD"
+)
+
+const maxToolOutputTailBytes = 16 * 1024
+
+type BackupPhaseTiming struct {
+	Phase      BackupPhase `json:"phase"`
+	StartedAt  time.Time   `json:"startedAt"`
+	DurationMs int64       `json:"durationMs"`
+	Bytes      int64       `json:"bytes"`
+	IsFinished bool        `json:"isFinished"`
+
+	busyDuration time.Duration
+}
+
+// BackupRunRecorder collects per-phase telemetry of a single backup run. Phases of the
+// streaming pipeline run concurrently, so their durations overlap rather than add up.
+// All methods are safe on a nil recorder, which lets callers skip telemetry entirely
+type BackupRunRecorder struct {
+	mu             sync.Mutex
+	phases         []*BackupPhaseTiming
+	toolOutputTail string
+	logListener    func(line string)
+}
+
+func NewBackupRunRecorder() *BackupRunRecorder {
+	return &BackupRunRecorder{}
+}
+
+// SetLogListener receives phase transitions and tool output line by line while the run is
+// in progress, so it can be relayed to whoever follows the job live
+func (r *BackupRunRecorder) SetLogListener(listener func(line string)) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logListener = listener
+}
+
+func (r *BackupRunRecorder) StartPhase(phase BackupPhase) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	_, isCreated := r.getOrCreatePhase(phase, time.Now().UTC())
+	r.mu.Unlock()
+
+	if isCreated {
+		r.emitLine(fmt.Sprintf("phase %s started", phase))
+	}
+}
+
+func (r *BackupRunRecorder) FinishPhase(phase BackupPhase, bytes int64) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	timing, _ := r.getOrCreatePhase(phase, time.Now().UTC())
+	if timing.IsFinished {
+		r.mu.Unlock()
+		return
+	}
+
+	timing.DurationMs = time.Since(timing.StartedAt).Milliseconds()
+	timing.Bytes = bytes
+	timing.IsFinished = true
+	durationMs := timing.DurationMs
+	r.mu.Unlock()
+
+	r.emitLine(fmt.Sprintf("phase %s finished in %d ms (%d bytes)", phase, durationMs, bytes))
+}
+
+// WrapToolOutput measures the dump tool's stdout: time until the first byte is counted as
+// connecting (auth, catalog locks), everything after it until EOF as dumping
+func (r *BackupRunRecorder) WrapToolOutput(reader io.Reader) io.Reader {
+	if r == nil {
+		return reader
+	}
+
+	r.StartPhase(BackupPhaseConnect)
+
+	return &toolOutputReader{reader: reader, recorder: r}
+}
+
+// WrapPhaseWriter attributes the time spent inside Write calls to the phase. Writes block on
+// downstream stages, so a slow upload also shows up here
+func (r *BackupRunRecorder) WrapPhaseWriter(phase BackupPhase, writer io.Writer) io.Writer {
+	if r == nil {
+		return writer
+	}
+
+	return &phaseWriter{writer: writer, recorder: r, phase: phase}
+}
+
+func (r *BackupRunRecorder) WrapPhaseReader(phase BackupPhase, reader io.Reader) io.Reader {
+	if r == nil {
+		return reader
+	}
+
+	r.StartPhase(phase)
+
+	return &phaseReader{reader: reader, recorder: r, phase: phase}
+}
+
+// WrapToolStderr forwards the tool's stderr to the log listener line by line as it is read
+func (r *BackupRunRecorder) WrapToolStderr(reader io.Reader, secrets ...string) io.Reader {
+	if r == nil {
+		return reader
+	}
+
+	return io.TeeReader(reader, &lineEmitter{recorder: r, secrets: secrets})
+}
+
+// SetToolOutput keeps the tail of the tool's stderr, which is where pg_dump and friends
+// report the actual reason of a failure. Secrets are masked because the tail is shown in UI
+func (r *BackupRunRecorder) SetToolOutput(output []byte, secrets ...string) {
+	if r == nil {
+		return
+	}
+
+	tail := maskSecrets(string(output), secrets)
+
+	if len(tail) > maxToolOutputTailBytes {
+		tail = tail[len(tail)-maxToolOutputTailBytes:]
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.toolOutputTail = tail
+}
+
+func (r *BackupRunRecorder) GetPhases() []BackupPhaseTiming {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	phases := make([]BackupPhaseTiming, 0, len(r.phases))
+	for _, timing := range r.phases {
+		phases = append(phases, *timing)
+	}
+
+	return phases
+}
+
+func (r *BackupRunRecorder) GetToolOutputTail() string {
+	if r == nil {
+		return ""
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.toolOutputTail
+}
+
+func (r *BackupRunRecorder) addBusyTime(phase BackupPhase, startedAt time.Time, bytes int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	timing, _ := r.getOrCreatePhase(phase, startedAt)
+	timing.busyDuration += time.Since(startedAt)
+	timing.DurationMs = timing.busyDuration.Milliseconds()
+	timing.Bytes += bytes
+	timing.IsFinished = true
+}
+
+func (r *BackupRunRecorder) getOrCreatePhase(
+	phase BackupPhase,
+	startedAt time.Time,
+) (*BackupPhaseTiming, bool) {
+	for _, timing := range r.phases {
+		if timing.Phase == phase {
+			return timing, false
+		}
+	}
+
+	timing := &BackupPhaseTiming{Phase: phase, StartedAt: startedAt}
+	r.phases = append(r.phases, timing)
+
+	return timing, true
+}
+
+func (r *BackupRunRecorder) emitLine(line string) {
+	r.mu.Lock()
+	listener := r.logListener
+	r.mu.Unlock()
+
+	if listener != nil {
+		listener(line)
+	}
+}
+
+func maskSecrets(text string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, "***")
+		}
+	}
+
+	return text
+}
+
+type lineEmitter struct {
+	recorder *BackupRunRecorder
+	secrets  []string
+	pending  []byte
+}
+
+func (e *lineEmitter) Write(p []byte) (int, error) {
+	e.pending = append(e.pending, p...)
+
+	for {
+		newlineIndex := bytes.IndexByte(e.pending, '\n')
+		if newlineIndex < 0 {
+			break
+		}
+
+		line := strings.TrimRight(string(e.pending[:newlineIndex]), "\r")
+		e.pending = e.pending[newlineIndex+1:]
+
+		if line != "" {
+			e.recorder.emitLine(maskSecrets(line, e.secrets))
+		}
+	}
+
+	return len(p), nil
+}
+
+type toolOutputReader struct {
+	reader      io.Reader
+	recorder    *BackupRunRecorder
+	bytesRead   int64
+	hasReadData bool
+}
+
+func (t *toolOutputReader) Read(p []byte) (int, error) {
+	n, err := t.reader.Read(p)
+
+	if n > 0 && !t.hasReadData {
+		t.hasReadData = true
+		t.recorder.FinishPhase(BackupPhaseConnect, 0)
+		t.recorder.StartPhase(BackupPhaseDump)
+	}
+
+	t.bytesRead += int64(n)
+
+	if err != nil && t.hasReadData {
+		t.recorder.FinishPhase(BackupPhaseDump, t.bytesRead)
+	}
+
+	return n, err
+}
+
+type phaseWriter struct {
+	writer   io.Writer
+	recorder *BackupRunRecorder
+	phase    BackupPhase
+}
+
+func (w *phaseWriter) Write(p []byte) (int, error) {
+	startedAt := time.Now().UTC()
+	n, err := w.writer.Write(p)
+	w.recorder.addBusyTime(w.phase, startedAt, int64(n))
+
+	return n, err
+}
+
+type phaseReader struct {
+	reader    io.Reader
+	recorder  *BackupRunRecorder
+	phase     BackupPhase
+	bytesRead int64
+}
+
+func (r *phaseReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytesRead += int64(n)
+
+	if err != nil {
+		r.recorder.FinishPhase(r.phase, r.bytesRead)
+	}
+
+	return n, err
+}
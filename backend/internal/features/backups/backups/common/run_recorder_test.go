@@ -0,0 +1,109 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BackupRunRecorder_ToolOutputSplitsConnectAndDump(t *testing.T) {
+	recorder := NewBackupRunRecorder()
+
+	reader := recorder.WrapToolOutput(strings.NewReader("dump content"))
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "dump content", string(data))
+
+	phases := recorder.GetPhases()
+	assert.Equal(t, 2, len(phases))
+	assert.Equal(t, BackupPhaseConnect, phases[0].Phase)
+	assert.True(t, phases[0].IsFinished)
+	assert.Equal(t, BackupPhaseDump, phases[1].Phase)
+	assert.True(t, phases[1].IsFinished)
+	assert.Equal(t, int64(len("dump content")), phases[1].Bytes)
+}
+
+func Test_BackupRunRecorder_ToolWithoutOutput_ConnectNotFinished(t *testing.T) {
+	recorder := NewBackupRunRecorder()
+
+	_, err := io.ReadAll(recorder.WrapToolOutput(strings.NewReader("")))
+	assert.NoError(t, err)
+
+	phases := recorder.GetPhases()
+	assert.Equal(t, 1, len(phases))
+	assert.Equal(t, BackupPhaseConnect, phases[0].Phase)
+	assert.False(t, phases[0].IsFinished)
+}
+
+func Test_BackupRunRecorder_PhaseWriterAndReaderCountBytes(t *testing.T) {
+	recorder := NewBackupRunRecorder()
+
+	var buffer bytes.Buffer
+	writer := recorder.WrapPhaseWriter(BackupPhaseEncrypt, &buffer)
+	_, _ = writer.Write([]byte("abc"))
+	_, _ = writer.Write([]byte("de"))
+
+	_, err := io.ReadAll(recorder.WrapPhaseReader(BackupPhaseUpload, &buffer))
+	assert.NoError(t, err)
+
+	phases := recorder.GetPhases()
+	assert.Equal(t, 2, len(phases))
+	assert.Equal(t, int64(5), phases[0].Bytes)
+	assert.Equal(t, int64(5), phases[1].Bytes)
+	assert.True(t, phases[1].IsFinished)
+}
+
+func Test_BackupRunRecorder_ToolOutputMasksSecretsAndKeepsTail(t *testing.T) {
+	recorder := NewBackupRunRecorder()
+
+	output := strings.Repeat("x", maxToolOutputTailBytes) + " password=s3cret failed"
+	recorder.SetToolOutput([]byte(output), "s3cret", "")
+
+	tail := recorder.GetToolOutputTail()
+	assert.Equal(t, maxToolOutputTailBytes, len(tail))
+	assert.True(t, strings.HasSuffix(tail, "password=*** failed"))
+	assert.NotContains(t, tail, "s3cret")
+}
+
+func Test_BackupRunRecorder_NilRecorder_PassesThrough(t *testing.T) {
+	var recorder *BackupRunRecorder
+
+	reader := strings.NewReader("data")
+	assert.Equal(t, io.Reader(reader), recorder.WrapToolOutput(reader))
+
+	recorder.StartPhase(BackupPhaseDump)
+	recorder.FinishPhase(BackupPhaseDump, 1)
+	recorder.SetToolOutput([]byte("stderr"))
+
+	assert.Nil(t, recorder.GetPhases())
+	assert.Equal(t, "", recorder.GetToolOutputTail())
+}
+
+func Test_BackupRunRecorder_LogListener_ReceivesPhasesAndMaskedStderrLines(t *testing.T) {
+	recorder := NewBackupRunRecorder()
+
+	lines := make([]string, 0)
+	recorder.SetLogListener(func(line string) {
+		lines = append(lines, line)
+	})
+
+	recorder.StartPhase(BackupPhaseUpload)
+	stderr := recorder.WrapToolStderr(
+		strings.NewReader("pg_dump: reading schemas\r\npg_dump: password s3cret\npartial"),
+		"s3cret",
+	)
+	_, err := io.ReadAll(stderr)
+	assert.NoError(t, err)
+	recorder.FinishPhase(BackupPhaseUpload, 10)
+
+	assert.Equal(t, []string{
+		"phase UPLOAD started",
+		"pg_dump: reading schemas",
+		"pg_dump: password ***",
+		lines[3],
+	}, lines)
+	assert.True(t, strings.HasPrefix(lines[3], "phase UPLOAD finished in "))
+}
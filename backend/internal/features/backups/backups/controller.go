@@ -2,10 +2,13 @@ package backups
 
 import (
 	"context"
+	"databasus-backend/internal/features/backups/backups/backuping"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_download "databasus-backend/internal/features/backups/backups/download"
 	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
 	users_middleware "databasus-backend/internal/features/users/middleware"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,8 +26,22 @@ func (c *BackupController) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/backups", c.GetBackups)
 	router.POST("/backups", c.MakeBackup)
 	router.POST("/backups/:id/download-token", c.GenerateDownloadToken)
+	router.POST("/backups/:id/share-links", c.CreateShareLink)
+	router.GET("/backups/:id/share-links", c.GetShareLinks)
+	router.GET("/backups/share-links/received", c.GetReceivedShareLinks)
+	router.DELETE("/backups/share-links/:shareLinkId", c.RevokeShareLink)
+	router.POST(
+		"/backups/share-links/:shareLinkId/download-token",
+		c.GenerateShareLinkDownloadToken,
+	)
 	router.DELETE("/backups/:id", c.DeleteBackup)
 	router.POST("/backups/:id/cancel", c.CancelBackup)
+	router.GET("/backups/:id/log", c.GetBackupRunLog)
+	router.GET("/backups/jobs/:id/logs", c.StreamBackupLogs)
+	router.GET("/backups/dead-letters", c.GetDeadLetters)
+	router.POST("/backups/dead-letters/:id/requeue", c.RequeueDeadLetter)
+	router.GET("/backups/schedule-projection", c.GetScheduleProjection)
+	router.GET("/storages/:id/backups", c.GetStorageBackups)
 }
 
 // RegisterPublicRoutes registers routes that don't require Bearer authentication
@@ -74,6 +91,57 @@ func (c *BackupController) GetBackups(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// GetStorageBackups
+// @Summary Get backups of a storage
+// @Description Get a paginated list of backups residing in the storage with their count and total size, overall and per database
+// @Tags backups
+// @Produce json
+// @Param id path string true "Storage ID"
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} GetStorageBackupsResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/{id}/backups [get]
+func (c *BackupController) GetStorageBackups(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	storageID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	var request GetStorageBackupsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.backupService.GetStorageBackups(
+		user,
+		storageID,
+		request.Limit,
+		request.Offset,
+	)
+	if err != nil {
+		if errors.Is(err, storages.ErrInsufficientPermissionsToViewStorage) ||
+			errors.Is(err, ErrSystemStorageBackupsOnlyForAdmins) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // MakeBackup
 // @Summary Create a backup
 // @Description Create a new backup for the specified database
@@ -99,7 +167,8 @@ func (c *BackupController) MakeBackup(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.backupService.MakeBackupWithAuth(user, request.DatabaseID); err != nil {
+	err := c.backupService.MakeBackupWithAuth(user, request.DatabaseID, request.Tags)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -169,6 +238,193 @@ func (c *BackupController) CancelBackup(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// GetBackupRunLog
+// @Summary Get run log of a backup
+// @Description Get per-phase timings (connect, dump, compress, encrypt, upload) and the tail
+// @Description of the dump tool output for the specified backup
+// @Tags backups
+// @Produce json
+// @Param id path string true "Backup ID"
+// @Success 200 {object} backups_core.BackupRunLog
+// @Failure 400
+// @Failure 401
+// @Router /backups/{id}/log [get]
+func (c *BackupController) GetBackupRunLog(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	runLog, err := c.backupService.GetBackupRunLog(user, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runLog)
+}
+
+// StreamBackupLogs
+// @Summary Stream logs of a backup job
+// @Description Stream logs of a backup job as Server-Sent Events. Lines already produced are
+// @Description replayed first; with follow=true the stream stays open until the job ends.
+// @Description Each "log" event carries a JSON entry, the final entry has isEnd=true
+// @Tags backups
+// @Produce text/event-stream
+// @Param id path string true "Backup ID"
+// @Param follow query bool false "Keep streaming while the backup is in progress"
+// @Success 200 {object} backuping.BackupLogEntry
+// @Failure 400
+// @Failure 401
+// @Router /backups/jobs/{id}/logs [get]
+func (c *BackupController) StreamBackupLogs(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	var request StreamBackupLogsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	backup, err := c.backupService.GetBackupForLogs(user, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Disables response buffering in nginx, otherwise lines arrive in large batches
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	err = c.backupService.StreamBackupLogs(
+		ctx.Request.Context(),
+		backup,
+		request.IsFollow,
+		func(entry backuping.BackupLogEntry) {
+			ctx.SSEvent("log", entry)
+			ctx.Writer.Flush()
+		},
+	)
+	if err != nil {
+		ctx.SSEvent("error", gin.H{"error": err.Error()})
+		ctx.Writer.Flush()
+	}
+}
+
+// GetDeadLetters
+// @Summary Get backups that exhausted retries
+// @Description Get failed backups the scheduler stopped retrying, with the error of each attempt
+// @Tags backups
+// @Produce json
+// @Param workspace_id query string true "Workspace ID"
+// @Success 200 {array} backups_core.BackupDeadLetter
+// @Failure 400
+// @Failure 401
+// @Router /backups/dead-letters [get]
+func (c *BackupController) GetDeadLetters(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request GetDeadLettersRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(request.WorkspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace_id"})
+		return
+	}
+
+	deadLetters, err := c.backupService.GetDeadLetters(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deadLetters)
+}
+
+// GetScheduleProjection
+// @Summary Get projected load of backup nodes
+// @Description Get scheduled backups projected to start and run over the next 24h in 30 minute buckets, admins only
+// @Tags backups
+// @Produce json
+// @Success 200 {object} backuping.ScheduleLoadProjection
+// @Failure 400
+// @Failure 401
+// @Router /backups/schedule-projection [get]
+func (c *BackupController) GetScheduleProjection(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	projection, err := c.backupService.GetScheduleProjection(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projection)
+}
+
+// RequeueDeadLetter
+// @Summary Requeue a backup that exhausted retries
+// @Description Start a new backup for the database and mark the dead letter as requeued
+// @Tags backups
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} map[string]string
+// @Failure 400
+// @Failure 401
+// @Router /backups/dead-letters/{id}/requeue [post]
+func (c *BackupController) RequeueDeadLetter(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid dead letter ID"})
+		return
+	}
+
+	if err := c.backupService.RequeueDeadLetter(user, id); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "backup requeued successfully"})
+}
+
 // GenerateDownloadToken
 // @Summary Generate short-lived download token
 // @Description Generate a token for downloading a backup file (valid for 5 minutes)
@@ -274,6 +530,16 @@ func (c *BackupController) GetFile(ctx *gin.Context) {
 		return
 	}
 
+	if downloadToken.ShareLinkID != nil {
+		if err := c.backupService.RecordShareLinkDownload(*downloadToken.ShareLinkID); err != nil {
+			c.backupService.UnregisterDownload(downloadToken.UserID)
+			c.backupService.ReleaseDownloadLock(downloadToken.UserID)
+			_ = fileReader.Close()
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	rateLimitedReader := backups_download.NewRateLimitedReader(fileReader, rateLimiter)
 
 	heartbeatCtx, cancelHeartbeat := context.WithCancel(context.Background())
@@ -310,8 +576,178 @@ func (c *BackupController) GetFile(ctx *gin.Context) {
 	c.backupService.WriteAuditLogForDownload(downloadToken.UserID, backup, database)
 }
 
+// CreateShareLink
+// @Summary Share a backup with a workspace member
+// @Description Create a time-limited link for another member of the workspace to download
+// @Description the backup. Downloads are counted and the link can be revoked
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path string true "Backup ID"
+// @Param request body backups_download.CreateShareLinkRequest true "Share link"
+// @Success 200 {object} backups_download.BackupShareLink
+// @Failure 400
+// @Failure 401
+// @Router /backups/{id}/share-links [post]
+func (c *BackupController) CreateShareLink(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	var request backups_download.CreateShareLinkRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, err := c.backupService.CreateShareLink(user, id, request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, link)
+}
+
+// GetShareLinks
+// @Summary Get share links of a backup
+// @Tags backups
+// @Produce json
+// @Param id path string true "Backup ID"
+// @Success 200 {array} backups_download.BackupShareLink
+// @Failure 400
+// @Failure 401
+// @Router /backups/{id}/share-links [get]
+func (c *BackupController) GetShareLinks(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	links, err := c.backupService.GetShareLinks(user, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, links)
+}
+
+// GetReceivedShareLinks
+// @Summary Get backups shared with the current user
+// @Description Get active share links of which the current user is the recipient
+// @Tags backups
+// @Produce json
+// @Success 200 {array} backups_download.BackupShareLink
+// @Failure 400
+// @Failure 401
+// @Router /backups/share-links/received [get]
+func (c *BackupController) GetReceivedShareLinks(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	links, err := c.backupService.GetReceivedShareLinks(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, links)
+}
+
+// RevokeShareLink
+// @Summary Revoke a share link
+// @Tags backups
+// @Param shareLinkId path string true "Share link ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /backups/share-links/{shareLinkId} [delete]
+func (c *BackupController) RevokeShareLink(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	shareLinkID, err := uuid.Parse(ctx.Param("shareLinkId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid share link ID"})
+		return
+	}
+
+	if err := c.backupService.RevokeShareLink(user, shareLinkID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GenerateShareLinkDownloadToken
+// @Summary Generate download token of a share link
+// @Description Generate a download token of a backup shared with the current user, the
+// @Description file is downloaded with it from /backups/{id}/file
+// @Tags backups
+// @Param shareLinkId path string true "Share link ID"
+// @Success 200 {object} backups_download.GenerateDownloadTokenResponse
+// @Failure 400
+// @Failure 401
+// @Failure 409 {object} map[string]string "Download already in progress"
+// @Router /backups/share-links/{shareLinkId}/download-token [post]
+func (c *BackupController) GenerateShareLinkDownloadToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	shareLinkID, err := uuid.Parse(ctx.Param("shareLinkId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid share link ID"})
+		return
+	}
+
+	response, err := c.backupService.GenerateShareLinkDownloadToken(user, shareLinkID)
+	if err != nil {
+		if err == backups_download.ErrDownloadAlreadyInProgress {
+			ctx.JSON(
+				http.StatusConflict,
+				gin.H{
+					"error": "Download already in progress for some of backups. Please wait until previous download completed or cancel it",
+				},
+			)
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 type MakeBackupRequest struct {
 	DatabaseID uuid.UUID `json:"database_id" binding:"required"`
+	// Tags are matched by retention holds of the backup config, e.g. "release:v1.2"
+	Tags []string `json:"tags"`
 }
 
 func (c *BackupController) generateBackupFilename(
@@ -325,15 +761,15 @@ func (c *BackupController) generateBackupFilename(
 	safeName := sanitizeFilename(database.Name)
 
 	// Determine extension based on database type
-	extension := c.getBackupExtension(database.Type)
+	extension := c.getBackupExtension(database)
 
 	return fmt.Sprintf("%s_backup_%s%s", safeName, timestamp, extension)
 }
 
 func (c *BackupController) getBackupExtension(
-	dbType databases.DatabaseType,
+	database *databases.Database,
 ) string {
-	switch dbType {
+	switch database.Type {
 	case databases.DatabaseTypeMysql, databases.DatabaseTypeMariadb:
 		return ".sql.zst"
 	case databases.DatabaseTypePostgres:
@@ -341,6 +777,22 @@ func (c *BackupController) getBackupExtension(
 		return ".dump"
 	case databases.DatabaseTypeMongodb:
 		return ".archive"
+	case databases.DatabaseTypeFilesystem:
+		return getFilesystemBackupExtension(database)
+	case databases.DatabaseTypeElasticsearch:
+		// manifest of the snapshot, the data stays in the snapshot repository
+		return ".snapshot.json"
+	case databases.DatabaseTypeInfluxdb:
+		// tar of the influx backup directory, shards in it are gzipped already
+		return ".influx.tar"
+	case databases.DatabaseTypeCassandra:
+		// tar of SSTables of all nodes, they are compressed by Cassandra already
+		return ".cassandra.tar"
+	case databases.DatabaseTypeCockroachdb:
+		// manifest of the backup collection written by the cluster into the storage
+		return ".cockroachdb.json"
+	case databases.DatabaseTypeNeo4j:
+		return getNeo4jBackupExtension(database)
 	default:
 		return ".backup"
 	}
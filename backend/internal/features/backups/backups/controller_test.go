@@ -18,6 +18,8 @@ import (
 
 	"databasus-backend/internal/config"
 	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	usecases_common "databasus-backend/internal/features/backups/backups/common"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_download "databasus-backend/internal/features/backups/backups/download"
 	backups_config "databasus-backend/internal/features/backups/config"
@@ -132,6 +134,60 @@ func Test_GetBackups_PermissionsEnforced(t *testing.T) {
 	}
 }
 
+func Test_GetStorageBackups_ReturnsPageWithSizeAggregates(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database, _, storage := createTestDatabaseWithBackups(workspace, owner, router)
+	createTestBackup(database, owner)
+
+	inProgressBackup := &backups_core.Backup{
+		ID:         uuid.New(),
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+		Status:     backups_core.BackupStatusInProgress,
+		CreatedAt:  time.Now().UTC(),
+	}
+	repo := &backups_core.BackupRepository{}
+	assert.NoError(t, repo.Save(inProgressBackup))
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var response GetStorageBackupsResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/backups?limit=2", storage.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Backups, 2)
+	assert.Equal(t, int64(3), response.Total)
+	assert.Equal(t, 2, response.Limit)
+	assert.Equal(t, 21.0, response.TotalSizeMb)
+	assert.Len(t, response.Databases, 1)
+	assert.Equal(t, database.ID, response.Databases[0].DatabaseID)
+	assert.Equal(t, int64(3), response.Databases[0].BackupsCount)
+	assert.Equal(t, 21.0, response.Databases[0].TotalSizeMb)
+
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/backups", storage.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusForbidden,
+	)
+}
+
 func Test_CreateBackup_PermissionsEnforced(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -1244,6 +1300,382 @@ func Test_GenerateDownloadToken_BlockedWhenDownloadInProgress(t *testing.T) {
 	workspaces_testing.RemoveTestWorkspace(workspace, router)
 }
 
+func Test_ShareLink_RecipientDownloads_DownloadsCountedAndLimited(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	recipient := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		recipient,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var link backups_download.BackupShareLink
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/share-links", backup.ID.String()),
+		"Bearer "+owner.Token,
+		backups_download.CreateShareLinkRequest{
+			RecipientUserID: recipient.UserID,
+			MaxDownloads:    1,
+		},
+		http.StatusOK,
+		&link,
+	)
+	assert.Equal(t, recipient.UserID, link.RecipientUserID)
+
+	// only the recipient can download through the link
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/share-links/%s/download-token", link.ID.String()),
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusBadRequest,
+	)
+
+	var tokenResponse backups_download.GenerateDownloadTokenResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/share-links/%s/download-token", link.ID.String()),
+		"Bearer "+recipient.Token,
+		nil,
+		http.StatusOK,
+		&tokenResponse,
+	)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/file?token=%s", backup.ID.String(), tokenResponse.Token),
+		"",
+		http.StatusOK,
+	)
+
+	var links []backups_download.BackupShareLink
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/share-links", backup.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&links,
+	)
+	assert.Len(t, links, 1)
+	assert.Equal(t, 1, links[0].DownloadCount)
+	assert.NotNil(t, links[0].LastDownloadedAt)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/share-links/%s/download-token", link.ID.String()),
+		"Bearer "+recipient.Token,
+		nil,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "no downloads left")
+}
+
+func Test_ShareLink_WhenRevoked_RecipientCannotDownload(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	recipient := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		recipient,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var link backups_download.BackupShareLink
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/share-links", backup.ID.String()),
+		"Bearer "+owner.Token,
+		backups_download.CreateShareLinkRequest{RecipientUserID: recipient.UserID},
+		http.StatusOK,
+		&link,
+	)
+
+	var receivedLinks []backups_download.BackupShareLink
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backups/share-links/received",
+		"Bearer "+recipient.Token,
+		http.StatusOK,
+		&receivedLinks,
+	)
+	assert.Len(t, receivedLinks, 1)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/share-links/%s", link.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusNoContent,
+	)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backups/share-links/received",
+		"Bearer "+recipient.Token,
+		http.StatusOK,
+		&receivedLinks,
+	)
+	assert.Empty(t, receivedLinks)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/share-links/%s/download-token", link.ID.String()),
+		"Bearer "+recipient.Token,
+		nil,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "revoked")
+}
+
+func Test_CreateShareLink_WhenRecipientIsNotWorkspaceMember_ReturnsError(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/share-links", backup.ID.String()),
+		"Bearer "+owner.Token,
+		backups_download.CreateShareLinkRequest{RecipientUserID: outsider.UserID},
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "not a member of the workspace")
+}
+
+func Test_GetBackupRunLog_PermissionsEnforced(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+
+	runLogRepository := &backups_core.BackupRunLogRepository{}
+	err := runLogRepository.Save(&backups_core.BackupRunLog{
+		BackupID: backup.ID,
+		Phases: []usecases_common.BackupPhaseTiming{
+			{
+				Phase:      usecases_common.BackupPhaseDump,
+				StartedAt:  time.Now().UTC(),
+				DurationMs: 1500,
+				Bytes:      1024,
+				IsFinished: true,
+			},
+		},
+		ToolOutputTail: "pg_dump: dumping contents of table",
+		CreatedAt:      time.Now().UTC(),
+	})
+	assert.NoError(t, err)
+
+	var runLog backups_core.BackupRunLog
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/log", backup.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&runLog,
+	)
+	assert.Equal(t, 1, len(runLog.Phases))
+	assert.Equal(t, usecases_common.BackupPhaseDump, runLog.Phases[0].Phase)
+	assert.Equal(t, int64(1024), runLog.Phases[0].Bytes)
+	assert.Equal(t, "pg_dump: dumping contents of table", runLog.ToolOutputTail)
+
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	testResp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/log", backup.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "insufficient permissions")
+
+	// Cleanup
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_StreamBackupLogs_ReplaysRelayedLines(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+
+	logRelay := backuping.GetBackupLogRelay()
+	logRelay.PublishLine(backup.ID, "pg_dump: reading extensions")
+	logRelay.PublishLine(backup.ID, "pg_dump: dumping contents of table public.users")
+	logRelay.PublishEnd(backup.ID)
+
+	testResp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/jobs/%s/logs?follow=true", backup.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+	body := string(testResp.Body)
+	assert.Contains(t, body, "event:log")
+	assert.Contains(t, body, "pg_dump: reading extensions")
+	assert.Contains(t, body, "public.users")
+	assert.Contains(t, body, `"isEnd":true`)
+
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	testResp = test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/jobs/%s/logs", backup.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "insufficient permissions")
+
+	// Cleanup
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_GetDeadLetters_PermissionsEnforced(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+	deadLetter := createTestDeadLetter(backup)
+
+	var deadLetters []*backups_core.BackupDeadLetter
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/dead-letters?workspace_id=%s", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&deadLetters,
+	)
+	assert.Equal(t, 1, len(deadLetters))
+	assert.Equal(t, deadLetter.ID, deadLetters[0].ID)
+	assert.Equal(t, 1, len(deadLetters[0].Attempts))
+	assert.Equal(t, "connection refused", deadLetters[0].Attempts[0].FailMessage)
+
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	testResp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/dead-letters?workspace_id=%s", workspace.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "insufficient permissions")
+
+	// Cleanup
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_RequeueDeadLetter_PermissionsEnforced(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database, backup, storage := createTestDatabaseWithBackups(workspace, owner, router)
+	deadLetter := createTestDeadLetter(backup)
+
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/dead-letters/%s/requeue", deadLetter.ID.String()),
+		"Bearer "+viewer.Token,
+		nil,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "insufficient permissions")
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/dead-letters/%s/requeue", deadLetter.ID.String()),
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+	)
+
+	var deadLetters []*backups_core.BackupDeadLetter
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/dead-letters?workspace_id=%s", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&deadLetters,
+	)
+	assert.Equal(t, 0, len(deadLetters))
+
+	// Cleanup
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
 func createTestRouter() *gin.Engine {
 	return CreateTestRouter()
 }
@@ -1371,12 +1803,20 @@ func createTestBackup(
 		panic("No storage found for workspace")
 	}
 
-	// Filter out system storages
+	// Filter out system storages. Responses have secrets hidden, so the storage used to save
+	// the file is loaded as a model
 	var nonSystemStorages []*storages.Storage
-	for _, storage := range loadedStorages {
-		if !storage.IsSystem {
-			nonSystemStorages = append(nonSystemStorages, storage)
+	for _, loadedStorage := range loadedStorages {
+		if loadedStorage.IsSystem {
+			continue
 		}
+
+		storage, err := storages.GetStorageService().GetStorageByID(loadedStorage.ID)
+		if err != nil {
+			panic(err)
+		}
+
+		nonSystemStorages = append(nonSystemStorages, storage)
 	}
 	if len(nonSystemStorages) == 0 {
 		panic("No non-system storage found for workspace")
@@ -1416,6 +1856,35 @@ func createTestBackup(
 	return backup
 }
 
+func createTestDeadLetter(backup *backups_core.Backup) *backups_core.BackupDeadLetter {
+	failMessage := "connection refused"
+	backup.Status = backups_core.BackupStatusFailed
+	backup.FailMessage = &failMessage
+
+	repo := &backups_core.BackupRepository{}
+	if err := repo.Save(backup); err != nil {
+		panic(err)
+	}
+
+	deadLetter := &backups_core.BackupDeadLetter{
+		BackupID:    &backup.ID,
+		DatabaseID:  backup.DatabaseID,
+		FailMessage: failMessage,
+		Attempts: []backups_core.BackupDeadLetterAttempt{
+			{BackupID: backup.ID, FailMessage: failMessage, CreatedAt: backup.CreatedAt},
+		},
+		AttemptsCount: 1,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	deadLetterRepo := &backups_core.BackupDeadLetterRepository{}
+	if err := deadLetterRepo.CreateIfAbsent(deadLetter); err != nil {
+		panic(err)
+	}
+
+	return deadLetter
+}
+
 func createExpiredDownloadToken(backupID, userID uuid.UUID) string {
 	tokenService := GetBackupService().downloadTokenService
 	token, err := tokenService.Generate(backupID, userID)
@@ -0,0 +1,38 @@
+package backups_core
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupDeadLetter is recorded once the scheduler has given up on a database's backups, so
+// the failure stays visible after newer scheduled runs replace it as the latest backup
+type BackupDeadLetter struct {
+	ID uuid.UUID `json:"id" gorm:"column:id;type:uuid;primaryKey"`
+
+	BackupID   *uuid.UUID `json:"backupId"   gorm:"column:backup_id;type:uuid"`
+	DatabaseID uuid.UUID  `json:"databaseId" gorm:"column:database_id;type:uuid;not null"`
+
+	FailMessage   string                    `json:"failMessage"   gorm:"column:fail_message;type:text;not null"`
+	Attempts      []BackupDeadLetterAttempt `json:"attempts"      gorm:"column:attempts;type:text;not null;serializer:json"`
+	AttemptsCount int                       `json:"attemptsCount" gorm:"column:attempts_count;not null"`
+
+	CreatedAt        time.Time  `json:"createdAt"                  gorm:"column:created_at"`
+	RequeuedAt       *time.Time `json:"requeuedAt,omitempty"       gorm:"column:requeued_at"`
+	RequeuedByUserID *uuid.UUID `json:"requeuedByUserId,omitempty" gorm:"column:requeued_by_user_id;type:uuid"`
+}
+
+type BackupDeadLetterAttempt struct {
+	BackupID    uuid.UUID `json:"backupId"`
+	FailMessage string    `json:"failMessage"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (BackupDeadLetter) TableName() string {
+	return "backup_dead_letters"
+}
+
+func (d *BackupDeadLetter) IsRequeued() bool {
+	return d.RequeuedAt != nil
+}
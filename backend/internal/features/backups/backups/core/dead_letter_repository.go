@@ -0,0 +1,87 @@
+package backups_core
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BackupDeadLetterRepository struct{}
+
+// CreateIfAbsent is idempotent per backup because the scheduler re-evaluates the same
+// failed backup on every tick until a new one is started
+func (r *BackupDeadLetterRepository) CreateIfAbsent(deadLetter *BackupDeadLetter) error {
+	if deadLetter.BackupID == nil || deadLetter.DatabaseID == uuid.Nil {
+		return errors.New("backup ID and database ID are required")
+	}
+
+	if deadLetter.ID == uuid.Nil {
+		deadLetter.ID = uuid.New()
+	}
+
+	return storage.
+		GetDb().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "backup_id"}},
+			DoNothing: true,
+		}).
+		Create(deadLetter).
+		Error
+}
+
+func (r *BackupDeadLetterRepository) Save(deadLetter *BackupDeadLetter) error {
+	return storage.GetDb().Save(deadLetter).Error
+}
+
+func (r *BackupDeadLetterRepository) FindByID(id uuid.UUID) (*BackupDeadLetter, error) {
+	var deadLetter BackupDeadLetter
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", id).
+		First(&deadLetter).Error; err != nil {
+		return nil, err
+	}
+
+	return &deadLetter, nil
+}
+
+func (r *BackupDeadLetterRepository) FindByBackupID(backupID uuid.UUID) (*BackupDeadLetter, error) {
+	var deadLetter BackupDeadLetter
+
+	if err := storage.
+		GetDb().
+		Where("backup_id = ?", backupID).
+		First(&deadLetter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &deadLetter, nil
+}
+
+func (r *BackupDeadLetterRepository) FindPendingByDatabaseIDs(
+	databaseIDs []uuid.UUID,
+) ([]*BackupDeadLetter, error) {
+	deadLetters := make([]*BackupDeadLetter, 0)
+
+	if len(databaseIDs) == 0 {
+		return deadLetters, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Where("database_id IN ? AND requeued_at IS NULL", databaseIDs).
+		Order("created_at DESC").
+		Find(&deadLetters).Error; err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
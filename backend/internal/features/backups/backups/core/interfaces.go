@@ -13,13 +13,23 @@ import (
 )
 
 type NotificationSender interface {
-	SendNotification(
+	SendEventNotification(
 		notifier *notifiers.Notifier,
+		eventType notifiers.NotificationEventType,
 		title string,
 		message string,
 	)
 }
 
+type BackupFailureOwnerNotifier interface {
+	NotifyOwnerAboutBackupFailure(
+		ownerUserID uuid.UUID,
+		databaseName string,
+		workspaceName string,
+		errorMessage string,
+	) error
+}
+
 type CreateBackupUsecase interface {
 	Execute(
 		ctx context.Context,
@@ -28,9 +38,35 @@ type CreateBackupUsecase interface {
 		database *databases.Database,
 		storage *storages.Storage,
 		backupProgressListener func(completedMBs float64),
+		runRecorder *usecases_common.BackupRunRecorder,
 	) (*usecases_common.BackupMetadata, error)
 }
 
+// BackupCompletedListener is called by the backuper after a backup is saved as completed
+type BackupCompletedListener interface {
+	OnBackupCompleted(backup *Backup)
+}
+
 type BackupRemoveListener interface {
 	OnBeforeBackupRemove(backup *Backup) error
 }
+
+// BackupStorageSelector may upload a backup to another storage than the configured one,
+// e.g. the closest member of a mirror group. It returns the given storage to keep it
+type BackupStorageSelector interface {
+	SelectBackupStorage(
+		nodeID uuid.UUID,
+		database *databases.Database,
+		storage *storages.Storage,
+	) *storages.Storage
+}
+
+// BackupPriorityProvider orders due backups, higher priorities are started first and get
+// the least busy nodes
+type BackupPriorityProvider interface {
+	GetBackupPriorities(databaseIDs []uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+type BackupQuotaChecker interface {
+	CheckCanStartBackup(databaseID uuid.UUID) error
+}
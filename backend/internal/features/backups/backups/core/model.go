@@ -2,6 +2,7 @@ package backups_core
 
 import (
 	backups_config "databasus-backend/internal/features/backups/config"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,5 +26,42 @@ type Backup struct {
 	EncryptionIV   *string                         `json:"-"          gorm:"column:encryption_iv"`
 	Encryption     backups_config.BackupEncryption `json:"encryption" gorm:"column:encryption;type:text;not null;default:'NONE'"`
 
+	// Tags are set when the backup is started and matched by retention holds of the config
+	Tags       []string `json:"tags" gorm:"-"`
+	TagsString string   `json:"-"    gorm:"column:tags;type:text;not null;default:''"`
+
+	// IsManual is set for backups started by users, scheduled backups and retries are not
+	IsManual bool `json:"isManual" gorm:"column:is_manual;type:boolean;not null;default:false"`
+
+	// FileName is the name of the file in the storage rendered from a naming template, the
+	// file is named by the backup ID if empty
+	FileName *string `json:"fileName" gorm:"column:file_name;type:text"`
+
+	// Checksum is sha256 of the file, it is set for backups whose file is uploaded by
+	// Databasus. Engines writing into storages themselves, like CockroachDB, leave it empty
+	Checksum *string `json:"checksum" gorm:"column:checksum;type:text"`
+	// Signature is the base64 Ed25519 signature of GetSignatureMessage by the signing key
+	// of the instance, restores check it to detect files changed in the storage
+	Signature *string `json:"signature" gorm:"column:signature;type:text"`
+	// AdoptedFromFile is the name of the existing dump the backup was copied from
+	AdoptedFromFile *string `json:"adoptedFromFile" gorm:"column:adopted_from_file;type:text"`
+
 	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
 }
+
+// GetSignatureMessage is the content signed for the backup file. It binds the checksum to
+// the backup ID, so a file cannot be swapped with another signed backup. Nil without checksum
+func (b *Backup) GetSignatureMessage() []byte {
+	if b.Checksum == nil {
+		return nil
+	}
+
+	return fmt.Appendf(nil, "databasus-backup:v1:%s:%s", b.ID, *b.Checksum)
+}
+
+// DatabaseBackupsSize is the count and size of backups of one database in a storage
+type DatabaseBackupsSize struct {
+	DatabaseID   uuid.UUID `json:"databaseId"   gorm:"column:database_id"`
+	BackupsCount int64     `json:"backupsCount" gorm:"column:backups_count"`
+	TotalSizeMb  float64   `json:"totalSizeMb"  gorm:"column:total_size_mb"`
+}
@@ -98,6 +98,62 @@ func (r *BackupRepository) FindLastByDatabaseID(databaseID uuid.UUID) (*Backup,
 	return &backup, nil
 }
 
+// FindLastByDatabaseIDs returns the newest backup of each database in one query, databases
+// without backups are absent from the map
+func (r *BackupRepository) FindLastByDatabaseIDs(
+	databaseIDs []uuid.UUID,
+) (map[uuid.UUID]*Backup, error) {
+	lastBackups := make(map[uuid.UUID]*Backup, len(databaseIDs))
+	if len(databaseIDs) == 0 {
+		return lastBackups, nil
+	}
+
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Select("DISTINCT ON (database_id) *").
+		Where("database_id IN ?", databaseIDs).
+		Order("database_id, created_at DESC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	for _, backup := range backups {
+		lastBackups[backup.DatabaseID] = backup
+	}
+
+	return lastBackups, nil
+}
+
+// FindLastCompletedByDatabaseIDs returns the newest completed backup of each database,
+// databases without completed backups are absent from the map
+func (r *BackupRepository) FindLastCompletedByDatabaseIDs(
+	databaseIDs []uuid.UUID,
+) (map[uuid.UUID]*Backup, error) {
+	lastBackups := make(map[uuid.UUID]*Backup, len(databaseIDs))
+	if len(databaseIDs) == 0 {
+		return lastBackups, nil
+	}
+
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Select("DISTINCT ON (database_id) *").
+		Where("database_id IN ? AND status = ?", databaseIDs, BackupStatusCompleted).
+		Order("database_id, created_at DESC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	for _, backup := range backups {
+		lastBackups[backup.DatabaseID] = backup
+	}
+
+	return lastBackups, nil
+}
+
 func (r *BackupRepository) FindByID(id uuid.UUID) (*Backup, error) {
 	var backup Backup
 
@@ -111,6 +167,22 @@ func (r *BackupRepository) FindByID(id uuid.UUID) (*Backup, error) {
 	return &backup, nil
 }
 
+func (r *BackupRepository) FindByIDs(ids []uuid.UUID) ([]*Backup, error) {
+	backups := make([]*Backup, 0, len(ids))
+	if len(ids) == 0 {
+		return backups, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Where("id IN ?", ids).
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
 func (r *BackupRepository) FindByStatus(status BackupStatus) ([]*Backup, error) {
 	var backups []*Backup
 
@@ -159,6 +231,46 @@ func (r *BackupRepository) FindByDatabaseIdAndStatus(
 	return backups, nil
 }
 
+func (r *BackupRepository) FindByDatabaseIdAndStatusCreatedAfter(
+	databaseID uuid.UUID,
+	status BackupStatus,
+	createdAfter time.Time,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Where(
+			"database_id = ? AND status = ? AND created_at > ?",
+			databaseID,
+			status,
+			createdAfter,
+		).
+		Order("created_at DESC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+func (r *BackupRepository) FindByDatabaseIDCreatedAfter(
+	databaseID uuid.UUID,
+	createdAfter time.Time,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Where("database_id = ? AND created_at > ?", databaseID, createdAfter).
+		Order("created_at ASC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
 func (r *BackupRepository) DeleteByID(id uuid.UUID) error {
 	return storage.GetDb().Delete(&Backup{}, "id = ?", id).Error
 }
@@ -228,6 +340,79 @@ func (r *BackupRepository) GetTotalSizeByDatabase(databaseID uuid.UUID) (float64
 	return totalSize, nil
 }
 
+func (r *BackupRepository) CountByStorageID(storageID uuid.UUID) (int64, error) {
+	var count int64
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Where("storage_id = ?", storageID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *BackupRepository) FindByStorageIDWithPagination(
+	storageID uuid.UUID,
+	limit, offset int,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Where("storage_id = ?", storageID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+// GetSizesByDatabaseForStorage sums backups of the storage per database, backups in
+// progress are counted but have no size yet
+func (r *BackupRepository) GetSizesByDatabaseForStorage(
+	storageID uuid.UUID,
+) ([]*DatabaseBackupsSize, error) {
+	var sizes []*DatabaseBackupsSize
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Select(
+			"database_id, COUNT(*) AS backups_count, "+
+				"COALESCE(SUM(backup_size_mb) FILTER (WHERE status != ?), 0) AS total_size_mb",
+			BackupStatusInProgress,
+		).
+		Where("storage_id = ?", storageID).
+		Group("database_id").
+		Order("total_size_mb DESC").
+		Scan(&sizes).Error; err != nil {
+		return nil, err
+	}
+
+	return sizes, nil
+}
+
+func (r *BackupRepository) GetTotalSizeByStorage(storageID uuid.UUID) (float64, error) {
+	var totalSize float64
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Select("COALESCE(SUM(backup_size_mb), 0)").
+		Where("storage_id = ? AND status != ?", storageID, BackupStatusInProgress).
+		Scan(&totalSize).Error; err != nil {
+		return 0, err
+	}
+
+	return totalSize, nil
+}
+
 func (r *BackupRepository) FindOldestByDatabaseExcludingInProgress(
 	databaseID uuid.UUID,
 	limit int,
@@ -245,3 +430,43 @@ func (r *BackupRepository) FindOldestByDatabaseExcludingInProgress(
 
 	return backups, nil
 }
+
+// FindAdoptedFileNames returns names of files already adopted from the storage for the
+// database, failed adoptions are not counted so they can be retried
+func (r *BackupRepository) FindAdoptedFileNames(
+	databaseID uuid.UUID,
+	storageID uuid.UUID,
+) ([]string, error) {
+	var fileNames []string
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Where(
+			"database_id = ? AND storage_id = ? AND adopted_from_file IS NOT NULL AND status != ?",
+			databaseID,
+			storageID,
+			BackupStatusFailed,
+		).
+		Pluck("adopted_from_file", &fileNames).Error; err != nil {
+		return nil, err
+	}
+
+	return fileNames, nil
+}
+
+// FindStorageIDsWithCompletedBackups returns storages holding at least one completed backup
+func (r *BackupRepository) FindStorageIDsWithCompletedBackups() ([]uuid.UUID, error) {
+	var storageIDs []uuid.UUID
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Where("status = ?", BackupStatusCompleted).
+		Distinct().
+		Pluck("storage_id", &storageIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return storageIDs, nil
+}
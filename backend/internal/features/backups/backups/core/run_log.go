@@ -0,0 +1,22 @@
+package backups_core
+
+import (
+	"time"
+
+	common "databasus-backend/internal/features/backups/backups/common"
+
+	"github.com/google/uuid"
+)
+
+type BackupRunLog struct {
+	BackupID uuid.UUID `json:"backupId" gorm:"column:backup_id;type:uuid;primaryKey"`
+
+	Phases         []common.BackupPhaseTiming `json:"phases"         gorm:"column:phases;type:text;not null;serializer:json"`
+	ToolOutputTail string                     `json:"toolOutputTail" gorm:"column:tool_output_tail;type:text;not null"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (BackupRunLog) TableName() string {
+	return "backup_run_logs"
+}
@@ -0,0 +1,36 @@
+package backups_core
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupRunLogRepository struct{}
+
+func (r *BackupRunLogRepository) Save(runLog *BackupRunLog) error {
+	if runLog.BackupID == uuid.Nil {
+		return errors.New("backup ID is required")
+	}
+
+	return storage.GetDb().Save(runLog).Error
+}
+
+func (r *BackupRunLogRepository) FindByBackupID(backupID uuid.UUID) (*BackupRunLog, error) {
+	var runLog BackupRunLog
+
+	if err := storage.
+		GetDb().
+		Where("backup_id = ?", backupID).
+		First(&runLog).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &runLog, nil
+}
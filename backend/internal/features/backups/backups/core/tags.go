@@ -0,0 +1,53 @@
+package backups_core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MaxBackupTagsCount = 20
+	MaxBackupTagLength = 100
+)
+
+// ValidateBackupTags checks tags set on a backup when it is started. Tags are stored
+// comma separated and matched by retention holds like file names, so ',' and '/' are
+// not allowed
+func ValidateBackupTags(tags []string) error {
+	if len(tags) > MaxBackupTagsCount {
+		return fmt.Errorf("backup cannot have more than %d tags", MaxBackupTagsCount)
+	}
+
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return errors.New("backup tag cannot be empty")
+		}
+
+		if len(tag) > MaxBackupTagLength {
+			return fmt.Errorf("backup tag cannot be longer than %d characters", MaxBackupTagLength)
+		}
+
+		if strings.ContainsAny(tag, ",/") {
+			return fmt.Errorf("backup tag %q cannot contain ',' or '/'", tag)
+		}
+	}
+
+	return nil
+}
+
+func (b *Backup) BeforeSave(tx *gorm.DB) error {
+	b.TagsString = strings.Join(b.Tags, ",")
+	return nil
+}
+
+func (b *Backup) AfterFind(tx *gorm.DB) error {
+	b.Tags = []string{}
+	if b.TagsString != "" {
+		b.Tags = strings.Split(b.TagsString, ",")
+	}
+
+	return nil
+}
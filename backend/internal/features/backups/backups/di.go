@@ -9,12 +9,15 @@ import (
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_download "databasus-backend/internal/features/backups/backups/download"
 	"databasus-backend/internal/features/backups/backups/usecases"
+	usecases_cockroachdb "databasus-backend/internal/features/backups/backups/usecases/cockroachdb"
+	usecases_elasticsearch "databasus-backend/internal/features/backups/backups/usecases/elasticsearch"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
 	task_cancellation "databasus-backend/internal/features/tasks/cancellation"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
@@ -22,12 +25,20 @@ import (
 
 var backupRepository = &backups_core.BackupRepository{}
 
+var backupDeadLetterRepository = &backups_core.BackupDeadLetterRepository{}
+
+var backupRunLogRepository = &backups_core.BackupRunLogRepository{}
+
+var backupShareLinkRepository = &backups_download.BackupShareLinkRepository{}
+
 var taskCancelManager = task_cancellation.GetTaskCancelManager()
 
 var backupService = &BackupService{
 	databases.GetDatabaseService(),
 	storages.GetStorageService(),
 	backupRepository,
+	backupDeadLetterRepository,
+	backupRunLogRepository,
 	notifiers.GetNotifierService(),
 	notifiers.GetNotifierService(),
 	backups_config.GetBackupConfigService(),
@@ -42,6 +53,9 @@ var backupService = &BackupService{
 	backups_download.GetDownloadTokenService(),
 	backuping.GetBackupsScheduler(),
 	backuping.GetBackupCleaner(),
+	backuping.GetBackupLogRelay(),
+	backupShareLinkRepository,
+	users_services.GetUserService(),
 }
 
 var backupController = &BackupController{
@@ -70,8 +84,16 @@ func SetupDependencies() {
 			SetDatabaseStorageChangeListener(backupService)
 
 		databases.GetDatabaseService().AddDbRemoveListener(backupService)
+		storages.GetStorageService().SetStorageProdReferenceChecker(backupService)
 		databases.GetDatabaseService().AddDbCopyListener(backups_config.GetBackupConfigService())
 
+		backuping.GetBackupCleaner().AddBackupRemoveListener(
+			usecases_elasticsearch.GetSnapshotRemover(),
+		)
+		backuping.GetBackupCleaner().AddBackupRemoveListener(
+			usecases_cockroachdb.GetCollectionRemover(),
+		)
+
 		isSetup.Store(true)
 	})
 
@@ -2,6 +2,14 @@ package backups_download
 
 import "github.com/google/uuid"
 
+type CreateShareLinkRequest struct {
+	RecipientUserID uuid.UUID `json:"recipientUserId" binding:"required"`
+	// ExpiresInHours defaults to 24, links live for 7 days at most
+	ExpiresInHours int    `json:"expiresInHours"`
+	MaxDownloads   int    `json:"maxDownloads"`
+	Note           string `json:"note"`
+}
+
 type GenerateDownloadTokenResponse struct {
 	Token    string    `json:"token"`
 	Filename string    `json:"filename"`
@@ -14,6 +14,9 @@ type DownloadToken struct {
 	ExpiresAt time.Time `json:"expiresAt" gorm:"column:expires_at;not null"`
 	Used      bool      `json:"used"      gorm:"column:used;not null;default:false"`
 	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at;not null"`
+
+	// ShareLinkID is set for tokens of a share link, their downloads are counted on it
+	ShareLinkID *uuid.UUID `json:"shareLinkId" gorm:"column:share_link_id;type:uuid"`
 }
 
 func (DownloadToken) TableName() string {
@@ -16,6 +16,17 @@ type DownloadTokenService struct {
 }
 
 func (s *DownloadTokenService) Generate(backupID, userID uuid.UUID) (string, error) {
+	return s.generate(backupID, userID, nil)
+}
+
+func (s *DownloadTokenService) GenerateForShareLink(link *BackupShareLink) (string, error) {
+	return s.generate(link.BackupID, link.RecipientUserID, &link.ID)
+}
+
+func (s *DownloadTokenService) generate(
+	backupID, userID uuid.UUID,
+	shareLinkID *uuid.UUID,
+) (string, error) {
 	if s.downloadTracker.IsDownloadInProgress(userID) {
 		return "", ErrDownloadAlreadyInProgress
 	}
@@ -23,11 +34,12 @@ func (s *DownloadTokenService) Generate(backupID, userID uuid.UUID) (string, err
 	token := GenerateSecureToken()
 
 	downloadToken := &DownloadToken{
-		Token:     token,
-		BackupID:  backupID,
-		UserID:    userID,
-		ExpiresAt: time.Now().UTC().Add(5 * time.Minute),
-		Used:      false,
+		Token:       token,
+		BackupID:    backupID,
+		UserID:      userID,
+		ShareLinkID: shareLinkID,
+		ExpiresAt:   time.Now().UTC().Add(5 * time.Minute),
+		Used:        false,
 	}
 
 	if err := s.repository.Create(downloadToken); err != nil {
@@ -0,0 +1,44 @@
+package backups_download
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultShareLinkExpiration = 24 * time.Hour
+	MaxShareLinkExpiration     = 7 * 24 * time.Hour
+)
+
+// BackupShareLink lets one workspace member download a backup another member shared. It
+// is not a pre-signed URL: the recipient signs in, access to the workspace is checked on
+// every download and downloads go through the usual download tokens
+type BackupShareLink struct {
+	ID              uuid.UUID `json:"id"              gorm:"column:id;type:uuid;primaryKey"`
+	BackupID        uuid.UUID `json:"backupId"        gorm:"column:backup_id;type:uuid;not null"`
+	CreatedByUserID uuid.UUID `json:"createdByUserId" gorm:"column:created_by_user_id;type:uuid;not null"`
+	RecipientUserID uuid.UUID `json:"recipientUserId" gorm:"column:recipient_user_id;type:uuid;not null"`
+	Note            string    `json:"note"            gorm:"column:note;type:text;not null;default:''"`
+
+	// MaxDownloads limits downloads of the link, 0 allows any count until it expires
+	MaxDownloads     int        `json:"maxDownloads"     gorm:"column:max_downloads;not null;default:0"`
+	DownloadCount    int        `json:"downloadCount"    gorm:"column:download_count;not null;default:0"`
+	LastDownloadedAt *time.Time `json:"lastDownloadedAt" gorm:"column:last_downloaded_at"`
+
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"column:expires_at;not null"`
+	RevokedAt *time.Time `json:"revokedAt" gorm:"column:revoked_at"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"column:created_at;not null"`
+}
+
+func (BackupShareLink) TableName() string {
+	return "backup_share_links"
+}
+
+func (l *BackupShareLink) IsActive(now time.Time) bool {
+	if l.RevokedAt != nil || now.After(l.ExpiresAt) {
+		return false
+	}
+
+	return l.MaxDownloads == 0 || l.DownloadCount < l.MaxDownloads
+}
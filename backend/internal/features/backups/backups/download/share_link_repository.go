@@ -0,0 +1,95 @@
+package backups_download
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupShareLinkRepository struct{}
+
+func (r *BackupShareLinkRepository) Create(link *BackupShareLink) error {
+	if link.ID == uuid.Nil {
+		link.ID = uuid.New()
+	}
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now().UTC()
+	}
+	return storage.GetDb().Create(link).Error
+}
+
+func (r *BackupShareLinkRepository) FindByID(id uuid.UUID) (*BackupShareLink, error) {
+	var link BackupShareLink
+
+	if err := storage.GetDb().Where("id = ?", id).First(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+func (r *BackupShareLinkRepository) FindByBackupID(backupID uuid.UUID) ([]*BackupShareLink, error) {
+	var links []*BackupShareLink
+
+	if err := storage.GetDb().
+		Where("backup_id = ?", backupID).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// FindActiveByRecipientID returns links the user can still download with
+func (r *BackupShareLinkRepository) FindActiveByRecipientID(
+	recipientUserID uuid.UUID,
+	now time.Time,
+) ([]*BackupShareLink, error) {
+	var links []*BackupShareLink
+
+	if err := storage.GetDb().
+		Where("recipient_user_id = ?", recipientUserID).
+		Where("revoked_at IS NULL AND expires_at > ?", now).
+		Where("max_downloads = 0 OR download_count < max_downloads").
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *BackupShareLinkRepository) Revoke(id uuid.UUID, now time.Time) error {
+	return storage.GetDb().
+		Model(&BackupShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", now).Error
+}
+
+// IncrementDownloadCount counts a download if the link is still active. It returns false
+// when the link was revoked, expired or used up meanwhile
+func (r *BackupShareLinkRepository) IncrementDownloadCount(
+	id uuid.UUID,
+	now time.Time,
+) (bool, error) {
+	result := storage.GetDb().
+		Model(&BackupShareLink{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, now).
+		Where("max_downloads = 0 OR download_count < max_downloads").
+		Updates(map[string]any{
+			"download_count":     gorm.Expr("download_count + 1"),
+			"last_downloaded_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -12,6 +12,14 @@ type GetBackupsRequest struct {
 	Offset     int    `form:"offset"`
 }
 
+type StreamBackupLogsRequest struct {
+	IsFollow bool `form:"follow"`
+}
+
+type GetDeadLettersRequest struct {
+	WorkspaceID string `form:"workspace_id" binding:"required"`
+}
+
 type GetBackupsResponse struct {
 	Backups []*backups_core.Backup `json:"backups"`
 	Total   int64                  `json:"total"`
@@ -19,6 +27,22 @@ type GetBackupsResponse struct {
 	Offset  int                    `json:"offset"`
 }
 
+type GetStorageBackupsRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+// GetStorageBackupsResponse is a page of backups in the storage. Aggregates cover all
+// backups of the storage, not only the page
+type GetStorageBackupsResponse struct {
+	Backups     []*backups_core.Backup              `json:"backups"`
+	Total       int64                               `json:"total"`
+	Limit       int                                 `json:"limit"`
+	Offset      int                                 `json:"offset"`
+	TotalSizeMb float64                             `json:"totalSizeMb"`
+	Databases   []*backups_core.DatabaseBackupsSize `json:"databases"`
+}
+
 type DecryptionReaderCloser struct {
 	*encryption.DecryptionReader
 	BaseReader io.ReadCloser
@@ -1,11 +1,13 @@
 package backups
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"time"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
 	"databasus-backend/internal/features/backups/backups/backuping"
@@ -14,26 +16,38 @@ import (
 	"databasus-backend/internal/features/backups/backups/encryption"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/environments"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
 	task_cancellation "databasus-backend/internal/features/tasks/cancellation"
+	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	util_encryption "databasus-backend/internal/util/encryption"
 
 	"github.com/google/uuid"
 )
 
+const (
+	logStreamBufferSize          = 256
+	logStreamStatusCheckInterval = 30 * time.Second
+)
+
 type BackupService struct {
-	databaseService     *databases.DatabaseService
-	storageService      *storages.StorageService
-	backupRepository    *backups_core.BackupRepository
-	notifierService     *notifiers.NotifierService
-	notificationSender  backups_core.NotificationSender
-	backupConfigService *backups_config.BackupConfigService
-	secretKeyService    *encryption_secrets.SecretKeyService
-	fieldEncryptor      util_encryption.FieldEncryptor
+	databaseService            *databases.DatabaseService
+	storageService             *storages.StorageService
+	backupRepository           *backups_core.BackupRepository
+	backupDeadLetterRepository *backups_core.BackupDeadLetterRepository
+	backupRunLogRepository     *backups_core.BackupRunLogRepository
+	notifierService            *notifiers.NotifierService
+	notificationSender         backups_core.NotificationSender
+	backupConfigService        *backups_config.BackupConfigService
+	secretKeyService           *encryption_secrets.SecretKeyService
+	fieldEncryptor             util_encryption.FieldEncryptor
 
 	createBackupUseCase backups_core.CreateBackupUsecase
 
@@ -47,6 +61,9 @@ type BackupService struct {
 	downloadTokenService   *backups_download.DownloadTokenService
 	backupSchedulerService *backuping.BackupsScheduler
 	backupCleaner          *backuping.BackupCleaner
+	backupLogRelay         *backuping.BackupLogRelay
+	shareLinkRepository    *backups_download.BackupShareLinkRepository
+	userService            *users_services.UserService
 }
 
 func (s *BackupService) AddBackupRemoveListener(listener backups_core.BackupRemoveListener) {
@@ -74,7 +91,12 @@ func (s *BackupService) OnBeforeDatabaseRemove(databaseID uuid.UUID) error {
 func (s *BackupService) MakeBackupWithAuth(
 	user *users_models.User,
 	databaseID uuid.UUID,
+	tags []string,
 ) error {
+	if err := backups_core.ValidateBackupTags(tags); err != nil {
+		return err
+	}
+
 	database, err := s.databaseService.GetDatabaseByID(databaseID)
 	if err != nil {
 		return err
@@ -92,7 +114,7 @@ func (s *BackupService) MakeBackupWithAuth(
 		return errors.New("insufficient permissions to create backup for this database")
 	}
 
-	s.backupSchedulerService.StartBackup(databaseID, true)
+	s.backupSchedulerService.StartBackupWithTags(databaseID, true, tags)
 
 	s.auditLogService.WriteAuditLog(
 		fmt.Sprintf("Backup manually initiated for database: %s", database.Name),
@@ -244,6 +266,235 @@ func (s *BackupService) CancelBackup(
 	return nil
 }
 
+func (s *BackupService) GetBackupRunLog(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*backups_core.BackupRunLog, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot get backup log for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to access backup log for this database")
+	}
+
+	runLog, err := s.backupRunLogRepository.FindByBackupID(backupID)
+	if err != nil {
+		return nil, err
+	}
+	if runLog == nil {
+		return nil, errors.New("backup log is not available for this backup")
+	}
+
+	return runLog, nil
+}
+
+func (s *BackupService) GetBackupForLogs(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*backups_core.Backup, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot get backup logs for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to access backup logs for this database")
+	}
+
+	return backup, nil
+}
+
+// StreamBackupLogs replays the relayed history and, when following a running backup, keeps
+// delivering new lines until the backup ends or ctx is cancelled. The backup status is
+// re-checked periodically so a follower does not hang forever if the processing node dies
+func (s *BackupService) StreamBackupLogs(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	isFollow bool,
+	onEntry func(entry backuping.BackupLogEntry),
+) error {
+	isFollowing := isFollow && backup.Status == backups_core.BackupStatusInProgress
+
+	var liveEntries chan backuping.BackupLogEntry
+	if isFollowing {
+		liveEntries = make(chan backuping.BackupLogEntry, logStreamBufferSize)
+
+		stopFollowing, err := s.backupLogRelay.Follow(
+			ctx,
+			backup.ID,
+			func(entry backuping.BackupLogEntry) {
+				select {
+				case liveEntries <- entry:
+				default:
+				}
+			},
+		)
+		if err != nil {
+			return err
+		}
+		defer stopFollowing()
+	}
+
+	history, err := s.backupLogRelay.GetHistory(backup.ID)
+	if err != nil {
+		return err
+	}
+
+	var lastSeq int64
+	for _, entry := range history {
+		onEntry(entry)
+		lastSeq = entry.Seq
+
+		if entry.IsEnd {
+			return nil
+		}
+	}
+
+	if !isFollowing {
+		return nil
+	}
+
+	statusTicker := time.NewTicker(logStreamStatusCheckInterval)
+	defer statusTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entry := <-liveEntries:
+			if entry.Seq <= lastSeq {
+				continue
+			}
+
+			onEntry(entry)
+			lastSeq = entry.Seq
+
+			if entry.IsEnd {
+				return nil
+			}
+		case <-statusTicker.C:
+			currentBackup, err := s.backupRepository.FindByID(backup.ID)
+			if err != nil {
+				return err
+			}
+
+			if currentBackup.Status != backups_core.BackupStatusInProgress {
+				return nil
+			}
+		}
+	}
+}
+
+func (s *BackupService) GetDeadLetters(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*backups_core.BackupDeadLetter, error) {
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspace(user, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseIDs := make([]uuid.UUID, 0, len(workspaceDatabases))
+	for _, database := range workspaceDatabases {
+		databaseIDs = append(databaseIDs, database.ID)
+	}
+
+	return s.backupDeadLetterRepository.FindPendingByDatabaseIDs(databaseIDs)
+}
+
+// GetScheduleProjection shows load of backup nodes over the next 24h. It covers databases of
+// all workspaces, so it is available to admins only
+func (s *BackupService) GetScheduleProjection(
+	user *users_models.User,
+) (*backuping.ScheduleLoadProjection, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only admins can view the schedule projection")
+	}
+
+	return s.backupSchedulerService.ProjectLoad(time.Now().UTC())
+}
+
+func (s *BackupService) RequeueDeadLetter(
+	user *users_models.User,
+	deadLetterID uuid.UUID,
+) error {
+	deadLetter, err := s.backupDeadLetterRepository.FindByID(deadLetterID)
+	if err != nil {
+		return err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(deadLetter.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	if database.WorkspaceID == nil {
+		return errors.New("cannot requeue backup for database without workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to requeue backup for this database")
+	}
+
+	if deadLetter.IsRequeued() {
+		return errors.New("backup is already requeued")
+	}
+
+	now := time.Now().UTC()
+	deadLetter.RequeuedAt = &now
+	deadLetter.RequeuedByUserID = &user.ID
+
+	if err := s.backupDeadLetterRepository.Save(deadLetter); err != nil {
+		return err
+	}
+
+	s.backupSchedulerService.StartBackup(database.ID, true)
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Failed backup requeued for database: %s (dead letter ID: %s)",
+			database.Name,
+			deadLetterID.String(),
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
 func (s *BackupService) GetBackupFile(
 	user *users_models.User,
 	backupID uuid.UUID,
@@ -313,14 +564,7 @@ func (s *BackupService) deleteDbBackups(databaseID uuid.UUID) error {
 		return err
 	}
 
-	for _, dbBackup := range dbBackups {
-		err := s.backupCleaner.DeleteBackup(dbBackup)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return s.backupCleaner.DeleteBackups(dbBackups)
 }
 
 // GetBackupReader returns a reader for the backup file
@@ -336,6 +580,7 @@ func (s *BackupService) getBackupReader(backupID uuid.UUID) (io.ReadCloser, erro
 		return nil, fmt.Errorf("failed to get storage: %w", err)
 	}
 
+	storage.BindFileName(backup.ID, backup.FileName)
 	fileReader, err := storage.GetFile(s.fieldEncryptor, backup.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get backup file: %w", err)
@@ -522,19 +767,94 @@ func (s *BackupService) generateBackupFilename(
 ) string {
 	timestamp := backup.CreatedAt.Format("2006-01-02_15-04-05")
 	safeName := sanitizeFilename(database.Name)
-	extension := s.getBackupExtension(database.Type)
+	extension := s.getBackupExtension(database)
 	return fmt.Sprintf("%s_backup_%s%s", safeName, timestamp, extension)
 }
 
-func (s *BackupService) getBackupExtension(dbType databases.DatabaseType) string {
-	switch dbType {
+func (s *BackupService) getBackupExtension(database *databases.Database) string {
+	switch database.Type {
 	case databases.DatabaseTypeMysql, databases.DatabaseTypeMariadb:
 		return ".sql.zst"
 	case databases.DatabaseTypePostgres:
 		return ".dump"
 	case databases.DatabaseTypeMongodb:
 		return ".archive"
+	case databases.DatabaseTypeFilesystem:
+		return getFilesystemBackupExtension(database)
+	case databases.DatabaseTypeElasticsearch:
+		// manifest of the snapshot, the data stays in the snapshot repository
+		return ".snapshot.json"
+	case databases.DatabaseTypeInfluxdb:
+		// tar of the influx backup directory, shards in it are gzipped already
+		return ".influx.tar"
+	case databases.DatabaseTypeCassandra:
+		// tar of SSTables of all nodes, they are compressed by Cassandra already
+		return ".cassandra.tar"
+	case databases.DatabaseTypeCockroachdb:
+		// manifest of the backup collection written by the cluster into the storage
+		return ".cockroachdb.json"
+	case databases.DatabaseTypeNeo4j:
+		return getNeo4jBackupExtension(database)
 	default:
 		return ".backup"
 	}
 }
+
+// getFilesystemBackupExtension follows the current compression of the source. Backups
+// made before a change of compression keep the old format, restore detects it by content
+func getFilesystemBackupExtension(database *databases.Database) string {
+	if database.Filesystem == nil {
+		return ".tar"
+	}
+
+	switch database.Filesystem.Compression {
+	case filesystem.FilesystemCompressionZstd:
+		return ".tar.zst"
+	case filesystem.FilesystemCompressionGzip:
+		return ".tar.gz"
+	default:
+		return ".tar"
+	}
+}
+
+// getNeo4jBackupExtension follows the current backup mode, restore detects the format of
+// older backups by content
+func getNeo4jBackupExtension(database *databases.Database) string {
+	if database.Neo4j != nil && database.Neo4j.BackupMode == neo4j.Neo4jBackupModeOnline {
+		return ".neo4j.tar"
+	}
+
+	return ".neo4j.dump"
+}
+
+// IsStorageReferencedByProd reports whether the storage keeps backups of a database whose
+// resolved environment is PROD
+func (s *BackupService) IsStorageReferencedByProd(storageID uuid.UUID) (bool, error) {
+	storageBackups, err := s.backupRepository.FindByStorageID(storageID)
+	if err != nil {
+		return false, err
+	}
+
+	checkedDatabaseIDs := make(map[uuid.UUID]bool)
+	for _, backup := range storageBackups {
+		if checkedDatabaseIDs[backup.DatabaseID] {
+			continue
+		}
+		checkedDatabaseIDs[backup.DatabaseID] = true
+
+		database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+		if err != nil {
+			return false, err
+		}
+
+		environment, err := s.databaseService.GetEnvironment(database)
+		if err != nil {
+			return false, err
+		}
+		if environment == environments.EnvironmentProd {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,244 @@
+package backups
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_download "databasus-backend/internal/features/backups/backups/download"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrShareLinkNotActive = errors.New("share link is revoked, expired or has no downloads left")
+
+func (s *BackupService) CreateShareLink(
+	user *users_models.User,
+	backupID uuid.UUID,
+	request backups_download.CreateShareLinkRequest,
+) (*backups_download.BackupShareLink, error) {
+	backup, database, err := s.getBackupWithAccess(user, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if backup.Status != backups_core.BackupStatusCompleted {
+		return nil, errors.New("only completed backups can be shared")
+	}
+
+	if request.RecipientUserID == user.ID {
+		return nil, errors.New("cannot share a backup with yourself")
+	}
+
+	recipient, err := s.userService.GetUserByID(request.RecipientUserID)
+	if err != nil || recipient == nil {
+		return nil, errors.New("recipient is not found")
+	}
+
+	canRecipientAccess, _, err := s.workspaceService.CanUserAccessWorkspace(
+		*database.WorkspaceID,
+		recipient,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !canRecipientAccess {
+		return nil, errors.New("recipient is not a member of the workspace of this backup")
+	}
+
+	expiration := backups_download.DefaultShareLinkExpiration
+	if request.ExpiresInHours != 0 {
+		expiration = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+	if expiration <= 0 || expiration > backups_download.MaxShareLinkExpiration {
+		return nil, fmt.Errorf(
+			"share links expire in 1 to %d hours",
+			int(backups_download.MaxShareLinkExpiration.Hours()),
+		)
+	}
+
+	if request.MaxDownloads < 0 {
+		return nil, errors.New("max downloads cannot be negative")
+	}
+
+	link := &backups_download.BackupShareLink{
+		BackupID:        backup.ID,
+		CreatedByUserID: user.ID,
+		RecipientUserID: recipient.ID,
+		Note:            request.Note,
+		MaxDownloads:    request.MaxDownloads,
+		ExpiresAt:       time.Now().UTC().Add(expiration),
+	}
+
+	if err := s.shareLinkRepository.Create(link); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Backup of database %s (ID: %s) shared with %s until %s",
+			database.Name,
+			backup.ID.String(),
+			recipient.Email,
+			link.ExpiresAt.Format(time.RFC3339),
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return link, nil
+}
+
+func (s *BackupService) GetShareLinks(
+	user *users_models.User,
+	backupID uuid.UUID,
+) ([]*backups_download.BackupShareLink, error) {
+	if _, _, err := s.getBackupWithAccess(user, backupID); err != nil {
+		return nil, err
+	}
+
+	return s.shareLinkRepository.FindByBackupID(backupID)
+}
+
+// GetReceivedShareLinks returns active links shared with the user
+func (s *BackupService) GetReceivedShareLinks(
+	user *users_models.User,
+) ([]*backups_download.BackupShareLink, error) {
+	return s.shareLinkRepository.FindActiveByRecipientID(user.ID, time.Now().UTC())
+}
+
+// RevokeShareLink is allowed to the creator of the link and to members managing databases
+// of the workspace
+func (s *BackupService) RevokeShareLink(user *users_models.User, shareLinkID uuid.UUID) error {
+	link, err := s.shareLinkRepository.FindByID(shareLinkID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return errors.New("share link is not found")
+	}
+
+	backup, err := s.backupRepository.FindByID(link.BackupID)
+	if err != nil {
+		return err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	if database.WorkspaceID == nil {
+		return errors.New("cannot revoke share link for database without workspace")
+	}
+
+	if link.CreatedByUserID != user.ID {
+		canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+		if err != nil {
+			return err
+		}
+		if !canManage {
+			return errors.New("insufficient permissions to revoke this share link")
+		}
+	}
+
+	if err := s.shareLinkRepository.Revoke(link.ID, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Share link of backup of database %s (ID: %s) revoked",
+			database.Name,
+			backup.ID.String(),
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+// GenerateShareLinkDownloadToken issues a download token to the recipient of the link.
+// Access to the workspace is checked again, a recipient removed from the workspace
+// cannot download anymore
+func (s *BackupService) GenerateShareLinkDownloadToken(
+	user *users_models.User,
+	shareLinkID uuid.UUID,
+) (*backups_download.GenerateDownloadTokenResponse, error) {
+	link, err := s.shareLinkRepository.FindByID(shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || link.RecipientUserID != user.ID {
+		return nil, errors.New("share link is not found")
+	}
+
+	if !link.IsActive(time.Now().UTC()) {
+		return nil, ErrShareLinkNotActive
+	}
+
+	backup, database, err := s.getBackupWithAccess(user, link.BackupID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.downloadTokenService.GenerateForShareLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backups_download.GenerateDownloadTokenResponse{
+		Token:    token,
+		Filename: s.generateBackupFilename(backup, database),
+		BackupID: backup.ID,
+	}, nil
+}
+
+// RecordShareLinkDownload counts a download of the link when the file starts streaming
+func (s *BackupService) RecordShareLinkDownload(shareLinkID uuid.UUID) error {
+	isCounted, err := s.shareLinkRepository.IncrementDownloadCount(
+		shareLinkID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if !isCounted {
+		return ErrShareLinkNotActive
+	}
+
+	return nil
+}
+
+func (s *BackupService) getBackupWithAccess(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*backups_core.Backup, *databases.Database, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, nil, errors.New("cannot share backup for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canAccess {
+		return nil, nil, errors.New("insufficient permissions to access backup for this database")
+	}
+
+	return backup, database, nil
+}
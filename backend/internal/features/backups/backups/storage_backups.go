@@ -0,0 +1,78 @@
+package backups
+
+import (
+	"errors"
+
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrSystemStorageBackupsOnlyForAdmins = errors.New(
+	"only admins can list backups of system storages, they hold backups of other workspaces",
+)
+
+// GetStorageBackups lists backups residing in the storage, newest first, with count and size
+// per database
+func (s *BackupService) GetStorageBackups(
+	user *users_models.User,
+	storageID uuid.UUID,
+	limit, offset int,
+) (*GetStorageBackupsResponse, error) {
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != users_enums.UserRoleAdmin {
+		if storage.IsSystem {
+			return nil, ErrSystemStorageBackupsOnlyForAdmins
+		}
+
+		canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(storage.WorkspaceID, user)
+		if err != nil {
+			return nil, err
+		}
+		if !canAccess {
+			return nil, storages.ErrInsufficientPermissionsToViewStorage
+		}
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	backups, err := s.backupRepository.FindByStorageIDWithPagination(storageID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.backupRepository.CountByStorageID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalSizeMb, err := s.backupRepository.GetTotalSizeByStorage(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := s.backupRepository.GetSizesByDatabaseForStorage(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetStorageBackupsResponse{
+		Backups:     backups,
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+		TotalSizeMb: totalSizeMb,
+		Databases:   databases,
+	}, nil
+}
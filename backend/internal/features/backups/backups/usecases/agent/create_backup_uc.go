@@ -0,0 +1,199 @@
+package usecases_agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/features/agents"
+	common "databasus-backend/internal/features/backups/backups/common"
+	usecases_postgresql "databasus-backend/internal/features/backups/backups/usecases/postgresql"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+)
+
+const (
+	// agentJobStartTimeout covers a disconnected agent: the job is published to nobody
+	// and the upload never starts
+	agentJobStartTimeout = 1 * time.Minute
+	// agentJobIdleTimeout is several progress intervals of the upload, so only an agent
+	// that is gone mid-job hits it
+	agentJobIdleTimeout = 2 * time.Minute
+)
+
+// CreateAgentBackupUsecase runs a backup of a database behind an agent. The dump command
+// is built here and sent to the agent, the agent uploads the output to any node, and
+// this node only follows job events. Status, notifications and retries stay with the
+// backup node as for any other backup
+type CreateAgentBackupUsecase struct {
+	logger                        *slog.Logger
+	agentJobBus                   *agents.AgentJobBus
+	createPostgresqlBackupUsecase *usecases_postgresql.CreatePostgresqlBackupUsecase
+}
+
+func (uc *CreateAgentBackupUsecase) Execute(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	if db.Type != databases.DatabaseTypePostgres {
+		return nil, errors.New("agents support only PostgreSQL databases")
+	}
+
+	agentID := *db.AgentID
+
+	uc.logger.Info(
+		"Creating backup via agent",
+		"databaseId",
+		db.ID,
+		"agentId",
+		agentID,
+		"storageId",
+		storage.ID,
+	)
+
+	command, err := uc.createPostgresqlBackupUsecase.BuildRemoteDumpCommand(db)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan agents.AgentJobEvent, 16)
+
+	stopFollowing, err := uc.agentJobBus.FollowEvents(
+		ctx,
+		backupID,
+		func(event agents.AgentJobEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow agent job events: %w", err)
+	}
+	defer stopFollowing()
+
+	if err := uc.agentJobBus.SendJob(agentID, agents.AgentJobMessage{
+		Type:     agents.AgentJobMessageTypeJob,
+		BackupID: backupID,
+		Job: &agents.AgentJob{
+			BackupID: backupID,
+			Tool:     command.Tool,
+			Args:     command.Args,
+			Env:      command.Env,
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send job to agent: %w", err)
+	}
+
+	return uc.waitForJob(
+		ctx,
+		agentID,
+		backupID,
+		command,
+		events,
+		backupProgressListener,
+		runRecorder,
+	)
+}
+
+func (uc *CreateAgentBackupUsecase) waitForJob(
+	ctx context.Context,
+	agentID uuid.UUID,
+	backupID uuid.UUID,
+	command *common.RemoteDumpCommand,
+	events <-chan agents.AgentJobEvent,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	timer := time.NewTimer(agentJobStartTimeout)
+	defer timer.Stop()
+
+	isStarted := false
+	var backupMetadata *common.BackupMetadata
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.cancelJob(agentID, backupID)
+			return nil, uc.checkCancellationReason()
+
+		case <-timer.C:
+			uc.cancelJob(agentID, backupID)
+
+			if !isStarted {
+				return nil, fmt.Errorf(
+					"agent did not start the backup within %s, check that the agent is connected",
+					agentJobStartTimeout,
+				)
+			}
+
+			return nil, fmt.Errorf("agent stopped reporting progress for %s", agentJobIdleTimeout)
+
+		case event := <-events:
+			timer.Reset(agentJobIdleTimeout)
+
+			switch event.Type {
+			case agents.AgentJobEventStarted:
+				isStarted = true
+				runRecorder.StartPhase(common.BackupPhaseDump)
+
+			case agents.AgentJobEventProgress:
+				if backupProgressListener != nil {
+					backupProgressListener(event.CompletedMBs)
+				}
+
+			case agents.AgentJobEventUploaded:
+				backupMetadata = event.Metadata
+				runRecorder.FinishPhase(
+					common.BackupPhaseDump,
+					int64(event.CompletedMBs*1024*1024),
+				)
+
+				if backupProgressListener != nil {
+					backupProgressListener(event.CompletedMBs)
+				}
+
+			case agents.AgentJobEventFinished:
+				runRecorder.SetToolOutput([]byte(event.ToolOutput), command.Secrets...)
+
+				if event.Error != "" {
+					return nil, fmt.Errorf("agent: %s", event.Error)
+				}
+
+				if backupMetadata == nil {
+					return nil, errors.New("agent finished the job without uploading the backup")
+				}
+
+				return backupMetadata, nil
+			}
+		}
+	}
+}
+
+func (uc *CreateAgentBackupUsecase) cancelJob(agentID uuid.UUID, backupID uuid.UUID) {
+	if err := uc.agentJobBus.SendJob(agentID, agents.AgentJobMessage{
+		Type:     agents.AgentJobMessageTypeCancel,
+		BackupID: backupID,
+	}); err != nil {
+		uc.logger.Error("Failed to cancel agent job", "backupId", backupID, "error", err)
+	}
+}
+
+func (uc *CreateAgentBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("backup cancelled due to shutdown")
+	}
+	return fmt.Errorf("backup cancelled")
+}
@@ -0,0 +1,17 @@
+package usecases_agent
+
+import (
+	"databasus-backend/internal/features/agents"
+	usecases_postgresql "databasus-backend/internal/features/backups/backups/usecases/postgresql"
+	"databasus-backend/internal/util/logger"
+)
+
+var createAgentBackupUsecase = &CreateAgentBackupUsecase{
+	logger.GetLogger(),
+	agents.GetAgentJobBus(),
+	usecases_postgresql.GetCreatePostgresqlBackupUsecase(),
+}
+
+func GetCreateAgentBackupUsecase() *CreateAgentBackupUsecase {
+	return createAgentBackupUsecase
+}
@@ -0,0 +1,635 @@
+package usecases_cassandra
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	cassandratypes "databasus-backend/internal/features/databases/databases/cassandra"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+const (
+	backupTimeout            = 23 * time.Hour
+	clearSnapshotTimeout     = 5 * time.Minute
+	shutdownCheckInterval    = 1 * time.Second
+	copyBufferSize           = 8 * 1024 * 1024
+	progressReportIntervalMB = 1.0
+)
+
+// errArchiveNotRead stops the archive writer after copying to storage failed
+var errArchiveNotRead = errors.New("archive is not read anymore")
+
+// CreateCassandraBackupUsecase takes `nodetool snapshot` on all nodes at once, then
+// streams SSTables of the snapshots node by node into one tar archive, ending with a
+// manifest of per-node results. Snapshots are cleared at the end in any case
+type CreateCassandraBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+type writeResult struct {
+	bytesWritten int
+	writeErr     error
+}
+
+func (uc *CreateCassandraBackupUsecase) Execute(
+	parentCtx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info(
+		"Creating Cassandra backup via nodetool snapshot",
+		"databaseId", db.ID,
+		"storageId", storage.ID,
+	)
+
+	cassandra := db.Cassandra
+	if cassandra == nil {
+		return nil, fmt.Errorf("cassandra configuration is required")
+	}
+
+	client, err := cassandra.NewNodeClient(uc.fieldEncryptor, db.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := uc.createBackupContext(parentCtx)
+	defer cancel()
+
+	nodes := cassandra.NodesList()
+	manifest := &cassandratypes.SnapshotManifest{
+		Tag:       cassandratypes.GetSnapshotTag(backupID.String()),
+		Version:   cassandra.Version,
+		Keyspaces: cassandra.KeyspacesList(),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	// snapshots may exist on nodes where nodetool failed late, so all nodes are cleared
+	defer uc.clearSnapshots(client, nodes, manifest.Tag)
+
+	runRecorder.StartPhase(common.BackupPhaseDump)
+	manifest.Nodes = uc.takeSnapshots(ctx, client, nodes, manifest.Tag, manifest.Keyspaces)
+	if ctx.Err() != nil {
+		return nil, uc.checkCancellationReason()
+	}
+	runRecorder.FinishPhase(common.BackupPhaseDump, 0)
+
+	if err := checkCompleteness(manifest, cassandra.IsAllowPartialBackup); err != nil {
+		runRecorder.SetToolOutput([]byte(formatNodesSummary(manifest)))
+		return nil, err
+	}
+
+	backupMetadata, err := uc.streamToStorage(
+		ctx,
+		backupID,
+		backupConfig,
+		client,
+		manifest,
+		storage,
+		backupProgressListener,
+		runRecorder,
+	)
+	runRecorder.SetToolOutput([]byte(formatNodesSummary(manifest)))
+	if err != nil {
+		return nil, err
+	}
+
+	// nodes may fail while their SSTables are collected
+	if err := checkCompleteness(manifest, cassandra.IsAllowPartialBackup); err != nil {
+		return nil, err
+	}
+
+	return backupMetadata, nil
+}
+
+// takeSnapshots runs nodetool snapshot on all nodes in parallel, so snapshots of the nodes
+// are as close in time as possible
+func (uc *CreateCassandraBackupUsecase) takeSnapshots(
+	ctx context.Context,
+	client *cassandratypes.NodeClient,
+	nodes []string,
+	tag string,
+	keyspaces []string,
+) []cassandratypes.NodeSnapshot {
+	snapshots := make([]cassandratypes.NodeSnapshot, len(nodes))
+
+	var waitGroup sync.WaitGroup
+	for index, node := range nodes {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			snapshots[index] = cassandratypes.NodeSnapshot{
+				Node:   node,
+				Status: cassandratypes.NodeSnapshotStatusCompleted,
+			}
+
+			if err := client.Snapshot(ctx, node, tag, keyspaces); err != nil {
+				uc.logger.Error("Cassandra snapshot failed", "node", node, "error", err)
+				snapshots[index].Status = cassandratypes.NodeSnapshotStatusFailed
+				snapshots[index].Error = err.Error()
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	return snapshots
+}
+
+func (uc *CreateCassandraBackupUsecase) clearSnapshots(
+	client *cassandratypes.NodeClient,
+	nodes []string,
+	tag string,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), clearSnapshotTimeout)
+	defer cancel()
+
+	for _, node := range nodes {
+		if err := client.ClearSnapshot(ctx, node, tag); err != nil {
+			uc.logger.Error(
+				"Failed to clear Cassandra snapshot, remove it with nodetool clearsnapshot",
+				"node", node,
+				"tag", tag,
+				"error", err,
+			)
+		}
+	}
+}
+
+func (uc *CreateCassandraBackupUsecase) streamToStorage(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	client *cassandratypes.NodeClient,
+	manifest *cassandratypes.SnapshotManifest,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info("Streaming Cassandra snapshots to storage", "tag", manifest.Tag)
+
+	archiveReader, archiveWriter := io.Pipe()
+	archiveErrCh := make(chan error, 1)
+	go func() {
+		archiveErr := uc.writeArchive(ctx, archiveWriter, client, manifest)
+		_ = archiveWriter.CloseWithError(archiveErr)
+		archiveErrCh <- archiveErr
+	}()
+
+	storageReader, storageWriter := io.Pipe()
+
+	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		storageWriter,
+	)
+	if err != nil {
+		_ = archiveReader.CloseWithError(err)
+		<-archiveErrCh
+		return nil, err
+	}
+
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
+	countingWriter := common.NewCountingWriter(finalWriter)
+
+	saveErrCh := make(chan error, 1)
+	go func() {
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
+		saveErrCh <- saveErr
+	}()
+
+	bytesWritten, copyErr := uc.copyWithShutdownCheck(
+		ctx,
+		countingWriter,
+		archiveReader,
+		backupProgressListener,
+	)
+
+	_ = archiveReader.CloseWithError(errArchiveNotRead)
+	archiveErr := <-archiveErrCh
+
+	select {
+	case <-ctx.Done():
+		uc.cleanupOnCancellation(encryptionWriter, storageWriter, saveErrCh)
+		return nil, uc.checkCancellationReason()
+	default:
+	}
+
+	if err := uc.closeWriters(encryptionWriter, storageWriter); err != nil {
+		<-saveErrCh
+		return nil, err
+	}
+
+	saveErr := <-saveErrCh
+
+	if archiveErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
+		sizeMB := float64(bytesWritten) / (1024 * 1024)
+		backupProgressListener(sizeMB)
+	}
+
+	switch {
+	case archiveErr != nil && !errors.Is(archiveErr, errArchiveNotRead):
+		return nil, fmt.Errorf("archive Cassandra snapshots: %w", archiveErr)
+	case copyErr != nil:
+		return nil, fmt.Errorf("copy to storage: %w", copyErr)
+	case saveErr != nil:
+		return nil, fmt.Errorf("save to storage: %w", saveErr)
+	}
+
+	return &backupMetadata, nil
+}
+
+// writeArchive copies snapshot files of completed nodes one by one and appends the
+// manifest. A node failing between files is marked failed and skipped, a failure inside
+// a file breaks the archive and fails the backup
+func (uc *CreateCassandraBackupUsecase) writeArchive(
+	ctx context.Context,
+	writer io.Writer,
+	client *cassandratypes.NodeClient,
+	manifest *cassandratypes.SnapshotManifest,
+) error {
+	tarWriter := tar.NewWriter(writer)
+
+	for index := range manifest.Nodes {
+		snapshot := &manifest.Nodes[index]
+		if snapshot.Status != cassandratypes.NodeSnapshotStatusCompleted {
+			continue
+		}
+
+		if err := uc.writeNodeSnapshot(ctx, tarWriter, client, manifest.Tag, snapshot); err != nil {
+			return err
+		}
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:     cassandratypes.ManifestFileName,
+		Mode:     0o644,
+		Size:     int64(len(manifestBytes)),
+		ModTime:  manifest.CreatedAt,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := tarWriter.Write(manifestBytes); err != nil {
+		return err
+	}
+
+	return tarWriter.Close()
+}
+
+// writeNodeSnapshot records errors of the node in the snapshot and returns only errors
+// which break the archive
+func (uc *CreateCassandraBackupUsecase) writeNodeSnapshot(
+	ctx context.Context,
+	tarWriter *tar.Writer,
+	client *cassandratypes.NodeClient,
+	tag string,
+	snapshot *cassandratypes.NodeSnapshot,
+) error {
+	uc.logger.Info("Collecting Cassandra snapshot", "node", snapshot.Node)
+
+	streamReader, streamWriter := io.Pipe()
+	streamErrCh := make(chan error, 1)
+	go func() {
+		streamErr := client.StreamSnapshot(ctx, snapshot.Node, tag, streamWriter)
+		_ = streamWriter.CloseWithError(streamErr)
+		streamErrCh <- streamErr
+	}()
+
+	tarReader := tar.NewReader(streamReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = streamReader.CloseWithError(err)
+			if streamErr := <-streamErrCh; streamErr != nil {
+				err = streamErr
+			}
+			uc.markNodeFailed(snapshot, fmt.Errorf("failed to read snapshot stream: %w", err))
+			return nil
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		archivePath, isSnapshotFile := cassandratypes.GetArchivePath(
+			snapshot.Node,
+			tag,
+			header.Name,
+		)
+		if !isSnapshotFile {
+			continue
+		}
+
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:     archivePath,
+			Mode:     0o644,
+			Size:     header.Size,
+			ModTime:  header.ModTime,
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			_ = streamReader.CloseWithError(err)
+			<-streamErrCh
+			return err
+		}
+
+		if _, err := io.Copy(tarWriter, tarReader); err != nil {
+			_ = streamReader.CloseWithError(err)
+			<-streamErrCh
+			return fmt.Errorf("node %s: %w", snapshot.Node, err)
+		}
+
+		snapshot.FilesCount++
+		snapshot.SizeBytes += header.Size
+	}
+
+	if streamErr := <-streamErrCh; streamErr != nil {
+		uc.markNodeFailed(snapshot, streamErr)
+	}
+
+	return nil
+}
+
+func (uc *CreateCassandraBackupUsecase) markNodeFailed(
+	snapshot *cassandratypes.NodeSnapshot,
+	err error,
+) {
+	uc.logger.Error("Failed to collect Cassandra snapshot", "node", snapshot.Node, "error", err)
+
+	snapshot.Status = cassandratypes.NodeSnapshotStatusFailed
+	snapshot.Error = err.Error()
+}
+
+func (uc *CreateCassandraBackupUsecase) createBackupContext(
+	parentCtx context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parentCtx, backupTimeout)
+
+	go func() {
+		ticker := time.NewTicker(shutdownCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+func (uc *CreateCassandraBackupUsecase) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storageWriter io.WriteCloser,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	backupMetadata := common.BackupMetadata{
+		Encryption: backups_config.BackupEncryptionNone,
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return storageWriter, nil, backupMetadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
+		storageWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to create encryption writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+
+	backupMetadata.Encryption = backups_config.BackupEncryptionEncrypted
+	backupMetadata.EncryptionSalt = &saltBase64
+	backupMetadata.EncryptionIV = &nonceBase64
+
+	return encryptionWriter, encryptionWriter, backupMetadata, nil
+}
+
+func (uc *CreateCassandraBackupUsecase) copyWithShutdownCheck(
+	ctx context.Context,
+	dst io.Writer,
+	src io.Reader,
+	backupProgressListener func(completedMBs float64),
+) (int64, error) {
+	buf := make([]byte, copyBufferSize)
+	var totalWritten int64
+	var lastReportedMB float64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return totalWritten, ctx.Err()
+		default:
+		}
+
+		if config.IsShouldShutdown() {
+			return totalWritten, errors.New("shutdown requested")
+		}
+
+		nr, readErr := src.Read(buf)
+		if nr > 0 {
+			writeResultCh := make(chan writeResult, 1)
+			go func() {
+				nw, writeErr := dst.Write(buf[:nr])
+				writeResultCh <- writeResult{nw, writeErr}
+			}()
+
+			var nw int
+			var writeErr error
+
+			select {
+			case <-ctx.Done():
+				return totalWritten, fmt.Errorf("copy cancelled during write: %w", ctx.Err())
+			case result := <-writeResultCh:
+				nw = result.bytesWritten
+				writeErr = result.writeErr
+			}
+
+			if nw < 0 || nr < nw {
+				nw = 0
+				if writeErr == nil {
+					writeErr = fmt.Errorf("invalid write result")
+				}
+			}
+
+			if writeErr != nil {
+				return totalWritten, writeErr
+			}
+			if nr != nw {
+				return totalWritten, io.ErrShortWrite
+			}
+			totalWritten += int64(nw)
+
+			if backupProgressListener != nil {
+				currentMB := float64(totalWritten) / (1024 * 1024)
+				if currentMB-lastReportedMB >= progressReportIntervalMB {
+					backupProgressListener(currentMB)
+					lastReportedMB = currentMB
+				}
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return totalWritten, nil
+			}
+			return totalWritten, readErr
+		}
+	}
+}
+
+func (uc *CreateCassandraBackupUsecase) cleanupOnCancellation(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+	saveErrCh chan error,
+) {
+	if encryptionWriter != nil {
+		_ = encryptionWriter.Close()
+	}
+	_ = storageWriter.CloseWithError(errors.New("backup cancelled"))
+	<-saveErrCh
+}
+
+func (uc *CreateCassandraBackupUsecase) closeWriters(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+) error {
+	if encryptionWriter != nil {
+		if err := encryptionWriter.Close(); err != nil {
+			uc.logger.Error("Failed to close encryption writer", "error", err)
+			return fmt.Errorf("failed to close encryption writer: %w", err)
+		}
+	}
+	if err := storageWriter.Close(); err != nil {
+		uc.logger.Error("Failed to close storage writer", "error", err)
+		return fmt.Errorf("failed to close storage writer: %w", err)
+	}
+	return nil
+}
+
+func (uc *CreateCassandraBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return errors.New("backup cancelled due to shutdown")
+	}
+	return errors.New("backup cancelled due to timeout")
+}
+
+func checkCompleteness(manifest *cassandratypes.SnapshotManifest, isAllowPartial bool) error {
+	completedCount := len(manifest.GetCompletedNodes())
+
+	if completedCount == 0 {
+		return fmt.Errorf("snapshot failed on all nodes: %s", getNodeErrors(manifest))
+	}
+
+	if completedCount < len(manifest.Nodes) && !isAllowPartial {
+		return fmt.Errorf(
+			"snapshot failed on %d of %d nodes: %s",
+			len(manifest.Nodes)-completedCount,
+			len(manifest.Nodes),
+			getNodeErrors(manifest),
+		)
+	}
+
+	return nil
+}
+
+func getNodeErrors(manifest *cassandratypes.SnapshotManifest) string {
+	nodeErrors := []string{}
+	for _, node := range manifest.Nodes {
+		if node.Status == cassandratypes.NodeSnapshotStatusFailed {
+			nodeErrors = append(nodeErrors, node.Node+": "+node.Error)
+		}
+	}
+
+	return strings.Join(nodeErrors, "; ")
+}
+
+// formatNodesSummary is kept as tool output of the run, so per-node results are visible
+// without downloading the backup
+func formatNodesSummary(manifest *cassandratypes.SnapshotManifest) string {
+	var summary strings.Builder
+	for _, node := range manifest.Nodes {
+		fmt.Fprintf(
+			&summary,
+			"%s: %s, %d files, %d bytes",
+			node.Node,
+			node.Status,
+			node.FilesCount,
+			node.SizeBytes,
+		)
+		if node.Error != "" {
+			summary.WriteString(", " + node.Error)
+		}
+		summary.WriteString("\n")
+	}
+
+	return summary.String()
+}
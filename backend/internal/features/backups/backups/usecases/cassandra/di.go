@@ -0,0 +1,17 @@
+package usecases_cassandra
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var createCassandraBackupUsecase = &CreateCassandraBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+func GetCreateCassandraBackupUsecase() *CreateCassandraBackupUsecase {
+	return createCassandraBackupUsecase
+}
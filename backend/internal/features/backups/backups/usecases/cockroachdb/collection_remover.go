@@ -0,0 +1,56 @@
+package usecases_cockroachdb
+
+import (
+	"log/slog"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	cockroachdbtypes "databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+// CollectionRemover deletes the backup collection of a removed backup, so retention
+// applies to the data written by the cluster as well as to the manifest
+type CollectionRemover struct {
+	databaseService *databases.DatabaseService
+	storageService  *storages.StorageService
+	fieldEncryptor  encryption.FieldEncryptor
+	logger          *slog.Logger
+}
+
+// OnBeforeBackupRemove never blocks removal of the backup, like an unreachable storage
+// does not block removal of other backups
+func (r *CollectionRemover) OnBeforeBackupRemove(backup *backups_core.Backup) error {
+	database, err := r.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil || database.Type != databases.DatabaseTypeCockroachdb {
+		return nil
+	}
+
+	if backup.Status != backups_core.BackupStatusCompleted {
+		// failed and cancelled runs clean up their collections themselves
+		return nil
+	}
+
+	storage, err := r.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		r.logger.Error(
+			"Failed to get storage of removed backup",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return nil
+	}
+
+	directory := cockroachdbtypes.GetBackupDirectory(backup.ID)
+	if err := deleteStorageDirectory(storage, r.fieldEncryptor, directory); err != nil {
+		r.logger.Error(
+			"Failed to delete collection of removed backup",
+			"backupId", backup.ID,
+			"directory", directory,
+			"error", err,
+		)
+	}
+
+	return nil
+}
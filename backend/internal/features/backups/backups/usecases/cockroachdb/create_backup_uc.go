@@ -0,0 +1,378 @@
+package usecases_cockroachdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	cockroachdbtypes "databasus-backend/internal/features/databases/databases/cockroachdb"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+const (
+	backupTimeout         = 23 * time.Hour
+	shutdownCheckInterval = 1 * time.Second
+	jobPollInterval       = 5 * time.Second
+	// cleanupTimeout bounds cancelling the job and deleting the collection of a failed
+	// backup, it runs after the backup context is done
+	cleanupTimeout = 10 * time.Minute
+)
+
+// CreateCockroachdbBackupUsecase runs BACKUP INTO a collection of the backup storage and
+// waits for the job. The cluster writes the data itself, only a manifest of the
+// collection goes through Databasus, so the backup is tracked with all other backups
+type CreateCockroachdbBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+func (uc *CreateCockroachdbBackupUsecase) Execute(
+	parentCtx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info(
+		"Creating CockroachDB backup via BACKUP statement",
+		"databaseId", db.ID,
+		"storageId", storage.ID,
+	)
+
+	cluster := db.Cockroachdb
+	if cluster == nil {
+		return nil, fmt.Errorf("cockroachdb configuration is required")
+	}
+
+	directory := cockroachdbtypes.GetBackupDirectory(backupID)
+
+	uri, err := getStorageURI(storage, uc.fieldEncryptor, directory)
+	if err != nil {
+		return nil, err
+	}
+
+	options := cockroachdbtypes.BackupOptions{URI: uri, Database: cluster.Database}
+
+	if backupConfig.Encryption == backups_config.BackupEncryptionEncrypted {
+		// the collection gets its own passphrase, it is kept in the encrypted manifest
+		passphrase, err := generatePassphrase()
+		if err != nil {
+			return nil, err
+		}
+		options.EncryptionPassphrase = passphrase
+	}
+
+	ctx, cancel := uc.createBackupContext(parentCtx)
+	defer cancel()
+
+	startedAt := time.Now().UTC()
+
+	runRecorder.StartPhase(common.BackupPhaseConnect)
+	client, err := cluster.NewJobClient(ctx, uc.logger, uc.fieldEncryptor, db.ID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+	defer client.Close()
+
+	jobID, err := client.StartBackup(ctx, options)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+	runRecorder.FinishPhase(common.BackupPhaseConnect, 0)
+
+	uc.logger.Info("CockroachDB backup job started", "backupId", backupID, "jobId", jobID)
+
+	runRecorder.StartPhase(common.BackupPhaseDump)
+	job, err := uc.waitForJob(ctx, client, jobID)
+	if err != nil {
+		uc.cleanup(cluster, db.ID, storage, directory, jobID)
+
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+
+	runRecorder.SetToolOutput([]byte(describeJob(jobID, job)))
+
+	if job.Status != cockroachdbtypes.JobStatusSucceeded {
+		uc.cleanup(cluster, db.ID, storage, directory, 0)
+		return nil, fmt.Errorf(
+			"backup job %d finished with status %s: %s",
+			jobID,
+			job.Status,
+			job.Error,
+		)
+	}
+
+	sizeBytes, err := client.GetBackupSize(ctx, options)
+	if err != nil {
+		uc.logger.Warn("Failed to get backup size", "backupId", backupID, "error", err)
+	}
+	runRecorder.FinishPhase(common.BackupPhaseDump, sizeBytes)
+
+	manifest := cockroachdbtypes.BackupManifest{
+		Version:              cluster.Version,
+		Database:             cluster.Database,
+		Directory:            directory,
+		JobID:                jobID,
+		EncryptionPassphrase: options.EncryptionPassphrase,
+		SizeBytes:            sizeBytes,
+		StartedAt:            startedAt,
+		FinishedAt:           time.Now().UTC(),
+	}
+
+	backupMetadata, err := uc.saveManifest(
+		ctx,
+		backupID,
+		backupConfig,
+		storage,
+		manifest,
+		runRecorder,
+	)
+	if err != nil {
+		uc.cleanup(cluster, db.ID, storage, directory, 0)
+
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+
+	if backupProgressListener != nil {
+		backupProgressListener(float64(sizeBytes) / (1024 * 1024))
+	}
+
+	return backupMetadata, nil
+}
+
+func (uc *CreateCockroachdbBackupUsecase) waitForJob(
+	ctx context.Context,
+	client *cockroachdbtypes.JobClient,
+	jobID int64,
+) (*cockroachdbtypes.JobInfo, error) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := client.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		if job.IsFinished() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// saveManifest writes the manifest through the same encryption as dumps of other
+// databases, restores read it like any backup file
+func (uc *CreateCockroachdbBackupUsecase) saveManifest(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storage *storages.Storage,
+	manifest cockroachdbtypes.BackupManifest,
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode backup manifest: %w", err)
+	}
+
+	var content bytes.Buffer
+
+	writer, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		&content,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := writer.Write(manifestJSON); err != nil {
+		return nil, fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+
+	if encryptionWriter != nil {
+		if err := encryptionWriter.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close encryption writer: %w", err)
+		}
+	}
+
+	if err := storage.SaveFile(
+		ctx,
+		uc.fieldEncryptor,
+		uc.logger,
+		backupID,
+		runRecorder.WrapPhaseReader(common.BackupPhaseUpload, &content),
+	); err != nil {
+		return nil, fmt.Errorf("save to storage: %w", err)
+	}
+
+	return &backupMetadata, nil
+}
+
+// cleanup cancels the job when it may still run and deletes the collection of a failed
+// backup. It is best effort, a leftover collection only takes space in the storage
+func (uc *CreateCockroachdbBackupUsecase) cleanup(
+	cluster *cockroachdbtypes.CockroachdbDatabase,
+	databaseID uuid.UUID,
+	storage *storages.Storage,
+	directory string,
+	runningJobID int64,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	if runningJobID != 0 {
+		// pgx closes the connection of the backup on cancellation, so a new one is opened
+		client, err := cluster.NewJobClient(ctx, uc.logger, uc.fieldEncryptor, databaseID)
+		if err == nil {
+			err = client.CancelJob(ctx, runningJobID)
+			client.Close()
+		}
+
+		if err != nil {
+			uc.logger.Error("Failed to cancel backup job", "jobId", runningJobID, "error", err)
+		}
+	}
+
+	if err := deleteStorageDirectory(storage, uc.fieldEncryptor, directory); err != nil {
+		uc.logger.Error(
+			"Failed to delete collection of failed backup",
+			"directory", directory,
+			"error", err,
+		)
+	}
+}
+
+func (uc *CreateCockroachdbBackupUsecase) createBackupContext(
+	parentCtx context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parentCtx, backupTimeout)
+
+	go func() {
+		ticker := time.NewTicker(shutdownCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+func (uc *CreateCockroachdbBackupUsecase) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	baseWriter io.Writer,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	backupMetadata := common.BackupMetadata{
+		Encryption: backups_config.BackupEncryptionNone,
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return baseWriter, nil, backupMetadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
+		baseWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to create encryption writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+
+	backupMetadata.Encryption = backups_config.BackupEncryptionEncrypted
+	backupMetadata.EncryptionSalt = &saltBase64
+	backupMetadata.EncryptionIV = &nonceBase64
+
+	return encryptionWriter, encryptionWriter, backupMetadata, nil
+}
+
+func (uc *CreateCockroachdbBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return errors.New("backup cancelled due to shutdown")
+	}
+	return errors.New("backup cancelled due to timeout")
+}
+
+func describeJob(jobID int64, job *cockroachdbtypes.JobInfo) string {
+	if job.Error != "" {
+		return fmt.Sprintf("backup job %d %s: %s", jobID, job.Status, job.Error)
+	}
+
+	return fmt.Sprintf("backup job %d %s", jobID, job.Status)
+}
+
+func generatePassphrase() (string, error) {
+	passphrase := make([]byte, 32)
+	if _, err := rand.Read(passphrase); err != nil {
+		return "", fmt.Errorf("failed to generate encryption passphrase: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(passphrase), nil
+}
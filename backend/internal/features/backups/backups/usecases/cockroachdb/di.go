@@ -0,0 +1,30 @@
+package usecases_cockroachdb
+
+import (
+	"databasus-backend/internal/features/databases"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var createCockroachdbBackupUsecase = &CreateCockroachdbBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+var collectionRemover = &CollectionRemover{
+	databases.GetDatabaseService(),
+	storages.GetStorageService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+
+func GetCreateCockroachdbBackupUsecase() *CreateCockroachdbBackupUsecase {
+	return createCockroachdbBackupUsecase
+}
+
+func GetCollectionRemover() *CollectionRemover {
+	return collectionRemover
+}
@@ -0,0 +1,45 @@
+package usecases_cockroachdb
+
+import (
+	"fmt"
+
+	cockroachdbtypes "databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+// getStorageURI maps the storage to the URI of the collection. Only storages the nodes can
+// write to themselves are supported
+func getStorageURI(
+	storage *storages.Storage,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+) (string, error) {
+	switch storage.Type {
+	case storages.StorageTypeS3:
+		return cockroachdbtypes.BuildS3URI(storage.S3Storage, encryptor, directory)
+	case storages.StorageTypeAzureBlob:
+		return cockroachdbtypes.BuildAzureURI(storage.AzureBlobStorage, encryptor, directory)
+	default:
+		return "", fmt.Errorf(
+			"CockroachDB writes backups into the storage itself, only S3 and Azure Blob "+
+				"storages are supported, not %s",
+			storage.Type,
+		)
+	}
+}
+
+func deleteStorageDirectory(
+	storage *storages.Storage,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+) error {
+	switch storage.Type {
+	case storages.StorageTypeS3:
+		return storage.S3Storage.DeleteDirectory(encryptor, directory)
+	case storages.StorageTypeAzureBlob:
+		return storage.AzureBlobStorage.DeleteDirectory(encryptor, directory)
+	default:
+		return nil
+	}
+}
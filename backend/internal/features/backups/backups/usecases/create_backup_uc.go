@@ -5,9 +5,16 @@ import (
 	"errors"
 
 	common "databasus-backend/internal/features/backups/backups/common"
+	usecases_agent "databasus-backend/internal/features/backups/backups/usecases/agent"
+	usecases_cassandra "databasus-backend/internal/features/backups/backups/usecases/cassandra"
+	usecases_cockroachdb "databasus-backend/internal/features/backups/backups/usecases/cockroachdb"
+	usecases_elasticsearch "databasus-backend/internal/features/backups/backups/usecases/elasticsearch"
+	usecases_filesystem "databasus-backend/internal/features/backups/backups/usecases/filesystem"
+	usecases_influxdb "databasus-backend/internal/features/backups/backups/usecases/influxdb"
 	usecases_mariadb "databasus-backend/internal/features/backups/backups/usecases/mariadb"
 	usecases_mongodb "databasus-backend/internal/features/backups/backups/usecases/mongodb"
 	usecases_mysql "databasus-backend/internal/features/backups/backups/usecases/mysql"
+	usecases_neo4j "databasus-backend/internal/features/backups/backups/usecases/neo4j"
 	usecases_postgresql "databasus-backend/internal/features/backups/backups/usecases/postgresql"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
@@ -17,10 +24,17 @@ import (
 )
 
 type CreateBackupUsecase struct {
-	CreatePostgresqlBackupUsecase *usecases_postgresql.CreatePostgresqlBackupUsecase
-	CreateMysqlBackupUsecase      *usecases_mysql.CreateMysqlBackupUsecase
-	CreateMariadbBackupUsecase    *usecases_mariadb.CreateMariadbBackupUsecase
-	CreateMongodbBackupUsecase    *usecases_mongodb.CreateMongodbBackupUsecase
+	CreatePostgresqlBackupUsecase    *usecases_postgresql.CreatePostgresqlBackupUsecase
+	CreateMysqlBackupUsecase         *usecases_mysql.CreateMysqlBackupUsecase
+	CreateMariadbBackupUsecase       *usecases_mariadb.CreateMariadbBackupUsecase
+	CreateMongodbBackupUsecase       *usecases_mongodb.CreateMongodbBackupUsecase
+	CreateFilesystemBackupUsecase    *usecases_filesystem.CreateFilesystemBackupUsecase
+	CreateElasticsearchBackupUsecase *usecases_elasticsearch.CreateElasticsearchBackupUsecase
+	CreateInfluxdbBackupUsecase      *usecases_influxdb.CreateInfluxdbBackupUsecase
+	CreateCassandraBackupUsecase     *usecases_cassandra.CreateCassandraBackupUsecase
+	CreateCockroachdbBackupUsecase   *usecases_cockroachdb.CreateCockroachdbBackupUsecase
+	CreateNeo4jBackupUsecase         *usecases_neo4j.CreateNeo4jBackupUsecase
+	CreateAgentBackupUsecase         *usecases_agent.CreateAgentBackupUsecase
 }
 
 func (uc *CreateBackupUsecase) Execute(
@@ -30,7 +44,20 @@ func (uc *CreateBackupUsecase) Execute(
 	database *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
+	if database.IsBehindAgent() {
+		return uc.CreateAgentBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+	}
+
 	switch database.Type {
 	case databases.DatabaseTypePostgres:
 		return uc.CreatePostgresqlBackupUsecase.Execute(
@@ -40,6 +67,7 @@ func (uc *CreateBackupUsecase) Execute(
 			database,
 			storage,
 			backupProgressListener,
+			runRecorder,
 		)
 
 	case databases.DatabaseTypeMysql:
@@ -50,6 +78,7 @@ func (uc *CreateBackupUsecase) Execute(
 			database,
 			storage,
 			backupProgressListener,
+			runRecorder,
 		)
 
 	case databases.DatabaseTypeMariadb:
@@ -60,6 +89,7 @@ func (uc *CreateBackupUsecase) Execute(
 			database,
 			storage,
 			backupProgressListener,
+			runRecorder,
 		)
 
 	case databases.DatabaseTypeMongodb:
@@ -70,6 +100,73 @@ func (uc *CreateBackupUsecase) Execute(
 			database,
 			storage,
 			backupProgressListener,
+			runRecorder,
+		)
+
+	case databases.DatabaseTypeFilesystem:
+		return uc.CreateFilesystemBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+
+	case databases.DatabaseTypeElasticsearch:
+		return uc.CreateElasticsearchBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+
+	case databases.DatabaseTypeInfluxdb:
+		return uc.CreateInfluxdbBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+
+	case databases.DatabaseTypeCassandra:
+		return uc.CreateCassandraBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+
+	case databases.DatabaseTypeCockroachdb:
+		return uc.CreateCockroachdbBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+
+	case databases.DatabaseTypeNeo4j:
+		return uc.CreateNeo4jBackupUsecase.Execute(
+			ctx,
+			backupID,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+			runRecorder,
 		)
 
 	default:
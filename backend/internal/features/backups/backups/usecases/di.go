@@ -1,9 +1,16 @@
 package usecases
 
 import (
+	usecases_agent "databasus-backend/internal/features/backups/backups/usecases/agent"
+	usecases_cassandra "databasus-backend/internal/features/backups/backups/usecases/cassandra"
+	usecases_cockroachdb "databasus-backend/internal/features/backups/backups/usecases/cockroachdb"
+	usecases_elasticsearch "databasus-backend/internal/features/backups/backups/usecases/elasticsearch"
+	usecases_filesystem "databasus-backend/internal/features/backups/backups/usecases/filesystem"
+	usecases_influxdb "databasus-backend/internal/features/backups/backups/usecases/influxdb"
 	usecases_mariadb "databasus-backend/internal/features/backups/backups/usecases/mariadb"
 	usecases_mongodb "databasus-backend/internal/features/backups/backups/usecases/mongodb"
 	usecases_mysql "databasus-backend/internal/features/backups/backups/usecases/mysql"
+	usecases_neo4j "databasus-backend/internal/features/backups/backups/usecases/neo4j"
 	usecases_postgresql "databasus-backend/internal/features/backups/backups/usecases/postgresql"
 )
 
@@ -12,6 +19,13 @@ var createBackupUsecase = &CreateBackupUsecase{
 	usecases_mysql.GetCreateMysqlBackupUsecase(),
 	usecases_mariadb.GetCreateMariadbBackupUsecase(),
 	usecases_mongodb.GetCreateMongodbBackupUsecase(),
+	usecases_filesystem.GetCreateFilesystemBackupUsecase(),
+	usecases_elasticsearch.GetCreateElasticsearchBackupUsecase(),
+	usecases_influxdb.GetCreateInfluxdbBackupUsecase(),
+	usecases_cassandra.GetCreateCassandraBackupUsecase(),
+	usecases_cockroachdb.GetCreateCockroachdbBackupUsecase(),
+	usecases_neo4j.GetCreateNeo4jBackupUsecase(),
+	usecases_agent.GetCreateAgentBackupUsecase(),
 }
 
 func GetCreateBackupUsecase() *CreateBackupUsecase {
@@ -0,0 +1,352 @@
+package usecases_elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	elasticsearchtypes "databasus-backend/internal/features/databases/databases/elasticsearch"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+const (
+	backupTimeout         = 23 * time.Hour
+	shutdownCheckInterval = 1 * time.Second
+	snapshotPollInterval  = 5 * time.Second
+	// snapshotCleanupTimeout bounds deletion of a cancelled or unusable snapshot, it runs
+	// after the backup context is done
+	snapshotCleanupTimeout = 1 * time.Minute
+)
+
+// CreateElasticsearchBackupUsecase asks the cluster to snapshot itself into its snapshot
+// repository and waits for completion. Only a manifest of the snapshot goes through
+// Databasus to the storage, so the snapshot is tracked with all other backups
+type CreateElasticsearchBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+func (uc *CreateElasticsearchBackupUsecase) Execute(
+	parentCtx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info(
+		"Creating Elasticsearch backup via snapshot API",
+		"databaseId", db.ID,
+		"storageId", storage.ID,
+	)
+
+	cluster := db.Elasticsearch
+	if cluster == nil {
+		return nil, fmt.Errorf("elasticsearch configuration is required")
+	}
+
+	client, err := cluster.NewSnapshotClient(uc.fieldEncryptor, db.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := uc.createBackupContext(parentCtx)
+	defer cancel()
+
+	snapshotName := elasticsearchtypes.SnapshotName(backupID)
+
+	runRecorder.StartPhase(common.BackupPhaseConnect)
+	if err := client.CreateSnapshot(
+		ctx,
+		cluster.Repository,
+		snapshotName,
+		elasticsearchtypes.CreateSnapshotRequest{
+			Indices:            strings.Join(cluster.IndicesList(), ","),
+			IncludeGlobalState: cluster.IsIncludeGlobalState,
+			Metadata: map[string]any{
+				"taken_by":    "databasus",
+				"backup_id":   backupID.String(),
+				"database_id": db.ID.String(),
+			},
+		},
+	); err != nil {
+		if ctx.Err() != nil {
+			// the request may have reached the cluster before cancellation
+			uc.deleteSnapshot(client, cluster.Repository, snapshotName)
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, fmt.Errorf("failed to start snapshot: %w", err)
+	}
+	runRecorder.FinishPhase(common.BackupPhaseConnect, 0)
+
+	runRecorder.StartPhase(common.BackupPhaseDump)
+	snapshot, err := uc.waitForSnapshot(ctx, client, cluster.Repository, snapshotName)
+	if err != nil {
+		uc.deleteSnapshot(client, cluster.Repository, snapshotName)
+
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+
+	runRecorder.SetToolOutput([]byte(describeSnapshot(snapshot)))
+
+	if snapshot.State != elasticsearchtypes.SnapshotStateSuccess {
+		// partial snapshots silently miss shards, they must not count as backups
+		uc.deleteSnapshot(client, cluster.Repository, snapshotName)
+		return nil, fmt.Errorf(
+			"snapshot finished with state %s: %s",
+			snapshot.State,
+			snapshot.Reason,
+		)
+	}
+
+	sizeBytes, err := client.GetSnapshotSize(ctx, cluster.Repository, snapshotName)
+	if err != nil {
+		uc.logger.Warn("Failed to get snapshot size", "backupId", backupID, "error", err)
+	}
+	runRecorder.FinishPhase(common.BackupPhaseDump, sizeBytes)
+
+	manifest := elasticsearchtypes.SnapshotManifest{
+		Flavor:             cluster.Flavor,
+		Version:            cluster.Version,
+		Repository:         cluster.Repository,
+		Snapshot:           snapshotName,
+		State:              snapshot.State,
+		Indices:            snapshot.Indices,
+		DataStreams:        snapshot.DataStreams,
+		IncludeGlobalState: cluster.IsIncludeGlobalState,
+		SizeBytes:          sizeBytes,
+		StartedAt:          time.UnixMilli(snapshot.StartTimeInMillis).UTC(),
+		FinishedAt:         time.UnixMilli(snapshot.EndTimeInMillis).UTC(),
+	}
+
+	backupMetadata, err := uc.saveManifest(
+		ctx,
+		backupID,
+		backupConfig,
+		storage,
+		manifest,
+		runRecorder,
+	)
+	if err != nil {
+		uc.deleteSnapshot(client, cluster.Repository, snapshotName)
+
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+
+	if backupProgressListener != nil {
+		backupProgressListener(float64(sizeBytes) / (1024 * 1024))
+	}
+
+	return backupMetadata, nil
+}
+
+func (uc *CreateElasticsearchBackupUsecase) waitForSnapshot(
+	ctx context.Context,
+	client *elasticsearchtypes.SnapshotClient,
+	repository, snapshotName string,
+) (*elasticsearchtypes.SnapshotInfo, error) {
+	ticker := time.NewTicker(snapshotPollInterval)
+	defer ticker.Stop()
+
+	for {
+		snapshot, err := client.GetSnapshot(ctx, repository, snapshotName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot status: %w", err)
+		}
+
+		if snapshot.State != elasticsearchtypes.SnapshotStateInProgress {
+			return snapshot, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// saveManifest writes the manifest through the same encryption as dumps of other
+// databases, restores read it like any backup file
+func (uc *CreateElasticsearchBackupUsecase) saveManifest(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storage *storages.Storage,
+	manifest elasticsearchtypes.SnapshotManifest,
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+
+	var content bytes.Buffer
+
+	writer, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		&content,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := writer.Write(manifestJSON); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+
+	if encryptionWriter != nil {
+		if err := encryptionWriter.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close encryption writer: %w", err)
+		}
+	}
+
+	if err := storage.SaveFile(
+		ctx,
+		uc.fieldEncryptor,
+		uc.logger,
+		backupID,
+		runRecorder.WrapPhaseReader(common.BackupPhaseUpload, &content),
+	); err != nil {
+		return nil, fmt.Errorf("save to storage: %w", err)
+	}
+
+	return &backupMetadata, nil
+}
+
+// deleteSnapshot aborts a running snapshot or removes an unusable one. It is best effort,
+// a leftover snapshot only takes space in the repository
+func (uc *CreateElasticsearchBackupUsecase) deleteSnapshot(
+	client *elasticsearchtypes.SnapshotClient,
+	repository, snapshotName string,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotCleanupTimeout)
+	defer cancel()
+
+	if err := client.DeleteSnapshot(ctx, repository, snapshotName); err != nil {
+		uc.logger.Error(
+			"Failed to delete snapshot",
+			"repository", repository,
+			"snapshot", snapshotName,
+			"error", err,
+		)
+	}
+}
+
+func (uc *CreateElasticsearchBackupUsecase) createBackupContext(
+	parentCtx context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parentCtx, backupTimeout)
+
+	go func() {
+		ticker := time.NewTicker(shutdownCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+func (uc *CreateElasticsearchBackupUsecase) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	baseWriter io.Writer,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	backupMetadata := common.BackupMetadata{
+		Encryption: backups_config.BackupEncryptionNone,
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return baseWriter, nil, backupMetadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
+		baseWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to create encryption writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+
+	backupMetadata.Encryption = backups_config.BackupEncryptionEncrypted
+	backupMetadata.EncryptionSalt = &saltBase64
+	backupMetadata.EncryptionIV = &nonceBase64
+
+	return encryptionWriter, encryptionWriter, backupMetadata, nil
+}
+
+func (uc *CreateElasticsearchBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return errors.New("backup cancelled due to shutdown")
+	}
+	return errors.New("backup cancelled due to timeout")
+}
+
+func describeSnapshot(snapshot *elasticsearchtypes.SnapshotInfo) string {
+	return fmt.Sprintf(
+		"snapshot %s %s: %d indices, %d data streams, shards %d/%d successful, %d failed",
+		snapshot.Snapshot,
+		snapshot.State,
+		len(snapshot.Indices),
+		len(snapshot.DataStreams),
+		snapshot.Shards.Successful,
+		snapshot.Shards.Total,
+		snapshot.Shards.Failed,
+	)
+}
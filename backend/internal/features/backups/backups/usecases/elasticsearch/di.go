@@ -0,0 +1,28 @@
+package usecases_elasticsearch
+
+import (
+	"databasus-backend/internal/features/databases"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var createElasticsearchBackupUsecase = &CreateElasticsearchBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+var snapshotRemover = &SnapshotRemover{
+	databases.GetDatabaseService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+
+func GetCreateElasticsearchBackupUsecase() *CreateElasticsearchBackupUsecase {
+	return createElasticsearchBackupUsecase
+}
+
+func GetSnapshotRemover() *SnapshotRemover {
+	return snapshotRemover
+}
@@ -0,0 +1,62 @@
+package usecases_elasticsearch
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	elasticsearchtypes "databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/util/encryption"
+)
+
+const snapshotDeleteTimeout = 5 * time.Minute
+
+// SnapshotRemover deletes the cluster snapshot of a removed backup, so retention applies
+// to the snapshot repository as well as to the storage
+type SnapshotRemover struct {
+	databaseService *databases.DatabaseService
+	fieldEncryptor  encryption.FieldEncryptor
+	logger          *slog.Logger
+}
+
+// OnBeforeBackupRemove never blocks removal of the backup: an unreachable cluster must
+// not keep backups past retention, like an unreachable storage does not
+func (r *SnapshotRemover) OnBeforeBackupRemove(backup *backups_core.Backup) error {
+	database, err := r.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil || database.Type != databases.DatabaseTypeElasticsearch ||
+		database.Elasticsearch == nil {
+		return nil
+	}
+
+	if backup.Status != backups_core.BackupStatusCompleted {
+		// failed and cancelled runs clean up their snapshots themselves
+		return nil
+	}
+
+	client, err := database.Elasticsearch.NewSnapshotClient(r.fieldEncryptor, database.ID)
+	if err != nil {
+		r.logger.Error("Failed to create snapshot client", "backupId", backup.ID, "error", err)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotDeleteTimeout)
+	defer cancel()
+
+	snapshotName := elasticsearchtypes.SnapshotName(backup.ID)
+	if err := client.DeleteSnapshot(
+		ctx,
+		database.Elasticsearch.Repository,
+		snapshotName,
+	); err != nil {
+		r.logger.Error(
+			"Failed to delete snapshot of removed backup",
+			"backupId", backup.ID,
+			"snapshot", snapshotName,
+			"error", err,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,458 @@
+package usecases_filesystem
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+
+	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	filesystemtypes "databasus-backend/internal/features/databases/databases/filesystem"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+)
+
+const (
+	backupTimeout            = 23 * time.Hour
+	shutdownCheckInterval    = 1 * time.Second
+	copyBufferSize           = 8 * 1024 * 1024
+	progressReportIntervalMB = 1.0
+	zstdCompressionLevel     = 5
+)
+
+// errArchiveNotRead stops the archive writer after copying to storage failed
+var errArchiveNotRead = errors.New("archive is not read anymore")
+
+type CreateFilesystemBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+type writeResult struct {
+	bytesWritten int
+	writeErr     error
+}
+
+func (uc *CreateFilesystemBackupUsecase) Execute(
+	parentCtx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info(
+		"Creating filesystem backup via tar",
+		"databaseId", db.ID,
+		"storageId", storage.ID,
+	)
+
+	source := db.Filesystem
+	if source == nil {
+		return nil, fmt.Errorf("filesystem source configuration is required")
+	}
+
+	// Roots may have been narrowed since the source was saved
+	if err := source.CheckPathAllowed(config.GetEnv().FilesystemBackupRoots); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := uc.createBackupContext(parentCtx)
+	defer cancel()
+
+	archivePath, releaseSnapshot, err := uc.createSnapshot(ctx, backupID, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s snapshot: %w", source.SnapshotMode, err)
+	}
+	defer releaseSnapshot()
+
+	return uc.streamToStorage(
+		ctx,
+		backupID,
+		backupConfig,
+		source,
+		archivePath,
+		storage,
+		backupProgressListener,
+		runRecorder,
+	)
+}
+
+func (uc *CreateFilesystemBackupUsecase) streamToStorage(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	source *filesystemtypes.FilesystemDatabase,
+	archivePath string,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info("Streaming filesystem backup to storage", "archivePath", archivePath)
+
+	warnings := &archiveWarnings{}
+
+	archiveReader, archiveWriter := io.Pipe()
+	archiveErrCh := make(chan error, 1)
+	go func() {
+		archiveErr := files_utils.WriteTarArchive(
+			ctx,
+			archiveWriter,
+			archivePath,
+			source,
+			warnings.add,
+		)
+		_ = archiveWriter.CloseWithError(archiveErr)
+		archiveErrCh <- archiveErr
+	}()
+
+	storageReader, storageWriter := io.Pipe()
+
+	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		storageWriter,
+	)
+	if err != nil {
+		_ = archiveReader.CloseWithError(err)
+		<-archiveErrCh
+		return nil, err
+	}
+
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
+	compressionWriter, err := uc.setupCompression(source.Compression, finalWriter)
+	if err != nil {
+		_ = archiveReader.CloseWithError(err)
+		<-archiveErrCh
+		return nil, err
+	}
+
+	var archiveDestination io.Writer = finalWriter
+	if compressionWriter != nil {
+		archiveDestination = runRecorder.WrapPhaseWriter(
+			common.BackupPhaseCompress,
+			compressionWriter,
+		)
+	}
+	countingWriter := common.NewCountingWriter(archiveDestination)
+
+	saveErrCh := make(chan error, 1)
+	go func() {
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
+		saveErrCh <- saveErr
+	}()
+
+	bytesWritten, copyErr := uc.copyWithShutdownCheck(
+		ctx,
+		countingWriter,
+		runRecorder.WrapToolOutput(archiveReader),
+		backupProgressListener,
+	)
+
+	_ = archiveReader.CloseWithError(errArchiveNotRead)
+	archiveErr := <-archiveErrCh
+
+	select {
+	case <-ctx.Done():
+		uc.cleanupOnCancellation(compressionWriter, encryptionWriter, storageWriter, saveErrCh)
+		return nil, uc.checkCancellationReason()
+	default:
+	}
+
+	if compressionWriter != nil {
+		if err := compressionWriter.Close(); err != nil {
+			uc.logger.Error("Failed to close compression writer", "error", err)
+		}
+	}
+	if err := uc.closeWriters(encryptionWriter, storageWriter); err != nil {
+		<-saveErrCh
+		return nil, err
+	}
+
+	saveErr := <-saveErrCh
+	runRecorder.SetToolOutput([]byte(warnings.String()))
+
+	if archiveErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
+		sizeMB := float64(bytesWritten) / (1024 * 1024)
+		backupProgressListener(sizeMB)
+	}
+
+	switch {
+	case archiveErr != nil && !errors.Is(archiveErr, errArchiveNotRead):
+		return nil, fmt.Errorf("archive %s: %w", source.Path, archiveErr)
+	case copyErr != nil:
+		return nil, fmt.Errorf("copy to storage: %w", copyErr)
+	case saveErr != nil:
+		return nil, fmt.Errorf("save to storage: %w", saveErr)
+	}
+
+	if warnings.count() > 0 {
+		uc.logger.Warn(
+			"Filesystem backup completed with skipped entries",
+			"backupId", backupID,
+			"skippedCount", warnings.count(),
+		)
+	}
+
+	return &backupMetadata, nil
+}
+
+// setupCompression returns nil writer for uncompressed archives
+func (uc *CreateFilesystemBackupUsecase) setupCompression(
+	compression filesystemtypes.FilesystemCompression,
+	writer io.Writer,
+) (io.WriteCloser, error) {
+	switch compression {
+	case filesystemtypes.FilesystemCompressionZstd:
+		zstdWriter, err := zstd.NewWriter(
+			writer,
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(zstdCompressionLevel)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd writer: %w", err)
+		}
+		return zstdWriter, nil
+	case filesystemtypes.FilesystemCompressionGzip:
+		return gzip.NewWriter(writer), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (uc *CreateFilesystemBackupUsecase) createBackupContext(
+	parentCtx context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parentCtx, backupTimeout)
+
+	go func() {
+		ticker := time.NewTicker(shutdownCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+func (uc *CreateFilesystemBackupUsecase) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storageWriter io.WriteCloser,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	backupMetadata := common.BackupMetadata{
+		Encryption: backups_config.BackupEncryptionNone,
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return storageWriter, nil, backupMetadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
+		storageWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to create encryption writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+
+	backupMetadata.Encryption = backups_config.BackupEncryptionEncrypted
+	backupMetadata.EncryptionSalt = &saltBase64
+	backupMetadata.EncryptionIV = &nonceBase64
+
+	return encryptionWriter, encryptionWriter, backupMetadata, nil
+}
+
+func (uc *CreateFilesystemBackupUsecase) copyWithShutdownCheck(
+	ctx context.Context,
+	dst io.Writer,
+	src io.Reader,
+	backupProgressListener func(completedMBs float64),
+) (int64, error) {
+	buf := make([]byte, copyBufferSize)
+	var totalWritten int64
+	var lastReportedMB float64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return totalWritten, ctx.Err()
+		default:
+		}
+
+		if config.IsShouldShutdown() {
+			return totalWritten, errors.New("shutdown requested")
+		}
+
+		nr, readErr := src.Read(buf)
+		if nr > 0 {
+			writeResultCh := make(chan writeResult, 1)
+			go func() {
+				nw, writeErr := dst.Write(buf[:nr])
+				writeResultCh <- writeResult{nw, writeErr}
+			}()
+
+			var nw int
+			var writeErr error
+
+			select {
+			case <-ctx.Done():
+				return totalWritten, fmt.Errorf("copy cancelled during write: %w", ctx.Err())
+			case result := <-writeResultCh:
+				nw = result.bytesWritten
+				writeErr = result.writeErr
+			}
+
+			if nw < 0 || nr < nw {
+				nw = 0
+				if writeErr == nil {
+					writeErr = fmt.Errorf("invalid write result")
+				}
+			}
+
+			if writeErr != nil {
+				return totalWritten, writeErr
+			}
+			if nr != nw {
+				return totalWritten, io.ErrShortWrite
+			}
+			totalWritten += int64(nw)
+
+			if backupProgressListener != nil {
+				currentMB := float64(totalWritten) / (1024 * 1024)
+				if currentMB-lastReportedMB >= progressReportIntervalMB {
+					backupProgressListener(currentMB)
+					lastReportedMB = currentMB
+				}
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return totalWritten, nil
+			}
+			return totalWritten, readErr
+		}
+	}
+}
+
+func (uc *CreateFilesystemBackupUsecase) cleanupOnCancellation(
+	compressionWriter io.WriteCloser,
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+	saveErrCh chan error,
+) {
+	if compressionWriter != nil {
+		_ = compressionWriter.Close()
+	}
+	if encryptionWriter != nil {
+		_ = encryptionWriter.Close()
+	}
+	_ = storageWriter.CloseWithError(errors.New("backup cancelled"))
+	<-saveErrCh
+}
+
+func (uc *CreateFilesystemBackupUsecase) closeWriters(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+) error {
+	if encryptionWriter != nil {
+		if err := encryptionWriter.Close(); err != nil {
+			uc.logger.Error("Failed to close encryption writer", "error", err)
+			return fmt.Errorf("failed to close encryption writer: %w", err)
+		}
+	}
+	if err := storageWriter.Close(); err != nil {
+		uc.logger.Error("Failed to close storage writer", "error", err)
+		return fmt.Errorf("failed to close storage writer: %w", err)
+	}
+	return nil
+}
+
+func (uc *CreateFilesystemBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return errors.New("backup cancelled due to shutdown")
+	}
+	return errors.New("backup cancelled due to timeout")
+}
+
+// archiveWarnings collects skipped entries, they are shown as tool output of the run
+type archiveWarnings struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (w *archiveWarnings) add(message string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lines = append(w.lines, message)
+}
+
+func (w *archiveWarnings) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.lines)
+}
+
+func (w *archiveWarnings) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return strings.Join(w.lines, "\n")
+}
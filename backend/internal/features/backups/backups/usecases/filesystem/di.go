@@ -0,0 +1,17 @@
+package usecases_filesystem
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var createFilesystemBackupUsecase = &CreateFilesystemBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+func GetCreateFilesystemBackupUsecase() *CreateFilesystemBackupUsecase {
+	return createFilesystemBackupUsecase
+}
@@ -0,0 +1,208 @@
+package usecases_filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	filesystemtypes "databasus-backend/internal/features/databases/databases/filesystem"
+)
+
+// snapshotReleaseTimeout is independent of the backup context, a cancelled backup must
+// not leave a snapshot that keeps consuming space
+const snapshotReleaseTimeout = 2 * time.Minute
+
+// createSnapshot returns the directory to archive and a func releasing the snapshot.
+// Without a snapshot mode it is the source path itself
+func (uc *CreateFilesystemBackupUsecase) createSnapshot(
+	ctx context.Context,
+	backupID uuid.UUID,
+	source *filesystemtypes.FilesystemDatabase,
+) (string, func(), error) {
+	releaser := &snapshotReleaser{uc: uc}
+
+	var archivePath string
+	var err error
+
+	switch source.SnapshotMode {
+	case filesystemtypes.FilesystemSnapshotModeBtrfs:
+		archivePath, err = uc.createBtrfsSnapshot(ctx, backupID, source, releaser)
+	case filesystemtypes.FilesystemSnapshotModeLvm:
+		archivePath, err = uc.createLvmSnapshot(ctx, backupID, source, releaser)
+	default:
+		return source.Path, func() {}, nil
+	}
+
+	if err != nil {
+		releaser.release()
+		return "", nil, err
+	}
+
+	return archivePath, releaser.release, nil
+}
+
+// createBtrfsSnapshot takes a read-only snapshot next to the source, which has to be
+// a subvolume
+func (uc *CreateFilesystemBackupUsecase) createBtrfsSnapshot(
+	ctx context.Context,
+	backupID uuid.UUID,
+	source *filesystemtypes.FilesystemDatabase,
+	releaser *snapshotReleaser,
+) (string, error) {
+	sourcePath := filepath.Clean(source.Path)
+	snapshotPath := filepath.Join(
+		filepath.Dir(sourcePath),
+		".databasus-snapshot-"+backupID.String(),
+	)
+
+	if _, err := runSnapshotCommand(
+		ctx,
+		"btrfs", "subvolume", "snapshot", "-r", sourcePath, snapshotPath,
+	); err != nil {
+		return "", err
+	}
+
+	releaser.add(func(ctx context.Context) error {
+		_, err := runSnapshotCommand(ctx, "btrfs", "subvolume", "delete", snapshotPath)
+		return err
+	})
+
+	return snapshotPath, nil
+}
+
+// createLvmSnapshot snapshots the logical volume the source is on and mounts the
+// snapshot read-only. The archived path is the source path inside the mounted snapshot
+func (uc *CreateFilesystemBackupUsecase) createLvmSnapshot(
+	ctx context.Context,
+	backupID uuid.UUID,
+	source *filesystemtypes.FilesystemDatabase,
+	releaser *snapshotReleaser,
+) (string, error) {
+	sourcePath, err := filepath.EvalSymlinks(source.Path)
+	if err != nil {
+		return "", err
+	}
+
+	device, err := findMount(ctx, "SOURCE", sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	mountPoint, err := findMount(ctx, "TARGET", sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	fsType, err := findMount(ctx, "FSTYPE", sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	volumeGroup, err := runSnapshotCommand(ctx, "lvs", "--noheadings", "-o", "vg_name", device)
+	if err != nil {
+		return "", fmt.Errorf("path is not on an LVM logical volume: %w", err)
+	}
+
+	snapshotName := "databasus_" + strings.ReplaceAll(backupID.String(), "-", "")
+	snapshotVolume := volumeGroup + "/" + snapshotName
+
+	if _, err := runSnapshotCommand(
+		ctx,
+		"lvcreate",
+		"--snapshot",
+		"--size", source.LvmSnapshotSize,
+		"--name", snapshotName,
+		device,
+	); err != nil {
+		return "", err
+	}
+
+	releaser.add(func(ctx context.Context) error {
+		_, err := runSnapshotCommand(ctx, "lvremove", "-f", snapshotVolume)
+		return err
+	})
+
+	mountDir, err := os.MkdirTemp(config.GetEnv().TempFolder, "fs_snapshot_")
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot mount directory: %w", err)
+	}
+
+	releaser.add(func(context.Context) error {
+		return os.Remove(mountDir)
+	})
+
+	// XFS refuses to mount a snapshot with the same UUID as the mounted origin
+	mountOptions := "ro"
+	if fsType == "xfs" {
+		mountOptions += ",nouuid"
+	}
+
+	if _, err := runSnapshotCommand(
+		ctx,
+		"mount", "-o", mountOptions, "/dev/"+snapshotVolume, mountDir,
+	); err != nil {
+		return "", err
+	}
+
+	releaser.add(func(ctx context.Context) error {
+		_, err := runSnapshotCommand(ctx, "umount", mountDir)
+		return err
+	})
+
+	relativePath, err := filepath.Rel(mountPoint, sourcePath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(mountDir, relativePath), nil
+}
+
+// snapshotReleaser undoes snapshot steps in reverse order
+type snapshotReleaser struct {
+	uc    *CreateFilesystemBackupUsecase
+	steps []func(ctx context.Context) error
+}
+
+func (r *snapshotReleaser) add(step func(ctx context.Context) error) {
+	r.steps = append(r.steps, step)
+}
+
+func (r *snapshotReleaser) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotReleaseTimeout)
+	defer cancel()
+
+	for i := len(r.steps) - 1; i >= 0; i-- {
+		if err := r.steps[i](ctx); err != nil {
+			r.uc.logger.Error("Failed to release filesystem snapshot", "error", err)
+		}
+	}
+
+	r.steps = nil
+}
+
+func findMount(ctx context.Context, column, path string) (string, error) {
+	value, err := runSnapshotCommand(ctx, "findmnt", "-n", "-o", column, "--target", path)
+	if err != nil {
+		return "", fmt.Errorf("failed to find mount of %s: %w", path, err)
+	}
+
+	return value, nil
+}
+
+func runSnapshotCommand(ctx context.Context, name string, args ...string) (string, error) {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	trimmedOutput := strings.TrimSpace(string(output))
+
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w: %s", name, args[0], err, trimmedOutput)
+	}
+
+	return trimmedOutput, nil
+}
@@ -0,0 +1,456 @@
+package usecases_influxdb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	influxdbtypes "databasus-backend/internal/features/databases/databases/influxdb"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	"databasus-backend/internal/util/tools"
+)
+
+const (
+	backupTimeout            = 23 * time.Hour
+	shutdownCheckInterval    = 1 * time.Second
+	copyBufferSize           = 8 * 1024 * 1024
+	progressReportIntervalMB = 1.0
+)
+
+// errArchiveNotRead stops the archive writer after copying to storage failed
+var errArchiveNotRead = errors.New("archive is not read anymore")
+
+// CreateInfluxdbBackupUsecase runs `influx backup` into a temp directory and streams the
+// directory to the storage as a tar archive. The CLI writes shards gzipped already, so
+// the archive is not compressed again
+type CreateInfluxdbBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+type writeResult struct {
+	bytesWritten int
+	writeErr     error
+}
+
+func (uc *CreateInfluxdbBackupUsecase) Execute(
+	parentCtx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info(
+		"Creating InfluxDB backup via influx backup",
+		"databaseId", db.ID,
+		"storageId", storage.ID,
+	)
+
+	influx := db.Influxdb
+	if influx == nil {
+		return nil, fmt.Errorf("influxdb configuration is required")
+	}
+
+	token, err := influx.DecryptToken(uc.fieldEncryptor, db.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := uc.createBackupContext(parentCtx)
+	defer cancel()
+
+	backupDir, err := os.MkdirTemp(config.GetEnv().TempFolder, "influx_backup_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(backupDir); err != nil {
+			uc.logger.Error("Failed to remove InfluxDB backup directory", "error", err)
+		}
+	}()
+
+	runRecorder.StartPhase(common.BackupPhaseDump)
+	if err := uc.runInfluxBackup(ctx, influx, token, backupDir, runRecorder); err != nil {
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, err
+	}
+	runRecorder.FinishPhase(common.BackupPhaseDump, getDirectorySize(backupDir))
+
+	return uc.streamToStorage(
+		ctx,
+		backupID,
+		backupConfig,
+		backupDir,
+		storage,
+		backupProgressListener,
+		runRecorder,
+	)
+}
+
+func (uc *CreateInfluxdbBackupUsecase) runInfluxBackup(
+	ctx context.Context,
+	influx *influxdbtypes.InfluxdbDatabase,
+	token string,
+	backupDir string,
+	runRecorder *common.BackupRunRecorder,
+) error {
+	influxBin := tools.GetInfluxdbExecutable(
+		tools.InfluxdbExecutableInflux,
+		config.GetEnv().EnvMode,
+		config.GetEnv().InfluxdbInstallDir,
+	)
+
+	args := []string{"backup", backupDir}
+	if influx.Bucket != "" {
+		args = append(args, "--bucket", influx.Bucket, "--org", influx.Org)
+	}
+	if influx.SkipTLSVerify {
+		args = append(args, "--skip-verify")
+	}
+
+	uc.logger.Info("Executing InfluxDB backup command", "command", influxBin, "args", args)
+
+	cmd := exec.CommandContext(ctx, influxBin, args...)
+
+	// the token goes through env so it is not visible in the process list, configs of the
+	// host must not override it
+	cmd.Env = os.Environ()
+	cmd.Env = append(cmd.Env,
+		"INFLUX_HOST="+influx.URL,
+		"INFLUX_TOKEN="+token,
+		"INFLUX_CONFIGS_PATH="+os.DevNull,
+	)
+
+	output, err := cmd.CombinedOutput()
+	runRecorder.SetToolOutput(output, token)
+	if err != nil {
+		return fmt.Errorf("%s backup failed: %w\noutput: %s", filepath.Base(influxBin), err, output)
+	}
+
+	return nil
+}
+
+func (uc *CreateInfluxdbBackupUsecase) streamToStorage(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	backupDir string,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info("Streaming InfluxDB backup to storage", "backupDir", backupDir)
+
+	archiveReader, archiveWriter := io.Pipe()
+	archiveErrCh := make(chan error, 1)
+	go func() {
+		archiveErr := files_utils.WriteTarArchive(
+			ctx,
+			archiveWriter,
+			backupDir,
+			allEntries{},
+			func(message string) {
+				uc.logger.Warn("InfluxDB backup entry skipped", "message", message)
+			},
+		)
+		_ = archiveWriter.CloseWithError(archiveErr)
+		archiveErrCh <- archiveErr
+	}()
+
+	storageReader, storageWriter := io.Pipe()
+
+	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		storageWriter,
+	)
+	if err != nil {
+		_ = archiveReader.CloseWithError(err)
+		<-archiveErrCh
+		return nil, err
+	}
+
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
+	countingWriter := common.NewCountingWriter(finalWriter)
+
+	saveErrCh := make(chan error, 1)
+	go func() {
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
+		saveErrCh <- saveErr
+	}()
+
+	bytesWritten, copyErr := uc.copyWithShutdownCheck(
+		ctx,
+		countingWriter,
+		archiveReader,
+		backupProgressListener,
+	)
+
+	_ = archiveReader.CloseWithError(errArchiveNotRead)
+	archiveErr := <-archiveErrCh
+
+	select {
+	case <-ctx.Done():
+		uc.cleanupOnCancellation(encryptionWriter, storageWriter, saveErrCh)
+		return nil, uc.checkCancellationReason()
+	default:
+	}
+
+	if err := uc.closeWriters(encryptionWriter, storageWriter); err != nil {
+		<-saveErrCh
+		return nil, err
+	}
+
+	saveErr := <-saveErrCh
+
+	if archiveErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
+		sizeMB := float64(bytesWritten) / (1024 * 1024)
+		backupProgressListener(sizeMB)
+	}
+
+	switch {
+	case archiveErr != nil && !errors.Is(archiveErr, errArchiveNotRead):
+		return nil, fmt.Errorf("archive InfluxDB backup: %w", archiveErr)
+	case copyErr != nil:
+		return nil, fmt.Errorf("copy to storage: %w", copyErr)
+	case saveErr != nil:
+		return nil, fmt.Errorf("save to storage: %w", saveErr)
+	}
+
+	return &backupMetadata, nil
+}
+
+func (uc *CreateInfluxdbBackupUsecase) createBackupContext(
+	parentCtx context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parentCtx, backupTimeout)
+
+	go func() {
+		ticker := time.NewTicker(shutdownCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+func (uc *CreateInfluxdbBackupUsecase) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storageWriter io.WriteCloser,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	backupMetadata := common.BackupMetadata{
+		Encryption: backups_config.BackupEncryptionNone,
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return storageWriter, nil, backupMetadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
+		storageWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to create encryption writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+
+	backupMetadata.Encryption = backups_config.BackupEncryptionEncrypted
+	backupMetadata.EncryptionSalt = &saltBase64
+	backupMetadata.EncryptionIV = &nonceBase64
+
+	return encryptionWriter, encryptionWriter, backupMetadata, nil
+}
+
+func (uc *CreateInfluxdbBackupUsecase) copyWithShutdownCheck(
+	ctx context.Context,
+	dst io.Writer,
+	src io.Reader,
+	backupProgressListener func(completedMBs float64),
+) (int64, error) {
+	buf := make([]byte, copyBufferSize)
+	var totalWritten int64
+	var lastReportedMB float64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return totalWritten, ctx.Err()
+		default:
+		}
+
+		if config.IsShouldShutdown() {
+			return totalWritten, errors.New("shutdown requested")
+		}
+
+		nr, readErr := src.Read(buf)
+		if nr > 0 {
+			writeResultCh := make(chan writeResult, 1)
+			go func() {
+				nw, writeErr := dst.Write(buf[:nr])
+				writeResultCh <- writeResult{nw, writeErr}
+			}()
+
+			var nw int
+			var writeErr error
+
+			select {
+			case <-ctx.Done():
+				return totalWritten, fmt.Errorf("copy cancelled during write: %w", ctx.Err())
+			case result := <-writeResultCh:
+				nw = result.bytesWritten
+				writeErr = result.writeErr
+			}
+
+			if nw < 0 || nr < nw {
+				nw = 0
+				if writeErr == nil {
+					writeErr = fmt.Errorf("invalid write result")
+				}
+			}
+
+			if writeErr != nil {
+				return totalWritten, writeErr
+			}
+			if nr != nw {
+				return totalWritten, io.ErrShortWrite
+			}
+			totalWritten += int64(nw)
+
+			if backupProgressListener != nil {
+				currentMB := float64(totalWritten) / (1024 * 1024)
+				if currentMB-lastReportedMB >= progressReportIntervalMB {
+					backupProgressListener(currentMB)
+					lastReportedMB = currentMB
+				}
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return totalWritten, nil
+			}
+			return totalWritten, readErr
+		}
+	}
+}
+
+func (uc *CreateInfluxdbBackupUsecase) cleanupOnCancellation(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+	saveErrCh chan error,
+) {
+	if encryptionWriter != nil {
+		_ = encryptionWriter.Close()
+	}
+	_ = storageWriter.CloseWithError(errors.New("backup cancelled"))
+	<-saveErrCh
+}
+
+func (uc *CreateInfluxdbBackupUsecase) closeWriters(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+) error {
+	if encryptionWriter != nil {
+		if err := encryptionWriter.Close(); err != nil {
+			uc.logger.Error("Failed to close encryption writer", "error", err)
+			return fmt.Errorf("failed to close encryption writer: %w", err)
+		}
+	}
+	if err := storageWriter.Close(); err != nil {
+		uc.logger.Error("Failed to close storage writer", "error", err)
+		return fmt.Errorf("failed to close storage writer: %w", err)
+	}
+	return nil
+}
+
+func (uc *CreateInfluxdbBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return errors.New("backup cancelled due to shutdown")
+	}
+	return errors.New("backup cancelled due to timeout")
+}
+
+// allEntries archives the whole backup directory
+type allEntries struct{}
+
+func (allEntries) IsExcluded(_ string) bool { return false }
+
+func (allEntries) IsIncluded(_ string) bool { return true }
+
+func getDirectorySize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+
+	return size
+}
@@ -0,0 +1,17 @@
+package usecases_influxdb
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var createInfluxdbBackupUsecase = &CreateInfluxdbBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+func GetCreateInfluxdbBackupUsecase() *CreateInfluxdbBackupUsecase {
+	return createInfluxdbBackupUsecase
+}
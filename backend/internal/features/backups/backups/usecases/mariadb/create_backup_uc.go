@@ -57,6 +57,7 @@ func (uc *CreateMariadbBackupUsecase) Execute(
 	db *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info(
 		"Creating MariaDB backup via mariadb-dump",
@@ -94,6 +95,7 @@ func (uc *CreateMariadbBackupUsecase) Execute(
 		decryptedPassword,
 		storage,
 		backupProgressListener,
+		runRecorder,
 		mdb,
 	)
 }
@@ -102,7 +104,7 @@ func (uc *CreateMariadbBackupUsecase) buildMariadbDumpArgs(
 	mdb *mariadbtypes.MariadbDatabase,
 ) []string {
 	args := []string{
-		"--host=" + mdb.Host,
+		"--host=" + mdb.DialHost(),
 		"--port=" + strconv.Itoa(mdb.Port),
 		"--user=" + mdb.Username,
 		"--single-transaction",
@@ -142,6 +144,7 @@ func (uc *CreateMariadbBackupUsecase) streamToStorage(
 	password string,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 	mdbConfig *mariadbtypes.MariadbDatabase,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info("Streaming MariaDB backup to storage", "mariadbBin", mariadbBin)
@@ -179,7 +182,7 @@ func (uc *CreateMariadbBackupUsecase) streamToStorage(
 
 	stderrCh := make(chan []byte, 1)
 	go func() {
-		stderrOutput, _ := io.ReadAll(pgStderr)
+		stderrOutput, _ := io.ReadAll(runRecorder.WrapToolStderr(pgStderr, password))
 		stderrCh <- stderrOutput
 	}()
 
@@ -194,16 +197,28 @@ func (uc *CreateMariadbBackupUsecase) streamToStorage(
 		return nil, err
 	}
 
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
 	zstdWriter, err := zstd.NewWriter(finalWriter,
 		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(zstdStorageCompressionLevel)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create zstd writer: %w", err)
 	}
-	countingWriter := common.NewCountingWriter(zstdWriter)
+	countingWriter := common.NewCountingWriter(
+		runRecorder.WrapPhaseWriter(common.BackupPhaseCompress, zstdWriter),
+	)
 
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(ctx, uc.fieldEncryptor, uc.logger, backupID, storageReader)
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
 		saveErrCh <- saveErr
 	}()
 
@@ -217,7 +232,7 @@ func (uc *CreateMariadbBackupUsecase) streamToStorage(
 		bytesWritten, err := uc.copyWithShutdownCheck(
 			ctx,
 			countingWriter,
-			pgStdout,
+			runRecorder.WrapToolOutput(pgStdout),
 			backupProgressListener,
 		)
 		bytesWrittenCh <- bytesWritten
@@ -245,6 +260,7 @@ func (uc *CreateMariadbBackupUsecase) streamToStorage(
 
 	saveErr := <-saveErrCh
 	stderrOutput := <-stderrCh
+	runRecorder.SetToolOutput(stderrOutput, password)
 
 	if waitErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
 		sizeMB := float64(bytesWritten) / (1024 * 1024)
@@ -292,7 +308,7 @@ user=%s
 password="%s"
 host=%s
 port=%d
-`, mdbConfig.Username, tools.EscapeMariadbPassword(password), mdbConfig.Host, mdbConfig.Port)
+`, mdbConfig.Username, tools.EscapeMariadbPassword(password), mdbConfig.DialHost(), mdbConfig.Port)
 
 	if mdbConfig.IsHttps {
 		content += "ssl=true\n"
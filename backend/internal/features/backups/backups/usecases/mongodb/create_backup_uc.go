@@ -51,6 +51,7 @@ func (uc *CreateMongodbBackupUsecase) Execute(
 	db *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info(
 		"Creating MongoDB backup via mongodump",
@@ -86,6 +87,7 @@ func (uc *CreateMongodbBackupUsecase) Execute(
 		args,
 		storage,
 		backupProgressListener,
+		runRecorder,
 	)
 }
 
@@ -120,6 +122,7 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 	args []string,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info("Streaming MongoDB backup to storage", "mongodumpBin", mongodumpBin)
 
@@ -129,9 +132,11 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 	cmd := exec.CommandContext(ctx, mongodumpBin, args...)
 
 	safeArgs := make([]string, len(args))
+	uris := make([]string, 0, 1)
 	for i, arg := range args {
 		if len(arg) > 6 && arg[:6] == "--uri=" {
 			safeArgs[i] = "--uri=mongodb://***:***@***"
+			uris = append(uris, arg[6:])
 		} else {
 			safeArgs[i] = arg
 		}
@@ -156,7 +161,7 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 
 	stderrCh := make(chan []byte, 1)
 	go func() {
-		stderrOutput, _ := io.ReadAll(pgStderr)
+		stderrOutput, _ := io.ReadAll(runRecorder.WrapToolStderr(pgStderr, uris...))
 		stderrCh <- stderrOutput
 	}()
 
@@ -171,11 +176,21 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 		return nil, err
 	}
 
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
 	countingWriter := common.NewCountingWriter(finalWriter)
 
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(ctx, uc.fieldEncryptor, uc.logger, backupID, storageReader)
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
 		saveErrCh <- saveErr
 	}()
 
@@ -189,7 +204,7 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 		bytesWritten, copyErr := uc.copyWithShutdownCheck(
 			ctx,
 			countingWriter,
-			pgStdout,
+			runRecorder.WrapToolOutput(pgStdout),
 			backupProgressListener,
 		)
 		bytesWrittenCh <- bytesWritten
@@ -214,6 +229,7 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 
 	saveErr := <-saveErrCh
 	stderrOutput := <-stderrCh
+	runRecorder.SetToolOutput(stderrOutput, uris...)
 
 	if waitErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
 		sizeMB := float64(bytesWritten) / (1024 * 1024)
@@ -57,6 +57,7 @@ func (uc *CreateMysqlBackupUsecase) Execute(
 	db *databases.Database,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info(
 		"Creating MySQL backup via mysqldump",
@@ -94,13 +95,14 @@ func (uc *CreateMysqlBackupUsecase) Execute(
 		decryptedPassword,
 		storage,
 		backupProgressListener,
+		runRecorder,
 		my,
 	)
 }
 
 func (uc *CreateMysqlBackupUsecase) buildMysqldumpArgs(my *mysqltypes.MysqlDatabase) []string {
 	args := []string{
-		"--host=" + my.Host,
+		"--host=" + my.DialHost(),
 		"--port=" + strconv.Itoa(my.Port),
 		"--user=" + my.Username,
 		"--single-transaction",
@@ -156,6 +158,7 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 	password string,
 	storage *storages.Storage,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 	myConfig *mysqltypes.MysqlDatabase,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info("Streaming MySQL backup to storage", "mysqlBin", mysqlBin)
@@ -193,7 +196,7 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 
 	stderrCh := make(chan []byte, 1)
 	go func() {
-		stderrOutput, _ := io.ReadAll(pgStderr)
+		stderrOutput, _ := io.ReadAll(runRecorder.WrapToolStderr(pgStderr, password))
 		stderrCh <- stderrOutput
 	}()
 
@@ -208,16 +211,28 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 		return nil, err
 	}
 
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
 	zstdWriter, err := zstd.NewWriter(finalWriter,
 		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(zstdStorageCompressionLevel)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create zstd writer: %w", err)
 	}
-	countingWriter := common.NewCountingWriter(zstdWriter)
+	countingWriter := common.NewCountingWriter(
+		runRecorder.WrapPhaseWriter(common.BackupPhaseCompress, zstdWriter),
+	)
 
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(ctx, uc.fieldEncryptor, uc.logger, backupID, storageReader)
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
 		saveErrCh <- saveErr
 	}()
 
@@ -231,7 +246,7 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 		bytesWritten, err := uc.copyWithShutdownCheck(
 			ctx,
 			countingWriter,
-			pgStdout,
+			runRecorder.WrapToolOutput(pgStdout),
 			backupProgressListener,
 		)
 		bytesWrittenCh <- bytesWritten
@@ -259,6 +274,7 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 
 	saveErr := <-saveErrCh
 	stderrOutput := <-stderrCh
+	runRecorder.SetToolOutput(stderrOutput, password)
 
 	if waitErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
 		sizeMB := float64(bytesWritten) / (1024 * 1024)
@@ -306,7 +322,7 @@ user=%s
 password="%s"
 host=%s
 port=%d
-`, myConfig.Username, tools.EscapeMysqlPassword(password), myConfig.Host, myConfig.Port)
+`, myConfig.Username, tools.EscapeMysqlPassword(password), myConfig.DialHost(), myConfig.Port)
 
 	if myConfig.IsHttps {
 		content += "ssl-mode=REQUIRED\n"
@@ -0,0 +1,520 @@
+package usecases_neo4j
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	common "databasus-backend/internal/features/backups/backups/common"
+	backup_encryption "databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	neo4jtypes "databasus-backend/internal/features/databases/databases/neo4j"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+)
+
+const (
+	backupTimeout            = 23 * time.Hour
+	bringOnlineTimeout       = 10 * time.Minute
+	cleanupTimeout           = 5 * time.Minute
+	shutdownCheckInterval    = 1 * time.Second
+	copyBufferSize           = 8 * 1024 * 1024
+	progressReportIntervalMB = 1.0
+)
+
+// errArchiveNotRead stops the host stream after copying to storage failed
+var errArchiveNotRead = errors.New("archive is not read anymore")
+
+// CreateNeo4jBackupUsecase streams `neo4j-admin database dump` of the stopped database
+// to the storage, or takes an online `neo4j-admin database backup` on the host and
+// streams its directory as a tar archive. Both formats are compressed by Neo4j already
+type CreateNeo4jBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+type writeResult struct {
+	bytesWritten int
+	writeErr     error
+}
+
+func (uc *CreateNeo4jBackupUsecase) Execute(
+	parentCtx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	db *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	n4j := db.Neo4j
+	if n4j == nil {
+		return nil, fmt.Errorf("neo4j configuration is required")
+	}
+
+	uc.logger.Info(
+		"Creating Neo4j backup via neo4j-admin",
+		"databaseId", db.ID,
+		"storageId", storage.ID,
+		"mode", n4j.BackupMode,
+	)
+
+	client, err := n4j.NewClient(uc.fieldEncryptor, db.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := uc.createBackupContext(parentCtx)
+	defer cancel()
+
+	if n4j.BackupMode == neo4jtypes.Neo4jBackupModeOnline {
+		return uc.createOnlineBackup(
+			ctx,
+			backupID,
+			backupConfig,
+			client,
+			n4j,
+			storage,
+			backupProgressListener,
+			runRecorder,
+		)
+	}
+
+	return uc.createDump(
+		ctx,
+		backupID,
+		backupConfig,
+		client,
+		n4j,
+		storage,
+		backupProgressListener,
+		runRecorder,
+	)
+}
+
+// createDump stops the database for the whole dump, neo4j-admin cannot dump a running
+// database. It is started again even when the backup fails or is cancelled
+func (uc *CreateNeo4jBackupUsecase) createDump(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	client *neo4jtypes.Client,
+	n4j *neo4jtypes.Neo4jDatabase,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	if err := uc.takeOffline(ctx, client, n4j); err != nil {
+		return nil, err
+	}
+	defer uc.bringOnline(client, n4j)
+
+	var toolOutput []byte
+	backupMetadata, err := uc.streamToStorage(
+		ctx,
+		backupID,
+		backupConfig,
+		storage,
+		func(writer io.Writer) error {
+			output, err := client.Dump(ctx, n4j.Database, writer)
+			toolOutput = output
+			return err
+		},
+		true,
+		backupProgressListener,
+		runRecorder,
+	)
+	runRecorder.SetToolOutput(toolOutput)
+
+	return backupMetadata, err
+}
+
+// createOnlineBackup backs up into a directory on the host, which is removed after it
+// is streamed to the storage
+func (uc *CreateNeo4jBackupUsecase) createOnlineBackup(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	client *neo4jtypes.Client,
+	n4j *neo4jtypes.Neo4jDatabase,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	directory := "/var/tmp/databasus-backup-" + backupID.String()
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		defer cancel()
+
+		if err := client.RemoveDirectory(cleanupCtx, directory); err != nil {
+			uc.logger.Error(
+				"Failed to remove Neo4j backup directory on the host",
+				"directory", directory,
+				"error", err,
+			)
+		}
+	}()
+
+	runRecorder.StartPhase(common.BackupPhaseDump)
+	output, err := client.Backup(ctx, n4j.Database, directory)
+	runRecorder.SetToolOutput(output)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, uc.checkCancellationReason()
+		}
+		return nil, fmt.Errorf("neo4j-admin database backup failed: %w", err)
+	}
+	runRecorder.FinishPhase(common.BackupPhaseDump, 0)
+
+	return uc.streamToStorage(
+		ctx,
+		backupID,
+		backupConfig,
+		storage,
+		func(writer io.Writer) error {
+			return client.StreamDirectory(ctx, directory, writer)
+		},
+		false,
+		backupProgressListener,
+		runRecorder,
+	)
+}
+
+// takeOffline stops the database with Cypher on Enterprise Edition. Community Edition
+// serves a single user database, so the whole service is stopped
+func (uc *CreateNeo4jBackupUsecase) takeOffline(
+	ctx context.Context,
+	client *neo4jtypes.Client,
+	n4j *neo4jtypes.Neo4jDatabase,
+) error {
+	uc.logger.Info("Stopping Neo4j database for dump", "database", n4j.Database)
+
+	if n4j.Edition == neo4jtypes.Neo4jEditionEnterprise {
+		if err := client.StopDatabase(ctx, n4j.Database); err != nil {
+			return fmt.Errorf("failed to stop database %s: %w", n4j.Database, err)
+		}
+		return nil
+	}
+
+	if err := client.StopService(ctx); err != nil {
+		return fmt.Errorf("failed to stop service %s: %w", n4j.ServiceName, err)
+	}
+	return nil
+}
+
+// bringOnline runs on its own context, so a cancelled backup still starts the database
+func (uc *CreateNeo4jBackupUsecase) bringOnline(
+	client *neo4jtypes.Client,
+	n4j *neo4jtypes.Neo4jDatabase,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), bringOnlineTimeout)
+	defer cancel()
+
+	var err error
+	if n4j.Edition == neo4jtypes.Neo4jEditionEnterprise {
+		err = client.StartDatabase(ctx, n4j.Database)
+	} else {
+		err = client.StartService(ctx)
+	}
+	if err == nil {
+		err = client.WaitForDatabase(ctx, n4j.Database, bringOnlineTimeout)
+	}
+
+	if err != nil {
+		uc.logger.Error(
+			"Failed to bring Neo4j database online after dump, start it manually",
+			"database", n4j.Database,
+			"error", err,
+		)
+	}
+}
+
+// streamToStorage copies the output of the host command to the storage. Dumps are timed
+// by their first byte, online backups are taken before streaming
+func (uc *CreateNeo4jBackupUsecase) streamToStorage(
+	ctx context.Context,
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storage *storages.Storage,
+	produce func(writer io.Writer) error,
+	isDump bool,
+	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
+) (*common.BackupMetadata, error) {
+	uc.logger.Info("Streaming Neo4j backup to storage", "backupId", backupID)
+
+	archiveReader, archiveWriter := io.Pipe()
+	archiveErrCh := make(chan error, 1)
+	go func() {
+		archiveErr := produce(archiveWriter)
+		_ = archiveWriter.CloseWithError(archiveErr)
+		archiveErrCh <- archiveErr
+	}()
+
+	var sourceReader io.Reader = archiveReader
+	if isDump {
+		sourceReader = runRecorder.WrapToolOutput(archiveReader)
+	}
+
+	storageReader, storageWriter := io.Pipe()
+
+	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
+		backupID,
+		backupConfig,
+		storageWriter,
+	)
+	if err != nil {
+		_ = archiveReader.CloseWithError(err)
+		<-archiveErrCh
+		return nil, err
+	}
+
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
+	countingWriter := common.NewCountingWriter(finalWriter)
+
+	saveErrCh := make(chan error, 1)
+	go func() {
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
+		saveErrCh <- saveErr
+	}()
+
+	bytesWritten, copyErr := uc.copyWithShutdownCheck(
+		ctx,
+		countingWriter,
+		sourceReader,
+		backupProgressListener,
+	)
+
+	_ = archiveReader.CloseWithError(errArchiveNotRead)
+	archiveErr := <-archiveErrCh
+
+	select {
+	case <-ctx.Done():
+		uc.cleanupOnCancellation(encryptionWriter, storageWriter, saveErrCh)
+		return nil, uc.checkCancellationReason()
+	default:
+	}
+
+	if err := uc.closeWriters(encryptionWriter, storageWriter); err != nil {
+		<-saveErrCh
+		return nil, err
+	}
+
+	saveErr := <-saveErrCh
+
+	if archiveErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
+		sizeMB := float64(bytesWritten) / (1024 * 1024)
+		backupProgressListener(sizeMB)
+	}
+
+	switch {
+	case archiveErr != nil && !errors.Is(archiveErr, errArchiveNotRead):
+		return nil, fmt.Errorf("stream Neo4j backup: %w", archiveErr)
+	case copyErr != nil:
+		return nil, fmt.Errorf("copy to storage: %w", copyErr)
+	case saveErr != nil:
+		return nil, fmt.Errorf("save to storage: %w", saveErr)
+	}
+
+	return &backupMetadata, nil
+}
+
+func (uc *CreateNeo4jBackupUsecase) createBackupContext(
+	parentCtx context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parentCtx, backupTimeout)
+
+	go func() {
+		ticker := time.NewTicker(shutdownCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
+
+func (uc *CreateNeo4jBackupUsecase) setupBackupEncryption(
+	backupID uuid.UUID,
+	backupConfig *backups_config.BackupConfig,
+	storageWriter io.WriteCloser,
+) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
+	backupMetadata := common.BackupMetadata{
+		Encryption: backups_config.BackupEncryptionNone,
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return storageWriter, nil, backupMetadata, nil
+	}
+
+	salt, err := backup_encryption.GenerateSalt()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	nonce, err := backup_encryption.GenerateNonce()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+	}
+
+	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
+		storageWriter,
+		masterKey,
+		backupID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, nil, backupMetadata, fmt.Errorf("failed to create encryption writer: %w", err)
+	}
+
+	saltBase64 := base64.StdEncoding.EncodeToString(salt)
+	nonceBase64 := base64.StdEncoding.EncodeToString(nonce)
+
+	backupMetadata.Encryption = backups_config.BackupEncryptionEncrypted
+	backupMetadata.EncryptionSalt = &saltBase64
+	backupMetadata.EncryptionIV = &nonceBase64
+
+	return encryptionWriter, encryptionWriter, backupMetadata, nil
+}
+
+func (uc *CreateNeo4jBackupUsecase) copyWithShutdownCheck(
+	ctx context.Context,
+	dst io.Writer,
+	src io.Reader,
+	backupProgressListener func(completedMBs float64),
+) (int64, error) {
+	buf := make([]byte, copyBufferSize)
+	var totalWritten int64
+	var lastReportedMB float64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return totalWritten, ctx.Err()
+		default:
+		}
+
+		if config.IsShouldShutdown() {
+			return totalWritten, errors.New("shutdown requested")
+		}
+
+		nr, readErr := src.Read(buf)
+		if nr > 0 {
+			writeResultCh := make(chan writeResult, 1)
+			go func() {
+				nw, writeErr := dst.Write(buf[:nr])
+				writeResultCh <- writeResult{nw, writeErr}
+			}()
+
+			var nw int
+			var writeErr error
+
+			select {
+			case <-ctx.Done():
+				return totalWritten, fmt.Errorf("copy cancelled during write: %w", ctx.Err())
+			case result := <-writeResultCh:
+				nw = result.bytesWritten
+				writeErr = result.writeErr
+			}
+
+			if nw < 0 || nr < nw {
+				nw = 0
+				if writeErr == nil {
+					writeErr = fmt.Errorf("invalid write result")
+				}
+			}
+
+			if writeErr != nil {
+				return totalWritten, writeErr
+			}
+			if nr != nw {
+				return totalWritten, io.ErrShortWrite
+			}
+			totalWritten += int64(nw)
+
+			if backupProgressListener != nil {
+				currentMB := float64(totalWritten) / (1024 * 1024)
+				if currentMB-lastReportedMB >= progressReportIntervalMB {
+					backupProgressListener(currentMB)
+					lastReportedMB = currentMB
+				}
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return totalWritten, nil
+			}
+			return totalWritten, readErr
+		}
+	}
+}
+
+func (uc *CreateNeo4jBackupUsecase) cleanupOnCancellation(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+	saveErrCh chan error,
+) {
+	if encryptionWriter != nil {
+		_ = encryptionWriter.Close()
+	}
+	_ = storageWriter.CloseWithError(errors.New("backup cancelled"))
+	<-saveErrCh
+}
+
+func (uc *CreateNeo4jBackupUsecase) closeWriters(
+	encryptionWriter *backup_encryption.EncryptionWriter,
+	storageWriter *io.PipeWriter,
+) error {
+	if encryptionWriter != nil {
+		if err := encryptionWriter.Close(); err != nil {
+			uc.logger.Error("Failed to close encryption writer", "error", err)
+			return fmt.Errorf("failed to close encryption writer: %w", err)
+		}
+	}
+	if err := storageWriter.Close(); err != nil {
+		uc.logger.Error("Failed to close storage writer", "error", err)
+		return fmt.Errorf("failed to close storage writer: %w", err)
+	}
+	return nil
+}
+
+func (uc *CreateNeo4jBackupUsecase) checkCancellationReason() error {
+	if config.IsShouldShutdown() {
+		return errors.New("backup cancelled due to shutdown")
+	}
+	return errors.New("backup cancelled due to timeout")
+}
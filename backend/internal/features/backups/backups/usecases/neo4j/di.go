@@ -0,0 +1,17 @@
+package usecases_neo4j
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var createNeo4jBackupUsecase = &CreateNeo4jBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+func GetCreateNeo4jBackupUsecase() *CreateNeo4jBackupUsecase {
+	return createNeo4jBackupUsecase
+}
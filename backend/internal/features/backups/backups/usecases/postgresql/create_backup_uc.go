@@ -60,6 +60,7 @@ func (uc *CreatePostgresqlBackupUsecase) Execute(
 	backupProgressListener func(
 		completedMBs float64,
 	),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info(
 		"Creating PostgreSQL backup via pg_dump custom format",
@@ -101,9 +102,53 @@ func (uc *CreatePostgresqlBackupUsecase) Execute(
 		storage,
 		db,
 		backupProgressListener,
+		runRecorder,
 	)
 }
 
+// BuildRemoteDumpCommand returns pg_dump invocation for a dump made on another host. The
+// password goes to PGPASSWORD, the remote side does not know about temporary .pgpass files
+func (uc *CreatePostgresqlBackupUsecase) BuildRemoteDumpCommand(
+	db *databases.Database,
+) (*common.RemoteDumpCommand, error) {
+	pg := db.Postgresql
+
+	if pg == nil {
+		return nil, fmt.Errorf("postgresql database configuration is required for pg_dump backups")
+	}
+
+	if pg.Database == nil || *pg.Database == "" {
+		return nil, fmt.Errorf("database name is required for pg_dump backups")
+	}
+
+	password, err := uc.fieldEncryptor.Decrypt(db.ID, pg.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt database password: %w", err)
+	}
+
+	sslMode := "prefer"
+	if pg.IsHttps {
+		sslMode = "require"
+	}
+
+	env := []string{
+		"PGPASSWORD=" + password,
+		"PGCLIENTENCODING=UTF8",
+		"PGCONNECT_TIMEOUT=" + strconv.Itoa(pgConnectTimeout),
+		"PGSSLMODE=" + sslMode,
+	}
+	if pg.HostAddress != "" {
+		env = append(env, "PGHOSTADDR="+pg.HostAddress)
+	}
+
+	return &common.RemoteDumpCommand{
+		Tool:    "pg_dump",
+		Args:    uc.buildPgDumpArgs(pg),
+		Env:     env,
+		Secrets: []string{password},
+	}, nil
+}
+
 // streamToStorage streams pg_dump output directly to storage
 func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 	parentCtx context.Context,
@@ -115,6 +160,7 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 	storage *storages.Storage,
 	db *databases.Database,
 	backupProgressListener func(completedMBs float64),
+	runRecorder *common.BackupRunRecorder,
 ) (*common.BackupMetadata, error) {
 	uc.logger.Info("Streaming PostgreSQL backup to storage", "pgBin", pgBin, "args", args)
 
@@ -146,6 +192,12 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 		return nil, err
 	}
 
+	// pg_dump connects to the address resolved with the DNS override, the host given with -h
+	// is kept for TLS and .pgpass
+	if db.Postgresql.HostAddress != "" {
+		cmd.Env = append(cmd.Env, "PGHOSTADDR="+db.Postgresql.HostAddress)
+	}
+
 	pgStdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, fmt.Errorf("stdout pipe: %w", err)
@@ -159,7 +211,7 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 	// Capture stderr in a separate goroutine to ensure we don't miss any error output
 	stderrCh := make(chan []byte, 1)
 	go func() {
-		stderrOutput, _ := io.ReadAll(pgStderr)
+		stderrOutput, _ := io.ReadAll(runRecorder.WrapToolStderr(pgStderr, password))
 		stderrCh <- stderrOutput
 	}()
 
@@ -174,6 +226,10 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 		return nil, err
 	}
 
+	if encryptionWriter != nil {
+		finalWriter = runRecorder.WrapPhaseWriter(common.BackupPhaseEncrypt, finalWriter)
+	}
+
 	countingWriter := common.NewCountingWriter(finalWriter)
 
 	// The backup ID becomes the object key / filename in storage
@@ -181,7 +237,13 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 	// Start streaming into storage in its own goroutine
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(ctx, uc.fieldEncryptor, uc.logger, backupID, storageReader)
+		saveErr := storage.SaveFile(
+			ctx,
+			uc.fieldEncryptor,
+			uc.logger,
+			backupID,
+			runRecorder.WrapPhaseReader(common.BackupPhaseUpload, storageReader),
+		)
 		saveErrCh <- saveErr
 	}()
 
@@ -197,7 +259,7 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 		bytesWritten, err := uc.copyWithShutdownCheck(
 			ctx,
 			countingWriter,
-			pgStdout,
+			runRecorder.WrapToolOutput(pgStdout),
 			backupProgressListener,
 		)
 		bytesWrittenCh <- bytesWritten
@@ -222,6 +284,7 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 
 	saveErr := <-saveErrCh
 	stderrOutput := <-stderrCh
+	runRecorder.SetToolOutput(stderrOutput, password)
 
 	// Send final sizing after backup is completed
 	if waitErr == nil && copyErr == nil && saveErr == nil && backupProgressListener != nil {
@@ -0,0 +1,159 @@
+package backups_badges
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BackupBadgeController struct {
+	backupBadgeService *BackupBadgeService
+}
+
+func (c *BackupBadgeController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/backup-badges/database/:databaseId", c.GetBackupBadge)
+	router.POST("/backup-badges/database/:databaseId/token", c.GenerateToken)
+	router.DELETE("/backup-badges/database/:databaseId", c.DeleteBackupBadge)
+}
+
+// RegisterPublicRoutes exposes badges without auth, they are opened by the badge token
+func (c *BackupBadgeController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.GET("/backup-badges/public/:token", c.GetPublicBadge)
+}
+
+// GetBackupBadge
+// @Summary Get backup badge
+// @Description Get the freshness badge of a database, null when there is no badge
+// @Tags backup-badges
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Success 200 {object} BackupBadge
+// @Failure 400
+// @Failure 401
+// @Router /backup-badges/database/{databaseId} [get]
+func (c *BackupBadgeController) GetBackupBadge(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	badge, err := c.backupBadgeService.GetBackupBadge(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, badge)
+}
+
+// GenerateToken
+// @Summary Generate backup badge token
+// @Description Create the freshness badge of a database or replace its token, embeds with the previous token stop rendering
+// @Tags backup-badges
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Success 200 {object} BackupBadgeTokenResponse
+// @Failure 400
+// @Failure 401
+// @Router /backup-badges/database/{databaseId}/token [post]
+func (c *BackupBadgeController) GenerateToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	response, err := c.backupBadgeService.GenerateToken(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteBackupBadge
+// @Summary Delete backup badge
+// @Description Delete the freshness badge of a database, its embeds stop rendering
+// @Tags backup-badges
+// @Param databaseId path string true "Database ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /backup-badges/database/{databaseId} [delete]
+func (c *BackupBadgeController) DeleteBackupBadge(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	if err := c.backupBadgeService.DeleteBackupBadge(user, databaseID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetPublicBadge
+// @Summary Get public backup badge
+// @Description Get an SVG badge with the age of the last completed backup of the database, opened by the badge token without auth. Backups older than maxAgeHours (24 by default) are shown as stale. The token may end with .svg
+// @Tags backup-badges
+// @Produce image/svg+xml
+// @Param token path string true "Backup badge token"
+// @Param maxAgeHours query int false "Hours after which the last backup is stale"
+// @Param label query string false "Left text of the badge"
+// @Success 200 {string} string
+// @Failure 400
+// @Failure 404
+// @Router /backup-badges/public/{token} [get]
+func (c *BackupBadgeController) GetPublicBadge(ctx *gin.Context) {
+	var request GetPublicBadgeRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token := strings.TrimSuffix(ctx.Param("token"), ".svg")
+
+	badge, err := c.backupBadgeService.GetPublicBadge(token, &request, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, ErrBackupBadgeNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// image proxies of wikis and code hosts would otherwise keep showing an old age
+	ctx.Header("Cache-Control", "no-cache, max-age=0")
+	ctx.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(badge))
+}
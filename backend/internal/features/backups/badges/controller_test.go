@@ -0,0 +1,96 @@
+package backups_badges
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetBackupBadgeController(),
+	)
+
+	v1 := router.Group("/api/v1")
+	GetBackupBadgeController().RegisterPublicRoutes(v1)
+
+	return router
+}
+
+func Test_GetPublicBadge_WhenDatabaseHasNoBackups_NoBackupsBadgeRendered(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	var tokenResponse BackupBadgeTokenResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-badges/database/"+database.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&tokenResponse,
+	)
+	assert.NotEmpty(t, tokenResponse.Token)
+
+	response := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/backup-badges/public/"+tokenResponse.Token+".svg?label=orders",
+		"",
+		http.StatusOK,
+	)
+
+	badge := string(response.Body)
+	assert.True(t, strings.HasPrefix(badge, "<svg "))
+	assert.Contains(t, badge, "<title>orders: no backups</title>")
+
+	// The previous token stops working once it is replaced
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-badges/database/"+database.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+	)
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/backup-badges/public/"+tokenResponse.Token,
+		"",
+		http.StatusNotFound,
+	)
+}
+
+func Test_RenderBadge_WhenTextHasMarkup_TextEscaped(t *testing.T) {
+	badge := renderBadge("<db>", formatAge(26*time.Hour), BadgeStatusStale.color())
+
+	assert.Contains(t, badge, "<title>&lt;db&gt;: 1d ago</title>")
+	assert.Contains(t, badge, `fill="#dfb317"`)
+	assert.NotContains(t, badge, "<db>")
+}
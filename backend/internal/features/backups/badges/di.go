@@ -0,0 +1,28 @@
+package backups_badges
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var backupBadgeRepository = &BackupBadgeRepository{}
+var backupBadgeService = &BackupBadgeService{
+	backupBadgeRepository,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+}
+var backupBadgeController = &BackupBadgeController{
+	backupBadgeService,
+}
+
+func GetBackupBadgeService() *BackupBadgeService {
+	return backupBadgeService
+}
+
+func GetBackupBadgeController() *BackupBadgeController {
+	return backupBadgeController
+}
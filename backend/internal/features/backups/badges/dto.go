@@ -0,0 +1,15 @@
+package backups_badges
+
+// BackupBadgeTokenResponse has the token of the badge, it is returned only when the token
+// is generated
+type BackupBadgeTokenResponse struct {
+	BackupBadge *BackupBadge `json:"backupBadge"`
+	Token       string       `json:"token"`
+}
+
+// GetPublicBadgeRequest is the query of a badge. MaxAgeHours marks older backups as stale,
+// label replaces the left text of the badge
+type GetPublicBadgeRequest struct {
+	MaxAgeHours int    `form:"maxAgeHours"`
+	Label       string `form:"label"`
+}
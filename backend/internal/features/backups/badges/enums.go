@@ -0,0 +1,23 @@
+package backups_badges
+
+type BadgeStatus string
+
+const (
+	BadgeStatusFresh     BadgeStatus = "FRESH"
+	BadgeStatusStale     BadgeStatus = "STALE"
+	BadgeStatusFailing   BadgeStatus = "FAILING"
+	BadgeStatusNoBackups BadgeStatus = "NO_BACKUPS"
+)
+
+func (s BadgeStatus) color() string {
+	switch s {
+	case BadgeStatusFresh:
+		return "#4c1"
+	case BadgeStatusStale:
+		return "#dfb317"
+	case BadgeStatusFailing:
+		return "#e05d44"
+	default:
+		return "#9f9f9f"
+	}
+}
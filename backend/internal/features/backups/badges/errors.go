@@ -0,0 +1,10 @@
+package backups_badges
+
+import "errors"
+
+var (
+	ErrBackupBadgeNotFound                  = errors.New("backup badge not found")
+	ErrInsufficientPermissionsToManageBadge = errors.New(
+		"insufficient permissions to manage backup badge",
+	)
+)
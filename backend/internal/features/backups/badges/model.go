@@ -0,0 +1,23 @@
+package backups_badges
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupBadge is an SVG badge with backup freshness of a database, opened by a token so it
+// can be embedded in wikis and READMEs without auth
+type BackupBadge struct {
+	ID         uuid.UUID `json:"id"         gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;not null"`
+
+	// TokenHash is sha256 of the token, the token is shown only when it is generated
+	TokenHash      string    `json:"-"              gorm:"column:token_hash;type:text;not null"`
+	TokenRotatedAt time.Time `json:"tokenRotatedAt" gorm:"column:token_rotated_at"`
+	CreatedAt      time.Time `json:"createdAt"      gorm:"column:created_at"`
+}
+
+func (BackupBadge) TableName() string {
+	return "backup_badges"
+}
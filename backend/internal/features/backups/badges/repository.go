@@ -0,0 +1,44 @@
+package backups_badges
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupBadgeRepository struct{}
+
+func (r *BackupBadgeRepository) Save(badge *BackupBadge) error {
+	return storage.GetDb().Save(badge).Error
+}
+
+func (r *BackupBadgeRepository) FindByDatabaseID(databaseID uuid.UUID) (*BackupBadge, error) {
+	return r.findBy("database_id = ?", databaseID)
+}
+
+func (r *BackupBadgeRepository) FindByTokenHash(tokenHash string) (*BackupBadge, error) {
+	return r.findBy("token_hash = ?", tokenHash)
+}
+
+func (r *BackupBadgeRepository) Delete(badge *BackupBadge) error {
+	return storage.GetDb().Delete(&BackupBadge{}, "id = ?", badge.ID).Error
+}
+
+func (r *BackupBadgeRepository) findBy(query string, value any) (*BackupBadge, error) {
+	var badge BackupBadge
+
+	if err := storage.
+		GetDb().
+		Where(query, value).
+		First(&badge).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &badge, nil
+}
@@ -0,0 +1,274 @@
+package backups_badges
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	backupBadgeTokenPrefix = "dbb_"
+
+	defaultBadgeLabel       = "backup"
+	maxBadgeLabelLength     = 40
+	defaultBadgeMaxAgeHours = 24
+	maxBadgeMaxAgeHours     = 365 * 24
+
+	// latestBackupsLimit bounds the backups searched for the last finished one, backups in
+	// progress are skipped
+	latestBackupsLimit = 10
+)
+
+type BackupBadgeService struct {
+	backupBadgeRepository *BackupBadgeRepository
+	backupRepository      *backups_core.BackupRepository
+	databaseService       *databases.DatabaseService
+	workspaceService      *workspaces_services.WorkspaceService
+	auditLogService       *audit_logs.AuditLogService
+}
+
+func (s *BackupBadgeService) GetBackupBadge(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*BackupBadge, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, ErrInsufficientPermissionsToManageBadge
+	}
+
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToManageBadge
+	}
+
+	return s.backupBadgeRepository.FindByDatabaseID(databaseID)
+}
+
+// GenerateToken creates the badge of the database or replaces its token, embeds with the
+// previous token stop rendering
+func (s *BackupBadgeService) GenerateToken(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*BackupBadgeTokenResponse, error) {
+	database, err := s.getManagedDatabase(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	badge, err := s.backupBadgeRepository.FindByDatabaseID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if badge == nil {
+		badge = &BackupBadge{
+			ID:         uuid.New(),
+			DatabaseID: databaseID,
+			CreatedAt:  now,
+		}
+	}
+
+	token, err := generateBackupBadgeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	badge.TokenHash = hashBackupBadgeToken(token)
+	badge.TokenRotatedAt = now
+
+	if err := s.backupBadgeRepository.Save(badge); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup badge token generated for database: %s", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return &BackupBadgeTokenResponse{BackupBadge: badge, Token: token}, nil
+}
+
+func (s *BackupBadgeService) DeleteBackupBadge(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) error {
+	database, err := s.getManagedDatabase(user, databaseID)
+	if err != nil {
+		return err
+	}
+
+	badge, err := s.backupBadgeRepository.FindByDatabaseID(databaseID)
+	if err != nil {
+		return err
+	}
+	if badge == nil {
+		return ErrBackupBadgeNotFound
+	}
+
+	if err := s.backupBadgeRepository.Delete(badge); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup badge deleted for database: %s", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+// GetPublicBadge renders the badge opened by the token. It shows the age of the last
+// completed backup, or that backups fail when the last finished backup failed
+func (s *BackupBadgeService) GetPublicBadge(
+	token string,
+	request *GetPublicBadgeRequest,
+	now time.Time,
+) (string, error) {
+	label := strings.TrimSpace(request.Label)
+	if label == "" {
+		label = defaultBadgeLabel
+	}
+	if len(label) > maxBadgeLabelLength {
+		return "", fmt.Errorf("label cannot be longer than %d characters", maxBadgeLabelLength)
+	}
+
+	maxAgeHours := request.MaxAgeHours
+	if maxAgeHours == 0 {
+		maxAgeHours = defaultBadgeMaxAgeHours
+	}
+	if maxAgeHours < 1 || maxAgeHours > maxBadgeMaxAgeHours {
+		return "", fmt.Errorf("max age must be between 1 and %d hours", maxBadgeMaxAgeHours)
+	}
+
+	badge, err := s.backupBadgeRepository.FindByTokenHash(hashBackupBadgeToken(token))
+	if err != nil {
+		return "", err
+	}
+	if badge == nil {
+		return "", ErrBackupBadgeNotFound
+	}
+
+	status, message, err := s.getBadgeStatus(
+		badge.DatabaseID,
+		time.Duration(maxAgeHours)*time.Hour,
+		now,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return renderBadge(label, message, status.color()), nil
+}
+
+func (s *BackupBadgeService) getBadgeStatus(
+	databaseID uuid.UUID,
+	maxAge time.Duration,
+	now time.Time,
+) (BadgeStatus, string, error) {
+	backups, err := s.backupRepository.FindByDatabaseIDWithLimit(databaseID, latestBackupsLimit)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Backups are ordered from the latest, the first finished one sets the status
+	for _, backup := range backups {
+		if backup.Status == backups_core.BackupStatusFailed {
+			return BadgeStatusFailing, "failing", nil
+		}
+
+		if backup.Status == backups_core.BackupStatusCompleted {
+			break
+		}
+	}
+
+	lastBackups, err := s.backupRepository.FindLastCompletedByDatabaseIDs(
+		[]uuid.UUID{databaseID},
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	lastBackup, isFound := lastBackups[databaseID]
+	if !isFound {
+		return BadgeStatusNoBackups, "no backups", nil
+	}
+
+	age := now.Sub(lastBackup.CreatedAt)
+	if age > maxAge {
+		return BadgeStatusStale, formatAge(age), nil
+	}
+
+	return BadgeStatusFresh, formatAge(age), nil
+}
+
+func (s *BackupBadgeService) getManagedDatabase(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*databases.Database, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, ErrInsufficientPermissionsToManageBadge
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageBadge
+	}
+
+	return database, nil
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
+
+func generateBackupBadgeToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate backup badge token: %w", err)
+	}
+
+	return backupBadgeTokenPrefix + hex.EncodeToString(randomBytes), nil
+}
+
+func hashBackupBadgeToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,78 @@
+package backups_badges
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// badgeCharWidth approximates Verdana at 11px, badges are not measured precisely like
+	// shields.io but stay readable for short texts
+	badgeCharWidth     = 7
+	badgeSidePadding   = 5
+	badgeLabelColor    = "#555"
+	badgeTextBaselineY = 14
+)
+
+// renderBadge draws a flat badge in the style of CI badges, the label on the left and the
+// message on a colored background on the right
+func renderBadge(label string, message string, color string) string {
+	labelWidth := utf8.RuneCountInString(label)*badgeCharWidth + 2*badgeSidePadding
+	messageWidth := utf8.RuneCountInString(message)*badgeCharWidth + 2*badgeSidePadding
+	width := labelWidth + messageWidth
+
+	escapedLabel := html.EscapeString(label)
+	escapedMessage := html.EscapeString(message)
+
+	var svg strings.Builder
+
+	fmt.Fprintf(
+		&svg,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`,
+		width, escapedLabel, escapedMessage,
+	)
+	fmt.Fprintf(&svg, `<title>%s: %s</title>`, escapedLabel, escapedMessage)
+	svg.WriteString(
+		`<linearGradient id="s" x2="0" y2="100%">` +
+			`<stop offset="0" stop-color="#bbb" stop-opacity=".1"/>` +
+			`<stop offset="1" stop-opacity=".1"/>` +
+			`</linearGradient>`,
+	)
+	fmt.Fprintf(
+		&svg,
+		`<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`,
+		width,
+	)
+	fmt.Fprintf(
+		&svg,
+		`<g clip-path="url(#r)">`+
+			`<rect width="%d" height="20" fill="%s"/>`+
+			`<rect x="%d" width="%d" height="20" fill="%s"/>`+
+			`<rect width="%d" height="20" fill="url(#s)"/>`+
+			`</g>`,
+		labelWidth, badgeLabelColor,
+		labelWidth, messageWidth, color,
+		width,
+	)
+	svg.WriteString(
+		`<g fill="#fff" text-anchor="middle" ` +
+			`font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`,
+	)
+	writeBadgeText(&svg, labelWidth/2, escapedLabel)
+	writeBadgeText(&svg, labelWidth+messageWidth/2, escapedMessage)
+	svg.WriteString(`</g></svg>`)
+
+	return svg.String()
+}
+
+// writeBadgeText writes the text twice, the darker copy one pixel lower is its shadow
+func writeBadgeText(svg *strings.Builder, x int, text string) {
+	fmt.Fprintf(
+		svg,
+		`<text x="%d" y="%d" fill="#010101" fill-opacity=".3">%s</text>`,
+		x, badgeTextBaselineY+1, text,
+	)
+	fmt.Fprintf(svg, `<text x="%d" y="%d">%s</text>`, x, badgeTextBaselineY, text)
+}
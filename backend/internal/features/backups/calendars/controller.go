@@ -0,0 +1,148 @@
+package backups_calendars
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CalendarFeedController struct {
+	calendarFeedService *CalendarFeedService
+}
+
+func (c *CalendarFeedController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/calendar-feeds/workspace/:workspaceId", c.GetCalendarFeed)
+	router.POST("/calendar-feeds/workspace/:workspaceId/token", c.GenerateToken)
+	router.DELETE("/calendar-feeds/workspace/:workspaceId", c.DeleteCalendarFeed)
+}
+
+// RegisterPublicRoutes exposes calendar feeds without auth, they are opened by the feed token
+func (c *CalendarFeedController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.GET("/calendar-feeds/public/:token", c.GetPublicCalendar)
+}
+
+// GetCalendarFeed
+// @Summary Get calendar feed
+// @Description Get the iCal feed of a workspace, null when there is no feed
+// @Tags calendar-feeds
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} CalendarFeed
+// @Failure 400
+// @Failure 401
+// @Router /calendar-feeds/workspace/{workspaceId} [get]
+func (c *CalendarFeedController) GetCalendarFeed(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	feed, err := c.calendarFeedService.GetCalendarFeed(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+}
+
+// GenerateToken
+// @Summary Generate calendar feed token
+// @Description Create the iCal feed of a workspace or replace its token, subscriptions with the previous token stop working
+// @Tags calendar-feeds
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} CalendarFeedTokenResponse
+// @Failure 400
+// @Failure 401
+// @Router /calendar-feeds/workspace/{workspaceId}/token [post]
+func (c *CalendarFeedController) GenerateToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	response, err := c.calendarFeedService.GenerateToken(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteCalendarFeed
+// @Summary Delete calendar feed
+// @Description Delete the iCal feed of a workspace, its subscriptions stop working
+// @Tags calendar-feeds
+// @Param workspaceId path string true "Workspace ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /calendar-feeds/workspace/{workspaceId} [delete]
+func (c *CalendarFeedController) DeleteCalendarFeed(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	if err := c.calendarFeedService.DeleteCalendarFeed(user, workspaceID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetPublicCalendar
+// @Summary Get public calendar
+// @Description Get scheduled backups and refreshes of a workspace for the next two weeks as iCal, opened by the feed token without auth. The token may end with .ics
+// @Tags calendar-feeds
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Success 200 {string} string
+// @Failure 404
+// @Router /calendar-feeds/public/{token} [get]
+func (c *CalendarFeedController) GetPublicCalendar(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	calendar, err := c.calendarFeedService.GetPublicCalendar(token, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, ErrCalendarFeedNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
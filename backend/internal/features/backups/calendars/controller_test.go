@@ -0,0 +1,130 @@
+package backups_calendars
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/intervals"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/period"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetCalendarFeedController(),
+	)
+
+	v1 := router.Group("/api/v1")
+	GetCalendarFeedController().RegisterPublicRoutes(v1)
+
+	return router
+}
+
+func Test_GetPublicCalendar_WhenBackupsScheduledDaily_EventPerDayExported(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	backupConfig, err := backups_config.GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	timeOfDay := "04:00"
+	backupConfig.BackupInterval = &intervals.Interval{
+		Interval:  intervals.IntervalDaily,
+		TimeOfDay: &timeOfDay,
+	}
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.StorePeriod = period.PeriodWeek
+	backupConfig.Storage = storage
+	backupConfig.StorageID = &storage.ID
+
+	_, err = backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	var tokenResponse CalendarFeedTokenResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/calendar-feeds/workspace/"+workspace.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&tokenResponse,
+	)
+	assert.NotEmpty(t, tokenResponse.Token)
+
+	response := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/calendar-feeds/public/"+tokenResponse.Token+".ics",
+		"",
+		http.StatusOK,
+	)
+
+	calendar := string(response.Body)
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, calendar, "SUMMARY:Backup: ")
+	assert.GreaterOrEqual(t, strings.Count(calendar, "BEGIN:VEVENT"), 14)
+
+	// The previous token stops working once it is replaced
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/calendar-feeds/workspace/"+workspace.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+	)
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/calendar-feeds/public/"+tokenResponse.Token,
+		"",
+		http.StatusNotFound,
+	)
+}
+
+func Test_RenderCalendar_WhenTextIsLong_LinesEscapedAndFolded(t *testing.T) {
+	startsAt := time.Date(2026, 4, 1, 4, 0, 0, 0, time.UTC)
+	calendar := renderCalendar("Team, backups", []calendarEvent{
+		{
+			UID:         "backup-1@databasus",
+			StartsAt:    startsAt,
+			EndsAt:      startsAt.Add(time.Hour),
+			Summary:     "Backup: orders",
+			Description: strings.Repeat("nightly dump; ", 10),
+			Category:    "Backup",
+		},
+	}, startsAt)
+
+	assert.Contains(t, calendar, "X-WR-CALNAME:Team\\, backups\r\n")
+	assert.Contains(t, calendar, "DTSTART:20260401T040000Z\r\n")
+	assert.Contains(t, calendar, `DESCRIPTION:nightly dump\; `)
+
+	for _, line := range strings.Split(calendar, "\r\n") {
+		assert.LessOrEqual(t, len(line), icalMaxLineOctets)
+	}
+}
@@ -0,0 +1,32 @@
+package backups_calendars
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	restores_refreshes "databasus-backend/internal/features/restores/refreshes"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var calendarFeedRepository = &CalendarFeedRepository{}
+var calendarFeedService = &CalendarFeedService{
+	calendarFeedRepository,
+	&restores_refreshes.RefreshRepository{},
+	backuping.GetBackupsScheduler(),
+	backups_config.GetBackupConfigService(),
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+}
+var calendarFeedController = &CalendarFeedController{
+	calendarFeedService,
+}
+
+func GetCalendarFeedService() *CalendarFeedService {
+	return calendarFeedService
+}
+
+func GetCalendarFeedController() *CalendarFeedController {
+	return calendarFeedController
+}
@@ -0,0 +1,8 @@
+package backups_calendars
+
+// CalendarFeedTokenResponse has the token of the feed, it is returned only when the token
+// is generated
+type CalendarFeedTokenResponse struct {
+	CalendarFeed *CalendarFeed `json:"calendarFeed"`
+	Token        string        `json:"token"`
+}
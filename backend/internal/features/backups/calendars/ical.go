@@ -0,0 +1,92 @@
+package backups_calendars
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalTimeLayout = "20060102T150405Z"
+
+	// icalMaxLineOctets is the line length after which RFC 5545 requires folding
+	icalMaxLineOctets = 75
+)
+
+type calendarEvent struct {
+	UID         string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Summary     string
+	Description string
+	Category    string
+}
+
+// renderCalendar builds an RFC 5545 calendar, times are written in UTC so calendar apps
+// show them in the time zone of the reader
+func renderCalendar(name string, events []calendarEvent, now time.Time) string {
+	var builder strings.Builder
+
+	writeLine := func(line string) {
+		builder.WriteString(foldLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//Databasus//Backup schedules//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeText(name))
+
+	for _, event := range events {
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + event.UID)
+		writeLine("DTSTAMP:" + now.UTC().Format(icalTimeLayout))
+		writeLine("DTSTART:" + event.StartsAt.UTC().Format(icalTimeLayout))
+		writeLine("DTEND:" + event.EndsAt.UTC().Format(icalTimeLayout))
+		writeLine("SUMMARY:" + escapeText(event.Summary))
+		writeLine("DESCRIPTION:" + escapeText(event.Description))
+		writeLine("CATEGORIES:" + escapeText(event.Category))
+		writeLine("TRANSP:TRANSPARENT")
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+
+	return builder.String()
+}
+
+func escapeText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// foldLine splits long lines into continuation lines starting with a space, without
+// breaking multi-byte characters
+func foldLine(line string) string {
+	if len(line) <= icalMaxLineOctets {
+		return line
+	}
+
+	var builder strings.Builder
+	lineOctets := 0
+
+	for _, char := range line {
+		charOctets := utf8.RuneLen(char)
+		if lineOctets+charOctets > icalMaxLineOctets {
+			builder.WriteString("\r\n ")
+			lineOctets = 1
+		}
+
+		builder.WriteRune(char)
+		lineOctets += charOctets
+	}
+
+	return builder.String()
+}
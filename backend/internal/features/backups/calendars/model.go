@@ -0,0 +1,23 @@
+package backups_calendars
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed is an iCal feed of scheduled backups and refreshes of a workspace, opened by
+// a token so calendar apps can subscribe to it without auth
+type CalendarFeed struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+
+	// TokenHash is sha256 of the token, the token is shown only when it is generated
+	TokenHash      string    `json:"-"              gorm:"column:token_hash;type:text;not null"`
+	TokenRotatedAt time.Time `json:"tokenRotatedAt" gorm:"column:token_rotated_at"`
+	CreatedAt      time.Time `json:"createdAt"      gorm:"column:created_at"`
+}
+
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
@@ -0,0 +1,44 @@
+package backups_calendars
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CalendarFeedRepository struct{}
+
+func (r *CalendarFeedRepository) Save(feed *CalendarFeed) error {
+	return storage.GetDb().Save(feed).Error
+}
+
+func (r *CalendarFeedRepository) FindByWorkspaceID(workspaceID uuid.UUID) (*CalendarFeed, error) {
+	return r.findBy("workspace_id = ?", workspaceID)
+}
+
+func (r *CalendarFeedRepository) FindByTokenHash(tokenHash string) (*CalendarFeed, error) {
+	return r.findBy("token_hash = ?", tokenHash)
+}
+
+func (r *CalendarFeedRepository) Delete(feed *CalendarFeed) error {
+	return storage.GetDb().Delete(&CalendarFeed{}, "id = ?", feed.ID).Error
+}
+
+func (r *CalendarFeedRepository) findBy(query string, value any) (*CalendarFeed, error) {
+	var feed CalendarFeed
+
+	if err := storage.
+		GetDb().
+		Where(query, value).
+		First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,315 @@
+package backups_calendars
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	restores_refreshes "databasus-backend/internal/features/restores/refreshes"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	calendarFeedTokenPrefix = "dcf_"
+
+	// calendarPeriod bounds how far ahead schedules are replayed on each request
+	calendarPeriod = 14 * 24 * time.Hour
+
+	// refreshProjectionTick matches the tick of the refresh background service
+	refreshProjectionTick = time.Minute
+
+	// defaultRefreshDuration is used for refresh jobs without a finished run yet
+	defaultRefreshDuration = 30 * time.Minute
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarFeedService struct {
+	calendarFeedRepository *CalendarFeedRepository
+	refreshRepository      *restores_refreshes.RefreshRepository
+	backupsScheduler       *backuping.BackupsScheduler
+	backupConfigService    *backups_config.BackupConfigService
+	databaseService        *databases.DatabaseService
+	workspaceService       *workspaces_services.WorkspaceService
+	auditLogService        *audit_logs.AuditLogService
+}
+
+func (s *CalendarFeedService) GetCalendarFeed(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*CalendarFeed, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view calendar feed")
+	}
+
+	return s.calendarFeedRepository.FindByWorkspaceID(workspaceID)
+}
+
+// GenerateToken creates the feed of the workspace or replaces its token, subscriptions
+// with the previous token stop working
+func (s *CalendarFeedService) GenerateToken(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*CalendarFeedTokenResponse, error) {
+	if err := s.checkCanManage(user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	feed, err := s.calendarFeedRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if feed == nil {
+		feed = &CalendarFeed{
+			ID:          uuid.New(),
+			WorkspaceID: workspaceID,
+			CreatedAt:   now,
+		}
+	}
+
+	token, err := generateCalendarFeedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed.TokenHash = hashCalendarFeedToken(token)
+	feed.TokenRotatedAt = now
+
+	if err := s.calendarFeedRepository.Save(feed); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog("Calendar feed token generated", &user.ID, &workspaceID)
+
+	return &CalendarFeedTokenResponse{CalendarFeed: feed, Token: token}, nil
+}
+
+func (s *CalendarFeedService) DeleteCalendarFeed(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	if err := s.checkCanManage(user, workspaceID); err != nil {
+		return err
+	}
+
+	feed, err := s.calendarFeedRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+	if feed == nil {
+		return ErrCalendarFeedNotFound
+	}
+
+	if err := s.calendarFeedRepository.Delete(feed); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog("Calendar feed deleted", &user.ID, &workspaceID)
+
+	return nil
+}
+
+// GetPublicCalendar renders scheduled backups and refresh jobs of the workspace of the
+// feed over the next two weeks. Paused schedules and retries are not shown
+func (s *CalendarFeedService) GetPublicCalendar(token string, now time.Time) (string, error) {
+	feed, err := s.calendarFeedRepository.FindByTokenHash(hashCalendarFeedToken(token))
+	if err != nil {
+		return "", err
+	}
+	if feed == nil {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(feed.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(feed.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	databaseNames := make(map[uuid.UUID]string, len(workspaceDatabases))
+	databaseIDs := make([]uuid.UUID, 0, len(workspaceDatabases))
+	for _, database := range workspaceDatabases {
+		databaseNames[database.ID] = database.Name
+		databaseIDs = append(databaseIDs, database.ID)
+	}
+
+	calendarEnd := now.Add(calendarPeriod)
+
+	backupEvents, err := s.getBackupEvents(databaseIDs, databaseNames, now, calendarEnd)
+	if err != nil {
+		return "", err
+	}
+
+	refreshEvents, err := s.getRefreshEvents(databaseIDs, databaseNames, now, calendarEnd)
+	if err != nil {
+		return "", err
+	}
+
+	events := append(backupEvents, refreshEvents...)
+	slices.SortFunc(events, func(a, b calendarEvent) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	return renderCalendar("Databasus: "+workspace.Name, events, now), nil
+}
+
+func (s *CalendarFeedService) getBackupEvents(
+	databaseIDs []uuid.UUID,
+	databaseNames map[uuid.UUID]string,
+	from time.Time,
+	to time.Time,
+) ([]calendarEvent, error) {
+	enabledBackupConfigs, err := s.backupConfigService.GetBackupConfigsWithEnabledBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceBackupConfigs := make([]*backups_config.BackupConfig, 0)
+	for _, backupConfig := range enabledBackupConfigs {
+		if slices.Contains(databaseIDs, backupConfig.DatabaseID) {
+			workspaceBackupConfigs = append(workspaceBackupConfigs, backupConfig)
+		}
+	}
+
+	runs, err := s.backupsScheduler.ProjectRuns(workspaceBackupConfigs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]calendarEvent, 0, len(runs))
+	for _, run := range runs {
+		databaseName := databaseNames[run.DatabaseID]
+
+		events = append(events, calendarEvent{
+			UID:      fmt.Sprintf("backup-%s-%d@databasus", run.DatabaseID, run.StartsAt.Unix()),
+			StartsAt: run.StartsAt,
+			EndsAt:   run.EndsAt,
+			Summary:  "Backup: " + databaseName,
+			Description: fmt.Sprintf(
+				"Scheduled backup of %s, expected to take as long as its last backup",
+				databaseName,
+			),
+			Category: "Backup",
+		})
+	}
+
+	return events, nil
+}
+
+// getRefreshEvents replays refresh jobs like their background service does, each run is
+// expected to take as long as the last finished run of the job
+func (s *CalendarFeedService) getRefreshEvents(
+	databaseIDs []uuid.UUID,
+	databaseNames map[uuid.UUID]string,
+	from time.Time,
+	to time.Time,
+) ([]calendarEvent, error) {
+	jobs, err := s.refreshRepository.FindEnabledJobsByDatabaseIDs(databaseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]calendarEvent, 0)
+	for _, job := range jobs {
+		if job.Interval == nil {
+			continue
+		}
+
+		duration, err := s.getRefreshDuration(job)
+		if err != nil {
+			return nil, err
+		}
+
+		lastRunAt := job.LastRunAt
+		for tick := from; tick.Before(to); tick = tick.Add(refreshProjectionTick) {
+			if !job.Interval.ShouldTriggerBackup(tick, lastRunAt) {
+				continue
+			}
+
+			events = append(events, calendarEvent{
+				UID:      fmt.Sprintf("refresh-%s-%d@databasus", job.ID, tick.Unix()),
+				StartsAt: tick,
+				EndsAt:   tick.Add(duration),
+				Summary:  fmt.Sprintf("Refresh: %s (%s)", job.Name, databaseNames[job.DatabaseID]),
+				Description: fmt.Sprintf(
+					"Scheduled restore of the latest backup of %s by refresh job %s",
+					databaseNames[job.DatabaseID],
+					job.Name,
+				),
+				Category: "Refresh",
+			})
+
+			startedAt := tick
+			lastRunAt = &startedAt
+		}
+	}
+
+	return events, nil
+}
+
+func (s *CalendarFeedService) getRefreshDuration(
+	job *restores_refreshes.RefreshJob,
+) (time.Duration, error) {
+	runs, err := s.refreshRepository.FindRunsByJobID(job.ID, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(runs) == 0 || runs[0].FinishedAt == nil {
+		return defaultRefreshDuration, nil
+	}
+
+	return max(runs[0].FinishedAt.Sub(runs[0].StartedAt), refreshProjectionTick), nil
+}
+
+func (s *CalendarFeedService) checkCanManage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to manage calendar feed")
+	}
+
+	return nil
+}
+
+func generateCalendarFeedToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+
+	return calendarFeedTokenPrefix + hex.EncodeToString(randomBytes), nil
+}
+
+// hashCalendarFeedToken uses plain sha256 like status page tokens, the tokens are random
+// and long
+func hashCalendarFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,38 @@
+package backups_config
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConcurrencyGroup limits scheduled backups running at once for databases of the group,
+// e.g. databases on one server, so dumps do not compete for its IO. Manual backups are
+// started regardless of the limit
+type ConcurrencyGroup struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Name        string    `json:"name"        gorm:"column:name;type:text;not null"`
+
+	MaxConcurrentBackups int `json:"maxConcurrentBackups" gorm:"column:max_concurrent_backups;type:int;not null"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (ConcurrencyGroup) TableName() string {
+	return "backup_concurrency_groups"
+}
+
+func (g *ConcurrencyGroup) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return errors.New("concurrency group name is required")
+	}
+
+	if g.MaxConcurrentBackups < 1 {
+		return errors.New("max concurrent backups must be at least 1")
+	}
+
+	return nil
+}
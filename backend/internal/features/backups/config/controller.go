@@ -2,6 +2,7 @@ package backups_config
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	users_middleware "databasus-backend/internal/features/users/middleware"
@@ -21,6 +22,25 @@ func (c *BackupConfigController) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/backup-configs/storage/:id/is-using", c.IsStorageUsing)
 	router.GET("/backup-configs/storage/:id/databases-count", c.CountDatabasesForStorage)
 	router.POST("/backup-configs/database/:id/transfer", c.TransferDatabase)
+	router.GET("/backup-configs/database/:id/transfers", c.GetDatabaseTransfers)
+	router.POST("/backup-configs/database/:id/pause", c.PauseDatabaseSchedule)
+	router.POST("/backup-configs/database/:id/resume", c.ResumeDatabaseSchedule)
+	router.POST("/backup-configs/workspace/:workspaceId/pause", c.PauseWorkspaceSchedules)
+	router.POST("/backup-configs/workspace/:workspaceId/resume", c.ResumeWorkspaceSchedules)
+	router.POST(
+		"/backup-configs/workspace/:workspaceId/assign-default-storage",
+		c.AssignDefaultStorage,
+	)
+	router.GET(
+		"/backup-configs/concurrency-groups/workspace/:workspaceId",
+		c.GetConcurrencyGroups,
+	)
+	router.POST(
+		"/backup-configs/concurrency-groups/workspace/:workspaceId",
+		c.CreateConcurrencyGroup,
+	)
+	router.PUT("/backup-configs/concurrency-groups/:id", c.UpdateConcurrencyGroup)
+	router.DELETE("/backup-configs/concurrency-groups/:id", c.DeleteConcurrencyGroup)
 }
 
 // SaveBackupConfig
@@ -194,12 +214,12 @@ func (c *BackupConfigController) CountDatabasesForStorage(ctx *gin.Context) {
 
 // TransferDatabase
 // @Summary Transfer database to another workspace
-// @Description Transfer a database from one workspace to another. Can transfer to a new storage or transfer with the existing storage. Can also specify target notifiers from the target workspace.
+// @Description Transfer a database from one workspace to another. Can transfer to a new storage, to an equivalent storage of the target workspace or with the existing storage. Can also specify target notifiers from the target workspace. Backups made before the move stay on their storage and the move is recorded in the transfer history.
 // @Tags backup-configs
 // @Accept json
 // @Produce json
 // @Param id path string true "Database ID"
-// @Param request body TransferDatabaseRequest true "Transfer request with targetWorkspaceId, storage options (targetStorageId or isTransferWithStorage), and optional targetNotifierIds"
+// @Param request body TransferDatabaseRequest true "Transfer request with targetWorkspaceId, storage options (targetStorageId, isUseEquivalentStorage or isTransferWithStorage), and optional targetNotifierIds"
 // @Success 200 {object} map[string]string "Database transferred successfully"
 // @Failure 400 {object} map[string]string "Invalid request, target storage/notifier not in target workspace, or transfer failed"
 // @Failure 401 {object} map[string]string "User not authenticated"
@@ -241,3 +261,387 @@ func (c *BackupConfigController) TransferDatabase(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "database transferred successfully"})
 }
+
+// GetDatabaseTransfers
+// @Summary Get transfer history of database
+// @Description Get moves of the database between workspaces with the storages used before and after each move, newest first
+// @Tags backup-configs
+// @Produce json
+// @Param id path string true "Database ID"
+// @Success 200 {array} DatabaseTransfer
+// @Failure 400 {object} map[string]string "Invalid database ID or insufficient permissions"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Router /backup-configs/database/{id}/transfers [get]
+func (c *BackupConfigController) GetDatabaseTransfers(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	transfers, err := c.backupConfigService.GetDatabaseTransfers(user, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+// PauseDatabaseSchedule
+// @Summary Pause backup schedule of database
+// @Description Stop scheduled backups and retries of the database until resumed or until pausedUntil. Manual backups still run. The reason is written to the audit log
+// @Tags backup-configs
+// @Accept json
+// @Param id path string true "Database ID"
+// @Param request body PauseScheduleRequest false "Optional auto-resume time and reason"
+// @Success 204
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/database/{id}/pause [post]
+func (c *BackupConfigController) PauseDatabaseSchedule(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request PauseScheduleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.backupConfigService.PauseDatabaseSchedule(user, id, &request); err != nil {
+		c.respondSchedulePauseError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ResumeDatabaseSchedule
+// @Summary Resume backup schedule of database
+// @Description Resume scheduled backups of a paused database
+// @Tags backup-configs
+// @Param id path string true "Database ID"
+// @Success 204
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/database/{id}/resume [post]
+func (c *BackupConfigController) ResumeDatabaseSchedule(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	if err := c.backupConfigService.ResumeDatabaseSchedule(user, id); err != nil {
+		c.respondSchedulePauseError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PauseWorkspaceSchedules
+// @Summary Pause backup schedules of workspace
+// @Description Pause backup schedules of all databases of the workspace until resumed or until pausedUntil. The reason is written to the audit log
+// @Tags backup-configs
+// @Accept json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body PauseScheduleRequest false "Optional auto-resume time and reason"
+// @Success 204
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/workspace/{workspaceId}/pause [post]
+func (c *BackupConfigController) PauseWorkspaceSchedules(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request PauseScheduleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.backupConfigService.PauseWorkspaceSchedules(
+		user,
+		workspaceID,
+		&request,
+	); err != nil {
+		c.respondSchedulePauseError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ResumeWorkspaceSchedules
+// @Summary Resume backup schedules of workspace
+// @Description Resume backup schedules of all databases of the workspace
+// @Tags backup-configs
+// @Param workspaceId path string true "Workspace ID"
+// @Success 204
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/workspace/{workspaceId}/resume [post]
+func (c *BackupConfigController) ResumeWorkspaceSchedules(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	if err := c.backupConfigService.ResumeWorkspaceSchedules(user, workspaceID); err != nil {
+		c.respondSchedulePauseError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AssignDefaultStorage
+// @Summary Assign default storage to workspace databases
+// @Description Attach the default storage of the workspace to its databases without storage. With isReassignAttached databases on other storages are moved too and their backups are deleted
+// @Tags backup-configs
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body AssignDefaultStorageRequest false "Whether to move databases attached to other storages"
+// @Success 200 {object} AssignDefaultStorageResponse
+// @Failure 400 {object} map[string]string "Invalid request or no default storage"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/workspace/{workspaceId}/assign-default-storage [post]
+func (c *BackupConfigController) AssignDefaultStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request AssignDefaultStorageRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.backupConfigService.AssignDefaultStorage(user, workspaceID, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToAssignStorage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetConcurrencyGroups
+// @Summary Get concurrency groups
+// @Description Get groups limiting scheduled backups running at once, e.g. for databases on one server
+// @Tags backup-configs
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {array} ConcurrencyGroup
+// @Failure 400 {object} map[string]string "Invalid workspace ID or insufficient permissions"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Router /backup-configs/concurrency-groups/workspace/{workspaceId} [get]
+func (c *BackupConfigController) GetConcurrencyGroups(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	groups, err := c.backupConfigService.GetConcurrencyGroups(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
+// CreateConcurrencyGroup
+// @Summary Create concurrency group
+// @Description Create a group limiting scheduled backups of its databases running at once
+// @Tags backup-configs
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body ConcurrencyGroup true "Name and max concurrent backups"
+// @Success 200 {object} ConcurrencyGroup
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/concurrency-groups/workspace/{workspaceId} [post]
+func (c *BackupConfigController) CreateConcurrencyGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var group ConcurrencyGroup
+	if err := ctx.ShouldBindJSON(&group); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdGroup, err := c.backupConfigService.CreateConcurrencyGroup(user, workspaceID, &group)
+	if err != nil {
+		c.respondConcurrencyGroupError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdGroup)
+}
+
+// UpdateConcurrencyGroup
+// @Summary Update concurrency group
+// @Description Update name and max concurrent backups of a concurrency group
+// @Tags backup-configs
+// @Accept json
+// @Produce json
+// @Param id path string true "Concurrency group ID"
+// @Param request body ConcurrencyGroup true "Name and max concurrent backups"
+// @Success 200 {object} ConcurrencyGroup
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/concurrency-groups/{id} [put]
+func (c *BackupConfigController) UpdateConcurrencyGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid concurrency group ID"})
+		return
+	}
+
+	var group ConcurrencyGroup
+	if err := ctx.ShouldBindJSON(&group); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedGroup, err := c.backupConfigService.UpdateConcurrencyGroup(user, id, &group)
+	if err != nil {
+		c.respondConcurrencyGroupError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedGroup)
+}
+
+// DeleteConcurrencyGroup
+// @Summary Delete concurrency group
+// @Description Delete a concurrency group, its databases are backed up without the limit
+// @Tags backup-configs
+// @Param id path string true "Concurrency group ID"
+// @Success 204
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /backup-configs/concurrency-groups/{id} [delete]
+func (c *BackupConfigController) DeleteConcurrencyGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid concurrency group ID"})
+		return
+	}
+
+	if err := c.backupConfigService.DeleteConcurrencyGroup(user, id); err != nil {
+		c.respondConcurrencyGroupError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *BackupConfigController) respondConcurrencyGroupError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrInsufficientPermissionsToManageConcurrencyGroup) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+func (c *BackupConfigController) respondSchedulePauseError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrInsufficientPermissionsToPauseSchedule) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
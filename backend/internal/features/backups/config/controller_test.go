@@ -345,6 +345,86 @@ func Test_GetBackupConfigByDbID_ReturnsDefaultConfigForNewDatabase(t *testing.T)
 	assert.NotNil(t, response.BackupInterval)
 }
 
+func Test_PauseWorkspaceSchedules_ThenResumeDatabase_PauseKeptUntilResumed(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configURL := "/api/v1/backup-configs/database/" + database.ID.String()
+
+	var backupConfig BackupConfig
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		configURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&backupConfig,
+	)
+
+	pausedUntil := time.Now().UTC().Add(2 * time.Hour).Truncate(time.Second)
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-configs/workspace/"+workspace.ID.String()+"/pause",
+		"Bearer "+owner.Token,
+		PauseScheduleRequest{PausedUntil: &pausedUntil, Reason: "storage migration"},
+		http.StatusNoContent,
+	)
+
+	// Saving the config from a form unaware of the pause keeps it
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-configs/save",
+		"Bearer "+owner.Token,
+		backupConfig,
+		http.StatusOK,
+	)
+
+	var pausedConfig BackupConfig
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		configURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&pausedConfig,
+	)
+	assert.True(t, pausedConfig.IsSchedulePaused)
+	assert.NotNil(t, pausedConfig.SchedulePausedUntil)
+	assert.True(t, pausedConfig.IsSchedulePausedAt(time.Now().UTC()))
+	assert.False(t, pausedConfig.IsSchedulePausedAt(pausedUntil.Add(time.Minute)))
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		configURL+"/resume",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusNoContent,
+	)
+
+	var resumedConfig BackupConfig
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		configURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&resumedConfig,
+	)
+	assert.False(t, resumedConfig.IsSchedulePaused)
+	assert.Nil(t, resumedConfig.SchedulePausedUntil)
+}
+
 func Test_GetDatabasePlan_ForNewDatabase_PlanAlwaysReturned(t *testing.T) {
 	router := createTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -1026,6 +1106,76 @@ func Test_TransferDatabase_ToNewStorage_DatabaseTransferd(t *testing.T) {
 	assert.Equal(t, targetStorage.ID, *retrievedConfig.StorageID)
 }
 
+func Test_TransferDatabase_ToEquivalentStorage_TransferRecorded(t *testing.T) {
+	router := createTestRouterWithStorageForTransfer()
+
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	sourceWorkspace := workspaces_testing.CreateTestWorkspace("Source Workspace", owner, router)
+	targetWorkspace := workspaces_testing.CreateTestWorkspace("Target Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", sourceWorkspace.ID, owner.Token, router)
+	sourceStorage := createTestStorage(sourceWorkspace.ID)
+
+	var targetStorage storages.Storage
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+owner.Token,
+		storages.Storage{
+			WorkspaceID:  targetWorkspace.ID,
+			Type:         storages.StorageTypeLocal,
+			Name:         sourceStorage.Name,
+			LocalStorage: &local_storage.LocalStorage{},
+		},
+		http.StatusOK,
+		&targetStorage,
+	)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(200 * time.Millisecond) // Wait for cascading deletes
+		workspaces_testing.RemoveTestWorkspace(sourceWorkspace, router)
+		workspaces_testing.RemoveTestWorkspace(targetWorkspace, router)
+	}()
+
+	backupConfig, err := GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	backupConfig.Storage = sourceStorage
+	backupConfig.StorageID = &sourceStorage.ID
+	_, err = GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-configs/database/"+database.ID.String()+"/transfer",
+		"Bearer "+owner.Token,
+		TransferDatabaseRequest{
+			TargetWorkspaceID:      targetWorkspace.ID,
+			IsUseEquivalentStorage: true,
+		},
+		http.StatusOK,
+	)
+
+	var transfers []DatabaseTransfer
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/database/"+database.ID.String()+"/transfers",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&transfers,
+	)
+
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, sourceWorkspace.ID, transfers[0].SourceWorkspaceID)
+	assert.Equal(t, targetWorkspace.ID, transfers[0].TargetWorkspaceID)
+	assert.Equal(t, sourceStorage.ID, *transfers[0].SourceStorageID)
+	assert.Equal(t, targetStorage.ID, *transfers[0].TargetStorageID)
+	assert.Equal(t, owner.UserID, transfers[0].TransferredByUserID)
+}
+
 func Test_TransferDatabase_WithExistingStorage_DatabaseAndStorageTransferd(t *testing.T) {
 	router := createTestRouterWithStorageForTransfer()
 
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	audit_logs "databasus-backend/internal/features/audit_logs"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/notifiers"
 	plans "databasus-backend/internal/features/plan"
@@ -20,6 +21,7 @@ var backupConfigService = &BackupConfigService{
 	notifiers.GetNotifierService(),
 	workspaces_services.GetWorkspaceService(),
 	plans.GetDatabasePlanService(),
+	audit_logs.GetAuditLogService(),
 	nil,
 }
 var backupConfigController = &BackupConfigController{
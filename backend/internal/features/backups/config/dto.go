@@ -1,11 +1,32 @@
 package backups_config
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type TransferDatabaseRequest struct {
 	TargetWorkspaceID       uuid.UUID   `json:"targetWorkspaceId"                 binding:"required"`
 	TargetStorageID         *uuid.UUID  `json:"targetStorageId,omitempty"`
 	IsTransferWithStorage   bool        `json:"isTransferWithStorage,omitempty"`
+	IsUseEquivalentStorage  bool        `json:"isUseEquivalentStorage,omitempty"`
 	IsTransferWithNotifiers bool        `json:"isTransferWithNotifiers,omitempty"`
 	TargetNotifierIDs       []uuid.UUID `json:"targetNotifierIds,omitempty"`
 }
+
+type PauseScheduleRequest struct {
+	// PausedUntil resumes the schedule automatically, the pause lasts until resumed if empty
+	PausedUntil *time.Time `json:"pausedUntil,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+}
+
+type AssignDefaultStorageRequest struct {
+	// IsReassignAttached also moves databases attached to another storage, deleting their
+	// backups
+	IsReassignAttached bool `json:"isReassignAttached,omitempty"`
+}
+
+type AssignDefaultStorageResponse struct {
+	AssignedDatabasesCount int `json:"assignedDatabasesCount"`
+}
@@ -27,4 +27,28 @@ var (
 	ErrTargetStorageNotSpecified = errors.New(
 		"target storage is not specified",
 	)
+	ErrEquivalentStorageNotFound = errors.New(
+		"target workspace has no storage of the same type and name",
+	)
+	ErrConcurrencyGroupNotFound = errors.New(
+		"concurrency group not found",
+	)
+	ErrConcurrencyGroupNotInWorkspace = errors.New(
+		"concurrency group does not belong to the workspace of the database",
+	)
+	ErrInsufficientPermissionsToManageConcurrencyGroup = errors.New(
+		"insufficient permissions to manage concurrency groups in this workspace",
+	)
+	ErrInsufficientPermissionsToPauseSchedule = errors.New(
+		"insufficient permissions to pause backup schedules in this workspace",
+	)
+	ErrInsufficientPermissionsToAssignStorage = errors.New(
+		"insufficient permissions to assign storage in this workspace",
+	)
+	ErrWorkspaceHasNoDefaultStorage = errors.New(
+		"workspace has no default storage",
+	)
+	ErrPausedUntilInPast = errors.New(
+		"pause end must be in the future",
+	)
 )
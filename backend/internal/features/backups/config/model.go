@@ -6,13 +6,21 @@ import (
 	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
 	"databasus-backend/internal/util/period"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// MaxScheduleSpreadMinutes keeps daily backups within the half of a day after their slot
+const MaxScheduleSpreadMinutes = 720
+
 type BackupConfig struct {
 	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;primaryKey;not null"`
 
@@ -38,6 +46,32 @@ type BackupConfig struct {
 	MaxBackupSizeMB int64 `json:"maxBackupSizeMb"       gorm:"column:max_backup_size_mb;type:int;not null"`
 	// MaxBackupsTotalSizeMB limits total size of all backups. 0 = unlimited.
 	MaxBackupsTotalSizeMB int64 `json:"maxBackupsTotalSizeMb" gorm:"column:max_backups_total_size_mb;type:int;not null"`
+
+	// ScheduleSpreadMinutes delays scheduled backups by a stable offset within the window,
+	// so databases sharing a time of day do not start at once. 0 = no delay
+	ScheduleSpreadMinutes int `json:"scheduleSpreadMinutes" gorm:"column:schedule_spread_minutes;type:int;not null;default:0"`
+
+	// ConcurrencyGroupID limits scheduled backups running at once with other databases of
+	// the group
+	ConcurrencyGroupID *uuid.UUID `json:"concurrencyGroupId" gorm:"column:concurrency_group_id;type:uuid"`
+
+	// Timeouts terminate the backup or restore process, HangTimeoutMinutes aborts and retries
+	// backups with no bytes progressed for that long. 0 = global default from env
+	BackupTimeoutMinutes  int `json:"backupTimeoutMinutes"  gorm:"column:backup_timeout_minutes;type:int;not null;default:0"`
+	RestoreTimeoutMinutes int `json:"restoreTimeoutMinutes" gorm:"column:restore_timeout_minutes;type:int;not null;default:0"`
+	HangTimeoutMinutes    int `json:"hangTimeoutMinutes"    gorm:"column:hang_timeout_minutes;type:int;not null;default:0"`
+
+	// Paused schedules start no scheduled backups or retries until resumed, or until
+	// SchedulePausedUntil if set. Managed by pause endpoints only, saving the config keeps it
+	IsSchedulePaused    bool       `json:"isSchedulePaused"    gorm:"column:is_schedule_paused;type:boolean;not null;default:false"`
+	SchedulePausedUntil *time.Time `json:"schedulePausedUntil" gorm:"column:schedule_paused_until;type:timestamptz"`
+
+	// FileNameTemplate names backup files of the database, it overrides the template of the
+	// storage. Used only on storages supporting file naming
+	FileNameTemplate string `json:"fileNameTemplate" gorm:"column:file_name_template;type:text;not null;default:''"`
+
+	RetentionHolds       []RetentionHold `json:"retentionHolds" gorm:"-"`
+	RetentionHoldsString string          `json:"-"              gorm:"column:retention_holds;type:text;not null;default:'[]'"`
 }
 
 func (h *BackupConfig) TableName() string {
@@ -58,6 +92,18 @@ func (b *BackupConfig) BeforeSave(tx *gorm.DB) error {
 		b.SendNotificationsOnString = ""
 	}
 
+	// Convert RetentionHolds array to JSON
+	retentionHolds := b.RetentionHolds
+	if retentionHolds == nil {
+		retentionHolds = []RetentionHold{}
+	}
+
+	retentionHoldsJSON, err := json.Marshal(retentionHolds)
+	if err != nil {
+		return err
+	}
+	b.RetentionHoldsString = string(retentionHoldsJSON)
+
 	return nil
 }
 
@@ -74,6 +120,14 @@ func (b *BackupConfig) AfterFind(tx *gorm.DB) error {
 		b.SendNotificationsOn = []BackupNotificationType{}
 	}
 
+	// Convert RetentionHoldsString to array
+	b.RetentionHolds = []RetentionHold{}
+	if b.RetentionHoldsString != "" {
+		if err := json.Unmarshal([]byte(b.RetentionHoldsString), &b.RetentionHolds); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -110,6 +164,21 @@ func (b *BackupConfig) Validate(plan *plans.DatabasePlan) error {
 		return errors.New("max backups total size must be non-negative")
 	}
 
+	if b.ScheduleSpreadMinutes < 0 || b.ScheduleSpreadMinutes > MaxScheduleSpreadMinutes {
+		return fmt.Errorf(
+			"schedule spread must be between 0 and %d minutes",
+			MaxScheduleSpreadMinutes,
+		)
+	}
+
+	if b.BackupTimeoutMinutes < 0 || b.RestoreTimeoutMinutes < 0 || b.HangTimeoutMinutes < 0 {
+		return errors.New("timeouts must be non-negative")
+	}
+
+	if err := storages.ValidateFileNameTemplate(b.FileNameTemplate); err != nil {
+		return err
+	}
+
 	// Validate against plan limits
 	// Check storage period limit
 	if plan.MaxStoragePeriod != period.PeriodForever {
@@ -118,6 +187,16 @@ func (b *BackupConfig) Validate(plan *plans.DatabasePlan) error {
 		}
 	}
 
+	if len(b.RetentionHolds) > MaxRetentionHolds {
+		return fmt.Errorf("no more than %d retention holds are allowed", MaxRetentionHolds)
+	}
+
+	for _, hold := range b.RetentionHolds {
+		if err := hold.Validate(plan); err != nil {
+			return err
+		}
+	}
+
 	// Check max backup size limit (0 in plan means unlimited)
 	if plan.MaxBackupSizeMB > 0 {
 		if b.MaxBackupSizeMB == 0 || b.MaxBackupSizeMB > plan.MaxBackupSizeMB {
@@ -150,5 +229,82 @@ func (b *BackupConfig) Copy(newDatabaseID uuid.UUID) *BackupConfig {
 		Encryption:            b.Encryption,
 		MaxBackupSizeMB:       b.MaxBackupSizeMB,
 		MaxBackupsTotalSizeMB: b.MaxBackupsTotalSizeMB,
+		ScheduleSpreadMinutes: b.ScheduleSpreadMinutes,
+		ConcurrencyGroupID:    b.ConcurrencyGroupID,
+		BackupTimeoutMinutes:  b.BackupTimeoutMinutes,
+		RestoreTimeoutMinutes: b.RestoreTimeoutMinutes,
+		HangTimeoutMinutes:    b.HangTimeoutMinutes,
+		FileNameTemplate:      b.FileNameTemplate,
+		RetentionHolds:        slices.Clone(b.RetentionHolds),
+	}
+}
+
+// GetScheduleOffset is derived from the database ID, so the database starts at the same
+// offset every time and its backups stay as regular as the interval
+func (b *BackupConfig) GetScheduleOffset() time.Duration {
+	if b.ScheduleSpreadMinutes <= 0 {
+		return 0
 	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write(b.DatabaseID[:])
+
+	return time.Duration(hash.Sum32()%uint32(b.ScheduleSpreadMinutes)) * time.Minute
+}
+
+// ShouldTriggerBackup checks the interval on a clock shifted back by the schedule offset,
+// the last backup is shifted too so HOURLY and CRON intervals do not drift
+func (b *BackupConfig) ShouldTriggerBackup(now time.Time, lastBackupTime *time.Time) bool {
+	offset := b.GetScheduleOffset()
+	if lastBackupTime == nil || offset == 0 {
+		return b.BackupInterval.ShouldTriggerBackup(now, lastBackupTime)
+	}
+
+	shiftedLastBackupTime := lastBackupTime.Add(-offset)
+
+	return b.BackupInterval.ShouldTriggerBackup(now.Add(-offset), &shiftedLastBackupTime)
+}
+
+// IsSchedulePausedAt treats a pause past its auto-resume time as resumed
+func (b *BackupConfig) IsSchedulePausedAt(now time.Time) bool {
+	if !b.IsSchedulePaused {
+		return false
+	}
+
+	return b.SchedulePausedUntil == nil || now.Before(*b.SchedulePausedUntil)
+}
+
+// GetBackupTimeout returns 0 if neither the database nor the env limits backups duration
+func (b *BackupConfig) GetBackupTimeout() time.Duration {
+	return minutesOrDefault(b.BackupTimeoutMinutes, config.GetEnv().BackupTimeoutMinutes)
+}
+
+func (b *BackupConfig) GetRestoreTimeout() time.Duration {
+	return minutesOrDefault(b.RestoreTimeoutMinutes, config.GetEnv().RestoreTimeoutMinutes)
+}
+
+func (b *BackupConfig) GetHangTimeout() time.Duration {
+	return minutesOrDefault(b.HangTimeoutMinutes, config.GetEnv().BackupHangTimeoutMinutes)
+}
+
+func minutesOrDefault(minutes int, defaultMinutes int) time.Duration {
+	if minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+
+	return time.Duration(defaultMinutes) * time.Minute
+}
+
+// GetFileNameTemplate returns the template naming backup files on the storage, empty if
+// files are named by their ID
+func (b *BackupConfig) GetFileNameTemplate(storage *storages.Storage) string {
+	if !storage.IsFileNamingSupported() {
+		return ""
+	}
+
+	if b.FileNameTemplate != "" {
+		return b.FileNameTemplate
+	}
+
+	return storage.FileNameTemplate
 }
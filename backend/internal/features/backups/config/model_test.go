@@ -2,6 +2,7 @@ package backups_config
 
 import (
 	"testing"
+	"time"
 
 	"databasus-backend/internal/features/intervals"
 	plans "databasus-backend/internal/features/plan"
@@ -364,6 +365,89 @@ func Test_Validate_WhenPlanLimitsAreAtBoundary_ValidationWorks(t *testing.T) {
 	}
 }
 
+func Test_Validate_WhenRetentionHoldExceedsPlanStoragePeriod_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.StorePeriod = period.PeriodWeek
+	config.RetentionHolds = []RetentionHold{
+		{TagPattern: "release:*", KeepPeriod: period.Period2Years},
+	}
+
+	plan := createUnlimitedPlan()
+	plan.MaxStoragePeriod = period.PeriodYear
+
+	err := config.Validate(plan)
+	assert.EqualError(t, err, "retention hold keep period exceeds plan limit")
+}
+
+func Test_Validate_WhenRetentionHoldPatternIsInvalid_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionHolds = []RetentionHold{
+		{TagPattern: "release:[", KeepPeriod: period.PeriodYear},
+	}
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, `retention hold tag pattern "release:[" is invalid`)
+}
+
+func Test_IsBackupHeld_WhenTagMatchesAndKeepPeriodNotPassed_BackupIsHeld(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionHolds = []RetentionHold{
+		{TagPattern: "release:*", KeepPeriod: period.Period2Years},
+	}
+
+	now := time.Now().UTC()
+	createdAt := now.Add(-400 * 24 * time.Hour)
+
+	assert.True(t, config.IsBackupHeld([]string{"manual", "release:v1.2"}, createdAt, now))
+	assert.False(t, config.IsBackupHeld([]string{"nightly"}, createdAt, now))
+	assert.False(t, config.IsBackupHeld(nil, createdAt, now))
+}
+
+func Test_IsBackupHeld_WhenKeepPeriodPassed_BackupIsNotHeld(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionHolds = []RetentionHold{
+		{TagPattern: "release:*", KeepPeriod: period.PeriodYear},
+	}
+
+	now := time.Now().UTC()
+	createdAt := now.Add(-400 * 24 * time.Hour)
+
+	assert.False(t, config.IsBackupHeld([]string{"release:v1.2"}, createdAt, now))
+}
+
+func Test_ShouldTriggerBackup_WhenScheduleIsSpread_BackupStartsAtStableOffset(t *testing.T) {
+	timeOfDay := "00:00"
+	config := createValidBackupConfig()
+	config.BackupInterval = &intervals.Interval{
+		Interval:  intervals.IntervalDaily,
+		TimeOfDay: &timeOfDay,
+	}
+	config.ScheduleSpreadMinutes = 120
+
+	offset := config.GetScheduleOffset()
+	assert.Less(t, offset, 120*time.Minute)
+
+	slot := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	lastBackupTime := slot.AddDate(0, 0, -1).Add(offset)
+
+	if offset > 0 {
+		assert.False(t, config.ShouldTriggerBackup(slot.Add(offset-time.Minute), &lastBackupTime))
+	}
+	assert.True(t, config.ShouldTriggerBackup(slot.Add(offset), &lastBackupTime))
+
+	// Once taken at the offset, the backup is not repeated within the day
+	startedAt := slot.Add(offset)
+	assert.False(t, config.ShouldTriggerBackup(slot.Add(23*time.Hour), &startedAt))
+}
+
+func Test_Validate_WhenScheduleSpreadExceedsMax_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.ScheduleSpreadMinutes = MaxScheduleSpreadMinutes + 1
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.Error(t, err)
+}
+
 func createValidBackupConfig() *BackupConfig {
 	intervalID := uuid.New()
 	return &BackupConfig{
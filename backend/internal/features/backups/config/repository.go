@@ -3,6 +3,7 @@ package backups_config
 import (
 	"databasus-backend/internal/storage"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -118,3 +119,134 @@ func (r *BackupConfigRepository) GetDatabasesIDsByStorageID(
 
 	return databasesIDs, nil
 }
+
+// UpdateSchedulePause changes only the pause columns, so configs saved meanwhile are kept
+func (r *BackupConfigRepository) UpdateSchedulePause(
+	databaseIDs []uuid.UUID,
+	isPaused bool,
+	pausedUntil *time.Time,
+) error {
+	if len(databaseIDs) == 0 {
+		return nil
+	}
+
+	return storage.
+		GetDb().
+		Model(&BackupConfig{}).
+		Where("database_id IN ?", databaseIDs).
+		Updates(map[string]any{
+			"is_schedule_paused":    isPaused,
+			"schedule_paused_until": pausedUntil,
+		}).Error
+}
+
+// ClearConcurrencyGroup detaches the database from its group, groups belong to a workspace
+func (r *BackupConfigRepository) ClearConcurrencyGroup(databaseID uuid.UUID) error {
+	return storage.
+		GetDb().
+		Model(&BackupConfig{}).
+		Where("database_id = ?", databaseID).
+		Update("concurrency_group_id", nil).Error
+}
+
+func (r *BackupConfigRepository) FindByConcurrencyGroupIDs(
+	groupIDs []uuid.UUID,
+) ([]*BackupConfig, error) {
+	var backupConfigs []*BackupConfig
+
+	if len(groupIDs) == 0 {
+		return backupConfigs, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Where("concurrency_group_id IN ?", groupIDs).
+		Find(&backupConfigs).Error; err != nil {
+		return nil, err
+	}
+
+	return backupConfigs, nil
+}
+
+func (r *BackupConfigRepository) SaveConcurrencyGroup(group *ConcurrencyGroup) error {
+	return storage.GetDb().Save(group).Error
+}
+
+func (r *BackupConfigRepository) FindConcurrencyGroupByID(
+	id uuid.UUID,
+) (*ConcurrencyGroup, error) {
+	var group ConcurrencyGroup
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", id).
+		First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (r *BackupConfigRepository) FindConcurrencyGroupsByIDs(
+	ids []uuid.UUID,
+) ([]*ConcurrencyGroup, error) {
+	var groups []*ConcurrencyGroup
+
+	if len(ids) == 0 {
+		return groups, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Where("id IN ?", ids).
+		Find(&groups).Error; err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r *BackupConfigRepository) FindConcurrencyGroupsByWorkspaceID(
+	workspaceID uuid.UUID,
+) ([]*ConcurrencyGroup, error) {
+	var groups []*ConcurrencyGroup
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ?", workspaceID).
+		Order("name ASC").
+		Find(&groups).Error; err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// DeleteConcurrencyGroup removes the group, backup configs are detached by the database
+func (r *BackupConfigRepository) DeleteConcurrencyGroup(group *ConcurrencyGroup) error {
+	return storage.GetDb().Delete(&ConcurrencyGroup{}, "id = ?", group.ID).Error
+}
+
+func (r *BackupConfigRepository) SaveDatabaseTransfer(transfer *DatabaseTransfer) error {
+	return storage.GetDb().Create(transfer).Error
+}
+
+func (r *BackupConfigRepository) FindDatabaseTransfersByDatabaseID(
+	databaseID uuid.UUID,
+) ([]*DatabaseTransfer, error) {
+	transfers := make([]*DatabaseTransfer, 0)
+
+	if err := storage.
+		GetDb().
+		Where("database_id = ?", databaseID).
+		Order("created_at DESC").
+		Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
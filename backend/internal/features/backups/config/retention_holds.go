@@ -0,0 +1,69 @@
+package backups_config
+
+import (
+	plans "databasus-backend/internal/features/plan"
+	"databasus-backend/internal/util/period"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const MaxRetentionHolds = 20
+
+// RetentionHold keeps backups with a tag matching TagPattern for KeepPeriod after they are
+// created, regardless of the store period and the total size limit. Patterns use shell
+// wildcards, so "release:*" matches "release:v1.2"
+type RetentionHold struct {
+	TagPattern string        `json:"tagPattern"`
+	KeepPeriod period.Period `json:"keepPeriod"`
+}
+
+func (h *RetentionHold) Validate(plan *plans.DatabasePlan) error {
+	if strings.TrimSpace(h.TagPattern) == "" {
+		return errors.New("retention hold tag pattern is required")
+	}
+
+	if _, err := path.Match(h.TagPattern, ""); err != nil {
+		return fmt.Errorf("retention hold tag pattern %q is invalid", h.TagPattern)
+	}
+
+	if !h.KeepPeriod.IsValid() {
+		return fmt.Errorf("retention hold keep period %q is invalid", h.KeepPeriod)
+	}
+
+	if plan.MaxStoragePeriod != period.PeriodForever &&
+		h.KeepPeriod.CompareTo(plan.MaxStoragePeriod) > 0 {
+		return errors.New("retention hold keep period exceeds plan limit")
+	}
+
+	return nil
+}
+
+// IsHolding reports whether a backup with the tags created at createdAt is still held
+func (h *RetentionHold) IsHolding(tags []string, createdAt, now time.Time) bool {
+	if h.KeepPeriod != period.PeriodForever &&
+		!createdAt.Add(h.KeepPeriod.ToDuration()).After(now) {
+		return false
+	}
+
+	for _, tag := range tags {
+		if isMatched, _ := path.Match(h.TagPattern, tag); isMatched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsBackupHeld reports whether any retention hold of the config keeps the backup
+func (b *BackupConfig) IsBackupHeld(tags []string, createdAt, now time.Time) bool {
+	for _, hold := range b.RetentionHolds {
+		if hold.IsHolding(tags, createdAt, now) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,7 +2,10 @@ package backups_config
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
+	audit_logs "databasus-backend/internal/features/audit_logs"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/intervals"
 	"databasus-backend/internal/features/notifiers"
@@ -21,6 +24,7 @@ type BackupConfigService struct {
 	notifierService        *notifiers.NotifierService
 	workspaceService       *workspaces_services.WorkspaceService
 	databasePlanService    *plans.DatabasePlanService
+	auditLogService        *audit_logs.AuditLogService
 
 	dbStorageChangeListener BackupConfigStorageChangeListener
 }
@@ -77,11 +81,32 @@ func (s *BackupConfigService) SaveBackupConfigWithAuth(
 		if err != nil {
 			return nil, err
 		}
-		if storage.WorkspaceID != *database.WorkspaceID && !storage.IsSystem {
+		if !storage.IsVisibleToWorkspace(*database.WorkspaceID) {
 			return nil, errors.New("storage does not belong to the same workspace as the database")
 		}
 	}
 
+	if backupConfig.ConcurrencyGroupID != nil {
+		group, err := s.backupConfigRepository.FindConcurrencyGroupByID(
+			*backupConfig.ConcurrencyGroupID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil || group.WorkspaceID != *database.WorkspaceID {
+			return nil, ErrConcurrencyGroupNotInWorkspace
+		}
+	}
+
+	existingConfig, err := s.backupConfigRepository.FindByDatabaseID(backupConfig.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	if existingConfig != nil {
+		backupConfig.IsSchedulePaused = existingConfig.IsSchedulePaused
+		backupConfig.SchedulePausedUntil = existingConfig.SchedulePausedUntil
+	}
+
 	return s.SaveBackupConfig(backupConfig)
 }
 
@@ -222,11 +247,17 @@ func (s *BackupConfigService) initializeDefaultConfig(
 		return err
 	}
 
+	defaultStorageID, err := s.getDefaultStorageID(databaseID)
+	if err != nil {
+		return err
+	}
+
 	timeOfDay := "04:00"
 
 	_, err = s.backupConfigRepository.Save(&BackupConfig{
 		DatabaseID:            databaseID,
 		IsBackupsEnabled:      false,
+		StorageID:             defaultStorageID,
 		StorePeriod:           plan.MaxStoragePeriod,
 		MaxBackupSizeMB:       plan.MaxBackupSizeMB,
 		MaxBackupsTotalSizeMB: plan.MaxBackupsTotalSizeMB,
@@ -246,6 +277,26 @@ func (s *BackupConfigService) initializeDefaultConfig(
 	return err
 }
 
+// getDefaultStorageID returns the default storage of the workspace of the database, so new
+// databases do not end up without storage
+func (s *BackupConfigService) getDefaultStorageID(databaseID uuid.UUID) (*uuid.UUID, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, nil
+	}
+
+	defaultStorage, err := s.storageService.GetWorkspaceDefaultStorage(*database.WorkspaceID)
+	if err != nil || defaultStorage == nil {
+		return nil, err
+	}
+
+	return &defaultStorage.ID, nil
+}
+
 func (s *BackupConfigService) TransferDatabaseToWorkspace(
 	user *users_models.User,
 	databaseID uuid.UUID,
@@ -289,6 +340,8 @@ func (s *BackupConfigService) TransferDatabaseToWorkspace(
 		s.transferNotifiers(user, database, request.TargetWorkspaceID)
 	}
 
+	sourceStorageID := backupConfig.StorageID
+
 	if request.IsTransferWithStorage {
 		if backupConfig.StorageID == nil {
 			return ErrDatabaseHasNoStorage
@@ -314,17 +367,15 @@ func (s *BackupConfigService) TransferDatabaseToWorkspace(
 		if err != nil {
 			return err
 		}
-	} else if request.TargetStorageID != nil {
-		targetStorage, err := s.storageService.GetStorageByID(*request.TargetStorageID)
+	} else if request.IsUseEquivalentStorage || request.TargetStorageID != nil {
+		targetStorage, err := s.getTransferTargetStorage(backupConfig, request)
 		if err != nil {
 			return err
 		}
 
-		if targetStorage.WorkspaceID != request.TargetWorkspaceID {
-			return ErrTargetStorageNotInTargetWorkspace
-		}
-
-		backupConfig.StorageID = request.TargetStorageID
+		// backups are kept on the previous storage, so the listener deleting them on
+		// storage change is not notified
+		backupConfig.StorageID = &targetStorage.ID
 		backupConfig.Storage = targetStorage
 
 		_, err = s.backupConfigRepository.Save(backupConfig)
@@ -340,6 +391,32 @@ func (s *BackupConfigService) TransferDatabaseToWorkspace(
 		return err
 	}
 
+	if err := s.backupConfigRepository.SaveDatabaseTransfer(&DatabaseTransfer{
+		ID:                  uuid.New(),
+		DatabaseID:          databaseID,
+		SourceWorkspaceID:   *database.WorkspaceID,
+		TargetWorkspaceID:   request.TargetWorkspaceID,
+		SourceStorageID:     sourceStorageID,
+		TargetStorageID:     backupConfig.StorageID,
+		TransferredByUserID: user.ID,
+		CreatedAt:           time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+
+	auditMessage := fmt.Sprintf(
+		"Database moved: %s from workspace %s to workspace %s",
+		database.Name,
+		*database.WorkspaceID,
+		request.TargetWorkspaceID,
+	)
+	s.auditLogService.WriteAuditLog(auditMessage, &user.ID, database.WorkspaceID)
+	s.auditLogService.WriteAuditLog(auditMessage, &user.ID, &request.TargetWorkspaceID)
+
+	if err := s.backupConfigRepository.ClearConcurrencyGroup(databaseID); err != nil {
+		return err
+	}
+
 	if len(request.TargetNotifierIDs) > 0 {
 		err = s.assignTargetNotifiers(databaseID, request.TargetNotifierIDs)
 		if err != nil {
@@ -350,6 +427,429 @@ func (s *BackupConfigService) TransferDatabaseToWorkspace(
 	return nil
 }
 
+func (s *BackupConfigService) PauseDatabaseSchedule(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	request *PauseScheduleRequest,
+) error {
+	database, err := s.getManagedDatabase(user, databaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := validatePauseScheduleRequest(request); err != nil {
+		return err
+	}
+
+	if err := s.backupConfigRepository.UpdateSchedulePause(
+		[]uuid.UUID{database.ID},
+		true,
+		request.PausedUntil,
+	); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Backup schedule of database %s paused%s",
+			database.Name,
+			describePause(request),
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+func (s *BackupConfigService) ResumeDatabaseSchedule(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) error {
+	database, err := s.getManagedDatabase(user, databaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.backupConfigRepository.UpdateSchedulePause(
+		[]uuid.UUID{database.ID},
+		false,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup schedule of database %s resumed", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+// PauseWorkspaceSchedules pauses every database of the workspace, databases added later
+// are not paused
+func (s *BackupConfigService) PauseWorkspaceSchedules(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	request *PauseScheduleRequest,
+) error {
+	databaseIDs, err := s.getManagedWorkspaceDatabaseIDs(user, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := validatePauseScheduleRequest(request); err != nil {
+		return err
+	}
+
+	if err := s.backupConfigRepository.UpdateSchedulePause(
+		databaseIDs,
+		true,
+		request.PausedUntil,
+	); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup schedules of workspace paused%s", describePause(request)),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+// ResumeWorkspaceSchedules resumes every database of the workspace, including databases
+// paused one by one
+func (s *BackupConfigService) ResumeWorkspaceSchedules(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	databaseIDs, err := s.getManagedWorkspaceDatabaseIDs(user, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.backupConfigRepository.UpdateSchedulePause(databaseIDs, false, nil); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		"Backup schedules of workspace resumed",
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+// AssignDefaultStorage attaches the default storage of the workspace to its databases
+// without storage. Databases with another storage are moved only if requested, their
+// backups are deleted like on any storage change
+func (s *BackupConfigService) AssignDefaultStorage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	request *AssignDefaultStorageRequest,
+) (*AssignDefaultStorageResponse, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToAssignStorage
+	}
+
+	defaultStorage, err := s.storageService.GetWorkspaceDefaultStorage(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if defaultStorage == nil {
+		return nil, ErrWorkspaceHasNoDefaultStorage
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AssignDefaultStorageResponse{}
+	for _, database := range workspaceDatabases {
+		backupConfig, err := s.GetBackupConfigByDbId(database.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if storageIDsEqual(backupConfig.StorageID, &defaultStorage.ID) ||
+			(backupConfig.StorageID != nil && !request.IsReassignAttached) {
+			continue
+		}
+
+		backupConfig.Storage = defaultStorage
+		backupConfig.StorageID = &defaultStorage.ID
+
+		if _, err := s.SaveBackupConfig(backupConfig); err != nil {
+			return nil, fmt.Errorf("failed to assign storage to %s: %w", database.Name, err)
+		}
+
+		response.AssignedDatabasesCount++
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Default storage %s assigned to %d databases",
+			defaultStorage.Name,
+			response.AssignedDatabasesCount,
+		),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return response, nil
+}
+
+func (s *BackupConfigService) GetConcurrencyGroups(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*ConcurrencyGroup, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view concurrency groups")
+	}
+
+	return s.backupConfigRepository.FindConcurrencyGroupsByWorkspaceID(workspaceID)
+}
+
+func (s *BackupConfigService) CreateConcurrencyGroup(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	group *ConcurrencyGroup,
+) (*ConcurrencyGroup, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageConcurrencyGroup
+	}
+
+	group.ID = uuid.New()
+	group.WorkspaceID = workspaceID
+	group.CreatedAt = time.Now().UTC()
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.backupConfigRepository.SaveConcurrencyGroup(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *BackupConfigService) UpdateConcurrencyGroup(
+	user *users_models.User,
+	groupID uuid.UUID,
+	incoming *ConcurrencyGroup,
+) (*ConcurrencyGroup, error) {
+	group, err := s.getManagedConcurrencyGroup(user, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = incoming.Name
+	group.MaxConcurrentBackups = incoming.MaxConcurrentBackups
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.backupConfigRepository.SaveConcurrencyGroup(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *BackupConfigService) DeleteConcurrencyGroup(
+	user *users_models.User,
+	groupID uuid.UUID,
+) error {
+	group, err := s.getManagedConcurrencyGroup(user, groupID)
+	if err != nil {
+		return err
+	}
+
+	return s.backupConfigRepository.DeleteConcurrencyGroup(group)
+}
+
+// GetConcurrencyGroupSlots returns how many more scheduled backups each group can start,
+// counting backups in progress of all databases of the group
+func (s *BackupConfigService) GetConcurrencyGroupSlots(
+	groupIDs []uuid.UUID,
+	inProgressDatabaseIDs []uuid.UUID,
+) (map[uuid.UUID]int, error) {
+	groups, err := s.backupConfigRepository.FindConcurrencyGroupsByIDs(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	groupConfigs, err := s.backupConfigRepository.FindByConcurrencyGroupIDs(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make(map[uuid.UUID]int, len(groups))
+	for _, group := range groups {
+		slots[group.ID] = group.MaxConcurrentBackups
+	}
+
+	groupIDByDatabaseID := make(map[uuid.UUID]uuid.UUID, len(groupConfigs))
+	for _, backupConfig := range groupConfigs {
+		groupIDByDatabaseID[backupConfig.DatabaseID] = *backupConfig.ConcurrencyGroupID
+	}
+
+	for _, databaseID := range inProgressDatabaseIDs {
+		if groupID, isInGroup := groupIDByDatabaseID[databaseID]; isInGroup {
+			slots[groupID]--
+		}
+	}
+
+	return slots, nil
+}
+
+func (s *BackupConfigService) getManagedDatabase(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*databases.Database, error) {
+	database, err := s.databaseService.GetDatabase(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToPauseSchedule
+	}
+
+	return database, nil
+}
+
+func (s *BackupConfigService) getManagedWorkspaceDatabaseIDs(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]uuid.UUID, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToPauseSchedule
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseIDs := make([]uuid.UUID, 0, len(workspaceDatabases))
+	for _, database := range workspaceDatabases {
+		databaseIDs = append(databaseIDs, database.ID)
+	}
+
+	return databaseIDs, nil
+}
+
+func (s *BackupConfigService) getManagedConcurrencyGroup(
+	user *users_models.User,
+	groupID uuid.UUID,
+) (*ConcurrencyGroup, error) {
+	group, err := s.backupConfigRepository.FindConcurrencyGroupByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrConcurrencyGroupNotFound
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(group.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageConcurrencyGroup
+	}
+
+	return group, nil
+}
+
+// GetDatabaseTransfers returns moves of the database between workspaces, newest first
+func (s *BackupConfigService) GetDatabaseTransfers(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) ([]*DatabaseTransfer, error) {
+	if _, err := s.databaseService.GetDatabase(user, databaseID); err != nil {
+		return nil, err
+	}
+
+	return s.backupConfigRepository.FindDatabaseTransfersByDatabaseID(databaseID)
+}
+
+// getTransferTargetStorage returns the storage the database backs up to after the move.
+// An equivalent storage is the current one if the target workspace sees it, e.g. a system
+// storage, otherwise a storage of the target workspace with the same type and name
+func (s *BackupConfigService) getTransferTargetStorage(
+	backupConfig *BackupConfig,
+	request *TransferDatabaseRequest,
+) (*storages.Storage, error) {
+	if request.TargetStorageID != nil {
+		targetStorage, err := s.storageService.GetStorageByID(*request.TargetStorageID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !targetStorage.IsVisibleToWorkspace(request.TargetWorkspaceID) {
+			return nil, ErrTargetStorageNotInTargetWorkspace
+		}
+
+		return targetStorage, nil
+	}
+
+	if backupConfig.StorageID == nil {
+		return nil, ErrDatabaseHasNoStorage
+	}
+
+	currentStorage, err := s.storageService.GetStorageByID(*backupConfig.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentStorage.IsVisibleToWorkspace(request.TargetWorkspaceID) {
+		return currentStorage, nil
+	}
+
+	targetStorages, err := s.storageService.GetWorkspaceStorages(request.TargetWorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, targetStorage := range targetStorages {
+		if targetStorage.Type == currentStorage.Type && targetStorage.Name == currentStorage.Name {
+			return targetStorage, nil
+		}
+	}
+
+	return nil, ErrEquivalentStorageNotFound
+}
+
 func (s *BackupConfigService) transferNotifiers(
 	user *users_models.User,
 	database *databases.Database,
@@ -372,7 +872,7 @@ func (s *BackupConfigService) validateTargetNotifiers(request *TransferDatabaseR
 			return err
 		}
 
-		if notifier.WorkspaceID != request.TargetWorkspaceID {
+		if notifier.WorkspaceID != request.TargetWorkspaceID && !notifier.IsSystem {
 			return ErrTargetNotifierNotInTargetWorkspace
 		}
 	}
@@ -406,3 +906,23 @@ func storageIDsEqual(id1, id2 *uuid.UUID) bool {
 	}
 	return *id1 == *id2
 }
+
+func validatePauseScheduleRequest(request *PauseScheduleRequest) error {
+	if request.PausedUntil != nil && !request.PausedUntil.After(time.Now().UTC()) {
+		return ErrPausedUntilInPast
+	}
+
+	return nil
+}
+
+func describePause(request *PauseScheduleRequest) string {
+	description := ""
+	if request.PausedUntil != nil {
+		description += " until " + request.PausedUntil.UTC().Format(time.RFC3339)
+	}
+	if request.Reason != "" {
+		description += ": " + request.Reason
+	}
+
+	return description
+}
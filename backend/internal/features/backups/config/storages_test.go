@@ -238,6 +238,59 @@ func Test_TransferStorageWithAttachedDatabase_CannotTransfer(t *testing.T) {
 	)
 }
 
+func Test_CreateDatabase_WhenWorkspaceHasDefaultStorage_StorageAttached(t *testing.T) {
+	router := createTestRouterWithStorage()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := createTestStorage(workspace.ID)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/workspace/%s/default", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		storages.SetDefaultStorageRequest{StorageID: &storage.ID},
+		http.StatusOK,
+	)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var backupConfig BackupConfig
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/database/"+database.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&backupConfig,
+	)
+
+	assert.NotNil(t, backupConfig.StorageID)
+	assert.Equal(t, storage.ID, *backupConfig.StorageID)
+
+	var assignResponse AssignDefaultStorageResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/backup-configs/workspace/%s/assign-default-storage",
+			workspace.ID.String(),
+		),
+		"Bearer "+owner.Token,
+		AssignDefaultStorageRequest{},
+		http.StatusOK,
+		&assignResponse,
+	)
+
+	assert.Equal(t, 0, assignResponse.AssignedDatabasesCount)
+}
+
 func createTestRouterWithStorage() *gin.Engine {
 	router := workspaces_testing.CreateTestRouter(
 		workspaces_controllers.GetWorkspaceController(),
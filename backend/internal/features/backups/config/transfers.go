@@ -0,0 +1,26 @@
+package backups_config
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DatabaseTransfer records a move of a database between workspaces. Backups made before
+// the move keep pointing at the storage they were uploaded to, SourceStorageID tells
+// where to find them
+type DatabaseTransfer struct {
+	ID                uuid.UUID  `json:"id"                gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID        uuid.UUID  `json:"databaseId"        gorm:"column:database_id;type:uuid;not null"`
+	SourceWorkspaceID uuid.UUID  `json:"sourceWorkspaceId" gorm:"column:source_workspace_id;type:uuid;not null"`
+	TargetWorkspaceID uuid.UUID  `json:"targetWorkspaceId" gorm:"column:target_workspace_id;type:uuid;not null"`
+	SourceStorageID   *uuid.UUID `json:"sourceStorageId"   gorm:"column:source_storage_id;type:uuid"`
+	TargetStorageID   *uuid.UUID `json:"targetStorageId"   gorm:"column:target_storage_id;type:uuid"`
+
+	TransferredByUserID uuid.UUID `json:"transferredByUserId" gorm:"column:transferred_by_user_id;type:uuid;not null"`
+	CreatedAt           time.Time `json:"createdAt"           gorm:"column:created_at"`
+}
+
+func (DatabaseTransfer) TableName() string {
+	return "database_transfers"
+}
@@ -0,0 +1,109 @@
+package backups_grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const requestTimeout = 30 * time.Second
+
+type annotationRequest struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time,omitempty"`
+	TimeEnd      int64    `json:"timeEnd,omitempty"`
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+}
+
+func createAnnotation(
+	baseURL string,
+	apiToken string,
+	request *annotationRequest,
+) (int64, error) {
+	var response struct {
+		ID int64 `json:"id"`
+	}
+
+	if err := sendJSON(
+		http.MethodPost,
+		strings.TrimRight(baseURL, "/")+"/api/annotations",
+		apiToken,
+		request,
+		&response,
+	); err != nil {
+		return 0, fmt.Errorf("failed to create Grafana annotation: %w", err)
+	}
+
+	return response.ID, nil
+}
+
+// updateAnnotation patches the annotation, fields left empty in the request are kept
+func updateAnnotation(
+	baseURL string,
+	apiToken string,
+	annotationID int64,
+	request *annotationRequest,
+) error {
+	var response struct{}
+
+	if err := sendJSON(
+		http.MethodPatch,
+		strings.TrimRight(baseURL, "/")+"/api/annotations/"+strconv.FormatInt(annotationID, 10),
+		apiToken,
+		request,
+		&response,
+	); err != nil {
+		return fmt.Errorf("failed to update Grafana annotation: %w", err)
+	}
+
+	return nil
+}
+
+func sendJSON(
+	method string,
+	url string,
+	apiToken string,
+	payload any,
+	response any,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiToken)
+
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API returned non-OK status: %s. Error: %s", resp.Status, bodyBytes)
+	}
+
+	if err := json.Unmarshal(bodyBytes, response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
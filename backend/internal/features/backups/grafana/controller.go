@@ -0,0 +1,154 @@
+package backups_grafana
+
+import (
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GrafanaController struct {
+	grafanaService *GrafanaService
+}
+
+func (c *GrafanaController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/grafana-integrations/workspace/:workspaceId", c.GetIntegration)
+	router.PUT("/grafana-integrations/workspace/:workspaceId", c.SaveIntegration)
+	router.DELETE("/grafana-integrations/workspace/:workspaceId", c.DeleteIntegration)
+	router.GET("/grafana-integrations/workspace/:workspaceId/dashboard", c.GetDashboard)
+}
+
+// GetIntegration
+// @Summary Get Grafana integration
+// @Description Get the Grafana annotations integration of a workspace, null when it is not configured
+// @Tags grafana-integrations
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} GrafanaIntegration
+// @Failure 400
+// @Failure 401
+// @Router /grafana-integrations/workspace/{workspaceId} [get]
+func (c *GrafanaController) GetIntegration(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	integration, err := c.grafanaService.GetIntegration(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// SaveIntegration
+// @Summary Save Grafana integration
+// @Description Create or update the integration pushing backup runs as Grafana annotations, an empty token keeps the saved one
+// @Tags grafana-integrations
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body GrafanaIntegration true "Grafana integration"
+// @Success 200 {object} GrafanaIntegration
+// @Failure 400
+// @Failure 401
+// @Router /grafana-integrations/workspace/{workspaceId} [put]
+func (c *GrafanaController) SaveIntegration(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var integration GrafanaIntegration
+	if err := ctx.ShouldBindJSON(&integration); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	savedIntegration, err := c.grafanaService.SaveIntegration(user, workspaceID, &integration)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, savedIntegration)
+}
+
+// DeleteIntegration
+// @Summary Delete Grafana integration
+// @Description Delete the Grafana integration of a workspace, pushed annotations stay in Grafana
+// @Tags grafana-integrations
+// @Param workspaceId path string true "Workspace ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /grafana-integrations/workspace/{workspaceId} [delete]
+func (c *GrafanaController) DeleteIntegration(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	if err := c.grafanaService.DeleteIntegration(user, workspaceID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetDashboard
+// @Summary Get Grafana dashboard
+// @Description Get a dashboard JSON model showing backup annotations of a workspace, to import into Grafana
+// @Tags grafana-integrations
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} map[string]any
+// @Failure 400
+// @Failure 401
+// @Router /grafana-integrations/workspace/{workspaceId}/dashboard [get]
+func (c *GrafanaController) GetDashboard(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	dashboard, err := c.grafanaService.GetDashboard(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dashboard)
+}
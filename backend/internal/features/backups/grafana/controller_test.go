@@ -0,0 +1,149 @@
+package backups_grafana
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+type receivedRequest struct {
+	Method  string
+	Path    string
+	Request annotationRequest
+}
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetGrafanaController(),
+	)
+}
+
+func Test_SyncAnnotations_WhenBackupRunsAndFinishes_AnnotationCreatedThenEnded(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	var mu sync.Mutex
+	var requests []receivedRequest
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request annotationRequest
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &request)
+
+			mu.Lock()
+			requests = append(requests, receivedRequest{r.Method, r.URL.Path, request})
+			mu.Unlock()
+
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"id":42,"message":"Annotation added"}`))
+		}),
+	)
+	defer server.Close()
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/grafana-integrations/workspace/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		GrafanaIntegration{
+			IsEnabled:    true,
+			URL:          server.URL,
+			APIToken:     "test-token",
+			DashboardUID: "db-performance",
+		},
+		http.StatusOK,
+	)
+
+	backupRepository := &backups_core.BackupRepository{}
+	startedAt := time.Now().UTC().Add(time.Second)
+	backup := &backups_core.Backup{
+		ID:         uuid.New(),
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+		Status:     backups_core.BackupStatusInProgress,
+		CreatedAt:  startedAt,
+	}
+	assert.NoError(t, backupRepository.Save(backup))
+
+	assert.NoError(t, GetGrafanaService().SyncAnnotations(startedAt.Add(time.Minute)))
+
+	backup.Status = backups_core.BackupStatusCompleted
+	backup.BackupDurationMs = 90_000
+	assert.NoError(t, backupRepository.Save(backup))
+
+	assert.NoError(t, GetGrafanaService().SyncAnnotations(startedAt.Add(2*time.Minute)))
+	// Finished annotations are not pushed again
+	assert.NoError(t, GetGrafanaService().SyncAnnotations(startedAt.Add(3*time.Minute)))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Len(t, requests, 2)
+	if len(requests) != 2 {
+		return
+	}
+
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/api/annotations", requests[0].Path)
+	assert.Equal(t, "db-performance", requests[0].Request.DashboardUID)
+	assert.Equal(t, startedAt.UnixMilli(), requests[0].Request.Time)
+	assert.Zero(t, requests[0].Request.TimeEnd)
+	assert.Contains(t, requests[0].Request.Tags, "database:"+database.Name)
+	assert.Contains(t, requests[0].Request.Tags, "in_progress")
+
+	assert.Equal(t, http.MethodPatch, requests[1].Method)
+	assert.Equal(t, "/api/annotations/42", requests[1].Path)
+	assert.Equal(t, startedAt.Add(90*time.Second).UnixMilli(), requests[1].Request.TimeEnd)
+	assert.Contains(t, requests[1].Request.Tags, "completed")
+
+	var dashboard map[string]any
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/grafana-integrations/workspace/"+workspace.ID.String()+"/dashboard",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&dashboard,
+	)
+	assert.Equal(t, "Databasus backups: "+workspace.Name, dashboard["title"])
+
+	var integration GrafanaIntegration
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/grafana-integrations/workspace/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&integration,
+	)
+	assert.Empty(t, integration.APIToken)
+}
@@ -0,0 +1,40 @@
+package backups_grafana
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var grafanaRepository = &GrafanaRepository{}
+var grafanaService = &GrafanaService{
+	grafanaRepository,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var grafanaController = &GrafanaController{
+	grafanaService,
+}
+var grafanaBackgroundService = &GrafanaBackgroundService{
+	grafanaService: grafanaService,
+	logger:         logger.GetLogger(),
+}
+
+func GetGrafanaService() *GrafanaService {
+	return grafanaService
+}
+
+func GetGrafanaController() *GrafanaController {
+	return grafanaController
+}
+
+func GetGrafanaBackgroundService() *GrafanaBackgroundService {
+	return grafanaBackgroundService
+}
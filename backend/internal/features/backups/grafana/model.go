@@ -0,0 +1,88 @@
+package backups_grafana
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// GrafanaIntegration pushes backup runs of the workspace databases as annotations to
+// Grafana. When DashboardUID is empty annotations are created for the organization and
+// are shown on any dashboard querying them by tags
+type GrafanaIntegration struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	IsEnabled   bool      `json:"isEnabled"   gorm:"column:is_enabled;type:boolean;not null"`
+
+	URL string `json:"url" gorm:"column:url;type:text;not null"`
+
+	// APIToken is a token of a service account with the annotations writer permission
+	APIToken string `json:"apiToken" gorm:"column:api_token;type:text;not null"`
+
+	DashboardUID string `json:"dashboardUid" gorm:"column:dashboard_uid;type:text"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (GrafanaIntegration) TableName() string {
+	return "grafana_integrations"
+}
+
+func (i *GrafanaIntegration) Validate() error {
+	parsedURL, err := url.Parse(i.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") ||
+		parsedURL.Host == "" {
+		return errors.New("Grafana URL must be a valid http or https URL")
+	}
+
+	if i.APIToken == "" {
+		return errors.New("Grafana API token is required")
+	}
+
+	return nil
+}
+
+// Update copies settings of the incoming integration, an empty token keeps the saved one
+func (i *GrafanaIntegration) Update(incoming *GrafanaIntegration) {
+	i.IsEnabled = incoming.IsEnabled
+	i.URL = incoming.URL
+	i.DashboardUID = incoming.DashboardUID
+
+	if incoming.APIToken != "" {
+		i.APIToken = incoming.APIToken
+	}
+}
+
+func (i *GrafanaIntegration) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	apiToken, err := encryptor.Encrypt(i.ID, i.APIToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt Grafana API token: %w", err)
+	}
+	i.APIToken = apiToken
+
+	return nil
+}
+
+func (i *GrafanaIntegration) HideSensitiveData() {
+	i.APIToken = ""
+}
+
+// GrafanaAnnotation links a backup to the annotation pushed for it. The annotation is
+// created when the backup is seen running and gets its end time when the backup finishes
+type GrafanaAnnotation struct {
+	ID                  uuid.UUID `json:"id"                  gorm:"column:id;type:uuid;primaryKey"`
+	IntegrationID       uuid.UUID `json:"integrationId"       gorm:"column:integration_id;type:uuid;not null"`
+	BackupID            uuid.UUID `json:"backupId"            gorm:"column:backup_id;type:uuid;not null"`
+	GrafanaAnnotationID int64     `json:"grafanaAnnotationId" gorm:"column:grafana_annotation_id;not null"`
+	IsFinished          bool      `json:"isFinished"          gorm:"column:is_finished;type:boolean;not null"`
+	CreatedAt           time.Time `json:"createdAt"           gorm:"column:created_at"`
+}
+
+func (GrafanaAnnotation) TableName() string {
+	return "grafana_backup_annotations"
+}
@@ -0,0 +1,76 @@
+package backups_grafana
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GrafanaRepository struct{}
+
+func (r *GrafanaRepository) SaveIntegration(integration *GrafanaIntegration) error {
+	return storage.GetDb().Save(integration).Error
+}
+
+func (r *GrafanaRepository) FindIntegrationByWorkspaceID(
+	workspaceID uuid.UUID,
+) (*GrafanaIntegration, error) {
+	var integration GrafanaIntegration
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ?", workspaceID).
+		First(&integration).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &integration, nil
+}
+
+func (r *GrafanaRepository) FindEnabledIntegrations() ([]*GrafanaIntegration, error) {
+	var integrations []*GrafanaIntegration
+
+	if err := storage.
+		GetDb().
+		Where("is_enabled = ?", true).
+		Find(&integrations).Error; err != nil {
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+// DeleteIntegration removes the integration, annotation links are removed by the database
+func (r *GrafanaRepository) DeleteIntegration(integration *GrafanaIntegration) error {
+	return storage.GetDb().Delete(&GrafanaIntegration{}, "id = ?", integration.ID).Error
+}
+
+func (r *GrafanaRepository) SaveAnnotation(annotation *GrafanaAnnotation) error {
+	return storage.GetDb().Save(annotation).Error
+}
+
+func (r *GrafanaRepository) FindAnnotationsByBackupIDs(
+	integrationID uuid.UUID,
+	backupIDs []uuid.UUID,
+) ([]*GrafanaAnnotation, error) {
+	var annotations []*GrafanaAnnotation
+
+	if len(backupIDs) == 0 {
+		return annotations, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Where("integration_id = ? AND backup_id IN ?", integrationID, backupIDs).
+		Find(&annotations).Error; err != nil {
+		return nil, err
+	}
+
+	return annotations, nil
+}
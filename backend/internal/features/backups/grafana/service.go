@@ -0,0 +1,392 @@
+package backups_grafana
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// annotationsLookback bounds backups pushed on each sync, backups started before the
+// integration was created are never pushed
+const annotationsLookback = 24 * time.Hour
+
+type GrafanaService struct {
+	grafanaRepository *GrafanaRepository
+	backupRepository  *backups_core.BackupRepository
+	databaseService   *databases.DatabaseService
+	workspaceService  *workspaces_services.WorkspaceService
+	auditLogService   *audit_logs.AuditLogService
+	fieldEncryptor    encryption.FieldEncryptor
+	logger            *slog.Logger
+}
+
+func (s *GrafanaService) GetIntegration(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*GrafanaIntegration, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view Grafana integration")
+	}
+
+	integration, err := s.grafanaRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	integration.HideSensitiveData()
+
+	return integration, nil
+}
+
+// SaveIntegration creates the integration of the workspace or updates it, an empty token
+// keeps the saved one
+func (s *GrafanaService) SaveIntegration(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	incoming *GrafanaIntegration,
+) (*GrafanaIntegration, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to manage Grafana integration")
+	}
+
+	integration, err := s.grafanaRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if integration == nil {
+		integration = &GrafanaIntegration{
+			ID:          uuid.New(),
+			WorkspaceID: workspaceID,
+			CreatedAt:   time.Now().UTC(),
+		}
+	}
+
+	integration.Update(incoming)
+
+	if err := integration.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := integration.EncryptSensitiveData(s.fieldEncryptor); err != nil {
+		return nil, err
+	}
+
+	if err := s.grafanaRepository.SaveIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Grafana integration saved: %s", integration.URL),
+		&user.ID,
+		&workspaceID,
+	)
+
+	integration.HideSensitiveData()
+
+	return integration, nil
+}
+
+func (s *GrafanaService) DeleteIntegration(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to manage Grafana integration")
+	}
+
+	integration, err := s.grafanaRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+	if integration == nil {
+		return errors.New("Grafana integration not found")
+	}
+
+	if err := s.grafanaRepository.DeleteIntegration(integration); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Grafana integration deleted: %s", integration.URL),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+// GetDashboard builds a dashboard JSON model to import into Grafana. It shows annotations
+// of the workspace backups, its annotation query can be copied to existing dashboards
+func (s *GrafanaService) GetDashboard(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (map[string]any, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view Grafana integration")
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildDashboard(workspace.Name, workspaceID), nil
+}
+
+// SyncAnnotations pushes backups of workspaces with enabled integrations to Grafana.
+// Annotations use the start time and duration of backups, so a sync delay does not
+// shift them
+func (s *GrafanaService) SyncAnnotations(now time.Time) error {
+	integrations, err := s.grafanaRepository.FindEnabledIntegrations()
+	if err != nil {
+		return err
+	}
+
+	for _, integration := range integrations {
+		if err := s.syncIntegration(integration, now); err != nil {
+			s.logger.Error(
+				"Failed to sync Grafana annotations",
+				"integrationId",
+				integration.ID,
+				"error",
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *GrafanaService) syncIntegration(integration *GrafanaIntegration, now time.Time) error {
+	apiToken, err := s.fieldEncryptor.Decrypt(integration.ID, integration.APIToken)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt Grafana API token: %w", err)
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(
+		integration.WorkspaceID,
+	)
+	if err != nil {
+		return err
+	}
+
+	createdAfter := now.Add(-annotationsLookback)
+	if integration.CreatedAt.After(createdAfter) {
+		createdAfter = integration.CreatedAt
+	}
+
+	for _, database := range workspaceDatabases {
+		if err := s.syncDatabase(integration, apiToken, database, createdAfter); err != nil {
+			s.logger.Error(
+				"Failed to sync Grafana annotations of database",
+				"integrationId",
+				integration.ID,
+				"databaseId",
+				database.ID,
+				"error",
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *GrafanaService) syncDatabase(
+	integration *GrafanaIntegration,
+	apiToken string,
+	database *databases.Database,
+	createdAfter time.Time,
+) error {
+	backups, err := s.backupRepository.FindByDatabaseIDCreatedAfter(database.ID, createdAfter)
+	if err != nil {
+		return err
+	}
+
+	backupIDs := make([]uuid.UUID, 0, len(backups))
+	for _, backup := range backups {
+		backupIDs = append(backupIDs, backup.ID)
+	}
+
+	annotations, err := s.grafanaRepository.FindAnnotationsByBackupIDs(integration.ID, backupIDs)
+	if err != nil {
+		return err
+	}
+
+	annotationsByBackupID := make(map[uuid.UUID]*GrafanaAnnotation, len(annotations))
+	for _, annotation := range annotations {
+		annotationsByBackupID[annotation.BackupID] = annotation
+	}
+
+	for _, backup := range backups {
+		isFinished := backup.Status != backups_core.BackupStatusInProgress
+		request := buildAnnotationRequest(integration, database, backup)
+
+		annotation := annotationsByBackupID[backup.ID]
+		if annotation == nil {
+			annotationID, err := createAnnotation(integration.URL, apiToken, request)
+			if err != nil {
+				return err
+			}
+
+			if err := s.grafanaRepository.SaveAnnotation(&GrafanaAnnotation{
+				ID:                  uuid.New(),
+				IntegrationID:       integration.ID,
+				BackupID:            backup.ID,
+				GrafanaAnnotationID: annotationID,
+				IsFinished:          isFinished,
+				CreatedAt:           time.Now().UTC(),
+			}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if annotation.IsFinished || !isFinished {
+			continue
+		}
+
+		if err := updateAnnotation(
+			integration.URL,
+			apiToken,
+			annotation.GrafanaAnnotationID,
+			request,
+		); err != nil {
+			return err
+		}
+
+		annotation.IsFinished = true
+		if err := s.grafanaRepository.SaveAnnotation(annotation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildAnnotationRequest describes the backup as a region from its start to its end, a
+// running backup is a point at its start
+func buildAnnotationRequest(
+	integration *GrafanaIntegration,
+	database *databases.Database,
+	backup *backups_core.Backup,
+) *annotationRequest {
+	startedAt := backup.CreatedAt
+	request := &annotationRequest{
+		DashboardUID: integration.DashboardUID,
+		Time:         startedAt.UnixMilli(),
+		Tags: []string{
+			"databasus",
+			"backup",
+			"workspace:" + integration.WorkspaceID.String(),
+			"database:" + database.Name,
+			strings.ToLower(string(backup.Status)),
+		},
+	}
+
+	switch backup.Status {
+	case backups_core.BackupStatusInProgress:
+		request.Text = fmt.Sprintf("Backup of %s started", database.Name)
+		return request
+	case backups_core.BackupStatusCompleted:
+		request.Text = fmt.Sprintf(
+			"Backup of %s completed in %s, %.2f MB",
+			database.Name,
+			time.Duration(backup.BackupDurationMs)*time.Millisecond,
+			backup.BackupSizeMb,
+		)
+	case backups_core.BackupStatusFailed:
+		request.Text = fmt.Sprintf("Backup of %s failed", database.Name)
+		if backup.FailMessage != nil {
+			request.Text += ": " + *backup.FailMessage
+		}
+	default:
+		request.Text = fmt.Sprintf(
+			"Backup of %s %s",
+			database.Name,
+			strings.ToLower(string(backup.Status)),
+		)
+	}
+
+	request.TimeEnd = startedAt.Add(time.Duration(backup.BackupDurationMs) * time.Millisecond).
+		UnixMilli()
+
+	return request
+}
+
+func buildDashboard(workspaceName string, workspaceID uuid.UUID) map[string]any {
+	annotationTags := []string{"databasus", "workspace:" + workspaceID.String()}
+
+	return map[string]any{
+		"title":         "Databasus backups: " + workspaceName,
+		"tags":          []string{"databasus"},
+		"timezone":      "browser",
+		"schemaVersion": 39,
+		"time":          map[string]string{"from": "now-24h", "to": "now"},
+		"annotations": map[string]any{
+			"list": []map[string]any{
+				{
+					"name":      "Databasus backups",
+					"enable":    true,
+					"iconColor": "rgba(255, 152, 48, 1)",
+					"datasource": map[string]string{
+						"type": "grafana",
+						"uid":  "-- Grafana --",
+					},
+					"target": map[string]any{
+						"type":     "tags",
+						"tags":     annotationTags,
+						"matchAny": false,
+						"limit":    100,
+					},
+				},
+			},
+		},
+		"panels": []map[string]any{
+			{
+				"id":      1,
+				"type":    "annolist",
+				"title":   "Backup runs",
+				"gridPos": map[string]int{"h": 12, "w": 24, "x": 0, "y": 0},
+				"options": map[string]any{
+					"onlyFromThisDashboard": false,
+					"onlyInTimeRange":       true,
+					"tags":                  annotationTags,
+					"limit":                 100,
+					"showUser":              false,
+					"showTime":              true,
+					"showTags":              true,
+				},
+			},
+		},
+	}
+}
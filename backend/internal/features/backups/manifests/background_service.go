@@ -0,0 +1,54 @@
+package backups_manifests
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// manifestExportInterval is how stale a manifest may get, it lists backups of the storage
+// as of its last export
+const manifestExportInterval = time.Hour
+
+type ManifestBackgroundService struct {
+	manifestService *ManifestService
+	logger          *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (s *ManifestBackgroundService) Run(ctx context.Context) {
+	wasAlreadyRun := s.hasRun.Load()
+
+	s.runOnce.Do(func() {
+		s.hasRun.Store(true)
+
+		s.logger.Info("Starting backup manifests background service")
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(manifestExportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.manifestService.ExportManifests(); err != nil {
+					s.logger.Error("Failed to export backup manifests", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", s))
+	}
+}
@@ -0,0 +1,33 @@
+package backups_manifests
+
+import (
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var manifestRepository = &ManifestRepository{}
+var manifestService = &ManifestService{
+	manifestRepository,
+	&backups_core.BackupRepository{},
+	storages.GetStorageService(),
+	databases.GetDatabaseService(),
+	encryption_signing.GetSigningService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var manifestBackgroundService = &ManifestBackgroundService{
+	manifestService: manifestService,
+	logger:          logger.GetLogger(),
+}
+
+func GetManifestService() *ManifestService {
+	return manifestService
+}
+
+func GetManifestBackgroundService() *ManifestBackgroundService {
+	return manifestBackgroundService
+}
@@ -0,0 +1,67 @@
+package backups_manifests
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	manifestVersion = 1
+
+	// LatestManifestFileID names the latest manifest in storages without named files. It
+	// is the same for every storage, so the manifest is found knowing nothing about it
+	LatestManifestFileID = "2f31786a-e6ae-5298-a0d4-f8ccb79f0cdc"
+	// latestManifestName and manifestsDirectory name manifests in S3 storages
+	latestManifestName = "databasus-manifests/latest.json"
+	manifestsDirectory = "databasus-manifests"
+)
+
+// BackupManifest lists completed backups of a storage with everything needed to find and
+// restore them when the database of Databasus is lost
+type BackupManifest struct {
+	Version     int              `json:"version"`
+	StorageID   uuid.UUID        `json:"storageId"`
+	StorageName string           `json:"storageName"`
+	StorageType string           `json:"storageType"`
+	GeneratedAt time.Time        `json:"generatedAt"`
+	Backups     []ManifestBackup `json:"backups"`
+}
+
+type ManifestBackup struct {
+	ID           uuid.UUID `json:"id"`
+	DatabaseID   uuid.UUID `json:"databaseId"`
+	DatabaseName string    `json:"databaseName"`
+	DatabaseType string    `json:"databaseType"`
+	CreatedAt    time.Time `json:"createdAt"`
+
+	// FileName is relative to the prefix or path of the storage
+	FileName   string  `json:"fileName"`
+	Checksum   *string `json:"checksum"`
+	SizeMb     float64 `json:"sizeMb"`
+	Encryption string  `json:"encryption"`
+
+	// Signature of the backup file, see backups_core.Backup.GetSignatureMessage
+	Signature *string `json:"signature"`
+}
+
+// SignedBackupManifest is the file written to storages. Signature covers the bytes of
+// Manifest exactly as written, so it is verified without re-encoding, see VerifyManifest
+type SignedBackupManifest struct {
+	Manifest           json.RawMessage `json:"manifest"`
+	SignatureAlgorithm string          `json:"signatureAlgorithm"`
+	Signature          string          `json:"signature"`
+}
+
+// ManifestExport remembers the content of the last manifest written to a storage, a new
+// manifest is written only when backups of the storage change
+type ManifestExport struct {
+	StorageID   uuid.UUID `gorm:"column:storage_id;type:uuid;primaryKey"`
+	ContentHash string    `gorm:"column:content_hash;type:text;not null"`
+	ExportedAt  time.Time `gorm:"column:exported_at;type:timestamptz;not null"`
+}
+
+func (ManifestExport) TableName() string {
+	return "backup_manifest_exports"
+}
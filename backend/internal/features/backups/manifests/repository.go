@@ -0,0 +1,27 @@
+package backups_manifests
+
+import (
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type ManifestRepository struct{}
+
+func (r *ManifestRepository) Save(export *ManifestExport) error {
+	return storage.GetDb().Save(export).Error
+}
+
+func (r *ManifestRepository) FindAll() ([]*ManifestExport, error) {
+	var exports []*ManifestExport
+
+	if err := storage.GetDb().Find(&exports).Error; err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
+
+func (r *ManifestRepository) DeleteByStorageID(storageID uuid.UUID) error {
+	return storage.GetDb().Delete(&ManifestExport{}, "storage_id = ?", storageID).Error
+}
@@ -0,0 +1,239 @@
+package backups_manifests
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// manifestSaveTimeout bounds writing one manifest, they are small files
+const manifestSaveTimeout = 5 * time.Minute
+
+type ManifestService struct {
+	manifestRepository *ManifestRepository
+	backupRepository   *backups_core.BackupRepository
+	storageService     *storages.StorageService
+	databaseService    *databases.DatabaseService
+	signingService     *encryption_signing.SigningService
+	fieldEncryptor     encryption.FieldEncryptor
+	logger             *slog.Logger
+}
+
+// ExportManifests writes a signed manifest to each storage whose backups changed since its
+// last manifest. Storages exported before are exported again when their last backup is
+// removed, so the manifest does not list removed backups
+func (s *ManifestService) ExportManifests() error {
+	signingKey, err := s.signingService.GetSigningKey()
+	if err != nil {
+		return err
+	}
+
+	storageIDs, err := s.backupRepository.FindStorageIDsWithCompletedBackups()
+	if err != nil {
+		return err
+	}
+
+	exports, err := s.manifestRepository.FindAll()
+	if err != nil {
+		return err
+	}
+
+	exportsByStorageID := make(map[uuid.UUID]*ManifestExport, len(exports))
+	for _, export := range exports {
+		exportsByStorageID[export.StorageID] = export
+
+		if !slices.Contains(storageIDs, export.StorageID) {
+			storageIDs = append(storageIDs, export.StorageID)
+		}
+	}
+
+	storagesByID, err := s.storageService.GetStoragesByIDs(storageIDs)
+	if err != nil {
+		return err
+	}
+
+	databaseCache := make(map[uuid.UUID]*databases.Database)
+
+	for _, storageID := range storageIDs {
+		storage, ok := storagesByID[storageID]
+		if !ok {
+			if err := s.manifestRepository.DeleteByStorageID(storageID); err != nil {
+				s.logger.Error("Failed to delete manifest export", "error", err)
+			}
+
+			continue
+		}
+
+		if err := s.exportStorageManifest(
+			storage,
+			exportsByStorageID[storageID],
+			databaseCache,
+			signingKey,
+		); err != nil {
+			s.logger.Error(
+				"Failed to export backup manifest",
+				"storageId", storageID,
+				"error", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *ManifestService) exportStorageManifest(
+	storage *storages.Storage,
+	export *ManifestExport,
+	databaseCache map[uuid.UUID]*databases.Database,
+	signingKey *encryption_signing.SigningKey,
+) error {
+	backups, err := s.backupRepository.FindByStorageIdAndStatus(
+		storage.ID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return err
+	}
+
+	manifest := &BackupManifest{
+		Version:     manifestVersion,
+		StorageID:   storage.ID,
+		StorageName: storage.Name,
+		StorageType: string(storage.Type),
+		Backups:     make([]ManifestBackup, 0, len(backups)),
+	}
+
+	for _, backup := range backups {
+		manifestBackup := ManifestBackup{
+			ID:         backup.ID,
+			DatabaseID: backup.DatabaseID,
+			CreatedAt:  backup.CreatedAt,
+			FileName:   storage.GetStoredFileName(backup.ID, backup.FileName, backup.Checksum),
+			Checksum:   backup.Checksum,
+			SizeMb:     backup.BackupSizeMb,
+			Encryption: string(backup.Encryption),
+			Signature:  backup.Signature,
+		}
+
+		if database := s.getDatabase(backup.DatabaseID, databaseCache); database != nil {
+			manifestBackup.DatabaseName = database.Name
+			manifestBackup.DatabaseType = string(database.Type)
+		}
+
+		manifest.Backups = append(manifest.Backups, manifestBackup)
+	}
+
+	contentHash, err := hashManifestContent(manifest)
+	if err != nil {
+		return err
+	}
+
+	if export != nil && export.ContentHash == contentHash {
+		return nil
+	}
+
+	manifest.GeneratedAt = time.Now().UTC()
+
+	signed, err := SignManifest(manifest, signingKey)
+	if err != nil {
+		return err
+	}
+
+	signedJSON, err := json.MarshalIndent(signed, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Each manifest is kept as a separate file Databasus never overwrites or removes, the
+	// latest one is also written under a well known name to be found first
+	versionName := fmt.Sprintf(
+		"%s/%s.json",
+		manifestsDirectory,
+		manifest.GeneratedAt.Format("20060102T150405Z"),
+	)
+	if err := s.saveManifestFile(storage, uuid.New(), versionName, signedJSON); err != nil {
+		return err
+	}
+
+	if err := s.saveManifestFile(
+		storage,
+		uuid.MustParse(LatestManifestFileID),
+		latestManifestName,
+		signedJSON,
+	); err != nil {
+		return err
+	}
+
+	return s.manifestRepository.Save(&ManifestExport{
+		StorageID:   storage.ID,
+		ContentHash: contentHash,
+		ExportedAt:  manifest.GeneratedAt,
+	})
+}
+
+func (s *ManifestService) saveManifestFile(
+	storage *storages.Storage,
+	fileID uuid.UUID,
+	name string,
+	content []byte,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), manifestSaveTimeout)
+	defer cancel()
+
+	return storage.SaveNamedFile(
+		ctx,
+		s.fieldEncryptor,
+		s.logger,
+		fileID,
+		name,
+		bytes.NewReader(content),
+	)
+}
+
+func (s *ManifestService) getDatabase(
+	databaseID uuid.UUID,
+	databaseCache map[uuid.UUID]*databases.Database,
+) *databases.Database {
+	if database, ok := databaseCache[databaseID]; ok {
+		return database
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		database = nil
+	}
+
+	databaseCache[databaseID] = database
+
+	return database
+}
+
+// hashManifestContent ignores GeneratedAt, so unchanged backups do not produce a new
+// manifest on each run
+func hashManifestContent(manifest *BackupManifest) (string, error) {
+	content, err := json.Marshal(struct {
+		StorageName string           `json:"storageName"`
+		Backups     []ManifestBackup `json:"backups"`
+	}{manifest.StorageName, manifest.Backups})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,133 @@
+package backups_manifests
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/backups/backups"
+	"databasus-backend/internal/features/databases"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/encryption"
+)
+
+func Test_ExportStorageManifest_WhenBackupsChange_SignedManifestWrittenToStorage(t *testing.T) {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+	)
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	backup := backups.CreateTestBackup(database.ID, storage.ID)
+
+	assert.NoError(t, exportTestStorage(storage))
+
+	manifest := readLatestManifest(t, storage)
+	assert.Equal(t, storage.ID, manifest.StorageID)
+	assert.Len(t, manifest.Backups, 1)
+	if len(manifest.Backups) != 1 {
+		return
+	}
+	assert.Equal(t, backup.ID, manifest.Backups[0].ID)
+	assert.Equal(t, backup.ID.String(), manifest.Backups[0].FileName)
+	assert.Equal(t, database.Name, manifest.Backups[0].DatabaseName)
+
+	export, err := findExport(storage.ID)
+	assert.NoError(t, err)
+	exportedAt := export.ExportedAt
+
+	// Unchanged backups keep the manifest
+	assert.NoError(t, exportTestStorage(storage))
+	export, err = findExport(storage.ID)
+	assert.NoError(t, err)
+	assert.True(t, exportedAt.Equal(export.ExportedAt))
+
+	backups.CreateTestBackup(database.ID, storage.ID)
+	assert.NoError(t, exportTestStorage(storage))
+
+	manifest = readLatestManifest(t, storage)
+	assert.Len(t, manifest.Backups, 2)
+}
+
+// exportTestStorage exports only the storage of the test, ExportManifests writes to every
+// storage of the test database
+func exportTestStorage(storage *storages.Storage) error {
+	signingKey, err := GetManifestService().signingService.GetSigningKey()
+	if err != nil {
+		return err
+	}
+
+	export, err := findExport(storage.ID)
+	if err != nil {
+		return err
+	}
+
+	return GetManifestService().exportStorageManifest(
+		storage,
+		export,
+		map[uuid.UUID]*databases.Database{},
+		signingKey,
+	)
+}
+
+func readLatestManifest(t *testing.T, storage *storages.Storage) *BackupManifest {
+	reader, err := storage.GetFile(
+		encryption.GetFieldEncryptor(),
+		uuid.MustParse(LatestManifestFileID),
+	)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+
+	var signed SignedBackupManifest
+	assert.NoError(t, json.Unmarshal(content, &signed))
+
+	secretKey, err := encryption_secrets.GetSecretKeyService().GetSecretKey()
+	assert.NoError(t, err)
+
+	manifest, err := VerifyManifest(&signed, secretKey)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+
+	return manifest
+}
+
+func findExport(storageID uuid.UUID) (*ManifestExport, error) {
+	exports, err := manifestRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, export := range exports {
+		if export.StorageID == storageID {
+			return export, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+package backups_manifests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+)
+
+const (
+	signatureAlgorithm = encryption_signing.Algorithm
+
+	// legacySignatureAlgorithm signed manifests before they were signed by the signing key,
+	// such manifests are still verified
+	legacySignatureAlgorithm = "HMAC-SHA256"
+	// legacySigningKeyContext keeps legacy signatures apart from other uses of the secret key
+	legacySigningKeyContext = "databasus-backup-manifest"
+)
+
+// SignManifest signs the manifest with the signing key of the instance, anyone holding its
+// public key is able to verify it
+func SignManifest(
+	manifest *BackupManifest,
+	signingKey *encryption_signing.SigningKey,
+) (*SignedBackupManifest, error) {
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	return &SignedBackupManifest{
+		Manifest:           manifestJSON,
+		SignatureAlgorithm: signatureAlgorithm,
+		Signature:          signingKey.Sign(manifestJSON),
+	}, nil
+}
+
+// VerifyManifest checks the signature and returns the manifest, any change of the manifest
+// bytes fails the check. The secret key verifies legacy manifests and derives the signing key
+func VerifyManifest(signed *SignedBackupManifest, secretKey string) (*BackupManifest, error) {
+	switch signed.SignatureAlgorithm {
+	case signatureAlgorithm:
+		signingKey, err := encryption_signing.DeriveSigningKey(secretKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := signingKey.Verify(signed.Manifest, signed.Signature); err != nil {
+			return nil, fmt.Errorf("manifest %w", err)
+		}
+	case legacySignatureAlgorithm:
+		expectedSignature := computeLegacySignature(signed.Manifest, secretKey)
+		if !hmac.Equal([]byte(expectedSignature), []byte(signed.Signature)) {
+			return nil, errors.New("manifest signature does not match")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", signed.SignatureAlgorithm)
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(signed.Manifest, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+func computeLegacySignature(manifestJSON []byte, secretKey string) string {
+	keyHash := hmac.New(sha256.New, []byte(secretKey))
+	keyHash.Write([]byte(legacySigningKeyContext))
+
+	signatureHash := hmac.New(sha256.New, keyHash.Sum(nil))
+	signatureHash.Write(manifestJSON)
+
+	return base64.StdEncoding.EncodeToString(signatureHash.Sum(nil))
+}
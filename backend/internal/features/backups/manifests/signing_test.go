@@ -0,0 +1,69 @@
+package backups_manifests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+)
+
+func Test_VerifyManifest_WhenManifestOrKeyChanged_Rejected(t *testing.T) {
+	manifest := &BackupManifest{
+		Version:     manifestVersion,
+		StorageID:   uuid.New(),
+		StorageName: "Backups bucket",
+		StorageType: "S3",
+		GeneratedAt: time.Now().UTC(),
+		Backups: []ManifestBackup{
+			{ID: uuid.New(), DatabaseID: uuid.New(), FileName: "orders.dump", SizeMb: 12.5},
+		},
+	}
+
+	signingKey, err := encryption_signing.DeriveSigningKey("secret-key")
+	assert.NoError(t, err)
+
+	signed, err := SignManifest(manifest, signingKey)
+	assert.NoError(t, err)
+	assert.Equal(t, encryption_signing.Algorithm, signed.SignatureAlgorithm)
+	assert.NoError(
+		t,
+		encryption_signing.VerifySignature(
+			signingKey.GetPublicKey(),
+			signed.Manifest,
+			signed.Signature,
+		),
+	)
+
+	verified, err := VerifyManifest(signed, "secret-key")
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Backups, verified.Backups)
+
+	_, err = VerifyManifest(signed, "other-secret-key")
+	assert.Error(t, err)
+
+	tampered := *signed
+	tampered.Manifest = []byte(
+		string(signed.Manifest[:len(signed.Manifest)-1]) + `,"extra":true}`,
+	)
+	_, err = VerifyManifest(&tampered, "secret-key")
+	assert.Error(t, err)
+}
+
+func Test_VerifyManifest_WhenSignedByLegacyHMAC_Verified(t *testing.T) {
+	manifestJSON := []byte(`{"version":1,"storageName":"Backups bucket","backups":[]}`)
+	legacy := &SignedBackupManifest{
+		Manifest:           manifestJSON,
+		SignatureAlgorithm: legacySignatureAlgorithm,
+		Signature:          computeLegacySignature(manifestJSON, "secret-key"),
+	}
+
+	verified, err := VerifyManifest(legacy, "secret-key")
+	assert.NoError(t, err)
+	assert.Equal(t, "Backups bucket", verified.StorageName)
+
+	_, err = VerifyManifest(legacy, "other-secret-key")
+	assert.Error(t, err)
+}
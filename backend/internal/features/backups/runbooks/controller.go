@@ -0,0 +1,68 @@
+package backups_runbooks
+
+import (
+	"fmt"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RunbookController struct {
+	runbookService *RunbookService
+}
+
+func (c *RunbookController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/runbooks/workspace/:workspaceId", c.GetRunbook)
+}
+
+// GetRunbook
+// @Summary Get disaster recovery runbook
+// @Description Get the runbook telling where the latest backup of each database lives and how to restore it, refreshed after each backup. With format=markdown it is downloaded as a markdown file
+// @Tags runbooks
+// @Produce json
+// @Produce text/markdown
+// @Param workspaceId path string true "Workspace ID"
+// @Param format query string false "json (default) or markdown"
+// @Success 200 {object} DRRunbook
+// @Failure 400
+// @Failure 401
+// @Router /runbooks/workspace/{workspaceId} [get]
+func (c *RunbookController) GetRunbook(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "markdown" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or markdown"})
+		return
+	}
+
+	runbook, err := c.runbookService.GetRunbook(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "json" {
+		ctx.JSON(http.StatusOK, runbook)
+		return
+	}
+
+	ctx.Header(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"dr-runbook-%s.md\"", workspaceID),
+	)
+	ctx.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(runbook.ToMarkdown()))
+}
@@ -0,0 +1,119 @@
+package backups_runbooks
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/backups/backups"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetRunbookController(),
+	)
+}
+
+func Test_GetRunbook_AfterBackupCompleted_RunbookPointsToLatestBackup(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	url := "/api/v1/runbooks/workspace/" + workspace.ID.String()
+
+	var runbook DRRunbook
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		url,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&runbook,
+	)
+	assert.Len(t, runbook.Databases, 1)
+	assert.Nil(t, runbook.Databases[0].LatestBackup)
+
+	backup := backups.CreateTestBackup(database.ID, storage.ID)
+	GetRunbookService().OnBackupCompleted(backup)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		url,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&runbook,
+	)
+	assert.Len(t, runbook.Databases, 1)
+	if len(runbook.Databases) != 1 || runbook.Databases[0].LatestBackup == nil {
+		t.FailNow()
+	}
+
+	runbookDatabase := runbook.Databases[0]
+	assert.Equal(t, backup.ID, runbookDatabase.LatestBackup.ID)
+	assert.Equal(t, backup.ID.String(), runbookDatabase.LatestBackup.FileName)
+	assert.Equal(t, string(storages.StorageTypeLocal), runbookDatabase.Storage.Type)
+	assert.Contains(t, runbookDatabase.Storage.Location["path"], backup.ID.String())
+	assert.Contains(t, strings.Join(runbookDatabase.RestoreCommands, "\n"), "pg_restore")
+
+	newerBackup := backups.CreateTestBackup(database.ID, storage.ID)
+	GetRunbookService().OnBackupCompleted(newerBackup)
+
+	markdownResponse := test_utils.MakeGetRequest(
+		t,
+		router,
+		url+"?format=markdown",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+	markdown := string(markdownResponse.Body)
+	assert.Contains(t, markdownResponse.Headers.Get("Content-Disposition"), "attachment")
+	assert.Contains(t, markdown, "## "+database.Name)
+	assert.Contains(t, markdown, newerBackup.ID.String())
+	assert.NotContains(t, markdown, backup.ID.String())
+}
+
+func Test_GetRunbook_WhenUserIsNotWorkspaceMember_Rejected(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/runbooks/workspace/"+workspace.ID.String(),
+		"Bearer "+outsider.Token,
+		http.StatusBadRequest,
+	)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/runbooks/workspace/"+workspace.ID.String()+"?format=pdf",
+		"Bearer "+owner.Token,
+		http.StatusBadRequest,
+	)
+}
@@ -0,0 +1,53 @@
+package backups_runbooks
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var runbookRepository = &RunbookRepository{}
+var runbookService = &RunbookService{
+	runbookRepository,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	storages.GetStorageService(),
+	workspaces_services.GetWorkspaceService(),
+	logger.GetLogger(),
+}
+var runbookController = &RunbookController{
+	runbookService,
+}
+
+func GetRunbookService() *RunbookService {
+	return runbookService
+}
+
+func GetRunbookController() *RunbookController {
+	return runbookController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		backuping.GetBackuperNode().AddBackupCompletedListener(runbookService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
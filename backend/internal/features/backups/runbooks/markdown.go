@@ -0,0 +1,80 @@
+package backups_runbooks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ToMarkdown renders the runbook for people, the JSON form is meant for scripts
+func (r *DRRunbook) ToMarkdown() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# Disaster recovery runbook: %s\n\n", r.WorkspaceName)
+	fmt.Fprintf(&builder, "Generated at %s.\n\n", r.GeneratedAt.Format(time.RFC3339))
+	builder.WriteString(
+		"Placeholders like `<S3_SECRET_KEY>` stand for secrets kept outside of this runbook, " +
+			"replace them before running the commands.\n",
+	)
+
+	if len(r.Databases) == 0 {
+		builder.WriteString("\nThe workspace has no databases.\n")
+	}
+
+	for _, database := range r.Databases {
+		fmt.Fprintf(&builder, "\n## %s (%s)\n\n", database.Name, database.Type)
+
+		if database.LatestBackup == nil {
+			builder.WriteString("No completed backups yet.\n")
+			continue
+		}
+
+		backup := database.LatestBackup
+		fmt.Fprintf(&builder, "- Latest backup: `%s`\n", backup.ID)
+		fmt.Fprintf(&builder, "- Created at: %s\n", backup.CreatedAt.Format(time.RFC3339))
+		fmt.Fprintf(&builder, "- Size: %.2f MB\n", backup.SizeMb)
+		fmt.Fprintf(&builder, "- File: `%s`\n", backup.FileName)
+		fmt.Fprintf(&builder, "- Encryption: %s\n", backup.Encryption)
+		if backup.Checksum != nil {
+			fmt.Fprintf(&builder, "- SHA-256: `%s`\n", *backup.Checksum)
+		}
+
+		if database.Storage != nil {
+			fmt.Fprintf(
+				&builder,
+				"\n### Storage: %s (%s)\n\n",
+				database.Storage.Name,
+				database.Storage.Type,
+			)
+
+			locationKeys := make([]string, 0, len(database.Storage.Location))
+			for key := range database.Storage.Location {
+				locationKeys = append(locationKeys, key)
+			}
+			sort.Strings(locationKeys)
+
+			for _, key := range locationKeys {
+				if value := database.Storage.Location[key]; value != "" {
+					fmt.Fprintf(&builder, "- %s: `%s`\n", key, value)
+				}
+			}
+		}
+
+		if len(database.RequiredKeys) > 0 {
+			builder.WriteString("\n### Required keys\n\n")
+
+			for _, key := range database.RequiredKeys {
+				fmt.Fprintf(&builder, "- `%s`: %s\n", key.Placeholder, key.Description)
+			}
+		}
+
+		builder.WriteString("\n### Restore\n\n```sh\n")
+		for _, command := range database.RestoreCommands {
+			builder.WriteString(command + "\n")
+		}
+		builder.WriteString("```\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,59 @@
+package backups_runbooks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DRRunbook tells how to restore databases of a workspace without the Databasus instance:
+// where the latest backup of each database lives, which keys are needed and which commands
+// restore it. It is regenerated after each completed backup of the workspace
+type DRRunbook struct {
+	WorkspaceID   uuid.UUID         `json:"workspaceId"   gorm:"column:workspace_id;type:uuid;primaryKey"`
+	WorkspaceName string            `json:"workspaceName" gorm:"column:workspace_name;type:text;not null"`
+	Databases     []RunbookDatabase `json:"databases"     gorm:"column:databases;type:text;not null;serializer:json"`
+	GeneratedAt   time.Time         `json:"generatedAt"   gorm:"column:generated_at;type:timestamptz;not null"`
+}
+
+func (DRRunbook) TableName() string {
+	return "dr_runbooks"
+}
+
+type RunbookDatabase struct {
+	DatabaseID uuid.UUID `json:"databaseId"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+
+	// LatestBackup is nil when the database has no completed backups yet
+	LatestBackup *RunbookBackup  `json:"latestBackup"`
+	Storage      *RunbookStorage `json:"storage"`
+
+	// RequiredKeys are placeholders used by the commands, their values are never written
+	// to the runbook
+	RequiredKeys    []RunbookKey `json:"requiredKeys"`
+	RestoreCommands []string     `json:"restoreCommands"`
+}
+
+type RunbookBackup struct {
+	ID         uuid.UUID `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	SizeMb     float64   `json:"sizeMb"`
+	FileName   string    `json:"fileName"`
+	Encryption string    `json:"encryption"`
+	Checksum   *string   `json:"checksum"`
+}
+
+// RunbookStorage holds only non-secret settings of the storage, credentials are listed in
+// RequiredKeys instead
+type RunbookStorage struct {
+	ID       uuid.UUID         `json:"id"`
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Location map[string]string `json:"location"`
+}
+
+type RunbookKey struct {
+	Placeholder string `json:"placeholder"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,32 @@
+package backups_runbooks
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RunbookRepository struct{}
+
+func (r *RunbookRepository) Save(runbook *DRRunbook) error {
+	return storage.GetDb().Save(runbook).Error
+}
+
+func (r *RunbookRepository) FindByWorkspaceID(workspaceID uuid.UUID) (*DRRunbook, error) {
+	var runbook DRRunbook
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ?", workspaceID).
+		First(&runbook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &runbook, nil
+}
@@ -0,0 +1,384 @@
+package backups_runbooks
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
+	gcs_storage "databasus-backend/internal/features/storages/models/gcs"
+	network_utils "databasus-backend/internal/util/network"
+)
+
+// restoredFileName is the local file every retrieval command downloads to, so restore
+// commands do not depend on the storage
+const restoredFileName = "backup.bin"
+
+func buildRunbookDatabase(
+	database *databases.Database,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) RunbookDatabase {
+	runbookDatabase := RunbookDatabase{
+		DatabaseID:      database.ID,
+		Name:            database.Name,
+		Type:            string(database.Type),
+		RequiredKeys:    []RunbookKey{},
+		RestoreCommands: []string{},
+	}
+
+	if backup == nil || storage == nil {
+		return runbookDatabase
+	}
+
+	objectName := storage.GetStoredFileName(backup.ID, backup.FileName, backup.Checksum)
+
+	runbookDatabase.LatestBackup = &RunbookBackup{
+		ID:         backup.ID,
+		CreatedAt:  backup.CreatedAt,
+		SizeMb:     backup.BackupSizeMb,
+		FileName:   objectName,
+		Encryption: string(backup.Encryption),
+		Checksum:   backup.Checksum,
+	}
+
+	location, storageKeys, retrieveCommand := describeStorage(storage, objectName)
+	if storage.IsSystem {
+		// Settings of system storages are redacted for workspace members, the runbook
+		// is readable by all of them
+		location = map[string]string{}
+		storageKeys = []RunbookKey{}
+		retrieveCommand = fmt.Sprintf(
+			"# %s is a system storage, ask admins of Databasus to download %s to %s",
+			storage.Name,
+			objectName,
+			restoredFileName,
+		)
+	}
+
+	runbookDatabase.Storage = &RunbookStorage{
+		ID:       storage.ID,
+		Name:     storage.Name,
+		Type:     string(storage.Type),
+		Location: location,
+	}
+	runbookDatabase.RequiredKeys = append(runbookDatabase.RequiredKeys, storageKeys...)
+	runbookDatabase.RestoreCommands = append(runbookDatabase.RestoreCommands, retrieveCommand)
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		runbookDatabase.RequiredKeys = append(runbookDatabase.RequiredKeys, RunbookKey{
+			Placeholder: "<DATABASUS_SECRET_KEY>",
+			Description: "Secret key of the Databasus instance, backup keys are derived from it",
+		})
+		runbookDatabase.RestoreCommands = append(
+			runbookDatabase.RestoreCommands,
+			"# The backup is encrypted, restore it through a Databasus instance started "+
+				"with <DATABASUS_SECRET_KEY>, or decrypt "+restoredFileName+" with it first",
+		)
+	}
+
+	restoreKeys, restoreCommands := describeRestore(database.Type)
+	runbookDatabase.RequiredKeys = append(runbookDatabase.RequiredKeys, restoreKeys...)
+	runbookDatabase.RestoreCommands = append(runbookDatabase.RestoreCommands, restoreCommands...)
+
+	return runbookDatabase
+}
+
+func describeStorage(
+	storage *storages.Storage,
+	objectName string,
+) (map[string]string, []RunbookKey, string) {
+	location := map[string]string{}
+
+	switch {
+	case storage.Type == storages.StorageTypeS3 && storage.S3Storage != nil:
+		s3 := storage.S3Storage
+		objectKey := joinPath(s3.S3Prefix, objectName)
+
+		location["bucket"] = s3.S3Bucket
+		location["region"] = s3.S3Region
+		location["endpoint"] = s3.S3Endpoint
+		location["objectKey"] = objectKey
+
+		command := fmt.Sprintf(
+			"AWS_ACCESS_KEY_ID=<S3_ACCESS_KEY> AWS_SECRET_ACCESS_KEY=<S3_SECRET_KEY> "+
+				"aws s3 cp \"s3://%s/%s\" %s --region %s",
+			s3.S3Bucket,
+			objectKey,
+			restoredFileName,
+			s3.S3Region,
+		)
+		if s3.S3Endpoint != "" {
+			command += " --endpoint-url " + s3.S3Endpoint
+		}
+
+		return location, []RunbookKey{
+			{Placeholder: "<S3_ACCESS_KEY>", Description: "Access key of the S3 bucket"},
+			{Placeholder: "<S3_SECRET_KEY>", Description: "Secret key of the S3 bucket"},
+		}, command
+
+	case storage.Type == storages.StorageTypeAzureBlob && storage.AzureBlobStorage != nil:
+		azure := storage.AzureBlobStorage
+		blobName := joinPath(azure.Prefix, objectName)
+
+		location["accountName"] = azure.AccountName
+		location["container"] = azure.ContainerName
+		location["endpoint"] = azure.Endpoint
+		location["blobName"] = blobName
+
+		if azure.AuthMethod == azure_blob_storage.AuthMethodConnectionString {
+			return location, []RunbookKey{{
+				Placeholder: "<AZURE_CONNECTION_STRING>",
+				Description: "Connection string of the storage account",
+			}}, fmt.Sprintf(
+				"az storage blob download --connection-string \"<AZURE_CONNECTION_STRING>\" "+
+					"--container-name %s --name \"%s\" --file %s",
+				azure.ContainerName,
+				blobName,
+				restoredFileName,
+			)
+		}
+
+		return location, []RunbookKey{{
+			Placeholder: "<AZURE_ACCOUNT_KEY>",
+			Description: "Key of the storage account",
+		}}, fmt.Sprintf(
+			"az storage blob download --account-name %s --account-key <AZURE_ACCOUNT_KEY> "+
+				"--container-name %s --name \"%s\" --file %s",
+			azure.AccountName,
+			azure.ContainerName,
+			blobName,
+			restoredFileName,
+		)
+
+	case storage.Type == storages.StorageTypeGCS && storage.GCSStorage != nil:
+		gcs := storage.GCSStorage
+		objectPath := joinPath(gcs.Prefix, objectName)
+
+		location["bucket"] = gcs.Bucket
+		location["objectName"] = objectPath
+
+		command := fmt.Sprintf(
+			"gcloud storage cp \"gs://%s/%s\" %s",
+			gcs.Bucket,
+			objectPath,
+			restoredFileName,
+		)
+
+		// workload identity stores no key, any account allowed to read the bucket works
+		if gcs.AuthMethod == gcs_storage.AuthMethodWorkloadIdentity {
+			return location, nil, command
+		}
+
+		return location, []RunbookKey{{
+			Placeholder: "<GCS_SERVICE_ACCOUNT_KEY_FILE>",
+			Description: "JSON key file of the service account of the bucket",
+		}}, "gcloud auth activate-service-account --key-file=<GCS_SERVICE_ACCOUNT_KEY_FILE> && " +
+			command
+
+	case storage.Type == storages.StorageTypeFTP && storage.FTPStorage != nil:
+		ftp := storage.FTPStorage
+		filePath := joinPath(ftp.Path, objectName)
+
+		location["host"] = ftp.Host
+		location["port"] = strconv.Itoa(ftp.Port)
+		location["path"] = filePath
+
+		scheme := "ftp"
+		if ftp.UseSSL {
+			scheme = "ftps"
+		}
+
+		return location, []RunbookKey{{
+			Placeholder: "<FTP_PASSWORD>",
+			Description: "Password of the FTP user " + ftp.Username,
+		}}, fmt.Sprintf(
+			"curl -o %s --user \"%s:<FTP_PASSWORD>\" \"%s://%s/%s\"",
+			restoredFileName,
+			ftp.Username,
+			scheme,
+			network_utils.JoinHostPort(ftp.Host, ftp.Port),
+			filePath,
+		)
+
+	case storage.Type == storages.StorageTypeSFTP && storage.SFTPStorage != nil:
+		sftp := storage.SFTPStorage
+		filePath := "/" + joinPath(sftp.Path, objectName)
+
+		location["host"] = sftp.Host
+		location["port"] = strconv.Itoa(sftp.Port)
+		location["path"] = filePath
+
+		// sftp takes IPv6 literals in brackets, as the path follows a colon
+		sftpHost := network_utils.NormalizeHost(sftp.Host)
+		if strings.Contains(sftpHost, ":") {
+			sftpHost = "[" + sftpHost + "]"
+		}
+
+		return location, []RunbookKey{{
+			Placeholder: "<SFTP_PRIVATE_KEY_FILE>",
+			Description: "Private key or password of the SFTP user " + sftp.Username,
+		}}, fmt.Sprintf(
+			"sftp -i <SFTP_PRIVATE_KEY_FILE> -P %d \"%s@%s:%s\" %s",
+			sftp.Port,
+			sftp.Username,
+			sftpHost,
+			filePath,
+			restoredFileName,
+		)
+
+	case storage.Type == storages.StorageTypeNAS && storage.NASStorage != nil:
+		nas := storage.NASStorage
+		filePath := joinPath(nas.Path, objectName)
+
+		location["host"] = nas.Host
+		location["port"] = strconv.Itoa(nas.Port)
+		location["share"] = nas.Share
+		location["domain"] = nas.Domain
+		location["path"] = filePath
+
+		return location, []RunbookKey{{
+			Placeholder: "<NAS_PASSWORD>",
+			Description: "Password of the NAS user " + nas.Username,
+		}}, fmt.Sprintf(
+			"smbclient \"//%s/%s\" -p %d -U \"%s%%<NAS_PASSWORD>\" -c 'get \"%s\" %s'",
+			nas.Host,
+			nas.Share,
+			nas.Port,
+			nas.Username,
+			filePath,
+			restoredFileName,
+		)
+
+	case storage.Type == storages.StorageTypeRclone && storage.RcloneStorage != nil:
+		location["remotePath"] = storage.RcloneStorage.RemotePath
+		location["path"] = joinPath(storage.RcloneStorage.RemotePath, objectName)
+
+		return location, []RunbookKey{{
+			Placeholder: "<RCLONE_CONFIG_FILE>",
+			Description: "rclone config of the storage, its remote is named in the path",
+		}}, fmt.Sprintf(
+			"rclone --config <RCLONE_CONFIG_FILE> copyto \"<REMOTE>:%s\" %s",
+			location["path"],
+			restoredFileName,
+		)
+
+	case storage.Type == storages.StorageTypeLocal:
+		filePath := path.Join(config.GetEnv().DataFolder, objectName)
+		location["path"] = filePath
+
+		return location, []RunbookKey{}, fmt.Sprintf(
+			"cp \"%s\" %s # on the Databasus node or from its data volume",
+			filePath,
+			restoredFileName,
+		)
+
+	case storage.Type == storages.StorageTypePlugin && storage.PluginStorage != nil:
+		location["plugin"] = storage.PluginStorage.PluginName
+		location["fileName"] = objectName
+		for key, value := range storage.PluginStorage.Config {
+			location["config."+key] = value
+		}
+
+		keys := []RunbookKey{}
+		for key := range storage.PluginStorage.SecretConfig {
+			keys = append(keys, RunbookKey{
+				Placeholder: "<PLUGIN_" + strings.ToUpper(key) + ">",
+				Description: "Secret setting " + key + " of the storage plugin",
+			})
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Placeholder < keys[j].Placeholder })
+
+		return location, keys, fmt.Sprintf(
+			"# Download %s with the tooling of the %s storage plugin to %s",
+			objectName,
+			storage.PluginStorage.PluginName,
+			restoredFileName,
+		)
+	}
+
+	location["fileName"] = objectName
+
+	return location, []RunbookKey{{
+		Placeholder: "<STORAGE_CREDENTIALS>",
+		Description: "Account the storage is connected with",
+	}}, fmt.Sprintf(
+		"# Download %s from the %s storage to %s",
+		objectName,
+		storage.Type,
+		restoredFileName,
+	)
+}
+
+// describeRestore returns commands loading the downloaded file into a target database.
+// Backups of other engines are made with engine tooling writing beside the file, like
+// BACKUP INTO of CockroachDB, and are restored through Databasus
+func describeRestore(databaseType databases.DatabaseType) ([]RunbookKey, []string) {
+	switch databaseType {
+	case databases.DatabaseTypePostgres:
+		return []RunbookKey{targetPasswordKey()}, []string{
+			"PGPASSWORD=<TARGET_PASSWORD> pg_restore --no-owner --no-acl " +
+				"-h <TARGET_HOST> -p <TARGET_PORT> -U <TARGET_USER> -d <TARGET_DATABASE> " +
+				restoredFileName,
+		}
+
+	case databases.DatabaseTypeMysql:
+		return []RunbookKey{targetPasswordKey()}, []string{
+			"zstd -d -c " + restoredFileName + " | MYSQL_PWD=<TARGET_PASSWORD> mysql " +
+				"-h <TARGET_HOST> -P <TARGET_PORT> -u <TARGET_USER> <TARGET_DATABASE>",
+		}
+
+	case databases.DatabaseTypeMariadb:
+		return []RunbookKey{targetPasswordKey()}, []string{
+			"zstd -d -c " + restoredFileName + " | MYSQL_PWD=<TARGET_PASSWORD> mariadb " +
+				"-h <TARGET_HOST> -P <TARGET_PORT> -u <TARGET_USER> <TARGET_DATABASE>",
+		}
+
+	case databases.DatabaseTypeMongodb:
+		return []RunbookKey{{
+			Placeholder: "<TARGET_URI>",
+			Description: "Connection URI of the database to restore into",
+		}}, []string{
+			"mongorestore --uri \"<TARGET_URI>\" --gzip --archive=" + restoredFileName,
+		}
+
+	case databases.DatabaseTypeFilesystem:
+		// GNU tar detects the compression of the archive by itself
+		return []RunbookKey{}, []string{
+			"mkdir -p <TARGET_DIRECTORY> && tar -xf " + restoredFileName +
+				" -C <TARGET_DIRECTORY>",
+		}
+	}
+
+	return []RunbookKey{}, []string{
+		fmt.Sprintf(
+			"# %s backups are restored through Databasus, upload %s to a Databasus "+
+				"instance and restore it from there",
+			databaseType,
+			restoredFileName,
+		),
+	}
+}
+
+func targetPasswordKey() RunbookKey {
+	return RunbookKey{
+		Placeholder: "<TARGET_PASSWORD>",
+		Description: "Password of the user of the database to restore into",
+	}
+}
+
+func joinPath(directory string, fileName string) string {
+	directory = strings.Trim(directory, "/")
+	if directory == "" {
+		return fileName
+	}
+
+	return directory + "/" + fileName
+}
@@ -0,0 +1,136 @@
+package backups_runbooks
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+type RunbookService struct {
+	runbookRepository *RunbookRepository
+	backupRepository  *backups_core.BackupRepository
+	databaseService   *databases.DatabaseService
+	storageService    *storages.StorageService
+	workspaceService  *workspaces_services.WorkspaceService
+	logger            *slog.Logger
+}
+
+// GetRunbook returns the runbook of the workspace, it is generated on the first request
+// and then refreshed after each completed backup
+func (s *RunbookService) GetRunbook(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*DRRunbook, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view runbook of this workspace")
+	}
+
+	runbook, err := s.runbookRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if runbook != nil {
+		return runbook, nil
+	}
+
+	return s.RefreshRunbook(workspaceID)
+}
+
+// RefreshRunbook regenerates the runbook of the workspace from its databases and their
+// latest completed backups
+func (s *RunbookService) RefreshRunbook(workspaceID uuid.UUID) (*DRRunbook, error) {
+	workspace, err := s.workspaceService.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseIDs := make([]uuid.UUID, 0, len(workspaceDatabases))
+	for _, database := range workspaceDatabases {
+		databaseIDs = append(databaseIDs, database.ID)
+	}
+
+	lastBackups, err := s.backupRepository.FindLastCompletedByDatabaseIDs(databaseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	storagesByID := make(map[uuid.UUID]*storages.Storage)
+	runbookDatabases := make([]RunbookDatabase, 0, len(workspaceDatabases))
+
+	for _, database := range workspaceDatabases {
+		backup := lastBackups[database.ID]
+
+		var storage *storages.Storage
+		if backup != nil {
+			storage = storagesByID[backup.StorageID]
+
+			if storage == nil {
+				storage, err = s.storageService.GetStorageByID(backup.StorageID)
+				if err != nil {
+					// The storage may be removed after the backup, the runbook still shows
+					// the backup exists
+					s.logger.Warn(
+						"Failed to get storage of backup for runbook",
+						"backupId", backup.ID,
+						"error", err,
+					)
+				} else {
+					storagesByID[backup.StorageID] = storage
+				}
+			}
+		}
+
+		runbookDatabases = append(runbookDatabases, buildRunbookDatabase(database, backup, storage))
+	}
+
+	runbook := &DRRunbook{
+		WorkspaceID:   workspaceID,
+		WorkspaceName: workspace.Name,
+		Databases:     runbookDatabases,
+		GeneratedAt:   time.Now().UTC(),
+	}
+
+	if err := s.runbookRepository.Save(runbook); err != nil {
+		return nil, err
+	}
+
+	return runbook, nil
+}
+
+func (s *RunbookService) OnBackupCompleted(backup *backups_core.Backup) {
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		s.logger.Error("Failed to get database of backup for runbook", "error", err)
+		return
+	}
+
+	if database.WorkspaceID == nil {
+		return
+	}
+
+	if _, err := s.RefreshRunbook(*database.WorkspaceID); err != nil {
+		s.logger.Error(
+			"Failed to refresh runbook",
+			"workspaceId", *database.WorkspaceID,
+			"error", err,
+		)
+	}
+}
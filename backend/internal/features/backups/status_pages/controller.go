@@ -0,0 +1,186 @@
+package backups_status_pages
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StatusPageController struct {
+	statusPageService *StatusPageService
+}
+
+func (c *StatusPageController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/status-pages/workspace/:workspaceId", c.GetStatusPage)
+	router.PUT("/status-pages/workspace/:workspaceId", c.SaveStatusPage)
+	router.DELETE("/status-pages/workspace/:workspaceId", c.DeleteStatusPage)
+	router.POST("/status-pages/workspace/:workspaceId/rotate-token", c.RotateToken)
+}
+
+// RegisterPublicRoutes exposes status pages without auth, they are opened by the page token
+func (c *StatusPageController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.GET("/status-pages/public/:token", c.GetPublicStatusPage)
+}
+
+// GetStatusPage
+// @Summary Get status page
+// @Description Get settings of the public status page of a workspace, null when there is no page
+// @Tags status-pages
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} StatusPage
+// @Failure 400
+// @Failure 401
+// @Router /status-pages/workspace/{workspaceId} [get]
+func (c *StatusPageController) GetStatusPage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	statusPage, err := c.statusPageService.GetStatusPage(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statusPage)
+}
+
+// SaveStatusPage
+// @Summary Save status page
+// @Description Create or update the public status page of a workspace, the token is returned only when the page is created
+// @Tags status-pages
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body SaveStatusPageRequest true "Status page settings"
+// @Success 200 {object} StatusPageTokenResponse
+// @Failure 400
+// @Failure 401
+// @Router /status-pages/workspace/{workspaceId} [put]
+func (c *StatusPageController) SaveStatusPage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request SaveStatusPageRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.statusPageService.SaveStatusPage(user, workspaceID, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteStatusPage
+// @Summary Delete status page
+// @Description Delete the public status page of a workspace, its link stops working
+// @Tags status-pages
+// @Param workspaceId path string true "Workspace ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /status-pages/workspace/{workspaceId} [delete]
+func (c *StatusPageController) DeleteStatusPage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	if err := c.statusPageService.DeleteStatusPage(user, workspaceID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RotateToken
+// @Summary Rotate status page token
+// @Description Generate a new token of the status page, links with the previous token stop working
+// @Tags status-pages
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} StatusPageTokenResponse
+// @Failure 400
+// @Failure 401
+// @Router /status-pages/workspace/{workspaceId}/rotate-token [post]
+func (c *StatusPageController) RotateToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	response, err := c.statusPageService.RotateToken(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetPublicStatusPage
+// @Summary Get public status page
+// @Description Get backup health of databases of a workspace, opened by the status page token without auth
+// @Tags status-pages
+// @Produce json
+// @Param token path string true "Status page token"
+// @Success 200 {object} PublicStatusPage
+// @Failure 404
+// @Router /status-pages/public/{token} [get]
+func (c *StatusPageController) GetPublicStatusPage(ctx *gin.Context) {
+	page, err := c.statusPageService.GetPublicStatusPage(ctx.Param("token"), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, ErrStatusPageNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
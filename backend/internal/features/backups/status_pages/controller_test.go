@@ -0,0 +1,167 @@
+package backups_status_pages
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetStatusPageController(),
+	)
+
+	v1 := router.Group("/api/v1")
+	GetStatusPageController().RegisterPublicRoutes(v1)
+
+	return router
+}
+
+func Test_GetPublicStatusPage_WhenAnonymized_HealthShownWithoutDatabaseNames(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	// From the oldest, the failure is before the success streak
+	now := time.Now().UTC()
+	statuses := []backups_core.BackupStatus{
+		backups_core.BackupStatusFailed,
+		backups_core.BackupStatusCompleted,
+		backups_core.BackupStatusCompleted,
+	}
+	for i, status := range statuses {
+		createdAt := now.Add(-time.Duration(len(statuses)-i) * time.Hour)
+		createBackup(t, database.ID, storage.ID, status, createdAt)
+	}
+
+	var saveResponse StatusPageTokenResponse
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/status-pages/workspace/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		SaveStatusPageRequest{Title: "Client backups", IsAnonymized: true},
+		http.StatusOK,
+		&saveResponse,
+	)
+	assert.NotEmpty(t, saveResponse.Token)
+
+	response := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/status-pages/public/"+saveResponse.Token,
+		"",
+		http.StatusOK,
+	)
+	assert.NotContains(t, string(response.Body), database.Name)
+
+	var page PublicStatusPage
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/status-pages/public/"+saveResponse.Token,
+		"",
+		http.StatusOK,
+		&page,
+	)
+
+	assert.Equal(t, "Client backups", page.Title)
+	assert.Len(t, page.Databases, 1)
+	assert.Equal(t, "Database 1", page.Databases[0].Label)
+	assert.Equal(t, DatabaseHealthHealthy, page.Databases[0].Health)
+	assert.Equal(t, 2, page.Databases[0].SuccessStreak)
+	assert.Equal(t, 0, page.Databases[0].FailureStreak)
+	assert.NotNil(t, page.Databases[0].LastSuccessAt)
+
+	// Updates keep the token, labels replace numbers
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/status-pages/workspace/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		SaveStatusPageRequest{
+			Title:          "Client backups",
+			IsAnonymized:   true,
+			DatabaseLabels: map[uuid.UUID]string{database.ID: "Primary"},
+		},
+		http.StatusOK,
+		&StatusPageTokenResponse{},
+	)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/status-pages/public/"+saveResponse.Token,
+		"",
+		http.StatusOK,
+		&page,
+	)
+	assert.Equal(t, "Primary", page.Databases[0].Label)
+
+	var rotateResponse StatusPageTokenResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/status-pages/workspace/"+workspace.ID.String()+"/rotate-token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&rotateResponse,
+	)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/status-pages/public/"+saveResponse.Token,
+		"",
+		http.StatusNotFound,
+	)
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/status-pages/public/"+rotateResponse.Token,
+		"",
+		http.StatusOK,
+	)
+}
+
+func createBackup(
+	t *testing.T,
+	databaseID uuid.UUID,
+	storageID uuid.UUID,
+	status backups_core.BackupStatus,
+	createdAt time.Time,
+) {
+	err := (&backups_core.BackupRepository{}).Save(&backups_core.Backup{
+		ID:         uuid.New(),
+		DatabaseID: databaseID,
+		StorageID:  storageID,
+		Status:     status,
+		CreatedAt:  createdAt,
+	})
+	assert.NoError(t, err)
+}
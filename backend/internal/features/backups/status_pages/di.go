@@ -0,0 +1,28 @@
+package backups_status_pages
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var statusPageRepository = &StatusPageRepository{}
+var statusPageService = &StatusPageService{
+	statusPageRepository,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+}
+var statusPageController = &StatusPageController{
+	statusPageService,
+}
+
+func GetStatusPageService() *StatusPageService {
+	return statusPageService
+}
+
+func GetStatusPageController() *StatusPageController {
+	return statusPageController
+}
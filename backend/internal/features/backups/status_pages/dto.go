@@ -0,0 +1,37 @@
+package backups_status_pages
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SaveStatusPageRequest struct {
+	Title          string               `json:"title"          binding:"required"`
+	IsAnonymized   bool                 `json:"isAnonymized"`
+	DatabaseLabels map[uuid.UUID]string `json:"databaseLabels"`
+}
+
+// StatusPageTokenResponse has the token of the page, it is returned only when the token
+// is generated
+type StatusPageTokenResponse struct {
+	StatusPage *StatusPage `json:"statusPage"`
+	Token      string      `json:"token"`
+}
+
+type PublicStatusPage struct {
+	Title       string                 `json:"title"`
+	GeneratedAt time.Time              `json:"generatedAt"`
+	Databases   []PublicDatabaseStatus `json:"databases"`
+}
+
+// PublicDatabaseStatus is computed from the latest finished backups, successes older than
+// them are not reported
+type PublicDatabaseStatus struct {
+	Label                 string         `json:"label"`
+	Health                DatabaseHealth `json:"health"`
+	LastSuccessAt         *time.Time     `json:"lastSuccessAt"`
+	LastSuccessAgeSeconds *int64         `json:"lastSuccessAgeSeconds"`
+	SuccessStreak         int            `json:"successStreak"`
+	FailureStreak         int            `json:"failureStreak"`
+}
@@ -0,0 +1,9 @@
+package backups_status_pages
+
+type DatabaseHealth string
+
+const (
+	DatabaseHealthHealthy   DatabaseHealth = "HEALTHY"
+	DatabaseHealthFailing   DatabaseHealth = "FAILING"
+	DatabaseHealthNoBackups DatabaseHealth = "NO_BACKUPS"
+)
@@ -0,0 +1,74 @@
+package backups_status_pages
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusPage is a public page with backup health of databases of a workspace, opened by a
+// token. When it is anonymized, databases are shown by their labels or by numbers instead
+// of names
+type StatusPage struct {
+	ID           uuid.UUID `json:"id"           gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID  uuid.UUID `json:"workspaceId"  gorm:"column:workspace_id;type:uuid;not null"`
+	Title        string    `json:"title"        gorm:"column:title;type:text;not null"`
+	IsAnonymized bool      `json:"isAnonymized" gorm:"column:is_anonymized;type:boolean;not null"`
+
+	// DatabaseLabels replace names of databases on the page, the page shows only labelled
+	// databases if there are labels
+	DatabaseLabels     map[uuid.UUID]string `json:"databaseLabels" gorm:"-"`
+	DatabaseLabelsJSON string               `json:"-"              gorm:"column:database_labels;type:text;not null"`
+
+	// TokenHash is sha256 of the token, the token is shown only when it is generated
+	TokenHash      string    `json:"-"              gorm:"column:token_hash;type:text;not null"`
+	TokenRotatedAt time.Time `json:"tokenRotatedAt" gorm:"column:token_rotated_at"`
+	CreatedAt      time.Time `json:"createdAt"      gorm:"column:created_at"`
+}
+
+func (StatusPage) TableName() string {
+	return "status_pages"
+}
+
+func (p *StatusPage) BeforeSave(tx *gorm.DB) error {
+	labels := p.DatabaseLabels
+	if labels == nil {
+		labels = map[uuid.UUID]string{}
+	}
+
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+
+	p.DatabaseLabelsJSON = string(labelsJSON)
+
+	return nil
+}
+
+func (p *StatusPage) AfterFind(tx *gorm.DB) error {
+	p.DatabaseLabels = map[uuid.UUID]string{}
+	if p.DatabaseLabelsJSON == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(p.DatabaseLabelsJSON), &p.DatabaseLabels)
+}
+
+func (p *StatusPage) Validate() error {
+	if strings.TrimSpace(p.Title) == "" {
+		return errors.New("status page title is required")
+	}
+
+	for _, label := range p.DatabaseLabels {
+		if strings.TrimSpace(label) == "" {
+			return errors.New("database labels cannot be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package backups_status_pages
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StatusPageRepository struct{}
+
+func (r *StatusPageRepository) Save(statusPage *StatusPage) error {
+	return storage.GetDb().Save(statusPage).Error
+}
+
+func (r *StatusPageRepository) FindByWorkspaceID(workspaceID uuid.UUID) (*StatusPage, error) {
+	return r.findBy("workspace_id = ?", workspaceID)
+}
+
+func (r *StatusPageRepository) FindByTokenHash(tokenHash string) (*StatusPage, error) {
+	return r.findBy("token_hash = ?", tokenHash)
+}
+
+func (r *StatusPageRepository) Delete(statusPage *StatusPage) error {
+	return storage.GetDb().Delete(&StatusPage{}, "id = ?", statusPage.ID).Error
+}
+
+func (r *StatusPageRepository) findBy(query string, value any) (*StatusPage, error) {
+	var statusPage StatusPage
+
+	if err := storage.
+		GetDb().
+		Where(query, value).
+		First(&statusPage).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &statusPage, nil
+}
@@ -0,0 +1,340 @@
+package backups_status_pages
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	statusPageTokenPrefix = "dsp_"
+
+	// latestBackupsLimit bounds streaks and the last success shown on the page
+	latestBackupsLimit = 50
+)
+
+var ErrStatusPageNotFound = errors.New("status page not found")
+
+type StatusPageService struct {
+	statusPageRepository *StatusPageRepository
+	backupRepository     *backups_core.BackupRepository
+	databaseService      *databases.DatabaseService
+	workspaceService     *workspaces_services.WorkspaceService
+	auditLogService      *audit_logs.AuditLogService
+}
+
+func (s *StatusPageService) GetStatusPage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*StatusPage, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view status page")
+	}
+
+	return s.statusPageRepository.FindByWorkspaceID(workspaceID)
+}
+
+// SaveStatusPage creates the status page of the workspace or updates it. The token is
+// returned only when the page is created
+func (s *StatusPageService) SaveStatusPage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	request *SaveStatusPageRequest,
+) (*StatusPageTokenResponse, error) {
+	if err := s.checkCanManage(user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for databaseID := range request.DatabaseLabels {
+		isInWorkspace := slices.ContainsFunc(
+			workspaceDatabases,
+			func(database *databases.Database) bool { return database.ID == databaseID },
+		)
+		if !isInWorkspace {
+			return nil, fmt.Errorf("database %s is not in this workspace", databaseID)
+		}
+	}
+
+	statusPage, err := s.statusPageRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StatusPageTokenResponse{}
+	if statusPage == nil {
+		now := time.Now().UTC()
+		statusPage = &StatusPage{
+			ID:             uuid.New(),
+			WorkspaceID:    workspaceID,
+			TokenRotatedAt: now,
+			CreatedAt:      now,
+		}
+
+		token, err := generateStatusPageToken()
+		if err != nil {
+			return nil, err
+		}
+
+		statusPage.TokenHash = hashStatusPageToken(token)
+		response.Token = token
+	}
+
+	statusPage.Title = request.Title
+	statusPage.IsAnonymized = request.IsAnonymized
+	statusPage.DatabaseLabels = request.DatabaseLabels
+
+	if err := statusPage.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.statusPageRepository.Save(statusPage); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Status page saved: %s", statusPage.Title),
+		&user.ID,
+		&workspaceID,
+	)
+
+	response.StatusPage = statusPage
+
+	return response, nil
+}
+
+// RotateToken replaces the token, links with the previous token stop working
+func (s *StatusPageService) RotateToken(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*StatusPageTokenResponse, error) {
+	if err := s.checkCanManage(user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	statusPage, err := s.statusPageRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if statusPage == nil {
+		return nil, ErrStatusPageNotFound
+	}
+
+	token, err := generateStatusPageToken()
+	if err != nil {
+		return nil, err
+	}
+
+	statusPage.TokenHash = hashStatusPageToken(token)
+	statusPage.TokenRotatedAt = time.Now().UTC()
+
+	if err := s.statusPageRepository.Save(statusPage); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Status page token rotated: %s", statusPage.Title),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return &StatusPageTokenResponse{StatusPage: statusPage, Token: token}, nil
+}
+
+func (s *StatusPageService) DeleteStatusPage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	if err := s.checkCanManage(user, workspaceID); err != nil {
+		return err
+	}
+
+	statusPage, err := s.statusPageRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+	if statusPage == nil {
+		return ErrStatusPageNotFound
+	}
+
+	if err := s.statusPageRepository.Delete(statusPage); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Status page deleted: %s", statusPage.Title),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+// GetPublicStatusPage summarizes backup health of databases of the page opened by the token
+func (s *StatusPageService) GetPublicStatusPage(
+	token string,
+	now time.Time,
+) (*PublicStatusPage, error) {
+	statusPage, err := s.statusPageRepository.FindByTokenHash(hashStatusPageToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if statusPage == nil {
+		return nil, ErrStatusPageNotFound
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(statusPage.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Databases are ordered by ID rather than by name or health, so numbers of anonymized
+	// databases stay the same between page loads and do not hint at names
+	slices.SortFunc(workspaceDatabases, func(a, b *databases.Database) int {
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+
+	page := &PublicStatusPage{
+		Title:       statusPage.Title,
+		GeneratedAt: now,
+		Databases:   []PublicDatabaseStatus{},
+	}
+
+	for _, database := range workspaceDatabases {
+		label, isShown := s.getDatabaseLabel(statusPage, database, len(page.Databases)+1)
+		if !isShown {
+			continue
+		}
+
+		databaseStatus, err := s.getDatabaseStatus(database.ID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		databaseStatus.Label = label
+		page.Databases = append(page.Databases, *databaseStatus)
+	}
+
+	return page, nil
+}
+
+// getDatabaseLabel shows only labelled databases when the page has labels, otherwise all
+// databases by name or, on anonymized pages, by number
+func (s *StatusPageService) getDatabaseLabel(
+	statusPage *StatusPage,
+	database *databases.Database,
+	number int,
+) (string, bool) {
+	if len(statusPage.DatabaseLabels) > 0 {
+		label, isLabelled := statusPage.DatabaseLabels[database.ID]
+		return label, isLabelled
+	}
+
+	if statusPage.IsAnonymized {
+		return fmt.Sprintf("Database %d", number), true
+	}
+
+	return database.Name, true
+}
+
+func (s *StatusPageService) getDatabaseStatus(
+	databaseID uuid.UUID,
+	now time.Time,
+) (*PublicDatabaseStatus, error) {
+	backups, err := s.backupRepository.FindByDatabaseIDWithLimit(databaseID, latestBackupsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &PublicDatabaseStatus{Health: DatabaseHealthNoBackups}
+	isStreakOver := false
+
+	// Backups are ordered from the latest, the first finished one sets the health
+	for _, backup := range backups {
+		if backup.Status != backups_core.BackupStatusCompleted &&
+			backup.Status != backups_core.BackupStatusFailed {
+			continue
+		}
+
+		isCompleted := backup.Status == backups_core.BackupStatusCompleted
+
+		if status.Health == DatabaseHealthNoBackups {
+			status.Health = DatabaseHealthFailing
+			if isCompleted {
+				status.Health = DatabaseHealthHealthy
+			}
+		}
+
+		if !isStreakOver {
+			switch {
+			case isCompleted && status.Health == DatabaseHealthHealthy:
+				status.SuccessStreak++
+			case !isCompleted && status.Health == DatabaseHealthFailing:
+				status.FailureStreak++
+			default:
+				isStreakOver = true
+			}
+		}
+
+		if isCompleted && status.LastSuccessAt == nil {
+			lastSuccessAt := backup.CreatedAt
+			lastSuccessAge := int64(now.Sub(lastSuccessAt).Seconds())
+			status.LastSuccessAt = &lastSuccessAt
+			status.LastSuccessAgeSeconds = &lastSuccessAge
+		}
+
+		if isStreakOver && status.LastSuccessAt != nil {
+			break
+		}
+	}
+
+	return status, nil
+}
+
+func (s *StatusPageService) checkCanManage(user *users_models.User, workspaceID uuid.UUID) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to manage status page")
+	}
+
+	return nil
+}
+
+func generateStatusPageToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate status page token: %w", err)
+	}
+
+	return statusPageTokenPrefix + hex.EncodeToString(randomBytes), nil
+}
+
+// hashStatusPageToken uses plain sha256 like agent tokens, the tokens are random and long
+func hashStatusPageToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
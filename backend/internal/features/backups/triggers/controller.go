@@ -0,0 +1,203 @@
+package backups_triggers
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BackupTriggerController struct {
+	backupTriggerService *BackupTriggerService
+}
+
+func (c *BackupTriggerController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/backup-triggers/database/:databaseId", c.GetBackupTrigger)
+	router.POST("/backup-triggers/database/:databaseId/token", c.GenerateToken)
+	router.DELETE("/backup-triggers/database/:databaseId", c.DeleteBackupTrigger)
+}
+
+// RegisterPublicRoutes exposes the webhook without auth, it is opened by the trigger token
+func (c *BackupTriggerController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.POST("/backup-triggers/public/:token", c.TriggerBackup)
+	router.POST("/backup-triggers/public/:token/gate", c.CheckDeploymentGate)
+}
+
+// GetBackupTrigger
+// @Summary Get backup trigger
+// @Description Get the webhook trigger of a database, null when there is no trigger
+// @Tags backup-triggers
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Success 200 {object} BackupTrigger
+// @Failure 400
+// @Failure 401
+// @Router /backup-triggers/database/{databaseId} [get]
+func (c *BackupTriggerController) GetBackupTrigger(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	trigger, err := c.backupTriggerService.GetBackupTrigger(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, trigger)
+}
+
+// GenerateToken
+// @Summary Generate backup trigger token
+// @Description Create the webhook trigger of a database or replace its token, calls with the previous token are rejected
+// @Tags backup-triggers
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Success 200 {object} BackupTriggerTokenResponse
+// @Failure 400
+// @Failure 401
+// @Router /backup-triggers/database/{databaseId}/token [post]
+func (c *BackupTriggerController) GenerateToken(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	response, err := c.backupTriggerService.GenerateToken(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteBackupTrigger
+// @Summary Delete backup trigger
+// @Description Delete the webhook trigger of a database, calls with its token are rejected
+// @Tags backup-triggers
+// @Param databaseId path string true "Database ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /backup-triggers/database/{databaseId} [delete]
+func (c *BackupTriggerController) DeleteBackupTrigger(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	if err := c.backupTriggerService.DeleteBackupTrigger(user, databaseID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TriggerBackup
+// @Summary Trigger backup
+// @Description Start a backup of the database of the trigger token without auth, e.g. from a CI pipeline before a migration. The body is optional
+// @Tags backup-triggers
+// @Accept json
+// @Param token path string true "Backup trigger token"
+// @Param request body TriggerBackupRequest false "Backup tags and reason"
+// @Success 202
+// @Failure 400
+// @Failure 404
+// @Failure 429
+// @Router /backup-triggers/public/{token} [post]
+func (c *BackupTriggerController) TriggerBackup(ctx *gin.Context) {
+	var request TriggerBackupRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := c.backupTriggerService.TriggerBackup(ctx.Param("token"), &request); err != nil {
+		switch {
+		case errors.Is(err, ErrBackupTriggerNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, ErrBackupTriggerRateLimited):
+			ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+// CheckDeploymentGate
+// @Summary Check deployment gate
+// @Description Tell a deployment pipeline whether the database of the trigger token had a completed backup within maxAgeMinutes, without auth. A stale database can get a backup triggered and the call can wait for it up to waitTimeoutSeconds. Returns 200 when the gate passes and 412 otherwise, so pipelines can fail on the status code
+// @Tags backup-triggers
+// @Accept json
+// @Produce json
+// @Param token path string true "Backup trigger token"
+// @Param request body CheckDeploymentGateRequest true "Gate settings"
+// @Success 200 {object} DeploymentGateResponse
+// @Failure 400
+// @Failure 404
+// @Failure 412 {object} DeploymentGateResponse
+// @Failure 429
+// @Router /backup-triggers/public/{token}/gate [post]
+func (c *BackupTriggerController) CheckDeploymentGate(ctx *gin.Context) {
+	var request CheckDeploymentGateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.backupTriggerService.CheckDeploymentGate(
+		ctx.Request.Context(),
+		ctx.Param("token"),
+		&request,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrBackupTriggerNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, ErrBackupTriggerRateLimited):
+			ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if !response.IsPassed {
+		ctx.JSON(http.StatusPreconditionFailed, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
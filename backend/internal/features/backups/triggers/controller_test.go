@@ -0,0 +1,170 @@
+package backups_triggers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetBackupTriggerController(),
+	)
+
+	v1 := router.Group("/api/v1")
+	GetBackupTriggerController().RegisterPublicRoutes(v1)
+
+	return router
+}
+
+func Test_TriggerBackup_WhenTokenRotated_PreviousTokenRejected(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	var tokenResponse BackupTriggerTokenResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-triggers/database/"+database.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&tokenResponse,
+	)
+	assert.NotEmpty(t, tokenResponse.Token)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-triggers/public/"+tokenResponse.Token,
+		"",
+		TriggerBackupRequest{Tags: []string{"pre-migration"}, Reason: "deploy 42"},
+		http.StatusAccepted,
+	)
+
+	var trigger BackupTrigger
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-triggers/database/"+database.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&trigger,
+	)
+	assert.NotNil(t, trigger.LastTriggeredAt)
+
+	// The previous token stops working once it is replaced
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-triggers/database/"+database.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+	)
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-triggers/public/"+tokenResponse.Token,
+		"",
+		nil,
+		http.StatusNotFound,
+	)
+}
+
+func Test_GenerateToken_WhenUserIsNotWorkspaceMember_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-triggers/database/"+database.ID.String()+"/token",
+		"Bearer "+outsider.Token,
+		nil,
+		http.StatusBadRequest,
+	)
+}
+
+func Test_CheckDeploymentGate_WhenNoBackupExists_GateFails(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	var tokenResponse BackupTriggerTokenResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-triggers/database/"+database.ID.String()+"/token",
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&tokenResponse,
+	)
+
+	var gateResponse DeploymentGateResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-triggers/public/"+tokenResponse.Token+"/gate",
+		"",
+		CheckDeploymentGateRequest{MaxAgeMinutes: 60},
+		http.StatusPreconditionFailed,
+		&gateResponse,
+	)
+	assert.False(t, gateResponse.IsPassed)
+	assert.False(t, gateResponse.IsBackupTriggered)
+	assert.Nil(t, gateResponse.LastBackupAt)
+	assert.Equal(t, database.ID, gateResponse.DatabaseID)
+
+	// Waiting without triggering a backup could never pass
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-triggers/public/"+tokenResponse.Token+"/gate",
+		"",
+		CheckDeploymentGateRequest{MaxAgeMinutes: 60, WaitTimeoutSeconds: 30},
+		http.StatusBadRequest,
+	)
+}
@@ -0,0 +1,34 @@
+package backups_triggers
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/logger"
+)
+
+var backupTriggerRepository = &BackupTriggerRepository{}
+var backupTriggerService = &BackupTriggerService{
+	backupTriggerRepository,
+	&backups_core.BackupRepository{},
+	backuping.GetBackupsScheduler(),
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	cache_utils.NewRateLimiter(cache_utils.GetValkeyClient()),
+	logger.GetLogger(),
+}
+var backupTriggerController = &BackupTriggerController{
+	backupTriggerService,
+}
+
+func GetBackupTriggerService() *BackupTriggerService {
+	return backupTriggerService
+}
+
+func GetBackupTriggerController() *BackupTriggerController {
+	return backupTriggerController
+}
@@ -0,0 +1,70 @@
+package backups_triggers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxDeploymentGateMaxAgeMinutes = 7 * 24 * 60
+	maxDeploymentGateWaitSeconds   = 30 * 60
+)
+
+// BackupTriggerTokenResponse has the token of the trigger, it is returned only when the
+// token is generated
+type BackupTriggerTokenResponse struct {
+	BackupTrigger *BackupTrigger `json:"backupTrigger"`
+	Token         string         `json:"token"`
+}
+
+// TriggerBackupRequest is the optional body of a webhook call. Tags are set on the backup
+// for retention holds, reason is written to the audit log, e.g. a pipeline URL
+type TriggerBackupRequest struct {
+	Tags   []string `json:"tags"`
+	Reason string   `json:"reason"`
+}
+
+// CheckDeploymentGateRequest asks whether the database had a completed backup within
+// MaxAgeMinutes. A stale database gets a backup when IsTriggeringIfStale is set, and the
+// call waits up to WaitTimeoutSeconds for it to complete
+type CheckDeploymentGateRequest struct {
+	MaxAgeMinutes       int  `json:"maxAgeMinutes"`
+	IsTriggeringIfStale bool `json:"isTriggeringIfStale"`
+	WaitTimeoutSeconds  int  `json:"waitTimeoutSeconds"`
+}
+
+func (r *CheckDeploymentGateRequest) Validate() error {
+	if r.MaxAgeMinutes < 1 || r.MaxAgeMinutes > maxDeploymentGateMaxAgeMinutes {
+		return fmt.Errorf(
+			"max age must be between 1 and %d minutes",
+			maxDeploymentGateMaxAgeMinutes,
+		)
+	}
+
+	if r.WaitTimeoutSeconds < 0 || r.WaitTimeoutSeconds > maxDeploymentGateWaitSeconds {
+		return fmt.Errorf(
+			"wait timeout must be between 0 and %d seconds",
+			maxDeploymentGateWaitSeconds,
+		)
+	}
+
+	if r.WaitTimeoutSeconds > 0 && !r.IsTriggeringIfStale {
+		return fmt.Errorf("wait timeout requires triggering a backup if stale")
+	}
+
+	return nil
+}
+
+type DeploymentGateResponse struct {
+	IsPassed      bool       `json:"isPassed"`
+	DatabaseID    uuid.UUID  `json:"databaseId"`
+	DatabaseName  string     `json:"databaseName"`
+	MaxAgeMinutes int        `json:"maxAgeMinutes"`
+	LastBackupID  *uuid.UUID `json:"lastBackupId"`
+	LastBackupAt  *time.Time `json:"lastBackupAt"`
+
+	IsBackupTriggered          bool    `json:"isBackupTriggered"`
+	TriggeredBackupFailMessage *string `json:"triggeredBackupFailMessage"`
+}
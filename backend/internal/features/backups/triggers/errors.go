@@ -0,0 +1,13 @@
+package backups_triggers
+
+import "errors"
+
+var (
+	ErrBackupTriggerNotFound    = errors.New("backup trigger not found")
+	ErrBackupTriggerRateLimited = errors.New(
+		"too many backups triggered for this database, try again later",
+	)
+	ErrInsufficientPermissionsToManageTrigger = errors.New(
+		"insufficient permissions to manage backup trigger",
+	)
+)
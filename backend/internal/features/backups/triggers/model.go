@@ -0,0 +1,24 @@
+package backups_triggers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupTrigger is an inbound webhook of a database, external systems like CI pipelines
+// call it with the token to start a backup right away, e.g. before a risky migration
+type BackupTrigger struct {
+	ID         uuid.UUID `json:"id"         gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;not null"`
+
+	// TokenHash is sha256 of the token, the token is shown only when it is generated
+	TokenHash       string     `json:"-"               gorm:"column:token_hash;type:text;not null"`
+	TokenRotatedAt  time.Time  `json:"tokenRotatedAt"  gorm:"column:token_rotated_at"`
+	LastTriggeredAt *time.Time `json:"lastTriggeredAt" gorm:"column:last_triggered_at"`
+	CreatedAt       time.Time  `json:"createdAt"       gorm:"column:created_at"`
+}
+
+func (BackupTrigger) TableName() string {
+	return "backup_triggers"
+}
@@ -0,0 +1,53 @@
+package backups_triggers
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupTriggerRepository struct{}
+
+func (r *BackupTriggerRepository) Save(trigger *BackupTrigger) error {
+	return storage.GetDb().Save(trigger).Error
+}
+
+func (r *BackupTriggerRepository) FindByDatabaseID(databaseID uuid.UUID) (*BackupTrigger, error) {
+	return r.findBy("database_id = ?", databaseID)
+}
+
+func (r *BackupTriggerRepository) FindByTokenHash(tokenHash string) (*BackupTrigger, error) {
+	return r.findBy("token_hash = ?", tokenHash)
+}
+
+func (r *BackupTriggerRepository) UpdateLastTriggeredAt(id uuid.UUID, triggeredAt time.Time) error {
+	return storage.GetDb().
+		Model(&BackupTrigger{}).
+		Where("id = ?", id).
+		Update("last_triggered_at", triggeredAt).
+		Error
+}
+
+func (r *BackupTriggerRepository) Delete(trigger *BackupTrigger) error {
+	return storage.GetDb().Delete(&BackupTrigger{}, "id = ?", trigger.ID).Error
+}
+
+func (r *BackupTriggerRepository) findBy(query string, value any) (*BackupTrigger, error) {
+	var trigger BackupTrigger
+
+	if err := storage.
+		GetDb().
+		Where(query, value).
+		First(&trigger).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &trigger, nil
+}
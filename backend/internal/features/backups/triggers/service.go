@@ -0,0 +1,384 @@
+package backups_triggers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
+
+	"github.com/google/uuid"
+)
+
+const (
+	backupTriggerTokenPrefix = "dbt_"
+
+	// maxTriggersPerHour bounds backups started by a misbehaving pipeline calling in a loop
+	maxTriggersPerHour = 6
+
+	maxTriggerReasonLength = 500
+
+	deploymentGatePollInterval = 5 * time.Second
+)
+
+type BackupTriggerService struct {
+	backupTriggerRepository *BackupTriggerRepository
+	backupRepository        *backups_core.BackupRepository
+	backupsScheduler        *backuping.BackupsScheduler
+	databaseService         *databases.DatabaseService
+	workspaceService        *workspaces_services.WorkspaceService
+	auditLogService         *audit_logs.AuditLogService
+	rateLimiter             *cache_utils.RateLimiter
+	logger                  *slog.Logger
+}
+
+func (s *BackupTriggerService) GetBackupTrigger(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*BackupTrigger, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, ErrInsufficientPermissionsToManageTrigger
+	}
+
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToManageTrigger
+	}
+
+	return s.backupTriggerRepository.FindByDatabaseID(databaseID)
+}
+
+// GenerateToken creates the trigger of the database or replaces its token, callers with
+// the previous token are rejected
+func (s *BackupTriggerService) GenerateToken(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*BackupTriggerTokenResponse, error) {
+	database, err := s.getManagedDatabase(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger, err := s.backupTriggerRepository.FindByDatabaseID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if trigger == nil {
+		trigger = &BackupTrigger{
+			ID:         uuid.New(),
+			DatabaseID: databaseID,
+			CreatedAt:  now,
+		}
+	}
+
+	token, err := generateBackupTriggerToken()
+	if err != nil {
+		return nil, err
+	}
+
+	trigger.TokenHash = hashBackupTriggerToken(token)
+	trigger.TokenRotatedAt = now
+
+	if err := s.backupTriggerRepository.Save(trigger); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup trigger token generated for database: %s", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return &BackupTriggerTokenResponse{BackupTrigger: trigger, Token: token}, nil
+}
+
+func (s *BackupTriggerService) DeleteBackupTrigger(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) error {
+	database, err := s.getManagedDatabase(user, databaseID)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := s.backupTriggerRepository.FindByDatabaseID(databaseID)
+	if err != nil {
+		return err
+	}
+	if trigger == nil {
+		return ErrBackupTriggerNotFound
+	}
+
+	if err := s.backupTriggerRepository.Delete(trigger); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup trigger deleted for database: %s", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+// TriggerBackup starts a backup of the database of the token. The backup runs in the
+// background like a manual one, it is skipped when a backup is already in progress
+func (s *BackupTriggerService) TriggerBackup(token string, request *TriggerBackupRequest) error {
+	if err := backups_core.ValidateBackupTags(request.Tags); err != nil {
+		return err
+	}
+
+	reason := strings.TrimSpace(request.Reason)
+	if len(reason) > maxTriggerReasonLength {
+		return fmt.Errorf("reason cannot be longer than %d characters", maxTriggerReasonLength)
+	}
+
+	trigger, err := s.findTriggerByToken(token)
+	if err != nil {
+		return err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(trigger.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Backup triggered by webhook for database: %s", database.Name)
+	if reason != "" {
+		message += fmt.Sprintf(" (reason: %s)", reason)
+	}
+
+	return s.startTriggeredBackup(trigger, database, request.Tags, message)
+}
+
+// CheckDeploymentGate tells a deployment pipeline whether the database of the token has a
+// completed backup started within the max age. A stale database can get a backup triggered
+// and the call can wait for it, so deploys are blocked only while the backup is not done
+func (s *BackupTriggerService) CheckDeploymentGate(
+	ctx context.Context,
+	token string,
+	request *CheckDeploymentGateRequest,
+) (*DeploymentGateResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	trigger, err := s.findTriggerByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(trigger.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.evaluateDeploymentGate(database, request.MaxAgeMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.IsPassed || !request.IsTriggeringIfStale {
+		return response, nil
+	}
+
+	triggeredAt := time.Now().UTC()
+	if err := s.startTriggeredBackup(
+		trigger,
+		database,
+		nil,
+		fmt.Sprintf("Backup triggered by deployment gate for database: %s", database.Name),
+	); err != nil {
+		return nil, err
+	}
+	response.IsBackupTriggered = true
+
+	if request.WaitTimeoutSeconds == 0 {
+		return response, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(
+		ctx,
+		time.Duration(request.WaitTimeoutSeconds)*time.Second,
+	)
+	defer cancel()
+
+	ticker := time.NewTicker(deploymentGatePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			return response, nil
+		case <-ticker.C:
+		}
+
+		waitedResponse, err := s.evaluateDeploymentGate(database, request.MaxAgeMinutes)
+		if err != nil {
+			return nil, err
+		}
+		waitedResponse.IsBackupTriggered = true
+
+		if waitedResponse.IsPassed {
+			return waitedResponse, nil
+		}
+
+		// the triggered backup failed, waiting longer would only delay the pipeline
+		lastBackup, err := s.backupRepository.FindLastByDatabaseID(database.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastBackup != nil && !lastBackup.CreatedAt.Before(triggeredAt) &&
+			(lastBackup.Status == backups_core.BackupStatusFailed ||
+				lastBackup.Status == backups_core.BackupStatusCanceled) {
+			waitedResponse.TriggeredBackupFailMessage = lastBackup.FailMessage
+			return waitedResponse, nil
+		}
+
+		response = waitedResponse
+	}
+}
+
+func (s *BackupTriggerService) evaluateDeploymentGate(
+	database *databases.Database,
+	maxAgeMinutes int,
+) (*DeploymentGateResponse, error) {
+	lastBackups, err := s.backupRepository.FindLastCompletedByDatabaseIDs(
+		[]uuid.UUID{database.ID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeploymentGateResponse{
+		DatabaseID:    database.ID,
+		DatabaseName:  database.Name,
+		MaxAgeMinutes: maxAgeMinutes,
+	}
+
+	lastBackup, isFound := lastBackups[database.ID]
+	if !isFound {
+		return response, nil
+	}
+
+	response.LastBackupID = &lastBackup.ID
+	response.LastBackupAt = &lastBackup.CreatedAt
+
+	// the backup holds data as of its start, so freshness is measured from it
+	freshAfter := time.Now().UTC().Add(-time.Duration(maxAgeMinutes) * time.Minute)
+	response.IsPassed = lastBackup.CreatedAt.After(freshAfter)
+
+	return response, nil
+}
+
+func (s *BackupTriggerService) startTriggeredBackup(
+	trigger *BackupTrigger,
+	database *databases.Database,
+	tags []string,
+	auditMessage string,
+) error {
+	isAllowed, err := s.rateLimiter.CheckLimit(
+		trigger.ID.String(),
+		"backup_trigger",
+		maxTriggersPerHour,
+		time.Hour,
+	)
+	if err != nil {
+		s.logger.Error("Failed to check backup trigger rate limit", "error", err)
+	}
+	if !isAllowed {
+		return ErrBackupTriggerRateLimited
+	}
+
+	s.backupsScheduler.StartBackupWithTags(database.ID, true, tags)
+
+	if err := s.backupTriggerRepository.UpdateLastTriggeredAt(
+		trigger.ID,
+		time.Now().UTC(),
+	); err != nil {
+		s.logger.Error(
+			"Failed to update last triggered time of backup trigger",
+			"triggerId", trigger.ID,
+			"error", err,
+		)
+	}
+
+	s.auditLogService.WriteAuditLog(auditMessage, nil, database.WorkspaceID)
+
+	return nil
+}
+
+func (s *BackupTriggerService) findTriggerByToken(token string) (*BackupTrigger, error) {
+	trigger, err := s.backupTriggerRepository.FindByTokenHash(hashBackupTriggerToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if trigger == nil {
+		return nil, ErrBackupTriggerNotFound
+	}
+
+	return trigger, nil
+}
+
+func (s *BackupTriggerService) getManagedDatabase(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*databases.Database, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, ErrInsufficientPermissionsToManageTrigger
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageTrigger
+	}
+
+	return database, nil
+}
+
+func generateBackupTriggerToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate backup trigger token: %w", err)
+	}
+
+	return backupTriggerTokenPrefix + hex.EncodeToString(randomBytes), nil
+}
+
+// hashBackupTriggerToken uses plain sha256 like calendar feed tokens, the tokens are random
+// and long
+func hashBackupTriggerToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,113 @@
+package billing_subscriptions
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const maxStripeWebhookPayloadBytes = 1 << 20
+
+type SubscriptionController struct {
+	subscriptionService *SubscriptionService
+}
+
+func (c *SubscriptionController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/billing/plans", c.GetPlans)
+	router.GET("/billing/subscriptions/workspaces/:workspaceId", c.GetWorkspaceSubscription)
+}
+
+// RegisterPublicRoutes registers routes called by Stripe, they are
+// authenticated by the webhook signature instead of user token
+func (c *SubscriptionController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.POST("/billing/stripe/webhook", c.HandleStripeWebhook)
+}
+
+// GetPlans
+// @Summary Get plans
+// @Description Get quotas of all available plans
+// @Tags billing
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} PlanQuota
+// @Failure 401 {object} map[string]string
+// @Router /billing/plans [get]
+func (c *SubscriptionController) GetPlans(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, GetPlanQuotas())
+}
+
+// GetWorkspaceSubscription
+// @Summary Get workspace subscription
+// @Description Get subscription plan, status and quotas of a workspace
+// @Tags billing
+// @Produce json
+// @Security BearerAuth
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} GetWorkspaceSubscriptionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /billing/subscriptions/workspaces/{workspaceId} [get]
+func (c *SubscriptionController) GetWorkspaceSubscription(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	response, err := c.subscriptionService.GetWorkspaceSubscription(user, workspaceID)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewSubscription) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// HandleStripeWebhook
+// @Summary Handle Stripe webhook
+// @Description Receive subscription lifecycle events from Stripe
+// @Tags billing
+// @Accept json
+// @Produce json
+// @Param Stripe-Signature header string true "Stripe webhook signature"
+// @Success 200
+// @Failure 400 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /billing/stripe/webhook [post]
+func (c *SubscriptionController) HandleStripeWebhook(ctx *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxStripeWebhookPayloadBytes))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	err = c.subscriptionService.HandleStripeWebhook(payload, ctx.GetHeader("Stripe-Signature"))
+	if err != nil {
+		if errors.Is(err, ErrStripeWebhookIsNotConfigured) {
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,77 @@
+package billing_subscriptions
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetWorkspaceSubscription_WithoutSubscription_ReturnsFreePlan(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createTestRouter()
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Billing test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	var response GetWorkspaceSubscriptionResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/billing/subscriptions/workspaces/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, PlanTierFree, response.PlanTier)
+	assert.Equal(t, GetPlanQuota(PlanTierFree), response.Quota)
+}
+
+func Test_GetWorkspaceSubscription_WhenUserIsNotMember_ReturnsForbidden(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	stranger := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createTestRouter()
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Billing test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/billing/subscriptions/workspaces/"+workspace.ID.String(),
+		"Bearer "+stranger.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_HandleStripeWebhook_WithoutValidSignature_IsRejected(t *testing.T) {
+	router := createTestRouter()
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/billing/stripe/webhook",
+		bytes.NewBufferString(`{"id":"evt_1","type":"customer.subscription.updated"}`),
+	)
+	req.Header.Set("Stripe-Signature", "t=1,v1=deadbeef")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Contains(t, []int{http.StatusBadRequest, http.StatusServiceUnavailable}, w.Code)
+}
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(GetSubscriptionController())
+	GetSubscriptionController().RegisterPublicRoutes(router.Group("/api/v1"))
+
+	return router
+}
@@ -0,0 +1,59 @@
+package billing_subscriptions
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	"databasus-backend/internal/features/databases"
+	plans "databasus-backend/internal/features/plan"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var subscriptionRepository = &WorkspaceSubscriptionRepository{}
+var subscriptionService = &SubscriptionService{
+	subscriptionRepository,
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var subscriptionController = &SubscriptionController{
+	subscriptionService,
+}
+
+func GetSubscriptionService() *SubscriptionService {
+	return subscriptionService
+}
+
+func GetSubscriptionController() *SubscriptionController {
+	return subscriptionController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		// plan limits are enforced only in cloud mode, self hosted
+		// instances stay unlimited
+		if config.GetEnv().IsCloud {
+			databases.GetDatabaseService().SetWorkspaceQuotaChecker(subscriptionService)
+			plans.GetDatabasePlanService().SetPlanLimitsProvider(subscriptionService)
+			backuping.GetBackupsScheduler().AddBackupQuotaChecker(subscriptionService)
+			backuping.GetBackupsScheduler().SetBackupPriorityProvider(subscriptionService)
+		}
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
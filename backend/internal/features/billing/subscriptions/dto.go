@@ -0,0 +1,40 @@
+package billing_subscriptions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetWorkspaceSubscriptionResponse struct {
+	WorkspaceID      uuid.UUID          `json:"workspaceId"`
+	PlanTier         PlanTier           `json:"planTier"`
+	Status           SubscriptionStatus `json:"status"`
+	CurrentPeriodEnd *time.Time         `json:"currentPeriodEnd"`
+	Quota            PlanQuota          `json:"quota"`
+}
+
+// stripeEvent contains only the fields of Stripe events we rely on
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object stripeSubscription `json:"object"`
+	} `json:"data"`
+}
+
+type stripeSubscription struct {
+	ID               string            `json:"id"`
+	Customer         string            `json:"customer"`
+	Status           string            `json:"status"`
+	CurrentPeriodEnd int64             `json:"current_period_end"`
+	Metadata         map[string]string `json:"metadata"`
+	Items            struct {
+		Data []struct {
+			Price struct {
+				ID string `json:"id"`
+			} `json:"price"`
+		} `json:"data"`
+	} `json:"items"`
+}
@@ -0,0 +1,20 @@
+package billing_subscriptions
+
+type PlanTier string
+
+const (
+	PlanTierFree     PlanTier = "FREE"
+	PlanTierStarter  PlanTier = "STARTER"
+	PlanTierPro      PlanTier = "PRO"
+	PlanTierBusiness PlanTier = "BUSINESS"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive     SubscriptionStatus = "ACTIVE"
+	SubscriptionStatusTrialing   SubscriptionStatus = "TRIALING"
+	SubscriptionStatusPastDue    SubscriptionStatus = "PAST_DUE"
+	SubscriptionStatusIncomplete SubscriptionStatus = "INCOMPLETE"
+	SubscriptionStatusCanceled   SubscriptionStatus = "CANCELED"
+)
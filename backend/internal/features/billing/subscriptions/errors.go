@@ -0,0 +1,18 @@
+package billing_subscriptions
+
+import "errors"
+
+var (
+	ErrUpgradeRequired = errors.New(
+		"upgrade required",
+	)
+	ErrStripeWebhookIsNotConfigured = errors.New(
+		"stripe webhook secret is not configured",
+	)
+	ErrInvalidStripeSignature = errors.New(
+		"invalid stripe signature",
+	)
+	ErrInsufficientPermissionsToViewSubscription = errors.New(
+		"insufficient permissions to view workspace subscription",
+	)
+)
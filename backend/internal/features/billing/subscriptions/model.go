@@ -0,0 +1,40 @@
+package billing_subscriptions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkspaceSubscription struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+
+	PlanTier PlanTier           `json:"planTier" gorm:"column:plan_tier;type:text;not null"`
+	Status   SubscriptionStatus `json:"status"   gorm:"column:status;type:text;not null"`
+
+	StripeCustomerID     string     `json:"-"                gorm:"column:stripe_customer_id;type:text;not null"`
+	StripeSubscriptionID string     `json:"-"                gorm:"column:stripe_subscription_id;type:text;not null"`
+	CurrentPeriodEnd     *time.Time `json:"currentPeriodEnd" gorm:"column:current_period_end"`
+
+	// LastStripeEventAt is the creation time of the last applied event. Stripe does not
+	// keep the order of webhooks, older events arriving later are dropped
+	LastStripeEventAt *time.Time `json:"-" gorm:"column:last_stripe_event_at"`
+
+	UpdatedAt time.Time `json:"updatedAt" gorm:"column:updated_at;not null"`
+}
+
+func (WorkspaceSubscription) TableName() string {
+	return "workspace_subscriptions"
+}
+
+// EffectivePlanTier keeps paid limits during the past due grace period
+// and falls back to the free plan once a subscription stops being paid
+func (s *WorkspaceSubscription) EffectivePlanTier() PlanTier {
+	switch s.Status {
+	case SubscriptionStatusActive, SubscriptionStatusTrialing, SubscriptionStatusPastDue:
+		return s.PlanTier
+	default:
+		return PlanTierFree
+	}
+}
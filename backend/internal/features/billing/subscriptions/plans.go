@@ -0,0 +1,120 @@
+package billing_subscriptions
+
+import (
+	"fmt"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/util/period"
+)
+
+type PlanQuota struct {
+	Tier PlanTier `json:"tier"`
+
+	// 0 means unlimited for all numeric limits
+	MaxDatabases    int           `json:"maxDatabases"`
+	MaxStorageGB    int64         `json:"maxStorageGb"`
+	MaxBackupSizeMB int64         `json:"maxBackupSizeMb"`
+	MaxStorePeriod  period.Period `json:"maxStorePeriod"`
+
+	// NodePriority orders due backups of the scheduler, higher priorities are started
+	// first and get the least busy nodes
+	NodePriority int `json:"nodePriority"`
+}
+
+// CheckCanAddDatabase fails when a workspace with databasesCount databases is at the limit
+func (q *PlanQuota) CheckCanAddDatabase(databasesCount int) error {
+	if q.MaxDatabases > 0 && databasesCount >= q.MaxDatabases {
+		return fmt.Errorf(
+			"%w: the %s plan allows up to %d databases per workspace, upgrade the plan to add more",
+			ErrUpgradeRequired,
+			q.Tier,
+			q.MaxDatabases,
+		)
+	}
+
+	return nil
+}
+
+// CheckCanStartBackup fails once completed backups of the workspace use up the plan storage
+func (q *PlanQuota) CheckCanStartBackup(storedSizeMB float64) error {
+	if q.MaxStorageGB > 0 && storedSizeMB >= float64(q.MaxStorageGB*1024) {
+		return fmt.Errorf(
+			"%w: the %s plan storage of %d GB is used up, upgrade the plan or remove old backups",
+			ErrUpgradeRequired,
+			q.Tier,
+			q.MaxStorageGB,
+		)
+	}
+
+	return nil
+}
+
+var planQuotas = map[PlanTier]PlanQuota{
+	PlanTierFree: {
+		Tier:            PlanTierFree,
+		MaxDatabases:    1,
+		MaxStorageGB:    4,
+		MaxBackupSizeMB: 100,
+		MaxStorePeriod:  period.PeriodWeek,
+		NodePriority:    0,
+	},
+	PlanTierStarter: {
+		Tier:            PlanTierStarter,
+		MaxDatabases:    5,
+		MaxStorageGB:    50,
+		MaxBackupSizeMB: 10 * 1024,
+		MaxStorePeriod:  period.Period3Month,
+		NodePriority:    1,
+	},
+	PlanTierPro: {
+		Tier:            PlanTierPro,
+		MaxDatabases:    20,
+		MaxStorageGB:    250,
+		MaxBackupSizeMB: 50 * 1024,
+		MaxStorePeriod:  period.PeriodYear,
+		NodePriority:    2,
+	},
+	PlanTierBusiness: {
+		Tier:            PlanTierBusiness,
+		MaxDatabases:    100,
+		MaxStorageGB:    1000,
+		MaxBackupSizeMB: 0,
+		MaxStorePeriod:  period.PeriodForever,
+		NodePriority:    3,
+	},
+}
+
+func GetPlanQuota(tier PlanTier) PlanQuota {
+	quota, isFound := planQuotas[tier]
+	if !isFound {
+		return planQuotas[PlanTierFree]
+	}
+
+	return quota
+}
+
+func GetPlanQuotas() []PlanQuota {
+	return []PlanQuota{
+		planQuotas[PlanTierFree],
+		planQuotas[PlanTierStarter],
+		planQuotas[PlanTierPro],
+		planQuotas[PlanTierBusiness],
+	}
+}
+
+func getPlanTierByStripePriceID(priceID string) (PlanTier, bool) {
+	env := config.GetEnv()
+
+	switch {
+	case priceID == "":
+		return "", false
+	case priceID == env.StripePriceIDStarter:
+		return PlanTierStarter, true
+	case priceID == env.StripePriceIDPro:
+		return PlanTierPro, true
+	case priceID == env.StripePriceIDBusiness:
+		return PlanTierBusiness, true
+	default:
+		return "", false
+	}
+}
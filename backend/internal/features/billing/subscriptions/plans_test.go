@@ -0,0 +1,33 @@
+package billing_subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckCanAddDatabase_AtPlanLimit_RequiresUpgrade(t *testing.T) {
+	quota := GetPlanQuota(PlanTierStarter)
+
+	assert.NoError(t, quota.CheckCanAddDatabase(quota.MaxDatabases-1))
+	assert.ErrorIs(t, quota.CheckCanAddDatabase(quota.MaxDatabases), ErrUpgradeRequired)
+}
+
+func Test_CheckCanStartBackup_WhenPlanStorageIsUsedUp_RequiresUpgrade(t *testing.T) {
+	quota := GetPlanQuota(PlanTierFree)
+	limitMB := float64(quota.MaxStorageGB * 1024)
+
+	assert.NoError(t, quota.CheckCanStartBackup(limitMB-1))
+	assert.ErrorIs(t, quota.CheckCanStartBackup(limitMB), ErrUpgradeRequired)
+}
+
+func Test_CheckQuota_WithUnlimitedPlan_AlwaysPasses(t *testing.T) {
+	quota := PlanQuota{Tier: PlanTierBusiness}
+
+	assert.NoError(t, quota.CheckCanAddDatabase(1_000_000))
+	assert.NoError(t, quota.CheckCanStartBackup(1<<40))
+}
+
+func Test_GetPlanQuota_WithUnknownTier_FallsBackToFreePlan(t *testing.T) {
+	assert.Equal(t, GetPlanQuota(PlanTierFree), GetPlanQuota(PlanTier("UNKNOWN")))
+}
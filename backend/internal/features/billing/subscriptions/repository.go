@@ -0,0 +1,124 @@
+package billing_subscriptions
+
+import (
+	"errors"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkspaceSubscriptionRepository struct{}
+
+func (r *WorkspaceSubscriptionRepository) Save(subscription *WorkspaceSubscription) error {
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+	}
+
+	return storage.GetDb().Save(subscription).Error
+}
+
+func (r *WorkspaceSubscriptionRepository) FindByWorkspaceID(
+	workspaceID uuid.UUID,
+) (*WorkspaceSubscription, error) {
+	var subscription WorkspaceSubscription
+
+	err := storage.GetDb().Where("workspace_id = ?", workspaceID).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *WorkspaceSubscriptionRepository) FindByStripeSubscriptionID(
+	stripeSubscriptionID string,
+) (*WorkspaceSubscription, error) {
+	var subscription WorkspaceSubscription
+
+	err := storage.GetDb().
+		Where("stripe_subscription_id = ?", stripeSubscriptionID).
+		First(&subscription).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// FindByDatabaseIDs returns subscriptions of workspaces of the databases, keyed by database.
+// Databases of workspaces without a subscription are left out
+func (r *WorkspaceSubscriptionRepository) FindByDatabaseIDs(
+	databaseIDs []uuid.UUID,
+) (map[uuid.UUID]*WorkspaceSubscription, error) {
+	subscriptionsByDatabaseID := make(map[uuid.UUID]*WorkspaceSubscription, len(databaseIDs))
+	if len(databaseIDs) == 0 {
+		return subscriptionsByDatabaseID, nil
+	}
+
+	var rows []struct {
+		DatabaseID uuid.UUID
+		WorkspaceSubscription
+	}
+
+	err := storage.GetDb().
+		Table("workspace_subscriptions s").
+		Select("d.id AS database_id, s.*").
+		Joins("JOIN databases d ON d.workspace_id = s.workspace_id").
+		Where("d.id IN ?", databaseIDs).
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		subscription := row.WorkspaceSubscription
+		subscriptionsByDatabaseID[row.DatabaseID] = &subscription
+	}
+
+	return subscriptionsByDatabaseID, nil
+}
+
+func (r *WorkspaceSubscriptionRepository) GetWorkspaceIDByDatabaseID(
+	databaseID uuid.UUID,
+) (*uuid.UUID, error) {
+	var workspaceIDs []uuid.UUID
+
+	err := storage.GetDb().
+		Table("databases").
+		Where("id = ? AND workspace_id IS NOT NULL", databaseID).
+		Pluck("workspace_id", &workspaceIDs).
+		Error
+	if err != nil || len(workspaceIDs) == 0 {
+		return nil, err
+	}
+
+	return &workspaceIDs[0], nil
+}
+
+func (r *WorkspaceSubscriptionRepository) GetWorkspaceStoredSizeMB(
+	workspaceID uuid.UUID,
+) (float64, error) {
+	var storedSizeMB float64
+
+	err := storage.GetDb().Raw(`
+		SELECT COALESCE(SUM(b.backup_size_mb), 0)
+		FROM backups b
+		JOIN databases d ON d.id = b.database_id
+		WHERE d.workspace_id = ? AND b.status = 'COMPLETED'`,
+		workspaceID,
+	).Scan(&storedSizeMB).Error
+
+	return storedSizeMB, err
+}
@@ -0,0 +1,271 @@
+package billing_subscriptions
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	plans "databasus-backend/internal/features/plan"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionService struct {
+	subscriptionRepository *WorkspaceSubscriptionRepository
+	workspaceService       *workspaces_services.WorkspaceService
+	auditLogService        *audit_logs.AuditLogService
+	logger                 *slog.Logger
+}
+
+func (s *SubscriptionService) GetWorkspaceSubscription(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*GetWorkspaceSubscriptionResponse, error) {
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, ErrInsufficientPermissionsToViewSubscription
+	}
+
+	subscription, err := s.subscriptionRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return &GetWorkspaceSubscriptionResponse{
+			WorkspaceID: workspaceID,
+			PlanTier:    PlanTierFree,
+			Status:      SubscriptionStatusActive,
+			Quota:       GetPlanQuota(PlanTierFree),
+		}, nil
+	}
+
+	return &GetWorkspaceSubscriptionResponse{
+		WorkspaceID:      workspaceID,
+		PlanTier:         subscription.EffectivePlanTier(),
+		Status:           subscription.Status,
+		CurrentPeriodEnd: subscription.CurrentPeriodEnd,
+		Quota:            GetPlanQuota(subscription.EffectivePlanTier()),
+	}, nil
+}
+
+func (s *SubscriptionService) HandleStripeWebhook(payload []byte, signatureHeader string) error {
+	secret := config.GetEnv().StripeWebhookSecret
+	if secret == "" {
+		return ErrStripeWebhookIsNotConfigured
+	}
+
+	if err := verifyStripeSignature(payload, signatureHeader, secret, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	event := &stripeEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return fmt.Errorf("failed to parse stripe event: %w", err)
+	}
+
+	switch event.Type {
+	case stripeEventSubscriptionCreated,
+		stripeEventSubscriptionUpdated,
+		stripeEventSubscriptionDeleted:
+		return s.applyStripeSubscription(event)
+	default:
+		s.logger.Debug("Ignoring stripe event", "eventId", event.ID, "eventType", event.Type)
+		return nil
+	}
+}
+
+// GetWorkspaceQuota returns nil outside of cloud mode, where
+// workspaces are unlimited
+func (s *SubscriptionService) GetWorkspaceQuota(workspaceID uuid.UUID) (*PlanQuota, error) {
+	if !config.GetEnv().IsCloud {
+		return nil, nil
+	}
+
+	subscription, err := s.subscriptionRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := PlanTierFree
+	if subscription != nil {
+		tier = subscription.EffectivePlanTier()
+	}
+
+	quota := GetPlanQuota(tier)
+	return &quota, nil
+}
+
+func (s *SubscriptionService) CheckCanAddDatabase(workspaceID uuid.UUID, databasesCount int) error {
+	quota, err := s.GetWorkspaceQuota(workspaceID)
+	if err != nil || quota == nil {
+		return err
+	}
+
+	return quota.CheckCanAddDatabase(databasesCount)
+}
+
+func (s *SubscriptionService) CheckCanStartBackup(databaseID uuid.UUID) error {
+	workspaceID, err := s.subscriptionRepository.GetWorkspaceIDByDatabaseID(databaseID)
+	if err != nil || workspaceID == nil {
+		return err
+	}
+
+	quota, err := s.GetWorkspaceQuota(*workspaceID)
+	if err != nil || quota == nil || quota.MaxStorageGB == 0 {
+		return err
+	}
+
+	storedSizeMB, err := s.subscriptionRepository.GetWorkspaceStoredSizeMB(*workspaceID)
+	if err != nil {
+		return err
+	}
+
+	return quota.CheckCanStartBackup(storedSizeMB)
+}
+
+// GetBackupPriorities returns the node priority of the plan of each database, databases
+// of workspaces without a subscription are on the free plan
+func (s *SubscriptionService) GetBackupPriorities(
+	databaseIDs []uuid.UUID,
+) (map[uuid.UUID]int, error) {
+	subscriptionsByDatabaseID, err := s.subscriptionRepository.FindByDatabaseIDs(databaseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := make(map[uuid.UUID]int, len(databaseIDs))
+	for _, databaseID := range databaseIDs {
+		tier := PlanTierFree
+		if subscription, ok := subscriptionsByDatabaseID[databaseID]; ok {
+			tier = subscription.EffectivePlanTier()
+		}
+
+		priorities[databaseID] = GetPlanQuota(tier).NodePriority
+	}
+
+	return priorities, nil
+}
+
+// GetDatabasePlanLimits derives per-database limits from the workspace
+// subscription, so existing backup config validation enforces the
+// retention ceiling and size limits of the plan
+func (s *SubscriptionService) GetDatabasePlanLimits(
+	databaseID uuid.UUID,
+) (*plans.DatabasePlan, error) {
+	workspaceID, err := s.subscriptionRepository.GetWorkspaceIDByDatabaseID(databaseID)
+	if err != nil || workspaceID == nil {
+		return nil, err
+	}
+
+	quota, err := s.GetWorkspaceQuota(*workspaceID)
+	if err != nil || quota == nil {
+		return nil, err
+	}
+
+	return &plans.DatabasePlan{
+		DatabaseID:            databaseID,
+		MaxBackupSizeMB:       quota.MaxBackupSizeMB,
+		MaxBackupsTotalSizeMB: quota.MaxStorageGB * 1024,
+		MaxStoragePeriod:      quota.MaxStorePeriod,
+	}, nil
+}
+
+// applyStripeSubscription finds the row by the Stripe subscription ID, and by workspace_id
+// metadata for subscriptions not seen yet. Events of replaced subscriptions and events
+// older than the last applied one are dropped, see isStaleStripeEvent
+func (s *SubscriptionService) applyStripeSubscription(event *stripeEvent) error {
+	stripeSubscription := event.Data.Object
+
+	subscription, err := s.subscriptionRepository.FindByStripeSubscriptionID(
+		stripeSubscription.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if subscription == nil {
+		workspaceID, err := uuid.Parse(stripeSubscription.Metadata["workspace_id"])
+		if err != nil {
+			s.logger.Warn(
+				"Stripe subscription has no valid workspace_id metadata, ignoring",
+				"eventId", event.ID,
+				"subscriptionId", stripeSubscription.ID,
+			)
+			return nil
+		}
+
+		subscription, err = s.subscriptionRepository.FindByWorkspaceID(workspaceID)
+		if err != nil {
+			return err
+		}
+
+		if subscription == nil {
+			subscription = &WorkspaceSubscription{
+				WorkspaceID: workspaceID,
+				PlanTier:    PlanTierFree,
+			}
+		}
+	}
+
+	if isStaleStripeEvent(subscription, event) {
+		s.logger.Info(
+			"Ignoring stale stripe subscription event",
+			"eventId", event.ID,
+			"eventType", event.Type,
+			"subscriptionId", stripeSubscription.ID,
+			"workspaceId", subscription.WorkspaceID,
+		)
+		return nil
+	}
+
+	for _, item := range stripeSubscription.Items.Data {
+		if tier, isFound := getPlanTierByStripePriceID(item.Price.ID); isFound {
+			subscription.PlanTier = tier
+			break
+		}
+	}
+
+	subscription.Status = mapStripeSubscriptionStatus(stripeSubscription.Status)
+	if event.Type == stripeEventSubscriptionDeleted {
+		subscription.Status = SubscriptionStatusCanceled
+	}
+
+	subscription.StripeCustomerID = stripeSubscription.Customer
+	subscription.StripeSubscriptionID = stripeSubscription.ID
+	subscription.UpdatedAt = time.Now().UTC()
+
+	if event.Created > 0 {
+		eventAt := time.Unix(event.Created, 0).UTC()
+		subscription.LastStripeEventAt = &eventAt
+	}
+
+	if stripeSubscription.CurrentPeriodEnd > 0 {
+		periodEnd := time.Unix(stripeSubscription.CurrentPeriodEnd, 0).UTC()
+		subscription.CurrentPeriodEnd = &periodEnd
+	}
+
+	if err := s.subscriptionRepository.Save(subscription); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Subscription changed: plan %s, status %s",
+			subscription.PlanTier,
+			subscription.Status,
+		),
+		nil,
+		&subscription.WorkspaceID,
+	)
+
+	return nil
+}
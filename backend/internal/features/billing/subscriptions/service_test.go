@@ -0,0 +1,62 @@
+package billing_subscriptions
+
+import (
+	"testing"
+	"time"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApplyStripeSubscription_WithLateEventsOfReplacedSubscription_KeepsCurrentOne(
+	t *testing.T,
+) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Billing test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	now := time.Now().UTC()
+
+	oldCreated := createStripeEvent(stripeEventSubscriptionCreated, "sub_old", now.Add(-time.Hour))
+	oldCreated.Data.Object.Metadata = map[string]string{"workspace_id": workspace.ID.String()}
+	assert.NoError(t, subscriptionService.applyStripeSubscription(oldCreated))
+
+	newCreated := createStripeEvent(stripeEventSubscriptionCreated, "sub_new", now)
+	newCreated.Data.Object.Metadata = map[string]string{"workspace_id": workspace.ID.String()}
+	assert.NoError(t, subscriptionService.applyStripeSubscription(newCreated))
+
+	// the old subscription is deleted after the upgrade, Stripe may deliver it at any time
+	oldDeleted := createStripeEvent(stripeEventSubscriptionDeleted, "sub_old", now.Add(time.Minute))
+	oldDeleted.Data.Object.Metadata = map[string]string{"workspace_id": workspace.ID.String()}
+	assert.NoError(t, subscriptionService.applyStripeSubscription(oldDeleted))
+
+	subscription, err := subscriptionRepository.FindByWorkspaceID(workspace.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "sub_new", subscription.StripeSubscriptionID)
+	assert.Equal(t, SubscriptionStatusActive, subscription.Status)
+
+	// an update created before the applied one arrives late and is dropped
+	lateUpdate := createStripeEvent(
+		stripeEventSubscriptionUpdated,
+		"sub_new",
+		now.Add(-time.Minute),
+	)
+	lateUpdate.Data.Object.Status = "past_due"
+	assert.NoError(t, subscriptionService.applyStripeSubscription(lateUpdate))
+
+	subscription, err = subscriptionRepository.FindByWorkspaceID(workspace.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, SubscriptionStatusActive, subscription.Status)
+
+	newDeleted := createStripeEvent(stripeEventSubscriptionDeleted, "sub_new", now.Add(time.Hour))
+	assert.NoError(t, subscriptionService.applyStripeSubscription(newDeleted))
+
+	subscription, err = subscriptionRepository.FindByWorkspaceID(workspace.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, SubscriptionStatusCanceled, subscription.Status)
+	assert.Equal(t, PlanTierFree, subscription.EffectivePlanTier())
+}
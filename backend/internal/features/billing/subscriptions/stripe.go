@@ -0,0 +1,117 @@
+package billing_subscriptions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const stripeSignatureTolerance = 5 * time.Minute
+
+const (
+	stripeEventSubscriptionCreated = "customer.subscription.created"
+	stripeEventSubscriptionUpdated = "customer.subscription.updated"
+	stripeEventSubscriptionDeleted = "customer.subscription.deleted"
+)
+
+// verifyStripeSignature checks the Stripe-Signature header which has
+// format "t=<unix timestamp>,v1=<hex hmac>[,v1=...]". Several v1
+// signatures are sent while the webhook secret is being rolled
+func verifyStripeSignature(payload []byte, header, secret string, now time.Time) error {
+	var timestamp string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, isFound := strings.Cut(strings.TrimSpace(part), "=")
+		if !isFound {
+			continue
+		}
+
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrInvalidStripeSignature
+	}
+
+	unixTimestamp, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidStripeSignature
+	}
+
+	signedAt := time.Unix(unixTimestamp, 0)
+	if now.Sub(signedAt).Abs() > stripeSignatureTolerance {
+		return ErrInvalidStripeSignature
+	}
+
+	expectedSignature := computeStripeSignature(payload, timestamp, secret)
+	for _, signature := range signatures {
+		decodedSignature, err := hex.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(decodedSignature, expectedSignature) {
+			return nil
+		}
+	}
+
+	return ErrInvalidStripeSignature
+}
+
+func computeStripeSignature(payload []byte, timestamp, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+func mapStripeSubscriptionStatus(status string) SubscriptionStatus {
+	switch status {
+	case "active":
+		return SubscriptionStatusActive
+	case "trialing":
+		return SubscriptionStatusTrialing
+	case "past_due", "unpaid":
+		return SubscriptionStatusPastDue
+	case "incomplete":
+		return SubscriptionStatusIncomplete
+	default:
+		return SubscriptionStatusCanceled
+	}
+}
+
+// isStaleStripeEvent reports events which must not change the subscription. A workspace
+// moves to another Stripe subscription only with its created event or once the current
+// one is canceled, so late updates and deletions of a replaced subscription are ignored.
+// Events older than the last applied one are ignored as well
+func isStaleStripeEvent(subscription *WorkspaceSubscription, event *stripeEvent) bool {
+	// Stripe timestamps events in whole seconds
+	if subscription.LastStripeEventAt != nil && event.Created > 0 &&
+		event.Created < subscription.LastStripeEventAt.Unix() {
+		return true
+	}
+
+	isOtherSubscription := subscription.StripeSubscriptionID != "" &&
+		subscription.StripeSubscriptionID != event.Data.Object.ID
+	if !isOtherSubscription {
+		return false
+	}
+
+	if event.Type == stripeEventSubscriptionDeleted {
+		return true
+	}
+
+	return event.Type != stripeEventSubscriptionCreated &&
+		subscription.Status != SubscriptionStatusCanceled
+}
@@ -0,0 +1,143 @@
+package billing_subscriptions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyStripeSignature_WithValidSignature_Succeeds(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"customer.subscription.updated"}`)
+	secret := "whsec_test"
+	now := time.Now().UTC()
+
+	header := createStripeSignatureHeader(payload, secret, now)
+
+	assert.NoError(t, verifyStripeSignature(payload, header, secret, now))
+}
+
+func Test_VerifyStripeSignature_WithRolledSecret_AcceptsAnyMatchingSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	now := time.Now().UTC()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	header := fmt.Sprintf(
+		"t=%s,v1=%s,v1=%s",
+		timestamp,
+		hex.EncodeToString(computeStripeSignature(payload, timestamp, "whsec_old")),
+		hex.EncodeToString(computeStripeSignature(payload, timestamp, "whsec_new")),
+	)
+
+	assert.NoError(t, verifyStripeSignature(payload, header, "whsec_new", now))
+}
+
+func Test_VerifyStripeSignature_WithInvalidInput_ReturnsError(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	secret := "whsec_test"
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name   string
+		header string
+	}{
+		{"empty header", ""},
+		{"wrong secret", createStripeSignatureHeader(payload, "whsec_other", now)},
+		{
+			"expired timestamp",
+			createStripeSignatureHeader(payload, secret, now.Add(-10*time.Minute)),
+		},
+		{"malformed signature", fmt.Sprintf("t=%d,v1=not-hex", now.Unix())},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyStripeSignature(payload, tc.header, secret, now)
+			assert.ErrorIs(t, err, ErrInvalidStripeSignature)
+		})
+	}
+
+	tamperedPayload := []byte(`{"id":"evt_2"}`)
+	header := createStripeSignatureHeader(payload, secret, now)
+
+	err := verifyStripeSignature(tamperedPayload, header, secret, now)
+	assert.ErrorIs(t, err, ErrInvalidStripeSignature)
+}
+
+func createStripeSignatureHeader(payload []byte, secret string, signedAt time.Time) string {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	signature := computeStripeSignature(payload, timestamp, secret)
+
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, hex.EncodeToString(signature))
+}
+
+func Test_IsStaleStripeEvent_ForReplacedSubscription_IgnoresLateUpdatesAndDeletions(t *testing.T) {
+	now := time.Now().UTC()
+	subscription := &WorkspaceSubscription{
+		StripeSubscriptionID: "sub_new",
+		Status:               SubscriptionStatusActive,
+		LastStripeEventAt:    &now,
+	}
+
+	lateEventAt := now.Add(time.Minute)
+
+	assert.True(t, isStaleStripeEvent(
+		subscription,
+		createStripeEvent(stripeEventSubscriptionDeleted, "sub_old", lateEventAt),
+	))
+	assert.True(t, isStaleStripeEvent(
+		subscription,
+		createStripeEvent(stripeEventSubscriptionUpdated, "sub_old", lateEventAt),
+	))
+	assert.False(t, isStaleStripeEvent(
+		subscription,
+		createStripeEvent(stripeEventSubscriptionCreated, "sub_next", lateEventAt),
+	))
+}
+
+func Test_IsStaleStripeEvent_WithOlderEvent_IsStale(t *testing.T) {
+	now := time.Now().UTC()
+	subscription := &WorkspaceSubscription{
+		StripeSubscriptionID: "sub_1",
+		Status:               SubscriptionStatusActive,
+		LastStripeEventAt:    &now,
+	}
+
+	assert.True(t, isStaleStripeEvent(
+		subscription,
+		createStripeEvent(stripeEventSubscriptionUpdated, "sub_1", now.Add(-time.Minute)),
+	))
+	assert.False(t, isStaleStripeEvent(
+		subscription,
+		createStripeEvent(stripeEventSubscriptionUpdated, "sub_1", now),
+	))
+}
+
+func Test_IsStaleStripeEvent_WhenCurrentSubscriptionIsCanceled_AcceptsOtherSubscription(
+	t *testing.T,
+) {
+	subscription := &WorkspaceSubscription{
+		StripeSubscriptionID: "sub_old",
+		Status:               SubscriptionStatusCanceled,
+	}
+
+	assert.False(t, isStaleStripeEvent(
+		subscription,
+		createStripeEvent(stripeEventSubscriptionUpdated, "sub_new", time.Now().UTC()),
+	))
+}
+
+func createStripeEvent(eventType, subscriptionID string, createdAt time.Time) *stripeEvent {
+	event := &stripeEvent{
+		ID:      "evt_" + subscriptionID,
+		Type:    eventType,
+		Created: createdAt.Unix(),
+	}
+	event.Data.Object.ID = subscriptionID
+	event.Data.Object.Status = "active"
+
+	return event
+}
@@ -0,0 +1,79 @@
+package billing_usage
+
+import (
+	"fmt"
+	"time"
+)
+
+// calculateUsageTotals sums counters of the days, stored bytes are
+// taken as the peak value
+func calculateUsageTotals(days []*WorkspaceUsage) UsageTotalsDTO {
+	total := UsageTotalsDTO{}
+
+	for _, day := range days {
+		total.BytesTransferred += day.BytesTransferred
+		total.JobsCount += day.JobsCount
+		total.NodeMinutes += day.NodeMinutes
+		total.PeakBytesStored = max(total.PeakBytesStored, day.BytesStored)
+	}
+
+	return total
+}
+
+// resolveUsagePeriod defaults to the last 30 days and aligns the start
+// to the beginning of the day, as usage is stored per day
+func resolveUsagePeriod(request *GetUsageRequest, now time.Time) (time.Time, time.Time, error) {
+	to := now
+	if request.To != nil {
+		to = request.To.UTC()
+	}
+
+	from := to.Add(-defaultUsagePeriod)
+	if request.From != nil {
+		from = request.From.UTC()
+	}
+
+	from = startOfDay(from)
+
+	if !from.Before(to) || to.Sub(from) > maxUsagePeriod {
+		return time.Time{}, time.Time{}, ErrInvalidUsagePeriod
+	}
+
+	return from, to, nil
+}
+
+func toUsageRecords(usage *WorkspaceUsage) []*UsageRecordDTO {
+	quantities := []struct {
+		metric   UsageMetric
+		quantity float64
+	}{
+		{UsageMetricBytesTransferred, float64(usage.BytesTransferred)},
+		{UsageMetricBytesStored, float64(usage.BytesStored)},
+		{UsageMetricJobsCount, float64(usage.JobsCount)},
+		{UsageMetricNodeMinutes, usage.NodeMinutes},
+	}
+
+	records := make([]*UsageRecordDTO, 0, len(quantities))
+	for _, q := range quantities {
+		records = append(records, &UsageRecordDTO{
+			Identifier: fmt.Sprintf(
+				"%s:%s:%s",
+				usage.WorkspaceID,
+				q.metric,
+				usage.PeriodStart.Format(time.DateOnly),
+			),
+			WorkspaceID: usage.WorkspaceID,
+			Metric:      q.metric,
+			Quantity:    q.quantity,
+			PeriodStart: usage.PeriodStart,
+			PeriodEnd:   usage.PeriodEnd(),
+			Timestamp:   usage.PeriodStart.Unix(),
+		})
+	}
+
+	return records
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,77 @@
+package billing_usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CalculateUsageTotals_ForSeveralDays_SumsCountersAndTakesPeakStoredBytes(t *testing.T) {
+	total := calculateUsageTotals([]*WorkspaceUsage{
+		{BytesTransferred: 100, BytesStored: 500, JobsCount: 2, NodeMinutes: 1.5},
+		{BytesTransferred: 50, BytesStored: 800, JobsCount: 1, NodeMinutes: 0.5},
+		{BytesTransferred: 0, BytesStored: 300, JobsCount: 0, NodeMinutes: 0},
+	})
+
+	assert.Equal(t, UsageTotalsDTO{
+		BytesTransferred: 150,
+		JobsCount:        3,
+		NodeMinutes:      2,
+		PeakBytesStored:  800,
+	}, total)
+}
+
+func Test_ResolveUsagePeriod_WithoutBounds_ReturnsLast30DaysFromStartOfDay(t *testing.T) {
+	now := time.Date(2026, 3, 15, 13, 45, 0, 0, time.UTC)
+
+	from, to, err := resolveUsagePeriod(&GetUsageRequest{}, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, now, to)
+}
+
+func Test_ResolveUsagePeriod_WithInvalidBounds_ReturnsError(t *testing.T) {
+	now := time.Date(2026, 3, 15, 13, 45, 0, 0, time.UTC)
+	tooEarly := now.Add(-maxUsagePeriod - 24*time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	_, _, err := resolveUsagePeriod(&GetUsageRequest{From: &tooEarly}, now)
+	assert.ErrorIs(t, err, ErrInvalidUsagePeriod)
+
+	_, _, err = resolveUsagePeriod(&GetUsageRequest{From: &later, To: &now}, now)
+	assert.ErrorIs(t, err, ErrInvalidUsagePeriod)
+}
+
+func Test_ToUsageRecords_ForDay_ReturnsRecordPerMetricWithStableIdentifiers(t *testing.T) {
+	workspaceID := uuid.New()
+	usage := &WorkspaceUsage{
+		WorkspaceID:      workspaceID,
+		PeriodStart:      time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		BytesTransferred: 100,
+		BytesStored:      500,
+		JobsCount:        2,
+		NodeMinutes:      1.5,
+	}
+
+	records := toUsageRecords(usage)
+
+	quantities := map[UsageMetric]float64{}
+	for _, record := range records {
+		quantities[record.Metric] = record.Quantity
+		assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), record.PeriodEnd)
+	}
+
+	assert.Equal(t, map[UsageMetric]float64{
+		UsageMetricBytesTransferred: 100,
+		UsageMetricBytesStored:      500,
+		UsageMetricJobsCount:        2,
+		UsageMetricNodeMinutes:      1.5,
+	}, quantities)
+	assert.Equal(t, workspaceID.String()+":JOBS_COUNT:2026-03-15", records[2].Identifier)
+
+	// re-exported records keep identifiers, so invoicing providers deduplicate them
+	assert.Equal(t, records, toUsageRecords(usage))
+}
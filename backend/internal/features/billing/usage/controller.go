@@ -0,0 +1,109 @@
+package billing_usage
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UsageController struct {
+	usageService *UsageService
+}
+
+func (c *UsageController) RegisterRoutes(router *gin.RouterGroup) {
+	usageRoutes := router.Group("/billing/usage")
+
+	usageRoutes.GET("/workspaces/:workspaceId", c.GetWorkspaceUsage)
+	usageRoutes.GET("/records", c.ExportUsageRecords)
+}
+
+// GetWorkspaceUsage
+// @Summary Get workspace usage
+// @Description Get daily usage of a workspace (cloud mode only)
+// @Tags billing
+// @Produce json
+// @Security BearerAuth
+// @Param workspaceId path string true "Workspace ID"
+// @Param from query string false "Period start (RFC3339 format), defaults to 30 days ago" format(date-time)
+// @Param to query string false "Period end (RFC3339 format), defaults to now" format(date-time)
+// @Success 200 {object} GetWorkspaceUsageResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /billing/usage/workspaces/{workspaceId} [get]
+func (c *UsageController) GetWorkspaceUsage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	request := &GetUsageRequest{}
+	if err := ctx.ShouldBindQuery(request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.usageService.GetWorkspaceUsage(user, workspaceID, request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewUsage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ExportUsageRecords
+// @Summary Export usage records (ADMIN only)
+// @Description Export usage of all workspaces as per-metric records for invoicing integrations (cloud mode only)
+// @Tags billing
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Period start (RFC3339 format), defaults to 30 days ago" format(date-time)
+// @Param to query string false "Period end (RFC3339 format), defaults to now" format(date-time)
+// @Success 200 {object} ExportUsageRecordsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /billing/usage/records [get]
+func (c *UsageController) ExportUsageRecords(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	request := &GetUsageRequest{}
+	if err := ctx.ShouldBindQuery(request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := c.usageService.ExportUsageRecords(user, request)
+	if err != nil {
+		if errors.Is(err, ErrOnlyAdminsCanExportUsage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
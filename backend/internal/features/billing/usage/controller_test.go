@@ -0,0 +1,60 @@
+package billing_usage
+
+import (
+	"net/http"
+	"testing"
+
+	"databasus-backend/internal/config"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetWorkspaceUsage_WhenNotCloud_ReturnsMeteringDisabledError(t *testing.T) {
+	if config.GetEnv().IsCloud {
+		t.Skip("metering is enabled in cloud mode")
+	}
+
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createTestRouter()
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Usage test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/billing/usage/workspaces/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusBadRequest,
+	)
+
+	assert.Contains(t, string(resp.Body), ErrMeteringIsNotEnabled.Error())
+}
+
+func Test_ExportUsageRecords_WhenNotCloud_ReturnsMeteringDisabledError(t *testing.T) {
+	if config.GetEnv().IsCloud {
+		t.Skip("metering is enabled in cloud mode")
+	}
+
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := createTestRouter()
+
+	resp := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/billing/usage/records",
+		"Bearer "+admin.Token,
+		http.StatusBadRequest,
+	)
+
+	assert.Contains(t, string(resp.Body), ErrMeteringIsNotEnabled.Error())
+}
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(GetUsageController())
+}
@@ -0,0 +1,37 @@
+package billing_usage
+
+import (
+	"sync"
+	"sync/atomic"
+
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var usageRepository = &WorkspaceUsageRepository{}
+var usageService = &UsageService{
+	usageRepository,
+	workspaces_services.GetWorkspaceService(),
+	logger.GetLogger(),
+}
+var usageController = &UsageController{
+	usageService,
+}
+var usageBackgroundService = &UsageBackgroundService{
+	usageService: usageService,
+	logger:       logger.GetLogger(),
+	runOnce:      sync.Once{},
+	hasRun:       atomic.Bool{},
+}
+
+func GetUsageService() *UsageService {
+	return usageService
+}
+
+func GetUsageController() *UsageController {
+	return usageController
+}
+
+func GetUsageBackgroundService() *UsageBackgroundService {
+	return usageBackgroundService
+}
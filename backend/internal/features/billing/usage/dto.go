@@ -0,0 +1,49 @@
+package billing_usage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetUsageRequest struct {
+	From *time.Time `form:"from" json:"from"`
+	To   *time.Time `form:"to"   json:"to"`
+}
+
+type GetWorkspaceUsageResponse struct {
+	WorkspaceID uuid.UUID         `json:"workspaceId"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Days        []*WorkspaceUsage `json:"days"`
+	Total       UsageTotalsDTO    `json:"total"`
+}
+
+type UsageTotalsDTO struct {
+	BytesTransferred int64   `json:"bytesTransferred"`
+	JobsCount        int64   `json:"jobsCount"`
+	NodeMinutes      float64 `json:"nodeMinutes"`
+
+	// stored bytes are a gauge, so the total is the
+	// peak value within the period instead of a sum
+	PeakBytesStored int64 `json:"peakBytesStored"`
+}
+
+// UsageRecordDTO is shaped after metered billing events of invoicing
+// providers (e.g. Stripe meter events): Identifier is stable between
+// exports, so re-sending the same record is idempotent on their side
+type UsageRecordDTO struct {
+	Identifier  string      `json:"identifier"`
+	WorkspaceID uuid.UUID   `json:"workspaceId"`
+	Metric      UsageMetric `json:"metric"`
+	Quantity    float64     `json:"quantity"`
+	PeriodStart time.Time   `json:"periodStart"`
+	PeriodEnd   time.Time   `json:"periodEnd"`
+	Timestamp   int64       `json:"timestamp"`
+}
+
+type ExportUsageRecordsResponse struct {
+	From    time.Time         `json:"from"`
+	To      time.Time         `json:"to"`
+	Records []*UsageRecordDTO `json:"records"`
+}
@@ -0,0 +1,10 @@
+package billing_usage
+
+type UsageMetric string
+
+const (
+	UsageMetricBytesTransferred UsageMetric = "BYTES_TRANSFERRED"
+	UsageMetricBytesStored      UsageMetric = "BYTES_STORED"
+	UsageMetricJobsCount        UsageMetric = "JOBS_COUNT"
+	UsageMetricNodeMinutes      UsageMetric = "NODE_MINUTES"
+)
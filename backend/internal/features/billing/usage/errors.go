@@ -0,0 +1,18 @@
+package billing_usage
+
+import "errors"
+
+var (
+	ErrMeteringIsNotEnabled = errors.New(
+		"usage metering is available only in cloud mode",
+	)
+	ErrInsufficientPermissionsToViewUsage = errors.New(
+		"insufficient permissions to view workspace usage",
+	)
+	ErrOnlyAdminsCanExportUsage = errors.New(
+		"only administrators can export usage records",
+	)
+	ErrInvalidUsagePeriod = errors.New(
+		"invalid usage period: 'from' must be before 'to' and range must not exceed 366 days",
+	)
+)
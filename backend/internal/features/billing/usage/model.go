@@ -0,0 +1,31 @@
+package billing_usage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceUsage is a daily usage snapshot of a single workspace. Days
+// are closed once the next day starts, so past rows stay immutable even
+// after backups are removed by the cleaner
+type WorkspaceUsage struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	PeriodStart time.Time `json:"periodStart" gorm:"column:period_start;not null"`
+
+	BytesTransferred int64   `json:"bytesTransferred" gorm:"column:bytes_transferred;not null;default:0"`
+	BytesStored      int64   `json:"bytesStored"      gorm:"column:bytes_stored;not null;default:0"`
+	JobsCount        int64   `json:"jobsCount"        gorm:"column:jobs_count;not null;default:0"`
+	NodeMinutes      float64 `json:"nodeMinutes"      gorm:"column:node_minutes;not null;default:0"`
+
+	UpdatedAt time.Time `json:"updatedAt" gorm:"column:updated_at;not null"`
+}
+
+func (WorkspaceUsage) TableName() string {
+	return "workspace_usages"
+}
+
+func (u *WorkspaceUsage) PeriodEnd() time.Time {
+	return u.PeriodStart.Add(24 * time.Hour)
+}
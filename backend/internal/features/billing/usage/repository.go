@@ -0,0 +1,94 @@
+package billing_usage
+
+import (
+	"time"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceUsageRepository struct{}
+
+// CalculateUsageForPeriod aggregates usage of every workspace from the
+// backups table. Stored bytes are taken as of the end of the period
+func (r *WorkspaceUsageRepository) CalculateUsageForPeriod(
+	periodStart, periodEnd time.Time,
+) ([]*WorkspaceUsage, error) {
+	var usages = make([]*WorkspaceUsage, 0)
+
+	sql := `
+		SELECT
+			d.workspace_id,
+			CAST(COALESCE(SUM(b.backup_size_mb) FILTER (
+				WHERE b.created_at >= @start AND b.created_at < @end
+			), 0) * 1048576 AS BIGINT) AS bytes_transferred,
+			CAST(COALESCE(SUM(b.backup_size_mb) FILTER (
+				WHERE b.status = 'COMPLETED' AND b.created_at < @end
+			), 0) * 1048576 AS BIGINT) AS bytes_stored,
+			COUNT(b.id) FILTER (
+				WHERE b.created_at >= @start AND b.created_at < @end AND b.status <> 'IN_PROGRESS'
+			) AS jobs_count,
+			COALESCE(SUM(b.backup_duration_ms) FILTER (
+				WHERE b.created_at >= @start AND b.created_at < @end
+			), 0) / 60000.0 AS node_minutes
+		FROM backups b
+		JOIN databases d ON d.id = b.database_id
+		WHERE d.workspace_id IS NOT NULL
+		GROUP BY d.workspace_id`
+
+	err := storage.GetDb().
+		Raw(sql, map[string]any{"start": periodStart, "end": periodEnd}).
+		Scan(&usages).
+		Error
+
+	return usages, err
+}
+
+func (r *WorkspaceUsageRepository) Upsert(usage *WorkspaceUsage) error {
+	if usage.ID == uuid.Nil {
+		usage.ID = uuid.New()
+	}
+
+	return storage.GetDb().
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "workspace_id"}, {Name: "period_start"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"bytes_transferred",
+				"bytes_stored",
+				"jobs_count",
+				"node_minutes",
+				"updated_at",
+			}),
+		}).
+		Create(usage).
+		Error
+}
+
+func (r *WorkspaceUsageRepository) FindByWorkspace(
+	workspaceID uuid.UUID,
+	from, to time.Time,
+) ([]*WorkspaceUsage, error) {
+	var usages = make([]*WorkspaceUsage, 0)
+
+	err := storage.GetDb().
+		Where("workspace_id = ? AND period_start >= ? AND period_start < ?", workspaceID, from, to).
+		Order("period_start ASC").
+		Find(&usages).
+		Error
+
+	return usages, err
+}
+
+func (r *WorkspaceUsageRepository) FindAll(from, to time.Time) ([]*WorkspaceUsage, error) {
+	var usages = make([]*WorkspaceUsage, 0)
+
+	err := storage.GetDb().
+		Where("period_start >= ? AND period_start < ?", from, to).
+		Order("period_start ASC, workspace_id ASC").
+		Find(&usages).
+		Error
+
+	return usages, err
+}
@@ -0,0 +1,142 @@
+package billing_usage
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"databasus-backend/internal/config"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultUsagePeriod = 30 * 24 * time.Hour
+	maxUsagePeriod     = 366 * 24 * time.Hour
+)
+
+type UsageService struct {
+	usageRepository  *WorkspaceUsageRepository
+	workspaceService *workspaces_services.WorkspaceService
+	logger           *slog.Logger
+}
+
+func (s *UsageService) GetWorkspaceUsage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	request *GetUsageRequest,
+) (*GetWorkspaceUsageResponse, error) {
+	if !config.GetEnv().IsCloud {
+		return nil, ErrMeteringIsNotEnabled
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, ErrInsufficientPermissionsToViewUsage
+	}
+
+	from, to, err := resolveUsagePeriod(request, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.usageRepository.FindByWorkspace(workspaceID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetWorkspaceUsageResponse{
+		WorkspaceID: workspaceID,
+		From:        from,
+		To:          to,
+		Days:        days,
+		Total:       calculateUsageTotals(days),
+	}, nil
+}
+
+func (s *UsageService) ExportUsageRecords(
+	user *users_models.User,
+	request *GetUsageRequest,
+) (*ExportUsageRecordsResponse, error) {
+	if !config.GetEnv().IsCloud {
+		return nil, ErrMeteringIsNotEnabled
+	}
+
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanExportUsage
+	}
+
+	from, to, err := resolveUsagePeriod(request, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := s.usageRepository.FindAll(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*UsageRecordDTO, 0, len(usages)*4)
+	for _, usage := range usages {
+		records = append(records, toUsageRecords(usage)...)
+	}
+
+	return &ExportUsageRecordsResponse{
+		From:    from,
+		To:      to,
+		Records: records,
+	}, nil
+}
+
+// CollectUsage refreshes snapshots of the current and the previous day.
+// The previous day is recalculated to include backups which started
+// before midnight but finished after it
+func (s *UsageService) CollectUsage() error {
+	today := startOfDay(time.Now().UTC())
+	yesterday := today.Add(-24 * time.Hour)
+
+	for _, periodStart := range []time.Time{yesterday, today} {
+		if err := s.collectUsageForDay(periodStart); err != nil {
+			return fmt.Errorf(
+				"failed to collect usage for %s: %w",
+				periodStart.Format(time.DateOnly),
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *UsageService) collectUsageForDay(periodStart time.Time) error {
+	periodEnd := periodStart.Add(24 * time.Hour)
+
+	usages, err := s.usageRepository.CalculateUsageForPeriod(periodStart, periodEnd)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, usage := range usages {
+		usage.PeriodStart = periodStart
+		usage.UpdatedAt = now
+
+		if err := s.usageRepository.Upsert(usage); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Debug(
+		"Collected workspaces usage",
+		"periodStart", periodStart,
+		"workspacesCount", len(usages),
+	)
+
+	return nil
+}
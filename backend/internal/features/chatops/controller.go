@@ -0,0 +1,189 @@
+package chatops
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const maxSlashCommandPayloadBytes = 64 * 1024
+
+type ChatopsController struct {
+	chatopsService *ChatopsService
+}
+
+func (c *ChatopsController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/chatops/link-code", c.GenerateLinkCode)
+	router.GET("/chatops/accounts", c.GetChatAccounts)
+	router.DELETE("/chatops/accounts/:id", c.UnlinkChatAccount)
+}
+
+// RegisterPublicRoutes exposes slash command endpoints without auth, requests are verified
+// by the signing secret of Slack or the token of the Mattermost command
+func (c *ChatopsController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.POST("/chatops/slack/commands", c.HandleSlackCommand)
+	router.POST("/chatops/mattermost/commands", c.HandleMattermostCommand)
+}
+
+// GenerateLinkCode
+// @Summary Generate chat link code
+// @Description Generate a code valid for 10 minutes, sending it with "/databasus link <code>" links the chat account to the user
+// @Tags chatops
+// @Produce json
+// @Success 200 {object} LinkCodeResponse
+// @Failure 400
+// @Failure 401
+// @Router /chatops/link-code [post]
+func (c *ChatopsController) GenerateLinkCode(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.chatopsService.GenerateLinkCode(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetChatAccounts
+// @Summary Get linked chat accounts
+// @Description Get Slack and Mattermost accounts linked to the user
+// @Tags chatops
+// @Produce json
+// @Success 200 {array} ChatAccount
+// @Failure 400
+// @Failure 401
+// @Router /chatops/accounts [get]
+func (c *ChatopsController) GetChatAccounts(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	accounts, err := c.chatopsService.GetChatAccounts(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, accounts)
+}
+
+// UnlinkChatAccount
+// @Summary Unlink chat account
+// @Description Unlink a chat account of the user, its slash commands are rejected until it is linked again
+// @Tags chatops
+// @Param id path string true "Chat account ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /chatops/accounts/{id} [delete]
+func (c *ChatopsController) UnlinkChatAccount(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	accountID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat account ID"})
+		return
+	}
+
+	if err := c.chatopsService.UnlinkChatAccount(user, accountID); err != nil {
+		if errors.Is(err, ErrChatAccountNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// HandleSlackCommand
+// @Summary Handle Slack slash command
+// @Description Run a /databasus command sent by Slack, the request is verified with CHATOPS_SLACK_SIGNING_SECRET
+// @Tags chatops
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param X-Slack-Signature header string true "Slack request signature"
+// @Param X-Slack-Request-Timestamp header string true "Slack request timestamp"
+// @Success 200 {object} SlashCommandResponse
+// @Failure 400
+// @Failure 401
+// @Failure 503
+// @Router /chatops/slack/commands [post]
+func (c *ChatopsController) HandleSlackCommand(ctx *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxSlashCommandPayloadBytes))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	response, err := c.chatopsService.HandleSlackCommand(
+		payload,
+		ctx.GetHeader("X-Slack-Request-Timestamp"),
+		ctx.GetHeader("X-Slack-Signature"),
+	)
+	if err != nil {
+		c.respondCommandError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// HandleMattermostCommand
+// @Summary Handle Mattermost slash command
+// @Description Run a /databasus command sent by Mattermost, the request is verified with CHATOPS_MATTERMOST_TOKEN
+// @Tags chatops
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Success 200 {object} SlashCommandResponse
+// @Failure 400
+// @Failure 401
+// @Failure 503
+// @Router /chatops/mattermost/commands [post]
+func (c *ChatopsController) HandleMattermostCommand(ctx *gin.Context) {
+	var command SlashCommand
+	if err := ctx.ShouldBind(&command); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.chatopsService.HandleMattermostCommand(&command)
+	if err != nil {
+		c.respondCommandError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *ChatopsController) respondCommandError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrSlackCommandsNotConfigured),
+		errors.Is(err, ErrMattermostCommandsNotConfigured):
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidCommandSignature):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,98 @@
+package chatops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetChatopsController(),
+	)
+
+	v1 := router.Group("/api/v1")
+	GetChatopsController().RegisterPublicRoutes(v1)
+
+	return router
+}
+
+func Test_LinkChatAccount_WithLinkCode_CommandsRunAsUser(t *testing.T) {
+	router := createTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	var linkCodeResponse LinkCodeResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/chatops/link-code",
+		"Bearer "+user.Token,
+		nil,
+		http.StatusOK,
+		&linkCodeResponse,
+	)
+	assert.Len(t, linkCodeResponse.Code, linkCodeLength)
+
+	command := &SlashCommand{
+		TeamID:  "T1",
+		UserID:  "U" + linkCodeResponse.Code,
+		Command: "/databasus",
+		Text:    "link " + linkCodeResponse.Code,
+	}
+
+	response := GetChatopsService().runCommand(ChatPlatformSlack, command)
+	assert.Equal(t, "Your chat account is linked to "+user.Email, response.Text)
+
+	// Link codes are single use
+	response = GetChatopsService().runCommand(ChatPlatformSlack, command)
+	assert.Contains(t, response.Text, "invalid or expired")
+
+	command.Text = "last-failures"
+	response = GetChatopsService().runCommand(ChatPlatformSlack, command)
+	assert.Equal(t, "No failed backups in the last 24 hours", response.Text)
+
+	var accounts []ChatAccount
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/chatops/accounts",
+		"Bearer "+user.Token,
+		http.StatusOK,
+		&accounts,
+	)
+	assert.Len(t, accounts, 1)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/chatops/accounts/"+accounts[0].ID.String(),
+		"Bearer "+user.Token,
+		http.StatusNoContent,
+	)
+
+	response = GetChatopsService().runCommand(ChatPlatformSlack, command)
+	assert.Contains(t, response.Text, "not linked")
+}
+
+func Test_HandleSlackCommand_WithoutSigningSecret_ReturnsServiceUnavailable(t *testing.T) {
+	router := createTestRouter()
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/chatops/slack/commands",
+		"",
+		nil,
+		http.StatusServiceUnavailable,
+	)
+}
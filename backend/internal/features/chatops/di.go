@@ -0,0 +1,36 @@
+package chatops
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/logger"
+)
+
+var chatAccountRepository = &ChatAccountRepository{}
+var chatopsService = &ChatopsService{
+	chatAccountRepository,
+	&backups_core.BackupRepository{},
+	backups.GetBackupService(),
+	users_services.GetUserService(),
+	workspaces_services.GetWorkspaceService(),
+	databases.GetDatabaseService(),
+	audit_logs.GetAuditLogService(),
+	cache_utils.NewCacheUtil[linkCode](cache_utils.GetValkeyClient(), "chatops_link_code:"),
+	logger.GetLogger(),
+}
+var chatopsController = &ChatopsController{
+	chatopsService,
+}
+
+func GetChatopsService() *ChatopsService {
+	return chatopsService
+}
+
+func GetChatopsController() *ChatopsController {
+	return chatopsController
+}
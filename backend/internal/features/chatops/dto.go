@@ -0,0 +1,34 @@
+package chatops
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlashCommand is the form Slack and Mattermost post for a slash command, Mattermost adds
+// the token of the command
+type SlashCommand struct {
+	TeamID  string `form:"team_id"`
+	UserID  string `form:"user_id"`
+	Command string `form:"command"`
+	Text    string `form:"text"`
+	Token   string `form:"token"`
+}
+
+// SlashCommandResponse is understood by both platforms, ephemeral replies are shown only
+// to the user who ran the command
+type SlashCommandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+type LinkCodeResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// linkCode is kept in the cache until the chat user sends it with the link command
+type linkCode struct {
+	UserID uuid.UUID `json:"userId"`
+}
@@ -0,0 +1,8 @@
+package chatops
+
+type ChatPlatform string
+
+const (
+	ChatPlatformSlack      ChatPlatform = "SLACK"
+	ChatPlatformMattermost ChatPlatform = "MATTERMOST"
+)
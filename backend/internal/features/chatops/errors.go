@@ -0,0 +1,14 @@
+package chatops
+
+import "errors"
+
+var (
+	ErrChatAccountNotFound        = errors.New("chat account not found")
+	ErrSlackCommandsNotConfigured = errors.New(
+		"slack commands are not configured, set CHATOPS_SLACK_SIGNING_SECRET",
+	)
+	ErrMattermostCommandsNotConfigured = errors.New(
+		"mattermost commands are not configured, set CHATOPS_MATTERMOST_TOKEN",
+	)
+	ErrInvalidCommandSignature = errors.New("invalid command signature")
+)
@@ -0,0 +1,22 @@
+package chatops
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatAccount links a Slack or Mattermost user to a Databasus user, slash commands of the
+// chat user run with permissions of the linked user
+type ChatAccount struct {
+	ID         uuid.UUID    `json:"id"         gorm:"column:id;type:uuid;primaryKey"`
+	UserID     uuid.UUID    `json:"userId"     gorm:"column:user_id;type:uuid;not null"`
+	Platform   ChatPlatform `json:"platform"   gorm:"column:platform;type:text;not null"`
+	TeamID     string       `json:"teamId"     gorm:"column:team_id;type:text;not null"`
+	ChatUserID string       `json:"chatUserId" gorm:"column:chat_user_id;type:text;not null"`
+	CreatedAt  time.Time    `json:"createdAt"  gorm:"column:created_at"`
+}
+
+func (ChatAccount) TableName() string {
+	return "chat_accounts"
+}
@@ -0,0 +1,73 @@
+package chatops
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ChatAccountRepository struct{}
+
+func (r *ChatAccountRepository) Save(account *ChatAccount) error {
+	return storage.GetDb().Save(account).Error
+}
+
+func (r *ChatAccountRepository) FindByID(id uuid.UUID) (*ChatAccount, error) {
+	var account ChatAccount
+
+	if err := storage.GetDb().Where("id = ?", id).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *ChatAccountRepository) FindByChatUser(
+	platform ChatPlatform,
+	teamID string,
+	chatUserID string,
+) (*ChatAccount, error) {
+	var account ChatAccount
+
+	if err := storage.
+		GetDb().
+		Where(
+			"platform = ? AND team_id = ? AND chat_user_id = ?",
+			platform,
+			teamID,
+			chatUserID,
+		).
+		First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *ChatAccountRepository) FindByUserID(userID uuid.UUID) ([]*ChatAccount, error) {
+	var accounts []*ChatAccount
+
+	if err := storage.
+		GetDb().
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *ChatAccountRepository) Delete(account *ChatAccount) error {
+	return storage.GetDb().Delete(&ChatAccount{}, "id = ?", account.ID).Error
+}
@@ -0,0 +1,544 @@
+package chatops
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
+
+	"github.com/google/uuid"
+)
+
+const (
+	linkCodeLength   = 8
+	linkCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	linkCodeTTL      = 10 * time.Minute
+
+	lastFailuresPeriod = 24 * time.Hour
+	lastFailuresLimit  = 10
+	failMessageLength  = 120
+
+	responseTypeEphemeral = "ephemeral"
+)
+
+type ChatopsService struct {
+	chatAccountRepository *ChatAccountRepository
+	backupRepository      *backups_core.BackupRepository
+	backupService         *backups.BackupService
+	userService           *users_services.UserService
+	workspaceService      *workspaces_services.WorkspaceService
+	databaseService       *databases.DatabaseService
+	auditLogService       *audit_logs.AuditLogService
+	linkCodeCache         *cache_utils.CacheUtil[linkCode]
+	logger                *slog.Logger
+}
+
+// GenerateLinkCode returns a short-lived code the user sends with "/databasus link <code>"
+// to link the chat account they send it from
+func (s *ChatopsService) GenerateLinkCode(user *users_models.User) (*LinkCodeResponse, error) {
+	code := make([]byte, linkCodeLength)
+	for i := range code {
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(linkCodeAlphabet))))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate link code: %w", err)
+		}
+
+		code[i] = linkCodeAlphabet[index.Int64()]
+	}
+
+	s.linkCodeCache.SetWithExpiration(string(code), &linkCode{UserID: user.ID}, linkCodeTTL)
+
+	return &LinkCodeResponse{
+		Code:      string(code),
+		ExpiresAt: time.Now().UTC().Add(linkCodeTTL),
+	}, nil
+}
+
+func (s *ChatopsService) GetChatAccounts(user *users_models.User) ([]*ChatAccount, error) {
+	return s.chatAccountRepository.FindByUserID(user.ID)
+}
+
+func (s *ChatopsService) UnlinkChatAccount(user *users_models.User, accountID uuid.UUID) error {
+	account, err := s.chatAccountRepository.FindByID(accountID)
+	if err != nil {
+		return err
+	}
+
+	// accounts of other users are reported as missing, so their IDs cannot be probed
+	if account == nil || account.UserID != user.ID {
+		return ErrChatAccountNotFound
+	}
+
+	if err := s.chatAccountRepository.Delete(account); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Chat account unlinked: %s user %s", account.Platform, account.ChatUserID),
+		&user.ID,
+		nil,
+	)
+
+	return nil
+}
+
+// HandleSlackCommand verifies the signature of the request with the signing secret of the
+// Slack app and runs the command
+func (s *ChatopsService) HandleSlackCommand(
+	payload []byte,
+	timestamp string,
+	signature string,
+) (*SlashCommandResponse, error) {
+	secret := config.GetEnv().ChatopsSlackSigningSecret
+	if secret == "" {
+		return nil, ErrSlackCommandsNotConfigured
+	}
+
+	if err := verifySlackSignature(
+		payload,
+		timestamp,
+		signature,
+		secret,
+		time.Now().UTC(),
+	); err != nil {
+		return nil, err
+	}
+
+	values, err := url.ParseQuery(string(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse slack command: %w", err)
+	}
+
+	return s.runCommand(ChatPlatformSlack, &SlashCommand{
+		TeamID:  values.Get("team_id"),
+		UserID:  values.Get("user_id"),
+		Command: values.Get("command"),
+		Text:    values.Get("text"),
+	}), nil
+}
+
+// HandleMattermostCommand verifies the token of the Mattermost slash command and runs it
+func (s *ChatopsService) HandleMattermostCommand(
+	command *SlashCommand,
+) (*SlashCommandResponse, error) {
+	token := config.GetEnv().ChatopsMattermostToken
+	if token == "" {
+		return nil, ErrMattermostCommandsNotConfigured
+	}
+
+	if err := verifyMattermostToken(command.Token, token); err != nil {
+		return nil, err
+	}
+
+	return s.runCommand(ChatPlatformMattermost, command), nil
+}
+
+func (s *ChatopsService) runCommand(
+	platform ChatPlatform,
+	command *SlashCommand,
+) *SlashCommandResponse {
+	args := strings.Fields(command.Text)
+
+	name := ""
+	if len(args) > 0 {
+		name = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	switch name {
+	case "", "help":
+		return reply(s.getHelp(command))
+	case "link":
+		return reply(s.linkChatAccount(platform, command, args))
+	}
+
+	account, err := s.chatAccountRepository.FindByChatUser(platform, command.TeamID, command.UserID)
+	if err != nil {
+		s.logger.Error("Failed to find chat account", "platform", platform, "error", err)
+		return reply("Failed to find your linked account, try again later")
+	}
+
+	if account == nil {
+		return reply(fmt.Sprintf(
+			"Your chat account is not linked to Databasus. "+
+				"Generate a link code in Databasus and run `%s link <code>`",
+			getCommandName(command),
+		))
+	}
+
+	user, err := s.userService.GetUserByID(account.UserID)
+	if err != nil || !user.IsActiveUser() {
+		return reply("Your Databasus user is not active")
+	}
+
+	switch name {
+	case "status":
+		return reply(s.getDatabaseStatus(user, args))
+	case "backup":
+		return reply(s.startBackup(user, args))
+	case "last-failures":
+		return reply(s.getLastFailures(user))
+	case "unlink":
+		return reply(s.unlinkCurrentAccount(user, account))
+	default:
+		return reply(fmt.Sprintf("Unknown command %q\n\n%s", name, s.getHelp(command)))
+	}
+}
+
+func (s *ChatopsService) getHelp(command *SlashCommand) string {
+	commandName := getCommandName(command)
+
+	return strings.Join([]string{
+		"Databasus commands:",
+		fmt.Sprintf("`%s status <database>` shows the last backups of the database", commandName),
+		fmt.Sprintf("`%s backup <database>` starts a backup of the database", commandName),
+		fmt.Sprintf("`%s last-failures` lists failed backups of the last 24 hours", commandName),
+		fmt.Sprintf("`%s link <code>` links your chat account to Databasus", commandName),
+		fmt.Sprintf("`%s unlink` unlinks your chat account", commandName),
+		"Databases with the same name in several workspaces are named as `<workspace>/<database>`",
+	}, "\n")
+}
+
+func (s *ChatopsService) linkChatAccount(
+	platform ChatPlatform,
+	command *SlashCommand,
+	args []string,
+) string {
+	if len(args) != 1 {
+		return fmt.Sprintf("Usage: `%s link <code>`", getCommandName(command))
+	}
+
+	if command.TeamID == "" || command.UserID == "" {
+		return "The command does not identify your chat account"
+	}
+
+	code := s.linkCodeCache.GetAndDelete(strings.ToUpper(args[0]))
+	if code == nil {
+		return "The link code is invalid or expired, generate a new one in Databasus"
+	}
+
+	user, err := s.userService.GetUserByID(code.UserID)
+	if err != nil || !user.IsActiveUser() {
+		return "Your Databasus user is not active"
+	}
+
+	account, err := s.chatAccountRepository.FindByChatUser(platform, command.TeamID, command.UserID)
+	if err != nil {
+		s.logger.Error("Failed to find chat account", "platform", platform, "error", err)
+		return "Failed to link your chat account, try again later"
+	}
+
+	// a chat user links one Databasus user, linking again moves the account
+	if account == nil {
+		account = &ChatAccount{
+			ID:         uuid.New(),
+			Platform:   platform,
+			TeamID:     command.TeamID,
+			ChatUserID: command.UserID,
+			CreatedAt:  time.Now().UTC(),
+		}
+	}
+	account.UserID = user.ID
+
+	if err := s.chatAccountRepository.Save(account); err != nil {
+		s.logger.Error("Failed to save chat account", "platform", platform, "error", err)
+		return "Failed to link your chat account, try again later"
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Chat account linked: %s user %s", platform, command.UserID),
+		&user.ID,
+		nil,
+	)
+
+	return fmt.Sprintf("Your chat account is linked to %s", user.Email)
+}
+
+func (s *ChatopsService) unlinkCurrentAccount(
+	user *users_models.User,
+	account *ChatAccount,
+) string {
+	if err := s.UnlinkChatAccount(user, account.ID); err != nil {
+		s.logger.Error("Failed to unlink chat account", "accountId", account.ID, "error", err)
+		return "Failed to unlink your chat account, try again later"
+	}
+
+	return "Your chat account is unlinked"
+}
+
+func (s *ChatopsService) getDatabaseStatus(user *users_models.User, args []string) string {
+	if len(args) != 1 {
+		return "Usage: `status <database>`"
+	}
+
+	database, workspaceName, err := s.findDatabase(user, args[0])
+	if err != nil {
+		return err.Error()
+	}
+
+	lines := []string{fmt.Sprintf("%s (workspace %s)", database.Name, workspaceName)}
+
+	latestBackups, err := s.backupRepository.FindByDatabaseIDWithLimit(database.ID, 1)
+	if err != nil {
+		s.logger.Error("Failed to get backups", "databaseId", database.ID, "error", err)
+		return "Failed to get backups of the database, try again later"
+	}
+
+	if len(latestBackups) == 0 {
+		return strings.Join(append(lines, "No backups yet"), "\n")
+	}
+
+	now := time.Now().UTC()
+	lastBackup := latestBackups[0]
+	lines = append(lines, fmt.Sprintf(
+		"Last backup: %s %s",
+		lastBackup.Status,
+		formatAge(now.Sub(lastBackup.CreatedAt)),
+	))
+
+	if lastBackup.Status == backups_core.BackupStatusFailed && lastBackup.FailMessage != nil {
+		lines = append(lines, "Error: "+truncate(*lastBackup.FailMessage, failMessageLength))
+	}
+
+	if lastBackup.Status != backups_core.BackupStatusCompleted {
+		lastCompletedBackups, err := s.backupRepository.FindLastCompletedByDatabaseIDs(
+			[]uuid.UUID{database.ID},
+		)
+		if err != nil {
+			s.logger.Error("Failed to get backups", "databaseId", database.ID, "error", err)
+			return "Failed to get backups of the database, try again later"
+		}
+
+		lastCompletedBackup, isFound := lastCompletedBackups[database.ID]
+		if isFound {
+			lastBackup = lastCompletedBackup
+		} else {
+			return strings.Join(append(lines, "No successful backups yet"), "\n")
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf(
+		"Last successful backup: %s, %.2f MB",
+		formatAge(now.Sub(lastBackup.CreatedAt)),
+		lastBackup.BackupSizeMb,
+	))
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *ChatopsService) startBackup(user *users_models.User, args []string) string {
+	if len(args) != 1 {
+		return "Usage: `backup <database>`"
+	}
+
+	database, workspaceName, err := s.findDatabase(user, args[0])
+	if err != nil {
+		return err.Error()
+	}
+
+	if err := s.backupService.MakeBackupWithAuth(user, database.ID, nil); err != nil {
+		return fmt.Sprintf("Failed to start a backup of %s: %s", database.Name, err.Error())
+	}
+
+	return fmt.Sprintf(
+		"Backup of %s (workspace %s) started, run `status %s` to follow it",
+		database.Name,
+		workspaceName,
+		args[0],
+	)
+}
+
+func (s *ChatopsService) getLastFailures(user *users_models.User) string {
+	userDatabases, err := s.getUserDatabases(user)
+	if err != nil {
+		s.logger.Error("Failed to get databases of user", "userId", user.ID, "error", err)
+		return "Failed to get your databases, try again later"
+	}
+
+	now := time.Now().UTC()
+
+	type failure struct {
+		backup        *backups_core.Backup
+		databaseName  string
+		workspaceName string
+	}
+	var failures []failure
+
+	for _, userDatabase := range userDatabases {
+		failedBackups, err := s.backupRepository.FindByDatabaseIdAndStatusCreatedAfter(
+			userDatabase.database.ID,
+			backups_core.BackupStatusFailed,
+			now.Add(-lastFailuresPeriod),
+		)
+		if err != nil {
+			s.logger.Error(
+				"Failed to get failed backups",
+				"databaseId", userDatabase.database.ID,
+				"error", err,
+			)
+			return "Failed to get failed backups, try again later"
+		}
+
+		for _, backup := range failedBackups {
+			failures = append(failures, failure{
+				backup:        backup,
+				databaseName:  userDatabase.database.Name,
+				workspaceName: userDatabase.workspaceName,
+			})
+		}
+	}
+
+	if len(failures) == 0 {
+		return "No failed backups in the last 24 hours"
+	}
+
+	slices.SortFunc(failures, func(a, b failure) int {
+		return b.backup.CreatedAt.Compare(a.backup.CreatedAt)
+	})
+
+	lines := []string{fmt.Sprintf("Failed backups in the last 24 hours: %d", len(failures))}
+	for _, failure := range failures[:min(len(failures), lastFailuresLimit)] {
+		line := fmt.Sprintf(
+			"%s (workspace %s) %s",
+			failure.databaseName,
+			failure.workspaceName,
+			formatAge(now.Sub(failure.backup.CreatedAt)),
+		)
+		if failure.backup.FailMessage != nil {
+			line += ": " + truncate(*failure.backup.FailMessage, failMessageLength)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type userDatabase struct {
+	database      *databases.Database
+	workspaceName string
+}
+
+func (s *ChatopsService) getUserDatabases(user *users_models.User) ([]userDatabase, error) {
+	workspaces, err := s.workspaceService.GetUserWorkspaces(user)
+	if err != nil {
+		return nil, err
+	}
+
+	var userDatabases []userDatabase
+	for _, workspace := range workspaces.Workspaces {
+		workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspace.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, database := range workspaceDatabases {
+			userDatabases = append(userDatabases, userDatabase{
+				database:      database,
+				workspaceName: workspace.Name,
+			})
+		}
+	}
+
+	return userDatabases, nil
+}
+
+// findDatabase finds a database of the workspaces of the user by name, "<workspace>/<name>"
+// picks one of databases with the same name in several workspaces
+func (s *ChatopsService) findDatabase(
+	user *users_models.User,
+	reference string,
+) (*databases.Database, string, error) {
+	workspaceName, databaseName, isWorkspaceGiven := strings.Cut(reference, "/")
+	if !isWorkspaceGiven {
+		databaseName = reference
+	}
+
+	userDatabases, err := s.getUserDatabases(user)
+	if err != nil {
+		s.logger.Error("Failed to get databases of user", "userId", user.ID, "error", err)
+		return nil, "", fmt.Errorf("failed to get your databases, try again later")
+	}
+
+	var matches []userDatabase
+	for _, userDatabase := range userDatabases {
+		if !strings.EqualFold(userDatabase.database.Name, databaseName) {
+			continue
+		}
+
+		if isWorkspaceGiven && !strings.EqualFold(userDatabase.workspaceName, workspaceName) {
+			continue
+		}
+
+		matches = append(matches, userDatabase)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, "", fmt.Errorf(
+			"database %q is not found among databases you can access",
+			reference,
+		)
+	case 1:
+		return matches[0].database, matches[0].workspaceName, nil
+	default:
+		references := make([]string, 0, len(matches))
+		for _, match := range matches {
+			references = append(references, match.workspaceName+"/"+match.database.Name)
+		}
+
+		return nil, "", fmt.Errorf(
+			"several databases are named %q, pick one of: %s",
+			reference,
+			strings.Join(references, ", "),
+		)
+	}
+}
+
+func reply(text string) *SlashCommandResponse {
+	return &SlashCommandResponse{ResponseType: responseTypeEphemeral, Text: text}
+}
+
+func getCommandName(command *SlashCommand) string {
+	if command.Command == "" {
+		return "/databasus"
+	}
+
+	return command.Command
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
+
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	return string(runes[:maxLength]) + "..."
+}
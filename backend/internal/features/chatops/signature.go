@@ -0,0 +1,63 @@
+package chatops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const slackSignatureTolerance = 5 * time.Minute
+
+// verifySlackSignature checks the X-Slack-Signature header, "v0=" and the hex HMAC of
+// "v0:<timestamp>:<body>" with the signing secret of the app. Old timestamps are rejected
+// so captured requests cannot be replayed
+func verifySlackSignature(
+	payload []byte,
+	timestamp string,
+	signature string,
+	secret string,
+	now time.Time,
+) error {
+	unixTimestamp, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidCommandSignature
+	}
+
+	signedAt := time.Unix(unixTimestamp, 0)
+	if now.Sub(signedAt).Abs() > slackSignatureTolerance {
+		return ErrInvalidCommandSignature
+	}
+
+	decodedSignature, err := hex.DecodeString(strings.TrimPrefix(signature, "v0="))
+	if err != nil || !strings.HasPrefix(signature, "v0=") {
+		return ErrInvalidCommandSignature
+	}
+
+	if !hmac.Equal(decodedSignature, computeSlackSignature(payload, timestamp, secret)) {
+		return ErrInvalidCommandSignature
+	}
+
+	return nil
+}
+
+func computeSlackSignature(payload []byte, timestamp string, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// verifyMattermostToken checks the token Mattermost sends with each request of the slash
+// command, Mattermost does not sign requests
+func verifyMattermostToken(token string, expectedToken string) error {
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
+		return ErrInvalidCommandSignature
+	}
+
+	return nil
+}
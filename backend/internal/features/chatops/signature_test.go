@@ -0,0 +1,65 @@
+package chatops
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifySlackSignature_WithValidSignature_Succeeds(t *testing.T) {
+	payload := []byte("team_id=T1&user_id=U1&command=%2Fdatabasus&text=last-failures")
+	secret := "slack_secret"
+	now := time.Now().UTC()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signature := "v0=" + hex.EncodeToString(computeSlackSignature(payload, timestamp, secret))
+
+	assert.NoError(t, verifySlackSignature(payload, timestamp, signature, secret, now))
+}
+
+func Test_VerifySlackSignature_WithInvalidInput_ReturnsError(t *testing.T) {
+	payload := []byte("team_id=T1&user_id=U1&text=status+orders")
+	secret := "slack_secret"
+	now := time.Now().UTC()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := "v0=" + hex.EncodeToString(computeSlackSignature(payload, timestamp, secret))
+
+	staleTimestamp := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+	staleSignature := "v0=" + hex.EncodeToString(
+		computeSlackSignature(payload, staleTimestamp, secret),
+	)
+
+	testCases := []struct {
+		name      string
+		payload   []byte
+		timestamp string
+		signature string
+	}{
+		{"changed payload", []byte("team_id=T1&user_id=U2"), timestamp, signature},
+		{"replayed request", payload, staleTimestamp, staleSignature},
+		{"missing version", payload, timestamp, signature[3:]},
+		{"missing timestamp", payload, "", signature},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := verifySlackSignature(
+				testCase.payload,
+				testCase.timestamp,
+				testCase.signature,
+				secret,
+				now,
+			)
+			assert.ErrorIs(t, err, ErrInvalidCommandSignature)
+		})
+	}
+}
+
+func Test_VerifyMattermostToken_WithWrongToken_ReturnsError(t *testing.T) {
+	assert.NoError(t, verifyMattermostToken("token", "token"))
+	assert.ErrorIs(t, verifyMattermostToken("other", "token"), ErrInvalidCommandSignature)
+	assert.ErrorIs(t, verifyMattermostToken("", ""), ErrInvalidCommandSignature)
+}
@@ -0,0 +1,134 @@
+package client_certificates
+
+import (
+	"errors"
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ClientCertificateController struct {
+	clientCertificateService *ClientCertificateService
+}
+
+func (c *ClientCertificateController) RegisterRoutes(router *gin.RouterGroup) {
+	adminOnly := users_middleware.RequireRole(users_enums.UserRoleAdmin)
+
+	router.GET("/client-certificates", adminOnly, c.GetCertificates)
+	router.POST("/client-certificates", adminOnly, c.RegisterCertificate)
+	router.DELETE("/client-certificates/:id", adminOnly, c.DeleteCertificate)
+}
+
+// GetCertificates
+// @Summary Get client certificates
+// @Description Get TLS client certificates registered for mutual TLS authentication (admin only)
+// @Tags client-certificates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} ClientCertificate
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /client-certificates [get]
+func (c *ClientCertificateController) GetCertificates(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	certificates, err := c.clientCertificateService.GetCertificates(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, certificates)
+}
+
+// RegisterCertificate
+// @Summary Register client certificate
+// @Description Map a PEM encoded TLS client certificate to the user it signs in as (admin only)
+// @Tags client-certificates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RegisterClientCertificateRequest true "Client certificate"
+// @Success 201 {object} ClientCertificate
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /client-certificates [post]
+func (c *ClientCertificateController) RegisterCertificate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request RegisterClientCertificateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	certificate, err := c.clientCertificateService.RegisterCertificate(user, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, certificate)
+}
+
+// DeleteCertificate
+// @Summary Delete client certificate
+// @Description Revoke a client certificate, it no longer signs in its user (admin only)
+// @Tags client-certificates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Client certificate ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /client-certificates/{id} [delete]
+func (c *ClientCertificateController) DeleteCertificate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid client certificate ID"})
+		return
+	}
+
+	if err := c.clientCertificateService.DeleteCertificate(user, id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Client certificate deleted"})
+}
+
+func (c *ClientCertificateController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrOnlyAdminsCanManageClientCertificates):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrClientCertificateNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrClientCertificateAlreadyRegistered):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
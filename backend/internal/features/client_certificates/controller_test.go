@@ -0,0 +1,169 @@
+package client_certificates
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	SetupDependencies()
+
+	return workspaces_testing.CreateTestRouter(GetClientCertificateController())
+}
+
+func Test_RegisterCertificate_RequestWithCertificate_SignedInAsMappedUser(t *testing.T) {
+	router := createTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	serviceAccount := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	certificate, certificatePEM := createTestCertificate(t)
+
+	var registered ClientCertificate
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/client-certificates",
+		"Bearer "+admin.Token,
+		RegisterClientCertificateRequest{
+			Name:        "CI pipeline",
+			UserID:      serviceAccount.UserID,
+			Certificate: certificatePEM,
+		},
+		http.StatusCreated,
+		&registered,
+	)
+	defer func() { _ = clientCertificateRepository.Delete(registered.ID) }()
+
+	assert.Equal(t, serviceAccount.UserID, registered.UserID)
+	assert.Equal(t, GetCertificateFingerprint(certificate), registered.Fingerprint)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/client-certificates",
+		"Bearer "+admin.Token,
+		RegisterClientCertificateRequest{
+			Name:        "CI pipeline",
+			UserID:      serviceAccount.UserID,
+			Certificate: certificatePEM,
+		},
+		http.StatusConflict,
+	)
+
+	assert.Equal(t, http.StatusOK, makeRequestWithCertificate(router, certificate))
+
+	stored, err := clientCertificateRepository.FindByID(registered.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/client-certificates/"+registered.ID.String(),
+		"Bearer "+admin.Token,
+		http.StatusOK,
+	)
+
+	assert.Equal(t, http.StatusUnauthorized, makeRequestWithCertificate(router, certificate))
+}
+
+func Test_RegisterCertificate_WithInvalidRequest_Rejected(t *testing.T) {
+	router := createTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	_, certificatePEM := createTestCertificate(t)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/client-certificates",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/client-certificates",
+		"Bearer "+admin.Token,
+		RegisterClientCertificateRequest{
+			Name:        "Broken",
+			UserID:      member.UserID,
+			Certificate: "not a certificate",
+		},
+		http.StatusBadRequest,
+	)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/client-certificates",
+		"Bearer "+admin.Token,
+		RegisterClientCertificateRequest{
+			Name:        "Unknown user",
+			UserID:      uuid.New(),
+			Certificate: certificatePEM,
+		},
+		http.StatusBadRequest,
+	)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/client-certificates/"+uuid.New().String(),
+		"Bearer "+admin.Token,
+		http.StatusNotFound,
+	)
+}
+
+// makeRequestWithCertificate sends a request without a JWT as if the TLS server verified
+// the client certificate
+func makeRequestWithCertificate(router *gin.Engine, certificate *x509.Certificate) int {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/client-certificates", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{certificate}}}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w.Code
+}
+
+func createTestCertificate(t *testing.T) (*x509.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "ci-" + uuid.New().String()[:8]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return certificate, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
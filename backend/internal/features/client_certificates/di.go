@@ -0,0 +1,51 @@
+package client_certificates
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_repositories "databasus-backend/internal/features/users/repositories"
+	users_services "databasus-backend/internal/features/users/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var clientCertificateRepository = &ClientCertificateRepository{}
+var clientCertificateService = &ClientCertificateService{
+	clientCertificateRepository,
+	users_repositories.GetUserRepository(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var clientCertificateController = &ClientCertificateController{
+	clientCertificateService,
+}
+
+func GetClientCertificateService() *ClientCertificateService {
+	return clientCertificateService
+}
+
+func GetClientCertificateController() *ClientCertificateController {
+	return clientCertificateController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		users_services.GetUserService().SetClientCertificateAuthenticator(
+			clientCertificateService,
+		)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
@@ -0,0 +1,10 @@
+package client_certificates
+
+import "github.com/google/uuid"
+
+type RegisterClientCertificateRequest struct {
+	Name   string    `json:"name"   binding:"required"`
+	UserID uuid.UUID `json:"userId" binding:"required"`
+	// Certificate is the PEM encoded client certificate, without its private key
+	Certificate string `json:"certificate" binding:"required"`
+}
@@ -0,0 +1,21 @@
+package client_certificates
+
+import "errors"
+
+var (
+	ErrOnlyAdminsCanManageClientCertificates = errors.New(
+		"only administrators can manage client certificates",
+	)
+	ErrClientCertificateNotFound = errors.New(
+		"client certificate not found",
+	)
+	ErrClientCertificateAlreadyRegistered = errors.New(
+		"client certificate is already registered",
+	)
+	ErrClientCertificateNotRegistered = errors.New(
+		"client certificate is not registered",
+	)
+	ErrClientCertificateExpired = errors.New(
+		"client certificate has expired",
+	)
+)
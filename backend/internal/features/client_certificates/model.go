@@ -0,0 +1,28 @@
+package client_certificates
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClientCertificate maps a TLS client certificate to the user automation signs in as,
+// usually a user dedicated to it like a service account
+type ClientCertificate struct {
+	ID     uuid.UUID `json:"id"     gorm:"column:id;type:uuid;primaryKey"`
+	UserID uuid.UUID `json:"userId" gorm:"column:user_id;type:uuid;not null"`
+	Name   string    `json:"name"   gorm:"column:name;type:text;not null"`
+
+	// Fingerprint is the SHA-256 of the DER encoded certificate in hex. Certificates are
+	// matched by it, so a renewed certificate is registered again
+	Fingerprint string    `json:"fingerprint" gorm:"column:fingerprint;type:text;not null"`
+	Subject     string    `json:"subject"     gorm:"column:subject;type:text;not null"`
+	NotAfter    time.Time `json:"notAfter"    gorm:"column:not_after;type:timestamptz;not null"`
+
+	CreatedAt  time.Time  `json:"createdAt"  gorm:"column:created_at;type:timestamptz;not null"`
+	LastUsedAt *time.Time `json:"lastUsedAt" gorm:"column:last_used_at;type:timestamptz"`
+}
+
+func (ClientCertificate) TableName() string {
+	return "client_certificates"
+}
@@ -0,0 +1,71 @@
+package client_certificates
+
+import (
+	"errors"
+	"time"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ClientCertificateRepository struct{}
+
+func (r *ClientCertificateRepository) Create(certificate *ClientCertificate) error {
+	if certificate.ID == uuid.Nil {
+		certificate.ID = uuid.New()
+	}
+
+	certificate.CreatedAt = time.Now().UTC()
+
+	return storage.GetDb().Create(certificate).Error
+}
+
+func (r *ClientCertificateRepository) FindAll() ([]*ClientCertificate, error) {
+	var certificates []*ClientCertificate
+
+	err := storage.GetDb().Order("created_at DESC").Find(&certificates).Error
+
+	return certificates, err
+}
+
+func (r *ClientCertificateRepository) FindByID(id uuid.UUID) (*ClientCertificate, error) {
+	return r.findOne("id = ?", id)
+}
+
+func (r *ClientCertificateRepository) FindByFingerprint(
+	fingerprint string,
+) (*ClientCertificate, error) {
+	return r.findOne("fingerprint = ?", fingerprint)
+}
+
+func (r *ClientCertificateRepository) UpdateLastUsedAt(id uuid.UUID, lastUsedAt time.Time) error {
+	return storage.GetDb().
+		Model(&ClientCertificate{}).
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).
+		Error
+}
+
+func (r *ClientCertificateRepository) Delete(id uuid.UUID) error {
+	return storage.GetDb().Delete(&ClientCertificate{}, "id = ?", id).Error
+}
+
+func (r *ClientCertificateRepository) findOne(
+	query string,
+	args ...any,
+) (*ClientCertificate, error) {
+	var certificate ClientCertificate
+
+	err := storage.GetDb().Where(query, args...).First(&certificate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &certificate, nil
+}
@@ -0,0 +1,186 @@
+package client_certificates
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	users_repositories "databasus-backend/internal/features/users/repositories"
+
+	"github.com/google/uuid"
+)
+
+// lastUsedUpdateInterval limits writes of LastUsedAt, automation may call the API often
+const lastUsedUpdateInterval = 5 * time.Minute
+
+type ClientCertificateService struct {
+	clientCertificateRepository *ClientCertificateRepository
+	userRepository              *users_repositories.UserRepository
+	auditLogService             *audit_logs.AuditLogService
+	logger                      *slog.Logger
+}
+
+func (s *ClientCertificateService) GetCertificates(
+	user *users_models.User,
+) ([]*ClientCertificate, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanManageClientCertificates
+	}
+
+	return s.clientCertificateRepository.FindAll()
+}
+
+func (s *ClientCertificateService) RegisterCertificate(
+	user *users_models.User,
+	request *RegisterClientCertificateRequest,
+) (*ClientCertificate, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanManageClientCertificates
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	certificate, err := parseCertificatePEM(request.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(certificate.NotAfter) {
+		return nil, ErrClientCertificateExpired
+	}
+
+	if err := verifyClientCertificate(certificate); err != nil {
+		return nil, err
+	}
+
+	targetUser, err := s.userRepository.GetUserByID(request.UserID)
+	if err != nil || targetUser == nil {
+		return nil, errors.New("user not found")
+	}
+
+	if !targetUser.IsActiveUser() {
+		return nil, errors.New("certificates can be registered only for active users")
+	}
+
+	fingerprint := GetCertificateFingerprint(certificate)
+
+	existing, err := s.clientCertificateRepository.FindByFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrClientCertificateAlreadyRegistered
+	}
+
+	clientCertificate := &ClientCertificate{
+		UserID:      targetUser.ID,
+		Name:        name,
+		Fingerprint: fingerprint,
+		Subject:     certificate.Subject.String(),
+		NotAfter:    certificate.NotAfter.UTC(),
+	}
+
+	if err := s.clientCertificateRepository.Create(clientCertificate); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Client certificate registered: %s for user %s",
+			clientCertificate.Name,
+			targetUser.Email,
+		),
+		&user.ID,
+		nil,
+	)
+
+	return clientCertificate, nil
+}
+
+func (s *ClientCertificateService) DeleteCertificate(user *users_models.User, id uuid.UUID) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return ErrOnlyAdminsCanManageClientCertificates
+	}
+
+	clientCertificate, err := s.clientCertificateRepository.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if clientCertificate == nil {
+		return ErrClientCertificateNotFound
+	}
+
+	if err := s.clientCertificateRepository.Delete(id); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Client certificate deleted: %s", clientCertificate.Name),
+		&user.ID,
+		nil,
+	)
+
+	return nil
+}
+
+// FindUserIDByCertificate implements users_interfaces.ClientCertificateAuthenticator
+func (s *ClientCertificateService) FindUserIDByCertificate(
+	certificate *x509.Certificate,
+) (uuid.UUID, error) {
+	clientCertificate, err := s.clientCertificateRepository.FindByFingerprint(
+		GetCertificateFingerprint(certificate),
+	)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if clientCertificate == nil {
+		return uuid.Nil, ErrClientCertificateNotRegistered
+	}
+
+	now := time.Now().UTC()
+	if now.After(clientCertificate.NotAfter) {
+		return uuid.Nil, ErrClientCertificateExpired
+	}
+
+	if clientCertificate.LastUsedAt == nil ||
+		now.Sub(*clientCertificate.LastUsedAt) > lastUsedUpdateInterval {
+		if err := s.clientCertificateRepository.UpdateLastUsedAt(
+			clientCertificate.ID,
+			now,
+		); err != nil {
+			s.logger.Error("Failed to update client certificate usage", "error", err)
+		}
+	}
+
+	return clientCertificate.UserID, nil
+}
+
+// verifyClientCertificate checks certificates against the client CA when mutual TLS is
+// enabled, so certificates the TLS server would reject are not registered
+func verifyClientCertificate(certificate *x509.Certificate) error {
+	clientCAs, err := LoadClientCAPool()
+	if err != nil {
+		return err
+	}
+	if clientCAs == nil {
+		return nil
+	}
+
+	if _, err := certificate.Verify(x509.VerifyOptions{
+		Roots:     clientCAs,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("certificate is not issued by the API mTLS client CA: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package client_certificates
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"databasus-backend/internal/config"
+)
+
+// GetCertificateFingerprint returns the SHA-256 of the DER encoded certificate in hex
+func GetCertificateFingerprint(certificate *x509.Certificate) string {
+	hash := sha256.Sum256(certificate.Raw)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// LoadClientCAPool returns the CA issuing client certificates, nil when mutual TLS is
+// disabled
+func LoadClientCAPool() (*x509.CertPool, error) {
+	clientCAFile := config.GetEnv().APIMTLSClientCAFile
+	if clientCAFile == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API mTLS client CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, errors.New("API mTLS client CA file contains no PEM certificates")
+	}
+
+	return pool, nil
+}
+
+func parseCertificatePEM(content string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(content))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("certificate must be a PEM encoded CERTIFICATE block")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return certificate, nil
+}
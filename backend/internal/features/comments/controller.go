@@ -0,0 +1,173 @@
+package comments
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CommentController struct {
+	commentService *CommentService
+}
+
+func (c *CommentController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/comments", c.GetComments)
+	router.POST("/comments", c.CreateComment)
+	router.PUT("/comments/:id", c.UpdateComment)
+	router.DELETE("/comments/:id", c.DeleteComment)
+}
+
+// GetComments
+// @Summary Get comments of a resource
+// @Description List markdown comments of a database, storage or notifier, oldest first
+// @Tags comments
+// @Produce json
+// @Param resourceType query string true "DATABASE, STORAGE or NOTIFIER"
+// @Param resourceId query string true "Resource ID"
+// @Success 200 {array} CommentDTO
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /comments [get]
+func (c *CommentController) GetComments(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request GetCommentsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, err := c.commentService.GetComments(user, request.ResourceType, request.ResourceID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comments)
+}
+
+// CreateComment
+// @Summary Add a comment to a resource
+// @Description Add a markdown comment to a database, storage or notifier of the workspace
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param request body CreateCommentRequest true "Comment"
+// @Success 200 {object} Comment
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /comments [post]
+func (c *CommentController) CreateComment(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CreateCommentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := c.commentService.CreateComment(user, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// UpdateComment
+// @Summary Update a comment
+// @Description Change the text of an own comment
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ID"
+// @Param request body UpdateCommentRequest true "Comment text"
+// @Success 200 {object} Comment
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /comments/{id} [put]
+func (c *CommentController) UpdateComment(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	commentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		return
+	}
+
+	var request UpdateCommentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := c.commentService.UpdateComment(user, commentID, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment
+// @Summary Delete a comment
+// @Description Delete an own comment, workspace admins may delete any comment of the workspace
+// @Tags comments
+// @Param id path string true "Comment ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /comments/{id} [delete]
+func (c *CommentController) DeleteComment(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	commentID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		return
+	}
+
+	if err := c.commentService.DeleteComment(user, commentID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "comment deleted successfully"})
+}
+
+func (c *CommentController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrInsufficientPermissionsToViewComments) ||
+		errors.Is(err, ErrInsufficientPermissionsToComment) ||
+		errors.Is(err, ErrInsufficientPermissionsToChangeComment) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
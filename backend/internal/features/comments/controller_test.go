@@ -0,0 +1,152 @@
+package comments
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/notifiers"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_models "databasus-backend/internal/features/workspaces/models"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/storage"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		notifiers.GetNotifierController(),
+		GetCommentController(),
+	)
+}
+
+func Test_Comments_WhenCommentedByMember_ListedAndChangedByAuthorOnly(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+
+	request := CreateCommentRequest{
+		ResourceType: ResourceTypeNotifier,
+		ResourceID:   notifier.ID,
+		Text:         "Routed to the **on-call** channel",
+	}
+
+	var comment Comment
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/comments",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&comment,
+	)
+	assert.Equal(t, owner.UserID, comment.AuthorID)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/comments",
+		"Bearer "+viewer.Token,
+		request,
+		http.StatusForbidden,
+	)
+
+	listURL := "/api/v1/comments?resourceType=NOTIFIER&resourceId=" + notifier.ID.String()
+
+	var comments []CommentDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		listURL,
+		"Bearer "+viewer.Token,
+		http.StatusOK,
+		&comments,
+	)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, request.Text, comments[0].Text)
+	assert.Equal(t, owner.Email, *comments[0].AuthorEmail)
+
+	test_utils.MakeGetRequest(t, router, listURL, "Bearer "+outsider.Token, http.StatusForbidden)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/comments/"+comment.ID.String(),
+		"Bearer "+viewer.Token,
+		UpdateCommentRequest{Text: "Changed"},
+		http.StatusForbidden,
+	)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/comments/"+comment.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		listURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&comments,
+	)
+	assert.Empty(t, comments)
+}
+
+func Test_UpdateNotifier_WhenWorkspaceRequiresChangeReason_ReasonRequired(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+
+	err := storage.GetDb().Model(&workspaces_models.Workspace{}).
+		Where("id = ?", workspace.ID).
+		Update("is_change_reason_required", true).Error
+	assert.NoError(t, err)
+
+	notifier.Name = "Renamed notifier"
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/notifiers",
+		"Bearer "+owner.Token,
+		notifier,
+		http.StatusBadRequest,
+	)
+
+	notifier.ChangeReason = "Ticket OPS-42"
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/notifiers",
+		"Bearer "+owner.Token,
+		notifier,
+		http.StatusOK,
+	)
+}
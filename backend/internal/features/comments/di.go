@@ -0,0 +1,30 @@
+package comments
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var commentRepository = &CommentRepository{}
+var commentService = &CommentService{
+	commentRepository,
+	databases.GetDatabaseService(),
+	storages.GetStorageService(),
+	notifiers.GetNotifierService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+}
+var commentController = &CommentController{
+	commentService,
+}
+
+func GetCommentService() *CommentService {
+	return commentService
+}
+
+func GetCommentController() *CommentController {
+	return commentController
+}
@@ -0,0 +1,34 @@
+package comments
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetCommentsRequest struct {
+	ResourceType ResourceType `form:"resourceType" binding:"required"`
+	ResourceID   uuid.UUID    `form:"resourceId"   binding:"required"`
+}
+
+type CreateCommentRequest struct {
+	ResourceType ResourceType `json:"resourceType" binding:"required"`
+	ResourceID   uuid.UUID    `json:"resourceId"   binding:"required"`
+	Text         string       `json:"text"         binding:"required"`
+}
+
+type UpdateCommentRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+type CommentDTO struct {
+	ID           uuid.UUID    `json:"id"           gorm:"column:id"`
+	ResourceType ResourceType `json:"resourceType" gorm:"column:resource_type"`
+	ResourceID   uuid.UUID    `json:"resourceId"   gorm:"column:resource_id"`
+	AuthorID     uuid.UUID    `json:"authorId"     gorm:"column:author_id"`
+	AuthorEmail  *string      `json:"authorEmail"  gorm:"column:author_email"`
+	AuthorName   *string      `json:"authorName"   gorm:"column:author_name"`
+	Text         string       `json:"text"         gorm:"column:text"`
+	CreatedAt    time.Time    `json:"createdAt"    gorm:"column:created_at"`
+	UpdatedAt    time.Time    `json:"updatedAt"    gorm:"column:updated_at"`
+}
@@ -0,0 +1,24 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInsufficientPermissionsToViewComments = errors.New(
+		"insufficient permissions to view comments of this resource",
+	)
+	ErrInsufficientPermissionsToComment = errors.New(
+		"insufficient permissions to comment on this resource",
+	)
+	ErrInsufficientPermissionsToChangeComment = errors.New(
+		"only the author or workspace admins can change this comment",
+	)
+	ErrInvalidResourceType = errors.New("resource type must be DATABASE, STORAGE or NOTIFIER")
+	ErrCommentTextRequired = errors.New("comment text is required")
+	ErrCommentTextTooLong  = fmt.Errorf(
+		"comment text must be at most %d characters",
+		maxCommentTextLength,
+	)
+)
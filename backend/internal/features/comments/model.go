@@ -0,0 +1,41 @@
+package comments
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ResourceType string
+
+const (
+	ResourceTypeDatabase ResourceType = "DATABASE"
+	ResourceTypeStorage  ResourceType = "STORAGE"
+	ResourceTypeNotifier ResourceType = "NOTIFIER"
+)
+
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceTypeDatabase, ResourceTypeStorage, ResourceTypeNotifier:
+		return true
+	default:
+		return false
+	}
+}
+
+// Comment is a markdown note on a database, storage or notifier. WorkspaceID is the
+// workspace owning the resource when the comment was written
+type Comment struct {
+	ID           uuid.UUID    `json:"id"           gorm:"column:id;type:uuid;primaryKey"`
+	ResourceType ResourceType `json:"resourceType" gorm:"column:resource_type;type:text;not null"`
+	ResourceID   uuid.UUID    `json:"resourceId"   gorm:"column:resource_id;type:uuid;not null"`
+	WorkspaceID  uuid.UUID    `json:"workspaceId"  gorm:"column:workspace_id;type:uuid;not null"`
+	AuthorID     uuid.UUID    `json:"authorId"     gorm:"column:author_id;type:uuid;not null"`
+	Text         string       `json:"text"         gorm:"column:text;type:text;not null"`
+	CreatedAt    time.Time    `json:"createdAt"    gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt    time.Time    `json:"updatedAt"    gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (Comment) TableName() string {
+	return "resource_comments"
+}
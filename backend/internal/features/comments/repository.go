@@ -0,0 +1,60 @@
+package comments
+
+import (
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type CommentRepository struct{}
+
+func (r *CommentRepository) Save(comment *Comment) error {
+	if comment.ID == uuid.Nil {
+		comment.ID = uuid.New()
+	}
+
+	return storage.GetDb().Save(comment).Error
+}
+
+func (r *CommentRepository) FindByID(id uuid.UUID) (*Comment, error) {
+	var comment Comment
+
+	if err := storage.GetDb().Where("id = ?", id).First(&comment).Error; err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// FindByResource returns comments oldest first with their authors
+func (r *CommentRepository) FindByResource(
+	resourceType ResourceType,
+	resourceID uuid.UUID,
+) ([]*CommentDTO, error) {
+	comments := make([]*CommentDTO, 0)
+
+	err := storage.GetDb().Raw(`
+		SELECT
+			c.id,
+			c.resource_type,
+			c.resource_id,
+			c.author_id,
+			c.text,
+			c.created_at,
+			c.updated_at,
+			u.email as author_email,
+			u.name as author_name
+		FROM resource_comments c
+		LEFT JOIN users u ON c.author_id = u.id
+		WHERE c.resource_type = ? AND c.resource_id = ?
+		ORDER BY c.created_at ASC`,
+		resourceType,
+		resourceID,
+	).Scan(&comments).Error
+
+	return comments, err
+}
+
+func (r *CommentRepository) Delete(id uuid.UUID) error {
+	return storage.GetDb().Where("id = ?", id).Delete(&Comment{}).Error
+}
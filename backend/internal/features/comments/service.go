@@ -0,0 +1,221 @@
+package comments
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const maxCommentTextLength = 10000
+
+type CommentService struct {
+	commentRepository *CommentRepository
+	databaseService   *databases.DatabaseService
+	storageService    *storages.StorageService
+	notifierService   *notifiers.NotifierService
+	workspaceService  *workspaces_services.WorkspaceService
+	auditLogService   *audit_logs.AuditLogService
+}
+
+func (s *CommentService) GetComments(
+	user *users_models.User,
+	resourceType ResourceType,
+	resourceID uuid.UUID,
+) ([]*CommentDTO, error) {
+	resource, err := s.getResource(resourceType, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(resource.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewComments
+	}
+
+	return s.commentRepository.FindByResource(resourceType, resourceID)
+}
+
+func (s *CommentService) CreateComment(
+	user *users_models.User,
+	request *CreateCommentRequest,
+) (*Comment, error) {
+	text, err := validateCommentText(request.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := s.getResource(request.ResourceType, request.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(resource.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToComment
+	}
+
+	now := time.Now().UTC()
+	comment := &Comment{
+		ResourceType: request.ResourceType,
+		ResourceID:   request.ResourceID,
+		WorkspaceID:  resource.WorkspaceID,
+		AuthorID:     user.ID,
+		Text:         text,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := s.commentRepository.Save(comment); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Comment added to %s: %s",
+			getResourceLabel(request.ResourceType),
+			resource.Name,
+		),
+		&user.ID,
+		&resource.WorkspaceID,
+	)
+
+	return comment, nil
+}
+
+// UpdateComment is allowed to the author only, so comments are not put in their mouth
+func (s *CommentService) UpdateComment(
+	user *users_models.User,
+	commentID uuid.UUID,
+	request *UpdateCommentRequest,
+) (*Comment, error) {
+	text, err := validateCommentText(request.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepository.FindByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.AuthorID != user.ID {
+		return nil, ErrInsufficientPermissionsToChangeComment
+	}
+
+	comment.Text = text
+	comment.UpdatedAt = time.Now().UTC()
+
+	if err := s.commentRepository.Save(comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment is allowed to the author and to admins of the workspace, e.g. to remove
+// outdated notes of former members
+func (s *CommentService) DeleteComment(user *users_models.User, commentID uuid.UUID) error {
+	comment, err := s.commentRepository.FindByID(commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.AuthorID != user.ID {
+		canManage, err := s.workspaceService.CanUserManageWorkspace(comment.WorkspaceID, user)
+		if err != nil {
+			return err
+		}
+		if !canManage {
+			return ErrInsufficientPermissionsToChangeComment
+		}
+	}
+
+	if err := s.commentRepository.Delete(comment.ID); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Comment deleted from %s %s",
+			getResourceLabel(comment.ResourceType),
+			comment.ResourceID,
+		),
+		&user.ID,
+		&comment.WorkspaceID,
+	)
+
+	return nil
+}
+
+type commentedResource struct {
+	WorkspaceID uuid.UUID
+	Name        string
+}
+
+func (s *CommentService) getResource(
+	resourceType ResourceType,
+	resourceID uuid.UUID,
+) (*commentedResource, error) {
+	switch resourceType {
+	case ResourceTypeDatabase:
+		database, err := s.databaseService.GetDatabaseByID(resourceID)
+		if err != nil {
+			return nil, err
+		}
+		if database.WorkspaceID == nil {
+			return nil, errors.New("database does not belong to a workspace")
+		}
+
+		return &commentedResource{*database.WorkspaceID, database.Name}, nil
+	case ResourceTypeStorage:
+		storage, err := s.storageService.GetStorageByID(resourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &commentedResource{storage.WorkspaceID, storage.Name}, nil
+	case ResourceTypeNotifier:
+		notifier, err := s.notifierService.GetNotifierByID(resourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &commentedResource{notifier.WorkspaceID, notifier.Name}, nil
+	default:
+		return nil, ErrInvalidResourceType
+	}
+}
+
+func getResourceLabel(resourceType ResourceType) string {
+	return strings.ToLower(string(resourceType))
+}
+
+func validateCommentText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		return "", ErrCommentTextRequired
+	}
+
+	if len(text) > maxCommentTextLength {
+		return "", ErrCommentTextTooLong
+	}
+
+	return text, nil
+}
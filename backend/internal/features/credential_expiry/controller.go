@@ -0,0 +1,70 @@
+package credential_expiry
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CredentialExpiryController struct {
+	credentialExpiryService *CredentialExpiryService
+}
+
+func (c *CredentialExpiryController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/credential-expiry/workspace/:workspaceId", c.GetExpiringCredentials)
+}
+
+// GetExpiringCredentials
+// @Summary Get expiring credentials
+// @Description List databases and storages of the workspace whose credentials expire within the days (30 by default), expired ones included
+// @Tags credential-expiry
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param days query int false "Days ahead, 1 to 365"
+// @Success 200 {object} ExpiringCredentialsResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /credential-expiry/workspace/{workspaceId} [get]
+func (c *CredentialExpiryController) GetExpiringCredentials(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request GetExpiringCredentialsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := c.credentialExpiryService.GetExpiringCredentials(
+		user,
+		workspaceID,
+		request.Days,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewCredentials) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
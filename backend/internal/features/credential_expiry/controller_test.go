@@ -0,0 +1,99 @@
+package credential_expiry
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/storage"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetCredentialExpiryController(),
+	)
+}
+
+func Test_GetExpiringCredentials_WhenCredentialsExpireSoon_Listed(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	testStorage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(testStorage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	now := time.Now().UTC()
+
+	err := storage.GetDb().Model(&databases.Database{}).
+		Where("id = ?", database.ID).
+		Update("credentials_expire_at", now.Add(-time.Hour)).Error
+	assert.NoError(t, err)
+
+	err = storage.GetDb().Model(&storages.Storage{}).
+		Where("id = ?", testStorage.ID).
+		Update("credentials_expire_at", now.AddDate(0, 0, 5)).Error
+	assert.NoError(t, err)
+
+	var response ExpiringCredentialsResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/credential-expiry/workspace/"+workspace.ID.String()+"?days=7",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, 7, response.WithinDays)
+	assert.Len(t, response.Credentials, 2)
+	assert.Equal(t, CredentialTypeDatabase, response.Credentials[0].Type)
+	assert.True(t, response.Credentials[0].IsExpired)
+	assert.Equal(t, CredentialTypeStorage, response.Credentials[1].Type)
+	assert.Equal(t, 5, response.Credentials[1].DaysLeft)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/credential-expiry/workspace/"+workspace.ID.String()+"?days=1",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&response,
+	)
+	assert.Len(t, response.Credentials, 1)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/credential-expiry/workspace/"+workspace.ID.String(),
+		"Bearer "+outsider.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_GetReminderDay_WhenDaysLeftChange_NextReminderDayReturned(t *testing.T) {
+	assert.Equal(t, 0, getReminderDay(0))
+	assert.Equal(t, 1, getReminderDay(1))
+	assert.Equal(t, 7, getReminderDay(2))
+	assert.Equal(t, 7, getReminderDay(7))
+	assert.Equal(t, 14, getReminderDay(8))
+	assert.Equal(t, 14, getReminderDay(14))
+}
@@ -0,0 +1,43 @@
+package credential_expiry
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var credentialReminderRepository = &CredentialReminderRepository{}
+var credentialExpiryService = &CredentialExpiryService{
+	credentialReminderRepository,
+	databases.GetDatabaseService(),
+	storages.GetStorageService(),
+	notifiers.GetNotifierService(),
+	workspaces_services.GetWorkspaceService(),
+	logger.GetLogger(),
+}
+var credentialExpiryController = &CredentialExpiryController{
+	credentialExpiryService,
+}
+var credentialExpiryBackgroundService = &CredentialExpiryBackgroundService{
+	credentialExpiryService: credentialExpiryService,
+	logger:                  logger.GetLogger(),
+	runOnce:                 sync.Once{},
+	hasRun:                  atomic.Bool{},
+}
+
+func GetCredentialExpiryService() *CredentialExpiryService {
+	return credentialExpiryService
+}
+
+func GetCredentialExpiryController() *CredentialExpiryController {
+	return credentialExpiryController
+}
+
+func GetCredentialExpiryBackgroundService() *CredentialExpiryBackgroundService {
+	return credentialExpiryBackgroundService
+}
@@ -0,0 +1,26 @@
+package credential_expiry
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetExpiringCredentialsRequest struct {
+	Days int `form:"days"`
+}
+
+type ExpiringCredential struct {
+	Type        CredentialType `json:"type"`
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	WorkspaceID uuid.UUID      `json:"workspaceId"`
+	ExpireAt    time.Time      `json:"expireAt"`
+	DaysLeft    int            `json:"daysLeft"`
+	IsExpired   bool           `json:"isExpired"`
+}
+
+type ExpiringCredentialsResponse struct {
+	Credentials []ExpiringCredential `json:"credentials"`
+	WithinDays  int                  `json:"withinDays"`
+}
@@ -0,0 +1,13 @@
+package credential_expiry
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInsufficientPermissionsToViewCredentials = errors.New(
+		"insufficient permissions to view credentials of this workspace",
+	)
+	ErrInvalidWithinDays = fmt.Errorf("days must be between 1 and %d", maxWithinDays)
+)
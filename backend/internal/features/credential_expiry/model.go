@@ -0,0 +1,27 @@
+package credential_expiry
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CredentialType string
+
+const (
+	CredentialTypeDatabase CredentialType = "DATABASE"
+	CredentialTypeStorage  CredentialType = "STORAGE"
+)
+
+// CredentialReminder is the last reminder sent for credentials of a database or storage.
+// ExpireAt is the expiry it was sent for, a new expiry date starts reminders over
+type CredentialReminder struct {
+	ResourceID   uuid.UUID `gorm:"column:resource_id;type:uuid;primaryKey"`
+	ExpireAt     time.Time `gorm:"column:expire_at;type:timestamptz;not null"`
+	ReminderDays int       `gorm:"column:reminder_days;type:int;not null"`
+	SentAt       time.Time `gorm:"column:sent_at;type:timestamptz;not null"`
+}
+
+func (CredentialReminder) TableName() string {
+	return "credential_reminders"
+}
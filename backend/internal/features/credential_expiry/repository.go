@@ -0,0 +1,36 @@
+package credential_expiry
+
+import (
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type CredentialReminderRepository struct{}
+
+func (r *CredentialReminderRepository) Save(reminder *CredentialReminder) error {
+	return storage.GetDb().Save(reminder).Error
+}
+
+func (r *CredentialReminderRepository) FindByResourceIDs(
+	resourceIDs []uuid.UUID,
+) (map[uuid.UUID]*CredentialReminder, error) {
+	reminders := make(map[uuid.UUID]*CredentialReminder, len(resourceIDs))
+	if len(resourceIDs) == 0 {
+		return reminders, nil
+	}
+
+	var found []*CredentialReminder
+	if err := storage.
+		GetDb().
+		Where("resource_id IN ?", resourceIDs).
+		Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	for _, reminder := range found {
+		reminders[reminder.ResourceID] = reminder
+	}
+
+	return reminders, nil
+}
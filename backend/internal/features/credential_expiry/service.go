@@ -0,0 +1,299 @@
+package credential_expiry
+
+import (
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultWithinDays = 30
+	maxWithinDays     = 365
+)
+
+// reminderDays are the days before expiry reminders are sent at, 0 is sent once the
+// credentials expired. Sorted ascending
+var reminderDays = []int{0, 1, 7, 14}
+
+type CredentialExpiryService struct {
+	credentialReminderRepository *CredentialReminderRepository
+	databaseService              *databases.DatabaseService
+	storageService               *storages.StorageService
+	notifierService              *notifiers.NotifierService
+	workspaceService             *workspaces_services.WorkspaceService
+	logger                       *slog.Logger
+}
+
+// GetExpiringCredentials lists databases and storages of the workspace whose credentials
+// expire within the days, already expired ones first
+func (s *CredentialExpiryService) GetExpiringCredentials(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	withinDays int,
+	now time.Time,
+) (*ExpiringCredentialsResponse, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewCredentials
+	}
+
+	if withinDays == 0 {
+		withinDays = defaultWithinDays
+	}
+	if withinDays < 0 || withinDays > maxWithinDays {
+		return nil, ErrInvalidWithinDays
+	}
+
+	expireBefore := now.AddDate(0, 0, withinDays)
+	credentials := make([]ExpiringCredential, 0)
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, database := range workspaceDatabases {
+		if database.CredentialsExpireAt == nil ||
+			!database.CredentialsExpireAt.Before(expireBefore) {
+			continue
+		}
+
+		credentials = append(credentials, newExpiringCredential(
+			CredentialTypeDatabase,
+			database.ID,
+			database.Name,
+			workspaceID,
+			*database.CredentialsExpireAt,
+			now,
+		))
+	}
+
+	workspaceStorages, err := s.storageService.GetWorkspaceStorages(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storage := range workspaceStorages {
+		if storage.CredentialsExpireAt == nil ||
+			!storage.CredentialsExpireAt.Before(expireBefore) {
+			continue
+		}
+
+		credentials = append(credentials, newExpiringCredential(
+			CredentialTypeStorage,
+			storage.ID,
+			storage.Name,
+			storage.WorkspaceID,
+			*storage.CredentialsExpireAt,
+			now,
+		))
+	}
+
+	slices.SortFunc(credentials, func(a, b ExpiringCredential) int {
+		return a.ExpireAt.Compare(b.ExpireAt)
+	})
+
+	return &ExpiringCredentialsResponse{Credentials: credentials, WithinDays: withinDays}, nil
+}
+
+// SendReminders notifies about credentials reaching the next reminder day. Notifiers of
+// the database are reminded about its connection, default notifiers of the workspace
+// owning the storage about storages
+func (s *CredentialExpiryService) SendReminders(now time.Time) error {
+	expireBefore := now.AddDate(0, 0, reminderDays[len(reminderDays)-1])
+
+	expiringDatabases, err := s.databaseService.GetDatabasesWithCredentialsExpiringBefore(
+		expireBefore,
+	)
+	if err != nil {
+		return err
+	}
+
+	expiringStorages, err := s.storageService.GetStoragesWithCredentialsExpiringBefore(
+		expireBefore,
+	)
+	if err != nil {
+		return err
+	}
+
+	resourceIDs := make([]uuid.UUID, 0, len(expiringDatabases)+len(expiringStorages))
+	for _, database := range expiringDatabases {
+		resourceIDs = append(resourceIDs, database.ID)
+	}
+	for _, storage := range expiringStorages {
+		resourceIDs = append(resourceIDs, storage.ID)
+	}
+
+	reminders, err := s.credentialReminderRepository.FindByResourceIDs(resourceIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, database := range expiringDatabases {
+		if database.WorkspaceID == nil {
+			continue
+		}
+
+		databaseNotifiers := make([]*notifiers.Notifier, 0, len(database.Notifiers))
+		for i := range database.Notifiers {
+			databaseNotifiers = append(databaseNotifiers, &database.Notifiers[i])
+		}
+
+		s.remind(
+			database.ID,
+			database.Name,
+			*database.WorkspaceID,
+			*database.CredentialsExpireAt,
+			databaseNotifiers,
+			reminders[database.ID],
+			now,
+		)
+	}
+
+	for _, storage := range expiringStorages {
+		defaultNotifiers, err := s.notifierService.GetWorkspaceDefaultNotifiers(
+			storage.WorkspaceID,
+		)
+		if err != nil {
+			s.logger.Error(
+				"Failed to get default notifiers for credential reminder",
+				"storageId", storage.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		s.remind(
+			storage.ID,
+			storage.Name,
+			storage.WorkspaceID,
+			*storage.CredentialsExpireAt,
+			defaultNotifiers,
+			reminders[storage.ID],
+			now,
+		)
+	}
+
+	return nil
+}
+
+func (s *CredentialExpiryService) remind(
+	resourceID uuid.UUID,
+	name string,
+	workspaceID uuid.UUID,
+	expireAt time.Time,
+	reminderNotifiers []*notifiers.Notifier,
+	lastReminder *CredentialReminder,
+	now time.Time,
+) {
+	daysLeft := getDaysLeft(expireAt, now)
+	reminderDay := getReminderDay(daysLeft)
+
+	if lastReminder != nil &&
+		lastReminder.ExpireAt.Equal(expireAt) &&
+		lastReminder.ReminderDays <= reminderDay {
+		return
+	}
+
+	if len(reminderNotifiers) > 0 {
+		workspace, err := s.workspaceService.GetWorkspaceByID(workspaceID)
+		if err != nil {
+			s.logger.Error(
+				"Failed to get workspace for credential reminder",
+				"resourceId", resourceID,
+				"error", err,
+			)
+			return
+		}
+
+		params := map[string]string{
+			"name":      name,
+			"workspace": workspace.Name,
+			"days":      strconv.Itoa(daysLeft),
+			"date":      expireAt.UTC().Format(time.DateOnly),
+		}
+
+		titleKey := i18n.MessageCredentialsExpiringTitle
+		messageKey := i18n.MessageCredentialsExpiringMessage
+		eventType := notifiers.NotificationEventCredentialsExpiring
+		if !expireAt.After(now) {
+			titleKey = i18n.MessageCredentialsExpiredTitle
+			messageKey = i18n.MessageCredentialsExpiredMessage
+			eventType = notifiers.NotificationEventCredentialsExpired
+		}
+
+		for _, notifier := range reminderNotifiers {
+			s.notifierService.SendEventNotification(
+				notifier,
+				eventType,
+				i18n.Translate(notifier.Locale, titleKey, params),
+				i18n.Translate(notifier.Locale, messageKey, params),
+			)
+		}
+	}
+
+	if err := s.credentialReminderRepository.Save(&CredentialReminder{
+		ResourceID:   resourceID,
+		ExpireAt:     expireAt,
+		ReminderDays: reminderDay,
+		SentAt:       now,
+	}); err != nil {
+		s.logger.Error(
+			"Failed to save credential reminder",
+			"resourceId", resourceID,
+			"error", err,
+		)
+	}
+}
+
+func newExpiringCredential(
+	credentialType CredentialType,
+	id uuid.UUID,
+	name string,
+	workspaceID uuid.UUID,
+	expireAt time.Time,
+	now time.Time,
+) ExpiringCredential {
+	return ExpiringCredential{
+		Type:        credentialType,
+		ID:          id,
+		Name:        name,
+		WorkspaceID: workspaceID,
+		ExpireAt:    expireAt,
+		DaysLeft:    getDaysLeft(expireAt, now),
+		IsExpired:   !expireAt.After(now),
+	}
+}
+
+// getDaysLeft rounds up, credentials expiring later today have 1 day left
+func getDaysLeft(expireAt time.Time, now time.Time) int {
+	if !expireAt.After(now) {
+		return 0
+	}
+
+	return int(math.Ceil(expireAt.Sub(now).Hours() / 24))
+}
+
+func getReminderDay(daysLeft int) int {
+	for _, day := range reminderDays {
+		if daysLeft <= day {
+			return day
+		}
+	}
+
+	return reminderDays[len(reminderDays)-1]
+}
@@ -1,10 +1,13 @@
 package databases
 
 import (
+	"databasus-backend/internal/features/ownership"
 	users_middleware "databasus-backend/internal/features/users/middleware"
 	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/etag"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,16 +22,14 @@ type DatabaseController struct {
 func (c *DatabaseController) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/databases/create", c.CreateDatabase)
 	router.POST("/databases/update", c.UpdateDatabase)
-	router.DELETE("/databases/:id", c.DeleteDatabase)
-	router.GET("/databases/:id", c.GetDatabase)
-	router.GET("/databases", c.GetDatabases)
-	router.POST("/databases/:id/test-connection", c.TestDatabaseConnection)
-	router.POST("/databases/test-connection-direct", c.TestDatabaseConnectionDirect)
-	router.POST("/databases/:id/copy", c.CopyDatabase)
-	router.GET("/databases/notifier/:id/is-using", c.IsNotifierUsing)
-	router.GET("/databases/notifier/:id/databases-count", c.CountDatabasesByNotifier)
-	router.POST("/databases/is-readonly", c.IsUserReadOnly)
-	router.POST("/databases/create-readonly-user", c.CreateReadOnlyUser)
+	c.registerSharedRoutes(router)
+}
+
+// RegisterRoutesV2 registers /api/v2 routes, where create and update are resource paths
+func (c *DatabaseController) RegisterRoutesV2(router *gin.RouterGroup) {
+	router.POST("/databases", c.CreateDatabase)
+	router.PUT("/databases/:id", c.UpdateDatabaseByID)
+	c.registerSharedRoutes(router)
 }
 
 // CreateDatabase
@@ -103,6 +104,40 @@ func (c *DatabaseController) UpdateDatabase(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, request)
 }
 
+// UpdateDatabaseByID is PUT /api/v2/databases/:id, the ID is taken from the path
+func (c *DatabaseController) UpdateDatabaseByID(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request Database
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.ID != uuid.Nil && request.ID != id {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id in body does not match path"})
+		return
+	}
+	request.ID = id
+
+	if err := c.databaseService.UpdateDatabase(user, &request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
 // DeleteDatabase
 // @Summary Delete a database
 // @Description Delete a database configuration
@@ -163,7 +198,7 @@ func (c *DatabaseController) GetDatabase(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, database)
+	etag.JSON(ctx, database)
 }
 
 // GetDatabases
@@ -172,6 +207,8 @@ func (c *DatabaseController) GetDatabase(ctx *gin.Context) {
 // @Tags databases
 // @Produce json
 // @Param workspace_id query string true "Workspace ID"
+// @Param owner_user_id query string false "Only databases owned by the user"
+// @Param owner_team query string false "Only databases owned by the team"
 // @Success 200 {array} Database
 // @Failure 400
 // @Failure 401
@@ -196,13 +233,23 @@ func (c *DatabaseController) GetDatabases(ctx *gin.Context) {
 		return
 	}
 
+	var ownerFilter ownership.Filter
+	if err := ctx.ShouldBindQuery(&ownerFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	databases, err := c.databaseService.GetDatabasesByWorkspace(user, workspaceID)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, databases)
+	databases = slices.DeleteFunc(databases, func(database *Database) bool {
+		return !ownerFilter.Matches(database.Ownership)
+	})
+
+	etag.JSON(ctx, databases)
 }
 
 // TestDatabaseConnection
@@ -333,6 +380,38 @@ func (c *DatabaseController) CountDatabasesByNotifier(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"count": count})
 }
 
+// AttachNotifierToAllDatabases
+// @Summary Attach a notifier to all databases
+// @Description Attach a notifier to every database of its workspace which lacks it, except databases opted out of default notifiers
+// @Tags databases
+// @Produce json
+// @Param id path string true "Notifier ID"
+// @Success 200 {object} map[string]int
+// @Failure 400
+// @Failure 401
+// @Router /databases/notifier/{id}/attach-to-all [post]
+func (c *DatabaseController) AttachNotifierToAllDatabases(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notifier ID"})
+		return
+	}
+
+	count, err := c.databaseService.AttachNotifierToAllDatabases(user, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"count": count})
+}
+
 // CopyDatabase
 // @Summary Copy a database
 // @Description Copy an existing database configuration
@@ -438,3 +517,17 @@ func (c *DatabaseController) CreateReadOnlyUser(ctx *gin.Context) {
 		Password: password,
 	})
 }
+
+func (c *DatabaseController) registerSharedRoutes(router *gin.RouterGroup) {
+	router.DELETE("/databases/:id", c.DeleteDatabase)
+	router.GET("/databases/:id", c.GetDatabase)
+	router.GET("/databases", c.GetDatabases)
+	router.POST("/databases/:id/test-connection", c.TestDatabaseConnection)
+	router.POST("/databases/test-connection-direct", c.TestDatabaseConnectionDirect)
+	router.POST("/databases/:id/copy", c.CopyDatabase)
+	router.GET("/databases/notifier/:id/is-using", c.IsNotifierUsing)
+	router.GET("/databases/notifier/:id/databases-count", c.CountDatabasesByNotifier)
+	router.POST("/databases/notifier/:id/attach-to-all", c.AttachNotifierToAllDatabases)
+	router.POST("/databases/is-readonly", c.IsUserReadOnly)
+	router.POST("/databases/create-readonly-user", c.CreateReadOnlyUser)
+}
@@ -16,6 +16,8 @@ import (
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/postgresql"
+	"databasus-backend/internal/features/environments"
+	"databasus-backend/internal/features/notifiers"
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_testing "databasus-backend/internal/features/users/testing"
 	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
@@ -256,6 +258,59 @@ func Test_UpdateDatabase_WhenUserIsNotWorkspaceMember_ReturnsForbidden(t *testin
 	assert.Contains(t, string(testResp.Body), "insufficient permissions")
 }
 
+func Test_UpdateDatabase_WhenMemberChangesProdLabel_ReturnsError(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+	defer RemoveTestDatabase(database)
+
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		member,
+		users_enums.WorkspaceRoleMember,
+		owner.Token,
+		router,
+	)
+
+	database.Environment = environments.EnvironmentProd
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/databases/update",
+		"Bearer "+member.Token,
+		database,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), ErrInsufficientPermissionsToChangeEnvironment.Error())
+
+	var response Database
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/databases/update",
+		"Bearer "+owner.Token,
+		database,
+		http.StatusOK,
+		&response,
+	)
+	assert.Equal(t, environments.EnvironmentProd, response.Environment)
+
+	// members cannot lift the label either
+	database.Environment = environments.EnvironmentDev
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/databases/update",
+		"Bearer "+member.Token,
+		database,
+		http.StatusBadRequest,
+	)
+}
+
 func Test_DeleteDatabase_PermissionsEnforced(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -1050,6 +1105,99 @@ func Test_TestConnection_PermissionsEnforced(t *testing.T) {
 	}
 }
 
+func Test_CreateDatabase_WorkspaceDefaultNotifiersAttachedUnlessOptedOut(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	defaultNotifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(defaultNotifier)
+	defaultNotifier.IsWorkspaceDefault = true
+	_, err := notifiers.GetNotifierRepository().Save(defaultNotifier)
+	assert.NoError(t, err)
+
+	database := createTestDatabaseViaAPI("Database 1", workspace.ID, owner.Token, router)
+	defer RemoveTestDatabase(database)
+
+	assert.True(t, database.HasNotifier(defaultNotifier.ID))
+
+	optedOutRequest := Database{
+		Name:                     "Database 2",
+		WorkspaceID:              &workspace.ID,
+		Type:                     DatabaseTypePostgres,
+		Postgresql:               getTestPostgresConfig(),
+		IsDefaultNotifiersOptOut: true,
+	}
+
+	var optedOutDatabase Database
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/databases/create",
+		"Bearer "+owner.Token,
+		optedOutRequest,
+		http.StatusCreated,
+		&optedOutDatabase,
+	)
+	defer RemoveTestDatabase(&optedOutDatabase)
+
+	assert.False(t, optedOutDatabase.HasNotifier(defaultNotifier.ID))
+}
+
+func Test_AttachNotifierToAllDatabases_OptedOutDatabasesSkipped(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+
+	database := createTestDatabaseViaAPI("Database 1", workspace.ID, owner.Token, router)
+	defer RemoveTestDatabase(database)
+
+	optedOutDatabase := createTestDatabaseViaAPI("Database 2", workspace.ID, owner.Token, router)
+	defer RemoveTestDatabase(optedOutDatabase)
+	optedOutDatabase.IsDefaultNotifiersOptOut = true
+	_, err := GetDatabaseService().dbRepository.Save(optedOutDatabase)
+	assert.NoError(t, err)
+
+	var response map[string]int
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/notifier/%s/attach-to-all", notifier.ID.String()),
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&response,
+	)
+	assert.Equal(t, 1, response["count"])
+
+	var updatedDatabase Database
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s", database.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&updatedDatabase,
+	)
+	assert.True(t, updatedDatabase.HasNotifier(notifier.ID))
+
+	var updatedOptedOutDatabase Database
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s", optedOutDatabase.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&updatedOptedOutDatabase,
+	)
+	assert.False(t, updatedOptedOutDatabase.HasNotifier(notifier.ID))
+}
+
 func createTestDatabaseViaAPI(
 	name string,
 	workspaceID uuid.UUID,
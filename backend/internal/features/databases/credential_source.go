@@ -0,0 +1,405 @@
+package databases
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+type CredentialSourceType string
+
+const (
+	CredentialSourceTypeWebhook CredentialSourceType = "WEBHOOK"
+	CredentialSourceTypeVault   CredentialSourceType = "VAULT"
+)
+
+const (
+	credentialSourceRequestTimeout = 30 * time.Second
+	maxCredentialResponseBytes     = 64 * 1024
+
+	// fetched credentials are reused by connection tests and health checks until they are
+	// about to expire, backups always fetch fresh ones
+	credentialReuseMargin = 5 * time.Minute
+	defaultCredentialTTL  = 15 * time.Minute
+
+	// credentialSourcePlaceholder satisfies password validation of the engine, the password
+	// is fetched from the source before connecting
+	credentialSourcePlaceholder = "credential-source"
+)
+
+// CredentialSource fetches short-lived credentials of the connection instead of storing a
+// long-lived password. A webhook is POSTed the database ID and name and answers with
+// {"username", "password", "expiresAt"}, Vault is read at a dynamic secrets path such as
+// database/creds/backup. An empty username keeps the username of the connection
+type CredentialSource struct {
+	Type CredentialSourceType `json:"type"`
+
+	WebhookURL string `json:"webhookUrl,omitempty"`
+	// WebhookSecret is sent as a bearer token
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+
+	VaultAddress   string `json:"vaultAddress,omitempty"`
+	VaultToken     string `json:"vaultToken,omitempty"`
+	VaultNamespace string `json:"vaultNamespace,omitempty"`
+	VaultPath      string `json:"vaultPath,omitempty"`
+}
+
+type fetchedCredentials struct {
+	Username  string
+	Password  string
+	ExpiresAt time.Time
+}
+
+type credentialCacheKey struct {
+	DatabaseID  uuid.UUID
+	Fingerprint string
+}
+
+var (
+	credentialCache      = make(map[credentialCacheKey]fetchedCredentials)
+	credentialCacheMutex sync.Mutex
+)
+
+func (s *CredentialSource) Validate(databaseType DatabaseType) error {
+	switch databaseType {
+	case DatabaseTypePostgres, DatabaseTypeMysql, DatabaseTypeMariadb, DatabaseTypeMongodb:
+	default:
+		return errors.New(
+			"credential sources support only PostgreSQL, MySQL, MariaDB and MongoDB databases",
+		)
+	}
+
+	switch s.Type {
+	case CredentialSourceTypeWebhook:
+		return validateCredentialSourceURL(s.WebhookURL, "webhook URL")
+	case CredentialSourceTypeVault:
+		if err := validateCredentialSourceURL(s.VaultAddress, "Vault address"); err != nil {
+			return err
+		}
+		if s.VaultToken == "" {
+			return errors.New("vault token is required")
+		}
+		if strings.Trim(s.VaultPath, "/") == "" {
+			return errors.New("vault secret path is required")
+		}
+		return nil
+	default:
+		return errors.New("invalid credential source type: " + string(s.Type))
+	}
+}
+
+func (s *CredentialSource) HideSensitiveData() {
+	s.WebhookSecret = ""
+	s.VaultToken = ""
+}
+
+func (s *CredentialSource) EncryptSensitiveFields(
+	databaseID uuid.UUID,
+	encryptor encryption.FieldEncryptor,
+) error {
+	var err error
+
+	if s.WebhookSecret != "" {
+		if s.WebhookSecret, err = encryptor.Encrypt(databaseID, s.WebhookSecret); err != nil {
+			return err
+		}
+	}
+
+	if s.VaultToken != "" {
+		if s.VaultToken, err = encryptor.Encrypt(databaseID, s.VaultToken); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update keeps the stored secrets when the incoming source leaves them empty, as they are
+// hidden in responses
+func (s *CredentialSource) Update(incoming *CredentialSource) {
+	webhookSecret := s.WebhookSecret
+	vaultToken := s.VaultToken
+
+	*s = *incoming
+
+	if s.WebhookSecret == "" {
+		s.WebhookSecret = webhookSecret
+	}
+	if s.VaultToken == "" {
+		s.VaultToken = vaultToken
+	}
+}
+
+// ApplyCredentialSource sets credentials of the source on the connection, reusing fetched
+// ones while they are valid. Nothing happens for databases without a source
+func (d *Database) ApplyCredentialSource(encryptor encryption.FieldEncryptor) error {
+	return d.applyCredentialSource(encryptor, false)
+}
+
+// ApplyFreshCredentials always fetches new credentials from the source, so long running
+// backups do not start with credentials about to expire
+func (d *Database) ApplyFreshCredentials(encryptor encryption.FieldEncryptor) error {
+	return d.applyCredentialSource(encryptor, true)
+}
+
+func (d *Database) applyCredentialSource(
+	encryptor encryption.FieldEncryptor,
+	isFresh bool,
+) error {
+	if d.CredentialSource == nil {
+		return nil
+	}
+
+	key := credentialCacheKey{DatabaseID: d.ID, Fingerprint: d.CredentialSource.fingerprint()}
+
+	if !isFresh {
+		credentialCacheMutex.Lock()
+		cached, ok := credentialCache[key]
+		credentialCacheMutex.Unlock()
+
+		if ok && time.Now().Add(credentialReuseMargin).Before(cached.ExpiresAt) {
+			d.setCredentials(cached.Username, cached.Password)
+			return nil
+		}
+	}
+
+	credentials, err := d.CredentialSource.fetch(d, encryptor)
+	if err != nil {
+		return fmt.Errorf("failed to fetch credentials from %s: %w", d.CredentialSource.Type, err)
+	}
+
+	credentialCacheMutex.Lock()
+	credentialCache[key] = *credentials
+	credentialCacheMutex.Unlock()
+
+	d.setCredentials(credentials.Username, credentials.Password)
+
+	return nil
+}
+
+func (d *Database) setCredentials(username, password string) {
+	switch {
+	case d.Postgresql != nil:
+		if username != "" {
+			d.Postgresql.Username = username
+		}
+		d.Postgresql.Password = password
+	case d.Mysql != nil:
+		if username != "" {
+			d.Mysql.Username = username
+		}
+		d.Mysql.Password = password
+	case d.Mariadb != nil:
+		if username != "" {
+			d.Mariadb.Username = username
+		}
+		d.Mariadb.Password = password
+	case d.Mongodb != nil:
+		if username != "" {
+			d.Mongodb.Username = username
+		}
+		d.Mongodb.Password = password
+	}
+}
+
+func (d *Database) getPassword() string {
+	switch {
+	case d.Postgresql != nil:
+		return d.Postgresql.Password
+	case d.Mysql != nil:
+		return d.Mysql.Password
+	case d.Mariadb != nil:
+		return d.Mariadb.Password
+	case d.Mongodb != nil:
+		return d.Mongodb.Password
+	}
+
+	return ""
+}
+
+func (s *CredentialSource) fetch(
+	database *Database,
+	encryptor encryption.FieldEncryptor,
+) (*fetchedCredentials, error) {
+	switch s.Type {
+	case CredentialSourceTypeWebhook:
+		secret, err := encryptor.Decrypt(database.ID, s.WebhookSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		return fetchWebhookCredentials(s.WebhookURL, secret, database)
+	case CredentialSourceTypeVault:
+		token, err := encryptor.Decrypt(database.ID, s.VaultToken)
+		if err != nil {
+			return nil, err
+		}
+
+		return fetchVaultCredentials(s.VaultAddress, token, s.VaultNamespace, s.VaultPath)
+	default:
+		return nil, errors.New("invalid credential source type: " + string(s.Type))
+	}
+}
+
+// fingerprint changes with the configuration of the source, so cached credentials are not
+// reused after it is edited
+func (s *CredentialSource) fingerprint() string {
+	configuration, _ := json.Marshal(s)
+	hash := sha256.Sum256(configuration)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func fetchWebhookCredentials(
+	webhookURL string,
+	secret string,
+	database *Database,
+) (*fetchedCredentials, error) {
+	payload, err := json.Marshal(map[string]string{
+		"databaseId":   database.ID.String(),
+		"databaseName": database.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+
+	var response struct {
+		Username  string     `json:"username"`
+		Password  string     `json:"password"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+	}
+
+	if err := doCredentialRequest(req, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Password == "" {
+		return nil, errors.New("webhook response has no password")
+	}
+
+	expiresAt := time.Now().Add(defaultCredentialTTL)
+	if response.ExpiresAt != nil {
+		expiresAt = *response.ExpiresAt
+	}
+
+	return &fetchedCredentials{
+		Username:  response.Username,
+		Password:  response.Password,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func fetchVaultCredentials(
+	address string,
+	token string,
+	namespace string,
+	path string,
+) (*fetchedCredentials, error) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		strings.TrimRight(address, "/")+"/v1/"+strings.Trim(path, "/"),
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Vault-Token", token)
+	if namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	var response struct {
+		LeaseDuration int `json:"lease_duration"`
+		Data          struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"data"`
+	}
+
+	if err := doCredentialRequest(req, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Data.Password == "" {
+		return nil, errors.New("vault secret has no password")
+	}
+
+	ttl := defaultCredentialTTL
+	if response.LeaseDuration > 0 {
+		ttl = time.Duration(response.LeaseDuration) * time.Second
+	}
+
+	return &fetchedCredentials{
+		Username:  response.Data.Username,
+		Password:  response.Data.Password,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// doCredentialRequest does not include the response body in errors of successful
+// requests, it holds the password
+func doCredentialRequest(req *http.Request, response any) error {
+	client := &http.Client{Timeout: credentialSourceRequestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxCredentialResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("source returned non-OK status: %s. Error: %s", resp.Status, bodyBytes)
+	}
+
+	if err := json.Unmarshal(bodyBytes, response); err != nil {
+		return errors.New("failed to parse response")
+	}
+
+	return nil
+}
+
+func validateCredentialSourceURL(rawURL string, name string) error {
+	if rawURL == "" {
+		return errors.New(name + " is required")
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return errors.New(name + " must be an http or https URL")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package databases
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"databasus-backend/internal/features/databases/databases/postgresql"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApplyCredentialSource_WhenWebhookAnswers_CredentialsReusedUntilFresh(t *testing.T) {
+	requestsCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsCount++
+		assert.Equal(t, "Bearer webhook-secret", r.Header.Get("Authorization"))
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"username": "backup-user",
+			"password": "short-lived-password",
+		})
+	}))
+	defer server.Close()
+
+	database := &Database{
+		ID:   uuid.New(),
+		Name: "Test Database",
+		Type: DatabaseTypePostgres,
+		Postgresql: &postgresql.PostgresqlDatabase{
+			Username: "postgres",
+		},
+		CredentialSource: &CredentialSource{
+			Type:          CredentialSourceTypeWebhook,
+			WebhookURL:    server.URL,
+			WebhookSecret: "webhook-secret",
+		},
+	}
+	encryptor := encryption.GetFieldEncryptor()
+
+	assert.NoError(t, database.ApplyCredentialSource(encryptor))
+	assert.Equal(t, "backup-user", database.Postgresql.Username)
+	assert.Equal(t, "short-lived-password", database.Postgresql.Password)
+
+	assert.NoError(t, database.ApplyCredentialSource(encryptor))
+	assert.Equal(t, 1, requestsCount)
+
+	assert.NoError(t, database.ApplyFreshCredentials(encryptor))
+	assert.Equal(t, 2, requestsCount)
+
+	database.clearSourcedCredentials()
+	assert.Empty(t, database.Postgresql.Password)
+}
+
+func Test_ApplyCredentialSource_WhenVaultReturnsLease_CredentialsApplied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/database/creds/backup", r.URL.Path)
+		assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+		assert.Equal(t, "team", r.Header.Get("X-Vault-Namespace"))
+
+		_, _ = w.Write([]byte(
+			`{"lease_duration":3600,"data":{"username":"v-backup","password":"vault-password"}}`,
+		))
+	}))
+	defer server.Close()
+
+	database := &Database{
+		ID:         uuid.New(),
+		Type:       DatabaseTypePostgres,
+		Postgresql: &postgresql.PostgresqlDatabase{},
+		CredentialSource: &CredentialSource{
+			Type:           CredentialSourceTypeVault,
+			VaultAddress:   server.URL,
+			VaultToken:     "vault-token",
+			VaultNamespace: "team",
+			VaultPath:      "/database/creds/backup",
+		},
+	}
+
+	assert.NoError(t, database.ApplyCredentialSource(encryption.GetFieldEncryptor()))
+	assert.Equal(t, "v-backup", database.Postgresql.Username)
+	assert.Equal(t, "vault-password", database.Postgresql.Password)
+}
+
+func Test_ValidateCredentialSource_WhenDatabaseTypeUnsupported_ReturnsError(t *testing.T) {
+	source := &CredentialSource{
+		Type:       CredentialSourceTypeWebhook,
+		WebhookURL: "https://example.com/credentials",
+	}
+
+	assert.NoError(t, source.Validate(DatabaseTypeMysql))
+	assert.Error(t, source.Validate(DatabaseTypeFilesystem))
+
+	source.WebhookURL = "ftp://example.com"
+	assert.Error(t, source.Validate(DatabaseTypePostgres))
+}
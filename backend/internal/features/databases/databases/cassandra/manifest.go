@@ -0,0 +1,73 @@
+package cassandra
+
+import (
+	"strings"
+	"time"
+)
+
+type NodeSnapshotStatus string
+
+const (
+	NodeSnapshotStatusCompleted NodeSnapshotStatus = "COMPLETED"
+	NodeSnapshotStatusFailed    NodeSnapshotStatus = "FAILED"
+)
+
+const (
+	// ManifestFileName is the last entry of the backup archive
+	ManifestFileName = "manifest.json"
+	// NodesDirectory holds SSTables of each node as nodes/<node>/<keyspace>/<table>/
+	NodesDirectory = "nodes"
+)
+
+// SnapshotManifest records which nodes made it into the backup archive
+type SnapshotManifest struct {
+	Tag       string         `json:"tag"`
+	Version   string         `json:"version"`
+	Keyspaces []string       `json:"keyspaces"`
+	Nodes     []NodeSnapshot `json:"nodes"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+type NodeSnapshot struct {
+	Node       string             `json:"node"`
+	Status     NodeSnapshotStatus `json:"status"`
+	FilesCount int                `json:"filesCount"`
+	SizeBytes  int64              `json:"sizeBytes"`
+	Error      string             `json:"error,omitempty"`
+}
+
+func (m *SnapshotManifest) GetCompletedNodes() []string {
+	nodes := []string{}
+	for _, node := range m.Nodes {
+		if node.Status == NodeSnapshotStatusCompleted {
+			nodes = append(nodes, node.Node)
+		}
+	}
+
+	return nodes
+}
+
+// GetSnapshotTag names snapshots by backup, so a snapshot left by a crash is recognizable
+func GetSnapshotTag(backupID string) string {
+	return "databasus-" + backupID
+}
+
+// GetArchivePath maps a path of a snapshot file relative to the data directory,
+// ./<keyspace>/<table>-<id>/snapshots/<tag>/<file>, to its path in the backup archive.
+// The table id is dropped, tables get new ids in the target cluster anyway
+func GetArchivePath(node string, tag string, snapshotPath string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(snapshotPath, "./"), "/")
+	if len(parts) < 5 || parts[2] != "snapshots" || parts[3] != tag {
+		return "", false
+	}
+
+	table := parts[1]
+	if index := strings.LastIndex(table, "-"); index > 0 {
+		table = table[:index]
+	}
+
+	return strings.Join(
+		append([]string{NodesDirectory, node, parts[0], table}, parts[4:]...),
+		"/",
+	), true
+}
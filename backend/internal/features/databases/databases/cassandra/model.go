@@ -0,0 +1,267 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+var (
+	nodeRegex     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.\-:]*$`)
+	keyspaceRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+)
+
+// CassandraDatabase is a Cassandra or ScyllaDB cluster backed up with `nodetool snapshot`
+// over SSH. Snapshots are taken on all nodes at once, their SSTables are collected
+// node by node into one archive
+type CassandraDatabase struct {
+	ID         uuid.UUID  `json:"id"         gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DatabaseID *uuid.UUID `json:"databaseId" gorm:"type:uuid;column:database_id"`
+
+	// Version is detected on connection, from the first node
+	Version string `json:"version" gorm:"type:text;not null;default:''"`
+
+	// Nodes is a comma separated list of node hosts, SSH and nodetool run on each of them
+	Nodes string `json:"nodes" gorm:"type:text;not null"`
+
+	SshPort       int    `json:"sshPort"       gorm:"column:ssh_port;type:int;not null;default:22"`
+	SshUsername   string `json:"sshUsername"   gorm:"column:ssh_username;type:text;not null"`
+	SshPassword   string `json:"sshPassword"   gorm:"column:ssh_password;type:text;not null;default:''"`
+	SshPrivateKey string `json:"sshPrivateKey" gorm:"column:ssh_private_key;type:text;not null;default:''"`
+	// IsUseSudo runs nodetool and reads SSTables with `sudo -n`, data directories usually
+	// belong to the cassandra user
+	IsUseSudo bool `json:"isUseSudo" gorm:"column:is_use_sudo;type:boolean;not null;default:false"`
+
+	// JMX credentials of nodetool, empty when JMX authentication is off
+	JmxUsername string `json:"jmxUsername" gorm:"column:jmx_username;type:text;not null;default:''"`
+	JmxPassword string `json:"jmxPassword" gorm:"column:jmx_password;type:text;not null;default:''"`
+
+	DataDirectory string `json:"dataDirectory" gorm:"column:data_directory;type:text;not null;default:'/var/lib/cassandra/data'"`
+	// Keyspaces is a comma separated list, empty means all keyspaces
+	Keyspaces string `json:"keyspaces" gorm:"type:text;not null;default:''"`
+	// IsAllowPartialBackup keeps backups with some nodes failed. With a replication
+	// factor above 1 the other nodes may still hold all data
+	IsAllowPartialBackup bool `json:"isAllowPartialBackup" gorm:"column:is_allow_partial_backup;type:boolean;not null;default:false"`
+
+	// restore only: CQL credentials of sstableloader
+	CqlUsername string `json:"cqlUsername" gorm:"-"`
+	CqlPassword string `json:"cqlPassword" gorm:"-"`
+	// restore only: directory on target nodes where SSTables are uploaded before loading
+	StagingDirectory string `json:"stagingDirectory" gorm:"-"`
+}
+
+func (c *CassandraDatabase) TableName() string {
+	return "cassandra_databases"
+}
+
+func (c *CassandraDatabase) Validate() error {
+	nodes := c.NodesList()
+	if len(nodes) == 0 {
+		return errors.New("at least one node is required")
+	}
+
+	for _, node := range nodes {
+		if !nodeRegex.MatchString(node) {
+			return fmt.Errorf("invalid node host: %s", node)
+		}
+	}
+
+	if c.SshPort <= 0 || c.SshPort > 65535 {
+		return errors.New("ssh port must be between 1 and 65535")
+	}
+
+	if c.SshUsername == "" {
+		return errors.New("ssh username is required")
+	}
+
+	if c.SshPassword == "" && c.SshPrivateKey == "" {
+		return errors.New("ssh password or private key is required")
+	}
+
+	if c.JmxUsername != "" && c.JmxPassword == "" {
+		return errors.New("jmx password is required when jmx username is set")
+	}
+
+	if !path.IsAbs(c.DataDirectory) {
+		return errors.New("data directory must be an absolute path")
+	}
+
+	for _, keyspace := range c.KeyspacesList() {
+		if !keyspaceRegex.MatchString(keyspace) {
+			return fmt.Errorf("invalid keyspace name: %s", keyspace)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRestoreTarget checks the connection settings of the target cluster. Backup
+// settings, e.g. the data directory, are not used by restores
+func (c *CassandraDatabase) ValidateRestoreTarget() error {
+	nodes := c.NodesList()
+	if len(nodes) == 0 {
+		return errors.New("at least one target node is required")
+	}
+
+	for _, node := range nodes {
+		if !nodeRegex.MatchString(node) {
+			return fmt.Errorf("invalid node host: %s", node)
+		}
+	}
+
+	if c.SshPort <= 0 || c.SshPort > 65535 {
+		return errors.New("ssh port must be between 1 and 65535")
+	}
+
+	if c.SshUsername == "" || (c.SshPassword == "" && c.SshPrivateKey == "") {
+		return errors.New("ssh username and password or private key are required")
+	}
+
+	if c.CqlUsername != "" && c.CqlPassword == "" {
+		return errors.New("cql password is required when cql username is set")
+	}
+
+	if c.StagingDirectory != "" && !path.IsAbs(c.StagingDirectory) {
+		return errors.New("staging directory must be an absolute path")
+	}
+
+	return nil
+}
+
+// TestConnection runs `nodetool version` on every node and checks the data directory
+func (c *CassandraDatabase) TestConnection(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client, err := c.NewNodeClient(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	for index, node := range c.NodesList() {
+		version, err := client.GetVersion(ctx, node)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", node, err)
+		}
+
+		if index == 0 {
+			c.Version = version
+		}
+
+		if err := client.CheckDataDirectory(ctx, node); err != nil {
+			return fmt.Errorf("node %s: %w", node, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *CassandraDatabase) HideSensitiveData() {
+	if c == nil {
+		return
+	}
+	c.SshPassword = ""
+	c.SshPrivateKey = ""
+	c.JmxPassword = ""
+	c.CqlPassword = ""
+}
+
+func (c *CassandraDatabase) Update(incoming *CassandraDatabase) {
+	c.Version = incoming.Version
+	c.Nodes = incoming.Nodes
+	c.SshPort = incoming.SshPort
+	c.SshUsername = incoming.SshUsername
+	c.IsUseSudo = incoming.IsUseSudo
+	c.JmxUsername = incoming.JmxUsername
+	c.DataDirectory = incoming.DataDirectory
+	c.Keyspaces = incoming.Keyspaces
+	c.IsAllowPartialBackup = incoming.IsAllowPartialBackup
+
+	if incoming.SshPassword != "" {
+		c.SshPassword = incoming.SshPassword
+	}
+	if incoming.SshPrivateKey != "" {
+		c.SshPrivateKey = incoming.SshPrivateKey
+	}
+	if incoming.JmxPassword != "" {
+		c.JmxPassword = incoming.JmxPassword
+	}
+}
+
+func (c *CassandraDatabase) EncryptSensitiveFields(
+	databaseID uuid.UUID,
+	encryptor encryption.FieldEncryptor,
+) error {
+	for _, field := range []*string{&c.SshPassword, &c.SshPrivateKey, &c.JmxPassword} {
+		if *field == "" {
+			continue
+		}
+
+		encrypted, err := encryptor.Encrypt(databaseID, *field)
+		if err != nil {
+			return err
+		}
+		*field = encrypted
+	}
+	return nil
+}
+
+// PopulateDbData detects the version on the first node
+func (c *CassandraDatabase) PopulateDbData(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	nodes := c.NodesList()
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, err := c.NewNodeClient(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	version, err := client.GetVersion(ctx, nodes[0])
+	if err != nil {
+		return fmt.Errorf("node %s: %w", nodes[0], err)
+	}
+
+	c.Version = version
+	return nil
+}
+
+func (c *CassandraDatabase) NodesList() []string {
+	return splitList(c.Nodes)
+}
+
+func (c *CassandraDatabase) KeyspacesList() []string {
+	return splitList(c.Keyspaces)
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
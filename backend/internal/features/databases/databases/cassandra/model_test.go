@@ -0,0 +1,105 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate_InvalidConfiguration_ReturnsError(t *testing.T) {
+	for name, database := range map[string]*CassandraDatabase{
+		"no nodes":        {Nodes: " , ", SshPort: 22, SshUsername: "u", SshPassword: "p"},
+		"invalid node":    {Nodes: "node1;rm", SshPort: 22, SshUsername: "u", SshPassword: "p"},
+		"invalid port":    {Nodes: "node1", SshPort: 0, SshUsername: "u", SshPassword: "p"},
+		"no ssh username": {Nodes: "node1", SshPort: 22, SshPassword: "p"},
+		"no ssh secret":   {Nodes: "node1", SshPort: 22, SshUsername: "u"},
+		"jmx no password": {
+			Nodes: "node1", SshPort: 22, SshUsername: "u", SshPassword: "p", JmxUsername: "jmx",
+		},
+		"relative data directory": {
+			Nodes: "node1", SshPort: 22, SshUsername: "u", SshPassword: "p", DataDirectory: "data",
+		},
+		"invalid keyspace": {
+			Nodes:         "node1",
+			SshPort:       22,
+			SshUsername:   "u",
+			SshPassword:   "p",
+			DataDirectory: "/var/lib/cassandra/data",
+			Keyspaces:     "shop, drop table",
+		},
+	} {
+		assert.Error(t, database.Validate(), name)
+	}
+}
+
+func Test_Validate_ValidConfiguration_Succeeds(t *testing.T) {
+	database := &CassandraDatabase{
+		Nodes:         "10.0.0.1, cassandra-2.internal,fd00::3",
+		SshPort:       22,
+		SshUsername:   "databasus",
+		SshPrivateKey: "key",
+		DataDirectory: "/var/lib/cassandra/data",
+		Keyspaces:     "shop,analytics_v2",
+	}
+
+	assert.NoError(t, database.Validate())
+	assert.Equal(
+		t,
+		[]string{"10.0.0.1", "cassandra-2.internal", "fd00::3"},
+		database.NodesList(),
+	)
+	assert.Equal(t, []string{"shop", "analytics_v2"}, database.KeyspacesList())
+}
+
+func Test_ValidateRestoreTarget_ChecksRestoreOptions(t *testing.T) {
+	target := &CassandraDatabase{Nodes: "node1", SshPort: 22, SshUsername: "u", SshPassword: "p"}
+	assert.NoError(t, target.ValidateRestoreTarget())
+
+	target.CqlUsername = "cassandra"
+	assert.Error(t, target.ValidateRestoreTarget())
+
+	target.CqlPassword = "secret"
+	target.StagingDirectory = "tmp"
+	assert.Error(t, target.ValidateRestoreTarget())
+
+	target.StagingDirectory = "/mnt/staging"
+	assert.NoError(t, target.ValidateRestoreTarget())
+}
+
+func Test_GetArchivePath_MapsSnapshotFilesToNodeDirectory(t *testing.T) {
+	tag := GetSnapshotTag("backup-id")
+
+	archivePath, isSnapshotFile := GetArchivePath(
+		"10.0.0.1",
+		tag,
+		"./shop/orders-5f1c0e7a3b2d11ee/snapshots/"+tag+"/nb-1-big-Data.db",
+	)
+	assert.True(t, isSnapshotFile)
+	assert.Equal(t, "nodes/10.0.0.1/shop/orders/nb-1-big-Data.db", archivePath)
+
+	_, isSnapshotFile = GetArchivePath(
+		"10.0.0.1",
+		tag,
+		"./shop/orders-5f1c0e7a3b2d11ee/snapshots/other/nb-1-big-Data.db",
+	)
+	assert.False(t, isSnapshotFile)
+
+	_, isSnapshotFile = GetArchivePath("10.0.0.1", tag, "./shop/orders/nb-1-big-Data.db")
+	assert.False(t, isSnapshotFile)
+}
+
+func Test_NodeClient_BuildsNodetoolCommands(t *testing.T) {
+	database := &CassandraDatabase{JmxUsername: "jmx", JmxPassword: "it's", IsUseSudo: true}
+
+	client, err := database.NewNodeClient(nil, uuid.New())
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		`sudo -n nodetool -u jmx -pw 'it'\''s' snapshot -t databasus-1 shop`,
+		client.nodetool("snapshot", "-t", "databasus-1", "shop"),
+	)
+	assert.Equal(t, `sudo -n sh -c 'test -d /data'`, client.asDataOwner("test -d /data"))
+	assert.Equal(t, "failed: ***", maskSecrets("failed: it's", []string{"", "it's"}))
+}
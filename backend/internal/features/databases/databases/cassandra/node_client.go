@@ -0,0 +1,339 @@
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshConnectTimeout = 30 * time.Second
+	maxCommandOutput  = 64 * 1024
+
+	safeShellChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./,:="
+)
+
+// NodeClient runs commands on nodes of the cluster over SSH, a connection is opened per
+// command. Secrets are decrypted once and masked in returned errors
+type NodeClient struct {
+	database *CassandraDatabase
+
+	sshPassword   string
+	sshPrivateKey string
+	jmxPassword   string
+	cqlPassword   string
+}
+
+func (c *CassandraDatabase) NewNodeClient(
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (*NodeClient, error) {
+	client := &NodeClient{database: c}
+
+	secrets := []struct {
+		name   string
+		value  string
+		target *string
+	}{
+		{"ssh password", c.SshPassword, &client.sshPassword},
+		{"ssh private key", c.SshPrivateKey, &client.sshPrivateKey},
+		{"jmx password", c.JmxPassword, &client.jmxPassword},
+		{"cql password", c.CqlPassword, &client.cqlPassword},
+	}
+
+	for _, secret := range secrets {
+		if secret.value == "" || encryptor == nil {
+			*secret.target = secret.value
+			continue
+		}
+
+		decrypted, err := encryptor.Decrypt(databaseID, secret.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", secret.name, err)
+		}
+		*secret.target = decrypted
+	}
+
+	return client, nil
+}
+
+// GetVersion returns the release version reported by `nodetool version`
+func (n *NodeClient) GetVersion(ctx context.Context, node string) (string, error) {
+	output, err := n.run(ctx, node, n.nodetool("version"), nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for line := range strings.SplitSeq(string(output), "\n") {
+		version, isFound := strings.CutPrefix(strings.TrimSpace(line), "ReleaseVersion:")
+		if isFound {
+			return strings.TrimSpace(version), nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"unexpected nodetool version output: %s",
+		strings.TrimSpace(string(output)),
+	)
+}
+
+func (n *NodeClient) CheckDataDirectory(ctx context.Context, node string) error {
+	command := n.asDataOwner("test -d " + shellQuote(n.database.DataDirectory))
+	if _, err := n.run(ctx, node, command, nil, nil); err != nil {
+		return fmt.Errorf("data directory %s is not readable: %w", n.database.DataDirectory, err)
+	}
+
+	return nil
+}
+
+// Snapshot flushes memtables and hard links SSTables of the keyspaces into snapshots
+// named by the tag, all keyspaces without keyspaces
+func (n *NodeClient) Snapshot(
+	ctx context.Context,
+	node string,
+	tag string,
+	keyspaces []string,
+) error {
+	args := []string{"snapshot", "-t", tag}
+	args = append(args, keyspaces...)
+
+	_, err := n.run(ctx, node, n.nodetool(args...), nil, nil)
+	return err
+}
+
+func (n *NodeClient) ClearSnapshot(ctx context.Context, node string, tag string) error {
+	_, err := n.run(ctx, node, n.nodetool("clearsnapshot", "-t", tag), nil, nil)
+	return err
+}
+
+// StreamSnapshot writes a tar of the snapshot files of the tag to the writer. Entries are
+// relative to the data directory: ./<keyspace>/<table>-<id>/snapshots/<tag>/<file>
+func (n *NodeClient) StreamSnapshot(
+	ctx context.Context,
+	node string,
+	tag string,
+	writer io.Writer,
+) error {
+	command := n.asDataOwner(fmt.Sprintf(
+		"cd %s && find . -path %s -type f -print0 | tar --null -cf - -T -",
+		shellQuote(n.database.DataDirectory),
+		shellQuote("*/snapshots/"+tag+"/*"),
+	))
+
+	_, err := n.run(ctx, node, command, nil, writer)
+	return err
+}
+
+// Upload extracts the tar from the reader into the directory on the node
+func (n *NodeClient) Upload(
+	ctx context.Context,
+	node string,
+	directory string,
+	archive io.Reader,
+) error {
+	command := fmt.Sprintf(
+		"mkdir -p %s && tar -xf - -C %s",
+		shellQuote(directory),
+		shellQuote(directory),
+	)
+
+	_, err := n.run(ctx, node, command, archive, nil)
+	return err
+}
+
+// LoadTable streams SSTables of <directory>/<keyspace>/<table> to the replicas owning
+// their tokens with sstableloader, so the topology of the source cluster does not matter
+func (n *NodeClient) LoadTable(
+	ctx context.Context,
+	node string,
+	directory string,
+	initialHosts []string,
+) error {
+	args := []string{"sstableloader", "-d", strings.Join(initialHosts, ",")}
+	if n.database.CqlUsername != "" {
+		args = append(args, "-u", n.database.CqlUsername, "-pw", n.cqlPassword)
+	}
+	args = append(args, directory)
+
+	_, err := n.run(ctx, node, joinCommand(args), nil, nil)
+	return err
+}
+
+func (n *NodeClient) RemoveDirectory(ctx context.Context, node string, directory string) error {
+	_, err := n.run(ctx, node, "rm -rf "+shellQuote(directory), nil, nil)
+	return err
+}
+
+func (n *NodeClient) nodetool(args ...string) string {
+	command := []string{"nodetool"}
+	if n.database.JmxUsername != "" {
+		command = append(command, "-u", n.database.JmxUsername, "-pw", n.jmxPassword)
+	}
+	command = append(command, args...)
+
+	if n.database.IsUseSudo {
+		return "sudo -n " + joinCommand(command)
+	}
+
+	return joinCommand(command)
+}
+
+func (n *NodeClient) asDataOwner(command string) string {
+	if n.database.IsUseSudo {
+		return "sudo -n sh -c " + shellQuote(command)
+	}
+
+	return command
+}
+
+// run executes the command on the node. Without stdout writer the output is returned,
+// with it only stderr is kept for the error
+func (n *NodeClient) run(
+	ctx context.Context,
+	node string,
+	command string,
+	stdin io.Reader,
+	stdout io.Writer,
+) ([]byte, error) {
+	client, err := n.connect(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	output := &limitedBuffer{limit: maxCommandOutput}
+	session.Stdin = stdin
+	session.Stderr = output
+	session.Stdout = output
+	if stdout != nil {
+		session.Stdout = stdout
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Close()
+			_ = client.Close()
+		case <-done:
+		}
+	}()
+
+	if err := session.Run(command); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return nil, fmt.Errorf(
+			"command failed: %w: %s",
+			err,
+			maskSecrets(
+				strings.TrimSpace(output.String()),
+				[]string{n.sshPassword, n.jmxPassword, n.cqlPassword},
+			),
+		)
+	}
+
+	return output.Bytes(), nil
+}
+
+func (n *NodeClient) connect(ctx context.Context, node string) (*ssh.Client, error) {
+	var authMethods []ssh.AuthMethod
+
+	if n.sshPrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(n.sshPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh private key: %w", err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if n.sshPassword != "" {
+		authMethods = append(authMethods, ssh.Password(n.sshPassword))
+	}
+
+	if len(authMethods) == 0 {
+		return nil, errors.New("no ssh authentication method configured")
+	}
+
+	config := &ssh.ClientConfig{
+		User:            n.database.SshUsername,
+		Auth:            authMethods,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshConnectTimeout,
+	}
+
+	address := net.JoinHostPort(node, strconv.Itoa(n.database.SshPort))
+
+	dialer := net.Dialer{Timeout: sshConnectTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("ssh handshake with %s failed: %w", address, err)
+	}
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// limitedBuffer keeps the first bytes of the output, enough for error messages
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		_, _ = b.Buffer.Write(p[:min(len(p), remaining)])
+	}
+
+	return len(p), nil
+}
+
+func joinCommand(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, safeShellChars) == "" {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func maskSecrets(output string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			output = strings.ReplaceAll(output, secret, "***")
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,259 @@
+package cockroachdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type JobStatus string
+
+const (
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCanceled  JobStatus = "canceled"
+)
+
+// JobInfo is the state of a BACKUP or RESTORE job from SHOW JOBS
+type JobInfo struct {
+	Status            JobStatus
+	FractionCompleted float64
+	Error             string
+}
+
+// IsFinished returns true for terminal states, running jobs may also be pending, paused
+// or reverting
+func (j *JobInfo) IsFinished() bool {
+	return j.Status == JobStatusSucceeded ||
+		j.Status == JobStatusFailed ||
+		j.Status == JobStatusCanceled
+}
+
+// BackupOptions are options of BACKUP and RESTORE statements. The URI and the passphrase
+// are passed as arguments quoted by pgx, CockroachDB redacts URI credentials in jobs
+type BackupOptions struct {
+	URI                  string
+	EncryptionPassphrase string
+	// Database is backed up or restored alone, empty means the full cluster
+	Database string
+	// NewDatabaseName renames the database on restore
+	NewDatabaseName string
+}
+
+// JobClient runs BACKUP and RESTORE as detached jobs over the SQL connection, so a lost
+// connection or a cancelled run does not leave a statement waiting for the job
+type JobClient struct {
+	conn   *pgx.Conn
+	logger *slog.Logger
+}
+
+func (c *CockroachdbDatabase) NewJobClient(
+	ctx context.Context,
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (*JobClient, error) {
+	password := c.Password
+	if encryptor != nil && password != "" {
+		decrypted, err := encryptor.Decrypt(databaseID, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt password: %w", err)
+		}
+		password = decrypted
+	}
+
+	conn, err := pgx.Connect(ctx, buildConnectionString(c, password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to CockroachDB: %w", err)
+	}
+
+	return &JobClient{conn, logger}, nil
+}
+
+func (j *JobClient) Close() {
+	if err := j.conn.Close(context.Background()); err != nil {
+		j.logger.Error("Failed to close connection", "error", err)
+	}
+}
+
+func (j *JobClient) GetVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := j.conn.QueryRow(ctx, "SELECT version()").Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to query version: %w", err)
+	}
+
+	return version, nil
+}
+
+func (j *JobClient) IsDatabaseExists(ctx context.Context, database string) (bool, error) {
+	var isExists bool
+	err := j.conn.QueryRow(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM [SHOW DATABASES] WHERE database_name = $1)",
+		database,
+	).Scan(&isExists)
+	if err != nil {
+		return false, fmt.Errorf("failed to list databases: %w", err)
+	}
+
+	return isExists, nil
+}
+
+// StartBackup starts BACKUP INTO a new collection at the URI and returns the job id. The
+// backup is taken 10 seconds in the past, so it does not conflict with running writes
+func (j *JobClient) StartBackup(ctx context.Context, options BackupOptions) (int64, error) {
+	statement, args := buildBackupStatement(options)
+
+	var jobID int64
+	if err := j.conn.QueryRow(ctx, statement, args...).Scan(&jobID); err != nil {
+		return 0, fmt.Errorf("failed to start backup: %w", err)
+	}
+
+	return jobID, nil
+}
+
+// StartRestore restores the latest backup of the collection at the URI and returns the job
+// id. Full cluster restores need a cluster without user data
+func (j *JobClient) StartRestore(ctx context.Context, options BackupOptions) (int64, error) {
+	statement, args := buildRestoreStatement(options)
+
+	var jobID int64
+	if err := j.conn.QueryRow(ctx, statement, args...).Scan(&jobID); err != nil {
+		return 0, fmt.Errorf("failed to start restore: %w", err)
+	}
+
+	return jobID, nil
+}
+
+func (j *JobClient) GetJob(ctx context.Context, jobID int64) (*JobInfo, error) {
+	var job JobInfo
+	err := j.conn.QueryRow(
+		ctx,
+		`SELECT status, coalesce(fraction_completed, 0), coalesce(error, '')
+		FROM [SHOW JOBS] WHERE job_id = $1`,
+		jobID,
+	).Scan(&job.Status, &job.FractionCompleted, &job.Error)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("job %d is not found", jobID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %d: %w", jobID, err)
+	}
+
+	return &job, nil
+}
+
+func (j *JobClient) CancelJob(ctx context.Context, jobID int64) error {
+	if _, err := j.conn.Exec(ctx, "CANCEL JOB $1", jobID); err != nil {
+		return fmt.Errorf("failed to cancel job %d: %w", jobID, err)
+	}
+
+	return nil
+}
+
+// GetBackupSize sums size_bytes of SHOW BACKUP for the latest backup of the collection
+func (j *JobClient) GetBackupSize(ctx context.Context, options BackupOptions) (int64, error) {
+	statement := "SHOW BACKUP FROM LATEST IN $1"
+	args := []any{options.URI}
+	if options.EncryptionPassphrase != "" {
+		statement += " WITH encryption_passphrase = $2"
+		args = append(args, options.EncryptionPassphrase)
+	}
+
+	rows, err := j.conn.Query(ctx, statement, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to show backup: %w", err)
+	}
+	defer rows.Close()
+
+	sizeColumn := -1
+	for index, field := range rows.FieldDescriptions() {
+		if field.Name == "size_bytes" {
+			sizeColumn = index
+		}
+	}
+
+	if sizeColumn == -1 {
+		return 0, errors.New("SHOW BACKUP returned no size_bytes column")
+	}
+
+	var sizeBytes int64
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return 0, err
+		}
+
+		if size, isInt := values[sizeColumn].(int64); isInt {
+			sizeBytes += size
+		}
+	}
+
+	return sizeBytes, rows.Err()
+}
+
+func buildBackupStatement(options BackupOptions) (string, []any) {
+	statement := "BACKUP"
+	if options.Database != "" {
+		statement += " DATABASE " + pgx.Identifier{options.Database}.Sanitize()
+	}
+	statement += " INTO $1 AS OF SYSTEM TIME '-10s' WITH detached"
+
+	args := []any{options.URI}
+	if options.EncryptionPassphrase != "" {
+		args = append(args, options.EncryptionPassphrase)
+		statement += fmt.Sprintf(", encryption_passphrase = $%d", len(args))
+	}
+
+	return statement, args
+}
+
+func buildRestoreStatement(options BackupOptions) (string, []any) {
+	statement := "RESTORE"
+	if options.Database != "" {
+		statement += " DATABASE " + pgx.Identifier{options.Database}.Sanitize()
+	}
+	statement += " FROM LATEST IN $1 WITH detached"
+
+	args := []any{options.URI}
+	if options.NewDatabaseName != "" {
+		args = append(args, options.NewDatabaseName)
+		statement += fmt.Sprintf(", new_db_name = $%d", len(args))
+	}
+	if options.EncryptionPassphrase != "" {
+		args = append(args, options.EncryptionPassphrase)
+		statement += fmt.Sprintf(", encryption_passphrase = $%d", len(args))
+	}
+
+	return statement, args
+}
+
+func buildConnectionString(c *CockroachdbDatabase, password string) string {
+	sslMode := "disable"
+	if c.IsHttps {
+		sslMode = "require"
+	}
+
+	// sessions stay in defaultdb, a restored database must not be in use
+	return fmt.Sprintf(
+		"host='%s' port=%d user='%s' password='%s' dbname=defaultdb sslmode=%s default_query_exec_mode=simple_protocol",
+		escapeConnectionStringValue(c.Host),
+		c.Port,
+		escapeConnectionStringValue(c.Username),
+		escapeConnectionStringValue(password),
+		sslMode,
+	)
+}
+
+func escapeConnectionStringValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return value
+}
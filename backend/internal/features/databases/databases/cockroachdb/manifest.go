@@ -0,0 +1,18 @@
+package cockroachdb
+
+import "time"
+
+// BackupManifest is the storage file of a backup. Backup data is in the collection at
+// Directory of the same storage, written by the cluster. The manifest is encrypted like
+// other backups when encryption is on, the passphrase of the collection is kept in it
+type BackupManifest struct {
+	Version string `json:"version"`
+	// Database is empty for full cluster backups
+	Database             string    `json:"database"`
+	Directory            string    `json:"directory"`
+	JobID                int64     `json:"jobId"`
+	EncryptionPassphrase string    `json:"encryptionPassphrase,omitempty"`
+	SizeBytes            int64     `json:"sizeBytes"`
+	StartedAt            time.Time `json:"startedAt"`
+	FinishedAt           time.Time `json:"finishedAt"`
+}
@@ -0,0 +1,213 @@
+package cockroachdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// minCockroachdbMajorVersion is the oldest major where BACKUP INTO collections, LATEST
+// and detached jobs work as used here
+const minCockroachdbMajorVersion = 22
+
+var (
+	versionRegex      = regexp.MustCompile(`CockroachDB \w+ v(\d+)\.(\d+)\.(\d+)`)
+	databaseNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_\-]*$`)
+)
+
+// CockroachdbDatabase is a CockroachDB cluster backed up by its own BACKUP statement.
+// Nodes write the backup straight into the S3 or Azure storage of the backup config,
+// the storage file of the backup is a manifest pointing to it
+type CockroachdbDatabase struct {
+	ID         uuid.UUID  `json:"id"         gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DatabaseID *uuid.UUID `json:"databaseId" gorm:"type:uuid;column:database_id"`
+
+	// Version is detected on connection
+	Version string `json:"version" gorm:"type:text;not null;default:''"`
+
+	Host     string `json:"host"     gorm:"type:text;not null"`
+	Port     int    `json:"port"     gorm:"type:int;not null;default:26257"`
+	Username string `json:"username" gorm:"type:text;not null"`
+	Password string `json:"password" gorm:"type:text;not null;default:''"`
+	IsHttps  bool   `json:"isHttps"  gorm:"type:boolean;not null;default:false"`
+	// Database is backed up alone, empty means a full cluster backup
+	Database string `json:"database" gorm:"type:text;not null;default:''"`
+
+	// restore only: restore the database of a database backup under this name
+	RestoreDatabaseName string `json:"restoreDatabaseName" gorm:"-"`
+}
+
+func (c *CockroachdbDatabase) TableName() string {
+	return "cockroachdb_databases"
+}
+
+func (c *CockroachdbDatabase) Validate() error {
+	if c.Host == "" {
+		return errors.New("host is required")
+	}
+
+	if c.Port <= 0 || c.Port > 65535 {
+		return errors.New("port must be between 1 and 65535")
+	}
+
+	if c.Username == "" {
+		return errors.New("username is required")
+	}
+
+	if c.Database != "" && !databaseNameRegex.MatchString(c.Database) {
+		return fmt.Errorf("invalid database name: %s", c.Database)
+	}
+
+	return nil
+}
+
+// TestConnection checks the version and that the database of database backups exists
+func (c *CockroachdbDatabase) TestConnection(
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := c.NewJobClient(ctx, logger, encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := c.populateVersion(ctx, client); err != nil {
+		return err
+	}
+
+	if c.Database == "" {
+		return nil
+	}
+
+	isExists, err := client.IsDatabaseExists(ctx, c.Database)
+	if err != nil {
+		return err
+	}
+
+	if !isExists {
+		return fmt.Errorf("database %s is not found", c.Database)
+	}
+
+	return nil
+}
+
+func (c *CockroachdbDatabase) HideSensitiveData() {
+	if c == nil {
+		return
+	}
+	c.Password = ""
+}
+
+func (c *CockroachdbDatabase) Update(incoming *CockroachdbDatabase) {
+	c.Version = incoming.Version
+	c.Host = incoming.Host
+	c.Port = incoming.Port
+	c.Username = incoming.Username
+	c.IsHttps = incoming.IsHttps
+	c.Database = incoming.Database
+
+	if incoming.Password != "" {
+		c.Password = incoming.Password
+	}
+}
+
+func (c *CockroachdbDatabase) EncryptSensitiveFields(
+	databaseID uuid.UUID,
+	encryptor encryption.FieldEncryptor,
+) error {
+	if c.Password != "" {
+		encrypted, err := encryptor.Encrypt(databaseID, c.Password)
+		if err != nil {
+			return err
+		}
+		c.Password = encrypted
+	}
+	return nil
+}
+
+func (c *CockroachdbDatabase) PopulateDbData(
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := c.NewJobClient(ctx, logger, encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return c.populateVersion(ctx, client)
+}
+
+// CheckRestoreCompatibility rejects restore options this backup cannot satisfy. Cluster
+// backups are restored as a whole, only a database backup can be renamed
+func (c *CockroachdbDatabase) CheckRestoreCompatibility(target *CockroachdbDatabase) error {
+	if target.RestoreDatabaseName == "" {
+		return nil
+	}
+
+	if c.Database == "" {
+		return errors.New("restore database name can be set only for backups of a database")
+	}
+
+	if !databaseNameRegex.MatchString(target.RestoreDatabaseName) {
+		return fmt.Errorf("invalid restore database name: %s", target.RestoreDatabaseName)
+	}
+
+	return nil
+}
+
+func (c *CockroachdbDatabase) populateVersion(ctx context.Context, client *JobClient) error {
+	versionString, err := client.GetVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	version, err := parseVersion(versionString)
+	if err != nil {
+		return err
+	}
+
+	c.Version = version
+	return nil
+}
+
+// parseVersion reads the release from output of version() like
+// "CockroachDB CCL v23.2.3 (x86_64-pc-linux-gnu, built 2024/03/18 ...)"
+func parseVersion(versionString string) (string, error) {
+	matches := versionRegex.FindStringSubmatch(versionString)
+	if matches == nil {
+		return "", fmt.Errorf(
+			"not a CockroachDB server, version() returned: %s",
+			versionString,
+		)
+	}
+
+	major, _ := strconv.Atoi(matches[1])
+	if major < minCockroachdbMajorVersion {
+		return "", fmt.Errorf(
+			"CockroachDB %s.%s is not supported, %d.1 or newer is required",
+			matches[1],
+			matches[2],
+			minCockroachdbMajorVersion,
+		)
+	}
+
+	return matches[1] + "." + matches[2] + "." + matches[3], nil
+}
@@ -0,0 +1,187 @@
+package cockroachdb
+
+import (
+	"net/url"
+	"testing"
+
+	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate_InvalidConfiguration_ReturnsError(t *testing.T) {
+	for name, database := range map[string]*CockroachdbDatabase{
+		"no host":          {Port: 26257, Username: "root"},
+		"invalid port":     {Host: "crdb", Port: 0, Username: "root"},
+		"no username":      {Host: "crdb", Port: 26257},
+		"invalid database": {Host: "crdb", Port: 26257, Username: "root", Database: "shop; DROP"},
+	} {
+		assert.Error(t, database.Validate(), name)
+	}
+}
+
+func Test_ParseVersion_ReadsReleaseAndRejectsOldVersions(t *testing.T) {
+	version, err := parseVersion(
+		"CockroachDB CCL v23.2.3 (x86_64-pc-linux-gnu, built 2024/03/18 16:59:33, go1.21.5)",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "23.2.3", version)
+
+	_, err = parseVersion("CockroachDB CCL v21.2.17 (x86_64-unknown-linux-gnu)")
+	assert.Error(t, err)
+
+	_, err = parseVersion("PostgreSQL 16.2 on x86_64-pc-linux-gnu")
+	assert.Error(t, err)
+}
+
+func Test_CheckRestoreCompatibility_RenamesOnlyDatabaseBackups(t *testing.T) {
+	clusterBackup := &CockroachdbDatabase{}
+	databaseBackup := &CockroachdbDatabase{Database: "shop"}
+
+	assert.NoError(t, clusterBackup.CheckRestoreCompatibility(&CockroachdbDatabase{}))
+	assert.NoError(t, databaseBackup.CheckRestoreCompatibility(
+		&CockroachdbDatabase{RestoreDatabaseName: "shop_restored"},
+	))
+	assert.Error(t, clusterBackup.CheckRestoreCompatibility(
+		&CockroachdbDatabase{RestoreDatabaseName: "shop_restored"},
+	))
+	assert.Error(t, databaseBackup.CheckRestoreCompatibility(
+		&CockroachdbDatabase{RestoreDatabaseName: "shop'"},
+	))
+}
+
+func Test_BuildStatements_PassUriAndPassphraseAsArguments(t *testing.T) {
+	statement, args := buildBackupStatement(BackupOptions{
+		URI:                  "s3://bucket/cockroachdb/1",
+		EncryptionPassphrase: "secret",
+		Database:             "shop",
+	})
+	assert.Equal(
+		t,
+		`BACKUP DATABASE "shop" INTO $1 AS OF SYSTEM TIME '-10s' WITH detached, `+
+			`encryption_passphrase = $2`,
+		statement,
+	)
+	assert.Equal(t, []any{"s3://bucket/cockroachdb/1", "secret"}, args)
+
+	statement, args = buildRestoreStatement(BackupOptions{
+		URI:             "s3://bucket/cockroachdb/1",
+		Database:        "shop",
+		NewDatabaseName: "shop_restored",
+	})
+	assert.Equal(
+		t,
+		`RESTORE DATABASE "shop" FROM LATEST IN $1 WITH detached, new_db_name = $2`,
+		statement,
+	)
+	assert.Equal(t, []any{"s3://bucket/cockroachdb/1", "shop_restored"}, args)
+
+	statement, _ = buildBackupStatement(BackupOptions{URI: "s3://bucket/cockroachdb/1"})
+	assert.Equal(t, `BACKUP INTO $1 AS OF SYSTEM TIME '-10s' WITH detached`, statement)
+}
+
+func Test_BuildS3URI_MapsStorageSettings(t *testing.T) {
+	storage := &s3_storage.S3Storage{
+		StorageID:   uuid.New(),
+		S3Bucket:    "backups",
+		S3Region:    "us-east-1",
+		S3AccessKey: "access",
+		S3SecretKey: "se/cret+",
+		S3Endpoint:  "minio.internal:9000",
+		S3Prefix:    "/databasus/",
+	}
+
+	uri, err := BuildS3URI(storage, plainEncryptor{}, GetBackupDirectory(uuid.Nil))
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "s3", parsed.Scheme)
+	assert.Equal(t, "backups", parsed.Host)
+	assert.Equal(t, "/databasus/cockroachdb/"+uuid.Nil.String(), parsed.Path)
+	assert.Equal(t, "se/cret+", parsed.Query().Get("AWS_SECRET_ACCESS_KEY"))
+	assert.Equal(t, "https://minio.internal:9000", parsed.Query().Get("AWS_ENDPOINT"))
+	assert.Equal(t, "true", parsed.Query().Get("AWS_USE_PATH_STYLE"))
+}
+
+func Test_BuildAzureURI_ReadsAccountKeyFromConnectionString(t *testing.T) {
+	storage := &azure_blob_storage.AzureBlobStorage{
+		StorageID:  uuid.New(),
+		AuthMethod: azure_blob_storage.AuthMethodConnectionString,
+		ConnectionString: "DefaultEndpointsProtocol=https;AccountName=acme;" +
+			"AccountKey=a2V5==;EndpointSuffix=core.windows.net",
+		ContainerName: "backups",
+	}
+
+	uri, err := BuildAzureURI(storage, plainEncryptor{}, "cockroachdb/1")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "azure-blob", parsed.Scheme)
+	assert.Equal(t, "/cockroachdb/1", parsed.Path)
+	assert.Equal(t, "acme", parsed.Query().Get("AZURE_ACCOUNT_NAME"))
+	assert.Equal(t, "a2V5==", parsed.Query().Get("AZURE_ACCOUNT_KEY"))
+
+	storage.ConnectionString = "BlobEndpoint=https://acme.blob.core.windows.net;" +
+		"SharedAccessSignature=sv=1"
+	_, err = BuildAzureURI(storage, plainEncryptor{}, "cockroachdb/1")
+	assert.Error(t, err)
+}
+
+type plainEncryptor struct{}
+
+func (plainEncryptor) Encrypt(_ uuid.UUID, plaintext string) (string, error) {
+	return plaintext, nil
+}
+
+func (plainEncryptor) Decrypt(_ uuid.UUID, ciphertext string) (string, error) {
+	return ciphertext, nil
+}
+
+func Test_BuildS3URI_WhenRoleAssumedWithoutKeys_ImplicitAuthUsed(t *testing.T) {
+	storage := &s3_storage.S3Storage{
+		StorageID:  uuid.New(),
+		S3Bucket:   "backups",
+		S3Region:   "eu-west-1",
+		AuthMethod: s3_storage.AuthMethodAssumeRole,
+		RoleARN:    "arn:aws:iam::123456789012:role/backups",
+	}
+
+	uri, err := BuildS3URI(storage, plainEncryptor{}, GetBackupDirectory(uuid.Nil))
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "implicit", parsed.Query().Get("AUTH"))
+	assert.Equal(t, storage.RoleARN, parsed.Query().Get("ASSUME_ROLE"))
+	assert.Empty(t, parsed.Query().Get("AWS_ACCESS_KEY_ID"))
+}
+
+func Test_BuildS3URI_WhenSSEKMSEnabled_EncryptionParamsSet(t *testing.T) {
+	storage := &s3_storage.S3Storage{
+		StorageID:            uuid.New(),
+		S3Bucket:             "backups",
+		S3AccessKey:          "access",
+		S3SecretKey:          "secret",
+		ServerSideEncryption: s3_storage.ServerSideEncryptionKMS,
+		KMSKeyID:             "arn:aws:kms:eu-west-1:123456789012:key/backups",
+	}
+
+	uri, err := BuildS3URI(storage, plainEncryptor{}, GetBackupDirectory(uuid.Nil))
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "aws:kms", parsed.Query().Get("S3_SERVER_ENC_MODE"))
+	assert.Equal(t, storage.KMSKeyID, parsed.Query().Get("S3_SERVER_ENC_KMS_ID"))
+
+	storage.ObjectLockMode = s3_storage.ObjectLockModeCompliance
+	storage.ObjectLockRetentionDays = 30
+
+	_, err = BuildS3URI(storage, plainEncryptor{}, GetBackupDirectory(uuid.Nil))
+	assert.Error(t, err)
+}
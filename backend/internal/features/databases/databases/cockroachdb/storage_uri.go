@@ -0,0 +1,185 @@
+package cockroachdb
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// backupsDirectory keeps backup collections apart from files of other backups, paths
+// are relative to the prefix of the storage
+const backupsDirectory = "cockroachdb"
+
+// GetBackupDirectory is the collection of the backup, each backup has its own one
+func GetBackupDirectory(backupID uuid.UUID) string {
+	return backupsDirectory + "/" + backupID.String()
+}
+
+// BuildS3URI maps the storage to an s3:// URI with credentials in query parameters, the
+// format BACKUP and RESTORE expect. Nodes connect to the endpoint themselves and assume
+// the role of the storage on their own
+func BuildS3URI(
+	storage *s3_storage.S3Storage,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+) (string, error) {
+	accessKey, err := encryptor.Decrypt(storage.StorageID, storage.S3AccessKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt S3 access key: %w", err)
+	}
+
+	secretKey, err := encryptor.Decrypt(storage.StorageID, storage.S3SecretKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt S3 secret key: %w", err)
+	}
+
+	query := url.Values{}
+
+	// without keys nodes use their own implicit credentials, e.g. the instance profile of
+	// the node rather than the one of Databasus
+	if accessKey != "" {
+		query.Set("AWS_ACCESS_KEY_ID", accessKey)
+		query.Set("AWS_SECRET_ACCESS_KEY", secretKey)
+	} else {
+		query.Set("AUTH", "implicit")
+	}
+
+	if storage.AuthMethod == s3_storage.AuthMethodAssumeRole {
+		if storage.ExternalID != "" {
+			return "", errors.New("external ID of the S3 role is not supported for CockroachDB")
+		}
+
+		query.Set("ASSUME_ROLE", storage.RoleARN)
+	}
+
+	// nodes write the files of the collection themselves, so retention cannot be set on
+	// them. Bucket keys are not supported either, a bucket default covers them
+	if storage.ObjectLockMode != "" && storage.ObjectLockMode != s3_storage.ObjectLockModeNone {
+		return "", errors.New(
+			"object lock retention of S3 storages is not supported for CockroachDB",
+		)
+	}
+
+	switch storage.ServerSideEncryption {
+	case s3_storage.ServerSideEncryptionS3:
+		query.Set("S3_SERVER_ENC_MODE", "AES256")
+	case s3_storage.ServerSideEncryptionKMS:
+		if storage.KMSKeyID == "" {
+			return "", errors.New("CockroachDB requires a KMS key ID for SSE-KMS encryption")
+		}
+
+		query.Set("S3_SERVER_ENC_MODE", "aws:kms")
+		query.Set("S3_SERVER_ENC_KMS_ID", storage.KMSKeyID)
+	}
+
+	if storage.S3Region != "" {
+		query.Set("AWS_REGION", storage.S3Region)
+	}
+
+	if storage.S3Endpoint != "" {
+		endpoint := storage.S3Endpoint
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			endpoint = "https://" + endpoint
+		}
+		query.Set("AWS_ENDPOINT", endpoint)
+
+		// S3 compatible servers are addressed by path unless virtual hosts are set up
+		if !storage.S3UseVirtualHostedStyle {
+			query.Set("AWS_USE_PATH_STYLE", "true")
+		}
+	}
+
+	uri := url.URL{
+		Scheme:   "s3",
+		Host:     storage.S3Bucket,
+		Path:     "/" + joinPrefix(storage.S3Prefix, directory),
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String(), nil
+}
+
+// BuildAzureURI maps the storage to an azure-blob:// URI. CockroachDB authenticates with
+// the account key only, connection strings are parsed for it
+func BuildAzureURI(
+	storage *azure_blob_storage.AzureBlobStorage,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+) (string, error) {
+	if storage.Endpoint != "" {
+		return "", errors.New(
+			"azure blob storages with a custom endpoint are not supported by CockroachDB backups",
+		)
+	}
+
+	var accountName, accountKey string
+
+	switch storage.AuthMethod {
+	case azure_blob_storage.AuthMethodAccountKey:
+		decrypted, err := encryptor.Decrypt(storage.StorageID, storage.AccountKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt Azure account key: %w", err)
+		}
+		accountName, accountKey = storage.AccountName, decrypted
+	case azure_blob_storage.AuthMethodConnectionString:
+		connectionString, err := encryptor.Decrypt(storage.StorageID, storage.ConnectionString)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt Azure connection string: %w", err)
+		}
+		accountName, accountKey = parseAzureConnectionString(connectionString)
+	default:
+		return "", fmt.Errorf("unsupported auth method: %s", storage.AuthMethod)
+	}
+
+	if accountName == "" || accountKey == "" {
+		return "", errors.New(
+			"azure blob storage must have an account name and key for CockroachDB backups",
+		)
+	}
+
+	uri := url.URL{
+		Scheme: "azure-blob",
+		Host:   storage.ContainerName,
+		Path:   "/" + joinPrefix(storage.Prefix, directory),
+		RawQuery: url.Values{
+			"AZURE_ACCOUNT_NAME": {accountName},
+			"AZURE_ACCOUNT_KEY":  {accountKey},
+		}.Encode(),
+	}
+
+	return uri.String(), nil
+}
+
+// parseAzureConnectionString reads AccountName and AccountKey, SAS connection strings
+// have neither
+func parseAzureConnectionString(connectionString string) (string, string) {
+	var accountName, accountKey string
+
+	for part := range strings.SplitSeq(connectionString, ";") {
+		key, value, isFound := strings.Cut(strings.TrimSpace(part), "=")
+		if !isFound {
+			continue
+		}
+
+		switch key {
+		case "AccountName":
+			accountName = value
+		case "AccountKey":
+			accountKey = value
+		}
+	}
+
+	return accountName, accountKey
+}
+
+func joinPrefix(prefix, directory string) string {
+	return strings.TrimPrefix(path.Join(strings.Trim(prefix, "/"), directory), "/")
+}
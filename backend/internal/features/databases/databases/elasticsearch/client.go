@@ -0,0 +1,309 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	SnapshotStateInProgress   = "IN_PROGRESS"
+	SnapshotStateSuccess      = "SUCCESS"
+	SnapshotStatePartial      = "PARTIAL"
+	SnapshotStateFailed       = "FAILED"
+	SnapshotStateIncompatible = "INCOMPATIBLE"
+)
+
+// SnapshotClient calls the snapshot API, which is the same in Elasticsearch 7+ and
+// OpenSearch. Timeouts come from contexts, restores may take hours
+type SnapshotClient struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+type ClusterInfo struct {
+	Version struct {
+		Number       string `json:"number"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
+type SnapshotInfo struct {
+	Snapshot          string   `json:"snapshot"`
+	State             string   `json:"state"`
+	Indices           []string `json:"indices"`
+	DataStreams       []string `json:"data_streams"`
+	StartTimeInMillis int64    `json:"start_time_in_millis"`
+	EndTimeInMillis   int64    `json:"end_time_in_millis"`
+	Reason            string   `json:"reason"`
+	Shards            struct {
+		Total      int `json:"total"`
+		Failed     int `json:"failed"`
+		Successful int `json:"successful"`
+	} `json:"shards"`
+}
+
+type CreateSnapshotRequest struct {
+	Indices            string         `json:"indices"`
+	IncludeGlobalState bool           `json:"include_global_state"`
+	Metadata           map[string]any `json:"metadata,omitempty"`
+}
+
+type RestoreSnapshotRequest struct {
+	Indices            string `json:"indices"`
+	IncludeGlobalState bool   `json:"include_global_state"`
+	IncludeAliases     bool   `json:"include_aliases"`
+	RenamePattern      string `json:"rename_pattern,omitempty"`
+	RenameReplacement  string `json:"rename_replacement,omitempty"`
+}
+
+// ClusterError is an error response of the cluster
+type ClusterError struct {
+	StatusCode int
+	Type       string
+	Reason     string
+}
+
+func (e *ClusterError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("cluster responded with %d: %s", e.StatusCode, e.Reason)
+	}
+
+	return fmt.Sprintf("cluster responded with %d: %s: %s", e.StatusCode, e.Type, e.Reason)
+}
+
+func NewSnapshotClient(baseURL, username, password string, skipTLSVerify bool) *SnapshotClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if skipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &SnapshotClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Transport: transport},
+	}
+}
+
+func (c *SnapshotClient) GetClusterInfo(ctx context.Context) (*ClusterInfo, error) {
+	var info ClusterInfo
+	if err := c.do(ctx, http.MethodGet, "/", nil, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func (c *SnapshotClient) VerifyRepository(ctx context.Context, repository string) error {
+	return c.do(ctx, http.MethodPost, "/_snapshot/"+url.PathEscape(repository)+"/_verify", nil, nil)
+}
+
+// CreateSnapshot starts a snapshot without waiting, progress is polled with GetSnapshot
+func (c *SnapshotClient) CreateSnapshot(
+	ctx context.Context,
+	repository, snapshot string,
+	request CreateSnapshotRequest,
+) error {
+	return c.do(
+		ctx,
+		http.MethodPut,
+		snapshotPath(repository, snapshot)+"?wait_for_completion=false",
+		request,
+		nil,
+	)
+}
+
+func (c *SnapshotClient) GetSnapshot(
+	ctx context.Context,
+	repository, snapshot string,
+) (*SnapshotInfo, error) {
+	var response struct {
+		Snapshots []SnapshotInfo `json:"snapshots"`
+	}
+
+	path := snapshotPath(repository, snapshot)
+	if err := c.do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Snapshots) == 0 {
+		return nil, fmt.Errorf("snapshot %s not found in repository %s", snapshot, repository)
+	}
+
+	return &response.Snapshots[0], nil
+}
+
+// GetSnapshotSize returns the total size of files referenced by the snapshot. Files are
+// shared between snapshots of the same repository, so sizes of snapshots overlap
+func (c *SnapshotClient) GetSnapshotSize(
+	ctx context.Context,
+	repository, snapshot string,
+) (int64, error) {
+	var response struct {
+		Snapshots []struct {
+			Stats struct {
+				Total struct {
+					SizeInBytes int64 `json:"size_in_bytes"`
+				} `json:"total"`
+			} `json:"stats"`
+		} `json:"snapshots"`
+	}
+
+	path := snapshotPath(repository, snapshot) + "/_status"
+	if err := c.do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return 0, err
+	}
+
+	if len(response.Snapshots) == 0 {
+		return 0, fmt.Errorf("snapshot %s not found in repository %s", snapshot, repository)
+	}
+
+	return response.Snapshots[0].Stats.Total.SizeInBytes, nil
+}
+
+// DeleteSnapshot also aborts a running snapshot. Missing snapshots are treated as deleted
+func (c *SnapshotClient) DeleteSnapshot(ctx context.Context, repository, snapshot string) error {
+	err := c.do(ctx, http.MethodDelete, snapshotPath(repository, snapshot), nil, nil)
+
+	var clusterError *ClusterError
+	if errors.As(err, &clusterError) && clusterError.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// RestoreSnapshot waits until primary shards of restored indices are recovered
+func (c *SnapshotClient) RestoreSnapshot(
+	ctx context.Context,
+	repository, snapshot string,
+	request RestoreSnapshotRequest,
+) error {
+	return c.do(
+		ctx,
+		http.MethodPost,
+		snapshotPath(repository, snapshot)+"/_restore?wait_for_completion=true",
+		request,
+		nil,
+	)
+}
+
+// DeleteIndices deletes indices by exact names, missing ones are ignored
+func (c *SnapshotClient) DeleteIndices(ctx context.Context, indices []string) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	escapedIndices := make([]string, 0, len(indices))
+	for _, index := range indices {
+		escapedIndices = append(escapedIndices, url.PathEscape(index))
+	}
+
+	path := "/" + strings.Join(escapedIndices, ",") + "?ignore_unavailable=true"
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// DeleteDataStreams deletes data streams with their backing indices
+func (c *SnapshotClient) DeleteDataStreams(ctx context.Context, dataStreams []string) error {
+	if len(dataStreams) == 0 {
+		return nil
+	}
+
+	escapedDataStreams := make([]string, 0, len(dataStreams))
+	for _, dataStream := range dataStreams {
+		escapedDataStreams = append(escapedDataStreams, url.PathEscape(dataStream))
+	}
+
+	err := c.do(
+		ctx,
+		http.MethodDelete,
+		"/_data_stream/"+strings.Join(escapedDataStreams, ","),
+		nil,
+		nil,
+	)
+
+	var clusterError *ClusterError
+	if errors.As(err, &clusterError) && clusterError.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+func (c *SnapshotClient) do(ctx context.Context, method, path string, body, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		requestBody = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return readClusterError(response)
+	}
+
+	if result == nil {
+		_, _ = io.Copy(io.Discard, response.Body)
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func readClusterError(response *http.Response) error {
+	clusterError := &ClusterError{StatusCode: response.StatusCode}
+
+	errorBody, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+
+	var envelope struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(errorBody, &envelope) == nil && envelope.Error.Reason != "" {
+		clusterError.Type = envelope.Error.Type
+		clusterError.Reason = envelope.Error.Reason
+	} else {
+		clusterError.Reason = strings.TrimSpace(string(errorBody))
+	}
+
+	return clusterError
+}
+
+func snapshotPath(repository, snapshot string) string {
+	return "/_snapshot/" + url.PathEscape(repository) + "/" + url.PathEscape(snapshot)
+}
@@ -0,0 +1,293 @@
+package elasticsearch
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+type ElasticsearchFlavor string
+
+const (
+	ElasticsearchFlavorElasticsearch ElasticsearchFlavor = "ELASTICSEARCH"
+	ElasticsearchFlavorOpensearch    ElasticsearchFlavor = "OPENSEARCH"
+)
+
+// minElasticsearchMajorVersion is the oldest major with the snapshot API responses
+// parsed here. All OpenSearch versions are supported
+const minElasticsearchMajorVersion = 7
+
+// ElasticsearchDatabase is a search cluster backed up by its own snapshot API. Snapshot
+// data stays in the snapshot repository of the cluster, the storage keeps a manifest
+type ElasticsearchDatabase struct {
+	ID         uuid.UUID  `json:"id"         gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DatabaseID *uuid.UUID `json:"databaseId" gorm:"type:uuid;column:database_id"`
+
+	// Flavor and Version are detected on connection
+	Flavor  ElasticsearchFlavor `json:"flavor"  gorm:"type:text;not null;default:'ELASTICSEARCH'"`
+	Version string              `json:"version" gorm:"type:text;not null;default:''"`
+
+	URL           string `json:"url"             gorm:"column:url;type:text;not null"`
+	Username      string `json:"username"        gorm:"type:text;not null;default:''"`
+	Password      string `json:"password"        gorm:"type:text;not null;default:''"`
+	SkipTLSVerify bool   `json:"skipTlsVerify"   gorm:"column:skip_tls_verify;type:boolean;not null;default:false"`
+
+	// Repository is a snapshot repository registered in the cluster
+	Repository string `json:"repository" gorm:"type:text;not null"`
+	// Indices is a comma separated list of indices, data streams and patterns
+	Indices              string `json:"indices"              gorm:"type:text;not null;default:'*'"`
+	IsIncludeGlobalState bool   `json:"isIncludeGlobalState" gorm:"column:is_include_global_state;type:boolean;not null;default:false"`
+
+	// restore only: prefix for restored index names, they are restored under their
+	// original names when empty
+	RestoreIndexPrefix string `json:"restoreIndexPrefix" gorm:"-"`
+	// restore only: delete indices of the snapshot before restoring them in place
+	IsDeleteExistingIndices bool `json:"isDeleteExistingIndices" gorm:"-"`
+}
+
+func (e *ElasticsearchDatabase) TableName() string {
+	return "elasticsearch_databases"
+}
+
+func (e *ElasticsearchDatabase) Validate() error {
+	if e.URL == "" {
+		return errors.New("url is required")
+	}
+
+	parsedURL, err := url.Parse(e.URL)
+	if err != nil || parsedURL.Host == "" ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return errors.New("url must be http(s)://host:port")
+	}
+
+	if e.Username != "" && e.Password == "" {
+		return errors.New("password is required when username is set")
+	}
+
+	if e.Repository == "" {
+		return errors.New("snapshot repository is required")
+	}
+
+	if strings.ContainsAny(e.Repository, "/?#, ") {
+		return errors.New("snapshot repository name is invalid")
+	}
+
+	for _, index := range strings.Split(e.Indices, ",") {
+		if strings.TrimSpace(index) == "" {
+			return errors.New("indices must be a comma separated list without empty items")
+		}
+	}
+
+	return nil
+}
+
+// TestConnection checks credentials, detects the version and verifies the snapshot
+// repository on all nodes, so a misconfigured repository fails here instead of at backup
+func (e *ElasticsearchDatabase) TestConnection(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := e.NewSnapshotClient(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := e.populateVersion(ctx, client); err != nil {
+		return err
+	}
+
+	if err := client.VerifyRepository(ctx, e.Repository); err != nil {
+		return fmt.Errorf("snapshot repository %s is not usable: %w", e.Repository, err)
+	}
+
+	return nil
+}
+
+func (e *ElasticsearchDatabase) HideSensitiveData() {
+	if e == nil {
+		return
+	}
+	e.Password = ""
+}
+
+func (e *ElasticsearchDatabase) Update(incoming *ElasticsearchDatabase) {
+	e.Flavor = incoming.Flavor
+	e.Version = incoming.Version
+	e.URL = incoming.URL
+	e.Username = incoming.Username
+	e.SkipTLSVerify = incoming.SkipTLSVerify
+	e.Repository = incoming.Repository
+	e.Indices = incoming.Indices
+	e.IsIncludeGlobalState = incoming.IsIncludeGlobalState
+
+	if incoming.Password != "" {
+		e.Password = incoming.Password
+	}
+}
+
+func (e *ElasticsearchDatabase) EncryptSensitiveFields(
+	databaseID uuid.UUID,
+	encryptor encryption.FieldEncryptor,
+) error {
+	if e.Password != "" {
+		encrypted, err := encryptor.Encrypt(databaseID, e.Password)
+		if err != nil {
+			return err
+		}
+		e.Password = encrypted
+	}
+	return nil
+}
+
+func (e *ElasticsearchDatabase) PopulateDbData(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := e.NewSnapshotClient(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	return e.populateVersion(ctx, client)
+}
+
+// NewSnapshotClient decrypts the password, nil encryptor means it is not encrypted
+func (e *ElasticsearchDatabase) NewSnapshotClient(
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (*SnapshotClient, error) {
+	password := e.Password
+	if encryptor != nil {
+		decryptedPassword, err := encryptor.Decrypt(databaseID, e.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt password: %w", err)
+		}
+		password = decryptedPassword
+	}
+
+	return NewSnapshotClient(e.URL, e.Username, password, e.SkipTLSVerify), nil
+}
+
+// IndicesList returns trimmed items of Indices
+func (e *ElasticsearchDatabase) IndicesList() []string {
+	indices := make([]string, 0)
+	for _, index := range strings.Split(e.Indices, ",") {
+		if trimmed := strings.TrimSpace(index); trimmed != "" {
+			indices = append(indices, trimmed)
+		}
+	}
+
+	return indices
+}
+
+func (e *ElasticsearchDatabase) populateVersion(ctx context.Context, client *SnapshotClient) error {
+	info, err := client.GetClusterInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	e.Version = info.Version.Number
+	e.Flavor = ElasticsearchFlavorElasticsearch
+	if info.Version.Distribution == "opensearch" {
+		e.Flavor = ElasticsearchFlavorOpensearch
+		return nil
+	}
+
+	major, _, _ := strings.Cut(info.Version.Number, ".")
+	majorVersion, err := strconv.Atoi(major)
+	if err != nil {
+		return fmt.Errorf("unexpected Elasticsearch version %q", info.Version.Number)
+	}
+
+	if majorVersion < minElasticsearchMajorVersion {
+		return fmt.Errorf(
+			"elasticsearch %s is not supported, the minimum version is %d",
+			info.Version.Number,
+			minElasticsearchMajorVersion,
+		)
+	}
+
+	return nil
+}
+
+// CheckRestoreCompatibility allows restores to the same flavor of the same or a newer
+// version. Restoring to a newer major can still fail for indices created two majors ago
+func (e *ElasticsearchDatabase) CheckRestoreCompatibility(target *ElasticsearchDatabase) error {
+	if e.Flavor != target.Flavor {
+		return fmt.Errorf("snapshots of %s cannot be restored to %s", e.Flavor, target.Flavor)
+	}
+
+	if compareVersions(e.Version, target.Version) > 0 {
+		return fmt.Errorf(
+			"backup cluster version %s is higher than restore cluster version %s. "+
+				"Snapshots can be restored only to the same or a newer version",
+			e.Version,
+			target.Version,
+		)
+	}
+
+	return nil
+}
+
+// SnapshotName is the name of the snapshot made for a backup. Names must be lowercase
+func SnapshotName(backupID uuid.UUID) string {
+	return "databasus-" + backupID.String()
+}
+
+// compareVersions compares dot separated numeric versions, suffixes like -SNAPSHOT are
+// ignored
+func compareVersions(left, right string) int {
+	leftParts := strings.Split(strings.SplitN(left, "-", 2)[0], ".")
+	rightParts := strings.Split(strings.SplitN(right, "-", 2)[0], ".")
+
+	for index := 0; index < max(len(leftParts), len(rightParts)); index++ {
+		var leftNumber, rightNumber int
+		if index < len(leftParts) {
+			leftNumber, _ = strconv.Atoi(leftParts[index])
+		}
+		if index < len(rightParts) {
+			rightNumber, _ = strconv.Atoi(rightParts[index])
+		}
+
+		if result := cmp.Compare(leftNumber, rightNumber); result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// SnapshotManifest is saved to the storage in place of snapshot data, restores find the
+// snapshot by it even if the source database was changed or removed
+type SnapshotManifest struct {
+	Flavor     ElasticsearchFlavor `json:"flavor"`
+	Version    string              `json:"version"`
+	Repository string              `json:"repository"`
+	Snapshot   string              `json:"snapshot"`
+	State      string              `json:"state"`
+	Indices    []string            `json:"indices"`
+	// DataStreams are restored with their backing indices
+	DataStreams        []string  `json:"dataStreams"`
+	IncludeGlobalState bool      `json:"includeGlobalState"`
+	SizeBytes          int64     `json:"sizeBytes"`
+	StartedAt          time.Time `json:"startedAt"`
+	FinishedAt         time.Time `json:"finishedAt"`
+}
@@ -0,0 +1,146 @@
+package elasticsearch
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate_InvalidConfiguration_ReturnsError(t *testing.T) {
+	for name, database := range map[string]*ElasticsearchDatabase{
+		"empty url":  {Repository: "backups", Indices: "*"},
+		"no scheme":  {URL: "localhost:9200", Repository: "backups", Indices: "*"},
+		"ftp scheme": {URL: "ftp://localhost", Repository: "backups", Indices: "*"},
+		"no password": {
+			URL:        "http://es:9200",
+			Username:   "elastic",
+			Repository: "backups",
+			Indices:    "*",
+		},
+		"no repository":  {URL: "http://es:9200", Indices: "*"},
+		"bad repository": {URL: "http://es:9200", Repository: "a/b", Indices: "*"},
+		"empty index":    {URL: "http://es:9200", Repository: "backups", Indices: "logs,,metrics"},
+	} {
+		assert.Error(t, database.Validate(), name)
+	}
+}
+
+func Test_Validate_ValidConfiguration_Succeeds(t *testing.T) {
+	database := &ElasticsearchDatabase{
+		URL:        "https://es.internal:9200",
+		Username:   "elastic",
+		Password:   "secret",
+		Repository: "backups",
+		Indices:    "logs-*, metrics",
+	}
+
+	assert.NoError(t, database.Validate())
+	assert.Equal(t, []string{"logs-*", "metrics"}, database.IndicesList())
+}
+
+func Test_CheckRestoreCompatibility_ComparesFlavorAndVersion(t *testing.T) {
+	backup := &ElasticsearchDatabase{Flavor: ElasticsearchFlavorElasticsearch, Version: "8.11.3"}
+
+	assert.NoError(t, backup.CheckRestoreCompatibility(
+		&ElasticsearchDatabase{Flavor: ElasticsearchFlavorElasticsearch, Version: "8.11.3"},
+	))
+	assert.NoError(t, backup.CheckRestoreCompatibility(
+		&ElasticsearchDatabase{Flavor: ElasticsearchFlavorElasticsearch, Version: "8.12.0"},
+	))
+	assert.Error(t, backup.CheckRestoreCompatibility(
+		&ElasticsearchDatabase{Flavor: ElasticsearchFlavorElasticsearch, Version: "8.9.0"},
+	))
+	assert.Error(t, backup.CheckRestoreCompatibility(
+		&ElasticsearchDatabase{Flavor: ElasticsearchFlavorOpensearch, Version: "9.0.0"},
+	))
+}
+
+func Test_CompareVersions_ComparesNumericParts(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("8.9.0", "8.10.0"))
+	assert.Equal(t, 1, compareVersions("2.11.1", "2.11"))
+	assert.Equal(t, 0, compareVersions("8.0.0-SNAPSHOT", "8.0.0"))
+}
+
+func Test_TestConnection_DetectsFlavorAndVerifiesRepository(t *testing.T) {
+	var verifiedPath, authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			authorization = request.Header.Get("Authorization")
+
+			switch request.URL.Path {
+			case "/":
+				_, _ = io.WriteString(
+					writer,
+					`{"version": {"number": "2.11.1", "distribution": "opensearch"}}`,
+				)
+			default:
+				verifiedPath = request.Method + " " + request.URL.Path
+				_, _ = io.WriteString(writer, `{"nodes": {}}`)
+			}
+		},
+	))
+	defer server.Close()
+
+	database := &ElasticsearchDatabase{
+		URL:        server.URL,
+		Username:   "admin",
+		Password:   "secret",
+		Repository: "backups",
+		Indices:    "*",
+	}
+
+	require.NoError(t, database.TestConnection(createTestLogger(), nil, uuid.New()))
+
+	assert.Equal(t, ElasticsearchFlavorOpensearch, database.Flavor)
+	assert.Equal(t, "2.11.1", database.Version)
+	assert.Equal(t, "POST /_snapshot/backups/_verify", verifiedPath)
+	assert.NotEmpty(t, authorization)
+}
+
+func Test_TestConnection_OldElasticsearch_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(writer, `{"version": {"number": "6.8.23"}}`)
+		},
+	))
+	defer server.Close()
+
+	database := &ElasticsearchDatabase{URL: server.URL, Repository: "backups", Indices: "*"}
+
+	assert.Error(t, database.TestConnection(createTestLogger(), nil, uuid.New()))
+}
+
+func Test_DeleteSnapshot_WhenSnapshotIsMissing_Succeeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, _ *http.Request) {
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(
+				writer,
+				`{"error": {"type": "snapshot_missing_exception", "reason": "missing"}}`,
+			)
+		},
+	))
+	defer server.Close()
+
+	client := NewSnapshotClient(server.URL, "", "", false)
+
+	assert.NoError(t, client.DeleteSnapshot(context.Background(), "backups", "databasus-1"))
+
+	_, err := client.GetSnapshot(context.Background(), "backups", "databasus-1")
+
+	var clusterError *ClusterError
+	require.ErrorAs(t, err, &clusterError)
+	assert.Equal(t, "snapshot_missing_exception", clusterError.Type)
+}
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
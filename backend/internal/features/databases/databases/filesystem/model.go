@@ -0,0 +1,258 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FilesystemCompression string
+
+const (
+	FilesystemCompressionZstd FilesystemCompression = "ZSTD"
+	FilesystemCompressionGzip FilesystemCompression = "GZIP"
+	FilesystemCompressionNone FilesystemCompression = "NONE"
+)
+
+type FilesystemSnapshotMode string
+
+const (
+	FilesystemSnapshotModeNone  FilesystemSnapshotMode = "NONE"
+	FilesystemSnapshotModeLvm   FilesystemSnapshotMode = "LVM"
+	FilesystemSnapshotModeBtrfs FilesystemSnapshotMode = "BTRFS"
+)
+
+// FilesystemDatabase is a directory backed up as a tar archive, e.g. a Docker volume or a
+// bind mount of an app without a supported database engine. Path is as seen by Databasus,
+// so the directory has to be mounted into its container
+type FilesystemDatabase struct {
+	ID         uuid.UUID  `json:"id"         gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DatabaseID *uuid.UUID `json:"databaseId" gorm:"type:uuid;column:database_id"`
+
+	Path string `json:"path" gorm:"type:text;not null"`
+
+	// backup settings. Globs are matched against paths relative to Path, a glob without
+	// "/" matches the name in any directory. Globs are stored newline separated, they
+	// may contain commas
+	IncludeGlobs       []string               `json:"includeGlobs" gorm:"-"`
+	IncludeGlobsString string                 `json:"-"            gorm:"column:include_globs;type:text;not null;default:''"`
+	ExcludeGlobs       []string               `json:"excludeGlobs" gorm:"-"`
+	ExcludeGlobsString string                 `json:"-"            gorm:"column:exclude_globs;type:text;not null;default:''"`
+	Compression        FilesystemCompression  `json:"compression"  gorm:"type:text;not null;default:'ZSTD'"`
+	SnapshotMode       FilesystemSnapshotMode `json:"snapshotMode" gorm:"column:snapshot_mode;type:text;not null;default:'NONE'"`
+	// LvmSnapshotSize is copy-on-write space of the snapshot in lvcreate format, e.g. 1G.
+	// The snapshot becomes invalid if more data is changed during the backup
+	LvmSnapshotSize string `json:"lvmSnapshotSize" gorm:"column:lvm_snapshot_size;type:text;not null;default:''"`
+
+	// restore settings (not saved to DB)
+	IsRemoveExistingFiles bool `json:"isRemoveExistingFiles" gorm:"-"`
+}
+
+func (f *FilesystemDatabase) TableName() string {
+	return "filesystem_databases"
+}
+
+func (f *FilesystemDatabase) BeforeSave(_ *gorm.DB) error {
+	f.IncludeGlobsString = strings.Join(f.IncludeGlobs, "\n")
+	f.ExcludeGlobsString = strings.Join(f.ExcludeGlobs, "\n")
+
+	return nil
+}
+
+func (f *FilesystemDatabase) AfterFind(_ *gorm.DB) error {
+	f.IncludeGlobs = splitGlobs(f.IncludeGlobsString)
+	f.ExcludeGlobs = splitGlobs(f.ExcludeGlobsString)
+
+	return nil
+}
+
+func (f *FilesystemDatabase) Validate() error {
+	if f.Path == "" {
+		return errors.New("path is required")
+	}
+
+	if !filepath.IsAbs(f.Path) {
+		return errors.New("path must be absolute")
+	}
+
+	if filepath.Clean(f.Path) == "/" {
+		return errors.New("path must not be the root directory")
+	}
+
+	for _, glob := range append(append([]string{}, f.IncludeGlobs...), f.ExcludeGlobs...) {
+		if strings.TrimSpace(glob) == "" {
+			return errors.New("globs must not be empty")
+		}
+
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", glob, err)
+		}
+	}
+
+	switch f.Compression {
+	case FilesystemCompressionZstd, FilesystemCompressionGzip, FilesystemCompressionNone:
+	default:
+		return fmt.Errorf("invalid compression: %s", f.Compression)
+	}
+
+	switch f.SnapshotMode {
+	case FilesystemSnapshotModeNone, FilesystemSnapshotModeBtrfs:
+	case FilesystemSnapshotModeLvm:
+		if f.LvmSnapshotSize == "" {
+			return errors.New("lvm snapshot size is required for LVM snapshots")
+		}
+	default:
+		return fmt.Errorf("invalid snapshot mode: %s", f.SnapshotMode)
+	}
+
+	return nil
+}
+
+// TestConnection checks the directory is readable and snapshot tools are installed
+func (f *FilesystemDatabase) TestConnection(
+	_ *slog.Logger,
+	_ encryption.FieldEncryptor,
+	_ uuid.UUID,
+) error {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return fmt.Errorf("path is not accessible, is it mounted into Databasus? %w", err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("path %s is not a directory", f.Path)
+	}
+
+	directory, err := os.Open(f.Path)
+	if err != nil {
+		return fmt.Errorf("directory is not readable: %w", err)
+	}
+	defer func() { _ = directory.Close() }()
+
+	if _, err := directory.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("directory is not readable: %w", err)
+	}
+
+	switch f.SnapshotMode {
+	case FilesystemSnapshotModeLvm:
+		for _, tool := range []string{"lvcreate", "lvremove", "findmnt", "mount", "umount"} {
+			if _, err := exec.LookPath(tool); err != nil {
+				return fmt.Errorf("%s is required for LVM snapshots: %w", tool, err)
+			}
+		}
+	case FilesystemSnapshotModeBtrfs:
+		if _, err := exec.LookPath("btrfs"); err != nil {
+			return fmt.Errorf("btrfs is required for btrfs snapshots: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *FilesystemDatabase) HideSensitiveData() {}
+
+func (f *FilesystemDatabase) Update(incoming *FilesystemDatabase) {
+	f.Path = incoming.Path
+	f.IncludeGlobs = incoming.IncludeGlobs
+	f.ExcludeGlobs = incoming.ExcludeGlobs
+	f.Compression = incoming.Compression
+	f.SnapshotMode = incoming.SnapshotMode
+	f.LvmSnapshotSize = incoming.LvmSnapshotSize
+}
+
+// CheckPathAllowed checks the path, with symlinks resolved, is inside one of roots.
+// Without the check anyone managing databases could download any file of the host
+func (f *FilesystemDatabase) CheckPathAllowed(roots []string) error {
+	if len(roots) == 0 {
+		return errors.New(
+			"filesystem sources are disabled, set FILESYSTEM_BACKUP_ROOTS to allow them",
+		)
+	}
+
+	resolvedPath := resolvePath(f.Path)
+
+	for _, root := range roots {
+		relativePath, err := filepath.Rel(resolvePath(root), resolvedPath)
+		if err != nil {
+			continue
+		}
+
+		if relativePath != ".." && !strings.HasPrefix(relativePath, "../") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("path %s is outside of FILESYSTEM_BACKUP_ROOTS", f.Path)
+}
+
+// IsExcluded reports whether an entry and, for directories, everything below it is
+// skipped. relativePath is slash separated
+func (f *FilesystemDatabase) IsExcluded(relativePath string) bool {
+	for _, glob := range f.ExcludeGlobs {
+		if matchesGlob(glob, relativePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsIncluded reports whether an entry is archived. An entry is included if it or any
+// of its parent directories matches an include glob, everything is included without them
+func (f *FilesystemDatabase) IsIncluded(relativePath string) bool {
+	if len(f.IncludeGlobs) == 0 {
+		return true
+	}
+
+	for candidate := relativePath; candidate != "."; candidate = path.Dir(candidate) {
+		for _, glob := range f.IncludeGlobs {
+			if matchesGlob(glob, candidate) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesGlob(glob, relativePath string) bool {
+	glob = strings.Trim(glob, "/")
+
+	if !strings.Contains(glob, "/") {
+		isMatched, _ := path.Match(glob, path.Base(relativePath))
+		return isMatched
+	}
+
+	isMatched, _ := path.Match(glob, relativePath)
+	return isMatched
+}
+
+func resolvePath(value string) string {
+	cleanPath := filepath.Clean(value)
+
+	resolvedPath, err := filepath.EvalSymlinks(cleanPath)
+	if err != nil {
+		return cleanPath
+	}
+
+	return resolvedPath
+}
+
+func splitGlobs(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+
+	return strings.Split(value, "\n")
+}
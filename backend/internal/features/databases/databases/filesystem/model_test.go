@@ -0,0 +1,104 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate_WithRelativeOrRootPath_ReturnsError(t *testing.T) {
+	for _, value := range []string{"", "data", "/"} {
+		database := &FilesystemDatabase{
+			Path:         value,
+			Compression:  FilesystemCompressionZstd,
+			SnapshotMode: FilesystemSnapshotModeNone,
+		}
+
+		assert.Error(t, database.Validate(), value)
+	}
+}
+
+func Test_Validate_LvmSnapshotWithoutSize_ReturnsError(t *testing.T) {
+	database := &FilesystemDatabase{
+		Path:         "/data",
+		Compression:  FilesystemCompressionGzip,
+		SnapshotMode: FilesystemSnapshotModeLvm,
+	}
+	assert.Error(t, database.Validate())
+
+	database.LvmSnapshotSize = "1G"
+	assert.NoError(t, database.Validate())
+}
+
+func Test_Validate_InvalidGlob_ReturnsError(t *testing.T) {
+	database := &FilesystemDatabase{
+		Path:         "/data",
+		ExcludeGlobs: []string{"[a-"},
+		Compression:  FilesystemCompressionNone,
+		SnapshotMode: FilesystemSnapshotModeNone,
+	}
+
+	assert.Error(t, database.Validate())
+}
+
+func Test_CheckPathAllowed_PathInsideRoots_Succeeds(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "volume"), 0o755))
+
+	database := &FilesystemDatabase{Path: filepath.Join(root, "volume")}
+	assert.NoError(t, database.CheckPathAllowed([]string{"/nonexistent", root}))
+
+	database.Path = root
+	assert.NoError(t, database.CheckPathAllowed([]string{root}))
+}
+
+func Test_CheckPathAllowed_PathOutsideRoots_ReturnsError(t *testing.T) {
+	root := t.TempDir()
+
+	for _, value := range []string{
+		filepath.Dir(root),
+		filepath.Join(root, "..", "other"),
+		root + "-sibling",
+	} {
+		database := &FilesystemDatabase{Path: value}
+		assert.Error(t, database.CheckPathAllowed([]string{root}), value)
+	}
+}
+
+func Test_CheckPathAllowed_SymlinkLeavingRoot_ReturnsError(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	assert.NoError(t, os.Symlink(outside, filepath.Join(root, "link")))
+
+	database := &FilesystemDatabase{Path: filepath.Join(root, "link")}
+	assert.Error(t, database.CheckPathAllowed([]string{root}))
+}
+
+func Test_CheckPathAllowed_WithoutRoots_ReturnsError(t *testing.T) {
+	database := &FilesystemDatabase{Path: t.TempDir()}
+	assert.Error(t, database.CheckPathAllowed(nil))
+}
+
+func Test_IsIncluded_WithIncludeGlobs_MatchesEntriesAndTheirChildren(t *testing.T) {
+	database := &FilesystemDatabase{IncludeGlobs: []string{"uploads", "config/*.yml"}}
+
+	assert.True(t, database.IsIncluded("uploads"))
+	assert.True(t, database.IsIncluded("uploads/2024/photo.jpg"))
+	assert.True(t, database.IsIncluded("config/app.yml"))
+	assert.False(t, database.IsIncluded("config/app.json"))
+	assert.False(t, database.IsIncluded("cache/item"))
+
+	assert.True(t, (&FilesystemDatabase{}).IsIncluded("anything"))
+}
+
+func Test_IsExcluded_GlobWithoutSlash_MatchesBaseNameAtAnyDepth(t *testing.T) {
+	database := &FilesystemDatabase{ExcludeGlobs: []string{"*.log", "data/tmp"}}
+
+	assert.True(t, database.IsExcluded("app.log"))
+	assert.True(t, database.IsExcluded("nested/dir/app.log"))
+	assert.True(t, database.IsExcluded("data/tmp"))
+	assert.False(t, database.IsExcluded("other/data/tmp"))
+	assert.False(t, database.IsExcluded("app.txt"))
+}
@@ -0,0 +1,250 @@
+package influxdb
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+// InfluxdbDatabase is an InfluxDB 2.x server backed up with `influx backup`. The backup
+// holds shards of all or one bucket and, for full backups, the metadata of the server
+type InfluxdbDatabase struct {
+	ID         uuid.UUID  `json:"id"         gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DatabaseID *uuid.UUID `json:"databaseId" gorm:"type:uuid;column:database_id"`
+
+	// Version is detected on connection
+	Version string `json:"version" gorm:"type:text;not null;default:''"`
+
+	URL string `json:"url" gorm:"column:url;type:text;not null"`
+	// Token must be an operator token for full backups, an all access token is enough
+	// with Bucket
+	Token         string `json:"token"         gorm:"type:text;not null"`
+	Org           string `json:"org"           gorm:"type:text;not null;default:''"`
+	Bucket        string `json:"bucket"        gorm:"type:text;not null;default:''"`
+	SkipTLSVerify bool   `json:"skipTlsVerify" gorm:"column:skip_tls_verify;type:boolean;not null;default:false"`
+
+	// restore only: restore the bucket of a bucket backup under this name
+	RestoreBucket string `json:"restoreBucket" gorm:"-"`
+	// restore only: replace all data and metadata of the server, tokens included
+	IsFullRestore bool `json:"isFullRestore" gorm:"-"`
+}
+
+func (i *InfluxdbDatabase) TableName() string {
+	return "influxdb_databases"
+}
+
+func (i *InfluxdbDatabase) Validate() error {
+	if i.URL == "" {
+		return errors.New("url is required")
+	}
+
+	parsedURL, err := url.Parse(i.URL)
+	if err != nil || parsedURL.Host == "" ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return errors.New("url must be http(s)://host:port")
+	}
+
+	if i.Token == "" {
+		return errors.New("token is required")
+	}
+
+	if i.Bucket != "" && i.Org == "" {
+		return errors.New("org is required when bucket is set")
+	}
+
+	return nil
+}
+
+// TestConnection checks the version and that the token can read the bucket or, without
+// bucket, list buckets of the server
+func (i *InfluxdbDatabase) TestConnection(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	token, err := i.DecryptToken(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := i.populateVersion(ctx); err != nil {
+		return err
+	}
+
+	query := url.Values{"limit": {"1"}}
+	if i.Bucket != "" {
+		query.Set("name", i.Bucket)
+		query.Set("org", i.Org)
+	}
+
+	var response struct {
+		Buckets []struct {
+			ID string `json:"id"`
+		} `json:"buckets"`
+	}
+
+	if err := i.get(ctx, "/api/v2/buckets?"+query.Encode(), token, &response); err != nil {
+		return fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	if i.Bucket != "" && len(response.Buckets) == 0 {
+		return fmt.Errorf("bucket %s is not found in org %s", i.Bucket, i.Org)
+	}
+
+	return nil
+}
+
+func (i *InfluxdbDatabase) HideSensitiveData() {
+	if i == nil {
+		return
+	}
+	i.Token = ""
+}
+
+func (i *InfluxdbDatabase) Update(incoming *InfluxdbDatabase) {
+	i.Version = incoming.Version
+	i.URL = incoming.URL
+	i.Org = incoming.Org
+	i.Bucket = incoming.Bucket
+	i.SkipTLSVerify = incoming.SkipTLSVerify
+
+	if incoming.Token != "" {
+		i.Token = incoming.Token
+	}
+}
+
+func (i *InfluxdbDatabase) EncryptSensitiveFields(
+	databaseID uuid.UUID,
+	encryptor encryption.FieldEncryptor,
+) error {
+	if i.Token != "" {
+		encrypted, err := encryptor.Encrypt(databaseID, i.Token)
+		if err != nil {
+			return err
+		}
+		i.Token = encrypted
+	}
+	return nil
+}
+
+func (i *InfluxdbDatabase) PopulateDbData(
+	_ *slog.Logger,
+	_ encryption.FieldEncryptor,
+	_ uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return i.populateVersion(ctx)
+}
+
+// DecryptToken returns the plain token, nil encryptor means it is not encrypted
+func (i *InfluxdbDatabase) DecryptToken(
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (string, error) {
+	if encryptor == nil {
+		return i.Token, nil
+	}
+
+	token, err := encryptor.Decrypt(databaseID, i.Token)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %w", err)
+	}
+
+	return token, nil
+}
+
+// CheckRestoreCompatibility rejects restore options this backup cannot satisfy. Full
+// restores need the metadata of a full backup, renaming needs a single bucket
+func (i *InfluxdbDatabase) CheckRestoreCompatibility(target *InfluxdbDatabase) error {
+	if target.IsFullRestore && target.RestoreBucket != "" {
+		return errors.New("restore bucket cannot be set for a full restore")
+	}
+
+	if target.IsFullRestore && i.Bucket != "" {
+		return fmt.Errorf(
+			"full restore needs a full backup, this backup has bucket %s only",
+			i.Bucket,
+		)
+	}
+
+	if target.RestoreBucket != "" && i.Bucket == "" {
+		return errors.New("restore bucket can be set only for backups of a single bucket")
+	}
+
+	return nil
+}
+
+// populateVersion reads /health, it needs no token
+func (i *InfluxdbDatabase) populateVersion(ctx context.Context) error {
+	var health struct {
+		Version string `json:"version"`
+	}
+
+	if err := i.get(ctx, "/health", "", &health); err != nil {
+		return fmt.Errorf("failed to connect to InfluxDB: %w", err)
+	}
+
+	version := strings.TrimPrefix(health.Version, "v")
+	if !strings.HasPrefix(version, "2.") {
+		return fmt.Errorf("InfluxDB %s is not supported, only 2.x servers are", health.Version)
+	}
+
+	i.Version = version
+	return nil
+}
+
+func (i *InfluxdbDatabase) get(ctx context.Context, path, token string, result any) error {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		strings.TrimSuffix(i.URL, "/")+path,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if token != "" {
+		request.Header.Set("Authorization", "Token "+token)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if i.SkipTLSVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	response, err := (&http.Client{Transport: transport}).Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4*1024))
+		return fmt.Errorf(
+			"server responded with %d: %s",
+			response.StatusCode,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
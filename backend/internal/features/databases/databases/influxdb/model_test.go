@@ -0,0 +1,121 @@
+package influxdb
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate_InvalidConfiguration_ReturnsError(t *testing.T) {
+	for name, database := range map[string]*InfluxdbDatabase{
+		"empty url":        {Token: "token"},
+		"no scheme":        {URL: "localhost:8086", Token: "token"},
+		"ftp scheme":       {URL: "ftp://localhost", Token: "token"},
+		"no token":         {URL: "http://influx:8086"},
+		"bucket no org":    {URL: "http://influx:8086", Token: "token", Bucket: "metrics"},
+		"only host scheme": {URL: "http://", Token: "token"},
+	} {
+		assert.Error(t, database.Validate(), name)
+	}
+}
+
+func Test_Validate_ValidConfiguration_Succeeds(t *testing.T) {
+	database := &InfluxdbDatabase{
+		URL:    "https://influx.internal:8086",
+		Token:  "token",
+		Org:    "acme",
+		Bucket: "metrics",
+	}
+
+	assert.NoError(t, database.Validate())
+}
+
+func Test_CheckRestoreCompatibility_ValidatesRestoreOptions(t *testing.T) {
+	fullBackup := &InfluxdbDatabase{}
+	bucketBackup := &InfluxdbDatabase{Org: "acme", Bucket: "metrics"}
+
+	assert.NoError(t, fullBackup.CheckRestoreCompatibility(&InfluxdbDatabase{IsFullRestore: true}))
+	assert.NoError(t, bucketBackup.CheckRestoreCompatibility(
+		&InfluxdbDatabase{RestoreBucket: "metrics_restored"},
+	))
+	assert.Error(t, bucketBackup.CheckRestoreCompatibility(&InfluxdbDatabase{IsFullRestore: true}))
+	assert.Error(t, fullBackup.CheckRestoreCompatibility(
+		&InfluxdbDatabase{RestoreBucket: "metrics_restored"},
+	))
+	assert.Error(t, fullBackup.CheckRestoreCompatibility(
+		&InfluxdbDatabase{IsFullRestore: true, RestoreBucket: "metrics_restored"},
+	))
+}
+
+func Test_TestConnection_DetectsVersionAndFindsBucket(t *testing.T) {
+	var bucketsQuery, authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/health":
+				_, _ = io.WriteString(writer, `{"status": "pass", "version": "v2.7.5"}`)
+			case "/api/v2/buckets":
+				authorization = request.Header.Get("Authorization")
+				bucketsQuery = request.URL.RawQuery
+				_, _ = io.WriteString(writer, `{"buckets": [{"id": "0f3c"}]}`)
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		},
+	))
+	defer server.Close()
+
+	database := &InfluxdbDatabase{
+		URL:    server.URL,
+		Token:  "secret",
+		Org:    "acme",
+		Bucket: "metrics",
+	}
+
+	require.NoError(t, database.TestConnection(createTestLogger(), nil, uuid.New()))
+
+	assert.Equal(t, "2.7.5", database.Version)
+	assert.Equal(t, "Token secret", authorization)
+	assert.Equal(t, "limit=1&name=metrics&org=acme", bucketsQuery)
+}
+
+func Test_TestConnection_MissingBucket_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path == "/health" {
+				_, _ = io.WriteString(writer, `{"version": "v2.7.5"}`)
+				return
+			}
+			_, _ = io.WriteString(writer, `{"buckets": []}`)
+		},
+	))
+	defer server.Close()
+
+	database := &InfluxdbDatabase{URL: server.URL, Token: "secret", Org: "acme", Bucket: "x"}
+
+	assert.Error(t, database.TestConnection(createTestLogger(), nil, uuid.New()))
+}
+
+func Test_TestConnection_InfluxdbV1_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(writer, `{"version": "1.8.10"}`)
+		},
+	))
+	defer server.Close()
+
+	database := &InfluxdbDatabase{URL: server.URL, Token: "secret"}
+
+	assert.Error(t, database.TestConnection(createTestLogger(), nil, uuid.New()))
+}
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"databasus-backend/internal/util/encryption"
+	network_utils "databasus-backend/internal/util/network"
 	"databasus-backend/internal/util/tools"
 
 	"github.com/google/uuid"
@@ -34,12 +36,26 @@ type MongodbDatabase struct {
 	IsHttps      bool   `json:"isHttps"      gorm:"type:boolean;default:false"`
 	IsSrv        bool   `json:"isSrv"        gorm:"column:is_srv;type:boolean;not null;default:false"`
 	CpuCount     int    `json:"cpuCount"     gorm:"column:cpu_count;type:int;not null;default:1"`
+
+	// address the host resolves to with the DNS override, set only while connecting. The host
+	// is kept for TLS so the certificate is checked against its name (not saved to DB)
+	HostAddress string `json:"-" gorm:"-"`
 }
 
 func (m *MongodbDatabase) TableName() string {
 	return "mongodb_databases"
 }
 
+// DialHost returns the address to connect to, which is the one resolved with the DNS override
+// when it is set
+func (m *MongodbDatabase) DialHost() string {
+	if m.HostAddress != "" {
+		return m.HostAddress
+	}
+
+	return m.Host
+}
+
 func (m *MongodbDatabase) Validate() error {
 	if m.Host == "" {
 		return errors.New("host is required")
@@ -80,9 +96,7 @@ func (m *MongodbDatabase) TestConnection(
 		return fmt.Errorf("failed to decrypt password: %w", err)
 	}
 
-	uri := m.buildConnectionURI(password)
-
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := m.buildClientOptions(password)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return fmt.Errorf("failed to connect to MongoDB: %w", err)
@@ -174,9 +188,7 @@ func (m *MongodbDatabase) PopulateVersion(
 		return fmt.Errorf("failed to decrypt password: %w", err)
 	}
 
-	uri := m.buildConnectionURI(password)
-
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := m.buildClientOptions(password)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
@@ -207,9 +219,7 @@ func (m *MongodbDatabase) IsUserReadOnly(
 		return false, nil, fmt.Errorf("failed to decrypt password: %w", err)
 	}
 
-	uri := m.buildConnectionURI(password)
-
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := m.buildClientOptions(password)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -394,9 +404,7 @@ func (m *MongodbDatabase) CreateReadOnlyUser(
 		return "", "", fmt.Errorf("failed to decrypt password: %w", err)
 	}
 
-	uri := m.buildConnectionURI(password)
-
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := m.buildClientOptions(password)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to connect to database: %w", err)
@@ -450,6 +458,18 @@ func (m *MongodbDatabase) CreateReadOnlyUser(
 	return "", "", errors.New("failed to generate unique username after 3 attempts")
 }
 
+// buildClientOptions builds the driver options. With the DNS override the URI has the resolved
+// address, so TLS is given the host name
+func (m *MongodbDatabase) buildClientOptions(password string) *options.ClientOptions {
+	clientOptions := options.Client().ApplyURI(m.buildConnectionURI(password))
+
+	if m.IsHttps && m.HostAddress != "" {
+		clientOptions.SetTLSConfig(&tls.Config{ServerName: m.Host, InsecureSkipVerify: true})
+	}
+
+	return clientOptions
+}
+
 // buildConnectionURI builds a MongoDB connection URI
 func (m *MongodbDatabase) buildConnectionURI(password string) string {
 	authDB := m.AuthDatabase
@@ -480,11 +500,10 @@ func (m *MongodbDatabase) buildConnectionURI(password string) string {
 	}
 
 	return fmt.Sprintf(
-		"mongodb://%s:%s@%s:%d/%s?authSource=%s&connectTimeoutMS=15000%s",
+		"mongodb://%s:%s@%s/%s?authSource=%s&connectTimeoutMS=15000%s",
 		url.QueryEscape(m.Username),
 		url.QueryEscape(password),
-		m.Host,
-		port,
+		network_utils.JoinHostPort(m.DialHost(), port),
 		m.Database,
 		authDB,
 		tlsParams,
@@ -520,11 +539,10 @@ func (m *MongodbDatabase) BuildMongodumpURI(password string) string {
 	}
 
 	return fmt.Sprintf(
-		"mongodb://%s:%s@%s:%d/?authSource=%s&connectTimeoutMS=15000%s",
+		"mongodb://%s:%s@%s/?authSource=%s&connectTimeoutMS=15000%s",
 		url.QueryEscape(m.Username),
 		url.QueryEscape(password),
-		m.Host,
-		port,
+		network_utils.JoinHostPort(m.DialHost(), port),
 		authDB,
 		tlsParams,
 	)
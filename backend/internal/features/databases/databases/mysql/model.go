@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"databasus-backend/internal/util/encryption"
+	network_utils "databasus-backend/internal/util/network"
 	"databasus-backend/internal/util/tools"
 
 	"github.com/go-sql-driver/mysql"
@@ -32,12 +33,26 @@ type MysqlDatabase struct {
 	Database   *string `json:"database"   gorm:"type:text"`
 	IsHttps    bool    `json:"isHttps"    gorm:"type:boolean;default:false"`
 	Privileges string  `json:"privileges" gorm:"column:privileges;type:text;not null;default:''"`
+
+	// address the host resolves to with the DNS override, set only while connecting. The host
+	// is kept for TLS so the certificate is checked against its name (not saved to DB)
+	HostAddress string `json:"-" gorm:"-"`
 }
 
 func (m *MysqlDatabase) TableName() string {
 	return "mysql_databases"
 }
 
+// DialHost returns the address to connect to, which is the one resolved with the DNS override
+// when it is set
+func (m *MysqlDatabase) DialHost() string {
+	if m.HostAddress != "" {
+		return m.HostAddress
+	}
+
+	return m.Host
+}
+
 func (m *MysqlDatabase) Validate() error {
 	if m.Host == "" {
 		return errors.New("host is required")
@@ -398,29 +413,40 @@ func HasPrivilege(privileges, priv string) bool {
 	return false
 }
 
+// registerTLSConfig registers the TLS config for the DSN and returns its name. With the DNS
+// override the config is registered per host, so TLS keeps the host name while the address is
+// dialed
+func (m *MysqlDatabase) registerTLSConfig() string {
+	name := "mysql-skip-verify"
+	config := &tls.Config{InsecureSkipVerify: true}
+
+	if m.HostAddress != "" {
+		name += "-" + m.Host
+		config.ServerName = m.Host
+	}
+
+	if err := mysql.RegisterTLSConfig(name, config); err != nil {
+		// Config might already be registered, which is fine
+		_ = err
+	}
+
+	return name
+}
+
 func (m *MysqlDatabase) buildDSN(password string, database string) string {
 	tlsConfig := "false"
 	allowCleartext := ""
 
 	if m.IsHttps {
-		err := mysql.RegisterTLSConfig("mysql-skip-verify", &tls.Config{
-			InsecureSkipVerify: true,
-		})
-		if err != nil {
-			// Config might already be registered, which is fine
-			_ = err
-		}
-
-		tlsConfig = "mysql-skip-verify"
+		tlsConfig = m.registerTLSConfig()
 		allowCleartext = "&allowCleartextPasswords=1"
 	}
 
 	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%d)/%s?parseTime=true&timeout=15s&tls=%s&charset=utf8mb4%s",
+		"%s:%s@tcp(%s)/%s?parseTime=true&timeout=15s&tls=%s&charset=utf8mb4%s",
 		m.Username,
 		password,
-		m.Host,
-		m.Port,
+		network_utils.JoinHostPort(m.DialHost(), m.Port),
 		database,
 		tlsConfig,
 		allowCleartext,
@@ -0,0 +1,121 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// neo4jUser owns the data directory in packages and images of Neo4j
+const neo4jUser = "neo4j"
+
+// CheckAdminTool runs neo4j-admin on the host to check the tool and permissions
+func (c *Client) CheckAdminTool(ctx context.Context) error {
+	if _, err := c.run(ctx, c.asNeo4jUser("neo4j-admin --version"), nil, nil); err != nil {
+		return fmt.Errorf("neo4j-admin is not usable on the host: %w", err)
+	}
+
+	return nil
+}
+
+// Dump writes a dump of the stopped database to the writer and returns log output of
+// neo4j-admin
+func (c *Client) Dump(ctx context.Context, database string, writer io.Writer) ([]byte, error) {
+	command := joinCommand([]string{"neo4j-admin", "database", "dump", database, "--to-stdout"})
+	return c.run(ctx, c.asNeo4jUser(command), nil, writer)
+}
+
+// Load replaces the stopped database with the dump from the reader
+func (c *Client) Load(ctx context.Context, database string, reader io.Reader) ([]byte, error) {
+	command := joinCommand([]string{
+		"neo4j-admin", "database", "load", database,
+		"--from-stdin", "--overwrite-destination=true",
+	})
+	return c.run(ctx, c.asNeo4jUser(command), reader, nil)
+}
+
+// Backup takes an online full backup of the database into the directory on the host
+// from the backup port of the local server
+func (c *Client) Backup(ctx context.Context, database string, directory string) ([]byte, error) {
+	command := fmt.Sprintf(
+		"mkdir -p %s && %s",
+		shellQuote(directory),
+		joinCommand([]string{
+			"neo4j-admin", "database", "backup", "--to-path=" + directory, database,
+		}),
+	)
+	return c.run(ctx, c.asNeo4jUser(command), nil, nil)
+}
+
+// Restore replaces the stopped or missing database with the backup artifacts in the
+// directory on the host
+func (c *Client) Restore(ctx context.Context, database string, directory string) ([]byte, error) {
+	command := joinCommand([]string{
+		"neo4j-admin", "database", "restore",
+		"--from-path=" + directory, "--overwrite-destination=true", database,
+	})
+	return c.run(ctx, c.asNeo4jUser(command), nil, nil)
+}
+
+// StreamDirectory writes a tar of the directory on the host to the writer
+func (c *Client) StreamDirectory(ctx context.Context, directory string, writer io.Writer) error {
+	_, err := c.run(ctx, c.asNeo4jUser("tar -cf - -C "+shellQuote(directory)+" ."), nil, writer)
+	return err
+}
+
+// Upload extracts the tar from the reader into the directory on the host
+func (c *Client) Upload(ctx context.Context, directory string, archive io.Reader) error {
+	command := fmt.Sprintf(
+		"mkdir -p %s && tar -xf - -C %s",
+		shellQuote(directory),
+		shellQuote(directory),
+	)
+
+	_, err := c.run(ctx, c.asNeo4jUser(command), archive, nil)
+	return err
+}
+
+func (c *Client) RemoveDirectory(ctx context.Context, directory string) error {
+	_, err := c.run(ctx, c.asNeo4jUser("rm -rf "+shellQuote(directory)), nil, nil)
+	return err
+}
+
+// StopService and StartService stop the whole server with systemd, the only way to take
+// a database of Community Edition offline
+func (c *Client) StopService(ctx context.Context) error {
+	_, err := c.run(ctx, c.asRoot(joinCommand([]string{
+		"systemctl", "stop", c.database.ServiceName,
+	})), nil, nil)
+	return err
+}
+
+func (c *Client) StartService(ctx context.Context) error {
+	_, err := c.run(ctx, c.asRoot(joinCommand([]string{
+		"systemctl", "start", c.database.ServiceName,
+	})), nil, nil)
+	return err
+}
+
+func (c *Client) asNeo4jUser(command string) string {
+	if c.database.IsUseSudo {
+		return "sudo -n -u " + neo4jUser + " sh -c " + shellQuote(command)
+	}
+
+	return command
+}
+
+func (c *Client) asRoot(command string) string {
+	if c.database.IsUseSudo {
+		return "sudo -n " + command
+	}
+
+	return command
+}
+
+// IsOnlineBackupArchive checks the ustar magic of the first tar header. Online backups
+// are stored as tar of the backup directory while dumps have their own format, so a
+// change of the mode does not break restores of older backups
+func IsOnlineBackupArchive(header []byte) bool {
+	return len(header) >= 262 && strings.HasPrefix(string(header[257:262]), "ustar")
+}
@@ -0,0 +1,219 @@
+package neo4j
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshConnectTimeout = 30 * time.Second
+	httpTimeout       = 60 * time.Second
+	maxCommandOutput  = 64 * 1024
+
+	safeShellChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./,:="
+)
+
+// Client talks Cypher to the HTTP API and runs neo4j-admin on the host over SSH, a
+// connection is opened per command. Secrets are decrypted once and masked in errors
+type Client struct {
+	database   *Neo4jDatabase
+	httpClient *http.Client
+
+	password      string
+	sshPassword   string
+	sshPrivateKey string
+}
+
+func (n *Neo4jDatabase) NewClient(
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (*Client, error) {
+	client := &Client{
+		database: n,
+		httpClient: &http.Client{
+			Timeout: httpTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: n.SkipTLSVerify},
+			},
+		},
+	}
+
+	secrets := []struct {
+		name   string
+		value  string
+		target *string
+	}{
+		{"password", n.Password, &client.password},
+		{"ssh password", n.SshPassword, &client.sshPassword},
+		{"ssh private key", n.SshPrivateKey, &client.sshPrivateKey},
+	}
+
+	for _, secret := range secrets {
+		if secret.value == "" || encryptor == nil {
+			*secret.target = secret.value
+			continue
+		}
+
+		decrypted, err := encryptor.Decrypt(databaseID, secret.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", secret.name, err)
+		}
+		*secret.target = decrypted
+	}
+
+	return client, nil
+}
+
+// run executes the command on the host. Without stdout writer the output is returned,
+// with it only stderr is kept and returned
+func (c *Client) run(
+	ctx context.Context,
+	command string,
+	stdin io.Reader,
+	stdout io.Writer,
+) ([]byte, error) {
+	client, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	output := &limitedBuffer{limit: maxCommandOutput}
+	session.Stdin = stdin
+	session.Stderr = output
+	session.Stdout = output
+	if stdout != nil {
+		session.Stdout = stdout
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Close()
+			_ = client.Close()
+		case <-done:
+		}
+	}()
+
+	if err := session.Run(command); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return nil, fmt.Errorf(
+			"command failed: %w: %s",
+			err,
+			maskSecrets(
+				strings.TrimSpace(output.String()),
+				[]string{c.password, c.sshPassword},
+			),
+		)
+	}
+
+	return output.Bytes(), nil
+}
+
+func (c *Client) connect(ctx context.Context) (*ssh.Client, error) {
+	var authMethods []ssh.AuthMethod
+
+	if c.sshPrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(c.sshPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh private key: %w", err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if c.sshPassword != "" {
+		authMethods = append(authMethods, ssh.Password(c.sshPassword))
+	}
+
+	if len(authMethods) == 0 {
+		return nil, errors.New("no ssh authentication method configured")
+	}
+
+	config := &ssh.ClientConfig{
+		User:            c.database.SshUsername,
+		Auth:            authMethods,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshConnectTimeout,
+	}
+
+	address := net.JoinHostPort(c.database.GetSshHost(), strconv.Itoa(c.database.SshPort))
+
+	dialer := net.Dialer{Timeout: sshConnectTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("ssh handshake with %s failed: %w", address, err)
+	}
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// limitedBuffer keeps the first bytes of the output, enough for error messages
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		_, _ = b.Buffer.Write(p[:min(len(p), remaining)])
+	}
+
+	return len(p), nil
+}
+
+func joinCommand(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, safeShellChars) == "" {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func maskSecrets(output string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			output = strings.ReplaceAll(output, secret, "***")
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,260 @@
+package neo4j
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	systemDatabase = "system"
+
+	databaseStatusOnline = "online"
+
+	databaseStatusPollInterval = 2 * time.Second
+)
+
+var ErrDatabaseNotFound = errors.New("database is not found")
+
+type cypherRequest struct {
+	Statements []cypherStatement `json:"statements"`
+}
+
+type cypherStatement struct {
+	Statement string `json:"statement"`
+}
+
+type cypherResponse struct {
+	Results []struct {
+		Columns []string `json:"columns"`
+		Data    []struct {
+			Row []any `json:"row"`
+		} `json:"data"`
+	} `json:"results"`
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetServerInfo reads the version and edition from the discovery document of the API
+func (c *Client) GetServerInfo(ctx context.Context) (string, Neo4jEdition, error) {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		strings.TrimRight(c.database.URL, "/")+"/",
+		nil,
+	)
+	if err != nil {
+		return "", "", err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to connect to Neo4j: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("neo4j returned status %d", response.StatusCode)
+	}
+
+	var discovery struct {
+		Version string `json:"neo4j_version"`
+		Edition string `json:"neo4j_edition"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&discovery); err != nil {
+		return "", "", fmt.Errorf("failed to parse discovery response: %w", err)
+	}
+
+	if discovery.Version == "" {
+		return "", "", errors.New("not a Neo4j HTTP API, neo4j_version is missing")
+	}
+
+	return discovery.Version, Neo4jEdition(discovery.Edition), nil
+}
+
+// GetDatabaseStatus returns the current status of the database, a status other than
+// online on any server of a cluster wins
+func (c *Client) GetDatabaseStatus(ctx context.Context, name string) (string, error) {
+	rows, err := c.query(
+		ctx,
+		systemDatabase,
+		"SHOW DATABASE "+quoteName(name)+" YIELD currentStatus",
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rows) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrDatabaseNotFound, name)
+	}
+
+	for _, row := range rows {
+		if status, _ := row[0].(string); status != databaseStatusOnline {
+			return status, nil
+		}
+	}
+
+	return databaseStatusOnline, nil
+}
+
+// StopDatabase and StartDatabase need Enterprise Edition, Community Edition stops the
+// service instead
+func (c *Client) StopDatabase(ctx context.Context, name string) error {
+	_, err := c.query(ctx, systemDatabase, "STOP DATABASE "+quoteName(name)+" WAIT")
+	return err
+}
+
+func (c *Client) StartDatabase(ctx context.Context, name string) error {
+	_, err := c.query(ctx, systemDatabase, "START DATABASE "+quoteName(name)+" WAIT")
+	return err
+}
+
+// CreateDatabase registers a database restored or loaded on the host under a new name
+func (c *Client) CreateDatabase(ctx context.Context, name string) error {
+	_, err := c.query(
+		ctx,
+		systemDatabase,
+		"CREATE DATABASE "+quoteName(name)+" IF NOT EXISTS WAIT",
+	)
+	return err
+}
+
+// WaitForDatabase polls until the database is online and answers Cypher or the timeout
+// passes. Servers restarted after a dump or load take a while to open the API
+func (c *Client) WaitForDatabase(
+	ctx context.Context,
+	name string,
+	timeout time.Duration,
+) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		status, err := c.GetDatabaseStatus(waitCtx, name)
+		switch {
+		case err != nil:
+			lastErr = err
+		case status != databaseStatusOnline:
+			lastErr = fmt.Errorf("database %s is %s", name, status)
+		default:
+			if _, err := c.query(waitCtx, name, "RETURN 1"); err != nil {
+				lastErr = err
+			} else {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-waitCtx.Done():
+			return fmt.Errorf("database %s is not online after %s: %w", name, timeout, lastErr)
+		case <-time.After(databaseStatusPollInterval):
+		}
+	}
+}
+
+// CountNodes and CountRelationships read counts from the count store, they are cheap on
+// any database size
+func (c *Client) CountNodes(ctx context.Context, name string) (int64, error) {
+	return c.queryCount(ctx, name, "MATCH (n) RETURN count(n)")
+}
+
+func (c *Client) CountRelationships(ctx context.Context, name string) (int64, error) {
+	return c.queryCount(ctx, name, "MATCH ()-[r]->() RETURN count(r)")
+}
+
+func (c *Client) queryCount(ctx context.Context, name string, statement string) (int64, error) {
+	rows, err := c.query(ctx, name, statement)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return 0, fmt.Errorf("unexpected result of %s", statement)
+	}
+
+	number, isNumber := rows[0][0].(json.Number)
+	if !isNumber {
+		return 0, fmt.Errorf("unexpected result of %s", statement)
+	}
+
+	return number.Int64()
+}
+
+// query runs the statement in its own transaction and returns rows of its result
+func (c *Client) query(ctx context.Context, database string, statement string) ([][]any, error) {
+	body, err := json.Marshal(cypherRequest{
+		Statements: []cypherStatement{{Statement: statement}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		strings.TrimRight(c.database.URL, "/")+"/db/"+database+"/tx/commit",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+	request.SetBasicAuth(c.database.Username, c.password)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Neo4j: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode == http.StatusUnauthorized {
+		return nil, errors.New("neo4j rejected the username or password")
+	}
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf(
+			"neo4j returned status %d: %s",
+			response.StatusCode,
+			strings.TrimSpace(string(message)),
+		)
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+
+	var result cypherResponse
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse Cypher response: %w", err)
+	}
+
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("%s: %s", result.Errors[0].Code, result.Errors[0].Message)
+	}
+
+	var rows [][]any
+	for _, statementResult := range result.Results {
+		for _, data := range statementResult.Data {
+			rows = append(rows, data.Row)
+		}
+	}
+
+	return rows, nil
+}
+
+// quoteName escapes the database name as a Cypher identifier
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,291 @@
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+type Neo4jBackupMode string
+
+const (
+	// Neo4jBackupModeDump runs neo4j-admin database dump, the database is offline meanwhile
+	Neo4jBackupModeDump Neo4jBackupMode = "DUMP"
+	// Neo4jBackupModeOnline runs neo4j-admin database backup of Enterprise Edition, the
+	// database stays online
+	Neo4jBackupModeOnline Neo4jBackupMode = "ONLINE_BACKUP"
+)
+
+type Neo4jEdition string
+
+const (
+	Neo4jEditionCommunity  Neo4jEdition = "community"
+	Neo4jEditionEnterprise Neo4jEdition = "enterprise"
+)
+
+// minNeo4jMajorVersion is the first major with the neo4j-admin database commands
+const minNeo4jMajorVersion = 5
+
+var databaseNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.\-]{2,62}$`)
+
+// Neo4jDatabase is a Neo4j server backed up with neo4j-admin over SSH on its host. Cypher
+// over the HTTP API detects the version and edition, stops and starts databases and
+// checks restored databases
+type Neo4jDatabase struct {
+	ID         uuid.UUID  `json:"id"         gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	DatabaseID *uuid.UUID `json:"databaseId" gorm:"type:uuid;column:database_id"`
+
+	// Version and Edition are detected on connection
+	Version string       `json:"version" gorm:"type:text;not null;default:''"`
+	Edition Neo4jEdition `json:"edition" gorm:"type:text;not null;default:''"`
+
+	// URL is the HTTP API, e.g. http://neo4j.internal:7474
+	URL           string `json:"url"           gorm:"column:url;type:text;not null"`
+	Username      string `json:"username"      gorm:"type:text;not null"`
+	Password      string `json:"password"      gorm:"type:text;not null"`
+	SkipTLSVerify bool   `json:"skipTlsVerify" gorm:"column:skip_tls_verify;type:boolean;not null;default:false"`
+	Database      string `json:"database"      gorm:"type:text;not null;default:'neo4j'"`
+
+	BackupMode Neo4jBackupMode `json:"backupMode" gorm:"column:backup_mode;type:text;not null;default:'DUMP'"`
+
+	// SshHost runs neo4j-admin, the host of the URL when empty
+	SshHost       string `json:"sshHost"       gorm:"column:ssh_host;type:text;not null;default:''"`
+	SshPort       int    `json:"sshPort"       gorm:"column:ssh_port;type:int;not null;default:22"`
+	SshUsername   string `json:"sshUsername"   gorm:"column:ssh_username;type:text;not null"`
+	SshPassword   string `json:"sshPassword"   gorm:"column:ssh_password;type:text;not null;default:''"`
+	SshPrivateKey string `json:"sshPrivateKey" gorm:"column:ssh_private_key;type:text;not null;default:''"`
+	// IsUseSudo runs neo4j-admin as the neo4j user and the service commands as root with
+	// `sudo -n`, otherwise the SSH user must own the Neo4j data directory
+	IsUseSudo bool `json:"isUseSudo" gorm:"column:is_use_sudo;type:boolean;not null;default:false"`
+	// ServiceName is stopped during dumps of Community Edition, it cannot stop a database
+	// alone
+	ServiceName string `json:"serviceName" gorm:"column:service_name;type:text;not null;default:'neo4j'"`
+
+	// restore only: restore into this database, the backed up name when empty
+	RestoreDatabase string `json:"restoreDatabase" gorm:"-"`
+	// restore only: directory on the host where online backups are uploaded to restore them
+	StagingDirectory string `json:"stagingDirectory" gorm:"-"`
+}
+
+func (n *Neo4jDatabase) TableName() string {
+	return "neo4j_databases"
+}
+
+func (n *Neo4jDatabase) Validate() error {
+	if err := n.validateConnection(); err != nil {
+		return err
+	}
+
+	if !databaseNameRegex.MatchString(n.Database) {
+		return fmt.Errorf("invalid database name: %s", n.Database)
+	}
+
+	switch n.BackupMode {
+	case Neo4jBackupModeDump:
+	case Neo4jBackupModeOnline:
+		if n.Edition == Neo4jEditionCommunity {
+			return errors.New("online backups need Neo4j Enterprise Edition")
+		}
+	default:
+		return fmt.Errorf("invalid backup mode: %s", n.BackupMode)
+	}
+
+	return nil
+}
+
+// ValidateRestoreTarget checks the connection settings of the target server and restore
+// options. Backup settings are not used by restores
+func (n *Neo4jDatabase) ValidateRestoreTarget() error {
+	if err := n.validateConnection(); err != nil {
+		return err
+	}
+
+	if n.RestoreDatabase != "" && !databaseNameRegex.MatchString(n.RestoreDatabase) {
+		return fmt.Errorf("invalid restore database name: %s", n.RestoreDatabase)
+	}
+
+	// Community Edition serves only the database of initial.dbms.default_database
+	if n.Edition == Neo4jEditionCommunity && n.RestoreDatabase != "" &&
+		n.RestoreDatabase != n.Database {
+		return errors.New("restoring into another database needs Neo4j Enterprise Edition")
+	}
+
+	if n.StagingDirectory != "" && !path.IsAbs(n.StagingDirectory) {
+		return errors.New("staging directory must be an absolute path")
+	}
+
+	return nil
+}
+
+// TestConnection detects the version and edition, checks the database exists and that
+// neo4j-admin runs on the SSH host
+func (n *Neo4jDatabase) TestConnection(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client, err := n.NewClient(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := n.populateServerInfo(ctx, client); err != nil {
+		return err
+	}
+
+	if n.BackupMode == Neo4jBackupModeOnline && n.Edition != Neo4jEditionEnterprise {
+		return errors.New("online backups need Neo4j Enterprise Edition")
+	}
+
+	if _, err := client.GetDatabaseStatus(ctx, n.Database); err != nil {
+		return err
+	}
+
+	return client.CheckAdminTool(ctx)
+}
+
+func (n *Neo4jDatabase) HideSensitiveData() {
+	if n == nil {
+		return
+	}
+	n.Password = ""
+	n.SshPassword = ""
+	n.SshPrivateKey = ""
+}
+
+func (n *Neo4jDatabase) Update(incoming *Neo4jDatabase) {
+	n.Version = incoming.Version
+	n.Edition = incoming.Edition
+	n.URL = incoming.URL
+	n.Username = incoming.Username
+	n.SkipTLSVerify = incoming.SkipTLSVerify
+	n.Database = incoming.Database
+	n.BackupMode = incoming.BackupMode
+	n.SshHost = incoming.SshHost
+	n.SshPort = incoming.SshPort
+	n.SshUsername = incoming.SshUsername
+	n.IsUseSudo = incoming.IsUseSudo
+	n.ServiceName = incoming.ServiceName
+
+	if incoming.Password != "" {
+		n.Password = incoming.Password
+	}
+	if incoming.SshPassword != "" {
+		n.SshPassword = incoming.SshPassword
+	}
+	if incoming.SshPrivateKey != "" {
+		n.SshPrivateKey = incoming.SshPrivateKey
+	}
+}
+
+func (n *Neo4jDatabase) EncryptSensitiveFields(
+	databaseID uuid.UUID,
+	encryptor encryption.FieldEncryptor,
+) error {
+	for _, field := range []*string{&n.Password, &n.SshPassword, &n.SshPrivateKey} {
+		if *field == "" {
+			continue
+		}
+
+		encrypted, err := encryptor.Encrypt(databaseID, *field)
+		if err != nil {
+			return err
+		}
+		*field = encrypted
+	}
+	return nil
+}
+
+func (n *Neo4jDatabase) PopulateDbData(
+	_ *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := n.NewClient(encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+
+	return n.populateServerInfo(ctx, client)
+}
+
+// GetSshHost returns the host neo4j-admin runs on
+func (n *Neo4jDatabase) GetSshHost() string {
+	if n.SshHost != "" {
+		return n.SshHost
+	}
+
+	parsedURL, err := url.Parse(n.URL)
+	if err != nil {
+		return ""
+	}
+
+	return parsedURL.Hostname()
+}
+
+func (n *Neo4jDatabase) validateConnection() error {
+	if n.URL == "" {
+		return errors.New("url is required")
+	}
+
+	parsedURL, err := url.Parse(n.URL)
+	if err != nil || parsedURL.Host == "" ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return errors.New("url must be http(s)://host:port of the HTTP API")
+	}
+
+	if n.Username == "" || n.Password == "" {
+		return errors.New("username and password are required")
+	}
+
+	if n.GetSshHost() == "" {
+		return errors.New("ssh host is required")
+	}
+
+	if n.SshPort <= 0 || n.SshPort > 65535 {
+		return errors.New("ssh port must be between 1 and 65535")
+	}
+
+	if n.SshUsername == "" || (n.SshPassword == "" && n.SshPrivateKey == "") {
+		return errors.New("ssh username and password or private key are required")
+	}
+
+	return nil
+}
+
+func (n *Neo4jDatabase) populateServerInfo(ctx context.Context, client *Client) error {
+	version, edition, err := client.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if major < minNeo4jMajorVersion {
+		return fmt.Errorf(
+			"Neo4j %s is not supported, %d.x or newer is required",
+			version,
+			minNeo4jMajorVersion,
+		)
+	}
+
+	n.Version = version
+	n.Edition = edition
+	return nil
+}
@@ -0,0 +1,101 @@
+package neo4j
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Validate_InvalidConfiguration_ReturnsError(t *testing.T) {
+	valid := func() *Neo4jDatabase {
+		return &Neo4jDatabase{
+			URL:         "http://neo4j:7474",
+			Username:    "neo4j",
+			Password:    "secret",
+			Database:    "neo4j",
+			BackupMode:  Neo4jBackupModeDump,
+			SshPort:     22,
+			SshUsername: "ops",
+			SshPassword: "secret",
+			ServiceName: "neo4j",
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	for name, mutate := range map[string]func(*Neo4jDatabase){
+		"no url":           func(n *Neo4jDatabase) { n.URL = "" },
+		"bolt url":         func(n *Neo4jDatabase) { n.URL = "bolt://neo4j:7687" },
+		"no password":      func(n *Neo4jDatabase) { n.Password = "" },
+		"invalid name":     func(n *Neo4jDatabase) { n.Database = "graph`; DROP" },
+		"invalid mode":     func(n *Neo4jDatabase) { n.BackupMode = "SNAPSHOT" },
+		"no ssh auth":      func(n *Neo4jDatabase) { n.SshPassword = "" },
+		"invalid ssh port": func(n *Neo4jDatabase) { n.SshPort = 0 },
+		"online on community": func(n *Neo4jDatabase) {
+			n.BackupMode = Neo4jBackupModeOnline
+			n.Edition = Neo4jEditionCommunity
+		},
+	} {
+		database := valid()
+		mutate(database)
+		assert.Error(t, database.Validate(), name)
+	}
+}
+
+func Test_GetSshHost_DefaultsToHostOfUrl(t *testing.T) {
+	database := &Neo4jDatabase{URL: "https://graph.internal:7473"}
+	assert.Equal(t, "graph.internal", database.GetSshHost())
+
+	database.SshHost = "10.0.0.5"
+	assert.Equal(t, "10.0.0.5", database.GetSshHost())
+}
+
+func Test_ValidateRestoreTarget_CommunityRestoresOnlyIntoItsDatabase(t *testing.T) {
+	target := &Neo4jDatabase{
+		URL:             "http://neo4j:7474",
+		Username:        "neo4j",
+		Password:        "secret",
+		Database:        "neo4j",
+		Edition:         Neo4jEditionCommunity,
+		SshPort:         22,
+		SshUsername:     "ops",
+		SshPrivateKey:   "key",
+		RestoreDatabase: "restored",
+	}
+	assert.Error(t, target.ValidateRestoreTarget())
+
+	target.Edition = Neo4jEditionEnterprise
+	assert.NoError(t, target.ValidateRestoreTarget())
+
+	target.StagingDirectory = "tmp"
+	assert.Error(t, target.ValidateRestoreTarget())
+}
+
+func Test_IsOnlineBackupArchive_DetectsTarHeader(t *testing.T) {
+	var archive bytes.Buffer
+	tarWriter := tar.NewWriter(&archive)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+		Name:     "neo4j-2026-01-01T00-00-00.backup",
+		Mode:     0o644,
+		Typeflag: tar.TypeReg,
+	}))
+	require.NoError(t, tarWriter.Close())
+
+	assert.True(t, IsOnlineBackupArchive(archive.Bytes()[:512]))
+	assert.False(t, IsOnlineBackupArchive(bytes.Repeat([]byte{0x28}, 512)))
+}
+
+func Test_Commands_QuoteArgumentsAndUseSudo(t *testing.T) {
+	client := &Client{database: &Neo4jDatabase{IsUseSudo: true, ServiceName: "neo4j"}}
+
+	assert.Equal(
+		t,
+		"sudo -n -u neo4j sh -c 'rm -rf /var/tmp/backup'",
+		client.asNeo4jUser("rm -rf "+shellQuote("/var/tmp/backup")),
+	)
+	assert.Equal(t, "sudo -n systemctl stop neo4j", client.asRoot("systemctl stop neo4j"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+	assert.Equal(t, "`we``ird`", quoteName("we`ird"))
+}
@@ -32,11 +32,20 @@ type PostgresqlDatabase struct {
 	Database *string `json:"database" gorm:"type:text"`
 	IsHttps  bool    `json:"isHttps"  gorm:"type:boolean;default:false"`
 
+	// address the host resolves to with the DNS override, set only while connecting. The host
+	// is kept for TLS so the certificate is checked against its name (not saved to DB)
+	HostAddress string `json:"-" gorm:"-"`
+
 	// backup settings
 	IncludeSchemas       []string `json:"includeSchemas" gorm:"-"`
 	IncludeSchemasString string   `json:"-"              gorm:"column:include_schemas;type:text;not null;default:''"`
 	CpuCount             int      `json:"cpuCount"       gorm:"column:cpu_count;type:int;not null;default:1"`
 
+	// TimescaleDB extension data, detected together with the version
+	TimescaledbVersion     string `json:"timescaledbVersion"     gorm:"column:timescaledb_version;type:text;not null;default:''"`
+	TimescaledbChunksCount int    `json:"timescaledbChunksCount" gorm:"column:timescaledb_chunks_count;type:int;not null;default:0"`
+	RecommendedCpuCount    int    `json:"recommendedCpuCount"    gorm:"-"`
+
 	// restore settings (not saved to DB)
 	IsExcludeExtensions bool `json:"isExcludeExtensions" gorm:"-"`
 }
@@ -62,6 +71,8 @@ func (p *PostgresqlDatabase) AfterFind(_ *gorm.DB) error {
 		p.IncludeSchemas = []string{}
 	}
 
+	p.RecommendedCpuCount = p.RecommendedRestoreCpuCount()
+
 	return nil
 }
 
@@ -153,6 +164,8 @@ func (p *PostgresqlDatabase) Update(incoming *PostgresqlDatabase) {
 	p.IsHttps = incoming.IsHttps
 	p.IncludeSchemas = incoming.IncludeSchemas
 	p.CpuCount = incoming.CpuCount
+	p.TimescaledbVersion = incoming.TimescaledbVersion
+	p.TimescaledbChunksCount = incoming.TimescaledbChunksCount
 
 	if incoming.Password != "" {
 		p.Password = incoming.Password
@@ -185,6 +198,7 @@ func (p *PostgresqlDatabase) PopulateDbData(
 }
 
 // PopulateVersion detects and sets the PostgreSQL version by querying the database.
+// TimescaleDB extension version and chunks count are detected along with it.
 func (p *PostgresqlDatabase) PopulateVersion(
 	logger *slog.Logger,
 	encryptor encryption.FieldEncryptor,
@@ -204,7 +218,7 @@ func (p *PostgresqlDatabase) PopulateVersion(
 
 	connStr := buildConnectionStringForDB(p, *p.Database, password)
 
-	conn, err := pgx.Connect(ctx, connStr)
+	conn, err := connect(ctx, p, connStr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -220,6 +234,15 @@ func (p *PostgresqlDatabase) PopulateVersion(
 	}
 
 	p.Version = detectedVersion
+
+	timescaledbVersion, chunksCount, err := detectTimescaledb(ctx, logger, conn)
+	if err != nil {
+		return err
+	}
+
+	p.TimescaledbVersion = timescaledbVersion
+	p.TimescaledbChunksCount = chunksCount
+	p.RecommendedCpuCount = p.RecommendedRestoreCpuCount()
 	return nil
 }
 
@@ -250,7 +273,7 @@ func (p *PostgresqlDatabase) IsUserReadOnly(
 
 	connStr := buildConnectionStringForDB(p, *p.Database, password)
 
-	conn, err := pgx.Connect(ctx, connStr)
+	conn, err := connect(ctx, p, connStr)
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -422,7 +445,7 @@ func (p *PostgresqlDatabase) CreateReadOnlyUser(
 
 	connStr := buildConnectionStringForDB(p, *p.Database, password)
 
-	conn, err := pgx.Connect(ctx, connStr)
+	conn, err := connect(ctx, p, connStr)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -712,7 +735,7 @@ func testSingleDatabaseConnection(
 	connStr := buildConnectionStringForDB(postgresDb, *postgresDb.Database, password)
 
 	// Test connection
-	conn, err := pgx.Connect(ctx, connStr)
+	conn, err := connect(ctx, postgresDb, connStr)
 	if err != nil {
 		// TODO make more readable errors:
 		// - handle wrong creds
@@ -903,6 +926,24 @@ func checkBackupPermissions(
 	return nil
 }
 
+// connect opens a connection with the connection string. When the DNS override resolved the
+// host, the connection goes to that address and the host name is still used for TLS
+func connect(ctx context.Context, p *PostgresqlDatabase, connStr string) (*pgx.Conn, error) {
+	config, err := pgx.ParseConfig(connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.HostAddress != "" {
+		hostAddress := p.HostAddress
+		config.LookupFunc = func(context.Context, string) ([]string, error) {
+			return []string{hostAddress}, nil
+		}
+	}
+
+	return pgx.ConnectConfig(ctx, config)
+}
+
 // buildConnectionStringForDB builds connection string for specific database
 func buildConnectionStringForDB(p *PostgresqlDatabase, dbName string, password string) string {
 	sslMode := "disable"
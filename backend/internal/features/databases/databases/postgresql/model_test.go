@@ -2,12 +2,24 @@ package postgresql
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"log/slog"
+	"math/big"
+	"net"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -243,6 +255,69 @@ func Test_TestConnection_SufficientPermissions_Success(t *testing.T) {
 	}
 }
 
+func Test_Connect_WhenDNSOverriddenWithVerifyFull_CertificateCheckedAgainstHost(t *testing.T) {
+	const host = "db.internal.example.com"
+
+	certificate, caFile := createTestCertificate(t, host)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	type handshakeResult struct {
+		serverName string
+		err        error
+	}
+	results := make(chan handshakeResult, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			results <- handshakeResult{err: err}
+			return
+		}
+		defer conn.Close()
+
+		// answer the SSLRequest sent before the TLS handshake
+		sslRequest := make([]byte, 8)
+		if _, err := io.ReadFull(conn, sslRequest); err != nil {
+			results <- handshakeResult{err: err}
+			return
+		}
+		if _, err := conn.Write([]byte("S")); err != nil {
+			results <- handshakeResult{err: err}
+			return
+		}
+
+		tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{certificate}})
+		err = tlsConn.Handshake()
+		results <- handshakeResult{serverName: tlsConn.ConnectionState().ServerName, err: err}
+	}()
+
+	p := &PostgresqlDatabase{
+		Host:        host,
+		Port:        listener.Addr().(*net.TCPAddr).Port,
+		Username:    "postgres",
+		HostAddress: "127.0.0.1",
+	}
+	connStr := buildConnectionStringForDB(p, "postgres", "password") +
+		" sslmode=verify-full sslrootcert=" + caFile
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the server closes after the handshake, only the TLS part of the connection is checked
+	_, _ = connect(ctx, p, connStr)
+
+	select {
+	case result := <-results:
+		require.NoError(t, result.err)
+		assert.Equal(t, host, result.serverName)
+	case <-ctx.Done():
+		t.Fatal("connection did not reach the overridden address")
+	}
+}
+
 func Test_IsUserReadOnly_AdminUser_ReturnsFalse(t *testing.T) {
 	env := config.GetEnv()
 	cases := []struct {
@@ -1310,6 +1385,31 @@ func Test_Validate_WhenRequiredFieldsMissing_ReturnsError(t *testing.T) {
 	}
 }
 
+func Test_RecommendedRestoreCpuCount_DependsOnTimescaledbChunks(t *testing.T) {
+	testCases := []struct {
+		name        string
+		version     string
+		chunksCount int
+		expected    int
+	}{
+		{name: "plain PostgreSQL", version: "", chunksCount: 0, expected: 1},
+		{name: "few chunks", version: "2.14.2", chunksCount: 10, expected: 1},
+		{name: "hundred chunks", version: "2.14.2", chunksCount: 100, expected: 4},
+		{name: "many chunks", version: "2.14.2", chunksCount: 5000, expected: 8},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &PostgresqlDatabase{
+				TimescaledbVersion:     tc.version,
+				TimescaledbChunksCount: tc.chunksCount,
+			}
+
+			assert.Equal(t, tc.expected, model.RecommendedRestoreCpuCount())
+		})
+	}
+}
+
 type PostgresContainer struct {
 	Host     string
 	Port     int
@@ -1386,3 +1486,29 @@ func extractPostgresVersion(versionStr string) tools.PostgresqlVersion {
 
 	return tools.GetPostgresqlVersionEnum("16")
 }
+
+func createTestCertificate(t *testing.T, host string) (tls.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "root.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, caFile
+}
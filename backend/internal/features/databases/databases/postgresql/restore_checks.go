@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"context"
+	"databasus-backend/internal/util/encryption"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// DatabaseEncoding is the encoding and locale of a database. pg_restore keeps the data as
+// it is, so text of another encoding fails to load and another collation changes the
+// order of indexes on text columns
+type DatabaseEncoding struct {
+	Encoding string `json:"encoding"`
+	Collate  string `json:"collate"`
+	Ctype    string `json:"ctype"`
+}
+
+// RestoreRequirements describe the source database for pre-restore checks of a target
+type RestoreRequirements struct {
+	Extensions []string         `json:"extensions"`
+	Encoding   DatabaseEncoding `json:"encoding"`
+}
+
+// RestoreTargetInfo is what the target server offers for RestoreRequirements
+type RestoreTargetInfo struct {
+	MissingExtensions []string         `json:"missingExtensions"`
+	Encoding          DatabaseEncoding `json:"encoding"`
+}
+
+// GetRestoreRequirements reads extensions and the encoding of the source database. It
+// reflects the database now, not at the time of the backup
+func (p *PostgresqlDatabase) GetRestoreRequirements(
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (*RestoreRequirements, error) {
+	if p.Database == nil || *p.Database == "" {
+		return nil, fmt.Errorf("source database name is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	conn, err := p.connect(ctx, encryptor, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := conn.Close(ctx); closeErr != nil {
+			logger.Error("Failed to close connection", "error", closeErr)
+		}
+	}()
+
+	// plpgsql is installed in every database, it is never missing
+	rows, err := conn.Query(
+		ctx,
+		"SELECT extname FROM pg_extension WHERE extname <> 'plpgsql' ORDER BY extname",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	extensions, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	encoding, err := getDatabaseEncoding(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreRequirements{Extensions: extensions, Encoding: *encoding}, nil
+}
+
+// InspectRestoreTarget finds extensions of the requirements the target server cannot
+// create and reads the encoding of the target database
+func (p *PostgresqlDatabase) InspectRestoreTarget(
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+	requirements *RestoreRequirements,
+) (*RestoreTargetInfo, error) {
+	if p.Database == nil || *p.Database == "" {
+		return nil, fmt.Errorf("target database name is required for pg_restore")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	conn, err := p.connect(ctx, encryptor, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := conn.Close(ctx); closeErr != nil {
+			logger.Error("Failed to close connection", "error", closeErr)
+		}
+	}()
+
+	rows, err := conn.Query(
+		ctx,
+		`SELECT required.name FROM unnest($1::text[]) AS required(name)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM pg_available_extensions available WHERE available.name = required.name
+		)
+		ORDER BY required.name`,
+		requirements.Extensions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check available extensions: %w", err)
+	}
+
+	missingExtensions, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to check available extensions: %w", err)
+	}
+
+	encoding, err := getDatabaseEncoding(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreTargetInfo{MissingExtensions: missingExtensions, Encoding: *encoding}, nil
+}
+
+func getDatabaseEncoding(ctx context.Context, conn *pgx.Conn) (*DatabaseEncoding, error) {
+	var encoding DatabaseEncoding
+	err := conn.QueryRow(
+		ctx,
+		`SELECT pg_encoding_to_char(encoding), datcollate, datctype
+		FROM pg_database WHERE datname = current_database()`,
+	).Scan(&encoding.Encoding, &encoding.Collate, &encoding.Ctype)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database encoding: %w", err)
+	}
+
+	return &encoding, nil
+}
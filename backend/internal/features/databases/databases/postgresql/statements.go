@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"context"
+	"databasus-backend/internal/util/encryption"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// ExecuteInTransaction runs the statements in the database in one transaction, nothing is
+// changed when one of them fails
+func (p *PostgresqlDatabase) ExecuteInTransaction(
+	ctx context.Context,
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+	statements []string,
+) error {
+	if p.Database == nil || *p.Database == "" {
+		return fmt.Errorf("database name is required")
+	}
+
+	conn, err := p.connect(ctx, encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := conn.Close(context.Background()); closeErr != nil {
+			logger.Error("Failed to close connection", "error", closeErr)
+		}
+	}()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("failed to execute %q: %w", statement, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
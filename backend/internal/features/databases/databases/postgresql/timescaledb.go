@@ -0,0 +1,239 @@
+package postgresql
+
+import (
+	"context"
+	"databasus-backend/internal/util/encryption"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// pg_restore restores every chunk of a hypertable as a separate table, parallel jobs
+	// pay off when there are enough chunks for each of them
+	timescaledbChunksPerRestoreJob = 25
+	timescaledbMaxRestoreJobs      = 8
+)
+
+var timescaledbVersionRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*(-[0-9A-Za-z.]+)?$`)
+
+// IsTimescaledb returns true when the timescaledb extension was installed in the database
+// at the time the version was detected
+func (p *PostgresqlDatabase) IsTimescaledb() bool {
+	return p.TimescaledbVersion != ""
+}
+
+// RecommendedRestoreCpuCount is a hint for the restore CPU count based on the chunks of
+// TimescaleDB hypertables. Plain PostgreSQL databases get 1
+func (p *PostgresqlDatabase) RecommendedRestoreCpuCount() int {
+	if !p.IsTimescaledb() {
+		return 1
+	}
+
+	return min(
+		timescaledbMaxRestoreJobs,
+		max(1, p.TimescaledbChunksCount/timescaledbChunksPerRestoreJob),
+	)
+}
+
+// CheckTimescaledbRestoreTarget verifies the target server can take a TimescaleDB backup:
+// the extension of the backup version must be available, preloaded and, when already
+// installed in the target database, of the same version
+func (p *PostgresqlDatabase) CheckTimescaledbRestoreTarget(
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+	version string,
+) error {
+	if p.Database == nil || *p.Database == "" {
+		return errors.New("target database name is required for pg_restore")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	conn, err := p.connect(ctx, encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := conn.Close(ctx); closeErr != nil {
+			logger.Error("Failed to close connection", "error", closeErr)
+		}
+	}()
+
+	var isAvailable bool
+	err = conn.QueryRow(
+		ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM pg_available_extension_versions
+			WHERE name = 'timescaledb' AND version = $1
+		)`,
+		version,
+	).Scan(&isAvailable)
+	if err != nil {
+		return fmt.Errorf("failed to check available timescaledb versions: %w", err)
+	}
+
+	if !isAvailable {
+		return fmt.Errorf(
+			"backup is made with TimescaleDB %s, the target server has no such extension version. "+
+				"Install exactly this version on the target server",
+			version,
+		)
+	}
+
+	var preloadLibraries string
+	if err := conn.QueryRow(ctx, "SHOW shared_preload_libraries").
+		Scan(&preloadLibraries); err != nil {
+		return fmt.Errorf("failed to read shared_preload_libraries: %w", err)
+	}
+
+	if !strings.Contains(preloadLibraries, "timescaledb") {
+		return errors.New(
+			"timescaledb is not in shared_preload_libraries of the target server",
+		)
+	}
+
+	installedVersion, err := getInstalledTimescaledbVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if installedVersion != "" && installedVersion != version {
+		return fmt.Errorf(
+			"target database has TimescaleDB %s installed, the backup is made with %s. "+
+				"Restore into a database without the extension or update it to %s",
+			installedVersion,
+			version,
+			version,
+		)
+	}
+
+	return nil
+}
+
+// PrepareTimescaledbRestore creates the extension of the backup version and switches the
+// target database into restoring mode, background workers stop and catalog triggers are off
+func (p *PostgresqlDatabase) PrepareTimescaledbRestore(
+	ctx context.Context,
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+	version string,
+) error {
+	if !timescaledbVersionRegex.MatchString(version) {
+		return fmt.Errorf("invalid timescaledb version: %s", version)
+	}
+
+	conn, err := p.connect(ctx, encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := conn.Close(ctx); closeErr != nil {
+			logger.Error("Failed to close connection", "error", closeErr)
+		}
+	}()
+
+	if _, err := conn.Exec(
+		ctx,
+		fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS timescaledb VERSION '%s'", version),
+	); err != nil {
+		return fmt.Errorf("failed to create timescaledb extension: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx, "SELECT timescaledb_pre_restore()"); err != nil {
+		return fmt.Errorf("failed to switch timescaledb into restoring mode: %w", err)
+	}
+
+	return nil
+}
+
+// FinishTimescaledbRestore switches the target database back from restoring mode. Call it
+// after pg_restore regardless of its result, otherwise background jobs stay stopped
+func (p *PostgresqlDatabase) FinishTimescaledbRestore(
+	ctx context.Context,
+	logger *slog.Logger,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	conn, err := p.connect(ctx, encryptor, databaseID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := conn.Close(ctx); closeErr != nil {
+			logger.Error("Failed to close connection", "error", closeErr)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "SELECT timescaledb_post_restore()"); err != nil {
+		return fmt.Errorf("failed to switch timescaledb out of restoring mode: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresqlDatabase) connect(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) (*pgx.Conn, error) {
+	password, err := decryptPasswordIfNeeded(p.Password, encryptor, databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt password: %w", err)
+	}
+
+	conn, err := connect(ctx, p, buildConnectionStringForDB(p, *p.Database, password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return conn, nil
+}
+
+// detectTimescaledb returns the installed extension version and the count of chunks of
+// all hypertables, the version is empty for plain PostgreSQL
+func detectTimescaledb(
+	ctx context.Context,
+	logger *slog.Logger,
+	conn *pgx.Conn,
+) (string, int, error) {
+	version, err := getInstalledTimescaledbVersion(ctx, conn)
+	if err != nil || version == "" {
+		return "", 0, err
+	}
+
+	var chunksCount int
+	if err := conn.QueryRow(ctx, "SELECT count(*) FROM timescaledb_information.chunks").
+		Scan(&chunksCount); err != nil {
+		// the view needs no special privileges, but it is only a hint anyway
+		logger.Warn("Failed to count timescaledb chunks", "error", err)
+		return version, 0, nil
+	}
+
+	return version, chunksCount, nil
+}
+
+func getInstalledTimescaledbVersion(ctx context.Context, conn *pgx.Conn) (string, error) {
+	var version string
+	err := conn.QueryRow(
+		ctx,
+		"SELECT extversion FROM pg_extension WHERE extname = 'timescaledb'",
+	).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query timescaledb extension: %w", err)
+	}
+
+	return version, nil
+}
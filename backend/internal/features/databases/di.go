@@ -24,6 +24,8 @@ var databaseService = &DatabaseService{
 	workspaces_services.GetWorkspaceService(),
 	audit_logs.GetAuditLogService(),
 	encryption.GetFieldEncryptor(),
+	nil,
+	nil,
 }
 
 var databaseController = &DatabaseController{
@@ -0,0 +1,67 @@
+package databases
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// dnsOverrideDatabaseTypes connect to a single host, which the DNS override resolves
+var dnsOverrideDatabaseTypes = []DatabaseType{
+	DatabaseTypePostgres,
+	DatabaseTypeMysql,
+	DatabaseTypeMariadb,
+	DatabaseTypeMongodb,
+}
+
+func (d *Database) validateDNSOverride() error {
+	if !slices.Contains(dnsOverrideDatabaseTypes, d.Type) {
+		return errors.New(
+			"DNS overrides are supported only for PostgreSQL, MySQL, MariaDB and MongoDB",
+		)
+	}
+
+	if d.AgentID != nil {
+		return errors.New("DNS overrides are not supported for databases behind an agent")
+	}
+
+	if d.Mongodb != nil && d.Mongodb.IsSrv {
+		return errors.New("DNS overrides are not supported for MongoDB SRV connections")
+	}
+
+	return d.DNSOverride.Validate()
+}
+
+// ApplyDNSOverride sets the address the host of the connection resolves to with the DNS
+// override, as database tools do their own lookups. The host itself is kept, so TLS checks the
+// certificate against it. The returned function clears the address again
+func (d *Database) ApplyDNSOverride(ctx context.Context) (func(), error) {
+	host, hostAddress := d.getHostFields()
+	if d.DNSOverride == nil || hostAddress == nil {
+		return func() {}, nil
+	}
+
+	resolvedHost, err := d.DNSOverride.ResolveHost(ctx, host)
+	if err != nil {
+		return func() {}, err
+	}
+
+	*hostAddress = resolvedHost
+
+	return func() { *hostAddress = "" }, nil
+}
+
+func (d *Database) getHostFields() (string, *string) {
+	switch {
+	case d.Postgresql != nil:
+		return d.Postgresql.Host, &d.Postgresql.HostAddress
+	case d.Mysql != nil:
+		return d.Mysql.Host, &d.Mysql.HostAddress
+	case d.Mariadb != nil:
+		return d.Mariadb.Host, &d.Mariadb.HostAddress
+	case d.Mongodb != nil && !d.Mongodb.IsSrv:
+		return d.Mongodb.Host, &d.Mongodb.HostAddress
+	default:
+		return "", nil
+	}
+}
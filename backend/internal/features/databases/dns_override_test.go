@@ -0,0 +1,57 @@
+package databases
+
+import (
+	"context"
+	"testing"
+
+	"databasus-backend/internal/features/databases/databases/mongodb"
+	"databasus-backend/internal/features/databases/databases/postgresql"
+	network_utils "databasus-backend/internal/util/network"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApplyDNSOverride_WhenHostOverridden_HostKeptAndAddressSetUntilRestored(t *testing.T) {
+	database := &Database{
+		ID:   uuid.New(),
+		Name: "Test Database",
+		Type: DatabaseTypePostgres,
+		Postgresql: &postgresql.PostgresqlDatabase{
+			Host: "db.internal.example.com",
+		},
+		DNSOverride: &network_utils.DNSOverride{
+			Hosts: map[string]string{"DB.internal.example.com": "10.20.0.5"},
+		},
+	}
+
+	restoreHost, err := database.ApplyDNSOverride(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal.example.com", database.Postgresql.Host)
+	assert.Equal(t, "10.20.0.5", database.Postgresql.HostAddress)
+
+	restoreHost()
+	assert.Equal(t, "db.internal.example.com", database.Postgresql.Host)
+	assert.Empty(t, database.Postgresql.HostAddress)
+}
+
+func Test_ValidateDNSOverride_WhenConnectionUnsupported_Rejected(t *testing.T) {
+	dnsOverride := &network_utils.DNSOverride{Server: "10.0.0.53"}
+
+	database := &Database{
+		Type:        DatabaseTypeMongodb,
+		Mongodb:     &mongodb.MongodbDatabase{Host: "cluster.example.com", IsSrv: true},
+		DNSOverride: dnsOverride,
+	}
+	assert.Error(t, database.validateDNSOverride())
+
+	database.Mongodb.IsSrv = false
+	assert.NoError(t, database.validateDNSOverride())
+
+	database.DNSOverride = &network_utils.DNSOverride{Server: "dns.example.com"}
+	assert.Error(t, database.validateDNSOverride())
+
+	database.Type = DatabaseTypeCassandra
+	database.DNSOverride = dnsOverride
+	assert.Error(t, database.validateDNSOverride())
+}
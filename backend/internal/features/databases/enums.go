@@ -3,10 +3,16 @@ package databases
 type DatabaseType string
 
 const (
-	DatabaseTypePostgres DatabaseType = "POSTGRES"
-	DatabaseTypeMysql    DatabaseType = "MYSQL"
-	DatabaseTypeMariadb  DatabaseType = "MARIADB"
-	DatabaseTypeMongodb  DatabaseType = "MONGODB"
+	DatabaseTypePostgres      DatabaseType = "POSTGRES"
+	DatabaseTypeMysql         DatabaseType = "MYSQL"
+	DatabaseTypeMariadb       DatabaseType = "MARIADB"
+	DatabaseTypeMongodb       DatabaseType = "MONGODB"
+	DatabaseTypeFilesystem    DatabaseType = "FILESYSTEM"
+	DatabaseTypeElasticsearch DatabaseType = "ELASTICSEARCH"
+	DatabaseTypeInfluxdb      DatabaseType = "INFLUXDB"
+	DatabaseTypeCassandra     DatabaseType = "CASSANDRA"
+	DatabaseTypeCockroachdb   DatabaseType = "COCKROACHDB"
+	DatabaseTypeNeo4j         DatabaseType = "NEO4J"
 )
 
 type HealthStatus string
@@ -0,0 +1,183 @@
+package databases
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"databasus-backend/internal/features/environments"
+	users_models "databasus-backend/internal/features/users/models"
+)
+
+const defaultMongodbPort = 27017
+
+var ErrInsufficientPermissionsToChangeEnvironment = errors.New(
+	"only workspace owners and admins can move databases in or out of PROD",
+)
+
+// GetEnvironment returns the label of the database or of its workspace
+func (s *DatabaseService) GetEnvironment(database *Database) (environments.Environment, error) {
+	if database.Environment != environments.EnvironmentNone || database.WorkspaceID == nil {
+		return database.Environment, nil
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(*database.WorkspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	return environments.Resolve(database.Environment, workspace.Environment), nil
+}
+
+// FindProdDatabaseByAddress returns a PROD database on the server of the address, nil
+// when none is registered. Restore targets are given as connection settings, so they are
+// matched to databases by the server
+func (s *DatabaseService) FindProdDatabaseByAddress(
+	databaseType DatabaseType,
+	address string,
+) (*Database, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	allDatabases, err := s.dbRepository.GetAllDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, database := range allDatabases {
+		if database.Type != databaseType || database.GetServerAddress() != address {
+			continue
+		}
+
+		environment, err := s.GetEnvironment(database)
+		if err != nil {
+			return nil, err
+		}
+
+		if environment == environments.EnvironmentProd {
+			return database, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetServerAddress identifies the server of the database, e.g. "db.internal:5432". Hosts
+// are compared as written, aliases of the same server are not resolved
+func (d *Database) GetServerAddress() string {
+	switch d.Type {
+	case DatabaseTypePostgres:
+		if d.Postgresql != nil {
+			return formatServerAddress(d.Postgresql.Host, d.Postgresql.Port)
+		}
+	case DatabaseTypeMysql:
+		if d.Mysql != nil {
+			return formatServerAddress(d.Mysql.Host, d.Mysql.Port)
+		}
+	case DatabaseTypeMariadb:
+		if d.Mariadb != nil {
+			return formatServerAddress(d.Mariadb.Host, d.Mariadb.Port)
+		}
+	case DatabaseTypeMongodb:
+		if d.Mongodb != nil {
+			port := defaultMongodbPort
+			if d.Mongodb.Port != nil {
+				port = *d.Mongodb.Port
+			}
+
+			return formatServerAddress(d.Mongodb.Host, port)
+		}
+	case DatabaseTypeCockroachdb:
+		if d.Cockroachdb != nil {
+			return formatServerAddress(d.Cockroachdb.Host, d.Cockroachdb.Port)
+		}
+	case DatabaseTypeElasticsearch:
+		if d.Elasticsearch != nil {
+			return getURLHost(d.Elasticsearch.URL)
+		}
+	case DatabaseTypeInfluxdb:
+		if d.Influxdb != nil {
+			return getURLHost(d.Influxdb.URL)
+		}
+	case DatabaseTypeNeo4j:
+		if d.Neo4j != nil {
+			return getURLHost(d.Neo4j.URL)
+		}
+	case DatabaseTypeCassandra:
+		if d.Cassandra != nil {
+			nodes := strings.Split(strings.ToLower(d.Cassandra.Nodes), ",")
+			for i := range nodes {
+				nodes[i] = strings.TrimSpace(nodes[i])
+			}
+			slices.Sort(nodes)
+
+			return strings.Join(nodes, ",")
+		}
+	case DatabaseTypeFilesystem:
+		if d.Filesystem != nil && d.Filesystem.Path != "" {
+			return filepath.Clean(d.Filesystem.Path)
+		}
+	}
+
+	return ""
+}
+
+// checkCanChangeEnvironment keeps PROD labels trustworthy, members could otherwise lift
+// the label and restore into the database. Labels are compared as resolved with the
+// workspace, so clearing or overriding an inherited PROD counts as well
+func (s *DatabaseService) checkCanChangeEnvironment(
+	user *users_models.User,
+	existingDatabase *Database,
+	database *Database,
+) error {
+	if existingDatabase.Environment == database.Environment {
+		return nil
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(*existingDatabase.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	existingEnvironment := environments.Resolve(
+		existingDatabase.Environment,
+		workspace.Environment,
+	)
+	newEnvironment := environments.Resolve(database.Environment, workspace.Environment)
+
+	if existingEnvironment != environments.EnvironmentProd &&
+		newEnvironment != environments.EnvironmentProd {
+		return nil
+	}
+
+	canManageWorkspace, err := s.workspaceService.CanUserManageWorkspace(workspace.ID, user)
+	if err != nil {
+		return err
+	}
+	if !canManageWorkspace {
+		return ErrInsufficientPermissionsToChangeEnvironment
+	}
+
+	return nil
+}
+
+func formatServerAddress(host string, port int) string {
+	if host == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", strings.ToLower(host), port)
+}
+
+func getURLHost(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(parsedURL.Host)
+}
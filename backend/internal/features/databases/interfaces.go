@@ -32,3 +32,11 @@ type DatabaseRemoveListener interface {
 type DatabaseCopyListener interface {
 	OnDatabaseCopied(originalDatabaseID, newDatabaseID uuid.UUID)
 }
+
+type WorkspaceQuotaChecker interface {
+	CheckCanAddDatabase(workspaceID uuid.UUID, databasesCount int) error
+}
+
+type DatabaseAgentChecker interface {
+	CheckCanUseAgent(workspaceID uuid.UUID, agentID uuid.UUID) error
+}
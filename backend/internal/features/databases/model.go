@@ -2,12 +2,21 @@ package databases
 
 import (
 	"context"
+	"databasus-backend/internal/features/databases/databases/cassandra"
+	"databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/influxdb"
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/mysql"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	"databasus-backend/internal/features/databases/databases/postgresql"
+	"databasus-backend/internal/features/environments"
 	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/ownership"
 	"databasus-backend/internal/util/encryption"
+	network_utils "databasus-backend/internal/util/network"
 	"errors"
 	"log/slog"
 	"time"
@@ -24,12 +33,45 @@ type Database struct {
 	Name        string       `json:"name"        gorm:"column:name;type:text;not null"`
 	Type        DatabaseType `json:"type"        gorm:"column:type;type:text;not null"`
 
-	Postgresql *postgresql.PostgresqlDatabase `json:"postgresql,omitempty" gorm:"foreignKey:DatabaseID"`
-	Mysql      *mysql.MysqlDatabase           `json:"mysql,omitempty"      gorm:"foreignKey:DatabaseID"`
-	Mariadb    *mariadb.MariadbDatabase       `json:"mariadb,omitempty"    gorm:"foreignKey:DatabaseID"`
-	Mongodb    *mongodb.MongodbDatabase       `json:"mongodb,omitempty"    gorm:"foreignKey:DatabaseID"`
+	// Environment is empty when the database takes the environment of its workspace
+	Environment environments.Environment `json:"environment" gorm:"column:environment;type:text;not null;default:''"`
+
+	ownership.Ownership
+
+	Postgresql    *postgresql.PostgresqlDatabase       `json:"postgresql,omitempty"    gorm:"foreignKey:DatabaseID"`
+	Mysql         *mysql.MysqlDatabase                 `json:"mysql,omitempty"         gorm:"foreignKey:DatabaseID"`
+	Mariadb       *mariadb.MariadbDatabase             `json:"mariadb,omitempty"       gorm:"foreignKey:DatabaseID"`
+	Mongodb       *mongodb.MongodbDatabase             `json:"mongodb,omitempty"       gorm:"foreignKey:DatabaseID"`
+	Filesystem    *filesystem.FilesystemDatabase       `json:"filesystem,omitempty"    gorm:"foreignKey:DatabaseID"`
+	Elasticsearch *elasticsearch.ElasticsearchDatabase `json:"elasticsearch,omitempty" gorm:"foreignKey:DatabaseID"`
+	Influxdb      *influxdb.InfluxdbDatabase           `json:"influxdb,omitempty"      gorm:"foreignKey:DatabaseID"`
+	Cassandra     *cassandra.CassandraDatabase         `json:"cassandra,omitempty"     gorm:"foreignKey:DatabaseID"`
+	Cockroachdb   *cockroachdb.CockroachdbDatabase     `json:"cockroachdb,omitempty"   gorm:"foreignKey:DatabaseID"`
+	Neo4j         *neo4j.Neo4jDatabase                 `json:"neo4j,omitempty"         gorm:"foreignKey:DatabaseID"`
 
 	Notifiers []notifiers.Notifier `json:"notifiers" gorm:"many2many:database_notifiers;"`
+	// IsDefaultNotifiersOptOut keeps default notifiers of the workspace from being attached
+	IsDefaultNotifiersOptOut bool `json:"isDefaultNotifiersOptOut" gorm:"column:is_default_notifiers_opt_out;type:boolean;not null;default:false"`
+
+	// AgentID is set for databases reachable only from a private network. Backups are
+	// dumped by the agent there instead of by backup nodes
+	AgentID *uuid.UUID `json:"agentId,omitempty" gorm:"column:agent_id;type:uuid"`
+
+	// CredentialsExpireAt is when the password of the connection has to be rotated,
+	// notifiers of the database are reminded before it
+	CredentialsExpireAt *time.Time `json:"credentialsExpireAt" gorm:"column:credentials_expire_at;type:timestamptz"`
+
+	// CredentialSource is set when credentials are fetched before connecting, the password
+	// of the connection is not stored then
+	CredentialSource *CredentialSource `json:"credentialSource,omitempty" gorm:"column:credential_source;type:text;serializer:json"`
+
+	// DNSOverride resolves the host of the connection with overrides or another DNS server,
+	// for nodes resolving names unlike app servers
+	DNSOverride *network_utils.DNSOverride `json:"dnsOverride,omitempty" gorm:"column:dns_override;type:text;serializer:json"`
+
+	// ChangeReason is sent with edits and written to the audit log, it is required in
+	// workspaces with IsChangeReasonRequired. Not stored on the database
+	ChangeReason string `json:"changeReason,omitempty" gorm:"-"`
 
 	// these fields are not reliable, but
 	// they are used for pretty UI
@@ -44,6 +86,35 @@ func (d *Database) Validate() error {
 		return errors.New("name is required")
 	}
 
+	if err := d.Environment.Validate(); err != nil {
+		return err
+	}
+
+	if d.AgentID != nil && d.Type != DatabaseTypePostgres {
+		return errors.New("agents support only PostgreSQL databases")
+	}
+
+	if d.CredentialSource != nil {
+		if d.AgentID != nil {
+			return errors.New("credential sources are not supported for databases behind an agent")
+		}
+
+		if err := d.CredentialSource.Validate(d.Type); err != nil {
+			return err
+		}
+
+		if d.getPassword() == "" {
+			d.setCredentials("", credentialSourcePlaceholder)
+			defer d.setCredentials("", "")
+		}
+	}
+
+	if d.DNSOverride != nil {
+		if err := d.validateDNSOverride(); err != nil {
+			return err
+		}
+	}
+
 	switch d.Type {
 	case DatabaseTypePostgres:
 		if d.Postgresql == nil {
@@ -65,6 +136,36 @@ func (d *Database) Validate() error {
 			return errors.New("mongodb database is required")
 		}
 		return d.Mongodb.Validate()
+	case DatabaseTypeFilesystem:
+		if d.Filesystem == nil {
+			return errors.New("filesystem source is required")
+		}
+		return d.Filesystem.Validate()
+	case DatabaseTypeElasticsearch:
+		if d.Elasticsearch == nil {
+			return errors.New("elasticsearch cluster is required")
+		}
+		return d.Elasticsearch.Validate()
+	case DatabaseTypeInfluxdb:
+		if d.Influxdb == nil {
+			return errors.New("influxdb server is required")
+		}
+		return d.Influxdb.Validate()
+	case DatabaseTypeCassandra:
+		if d.Cassandra == nil {
+			return errors.New("cassandra cluster is required")
+		}
+		return d.Cassandra.Validate()
+	case DatabaseTypeCockroachdb:
+		if d.Cockroachdb == nil {
+			return errors.New("cockroachdb database is required")
+		}
+		return d.Cockroachdb.Validate()
+	case DatabaseTypeNeo4j:
+		if d.Neo4j == nil {
+			return errors.New("neo4j database is required")
+		}
+		return d.Neo4j.Validate()
 	default:
 		return errors.New("invalid database type: " + string(d.Type))
 	}
@@ -82,6 +183,16 @@ func (d *Database) TestConnection(
 	logger *slog.Logger,
 	encryptor encryption.FieldEncryptor,
 ) error {
+	if err := d.ApplyCredentialSource(encryptor); err != nil {
+		return err
+	}
+
+	restoreHost, err := d.ApplyDNSOverride(context.Background())
+	if err != nil {
+		return err
+	}
+	defer restoreHost()
+
 	return d.getSpecificDatabase().TestConnection(logger, encryptor, d.ID)
 }
 
@@ -90,6 +201,16 @@ func (d *Database) IsUserReadOnly(
 	logger *slog.Logger,
 	encryptor encryption.FieldEncryptor,
 ) (bool, []string, error) {
+	if err := d.ApplyCredentialSource(encryptor); err != nil {
+		return false, nil, err
+	}
+
+	restoreHost, err := d.ApplyDNSOverride(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	defer restoreHost()
+
 	switch d.Type {
 	case DatabaseTypePostgres:
 		return d.Postgresql.IsUserReadOnly(ctx, logger, encryptor, d.ID)
@@ -105,10 +226,20 @@ func (d *Database) IsUserReadOnly(
 }
 
 func (d *Database) HideSensitiveData() {
+	if d.CredentialSource != nil {
+		d.CredentialSource.HideSensitiveData()
+	}
+
 	d.getSpecificDatabase().HideSensitiveData()
 }
 
 func (d *Database) EncryptSensitiveFields(encryptor encryption.FieldEncryptor) error {
+	if d.CredentialSource != nil {
+		if err := d.CredentialSource.EncryptSensitiveFields(d.ID, encryptor); err != nil {
+			return err
+		}
+	}
+
 	if d.Postgresql != nil {
 		return d.Postgresql.EncryptSensitiveFields(d.ID, encryptor)
 	}
@@ -121,6 +252,21 @@ func (d *Database) EncryptSensitiveFields(encryptor encryption.FieldEncryptor) e
 	if d.Mongodb != nil {
 		return d.Mongodb.EncryptSensitiveFields(d.ID, encryptor)
 	}
+	if d.Elasticsearch != nil {
+		return d.Elasticsearch.EncryptSensitiveFields(d.ID, encryptor)
+	}
+	if d.Influxdb != nil {
+		return d.Influxdb.EncryptSensitiveFields(d.ID, encryptor)
+	}
+	if d.Cassandra != nil {
+		return d.Cassandra.EncryptSensitiveFields(d.ID, encryptor)
+	}
+	if d.Cockroachdb != nil {
+		return d.Cockroachdb.EncryptSensitiveFields(d.ID, encryptor)
+	}
+	if d.Neo4j != nil {
+		return d.Neo4j.EncryptSensitiveFields(d.ID, encryptor)
+	}
 	return nil
 }
 
@@ -128,6 +274,16 @@ func (d *Database) PopulateDbData(
 	logger *slog.Logger,
 	encryptor encryption.FieldEncryptor,
 ) error {
+	if err := d.ApplyCredentialSource(encryptor); err != nil {
+		return err
+	}
+
+	restoreHost, err := d.ApplyDNSOverride(context.Background())
+	if err != nil {
+		return err
+	}
+	defer restoreHost()
+
 	if d.Postgresql != nil {
 		return d.Postgresql.PopulateDbData(logger, encryptor, d.ID)
 	}
@@ -140,13 +296,50 @@ func (d *Database) PopulateDbData(
 	if d.Mongodb != nil {
 		return d.Mongodb.PopulateDbData(logger, encryptor, d.ID)
 	}
+	if d.Elasticsearch != nil {
+		return d.Elasticsearch.PopulateDbData(logger, encryptor, d.ID)
+	}
+	if d.Influxdb != nil {
+		return d.Influxdb.PopulateDbData(logger, encryptor, d.ID)
+	}
+	if d.Cassandra != nil {
+		return d.Cassandra.PopulateDbData(logger, encryptor, d.ID)
+	}
+	if d.Cockroachdb != nil {
+		return d.Cockroachdb.PopulateDbData(logger, encryptor, d.ID)
+	}
+	if d.Neo4j != nil {
+		return d.Neo4j.PopulateDbData(logger, encryptor, d.ID)
+	}
 	return nil
 }
 
+func (d *Database) HasNotifier(notifierID uuid.UUID) bool {
+	for _, notifier := range d.Notifiers {
+		if notifier.ID == notifierID {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (d *Database) Update(incoming *Database) {
 	d.Name = incoming.Name
 	d.Type = incoming.Type
+	d.Environment = incoming.Environment
+	d.Ownership = incoming.Ownership
 	d.Notifiers = incoming.Notifiers
+	d.IsDefaultNotifiersOptOut = incoming.IsDefaultNotifiersOptOut
+	d.AgentID = incoming.AgentID
+	d.CredentialsExpireAt = incoming.CredentialsExpireAt
+	d.DNSOverride = incoming.DNSOverride
+
+	if d.CredentialSource != nil && incoming.CredentialSource != nil {
+		d.CredentialSource.Update(incoming.CredentialSource)
+	} else {
+		d.CredentialSource = incoming.CredentialSource
+	}
 
 	switch d.Type {
 	case DatabaseTypePostgres:
@@ -165,9 +358,45 @@ func (d *Database) Update(incoming *Database) {
 		if d.Mongodb != nil && incoming.Mongodb != nil {
 			d.Mongodb.Update(incoming.Mongodb)
 		}
+	case DatabaseTypeFilesystem:
+		if d.Filesystem != nil && incoming.Filesystem != nil {
+			d.Filesystem.Update(incoming.Filesystem)
+		}
+	case DatabaseTypeElasticsearch:
+		if d.Elasticsearch != nil && incoming.Elasticsearch != nil {
+			d.Elasticsearch.Update(incoming.Elasticsearch)
+		}
+	case DatabaseTypeInfluxdb:
+		if d.Influxdb != nil && incoming.Influxdb != nil {
+			d.Influxdb.Update(incoming.Influxdb)
+		}
+	case DatabaseTypeCassandra:
+		if d.Cassandra != nil && incoming.Cassandra != nil {
+			d.Cassandra.Update(incoming.Cassandra)
+		}
+	case DatabaseTypeCockroachdb:
+		if d.Cockroachdb != nil && incoming.Cockroachdb != nil {
+			d.Cockroachdb.Update(incoming.Cockroachdb)
+		}
+	case DatabaseTypeNeo4j:
+		if d.Neo4j != nil && incoming.Neo4j != nil {
+			d.Neo4j.Update(incoming.Neo4j)
+		}
 	}
 }
 
+// clearSourcedCredentials keeps passwords fetched from the credential source from being
+// saved
+func (d *Database) clearSourcedCredentials() {
+	if d.CredentialSource != nil {
+		d.setCredentials("", "")
+	}
+}
+
+func (d *Database) IsBehindAgent() bool {
+	return d.AgentID != nil
+}
+
 func (d *Database) getSpecificDatabase() DatabaseConnector {
 	switch d.Type {
 	case DatabaseTypePostgres:
@@ -178,6 +407,18 @@ func (d *Database) getSpecificDatabase() DatabaseConnector {
 		return d.Mariadb
 	case DatabaseTypeMongodb:
 		return d.Mongodb
+	case DatabaseTypeFilesystem:
+		return d.Filesystem
+	case DatabaseTypeElasticsearch:
+		return d.Elasticsearch
+	case DatabaseTypeInfluxdb:
+		return d.Influxdb
+	case DatabaseTypeCassandra:
+		return d.Cassandra
+	case DatabaseTypeCockroachdb:
+		return d.Cockroachdb
+	case DatabaseTypeNeo4j:
+		return d.Neo4j
 	}
 
 	panic("invalid database type: " + string(d.Type))
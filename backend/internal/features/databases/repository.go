@@ -1,12 +1,19 @@
 package databases
 
 import (
+	"databasus-backend/internal/features/databases/databases/cassandra"
+	"databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/influxdb"
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/mysql"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	"databasus-backend/internal/features/databases/databases/postgresql"
 	"databasus-backend/internal/storage"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -44,17 +51,73 @@ func (r *DatabaseRepository) Save(database *Database) (*Database, error) {
 				return errors.New("mongodb configuration is required for MongoDB database")
 			}
 			database.Mongodb.DatabaseID = &database.ID
+		case DatabaseTypeFilesystem:
+			if database.Filesystem == nil {
+				return errors.New("filesystem configuration is required for filesystem source")
+			}
+			database.Filesystem.DatabaseID = &database.ID
+		case DatabaseTypeElasticsearch:
+			if database.Elasticsearch == nil {
+				return errors.New("elasticsearch configuration is required for Elasticsearch database")
+			}
+			database.Elasticsearch.DatabaseID = &database.ID
+		case DatabaseTypeInfluxdb:
+			if database.Influxdb == nil {
+				return errors.New("influxdb configuration is required for InfluxDB database")
+			}
+			database.Influxdb.DatabaseID = &database.ID
+		case DatabaseTypeCassandra:
+			if database.Cassandra == nil {
+				return errors.New("cassandra configuration is required for Cassandra database")
+			}
+			database.Cassandra.DatabaseID = &database.ID
+		case DatabaseTypeCockroachdb:
+			if database.Cockroachdb == nil {
+				return errors.New("cockroachdb configuration is required for CockroachDB database")
+			}
+			database.Cockroachdb.DatabaseID = &database.ID
+		case DatabaseTypeNeo4j:
+			if database.Neo4j == nil {
+				return errors.New("neo4j configuration is required for Neo4j database")
+			}
+			database.Neo4j.DatabaseID = &database.ID
 		}
 
+		database.clearSourcedCredentials()
+
 		if isNew {
 			if err := tx.Create(database).
-				Omit("Postgresql", "Mysql", "Mariadb", "Mongodb", "Notifiers").
+				Omit(
+					"Postgresql",
+					"Mysql",
+					"Mariadb",
+					"Mongodb",
+					"Filesystem",
+					"Elasticsearch",
+					"Influxdb",
+					"Cassandra",
+					"Cockroachdb",
+					"Neo4j",
+					"Notifiers",
+				).
 				Error; err != nil {
 				return err
 			}
 		} else {
 			if err := tx.Save(database).
-				Omit("Postgresql", "Mysql", "Mariadb", "Mongodb", "Notifiers").
+				Omit(
+					"Postgresql",
+					"Mysql",
+					"Mariadb",
+					"Mongodb",
+					"Filesystem",
+					"Elasticsearch",
+					"Influxdb",
+					"Cassandra",
+					"Cockroachdb",
+					"Neo4j",
+					"Notifiers",
+				).
 				Error; err != nil {
 				return err
 			}
@@ -109,6 +172,78 @@ func (r *DatabaseRepository) Save(database *Database) (*Database, error) {
 					return err
 				}
 			}
+		case DatabaseTypeFilesystem:
+			database.Filesystem.DatabaseID = &database.ID
+			if database.Filesystem.ID == uuid.Nil {
+				database.Filesystem.ID = uuid.New()
+				if err := tx.Create(database.Filesystem).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(database.Filesystem).Error; err != nil {
+					return err
+				}
+			}
+		case DatabaseTypeElasticsearch:
+			database.Elasticsearch.DatabaseID = &database.ID
+			if database.Elasticsearch.ID == uuid.Nil {
+				database.Elasticsearch.ID = uuid.New()
+				if err := tx.Create(database.Elasticsearch).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(database.Elasticsearch).Error; err != nil {
+					return err
+				}
+			}
+		case DatabaseTypeInfluxdb:
+			database.Influxdb.DatabaseID = &database.ID
+			if database.Influxdb.ID == uuid.Nil {
+				database.Influxdb.ID = uuid.New()
+				if err := tx.Create(database.Influxdb).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(database.Influxdb).Error; err != nil {
+					return err
+				}
+			}
+		case DatabaseTypeCassandra:
+			database.Cassandra.DatabaseID = &database.ID
+			if database.Cassandra.ID == uuid.Nil {
+				database.Cassandra.ID = uuid.New()
+				if err := tx.Create(database.Cassandra).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(database.Cassandra).Error; err != nil {
+					return err
+				}
+			}
+		case DatabaseTypeCockroachdb:
+			database.Cockroachdb.DatabaseID = &database.ID
+			if database.Cockroachdb.ID == uuid.Nil {
+				database.Cockroachdb.ID = uuid.New()
+				if err := tx.Create(database.Cockroachdb).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(database.Cockroachdb).Error; err != nil {
+					return err
+				}
+			}
+		case DatabaseTypeNeo4j:
+			database.Neo4j.DatabaseID = &database.ID
+			if database.Neo4j.ID == uuid.Nil {
+				database.Neo4j.ID = uuid.New()
+				if err := tx.Create(database.Neo4j).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(database.Neo4j).Error; err != nil {
+					return err
+				}
+			}
 		}
 
 		if err := tx.
@@ -137,6 +272,12 @@ func (r *DatabaseRepository) FindByID(id uuid.UUID) (*Database, error) {
 		Preload("Mysql").
 		Preload("Mariadb").
 		Preload("Mongodb").
+		Preload("Filesystem").
+		Preload("Elasticsearch").
+		Preload("Influxdb").
+		Preload("Cassandra").
+		Preload("Cockroachdb").
+		Preload("Neo4j").
 		Preload("Notifiers").
 		Where("id = ?", id).
 		First(&database).Error; err != nil {
@@ -155,6 +296,12 @@ func (r *DatabaseRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Databa
 		Preload("Mysql").
 		Preload("Mariadb").
 		Preload("Mongodb").
+		Preload("Filesystem").
+		Preload("Elasticsearch").
+		Preload("Influxdb").
+		Preload("Cassandra").
+		Preload("Cockroachdb").
+		Preload("Neo4j").
 		Preload("Notifiers").
 		Where("workspace_id = ?", workspaceID).
 		Order("CASE WHEN health_status = 'UNAVAILABLE' THEN 1 WHEN health_status = 'AVAILABLE' THEN 2 WHEN health_status IS NULL THEN 3 ELSE 4 END, name ASC").
@@ -203,6 +350,42 @@ func (r *DatabaseRepository) Delete(id uuid.UUID) error {
 				Delete(&mongodb.MongodbDatabase{}).Error; err != nil {
 				return err
 			}
+		case DatabaseTypeFilesystem:
+			if err := tx.
+				Where("database_id = ?", id).
+				Delete(&filesystem.FilesystemDatabase{}).Error; err != nil {
+				return err
+			}
+		case DatabaseTypeElasticsearch:
+			if err := tx.
+				Where("database_id = ?", id).
+				Delete(&elasticsearch.ElasticsearchDatabase{}).Error; err != nil {
+				return err
+			}
+		case DatabaseTypeInfluxdb:
+			if err := tx.
+				Where("database_id = ?", id).
+				Delete(&influxdb.InfluxdbDatabase{}).Error; err != nil {
+				return err
+			}
+		case DatabaseTypeCassandra:
+			if err := tx.
+				Where("database_id = ?", id).
+				Delete(&cassandra.CassandraDatabase{}).Error; err != nil {
+				return err
+			}
+		case DatabaseTypeCockroachdb:
+			if err := tx.
+				Where("database_id = ?", id).
+				Delete(&cockroachdb.CockroachdbDatabase{}).Error; err != nil {
+				return err
+			}
+		case DatabaseTypeNeo4j:
+			if err := tx.
+				Where("database_id = ?", id).
+				Delete(&neo4j.Neo4jDatabase{}).Error; err != nil {
+				return err
+			}
 		}
 
 		if err := tx.Delete(&Database{}, id).Error; err != nil {
@@ -236,7 +419,32 @@ func (r *DatabaseRepository) GetAllDatabases() ([]*Database, error) {
 		Preload("Mysql").
 		Preload("Mariadb").
 		Preload("Mongodb").
+		Preload("Filesystem").
+		Preload("Elasticsearch").
+		Preload("Influxdb").
+		Preload("Cassandra").
+		Preload("Cockroachdb").
+		Preload("Neo4j").
+		Preload("Notifiers").
+		Find(&databases).Error; err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+// FindWithCredentialsExpiringBefore returns databases whose credentials expire before the
+// time, including already expired ones
+func (r *DatabaseRepository) FindWithCredentialsExpiringBefore(
+	expireBefore time.Time,
+) ([]*Database, error) {
+	var databases []*Database
+
+	if err := storage.
+		GetDb().
 		Preload("Notifiers").
+		Where("credentials_expire_at IS NOT NULL AND credentials_expire_at < ?", expireBefore).
+		Order("credentials_expire_at ASC").
 		Find(&databases).Error; err != nil {
 		return nil, err
 	}
@@ -9,9 +9,15 @@ import (
 
 	"databasus-backend/internal/config"
 	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases/databases/cassandra"
+	"databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/influxdb"
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/mysql"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	"databasus-backend/internal/features/databases/databases/postgresql"
 	"databasus-backend/internal/features/notifiers"
 	users_models "databasus-backend/internal/features/users/models"
@@ -33,6 +39,9 @@ type DatabaseService struct {
 	workspaceService *workspaces_services.WorkspaceService
 	auditLogService  *audit_logs.AuditLogService
 	fieldEncryptor   encryption.FieldEncryptor
+
+	workspaceQuotaChecker WorkspaceQuotaChecker
+	agentChecker          DatabaseAgentChecker
 }
 
 func (s *DatabaseService) AddDbCreationListener(
@@ -53,6 +62,14 @@ func (s *DatabaseService) AddDbCopyListener(
 	s.dbCopyListener = append(s.dbCopyListener, dbCopyListener)
 }
 
+func (s *DatabaseService) SetAgentChecker(checker DatabaseAgentChecker) {
+	s.agentChecker = checker
+}
+
+func (s *DatabaseService) SetWorkspaceQuotaChecker(checker WorkspaceQuotaChecker) {
+	s.workspaceQuotaChecker = checker
+}
+
 func (s *DatabaseService) GetNotifierAttachedDatabasesIDs(
 	notifierID uuid.UUID,
 ) ([]uuid.UUID, error) {
@@ -77,14 +94,38 @@ func (s *DatabaseService) CreateDatabase(
 		return nil, errors.New("insufficient permissions to create database in this workspace")
 	}
 
+	if err := s.checkWorkspaceQuota(workspaceID); err != nil {
+		return nil, err
+	}
+
 	database.WorkspaceID = &workspaceID
 
 	if err := database.Validate(); err != nil {
 		return nil, err
 	}
 
-	if err := database.PopulateDbData(s.logger, s.fieldEncryptor); err != nil {
-		return nil, fmt.Errorf("failed to auto-detect database data: %w", err)
+	if err := s.checkCanChangeEnvironment(
+		user,
+		&Database{WorkspaceID: &workspaceID},
+		database,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAgent(workspaceID, database); err != nil {
+		return nil, err
+	}
+
+	if err := database.Ownership.Validate(workspaceID, s.workspaceService); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFilesystem(database); err != nil {
+		return nil, err
+	}
+
+	if err := s.populateDbData(database); err != nil {
+		return nil, err
 	}
 
 	if config.GetEnv().IsCloud {
@@ -108,6 +149,10 @@ func (s *DatabaseService) CreateDatabase(
 		return nil, fmt.Errorf("failed to encrypt sensitive fields: %w", err)
 	}
 
+	if err := s.attachDefaultNotifiers(workspaceID, database); err != nil {
+		return nil, err
+	}
+
 	database, err = s.dbRepository.Save(database)
 	if err != nil {
 		return nil, err
@@ -155,20 +200,48 @@ func (s *DatabaseService) UpdateDatabase(
 		return err
 	}
 
+	if err := s.workspaceService.ValidateChangeReason(
+		*existingDatabase.WorkspaceID,
+		database.ChangeReason,
+	); err != nil {
+		return err
+	}
+
+	if err := s.checkCanChangeEnvironment(user, existingDatabase, database); err != nil {
+		return err
+	}
+
 	for _, notifier := range database.Notifiers {
-		if notifier.WorkspaceID != *existingDatabase.WorkspaceID {
+		if notifier.WorkspaceID != *existingDatabase.WorkspaceID && !notifier.IsSystem {
 			return errors.New("notifier does not belong to this workspace")
 		}
 	}
 
+	previousOwnership := existingDatabase.Ownership
 	existingDatabase.Update(database)
 
 	if err := existingDatabase.Validate(); err != nil {
 		return err
 	}
 
-	if err := existingDatabase.PopulateDbData(s.logger, s.fieldEncryptor); err != nil {
-		return fmt.Errorf("failed to auto-detect database data: %w", err)
+	if err := s.checkAgent(*existingDatabase.WorkspaceID, existingDatabase); err != nil {
+		return err
+	}
+
+	if err := existingDatabase.Ownership.ValidateUpdate(
+		previousOwnership,
+		*existingDatabase.WorkspaceID,
+		s.workspaceService,
+	); err != nil {
+		return err
+	}
+
+	if err := s.checkFilesystem(existingDatabase); err != nil {
+		return err
+	}
+
+	if err := s.populateDbData(existingDatabase); err != nil {
+		return err
 	}
 
 	if config.GetEnv().IsCloud {
@@ -201,8 +274,9 @@ func (s *DatabaseService) UpdateDatabase(
 		return err
 	}
 
-	s.auditLogService.WriteAuditLog(
+	s.auditLogService.WriteChangeAuditLog(
 		fmt.Sprintf("Database updated: %s", existingDatabase.Name),
+		database.ChangeReason,
 		&user.ID,
 		existingDatabase.WorkspaceID,
 	)
@@ -267,10 +341,27 @@ func (s *DatabaseService) GetDatabase(
 		return nil, errors.New("insufficient permissions to access this database")
 	}
 
+	s.auditDatabaseRead(user, database)
+
 	database.HideSensitiveData()
 	return database, nil
 }
 
+// auditDatabaseRead records reads of connection settings by admins
+func (s *DatabaseService) auditDatabaseRead(user *users_models.User, database *Database) {
+	canManage, err := s.workspaceService.CanUserManageWorkspace(*database.WorkspaceID, user)
+	if err != nil || !canManage {
+		return
+	}
+
+	s.auditLogService.WriteReadAuditLog(
+		fmt.Sprintf("Database viewed: %s", database.Name),
+		database.ID,
+		user.ID,
+		database.WorkspaceID,
+	)
+}
+
 func (s *DatabaseService) GetDatabasesByWorkspace(
 	user *users_models.User,
 	workspaceID uuid.UUID,
@@ -345,6 +436,16 @@ func (s *DatabaseService) TestDatabaseConnection(
 		return errors.New("insufficient permissions to test connection for this database")
 	}
 
+	if database.IsBehindAgent() {
+		return errors.New(
+			"database is reachable only by its agent, run a backup to check the connection",
+		)
+	}
+
+	if err := s.checkFilesystem(database); err != nil {
+		return err
+	}
+
 	err = database.TestConnection(s.logger, s.fieldEncryptor)
 	if err != nil {
 		lastSaveError := err.Error()
@@ -389,6 +490,16 @@ func (s *DatabaseService) TestDatabaseConnectionDirect(
 		usingDatabase = database
 	}
 
+	if usingDatabase.IsBehindAgent() {
+		return errors.New(
+			"database is reachable only by its agent, run a backup to check the connection",
+		)
+	}
+
+	if err := s.checkFilesystem(usingDatabase); err != nil {
+		return err
+	}
+
 	return usingDatabase.TestConnection(s.logger, s.fieldEncryptor)
 }
 
@@ -402,6 +513,16 @@ func (s *DatabaseService) GetAllDatabases() ([]*Database, error) {
 	return s.dbRepository.GetAllDatabases()
 }
 
+func (s *DatabaseService) GetDatabasesByWorkspaceID(workspaceID uuid.UUID) ([]*Database, error) {
+	return s.dbRepository.FindByWorkspaceID(workspaceID)
+}
+
+func (s *DatabaseService) GetDatabasesWithCredentialsExpiringBefore(
+	expireBefore time.Time,
+) ([]*Database, error) {
+	return s.dbRepository.FindWithCredentialsExpiringBefore(expireBefore)
+}
+
 func (s *DatabaseService) SetBackupError(databaseID uuid.UUID, errorMessage string) error {
 	database, err := s.dbRepository.FindByID(databaseID)
 	if err != nil {
@@ -454,11 +575,17 @@ func (s *DatabaseService) CopyDatabase(
 		return nil, errors.New("insufficient permissions to copy this database")
 	}
 
+	if err := s.checkWorkspaceQuota(*existingDatabase.WorkspaceID); err != nil {
+		return nil, err
+	}
+
 	newDatabase := &Database{
 		ID:                     uuid.Nil,
 		WorkspaceID:            existingDatabase.WorkspaceID,
 		Name:                   existingDatabase.Name + " (Copy)",
 		Type:                   existingDatabase.Type,
+		Environment:            existingDatabase.Environment,
+		Ownership:              existingDatabase.Ownership,
 		Notifiers:              existingDatabase.Notifiers,
 		LastBackupTime:         nil,
 		LastBackupErrorMessage: nil,
@@ -480,6 +607,9 @@ func (s *DatabaseService) CopyDatabase(
 				IsHttps:        existingDatabase.Postgresql.IsHttps,
 				IncludeSchemas: existingDatabase.Postgresql.IncludeSchemas,
 				CpuCount:       existingDatabase.Postgresql.CpuCount,
+
+				TimescaledbVersion:     existingDatabase.Postgresql.TimescaledbVersion,
+				TimescaledbChunksCount: existingDatabase.Postgresql.TimescaledbChunksCount,
 			}
 		}
 	case DatabaseTypeMysql:
@@ -526,6 +656,103 @@ func (s *DatabaseService) CopyDatabase(
 				CpuCount:     existingDatabase.Mongodb.CpuCount,
 			}
 		}
+	case DatabaseTypeFilesystem:
+		if existingDatabase.Filesystem != nil {
+			newDatabase.Filesystem = &filesystem.FilesystemDatabase{
+				ID:              uuid.Nil,
+				DatabaseID:      nil,
+				Path:            existingDatabase.Filesystem.Path,
+				IncludeGlobs:    existingDatabase.Filesystem.IncludeGlobs,
+				ExcludeGlobs:    existingDatabase.Filesystem.ExcludeGlobs,
+				Compression:     existingDatabase.Filesystem.Compression,
+				SnapshotMode:    existingDatabase.Filesystem.SnapshotMode,
+				LvmSnapshotSize: existingDatabase.Filesystem.LvmSnapshotSize,
+			}
+		}
+	case DatabaseTypeElasticsearch:
+		if existingDatabase.Elasticsearch != nil {
+			newDatabase.Elasticsearch = &elasticsearch.ElasticsearchDatabase{
+				ID:                   uuid.Nil,
+				DatabaseID:           nil,
+				Flavor:               existingDatabase.Elasticsearch.Flavor,
+				Version:              existingDatabase.Elasticsearch.Version,
+				URL:                  existingDatabase.Elasticsearch.URL,
+				Username:             existingDatabase.Elasticsearch.Username,
+				Password:             existingDatabase.Elasticsearch.Password,
+				SkipTLSVerify:        existingDatabase.Elasticsearch.SkipTLSVerify,
+				Repository:           existingDatabase.Elasticsearch.Repository,
+				Indices:              existingDatabase.Elasticsearch.Indices,
+				IsIncludeGlobalState: existingDatabase.Elasticsearch.IsIncludeGlobalState,
+			}
+		}
+	case DatabaseTypeInfluxdb:
+		if existingDatabase.Influxdb != nil {
+			newDatabase.Influxdb = &influxdb.InfluxdbDatabase{
+				ID:            uuid.Nil,
+				DatabaseID:    nil,
+				Version:       existingDatabase.Influxdb.Version,
+				URL:           existingDatabase.Influxdb.URL,
+				Token:         existingDatabase.Influxdb.Token,
+				Org:           existingDatabase.Influxdb.Org,
+				Bucket:        existingDatabase.Influxdb.Bucket,
+				SkipTLSVerify: existingDatabase.Influxdb.SkipTLSVerify,
+			}
+		}
+	case DatabaseTypeCassandra:
+		if existingDatabase.Cassandra != nil {
+			newDatabase.Cassandra = &cassandra.CassandraDatabase{
+				ID:                   uuid.Nil,
+				DatabaseID:           nil,
+				Version:              existingDatabase.Cassandra.Version,
+				Nodes:                existingDatabase.Cassandra.Nodes,
+				SshPort:              existingDatabase.Cassandra.SshPort,
+				SshUsername:          existingDatabase.Cassandra.SshUsername,
+				SshPassword:          existingDatabase.Cassandra.SshPassword,
+				SshPrivateKey:        existingDatabase.Cassandra.SshPrivateKey,
+				IsUseSudo:            existingDatabase.Cassandra.IsUseSudo,
+				JmxUsername:          existingDatabase.Cassandra.JmxUsername,
+				JmxPassword:          existingDatabase.Cassandra.JmxPassword,
+				DataDirectory:        existingDatabase.Cassandra.DataDirectory,
+				Keyspaces:            existingDatabase.Cassandra.Keyspaces,
+				IsAllowPartialBackup: existingDatabase.Cassandra.IsAllowPartialBackup,
+			}
+		}
+	case DatabaseTypeCockroachdb:
+		if existingDatabase.Cockroachdb != nil {
+			newDatabase.Cockroachdb = &cockroachdb.CockroachdbDatabase{
+				ID:         uuid.Nil,
+				DatabaseID: nil,
+				Version:    existingDatabase.Cockroachdb.Version,
+				Host:       existingDatabase.Cockroachdb.Host,
+				Port:       existingDatabase.Cockroachdb.Port,
+				Username:   existingDatabase.Cockroachdb.Username,
+				Password:   existingDatabase.Cockroachdb.Password,
+				IsHttps:    existingDatabase.Cockroachdb.IsHttps,
+				Database:   existingDatabase.Cockroachdb.Database,
+			}
+		}
+	case DatabaseTypeNeo4j:
+		if existingDatabase.Neo4j != nil {
+			newDatabase.Neo4j = &neo4j.Neo4jDatabase{
+				ID:            uuid.Nil,
+				DatabaseID:    nil,
+				Version:       existingDatabase.Neo4j.Version,
+				Edition:       existingDatabase.Neo4j.Edition,
+				URL:           existingDatabase.Neo4j.URL,
+				Username:      existingDatabase.Neo4j.Username,
+				Password:      existingDatabase.Neo4j.Password,
+				SkipTLSVerify: existingDatabase.Neo4j.SkipTLSVerify,
+				Database:      existingDatabase.Neo4j.Database,
+				BackupMode:    existingDatabase.Neo4j.BackupMode,
+				SshHost:       existingDatabase.Neo4j.SshHost,
+				SshPort:       existingDatabase.Neo4j.SshPort,
+				SshUsername:   existingDatabase.Neo4j.SshUsername,
+				SshPassword:   existingDatabase.Neo4j.SshPassword,
+				SshPrivateKey: existingDatabase.Neo4j.SshPrivateKey,
+				IsUseSudo:     existingDatabase.Neo4j.IsUseSudo,
+				ServiceName:   existingDatabase.Neo4j.ServiceName,
+			}
+		}
 	}
 
 	if err := newDatabase.Validate(); err != nil {
@@ -563,6 +790,18 @@ func (s *DatabaseService) TransferDatabaseToWorkspace(
 		return err
 	}
 
+	if err := s.checkWorkspaceQuota(targetWorkspaceID); err != nil {
+		return err
+	}
+
+	// an inherited environment is pinned, so a PROD database does not lose its label by
+	// moving to a workspace without one
+	environment, err := s.GetEnvironment(database)
+	if err != nil {
+		return err
+	}
+	database.Environment = environment
+
 	sourceWorkspaceID := database.WorkspaceID
 	database.WorkspaceID = &targetWorkspaceID
 
@@ -581,6 +820,54 @@ func (s *DatabaseService) TransferDatabaseToWorkspace(
 	return nil
 }
 
+// AttachNotifierToAllDatabases attaches the notifier to databases of its workspace which
+// lack it, e.g. after it became a workspace default. Databases opted out of default
+// notifiers are skipped. Returns the number of databases the notifier was attached to
+func (s *DatabaseService) AttachNotifierToAllDatabases(
+	user *users_models.User,
+	notifierID uuid.UUID,
+) (int, error) {
+	notifier, err := s.notifierService.GetNotifierByID(notifierID)
+	if err != nil {
+		return 0, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(notifier.WorkspaceID, user)
+	if err != nil {
+		return 0, err
+	}
+	if !canManage {
+		return 0, errors.New("insufficient permissions to manage databases in this workspace")
+	}
+
+	databases, err := s.dbRepository.FindByWorkspaceID(notifier.WorkspaceID)
+	if err != nil {
+		return 0, err
+	}
+
+	attachedCount := 0
+	for _, database := range databases {
+		if database.IsDefaultNotifiersOptOut || database.HasNotifier(notifier.ID) {
+			continue
+		}
+
+		database.Notifiers = append(database.Notifiers, *notifier)
+		if _, err := s.dbRepository.Save(database); err != nil {
+			return attachedCount, err
+		}
+
+		attachedCount++
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Notifier %s attached to %d databases", notifier.Name, attachedCount),
+		&user.ID,
+		&notifier.WorkspaceID,
+	)
+
+	return attachedCount, nil
+}
+
 func (s *DatabaseService) UpdateDatabaseNotifiers(
 	databaseID uuid.UUID,
 	newNotifiers []notifiers.Notifier,
@@ -785,3 +1072,87 @@ func (s *DatabaseService) CreateReadOnlyUser(
 
 	return username, password, nil
 }
+
+func (s *DatabaseService) attachDefaultNotifiers(workspaceID uuid.UUID, database *Database) error {
+	if database.IsDefaultNotifiersOptOut {
+		return nil
+	}
+
+	defaultNotifiers, err := s.notifierService.GetWorkspaceDefaultNotifiers(workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get default notifiers: %w", err)
+	}
+
+	for _, notifier := range defaultNotifiers {
+		if !database.HasNotifier(notifier.ID) {
+			database.Notifiers = append(database.Notifiers, *notifier)
+		}
+	}
+
+	return nil
+}
+
+func (s *DatabaseService) checkWorkspaceQuota(workspaceID uuid.UUID) error {
+	if s.workspaceQuotaChecker == nil {
+		return nil
+	}
+
+	databases, err := s.dbRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	return s.workspaceQuotaChecker.CheckCanAddDatabase(workspaceID, len(databases))
+}
+
+// checkAgent verifies the agent belongs to the same workspace. Cloud mode requires a
+// read-only check on a direct connection, so agents are not allowed there
+func (s *DatabaseService) checkAgent(workspaceID uuid.UUID, database *Database) error {
+	if !database.IsBehindAgent() {
+		return nil
+	}
+
+	if config.GetEnv().IsCloud {
+		return errors.New("agents are not available in cloud mode")
+	}
+
+	if database.Postgresql != nil && database.Postgresql.Version == "" {
+		return errors.New(
+			"postgresql version is required, it is not detected for databases behind an agent",
+		)
+	}
+
+	if s.agentChecker == nil {
+		return errors.New("agents are not available")
+	}
+
+	return s.agentChecker.CheckCanUseAgent(workspaceID, *database.AgentID)
+}
+
+// populateDbData is skipped for databases behind an agent, they are not reachable from
+// this node
+func (s *DatabaseService) populateDbData(database *Database) error {
+	if database.IsBehindAgent() {
+		return nil
+	}
+
+	if err := database.PopulateDbData(s.logger, s.fieldEncryptor); err != nil {
+		return fmt.Errorf("failed to auto-detect database data: %w", err)
+	}
+
+	return nil
+}
+
+// checkFilesystem keeps filesystem sources inside FILESYSTEM_BACKUP_ROOTS, they read and
+// restore files of the host Databasus runs on
+func (s *DatabaseService) checkFilesystem(database *Database) error {
+	if database.Type != DatabaseTypeFilesystem || database.Filesystem == nil {
+		return nil
+	}
+
+	if config.GetEnv().IsCloud {
+		return errors.New("filesystem sources are not available in cloud mode")
+	}
+
+	return database.Filesystem.CheckPathAllowed(config.GetEnv().FilesystemBackupRoots)
+}
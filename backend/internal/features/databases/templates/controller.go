@@ -0,0 +1,166 @@
+package databases_templates
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ConnectionTemplateController struct {
+	connectionTemplateService *ConnectionTemplateService
+}
+
+func (c *ConnectionTemplateController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/connection-templates", c.GetTemplates)
+	router.POST("/connection-templates", c.SaveTemplate)
+	router.DELETE("/connection-templates/:id", c.DeleteTemplate)
+	router.POST("/connection-templates/:id/databases", c.CreateDatabaseFromTemplate)
+}
+
+// GetTemplates
+// @Summary Get connection templates
+// @Description Get connection templates defined by admins. Connection settings are returned to admins only, with secrets hidden
+// @Tags connection-templates
+// @Produce json
+// @Success 200 {array} ConnectionTemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /connection-templates [get]
+func (c *ConnectionTemplateController) GetTemplates(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	templates, err := c.connectionTemplateService.GetTemplates(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+
+// SaveTemplate
+// @Summary Save a connection template
+// @Description Create a connection template or update it when id is set (admin only). Secrets left empty on update keep their saved values
+// @Tags connection-templates
+// @Accept json
+// @Produce json
+// @Param request body ConnectionTemplate true "Connection template"
+// @Success 200 {object} ConnectionTemplateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /connection-templates [post]
+func (c *ConnectionTemplateController) SaveTemplate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request ConnectionTemplate
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := c.connectionTemplateService.SaveTemplate(user, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageTemplates) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate
+// @Summary Delete a connection template
+// @Description Delete a connection template (admin only). Databases created from it are kept
+// @Tags connection-templates
+// @Param id path string true "Connection template ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /connection-templates/{id} [delete]
+func (c *ConnectionTemplateController) DeleteTemplate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	templateID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid connection template ID"})
+		return
+	}
+
+	if err := c.connectionTemplateService.DeleteTemplate(user, templateID); err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageTemplates) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "connection template deleted successfully"})
+}
+
+// CreateDatabaseFromTemplate
+// @Summary Create a database from a connection template
+// @Description Create a database in the workspace with connection settings of the template
+// @Tags connection-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Connection template ID"
+// @Param request body CreateDatabaseFromTemplateRequest true "Workspace and name of the database"
+// @Success 201 {object} databases.Database
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /connection-templates/{id}/databases [post]
+func (c *ConnectionTemplateController) CreateDatabaseFromTemplate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	templateID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid connection template ID"})
+		return
+	}
+
+	var request CreateDatabaseFromTemplateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	database, err := c.connectionTemplateService.CreateDatabaseFromTemplate(
+		user,
+		templateID,
+		&request,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, database)
+}
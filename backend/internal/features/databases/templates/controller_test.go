@@ -0,0 +1,114 @@
+package databases_templates
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetConnectionTemplateController(),
+	)
+}
+
+func Test_ConnectionTemplate_ReadOnlyForMembersAndSecretsHidden(t *testing.T) {
+	router := createTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", member, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	template := ConnectionTemplate{
+		Name: "Shared Postgres " + uuid.New().String(),
+		Connection: &databases.Database{
+			Type:       databases.DatabaseTypePostgres,
+			Postgresql: databases.GetTestPostgresConfig(),
+		},
+	}
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/connection-templates",
+		"Bearer "+member.Token,
+		template,
+		http.StatusForbidden,
+	)
+
+	var savedTemplate ConnectionTemplateResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/connection-templates",
+		"Bearer "+admin.Token,
+		template,
+		http.StatusOK,
+		&savedTemplate,
+	)
+	defer test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/connection-templates/"+savedTemplate.ID.String(),
+		"Bearer "+admin.Token,
+		http.StatusOK,
+	)
+
+	assert.Equal(t, databases.DatabaseTypePostgres, savedTemplate.Type)
+	assert.NotNil(t, savedTemplate.Connection, "Admin should see connection settings")
+	assert.Equal(t, template.Connection.Postgresql.Host, savedTemplate.Connection.Postgresql.Host)
+	assert.Equal(t, "", savedTemplate.Connection.Postgresql.Password)
+
+	var memberTemplates []ConnectionTemplateResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/connection-templates",
+		"Bearer "+member.Token,
+		http.StatusOK,
+		&memberTemplates,
+	)
+
+	foundTemplate := false
+	for _, memberTemplate := range memberTemplates {
+		if memberTemplate.ID == savedTemplate.ID {
+			foundTemplate = true
+			assert.Nil(t, memberTemplate.Connection, "Non-admin should not see connection settings")
+		}
+	}
+	assert.True(t, foundTemplate, "Template should be listed to every user")
+
+	var database databases.Database
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/connection-templates/%s/databases", savedTemplate.ID.String()),
+		"Bearer "+member.Token,
+		CreateDatabaseFromTemplateRequest{WorkspaceID: workspace.ID, Name: "From Template"},
+		http.StatusCreated,
+		&database,
+	)
+	defer databases.RemoveTestDatabase(&database)
+
+	assert.NotEqual(t, uuid.Nil, database.ID)
+	assert.Equal(t, "From Template", database.Name)
+	assert.Equal(t, workspace.ID, *database.WorkspaceID)
+	assert.Equal(t, "", database.Postgresql.Password)
+
+	createdDatabase, err := databases.GetDatabaseService().GetDatabaseByID(database.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, createdDatabase.Postgresql.Password, "Password is taken from the template")
+}
@@ -0,0 +1,25 @@
+package databases_templates
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/util/encryption"
+)
+
+var connectionTemplateService = &ConnectionTemplateService{
+	&ConnectionTemplateRepository{},
+	databases.GetDatabaseService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+}
+var connectionTemplateController = &ConnectionTemplateController{
+	connectionTemplateService,
+}
+
+func GetConnectionTemplateService() *ConnectionTemplateService {
+	return connectionTemplateService
+}
+
+func GetConnectionTemplateController() *ConnectionTemplateController {
+	return connectionTemplateController
+}
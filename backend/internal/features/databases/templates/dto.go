@@ -0,0 +1,25 @@
+package databases_templates
+
+import (
+	"time"
+
+	"databasus-backend/internal/features/databases"
+
+	"github.com/google/uuid"
+)
+
+type CreateDatabaseFromTemplateRequest struct {
+	WorkspaceID uuid.UUID `json:"workspaceId" binding:"required"`
+	Name        string    `json:"name"        binding:"required"`
+}
+
+// ConnectionTemplateResponse has the same JSON shape as ConnectionTemplate. Connection is
+// nil for non-admins and has secrets blanked for admins
+type ConnectionTemplateResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Type        databases.DatabaseType `json:"type"`
+	Connection  *databases.Database    `json:"connection"`
+	CreatedAt   time.Time              `json:"createdAt"`
+}
@@ -0,0 +1,126 @@
+package databases_templates
+
+import (
+	"encoding/json"
+	"time"
+
+	"databasus-backend/internal/features/databases"
+
+	"github.com/google/uuid"
+)
+
+// ConnectionTemplate is connection settings defined by admins, e.g. of a shared server.
+// Every workspace sees it read-only and can create databases from it
+type ConnectionTemplate struct {
+	ID          uuid.UUID              `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	Name        string                 `json:"name"        gorm:"column:name;type:text;not null"`
+	Description string                 `json:"description" gorm:"column:description;type:text;not null;default:''"`
+	Type        databases.DatabaseType `json:"type"        gorm:"column:type;type:text;not null"`
+
+	// Connection keeps only the specific database of the type, with secrets encrypted like
+	// on databases
+	Connection *databases.Database `json:"connection" gorm:"column:connection;type:text;not null;serializer:json"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (ConnectionTemplate) TableName() string {
+	return "connection_templates"
+}
+
+// newDatabase copies the connection into a database not saved yet, so the template is not
+// changed by saving the database
+func (t *ConnectionTemplate) newDatabase(name string) (*databases.Database, error) {
+	database, err := copyConnection(t.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	database.ID = uuid.Nil
+	database.Name = name
+
+	return database, nil
+}
+
+// copyConnection drops everything but connection settings of the type. Specific databases
+// lose their IDs, so saving a database never overwrites another one
+func copyConnection(source *databases.Database) (*databases.Database, error) {
+	data, err := json.Marshal(&databases.Database{
+		Type:          source.Type,
+		Postgresql:    source.Postgresql,
+		Mysql:         source.Mysql,
+		Mariadb:       source.Mariadb,
+		Mongodb:       source.Mongodb,
+		Filesystem:    source.Filesystem,
+		Elasticsearch: source.Elasticsearch,
+		Influxdb:      source.Influxdb,
+		Cassandra:     source.Cassandra,
+		Cockroachdb:   source.Cockroachdb,
+		Neo4j:         source.Neo4j,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var connection databases.Database
+	if err := json.Unmarshal(data, &connection); err != nil {
+		return nil, err
+	}
+
+	connection.ID = source.ID
+
+	switch connection.Type {
+	case databases.DatabaseTypePostgres:
+		if connection.Postgresql != nil {
+			connection.Postgresql.ID = uuid.Nil
+			connection.Postgresql.DatabaseID = nil
+		}
+	case databases.DatabaseTypeMysql:
+		if connection.Mysql != nil {
+			connection.Mysql.ID = uuid.Nil
+			connection.Mysql.DatabaseID = nil
+		}
+	case databases.DatabaseTypeMariadb:
+		if connection.Mariadb != nil {
+			connection.Mariadb.ID = uuid.Nil
+			connection.Mariadb.DatabaseID = nil
+		}
+	case databases.DatabaseTypeMongodb:
+		if connection.Mongodb != nil {
+			connection.Mongodb.ID = uuid.Nil
+			connection.Mongodb.DatabaseID = nil
+		}
+	case databases.DatabaseTypeFilesystem:
+		if connection.Filesystem != nil {
+			connection.Filesystem.ID = uuid.Nil
+			connection.Filesystem.DatabaseID = nil
+		}
+	case databases.DatabaseTypeElasticsearch:
+		if connection.Elasticsearch != nil {
+			connection.Elasticsearch.ID = uuid.Nil
+			connection.Elasticsearch.DatabaseID = nil
+		}
+	case databases.DatabaseTypeInfluxdb:
+		if connection.Influxdb != nil {
+			connection.Influxdb.ID = uuid.Nil
+			connection.Influxdb.DatabaseID = nil
+		}
+	case databases.DatabaseTypeCassandra:
+		if connection.Cassandra != nil {
+			connection.Cassandra.ID = uuid.Nil
+			connection.Cassandra.DatabaseID = nil
+		}
+	case databases.DatabaseTypeCockroachdb:
+		if connection.Cockroachdb != nil {
+			connection.Cockroachdb.ID = uuid.Nil
+			connection.Cockroachdb.DatabaseID = nil
+		}
+	case databases.DatabaseTypeNeo4j:
+		if connection.Neo4j != nil {
+			connection.Neo4j.ID = uuid.Nil
+			connection.Neo4j.DatabaseID = nil
+		}
+	}
+
+	return &connection, nil
+}
@@ -0,0 +1,37 @@
+package databases_templates
+
+import (
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type ConnectionTemplateRepository struct{}
+
+func (r *ConnectionTemplateRepository) Save(template *ConnectionTemplate) error {
+	return storage.GetDb().Save(template).Error
+}
+
+func (r *ConnectionTemplateRepository) FindByID(id uuid.UUID) (*ConnectionTemplate, error) {
+	var template ConnectionTemplate
+
+	if err := storage.GetDb().Where("id = ?", id).First(&template).Error; err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *ConnectionTemplateRepository) FindAll() ([]*ConnectionTemplate, error) {
+	var templates []*ConnectionTemplate
+
+	if err := storage.GetDb().Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *ConnectionTemplateRepository) Delete(template *ConnectionTemplate) error {
+	return storage.GetDb().Delete(template).Error
+}
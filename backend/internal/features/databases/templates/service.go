@@ -0,0 +1,215 @@
+package databases_templates
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInsufficientPermissionsToManageTemplates = errors.New(
+		"only admins can manage connection templates",
+	)
+	ErrConnectionRequired = errors.New("connection is required")
+)
+
+type ConnectionTemplateService struct {
+	templateRepository *ConnectionTemplateRepository
+	databaseService    *databases.DatabaseService
+	auditLogService    *audit_logs.AuditLogService
+	fieldEncryptor     encryption.FieldEncryptor
+}
+
+// GetTemplates lists templates to every user, only admins see their connection settings
+func (s *ConnectionTemplateService) GetTemplates(
+	user *users_models.User,
+) ([]*ConnectionTemplateResponse, error) {
+	templates, err := s.templateRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*ConnectionTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		responses = append(responses, toConnectionTemplateResponse(user, template))
+	}
+
+	return responses, nil
+}
+
+func (s *ConnectionTemplateService) SaveTemplate(
+	user *users_models.User,
+	template *ConnectionTemplate,
+) (*ConnectionTemplateResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrInsufficientPermissionsToManageTemplates
+	}
+
+	if template.Connection == nil {
+		return nil, ErrConnectionRequired
+	}
+
+	isUpdate := template.ID != uuid.Nil
+
+	savingTemplate := template
+	if isUpdate {
+		existingTemplate, err := s.templateRepository.FindByID(template.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// secrets left empty by the form keep their saved values
+		if existingTemplate.Connection.Type == template.Connection.Type {
+			existingTemplate.Connection.Update(template.Connection)
+		} else {
+			existingTemplate.Connection = template.Connection
+		}
+
+		existingTemplate.Name = template.Name
+		existingTemplate.Description = template.Description
+		savingTemplate = existingTemplate
+	} else {
+		savingTemplate.ID = uuid.New()
+		savingTemplate.CreatedAt = time.Now().UTC()
+	}
+
+	savingTemplate.Connection.ID = savingTemplate.ID
+
+	connection, err := copyConnection(savingTemplate.Connection)
+	if err != nil {
+		return nil, err
+	}
+	connection.Name = savingTemplate.Name
+
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := connection.EncryptSensitiveFields(s.fieldEncryptor); err != nil {
+		return nil, fmt.Errorf("failed to encrypt sensitive fields: %w", err)
+	}
+
+	savingTemplate.Type = connection.Type
+	savingTemplate.Connection = connection
+
+	if err := s.templateRepository.Save(savingTemplate); err != nil {
+		return nil, err
+	}
+
+	if isUpdate {
+		s.auditLogService.WriteAuditLog(
+			fmt.Sprintf("Connection template updated: %s", savingTemplate.Name),
+			&user.ID,
+			nil,
+		)
+	} else {
+		s.auditLogService.WriteAuditLog(
+			fmt.Sprintf("Connection template created: %s", savingTemplate.Name),
+			&user.ID,
+			nil,
+		)
+	}
+
+	return toConnectionTemplateResponse(user, savingTemplate), nil
+}
+
+func (s *ConnectionTemplateService) DeleteTemplate(
+	user *users_models.User,
+	templateID uuid.UUID,
+) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return ErrInsufficientPermissionsToManageTemplates
+	}
+
+	template, err := s.templateRepository.FindByID(templateID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.templateRepository.Delete(template); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Connection template deleted: %s", template.Name),
+		&user.ID,
+		nil,
+	)
+
+	return nil
+}
+
+// CreateDatabaseFromTemplate creates a database with connection settings of the template.
+// Permissions of the workspace are checked like for any created database
+func (s *ConnectionTemplateService) CreateDatabaseFromTemplate(
+	user *users_models.User,
+	templateID uuid.UUID,
+	request *CreateDatabaseFromTemplateRequest,
+) (*databases.Database, error) {
+	template, err := s.templateRepository.FindByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := template.newDatabase(request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err = s.databaseService.CreateDatabase(user, request.WorkspaceID, database)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Database %s created from connection template %s",
+			database.Name,
+			template.Name,
+		),
+		&user.ID,
+		&request.WorkspaceID,
+	)
+
+	database.HideSensitiveData()
+
+	return database, nil
+}
+
+// toConnectionTemplateResponse hides connection settings like system storages are hidden
+// from non-admins
+func toConnectionTemplateResponse(
+	user *users_models.User,
+	template *ConnectionTemplate,
+) *ConnectionTemplateResponse {
+	response := &ConnectionTemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Type:        template.Type,
+		CreatedAt:   template.CreatedAt,
+	}
+
+	if user.Role != users_enums.UserRoleAdmin {
+		return response
+	}
+
+	connection, err := copyConnection(template.Connection)
+	if err != nil {
+		return response
+	}
+	connection.Name = template.Name
+	connection.HideSensitiveData()
+
+	response.Connection = connection
+
+	return response
+}
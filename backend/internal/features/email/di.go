@@ -1,20 +1,12 @@
 package email
 
 import (
-	"databasus-backend/internal/config"
 	"databasus-backend/internal/util/logger"
 )
 
-var env = config.GetEnv()
-var log = logger.GetLogger()
-
 var emailSMTPSender = &EmailSMTPSender{
-	log,
-	env.SMTPHost,
-	env.SMTPPort,
-	env.SMTPUser,
-	env.SMTPPassword,
-	env.SMTPHost != "" && env.SMTPPort != 0,
+	logger.GetLogger(),
+	newSMTPConnectionPool(MaxPooledConnections, SMTPOperationTimeout),
 }
 
 func GetEmailSMTPSender() *EmailSMTPSender {
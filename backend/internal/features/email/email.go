@@ -1,13 +1,20 @@
 package email
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
 	"mime"
 	"net"
 	"net/smtp"
+	"strings"
 	"time"
+
+	"databasus-backend/internal/config"
+	network_utils "databasus-backend/internal/util/network"
+	proxy_utils "databasus-backend/internal/util/proxy"
 )
 
 const (
@@ -19,33 +26,118 @@ const (
 )
 
 type EmailSMTPSender struct {
-	logger       *slog.Logger
-	smtpHost     string
-	smtpPort     int
-	smtpUser     string
-	smtpPassword string
-	isConfigured bool
+	logger *slog.Logger
+	pool   *smtpConnectionPool
+}
+
+type smtpServer struct {
+	host     string
+	port     int
+	user     string
+	password string
 }
 
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// SendEmail reads SMTP settings on each call, so changes picked up by settings reload apply
+// to the next email without restart
 func (s *EmailSMTPSender) SendEmail(to, subject, body string) error {
-	if !s.isConfigured {
-		s.logger.Warn("Skipping email send, SMTP not initialized", "to", to, "subject", subject)
-		return nil
+	return s.SendEmails([]Email{{To: to, Subject: subject, Body: body}})
+}
+
+// SendEmails sends emails one after another through pooled SMTP sessions, so a batch needs
+// one connection and authentication. A failed email does not stop the rest of the batch
+func (s *EmailSMTPSender) SendEmails(emails []Email) error {
+	var sendErrors []error
+	for i, err := range s.SendEmailsWithResults(emails) {
+		if err != nil {
+			sendErrors = append(sendErrors, fmt.Errorf("email to %s: %w", emails[i].To, err))
+		}
 	}
 
-	from := s.smtpUser
+	return errors.Join(sendErrors...)
+}
+
+func (s *EmailSMTPSender) IsConfigured() bool {
+	return config.GetReloadableSettings().IsSMTPConfigured()
+}
+
+// SendEmailsWithResults is SendEmails which returns the error of each email at its index,
+// nil for sent emails and for all emails when SMTP is not configured
+func (s *EmailSMTPSender) SendEmailsWithResults(emails []Email) []error {
+	settings := config.GetReloadableSettings()
+	if !settings.IsSMTPConfigured() {
+		s.logger.Warn("Skipping email send, SMTP not initialized", "emailsCount", len(emails))
+		return make([]error, len(emails))
+	}
+
+	server := smtpServer{
+		host:     network_utils.NormalizeHost(settings.SMTPHost),
+		port:     settings.SMTPPort,
+		user:     settings.SMTPUser,
+		password: settings.SMTPPassword,
+	}
+
+	return s.sendEmailsToServer(server, emails)
+}
+
+func (s *EmailSMTPSender) sendEmailsToServer(server smtpServer, emails []Email) []error {
+	results := make([]error, len(emails))
+
+	from := server.user
 	if from == "" {
-		from = "noreply@" + s.smtpHost
+		from = "noreply@" + server.host
 	}
 
-	emailContent := s.buildEmailContent(to, subject, body, from)
-	isAuthRequired := s.smtpUser != "" && s.smtpPassword != ""
+	isAuthRequired := server.user != "" && server.password != ""
+	connect := func() (*smtp.Client, net.Conn, error) {
+		createClient := func() (*smtp.Client, net.Conn, error) {
+			return s.createStartTLSClient(server)
+		}
+		if server.port == ImplicitTLSPort {
+			createClient = func() (*smtp.Client, net.Conn, error) {
+				return s.createImplicitTLSClient(server)
+			}
+		}
 
-	if s.smtpPort == ImplicitTLSPort {
-		return s.sendImplicitTLS(to, from, emailContent, isAuthRequired)
+		return s.authenticateWithRetry(server, createClient, isAuthRequired)
 	}
 
-	return s.sendStartTLS(to, from, emailContent, isAuthRequired)
+	var conn *pooledConnection
+
+	for i, email := range emails {
+		if conn == nil {
+			var err error
+			conn, err = s.pool.acquire(server, connect)
+			if err != nil {
+				for j := i; j < len(emails); j++ {
+					results[j] = err
+				}
+				return results
+			}
+		}
+
+		emailContent := s.buildEmailContent(email.To, email.Subject, email.Body, from)
+		conn.startOperation()
+		err := s.sendEmail(conn.client, email.To, from, emailContent)
+		conn.messagesSent++
+		results[i] = err
+
+		if err != nil || conn.messagesSent >= MaxMessagesPerConnection {
+			s.pool.release(conn, err)
+			conn = nil
+		}
+	}
+
+	if conn != nil {
+		s.pool.release(conn, nil)
+	}
+
+	return results
 }
 
 func (s *EmailSMTPSender) buildEmailContent(to, subject, body, from string) []byte {
@@ -69,71 +161,49 @@ func (s *EmailSMTPSender) buildEmailContent(to, subject, body, from string) []by
 	return []byte(fromHeader + toHeader + subjectHeader + dateHeader + mimeHeaders + body)
 }
 
-func (s *EmailSMTPSender) sendImplicitTLS(
-	to, from string,
-	emailContent []byte,
-	isAuthRequired bool,
-) error {
-	createClient := func() (*smtp.Client, func(), error) {
-		return s.createImplicitTLSClient()
-	}
-
-	client, cleanup, err := s.authenticateWithRetry(createClient, isAuthRequired)
-	if err != nil {
-		return err
-	}
-	defer cleanup()
-
-	return s.sendEmail(client, to, from, emailContent)
-}
-
-func (s *EmailSMTPSender) sendStartTLS(
-	to, from string,
-	emailContent []byte,
-	isAuthRequired bool,
-) error {
-	createClient := func() (*smtp.Client, func(), error) {
-		return s.createStartTLSClient()
-	}
+// Clients are returned with the raw connection, so the pool can move its deadline before
+// every exchange. The deadline set here covers connecting, TLS and authentication
+func (s *EmailSMTPSender) createImplicitTLSClient(
+	server smtpServer,
+) (*smtp.Client, net.Conn, error) {
+	addr := network_utils.JoinHostPort(server.host, server.port)
+	tlsConfig := &tls.Config{ServerName: server.host}
+	dialer := &net.Dialer{Timeout: DefaultTimeout}
 
-	client, cleanup, err := s.authenticateWithRetry(createClient, isAuthRequired)
+	tcpConn, err := proxy_utils.DialContext(context.Background(), dialer, "", addr)
 	if err != nil {
-		return err
+		return nil, nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
 	}
-	defer cleanup()
-
-	return s.sendEmail(client, to, from, emailContent)
-}
-
-func (s *EmailSMTPSender) createImplicitTLSClient() (*smtp.Client, func(), error) {
-	addr := net.JoinHostPort(s.smtpHost, fmt.Sprintf("%d", s.smtpPort))
-	tlsConfig := &tls.Config{ServerName: s.smtpHost}
-	dialer := &net.Dialer{Timeout: DefaultTimeout}
+	_ = tcpConn.SetDeadline(time.Now().Add(s.pool.operationTimeout))
 
-	conn, err := tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
-	if err != nil {
+	conn := tls.Client(tcpConn, tlsConfig)
+	if err := conn.Handshake(); err != nil {
+		_ = tcpConn.Close()
 		return nil, nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
 	}
 
-	client, err := smtp.NewClient(conn, s.smtpHost)
+	client, err := smtp.NewClient(conn, server.host)
 	if err != nil {
 		_ = conn.Close()
 		return nil, nil, fmt.Errorf("failed to create SMTP client: %w", err)
 	}
 
-	return client, func() { _ = client.Quit() }, nil
+	return client, tcpConn, nil
 }
 
-func (s *EmailSMTPSender) createStartTLSClient() (*smtp.Client, func(), error) {
-	addr := net.JoinHostPort(s.smtpHost, fmt.Sprintf("%d", s.smtpPort))
+func (s *EmailSMTPSender) createStartTLSClient(
+	server smtpServer,
+) (*smtp.Client, net.Conn, error) {
+	addr := network_utils.JoinHostPort(server.host, server.port)
 	dialer := &net.Dialer{Timeout: DefaultTimeout}
 
-	conn, err := dialer.Dial("tcp", addr)
+	conn, err := proxy_utils.DialContext(context.Background(), dialer, "", addr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
 	}
+	_ = conn.SetDeadline(time.Now().Add(s.pool.operationTimeout))
 
-	client, err := smtp.NewClient(conn, s.smtpHost)
+	client, err := smtp.NewClient(conn, server.host)
 	if err != nil {
 		_ = conn.Close()
 		return nil, nil, fmt.Errorf("failed to create SMTP client: %w", err)
@@ -145,54 +215,62 @@ func (s *EmailSMTPSender) createStartTLSClient() (*smtp.Client, func(), error) {
 		return nil, nil, fmt.Errorf("SMTP hello failed: %w", err)
 	}
 
+	// the TLS connection wraps conn, so deadlines set on conn still apply after STARTTLS
 	if ok, _ := client.Extension("STARTTLS"); ok {
-		if err := client.StartTLS(&tls.Config{ServerName: s.smtpHost}); err != nil {
+		if err := client.StartTLS(&tls.Config{ServerName: server.host}); err != nil {
 			_ = client.Quit()
 			_ = conn.Close()
 			return nil, nil, fmt.Errorf("STARTTLS failed: %w", err)
 		}
 	}
 
-	return client, func() { _ = client.Quit() }, nil
+	return client, conn, nil
 }
 
 func (s *EmailSMTPSender) authenticateWithRetry(
-	createClient func() (*smtp.Client, func(), error),
+	server smtpServer,
+	createClient func() (*smtp.Client, net.Conn, error),
 	isAuthRequired bool,
-) (*smtp.Client, func(), error) {
-	client, cleanup, err := createClient()
+) (*smtp.Client, net.Conn, error) {
+	client, conn, err := createClient()
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if !isAuthRequired {
-		return client, cleanup, nil
+		return client, conn, nil
 	}
 
 	// Try PLAIN auth first
-	plainAuth := smtp.PlainAuth("", s.smtpUser, s.smtpPassword, s.smtpHost)
+	plainAuth := smtp.PlainAuth("", server.user, server.password, server.host)
 	if err := client.Auth(plainAuth); err == nil {
-		return client, cleanup, nil
+		return client, conn, nil
 	}
 
 	// PLAIN auth failed, connection may be closed - recreate and try LOGIN auth
-	cleanup()
+	_ = client.Quit()
+	_ = client.Close()
 
-	client, cleanup, err = createClient()
+	client, conn, err = createClient()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	loginAuth := &loginAuth{username: s.smtpUser, password: s.smtpPassword}
+	loginAuth := &loginAuth{username: server.user, password: server.password}
 	if err := client.Auth(loginAuth); err != nil {
-		cleanup()
+		_ = client.Quit()
+		_ = client.Close()
 		return nil, nil, fmt.Errorf("SMTP authentication failed: %w", err)
 	}
 
-	return client, cleanup, nil
+	return client, conn, nil
 }
 
 func (s *EmailSMTPSender) sendEmail(client *smtp.Client, to, from string, content []byte) error {
+	if isPipelining, _ := client.Extension("PIPELINING"); isPipelining {
+		return s.sendPipelinedEmail(client, to, from, content)
+	}
+
 	if err := client.Mail(from); err != nil {
 		return fmt.Errorf("failed to set sender: %w", err)
 	}
@@ -217,6 +295,85 @@ func (s *EmailSMTPSender) sendEmail(client *smtp.Client, to, from string, conten
 	return nil
 }
 
+// sendPipelinedEmail sends MAIL, RCPT and DATA at once and reads their replies afterwards
+// (RFC 2920), so an email costs two round trips instead of four. net/smtp has no support
+// for it, so commands go through the textproto connection of the client. After a failed
+// command the session is not reused, so the transaction is not reset
+func (s *EmailSMTPSender) sendPipelinedEmail(
+	client *smtp.Client,
+	to, from string,
+	content []byte,
+) error {
+	if strings.ContainsAny(from, "\r\n") || strings.ContainsAny(to, "\r\n") {
+		return errors.New("email addresses must not contain line breaks")
+	}
+
+	mailCommand := "MAIL FROM:<" + from + ">"
+	if ok, _ := client.Extension("8BITMIME"); ok {
+		mailCommand += " BODY=8BITMIME"
+	}
+	if ok, _ := client.Extension("SMTPUTF8"); ok {
+		mailCommand += " SMTPUTF8"
+	}
+
+	mailID, err := client.Text.Cmd("%s", mailCommand)
+	if err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+
+	rcptID, err := client.Text.Cmd("RCPT TO:<%s>", to)
+	if err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+
+	dataID, err := client.Text.Cmd("DATA")
+	if err != nil {
+		return fmt.Errorf("failed to get data writer: %w", err)
+	}
+
+	// every reply is read, so a rejected sender does not leave replies of RCPT and DATA
+	// unread on the connection
+	mailErr := readPipelinedReply(client, mailID, 250)
+	rcptErr := readPipelinedReply(client, rcptID, 25)
+	dataErr := readPipelinedReply(client, dataID, 354)
+
+	if mailErr != nil {
+		return fmt.Errorf("failed to set sender: %w", mailErr)
+	}
+
+	if rcptErr != nil {
+		return fmt.Errorf("failed to set recipient: %w", rcptErr)
+	}
+
+	if dataErr != nil {
+		return fmt.Errorf("failed to get data writer: %w", dataErr)
+	}
+
+	writer := client.Text.DotWriter()
+	if _, err = writer.Write(content); err != nil {
+		return fmt.Errorf("failed to write email content: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("failed to close data writer: %w", err)
+	}
+
+	if _, _, err = client.Text.ReadResponse(250); err != nil {
+		return fmt.Errorf("failed to close data writer: %w", err)
+	}
+
+	return nil
+}
+
+func readPipelinedReply(client *smtp.Client, id uint, expectedCode int) error {
+	client.Text.StartResponse(id)
+	defer client.Text.EndResponse(id)
+
+	_, _, err := client.Text.ReadResponse(expectedCode)
+
+	return err
+}
+
 func encodeRFC2047(s string) string {
 	return mime.QEncoding.Encode("UTF-8", s)
 }
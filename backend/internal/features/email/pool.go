@@ -0,0 +1,187 @@
+package email
+
+import (
+	"net"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+const (
+	MaxPooledConnections        = 4
+	PooledConnectionIdleTimeout = 30 * time.Second
+	// MaxMessagesPerConnection stays below limits of common servers, which close sessions
+	// after about 100 messages
+	MaxMessagesPerConnection = 50
+	// SMTPOperationTimeout bounds each exchange with the server: connecting with
+	// authentication, sending one email, NOOP and QUIT. Without it a server which stops
+	// answering holds a pool slot forever and blocks every next email
+	SMTPOperationTimeout = 30 * time.Second
+)
+
+type pooledConnection struct {
+	server       smtpServer
+	client       *smtp.Client
+	conn         net.Conn
+	lastUsedAt   time.Time
+	messagesSent int
+
+	operationTimeout time.Duration
+}
+
+// startOperation moves the deadline of the session, it is called before every exchange,
+// so a long batch is not cut by the deadline of its first email
+func (c *pooledConnection) startOperation() {
+	_ = c.conn.SetDeadline(time.Now().Add(c.operationTimeout))
+}
+
+func (c *pooledConnection) close() {
+	c.startOperation()
+	_ = c.client.Quit()
+	_ = c.client.Close()
+}
+
+// abort drops the connection without QUIT. After a failed command the server may still
+// wait for message data, so QUIT would not be answered until the deadline
+func (c *pooledConnection) abort() {
+	_ = c.client.Close()
+}
+
+// smtpConnectionPool keeps authenticated SMTP sessions open to send next emails through them.
+// Sessions are reused only for the server they were opened to, so reloaded settings with
+// another server or credentials close the old sessions
+type smtpConnectionPool struct {
+	mu     sync.Mutex
+	server smtpServer
+	idle   []*pooledConnection
+
+	// slots limit connections open at the same time, idle ones included
+	slots chan struct{}
+
+	operationTimeout time.Duration
+}
+
+func newSMTPConnectionPool(size int, operationTimeout time.Duration) *smtpConnectionPool {
+	return &smtpConnectionPool{
+		slots:            make(chan struct{}, size),
+		operationTimeout: operationTimeout,
+	}
+}
+
+// acquire returns an idle session to the server or opens a new one with connect. It blocks
+// while all connections of the pool are in use
+func (p *smtpConnectionPool) acquire(
+	server smtpServer,
+	connect func() (*smtp.Client, net.Conn, error),
+) (*pooledConnection, error) {
+	p.slots <- struct{}{}
+
+	if conn := p.takeIdle(server); conn != nil {
+		return conn, nil
+	}
+
+	client, netConn, err := connect()
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+
+	return &pooledConnection{
+		server:           server,
+		client:           client,
+		conn:             netConn,
+		operationTimeout: p.operationTimeout,
+	}, nil
+}
+
+// release returns the session to the pool. Sessions which failed or sent too many messages
+// are closed instead, as their state on the server is unknown
+func (p *smtpConnectionPool) release(conn *pooledConnection, sendErr error) {
+	defer func() { <-p.slots }()
+
+	if sendErr != nil {
+		conn.abort()
+		return
+	}
+
+	if conn.messagesSent >= MaxMessagesPerConnection {
+		conn.close()
+		return
+	}
+
+	conn.lastUsedAt = time.Now()
+
+	p.mu.Lock()
+	if conn.server != p.server {
+		p.mu.Unlock()
+		conn.close()
+		return
+	}
+	p.idle = append(p.idle, conn)
+	p.mu.Unlock()
+
+	time.AfterFunc(PooledConnectionIdleTimeout, p.closeExpiredIdle)
+}
+
+func (p *smtpConnectionPool) takeIdle(server smtpServer) *pooledConnection {
+	for {
+		p.mu.Lock()
+
+		if p.server != server {
+			staleConnections := p.idle
+			p.idle = nil
+			p.server = server
+			p.mu.Unlock()
+
+			for _, conn := range staleConnections {
+				conn.close()
+			}
+
+			return nil
+		}
+
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			return nil
+		}
+
+		conn := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		if time.Since(conn.lastUsedAt) > PooledConnectionIdleTimeout {
+			conn.close()
+			continue
+		}
+
+		// Servers drop idle sessions on their own, NOOP finds it out before a message is sent
+		conn.startOperation()
+		if err := conn.client.Noop(); err != nil {
+			conn.abort()
+			continue
+		}
+
+		return conn
+	}
+}
+
+func (p *smtpConnectionPool) closeExpiredIdle() {
+	p.mu.Lock()
+
+	activeConnections := make([]*pooledConnection, 0, len(p.idle))
+	expiredConnections := []*pooledConnection{}
+	for _, conn := range p.idle {
+		if time.Since(conn.lastUsedAt) >= PooledConnectionIdleTimeout {
+			expiredConnections = append(expiredConnections, conn)
+		} else {
+			activeConnections = append(activeConnections, conn)
+		}
+	}
+	p.idle = activeConnections
+
+	p.mu.Unlock()
+
+	for _, conn := range expiredConnections {
+		conn.close()
+	}
+}
@@ -0,0 +1,199 @@
+package email
+
+import (
+	"bufio"
+	"log/slog"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SMTPConnectionPool_SessionReusedForNextEmails(t *testing.T) {
+	testServer := startTestSMTPServer(t, false)
+	sender := newTestSMTPSender(SMTPOperationTimeout)
+
+	for range 3 {
+		sendTestEmail(t, sender, testServer.server)
+	}
+
+	assert.Equal(t, int32(1), testServer.connectionsCount.Load())
+	assert.Equal(t, int32(3), testServer.emailsCount.Load())
+}
+
+func Test_SMTPConnectionPool_WhenServerChanged_NewSessionOpened(t *testing.T) {
+	testServer := startTestSMTPServer(t, false)
+	sender := newTestSMTPSender(SMTPOperationTimeout)
+
+	otherServer := testServer.server
+	otherServer.user = "other"
+
+	sendTestEmail(t, sender, testServer.server)
+	sendTestEmail(t, sender, otherServer)
+
+	assert.Equal(t, int32(2), testServer.connectionsCount.Load())
+}
+
+func Test_SendEmails_WhenServerSupportsPipelining_CommandsSentWithoutWaitingForReplies(
+	t *testing.T,
+) {
+	// the server answers MAIL and RCPT only after DATA, so waiting for each reply times out
+	testServer := startTestSMTPServer(t, true)
+	sender := newTestSMTPSender(time.Second)
+
+	results := sender.sendEmailsToServer(testServer.server, []Email{
+		{To: "first@example.com", Subject: "Subject", Body: "Body"},
+		{To: "rejected@example.com", Subject: "Subject", Body: "Body"},
+		{To: "second@example.com", Subject: "Subject", Body: "Body"},
+	})
+
+	assert.NoError(t, results[0])
+	assert.ErrorContains(t, results[1], "failed to set recipient")
+	assert.NoError(t, results[2])
+
+	// the session with the rejected recipient is closed, the last email opens a new one
+	assert.Equal(t, int32(2), testServer.connectionsCount.Load())
+	assert.Equal(t, int32(2), testServer.emailsCount.Load())
+}
+
+func Test_SendEmails_WhenServerStopsResponding_FailsAfterOperationTimeout(t *testing.T) {
+	testServer := startTestSMTPServer(t, false)
+	testServer.isStalledOnData.Store(true)
+	sender := newTestSMTPSender(200 * time.Millisecond)
+
+	startedAt := time.Now()
+	results := sender.sendEmailsToServer(testServer.server, []Email{
+		{To: "admin@example.com", Subject: "Subject", Body: "Body"},
+	})
+
+	assert.ErrorContains(t, results[0], "i/o timeout")
+	assert.Less(t, time.Since(startedAt), 2*time.Second)
+
+	// the stalled session is not returned to the pool, so it cannot block the next email
+	testServer.isStalledOnData.Store(false)
+	results = sender.sendEmailsToServer(testServer.server, []Email{
+		{To: "admin@example.com", Subject: "Subject", Body: "Body"},
+	})
+
+	assert.NoError(t, results[0])
+	assert.Equal(t, int32(2), testServer.connectionsCount.Load())
+}
+
+func newTestSMTPSender(operationTimeout time.Duration) *EmailSMTPSender {
+	return &EmailSMTPSender{
+		slog.Default(),
+		newSMTPConnectionPool(MaxPooledConnections, operationTimeout),
+	}
+}
+
+func sendTestEmail(t *testing.T, sender *EmailSMTPSender, server smtpServer) {
+	results := sender.sendEmailsToServer(server, []Email{
+		{To: "admin@example.com", Subject: "Subject", Body: "Body"},
+	})
+
+	assert.NoError(t, results[0])
+}
+
+type testSMTPServer struct {
+	server smtpServer
+
+	isPipeliningSupported bool
+	isStalledOnData       atomic.Bool
+
+	connectionsCount atomic.Int32
+	emailsCount      atomic.Int32
+}
+
+// startTestSMTPServer accepts emails without authentication and counts opened connections
+// and received emails. Recipients starting with "rejected" are refused. With pipelining,
+// replies to MAIL and RCPT are held back until DATA, as RFC 2920 allows
+func startTestSMTPServer(t *testing.T, isPipeliningSupported bool) *testSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	testServer := &testSMTPServer{
+		server: smtpServer{
+			host: "127.0.0.1",
+			port: listener.Addr().(*net.TCPAddr).Port,
+		},
+		isPipeliningSupported: isPipeliningSupported,
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			testServer.connectionsCount.Add(1)
+
+			go testServer.serveSession(conn)
+		}
+	}()
+
+	return testServer
+}
+
+func (s *testSMTPServer) serveSession(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	_, _ = conn.Write([]byte("220 localhost ESMTP\r\n"))
+
+	reader := bufio.NewReader(conn)
+	isReadingData := false
+	pendingReplies := ""
+
+	reply := func(text string, isPipelined bool) {
+		if isPipelined && s.isPipeliningSupported {
+			pendingReplies += text
+			return
+		}
+
+		_, _ = conn.Write([]byte(pendingReplies + text))
+		pendingReplies = ""
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		if isReadingData {
+			if line == ".\r\n" {
+				isReadingData = false
+				s.emailsCount.Add(1)
+				_, _ = conn.Write([]byte("250 queued\r\n"))
+			}
+			continue
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO") && s.isPipeliningSupported:
+			_, _ = conn.Write([]byte("250-localhost\r\n250 PIPELINING\r\n"))
+		case strings.HasPrefix(command, "MAIL"):
+			reply("250 ok\r\n", true)
+		case strings.HasPrefix(command, "RCPT TO:<REJECTED"):
+			reply("550 no such user\r\n", true)
+		case strings.HasPrefix(command, "RCPT"):
+			reply("250 ok\r\n", true)
+		case strings.HasPrefix(command, "DATA"):
+			if s.isStalledOnData.Load() {
+				continue
+			}
+
+			isReadingData = true
+			reply("354 go ahead\r\n", false)
+		case strings.HasPrefix(command, "QUIT"):
+			_, _ = conn.Write([]byte("221 bye\r\n"))
+			return
+		default:
+			_, _ = conn.Write([]byte("250 ok\r\n"))
+		}
+	}
+}
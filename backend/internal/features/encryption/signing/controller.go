@@ -0,0 +1,42 @@
+package signing
+
+import (
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SigningController struct {
+	signingService *SigningService
+}
+
+func (c *SigningController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/system/signing-key", c.GetPublicKey)
+}
+
+// GetPublicKey
+// @Summary Get signing public key
+// @Description Get the public key of the instance verifying signatures of backups and manifests
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} PublicKeyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /system/signing-key [get]
+func (c *SigningController) GetPublicKey(ctx *gin.Context) {
+	if _, ok := users_middleware.GetUserFromContext(ctx); !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.signingService.GetPublicKey()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
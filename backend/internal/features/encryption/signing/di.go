@@ -0,0 +1,18 @@
+package signing
+
+import "databasus-backend/internal/features/encryption/secrets"
+
+var signingService = &SigningService{
+	secretKeyService: secrets.GetSecretKeyService(),
+}
+var signingController = &SigningController{
+	signingService,
+}
+
+func GetSigningService() *SigningService {
+	return signingService
+}
+
+func GetSigningController() *SigningController {
+	return signingController
+}
@@ -0,0 +1,9 @@
+package signing
+
+// PublicKeyResponse is the key to verify signatures of backups and manifests outside of
+// Databasus
+type PublicKeyResponse struct {
+	Algorithm    string `json:"algorithm"`
+	PublicKey    string `json:"publicKey"`
+	PublicKeyPEM string `json:"publicKeyPem"`
+}
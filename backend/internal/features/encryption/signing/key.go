@@ -0,0 +1,74 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	// Algorithm of signatures made by signing keys
+	Algorithm = "Ed25519"
+	// signingKeyContext keeps the signing key apart from other keys derived from the
+	// secret key
+	signingKeyContext = "databasus-signing-key"
+)
+
+// SigningKey is the Ed25519 key pair of the instance. It is derived from the secret key,
+// so every node of the instance signs with the same key and nothing else is stored
+type SigningKey struct {
+	privateKey ed25519.PrivateKey
+}
+
+func DeriveSigningKey(secretKey string) (*SigningKey, error) {
+	if secretKey == "" {
+		return nil, errors.New("secret key is required to derive the signing key")
+	}
+
+	seed := hmac.New(sha256.New, []byte(secretKey))
+	seed.Write([]byte(signingKeyContext))
+
+	return &SigningKey{privateKey: ed25519.NewKeyFromSeed(seed.Sum(nil))}, nil
+}
+
+// Sign returns the base64 signature of the message
+func (k *SigningKey) Sign(message []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(k.privateKey, message))
+}
+
+func (k *SigningKey) Verify(message []byte, signature string) error {
+	return VerifySignature(k.GetPublicKey(), message, signature)
+}
+
+func (k *SigningKey) GetPublicKey() ed25519.PublicKey {
+	return k.privateKey.Public().(ed25519.PublicKey)
+}
+
+// GetPublicKeyPEM encodes the public key as PKIX, the format openssl reads
+func (k *SigningKey) GetPublicKeyPEM() (string, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(k.GetPublicKey())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})), nil
+}
+
+// VerifySignature checks a base64 signature made by the private key of publicKey
+func VerifySignature(publicKey ed25519.PublicKey, message []byte, signature string) error {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.New("signature is not valid base64")
+	}
+
+	if !ed25519.Verify(publicKey, message, signatureBytes) {
+		return errors.New("signature does not match")
+	}
+
+	return nil
+}
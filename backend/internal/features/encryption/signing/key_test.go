@@ -0,0 +1,34 @@
+package signing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DeriveSigningKey_SameSecretKey_SignaturesVerifiedOnlyByItsPublicKey(t *testing.T) {
+	signingKey, err := DeriveSigningKey("secret-key")
+	assert.NoError(t, err)
+
+	sameSigningKey, err := DeriveSigningKey("secret-key")
+	assert.NoError(t, err)
+	assert.Equal(t, signingKey.GetPublicKey(), sameSigningKey.GetPublicKey())
+
+	otherSigningKey, err := DeriveSigningKey("other-secret-key")
+	assert.NoError(t, err)
+
+	message := []byte("backup content")
+	signature := signingKey.Sign(message)
+
+	assert.NoError(t, VerifySignature(sameSigningKey.GetPublicKey(), message, signature))
+	assert.Error(t, VerifySignature(otherSigningKey.GetPublicKey(), message, signature))
+	assert.Error(t, signingKey.Verify([]byte("changed content"), signature))
+	assert.Error(t, signingKey.Verify(message, "not base64"))
+
+	publicKeyPEM, err := signingKey.GetPublicKeyPEM()
+	assert.NoError(t, err)
+	assert.Contains(t, publicKeyPEM, "BEGIN PUBLIC KEY")
+
+	_, err = DeriveSigningKey("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package signing
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	"databasus-backend/internal/features/encryption/secrets"
+)
+
+type SigningService struct {
+	secretKeyService *secrets.SecretKeyService
+
+	mutex     sync.Mutex
+	cachedKey *SigningKey
+}
+
+func (s *SigningService) GetSigningKey() (*SigningKey, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.cachedKey != nil {
+		return s.cachedKey, nil
+	}
+
+	secretKey, err := s.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	signingKey, err := DeriveSigningKey(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cachedKey = signingKey
+
+	return signingKey, nil
+}
+
+func (s *SigningService) Sign(message []byte) (string, error) {
+	signingKey, err := s.GetSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	return signingKey.Sign(message), nil
+}
+
+func (s *SigningService) Verify(message []byte, signature string) error {
+	signingKey, err := s.GetSigningKey()
+	if err != nil {
+		return err
+	}
+
+	return signingKey.Verify(message, signature)
+}
+
+func (s *SigningService) GetPublicKey() (*PublicKeyResponse, error) {
+	signingKey, err := s.GetSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyPEM, err := signingKey.GetPublicKeyPEM()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKeyResponse{
+		Algorithm:    Algorithm,
+		PublicKey:    base64.StdEncoding.EncodeToString(signingKey.GetPublicKey()),
+		PublicKeyPEM: publicKeyPEM,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package environments
+
+import "fmt"
+
+// Environment labels databases and workspaces. A database without a label takes the label
+// of its workspace, restores into PROD databases and deletion of their storages are guarded
+type Environment string
+
+const (
+	EnvironmentNone    Environment = ""
+	EnvironmentProd    Environment = "PROD"
+	EnvironmentStaging Environment = "STAGING"
+	EnvironmentDev     Environment = "DEV"
+)
+
+func (e Environment) Validate() error {
+	switch e {
+	case EnvironmentNone, EnvironmentProd, EnvironmentStaging, EnvironmentDev:
+		return nil
+	default:
+		return fmt.Errorf("invalid environment: %s", e)
+	}
+}
+
+// Resolve returns the label of the database, or the label of its workspace when the
+// database has none
+func Resolve(databaseEnvironment Environment, workspaceEnvironment Environment) Environment {
+	if databaseEnvironment != EnvironmentNone {
+		return databaseEnvironment
+	}
+
+	return workspaceEnvironment
+}
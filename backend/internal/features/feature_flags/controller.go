@@ -0,0 +1,161 @@
+package feature_flags
+
+import (
+	"errors"
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FeatureFlagController struct {
+	featureFlagService *FeatureFlagService
+}
+
+func (c *FeatureFlagController) RegisterRoutes(router *gin.RouterGroup) {
+	adminOnly := users_middleware.RequireRole(users_enums.UserRoleAdmin)
+
+	router.GET("/features", c.GetFeatures)
+	router.PUT("/features/workspaces/:workspaceId", adminOnly, c.SetWorkspaceOverride)
+	router.DELETE(
+		"/features/workspaces/:workspaceId/:flag",
+		adminOnly,
+		c.DeleteWorkspaceOverride,
+	)
+}
+
+// GetFeatures
+// @Summary Get feature flags
+// @Description Get state of experimental features, optionally including overrides of a workspace
+// @Tags features
+// @Produce json
+// @Security BearerAuth
+// @Param workspaceId query string false "Workspace ID"
+// @Success 200 {object} GetFeaturesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /features [get]
+func (c *FeatureFlagController) GetFeatures(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var workspaceID *uuid.UUID
+	if workspaceIDStr := ctx.Query("workspaceId"); workspaceIDStr != "" {
+		parsedID, err := uuid.Parse(workspaceIDStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+			return
+		}
+
+		workspaceID = &parsedID
+	}
+
+	response, err := c.featureFlagService.GetFeatures(user, workspaceID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// SetWorkspaceOverride
+// @Summary Override feature flag for workspace
+// @Description Enable or disable feature for a single workspace regardless of instance configuration (admin only)
+// @Tags features
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body SetWorkspaceOverrideRequest true "Feature flag override"
+// @Success 200 {object} WorkspaceFeatureFlag
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /features/workspaces/{workspaceId} [put]
+func (c *FeatureFlagController) SetWorkspaceOverride(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request SetWorkspaceOverrideRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	override, err := c.featureFlagService.SetWorkspaceOverride(user, workspaceID, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, override)
+}
+
+// DeleteWorkspaceOverride
+// @Summary Remove feature flag override
+// @Description Remove workspace override so instance configuration is used again (admin only)
+// @Tags features
+// @Produce json
+// @Security BearerAuth
+// @Param workspaceId path string true "Workspace ID"
+// @Param flag path string true "Feature flag"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /features/workspaces/{workspaceId}/{flag} [delete]
+func (c *FeatureFlagController) DeleteWorkspaceOverride(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	err = c.featureFlagService.DeleteWorkspaceOverride(
+		user,
+		workspaceID,
+		FeatureFlag(ctx.Param("flag")),
+	)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Feature flag override removed"})
+}
+
+func (c *FeatureFlagController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInsufficientPermissionsToViewFeatures),
+		errors.Is(err, ErrOnlyAdminsCanManageFeatureFlags):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrFeatureFlagOverrideNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,113 @@
+package feature_flags
+
+import (
+	"net/http"
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetFeatures_WhenWorkspaceOverrideIsSet_FeatureIsEnabledOnlyForWorkspace(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetFeatureFlagController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Flags test", admin.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	otherWorkspace, err := workspaces_testing.CreateTestWorkspaceDirect("Flags test", admin.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(otherWorkspace.ID)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/features/workspaces/"+workspace.ID.String(),
+		"Bearer "+admin.Token,
+		SetWorkspaceOverrideRequest{Flag: FeatureFlagPitrBeta, IsEnabled: true},
+		http.StatusOK,
+	)
+
+	enabledFeature := getFeature(t, admin.Token, workspace.ID.String(), FeatureFlagPitrBeta)
+	assert.True(t, enabledFeature.IsEnabled)
+	assert.True(t, enabledFeature.IsOverridden)
+	assert.True(t, GetFeatureFlagService().IsFeatureEnabled(FeatureFlagPitrBeta, workspace.ID))
+
+	otherFeature := getFeature(t, admin.Token, otherWorkspace.ID.String(), FeatureFlagPitrBeta)
+	assert.False(t, otherFeature.IsOverridden)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/features/workspaces/"+workspace.ID.String()+"/"+string(FeatureFlagPitrBeta),
+		"Bearer "+admin.Token,
+		http.StatusOK,
+	)
+
+	resetFeature := getFeature(t, admin.Token, workspace.ID.String(), FeatureFlagPitrBeta)
+	assert.False(t, resetFeature.IsOverridden)
+}
+
+func Test_SetWorkspaceOverride_WhenUserIsNotAdmin_ReturnsForbidden(t *testing.T) {
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetFeatureFlagController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Flags test", member.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/features/workspaces/"+workspace.ID.String(),
+		"Bearer "+member.Token,
+		SetWorkspaceOverrideRequest{Flag: FeatureFlagPitrBeta, IsEnabled: true},
+		http.StatusForbidden,
+	)
+}
+
+func Test_GetFeatures_WhenUserIsNotWorkspaceMember_ReturnsForbidden(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	stranger := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetFeatureFlagController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Flags test", owner.UserID)
+	assert.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/features?workspaceId="+workspace.ID.String(),
+		"Bearer "+stranger.Token,
+		http.StatusForbidden,
+	)
+}
+
+func getFeature(t *testing.T, token, workspaceID string, flag FeatureFlag) FeatureFlagDTO {
+	router := workspaces_testing.CreateTestRouter(GetFeatureFlagController())
+
+	var response GetFeaturesResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/features?workspaceId="+workspaceID,
+		"Bearer "+token,
+		http.StatusOK,
+		&response,
+	)
+
+	for _, feature := range response.Features {
+		if feature.Flag == flag {
+			return feature
+		}
+	}
+
+	t.Fatalf("feature %s not found", flag)
+	return FeatureFlagDTO{}
+}
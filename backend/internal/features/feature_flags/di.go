@@ -0,0 +1,26 @@
+package feature_flags
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var featureFlagRepository = &WorkspaceFeatureFlagRepository{}
+var featureFlagService = &FeatureFlagService{
+	featureFlagRepository,
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var featureFlagController = &FeatureFlagController{
+	featureFlagService,
+}
+
+func GetFeatureFlagService() *FeatureFlagService {
+	return featureFlagService
+}
+
+func GetFeatureFlagController() *FeatureFlagController {
+	return featureFlagController
+}
@@ -0,0 +1,18 @@
+package feature_flags
+
+type FeatureFlagDTO struct {
+	Flag      FeatureFlag `json:"flag"`
+	IsEnabled bool        `json:"isEnabled"`
+	// IsOverridden is true when value comes from workspace override
+	// instead of instance configuration
+	IsOverridden bool `json:"isOverridden"`
+}
+
+type GetFeaturesResponse struct {
+	Features []FeatureFlagDTO `json:"features"`
+}
+
+type SetWorkspaceOverrideRequest struct {
+	Flag      FeatureFlag `json:"flag"      binding:"required"`
+	IsEnabled bool        `json:"isEnabled"`
+}
@@ -0,0 +1,19 @@
+package feature_flags
+
+import "slices"
+
+type FeatureFlag string
+
+const (
+	FeatureFlagPitrBeta FeatureFlag = "pitr_beta"
+)
+
+func GetKnownFeatureFlags() []FeatureFlag {
+	return []FeatureFlag{
+		FeatureFlagPitrBeta,
+	}
+}
+
+func IsKnownFeatureFlag(flag FeatureFlag) bool {
+	return slices.Contains(GetKnownFeatureFlags(), flag)
+}
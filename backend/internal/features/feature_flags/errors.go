@@ -0,0 +1,18 @@
+package feature_flags
+
+import "errors"
+
+var (
+	ErrUnknownFeatureFlag = errors.New(
+		"unknown feature flag",
+	)
+	ErrInsufficientPermissionsToViewFeatures = errors.New(
+		"insufficient permissions to view workspace features",
+	)
+	ErrOnlyAdminsCanManageFeatureFlags = errors.New(
+		"only administrators can manage feature flags",
+	)
+	ErrFeatureFlagOverrideNotFound = errors.New(
+		"feature flag override not found",
+	)
+)
@@ -0,0 +1,19 @@
+package feature_flags
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkspaceFeatureFlag struct {
+	ID          uuid.UUID   `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID   `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Flag        FeatureFlag `json:"flag"        gorm:"column:flag;type:text;not null"`
+	IsEnabled   bool        `json:"isEnabled"   gorm:"column:is_enabled;not null"`
+	UpdatedAt   time.Time   `json:"updatedAt"   gorm:"column:updated_at;not null"`
+}
+
+func (WorkspaceFeatureFlag) TableName() string {
+	return "workspace_feature_flags"
+}
@@ -0,0 +1,62 @@
+package feature_flags
+
+import (
+	"errors"
+	"time"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceFeatureFlagRepository struct{}
+
+func (r *WorkspaceFeatureFlagRepository) Upsert(override *WorkspaceFeatureFlag) error {
+	if override.ID == uuid.Nil {
+		override.ID = uuid.New()
+	}
+
+	override.UpdatedAt = time.Now().UTC()
+
+	return storage.GetDb().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "flag"}},
+		DoUpdates: clause.AssignmentColumns([]string{"is_enabled", "updated_at"}),
+	}).Create(override).Error
+}
+
+func (r *WorkspaceFeatureFlagRepository) FindByWorkspaceID(
+	workspaceID uuid.UUID,
+) ([]*WorkspaceFeatureFlag, error) {
+	var overrides []*WorkspaceFeatureFlag
+
+	err := storage.GetDb().Where("workspace_id = ?", workspaceID).Find(&overrides).Error
+
+	return overrides, err
+}
+
+func (r *WorkspaceFeatureFlagRepository) FindByWorkspaceIDAndFlag(
+	workspaceID uuid.UUID,
+	flag FeatureFlag,
+) (*WorkspaceFeatureFlag, error) {
+	var override WorkspaceFeatureFlag
+
+	err := storage.GetDb().
+		Where("workspace_id = ? AND flag = ?", workspaceID, flag).
+		First(&override).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *WorkspaceFeatureFlagRepository) Delete(id uuid.UUID) error {
+	return storage.GetDb().Delete(&WorkspaceFeatureFlag{}, "id = ?", id).Error
+}
@@ -0,0 +1,173 @@
+package feature_flags
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+type FeatureFlagService struct {
+	featureFlagRepository *WorkspaceFeatureFlagRepository
+	workspaceService      *workspaces_services.WorkspaceService
+	auditLogService       *audit_logs.AuditLogService
+	logger                *slog.Logger
+}
+
+// GetFeatures returns state of all known flags. Without workspace only
+// instance configuration is taken into account
+func (s *FeatureFlagService) GetFeatures(
+	user *users_models.User,
+	workspaceID *uuid.UUID,
+) (*GetFeaturesResponse, error) {
+	overrides := map[FeatureFlag]bool{}
+
+	if workspaceID != nil {
+		canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*workspaceID, user)
+		if err != nil {
+			return nil, err
+		}
+		if !canAccess {
+			return nil, ErrInsufficientPermissionsToViewFeatures
+		}
+
+		overrides, err = s.getWorkspaceOverrides(*workspaceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	features := make([]FeatureFlagDTO, 0, len(GetKnownFeatureFlags()))
+	for _, flag := range GetKnownFeatureFlags() {
+		isEnabled, isOverridden := overrides[flag]
+		if !isOverridden {
+			isEnabled = s.isEnabledByConfig(flag)
+		}
+
+		features = append(features, FeatureFlagDTO{
+			Flag:         flag,
+			IsEnabled:    isEnabled,
+			IsOverridden: isOverridden,
+		})
+	}
+
+	return &GetFeaturesResponse{Features: features}, nil
+}
+
+// IsFeatureEnabled is used by backend code to gate experimental logic.
+// Lookup errors are treated as disabled flag
+func (s *FeatureFlagService) IsFeatureEnabled(flag FeatureFlag, workspaceID uuid.UUID) bool {
+	override, err := s.featureFlagRepository.FindByWorkspaceIDAndFlag(workspaceID, flag)
+	if err != nil {
+		s.logger.Error(
+			"failed to get feature flag override",
+			"flag", flag,
+			"workspaceId", workspaceID,
+			"error", err,
+		)
+		return false
+	}
+
+	if override != nil {
+		return override.IsEnabled
+	}
+
+	return s.isEnabledByConfig(flag)
+}
+
+func (s *FeatureFlagService) SetWorkspaceOverride(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	request *SetWorkspaceOverrideRequest,
+) (*WorkspaceFeatureFlag, error) {
+	if !user.CanUpdateSettings() {
+		return nil, ErrOnlyAdminsCanManageFeatureFlags
+	}
+
+	if !IsKnownFeatureFlag(request.Flag) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFeatureFlag, request.Flag)
+	}
+
+	override := &WorkspaceFeatureFlag{
+		WorkspaceID: workspaceID,
+		Flag:        request.Flag,
+		IsEnabled:   request.IsEnabled,
+	}
+
+	if err := s.featureFlagRepository.Upsert(override); err != nil {
+		return nil, fmt.Errorf("failed to save feature flag override: %w", err)
+	}
+
+	state := "disabled"
+	if request.IsEnabled {
+		state = "enabled"
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Feature flag %s %s for workspace", request.Flag, state),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return s.featureFlagRepository.FindByWorkspaceIDAndFlag(workspaceID, request.Flag)
+}
+
+func (s *FeatureFlagService) DeleteWorkspaceOverride(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	flag FeatureFlag,
+) error {
+	if !user.CanUpdateSettings() {
+		return ErrOnlyAdminsCanManageFeatureFlags
+	}
+
+	override, err := s.featureFlagRepository.FindByWorkspaceIDAndFlag(workspaceID, flag)
+	if err != nil {
+		return fmt.Errorf("failed to get feature flag override: %w", err)
+	}
+
+	if override == nil {
+		return ErrFeatureFlagOverrideNotFound
+	}
+
+	if err := s.featureFlagRepository.Delete(override.ID); err != nil {
+		return fmt.Errorf("failed to delete feature flag override: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Feature flag %s override removed for workspace", flag),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+func (s *FeatureFlagService) getWorkspaceOverrides(
+	workspaceID uuid.UUID,
+) (map[FeatureFlag]bool, error) {
+	overrides, err := s.featureFlagRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flag overrides: %w", err)
+	}
+
+	result := make(map[FeatureFlag]bool, len(overrides))
+	for _, override := range overrides {
+		result[override.Flag] = override.IsEnabled
+	}
+
+	return result, nil
+}
+
+func (s *FeatureFlagService) isEnabledByConfig(flag FeatureFlag) bool {
+	return slices.ContainsFunc(config.GetEnv().EnabledFeatureFlags, func(value string) bool {
+		return strings.TrimSpace(value) == string(flag)
+	})
+}
@@ -0,0 +1,47 @@
+package graphql
+
+import (
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLController struct {
+	graphqlService *GraphQLService
+}
+
+func (c *GraphQLController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/graphql", c.Query)
+}
+
+// Query
+// @Summary Run GraphQL query
+// @Description Read-only GraphQL facade over workspaces, databases, backups, storages and
+// @Description audit logs. Fields the user cannot access are returned as null with an error,
+// @Description following GraphQL partial results
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body GraphQLRequest true "GraphQL query"
+// @Success 200 {object} GraphQLResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /graphql [post]
+func (c *GraphQLController) Query(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request GraphQLRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.graphqlService.Execute(user, &request))
+}
@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"net/http"
+	"testing"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Query_WhenUserIsWorkspaceMember_ReturnsNestedData(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetGraphQLController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("GraphQL test", owner.UserID)
+	require.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	var response GraphQLResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/graphql",
+		"Bearer "+owner.Token,
+		GraphQLRequest{
+			Query: `query Workspace($id: ID!) {
+				workspace(id: $id) {
+					name
+					databases { id name backups(limit: 5) { id } }
+					storages { id name }
+				}
+			}`,
+			Variables: map[string]any{"id": workspace.ID.String()},
+		},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Empty(t, response.Errors)
+
+	workspaceData, ok := response.Data["workspace"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "GraphQL test", workspaceData["name"])
+	assert.Equal(t, []any{map[string]any{
+		"id":   storage.ID.String(),
+		"name": storage.Name,
+	}}, workspaceData["storages"])
+
+	databasesData, ok := workspaceData["databases"].([]any)
+	require.True(t, ok)
+	require.Len(t, databasesData, 1)
+
+	databaseData := databasesData[0].(map[string]any)
+	assert.Equal(t, database.ID.String(), databaseData["id"])
+	assert.Equal(t, database.Name, databaseData["name"])
+	assert.Empty(t, databaseData["backups"])
+}
+
+func Test_Query_WhenUserIsNotWorkspaceMember_ReturnsErrorForForbiddenFieldOnly(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	stranger := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetGraphQLController())
+
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("GraphQL test", owner.UserID)
+	require.NoError(t, err)
+	defer workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+
+	var response GraphQLResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/graphql",
+		"Bearer "+stranger.Token,
+		GraphQLRequest{
+			Query: `{
+				workspaces { id }
+				workspace(id: "` + workspace.ID.String() + `") { name }
+				auditLogs { id }
+			}`,
+		},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, []any{}, response.Data["workspaces"])
+	assert.Nil(t, response.Data["workspace"])
+	assert.Nil(t, response.Data["auditLogs"])
+
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, []any{"workspace"}, response.Errors[0].Path)
+	assert.Equal(t, []any{"auditLogs"}, response.Errors[1].Path)
+}
+
+func Test_Query_WhenQueryIsInvalid_ReturnsErrorWithoutData(t *testing.T) {
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetGraphQLController())
+
+	var response GraphQLResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/graphql",
+		"Bearer "+user.Token,
+		GraphQLRequest{Query: `mutation { deleteWorkspace { id } }`},
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Nil(t, response.Data)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "mutation operations are not supported", response.Errors[0].Message)
+}
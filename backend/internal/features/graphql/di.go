@@ -0,0 +1,25 @@
+package graphql
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var graphqlService = &GraphQLService{
+	workspaceService:  workspaces_services.GetWorkspaceService(),
+	membershipService: workspaces_services.GetMembershipService(),
+	databaseService:   databases.GetDatabaseService(),
+	backupService:     backups.GetBackupService(),
+	storageService:    storages.GetStorageService(),
+	auditLogService:   audit_logs.GetAuditLogService(),
+}
+var graphqlController = &GraphQLController{
+	graphqlService,
+}
+
+func GetGraphQLController() *GraphQLController {
+	return graphqlController
+}
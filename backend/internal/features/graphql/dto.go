@@ -0,0 +1,17 @@
+package graphql
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"         binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type GraphQLResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
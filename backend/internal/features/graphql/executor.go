@@ -0,0 +1,347 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	users_models "databasus-backend/internal/features/users/models"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+type requestContext struct {
+	User      *users_models.User
+	Variables map[string]any
+	Errors    []GraphQLError
+
+	schema    *executableSchema
+	fragments ast.FragmentDefinitionList
+	loaded    map[string]loadResult
+}
+
+type loadResult struct {
+	value any
+	err   error
+}
+
+func (rc *requestContext) load(key string, fn func() (any, error)) (any, error) {
+	if result, isLoaded := rc.loaded[key]; isLoaded {
+		return result.value, result.err
+	}
+
+	loadedValue, err := fn()
+	rc.loaded[key] = loadResult{loadedValue, err}
+
+	return loadedValue, err
+}
+
+func (rc *requestContext) addError(err error, path []any) {
+	rc.Errors = append(rc.Errors, GraphQLError{
+		Message: err.Error(),
+		Path:    append([]any{}, path...),
+	})
+}
+
+// execute parses and validates the query against the schema first, so resolvers are only
+// called for queries that are known to be valid and within the size limits
+func execute(
+	schema *executableSchema,
+	user *users_models.User,
+	request *GraphQLRequest,
+) *GraphQLResponse {
+	document, queryErrors := gqlparser.LoadQueryWithRules(
+		schema.schema,
+		request.Query,
+		schema.rules,
+	)
+	if len(queryErrors) > 0 {
+		return &GraphQLResponse{Errors: toGraphQLErrors(queryErrors)}
+	}
+
+	operation := document.Operations.ForName(request.OperationName)
+	if operation == nil {
+		if request.OperationName == "" {
+			return newErrorResponse("operationName is required when query has several operations")
+		}
+
+		return newErrorResponse(fmt.Sprintf("operation %s is not found", request.OperationName))
+	}
+
+	if operation.Operation != ast.Query {
+		return newErrorResponse(fmt.Sprintf("%s operations are not supported", operation.Operation))
+	}
+
+	variables, err := validator.VariableValues(schema.schema, operation, request.Variables)
+	if err != nil {
+		var gqlErr *gqlerror.Error
+		if errors.As(err, &gqlErr) {
+			return &GraphQLResponse{Errors: toGraphQLErrors(gqlerror.List{gqlErr})}
+		}
+
+		return newErrorResponse(err.Error())
+	}
+
+	rc := &requestContext{
+		User:      user,
+		Variables: variables,
+		schema:    schema,
+		fragments: document.Fragments,
+		loaded:    map[string]loadResult{},
+	}
+	data := rc.executeObject(schema.schema.Query, map[string]any{}, operation.SelectionSet, nil)
+
+	return &GraphQLResponse{Data: data, Errors: rc.Errors}
+}
+
+func (rc *requestContext) executeObject(
+	definition *ast.Definition,
+	source any,
+	selections ast.SelectionSet,
+	path []any,
+) map[string]any {
+	keys, fieldsByKey := rc.collectFields(definition, selections)
+	result := make(map[string]any, len(keys))
+
+	for _, key := range keys {
+		fields := fieldsByKey[key]
+		fieldPath := appendPath(path, key)
+
+		fieldValue, err := rc.resolveField(definition, source, fields[0])
+		if err != nil {
+			rc.addError(err, fieldPath)
+			result[key] = nil
+			continue
+		}
+
+		result[key] = rc.completeValue(
+			fields[0].Definition.Type,
+			fieldValue,
+			mergeSelections(fields),
+			fieldPath,
+		)
+	}
+
+	return result
+}
+
+// collectFields flattens fragments and groups fields by response key, so the same field
+// selected in several fragments is resolved once
+func (rc *requestContext) collectFields(
+	definition *ast.Definition,
+	selections ast.SelectionSet,
+) ([]string, map[string][]*ast.Field) {
+	keys := []string{}
+	fieldsByKey := map[string][]*ast.Field{}
+	visitedFragments := map[string]bool{}
+
+	var collect func(selections ast.SelectionSet)
+	collect = func(selections ast.SelectionSet) {
+		for _, selection := range selections {
+			switch typedSelection := selection.(type) {
+			case *ast.Field:
+				if !rc.isIncluded(typedSelection.Directives) {
+					continue
+				}
+
+				key := typedSelection.Alias
+				if key == "" {
+					key = typedSelection.Name
+				}
+
+				if _, isCollected := fieldsByKey[key]; !isCollected {
+					keys = append(keys, key)
+				}
+
+				fieldsByKey[key] = append(fieldsByKey[key], typedSelection)
+			case *ast.InlineFragment:
+				if !rc.isIncluded(typedSelection.Directives) ||
+					!isTypeConditionMet(definition, typedSelection.TypeCondition) {
+					continue
+				}
+
+				collect(typedSelection.SelectionSet)
+			case *ast.FragmentSpread:
+				if !rc.isIncluded(typedSelection.Directives) ||
+					visitedFragments[typedSelection.Name] {
+					continue
+				}
+
+				visitedFragments[typedSelection.Name] = true
+
+				fragment := rc.fragments.ForName(typedSelection.Name)
+				if fragment == nil || !isTypeConditionMet(definition, fragment.TypeCondition) {
+					continue
+				}
+
+				collect(fragment.SelectionSet)
+			}
+		}
+	}
+
+	collect(selections)
+
+	return keys, fieldsByKey
+}
+
+func (rc *requestContext) isIncluded(directives ast.DirectiveList) bool {
+	if skip := directives.ForName("skip"); skip != nil {
+		if isSkipped, _ := skip.ArgumentMap(rc.Variables)["if"].(bool); isSkipped {
+			return false
+		}
+	}
+
+	if include := directives.ForName("include"); include != nil {
+		if isIncluded, _ := include.ArgumentMap(rc.Variables)["if"].(bool); !isIncluded {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (rc *requestContext) resolveField(
+	definition *ast.Definition,
+	source any,
+	field *ast.Field,
+) (any, error) {
+	args := field.ArgumentMap(rc.Variables)
+
+	switch field.Name {
+	case "__typename":
+		return definition.Name, nil
+	case "__schema":
+		return &introspectionSchema{schema: rc.schema.schema}, nil
+	case "__type":
+		typeName, _ := args["name"].(string)
+		return newIntrospectionNamedType(rc.schema.schema, rc.schema.schema.Types[typeName]), nil
+	}
+
+	if typ, isObjectType := rc.schema.objectTypes[definition.Name]; isObjectType {
+		if fieldRelation, isRelation := typ.Relations[field.Name]; isRelation {
+			parent, _ := source.(map[string]any)
+
+			resolved, err := fieldRelation.Resolve(rc, parent, args)
+			if err != nil {
+				return nil, err
+			}
+
+			return toJSONValue(resolved)
+		}
+	}
+
+	switch typedSource := source.(type) {
+	case introspectionObject:
+		return typedSource.resolveIntrospectionField(field.Name, args), nil
+	case map[string]any:
+		return typedSource[field.Name], nil
+	default:
+		return nil, nil
+	}
+}
+
+// completeValue walks lists and objects of the resolved value following the schema type.
+// Scalars are already in their JSON form
+func (rc *requestContext) completeValue(
+	typ *ast.Type,
+	value any,
+	selections ast.SelectionSet,
+	path []any,
+) any {
+	if value == nil {
+		return nil
+	}
+
+	if typ.Elem != nil {
+		items, isList := value.([]any)
+		if !isList {
+			rc.addError(fmt.Errorf("resolver of type %s returned %T", typ.String(), value), path)
+			return nil
+		}
+
+		completed := make([]any, 0, len(items))
+		for index, item := range items {
+			completed = append(
+				completed,
+				rc.completeValue(typ.Elem, item, selections, appendPath(path, index)),
+			)
+		}
+
+		return completed
+	}
+
+	definition := rc.schema.schema.Types[typ.NamedType]
+	if definition == nil || definition.Kind != ast.Object {
+		return value
+	}
+
+	switch value.(type) {
+	case map[string]any, introspectionObject:
+		return rc.executeObject(definition, value, selections, path)
+	default:
+		rc.addError(fmt.Errorf("resolver of type %s returned %T", typ.NamedType, value), path)
+		return nil
+	}
+}
+
+func isTypeConditionMet(definition *ast.Definition, typeCondition string) bool {
+	return typeCondition == "" || typeCondition == definition.Name
+}
+
+func mergeSelections(fields []*ast.Field) ast.SelectionSet {
+	if len(fields) == 1 {
+		return fields[0].SelectionSet
+	}
+
+	selections := ast.SelectionSet{}
+	for _, field := range fields {
+		selections = append(selections, field.SelectionSet...)
+	}
+
+	return selections
+}
+
+// appendPath copies the path, so sibling fields never share the backing array
+func appendPath(path []any, element any) []any {
+	return append(append(make([]any, 0, len(path)+1), path...), element)
+}
+
+func toJSONValue(resolved any) (any, error) {
+	if resolved == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonValue any
+	if err := json.Unmarshal(encoded, &jsonValue); err != nil {
+		return nil, err
+	}
+
+	return jsonValue, nil
+}
+
+func toGraphQLErrors(gqlErrors gqlerror.List) []GraphQLError {
+	graphQLErrors := make([]GraphQLError, 0, len(gqlErrors))
+
+	for _, gqlErr := range gqlErrors {
+		message := gqlErr.Message
+		if len(gqlErr.Path) > 0 {
+			message = gqlErr.Path.String() + " " + message
+		}
+
+		graphQLErrors = append(graphQLErrors, GraphQLError{Message: message})
+	}
+
+	return graphQLErrors
+}
+
+func newErrorResponse(message string) *GraphQLResponse {
+	return &GraphQLResponse{Errors: []GraphQLError{{Message: message}}}
+}
@@ -0,0 +1,386 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Details   *testDetails   `json:"details"`
+	Tags      []string       `json:"tags"`
+	Secret    string         `json:"-"`
+	Extra     map[string]any `json:"extra"`
+}
+
+type testDetails struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func Test_Execute_WhenQuerySelectsNestedFields_ReturnsOnlySelectedFields(t *testing.T) {
+	itemID := uuid.New()
+
+	response := executeTestQuery(t, `
+		query Items($limit: Int) {
+			items(limit: $limit) {
+				id
+				title: name
+				details { host }
+				__typename
+			}
+		}`, map[string]any{"limit": float64(1)}, itemID)
+
+	assert.Empty(t, response.Errors)
+	assert.Equal(t, []any{map[string]any{
+		"id":         itemID.String(),
+		"title":      "first",
+		"details":    map[string]any{"host": "localhost"},
+		"__typename": "Item",
+	}}, response.Data["items"])
+}
+
+func Test_Execute_WhenRelationFails_ReturnsPartialDataWithError(t *testing.T) {
+	response := executeTestQuery(t, `{
+		items { name forbidden { name } }
+		item { name }
+	}`, nil, uuid.New())
+
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, "access denied", response.Errors[0].Message)
+	assert.Equal(t, []any{"items", 0, "forbidden"}, response.Errors[0].Path)
+	assert.Equal(t, []any{"items", 1, "forbidden"}, response.Errors[1].Path)
+
+	assert.Equal(t, []any{
+		map[string]any{"name": "first", "forbidden": nil},
+		map[string]any{"name": "second", "forbidden": nil},
+	}, response.Data["items"])
+	assert.Equal(t, map[string]any{"name": "first"}, response.Data["item"])
+}
+
+func Test_Execute_WhenQuerySelectsFragmentsAndAliases_MergesSelections(t *testing.T) {
+	itemID := uuid.New()
+
+	response := executeTestQuery(t, `
+		query Item($withTags: Boolean!) {
+			first: item { ...itemFields details { port } }
+			second: item {
+				... on Item { name }
+				tags @include(if: $withTags)
+				extra @skip(if: true)
+			}
+		}
+
+		fragment itemFields on Item {
+			id
+			details { host }
+		}`, map[string]any{"withTags": false}, itemID)
+
+	assert.Empty(t, response.Errors)
+	assert.Equal(t, map[string]any{
+		"id":      itemID.String(),
+		"details": map[string]any{"host": "localhost", "port": float64(5432)},
+	}, response.Data["first"])
+	assert.Equal(t, map[string]any{"name": "first"}, response.Data["second"])
+}
+
+func Test_Execute_WhenSchemaIsIntrospected_ReturnsTypesAndFields(t *testing.T) {
+	response := executeTestQuery(t, `{
+		__schema { queryType { name } }
+		__type(name: "Item") {
+			kind
+			fields { name type { kind name ofType { name } } }
+		}
+	}`, nil, uuid.New())
+
+	require.Empty(t, response.Errors)
+	assert.Equal(
+		t,
+		map[string]any{"queryType": map[string]any{"name": "Query"}},
+		response.Data["__schema"],
+	)
+
+	itemType := response.Data["__type"].(map[string]any)
+	assert.Equal(t, "OBJECT", itemType["kind"])
+
+	fieldTypes := map[string]any{}
+	for _, field := range itemType["fields"].([]any) {
+		fieldMap := field.(map[string]any)
+		fieldTypes[fieldMap["name"].(string)] = fieldMap["type"]
+	}
+
+	assert.Equal(t, map[string]any{
+		"id":        map[string]any{"kind": "SCALAR", "name": "ID", "ofType": nil},
+		"name":      map[string]any{"kind": "SCALAR", "name": "String", "ofType": nil},
+		"createdAt": map[string]any{"kind": "SCALAR", "name": "String", "ofType": nil},
+		"details":   map[string]any{"kind": "OBJECT", "name": "TestDetails", "ofType": nil},
+		"tags": map[string]any{
+			"kind":   "LIST",
+			"name":   nil,
+			"ofType": map[string]any{"name": "String"},
+		},
+		"extra":     map[string]any{"kind": "SCALAR", "name": "JSON", "ofType": nil},
+		"forbidden": map[string]any{"kind": "OBJECT", "name": "Item", "ofType": nil},
+	}, fieldTypes)
+}
+
+func Test_Execute_WhenStandardIntrospectionQueryIsSent_ReturnsSchema(t *testing.T) {
+	response := executeTestQuery(t, introspectionQuery, nil, uuid.New())
+
+	require.Empty(t, response.Errors)
+
+	schema := response.Data["__schema"].(map[string]any)
+	typeNames := []any{}
+	for _, typ := range schema["types"].([]any) {
+		typeNames = append(typeNames, typ.(map[string]any)["name"])
+	}
+
+	assert.Contains(t, typeNames, "Query")
+	assert.Contains(t, typeNames, "Item")
+	assert.Contains(t, typeNames, "TestDetails")
+	assert.Contains(t, typeNames, "__Schema")
+	assert.NotEmpty(t, schema["directives"])
+}
+
+func Test_Execute_WhenQueryIsInvalid_ReturnsErrorWithoutData(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		message   string
+	}{
+		{
+			name:    "field hidden from JSON",
+			query:   `{ item { name secret } }`,
+			message: `Cannot query field "secret" on type "Item".`,
+		},
+		{
+			name:    "unknown nested field",
+			query:   `{ item { name details { password } } }`,
+			message: `Cannot query field "password" on type "TestDetails".`,
+		},
+		{
+			name:    "selection on scalar",
+			query:   `{ item { name createdAt { year } } }`,
+			message: `Cannot query field "year" on type "String".`,
+		},
+		{
+			name:    "object without selection",
+			query:   `{ item { name details } }`,
+			message: `Field "details" of type "TestDetails" must have a selection of subfields. Did you mean "details { ... }"?`,
+		},
+		{
+			name:    "mutation",
+			query:   `mutation { deleteItem(id: "1") { id } }`,
+			message: `Schema does not support operation type "mutation"`,
+		},
+		{
+			name: "too deep",
+			query: "{ item {" + strings.Repeat(" forbidden {", 8) + " name" +
+				strings.Repeat(" }", 10),
+			message: "query is nested deeper than 8 levels",
+		},
+		{
+			name:    "too many aliases",
+			query:   buildAliasesQuery(maxQueryFields),
+			message: fmt.Sprintf("query selects more than %d fields", maxQueryFields),
+		},
+		{
+			name:    "missing variable",
+			query:   `query Items($limit: Int!) { items(limit: $limit) { id } }`,
+			message: "variable.limit must be defined",
+		},
+		{
+			name:      "wrong variable type",
+			query:     `query Items($limit: Int) { items(limit: $limit) { id } }`,
+			variables: map[string]any{"limit": "ten"},
+			message:   "variable.limit cannot use string as Int",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			response := executeTestQuery(t, testCase.query, testCase.variables, uuid.New())
+
+			require.NotEmpty(t, response.Errors)
+			assert.Equal(t, testCase.message, response.Errors[0].Message)
+			assert.Nil(t, response.Data)
+		})
+	}
+}
+
+func buildAliasesQuery(aliasesCount int) string {
+	query := &strings.Builder{}
+	query.WriteString("{ item {")
+
+	for index := range aliasesCount {
+		fmt.Fprintf(query, " name%d: name", index)
+	}
+
+	query.WriteString(" } }")
+
+	return query.String()
+}
+
+func executeTestQuery(
+	t *testing.T,
+	query string,
+	variables map[string]any,
+	itemID uuid.UUID,
+) *GraphQLResponse {
+	t.Helper()
+
+	items := []*testItem{
+		{
+			ID:      itemID,
+			Name:    "first",
+			Details: &testDetails{Host: "localhost", Port: 5432},
+			Secret:  "secret",
+		},
+		{ID: uuid.New(), Name: "second"},
+	}
+
+	itemType := &objectType{Name: "Item", Model: reflect.TypeFor[testItem]()}
+	itemType.Relations = map[string]*relation{
+		"forbidden": {
+			Type: itemType,
+			Resolve: func(_ *requestContext, _ map[string]any, _ map[string]any) (any, error) {
+				return nil, errors.New("access denied")
+			},
+		},
+	}
+
+	queryType := &objectType{
+		Name: "Query",
+		Relations: map[string]*relation{
+			"items": {
+				Type:      itemType,
+				IsList:    true,
+				Arguments: []relationArgument{{Name: "limit", Type: "Int"}},
+				Resolve: func(_ *requestContext, _ map[string]any, args map[string]any) (any, error) {
+					limit, err := getIntArgument(args, "limit", len(items))
+					if err != nil {
+						return nil, err
+					}
+
+					return items[:limit], nil
+				},
+			},
+			"item": {
+				Type: itemType,
+				Resolve: func(_ *requestContext, _ map[string]any, _ map[string]any) (any, error) {
+					return items[0], nil
+				},
+			},
+		},
+	}
+
+	schema, err := buildSchema(queryType)
+	require.NoError(t, err)
+
+	return execute(schema, nil, &GraphQLRequest{Query: query, Variables: variables})
+}
+
+// introspectionQuery is the query GraphiQL and code generators send
+const introspectionQuery = `
+	query IntrospectionQuery {
+		__schema {
+			queryType { name }
+			mutationType { name }
+			subscriptionType { name }
+			types { ...FullType }
+			directives {
+				name
+				description
+				locations
+				args { ...InputValue }
+			}
+		}
+	}
+
+	fragment FullType on __Type {
+		kind
+		name
+		description
+		fields(includeDeprecated: true) {
+			name
+			description
+			args { ...InputValue }
+			type { ...TypeRef }
+			isDeprecated
+			deprecationReason
+		}
+		inputFields { ...InputValue }
+		interfaces { ...TypeRef }
+		enumValues(includeDeprecated: true) {
+			name
+			description
+			isDeprecated
+			deprecationReason
+		}
+		possibleTypes { ...TypeRef }
+	}
+
+	fragment InputValue on __InputValue {
+		name
+		description
+		type { ...TypeRef }
+		defaultValue
+	}
+
+	fragment TypeRef on __Type {
+		kind
+		name
+		ofType {
+			kind
+			name
+			ofType {
+				kind
+				name
+				ofType {
+					kind
+					name
+					ofType {
+						kind
+						name
+						ofType {
+							kind
+							name
+							ofType {
+								kind
+								name
+								ofType {
+									kind
+									name
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+func Test_BuildSchema_ForServiceTypes_ExposesRelationsWithArguments(t *testing.T) {
+	schema, err := buildSchema((&GraphQLService{}).buildQueryType())
+	require.NoError(t, err)
+
+	backupsField := schema.schema.Types["Database"].Fields.ForName("backups")
+	require.NotNil(t, backupsField)
+	assert.Equal(t, "[Backup]", backupsField.Type.String())
+	assert.NotNil(t, backupsField.Arguments.ForName("limit"))
+
+	workspaceField := schema.schema.Query.Fields.ForName("workspace")
+	require.NotNil(t, workspaceField)
+	assert.Equal(t, "ID!", workspaceField.Arguments.ForName("id").Type.String())
+}
@@ -0,0 +1,386 @@
+package graphql
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// introspectionObject is the source of the __Schema, __Type, __Field, __InputValue,
+// __EnumValue and __Directive objects. They are read from the parsed schema, so the
+// introspection always matches what the validator accepts
+type introspectionObject interface {
+	resolveIntrospectionField(name string, args map[string]any) any
+}
+
+type introspectionSchema struct {
+	schema *ast.Schema
+}
+
+func (s *introspectionSchema) resolveIntrospectionField(name string, _ map[string]any) any {
+	switch name {
+	case "description":
+		return getOptionalString(s.schema.Description)
+	case "types":
+		names := make([]string, 0, len(s.schema.Types))
+		for typeName := range s.schema.Types {
+			names = append(names, typeName)
+		}
+
+		slices.Sort(names)
+
+		types := make([]any, 0, len(names))
+		for _, typeName := range names {
+			types = append(types, newIntrospectionNamedType(s.schema, s.schema.Types[typeName]))
+		}
+
+		return types
+	case "queryType":
+		return newIntrospectionNamedType(s.schema, s.schema.Query)
+	case "mutationType":
+		return newIntrospectionNamedType(s.schema, s.schema.Mutation)
+	case "subscriptionType":
+		return newIntrospectionNamedType(s.schema, s.schema.Subscription)
+	case "directives":
+		names := make([]string, 0, len(s.schema.Directives))
+		for directiveName := range s.schema.Directives {
+			names = append(names, directiveName)
+		}
+
+		slices.Sort(names)
+
+		directives := make([]any, 0, len(names))
+		for _, directiveName := range names {
+			directives = append(directives, &introspectionDirective{
+				schema:    s.schema,
+				directive: s.schema.Directives[directiveName],
+			})
+		}
+
+		return directives
+	default:
+		return nil
+	}
+}
+
+// introspectionType is either a named type (definition is set) or a LIST / NON_NULL
+// wrapper around typeRef
+type introspectionType struct {
+	schema     *ast.Schema
+	definition *ast.Definition
+	typeRef    *ast.Type
+}
+
+func newIntrospectionNamedType(schema *ast.Schema, definition *ast.Definition) any {
+	if definition == nil {
+		return nil
+	}
+
+	return &introspectionType{schema: schema, definition: definition}
+}
+
+func newIntrospectionType(schema *ast.Schema, typeRef *ast.Type) any {
+	if typeRef == nil {
+		return nil
+	}
+
+	if typeRef.NonNull || typeRef.Elem != nil {
+		return &introspectionType{schema: schema, typeRef: typeRef}
+	}
+
+	return newIntrospectionNamedType(schema, schema.Types[typeRef.NamedType])
+}
+
+func (t *introspectionType) resolveIntrospectionField(name string, args map[string]any) any {
+	if t.definition == nil {
+		return t.resolveWrapperField(name)
+	}
+
+	isDeprecatedIncluded, _ := args["includeDeprecated"].(bool)
+
+	switch name {
+	case "kind":
+		return string(t.definition.Kind)
+	case "name":
+		return t.definition.Name
+	case "description":
+		return getOptionalString(t.definition.Description)
+	case "specifiedByURL":
+		return getDirectiveArgument(t.definition.Directives, "specifiedBy", "url")
+	case "fields":
+		if t.definition.Kind != ast.Object && t.definition.Kind != ast.Interface {
+			return nil
+		}
+
+		fields := []any{}
+		for _, field := range t.definition.Fields {
+			if strings.HasPrefix(field.Name, "__") ||
+				!isDeprecatedIncluded && isDeprecated(field.Directives) {
+				continue
+			}
+
+			fields = append(fields, &introspectionField{schema: t.schema, field: field})
+		}
+
+		return fields
+	case "interfaces":
+		if t.definition.Kind != ast.Object && t.definition.Kind != ast.Interface {
+			return nil
+		}
+
+		interfaces := []any{}
+		for _, interfaceName := range t.definition.Interfaces {
+			interfaces = append(
+				interfaces,
+				newIntrospectionNamedType(t.schema, t.schema.Types[interfaceName]),
+			)
+		}
+
+		return interfaces
+	case "possibleTypes":
+		if !t.definition.IsAbstractType() {
+			return nil
+		}
+
+		possibleTypes := []any{}
+		for _, possibleType := range t.schema.GetPossibleTypes(t.definition) {
+			possibleTypes = append(possibleTypes, newIntrospectionNamedType(t.schema, possibleType))
+		}
+
+		return possibleTypes
+	case "enumValues":
+		if t.definition.Kind != ast.Enum {
+			return nil
+		}
+
+		enumValues := []any{}
+		for _, enumValue := range t.definition.EnumValues {
+			if !isDeprecatedIncluded && isDeprecated(enumValue.Directives) {
+				continue
+			}
+
+			enumValues = append(enumValues, &introspectionEnumValue{enumValue: enumValue})
+		}
+
+		return enumValues
+	case "inputFields":
+		if t.definition.Kind != ast.InputObject {
+			return nil
+		}
+
+		inputFields := []any{}
+		for _, field := range t.definition.Fields {
+			if !isDeprecatedIncluded && isDeprecated(field.Directives) {
+				continue
+			}
+
+			inputFields = append(inputFields, &introspectionInputValue{
+				schema:       t.schema,
+				name:         field.Name,
+				description:  field.Description,
+				typeRef:      field.Type,
+				defaultValue: field.DefaultValue,
+				directives:   field.Directives,
+			})
+		}
+
+		return inputFields
+	case "isOneOf":
+		if t.definition.Kind != ast.InputObject {
+			return nil
+		}
+
+		return t.definition.Directives.ForName("oneOf") != nil
+	default:
+		return nil
+	}
+}
+
+func (t *introspectionType) resolveWrapperField(name string) any {
+	switch name {
+	case "kind":
+		if t.typeRef.NonNull {
+			return "NON_NULL"
+		}
+
+		return "LIST"
+	case "ofType":
+		if t.typeRef.NonNull {
+			ofType := *t.typeRef
+			ofType.NonNull = false
+
+			return newIntrospectionType(t.schema, &ofType)
+		}
+
+		return newIntrospectionType(t.schema, t.typeRef.Elem)
+	default:
+		return nil
+	}
+}
+
+type introspectionField struct {
+	schema *ast.Schema
+	field  *ast.FieldDefinition
+}
+
+func (f *introspectionField) resolveIntrospectionField(name string, args map[string]any) any {
+	switch name {
+	case "name":
+		return f.field.Name
+	case "description":
+		return getOptionalString(f.field.Description)
+	case "args":
+		return getIntrospectionArguments(f.schema, f.field.Arguments, args)
+	case "type":
+		return newIntrospectionType(f.schema, f.field.Type)
+	case "isDeprecated":
+		return isDeprecated(f.field.Directives)
+	case "deprecationReason":
+		return getDeprecationReason(f.field.Directives)
+	default:
+		return nil
+	}
+}
+
+type introspectionInputValue struct {
+	schema       *ast.Schema
+	name         string
+	description  string
+	typeRef      *ast.Type
+	defaultValue *ast.Value
+	directives   ast.DirectiveList
+}
+
+func (v *introspectionInputValue) resolveIntrospectionField(name string, _ map[string]any) any {
+	switch name {
+	case "name":
+		return v.name
+	case "description":
+		return getOptionalString(v.description)
+	case "type":
+		return newIntrospectionType(v.schema, v.typeRef)
+	case "defaultValue":
+		if v.defaultValue == nil {
+			return nil
+		}
+
+		return v.defaultValue.String()
+	case "isDeprecated":
+		return isDeprecated(v.directives)
+	case "deprecationReason":
+		return getDeprecationReason(v.directives)
+	default:
+		return nil
+	}
+}
+
+type introspectionEnumValue struct {
+	enumValue *ast.EnumValueDefinition
+}
+
+func (v *introspectionEnumValue) resolveIntrospectionField(name string, _ map[string]any) any {
+	switch name {
+	case "name":
+		return v.enumValue.Name
+	case "description":
+		return getOptionalString(v.enumValue.Description)
+	case "isDeprecated":
+		return isDeprecated(v.enumValue.Directives)
+	case "deprecationReason":
+		return getDeprecationReason(v.enumValue.Directives)
+	default:
+		return nil
+	}
+}
+
+type introspectionDirective struct {
+	schema    *ast.Schema
+	directive *ast.DirectiveDefinition
+}
+
+func (d *introspectionDirective) resolveIntrospectionField(name string, args map[string]any) any {
+	switch name {
+	case "name":
+		return d.directive.Name
+	case "description":
+		return getOptionalString(d.directive.Description)
+	case "isRepeatable":
+		return d.directive.IsRepeatable
+	case "locations":
+		locations := make([]any, 0, len(d.directive.Locations))
+		for _, location := range d.directive.Locations {
+			locations = append(locations, string(location))
+		}
+
+		return locations
+	case "args":
+		return getIntrospectionArguments(d.schema, d.directive.Arguments, args)
+	default:
+		return nil
+	}
+}
+
+func getIntrospectionArguments(
+	schema *ast.Schema,
+	arguments ast.ArgumentDefinitionList,
+	args map[string]any,
+) []any {
+	isDeprecatedIncluded, _ := args["includeDeprecated"].(bool)
+
+	inputValues := []any{}
+	for _, argument := range arguments {
+		if !isDeprecatedIncluded && isDeprecated(argument.Directives) {
+			continue
+		}
+
+		inputValues = append(inputValues, &introspectionInputValue{
+			schema:       schema,
+			name:         argument.Name,
+			description:  argument.Description,
+			typeRef:      argument.Type,
+			defaultValue: argument.DefaultValue,
+			directives:   argument.Directives,
+		})
+	}
+
+	return inputValues
+}
+
+func isDeprecated(directives ast.DirectiveList) bool {
+	return directives.ForName("deprecated") != nil
+}
+
+func getDeprecationReason(directives ast.DirectiveList) any {
+	if !isDeprecated(directives) {
+		return nil
+	}
+
+	if reason := getDirectiveArgument(directives, "deprecated", "reason"); reason != nil {
+		return reason
+	}
+
+	return "No longer supported"
+}
+
+func getDirectiveArgument(directives ast.DirectiveList, directiveName, argumentName string) any {
+	directive := directives.ForName(directiveName)
+	if directive == nil {
+		return nil
+	}
+
+	argument := directive.Arguments.ForName(argumentName)
+	if argument == nil || argument.Value == nil {
+		return nil
+	}
+
+	return argument.Value.Raw
+}
+
+func getOptionalString(text string) any {
+	if text == "" {
+		return nil
+	}
+
+	return text
+}
@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator/core"
+)
+
+const (
+	maxQueryDepth = 8
+
+	// every alias and every spread of a fragment is counted, so a small query text cannot
+	// expand into thousands of resolver calls. The standard introspection query is ~200
+	maxQueryFields = 500
+)
+
+// maxQuerySizeRule rejects operations before anything is resolved. Introspection fields
+// do not count towards the depth (their nesting is bounded by MaxIntrospectionDepth), but
+// they do count towards the number of fields
+var maxQuerySizeRule = core.Rule{
+	Name: "MaxQuerySize",
+	RuleFunc: func(observers *core.Events, addError core.AddErrFunc) {
+		observers.OnOperation(func(walker *core.Walker, operation *ast.OperationDefinition) {
+			counter := &querySizeCounter{
+				fragments:        walker.Document.Fragments,
+				visitedFragments: map[string]bool{},
+			}
+			counter.walk(operation.SelectionSet, 1)
+
+			if counter.fieldsCount > maxQueryFields {
+				addError(
+					core.Message("query selects more than %d fields", maxQueryFields),
+					core.At(operation.Position),
+				)
+			}
+
+			if counter.depth > maxQueryDepth {
+				addError(
+					core.Message("query is nested deeper than %d levels", maxQueryDepth),
+					core.At(operation.Position),
+				)
+			}
+		})
+	},
+}
+
+type querySizeCounter struct {
+	fragments        ast.FragmentDefinitionList
+	visitedFragments map[string]bool
+
+	fieldsCount int
+	depth       int
+}
+
+func (c *querySizeCounter) walk(selections ast.SelectionSet, depth int) {
+	for _, selection := range selections {
+		if c.fieldsCount > maxQueryFields {
+			return
+		}
+
+		switch typedSelection := selection.(type) {
+		case *ast.Field:
+			c.fieldsCount++
+
+			if strings.HasPrefix(typedSelection.Name, "__") {
+				c.walk(typedSelection.SelectionSet, 0)
+				continue
+			}
+
+			if depth > 0 {
+				c.depth = max(c.depth, depth)
+				c.walk(typedSelection.SelectionSet, depth+1)
+			} else {
+				c.walk(typedSelection.SelectionSet, 0)
+			}
+		case *ast.InlineFragment:
+			c.walk(typedSelection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			// cycles are reported by NoFragmentCycles, here they are only not followed
+			fragment := c.fragments.ForName(typedSelection.Name)
+			if fragment == nil || c.visitedFragments[fragment.Name] {
+				continue
+			}
+
+			c.visitedFragments[fragment.Name] = true
+			c.walk(fragment.SelectionSet, depth)
+			delete(c.visitedFragments, fragment.Name)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package graphql
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator/rules"
+)
+
+// objectType describes what can be selected on an object. Scalar fields are taken from
+// the JSON form of the REST response model, so GraphQL exposes exactly the same (already
+// redacted) data as REST does. Relations are resolved through services and every service
+// call checks permissions of the user, so a forbidden nested field becomes null with an
+// error while the rest of the query still succeeds
+type objectType struct {
+	Name      string
+	Model     reflect.Type
+	Relations map[string]*relation
+}
+
+type relation struct {
+	Type      *objectType
+	IsList    bool
+	Arguments []relationArgument
+	Resolve   func(rc *requestContext, parent map[string]any, args map[string]any) (any, error)
+}
+
+// relationArgument is declared in SDL, e.g. Type is "ID!" or "Int"
+type relationArgument struct {
+	Name string
+	Type string
+}
+
+// executableSchema is the GraphQL schema generated from the object types. Queries are
+// parsed and validated against it, so only the executor is implemented here
+type executableSchema struct {
+	schema      *ast.Schema
+	objectTypes map[string]*objectType
+	rules       *rules.Rules
+}
+
+var graphQLNameRegex = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	uuidType          = reflect.TypeFor[uuid.UUID]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func buildSchema(queryType *objectType) (*executableSchema, error) {
+	builder := &schemaBuilder{
+		objectTypes: map[string]*objectType{},
+		modelNames:  map[reflect.Type]string{},
+		usedNames:   map[string]bool{},
+	}
+
+	builder.registerObjectType(queryType)
+
+	sdl := &strings.Builder{}
+	sdl.WriteString("\"Any JSON value\"\nscalar JSON\n\n")
+	sdl.WriteString("schema {\n  query: " + queryType.Name + "\n}\n")
+
+	for _, typ := range builder.orderedObjectTypes {
+		builder.writeObjectType(typ)
+	}
+
+	for index := 0; index < len(builder.orderedModels); index++ {
+		builder.writeModelType(builder.orderedModels[index])
+	}
+
+	for _, definition := range builder.definitions {
+		sdl.WriteString("\n" + definition)
+	}
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+
+	queryRules := rules.NewDefaultRules()
+	queryRules.AddRule(maxQuerySizeRule.Name, maxQuerySizeRule.RuleFunc)
+
+	return &executableSchema{
+		schema:      schema,
+		objectTypes: builder.objectTypes,
+		rules:       queryRules,
+	}, nil
+}
+
+type schemaBuilder struct {
+	objectTypes        map[string]*objectType
+	orderedObjectTypes []*objectType
+
+	// nested structs of models (e.g. database.postgresql) become object types without
+	// relations, named after the Go type
+	modelNames    map[reflect.Type]string
+	orderedModels []reflect.Type
+
+	usedNames   map[string]bool
+	definitions []string
+}
+
+func (b *schemaBuilder) registerObjectType(typ *objectType) {
+	if _, isRegistered := b.objectTypes[typ.Name]; isRegistered {
+		return
+	}
+
+	b.objectTypes[typ.Name] = typ
+	b.orderedObjectTypes = append(b.orderedObjectTypes, typ)
+	b.usedNames[typ.Name] = true
+
+	if typ.Model != nil {
+		b.modelNames[derefType(typ.Model)] = typ.Name
+	}
+
+	for _, name := range getSortedRelationNames(typ) {
+		b.registerObjectType(typ.Relations[name].Type)
+	}
+}
+
+func (b *schemaBuilder) writeObjectType(typ *objectType) {
+	fields := []string{}
+
+	if typ.Model != nil {
+		for _, field := range getJSONFields(typ.Model) {
+			if _, isRelation := typ.Relations[field.Name]; isRelation {
+				continue
+			}
+
+			if fieldType, isSupported := b.getFieldType(field.Type); isSupported {
+				fields = append(fields, field.Name+": "+fieldType)
+			}
+		}
+	}
+
+	for _, name := range getSortedRelationNames(typ) {
+		fieldRelation := typ.Relations[name]
+
+		arguments := make([]string, 0, len(fieldRelation.Arguments))
+		for _, argument := range fieldRelation.Arguments {
+			arguments = append(arguments, argument.Name+": "+argument.Type)
+		}
+
+		field := name
+		if len(arguments) > 0 {
+			field += "(" + strings.Join(arguments, ", ") + ")"
+		}
+
+		if fieldRelation.IsList {
+			field += ": [" + fieldRelation.Type.Name + "]"
+		} else {
+			field += ": " + fieldRelation.Type.Name
+		}
+
+		fields = append(fields, field)
+	}
+
+	b.writeDefinition(typ.Name, fields)
+}
+
+func (b *schemaBuilder) writeModelType(model reflect.Type) {
+	fields := []string{}
+
+	for _, field := range getJSONFields(model) {
+		if fieldType, isSupported := b.getFieldType(field.Type); isSupported {
+			fields = append(fields, field.Name+": "+fieldType)
+		}
+	}
+
+	b.writeDefinition(b.modelNames[model], fields)
+}
+
+func (b *schemaBuilder) writeDefinition(name string, fields []string) {
+	definition := &strings.Builder{}
+	definition.WriteString("type " + name + " {\n")
+
+	for _, field := range fields {
+		definition.WriteString("  " + field + "\n")
+	}
+
+	definition.WriteString("}\n")
+	b.definitions = append(b.definitions, definition.String())
+}
+
+// getFieldType maps Go types to GraphQL. Types with own JSON form (time.Time, uuid.UUID,
+// enums) are scalars for the client, maps and interfaces are passed as JSON
+func (b *schemaBuilder) getFieldType(typ reflect.Type) (string, bool) {
+	typ = derefType(typ)
+
+	switch {
+	case typ == timeType:
+		return "String", true
+	case typ == uuidType:
+		return "ID", true
+	case hasOwnJSONForm(typ):
+		return "JSON", true
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "String", true
+	case reflect.Bool:
+		return "Boolean", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int", true
+	case reflect.Float32, reflect.Float64:
+		return "Float", true
+	case reflect.Map, reflect.Interface:
+		return "JSON", true
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return "String", true
+		}
+
+		elementType, isSupported := b.getFieldType(typ.Elem())
+		if !isSupported {
+			return "", false
+		}
+
+		return "[" + elementType + "]", true
+	case reflect.Struct:
+		if len(getJSONFields(typ)) == 0 {
+			return "JSON", true
+		}
+
+		return b.getModelName(typ), true
+	default:
+		return "", false
+	}
+}
+
+func (b *schemaBuilder) getModelName(model reflect.Type) string {
+	if name, isNamed := b.modelNames[model]; isNamed {
+		return name
+	}
+
+	name := toGraphQLName(model.Name())
+	if name == "" || b.usedNames[name] {
+		name = toGraphQLName(path.Base(model.PkgPath())) + name
+	}
+
+	for suffix := 2; b.usedNames[name]; suffix++ {
+		name = fmt.Sprintf("%s%d", strings.TrimRight(name, "0123456789"), suffix)
+	}
+
+	b.modelNames[model] = name
+	b.usedNames[name] = true
+	b.orderedModels = append(b.orderedModels, model)
+
+	return name
+}
+
+type jsonField struct {
+	Name string
+	Type reflect.Type
+}
+
+// getJSONFields lists fields as encoding/json writes them, including embedded structs.
+// Names GraphQL cannot express are skipped
+func getJSONFields(model reflect.Type) []jsonField {
+	model = derefType(model)
+	if model.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := []jsonField{}
+	embeddedFields := []jsonField{}
+
+	for index := range model.NumField() {
+		structField := model.Field(index)
+
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(tag, ",")
+
+		if structField.Anonymous && jsonName == "" &&
+			derefType(structField.Type).Kind() == reflect.Struct {
+			embeddedFields = append(embeddedFields, getJSONFields(structField.Type)...)
+			continue
+		}
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = structField.Name
+		}
+
+		if !graphQLNameRegex.MatchString(jsonName) || strings.HasPrefix(jsonName, "__") {
+			continue
+		}
+
+		fields = append(fields, jsonField{Name: jsonName, Type: structField.Type})
+	}
+
+	// fields of the outer struct win over embedded ones, as in encoding/json
+	for _, embeddedField := range embeddedFields {
+		isShadowed := slices.ContainsFunc(fields, func(field jsonField) bool {
+			return field.Name == embeddedField.Name
+		})
+
+		if !isShadowed {
+			fields = append(fields, embeddedField)
+		}
+	}
+
+	return fields
+}
+
+func getSortedRelationNames(typ *objectType) []string {
+	names := make([]string, 0, len(typ.Relations))
+	for name := range typ.Relations {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func hasOwnJSONForm(typ reflect.Type) bool {
+	return typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) ||
+		reflect.PointerTo(typ).Implements(jsonMarshalerType) ||
+		reflect.PointerTo(typ).Implements(textMarshalerType)
+}
+
+func toGraphQLName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r == '_' || r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z')
+	})
+
+	for index, word := range words {
+		words[index] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.TrimLeft(strings.Join(words, ""), "0123456789_")
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,425 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_dto "databasus-backend/internal/features/workspaces/dto"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+var (
+	idArguments         = []relationArgument{{Name: "id", Type: "ID!"}}
+	paginationArguments = []relationArgument{
+		{Name: "limit", Type: "Int"},
+		{Name: "offset", Type: "Int"},
+	}
+)
+
+// GraphQLService is a read-only facade over the REST services. It holds no data access
+// of its own, so permissions and redaction stay defined in one place
+type GraphQLService struct {
+	workspaceService  *workspaces_services.WorkspaceService
+	membershipService *workspaces_services.MembershipService
+	databaseService   *databases.DatabaseService
+	backupService     *backups.BackupService
+	storageService    *storages.StorageService
+	auditLogService   *audit_logs.AuditLogService
+
+	schema     *executableSchema
+	schemaErr  error
+	schemaOnce sync.Once
+}
+
+func (s *GraphQLService) Execute(
+	user *users_models.User,
+	request *GraphQLRequest,
+) *GraphQLResponse {
+	s.schemaOnce.Do(func() {
+		s.schema, s.schemaErr = buildSchema(s.buildQueryType())
+	})
+
+	if s.schemaErr != nil {
+		return newErrorResponse(s.schemaErr.Error())
+	}
+
+	return execute(s.schema, user, request)
+}
+
+func (s *GraphQLService) buildQueryType() *objectType {
+	workspaceType := &objectType{
+		Name:  "Workspace",
+		Model: reflect.TypeFor[workspaces_dto.WorkspaceResponseDTO](),
+	}
+	databaseType := &objectType{Name: "Database", Model: reflect.TypeFor[databases.Database]()}
+	backupType := &objectType{Name: "Backup", Model: reflect.TypeFor[backups_core.Backup]()}
+	storageType := &objectType{Name: "Storage", Model: reflect.TypeFor[storages.StorageResponse]()}
+	memberType := &objectType{
+		Name:  "WorkspaceMember",
+		Model: reflect.TypeFor[workspaces_dto.WorkspaceMemberResponseDTO](),
+	}
+	auditLogType := &objectType{Name: "AuditLog", Model: reflect.TypeFor[audit_logs.AuditLogDTO]()}
+
+	workspaceType.Relations = map[string]*relation{
+		"databases": {Type: databaseType, IsList: true, Resolve: s.resolveWorkspaceDatabases},
+		"storages":  {Type: storageType, IsList: true, Resolve: s.resolveWorkspaceStorages},
+		"members":   {Type: memberType, IsList: true, Resolve: s.resolveWorkspaceMembers},
+		"auditLogs": {
+			Type:      auditLogType,
+			IsList:    true,
+			Arguments: paginationArguments,
+			Resolve:   s.resolveWorkspaceAuditLogs,
+		},
+	}
+
+	databaseType.Relations = map[string]*relation{
+		"workspace": {Type: workspaceType, Resolve: s.resolveParentWorkspace},
+		"backups": {
+			Type:      backupType,
+			IsList:    true,
+			Arguments: paginationArguments,
+			Resolve:   s.resolveDatabaseBackups,
+		},
+	}
+
+	backupType.Relations = map[string]*relation{
+		"database": {Type: databaseType, Resolve: s.resolveBackupDatabase},
+		"storage":  {Type: storageType, Resolve: s.resolveBackupStorage},
+	}
+
+	storageType.Relations = map[string]*relation{
+		"workspace": {Type: workspaceType, Resolve: s.resolveParentWorkspace},
+	}
+
+	return &objectType{
+		Name: "Query",
+		Relations: map[string]*relation{
+			"workspaces": {Type: workspaceType, IsList: true, Resolve: s.resolveWorkspaces},
+			"workspace": {
+				Type:      workspaceType,
+				Arguments: idArguments,
+				Resolve:   s.resolveWorkspace,
+			},
+			"database": {Type: databaseType, Arguments: idArguments, Resolve: s.resolveDatabase},
+			"storage":  {Type: storageType, Arguments: idArguments, Resolve: s.resolveStorage},
+			"auditLogs": {
+				Type:      auditLogType,
+				IsList:    true,
+				Arguments: paginationArguments,
+				Resolve:   s.resolveGlobalAuditLogs,
+			},
+		},
+	}
+}
+
+func (s *GraphQLService) resolveWorkspaces(
+	rc *requestContext,
+	_ map[string]any,
+	_ map[string]any,
+) (any, error) {
+	response, err := s.workspaceService.GetUserWorkspaces(rc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Workspaces, nil
+}
+
+func (s *GraphQLService) resolveWorkspace(
+	rc *requestContext,
+	_ map[string]any,
+	args map[string]any,
+) (any, error) {
+	workspaceID, err := getUUIDArgument(args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getWorkspace(rc, workspaceID)
+}
+
+func (s *GraphQLService) resolveParentWorkspace(
+	rc *requestContext,
+	parent map[string]any,
+	_ map[string]any,
+) (any, error) {
+	workspaceID, err := getUUIDField(parent, "workspaceId")
+	if err != nil || workspaceID == uuid.Nil {
+		return nil, err
+	}
+
+	return s.getWorkspace(rc, workspaceID)
+}
+
+func (s *GraphQLService) resolveWorkspaceDatabases(
+	rc *requestContext,
+	parent map[string]any,
+	_ map[string]any,
+) (any, error) {
+	workspaceID, err := getUUIDField(parent, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.databaseService.GetDatabasesByWorkspace(rc.User, workspaceID)
+}
+
+func (s *GraphQLService) resolveWorkspaceStorages(
+	rc *requestContext,
+	parent map[string]any,
+	_ map[string]any,
+) (any, error) {
+	workspaceID, err := getUUIDField(parent, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storageService.GetStorages(rc.User, workspaceID)
+}
+
+func (s *GraphQLService) resolveWorkspaceMembers(
+	rc *requestContext,
+	parent map[string]any,
+	_ map[string]any,
+) (any, error) {
+	workspaceID, err := getUUIDField(parent, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.membershipService.GetMembers(workspaceID, rc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Members, nil
+}
+
+func (s *GraphQLService) resolveWorkspaceAuditLogs(
+	rc *requestContext,
+	parent map[string]any,
+	args map[string]any,
+) (any, error) {
+	workspaceID, err := getUUIDField(parent, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := getAuditLogsRequest(args)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.workspaceService.GetWorkspaceAuditLogs(workspaceID, rc.User, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.AuditLogs, nil
+}
+
+func (s *GraphQLService) resolveGlobalAuditLogs(
+	rc *requestContext,
+	_ map[string]any,
+	args map[string]any,
+) (any, error) {
+	request, err := getAuditLogsRequest(args)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.auditLogService.GetGlobalAuditLogs(rc.User, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.AuditLogs, nil
+}
+
+func (s *GraphQLService) resolveDatabase(
+	rc *requestContext,
+	_ map[string]any,
+	args map[string]any,
+) (any, error) {
+	databaseID, err := getUUIDArgument(args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDatabase(rc, databaseID)
+}
+
+func (s *GraphQLService) resolveDatabaseBackups(
+	rc *requestContext,
+	parent map[string]any,
+	args map[string]any,
+) (any, error) {
+	databaseID, err := getUUIDField(parent, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := getPaginationArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.backupService.GetBackups(rc.User, databaseID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Backups, nil
+}
+
+func (s *GraphQLService) resolveBackupDatabase(
+	rc *requestContext,
+	parent map[string]any,
+	_ map[string]any,
+) (any, error) {
+	databaseID, err := getUUIDField(parent, "databaseId")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDatabase(rc, databaseID)
+}
+
+func (s *GraphQLService) resolveStorage(
+	rc *requestContext,
+	_ map[string]any,
+	args map[string]any,
+) (any, error) {
+	storageID, err := getUUIDArgument(args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getStorage(rc, storageID)
+}
+
+func (s *GraphQLService) resolveBackupStorage(
+	rc *requestContext,
+	parent map[string]any,
+	_ map[string]any,
+) (any, error) {
+	storageID, err := getUUIDField(parent, "storageId")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getStorage(rc, storageID)
+}
+
+// Lists of backups usually point to a few databases and storages, so lookups are shared
+// within a request instead of being repeated per backup
+func (s *GraphQLService) getWorkspace(rc *requestContext, workspaceID uuid.UUID) (any, error) {
+	return rc.load("workspace:"+workspaceID.String(), func() (any, error) {
+		return s.workspaceService.GetWorkspace(workspaceID, rc.User)
+	})
+}
+
+func (s *GraphQLService) getDatabase(rc *requestContext, databaseID uuid.UUID) (any, error) {
+	return rc.load("database:"+databaseID.String(), func() (any, error) {
+		return s.databaseService.GetDatabase(rc.User, databaseID)
+	})
+}
+
+func (s *GraphQLService) getStorage(rc *requestContext, storageID uuid.UUID) (any, error) {
+	return rc.load("storage:"+storageID.String(), func() (any, error) {
+		return s.storageService.GetStorage(rc.User, storageID)
+	})
+}
+
+func getUUIDArgument(args map[string]any, name string) (uuid.UUID, error) {
+	rawValue, isFound := args[name]
+	if !isFound {
+		return uuid.Nil, fmt.Errorf("argument %s is required", name)
+	}
+
+	text, isString := rawValue.(string)
+	if !isString {
+		return uuid.Nil, fmt.Errorf("argument %s must be a string", name)
+	}
+
+	id, err := uuid.Parse(text)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("argument %s is not a valid ID", name)
+	}
+
+	return id, nil
+}
+
+func getUUIDField(parent map[string]any, name string) (uuid.UUID, error) {
+	text, _ := parent[name].(string)
+	if text == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(text)
+}
+
+func getPaginationArguments(args map[string]any) (int, int, error) {
+	limit, err := getIntArgument(args, "limit", defaultListLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	offset, err := getIntArgument(args, "offset", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if limit <= 0 || limit > maxListLimit {
+		return 0, 0, fmt.Errorf("argument limit must be between 1 and %d", maxListLimit)
+	}
+
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("argument offset must not be negative")
+	}
+
+	return limit, offset, nil
+}
+
+// Literals are parsed as int64, variables come from JSON as float64
+func getIntArgument(args map[string]any, name string, defaultValue int) (int, error) {
+	switch rawValue := args[name].(type) {
+	case nil:
+		return defaultValue, nil
+	case int:
+		return rawValue, nil
+	case int64:
+		return int(rawValue), nil
+	case float64:
+		if rawValue != float64(int(rawValue)) {
+			return 0, fmt.Errorf("argument %s must be an integer", name)
+		}
+
+		return int(rawValue), nil
+	default:
+		return 0, fmt.Errorf("argument %s must be an integer", name)
+	}
+}
+
+func getAuditLogsRequest(args map[string]any) (*audit_logs.GetAuditLogsRequest, error) {
+	limit, offset, err := getPaginationArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &audit_logs.GetAuditLogsRequest{Limit: limit, Offset: offset}, nil
+}
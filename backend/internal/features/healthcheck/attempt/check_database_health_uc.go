@@ -3,6 +3,8 @@ package healthcheck_attempt
 import (
 	"databasus-backend/internal/features/databases"
 	healthcheck_config "databasus-backend/internal/features/healthcheck/config"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/util/i18n"
 	"databasus-backend/internal/util/logger"
 	"errors"
 	"fmt"
@@ -33,6 +35,12 @@ func (uc *CheckDatabaseHealthUseCase) Execute(
 		return err
 	}
 
+	// Databases behind an agent are not reachable from here, a check would only mark
+	// them unavailable on every attempt
+	if database.IsBehindAgent() {
+		return nil
+	}
+
 	isExecuteNewAttempt, err := uc.isReadyForNewAttempt(
 		now,
 		database,
@@ -195,6 +203,30 @@ func (uc *CheckDatabaseHealthUseCase) validateDatabase(
 		if database.Mongodb == nil {
 			return fmt.Errorf("database MongoDB config is not set")
 		}
+	case databases.DatabaseTypeFilesystem:
+		if database.Filesystem == nil {
+			return fmt.Errorf("database filesystem config is not set")
+		}
+	case databases.DatabaseTypeElasticsearch:
+		if database.Elasticsearch == nil {
+			return fmt.Errorf("database Elasticsearch config is not set")
+		}
+	case databases.DatabaseTypeInfluxdb:
+		if database.Influxdb == nil {
+			return fmt.Errorf("database InfluxDB config is not set")
+		}
+	case databases.DatabaseTypeCassandra:
+		if database.Cassandra == nil {
+			return fmt.Errorf("database Cassandra config is not set")
+		}
+	case databases.DatabaseTypeCockroachdb:
+		if database.Cockroachdb == nil {
+			return fmt.Errorf("database CockroachDB config is not set")
+		}
+	case databases.DatabaseTypeNeo4j:
+		if database.Neo4j == nil {
+			return fmt.Errorf("database Neo4j config is not set")
+		}
 	default:
 		return fmt.Errorf("unsupported database type: %s", database.Type)
 	}
@@ -233,23 +265,23 @@ func (uc *CheckDatabaseHealthUseCase) sendDbStatusNotification(
 		return
 	}
 
-	messageTitle := ""
-	messageBody := ""
-
+	titleKey := i18n.MessageDatabaseUnavailableTitle
+	messageKey := i18n.MessageDatabaseUnavailableMessage
+	eventType := notifiers.NotificationEventDatabaseUnavailable
 	if newHealthStatus == databases.HealthStatusAvailable {
-		messageTitle = fmt.Sprintf("✅ [%s] DB is online", database.Name)
-		messageBody = fmt.Sprintf("✅ [%s] DB is back online", database.Name)
-	} else {
-		messageTitle = fmt.Sprintf("❌ [%s] DB is unavailable", database.Name)
-		messageBody = fmt.Sprintf("❌ [%s] DB is currently unavailable", database.Name)
+		titleKey = i18n.MessageDatabaseOnlineTitle
+		messageKey = i18n.MessageDatabaseOnlineMessage
+		eventType = notifiers.NotificationEventDatabaseOnline
 	}
 
+	params := map[string]string{"database": database.Name}
+
 	for _, notifier := range database.Notifiers {
-		uc.healthcheckAttemptSender.SendNotification(
+		uc.healthcheckAttemptSender.SendEventNotification(
 			&notifier,
-			messageTitle,
-			messageBody,
+			eventType,
+			i18n.Translate(notifier.Locale, titleKey, params),
+			i18n.Translate(notifier.Locale, messageKey, params),
 		)
 	}
-
 }
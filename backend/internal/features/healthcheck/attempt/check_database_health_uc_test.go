@@ -42,7 +42,13 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 
 		// Setup mock notifier sender
 		mockSender := &MockHealthcheckAttemptSender{}
-		mockSender.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return()
+		mockSender.On(
+			"SendEventNotification",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return()
 
 		// Setup mock database service
 		mockDatabaseService := &MockDatabaseService{}
@@ -95,8 +101,9 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 		// Verify notification was sent
 		mockSender.AssertCalled(
 			t,
-			"SendNotification",
+			"SendEventNotification",
 			mock.Anything,
+			notifiers.NotificationEventDatabaseUnavailable,
 			fmt.Sprintf("❌ [%s] DB is unavailable", database.Name),
 			fmt.Sprintf("❌ [%s] DB is currently unavailable", database.Name),
 		)
@@ -160,8 +167,9 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 			// Verify no notification was sent (not marked as down yet)
 			mockSender.AssertNotCalled(
 				t,
-				"SendNotification",
+				"SendEventNotification",
 				mock.Anything,
+				notifiers.NotificationEventDatabaseUnavailable,
 				fmt.Sprintf("❌ [%s] DB is unavailable", database.Name),
 				fmt.Sprintf("❌ [%s] DB is currently unavailable", database.Name),
 			)
@@ -183,7 +191,13 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 
 			// Setup mock notifier sender
 			mockSender := &MockHealthcheckAttemptSender{}
-			mockSender.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return()
+			mockSender.On(
+				"SendEventNotification",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+			).Return()
 
 			// Setup mock database service
 			mockDatabaseService := &MockDatabaseService{}
@@ -239,8 +253,9 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 			// Verify notification was sent
 			mockSender.AssertCalled(
 				t,
-				"SendNotification",
+				"SendEventNotification",
 				mock.Anything,
+				notifiers.NotificationEventDatabaseUnavailable,
 				fmt.Sprintf("❌ [%s] DB is unavailable", database.Name),
 				fmt.Sprintf("❌ [%s] DB is currently unavailable", database.Name),
 			)
@@ -260,7 +275,13 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 
 		// Setup mock notifier sender
 		mockSender := &MockHealthcheckAttemptSender{}
-		mockSender.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return()
+		mockSender.On(
+			"SendEventNotification",
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+			mock.Anything,
+		).Return()
 
 		// Setup mock database service - connection succeeds
 		mockDatabaseService := &MockDatabaseService{}
@@ -312,8 +333,9 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 		// Verify notification was sent for recovery
 		mockSender.AssertCalled(
 			t,
-			"SendNotification",
+			"SendEventNotification",
 			mock.Anything,
+			notifiers.NotificationEventDatabaseOnline,
 			fmt.Sprintf("✅ [%s] DB is online", database.Name),
 			fmt.Sprintf("✅ [%s] DB is back online", database.Name),
 		)
@@ -327,7 +349,13 @@ func Test_CheckDatabaseHealthUseCase(t *testing.T) {
 
 			// Setup mock notifier sender
 			mockSender := &MockHealthcheckAttemptSender{}
-			mockSender.On("SendNotification", mock.Anything, mock.Anything, mock.Anything).Return()
+			mockSender.On(
+				"SendEventNotification",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+			).Return()
 
 			// Setup mock database service - connection succeeds
 			mockDatabaseService := &MockDatabaseService{}
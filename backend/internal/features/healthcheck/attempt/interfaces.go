@@ -8,8 +8,9 @@ import (
 )
 
 type HealthcheckAttemptSender interface {
-	SendNotification(
+	SendEventNotification(
 		notifier *notifiers.Notifier,
+		eventType notifiers.NotificationEventType,
 		title string,
 		message string,
 	)
@@ -12,12 +12,13 @@ type MockHealthcheckAttemptSender struct {
 	mock.Mock
 }
 
-func (m *MockHealthcheckAttemptSender) SendNotification(
+func (m *MockHealthcheckAttemptSender) SendEventNotification(
 	notifier *notifiers.Notifier,
+	eventType notifiers.NotificationEventType,
 	title string,
 	message string,
 ) {
-	m.Called(notifier, title, message)
+	m.Called(notifier, eventType, title, message)
 }
 
 type MockDatabaseService struct {
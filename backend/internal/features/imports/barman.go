@@ -0,0 +1,189 @@
+package imports
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseBarmanConfig reads barman.conf with its server sections. barman keeps backups on
+// its own server, so databases are planned without storage
+func parseBarmanConfig(config string, jobs *crontabJobs) *ImportPlan {
+	sections := parseIni(config)
+	global := findIniSection(sections, "barman")
+
+	plan := &ImportPlan{Databases: make([]*PlannedDatabase, 0), Warnings: make([]string, 0)}
+
+	for _, section := range sections {
+		if section.Name == "" || section.Name == "barman" {
+			continue
+		}
+
+		if isBarmanValueFalse(section.Values["active"]) {
+			plan.Warnings = append(
+				plan.Warnings,
+				fmt.Sprintf("server %s is not active and is skipped", section.Name),
+			)
+
+			continue
+		}
+
+		plan.Databases = append(plan.Databases, parseBarmanServer(section, global, jobs))
+	}
+
+	if len(plan.Databases) == 0 {
+		plan.Warnings = append(plan.Warnings, "no server found in the config")
+	}
+
+	return plan
+}
+
+func parseBarmanServer(
+	server *iniSection,
+	global *iniSection,
+	jobs *crontabJobs,
+) *PlannedDatabase {
+	connection := parseConninfo(server.Values["conninfo"])
+
+	database := &PlannedDatabase{
+		Name:     server.Name,
+		Host:     connection["host"],
+		Port:     parsePort(connection["port"]),
+		Username: valueOrDefault(connection["user"], "postgres"),
+		Password: connection["password"],
+		Database: valueOrDefault(connection["dbname"], "postgres"),
+		Warnings: []string{"barman keeps backups on its own server, choose a storage"},
+	}
+
+	if database.Host == "" || strings.HasPrefix(database.Host, "/") {
+		database.Host = ""
+		database.Warnings = append(
+			database.Warnings,
+			"conninfo has no host, PostgreSQL runs on the barman host, set its address",
+		)
+	}
+
+	if database.Password == "" {
+		database.Warnings = append(database.Warnings, "conninfo has no password, set the password")
+	}
+
+	schedule := database.setSchedule(jobs, server.Name)
+
+	retentionPolicy := lookupIniValue("retention_policy", server, global)
+	count, window := parseBarmanRetentionPolicy(retentionPolicy)
+	database.setRetention(retentionPolicy, count, window, schedule)
+
+	return database
+}
+
+// parseBarmanRetentionPolicy reads "REDUNDANCY N" as a count of backups and
+// "RECOVERY WINDOW OF N DAYS|WEEKS|MONTHS" as a window
+func parseBarmanRetentionPolicy(retentionPolicy string) (int, time.Duration) {
+	fields := strings.Fields(strings.ToUpper(retentionPolicy))
+
+	if len(fields) == 2 && fields[0] == "REDUNDANCY" {
+		count, _ := strconv.Atoi(fields[1])
+		return count, 0
+	}
+
+	if len(fields) == 5 && fields[0] == "RECOVERY" && fields[1] == "WINDOW" &&
+		fields[2] == "OF" {
+		value, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return 0, 0
+		}
+
+		day := 24 * time.Hour
+
+		switch strings.TrimSuffix(fields[4], "S") {
+		case "DAY":
+			return 0, time.Duration(value) * day
+		case "WEEK":
+			return 0, time.Duration(value) * 7 * day
+		case "MONTH":
+			return 0, time.Duration(value) * 30 * day
+		}
+	}
+
+	return 0, 0
+}
+
+// parseConninfo reads libpq connection strings, both "key=value" pairs with optional
+// single quotes and postgresql:// URIs
+func parseConninfo(conninfo string) map[string]string {
+	conninfo = strings.TrimSpace(conninfo)
+	values := make(map[string]string)
+
+	if strings.HasPrefix(conninfo, "postgres://") || strings.HasPrefix(conninfo, "postgresql://") {
+		uri, err := url.Parse(conninfo)
+		if err != nil {
+			return values
+		}
+
+		values["host"] = uri.Hostname()
+		values["port"] = uri.Port()
+		values["dbname"] = strings.TrimPrefix(uri.Path, "/")
+
+		if uri.User != nil {
+			values["user"] = uri.User.Username()
+			values["password"], _ = uri.User.Password()
+		}
+
+		for key, queryValues := range uri.Query() {
+			if len(queryValues) > 0 && values[key] == "" {
+				values[key] = queryValues[0]
+			}
+		}
+
+		return values
+	}
+
+	for len(conninfo) > 0 {
+		key, rest, found := strings.Cut(conninfo, "=")
+		if !found {
+			break
+		}
+
+		value, rest := readConninfoValue(strings.TrimLeft(rest, " "))
+		values[strings.TrimSpace(key)] = value
+		conninfo = strings.TrimLeft(rest, " ")
+	}
+
+	return values
+}
+
+// readConninfoValue returns the value at the start of the text and the rest after it
+func readConninfoValue(text string) (string, string) {
+	if !strings.HasPrefix(text, "'") {
+		value, rest, _ := strings.Cut(text, " ")
+		return value, rest
+	}
+
+	var value strings.Builder
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if i+1 < len(text) {
+				i++
+				value.WriteByte(text[i])
+			}
+		case '\'':
+			return value.String(), text[i+1:]
+		default:
+			value.WriteByte(text[i])
+		}
+	}
+
+	return value.String(), ""
+}
+
+func isBarmanValueFalse(value string) bool {
+	switch strings.ToLower(value) {
+	case "false", "off", "no", "0":
+		return true
+	}
+
+	return false
+}
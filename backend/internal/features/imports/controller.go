@@ -0,0 +1,98 @@
+package imports
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImportController struct {
+	importService *ImportService
+}
+
+func (c *ImportController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/imports/parse", c.ParseImport)
+	router.POST("/imports/workspace/:workspaceId", c.ImportDatabases)
+}
+
+// ParseImport
+// @Summary Parse config of backup tool
+// @Description Read a pgBackRest, WAL-G or barman config with an optional crontab and return the databases, storages and schedules it would create. Nothing is created, the plan is reviewed and sent to the import endpoint
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Param request body ParseImportRequest true "Source, config and crontab"
+// @Success 200 {object} ImportPlan
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Router /imports/parse [post]
+func (c *ImportController) ParseImport(ctx *gin.Context) {
+	if _, ok := users_middleware.GetUserFromContext(ctx); !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request ParseImportRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := c.importService.ParseImport(&request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+// ImportDatabases
+// @Summary Import databases
+// @Description Create the databases of a reviewed plan with their storages and schedules. Each database is created on its own, failures are reported per database
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body ImportPlan true "Reviewed import plan"
+// @Success 200 {object} ImportResult
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Router /imports/workspace/{workspaceId} [post]
+func (c *ImportController) ImportDatabases(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var plan ImportPlan
+	if err := ctx.ShouldBindJSON(&plan); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.importService.ImportDatabases(user, workspaceID, &plan)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToImport) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,259 @@
+package imports
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronIntervalReference is where the interval between runs of a schedule is measured from,
+// a fixed Monday keeps plans of the same config the same
+var cronIntervalReference = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronBackupJob is a backup command of a crontab line. Target is the stanza of pgBackRest
+// or the server of barman, it is empty for WAL-G and "all" for "barman backup all"
+type cronBackupJob struct {
+	Target         string
+	CronExpression string
+	IsFull         bool
+}
+
+type crontabJobs struct {
+	Backups []cronBackupJob
+
+	// WalGRetainCount is N of "wal-g delete retain N", zero when the crontab has none
+	WalGRetainCount int
+}
+
+type cronSchedule struct {
+	CronExpression string
+
+	// FullBackupInterval is how often full backups of the tool are taken, retention
+	// counted in backups is converted to a period with it
+	FullBackupInterval time.Duration
+}
+
+func parseCrontab(text string) *crontabJobs {
+	jobs := &crontabJobs{Backups: make([]cronBackupJob, 0)}
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		var cronExpression string
+		var command []string
+
+		if strings.HasPrefix(fields[0], "@") {
+			// @reboot and unknown descriptors have no schedule to import
+			expression, ok := cronDescriptors[fields[0]]
+			if !ok {
+				continue
+			}
+
+			cronExpression = expression
+			command = fields[1:]
+		} else {
+			// lines setting variables like MAILTO have fewer fields or fail to parse
+			if len(fields) < 6 {
+				continue
+			}
+
+			cronExpression = strings.Join(fields[:5], " ")
+			command = fields[5:]
+		}
+
+		if _, err := parser.Parse(cronExpression); err != nil {
+			continue
+		}
+
+		jobs.parseCommand(cronExpression, command)
+	}
+
+	return jobs
+}
+
+// getSchedule picks the most frequent schedule among backups of the target, Databasus
+// takes a full dump on each run so incremental and differential schedules are merged
+func (j *crontabJobs) getSchedule(target string) (*cronSchedule, []string) {
+	warnings := make([]string, 0)
+
+	var mostFrequent *cronBackupJob
+	var mostFrequentInterval time.Duration
+	var fullBackupInterval time.Duration
+
+	matchedCount := 0
+	for _, job := range j.Backups {
+		if job.Target != target && job.Target != "all" {
+			continue
+		}
+
+		matchedCount++
+		interval := getCronInterval(job.CronExpression)
+
+		if mostFrequent == nil || interval < mostFrequentInterval {
+			mostFrequent = &job
+			mostFrequentInterval = interval
+		}
+
+		if job.IsFull && (fullBackupInterval == 0 || interval < fullBackupInterval) {
+			fullBackupInterval = interval
+		}
+	}
+
+	if mostFrequent == nil {
+		return nil, warnings
+	}
+
+	if matchedCount > 1 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%d backup schedules found, the most frequent one (%s) is used because each "+
+				"Databasus backup is a full dump",
+			matchedCount,
+			mostFrequent.CronExpression,
+		))
+	}
+
+	if fullBackupInterval == 0 {
+		fullBackupInterval = mostFrequentInterval
+	}
+
+	return &cronSchedule{
+		CronExpression:     mostFrequent.CronExpression,
+		FullBackupInterval: fullBackupInterval,
+	}, warnings
+}
+
+func (j *crontabJobs) parseCommand(cronExpression string, command []string) {
+	for i, field := range command {
+		args := command[i+1:]
+
+		switch path.Base(field) {
+		case "pgbackrest":
+			j.parsePgBackRestCommand(cronExpression, args)
+			return
+		case "wal-g":
+			j.parseWalGCommand(cronExpression, args)
+			return
+		case "barman":
+			j.parseBarmanCommand(cronExpression, args)
+			return
+		}
+	}
+}
+
+func (j *crontabJobs) parsePgBackRestCommand(cronExpression string, args []string) {
+	stanza := getCommandOption(args, "--stanza")
+	if stanza == "" || !slices.Contains(args, "backup") {
+		return
+	}
+
+	// pgBackRest takes incremental backups by default once a full one exists
+	backupType := getCommandOption(args, "--type")
+
+	j.Backups = append(j.Backups, cronBackupJob{
+		Target:         stanza,
+		CronExpression: cronExpression,
+		IsFull:         backupType == "full",
+	})
+}
+
+func (j *crontabJobs) parseWalGCommand(cronExpression string, args []string) {
+	if len(args) == 0 {
+		return
+	}
+
+	switch args[0] {
+	case "backup-push":
+		j.Backups = append(j.Backups, cronBackupJob{
+			CronExpression: cronExpression,
+			IsFull:         !slices.Contains(args, "--delta-from-name"),
+		})
+	case "delete":
+		// wal-g delete retain [FULL|FIND_FULL] N
+		if len(args) < 3 || args[1] != "retain" {
+			return
+		}
+
+		countArg := args[2]
+		if (countArg == "FULL" || countArg == "FIND_FULL") && len(args) > 3 {
+			countArg = args[3]
+		}
+
+		if count, err := strconv.Atoi(countArg); err == nil && count > 0 {
+			j.WalGRetainCount = count
+		}
+	}
+}
+
+func (j *crontabJobs) parseBarmanCommand(cronExpression string, args []string) {
+	for i, arg := range args {
+		if arg != "backup" || i+1 >= len(args) {
+			continue
+		}
+
+		for _, server := range args[i+1:] {
+			// the rest of the line is another shell command or a redirect
+			if strings.ContainsAny(server, ";&|>") {
+				break
+			}
+
+			if strings.HasPrefix(server, "-") {
+				continue
+			}
+
+			j.Backups = append(j.Backups, cronBackupJob{
+				Target:         server,
+				CronExpression: cronExpression,
+				IsFull:         true,
+			})
+		}
+
+		return
+	}
+}
+
+// getCommandOption reads "--name=value" and "--name value"
+func getCommandOption(args []string, name string) string {
+	for i, arg := range args {
+		if value, found := strings.CutPrefix(arg, name+"="); found {
+			return strings.Trim(value, `"'`)
+		}
+
+		if arg == name && i+1 < len(args) {
+			return strings.Trim(args[i+1], `"'`)
+		}
+	}
+
+	return ""
+}
+
+func getCronInterval(cronExpression string) time.Duration {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(cronExpression)
+	if err != nil {
+		return 0
+	}
+
+	firstRun := schedule.Next(cronIntervalReference)
+	return schedule.Next(firstRun).Sub(firstRun)
+}
@@ -0,0 +1,28 @@
+package imports
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var importService = &ImportService{
+	storages.GetStorageService(),
+	databases.GetDatabaseService(),
+	backups_config.GetBackupConfigService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+}
+var importController = &ImportController{
+	importService,
+}
+
+func GetImportService() *ImportService {
+	return importService
+}
+
+func GetImportController() *ImportController {
+	return importController
+}
@@ -0,0 +1,57 @@
+package imports
+
+import (
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/period"
+
+	"github.com/google/uuid"
+)
+
+type ParseImportRequest struct {
+	Source ImportSource `json:"source" binding:"required"`
+	Config string       `json:"config" binding:"required"`
+
+	// Crontab with backup commands of the tool, schedules and WAL-G retention are read
+	// from it
+	Crontab string `json:"crontab"`
+}
+
+// ImportPlan is what the import would create. It is returned for review, e.g. to fill
+// passwords the tool does not keep, and applied as it is sent back
+type ImportPlan struct {
+	Databases []*PlannedDatabase `json:"databases"`
+	Warnings  []string           `json:"warnings"`
+}
+
+// PlannedDatabase is a PostgreSQL database with its storage and schedule. Storage is nil
+// when the repository of the tool has no matching Databasus storage, and the schedule is
+// empty when no backup command of the database is in the crontab
+type PlannedDatabase struct {
+	Name string `json:"name"`
+
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	// Password is empty when the tool relies on .pgpass or peer auth
+	Password string `json:"password"`
+	Database string `json:"database"`
+
+	Storage        *storages.Storage `json:"storage"`
+	CronExpression string            `json:"cronExpression"`
+	StorePeriod    period.Period     `json:"storePeriod"`
+
+	Warnings []string `json:"warnings"`
+}
+
+type ImportResult struct {
+	Databases []ImportedDatabase `json:"databases"`
+}
+
+// ImportedDatabase has the error of the database when it was not imported, databases
+// are imported one by one so others are kept
+type ImportedDatabase struct {
+	Name       string     `json:"name"`
+	DatabaseID *uuid.UUID `json:"databaseId"`
+	StorageID  *uuid.UUID `json:"storageId"`
+	Error      string     `json:"error,omitempty"`
+}
@@ -0,0 +1,9 @@
+package imports
+
+type ImportSource string
+
+const (
+	ImportSourcePgBackRest ImportSource = "PGBACKREST"
+	ImportSourceWalG       ImportSource = "WALG"
+	ImportSourceBarman     ImportSource = "BARMAN"
+)
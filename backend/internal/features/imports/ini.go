@@ -0,0 +1,66 @@
+package imports
+
+import (
+	"bufio"
+	"strings"
+)
+
+// iniSection keeps keys lowercased, both pgBackRest and barman treat them case insensitively
+type iniSection struct {
+	Name   string
+	Values map[string]string
+}
+
+// parseIni reads sections in their order of appearance. Keys before the first section go
+// to a section without name, comments start with "#" or ";"
+func parseIni(text string) []*iniSection {
+	sections := []*iniSection{{Values: make(map[string]string)}}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections = append(sections, &iniSection{
+				Name:   strings.TrimSpace(line[1 : len(line)-1]),
+				Values: make(map[string]string),
+			})
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		section := sections[len(sections)-1]
+		section.Values[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+
+	return sections
+}
+
+func findIniSection(sections []*iniSection, name string) *iniSection {
+	for _, section := range sections {
+		if section.Name == name {
+			return section
+		}
+	}
+
+	return &iniSection{Name: name, Values: make(map[string]string)}
+}
+
+// lookupIniValue returns the first value of the key found in the sections
+func lookupIniValue(key string, sections ...*iniSection) string {
+	for _, section := range sections {
+		if value, ok := section.Values[key]; ok && value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
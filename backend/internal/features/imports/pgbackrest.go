@@ -0,0 +1,195 @@
+package imports
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/features/storages"
+	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
+)
+
+const (
+	pgBackRestDefaultRepoPath = "/var/lib/pgbackrest"
+
+	// importedPrefixDirectory keeps Databasus files apart from the files of the tool when
+	// both write to the same bucket
+	importedPrefixDirectory = "databasus"
+)
+
+// parsePgBackRestConfig reads pgbackrest.conf, each stanza is a database. Options of a
+// stanza override [global], only repo1 and pg1 are imported
+func parsePgBackRestConfig(config string, jobs *crontabJobs) *ImportPlan {
+	sections := parseIni(config)
+	global := findIniSection(sections, "global")
+
+	plan := &ImportPlan{Databases: make([]*PlannedDatabase, 0), Warnings: make([]string, 0)}
+
+	for _, section := range sections {
+		// sections like [global:archive-push] hold options of a single command
+		if section.Name == "" || section.Name == "global" || strings.Contains(section.Name, ":") {
+			continue
+		}
+
+		plan.Databases = append(plan.Databases, parsePgBackRestStanza(section, global, jobs))
+	}
+
+	if len(plan.Databases) == 0 {
+		plan.Warnings = append(plan.Warnings, "no stanza found in the config")
+	}
+
+	return plan
+}
+
+func parsePgBackRestStanza(
+	stanza *iniSection,
+	global *iniSection,
+	jobs *crontabJobs,
+) *PlannedDatabase {
+	lookup := func(key string) string {
+		return lookupIniValue(key, stanza, global)
+	}
+
+	database := &PlannedDatabase{
+		Name:     stanza.Name,
+		Host:     lookup("pg1-host"),
+		Port:     parsePort(lookup("pg1-port")),
+		Username: valueOrDefault(lookup("pg1-user"), "postgres"),
+		Database: valueOrDefault(lookup("pg1-database"), "postgres"),
+		Warnings: []string{"pgBackRest connects without password, set the password"},
+	}
+
+	if database.Host == "" {
+		database.Warnings = append(
+			database.Warnings,
+			"pg1-host is not set, PostgreSQL runs on the pgBackRest host, set its address",
+		)
+	}
+
+	if lookup("pg2-path") != "" || lookup("pg2-host") != "" {
+		database.Warnings = append(
+			database.Warnings,
+			"only pg1 is imported, backups of standbys are not needed with Databasus",
+		)
+	}
+
+	database.Storage = parsePgBackRestRepo(stanza.Name, lookup, database)
+
+	schedule := database.setSchedule(jobs, stanza.Name)
+
+	retentionFull := lookup("repo1-retention-full")
+	retentionCount, _ := strconv.Atoi(retentionFull)
+
+	if lookup("repo1-retention-full-type") == "time" {
+		database.setRetention(
+			retentionFull+" days",
+			0,
+			time.Duration(retentionCount)*24*time.Hour,
+			schedule,
+		)
+	} else {
+		database.setRetention(retentionFull, retentionCount, 0, schedule)
+	}
+
+	return database
+}
+
+func parsePgBackRestRepo(
+	stanzaName string,
+	lookup func(string) string,
+	database *PlannedDatabase,
+) *storages.Storage {
+	repoValue := func(option string) string {
+		if value := lookup("repo1-" + option); value != "" {
+			return value
+		}
+
+		return lookup("repo-" + option)
+	}
+
+	repoPath := valueOrDefault(repoValue("path"), pgBackRestDefaultRepoPath)
+	prefix := strings.Trim(path.Join(repoPath, importedPrefixDirectory), "/")
+
+	repoType := valueOrDefault(repoValue("type"), "posix")
+
+	switch repoType {
+	case "s3":
+		bucket := repoValue("s3-bucket")
+
+		return &storages.Storage{
+			Type: storages.StorageTypeS3,
+			Name: "pgBackRest: " + bucket,
+			S3Storage: &s3_storage.S3Storage{
+				S3Bucket:                bucket,
+				S3Region:                repoValue("s3-region"),
+				S3AccessKey:             repoValue("s3-key"),
+				S3SecretKey:             repoValue("s3-key-secret"),
+				S3Endpoint:              normalizeS3Endpoint(repoValue("s3-endpoint")),
+				S3Prefix:                prefix,
+				S3UseVirtualHostedStyle: repoValue("s3-uri-style") != "path",
+				SkipTLSVerify:           repoValue("storage-verify-tls") == "n",
+			},
+		}
+	case "azure":
+		container := repoValue("azure-container")
+
+		if repoValue("azure-key-type") == "sas" {
+			database.Warnings = append(
+				database.Warnings,
+				"SAS keys are not supported by Azure storages, set the account key",
+			)
+		}
+
+		return &storages.Storage{
+			Type: storages.StorageTypeAzureBlob,
+			Name: "pgBackRest: " + container,
+			AzureBlobStorage: &azure_blob_storage.AzureBlobStorage{
+				AuthMethod:    azure_blob_storage.AuthMethodAccountKey,
+				AccountName:   repoValue("azure-account"),
+				AccountKey:    repoValue("azure-key"),
+				ContainerName: container,
+				Prefix:        prefix,
+			},
+		}
+	default:
+		database.Warnings = append(database.Warnings, fmt.Sprintf(
+			"repository type %q of stanza %s has no matching storage, choose a storage",
+			repoType,
+			stanzaName,
+		))
+
+		return nil
+	}
+}
+
+// normalizeS3Endpoint leaves AWS endpoints empty so the endpoint of the region is used
+func normalizeS3Endpoint(endpoint string) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	host := strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://")
+	if strings.HasSuffix(host, "amazonaws.com") {
+		return ""
+	}
+
+	return endpoint
+}
+
+func parsePort(value string) int {
+	port, err := strconv.Atoi(value)
+	if err != nil || port <= 0 {
+		return 5432
+	}
+
+	return port
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,77 @@
+package imports
+
+import (
+	"fmt"
+	"time"
+
+	"databasus-backend/internal/util/period"
+)
+
+var storePeriods = []period.Period{
+	period.PeriodDay,
+	period.PeriodWeek,
+	period.PeriodMonth,
+	period.Period3Month,
+	period.Period6Month,
+	period.PeriodYear,
+	period.Period2Years,
+	period.Period3Years,
+	period.Period4Years,
+	period.Period5Years,
+}
+
+// getStorePeriod rounds the retention up so imported backups are kept at least as long as
+// the tool kept them
+func getStorePeriod(retention time.Duration) period.Period {
+	for _, storePeriod := range storePeriods {
+		if storePeriod.ToDuration() >= retention {
+			return storePeriod
+		}
+	}
+
+	return period.PeriodForever
+}
+
+// setRetention sets the store period from the retention of the tool. A count of backups
+// needs the interval between full backups, without it the default store period is kept
+func (d *PlannedDatabase) setRetention(
+	description string,
+	count int,
+	window time.Duration,
+	schedule *cronSchedule,
+) {
+	if window > 0 {
+		d.StorePeriod = getStorePeriod(window)
+		return
+	}
+
+	if count > 0 && schedule != nil && schedule.FullBackupInterval > 0 {
+		d.StorePeriod = getStorePeriod(time.Duration(count) * schedule.FullBackupInterval)
+		return
+	}
+
+	if description == "" {
+		d.Warnings = append(d.Warnings, "no retention found, the default store period is used")
+		return
+	}
+
+	d.Warnings = append(d.Warnings, fmt.Sprintf(
+		"retention %q cannot be converted without a full backup schedule, "+
+			"the default store period is used",
+		description,
+	))
+}
+
+func (d *PlannedDatabase) setSchedule(jobs *crontabJobs, target string) *cronSchedule {
+	schedule, warnings := jobs.getSchedule(target)
+	d.Warnings = append(d.Warnings, warnings...)
+
+	if schedule == nil {
+		d.Warnings = append(d.Warnings, "no backup command found in the crontab, set the schedule")
+		return nil
+	}
+
+	d.CronExpression = schedule.CronExpression
+
+	return schedule
+}
@@ -0,0 +1,214 @@
+package imports
+
+import (
+	"errors"
+	"fmt"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/databases/databases/postgresql"
+	"databasus-backend/internal/features/intervals"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInsufficientPermissionsToImport = errors.New(
+		"insufficient permissions to import databases in this workspace",
+	)
+	ErrUnknownImportSource = errors.New("unknown import source")
+)
+
+type ImportService struct {
+	storageService      *storages.StorageService
+	databaseService     *databases.DatabaseService
+	backupConfigService *backups_config.BackupConfigService
+	workspaceService    *workspaces_services.WorkspaceService
+	auditLogService     *audit_logs.AuditLogService
+}
+
+// ParseImport builds the plan of the config without creating anything
+func (s *ImportService) ParseImport(request *ParseImportRequest) (*ImportPlan, error) {
+	jobs := parseCrontab(request.Crontab)
+
+	switch request.Source {
+	case ImportSourcePgBackRest:
+		return parsePgBackRestConfig(request.Config, jobs), nil
+	case ImportSourceWalG:
+		return parseWalGConfig(request.Config, jobs)
+	case ImportSourceBarman:
+		return parseBarmanConfig(request.Config, jobs), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownImportSource, request.Source)
+	}
+}
+
+// ImportDatabases creates databases of the plan with their storages and schedules.
+// Databases are created one by one, a failed database is reported and the rest are kept.
+// Databases sharing a repository share the storage
+func (s *ImportService) ImportDatabases(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	plan *ImportPlan,
+) (*ImportResult, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToImport
+	}
+
+	result := &ImportResult{Databases: make([]ImportedDatabase, 0, len(plan.Databases))}
+	createdStorages := make(map[string]*storages.Storage)
+	importedCount := 0
+
+	for _, plannedDatabase := range plan.Databases {
+		imported := ImportedDatabase{Name: plannedDatabase.Name}
+
+		err := s.importDatabase(user, workspaceID, plannedDatabase, createdStorages, &imported)
+		if err != nil {
+			imported.Error = err.Error()
+		} else {
+			importedCount++
+		}
+
+		result.Databases = append(result.Databases, imported)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Imported %d of %d databases", importedCount, len(plan.Databases)),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return result, nil
+}
+
+func (s *ImportService) importDatabase(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	plannedDatabase *PlannedDatabase,
+	createdStorages map[string]*storages.Storage,
+	imported *ImportedDatabase,
+) error {
+	storage, err := s.getOrCreateStorage(user, workspaceID, plannedDatabase, createdStorages)
+	if err != nil {
+		return fmt.Errorf("failed to create storage: %w", err)
+	}
+	if storage != nil {
+		imported.StorageID = &storage.ID
+	}
+
+	databaseName := plannedDatabase.Database
+	database, err := s.databaseService.CreateDatabase(user, workspaceID, &databases.Database{
+		Name: plannedDatabase.Name,
+		Type: databases.DatabaseTypePostgres,
+		Postgresql: &postgresql.PostgresqlDatabase{
+			Host:     plannedDatabase.Host,
+			Port:     plannedDatabase.Port,
+			Username: plannedDatabase.Username,
+			Password: plannedDatabase.Password,
+			Database: &databaseName,
+			CpuCount: 1,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create database: %w", err)
+	}
+	imported.DatabaseID = &database.ID
+
+	if plannedDatabase.CronExpression == "" && plannedDatabase.StorePeriod == "" &&
+		storage == nil {
+		return nil
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+	if err != nil {
+		return err
+	}
+
+	if plannedDatabase.CronExpression != "" {
+		cronExpression := plannedDatabase.CronExpression
+
+		if backupConfig.BackupInterval == nil {
+			backupConfig.BackupInterval = &intervals.Interval{}
+		}
+		backupConfig.BackupInterval.Interval = intervals.IntervalCron
+		backupConfig.BackupInterval.CronExpression = &cronExpression
+	}
+
+	if plannedDatabase.StorePeriod != "" {
+		backupConfig.StorePeriod = plannedDatabase.StorePeriod
+	}
+
+	// backups are enabled only once they have somewhere to go
+	if storage != nil {
+		backupConfig.Storage = storage
+		backupConfig.StorageID = &storage.ID
+		backupConfig.IsBackupsEnabled = true
+	}
+
+	if _, err := s.backupConfigService.SaveBackupConfigWithAuth(user, backupConfig); err != nil {
+		return fmt.Errorf("failed to save backup config: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ImportService) getOrCreateStorage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	plannedDatabase *PlannedDatabase,
+	createdStorages map[string]*storages.Storage,
+) (*storages.Storage, error) {
+	if plannedDatabase.Storage == nil {
+		return nil, nil
+	}
+
+	storageKey := getStorageKey(plannedDatabase.Storage)
+	if storage, ok := createdStorages[storageKey]; ok {
+		return storage, nil
+	}
+
+	storage := plannedDatabase.Storage
+	storage.ID = uuid.Nil
+	storage.IsSystem = false
+
+	if err := s.storageService.SaveStorage(user, workspaceID, storage); err != nil {
+		return nil, err
+	}
+
+	createdStorages[storageKey] = storage
+
+	return storage, nil
+}
+
+// getStorageKey identifies the repository of a planned storage, stanzas of pgBackRest
+// usually share it
+func getStorageKey(storage *storages.Storage) string {
+	switch {
+	case storage.S3Storage != nil:
+		return fmt.Sprintf(
+			"%s|%s|%s|%s",
+			storage.Type,
+			storage.S3Storage.S3Endpoint,
+			storage.S3Storage.S3Bucket,
+			storage.S3Storage.S3Prefix,
+		)
+	case storage.AzureBlobStorage != nil:
+		return fmt.Sprintf(
+			"%s|%s|%s|%s",
+			storage.Type,
+			storage.AzureBlobStorage.AccountName,
+			storage.AzureBlobStorage.ContainerName,
+			storage.AzureBlobStorage.Prefix,
+		)
+	default:
+		return fmt.Sprintf("%s|%s", storage.Type, storage.Name)
+	}
+}
@@ -0,0 +1,150 @@
+package imports
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"databasus-backend/internal/features/storages"
+	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
+)
+
+// parseWalGConfig reads the environment of WAL-G, either as env lines or as the JSON of
+// .walg.json. WAL-G backs up a single cluster so the plan has one database
+func parseWalGConfig(config string, jobs *crontabJobs) (*ImportPlan, error) {
+	variables, err := parseWalGVariables(config)
+	if err != nil {
+		return nil, err
+	}
+
+	database := &PlannedDatabase{
+		Host:     variables["PGHOST"],
+		Port:     parsePort(variables["PGPORT"]),
+		Username: valueOrDefault(variables["PGUSER"], "postgres"),
+		Password: variables["PGPASSWORD"],
+		Database: valueOrDefault(variables["PGDATABASE"], "postgres"),
+		Warnings: make([]string, 0),
+	}
+	database.Name = database.Database
+
+	// a path in PGHOST is the socket directory of a local server
+	if database.Host == "" || strings.HasPrefix(database.Host, "/") {
+		database.Host = ""
+		database.Warnings = append(
+			database.Warnings,
+			"PGHOST is not set, PostgreSQL runs on the WAL-G host, set its address",
+		)
+	}
+
+	if database.Password == "" {
+		database.Warnings = append(database.Warnings, "PGPASSWORD is not set, set the password")
+	}
+
+	database.Storage = parseWalGStorage(variables, database)
+
+	schedule := database.setSchedule(jobs, "")
+
+	retention := ""
+	if jobs.WalGRetainCount > 0 {
+		retention = strconv.Itoa(jobs.WalGRetainCount)
+	}
+	database.setRetention(retention, jobs.WalGRetainCount, 0, schedule)
+
+	return &ImportPlan{Databases: []*PlannedDatabase{database}, Warnings: make([]string, 0)}, nil
+}
+
+func parseWalGVariables(config string) (map[string]string, error) {
+	variables := make(map[string]string)
+
+	if strings.HasPrefix(strings.TrimSpace(config), "{") {
+		var values map[string]any
+		if err := json.Unmarshal([]byte(config), &values); err != nil {
+			return nil, fmt.Errorf("invalid WAL-G JSON config: %w", err)
+		}
+
+		for key, value := range values {
+			variables[key] = fmt.Sprint(value)
+		}
+
+		return variables, nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(config))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !found {
+			continue
+		}
+
+		variables[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return variables, nil
+}
+
+func parseWalGStorage(variables map[string]string, database *PlannedDatabase) *storages.Storage {
+	if prefix := variables["WALG_S3_PREFIX"]; prefix != "" {
+		bucket, objectPath := splitStorageURL(prefix, "s3://")
+
+		return &storages.Storage{
+			Type: storages.StorageTypeS3,
+			Name: "WAL-G: " + bucket,
+			S3Storage: &s3_storage.S3Storage{
+				S3Bucket:                bucket,
+				S3Region:                variables["AWS_REGION"],
+				S3AccessKey:             variables["AWS_ACCESS_KEY_ID"],
+				S3SecretKey:             variables["AWS_SECRET_ACCESS_KEY"],
+				S3Endpoint:              normalizeS3Endpoint(variables["AWS_ENDPOINT"]),
+				S3Prefix:                objectPath,
+				S3UseVirtualHostedStyle: variables["AWS_S3_FORCE_PATH_STYLE"] != "true",
+			},
+		}
+	}
+
+	if prefix := variables["WALG_AZ_PREFIX"]; prefix != "" {
+		container, objectPath := splitStorageURL(prefix, "azure://")
+
+		if variables["AZURE_STORAGE_SAS_TOKEN"] != "" {
+			database.Warnings = append(
+				database.Warnings,
+				"SAS tokens are not supported by Azure storages, set the account key",
+			)
+		}
+
+		return &storages.Storage{
+			Type: storages.StorageTypeAzureBlob,
+			Name: "WAL-G: " + container,
+			AzureBlobStorage: &azure_blob_storage.AzureBlobStorage{
+				AuthMethod:    azure_blob_storage.AuthMethodAccountKey,
+				AccountName:   variables["AZURE_STORAGE_ACCOUNT"],
+				AccountKey:    variables["AZURE_STORAGE_ACCESS_KEY"],
+				ContainerName: container,
+				Prefix:        objectPath,
+			},
+		}
+	}
+
+	database.Warnings = append(
+		database.Warnings,
+		"only WALG_S3_PREFIX and WALG_AZ_PREFIX have matching storages, choose a storage",
+	)
+
+	return nil
+}
+
+// splitStorageURL splits "scheme://bucket/path" into the bucket and the prefix for
+// Databasus files under the path
+func splitStorageURL(storageURL string, scheme string) (string, string) {
+	bucket, objectPath, _ := strings.Cut(strings.TrimPrefix(storageURL, scheme), "/")
+
+	return bucket, strings.Trim(path.Join(objectPath, importedPrefixDirectory), "/")
+}
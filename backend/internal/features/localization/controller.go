@@ -0,0 +1,134 @@
+package localization
+
+import (
+	"errors"
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocalizationController struct {
+	localizationService *LocalizationService
+}
+
+func (c *LocalizationController) RegisterRoutes(router *gin.RouterGroup) {
+	adminOnly := users_middleware.RequireRole(users_enums.UserRoleAdmin)
+
+	router.GET("/localization/messages", adminOnly, c.GetMessages)
+	router.PUT("/localization/overrides", adminOnly, c.UpsertOverride)
+	router.DELETE("/localization/overrides/:locale/:key", adminOnly, c.DeleteOverride)
+}
+
+// GetMessages
+// @Summary Get message templates
+// @Description Get built-in templates and admin overrides of notification and email messages for a locale (admin only)
+// @Tags localization
+// @Produce json
+// @Security BearerAuth
+// @Param locale query string false "Locale, defaults to en"
+// @Success 200 {object} GetMessagesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /localization/messages [get]
+func (c *LocalizationController) GetMessages(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	locale := i18n.Locale(ctx.DefaultQuery("locale", string(i18n.DefaultLocale)))
+
+	response, err := c.localizationService.GetMessages(user, locale)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpsertOverride
+// @Summary Override message template
+// @Description Replace built-in template of a message for a locale (admin only)
+// @Tags localization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpsertOverrideRequest true "Template override"
+// @Success 200 {object} MessageTemplateOverride
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /localization/overrides [put]
+func (c *LocalizationController) UpsertOverride(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request UpsertOverrideRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	override, err := c.localizationService.UpsertOverride(user, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, override)
+}
+
+// DeleteOverride
+// @Summary Reset message template
+// @Description Remove template override so built-in template is used again (admin only)
+// @Tags localization
+// @Produce json
+// @Security BearerAuth
+// @Param locale path string true "Locale"
+// @Param key path string true "Message key"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /localization/overrides/{locale}/{key} [delete]
+func (c *LocalizationController) DeleteOverride(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	err := c.localizationService.DeleteOverride(
+		user,
+		i18n.Locale(ctx.Param("locale")),
+		i18n.MessageKey(ctx.Param("key")),
+	)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Template override removed"})
+}
+
+func (c *LocalizationController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInsufficientPermissionsToManageTemplates):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrOverrideNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
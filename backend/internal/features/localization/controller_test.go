@@ -0,0 +1,115 @@
+package localization
+
+import (
+	"net/http"
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/i18n"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UpsertOverride_WhenAdminOverridesTemplate_TranslateUsesOverride(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetLocalizationController())
+	SetupDependencies()
+
+	request := UpsertOverrideRequest{
+		Locale:   i18n.LocaleDe,
+		Key:      i18n.MessageDatabaseOnlineTitle,
+		Template: "[{database}] ist wieder da",
+	}
+	defer deleteOverride(t, admin.Token, request.Locale, request.Key)
+
+	var override MessageTemplateOverride
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/localization/overrides",
+		"Bearer "+admin.Token,
+		request,
+		http.StatusOK,
+		&override,
+	)
+
+	assert.Equal(t, request.Template, override.Template)
+	assert.Equal(
+		t,
+		"[main] ist wieder da",
+		i18n.Translate(
+			i18n.LocaleDe,
+			i18n.MessageDatabaseOnlineTitle,
+			map[string]string{"database": "main"},
+		),
+	)
+
+	var response GetMessagesResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/localization/messages?locale=de",
+		"Bearer "+admin.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	isOverrideListed := false
+	for _, message := range response.Messages {
+		if message.Key == request.Key && message.Override != nil {
+			isOverrideListed = *message.Override == request.Template
+		}
+	}
+	assert.True(t, isOverrideListed)
+}
+
+func Test_UpsertOverride_WithUnknownKey_ReturnsBadRequest(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetLocalizationController())
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/localization/overrides",
+		"Bearer "+admin.Token,
+		UpsertOverrideRequest{
+			Locale:   i18n.LocaleEn,
+			Key:      "unknown_key",
+			Template: "Hello",
+		},
+		http.StatusBadRequest,
+	)
+}
+
+func Test_UpsertOverride_WhenUserIsNotAdmin_ReturnsForbidden(t *testing.T) {
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetLocalizationController())
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/localization/overrides",
+		"Bearer "+member.Token,
+		UpsertOverrideRequest{
+			Locale:   i18n.LocaleEn,
+			Key:      i18n.MessageBackupFailedTitle,
+			Template: "Hello",
+		},
+		http.StatusForbidden,
+	)
+}
+
+func deleteOverride(t *testing.T, token string, locale i18n.Locale, key i18n.MessageKey) {
+	router := workspaces_testing.CreateTestRouter(GetLocalizationController())
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/localization/overrides/"+string(locale)+"/"+string(key),
+		"Bearer "+token,
+		http.StatusOK,
+	)
+}
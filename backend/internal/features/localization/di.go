@@ -0,0 +1,47 @@
+package localization
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/util/i18n"
+	"databasus-backend/internal/util/logger"
+)
+
+var overrideRepository = &MessageTemplateOverrideRepository{}
+var localizationService = &LocalizationService{
+	overrideRepository,
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var localizationController = &LocalizationController{
+	localizationService,
+}
+
+func GetLocalizationService() *LocalizationService {
+	return localizationService
+}
+
+func GetLocalizationController() *LocalizationController {
+	return localizationController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		i18n.SetTemplateOverrideSource(localizationService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
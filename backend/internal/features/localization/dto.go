@@ -0,0 +1,21 @@
+package localization
+
+import "databasus-backend/internal/util/i18n"
+
+type GetMessagesResponse struct {
+	Locale           i18n.Locale           `json:"locale"`
+	SupportedLocales []i18n.Locale         `json:"supportedLocales"`
+	Messages         []LocalizedMessageDTO `json:"messages"`
+}
+
+type LocalizedMessageDTO struct {
+	Key             i18n.MessageKey `json:"key"`
+	DefaultTemplate string          `json:"defaultTemplate"`
+	Override        *string         `json:"override"`
+}
+
+type UpsertOverrideRequest struct {
+	Locale   i18n.Locale     `json:"locale"   binding:"required"`
+	Key      i18n.MessageKey `json:"key"      binding:"required"`
+	Template string          `json:"template" binding:"required"`
+}
@@ -0,0 +1,24 @@
+package localization
+
+import "errors"
+
+var (
+	ErrInsufficientPermissionsToManageTemplates = errors.New(
+		"insufficient permissions to manage message templates",
+	)
+	ErrUnsupportedLocale = errors.New(
+		"unsupported locale",
+	)
+	ErrUnknownMessageKey = errors.New(
+		"unknown message key",
+	)
+	ErrTemplateIsRequired = errors.New(
+		"template is required",
+	)
+	ErrTemplateIsTooLong = errors.New(
+		"template is too long",
+	)
+	ErrOverrideNotFound = errors.New(
+		"template override not found",
+	)
+)
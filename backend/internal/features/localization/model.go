@@ -0,0 +1,21 @@
+package localization
+
+import (
+	"time"
+
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+)
+
+type MessageTemplateOverride struct {
+	ID         uuid.UUID       `json:"id"         gorm:"column:id;type:uuid;primaryKey"`
+	Locale     i18n.Locale     `json:"locale"     gorm:"column:locale;type:text;not null"`
+	MessageKey i18n.MessageKey `json:"messageKey" gorm:"column:message_key;type:text;not null"`
+	Template   string          `json:"template"   gorm:"column:template;type:text;not null"`
+	UpdatedAt  time.Time       `json:"updatedAt"  gorm:"column:updated_at;not null"`
+}
+
+func (MessageTemplateOverride) TableName() string {
+	return "message_template_overrides"
+}
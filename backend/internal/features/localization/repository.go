@@ -0,0 +1,63 @@
+package localization
+
+import (
+	"errors"
+	"time"
+
+	"databasus-backend/internal/storage"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MessageTemplateOverrideRepository struct{}
+
+func (r *MessageTemplateOverrideRepository) Upsert(override *MessageTemplateOverride) error {
+	if override.ID == uuid.Nil {
+		override.ID = uuid.New()
+	}
+
+	override.UpdatedAt = time.Now().UTC()
+
+	return storage.GetDb().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "locale"}, {Name: "message_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"template", "updated_at"}),
+	}).Create(override).Error
+}
+
+func (r *MessageTemplateOverrideRepository) FindByLocaleAndKey(
+	locale i18n.Locale,
+	key i18n.MessageKey,
+) (*MessageTemplateOverride, error) {
+	var override MessageTemplateOverride
+
+	err := storage.GetDb().
+		Where("locale = ? AND message_key = ?", locale, key).
+		First(&override).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *MessageTemplateOverrideRepository) FindByLocale(
+	locale i18n.Locale,
+) ([]*MessageTemplateOverride, error) {
+	var overrides []*MessageTemplateOverride
+
+	err := storage.GetDb().Where("locale = ?", locale).Find(&overrides).Error
+
+	return overrides, err
+}
+
+func (r *MessageTemplateOverrideRepository) Delete(id uuid.UUID) error {
+	return storage.GetDb().Delete(&MessageTemplateOverride{}, "id = ?", id).Error
+}
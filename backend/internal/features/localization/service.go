@@ -0,0 +1,173 @@
+package localization
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/i18n"
+)
+
+const maxTemplateLength = 4096
+
+type LocalizationService struct {
+	overrideRepository *MessageTemplateOverrideRepository
+	auditLogService    *audit_logs.AuditLogService
+	logger             *slog.Logger
+}
+
+func (s *LocalizationService) GetMessages(
+	user *users_models.User,
+	locale i18n.Locale,
+) (*GetMessagesResponse, error) {
+	if !user.CanUpdateSettings() {
+		return nil, ErrInsufficientPermissionsToManageTemplates
+	}
+
+	if !i18n.IsSupportedLocale(locale) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+
+	overrides, err := s.overrideRepository.FindByLocale(locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template overrides: %w", err)
+	}
+
+	overridesByKey := make(map[i18n.MessageKey]string, len(overrides))
+	for _, override := range overrides {
+		overridesByKey[override.MessageKey] = override.Template
+	}
+
+	keys := i18n.GetMessageKeys()
+	messages := make([]LocalizedMessageDTO, 0, len(keys))
+	for _, key := range keys {
+		message := LocalizedMessageDTO{
+			Key:             key,
+			DefaultTemplate: i18n.GetDefaultTemplate(locale, key),
+		}
+
+		if template, isFound := overridesByKey[key]; isFound {
+			message.Override = &template
+		}
+
+		messages = append(messages, message)
+	}
+
+	return &GetMessagesResponse{
+		Locale:           locale,
+		SupportedLocales: i18n.GetSupportedLocales(),
+		Messages:         messages,
+	}, nil
+}
+
+func (s *LocalizationService) UpsertOverride(
+	user *users_models.User,
+	request *UpsertOverrideRequest,
+) (*MessageTemplateOverride, error) {
+	if !user.CanUpdateSettings() {
+		return nil, ErrInsufficientPermissionsToManageTemplates
+	}
+
+	if err := s.validateLocaleAndKey(request.Locale, request.Key); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(request.Template) == "" {
+		return nil, ErrTemplateIsRequired
+	}
+
+	if len(request.Template) > maxTemplateLength {
+		return nil, fmt.Errorf("%w: max %d characters", ErrTemplateIsTooLong, maxTemplateLength)
+	}
+
+	override := &MessageTemplateOverride{
+		Locale:     request.Locale,
+		MessageKey: request.Key,
+		Template:   request.Template,
+	}
+
+	if err := s.overrideRepository.Upsert(override); err != nil {
+		return nil, fmt.Errorf("failed to save template override: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Message template %s overridden for locale %s", request.Key, request.Locale),
+		&user.ID,
+		nil,
+	)
+
+	return s.overrideRepository.FindByLocaleAndKey(request.Locale, request.Key)
+}
+
+func (s *LocalizationService) DeleteOverride(
+	user *users_models.User,
+	locale i18n.Locale,
+	key i18n.MessageKey,
+) error {
+	if !user.CanUpdateSettings() {
+		return ErrInsufficientPermissionsToManageTemplates
+	}
+
+	if err := s.validateLocaleAndKey(locale, key); err != nil {
+		return err
+	}
+
+	override, err := s.overrideRepository.FindByLocaleAndKey(locale, key)
+	if err != nil {
+		return fmt.Errorf("failed to get template override: %w", err)
+	}
+
+	if override == nil {
+		return ErrOverrideNotFound
+	}
+
+	if err := s.overrideRepository.Delete(override.ID); err != nil {
+		return fmt.Errorf("failed to delete template override: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Message template %s reset to default for locale %s", key, locale),
+		&user.ID,
+		nil,
+	)
+
+	return nil
+}
+
+// GetTemplateOverride implements i18n.TemplateOverrideSource. Lookup
+// errors fall back to built-in template, so notifications are still sent
+func (s *LocalizationService) GetTemplateOverride(
+	locale i18n.Locale,
+	key i18n.MessageKey,
+) (string, bool) {
+	override, err := s.overrideRepository.FindByLocaleAndKey(locale, key)
+	if err != nil {
+		s.logger.Error(
+			"failed to get template override",
+			"locale", locale,
+			"key", key,
+			"error", err,
+		)
+		return "", false
+	}
+
+	if override == nil {
+		return "", false
+	}
+
+	return override.Template, true
+}
+
+func (s *LocalizationService) validateLocaleAndKey(locale i18n.Locale, key i18n.MessageKey) error {
+	if !i18n.IsSupportedLocale(locale) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+
+	if !i18n.IsKnownMessageKey(key) {
+		return fmt.Errorf("%w: %s", ErrUnknownMessageKey, key)
+	}
+
+	return nil
+}
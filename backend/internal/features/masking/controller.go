@@ -0,0 +1,90 @@
+package masking
+
+import (
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MaskingController struct {
+	maskingService *MaskingService
+}
+
+func (c *MaskingController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/masking-rules/:databaseId", c.GetRules)
+	router.PUT("/masking-rules/:databaseId", c.SaveRules)
+}
+
+// GetRules
+// @Summary Get masking rules
+// @Description Get column masking rules applied to restores of a database
+// @Tags masking-rules
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Success 200 {array} MaskingRule
+// @Failure 400
+// @Failure 401
+// @Router /masking-rules/{databaseId} [get]
+func (c *MaskingController) GetRules(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	rules, err := c.maskingService.GetRules(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// SaveRules
+// @Summary Save masking rules
+// @Description Replace column masking rules of a database
+// @Tags masking-rules
+// @Accept json
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Param request body SaveMaskingRulesRequest true "Masking rules"
+// @Success 200 {array} MaskingRule
+// @Failure 400
+// @Failure 401
+// @Router /masking-rules/{databaseId} [put]
+func (c *MaskingController) SaveRules(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request SaveMaskingRulesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := c.maskingService.SaveRules(user, databaseID, request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
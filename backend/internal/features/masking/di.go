@@ -0,0 +1,52 @@
+package masking
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var maskingRuleRepository = &MaskingRuleRepository{}
+var maskingService = &MaskingService{
+	databases.GetDatabaseService(),
+	maskingRuleRepository,
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var maskingController = &MaskingController{
+	maskingService,
+}
+
+func GetMaskingService() *MaskingService {
+	return maskingService
+}
+
+func GetMaskingController() *MaskingController {
+	return maskingController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		databases.GetDatabaseService().AddDbCopyListener(maskingService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
@@ -0,0 +1,5 @@
+package masking
+
+type SaveMaskingRulesRequest struct {
+	Rules []*MaskingRule `json:"rules"`
+}
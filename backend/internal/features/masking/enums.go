@@ -0,0 +1,14 @@
+package masking
+
+type MaskingStrategy string
+
+const (
+	// MaskingStrategyHash replaces the value with its MD5, equal values stay equal so
+	// joins on the column keep working
+	MaskingStrategyHash MaskingStrategy = "HASH"
+	// MaskingStrategyEmail replaces the value with a unique address of a reserved domain
+	MaskingStrategyEmail    MaskingStrategy = "EMAIL"
+	MaskingStrategyNull     MaskingStrategy = "NULL"
+	MaskingStrategyRedact   MaskingStrategy = "REDACT"
+	MaskingStrategyKeepLast MaskingStrategy = "KEEP_LAST_4"
+)
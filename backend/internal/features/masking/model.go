@@ -0,0 +1,51 @@
+package masking
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaskingRule masks one column of a restored database. Rules run after the restore as
+// UPDATE statements, so they apply to text columns of the target
+type MaskingRule struct {
+	ID         uuid.UUID `json:"id"         gorm:"column:id;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;not null"`
+
+	// Schema is the PostgreSQL schema, public when empty. MySQL and MariaDB mask tables of
+	// the restored database when empty
+	Schema   string          `json:"schema"   gorm:"column:schema_name;type:text;not null;default:''"`
+	Table    string          `json:"table"    gorm:"column:table_name;type:text;not null"`
+	Column   string          `json:"column"   gorm:"column:column_name;type:text;not null"`
+	Strategy MaskingStrategy `json:"strategy" gorm:"column:strategy;type:text;not null"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at;default:now()"`
+}
+
+func (MaskingRule) TableName() string {
+	return "masking_rules"
+}
+
+func (r *MaskingRule) Validate() error {
+	if r.Table == "" {
+		return errors.New("table name is required")
+	}
+
+	if r.Column == "" {
+		return errors.New("column name is required")
+	}
+
+	switch r.Strategy {
+	case MaskingStrategyHash,
+		MaskingStrategyEmail,
+		MaskingStrategyNull,
+		MaskingStrategyRedact,
+		MaskingStrategyKeepLast:
+	default:
+		return fmt.Errorf("invalid masking strategy: %s", r.Strategy)
+	}
+
+	return nil
+}
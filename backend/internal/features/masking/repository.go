@@ -0,0 +1,44 @@
+package masking
+
+import (
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaskingRuleRepository struct{}
+
+func (r *MaskingRuleRepository) FindByDatabaseID(databaseID uuid.UUID) ([]*MaskingRule, error) {
+	var rules []*MaskingRule
+
+	if err := storage.
+		GetDb().
+		Where("database_id = ?", databaseID).
+		Order("table_name ASC, column_name ASC").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ReplaceForDatabase swaps all rules of the database in one transaction
+func (r *MaskingRuleRepository) ReplaceForDatabase(
+	databaseID uuid.UUID,
+	rules []*MaskingRule,
+) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("database_id = ?", databaseID).
+			Delete(&MaskingRule{}).Error; err != nil {
+			return err
+		}
+
+		if len(rules) == 0 {
+			return nil
+		}
+
+		return tx.Create(&rules).Error
+	})
+}
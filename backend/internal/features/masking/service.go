@@ -0,0 +1,219 @@
+package masking
+
+import (
+	"context"
+	"databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type MaskingService struct {
+	databaseService       *databases.DatabaseService
+	maskingRuleRepository *MaskingRuleRepository
+	workspaceService      *workspaces_services.WorkspaceService
+	auditLogService       *audit_logs.AuditLogService
+	fieldEncryptor        encryption.FieldEncryptor
+	logger                *slog.Logger
+}
+
+func (s *MaskingService) OnDatabaseCopied(originalDatabaseID, newDatabaseID uuid.UUID) {
+	rules, err := s.maskingRuleRepository.FindByDatabaseID(originalDatabaseID)
+	if err != nil || len(rules) == 0 {
+		return
+	}
+
+	copiedRules := make([]*MaskingRule, 0, len(rules))
+	for _, rule := range rules {
+		copiedRules = append(copiedRules, &MaskingRule{
+			ID:         uuid.New(),
+			DatabaseID: newDatabaseID,
+			Schema:     rule.Schema,
+			Table:      rule.Table,
+			Column:     rule.Column,
+			Strategy:   rule.Strategy,
+		})
+	}
+
+	if err := s.maskingRuleRepository.ReplaceForDatabase(newDatabaseID, copiedRules); err != nil {
+		s.logger.Error("failed to copy masking rules", "error", err)
+	}
+}
+
+func (s *MaskingService) GetRules(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) ([]*MaskingRule, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot access masking rules for databases without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view masking rules")
+	}
+
+	return s.maskingRuleRepository.FindByDatabaseID(database.ID)
+}
+
+func (s *MaskingService) SaveRules(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	request SaveMaskingRulesRequest,
+) ([]*MaskingRule, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot modify masking rules for databases without workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to modify masking rules")
+	}
+
+	if !IsMaskingSupported(database.Type) {
+		return nil, fmt.Errorf("data masking is not supported for %s", database.Type)
+	}
+
+	rules := make([]*MaskingRule, 0, len(request.Rules))
+	seenColumns := make(map[string]bool)
+	for _, rule := range request.Rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+
+		key := rule.Schema + "." + rule.Table + "." + rule.Column
+		if seenColumns[key] {
+			return nil, fmt.Errorf("column %s has more than one masking rule", key)
+		}
+		seenColumns[key] = true
+
+		rules = append(rules, &MaskingRule{
+			ID:         uuid.New(),
+			DatabaseID: database.ID,
+			Schema:     rule.Schema,
+			Table:      rule.Table,
+			Column:     rule.Column,
+			Strategy:   rule.Strategy,
+		})
+	}
+
+	if err := s.maskingRuleRepository.ReplaceForDatabase(database.ID, rules); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Masking rules updated for database '%s' (%d rules)",
+			database.Name,
+			len(rules),
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return s.maskingRuleRepository.FindByDatabaseID(database.ID)
+}
+
+// ValidateCanApplyMasking checks a restore of the database can be masked before the
+// restore starts, masking which fails after the restore leaves unmasked data behind
+func (s *MaskingService) ValidateCanApplyMasking(database *databases.Database) error {
+	if !IsMaskingSupported(database.Type) {
+		return fmt.Errorf("data masking is not supported for %s", database.Type)
+	}
+
+	rules, err := s.maskingRuleRepository.FindByDatabaseID(database.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return errors.New("no masking rules are defined for this database")
+	}
+
+	return nil
+}
+
+// ApplyMasking runs the masking rules of the original database in the restored database
+func (s *MaskingService) ApplyMasking(
+	ctx context.Context,
+	originalDB *databases.Database,
+	restoredDB *databases.Database,
+) error {
+	rules, err := s.maskingRuleRepository.FindByDatabaseID(originalDB.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return errors.New("no masking rules are defined for this database")
+	}
+
+	statements, err := BuildMaskingStatements(originalDB.Type, rules)
+	if err != nil {
+		return err
+	}
+
+	switch originalDB.Type {
+	case databases.DatabaseTypePostgres:
+		err = restoredDB.Postgresql.ExecuteInTransaction(
+			ctx,
+			s.logger,
+			s.fieldEncryptor,
+			originalDB.ID,
+			statements,
+		)
+	case databases.DatabaseTypeMysql:
+		err = restoredDB.Mysql.ExecuteInTransaction(
+			ctx,
+			s.logger,
+			s.fieldEncryptor,
+			originalDB.ID,
+			statements,
+		)
+	case databases.DatabaseTypeMariadb:
+		err = restoredDB.Mariadb.ExecuteInTransaction(
+			ctx,
+			s.logger,
+			s.fieldEncryptor,
+			originalDB.ID,
+			statements,
+		)
+	default:
+		return fmt.Errorf("data masking is not supported for %s", originalDB.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to mask restored data: %w", err)
+	}
+
+	s.logger.Info(
+		"Masking rules applied to restored database",
+		"databaseId", originalDB.ID,
+		"rules", len(rules),
+		"tables", len(statements),
+	)
+
+	return nil
+}
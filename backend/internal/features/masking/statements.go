@@ -0,0 +1,133 @@
+package masking
+
+import (
+	"databasus-backend/internal/features/databases"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maskedEmailDomain is reserved by RFC 2606, masked addresses can never be delivered
+const maskedEmailDomain = "example.invalid"
+
+type maskingDialect struct {
+	quoteIdentifier func(name string) string
+	defaultSchema   string
+	// expressions mask the quoted column put in place of {column}. They keep NULL as NULL,
+	// because an UPDATE of a table sets all its masked columns
+	expressions map[MaskingStrategy]string
+}
+
+var postgresqlDialect = maskingDialect{
+	quoteIdentifier: func(name string) string {
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	},
+	defaultSchema: "public",
+	expressions: map[MaskingStrategy]string{
+		MaskingStrategyHash: "md5({column}::text)",
+		MaskingStrategyEmail: "'user_' || left(md5({column}::text), 16) || " +
+			"'@" + maskedEmailDomain + "'",
+		MaskingStrategyNull:   "NULL",
+		MaskingStrategyRedact: "CASE WHEN {column} IS NULL THEN NULL ELSE 'REDACTED' END",
+		MaskingStrategyKeepLast: "repeat('*', greatest(length({column}::text) - 4, 0)) || " +
+			"right({column}::text, 4)",
+	},
+}
+
+var mysqlDialect = maskingDialect{
+	quoteIdentifier: func(name string) string {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	},
+	defaultSchema: "",
+	expressions: map[MaskingStrategy]string{
+		MaskingStrategyHash: "MD5({column})",
+		MaskingStrategyEmail: "CONCAT('user_', LEFT(MD5({column}), 16), " +
+			"'@" + maskedEmailDomain + "')",
+		MaskingStrategyNull:   "NULL",
+		MaskingStrategyRedact: "CASE WHEN {column} IS NULL THEN NULL ELSE 'REDACTED' END",
+		MaskingStrategyKeepLast: "CONCAT(REPEAT('*', GREATEST(CHAR_LENGTH({column}) - 4, 0)), " +
+			"RIGHT({column}, 4))",
+	},
+}
+
+func IsMaskingSupported(databaseType databases.DatabaseType) bool {
+	_, err := getMaskingDialect(databaseType)
+	return err == nil
+}
+
+// BuildMaskingStatements returns one UPDATE per table of the rules. NULL values are left
+// as they are, so masking does not fill optional columns
+func BuildMaskingStatements(
+	databaseType databases.DatabaseType,
+	rules []*MaskingRule,
+) ([]string, error) {
+	dialect, err := getMaskingDialect(databaseType)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByTable := make(map[string][]*MaskingRule)
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+
+		table := dialect.quoteTable(rule.Schema, rule.Table)
+		rulesByTable[table] = append(rulesByTable[table], rule)
+	}
+
+	tables := make([]string, 0, len(rulesByTable))
+	for table := range rulesByTable {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	statements := make([]string, 0, len(tables))
+	for _, table := range tables {
+		assignments := make([]string, 0, len(rulesByTable[table]))
+		conditions := make([]string, 0, len(rulesByTable[table]))
+
+		for _, rule := range rulesByTable[table] {
+			column := dialect.quoteIdentifier(rule.Column)
+			expression := strings.ReplaceAll(
+				dialect.expressions[rule.Strategy],
+				"{column}",
+				column,
+			)
+			assignments = append(assignments, column+" = "+expression)
+			conditions = append(conditions, column+" IS NOT NULL")
+		}
+
+		statements = append(statements, fmt.Sprintf(
+			"UPDATE %s SET %s WHERE %s",
+			table,
+			strings.Join(assignments, ", "),
+			strings.Join(conditions, " OR "),
+		))
+	}
+
+	return statements, nil
+}
+
+func (d maskingDialect) quoteTable(schema string, table string) string {
+	if schema == "" {
+		schema = d.defaultSchema
+	}
+
+	if schema == "" {
+		return d.quoteIdentifier(table)
+	}
+
+	return d.quoteIdentifier(schema) + "." + d.quoteIdentifier(table)
+}
+
+func getMaskingDialect(databaseType databases.DatabaseType) (maskingDialect, error) {
+	switch databaseType {
+	case databases.DatabaseTypePostgres:
+		return postgresqlDialect, nil
+	case databases.DatabaseTypeMysql, databases.DatabaseTypeMariadb:
+		return mysqlDialect, nil
+	default:
+		return maskingDialect{}, fmt.Errorf("data masking is not supported for %s", databaseType)
+	}
+}
@@ -0,0 +1,61 @@
+package masking
+
+import (
+	"testing"
+
+	"databasus-backend/internal/features/databases"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BuildMaskingStatements_ForPostgresql_OneUpdatePerTable(t *testing.T) {
+	rules := []*MaskingRule{
+		{Table: "users", Column: "email", Strategy: MaskingStrategyEmail},
+		{Schema: "billing", Table: "cards", Column: "number", Strategy: MaskingStrategyKeepLast},
+		{Table: "users", Column: "phone", Strategy: MaskingStrategyNull},
+	}
+
+	statements, err := BuildMaskingStatements(databases.DatabaseTypePostgres, rules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`UPDATE "billing"."cards" SET "number" = repeat('*', greatest(length("number"::text) - 4, 0)) || ` +
+			`right("number"::text, 4) WHERE "number" IS NOT NULL`,
+		`UPDATE "public"."users" SET "email" = 'user_' || left(md5("email"::text), 16) || ` +
+			`'@example.invalid', "phone" = NULL WHERE "email" IS NOT NULL OR "phone" IS NOT NULL`,
+	}, statements)
+}
+
+func Test_BuildMaskingStatements_ForMysql_IdentifiersQuotedWithBackticks(t *testing.T) {
+	rules := []*MaskingRule{
+		{Table: "my`table", Column: "secret", Strategy: MaskingStrategyRedact},
+	}
+
+	statements, err := BuildMaskingStatements(databases.DatabaseTypeMysql, rules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"UPDATE `my``table` SET `secret` = CASE WHEN `secret` IS NULL THEN NULL " +
+			"ELSE 'REDACTED' END WHERE `secret` IS NOT NULL",
+	}, statements)
+}
+
+func Test_BuildMaskingStatements_WithInvalidRule_ReturnsError(t *testing.T) {
+	rules := []*MaskingRule{
+		{Table: "users", Column: "email", Strategy: "SHUFFLE"},
+	}
+
+	_, err := BuildMaskingStatements(databases.DatabaseTypePostgres, rules)
+
+	assert.ErrorContains(t, err, "invalid masking strategy")
+}
+
+func Test_BuildMaskingStatements_ForUnsupportedDatabase_ReturnsError(t *testing.T) {
+	rules := []*MaskingRule{
+		{Table: "users", Column: "email", Strategy: MaskingStrategyHash},
+	}
+
+	_, err := BuildMaskingStatements(databases.DatabaseTypeMongodb, rules)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package notifiers_broadcasts
+
+import (
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BroadcastController struct {
+	broadcastService *BroadcastService
+}
+
+func (c *BroadcastController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST(
+		"/broadcasts",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.CreateBroadcast,
+	)
+	router.GET(
+		"/broadcasts",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.GetBroadcasts,
+	)
+	router.GET(
+		"/broadcasts/:id/deliveries",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.GetBroadcastDeliveries,
+	)
+}
+
+// CreateBroadcast
+// @Summary Send a broadcast
+// @Description Send a one-off message through notifiers of all workspaces (or the given ones) and optionally by email to their members (admin only)
+// @Tags broadcasts
+// @Accept json
+// @Produce json
+// @Param request body CreateBroadcastRequest true "Broadcast"
+// @Success 200 {object} Broadcast
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /broadcasts [post]
+func (c *BroadcastController) CreateBroadcast(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CreateBroadcastRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	broadcast, err := c.broadcastService.CreateBroadcast(user, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, broadcast)
+}
+
+// GetBroadcasts
+// @Summary Get broadcasts
+// @Description Get the latest broadcasts with their delivery counts (admin only)
+// @Tags broadcasts
+// @Produce json
+// @Success 200 {array} Broadcast
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /broadcasts [get]
+func (c *BroadcastController) GetBroadcasts(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	broadcasts, err := c.broadcastService.GetBroadcasts(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, broadcasts)
+}
+
+// GetBroadcastDeliveries
+// @Summary Get broadcast deliveries
+// @Description Get the result of sending a broadcast to each notifier and email address (admin only)
+// @Tags broadcasts
+// @Produce json
+// @Param id path string true "Broadcast ID"
+// @Success 200 {array} BroadcastDelivery
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /broadcasts/{id}/deliveries [get]
+func (c *BroadcastController) GetBroadcastDeliveries(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	broadcastID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid broadcast ID"})
+		return
+	}
+
+	deliveries, err := c.broadcastService.GetBroadcastDeliveries(user, broadcastID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,95 @@
+package notifiers_broadcasts
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/notifiers"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetBroadcastController(),
+	)
+}
+
+func Test_CreateBroadcast_DeliveredToWorkspaceNotifiers(t *testing.T) {
+	router := createTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", admin, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	defer server.Close()
+
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	notifier.WebhookNotifier.WebhookURL = server.URL
+	_, err := notifiers.GetNotifierRepository().Save(notifier)
+	assert.NoError(t, err)
+
+	var broadcast Broadcast
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/broadcasts",
+		"Bearer "+admin.Token,
+		CreateBroadcastRequest{
+			Title:        "Datacenter maintenance",
+			Message:      "Backups are paused on Saturday",
+			WorkspaceIDs: []uuid.UUID{workspace.ID},
+		},
+		http.StatusOK,
+		&broadcast,
+	)
+	assert.Equal(t, BroadcastStatusInProgress, broadcast.Status)
+
+	var deliveries []BroadcastDelivery
+	assert.Eventually(t, func() bool {
+		test_utils.MakeGetRequestAndUnmarshal(
+			t,
+			router,
+			fmt.Sprintf("/api/v1/broadcasts/%s/deliveries", broadcast.ID.String()),
+			"Bearer "+admin.Token,
+			http.StatusOK,
+			&deliveries,
+		)
+		return len(deliveries) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	assert.Equal(t, DeliveryChannelNotifier, deliveries[0].Channel)
+	assert.Equal(t, notifier.ID, *deliveries[0].NotifierID)
+	assert.Equal(t, DeliveryStatusSent, deliveries[0].Status)
+}
+
+func Test_CreateBroadcast_WhenUserIsNotAdmin_ReturnsForbidden(t *testing.T) {
+	router := createTestRouter()
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/broadcasts",
+		"Bearer "+member.Token,
+		CreateBroadcastRequest{Title: "Maintenance", Message: "Backups are paused"},
+		http.StatusForbidden,
+	)
+}
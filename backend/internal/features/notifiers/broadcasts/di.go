@@ -0,0 +1,32 @@
+package notifiers_broadcasts
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/email"
+	"databasus-backend/internal/features/notifiers"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var broadcastRepository = &BroadcastRepository{}
+var broadcastService = &BroadcastService{
+	broadcastRepository,
+	notifiers.GetNotifierService(),
+	workspaces_services.GetWorkspaceService(),
+	email.GetEmailSMTPSender(),
+	users_services.GetBrandingService(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var broadcastController = &BroadcastController{
+	broadcastService,
+}
+
+func GetBroadcastService() *BroadcastService {
+	return broadcastService
+}
+
+func GetBroadcastController() *BroadcastController {
+	return broadcastController
+}
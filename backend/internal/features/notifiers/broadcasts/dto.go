@@ -0,0 +1,10 @@
+package notifiers_broadcasts
+
+import "github.com/google/uuid"
+
+type CreateBroadcastRequest struct {
+	Title        string      `json:"title"        binding:"required,max=255"`
+	Message      string      `json:"message"      binding:"required,max=2000"`
+	IsSendEmails bool        `json:"isSendEmails"`
+	WorkspaceIDs []uuid.UUID `json:"workspaceIds"`
+}
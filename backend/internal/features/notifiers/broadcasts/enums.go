@@ -0,0 +1,22 @@
+package notifiers_broadcasts
+
+type BroadcastStatus string
+
+const (
+	BroadcastStatusInProgress BroadcastStatus = "IN_PROGRESS"
+	BroadcastStatusCompleted  BroadcastStatus = "COMPLETED"
+)
+
+type DeliveryChannel string
+
+const (
+	DeliveryChannelNotifier DeliveryChannel = "NOTIFIER"
+	DeliveryChannelEmail    DeliveryChannel = "EMAIL"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent   DeliveryStatus = "SENT"
+	DeliveryStatusFailed DeliveryStatus = "FAILED"
+)
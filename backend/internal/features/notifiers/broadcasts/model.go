@@ -0,0 +1,49 @@
+package notifiers_broadcasts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Broadcast is a one-off message from admins, e.g. about planned maintenance, sent through
+// notifiers of workspaces and optionally by email to their members
+type Broadcast struct {
+	ID              uuid.UUID       `json:"id"              gorm:"column:id;type:uuid;primaryKey"`
+	Title           string          `json:"title"           gorm:"column:title;type:text;not null"`
+	Message         string          `json:"message"         gorm:"column:message;type:text;not null"`
+	IsSendEmails    bool            `json:"isSendEmails"    gorm:"column:is_send_emails;type:boolean;not null"`
+	Status          BroadcastStatus `json:"status"          gorm:"column:status;type:text;not null"`
+	CreatedByUserID uuid.UUID       `json:"createdByUserId" gorm:"column:created_by_user_id;type:uuid;not null"`
+
+	// WorkspaceIDs limits the broadcast to these workspaces, empty means all workspaces
+	WorkspaceIDs []uuid.UUID `json:"workspaceIds" gorm:"column:workspace_ids;type:text;not null;serializer:json"`
+
+	SentCount   int `json:"sentCount"   gorm:"column:sent_count;type:int;not null"`
+	FailedCount int `json:"failedCount" gorm:"column:failed_count;type:int;not null"`
+
+	CreatedAt  time.Time  `json:"createdAt"  gorm:"column:created_at"`
+	FinishedAt *time.Time `json:"finishedAt" gorm:"column:finished_at"`
+}
+
+func (Broadcast) TableName() string {
+	return "broadcasts"
+}
+
+// BroadcastDelivery is the result of sending a broadcast to one notifier or email address.
+// Email deliveries are per user, so they have no workspace
+type BroadcastDelivery struct {
+	ID          uuid.UUID       `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	BroadcastID uuid.UUID       `json:"broadcastId" gorm:"column:broadcast_id;type:uuid;not null"`
+	WorkspaceID *uuid.UUID      `json:"workspaceId" gorm:"column:workspace_id;type:uuid"`
+	Channel     DeliveryChannel `json:"channel"     gorm:"column:channel;type:text;not null"`
+	NotifierID  *uuid.UUID      `json:"notifierId"  gorm:"column:notifier_id;type:uuid"`
+	Recipient   string          `json:"recipient"   gorm:"column:recipient;type:text;not null"`
+	Status      DeliveryStatus  `json:"status"      gorm:"column:status;type:text;not null"`
+	Error       *string         `json:"error"       gorm:"column:error;type:text"`
+	SentAt      time.Time       `json:"sentAt"      gorm:"column:sent_at"`
+}
+
+func (BroadcastDelivery) TableName() string {
+	return "broadcast_deliveries"
+}
@@ -0,0 +1,70 @@
+package notifiers_broadcasts
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BroadcastRepository struct{}
+
+func (r *BroadcastRepository) Save(broadcast *Broadcast) error {
+	return storage.GetDb().Save(broadcast).Error
+}
+
+func (r *BroadcastRepository) FindByID(id uuid.UUID) (*Broadcast, error) {
+	var broadcast Broadcast
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", id).
+		First(&broadcast).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &broadcast, nil
+}
+
+func (r *BroadcastRepository) FindLatest(limit int) ([]*Broadcast, error) {
+	var broadcasts []*Broadcast
+
+	if err := storage.
+		GetDb().
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&broadcasts).Error; err != nil {
+		return nil, err
+	}
+
+	return broadcasts, nil
+}
+
+func (r *BroadcastRepository) SaveDeliveries(deliveries []*BroadcastDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return storage.GetDb().Create(&deliveries).Error
+}
+
+func (r *BroadcastRepository) FindDeliveriesByBroadcastID(
+	broadcastID uuid.UUID,
+) ([]*BroadcastDelivery, error) {
+	var deliveries []*BroadcastDelivery
+
+	if err := storage.
+		GetDb().
+		Where("broadcast_id = ?", broadcastID).
+		Order("sent_at ASC").
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
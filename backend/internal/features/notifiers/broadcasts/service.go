@@ -0,0 +1,310 @@
+package notifiers_broadcasts
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/email"
+	"databasus-backend/internal/features/notifiers"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_models "databasus-backend/internal/features/workspaces/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+const latestBroadcastsLimit = 50
+
+type BroadcastService struct {
+	broadcastRepository *BroadcastRepository
+	notifierService     *notifiers.NotifierService
+	workspaceService    *workspaces_services.WorkspaceService
+	emailSender         *email.EmailSMTPSender
+	brandingService     *users_services.BrandingService
+	auditLogService     *audit_logs.AuditLogService
+	logger              *slog.Logger
+}
+
+// CreateBroadcast saves the broadcast and delivers it in background, as instances with many
+// workspaces need longer than a request. Deliveries are tracked on the broadcast
+func (s *BroadcastService) CreateBroadcast(
+	user *users_models.User,
+	request *CreateBroadcastRequest,
+) (*Broadcast, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only admins can send broadcasts")
+	}
+
+	if strings.TrimSpace(request.Title) == "" || strings.TrimSpace(request.Message) == "" {
+		return nil, errors.New("broadcast title and message are required")
+	}
+
+	for _, workspaceID := range request.WorkspaceIDs {
+		workspace, err := s.workspaceService.GetWorkspaceByID(workspaceID)
+		if err != nil || workspace == nil {
+			return nil, fmt.Errorf("workspace %s not found", workspaceID)
+		}
+	}
+
+	broadcast := &Broadcast{
+		ID:              uuid.New(),
+		Title:           request.Title,
+		Message:         request.Message,
+		IsSendEmails:    request.IsSendEmails,
+		Status:          BroadcastStatusInProgress,
+		CreatedByUserID: user.ID,
+		WorkspaceIDs:    request.WorkspaceIDs,
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	if broadcast.WorkspaceIDs == nil {
+		broadcast.WorkspaceIDs = []uuid.UUID{}
+	}
+
+	if err := s.broadcastRepository.Save(broadcast); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Broadcast sent: %s", broadcast.Title),
+		&user.ID,
+		nil,
+	)
+
+	go s.deliverBroadcast(broadcast)
+
+	return broadcast, nil
+}
+
+func (s *BroadcastService) GetBroadcasts(user *users_models.User) ([]*Broadcast, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only admins can view broadcasts")
+	}
+
+	return s.broadcastRepository.FindLatest(latestBroadcastsLimit)
+}
+
+func (s *BroadcastService) GetBroadcastDeliveries(
+	user *users_models.User,
+	broadcastID uuid.UUID,
+) ([]*BroadcastDelivery, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only admins can view broadcasts")
+	}
+
+	broadcast, err := s.broadcastRepository.FindByID(broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	if broadcast == nil {
+		return nil, errors.New("broadcast not found")
+	}
+
+	return s.broadcastRepository.FindDeliveriesByBroadcastID(broadcastID)
+}
+
+func (s *BroadcastService) deliverBroadcast(broadcast *Broadcast) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error(
+				"Panic while delivering broadcast",
+				"broadcastId", broadcast.ID,
+				"panic", r,
+			)
+		}
+	}()
+
+	workspaces, err := s.workspaceService.GetAllWorkspaces()
+	if err != nil {
+		s.logger.Error("Failed to get workspaces for broadcast", "error", err)
+		workspaces = nil
+	}
+
+	deliveries := []*BroadcastDelivery{}
+	emailRecipients := []string{}
+
+	for _, workspace := range workspaces {
+		isSelected := len(broadcast.WorkspaceIDs) == 0 ||
+			slices.Contains(broadcast.WorkspaceIDs, workspace.ID)
+		if !isSelected {
+			continue
+		}
+
+		deliveries = append(deliveries, s.deliverToNotifiers(broadcast, workspace)...)
+
+		if broadcast.IsSendEmails {
+			emailRecipients = s.appendMemberEmails(emailRecipients, workspace)
+		}
+	}
+
+	if broadcast.IsSendEmails {
+		if s.emailSender.IsConfigured() {
+			deliveries = append(deliveries, s.deliverByEmail(broadcast, emailRecipients)...)
+		} else {
+			s.logger.Warn(
+				"Skipping broadcast emails, SMTP not configured",
+				"broadcastId", broadcast.ID,
+			)
+		}
+	}
+
+	if err := s.broadcastRepository.SaveDeliveries(deliveries); err != nil {
+		s.logger.Error("Failed to save broadcast deliveries", "error", err)
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.Status == DeliveryStatusSent {
+			broadcast.SentCount++
+		} else {
+			broadcast.FailedCount++
+		}
+	}
+
+	finishedAt := time.Now().UTC()
+	broadcast.Status = BroadcastStatusCompleted
+	broadcast.FinishedAt = &finishedAt
+
+	if err := s.broadcastRepository.Save(broadcast); err != nil {
+		s.logger.Error("Failed to save broadcast", "error", err)
+	}
+}
+
+func (s *BroadcastService) deliverToNotifiers(
+	broadcast *Broadcast,
+	workspace *workspaces_models.Workspace,
+) []*BroadcastDelivery {
+	workspaceNotifiers, err := s.notifierService.GetNotifiersByWorkspaceID(workspace.ID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get notifiers for broadcast",
+			"workspaceId", workspace.ID,
+			"error", err,
+		)
+		return nil
+	}
+
+	deliveries := make([]*BroadcastDelivery, 0, len(workspaceNotifiers))
+	for _, notifier := range workspaceNotifiers {
+		sendErr := s.notifierService.DeliverNotification(
+			notifier,
+			broadcast.Title,
+			broadcast.Message,
+		)
+
+		delivery := newDelivery(broadcast.ID, DeliveryChannelNotifier, notifier.Name, sendErr)
+		delivery.WorkspaceID = &workspace.ID
+		delivery.NotifierID = &notifier.ID
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
+
+func (s *BroadcastService) appendMemberEmails(
+	emailRecipients []string,
+	workspace *workspaces_models.Workspace,
+) []string {
+	members, err := s.workspaceService.GetWorkspaceMembers(workspace.ID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get members for broadcast",
+			"workspaceId", workspace.ID,
+			"error", err,
+		)
+		return emailRecipients
+	}
+
+	for _, member := range members {
+		if member.Email != "" && !slices.Contains(emailRecipients, member.Email) {
+			emailRecipients = append(emailRecipients, member.Email)
+		}
+	}
+
+	return emailRecipients
+}
+
+func (s *BroadcastService) deliverByEmail(
+	broadcast *Broadcast,
+	emailRecipients []string,
+) []*BroadcastDelivery {
+	body := s.buildBroadcastEmailHTML(broadcast)
+
+	emails := make([]email.Email, len(emailRecipients))
+	for i, recipient := range emailRecipients {
+		emails[i] = email.Email{To: recipient, Subject: broadcast.Title, Body: body}
+	}
+
+	results := s.emailSender.SendEmailsWithResults(emails)
+
+	deliveries := make([]*BroadcastDelivery, len(emailRecipients))
+	for i, recipient := range emailRecipients {
+		deliveries[i] = newDelivery(broadcast.ID, DeliveryChannelEmail, recipient, results[i])
+	}
+
+	return deliveries
+}
+
+func (s *BroadcastService) buildBroadcastEmailHTML(broadcast *Broadcast) string {
+	branding := s.brandingService.GetBrandingOrDefault()
+	message := strings.ReplaceAll(html.EscapeString(broadcast.Message), "\n", "<br>")
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+	<div style="background-color: #f8f9fa; border-radius: 8px; padding: 30px; margin: 20px 0;">
+		%s
+		<h1 style="color: %s; margin-top: 0;">%s</h1>
+
+		<p style="font-size: 16px; margin: 20px 0;">
+			%s
+		</p>
+		%s
+	</div>
+</body>
+</html>
+	`,
+		s.brandingService.BuildEmailHeader(branding),
+		branding.AccentColor,
+		html.EscapeString(broadcast.Title),
+		message,
+		s.brandingService.BuildEmailFooter(branding),
+	)
+}
+
+func newDelivery(
+	broadcastID uuid.UUID,
+	channel DeliveryChannel,
+	recipient string,
+	sendErr error,
+) *BroadcastDelivery {
+	delivery := &BroadcastDelivery{
+		ID:          uuid.New(),
+		BroadcastID: broadcastID,
+		Channel:     channel,
+		Recipient:   recipient,
+		Status:      DeliveryStatusSent,
+		SentAt:      time.Now().UTC(),
+	}
+
+	if sendErr != nil {
+		errMsg := sendErr.Error()
+		delivery.Status = DeliveryStatusFailed
+		delivery.Error = &errMsg
+	}
+
+	return delivery
+}
@@ -2,15 +2,20 @@ package notifiers
 
 import (
 	"errors"
+	"io"
+	"time"
 
 	users_middleware "databasus-backend/internal/features/users/middleware"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/etag"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+const maxIncidentWebhookPayloadBytes = 1 << 20
+
 type NotifierController struct {
 	notifierService  *NotifierService
 	workspaceService *workspaces_services.WorkspaceService
@@ -18,12 +23,20 @@ type NotifierController struct {
 
 func (c *NotifierController) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/notifiers", c.SaveNotifier)
-	router.GET("/notifiers", c.GetNotifiers)
-	router.GET("/notifiers/:id", c.GetNotifier)
-	router.DELETE("/notifiers/:id", c.DeleteNotifier)
-	router.POST("/notifiers/:id/test", c.SendTestNotification)
-	router.POST("/notifiers/:id/transfer", c.TransferNotifierToWorkspace)
-	router.POST("/notifiers/direct-test", c.SendTestNotificationDirect)
+	c.registerSharedRoutes(router)
+}
+
+// RegisterRoutesV2 registers /api/v2 routes, where create and update are split
+func (c *NotifierController) RegisterRoutesV2(router *gin.RouterGroup) {
+	router.POST("/notifiers", c.CreateNotifier)
+	router.PUT("/notifiers/:id", c.UpdateNotifier)
+	c.registerSharedRoutes(router)
+}
+
+// RegisterPublicRoutes registers routes called by incident management services, they are
+// authenticated by the webhook secret of the notifier instead of user token
+func (c *NotifierController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.POST("/notifiers/:id/incident-webhook", c.HandleIncidentWebhook)
 }
 
 // SaveNotifier
@@ -58,14 +71,84 @@ func (c *NotifierController) SaveNotifier(ctx *gin.Context) {
 	}
 
 	if err := c.notifierService.SaveNotifier(user, request.WorkspaceID, &request); err != nil {
-		if errors.Is(err, ErrInsufficientPermissionsToManageNotifier) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
-		}
+		c.respondSaveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// CreateNotifier is POST /api/v2/notifiers. Unlike v1 SaveNotifier it never updates
+func (c *NotifierController) CreateNotifier(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request Notifier
+	if err := ctx.ShouldBindJSON(&request); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if request.WorkspaceID == uuid.Nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "workspaceId is required"})
+		return
+	}
+
+	if request.ID != uuid.Nil {
+		ctx.JSON(
+			http.StatusBadRequest,
+			gin.H{"error": "id must be empty, use PUT /notifiers/{id} to update"},
+		)
+		return
+	}
+
+	if err := c.notifierService.SaveNotifier(user, request.WorkspaceID, &request); err != nil {
+		c.respondSaveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, request)
+}
+
+// UpdateNotifier is PUT /api/v2/notifiers/:id, the ID is taken from the path
+func (c *NotifierController) UpdateNotifier(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notifier ID"})
+		return
+	}
+
+	var request Notifier
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.ID != uuid.Nil && request.ID != id {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id in body does not match path"})
+		return
+	}
+	request.ID = id
+
+	if request.WorkspaceID == uuid.Nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "workspaceId is required"})
+		return
+	}
+
+	if err := c.notifierService.SaveNotifier(user, request.WorkspaceID, &request); err != nil {
+		c.respondSaveError(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, request)
 }
 
@@ -76,7 +159,7 @@ func (c *NotifierController) SaveNotifier(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "JWT token"
 // @Param id path string true "Notifier ID"
-// @Success 200 {object} Notifier
+// @Success 200 {object} NotifierResponse
 // @Failure 400
 // @Failure 401
 // @Failure 403
@@ -104,7 +187,7 @@ func (c *NotifierController) GetNotifier(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, notifier)
+	etag.JSON(ctx, notifier)
 }
 
 // GetNotifiers
@@ -114,7 +197,7 @@ func (c *NotifierController) GetNotifier(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "JWT token"
 // @Param workspace_id query string true "Workspace ID"
-// @Success 200 {array} Notifier
+// @Success 200 {array} NotifierResponse
 // @Failure 400
 // @Failure 401
 // @Failure 403
@@ -148,7 +231,7 @@ func (c *NotifierController) GetNotifiers(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, notifiers)
+	etag.JSON(ctx, notifiers)
 }
 
 // DeleteNotifier
@@ -225,6 +308,50 @@ func (c *NotifierController) SendTestNotification(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "test notification sent successfully"})
 }
 
+// SendTestEvent
+// @Summary Send test event
+// @Description Send a sample event of the given type through the notifier, marked as a test
+// @Tags notifiers
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Notifier ID"
+// @Param type query string true "Event type" Enums(backup_failed, backup_success, refresh_failed, refresh_success, database_unavailable, database_online, notifier_broken)
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /notifiers/{id}/test-event [post]
+func (c *NotifierController) SendTestEvent(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notifier ID"})
+		return
+	}
+
+	eventType := TestEventType(ctx.Query("type"))
+	if eventType == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "type query parameter is required"})
+		return
+	}
+
+	if err := c.notifierService.SendTestEvent(user, id, eventType); err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToTestNotifier) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "test event sent successfully"})
+}
+
 // TransferNotifierToWorkspace
 // @Summary Transfer notifier to another workspace
 // @Description Transfer a notifier from one workspace to another
@@ -332,3 +459,261 @@ func (c *NotifierController) SendTestNotificationDirect(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "test notification sent successfully"})
 }
+
+// GetNotifierIncidents
+// @Summary Get notifier incidents
+// @Description Get the latest incidents opened by a PagerDuty or Opsgenie notifier with their acknowledgement state
+// @Tags notifiers
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Notifier ID"
+// @Success 200 {array} NotifierIncident
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /notifiers/{id}/incidents [get]
+func (c *NotifierController) GetNotifierIncidents(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notifier ID"})
+		return
+	}
+
+	incidents, err := c.notifierService.GetNotifierIncidents(user, id)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewNotifier) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, incidents)
+}
+
+// HandleIncidentWebhook
+// @Summary Handle incident webhook
+// @Description Receive acknowledgement and resolution of incidents from PagerDuty V3 webhooks or Opsgenie outgoing webhooks
+// @Tags notifiers
+// @Accept json
+// @Param id path string true "Notifier ID"
+// @Param X-PagerDuty-Signature header string false "PagerDuty webhook signature"
+// @Param X-Databasus-Webhook-Secret header string false "Webhook secret configured in Opsgenie"
+// @Success 200
+// @Failure 400
+// @Router /notifiers/{id}/incident-webhook [post]
+func (c *NotifierController) HandleIncidentWebhook(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notifier ID"})
+		return
+	}
+
+	payload, err := io.ReadAll(
+		io.LimitReader(ctx.Request.Body, maxIncidentWebhookPayloadBytes),
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	err = c.notifierService.HandleIncidentWebhook(
+		id,
+		ctx.Request.Header,
+		payload,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// GetNotificationEvents
+// @Summary Get notification events
+// @Description List every event sent to notifiers with its default severity
+// @Tags notifiers
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Success 200 {array} NotificationEvent
+// @Failure 401
+// @Router /notifiers/events [get]
+func (c *NotifierController) GetNotificationEvents(ctx *gin.Context) {
+	if _, ok := users_middleware.GetUserFromContext(ctx); !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GetNotificationEvents())
+}
+
+// GetWorkspaceEventSeverities
+// @Summary Get event severities of a workspace
+// @Description List every event with its severity in the workspace, overridden or default
+// @Tags notifiers
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {array} EventSeverityResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /notifiers/events/workspace/{workspaceId} [get]
+func (c *NotifierController) GetWorkspaceEventSeverities(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	severities, err := c.notifierService.GetWorkspaceEventSeverities(user, workspaceID)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewNotifiers) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, severities)
+}
+
+// UpdateWorkspaceEventSeverity
+// @Summary Override event severity
+// @Description Override the severity of an event in the workspace, incident notifiers page by it
+// @Tags notifiers
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param workspaceId path string true "Workspace ID"
+// @Param eventType path string true "Event type"
+// @Param request body UpdateEventSeverityRequest true "Severity"
+// @Success 200 {object} NotificationSeverityOverride
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /notifiers/events/workspace/{workspaceId}/{eventType} [put]
+func (c *NotifierController) UpdateWorkspaceEventSeverity(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request UpdateEventSeverityRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	override, err := c.notifierService.UpdateWorkspaceEventSeverity(
+		user,
+		workspaceID,
+		NotificationEventType(ctx.Param("eventType")),
+		request.Severity,
+	)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageEventSeverities) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, override)
+}
+
+// ResetWorkspaceEventSeverity
+// @Summary Reset event severity
+// @Description Remove the severity override of an event, the default severity applies again
+// @Tags notifiers
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param workspaceId path string true "Workspace ID"
+// @Param eventType path string true "Event type"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /notifiers/events/workspace/{workspaceId}/{eventType} [delete]
+func (c *NotifierController) ResetWorkspaceEventSeverity(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	if err := c.notifierService.ResetWorkspaceEventSeverity(
+		user,
+		workspaceID,
+		NotificationEventType(ctx.Param("eventType")),
+	); err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageEventSeverities) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "event severity reset to default"})
+}
+
+func (c *NotifierController) registerSharedRoutes(router *gin.RouterGroup) {
+	router.GET("/notifiers", c.GetNotifiers)
+	router.GET("/notifiers/:id", c.GetNotifier)
+	router.DELETE("/notifiers/:id", c.DeleteNotifier)
+	router.POST("/notifiers/:id/test", c.SendTestNotification)
+	router.POST("/notifiers/:id/test-event", c.SendTestEvent)
+	router.POST("/notifiers/:id/transfer", c.TransferNotifierToWorkspace)
+	router.GET("/notifiers/:id/incidents", c.GetNotifierIncidents)
+	router.POST("/notifiers/direct-test", c.SendTestNotificationDirect)
+	router.GET("/notifiers/events", c.GetNotificationEvents)
+	router.GET("/notifiers/events/workspace/:workspaceId", c.GetWorkspaceEventSeverities)
+	router.PUT(
+		"/notifiers/events/workspace/:workspaceId/:eventType",
+		c.UpdateWorkspaceEventSeverity,
+	)
+	router.DELETE(
+		"/notifiers/events/workspace/:workspaceId/:eventType",
+		c.ResetWorkspaceEventSeverity,
+	)
+}
+
+func (c *NotifierController) respondSaveError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrInsufficientPermissionsToManageNotifier) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
@@ -18,6 +19,11 @@ var notifierService = &NotifierService{
 	audit_logs.GetAuditLogService(),
 	encryption.GetFieldEncryptor(),
 	nil,
+	users_services.GetBrandingService(),
+}
+var notifierHealthCheckBackgroundService = &NotifierHealthCheckBackgroundService{
+	notifierService: notifierService,
+	logger:          logger.GetLogger(),
 }
 var notifierController = &NotifierController{
 	notifierService,
@@ -32,6 +38,10 @@ func GetNotifierService() *NotifierService {
 	return notifierService
 }
 
+func GetNotifierHealthCheckBackgroundService() *NotifierHealthCheckBackgroundService {
+	return notifierHealthCheckBackgroundService
+}
+
 func GetNotifierRepository() *NotifierRepository {
 	return notifierRepository
 }
@@ -1,7 +1,145 @@
 package notifiers
 
-import "github.com/google/uuid"
+import (
+	"slices"
+	"time"
+
+	discord_notifier "databasus-backend/internal/features/notifiers/models/discord"
+	"databasus-backend/internal/features/notifiers/models/email_notifier"
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
+	opsgenie_notifier "databasus-backend/internal/features/notifiers/models/opsgenie"
+	pagerduty_notifier "databasus-backend/internal/features/notifiers/models/pagerduty"
+	slack_notifier "databasus-backend/internal/features/notifiers/models/slack"
+	teams_notifier "databasus-backend/internal/features/notifiers/models/teams"
+	telegram_notifier "databasus-backend/internal/features/notifiers/models/telegram"
+	webhook_notifier "databasus-backend/internal/features/notifiers/models/webhook"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+)
 
 type TransferNotifierRequest struct {
 	TargetWorkspaceID uuid.UUID `json:"targetWorkspaceId" binding:"required"`
 }
+
+// NotifierResponse has the same JSON shape as Notifier. Secrets are blanked on copies of
+// specific notifiers, so the loaded model keeps its credentials if it is saved later
+type NotifierResponse struct {
+	ID            uuid.UUID    `json:"id"`
+	WorkspaceID   uuid.UUID    `json:"workspaceId"`
+	Name          string       `json:"name"`
+	NotifierType  NotifierType `json:"notifierType"`
+	LastSendError *string      `json:"lastSendError"`
+	Locale        i18n.Locale  `json:"locale"`
+	IsSystem      bool         `json:"isSystem"`
+
+	IsWorkspaceDefault bool `json:"isWorkspaceDefault"`
+
+	HealthStatus      NotifierHealthStatus `json:"healthStatus"`
+	HealthCheckError  *string              `json:"healthCheckError"`
+	LastHealthCheckAt *time.Time           `json:"lastHealthCheckAt"`
+
+	TelegramNotifier  *telegram_notifier.TelegramNotifier   `json:"telegramNotifier"`
+	EmailNotifier     *email_notifier.EmailNotifier         `json:"emailNotifier"`
+	WebhookNotifier   *webhook_notifier.WebhookNotifier     `json:"webhookNotifier"`
+	SlackNotifier     *slack_notifier.SlackNotifier         `json:"slackNotifier"`
+	DiscordNotifier   *discord_notifier.DiscordNotifier     `json:"discordNotifier"`
+	TeamsNotifier     *teams_notifier.TeamsNotifier         `json:"teamsNotifier,omitempty"`
+	PagerDutyNotifier *pagerduty_notifier.PagerDutyNotifier `json:"pagerDutyNotifier,omitempty"`
+	OpsgenieNotifier  *opsgenie_notifier.OpsgenieNotifier   `json:"opsgenieNotifier,omitempty"`
+}
+
+// ToNotifierResponses maps notifiers in one pass. isSpecificDataHidden drops notifier
+// settings entirely, it is used for system notifiers shown to non-admins
+func ToNotifierResponses(
+	notifiers []*Notifier,
+	isSpecificDataHidden func(notifier *Notifier) bool,
+) []*NotifierResponse {
+	responses := make([]*NotifierResponse, 0, len(notifiers))
+
+	for _, notifier := range notifiers {
+		responses = append(
+			responses,
+			ToNotifierResponse(notifier, isSpecificDataHidden(notifier)),
+		)
+	}
+
+	return responses
+}
+
+func ToNotifierResponse(notifier *Notifier, isSpecificDataHidden bool) *NotifierResponse {
+	response := &NotifierResponse{
+		ID:                 notifier.ID,
+		WorkspaceID:        notifier.WorkspaceID,
+		Name:               notifier.Name,
+		NotifierType:       notifier.NotifierType,
+		LastSendError:      notifier.LastSendError,
+		Locale:             notifier.Locale,
+		IsSystem:           notifier.IsSystem,
+		IsWorkspaceDefault: notifier.IsWorkspaceDefault,
+		HealthStatus:       notifier.HealthStatus,
+		HealthCheckError:   notifier.HealthCheckError,
+		LastHealthCheckAt:  notifier.LastHealthCheckAt,
+	}
+
+	if isSpecificDataHidden {
+		return response
+	}
+
+	response.TelegramNotifier = copyPointer(notifier.TelegramNotifier)
+	response.EmailNotifier = copyPointer(notifier.EmailNotifier)
+	response.WebhookNotifier = copyPointer(notifier.WebhookNotifier)
+	response.SlackNotifier = copyPointer(notifier.SlackNotifier)
+	response.DiscordNotifier = copyPointer(notifier.DiscordNotifier)
+	response.TeamsNotifier = copyPointer(notifier.TeamsNotifier)
+	response.PagerDutyNotifier = copyPointer(notifier.PagerDutyNotifier)
+	response.OpsgenieNotifier = copyPointer(notifier.OpsgenieNotifier)
+
+	// The only reference field among notifiers, a shallow copy would share it with the model
+	if response.WebhookNotifier != nil {
+		response.WebhookNotifier.Headers = slices.Clone(notifier.WebhookNotifier.Headers)
+	}
+
+	response.hideSensitiveData()
+
+	return response
+}
+
+func (r *NotifierResponse) hideSensitiveData() {
+	copiedNotifier := &Notifier{
+		NotifierType:      r.NotifierType,
+		TelegramNotifier:  r.TelegramNotifier,
+		EmailNotifier:     r.EmailNotifier,
+		WebhookNotifier:   r.WebhookNotifier,
+		SlackNotifier:     r.SlackNotifier,
+		DiscordNotifier:   r.DiscordNotifier,
+		TeamsNotifier:     r.TeamsNotifier,
+		PagerDutyNotifier: r.PagerDutyNotifier,
+		OpsgenieNotifier:  r.OpsgenieNotifier,
+	}
+
+	copiedNotifier.HideSensitiveData()
+}
+
+func copyPointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+
+	return &copied
+}
+
+// EventSeverityResponse is an event of the catalog with its severity in the workspace
+type EventSeverityResponse struct {
+	EventType       NotificationEventType       `json:"eventType"`
+	Description     string                      `json:"description"`
+	DefaultSeverity notifier_incidents.Severity `json:"defaultSeverity"`
+	Severity        notifier_incidents.Severity `json:"severity"`
+	IsOverridden    bool                        `json:"isOverridden"`
+}
+
+type UpdateEventSeverityRequest struct {
+	Severity notifier_incidents.Severity `json:"severity" binding:"required"`
+}
@@ -3,10 +3,30 @@ package notifiers
 type NotifierType string
 
 const (
-	NotifierTypeEmail    NotifierType = "EMAIL"
-	NotifierTypeTelegram NotifierType = "TELEGRAM"
-	NotifierTypeWebhook  NotifierType = "WEBHOOK"
-	NotifierTypeSlack    NotifierType = "SLACK"
-	NotifierTypeDiscord  NotifierType = "DISCORD"
-	NotifierTypeTeams    NotifierType = "TEAMS"
+	NotifierTypeEmail     NotifierType = "EMAIL"
+	NotifierTypeTelegram  NotifierType = "TELEGRAM"
+	NotifierTypeWebhook   NotifierType = "WEBHOOK"
+	NotifierTypeSlack     NotifierType = "SLACK"
+	NotifierTypeDiscord   NotifierType = "DISCORD"
+	NotifierTypeTeams     NotifierType = "TEAMS"
+	NotifierTypePagerDuty NotifierType = "PAGERDUTY"
+	NotifierTypeOpsgenie  NotifierType = "OPSGENIE"
+)
+
+type NotifierHealthStatus string
+
+const (
+	// NotifierHealthStatusUnknown is set before the first check and for notifiers which
+	// cannot be checked without sending a notification
+	NotifierHealthStatusUnknown NotifierHealthStatus = "UNKNOWN"
+	NotifierHealthStatusHealthy NotifierHealthStatus = "HEALTHY"
+	NotifierHealthStatusBroken  NotifierHealthStatus = "BROKEN"
+)
+
+type NotifierIncidentStatus string
+
+const (
+	NotifierIncidentStatusTriggered    NotifierIncidentStatus = "TRIGGERED"
+	NotifierIncidentStatusAcknowledged NotifierIncidentStatus = "ACKNOWLEDGED"
+	NotifierIncidentStatusResolved     NotifierIncidentStatus = "RESOLVED"
 )
@@ -15,6 +15,12 @@ var (
 	ErrInsufficientPermissionsToTestNotifier = errors.New(
 		"insufficient permissions to test notifier in this workspace",
 	)
+	ErrUnknownTestEventType = errors.New(
+		"unknown test event type",
+	)
+	ErrNotifierDoesNotSupportIncidents = errors.New(
+		"notifier does not open incidents",
+	)
 	ErrNotifierDoesNotBelongToWorkspace = errors.New(
 		"notifier does not belong to this workspace",
 	)
@@ -30,6 +36,21 @@ var (
 	ErrNotifierHasAttachedDatabasesCannotTransfer = errors.New(
 		"notifier has attached databases and cannot be transferred",
 	)
+	ErrSystemNotifierCannotBeTransferred = errors.New(
+		"system notifier cannot be transferred between workspaces",
+	)
+	ErrSystemNotifierCannotBeMadePrivate = errors.New(
+		"system notifier cannot be changed to non-system",
+	)
+	ErrInsufficientPermissionsToManageEventSeverities = errors.New(
+		"insufficient permissions to manage event severities in this workspace",
+	)
+	ErrUnknownNotificationEvent = errors.New(
+		"unknown notification event type",
+	)
+	ErrInvalidNotificationSeverity = errors.New(
+		"severity must be one of INFO, WARNING, ERROR or CRITICAL",
+	)
 	ErrNotifierHasOtherAttachedDatabasesCannotTransfer = errors.New(
 		"notifier has other attached databases and cannot be transferred",
 	)
@@ -0,0 +1,137 @@
+package notifiers
+
+import (
+	"time"
+
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
+
+	"github.com/google/uuid"
+)
+
+type NotificationEventType string
+
+const (
+	NotificationEventBackupFailed             NotificationEventType = "backup_failed"
+	NotificationEventBackupSuccess            NotificationEventType = "backup_success"
+	NotificationEventRefreshFailed            NotificationEventType = "refresh_failed"
+	NotificationEventRefreshSuccess           NotificationEventType = "refresh_success"
+	NotificationEventDatabaseUnavailable      NotificationEventType = "database_unavailable"
+	NotificationEventDatabaseOnline           NotificationEventType = "database_online"
+	NotificationEventNotifierBroken           NotificationEventType = "notifier_broken"
+	NotificationEventCredentialsExpiring      NotificationEventType = "credentials_expiring"
+	NotificationEventCredentialsExpired       NotificationEventType = "credentials_expired"
+	NotificationEventStorageQuotaSoftExceeded NotificationEventType = "storage_quota_soft_exceeded"
+	NotificationEventStorageQuotaHardExceeded NotificationEventType = "storage_quota_hard_exceeded"
+	NotificationEventSecurityNewDeviceSignIn  NotificationEventType = "security_new_device_sign_in"
+	NotificationEventSecurityFailedSignIns    NotificationEventType = "security_failed_sign_ins"
+	NotificationEventSecurityPolicyChanged    NotificationEventType = "security_policy_changed"
+)
+
+// NotificationEvent is an entry of the event catalog. Workspaces may override the default
+// severity, see NotificationSeverityOverride
+type NotificationEvent struct {
+	Type            NotificationEventType       `json:"type"`
+	DefaultSeverity notifier_incidents.Severity `json:"defaultSeverity"`
+	Description     string                      `json:"description"`
+}
+
+// notificationEvents lists every event sent to notifiers, in the order shown to users
+var notificationEvents = []NotificationEvent{
+	{
+		NotificationEventBackupFailed,
+		notifier_incidents.SeverityError,
+		"A backup of a database failed",
+	},
+	{
+		NotificationEventBackupSuccess,
+		notifier_incidents.SeverityInfo,
+		"A backup of a database completed",
+	},
+	{
+		NotificationEventRefreshFailed,
+		notifier_incidents.SeverityError,
+		"A refresh failed to restore the latest backup into its target",
+	},
+	{
+		NotificationEventRefreshSuccess,
+		notifier_incidents.SeverityInfo,
+		"A refresh restored the latest backup into its target",
+	},
+	{
+		NotificationEventDatabaseUnavailable,
+		notifier_incidents.SeverityCritical,
+		"The health check cannot connect to a database",
+	},
+	{
+		NotificationEventDatabaseOnline,
+		notifier_incidents.SeverityInfo,
+		"A database is reachable again after being unavailable",
+	},
+	{
+		NotificationEventNotifierBroken,
+		notifier_incidents.SeverityWarning,
+		"Another notifier of the workspace failed its health check",
+	},
+	{
+		NotificationEventCredentialsExpiring,
+		notifier_incidents.SeverityWarning,
+		"Credentials of a database or storage expire soon",
+	},
+	{
+		NotificationEventCredentialsExpired,
+		notifier_incidents.SeverityError,
+		"Credentials of a database or storage have expired",
+	},
+	{
+		NotificationEventStorageQuotaSoftExceeded,
+		notifier_incidents.SeverityWarning,
+		"A storage reached its soft quota",
+	},
+	{
+		NotificationEventStorageQuotaHardExceeded,
+		notifier_incidents.SeverityCritical,
+		"A storage reached its hard quota and new backups to it are blocked",
+	},
+	{
+		NotificationEventSecurityNewDeviceSignIn,
+		notifier_incidents.SeverityWarning,
+		"A user signed in from a new device",
+	},
+	{
+		NotificationEventSecurityFailedSignIns,
+		notifier_incidents.SeverityWarning,
+		"Several sign-ins of a user failed in a row",
+	},
+	{
+		NotificationEventSecurityPolicyChanged,
+		notifier_incidents.SeverityInfo,
+		"An admin changed the security policy of the instance",
+	},
+}
+
+// NotificationSeverityOverride replaces the default severity of an event in a workspace,
+// e.g. to page people on failed refreshes of production workspaces
+type NotificationSeverityOverride struct {
+	WorkspaceID uuid.UUID                   `json:"workspaceId" gorm:"column:workspace_id;type:uuid;primaryKey"`
+	EventType   NotificationEventType       `json:"eventType"   gorm:"column:event_type;type:text;primaryKey"`
+	Severity    notifier_incidents.Severity `json:"severity"    gorm:"column:severity;type:text;not null"`
+	UpdatedAt   time.Time                   `json:"updatedAt"   gorm:"column:updated_at;not null"`
+}
+
+func (NotificationSeverityOverride) TableName() string {
+	return "notification_severity_overrides"
+}
+
+func GetNotificationEvents() []NotificationEvent {
+	return notificationEvents
+}
+
+func findNotificationEvent(eventType NotificationEventType) (NotificationEvent, bool) {
+	for _, event := range notificationEvents {
+		if event.Type == eventType {
+			return event, true
+		}
+	}
+
+	return NotificationEvent{}, false
+}
@@ -0,0 +1,31 @@
+package notifiers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotifierIncident is an incident opened by an incident notifier. Notifications with the
+// same heading are repeats of the open incident, until it is resolved
+type NotifierIncident struct {
+	ID         uuid.UUID              `json:"id"         gorm:"column:id;type:uuid;primaryKey"`
+	NotifierID uuid.UUID              `json:"notifierId" gorm:"column:notifier_id;type:uuid;not null"`
+	DedupKey   string                 `json:"dedupKey"   gorm:"column:dedup_key;type:text;not null"`
+	Title      string                 `json:"title"      gorm:"column:title;type:text;not null"`
+	Status     NotifierIncidentStatus `json:"status"     gorm:"column:status;type:text;not null"`
+
+	// TriggerCount includes repeats which were not sent, because the incident was
+	// acknowledged
+	TriggerCount    int       `json:"triggerCount"    gorm:"column:trigger_count;type:int;not null"`
+	LastTriggeredAt time.Time `json:"lastTriggeredAt" gorm:"column:last_triggered_at"`
+
+	AcknowledgedBy *string    `json:"acknowledgedBy" gorm:"column:acknowledged_by;type:text"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt" gorm:"column:acknowledged_at"`
+	ResolvedAt     *time.Time `json:"resolvedAt"     gorm:"column:resolved_at"`
+	CreatedAt      time.Time  `json:"createdAt"      gorm:"column:created_at"`
+}
+
+func (NotifierIncident) TableName() string {
+	return "notifier_incidents"
+}
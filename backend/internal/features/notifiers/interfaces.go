@@ -1,8 +1,10 @@
 package notifiers
 
 import (
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
 	"databasus-backend/internal/util/encryption"
 	"log/slog"
+	"net/http"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +24,31 @@ type NotificationSender interface {
 	EncryptSensitiveData(encryptor encryption.FieldEncryptor) error
 }
 
+// NotificationVerifier is implemented by notifiers which can be checked without delivering
+// a notification to people
+type NotificationVerifier interface {
+	Verify(encryptor encryption.FieldEncryptor, logger *slog.Logger) error
+}
+
+// IncidentNotifier is implemented by notifiers which open incidents in an incident
+// management service and receive webhooks when people acknowledge or resolve them
+type IncidentNotifier interface {
+	SendIncident(
+		encryptor encryption.FieldEncryptor,
+		logger *slog.Logger,
+		dedupKey string,
+		severity notifier_incidents.Severity,
+		heading string,
+		message string,
+	) error
+
+	ParseIncidentWebhook(
+		encryptor encryption.FieldEncryptor,
+		header http.Header,
+		body []byte,
+	) (*notifier_incidents.WebhookEvent, error)
+}
+
 type NotifierDatabaseCounter interface {
 	GetNotifierAttachedDatabasesIDs(notifierID uuid.UUID) ([]uuid.UUID, error)
 }
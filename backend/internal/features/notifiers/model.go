@@ -3,13 +3,19 @@ package notifiers
 import (
 	discord_notifier "databasus-backend/internal/features/notifiers/models/discord"
 	"databasus-backend/internal/features/notifiers/models/email_notifier"
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
+	opsgenie_notifier "databasus-backend/internal/features/notifiers/models/opsgenie"
+	pagerduty_notifier "databasus-backend/internal/features/notifiers/models/pagerduty"
 	slack_notifier "databasus-backend/internal/features/notifiers/models/slack"
 	teams_notifier "databasus-backend/internal/features/notifiers/models/teams"
 	telegram_notifier "databasus-backend/internal/features/notifiers/models/telegram"
 	webhook_notifier "databasus-backend/internal/features/notifiers/models/webhook"
 	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/i18n"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,14 +26,33 @@ type Notifier struct {
 	Name          string       `json:"name"          gorm:"column:name;not null;type:varchar(255)"`
 	NotifierType  NotifierType `json:"notifierType"  gorm:"column:notifier_type;not null;type:varchar(50)"`
 	LastSendError *string      `json:"lastSendError" gorm:"column:last_send_error;type:text"`
+	Locale        i18n.Locale  `json:"locale"        gorm:"column:locale;type:text;not null;default:en"`
+
+	// IsSystem notifiers are defined by admins and shared read-only with every workspace,
+	// e.g. a central ops channel
+	IsSystem bool `json:"isSystem" gorm:"column:is_system;type:boolean;not null;default:false"`
+
+	// IsWorkspaceDefault notifiers are attached to each database created in the workspace,
+	// unless the database opts out of default notifiers
+	IsWorkspaceDefault bool `json:"isWorkspaceDefault" gorm:"column:is_workspace_default;type:boolean;not null;default:false"`
+
+	HealthStatus      NotifierHealthStatus `json:"healthStatus"      gorm:"column:health_status;type:text;not null;default:UNKNOWN"`
+	HealthCheckError  *string              `json:"healthCheckError"  gorm:"column:health_check_error;type:text"`
+	LastHealthCheckAt *time.Time           `json:"lastHealthCheckAt" gorm:"column:last_health_check_at"`
+
+	// ChangeReason is sent with edits and written to the audit log, it is required in
+	// workspaces with IsChangeReasonRequired. Not stored on the notifier
+	ChangeReason string `json:"changeReason,omitempty" gorm:"-"`
 
 	// specific notifier
-	TelegramNotifier *telegram_notifier.TelegramNotifier `json:"telegramNotifier"        gorm:"foreignKey:NotifierID"`
-	EmailNotifier    *email_notifier.EmailNotifier       `json:"emailNotifier"           gorm:"foreignKey:NotifierID"`
-	WebhookNotifier  *webhook_notifier.WebhookNotifier   `json:"webhookNotifier"         gorm:"foreignKey:NotifierID"`
-	SlackNotifier    *slack_notifier.SlackNotifier       `json:"slackNotifier"           gorm:"foreignKey:NotifierID"`
-	DiscordNotifier  *discord_notifier.DiscordNotifier   `json:"discordNotifier"         gorm:"foreignKey:NotifierID"`
-	TeamsNotifier    *teams_notifier.TeamsNotifier       `json:"teamsNotifier,omitempty" gorm:"foreignKey:NotifierID;constraint:OnDelete:CASCADE"`
+	TelegramNotifier  *telegram_notifier.TelegramNotifier   `json:"telegramNotifier"        gorm:"foreignKey:NotifierID"`
+	EmailNotifier     *email_notifier.EmailNotifier         `json:"emailNotifier"           gorm:"foreignKey:NotifierID"`
+	WebhookNotifier   *webhook_notifier.WebhookNotifier     `json:"webhookNotifier"         gorm:"foreignKey:NotifierID"`
+	SlackNotifier     *slack_notifier.SlackNotifier         `json:"slackNotifier"           gorm:"foreignKey:NotifierID"`
+	DiscordNotifier   *discord_notifier.DiscordNotifier     `json:"discordNotifier"         gorm:"foreignKey:NotifierID"`
+	TeamsNotifier     *teams_notifier.TeamsNotifier         `json:"teamsNotifier,omitempty" gorm:"foreignKey:NotifierID;constraint:OnDelete:CASCADE"`
+	PagerDutyNotifier *pagerduty_notifier.PagerDutyNotifier `json:"pagerDutyNotifier,omitempty" gorm:"foreignKey:NotifierID"`
+	OpsgenieNotifier  *opsgenie_notifier.OpsgenieNotifier   `json:"opsgenieNotifier,omitempty" gorm:"foreignKey:NotifierID"`
 }
 
 func (n *Notifier) TableName() string {
@@ -39,6 +64,10 @@ func (n *Notifier) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("name is required")
 	}
 
+	if n.Locale != "" && !i18n.IsSupportedLocale(n.Locale) {
+		return fmt.Errorf("unsupported locale: %s", n.Locale)
+	}
+
 	return n.getSpecificNotifier().Validate(encryptor)
 }
 
@@ -60,6 +89,50 @@ func (n *Notifier) Send(
 	return err
 }
 
+// Verify checks the notifier without delivering a notification. Notifiers which cannot be
+// checked this way are reported as not verifiable
+func (n *Notifier) Verify(
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+) (isVerifiable bool, err error) {
+	verifier, isVerifiable := n.getSpecificNotifier().(NotificationVerifier)
+	if !isVerifiable {
+		return false, nil
+	}
+
+	return true, verifier.Verify(encryptor, logger)
+}
+
+// SendIncident opens or repeats the incident of the heading with the severity of the event
+func (n *Notifier) SendIncident(
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	severity notifier_incidents.Severity,
+	heading string,
+	message string,
+) error {
+	incidentNotifier, isIncidentNotifier := n.getSpecificNotifier().(IncidentNotifier)
+	if !isIncidentNotifier {
+		return ErrNotifierDoesNotSupportIncidents
+	}
+
+	return incidentNotifier.SendIncident(
+		encryptor,
+		logger,
+		notifier_incidents.DedupKey(heading),
+		severity,
+		heading,
+		message,
+	)
+}
+
+// IsIncidentNotifier reports notifiers which open incidents, their notifications are
+// tracked as incidents with state synced back by webhooks
+func (n *Notifier) IsIncidentNotifier() bool {
+	_, isIncidentNotifier := n.getSpecificNotifier().(IncidentNotifier)
+	return isIncidentNotifier
+}
+
 func (n *Notifier) HideSensitiveData() {
 	n.getSpecificNotifier().HideSensitiveData()
 }
@@ -71,6 +144,9 @@ func (n *Notifier) EncryptSensitiveData(encryptor encryption.FieldEncryptor) err
 func (n *Notifier) Update(incoming *Notifier) {
 	n.Name = incoming.Name
 	n.NotifierType = incoming.NotifierType
+	n.Locale = incoming.Locale
+	n.IsWorkspaceDefault = incoming.IsWorkspaceDefault
+	n.IsSystem = incoming.IsSystem
 
 	switch n.NotifierType {
 	case NotifierTypeTelegram:
@@ -97,6 +173,14 @@ func (n *Notifier) Update(incoming *Notifier) {
 		if n.TeamsNotifier != nil && incoming.TeamsNotifier != nil {
 			n.TeamsNotifier.Update(incoming.TeamsNotifier)
 		}
+	case NotifierTypePagerDuty:
+		if n.PagerDutyNotifier != nil && incoming.PagerDutyNotifier != nil {
+			n.PagerDutyNotifier.Update(incoming.PagerDutyNotifier)
+		}
+	case NotifierTypeOpsgenie:
+		if n.OpsgenieNotifier != nil && incoming.OpsgenieNotifier != nil {
+			n.OpsgenieNotifier.Update(incoming.OpsgenieNotifier)
+		}
 	}
 }
 
@@ -114,6 +198,10 @@ func (n *Notifier) getSpecificNotifier() NotificationSender {
 		return n.DiscordNotifier
 	case NotifierTypeTeams:
 		return n.TeamsNotifier
+	case NotifierTypePagerDuty:
+		return n.PagerDutyNotifier
+	case NotifierTypeOpsgenie:
+		return n.OpsgenieNotifier
 	default:
 		panic("unknown notifier type: " + string(n.NotifierType))
 	}
@@ -83,6 +83,34 @@ func (d *DiscordNotifier) Send(
 	return nil
 }
 
+// Verify fetches the webhook, Discord returns it without posting to the channel
+func (d *DiscordNotifier) Verify(encryptor encryption.FieldEncryptor, _ *slog.Logger) error {
+	webhookURL, err := encryptor.Decrypt(d.NotifierID, d.ChannelWebhookURL)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt webhook URL: %w", err)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Get(webhookURL)
+	if err != nil {
+		return errors.New("failed to reach Discord webhook")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(
+			"discord API returned non-OK status: %s. Error: %s",
+			resp.Status,
+			string(bodyBytes),
+		)
+	}
+
+	return nil
+}
+
 func (d *DiscordNotifier) HideSensitiveData() {
 	d.ChannelWebhookURL = ""
 }
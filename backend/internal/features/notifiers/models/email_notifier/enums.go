@@ -0,0 +1,30 @@
+package email_notifier
+
+import "crypto/tls"
+
+type TLSPolicy string
+
+const (
+	// TLSPolicyOpportunistic upgrades with STARTTLS when the server offers it
+	TLSPolicyOpportunistic TLSPolicy = "OPPORTUNISTIC"
+	// TLSPolicyRequired fails when the connection cannot be encrypted
+	TLSPolicyRequired TLSPolicy = "REQUIRED"
+	// TLSPolicyPlaintextAllowed never upgrades the connection, e.g. for relays in lab networks
+	TLSPolicyPlaintextAllowed TLSPolicy = "PLAINTEXT_ALLOWED"
+)
+
+type TLSVersion string
+
+const (
+	TLSVersion10 TLSVersion = "TLS1.0"
+	TLSVersion11 TLSVersion = "TLS1.1"
+	TLSVersion12 TLSVersion = "TLS1.2"
+	TLSVersion13 TLSVersion = "TLS1.3"
+)
+
+var tlsVersions = map[TLSVersion]uint16{
+	TLSVersion10: tls.VersionTLS10,
+	TLSVersion11: tls.VersionTLS11,
+	TLSVersion12: tls.VersionTLS12,
+	TLSVersion13: tls.VersionTLS13,
+}
@@ -1,8 +1,12 @@
 package email_notifier
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"databasus-backend/internal/util/encryption"
+	network_utils "databasus-backend/internal/util/network"
+	proxy_utils "databasus-backend/internal/util/proxy"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -30,6 +34,16 @@ type EmailNotifier struct {
 	SMTPUser     string    `json:"smtpUser"     gorm:"type:varchar(255);column:smtp_user"`
 	SMTPPassword string    `json:"smtpPassword" gorm:"type:varchar(255);column:smtp_password"`
 	From         string    `json:"from"         gorm:"type:varchar(255);column:from_email"`
+
+	// Empty TLS policy is opportunistic, as for notifiers created before policies. Custom CA
+	// is a PEM bundle trusted in addition to system CAs
+	TLSPolicy     TLSPolicy  `json:"tlsPolicy"     gorm:"not null;type:text;column:tls_policy"`
+	CustomCACert  string     `json:"customCaCert"  gorm:"not null;type:text;column:custom_ca_cert"`
+	MinTLSVersion TLSVersion `json:"minTlsVersion" gorm:"not null;type:text;column:min_tls_version"`
+
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this notifier. HTTP proxies have to allow
+	// CONNECT to the SMTP port, see proxy_utils.DialContext
+	ProxyURL string `json:"proxyUrl" gorm:"not null;default:'';type:text;column:proxy_url"`
 }
 
 func (e *EmailNotifier) TableName() string {
@@ -54,7 +68,29 @@ func (e *EmailNotifier) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("SMTP user and password must both be provided or both be empty")
 	}
 
-	return nil
+	switch e.TLSPolicy {
+	case "", TLSPolicyOpportunistic, TLSPolicyRequired:
+	case TLSPolicyPlaintextAllowed:
+		if e.SMTPPort == ImplicitTLSPort {
+			return fmt.Errorf("port %d always uses TLS, plaintext is not allowed", ImplicitTLSPort)
+		}
+	default:
+		return errors.New("invalid TLS policy: " + string(e.TLSPolicy))
+	}
+
+	if e.MinTLSVersion != "" {
+		if _, ok := tlsVersions[e.MinTLSVersion]; !ok {
+			return errors.New("invalid minimum TLS version: " + string(e.MinTLSVersion))
+		}
+	}
+
+	if e.CustomCACert != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(e.CustomCACert)) {
+			return errors.New("custom CA bundle contains no valid PEM certificates")
+		}
+	}
+
+	return proxy_utils.ValidateProxyURL(e.ProxyURL)
 }
 
 func (e *EmailNotifier) Send(
@@ -89,8 +125,36 @@ func (e *EmailNotifier) Send(
 	return e.sendStartTLS(emailContent, from, smtpPassword, isAuthRequired)
 }
 
+// Verify connects and authenticates to the SMTP server without sending an email
+func (e *EmailNotifier) Verify(encryptor encryption.FieldEncryptor, _ *slog.Logger) error {
+	var smtpPassword string
+	if e.SMTPPassword != "" {
+		decrypted, err := encryptor.Decrypt(e.NotifierID, e.SMTPPassword)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt SMTP password: %w", err)
+		}
+		smtpPassword = decrypted
+	}
+
+	createClient := e.createStartTLSClient
+	if e.SMTPPort == ImplicitTLSPort {
+		createClient = e.createImplicitTLSClient
+	}
+
+	isAuthRequired := e.SMTPUser != "" && smtpPassword != ""
+
+	_, cleanup, err := e.authenticateWithRetry(createClient, smtpPassword, isAuthRequired)
+	if err != nil {
+		return err
+	}
+	cleanup()
+
+	return nil
+}
+
 func (e *EmailNotifier) HideSensitiveData() {
 	e.SMTPPassword = ""
+	e.ProxyURL = proxy_utils.RedactProxyURL(e.ProxyURL)
 }
 
 func (e *EmailNotifier) Update(incoming *EmailNotifier) {
@@ -99,6 +163,10 @@ func (e *EmailNotifier) Update(incoming *EmailNotifier) {
 	e.SMTPPort = incoming.SMTPPort
 	e.SMTPUser = incoming.SMTPUser
 	e.From = incoming.From
+	e.TLSPolicy = incoming.TLSPolicy
+	e.CustomCACert = incoming.CustomCACert
+	e.MinTLSVersion = incoming.MinTLSVersion
+	e.ProxyURL = proxy_utils.MergeRedactedProxyURL(e.ProxyURL, incoming.ProxyURL)
 
 	if incoming.SMTPPassword != "" {
 		e.SMTPPassword = incoming.SMTPPassword
@@ -185,17 +253,74 @@ func (e *EmailNotifier) sendStartTLS(
 	return e.sendEmail(client, from, emailContent)
 }
 
+// getHost strips brackets of IPv6 literals, TLS and SMTP auth expect the bare host
+func (e *EmailNotifier) getHost() string {
+	return network_utils.NormalizeHost(e.SMTPHost)
+}
+
+func (e *EmailNotifier) buildTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: e.getHost()}
+
+	if e.MinTLSVersion != "" {
+		minVersion, ok := tlsVersions[e.MinTLSVersion]
+		if !ok {
+			return nil, errors.New("invalid minimum TLS version: " + string(e.MinTLSVersion))
+		}
+		tlsConfig.MinVersion = minVersion
+	}
+
+	if e.CustomCACert != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM([]byte(e.CustomCACert)) {
+			return nil, errors.New("custom CA bundle contains no valid PEM certificates")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	return tlsConfig, nil
+}
+
+// describeTLSError adds the configured TLS settings to handshake errors, so a server
+// which cannot satisfy them is told apart from other connection problems
+func (e *EmailNotifier) describeTLSError(err error) error {
+	details := "system CAs"
+	if e.CustomCACert != "" {
+		details = "custom CA bundle"
+	}
+	if e.MinTLSVersion != "" {
+		details += ", minimum version " + string(e.MinTLSVersion)
+	}
+
+	return fmt.Errorf("TLS handshake with SMTP server failed (%s): %w", details, err)
+}
+
 func (e *EmailNotifier) createImplicitTLSClient() (*smtp.Client, func(), error) {
-	addr := net.JoinHostPort(e.SMTPHost, fmt.Sprintf("%d", e.SMTPPort))
-	tlsConfig := &tls.Config{ServerName: e.SMTPHost}
+	addr := network_utils.JoinHostPort(e.SMTPHost, e.SMTPPort)
 	dialer := &net.Dialer{Timeout: DefaultTimeout}
 
-	conn, err := tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	tlsConfig, err := e.buildTLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tcpConn, err := proxy_utils.DialContext(context.Background(), dialer, e.ProxyURL, addr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
 	}
 
-	client, err := smtp.NewClient(conn, e.SMTPHost)
+	conn := tls.Client(tcpConn, tlsConfig)
+	_ = conn.SetDeadline(time.Now().Add(DefaultTimeout))
+	if err := conn.Handshake(); err != nil {
+		_ = tcpConn.Close()
+		return nil, nil, e.describeTLSError(err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	client, err := smtp.NewClient(conn, e.getHost())
 	if err != nil {
 		_ = conn.Close()
 		return nil, nil, fmt.Errorf("failed to create SMTP client: %w", err)
@@ -205,15 +330,15 @@ func (e *EmailNotifier) createImplicitTLSClient() (*smtp.Client, func(), error)
 }
 
 func (e *EmailNotifier) createStartTLSClient() (*smtp.Client, func(), error) {
-	addr := net.JoinHostPort(e.SMTPHost, fmt.Sprintf("%d", e.SMTPPort))
+	addr := network_utils.JoinHostPort(e.SMTPHost, e.SMTPPort)
 	dialer := &net.Dialer{Timeout: DefaultTimeout}
 
-	conn, err := dialer.Dial("tcp", addr)
+	conn, err := proxy_utils.DialContext(context.Background(), dialer, e.ProxyURL, addr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
 	}
 
-	client, err := smtp.NewClient(conn, e.SMTPHost)
+	client, err := smtp.NewClient(conn, e.getHost())
 	if err != nil {
 		_ = conn.Close()
 		return nil, nil, fmt.Errorf("failed to create SMTP client: %w", err)
@@ -225,12 +350,29 @@ func (e *EmailNotifier) createStartTLSClient() (*smtp.Client, func(), error) {
 		return nil, nil, fmt.Errorf("SMTP hello failed: %w", err)
 	}
 
+	if e.TLSPolicy == TLSPolicyPlaintextAllowed {
+		return client, func() { _ = client.Quit() }, nil
+	}
+
 	if ok, _ := client.Extension("STARTTLS"); ok {
-		if err := client.StartTLS(&tls.Config{ServerName: e.SMTPHost}); err != nil {
+		tlsConfig, err := e.buildTLSConfig()
+		if err != nil {
+			_ = client.Quit()
+			_ = conn.Close()
+			return nil, nil, err
+		}
+
+		if err := client.StartTLS(tlsConfig); err != nil {
 			_ = client.Quit()
 			_ = conn.Close()
-			return nil, nil, fmt.Errorf("STARTTLS failed: %w", err)
+			return nil, nil, fmt.Errorf("STARTTLS failed: %w", e.describeTLSError(err))
 		}
+	} else if e.TLSPolicy == TLSPolicyRequired {
+		_ = client.Quit()
+		_ = conn.Close()
+		return nil, nil, errors.New(
+			"SMTP server does not support STARTTLS, but the TLS policy requires encryption",
+		)
 	}
 
 	return client, func() { _ = client.Quit() }, nil
@@ -251,7 +393,7 @@ func (e *EmailNotifier) authenticateWithRetry(
 	}
 
 	// Try PLAIN auth first
-	plainAuth := smtp.PlainAuth("", e.SMTPUser, password, e.SMTPHost)
+	plainAuth := smtp.PlainAuth("", e.SMTPUser, password, e.getHost())
 	if err := client.Auth(plainAuth); err == nil {
 		return client, cleanup, nil
 	}
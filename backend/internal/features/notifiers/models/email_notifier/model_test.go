@@ -0,0 +1,113 @@
+package email_notifier
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailNotifier_Validate_TLSOptions(t *testing.T) {
+	t.Run("Unknown TLS policy: Validation fails", func(t *testing.T) {
+		notifier := createTestEmailNotifier(587)
+		notifier.TLSPolicy = "SOMETIMES"
+
+		assert.ErrorContains(t, notifier.Validate(nil), "invalid TLS policy")
+	})
+
+	t.Run("Plaintext on implicit TLS port: Validation fails", func(t *testing.T) {
+		notifier := createTestEmailNotifier(ImplicitTLSPort)
+		notifier.TLSPolicy = TLSPolicyPlaintextAllowed
+
+		assert.ErrorContains(t, notifier.Validate(nil), "plaintext is not allowed")
+	})
+
+	t.Run("Unknown minimum TLS version: Validation fails", func(t *testing.T) {
+		notifier := createTestEmailNotifier(587)
+		notifier.MinTLSVersion = "SSL3"
+
+		assert.ErrorContains(t, notifier.Validate(nil), "invalid minimum TLS version")
+	})
+
+	t.Run("CA bundle without certificates: Validation fails", func(t *testing.T) {
+		notifier := createTestEmailNotifier(587)
+		notifier.CustomCACert = "not a certificate"
+
+		assert.ErrorContains(t, notifier.Validate(nil), "no valid PEM certificates")
+	})
+
+	t.Run("Required TLS with minimum version: Validation passes", func(t *testing.T) {
+		notifier := createTestEmailNotifier(587)
+		notifier.TLSPolicy = TLSPolicyRequired
+		notifier.MinTLSVersion = TLSVersion13
+
+		assert.NoError(t, notifier.Validate(nil))
+	})
+}
+
+func TestEmailNotifier_Verify_WhenServerHasNoStartTLS(t *testing.T) {
+	port := startSMTPServerWithoutStartTLS(t)
+
+	t.Run("Opportunistic TLS: Plaintext connection is used", func(t *testing.T) {
+		notifier := createTestEmailNotifier(port)
+		notifier.TLSPolicy = TLSPolicyOpportunistic
+
+		assert.NoError(t, notifier.Verify(nil, nil))
+	})
+
+	t.Run("Required TLS: Verification fails", func(t *testing.T) {
+		notifier := createTestEmailNotifier(port)
+		notifier.TLSPolicy = TLSPolicyRequired
+
+		assert.ErrorContains(t, notifier.Verify(nil, nil), "does not support STARTTLS")
+	})
+}
+
+func createTestEmailNotifier(port int) *EmailNotifier {
+	return &EmailNotifier{
+		TargetEmail: "admin@example.com",
+		SMTPHost:    "127.0.0.1",
+		SMTPPort:    port,
+	}
+}
+
+// startSMTPServerWithoutStartTLS accepts SMTP sessions which do not offer any extension
+func startSMTPServerWithoutStartTLS(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer func() { _ = conn.Close() }()
+
+				_, _ = conn.Write([]byte("220 localhost ESMTP\r\n"))
+
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					if strings.HasPrefix(strings.ToUpper(line), "QUIT") {
+						_, _ = conn.Write([]byte("221 bye\r\n"))
+						return
+					}
+
+					_, _ = conn.Write([]byte("250 localhost\r\n"))
+				}
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,48 @@
+package notifier_incidents
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type IncidentAction string
+
+const (
+	IncidentActionAcknowledge IncidentAction = "ACKNOWLEDGE"
+	IncidentActionResolve     IncidentAction = "RESOLVE"
+)
+
+// Severity of a notification, incident management services page or route by it
+type Severity string
+
+const (
+	SeverityInfo     Severity = "INFO"
+	SeverityWarning  Severity = "WARNING"
+	SeverityError    Severity = "ERROR"
+	SeverityCritical Severity = "CRITICAL"
+)
+
+func (s Severity) IsValid() bool {
+	switch s {
+	case SeverityInfo, SeverityWarning, SeverityError, SeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookEvent is a state change of an incident reported by the incident management
+// service. Events about incidents not created by Databasus have no action
+type WebhookEvent struct {
+	DedupKey string
+	Action   IncidentAction
+	Actor    string
+}
+
+// DedupKey groups repeated notifications with the same heading into one incident, e.g.
+// the failures of backups of one database
+func DedupKey(heading string) string {
+	hash := sha256.Sum256([]byte(heading))
+
+	return "databasus-" + hex.EncodeToString(hash[:16])
+}
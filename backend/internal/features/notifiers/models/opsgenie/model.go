@@ -0,0 +1,258 @@
+package opsgenie_notifier
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+type OpsgenieRegion string
+
+const (
+	OpsgenieRegionUS OpsgenieRegion = "US"
+	OpsgenieRegionEU OpsgenieRegion = "EU"
+)
+
+const (
+	// WebhookSecretHeader is configured as a custom header of the Opsgenie webhook
+	// integration, Opsgenie does not sign webhooks
+	WebhookSecretHeader = "X-Databasus-Webhook-Secret"
+
+	maxMessageLength     = 130
+	maxDescriptionLength = 15000
+)
+
+var alertsAPIURLs = map[OpsgenieRegion]string{
+	OpsgenieRegionUS: "https://api.opsgenie.com/v2/alerts",
+	OpsgenieRegionEU: "https://api.eu.opsgenie.com/v2/alerts",
+}
+
+type OpsgenieNotifier struct {
+	NotifierID uuid.UUID      `json:"notifierId" gorm:"primaryKey;column:notifier_id"`
+	APIKey     string         `json:"apiKey"     gorm:"not null;column:api_key"`
+	Region     OpsgenieRegion `json:"region"     gorm:"not null;column:region;default:US"`
+
+	// WebhookSecret is compared with the header sent by the webhook integration, alert
+	// state is synced back only when it is set
+	WebhookSecret string `json:"webhookSecret" gorm:"column:webhook_secret"`
+}
+
+func (o *OpsgenieNotifier) TableName() string {
+	return "opsgenie_notifiers"
+}
+
+func (o *OpsgenieNotifier) Validate(encryptor encryption.FieldEncryptor) error {
+	if o.APIKey == "" {
+		return errors.New("API key is required")
+	}
+
+	if o.Region != "" {
+		if _, isKnown := alertsAPIURLs[o.Region]; !isKnown {
+			return fmt.Errorf("invalid Opsgenie region: %s", o.Region)
+		}
+	}
+
+	return nil
+}
+
+func (o *OpsgenieNotifier) Send(
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	heading string,
+	message string,
+) error {
+	return o.SendIncident(
+		encryptor,
+		logger,
+		notifier_incidents.DedupKey(heading),
+		notifier_incidents.SeverityError,
+		heading,
+		message,
+	)
+}
+
+// SendIncident creates an alert, Opsgenie counts alerts with the same alias as repeats of
+// the open one
+func (o *OpsgenieNotifier) SendIncident(
+	encryptor encryption.FieldEncryptor,
+	_ *slog.Logger,
+	dedupKey string,
+	severity notifier_incidents.Severity,
+	heading string,
+	message string,
+) error {
+	apiKey, err := encryptor.Decrypt(o.NotifierID, o.APIKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt API key: %w", err)
+	}
+
+	payload := map[string]any{
+		"message":     truncate(heading, maxMessageLength),
+		"alias":       dedupKey,
+		"description": truncate(message, maxDescriptionLength),
+		"source":      "Databasus",
+		"priority":    toOpsgeniePriority(severity),
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Opsgenie payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", o.getAlertsAPIURL(), bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Opsgenie alert: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(
+			"Opsgenie API returned non-OK status: %s. Error: %s",
+			resp.Status,
+			string(bodyBytes),
+		)
+	}
+
+	return nil
+}
+
+// ParseIncidentWebhook checks the secret header of an outgoing webhook and reads the alert
+// state change from it
+func (o *OpsgenieNotifier) ParseIncidentWebhook(
+	encryptor encryption.FieldEncryptor,
+	header http.Header,
+	body []byte,
+) (*notifier_incidents.WebhookEvent, error) {
+	if o.WebhookSecret == "" {
+		return nil, errors.New("webhook secret is not configured for this notifier")
+	}
+
+	webhookSecret, err := encryptor.Decrypt(o.NotifierID, o.WebhookSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare(
+		[]byte(header.Get(WebhookSecretHeader)),
+		[]byte(webhookSecret),
+	) != 1 {
+		return nil, errors.New("invalid webhook secret")
+	}
+
+	var webhook struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Alias    string `json:"alias"`
+			Username string `json:"username"`
+		} `json:"alert"`
+	}
+
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	event := &notifier_incidents.WebhookEvent{
+		DedupKey: webhook.Alert.Alias,
+		Actor:    webhook.Alert.Username,
+	}
+
+	switch webhook.Action {
+	case "Acknowledge":
+		event.Action = notifier_incidents.IncidentActionAcknowledge
+	case "Close":
+		event.Action = notifier_incidents.IncidentActionResolve
+	}
+
+	return event, nil
+}
+
+func (o *OpsgenieNotifier) HideSensitiveData() {
+	o.APIKey = ""
+	o.WebhookSecret = ""
+}
+
+func (o *OpsgenieNotifier) Update(incoming *OpsgenieNotifier) {
+	o.Region = incoming.Region
+
+	if incoming.APIKey != "" {
+		o.APIKey = incoming.APIKey
+	}
+
+	if incoming.WebhookSecret != "" {
+		o.WebhookSecret = incoming.WebhookSecret
+	}
+}
+
+func (o *OpsgenieNotifier) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	if o.APIKey != "" {
+		encrypted, err := encryptor.Encrypt(o.NotifierID, o.APIKey)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt API key: %w", err)
+		}
+		o.APIKey = encrypted
+	}
+
+	if o.WebhookSecret != "" {
+		encrypted, err := encryptor.Encrypt(o.NotifierID, o.WebhookSecret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+		o.WebhookSecret = encrypted
+	}
+
+	return nil
+}
+
+func (o *OpsgenieNotifier) getAlertsAPIURL() string {
+	if url, isKnown := alertsAPIURLs[o.Region]; isKnown {
+		return url
+	}
+
+	return alertsAPIURLs[OpsgenieRegionUS]
+}
+
+func truncate(value string, maxLength int) string {
+	runes := []rune(value)
+	if len(runes) > maxLength {
+		return string(runes[:maxLength])
+	}
+
+	return value
+}
+
+// toOpsgeniePriority maps the severity to alert priorities, errors keep P2 which was sent
+// for every alert before severities
+func toOpsgeniePriority(severity notifier_incidents.Severity) string {
+	switch severity {
+	case notifier_incidents.SeverityCritical:
+		return "P1"
+	case notifier_incidents.SeverityWarning:
+		return "P3"
+	case notifier_incidents.SeverityInfo:
+		return "P5"
+	default:
+		return "P2"
+	}
+}
@@ -0,0 +1,262 @@
+package pagerduty_notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const (
+	eventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+
+	// SignatureHeader is set by PagerDuty on V3 webhooks, it has format
+	// "v1=<hex hmac>[,v1=...]" with several signatures while the secret is being rolled
+	SignatureHeader = "X-PagerDuty-Signature"
+
+	maxSummaryLength = 1024
+)
+
+type PagerDutyNotifier struct {
+	NotifierID uuid.UUID `json:"notifierId" gorm:"primaryKey;column:notifier_id"`
+	RoutingKey string    `json:"routingKey" gorm:"not null;column:routing_key"`
+
+	// WebhookSecret is the signing secret of the V3 webhook subscription, incident state is
+	// synced back only when it is set
+	WebhookSecret string `json:"webhookSecret" gorm:"column:webhook_secret"`
+}
+
+func (p *PagerDutyNotifier) TableName() string {
+	return "pagerduty_notifiers"
+}
+
+func (p *PagerDutyNotifier) Validate(encryptor encryption.FieldEncryptor) error {
+	if p.RoutingKey == "" {
+		return errors.New("routing key is required")
+	}
+
+	return nil
+}
+
+func (p *PagerDutyNotifier) Send(
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	heading string,
+	message string,
+) error {
+	return p.SendIncident(
+		encryptor,
+		logger,
+		notifier_incidents.DedupKey(heading),
+		notifier_incidents.SeverityError,
+		heading,
+		message,
+	)
+}
+
+// SendIncident triggers an event, PagerDuty groups events with the same dedup key into
+// one open incident
+func (p *PagerDutyNotifier) SendIncident(
+	encryptor encryption.FieldEncryptor,
+	_ *slog.Logger,
+	dedupKey string,
+	severity notifier_incidents.Severity,
+	heading string,
+	message string,
+) error {
+	routingKey, err := encryptor.Decrypt(p.NotifierID, p.RoutingKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt routing key: %w", err)
+	}
+
+	summary := []rune(heading)
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength]
+	}
+
+	payload := map[string]any{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    dedupKey,
+		"payload": map[string]any{
+			"summary":  string(summary),
+			"source":   "databasus",
+			"severity": toPagerDutySeverity(severity),
+			"custom_details": map[string]string{
+				"message": message,
+			},
+		},
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PagerDuty payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", eventsAPIURL, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send PagerDuty event: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(
+			"PagerDuty API returned non-OK status: %s. Error: %s",
+			resp.Status,
+			string(bodyBytes),
+		)
+	}
+
+	return nil
+}
+
+// ParseIncidentWebhook verifies the signature of a V3 webhook and reads the incident state
+// change from it
+func (p *PagerDutyNotifier) ParseIncidentWebhook(
+	encryptor encryption.FieldEncryptor,
+	header http.Header,
+	body []byte,
+) (*notifier_incidents.WebhookEvent, error) {
+	if p.WebhookSecret == "" {
+		return nil, errors.New("webhook secret is not configured for this notifier")
+	}
+
+	webhookSecret, err := encryptor.Decrypt(p.NotifierID, p.WebhookSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	if !isValidSignature(body, header.Get(SignatureHeader), webhookSecret) {
+		return nil, errors.New("invalid webhook signature")
+	}
+
+	var webhook struct {
+		Event struct {
+			EventType string `json:"event_type"`
+			Agent     *struct {
+				Summary string `json:"summary"`
+			} `json:"agent"`
+			Data struct {
+				IncidentKey string `json:"incident_key"`
+			} `json:"data"`
+		} `json:"event"`
+	}
+
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	event := &notifier_incidents.WebhookEvent{
+		DedupKey: webhook.Event.Data.IncidentKey,
+	}
+
+	switch webhook.Event.EventType {
+	case "incident.acknowledged":
+		event.Action = notifier_incidents.IncidentActionAcknowledge
+	case "incident.resolved":
+		event.Action = notifier_incidents.IncidentActionResolve
+	}
+
+	if webhook.Event.Agent != nil {
+		event.Actor = webhook.Event.Agent.Summary
+	}
+
+	return event, nil
+}
+
+func (p *PagerDutyNotifier) HideSensitiveData() {
+	p.RoutingKey = ""
+	p.WebhookSecret = ""
+}
+
+func (p *PagerDutyNotifier) Update(incoming *PagerDutyNotifier) {
+	if incoming.RoutingKey != "" {
+		p.RoutingKey = incoming.RoutingKey
+	}
+
+	if incoming.WebhookSecret != "" {
+		p.WebhookSecret = incoming.WebhookSecret
+	}
+}
+
+func (p *PagerDutyNotifier) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	if p.RoutingKey != "" {
+		encrypted, err := encryptor.Encrypt(p.NotifierID, p.RoutingKey)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt routing key: %w", err)
+		}
+		p.RoutingKey = encrypted
+	}
+
+	if p.WebhookSecret != "" {
+		encrypted, err := encryptor.Encrypt(p.NotifierID, p.WebhookSecret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+		p.WebhookSecret = encrypted
+	}
+
+	return nil
+}
+
+func isValidSignature(body []byte, header, secret string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expectedSignature := mac.Sum(nil)
+
+	for _, part := range strings.Split(header, ",") {
+		signature, isFound := strings.CutPrefix(strings.TrimSpace(part), "v1=")
+		if !isFound {
+			continue
+		}
+
+		decodedSignature, err := hex.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(decodedSignature, expectedSignature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toPagerDutySeverity maps the severity to the values of the Events API, unknown ones are
+// sent as errors
+func toPagerDutySeverity(severity notifier_incidents.Severity) string {
+	switch severity {
+	case notifier_incidents.SeverityInfo:
+		return "info"
+	case notifier_incidents.SeverityWarning:
+		return "warning"
+	case notifier_incidents.SeverityCritical:
+		return "critical"
+	default:
+		return "error"
+	}
+}
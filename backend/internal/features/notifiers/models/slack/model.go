@@ -3,6 +3,7 @@ package slack_notifier
 import (
 	"bytes"
 	"databasus-backend/internal/util/encryption"
+	proxy_utils "databasus-backend/internal/util/proxy"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,9 @@ type SlackNotifier struct {
 	NotifierID   uuid.UUID `json:"notifierId"   gorm:"primaryKey;column:notifier_id"`
 	BotToken     string    `json:"botToken"     gorm:"not null;column:bot_token"`
 	TargetChatID string    `json:"targetChatId" gorm:"not null;column:target_chat_id"`
+
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this notifier, see proxy_utils.GetProxyFunc
+	ProxyURL string `json:"proxyUrl" gorm:"not null;default:'';type:text;column:proxy_url"`
 }
 
 func (s *SlackNotifier) TableName() string { return "slack_notifiers" }
@@ -41,7 +45,7 @@ func (s *SlackNotifier) Validate(encryptor encryption.FieldEncryptor) error {
 		)
 	}
 
-	return nil
+	return proxy_utils.ValidateProxyURL(s.ProxyURL)
 }
 
 func (s *SlackNotifier) Send(
@@ -78,9 +82,7 @@ func (s *SlackNotifier) Send(
 		attempts = 0
 	)
 
-	client := &http.Client{
-		Timeout: requestTimeout,
-	}
+	client := proxy_utils.NewHTTPClient(s.ProxyURL, requestTimeout)
 
 	for {
 		attempts++
@@ -148,12 +150,56 @@ func (s *SlackNotifier) Send(
 	}
 }
 
+// Verify checks the bot token with auth.test, nothing is posted to the channel
+func (s *SlackNotifier) Verify(encryptor encryption.FieldEncryptor, logger *slog.Logger) error {
+	botToken, err := encryptor.Decrypt(s.NotifierID, s.BotToken)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt bot token: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://slack.com/api/auth.test", nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	client := proxy_utils.NewHTTPClient(s.ProxyURL, 30*time.Second)
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("check slack token: %w", err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	var respBody struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	if !respBody.OK {
+		return fmt.Errorf("slack API error: %s", respBody.Error)
+	}
+
+	return nil
+}
+
 func (s *SlackNotifier) HideSensitiveData() {
 	s.BotToken = ""
+	s.ProxyURL = proxy_utils.RedactProxyURL(s.ProxyURL)
 }
 
 func (s *SlackNotifier) Update(incoming *SlackNotifier) {
 	s.TargetChatID = incoming.TargetChatID
+	s.ProxyURL = proxy_utils.MergeRedactedProxyURL(s.ProxyURL, incoming.ProxyURL)
 
 	if incoming.BotToken != "" {
 		s.BotToken = incoming.BotToken
@@ -92,6 +92,40 @@ func (t *TelegramNotifier) Send(
 	return nil
 }
 
+// Verify checks the bot token can access the target chat, nothing is posted to the chat
+func (t *TelegramNotifier) Verify(encryptor encryption.FieldEncryptor, _ *slog.Logger) error {
+	botToken, err := encryptor.Decrypt(t.NotifierID, t.BotToken)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt bot token: %w", err)
+	}
+
+	apiURL := fmt.Sprintf(
+		"https://api.telegram.org/bot%s/getChat?chat_id=%s",
+		botToken,
+		url.QueryEscape(t.TargetChatID),
+	)
+
+	client := &http.Client{}
+	resp, err := client.Get(apiURL)
+	if err != nil {
+		return errors.New("failed to reach telegram API")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf(
+			"telegram API returned non-OK status: %s. Error: %s",
+			resp.Status,
+			string(bodyBytes),
+		)
+	}
+
+	return nil
+}
+
 func (t *TelegramNotifier) HideSensitiveData() {
 	t.BotToken = ""
 }
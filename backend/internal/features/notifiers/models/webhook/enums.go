@@ -6,3 +6,8 @@ const (
 	WebhookMethodPOST WebhookMethod = "POST"
 	WebhookMethodGET  WebhookMethod = "GET"
 )
+
+const (
+	PingEventHeader = "X-Databasus-Event"
+	PingEventName   = "ping"
+)
@@ -3,6 +3,7 @@ package webhook_notifier
 import (
 	"bytes"
 	"databasus-backend/internal/util/encryption"
+	proxy_utils "databasus-backend/internal/util/proxy"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +33,9 @@ type WebhookNotifier struct {
 	BodyTemplate  *string       `json:"bodyTemplate"  gorm:"column:body_template;type:text"`
 	HeadersJSON   string        `json:"-"             gorm:"column:headers;type:text"`
 
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this notifier, see proxy_utils.GetProxyFunc
+	ProxyURL string `json:"proxyUrl" gorm:"not null;default:'';type:text;column:proxy_url"`
+
 	Headers []WebhookHeader `json:"headers" gorm:"-"`
 }
 
@@ -88,7 +92,7 @@ func (t *WebhookNotifier) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("webhook method is required")
 	}
 
-	return nil
+	return proxy_utils.ValidateProxyURL(t.ProxyURL)
 }
 
 func (t *WebhookNotifier) Send(
@@ -111,10 +115,31 @@ func (t *WebhookNotifier) Send(
 	}
 }
 
+// Verify sends a ping event which receivers can tell from notifications by the
+// X-Databasus-Event header. Any 2xx response means the webhook works
+func (t *WebhookNotifier) Verify(encryptor encryption.FieldEncryptor, logger *slog.Logger) error {
+	if err := t.decryptHeadersForSending(encryptor); err != nil {
+		return err
+	}
+
+	t.Headers = append(t.Headers, WebhookHeader{Key: PingEventHeader, Value: PingEventName})
+
+	switch t.WebhookMethod {
+	case WebhookMethodGET:
+		return t.sendGET(t.WebhookURL, PingEventName, "", logger)
+	case WebhookMethodPOST:
+		return t.sendPOST(t.WebhookURL, PingEventName, "", logger)
+	default:
+		return fmt.Errorf("unsupported webhook method: %s", t.WebhookMethod)
+	}
+}
+
 func (t *WebhookNotifier) HideSensitiveData() {
 	for i := range t.Headers {
 		t.Headers[i].Value = ""
 	}
+
+	t.ProxyURL = proxy_utils.RedactProxyURL(t.ProxyURL)
 }
 
 func (t *WebhookNotifier) Update(incoming *WebhookNotifier) {
@@ -122,6 +147,7 @@ func (t *WebhookNotifier) Update(incoming *WebhookNotifier) {
 	t.WebhookMethod = incoming.WebhookMethod
 	t.BodyTemplate = incoming.BodyTemplate
 	t.Headers = incoming.Headers
+	t.ProxyURL = proxy_utils.MergeRedactedProxyURL(t.ProxyURL, incoming.ProxyURL)
 }
 
 func (t *WebhookNotifier) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
@@ -153,7 +179,7 @@ func (t *WebhookNotifier) sendGET(webhookURL, heading, message string, logger *s
 
 	t.applyHeaders(req)
 
-	client := &http.Client{}
+	client := proxy_utils.NewHTTPClient(t.ProxyURL, 0)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send GET webhook: %w", err)
@@ -200,7 +226,7 @@ func (t *WebhookNotifier) sendPOST(webhookURL, heading, message string, logger *
 
 	t.applyHeaders(req)
 
-	client := &http.Client{}
+	client := proxy_utils.NewHTTPClient(t.ProxyURL, 0)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send POST webhook: %w", err)
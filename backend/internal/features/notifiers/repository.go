@@ -2,6 +2,8 @@ package notifiers
 
 import (
 	"databasus-backend/internal/storage"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -39,6 +41,14 @@ func (r *NotifierRepository) Save(notifier *Notifier) (*Notifier, error) {
 			if notifier.TeamsNotifier != nil {
 				notifier.TeamsNotifier.NotifierID = notifier.ID
 			}
+		case NotifierTypePagerDuty:
+			if notifier.PagerDutyNotifier != nil {
+				notifier.PagerDutyNotifier.NotifierID = notifier.ID
+			}
+		case NotifierTypeOpsgenie:
+			if notifier.OpsgenieNotifier != nil {
+				notifier.OpsgenieNotifier.NotifierID = notifier.ID
+			}
 		}
 
 		if notifier.ID == uuid.Nil {
@@ -50,6 +60,8 @@ func (r *NotifierRepository) Save(notifier *Notifier) (*Notifier, error) {
 					"SlackNotifier",
 					"DiscordNotifier",
 					"TeamsNotifier",
+					"PagerDutyNotifier",
+					"OpsgenieNotifier",
 				).
 				Create(notifier).Error; err != nil {
 				return err
@@ -63,6 +75,8 @@ func (r *NotifierRepository) Save(notifier *Notifier) (*Notifier, error) {
 					"SlackNotifier",
 					"DiscordNotifier",
 					"TeamsNotifier",
+					"PagerDutyNotifier",
+					"OpsgenieNotifier",
 				).
 				Save(notifier).Error; err != nil {
 				return err
@@ -112,6 +126,20 @@ func (r *NotifierRepository) Save(notifier *Notifier) (*Notifier, error) {
 					return err
 				}
 			}
+		case NotifierTypePagerDuty:
+			if notifier.PagerDutyNotifier != nil {
+				notifier.PagerDutyNotifier.NotifierID = notifier.ID
+				if err := tx.Save(notifier.PagerDutyNotifier).Error; err != nil {
+					return err
+				}
+			}
+		case NotifierTypeOpsgenie:
+			if notifier.OpsgenieNotifier != nil {
+				notifier.OpsgenieNotifier.NotifierID = notifier.ID
+				if err := tx.Save(notifier.OpsgenieNotifier).Error; err != nil {
+					return err
+				}
+			}
 		}
 
 		return nil
@@ -135,6 +163,8 @@ func (r *NotifierRepository) FindByID(id uuid.UUID) (*Notifier, error) {
 		Preload("SlackNotifier").
 		Preload("DiscordNotifier").
 		Preload("TeamsNotifier").
+		Preload("PagerDutyNotifier").
+		Preload("OpsgenieNotifier").
 		Where("id = ?", id).
 		First(&notifier).Error; err != nil {
 		return nil, err
@@ -154,6 +184,8 @@ func (r *NotifierRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Notifi
 		Preload("SlackNotifier").
 		Preload("DiscordNotifier").
 		Preload("TeamsNotifier").
+		Preload("PagerDutyNotifier").
+		Preload("OpsgenieNotifier").
 		Where("workspace_id = ?", workspaceID).
 		Order("name ASC").
 		Find(&notifiers).Error; err != nil {
@@ -163,6 +195,193 @@ func (r *NotifierRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Notifi
 	return notifiers, nil
 }
 
+// FindAvailableByWorkspaceID returns notifiers of the workspace and system notifiers of
+// every workspace
+func (r *NotifierRepository) FindAvailableByWorkspaceID(
+	workspaceID uuid.UUID,
+) ([]*Notifier, error) {
+	var notifiers []*Notifier
+
+	if err := storage.
+		GetDb().
+		Preload("TelegramNotifier").
+		Preload("EmailNotifier").
+		Preload("WebhookNotifier").
+		Preload("SlackNotifier").
+		Preload("DiscordNotifier").
+		Preload("TeamsNotifier").
+		Preload("PagerDutyNotifier").
+		Preload("OpsgenieNotifier").
+		Where("workspace_id = ? OR is_system = TRUE", workspaceID).
+		Order("name ASC").
+		Find(&notifiers).Error; err != nil {
+		return nil, err
+	}
+
+	return notifiers, nil
+}
+
+func (r *NotifierRepository) FindDefaultsByWorkspaceID(
+	workspaceID uuid.UUID,
+) ([]*Notifier, error) {
+	var notifiers []*Notifier
+
+	if err := storage.
+		GetDb().
+		Preload("TelegramNotifier").
+		Preload("EmailNotifier").
+		Preload("WebhookNotifier").
+		Preload("SlackNotifier").
+		Preload("DiscordNotifier").
+		Preload("TeamsNotifier").
+		Preload("PagerDutyNotifier").
+		Preload("OpsgenieNotifier").
+		Where("workspace_id = ? AND is_workspace_default = ?", workspaceID, true).
+		Order("name ASC").
+		Find(&notifiers).Error; err != nil {
+		return nil, err
+	}
+
+	return notifiers, nil
+}
+
+func (r *NotifierRepository) FindAll() ([]*Notifier, error) {
+	var notifiers []*Notifier
+
+	if err := storage.
+		GetDb().
+		Preload("TelegramNotifier").
+		Preload("EmailNotifier").
+		Preload("WebhookNotifier").
+		Preload("SlackNotifier").
+		Preload("DiscordNotifier").
+		Preload("TeamsNotifier").
+		Preload("PagerDutyNotifier").
+		Preload("OpsgenieNotifier").
+		Order("workspace_id ASC, name ASC").
+		Find(&notifiers).Error; err != nil {
+		return nil, err
+	}
+
+	return notifiers, nil
+}
+
+// UpdateHealth writes only health columns, so a check does not save credentials of the
+// specific notifier decrypted while verifying it
+func (r *NotifierRepository) UpdateHealth(
+	notifierID uuid.UUID,
+	status NotifierHealthStatus,
+	healthCheckError *string,
+	checkedAt time.Time,
+) error {
+	return storage.
+		GetDb().
+		Model(&Notifier{}).
+		Where("id = ?", notifierID).
+		Updates(map[string]any{
+			"health_status":        status,
+			"health_check_error":   healthCheckError,
+			"last_health_check_at": checkedAt,
+		}).Error
+}
+
+func (r *NotifierRepository) SaveIncident(incident *NotifierIncident) error {
+	return storage.GetDb().Save(incident).Error
+}
+
+func (r *NotifierRepository) FindOpenIncident(
+	notifierID uuid.UUID,
+	dedupKey string,
+) (*NotifierIncident, error) {
+	var incident NotifierIncident
+
+	if err := storage.
+		GetDb().
+		Where(
+			"notifier_id = ? AND dedup_key = ? AND status <> ?",
+			notifierID,
+			dedupKey,
+			NotifierIncidentStatusResolved,
+		).
+		Order("created_at DESC").
+		First(&incident).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+func (r *NotifierRepository) FindIncidentsByNotifierID(
+	notifierID uuid.UUID,
+	limit int,
+) ([]*NotifierIncident, error) {
+	var incidents []*NotifierIncident
+
+	if err := storage.
+		GetDb().
+		Where("notifier_id = ?", notifierID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+func (r *NotifierRepository) FindSeverityOverridesByWorkspaceID(
+	workspaceID uuid.UUID,
+) ([]*NotificationSeverityOverride, error) {
+	var overrides []*NotificationSeverityOverride
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ?", workspaceID).
+		Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+func (r *NotifierRepository) FindSeverityOverride(
+	workspaceID uuid.UUID,
+	eventType NotificationEventType,
+) (*NotificationSeverityOverride, error) {
+	var override NotificationSeverityOverride
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ? AND event_type = ?", workspaceID, eventType).
+		First(&override).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *NotifierRepository) SaveSeverityOverride(override *NotificationSeverityOverride) error {
+	return storage.GetDb().Save(override).Error
+}
+
+func (r *NotifierRepository) DeleteSeverityOverride(
+	workspaceID uuid.UUID,
+	eventType NotificationEventType,
+) error {
+	return storage.
+		GetDb().
+		Where("workspace_id = ? AND event_type = ?", workspaceID, eventType).
+		Delete(&NotificationSeverityOverride{}).Error
+}
+
 func (r *NotifierRepository) Delete(notifier *Notifier) error {
 	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
 		switch notifier.NotifierType {
@@ -202,6 +421,18 @@ func (r *NotifierRepository) Delete(notifier *Notifier) error {
 					return err
 				}
 			}
+		case NotifierTypePagerDuty:
+			if notifier.PagerDutyNotifier != nil {
+				if err := tx.Delete(notifier.PagerDutyNotifier).Error; err != nil {
+					return err
+				}
+			}
+		case NotifierTypeOpsgenie:
+			if notifier.OpsgenieNotifier != nil {
+				if err := tx.Delete(notifier.OpsgenieNotifier).Error; err != nil {
+					return err
+				}
+			}
 		}
 
 		return tx.Delete(notifier).Error
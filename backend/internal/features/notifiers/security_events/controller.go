@@ -0,0 +1,87 @@
+package notifiers_security_events
+
+import (
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SecurityEventController struct {
+	securityEventService *SecurityEventService
+}
+
+func (c *SecurityEventController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET(
+		"/security-events/settings",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.GetSettings,
+	)
+	router.PUT(
+		"/security-events/settings",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.UpdateSettings,
+	)
+}
+
+// GetSettings
+// @Summary Get security event settings
+// @Description Get the notifier receiving security events and which events it receives (admin only)
+// @Tags security-events
+// @Produce json
+// @Success 200 {object} SecurityEventSettings
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /security-events/settings [get]
+func (c *SecurityEventController) GetSettings(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	settings, err := c.securityEventService.GetSettings(user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings
+// @Summary Update security event settings
+// @Description Route new device sign-ins, failed sign-in streaks and security policy changes to a notifier and toggle emails to affected users (admin only)
+// @Tags security-events
+// @Accept json
+// @Produce json
+// @Param request body SecurityEventSettings true "Settings"
+// @Success 200 {object} SecurityEventSettings
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /security-events/settings [put]
+func (c *SecurityEventController) UpdateSettings(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request SecurityEventSettings
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := c.securityEventService.UpdateSettings(user, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
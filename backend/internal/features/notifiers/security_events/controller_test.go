@@ -0,0 +1,170 @@
+package notifiers_security_events
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/notifiers"
+	users_dto "databasus-backend/internal/features/users/dto"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_services "databasus-backend/internal/features/users/services"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	SetupDependencies()
+
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetSecurityEventController(),
+	)
+}
+
+func Test_SignIn_FromNewDevice_RoutedToAdminNotifier(t *testing.T) {
+	router := createTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", admin, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	var mu sync.Mutex
+	receivedBodies := []string{}
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			receivedBodies = append(receivedBodies, string(body))
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	defer server.Close()
+
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	notifier.WebhookNotifier.WebhookURL = server.URL
+	_, err := notifiers.GetNotifierRepository().Save(notifier)
+	assert.NoError(t, err)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/security-events/settings",
+		"Bearer "+admin.Token,
+		SecurityEventSettings{
+			NotifierID: &notifier.ID,
+			EventTypes: []SecurityEventType{SecurityEventNewDeviceSignIn},
+		},
+		http.StatusOK,
+	)
+	defer test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/security-events/settings",
+		"Bearer "+admin.Token,
+		SecurityEventSettings{IsEmailAffectedUsers: true},
+		http.StatusOK,
+	)
+
+	email := "security-" + uuid.New().String() + "@example.com"
+	password := "password123"
+	err = users_services.GetUserService().SignUp(&users_dto.SignUpRequestDTO{
+		Email:    email,
+		Password: password,
+		Name:     "Security Test",
+	})
+	assert.NoError(t, err)
+
+	signIn := func(userAgent string, password string) error {
+		_, err := users_services.GetUserService().SignIn(
+			&users_dto.SignInRequestDTO{Email: email, Password: password},
+			users_dto.SignInClientDTO{IPAddress: "203.0.113.7", UserAgent: userAgent},
+		)
+		return err
+	}
+
+	assert.NoError(t, signIn("Firefox", password))
+	assert.NoError(t, signIn("Firefox", password))
+	assert.NoError(t, signIn("Chrome", password))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, body := range receivedBodies {
+			if strings.Contains(body, email) && strings.Contains(body, "Chrome") {
+				return true
+			}
+		}
+
+		return false
+	}, 10*time.Second, 100*time.Millisecond)
+
+	user, err := users_services.GetUserService().GetUserByEmail(email)
+	assert.NoError(t, err)
+
+	devices, err := securityEventRepository.FindKnownDevicesByUserID(user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, devices, 2)
+
+	for range failedSignInStreakThreshold {
+		assert.Error(t, signIn("Chrome", "wrong-password"))
+	}
+
+	streak, err := securityEventRepository.FindFailureStreak(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, failedSignInStreakThreshold, streak.FailedCount)
+
+	assert.NoError(t, signIn("Chrome", password))
+
+	streak, err = securityEventRepository.FindFailureStreak(user.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, streak)
+}
+
+func Test_UpdateSettings_WithInvalidRequest_Rejected(t *testing.T) {
+	router := createTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	missingNotifierID := uuid.New()
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/security-events/settings",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/security-events/settings",
+		"Bearer "+admin.Token,
+		SecurityEventSettings{EventTypes: []SecurityEventType{"UNKNOWN"}},
+		http.StatusBadRequest,
+	)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/security-events/settings",
+		"Bearer "+admin.Token,
+		SecurityEventSettings{NotifierID: &missingNotifierID},
+		http.StatusBadRequest,
+	)
+}
@@ -0,0 +1,53 @@
+package notifiers_security_events
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/email"
+	"databasus-backend/internal/features/notifiers"
+	users_services "databasus-backend/internal/features/users/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var securityEventRepository = &SecurityEventRepository{}
+var securityEventService = &SecurityEventService{
+	securityEventRepository,
+	notifiers.GetNotifierService(),
+	email.GetEmailSMTPSender(),
+	users_services.GetBrandingService(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var securityEventController = &SecurityEventController{
+	securityEventService,
+}
+
+func GetSecurityEventService() *SecurityEventService {
+	return securityEventService
+}
+
+func GetSecurityEventController() *SecurityEventController {
+	return securityEventController
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		users_services.GetUserService().SetSecurityEventListener(securityEventService)
+		users_services.GetSettingsService().SetSecurityEventListener(securityEventService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
@@ -0,0 +1,34 @@
+package notifiers_security_events
+
+import "databasus-backend/internal/features/notifiers"
+
+type SecurityEventType string
+
+const (
+	SecurityEventNewDeviceSignIn       SecurityEventType = "NEW_DEVICE_SIGN_IN"
+	SecurityEventFailedSignInStreak    SecurityEventType = "FAILED_SIGN_IN_STREAK"
+	SecurityEventSecurityPolicyChanged SecurityEventType = "SECURITY_POLICY_CHANGED"
+)
+
+func (t SecurityEventType) IsValid() bool {
+	switch t {
+	case SecurityEventNewDeviceSignIn,
+		SecurityEventFailedSignInStreak,
+		SecurityEventSecurityPolicyChanged:
+		return true
+	}
+
+	return false
+}
+
+// getNotificationEventType is the event of the notifier catalog, which sets its severity
+func (t SecurityEventType) getNotificationEventType() notifiers.NotificationEventType {
+	switch t {
+	case SecurityEventNewDeviceSignIn:
+		return notifiers.NotificationEventSecurityNewDeviceSignIn
+	case SecurityEventFailedSignInStreak:
+		return notifiers.NotificationEventSecurityFailedSignIns
+	default:
+		return notifiers.NotificationEventSecurityPolicyChanged
+	}
+}
@@ -0,0 +1,57 @@
+package notifiers_security_events
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventSettings routes security events to the notifier of admins. Affected users are
+// emailed about sign-ins to their own account
+type SecurityEventSettings struct {
+	ID         uuid.UUID  `json:"-"          gorm:"column:id;type:uuid;primaryKey"`
+	NotifierID *uuid.UUID `json:"notifierId" gorm:"column:notifier_id;type:uuid"`
+	// EventTypes are sent to the notifier, empty means all of them
+	EventTypes           []SecurityEventType `json:"eventTypes"           gorm:"column:event_types;type:text;not null;serializer:json"`
+	IsEmailAffectedUsers bool                `json:"isEmailAffectedUsers" gorm:"column:is_email_affected_users;type:boolean;not null"`
+}
+
+func (SecurityEventSettings) TableName() string {
+	return "security_event_settings"
+}
+
+func (s *SecurityEventSettings) IsRoutedToNotifier(eventType SecurityEventType) bool {
+	if s.NotifierID == nil {
+		return false
+	}
+
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType)
+}
+
+// KnownDevice is a browser or client the user signed in from before, told apart by its
+// user agent
+type KnownDevice struct {
+	ID            uuid.UUID `json:"id"            gorm:"column:id;type:uuid;primaryKey"`
+	UserID        uuid.UUID `json:"userId"        gorm:"column:user_id;type:uuid;not null"`
+	DeviceHash    string    `json:"-"             gorm:"column:device_hash;type:text;not null"`
+	UserAgent     string    `json:"userAgent"     gorm:"column:user_agent;type:text;not null"`
+	LastIPAddress string    `json:"lastIpAddress" gorm:"column:last_ip_address;type:text;not null"`
+	FirstSeenAt   time.Time `json:"firstSeenAt"   gorm:"column:first_seen_at"`
+	LastSeenAt    time.Time `json:"lastSeenAt"    gorm:"column:last_seen_at"`
+}
+
+func (KnownDevice) TableName() string {
+	return "user_known_devices"
+}
+
+// SignInFailureStreak counts sign-ins with a wrong password since the last successful one
+type SignInFailureStreak struct {
+	UserID       uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	FailedCount  int       `gorm:"column:failed_count;type:int;not null"`
+	LastFailedAt time.Time `gorm:"column:last_failed_at"`
+}
+
+func (SignInFailureStreak) TableName() string {
+	return "sign_in_failure_streaks"
+}
@@ -0,0 +1,106 @@
+package notifiers_security_events
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SecurityEventRepository struct{}
+
+func (r *SecurityEventRepository) GetSettings() (*SecurityEventSettings, error) {
+	var settings SecurityEventSettings
+
+	if err := storage.GetDb().First(&settings).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		defaultSettings := &SecurityEventSettings{
+			ID:                   uuid.New(),
+			EventTypes:           []SecurityEventType{},
+			IsEmailAffectedUsers: true,
+		}
+
+		if err := storage.GetDb().Create(defaultSettings).Error; err != nil {
+			return nil, err
+		}
+
+		return defaultSettings, nil
+	}
+
+	return &settings, nil
+}
+
+func (r *SecurityEventRepository) SaveSettings(settings *SecurityEventSettings) error {
+	return storage.GetDb().Save(settings).Error
+}
+
+func (r *SecurityEventRepository) FindKnownDevice(
+	userID uuid.UUID,
+	deviceHash string,
+) (*KnownDevice, error) {
+	var device KnownDevice
+
+	if err := storage.
+		GetDb().
+		Where("user_id = ? AND device_hash = ?", userID, deviceHash).
+		First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+func (r *SecurityEventRepository) FindKnownDevicesByUserID(
+	userID uuid.UUID,
+) ([]*KnownDevice, error) {
+	var devices []*KnownDevice
+
+	if err := storage.
+		GetDb().
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (r *SecurityEventRepository) SaveKnownDevice(device *KnownDevice) error {
+	return storage.GetDb().Save(device).Error
+}
+
+func (r *SecurityEventRepository) FindFailureStreak(
+	userID uuid.UUID,
+) (*SignInFailureStreak, error) {
+	var streak SignInFailureStreak
+
+	if err := storage.
+		GetDb().
+		Where("user_id = ?", userID).
+		First(&streak).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &streak, nil
+}
+
+func (r *SecurityEventRepository) SaveFailureStreak(streak *SignInFailureStreak) error {
+	return storage.GetDb().Save(streak).Error
+}
+
+func (r *SecurityEventRepository) DeleteFailureStreak(userID uuid.UUID) error {
+	return storage.GetDb().Where("user_id = ?", userID).Delete(&SignInFailureStreak{}).Error
+}
@@ -0,0 +1,401 @@
+package notifiers_security_events
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/notifiers"
+	users_dto "databasus-backend/internal/features/users/dto"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_interfaces "databasus-backend/internal/features/users/interfaces"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+)
+
+const (
+	failedSignInStreakThreshold = 5
+	// failedSignInStreakWindow restarts the streak after a quiet period, so a few typos a
+	// month do not add up to an alert
+	failedSignInStreakWindow = time.Hour
+	maxUserAgentLength       = 512
+)
+
+type SecurityEventService struct {
+	securityEventRepository *SecurityEventRepository
+	notifierService         *notifiers.NotifierService
+	emailSender             users_interfaces.EmailSender
+	brandingService         *users_services.BrandingService
+	auditLogService         *audit_logs.AuditLogService
+	logger                  *slog.Logger
+}
+
+type securityEvent struct {
+	eventType SecurityEventType
+	// affectedUser is emailed about the event, nil for events of the whole instance
+	affectedUser *users_models.User
+	titleKey     i18n.MessageKey
+	messageKey   i18n.MessageKey
+	params       map[string]string
+}
+
+func (s *SecurityEventService) GetSettings(
+	user *users_models.User,
+) (*SecurityEventSettings, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only admins can view security event settings")
+	}
+
+	return s.securityEventRepository.GetSettings()
+}
+
+func (s *SecurityEventService) UpdateSettings(
+	user *users_models.User,
+	request *SecurityEventSettings,
+) (*SecurityEventSettings, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, errors.New("only admins can change security event settings")
+	}
+
+	if request.NotifierID != nil {
+		if _, err := s.notifierService.GetNotifierByID(*request.NotifierID); err != nil {
+			return nil, errors.New("notifier not found")
+		}
+	}
+
+	eventTypes := []SecurityEventType{}
+	for _, eventType := range request.EventTypes {
+		if !eventType.IsValid() {
+			return nil, fmt.Errorf("invalid security event type: %s", eventType)
+		}
+
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	settings, err := s.securityEventRepository.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	settings.NotifierID = request.NotifierID
+	settings.EventTypes = eventTypes
+	settings.IsEmailAffectedUsers = request.IsEmailAffectedUsers
+
+	if err := s.securityEventRepository.SaveSettings(settings); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog("Security event settings changed", &user.ID, nil)
+
+	return settings, nil
+}
+
+// OnSignInSucceeded remembers the device and alerts about new ones. The first device of a
+// user is not alerted, every account starts with one
+func (s *SecurityEventService) OnSignInSucceeded(
+	user *users_models.User,
+	client users_dto.SignInClientDTO,
+) {
+	if err := s.securityEventRepository.DeleteFailureStreak(user.ID); err != nil {
+		s.logger.Error("Failed to reset sign-in failure streak", "error", err)
+	}
+
+	isNewDevice, err := s.rememberDevice(user.ID, client)
+	if err != nil {
+		s.logger.Error("Failed to remember sign-in device", "error", err)
+		return
+	}
+
+	if !isNewDevice {
+		return
+	}
+
+	s.emit(securityEvent{
+		eventType:    SecurityEventNewDeviceSignIn,
+		affectedUser: user,
+		titleKey:     i18n.MessageSecurityNewDeviceTitle,
+		messageKey:   i18n.MessageSecurityNewDeviceMessage,
+		params: map[string]string{
+			"email":  user.Email,
+			"device": describeDevice(client.UserAgent),
+			"ip":     client.IPAddress,
+		},
+	})
+}
+
+// OnSignInFailed alerts once per threshold of failures in a row
+func (s *SecurityEventService) OnSignInFailed(
+	user *users_models.User,
+	client users_dto.SignInClientDTO,
+) {
+	now := time.Now().UTC()
+
+	streak, err := s.securityEventRepository.FindFailureStreak(user.ID)
+	if err != nil {
+		s.logger.Error("Failed to get sign-in failure streak", "error", err)
+		return
+	}
+
+	if streak == nil || now.Sub(streak.LastFailedAt) > failedSignInStreakWindow {
+		streak = &SignInFailureStreak{UserID: user.ID}
+	}
+
+	streak.FailedCount++
+	streak.LastFailedAt = now
+
+	if err := s.securityEventRepository.SaveFailureStreak(streak); err != nil {
+		s.logger.Error("Failed to save sign-in failure streak", "error", err)
+		return
+	}
+
+	if streak.FailedCount%failedSignInStreakThreshold != 0 {
+		return
+	}
+
+	s.emit(securityEvent{
+		eventType:    SecurityEventFailedSignInStreak,
+		affectedUser: user,
+		titleKey:     i18n.MessageSecurityFailedSignInsTitle,
+		messageKey:   i18n.MessageSecurityFailedSignInsMessage,
+		params: map[string]string{
+			"email": user.Email,
+			"count": strconv.Itoa(streak.FailedCount),
+			"ip":    client.IPAddress,
+		},
+	})
+}
+
+func (s *SecurityEventService) OnSecurityPolicyChanged(
+	changedBy *users_models.User,
+	previous users_models.SecurityPolicy,
+	current users_models.SecurityPolicy,
+) {
+	changes := describePolicyChanges(previous, current)
+	if len(changes) == 0 {
+		return
+	}
+
+	s.emit(securityEvent{
+		eventType:  SecurityEventSecurityPolicyChanged,
+		titleKey:   i18n.MessageSecurityPolicyChangedTitle,
+		messageKey: i18n.MessageSecurityPolicyChangedMessage,
+		params: map[string]string{
+			"email":   changedBy.Email,
+			"changes": strings.Join(changes, ", "),
+		},
+	})
+}
+
+func (s *SecurityEventService) rememberDevice(
+	userID uuid.UUID,
+	client users_dto.SignInClientDTO,
+) (bool, error) {
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	hash := sha256.Sum256([]byte(userAgent))
+	deviceHash := hex.EncodeToString(hash[:])
+	now := time.Now().UTC()
+
+	device, err := s.securityEventRepository.FindKnownDevice(userID, deviceHash)
+	if err != nil {
+		return false, err
+	}
+
+	if device != nil {
+		device.LastIPAddress = client.IPAddress
+		device.LastSeenAt = now
+
+		return false, s.securityEventRepository.SaveKnownDevice(device)
+	}
+
+	knownDevices, err := s.securityEventRepository.FindKnownDevicesByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	device = &KnownDevice{
+		ID:            uuid.New(),
+		UserID:        userID,
+		DeviceHash:    deviceHash,
+		UserAgent:     userAgent,
+		LastIPAddress: client.IPAddress,
+		FirstSeenAt:   now,
+		LastSeenAt:    now,
+	}
+
+	if err := s.securityEventRepository.SaveKnownDevice(device); err != nil {
+		return false, err
+	}
+
+	return len(knownDevices) > 0, nil
+}
+
+// emit delivers in background, so sign-ins do not wait for notifiers and SMTP
+func (s *SecurityEventService) emit(event securityEvent) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.logger.Error(
+					"Panic while delivering security event",
+					"eventType", event.eventType,
+					"panic", r,
+				)
+			}
+		}()
+
+		s.deliver(event)
+	}()
+}
+
+func (s *SecurityEventService) deliver(event securityEvent) {
+	settings, err := s.securityEventRepository.GetSettings()
+	if err != nil {
+		s.logger.Error("Failed to get security event settings", "error", err)
+		return
+	}
+
+	if settings.IsRoutedToNotifier(event.eventType) {
+		s.deliverToNotifier(*settings.NotifierID, event)
+	}
+
+	if event.affectedUser != nil && settings.IsEmailAffectedUsers &&
+		s.emailSender.IsConfigured() {
+		s.deliverByEmail(event)
+	}
+}
+
+func (s *SecurityEventService) deliverToNotifier(notifierID uuid.UUID, event securityEvent) {
+	notifier, err := s.notifierService.GetNotifierByID(notifierID)
+	if err != nil {
+		s.logger.Error("Failed to get security events notifier", "error", err)
+		return
+	}
+
+	if err := s.notifierService.DeliverEventNotification(
+		notifier,
+		event.eventType.getNotificationEventType(),
+		i18n.Translate(notifier.Locale, event.titleKey, event.params),
+		i18n.Translate(notifier.Locale, event.messageKey, event.params),
+	); err != nil {
+		s.logger.Error(
+			"Failed to send security event to notifier",
+			"eventType", event.eventType,
+			"error", err,
+		)
+	}
+}
+
+func (s *SecurityEventService) deliverByEmail(event securityEvent) {
+	locale := event.affectedUser.Locale
+	title := i18n.Translate(locale, event.titleKey, event.params)
+	message := i18n.Translate(locale, event.messageKey, event.params)
+
+	if err := s.emailSender.SendEmail(
+		event.affectedUser.Email,
+		title,
+		s.buildSecurityEventEmailHTML(title, message),
+	); err != nil {
+		s.logger.Error(
+			"Failed to email security event",
+			"eventType", event.eventType,
+			"error", err,
+		)
+	}
+}
+
+func (s *SecurityEventService) buildSecurityEventEmailHTML(title, message string) string {
+	branding := s.brandingService.GetBrandingOrDefault()
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+	<div style="background-color: #f8f9fa; border-radius: 8px; padding: 30px; margin: 20px 0;">
+		%s
+		<h1 style="color: %s; margin-top: 0;">%s</h1>
+
+		<p style="font-size: 16px; margin: 20px 0;">
+			%s
+		</p>
+		%s
+	</div>
+</body>
+</html>
+	`,
+		s.brandingService.BuildEmailHeader(branding),
+		branding.AccentColor,
+		html.EscapeString(title),
+		html.EscapeString(message),
+		s.brandingService.BuildEmailFooter(branding),
+	)
+}
+
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "unknown device"
+	}
+
+	if len(userAgent) > 120 {
+		return userAgent[:120] + "..."
+	}
+
+	return userAgent
+}
+
+func describePolicyChanges(previous, current users_models.SecurityPolicy) []string {
+	changes := []string{}
+
+	if previous.SessionLifetimeHours != current.SessionLifetimeHours {
+		changes = append(changes, fmt.Sprintf(
+			"sessionLifetimeHours %d -> %d",
+			previous.SessionLifetimeHours,
+			current.SessionLifetimeHours,
+		))
+	}
+
+	if previous.PasswordMinLength != current.PasswordMinLength {
+		changes = append(changes, fmt.Sprintf(
+			"passwordMinLength %d -> %d",
+			previous.PasswordMinLength,
+			current.PasswordMinLength,
+		))
+	}
+
+	if previous.IsPasswordComplexityRequired != current.IsPasswordComplexityRequired {
+		changes = append(changes, fmt.Sprintf(
+			"isPasswordComplexityRequired %t -> %t",
+			previous.IsPasswordComplexityRequired,
+			current.IsPasswordComplexityRequired,
+		))
+	}
+
+	if !slices.Equal(previous.AllowedSSODomains, current.AllowedSSODomains) {
+		changes = append(changes, fmt.Sprintf(
+			"allowedSsoDomains %v -> %v",
+			previous.AllowedSSODomains,
+			current.AllowedSSODomains,
+		))
+	}
+
+	return changes
+}
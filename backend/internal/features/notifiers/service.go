@@ -3,15 +3,24 @@ package notifiers
 import (
 	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
+	"time"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
+	notifier_incidents "databasus-backend/internal/features/notifiers/models/incidents"
+	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/i18n"
 
 	"github.com/google/uuid"
 )
 
+const maxListedIncidents = 100
+
 type NotifierService struct {
 	notifierRepository      *NotifierRepository
 	logger                  *slog.Logger
@@ -19,6 +28,7 @@ type NotifierService struct {
 	auditLogService         *audit_logs.AuditLogService
 	fieldEncryptor          encryption.FieldEncryptor
 	notifierDatabaseCounter NotifierDatabaseCounter
+	brandingService         *users_services.BrandingService
 }
 
 func (s *NotifierService) SetNotifierDatabaseCounter(
@@ -40,6 +50,11 @@ func (s *NotifierService) SaveNotifier(
 		return ErrInsufficientPermissionsToManageNotifier
 	}
 
+	if notifier.IsSystem && user.Role != users_enums.UserRoleAdmin {
+		// only admin can manage system notifier
+		return ErrInsufficientPermissionsToManageNotifier
+	}
+
 	isUpdate := notifier.ID != uuid.Nil
 
 	if isUpdate {
@@ -52,6 +67,17 @@ func (s *NotifierService) SaveNotifier(
 			return ErrNotifierDoesNotBelongToWorkspace
 		}
 
+		if existingNotifier.IsSystem && !notifier.IsSystem {
+			return ErrSystemNotifierCannotBeMadePrivate
+		}
+
+		if err := s.workspaceService.ValidateChangeReason(
+			workspaceID,
+			notifier.ChangeReason,
+		); err != nil {
+			return err
+		}
+
 		existingNotifier.Update(notifier)
 
 		if err := existingNotifier.EncryptSensitiveData(s.fieldEncryptor); err != nil {
@@ -62,13 +88,18 @@ func (s *NotifierService) SaveNotifier(
 			return err
 		}
 
+		// Settings may be fixed or broken by the update, the next check tells
+		existingNotifier.HealthStatus = NotifierHealthStatusUnknown
+		existingNotifier.HealthCheckError = nil
+
 		_, err = s.notifierRepository.Save(existingNotifier)
 		if err != nil {
 			return err
 		}
 
-		s.auditLogService.WriteAuditLog(
+		s.auditLogService.WriteChangeAuditLog(
 			fmt.Sprintf("Notifier updated: %s", existingNotifier.Name),
+			notifier.ChangeReason,
 			&user.ID,
 			&workspaceID,
 		)
@@ -115,6 +146,11 @@ func (s *NotifierService) DeleteNotifier(
 		return ErrInsufficientPermissionsToManageNotifier
 	}
 
+	if notifier.IsSystem && user.Role != users_enums.UserRoleAdmin {
+		// only admin can manage system notifier
+		return ErrInsufficientPermissionsToManageNotifier
+	}
+
 	attachedDatabasesIDs, err := s.notifierDatabaseCounter.GetNotifierAttachedDatabasesIDs(
 		notifier.ID,
 	)
@@ -142,22 +178,43 @@ func (s *NotifierService) DeleteNotifier(
 func (s *NotifierService) GetNotifier(
 	user *users_models.User,
 	id uuid.UUID,
-) (*Notifier, error) {
+) (*NotifierResponse, error) {
 	notifier, err := s.notifierRepository.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	canView, _, err := s.workspaceService.CanUserAccessWorkspace(notifier.WorkspaceID, user)
-	if err != nil {
-		return nil, err
+	if !notifier.IsSystem {
+		canView, _, err := s.workspaceService.CanUserAccessWorkspace(notifier.WorkspaceID, user)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, ErrInsufficientPermissionsToViewNotifier
+		}
 	}
-	if !canView {
-		return nil, ErrInsufficientPermissionsToViewNotifier
+
+	isSpecificDataHidden := s.isSpecificDataHidden(user, notifier)
+	if !isSpecificDataHidden {
+		s.auditNotifierRead(user, notifier)
 	}
 
-	notifier.HideSensitiveData()
-	return notifier, nil
+	return ToNotifierResponse(notifier, isSpecificDataHidden), nil
+}
+
+// auditNotifierRead records reads by admins, members only see what they can use anyway
+func (s *NotifierService) auditNotifierRead(user *users_models.User, notifier *Notifier) {
+	canManage, err := s.workspaceService.CanUserManageWorkspace(notifier.WorkspaceID, user)
+	if err != nil || !canManage {
+		return
+	}
+
+	s.auditLogService.WriteReadAuditLog(
+		fmt.Sprintf("Notifier viewed: %s", notifier.Name),
+		notifier.ID,
+		user.ID,
+		&notifier.WorkspaceID,
+	)
 }
 
 func (s *NotifierService) GetNotifierByID(id uuid.UUID) (*Notifier, error) {
@@ -167,7 +224,7 @@ func (s *NotifierService) GetNotifierByID(id uuid.UUID) (*Notifier, error) {
 func (s *NotifierService) GetNotifiers(
 	user *users_models.User,
 	workspaceID uuid.UUID,
-) ([]*Notifier, error) {
+) ([]*NotifierResponse, error) {
 	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
 	if err != nil {
 		return nil, err
@@ -176,16 +233,24 @@ func (s *NotifierService) GetNotifiers(
 		return nil, ErrInsufficientPermissionsToViewNotifiers
 	}
 
-	notifiers, err := s.notifierRepository.FindByWorkspaceID(workspaceID)
+	notifiers, err := s.notifierRepository.FindAvailableByWorkspaceID(workspaceID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, notifier := range notifiers {
-		notifier.HideSensitiveData()
-	}
+	return ToNotifierResponses(notifiers, func(notifier *Notifier) bool {
+		return s.isSpecificDataHidden(user, notifier)
+	}), nil
+}
 
-	return notifiers, nil
+func (s *NotifierService) GetNotifiersByWorkspaceID(workspaceID uuid.UUID) ([]*Notifier, error) {
+	return s.notifierRepository.FindByWorkspaceID(workspaceID)
+}
+
+func (s *NotifierService) GetWorkspaceDefaultNotifiers(
+	workspaceID uuid.UUID,
+) ([]*Notifier, error) {
+	return s.notifierRepository.FindDefaultsByWorkspaceID(workspaceID)
 }
 
 func (s *NotifierService) SendTestNotification(
@@ -205,6 +270,11 @@ func (s *NotifierService) SendTestNotification(
 		return ErrInsufficientPermissionsToTestNotifier
 	}
 
+	if notifier.IsSystem && user.Role != users_enums.UserRoleAdmin {
+		// only admin can test system notifier
+		return ErrInsufficientPermissionsToTestNotifier
+	}
+
 	err = notifier.Send(s.fieldEncryptor, s.logger, "Test message", "This is a test message")
 	if err != nil {
 		return err
@@ -218,6 +288,45 @@ func (s *NotifierService) SendTestNotification(
 	return nil
 }
 
+// SendTestEvent sends a sample of a real event through the notifier, so templates and
+// routing on the receiving side can be checked before an incident
+func (s *NotifierService) SendTestEvent(
+	user *users_models.User,
+	notifierID uuid.UUID,
+	eventType TestEventType,
+) error {
+	notifier, err := s.notifierRepository.FindByID(notifierID)
+	if err != nil {
+		return err
+	}
+
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(notifier.WorkspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canView {
+		return ErrInsufficientPermissionsToTestNotifier
+	}
+
+	if notifier.IsSystem && user.Role != users_enums.UserRoleAdmin {
+		// only admin can test system notifier
+		return ErrInsufficientPermissionsToTestNotifier
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(notifier.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	title, message, err := buildTestEvent(eventType, notifier.Locale, workspace.Name)
+	if err != nil {
+		return err
+	}
+
+	// test event types are events of the catalog, so overridden severities are checked too
+	return s.DeliverEventNotification(notifier, NotificationEventType(eventType), title, message)
+}
+
 func (s *NotifierService) SendTestNotificationToNotifier(
 	notifier *Notifier,
 ) error {
@@ -255,11 +364,50 @@ func (s *NotifierService) SendTestNotificationToNotifier(
 	return usingNotifier.Send(s.fieldEncryptor, s.logger, "Test message", "This is a test message")
 }
 
-func (s *NotifierService) SendNotification(
+// SendEventNotification sends a notification about an event of the catalog, with the
+// severity of the event in the workspace of the notifier
+func (s *NotifierService) SendEventNotification(
 	notifier *Notifier,
+	eventType NotificationEventType,
 	title string,
 	message string,
 ) {
+	_ = s.DeliverEventNotification(notifier, eventType, title, message)
+}
+
+// DeliverEventNotification is SendEventNotification which returns the send error, for
+// callers which track deliveries
+func (s *NotifierService) DeliverEventNotification(
+	notifier *Notifier,
+	eventType NotificationEventType,
+	title string,
+	message string,
+) error {
+	severity := s.GetEventSeverity(notifier.WorkspaceID, eventType)
+
+	return s.deliverNotification(notifier, severity, title, message)
+}
+
+// DeliverNotification sends a message which is not an event of the catalog, e.g. a
+// broadcast of admins. Incidents are opened with the error severity
+func (s *NotifierService) DeliverNotification(
+	notifier *Notifier,
+	title string,
+	message string,
+) error {
+	return s.deliverNotification(notifier, notifier_incidents.SeverityError, title, message)
+}
+
+func (s *NotifierService) deliverNotification(
+	notifier *Notifier,
+	severity notifier_incidents.Severity,
+	title string,
+	message string,
+) error {
+	if signature := s.brandingService.GetNotificationSignature(); signature != "" {
+		message += "\n\n" + signature
+	}
+
 	// Truncate message to 2000 characters if it's too long
 	messageRunes := []rune(message)
 	if len(messageRunes) > 2000 {
@@ -268,25 +416,324 @@ func (s *NotifierService) SendNotification(
 
 	notifiedFromDb, err := s.notifierRepository.FindByID(notifier.ID)
 	if err != nil {
-		return
+		return err
 	}
 
-	err = notifiedFromDb.Send(s.fieldEncryptor, s.logger, title, message)
-	if err != nil {
-		errMsg := err.Error()
+	var sendErr error
+	if notifiedFromDb.IsIncidentNotifier() {
+		sendErr = s.sendIncidentNotification(
+			notifiedFromDb,
+			severity,
+			title,
+			message,
+			time.Now().UTC(),
+		)
+	} else {
+		sendErr = notifiedFromDb.Send(s.fieldEncryptor, s.logger, title, message)
+	}
+
+	if sendErr != nil {
+		errMsg := sendErr.Error()
 		notifiedFromDb.LastSendError = &errMsg
+	} else {
+		notifiedFromDb.LastSendError = nil
+	}
+
+	_, err = s.notifierRepository.Save(notifiedFromDb)
+	if err != nil {
+		s.logger.Error("Failed to save notifier", "error", err)
+	}
+
+	return sendErr
+}
+
+// sendIncidentNotification opens an incident or repeats the open one. Repeats of an
+// acknowledged incident are only counted, so people working on it are not paged again
+func (s *NotifierService) sendIncidentNotification(
+	notifier *Notifier,
+	severity notifier_incidents.Severity,
+	title string,
+	message string,
+	now time.Time,
+) error {
+	dedupKey := notifier_incidents.DedupKey(title)
+
+	incident, err := s.notifierRepository.FindOpenIncident(notifier.ID, dedupKey)
+	if err != nil {
+		return err
+	}
 
-		_, err = s.notifierRepository.Save(notifiedFromDb)
+	if incident == nil || incident.Status != NotifierIncidentStatusAcknowledged {
+		err := notifier.SendIncident(s.fieldEncryptor, s.logger, severity, title, message)
 		if err != nil {
-			s.logger.Error("Failed to save notifier", "error", err)
+			return err
 		}
 	}
 
-	notifiedFromDb.LastSendError = nil
-	_, err = s.notifierRepository.Save(notifiedFromDb)
+	if incident == nil {
+		incident = &NotifierIncident{
+			ID:         uuid.New(),
+			NotifierID: notifier.ID,
+			DedupKey:   dedupKey,
+			Title:      title,
+			Status:     NotifierIncidentStatusTriggered,
+			CreatedAt:  now,
+		}
+	}
+
+	incident.TriggerCount++
+	incident.LastTriggeredAt = now
+
+	if err := s.notifierRepository.SaveIncident(incident); err != nil {
+		s.logger.Error("Failed to save notifier incident", "error", err)
+	}
+
+	return nil
+}
+
+// GetWorkspaceEventSeverities lists the event catalog with the severities of the workspace
+func (s *NotifierService) GetWorkspaceEventSeverities(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]EventSeverityResponse, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
 	if err != nil {
-		s.logger.Error("Failed to save notifier", "error", err)
+		return nil, err
 	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewNotifiers
+	}
+
+	overrides, err := s.notifierRepository.FindSeverityOverridesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	overriddenSeverities := make(map[NotificationEventType]notifier_incidents.Severity)
+	for _, override := range overrides {
+		overriddenSeverities[override.EventType] = override.Severity
+	}
+
+	severities := make([]EventSeverityResponse, 0, len(notificationEvents))
+	for _, event := range notificationEvents {
+		severity, isOverridden := overriddenSeverities[event.Type]
+		if !isOverridden {
+			severity = event.DefaultSeverity
+		}
+
+		severities = append(severities, EventSeverityResponse{
+			EventType:       event.Type,
+			Description:     event.Description,
+			DefaultSeverity: event.DefaultSeverity,
+			Severity:        severity,
+			IsOverridden:    isOverridden,
+		})
+	}
+
+	return severities, nil
+}
+
+// UpdateWorkspaceEventSeverity overrides the severity of the event in the workspace. The
+// severity applies to every notifier of the workspace, system notifiers included
+func (s *NotifierService) UpdateWorkspaceEventSeverity(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	eventType NotificationEventType,
+	severity notifier_incidents.Severity,
+) (*NotificationSeverityOverride, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageEventSeverities
+	}
+
+	if _, isFound := findNotificationEvent(eventType); !isFound {
+		return nil, ErrUnknownNotificationEvent
+	}
+
+	if !severity.IsValid() {
+		return nil, ErrInvalidNotificationSeverity
+	}
+
+	override := &NotificationSeverityOverride{
+		WorkspaceID: workspaceID,
+		EventType:   eventType,
+		Severity:    severity,
+		UpdatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.notifierRepository.SaveSeverityOverride(override); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Severity of event %s set to %s", eventType, severity),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return override, nil
+}
+
+// ResetWorkspaceEventSeverity removes the override, the default severity applies again
+func (s *NotifierService) ResetWorkspaceEventSeverity(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	eventType NotificationEventType,
+) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrInsufficientPermissionsToManageEventSeverities
+	}
+
+	event, isFound := findNotificationEvent(eventType)
+	if !isFound {
+		return ErrUnknownNotificationEvent
+	}
+
+	if err := s.notifierRepository.DeleteSeverityOverride(workspaceID, eventType); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Severity of event %s reset to default %s",
+			eventType,
+			event.DefaultSeverity,
+		),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+// GetEventSeverity resolves the severity of the event in the workspace. Lookup errors fall
+// back to the default, a notification is better sent with the default than not at all
+func (s *NotifierService) GetEventSeverity(
+	workspaceID uuid.UUID,
+	eventType NotificationEventType,
+) notifier_incidents.Severity {
+	event, isFound := findNotificationEvent(eventType)
+	if !isFound {
+		return notifier_incidents.SeverityError
+	}
+
+	override, err := s.notifierRepository.FindSeverityOverride(workspaceID, eventType)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get event severity override",
+			"workspaceId", workspaceID,
+			"eventType", eventType,
+			"error", err,
+		)
+		return event.DefaultSeverity
+	}
+
+	if override == nil {
+		return event.DefaultSeverity
+	}
+
+	return override.Severity
+}
+
+// GetNotifierIncidents returns the latest incidents opened by the notifier
+func (s *NotifierService) GetNotifierIncidents(
+	user *users_models.User,
+	notifierID uuid.UUID,
+) ([]*NotifierIncident, error) {
+	notifier, err := s.notifierRepository.FindByID(notifierID)
+	if err != nil {
+		return nil, err
+	}
+
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(notifier.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewNotifier
+	}
+
+	return s.notifierRepository.FindIncidentsByNotifierID(notifierID, maxListedIncidents)
+}
+
+// HandleIncidentWebhook reflects acknowledgement or resolution of an incident in the
+// incident management service. Webhooks about incidents not opened by Databasus and
+// about other state changes are ignored
+func (s *NotifierService) HandleIncidentWebhook(
+	notifierID uuid.UUID,
+	header http.Header,
+	body []byte,
+	now time.Time,
+) error {
+	notifier, err := s.notifierRepository.FindByID(notifierID)
+	if err != nil {
+		return err
+	}
+
+	incidentNotifier, isIncidentNotifier := notifier.getSpecificNotifier().(IncidentNotifier)
+	if !isIncidentNotifier {
+		return ErrNotifierDoesNotSupportIncidents
+	}
+
+	event, err := incidentNotifier.ParseIncidentWebhook(s.fieldEncryptor, header, body)
+	if err != nil {
+		return err
+	}
+
+	if event.Action == "" || event.DedupKey == "" {
+		return nil
+	}
+
+	incident, err := s.notifierRepository.FindOpenIncident(notifier.ID, event.DedupKey)
+	if err != nil {
+		return err
+	}
+	if incident == nil {
+		return nil
+	}
+
+	var actor *string
+	if event.Actor != "" {
+		actor = &event.Actor
+	}
+
+	switch event.Action {
+	case notifier_incidents.IncidentActionAcknowledge:
+		if incident.Status == NotifierIncidentStatusAcknowledged {
+			return nil
+		}
+
+		incident.Status = NotifierIncidentStatusAcknowledged
+		incident.AcknowledgedBy = actor
+		incident.AcknowledgedAt = &now
+	case notifier_incidents.IncidentActionResolve:
+		incident.Status = NotifierIncidentStatusResolved
+		incident.ResolvedAt = &now
+	}
+
+	if err := s.notifierRepository.SaveIncident(incident); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Incident %s via notifier %s: %s",
+			strings.ToLower(string(incident.Status)),
+			notifier.Name,
+			incident.Title,
+		),
+		nil,
+		&notifier.WorkspaceID,
+	)
+
+	return nil
 }
 
 func (s *NotifierService) TransferNotifierToWorkspace(
@@ -300,6 +747,10 @@ func (s *NotifierService) TransferNotifierToWorkspace(
 		return err
 	}
 
+	if existingNotifier.IsSystem {
+		return ErrSystemNotifierCannotBeTransferred
+	}
+
 	canManageSource, err := s.workspaceService.CanUserManageDBs(existingNotifier.WorkspaceID, user)
 	if err != nil {
 		return err
@@ -353,12 +804,119 @@ func (s *NotifierService) TransferNotifierToWorkspace(
 	return nil
 }
 
+// CheckNotifiersHealth verifies every notifier without delivering notifications. When a
+// notifier becomes broken, other healthy notifiers of its workspace are alerted
+func (s *NotifierService) CheckNotifiersHealth(now time.Time) error {
+	notifiers, err := s.notifierRepository.FindAll()
+	if err != nil {
+		return err
+	}
+
+	brokenNotifiers := []*Notifier{}
+	healthyNotifiersByWorkspace := map[uuid.UUID][]*Notifier{}
+
+	for _, notifier := range notifiers {
+		previousStatus := notifier.HealthStatus
+
+		isVerifiable, verifyErr := notifier.Verify(s.fieldEncryptor, s.logger)
+		if !isVerifiable {
+			continue
+		}
+
+		status := NotifierHealthStatusHealthy
+		var healthCheckError *string
+		if verifyErr != nil {
+			status = NotifierHealthStatusBroken
+			errMsg := verifyErr.Error()
+			healthCheckError = &errMsg
+		}
+
+		if err := s.notifierRepository.UpdateHealth(
+			notifier.ID,
+			status,
+			healthCheckError,
+			now,
+		); err != nil {
+			s.logger.Error(
+				"Failed to update notifier health",
+				"notifierId", notifier.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		notifier.HealthCheckError = healthCheckError
+
+		if status == NotifierHealthStatusHealthy {
+			healthyNotifiersByWorkspace[notifier.WorkspaceID] = append(
+				healthyNotifiersByWorkspace[notifier.WorkspaceID],
+				notifier,
+			)
+		} else if previousStatus != NotifierHealthStatusBroken {
+			brokenNotifiers = append(brokenNotifiers, notifier)
+		}
+	}
+
+	for _, brokenNotifier := range brokenNotifiers {
+		s.alertAboutBrokenNotifier(
+			brokenNotifier,
+			healthyNotifiersByWorkspace[brokenNotifier.WorkspaceID],
+		)
+	}
+
+	return nil
+}
+
+func (s *NotifierService) alertAboutBrokenNotifier(
+	brokenNotifier *Notifier,
+	healthyNotifiers []*Notifier,
+) {
+	s.logger.Warn(
+		"Notifier stopped working",
+		"notifierId", brokenNotifier.ID,
+		"error", *brokenNotifier.HealthCheckError,
+	)
+
+	if len(healthyNotifiers) == 0 {
+		return
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(brokenNotifier.WorkspaceID)
+	if err != nil {
+		s.logger.Error("Failed to get workspace of broken notifier", "error", err)
+		return
+	}
+
+	params := map[string]string{
+		"notifier":  brokenNotifier.Name,
+		"workspace": workspace.Name,
+		"error":     *brokenNotifier.HealthCheckError,
+	}
+
+	for _, notifier := range healthyNotifiers {
+		s.SendEventNotification(
+			notifier,
+			NotificationEventNotifierBroken,
+			i18n.Translate(notifier.Locale, i18n.MessageNotifierBrokenTitle, params),
+			i18n.Translate(notifier.Locale, i18n.MessageNotifierBrokenMessage, params),
+		)
+	}
+}
+
 func (s *NotifierService) OnBeforeWorkspaceDeletion(workspaceID uuid.UUID) error {
 	notifiers, err := s.notifierRepository.FindByWorkspaceID(workspaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get notifiers for workspace deletion: %w", err)
 	}
 
+	for _, notifier := range notifiers {
+		if notifier.IsSystem {
+			return fmt.Errorf(
+				"system notifier cannot be deleted due to workspace deletion, please remove notifier first",
+			)
+		}
+	}
+
 	for _, notifier := range notifiers {
 		if err := s.notifierRepository.Delete(notifier); err != nil {
 			return fmt.Errorf("failed to delete notifier %s: %w", notifier.ID, err)
@@ -367,3 +925,8 @@ func (s *NotifierService) OnBeforeWorkspaceDeletion(workspaceID uuid.UUID) error
 
 	return nil
 }
+
+// System notifiers are shared with every workspace, but only admins may see their settings
+func (s *NotifierService) isSpecificDataHidden(user *users_models.User, notifier *Notifier) bool {
+	return notifier.IsSystem && user.Role != users_enums.UserRoleAdmin
+}
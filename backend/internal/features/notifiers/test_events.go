@@ -0,0 +1,91 @@
+package notifiers
+
+import (
+	"databasus-backend/internal/util/i18n"
+	"fmt"
+)
+
+type TestEventType string
+
+const (
+	TestEventBackupFailed        TestEventType = "backup_failed"
+	TestEventBackupSuccess       TestEventType = "backup_success"
+	TestEventRefreshFailed       TestEventType = "refresh_failed"
+	TestEventRefreshSuccess      TestEventType = "refresh_success"
+	TestEventDatabaseUnavailable TestEventType = "database_unavailable"
+	TestEventDatabaseOnline      TestEventType = "database_online"
+	TestEventNotifierBroken      TestEventType = "notifier_broken"
+)
+
+const TestEventTitlePrefix = "[TEST] "
+
+type testEventTemplate struct {
+	titleKey   i18n.MessageKey
+	messageKey i18n.MessageKey
+	// failMessage replaces the message for failure events, which send the error as is
+	failMessage string
+}
+
+var testEventTemplates = map[TestEventType]testEventTemplate{
+	TestEventBackupFailed: {
+		titleKey:    i18n.MessageBackupFailedTitle,
+		failMessage: "pg_dump: error: connection to server failed: timeout expired",
+	},
+	TestEventBackupSuccess: {
+		titleKey:   i18n.MessageBackupSuccessTitle,
+		messageKey: i18n.MessageBackupSuccessMessage,
+	},
+	TestEventRefreshFailed: {
+		titleKey:    i18n.MessageRefreshFailedTitle,
+		failMessage: "restore failed: target database is not reachable",
+	},
+	TestEventRefreshSuccess: {
+		titleKey:   i18n.MessageRefreshSuccessTitle,
+		messageKey: i18n.MessageRefreshSuccessMessage,
+	},
+	TestEventDatabaseUnavailable: {
+		titleKey:   i18n.MessageDatabaseUnavailableTitle,
+		messageKey: i18n.MessageDatabaseUnavailableMessage,
+	},
+	TestEventDatabaseOnline: {
+		titleKey:   i18n.MessageDatabaseOnlineTitle,
+		messageKey: i18n.MessageDatabaseOnlineMessage,
+	},
+	TestEventNotifierBroken: {
+		titleKey:   i18n.MessageNotifierBrokenTitle,
+		messageKey: i18n.MessageNotifierBrokenMessage,
+	},
+}
+
+// buildTestEvent renders the event with the same templates and overrides as real events,
+// filled with sample values. The title and message are marked as a test
+func buildTestEvent(
+	eventType TestEventType,
+	locale i18n.Locale,
+	workspaceName string,
+) (title string, message string, err error) {
+	template, isFound := testEventTemplates[eventType]
+	if !isFound {
+		return "", "", fmt.Errorf("%w: %s", ErrUnknownTestEventType, eventType)
+	}
+
+	params := map[string]string{
+		"database":  "sample-database",
+		"workspace": workspaceName,
+		"refresh":   "Sample refresh",
+		"notifier":  "Sample notifier",
+		"duration":  "1m 23s 456ms",
+		"size":      "1.25 GB",
+		"error":     "webhook returned status 500",
+	}
+
+	title = TestEventTitlePrefix + i18n.Translate(locale, template.titleKey, params)
+
+	message = template.failMessage
+	if template.messageKey != "" {
+		message = i18n.Translate(locale, template.messageKey, params)
+	}
+	message += "\n\n" + i18n.Translate(locale, i18n.MessageTestEventNote, nil)
+
+	return title, message, nil
+}
@@ -0,0 +1,154 @@
+package notifiers_tickets
+
+import (
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TicketController struct {
+	ticketService *TicketService
+}
+
+func (c *TicketController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/ticket-integrations/workspace/:workspaceId", c.GetIntegration)
+	router.PUT("/ticket-integrations/workspace/:workspaceId", c.SaveIntegration)
+	router.DELETE("/ticket-integrations/workspace/:workspaceId", c.DeleteIntegration)
+	router.GET("/ticket-integrations/workspace/:workspaceId/tickets", c.GetTickets)
+}
+
+// GetIntegration
+// @Summary Get ticket integration
+// @Description Get the Jira or ServiceNow integration of a workspace, null when it is not configured
+// @Tags ticket-integrations
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} TicketIntegration
+// @Failure 400
+// @Failure 401
+// @Router /ticket-integrations/workspace/{workspaceId} [get]
+func (c *TicketController) GetIntegration(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	integration, err := c.ticketService.GetIntegration(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// SaveIntegration
+// @Summary Save ticket integration
+// @Description Create or update the integration opening tickets for repeated backup failures, empty secrets keep the saved ones
+// @Tags ticket-integrations
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body TicketIntegration true "Ticket integration"
+// @Success 200 {object} TicketIntegration
+// @Failure 400
+// @Failure 401
+// @Router /ticket-integrations/workspace/{workspaceId} [put]
+func (c *TicketController) SaveIntegration(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var integration TicketIntegration
+	if err := ctx.ShouldBindJSON(&integration); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	savedIntegration, err := c.ticketService.SaveIntegration(user, workspaceID, &integration)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, savedIntegration)
+}
+
+// DeleteIntegration
+// @Summary Delete ticket integration
+// @Description Delete the ticket integration of a workspace with its tickets history
+// @Tags ticket-integrations
+// @Param workspaceId path string true "Workspace ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /ticket-integrations/workspace/{workspaceId} [delete]
+func (c *TicketController) DeleteIntegration(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	if err := c.ticketService.DeleteIntegration(user, workspaceID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetTickets
+// @Summary Get tickets
+// @Description Get the latest tickets opened for repeated backup failures in a workspace
+// @Tags ticket-integrations
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {array} Ticket
+// @Failure 400
+// @Failure 401
+// @Router /ticket-integrations/workspace/{workspaceId}/tickets [get]
+func (c *TicketController) GetTickets(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	tickets, err := c.ticketService.GetTickets(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tickets)
+}
@@ -0,0 +1,141 @@
+package notifiers_tickets
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetTicketController(),
+	)
+}
+
+func Test_ProcessFailures_WhenBackupsFailRepeatedly_OneJiraIssueOpenedPerWindow(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	var requestsCount atomic.Int32
+	var receivedSummary atomic.Value
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestsCount.Add(1)
+
+			var request struct {
+				Fields struct {
+					Summary string `json:"summary"`
+				} `json:"fields"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &request)
+			receivedSummary.Store(request.Fields.Summary)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"10000","key":"OPS-1"}`))
+		}),
+	)
+	defer server.Close()
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/ticket-integrations/workspace/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		TicketIntegration{
+			Provider:          TicketProviderJira,
+			IsEnabled:         true,
+			FailuresThreshold: 2,
+			WindowHours:       24,
+			SummaryTemplate:   DefaultSummaryTemplate,
+			JiraURL:           server.URL,
+			JiraEmail:         "ops@example.com",
+			JiraAPIToken:      "test-token",
+			JiraProjectKey:    "OPS",
+			JiraIssueType:     "Bug",
+		},
+		http.StatusOK,
+	)
+
+	now := time.Now().UTC()
+	backupRepository := &backups_core.BackupRepository{}
+	for range 2 {
+		failMessage := "pg_dump: connection refused"
+		err := backupRepository.Save(&backups_core.Backup{
+			ID:          uuid.New(),
+			DatabaseID:  database.ID,
+			StorageID:   storage.ID,
+			Status:      backups_core.BackupStatusFailed,
+			FailMessage: &failMessage,
+			CreatedAt:   now.Add(-time.Hour),
+		})
+		assert.NoError(t, err)
+	}
+
+	err := GetTicketService().ProcessFailures(now)
+	assert.NoError(t, err)
+
+	// Failures in the same window do not open another ticket
+	err = GetTicketService().ProcessFailures(now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), requestsCount.Load())
+	summary, _ := receivedSummary.Load().(string)
+	assert.Equal(t, "Backups of "+database.Name+" failed 2 times in 24h", summary)
+
+	var tickets []Ticket
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/ticket-integrations/workspace/"+workspace.ID.String()+"/tickets",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&tickets,
+	)
+
+	assert.Len(t, tickets, 1)
+	assert.Equal(t, "OPS-1", tickets[0].ExternalKey)
+	assert.Equal(t, server.URL+"/browse/OPS-1", tickets[0].ExternalURL)
+	assert.Equal(t, database.ID, tickets[0].DatabaseID)
+
+	var integration TicketIntegration
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/ticket-integrations/workspace/"+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&integration,
+	)
+
+	assert.Empty(t, integration.JiraAPIToken)
+}
@@ -0,0 +1,42 @@
+package notifiers_tickets
+
+import (
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var ticketRepository = &TicketRepository{}
+var ticketService = &TicketService{
+	ticketRepository,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+	config.GetEnv().DatabasusURL,
+}
+var ticketController = &TicketController{
+	ticketService,
+}
+var ticketBackgroundService = &TicketBackgroundService{
+	ticketService: ticketService,
+	logger:        logger.GetLogger(),
+}
+
+func GetTicketService() *TicketService {
+	return ticketService
+}
+
+func GetTicketController() *TicketController {
+	return ticketController
+}
+
+func GetTicketBackgroundService() *TicketBackgroundService {
+	return ticketBackgroundService
+}
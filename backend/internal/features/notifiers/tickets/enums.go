@@ -0,0 +1,8 @@
+package notifiers_tickets
+
+type TicketProvider string
+
+const (
+	TicketProviderJira       TicketProvider = "JIRA"
+	TicketProviderServiceNow TicketProvider = "SERVICENOW"
+)
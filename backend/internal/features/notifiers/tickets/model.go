@@ -0,0 +1,171 @@
+package notifiers_tickets
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultSummaryTemplate     = "Backups of {database} failed {failures} times in {window}"
+	DefaultDescriptionTemplate = "Backups of database {database} in workspace {workspace} " +
+		"failed {failures} times in the last {window}.\n\n" +
+		"Last error: {lastError}\n\n" +
+		"Job log: {jobLogUrl}"
+)
+
+// TicketIntegration opens a ticket when backups of a database fail FailuresThreshold times
+// within WindowHours. Templates support {database}, {workspace}, {failures}, {window},
+// {lastError} and {jobLogUrl} placeholders
+type TicketIntegration struct {
+	ID          uuid.UUID      `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID      `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Provider    TicketProvider `json:"provider"    gorm:"column:provider;type:text;not null"`
+	IsEnabled   bool           `json:"isEnabled"   gorm:"column:is_enabled;type:boolean;not null"`
+
+	FailuresThreshold int `json:"failuresThreshold" gorm:"column:failures_threshold;type:int;not null"`
+	WindowHours       int `json:"windowHours"       gorm:"column:window_hours;type:int;not null"`
+
+	SummaryTemplate     string `json:"summaryTemplate"     gorm:"column:summary_template;type:text;not null"`
+	DescriptionTemplate string `json:"descriptionTemplate" gorm:"column:description_template;type:text;not null"`
+
+	// Jira Cloud, the API token is used with basic auth of the account email
+	JiraURL        string `json:"jiraUrl"        gorm:"column:jira_url;type:text"`
+	JiraEmail      string `json:"jiraEmail"      gorm:"column:jira_email;type:text"`
+	JiraAPIToken   string `json:"jiraApiToken"   gorm:"column:jira_api_token;type:text"`
+	JiraProjectKey string `json:"jiraProjectKey" gorm:"column:jira_project_key;type:text"`
+	JiraIssueType  string `json:"jiraIssueType"  gorm:"column:jira_issue_type;type:text"`
+
+	// ServiceNow, tickets are opened as incidents
+	ServiceNowURL             string `json:"serviceNowUrl"             gorm:"column:service_now_url;type:text"`
+	ServiceNowUsername        string `json:"serviceNowUsername"        gorm:"column:service_now_username;type:text"`
+	ServiceNowPassword        string `json:"serviceNowPassword"        gorm:"column:service_now_password;type:text"`
+	ServiceNowAssignmentGroup string `json:"serviceNowAssignmentGroup" gorm:"column:service_now_assignment_group;type:text"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (TicketIntegration) TableName() string {
+	return "ticket_integrations"
+}
+
+func (i *TicketIntegration) Validate() error {
+	if i.FailuresThreshold < 1 {
+		return errors.New("failures threshold must be at least 1")
+	}
+
+	if i.WindowHours < 1 {
+		return errors.New("window must be at least 1 hour")
+	}
+
+	if strings.TrimSpace(i.SummaryTemplate) == "" {
+		return errors.New("summary template is required")
+	}
+
+	switch i.Provider {
+	case TicketProviderJira:
+		if err := validateURL(i.JiraURL, "Jira URL"); err != nil {
+			return err
+		}
+
+		if i.JiraEmail == "" || i.JiraAPIToken == "" {
+			return errors.New("Jira email and API token are required")
+		}
+
+		if i.JiraProjectKey == "" || i.JiraIssueType == "" {
+			return errors.New("Jira project key and issue type are required")
+		}
+	case TicketProviderServiceNow:
+		if err := validateURL(i.ServiceNowURL, "ServiceNow URL"); err != nil {
+			return err
+		}
+
+		if i.ServiceNowUsername == "" || i.ServiceNowPassword == "" {
+			return errors.New("ServiceNow username and password are required")
+		}
+	default:
+		return fmt.Errorf("unknown ticket provider: %s", i.Provider)
+	}
+
+	return nil
+}
+
+// Update copies settings of the incoming integration, empty secrets keep the saved ones
+func (i *TicketIntegration) Update(incoming *TicketIntegration) {
+	i.Provider = incoming.Provider
+	i.IsEnabled = incoming.IsEnabled
+	i.FailuresThreshold = incoming.FailuresThreshold
+	i.WindowHours = incoming.WindowHours
+	i.SummaryTemplate = incoming.SummaryTemplate
+	i.DescriptionTemplate = incoming.DescriptionTemplate
+
+	i.JiraURL = incoming.JiraURL
+	i.JiraEmail = incoming.JiraEmail
+	i.JiraProjectKey = incoming.JiraProjectKey
+	i.JiraIssueType = incoming.JiraIssueType
+
+	i.ServiceNowURL = incoming.ServiceNowURL
+	i.ServiceNowUsername = incoming.ServiceNowUsername
+	i.ServiceNowAssignmentGroup = incoming.ServiceNowAssignmentGroup
+
+	if incoming.JiraAPIToken != "" {
+		i.JiraAPIToken = incoming.JiraAPIToken
+	}
+
+	if incoming.ServiceNowPassword != "" {
+		i.ServiceNowPassword = incoming.ServiceNowPassword
+	}
+}
+
+func (i *TicketIntegration) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	jiraAPIToken, err := encryptor.Encrypt(i.ID, i.JiraAPIToken)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt Jira API token: %w", err)
+	}
+	i.JiraAPIToken = jiraAPIToken
+
+	serviceNowPassword, err := encryptor.Encrypt(i.ID, i.ServiceNowPassword)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt ServiceNow password: %w", err)
+	}
+	i.ServiceNowPassword = serviceNowPassword
+
+	return nil
+}
+
+func (i *TicketIntegration) HideSensitiveData() {
+	i.JiraAPIToken = ""
+	i.ServiceNowPassword = ""
+}
+
+// Ticket was opened for repeated backup failures of a database
+type Ticket struct {
+	ID            uuid.UUID `json:"id"            gorm:"column:id;type:uuid;primaryKey"`
+	IntegrationID uuid.UUID `json:"integrationId" gorm:"column:integration_id;type:uuid;not null"`
+	DatabaseID    uuid.UUID `json:"databaseId"    gorm:"column:database_id;type:uuid;not null"`
+	ExternalKey   string    `json:"externalKey"   gorm:"column:external_key;type:text;not null"`
+	ExternalURL   string    `json:"externalUrl"   gorm:"column:external_url;type:text;not null"`
+	FailuresCount int       `json:"failuresCount" gorm:"column:failures_count;type:int;not null"`
+	LastBackupID  uuid.UUID `json:"lastBackupId"  gorm:"column:last_backup_id;type:uuid;not null"`
+	CreatedAt     time.Time `json:"createdAt"     gorm:"column:created_at"`
+}
+
+func (Ticket) TableName() string {
+	return "backup_failure_tickets"
+}
+
+func validateURL(rawURL string, name string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") ||
+		parsedURL.Host == "" {
+		return fmt.Errorf("%s must be a valid http or https URL", name)
+	}
+
+	return nil
+}
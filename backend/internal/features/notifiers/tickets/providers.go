@@ -0,0 +1,165 @@
+package notifiers_tickets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+)
+
+const requestTimeout = 30 * time.Second
+
+type openedTicket struct {
+	Key string
+	URL string
+}
+
+func openTicket(
+	integration *TicketIntegration,
+	encryptor encryption.FieldEncryptor,
+	summary string,
+	description string,
+) (*openedTicket, error) {
+	switch integration.Provider {
+	case TicketProviderJira:
+		return openJiraIssue(integration, encryptor, summary, description)
+	case TicketProviderServiceNow:
+		return openServiceNowIncident(integration, encryptor, summary, description)
+	default:
+		return nil, fmt.Errorf("unknown ticket provider: %s", integration.Provider)
+	}
+}
+
+// openJiraIssue uses API v2, which accepts plain text descriptions unlike the document
+// format of v3
+func openJiraIssue(
+	integration *TicketIntegration,
+	encryptor encryption.FieldEncryptor,
+	summary string,
+	description string,
+) (*openedTicket, error) {
+	apiToken, err := encryptor.Decrypt(integration.ID, integration.JiraAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt Jira API token: %w", err)
+	}
+
+	baseURL := strings.TrimRight(integration.JiraURL, "/")
+	payload := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": integration.JiraProjectKey},
+			"issuetype":   map[string]string{"name": integration.JiraIssueType},
+			"summary":     summary,
+			"description": description,
+		},
+	}
+
+	var response struct {
+		Key string `json:"key"`
+	}
+
+	if err := postJSON(
+		baseURL+"/rest/api/2/issue",
+		integration.JiraEmail,
+		apiToken,
+		payload,
+		&response,
+	); err != nil {
+		return nil, fmt.Errorf("failed to create Jira issue: %w", err)
+	}
+
+	return &openedTicket{
+		Key: response.Key,
+		URL: baseURL + "/browse/" + response.Key,
+	}, nil
+}
+
+func openServiceNowIncident(
+	integration *TicketIntegration,
+	encryptor encryption.FieldEncryptor,
+	summary string,
+	description string,
+) (*openedTicket, error) {
+	password, err := encryptor.Decrypt(integration.ID, integration.ServiceNowPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt ServiceNow password: %w", err)
+	}
+
+	baseURL := strings.TrimRight(integration.ServiceNowURL, "/")
+	payload := map[string]string{
+		"short_description": summary,
+		"description":       description,
+	}
+	if integration.ServiceNowAssignmentGroup != "" {
+		payload["assignment_group"] = integration.ServiceNowAssignmentGroup
+	}
+
+	var response struct {
+		Result struct {
+			SysID  string `json:"sys_id"`
+			Number string `json:"number"`
+		} `json:"result"`
+	}
+
+	if err := postJSON(
+		baseURL+"/api/now/table/incident",
+		integration.ServiceNowUsername,
+		password,
+		payload,
+		&response,
+	); err != nil {
+		return nil, fmt.Errorf("failed to create ServiceNow incident: %w", err)
+	}
+
+	return &openedTicket{
+		Key: response.Result.Number,
+		URL: baseURL + "/nav_to.do?uri=incident.do?sys_id=" + response.Result.SysID,
+	}, nil
+}
+
+func postJSON(
+	url string,
+	username string,
+	password string,
+	payload any,
+	response any,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(username, password)
+
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("API returned non-OK status: %s. Error: %s", resp.Status, bodyBytes)
+	}
+
+	if err := json.Unmarshal(bodyBytes, response); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
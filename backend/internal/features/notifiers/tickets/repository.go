@@ -0,0 +1,95 @@
+package notifiers_tickets
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TicketRepository struct{}
+
+func (r *TicketRepository) SaveIntegration(integration *TicketIntegration) error {
+	return storage.GetDb().Save(integration).Error
+}
+
+func (r *TicketRepository) FindIntegrationByWorkspaceID(
+	workspaceID uuid.UUID,
+) (*TicketIntegration, error) {
+	var integration TicketIntegration
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ?", workspaceID).
+		First(&integration).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &integration, nil
+}
+
+func (r *TicketRepository) FindEnabledIntegrations() ([]*TicketIntegration, error) {
+	var integrations []*TicketIntegration
+
+	if err := storage.
+		GetDb().
+		Where("is_enabled = ?", true).
+		Find(&integrations).Error; err != nil {
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+// DeleteIntegration removes the integration, tickets are removed by the database
+func (r *TicketRepository) DeleteIntegration(integration *TicketIntegration) error {
+	return storage.GetDb().Delete(&TicketIntegration{}, "id = ?", integration.ID).Error
+}
+
+func (r *TicketRepository) SaveTicket(ticket *Ticket) error {
+	return storage.GetDb().Save(ticket).Error
+}
+
+func (r *TicketRepository) FindLastTicketByDatabaseID(
+	integrationID uuid.UUID,
+	databaseID uuid.UUID,
+) (*Ticket, error) {
+	var ticket Ticket
+
+	if err := storage.
+		GetDb().
+		Where("integration_id = ? AND database_id = ?", integrationID, databaseID).
+		Order("created_at DESC").
+		First(&ticket).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+func (r *TicketRepository) FindTicketsByIntegrationID(
+	integrationID uuid.UUID,
+	limit int,
+) ([]*Ticket, error) {
+	var tickets []*Ticket
+
+	if err := storage.
+		GetDb().
+		Where("integration_id = ?", integrationID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
@@ -0,0 +1,310 @@
+package notifiers_tickets
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+)
+
+const latestTicketsLimit = 100
+
+type TicketService struct {
+	ticketRepository *TicketRepository
+	backupRepository *backups_core.BackupRepository
+	databaseService  *databases.DatabaseService
+	workspaceService *workspaces_services.WorkspaceService
+	auditLogService  *audit_logs.AuditLogService
+	fieldEncryptor   encryption.FieldEncryptor
+	logger           *slog.Logger
+	databasusURL     string
+}
+
+func (s *TicketService) GetIntegration(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*TicketIntegration, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view ticket integration")
+	}
+
+	integration, err := s.ticketRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil || integration == nil {
+		return nil, err
+	}
+
+	integration.HideSensitiveData()
+
+	return integration, nil
+}
+
+// SaveIntegration creates the integration of the workspace or updates it, empty secrets keep
+// the saved ones
+func (s *TicketService) SaveIntegration(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	incoming *TicketIntegration,
+) (*TicketIntegration, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to manage ticket integration")
+	}
+
+	integration, err := s.ticketRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if integration == nil {
+		integration = &TicketIntegration{
+			ID:          uuid.New(),
+			WorkspaceID: workspaceID,
+			CreatedAt:   time.Now().UTC(),
+		}
+	}
+
+	integration.Update(incoming)
+
+	if strings.TrimSpace(integration.DescriptionTemplate) == "" {
+		integration.DescriptionTemplate = DefaultDescriptionTemplate
+	}
+
+	if err := integration.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := integration.EncryptSensitiveData(s.fieldEncryptor); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketRepository.SaveIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Ticket integration saved: %s", integration.Provider),
+		&user.ID,
+		&workspaceID,
+	)
+
+	integration.HideSensitiveData()
+
+	return integration, nil
+}
+
+func (s *TicketService) DeleteIntegration(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to manage ticket integration")
+	}
+
+	integration, err := s.ticketRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+	if integration == nil {
+		return errors.New("ticket integration not found")
+	}
+
+	if err := s.ticketRepository.DeleteIntegration(integration); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Ticket integration deleted: %s", integration.Provider),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+func (s *TicketService) GetTickets(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*Ticket, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.New("insufficient permissions to view tickets")
+	}
+
+	integration, err := s.ticketRepository.FindIntegrationByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if integration == nil {
+		return []*Ticket{}, nil
+	}
+
+	return s.ticketRepository.FindTicketsByIntegrationID(integration.ID, latestTicketsLimit)
+}
+
+// ProcessFailures opens tickets for databases whose backups failed often enough within the
+// window of the integration. A database gets at most one ticket per window
+func (s *TicketService) ProcessFailures(now time.Time) error {
+	integrations, err := s.ticketRepository.FindEnabledIntegrations()
+	if err != nil {
+		return err
+	}
+	if len(integrations) == 0 {
+		return nil
+	}
+
+	allDatabases, err := s.databaseService.GetAllDatabases()
+	if err != nil {
+		return err
+	}
+
+	databasesByWorkspace := make(map[uuid.UUID][]*databases.Database)
+	for _, database := range allDatabases {
+		if database.WorkspaceID == nil {
+			continue
+		}
+
+		databasesByWorkspace[*database.WorkspaceID] = append(
+			databasesByWorkspace[*database.WorkspaceID],
+			database,
+		)
+	}
+
+	for _, integration := range integrations {
+		for _, database := range databasesByWorkspace[integration.WorkspaceID] {
+			if err := s.processDatabaseFailures(integration, database, now); err != nil {
+				s.logger.Error(
+					"Failed to process backup failures for ticket",
+					"integrationId",
+					integration.ID,
+					"databaseId",
+					database.ID,
+					"error",
+					err,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *TicketService) processDatabaseFailures(
+	integration *TicketIntegration,
+	database *databases.Database,
+	now time.Time,
+) error {
+	windowStart := now.Add(-time.Duration(integration.WindowHours) * time.Hour)
+
+	failedBackups, err := s.backupRepository.FindByDatabaseIdAndStatusCreatedAfter(
+		database.ID,
+		backups_core.BackupStatusFailed,
+		windowStart,
+	)
+	if err != nil {
+		return err
+	}
+	if len(failedBackups) < integration.FailuresThreshold {
+		return nil
+	}
+
+	lastTicket, err := s.ticketRepository.FindLastTicketByDatabaseID(integration.ID, database.ID)
+	if err != nil {
+		return err
+	}
+	if lastTicket != nil && lastTicket.CreatedAt.After(windowStart) {
+		return nil
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(integration.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	// Failed backups are ordered from the latest
+	lastBackup := failedBackups[0]
+	lastError := ""
+	if lastBackup.FailMessage != nil {
+		lastError = *lastBackup.FailMessage
+	}
+
+	params := map[string]string{
+		"database":  database.Name,
+		"workspace": workspace.Name,
+		"failures":  strconv.Itoa(len(failedBackups)),
+		"window":    fmt.Sprintf("%dh", integration.WindowHours),
+		"lastError": lastError,
+		"jobLogUrl": s.getJobLogURL(lastBackup.ID),
+	}
+
+	openedTicket, err := openTicket(
+		integration,
+		s.fieldEncryptor,
+		i18n.Render(integration.SummaryTemplate, params),
+		i18n.Render(integration.DescriptionTemplate, params),
+	)
+	if err != nil {
+		return err
+	}
+
+	ticket := &Ticket{
+		ID:            uuid.New(),
+		IntegrationID: integration.ID,
+		DatabaseID:    database.ID,
+		ExternalKey:   openedTicket.Key,
+		ExternalURL:   openedTicket.URL,
+		FailuresCount: len(failedBackups),
+		LastBackupID:  lastBackup.ID,
+		CreatedAt:     now,
+	}
+
+	if err := s.ticketRepository.SaveTicket(ticket); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Ticket %s opened for repeated backup failures of database %s",
+			ticket.ExternalKey,
+			database.Name,
+		),
+		nil,
+		&integration.WorkspaceID,
+	)
+
+	return nil
+}
+
+// getJobLogURL links the run log of the backup, it needs DATABASUS_URL to be set
+func (s *TicketService) getJobLogURL(backupID uuid.UUID) string {
+	if s.databasusURL == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/api/v1/backups/%s/log", strings.TrimRight(s.databasusURL, "/"), backupID)
+}
@@ -0,0 +1,113 @@
+package ownership
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+
+	"github.com/google/uuid"
+)
+
+const maxOwnerTeamLength = 255
+
+// Ownership tells who to ask before touching a database or storage. The owner is a member
+// of the workspace, the team is free text like "payments"
+type Ownership struct {
+	OwnerUserID *uuid.UUID `json:"ownerUserId" gorm:"column:owner_user_id;type:uuid"`
+	OwnerTeam   string     `json:"ownerTeam"   gorm:"column:owner_team;type:text;not null;default:''"`
+}
+
+// Filter matches resources by owner, empty fields match any resource
+type Filter struct {
+	OwnerUserID *uuid.UUID `form:"owner_user_id"`
+	OwnerTeam   string     `form:"owner_team"`
+}
+
+type MembershipChecker interface {
+	GetUserWorkspaceRole(
+		workspaceID uuid.UUID,
+		userID uuid.UUID,
+	) (*users_enums.WorkspaceRole, error)
+}
+
+// Validate trims the team and requires the owner to be a member of the workspace
+func (o *Ownership) Validate(workspaceID uuid.UUID, checker MembershipChecker) error {
+	if err := o.validateTeam(); err != nil {
+		return err
+	}
+
+	return o.validateOwner(workspaceID, checker)
+}
+
+// ValidateUpdate accepts an unchanged owner who left the workspace, so other settings of the
+// resource can still be edited. Such resources are in the orphaned resources report
+func (o *Ownership) ValidateUpdate(
+	previous Ownership,
+	workspaceID uuid.UUID,
+	checker MembershipChecker,
+) error {
+	if err := o.validateTeam(); err != nil {
+		return err
+	}
+
+	if o.OwnerUserID != nil && previous.OwnerUserID != nil &&
+		*o.OwnerUserID == *previous.OwnerUserID {
+		return nil
+	}
+
+	return o.validateOwner(workspaceID, checker)
+}
+
+func (o *Ownership) validateTeam() error {
+	o.OwnerTeam = strings.TrimSpace(o.OwnerTeam)
+
+	if len(o.OwnerTeam) > maxOwnerTeamLength {
+		return fmt.Errorf("owner team must be at most %d characters", maxOwnerTeamLength)
+	}
+
+	return nil
+}
+
+func (o *Ownership) validateOwner(workspaceID uuid.UUID, checker MembershipChecker) error {
+	if o.OwnerUserID == nil {
+		return nil
+	}
+
+	role, err := checker.GetUserWorkspaceRole(workspaceID, *o.OwnerUserID)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("owner must be a member of the workspace")
+	}
+
+	return nil
+}
+
+// IsOrphaned reports whether the owner is set but no longer a member of the workspace
+func (o *Ownership) IsOrphaned(workspaceID uuid.UUID, checker MembershipChecker) (bool, error) {
+	if o.OwnerUserID == nil {
+		return false, nil
+	}
+
+	role, err := checker.GetUserWorkspaceRole(workspaceID, *o.OwnerUserID)
+	if err != nil {
+		return false, err
+	}
+
+	return role == nil, nil
+}
+
+func (f *Filter) Matches(o Ownership) bool {
+	if f.OwnerUserID != nil && (o.OwnerUserID == nil || *o.OwnerUserID != *f.OwnerUserID) {
+		return false
+	}
+
+	if f.OwnerTeam != "" && !strings.EqualFold(o.OwnerTeam, strings.TrimSpace(f.OwnerTeam)) {
+		return false
+	}
+
+	return true
+}
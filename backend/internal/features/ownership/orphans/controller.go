@@ -0,0 +1,57 @@
+package ownership_orphans
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrphanedResourceController struct {
+	orphanedResourceService *OrphanedResourceService
+}
+
+func (c *OrphanedResourceController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/ownership/workspace/:workspaceId/orphaned", c.GetOrphanedResources)
+}
+
+// GetOrphanedResources
+// @Summary Get orphaned resources
+// @Description List databases and storages of the workspace whose owner is no longer a member of it
+// @Tags ownership
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} OrphanedResourcesResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /ownership/workspace/{workspaceId}/orphaned [get]
+func (c *OrphanedResourceController) GetOrphanedResources(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	response, err := c.orphanedResourceService.GetOrphanedResources(user, workspaceID)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewResources) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
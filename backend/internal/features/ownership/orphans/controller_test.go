@@ -0,0 +1,82 @@
+package ownership_orphans
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/storage"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetOrphanedResourceController(),
+	)
+}
+
+func Test_GetOrphanedResources_WhenOwnerIsNotMember_ResourceListed(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	formerMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	testStorage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(testStorage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	err := storage.GetDb().Model(&databases.Database{}).
+		Where("id = ?", database.ID).
+		Updates(map[string]any{"owner_user_id": formerMember.UserID, "owner_team": "Payments"}).
+		Error
+	assert.NoError(t, err)
+
+	err = storage.GetDb().Model(&storages.Storage{}).
+		Where("id = ?", testStorage.ID).
+		Update("owner_user_id", viewer.UserID).Error
+	assert.NoError(t, err)
+
+	url := "/api/v1/ownership/workspace/" + workspace.ID.String() + "/orphaned"
+
+	var response OrphanedResourcesResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		url,
+		"Bearer "+viewer.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Len(t, response.Resources, 1)
+	assert.Equal(t, ResourceTypeDatabase, response.Resources[0].Type)
+	assert.Equal(t, database.ID, response.Resources[0].ID)
+	assert.Equal(t, formerMember.UserID, response.Resources[0].OwnerUserID)
+	assert.Equal(t, "Payments", response.Resources[0].OwnerTeam)
+
+	test_utils.MakeGetRequest(t, router, url, "Bearer "+outsider.Token, http.StatusForbidden)
+}
@@ -0,0 +1,24 @@
+package ownership_orphans
+
+import (
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var orphanedResourceService = &OrphanedResourceService{
+	databases.GetDatabaseService(),
+	storages.GetStorageService(),
+	workspaces_services.GetWorkspaceService(),
+}
+var orphanedResourceController = &OrphanedResourceController{
+	orphanedResourceService,
+}
+
+func GetOrphanedResourceService() *OrphanedResourceService {
+	return orphanedResourceService
+}
+
+func GetOrphanedResourceController() *OrphanedResourceController {
+	return orphanedResourceController
+}
@@ -0,0 +1,23 @@
+package ownership_orphans
+
+import "github.com/google/uuid"
+
+type ResourceType string
+
+const (
+	ResourceTypeDatabase ResourceType = "DATABASE"
+	ResourceTypeStorage  ResourceType = "STORAGE"
+)
+
+// OrphanedResource is owned by a user who is no longer a member of the workspace
+type OrphanedResource struct {
+	Type        ResourceType `json:"type"`
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	OwnerUserID uuid.UUID    `json:"ownerUserId"`
+	OwnerTeam   string       `json:"ownerTeam"`
+}
+
+type OrphanedResourcesResponse struct {
+	Resources []OrphanedResource `json:"resources"`
+}
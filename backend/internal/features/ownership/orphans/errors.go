@@ -0,0 +1,7 @@
+package ownership_orphans
+
+import "errors"
+
+var ErrInsufficientPermissionsToViewResources = errors.New(
+	"insufficient permissions to view resources of this workspace",
+)
@@ -0,0 +1,103 @@
+package ownership_orphans
+
+import (
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/ownership"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+type OrphanedResourceService struct {
+	databaseService  *databases.DatabaseService
+	storageService   *storages.StorageService
+	workspaceService *workspaces_services.WorkspaceService
+}
+
+// GetOrphanedResources lists databases and storages of the workspace whose owner left it.
+// System storages of other workspaces shared with this one are not included
+func (s *OrphanedResourceService) GetOrphanedResources(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*OrphanedResourcesResponse, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewResources
+	}
+
+	resources := make([]OrphanedResource, 0)
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, database := range workspaceDatabases {
+		resource, err := s.toOrphanedResource(
+			ResourceTypeDatabase,
+			database.ID,
+			database.Name,
+			database.Ownership,
+			workspaceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if resource != nil {
+			resources = append(resources, *resource)
+		}
+	}
+
+	workspaceStorages, err := s.storageService.GetWorkspaceStorages(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, storage := range workspaceStorages {
+		if storage.WorkspaceID != workspaceID {
+			continue
+		}
+
+		resource, err := s.toOrphanedResource(
+			ResourceTypeStorage,
+			storage.ID,
+			storage.Name,
+			storage.Ownership,
+			workspaceID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if resource != nil {
+			resources = append(resources, *resource)
+		}
+	}
+
+	return &OrphanedResourcesResponse{Resources: resources}, nil
+}
+
+func (s *OrphanedResourceService) toOrphanedResource(
+	resourceType ResourceType,
+	id uuid.UUID,
+	name string,
+	resourceOwnership ownership.Ownership,
+	workspaceID uuid.UUID,
+) (*OrphanedResource, error) {
+	isOrphaned, err := resourceOwnership.IsOrphaned(workspaceID, s.workspaceService)
+	if err != nil || !isOrphaned {
+		return nil, err
+	}
+
+	return &OrphanedResource{
+		Type:        resourceType,
+		ID:          id,
+		Name:        name,
+		OwnerUserID: *resourceOwnership.OwnerUserID,
+		OwnerTeam:   resourceOwnership.OwnerTeam,
+	}, nil
+}
@@ -9,6 +9,7 @@ var databasePlanRepository = &DatabasePlanRepository{}
 var databasePlanService = &DatabasePlanService{
 	databasePlanRepository,
 	logger.GetLogger(),
+	nil,
 }
 
 func GetDatabasePlanService() *DatabasePlanService {
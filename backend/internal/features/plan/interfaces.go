@@ -0,0 +1,10 @@
+package plans
+
+import "github.com/google/uuid"
+
+// DatabasePlanLimitsProvider overrides stored plans with limits derived
+// from elsewhere (e.g. workspace subscription). It returns nil when the
+// stored plan should be used
+type DatabasePlanLimitsProvider interface {
+	GetDatabasePlanLimits(databaseID uuid.UUID) (*DatabasePlan, error)
+}
@@ -11,9 +11,26 @@ import (
 type DatabasePlanService struct {
 	databasePlanRepository *DatabasePlanRepository
 	logger                 *slog.Logger
+
+	planLimitsProvider DatabasePlanLimitsProvider
+}
+
+func (s *DatabasePlanService) SetPlanLimitsProvider(provider DatabasePlanLimitsProvider) {
+	s.planLimitsProvider = provider
 }
 
 func (s *DatabasePlanService) GetDatabasePlan(databaseID uuid.UUID) (*DatabasePlan, error) {
+	if s.planLimitsProvider != nil {
+		providedPlan, err := s.planLimitsProvider.GetDatabasePlanLimits(databaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		if providedPlan != nil {
+			return providedPlan, nil
+		}
+	}
+
 	plan, err := s.databasePlanRepository.GetDatabasePlan(databaseID)
 	if err != nil {
 		return nil, err
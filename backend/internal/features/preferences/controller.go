@@ -0,0 +1,77 @@
+package preferences
+
+import (
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	users_models "databasus-backend/internal/features/users/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PreferencesController struct {
+	preferencesService *PreferencesService
+}
+
+func (c *PreferencesController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/me/preferences", c.GetPreferences)
+	router.PUT("/users/me/preferences", c.UpdatePreferences)
+}
+
+// GetPreferences
+// @Summary Get own preferences
+// @Description Get display timezone, default landing workspace and personal notification opt-ins
+// @Tags users
+// @Produce json
+// @Success 200 {object} users_models.UserPreferences
+// @Failure 401
+// @Failure 500
+// @Router /users/me/preferences [get]
+func (c *PreferencesController) GetPreferences(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	preferences, err := c.preferencesService.GetPreferences(user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences
+// @Summary Update own preferences
+// @Description Replace display timezone, default landing workspace and personal notification opt-ins
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body users_models.UserPreferences true "Preferences"
+// @Success 200 {object} users_models.UserPreferences
+// @Failure 400
+// @Failure 401
+// @Router /users/me/preferences [put]
+func (c *PreferencesController) UpdatePreferences(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request users_models.UserPreferences
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences, err := c.preferencesService.UpdatePreferences(user, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preferences)
+}
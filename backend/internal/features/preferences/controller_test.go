@@ -0,0 +1,152 @@
+package preferences
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/storage"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetPreferencesController(),
+	)
+}
+
+func Test_UpdatePreferences_WithValidPreferences_ReturnedWithProfile(t *testing.T) {
+	router := createTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	request := users_models.UserPreferences{
+		Timezone:                      "Europe/Berlin",
+		DefaultWorkspaceID:            &workspace.ID,
+		IsNotifyOnOwnedBackupFailures: true,
+	}
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/me/preferences",
+		"Bearer "+user.Token,
+		request,
+		http.StatusOK,
+	)
+
+	var preferences users_models.UserPreferences
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/me/preferences",
+		"Bearer "+user.Token,
+		http.StatusOK,
+		&preferences,
+	)
+	assert.Equal(t, request, preferences)
+
+	storedUser, err := users_services.GetUserService().GetUserByID(user.UserID)
+	assert.NoError(t, err)
+	profile := users_services.GetUserService().GetCurrentUserProfile(storedUser)
+	assert.Equal(t, request, profile.Preferences)
+}
+
+func Test_UpdatePreferences_WithInvalidValues_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	otherUser := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	otherWorkspace := workspaces_testing.CreateTestWorkspace("Other Workspace", otherUser, router)
+	defer workspaces_testing.RemoveTestWorkspace(otherWorkspace, router)
+	missingWorkspaceID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		preferences users_models.UserPreferences
+	}{
+		{
+			name:        "unknown timezone",
+			preferences: users_models.UserPreferences{Timezone: "Mars/Olympus"},
+		},
+		{
+			name:        "local timezone",
+			preferences: users_models.UserPreferences{Timezone: "Local"},
+		},
+		{
+			name: "workspace of other user",
+			preferences: users_models.UserPreferences{
+				DefaultWorkspaceID: &otherWorkspace.ID,
+			},
+		},
+		{
+			name: "missing workspace",
+			preferences: users_models.UserPreferences{
+				DefaultWorkspaceID: &missingWorkspaceID,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			test_utils.MakePutRequest(
+				t,
+				router,
+				"/api/v1/users/me/preferences",
+				"Bearer "+user.Token,
+				tc.preferences,
+				http.StatusBadRequest,
+			)
+		})
+	}
+}
+
+func Test_NotifyOwnerAboutBackupFailure_WhenOptedInWithVerifiedEmail_EmailSent(t *testing.T) {
+	mockEmailSender := users_testing.NewMockEmailSender()
+	mockEmailSender.IsSMTPConfigured = true
+	users_services.GetUserService().SetEmailSender(mockEmailSender)
+	defer users_services.GetUserService().SetEmailSender(users_testing.NewMockEmailSender())
+
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	err := users_services.GetUserService().UpdateUserPreferences(
+		owner.UserID,
+		users_models.UserPreferences{IsNotifyOnOwnedBackupFailures: true},
+	)
+	assert.NoError(t, err)
+
+	err = users_services.GetUserService().NotifyOwnerAboutBackupFailure(
+		owner.UserID,
+		"orders",
+		"Payments",
+		"connection refused",
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, mockEmailSender.SentEmails)
+
+	err = storage.GetDb().Model(&users_models.User{}).
+		Where("id = ?", owner.UserID).
+		Update("is_email_verified", true).Error
+	assert.NoError(t, err)
+
+	err = users_services.GetUserService().NotifyOwnerAboutBackupFailure(
+		owner.UserID,
+		"orders",
+		"Payments",
+		"connection refused",
+	)
+	assert.NoError(t, err)
+	assert.Len(t, mockEmailSender.SentEmails, 1)
+	assert.Equal(t, owner.Email, mockEmailSender.SentEmails[0].To)
+	assert.Contains(t, mockEmailSender.SentEmails[0].Body, "connection refused")
+}
@@ -0,0 +1,22 @@
+package preferences
+
+import (
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var preferencesService = &PreferencesService{
+	users_services.GetUserService(),
+	workspaces_services.GetWorkspaceService(),
+}
+var preferencesController = &PreferencesController{
+	preferencesService,
+}
+
+func GetPreferencesService() *PreferencesService {
+	return preferencesService
+}
+
+func GetPreferencesController() *PreferencesController {
+	return preferencesController
+}
@@ -0,0 +1,12 @@
+package preferences
+
+import "errors"
+
+var (
+	ErrInvalidTimezone = errors.New(
+		"timezone must be an IANA time zone name like Europe/Berlin",
+	)
+	ErrDefaultWorkspaceNotAccessible = errors.New(
+		"default workspace must be a workspace you are a member of",
+	)
+)
@@ -0,0 +1,70 @@
+package preferences
+
+import (
+	"time"
+	// the runtime image has no system time zone database
+	_ "time/tzdata"
+
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+type PreferencesService struct {
+	userService      *users_services.UserService
+	workspaceService *workspaces_services.WorkspaceService
+}
+
+// GetPreferences hides the default workspace once the user lost access to it, so clients
+// fall back to their own choice of the landing workspace
+func (s *PreferencesService) GetPreferences(
+	user *users_models.User,
+) (*users_models.UserPreferences, error) {
+	preferences := user.Preferences
+
+	if preferences.DefaultWorkspaceID != nil {
+		canView, _, err := s.workspaceService.CanUserAccessWorkspace(
+			*preferences.DefaultWorkspaceID,
+			user,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			preferences.DefaultWorkspaceID = nil
+		}
+	}
+
+	return &preferences, nil
+}
+
+func (s *PreferencesService) UpdatePreferences(
+	user *users_models.User,
+	request *users_models.UserPreferences,
+) (*users_models.UserPreferences, error) {
+	if request.Timezone != "" {
+		if _, err := time.LoadLocation(request.Timezone); err != nil ||
+			request.Timezone == "Local" {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	if request.DefaultWorkspaceID != nil {
+		canView, _, err := s.workspaceService.CanUserAccessWorkspace(
+			*request.DefaultWorkspaceID,
+			user,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, ErrDefaultWorkspaceNotAccessible
+		}
+	}
+
+	if err := s.userService.UpdateUserPreferences(user.ID, *request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
@@ -3,6 +3,7 @@ package restores
 import (
 	restores_core "databasus-backend/internal/features/restores/core"
 	users_middleware "databasus-backend/internal/features/users/middleware"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,7 +17,9 @@ type RestoreController struct {
 func (c *RestoreController) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/restores/:backupId", c.GetRestores)
 	router.POST("/restores/:backupId/restore", c.RestoreBackup)
+	router.POST("/restores/:backupId/check", c.CheckRestore)
 	router.POST("/restores/cancel/:restoreId", c.CancelRestore)
+	router.GET("/backups/:id/restore-estimate", c.GetRestoreEstimate)
 }
 
 // GetRestores
@@ -51,14 +54,50 @@ func (c *RestoreController) GetRestores(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, restores)
 }
 
+// GetRestoreEstimate
+// @Summary Estimate restore duration of a backup
+// @Description Estimate how long restoring the backup takes if started now, based on the backup size,
+// @Description throughput of past restores of the database and storage types and current load of restore nodes
+// @Tags restores
+// @Produce json
+// @Param id path string true "Backup ID"
+// @Success 200 {object} restores_core.RestoreEstimate
+// @Failure 400
+// @Failure 401
+// @Router /backups/{id}/restore-estimate [get]
+func (c *RestoreController) GetRestoreEstimate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	backupID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	estimate, err := c.restoreService.GetRestoreEstimate(user, backupID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, estimate)
+}
+
 // RestoreBackup
 // @Summary Restore a backup
-// @Description Start a restore process for a specific backup
+// @Description Start a restore process for a specific backup. Failed pre-restore checks
+// @Description block the restore and are returned in report unless listed in overrideChecks.
+// @Description Restores into a PROD database require owner or admin role and isProdRestoreConfirmed
 // @Tags restores
 // @Param backupId path string true "Backup ID"
 // @Success 200 {object} map[string]string
 // @Failure 400
 // @Failure 401
+// @Failure 403
 // @Router /restores/{backupId}/restore [post]
 func (c *RestoreController) RestoreBackup(ctx *gin.Context) {
 	user, ok := users_middleware.GetUserFromContext(ctx)
@@ -80,6 +119,20 @@ func (c *RestoreController) RestoreBackup(ctx *gin.Context) {
 	}
 
 	if err := c.restoreService.RestoreBackupWithAuth(user, backupID, requestDTO); err != nil {
+		var checksErr *restores_core.PreRestoreChecksError
+		if errors.As(err, &checksErr) {
+			ctx.JSON(
+				http.StatusBadRequest,
+				gin.H{"error": err.Error(), "report": checksErr.Report},
+			)
+			return
+		}
+
+		if errors.Is(err, ErrInsufficientPermissionsToRestoreToProd) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -87,6 +140,43 @@ func (c *RestoreController) RestoreBackup(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "restore started successfully"})
 }
 
+// CheckRestore
+// @Summary Check a restore of a backup
+// @Description Run pre-restore checks of the target without starting the restore
+// @Tags restores
+// @Param backupId path string true "Backup ID"
+// @Success 200 {object} restores_core.PreRestoreReport
+// @Failure 400
+// @Failure 401
+// @Router /restores/{backupId}/check [post]
+func (c *RestoreController) CheckRestore(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	backupID, err := uuid.Parse(ctx.Param("backupId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	var requestDTO restores_core.RestoreBackupRequest
+	if err := ctx.ShouldBindJSON(&requestDTO); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.restoreService.CheckRestoreWithAuth(user, backupID, requestDTO)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
 // CancelRestore
 // @Summary Cancel an in-progress restore
 // @Description Cancel a restore that is currently in progress
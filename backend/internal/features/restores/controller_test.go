@@ -402,6 +402,70 @@ func Test_RestoreBackup_DiskSpaceValidation(t *testing.T) {
 	}
 }
 
+func Test_CheckRestore_WhenDiskSpaceIsInsufficient_ReportBlockedUnlessOverridden(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	database, backup := createTestDatabaseWithBackupForRestore(workspace, owner, router)
+	defer cleanupDatabaseWithBackup(database, backup)
+
+	repo := &backups_core.BackupRepository{}
+	backup.BackupSizeMb = 10485760.0
+	err := repo.Save(backup)
+	assert.NoError(t, err)
+
+	request := restores_core.RestoreBackupRequest{
+		PostgresqlDatabase: &postgresql.PostgresqlDatabase{
+			Version:  tools.PostgresqlVersion16,
+			Host:     env_config.GetEnv().TestLocalhost,
+			Port:     5432,
+			Username: "postgres",
+			Password: "postgres",
+			CpuCount: 4,
+		},
+	}
+
+	var report restores_core.PreRestoreReport
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/restores/%s/check", backup.ID.String()),
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&report,
+	)
+
+	assert.True(t, report.IsBlocked)
+	diskCheck := findPreRestoreCheck(report, restores_core.PreRestoreCheckTypeDiskSpace)
+	assert.NotNil(t, diskCheck)
+	assert.Equal(t, restores_core.PreRestoreCheckStatusFailed, diskCheck.Status)
+	assert.False(t, diskCheck.IsOverridden)
+
+	request.OverrideChecks = []restores_core.PreRestoreCheckType{
+		restores_core.PreRestoreCheckTypeDiskSpace,
+	}
+
+	var overriddenReport restores_core.PreRestoreReport
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/restores/%s/check", backup.ID.String()),
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&overriddenReport,
+	)
+
+	diskCheck = findPreRestoreCheck(overriddenReport, restores_core.PreRestoreCheckTypeDiskSpace)
+	assert.NotNil(t, diskCheck)
+	assert.Equal(t, restores_core.PreRestoreCheckStatusFailed, diskCheck.Status)
+	assert.True(t, diskCheck.IsOverridden)
+	assert.Empty(t, overriddenReport.GetBlockingChecks())
+}
+
 func Test_CancelRestore_InProgressRestore_SuccessfullyCancelled(t *testing.T) {
 	cache_utils.ClearAllCache()
 	tasks_cancellation.SetupDependencies()
@@ -583,6 +647,57 @@ func Test_RestoreBackup_WithParallelRestoreInProgress_ReturnsError(t *testing.T)
 	assert.Contains(t, string(testResp2.Body), "another restore is already in progress")
 }
 
+func Test_GetRestoreEstimate_WithPastRestores_EstimatedFromHistory(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	database, backup := createTestDatabaseWithBackupForRestore(workspace, owner, router)
+	defer cleanupDatabaseWithBackup(database, backup)
+
+	pastRestore := &restores_core.Restore{
+		Status:            restores_core.RestoreStatusCompleted,
+		BackupID:          backup.ID,
+		RestoreDurationMs: 2000,
+		CreatedAt:         time.Now().UTC(),
+	}
+	assert.NoError(t, restoreRepository.Save(pastRestore))
+	defer restoreRepository.DeleteByID(pastRestore.ID)
+
+	var estimate restores_core.RestoreEstimate
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/restore-estimate", backup.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&estimate,
+	)
+
+	assert.Equal(t, backup.ID, estimate.BackupID)
+	assert.Equal(t, 10.5, estimate.BackupSizeMb)
+	assert.Equal(
+		t,
+		restores_core.RestoreThroughputSourceEngineAndStorage,
+		estimate.ThroughputSource,
+	)
+	assert.GreaterOrEqual(t, estimate.SampleRestoresCount, int64(1))
+	assert.Greater(t, estimate.ThroughputMBs, 0.0)
+	assert.Greater(t, estimate.EstimatedDurationMs, int64(0))
+	assert.True(t, estimate.EstimatedFinishAt.After(time.Now().UTC()))
+
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	testResp := test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/restore-estimate", backup.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "insufficient permissions")
+}
+
 func createTestRouter() *gin.Engine {
 	return CreateTestRouter()
 }
@@ -775,6 +890,19 @@ func cleanupDatabaseWithBackup(database *databases.Database, backup *backups_cor
 	}
 }
 
+func findPreRestoreCheck(
+	report restores_core.PreRestoreReport,
+	checkType restores_core.PreRestoreCheckType,
+) *restores_core.PreRestoreCheck {
+	for _, check := range report.Checks {
+		if check.Type == checkType {
+			return &check
+		}
+	}
+
+	return nil
+}
+
 func cleanupBackup(backup *backups_core.Backup) {
 	repo := &backups_core.BackupRepository{}
 	repo.DeleteByID(backup.ID)
@@ -0,0 +1,70 @@
+package restores_core
+
+import (
+	"slices"
+	"strings"
+)
+
+type PreRestoreCheck struct {
+	Type    PreRestoreCheckType   `json:"type"`
+	Status  PreRestoreCheckStatus `json:"status"`
+	Message string                `json:"message"`
+	// IsOverridden is set for failed checks listed in overrideChecks of the request
+	IsOverridden bool `json:"isOverridden"`
+}
+
+// PreRestoreReport lists checks of the target run before a restore starts. Failed checks
+// block the restore unless they are overridden, so a restore does not stop halfway and
+// leave the target unusable
+type PreRestoreReport struct {
+	Checks    []PreRestoreCheck `json:"checks"`
+	IsBlocked bool              `json:"isBlocked"`
+}
+
+type PreRestoreChecksError struct {
+	Report *PreRestoreReport
+}
+
+func (e *PreRestoreChecksError) Error() string {
+	messages := make([]string, 0, len(e.Report.Checks))
+	for _, check := range e.Report.GetBlockingChecks() {
+		messages = append(messages, check.Message)
+	}
+
+	return "pre-restore checks failed: " + strings.Join(messages, "; ")
+}
+
+func NewPreRestoreReport() *PreRestoreReport {
+	return &PreRestoreReport{Checks: []PreRestoreCheck{}}
+}
+
+// Add records the check. A failed check blocks the report unless its type is overridden
+func (r *PreRestoreReport) Add(
+	checkType PreRestoreCheckType,
+	status PreRestoreCheckStatus,
+	message string,
+	overrides []PreRestoreCheckType,
+) {
+	check := PreRestoreCheck{Type: checkType, Status: status, Message: message}
+
+	if status == PreRestoreCheckStatusFailed {
+		if slices.Contains(overrides, checkType) {
+			check.IsOverridden = true
+		} else {
+			r.IsBlocked = true
+		}
+	}
+
+	r.Checks = append(r.Checks, check)
+}
+
+func (r *PreRestoreReport) GetBlockingChecks() []PreRestoreCheck {
+	var blockingChecks []PreRestoreCheck
+	for _, check := range r.Checks {
+		if check.Status == PreRestoreCheckStatusFailed && !check.IsOverridden {
+			blockingChecks = append(blockingChecks, check)
+		}
+	}
+
+	return blockingChecks
+}
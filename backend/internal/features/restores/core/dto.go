@@ -1,15 +1,72 @@
 package restores_core
 
 import (
+	"databasus-backend/internal/features/databases/databases/cassandra"
+	"databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/influxdb"
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/mysql"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	"databasus-backend/internal/features/databases/databases/postgresql"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type RestoreBackupRequest struct {
-	PostgresqlDatabase *postgresql.PostgresqlDatabase `json:"postgresqlDatabase"`
-	MysqlDatabase      *mysql.MysqlDatabase           `json:"mysqlDatabase"`
-	MariadbDatabase    *mariadb.MariadbDatabase       `json:"mariadbDatabase"`
-	MongodbDatabase    *mongodb.MongodbDatabase       `json:"mongodbDatabase"`
+	PostgresqlDatabase    *postgresql.PostgresqlDatabase       `json:"postgresqlDatabase"`
+	MysqlDatabase         *mysql.MysqlDatabase                 `json:"mysqlDatabase"`
+	MariadbDatabase       *mariadb.MariadbDatabase             `json:"mariadbDatabase"`
+	MongodbDatabase       *mongodb.MongodbDatabase             `json:"mongodbDatabase"`
+	FilesystemDatabase    *filesystem.FilesystemDatabase       `json:"filesystemDatabase"`
+	ElasticsearchDatabase *elasticsearch.ElasticsearchDatabase `json:"elasticsearchDatabase"`
+	InfluxdbDatabase      *influxdb.InfluxdbDatabase           `json:"influxdbDatabase"`
+	CassandraDatabase     *cassandra.CassandraDatabase         `json:"cassandraDatabase"`
+	CockroachdbDatabase   *cockroachdb.CockroachdbDatabase     `json:"cockroachdbDatabase"`
+	Neo4jDatabase         *neo4j.Neo4jDatabase                 `json:"neo4jDatabase"`
+
+	// IsApplyMasking runs masking rules of the database on the restored data, the restore
+	// fails when they cannot be applied
+	IsApplyMasking bool `json:"isApplyMasking"`
+
+	// OverrideChecks lets the restore start despite failed pre-restore checks of these types
+	OverrideChecks []PreRestoreCheckType `json:"overrideChecks"`
+
+	// IsProdRestoreConfirmed is required when the target is on the server of a PROD database
+	IsProdRestoreConfirmed bool `json:"isProdRestoreConfirmed"`
+}
+
+// RestoreThroughputHistory sums recent completed restores, it is empty when none match
+type RestoreThroughputHistory struct {
+	RestoresCount   int64   `gorm:"column:restores_count"`
+	TotalSizeMb     float64 `gorm:"column:total_size_mb"`
+	TotalDurationMs int64   `gorm:"column:total_duration_ms"`
+}
+
+func (h *RestoreThroughputHistory) GetThroughputMBs() float64 {
+	if h.RestoresCount == 0 || h.TotalDurationMs <= 0 {
+		return 0
+	}
+
+	return h.TotalSizeMb / (float64(h.TotalDurationMs) / 1000)
+}
+
+// RestoreEstimate is the expected duration of restoring the backup if started now.
+// ActiveRestores are running on the least busy node and share its bandwidth with the restore
+type RestoreEstimate struct {
+	BackupID     uuid.UUID `json:"backupId"`
+	BackupSizeMb float64   `json:"backupSizeMb"`
+
+	ThroughputMBs       float64                 `json:"throughputMBs"`
+	ThroughputSource    RestoreThroughputSource `json:"throughputSource"`
+	SampleRestoresCount int64                   `json:"sampleRestoresCount"`
+
+	AvailableNodes int `json:"availableNodes"`
+	ActiveRestores int `json:"activeRestores"`
+
+	EstimatedDurationMs int64     `json:"estimatedDurationMs"`
+	EstimatedFinishAt   time.Time `json:"estimatedFinishAt"`
 }
@@ -8,3 +8,35 @@ const (
 	RestoreStatusFailed     RestoreStatus = "FAILED"
 	RestoreStatusCanceled   RestoreStatus = "CANCELED"
 )
+
+type PreRestoreCheckType string
+
+const (
+	PreRestoreCheckTypeVersion    PreRestoreCheckType = "VERSION"
+	PreRestoreCheckTypeExtensions PreRestoreCheckType = "EXTENSIONS"
+	PreRestoreCheckTypeEncoding   PreRestoreCheckType = "ENCODING"
+	PreRestoreCheckTypeDiskSpace  PreRestoreCheckType = "DISK_SPACE"
+)
+
+type PreRestoreCheckStatus string
+
+const (
+	PreRestoreCheckStatusPassed  PreRestoreCheckStatus = "PASSED"
+	PreRestoreCheckStatusWarning PreRestoreCheckStatus = "WARNING"
+	PreRestoreCheckStatusFailed  PreRestoreCheckStatus = "FAILED"
+	// PreRestoreCheckStatusSkipped is set when the check could not run, e.g. the source
+	// database is not reachable anymore. It does not block the restore
+	PreRestoreCheckStatusSkipped PreRestoreCheckStatus = "SKIPPED"
+)
+
+type RestoreThroughputSource string
+
+const (
+	// RestoreThroughputSourceEngineAndStorage is measured from past restores of the same
+	// database type from the same storage type
+	RestoreThroughputSourceEngineAndStorage RestoreThroughputSource = "ENGINE_AND_STORAGE_HISTORY"
+	RestoreThroughputSourceEngine           RestoreThroughputSource = "ENGINE_HISTORY"
+	// RestoreThroughputSourceNode is the network throughput of the restore node, used
+	// before any restore of the database type completed
+	RestoreThroughputSourceNode RestoreThroughputSource = "NODE_THROUGHPUT"
+)
@@ -2,9 +2,15 @@ package restores_core
 
 import (
 	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases/databases/cassandra"
+	"databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/influxdb"
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/mysql"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	"databasus-backend/internal/features/databases/databases/postgresql"
 	"time"
 
@@ -18,10 +24,19 @@ type Restore struct {
 	BackupID uuid.UUID `json:"backupId" gorm:"column:backup_id;type:uuid;not null"`
 	Backup   *backups_core.Backup
 
-	PostgresqlDatabase *postgresql.PostgresqlDatabase `json:"postgresqlDatabase" gorm:"-"`
-	MysqlDatabase      *mysql.MysqlDatabase           `json:"mysqlDatabase"      gorm:"-"`
-	MariadbDatabase    *mariadb.MariadbDatabase       `json:"mariadbDatabase"    gorm:"-"`
-	MongodbDatabase    *mongodb.MongodbDatabase       `json:"mongodbDatabase"    gorm:"-"`
+	PostgresqlDatabase    *postgresql.PostgresqlDatabase       `json:"postgresqlDatabase" gorm:"-"`
+	MysqlDatabase         *mysql.MysqlDatabase                 `json:"mysqlDatabase"      gorm:"-"`
+	MariadbDatabase       *mariadb.MariadbDatabase             `json:"mariadbDatabase"    gorm:"-"`
+	MongodbDatabase       *mongodb.MongodbDatabase             `json:"mongodbDatabase"    gorm:"-"`
+	FilesystemDatabase    *filesystem.FilesystemDatabase       `json:"filesystemDatabase" gorm:"-"`
+	ElasticsearchDatabase *elasticsearch.ElasticsearchDatabase `json:"elasticsearchDatabase" gorm:"-"`
+	InfluxdbDatabase      *influxdb.InfluxdbDatabase           `json:"influxdbDatabase"      gorm:"-"`
+	CassandraDatabase     *cassandra.CassandraDatabase         `json:"cassandraDatabase"     gorm:"-"`
+	CockroachdbDatabase   *cockroachdb.CockroachdbDatabase     `json:"cockroachdbDatabase"   gorm:"-"`
+	Neo4jDatabase         *neo4j.Neo4jDatabase                 `json:"neo4jDatabase"         gorm:"-"`
+
+	// IsMasked restores complete only after masking rules of the database are applied
+	IsMasked bool `json:"isMasked" gorm:"column:is_masked;type:boolean;not null;default:false"`
 
 	FailMessage *string `json:"failMessage" gorm:"column:fail_message"`
 
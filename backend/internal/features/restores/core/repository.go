@@ -15,12 +15,36 @@ func (r *RestoreRepository) Save(restore *Restore) error {
 	if isNew {
 		restore.ID = uuid.New()
 		return db.Create(restore).
-			Omit("Backup", "PostgresqlDatabase", "MysqlDatabase", "MariadbDatabase", "MongodbDatabase").
+			Omit(
+				"Backup",
+				"PostgresqlDatabase",
+				"MysqlDatabase",
+				"MariadbDatabase",
+				"MongodbDatabase",
+				"FilesystemDatabase",
+				"ElasticsearchDatabase",
+				"InfluxdbDatabase",
+				"CassandraDatabase",
+				"CockroachdbDatabase",
+				"Neo4jDatabase",
+			).
 			Error
 	}
 
 	return db.Save(restore).
-		Omit("Backup", "PostgresqlDatabase", "MysqlDatabase", "MariadbDatabase", "MongodbDatabase").
+		Omit(
+			"Backup",
+			"PostgresqlDatabase",
+			"MysqlDatabase",
+			"MariadbDatabase",
+			"MongodbDatabase",
+			"FilesystemDatabase",
+			"ElasticsearchDatabase",
+			"InfluxdbDatabase",
+			"CassandraDatabase",
+			"CockroachdbDatabase",
+			"Neo4jDatabase",
+		).
 		Error
 }
 
@@ -89,3 +113,41 @@ func (r *RestoreRepository) FindInProgressRestoresByDatabaseID(
 func (r *RestoreRepository) DeleteByID(id uuid.UUID) error {
 	return storage.GetDb().Delete(&Restore{}, "id = ?", id).Error
 }
+
+// GetThroughputHistory sums the latest completed restores of the database type. Restores
+// from any storage type are included when storageType is empty
+func (r *RestoreRepository) GetThroughputHistory(
+	databaseType string,
+	storageType string,
+	limit int,
+) (*RestoreThroughputHistory, error) {
+	var history RestoreThroughputHistory
+
+	if err := storage.
+		GetDb().
+		Raw(`
+			SELECT
+				COUNT(*) AS restores_count,
+				COALESCE(SUM(recent.backup_size_mb), 0) AS total_size_mb,
+				COALESCE(SUM(recent.restore_duration_ms), 0) AS total_duration_ms
+			FROM (
+				SELECT b.backup_size_mb, r.restore_duration_ms
+				FROM restores r
+				JOIN backups b ON b.id = r.backup_id
+				JOIN databases d ON d.id = b.database_id
+				JOIN storages s ON s.id = b.storage_id
+				WHERE r.status = ?
+					AND r.restore_duration_ms > 0
+					AND b.backup_size_mb > 0
+					AND d.type = ?
+					AND (? = '' OR s.type = ?)
+				ORDER BY r.created_at DESC
+				LIMIT ?
+			) recent
+		`, RestoreStatusCompleted, databaseType, storageType, storageType, limit).
+		Scan(&history).Error; err != nil {
+		return nil, err
+	}
+
+	return &history, nil
+}
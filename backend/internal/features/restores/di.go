@@ -10,7 +10,9 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/disk"
+	"databasus-backend/internal/features/masking"
 	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/restores/restoring"
 	"databasus-backend/internal/features/restores/usecases"
 	"databasus-backend/internal/features/storages"
 	tasks_cancellation "databasus-backend/internal/features/tasks/cancellation"
@@ -33,11 +35,17 @@ var restoreService = &RestoreService{
 	encryption.GetFieldEncryptor(),
 	disk.GetDiskService(),
 	tasks_cancellation.GetTaskCancelManager(),
+	masking.GetMaskingService(),
+	restoring.GetRestoreNodesRegistry(),
 }
 var restoreController = &RestoreController{
 	restoreService,
 }
 
+func GetRestoreService() *RestoreService {
+	return restoreService
+}
+
 func GetRestoreController() *RestoreController {
 	return restoreController
 }
@@ -0,0 +1,74 @@
+package restores
+
+import (
+	"errors"
+
+	"databasus-backend/internal/features/databases"
+	restores_core "databasus-backend/internal/features/restores/core"
+	users_models "databasus-backend/internal/features/users/models"
+)
+
+var (
+	ErrInsufficientPermissionsToRestoreToProd = errors.New(
+		"only workspace owners and admins can restore into a PROD database",
+	)
+	ErrProdRestoreNotConfirmed = errors.New(
+		"restore into a PROD database must be confirmed with isProdRestoreConfirmed",
+	)
+	ErrRefreshToProdNotAllowed = errors.New("refresh jobs cannot restore into a PROD database")
+)
+
+// checkTargetEnvironment guards restores whose target is on the server of a PROD database.
+// Restores without a user come from refresh jobs, which cannot target PROD at all. Returns
+// the PROD database, nil when the target is not PROD
+func (s *RestoreService) checkTargetEnvironment(
+	user *users_models.User,
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) (*databases.Database, error) {
+	target := &databases.Database{
+		Type:          backupDatabase.Type,
+		Postgresql:    requestDTO.PostgresqlDatabase,
+		Mysql:         requestDTO.MysqlDatabase,
+		Mariadb:       requestDTO.MariadbDatabase,
+		Mongodb:       requestDTO.MongodbDatabase,
+		Filesystem:    requestDTO.FilesystemDatabase,
+		Elasticsearch: requestDTO.ElasticsearchDatabase,
+		Influxdb:      requestDTO.InfluxdbDatabase,
+		Cassandra:     requestDTO.CassandraDatabase,
+		Cockroachdb:   requestDTO.CockroachdbDatabase,
+		Neo4j:         requestDTO.Neo4jDatabase,
+	}
+
+	prodDatabase, err := s.databaseService.FindProdDatabaseByAddress(
+		target.Type,
+		target.GetServerAddress(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if prodDatabase == nil {
+		return nil, nil
+	}
+
+	if user == nil {
+		return nil, ErrRefreshToProdNotAllowed
+	}
+
+	canManageWorkspace, err := s.workspaceService.CanUserManageWorkspace(
+		*prodDatabase.WorkspaceID,
+		user,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageWorkspace {
+		return nil, ErrInsufficientPermissionsToRestoreToProd
+	}
+
+	if !requestDTO.IsProdRestoreConfirmed {
+		return nil, ErrProdRestoreNotConfirmed
+	}
+
+	return prodDatabase, nil
+}
@@ -0,0 +1,54 @@
+package restores_plans
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// restorePlansCheckInterval is short, plans run during outages and every step waits for the
+// check after its dependencies complete
+const restorePlansCheckInterval = 10 * time.Second
+
+type RestorePlanBackgroundService struct {
+	restorePlanService *RestorePlanService
+	logger             *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (s *RestorePlanBackgroundService) Run(ctx context.Context) {
+	wasAlreadyRun := s.hasRun.Load()
+
+	s.runOnce.Do(func() {
+		s.hasRun.Store(true)
+
+		s.logger.Info("Starting restore plan background service")
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(restorePlansCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.restorePlanService.ProcessRestorePlans(time.Now().UTC()); err != nil {
+					s.logger.Error("Failed to process restore plans", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", s))
+	}
+}
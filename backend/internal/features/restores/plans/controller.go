@@ -0,0 +1,157 @@
+package restores_plans
+
+import (
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RestorePlanController struct {
+	restorePlanService *RestorePlanService
+}
+
+func (c *RestorePlanController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/restore-plans/workspace/:workspaceId", c.CreateRestorePlan)
+	router.GET("/restore-plans/workspace/:workspaceId", c.GetRestorePlans)
+	router.GET("/restore-plans/:id", c.GetRestorePlan)
+	router.POST("/restore-plans/:id/cancel", c.CancelRestorePlan)
+}
+
+// CreateRestorePlan
+// @Summary Start a restore plan
+// @Description Restore several databases of the workspace in one plan. Steps without dependsOn start right away,
+// @Description other steps start once every step they depend on completed and are skipped if one of them did not
+// @Tags restore-plans
+// @Accept json
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body RestorePlan true "Restore plan"
+// @Success 200 {object} RestorePlan
+// @Failure 400
+// @Failure 401
+// @Router /restore-plans/workspace/{workspaceId} [post]
+func (c *RestorePlanController) CreateRestorePlan(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var plan RestorePlan
+	if err := ctx.ShouldBindJSON(&plan); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdPlan, err := c.restorePlanService.CreateRestorePlan(user, workspaceID, &plan)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdPlan)
+}
+
+// GetRestorePlans
+// @Summary Get restore plans
+// @Description Get the latest restore plans of a workspace with their steps and progress
+// @Tags restore-plans
+// @Produce json
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {array} RestorePlan
+// @Failure 400
+// @Failure 401
+// @Router /restore-plans/workspace/{workspaceId} [get]
+func (c *RestorePlanController) GetRestorePlans(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	plans, err := c.restorePlanService.GetRestorePlans(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plans)
+}
+
+// GetRestorePlan
+// @Summary Get a restore plan
+// @Description Get a restore plan with the status of each step and combined progress
+// @Tags restore-plans
+// @Produce json
+// @Param id path string true "Restore plan ID"
+// @Success 200 {object} RestorePlan
+// @Failure 400
+// @Failure 401
+// @Router /restore-plans/{id} [get]
+func (c *RestorePlanController) GetRestorePlan(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	planID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid restore plan ID"})
+		return
+	}
+
+	plan, err := c.restorePlanService.GetRestorePlan(user, planID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
+
+// CancelRestorePlan
+// @Summary Cancel a restore plan
+// @Description Skip steps which did not start yet and cancel running restores of the plan
+// @Tags restore-plans
+// @Produce json
+// @Param id path string true "Restore plan ID"
+// @Success 200 {object} RestorePlan
+// @Failure 400
+// @Failure 401
+// @Router /restore-plans/{id}/cancel [post]
+func (c *RestorePlanController) CancelRestorePlan(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	planID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid restore plan ID"})
+		return
+	}
+
+	plan, err := c.restorePlanService.CancelRestorePlan(user, planID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plan)
+}
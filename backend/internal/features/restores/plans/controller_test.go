@@ -0,0 +1,149 @@
+package restores_plans
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/backups/backups"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/databases/databases/postgresql"
+	"databasus-backend/internal/features/notifiers"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+	"databasus-backend/internal/util/tools"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetRestorePlanController(),
+	)
+}
+
+func Test_CreateRestorePlan_WhenStepFails_DependentStepsSkipped(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+	backup := backups.CreateTestBackup(database.ID, storage.ID)
+
+	request := RestorePlan{
+		Name: "Disaster recovery",
+		Steps: []*RestorePlanStep{
+			{
+				Key:      "users",
+				BackupID: backup.ID,
+				Target:   createUnreachableTarget(),
+			},
+			{
+				Key:       "orders",
+				BackupID:  backup.ID,
+				Target:    createUnreachableTarget(),
+				DependsOn: []string{"users"},
+			},
+		},
+	}
+
+	var plan RestorePlan
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/restore-plans/workspace/%s", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&plan,
+	)
+
+	assert.Equal(t, RestorePlanStatusFailed, plan.Status)
+	assert.Len(t, plan.Steps, 2)
+	assert.Equal(t, RestorePlanStepStatusFailed, plan.Steps[0].Status)
+	assert.Equal(t, RestorePlanStepStatusSkipped, plan.Steps[1].Status)
+	assert.Contains(t, *plan.Steps[1].FailMessage, `"users"`)
+	assert.Equal(t, "", plan.Steps[0].Target.PostgresqlDatabase.Password)
+	assert.Equal(t, 100, plan.Progress.Percent)
+	assert.Equal(t, 1, plan.Progress.SkippedSteps)
+
+	var storedPlan RestorePlan
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/restore-plans/%s", plan.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&storedPlan,
+	)
+	assert.Equal(t, RestorePlanStatusFailed, storedPlan.Status)
+	assert.Equal(t, []string{"users"}, storedPlan.Steps[1].DependsOn)
+
+	nonMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/restore-plans/%s", plan.ID.String()),
+		"Bearer "+nonMember.Token,
+		http.StatusBadRequest,
+	)
+}
+
+func Test_CreateRestorePlan_WithBackupOfOtherWorkspace_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+	otherWorkspace := workspaces_testing.CreateTestWorkspace("Other Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(otherWorkspace, router)
+
+	storage := storages.CreateTestStorage(otherWorkspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(otherWorkspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(otherWorkspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+	backup := backups.CreateTestBackup(database.ID, storage.ID)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/restore-plans/workspace/%s", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		RestorePlan{
+			Name: "Disaster recovery",
+			Steps: []*RestorePlanStep{
+				{Key: "users", BackupID: backup.ID, Target: createUnreachableTarget()},
+			},
+		},
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "does not belong to the workspace")
+}
+
+// createUnreachableTarget points to a closed port, so restores fail before they start
+func createUnreachableTarget() *restores_core.RestoreBackupRequest {
+	return &restores_core.RestoreBackupRequest{
+		PostgresqlDatabase: &postgresql.PostgresqlDatabase{
+			Version:  tools.PostgresqlVersion16,
+			Host:     "127.0.0.1",
+			Port:     1,
+			Username: "postgres",
+			Password: "postgres",
+		},
+	}
+}
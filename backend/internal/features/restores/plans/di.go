@@ -0,0 +1,46 @@
+package restores_plans
+
+import (
+	"databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/restores"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+	"sync"
+)
+
+var restorePlanRepository = &RestorePlanRepository{}
+var restorePlanService = &RestorePlanService{
+	restorePlanRepository,
+	backups.GetBackupService(),
+	restores.GetRestoreService(),
+	databases.GetDatabaseService(),
+	users_services.GetUserService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+	sync.Mutex{},
+}
+var restorePlanController = &RestorePlanController{
+	restorePlanService,
+}
+var restorePlanBackgroundService = &RestorePlanBackgroundService{
+	restorePlanService: restorePlanService,
+	logger:             logger.GetLogger(),
+}
+
+func GetRestorePlanService() *RestorePlanService {
+	return restorePlanService
+}
+
+func GetRestorePlanController() *RestorePlanController {
+	return restorePlanController
+}
+
+func GetRestorePlanBackgroundService() *RestorePlanBackgroundService {
+	return restorePlanBackgroundService
+}
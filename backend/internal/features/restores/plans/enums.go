@@ -0,0 +1,28 @@
+package restores_plans
+
+type RestorePlanStatus string
+
+const (
+	RestorePlanStatusInProgress RestorePlanStatus = "IN_PROGRESS"
+	RestorePlanStatusCompleted  RestorePlanStatus = "COMPLETED"
+	RestorePlanStatusFailed     RestorePlanStatus = "FAILED"
+	RestorePlanStatusCanceled   RestorePlanStatus = "CANCELED"
+)
+
+type RestorePlanStepStatus string
+
+const (
+	RestorePlanStepStatusPending    RestorePlanStepStatus = "PENDING"
+	RestorePlanStepStatusInProgress RestorePlanStepStatus = "IN_PROGRESS"
+	RestorePlanStepStatusCompleted  RestorePlanStepStatus = "COMPLETED"
+	RestorePlanStepStatusFailed     RestorePlanStepStatus = "FAILED"
+	// RestorePlanStepStatusSkipped is set for steps whose dependency did not complete and
+	// for pending steps of canceled plans
+	RestorePlanStepStatusSkipped RestorePlanStepStatus = "SKIPPED"
+)
+
+func (s RestorePlanStepStatus) IsFinished() bool {
+	return s == RestorePlanStepStatusCompleted ||
+		s == RestorePlanStepStatusFailed ||
+		s == RestorePlanStepStatusSkipped
+}
@@ -0,0 +1,261 @@
+package restores_plans
+
+import (
+	restores_core "databasus-backend/internal/features/restores/core"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxRestorePlanSteps = 50
+
+// RestorePlan restores several databases at once, e.g. every database of an application
+// during disaster recovery. Steps start as soon as the steps they depend on complete
+type RestorePlan struct {
+	ID          uuid.UUID         `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID         `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Name        string            `json:"name"        gorm:"column:name;type:text;not null"`
+	Status      RestorePlanStatus `json:"status"      gorm:"column:status;type:text;not null"`
+
+	// MaxParallelRestores limits steps restoring at the same time, 0 starts every step whose
+	// dependencies completed
+	MaxParallelRestores int `json:"maxParallelRestores" gorm:"column:max_parallel_restores;not null"`
+
+	// CreatedByUserID is the user restores of the plan are started for, so steps starting
+	// later are checked against the permissions of that user
+	CreatedByUserID uuid.UUID `json:"createdByUserId" gorm:"column:created_by_user_id;type:uuid;not null"`
+
+	Steps    []*RestorePlanStep   `json:"steps"              gorm:"foreignKey:PlanID"`
+	Progress *RestorePlanProgress `json:"progress,omitempty" gorm:"-"`
+
+	CreatedAt  time.Time  `json:"createdAt"  gorm:"column:created_at"`
+	FinishedAt *time.Time `json:"finishedAt" gorm:"column:finished_at"`
+}
+
+func (RestorePlan) TableName() string {
+	return "restore_plans"
+}
+
+// RestorePlanStep restores one backup into its target. Key names the step for DependsOn of
+// other steps of the plan
+type RestorePlanStep struct {
+	ID       uuid.UUID `json:"id"       gorm:"column:id;type:uuid;primaryKey"`
+	PlanID   uuid.UUID `json:"planId"   gorm:"column:plan_id;type:uuid;not null"`
+	Position int       `json:"position" gorm:"column:position;not null"`
+	Key      string    `json:"key"      gorm:"column:key;type:text;not null"`
+	BackupID uuid.UUID `json:"backupId" gorm:"column:backup_id;type:uuid;not null"`
+
+	// Target is restored like a manual restore. Passwords are encrypted with the ID of the
+	// database of the backup, restores decrypt them with it
+	Target     *restores_core.RestoreBackupRequest `json:"target" gorm:"-"`
+	TargetJSON string                              `json:"-"      gorm:"column:target;type:text;not null"`
+
+	DependsOn       []string `json:"dependsOn" gorm:"-"`
+	DependsOnString string   `json:"-"         gorm:"column:depends_on;type:text;not null"`
+
+	Status      RestorePlanStepStatus `json:"status"      gorm:"column:status;type:text;not null"`
+	RestoreID   *uuid.UUID            `json:"restoreId"   gorm:"column:restore_id;type:uuid"`
+	FailMessage *string               `json:"failMessage" gorm:"column:fail_message"`
+
+	StartedAt  *time.Time `json:"startedAt"  gorm:"column:started_at"`
+	FinishedAt *time.Time `json:"finishedAt" gorm:"column:finished_at"`
+}
+
+func (RestorePlanStep) TableName() string {
+	return "restore_plan_steps"
+}
+
+// RestorePlanProgress counts steps of the plan by status. Percent is the share of finished
+// steps, whether they completed or not
+type RestorePlanProgress struct {
+	TotalSteps      int `json:"totalSteps"`
+	PendingSteps    int `json:"pendingSteps"`
+	InProgressSteps int `json:"inProgressSteps"`
+	CompletedSteps  int `json:"completedSteps"`
+	FailedSteps     int `json:"failedSteps"`
+	SkippedSteps    int `json:"skippedSteps"`
+	Percent         int `json:"percent"`
+}
+
+func (s *RestorePlanStep) BeforeSave(tx *gorm.DB) error {
+	if s.Target != nil {
+		targetJSON, err := json.Marshal(s.Target)
+		if err != nil {
+			return err
+		}
+
+		s.TargetJSON = string(targetJSON)
+	}
+
+	s.DependsOnString = strings.Join(s.DependsOn, ",")
+
+	return nil
+}
+
+func (s *RestorePlanStep) AfterFind(tx *gorm.DB) error {
+	if s.TargetJSON != "" {
+		s.Target = &restores_core.RestoreBackupRequest{}
+		if err := json.Unmarshal([]byte(s.TargetJSON), s.Target); err != nil {
+			return err
+		}
+	}
+
+	s.DependsOn = []string{}
+	if s.DependsOnString != "" {
+		s.DependsOn = strings.Split(s.DependsOnString, ",")
+	}
+
+	return nil
+}
+
+func (s *RestorePlanStep) HideSensitiveData() {
+	if s.Target == nil {
+		return
+	}
+
+	s.Target.PostgresqlDatabase.HideSensitiveData()
+	s.Target.MysqlDatabase.HideSensitiveData()
+	s.Target.MariadbDatabase.HideSensitiveData()
+	s.Target.MongodbDatabase.HideSensitiveData()
+	s.Target.ElasticsearchDatabase.HideSensitiveData()
+	s.Target.InfluxdbDatabase.HideSensitiveData()
+	s.Target.CassandraDatabase.HideSensitiveData()
+	s.Target.CockroachdbDatabase.HideSensitiveData()
+	s.Target.Neo4jDatabase.HideSensitiveData()
+}
+
+// Validate checks the plan as requested, keys must be unique and dependencies must not form
+// a cycle, otherwise steps would wait forever
+func (p *RestorePlan) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if p.MaxParallelRestores < 0 {
+		return errors.New("max parallel restores must not be negative")
+	}
+
+	if len(p.Steps) == 0 {
+		return errors.New("restore plan must have at least one step")
+	}
+
+	if len(p.Steps) > maxRestorePlanSteps {
+		return fmt.Errorf("restore plan can have at most %d steps", maxRestorePlanSteps)
+	}
+
+	keys := make([]string, 0, len(p.Steps))
+	for _, step := range p.Steps {
+		step.Key = strings.TrimSpace(step.Key)
+		if step.Key == "" {
+			return errors.New("step key is required")
+		}
+		if strings.Contains(step.Key, ",") {
+			return fmt.Errorf("step key %q must not contain commas", step.Key)
+		}
+		if slices.Contains(keys, step.Key) {
+			return fmt.Errorf("step key %q is used more than once", step.Key)
+		}
+
+		if step.Target == nil {
+			return fmt.Errorf("step %q has no restore target", step.Key)
+		}
+
+		keys = append(keys, step.Key)
+	}
+
+	for _, step := range p.Steps {
+		for _, dependency := range step.DependsOn {
+			if dependency == step.Key {
+				return fmt.Errorf("step %q depends on itself", step.Key)
+			}
+			if !slices.Contains(keys, dependency) {
+				return fmt.Errorf("step %q depends on unknown step %q", step.Key, dependency)
+			}
+		}
+	}
+
+	if p.hasDependencyCycle() {
+		return errors.New("dependencies of steps form a cycle")
+	}
+
+	return nil
+}
+
+func (p *RestorePlan) FindStepByKey(key string) *RestorePlanStep {
+	for _, step := range p.Steps {
+		if step.Key == key {
+			return step
+		}
+	}
+
+	return nil
+}
+
+func (p *RestorePlan) GetProgress() *RestorePlanProgress {
+	progress := &RestorePlanProgress{TotalSteps: len(p.Steps)}
+
+	for _, step := range p.Steps {
+		switch step.Status {
+		case RestorePlanStepStatusPending:
+			progress.PendingSteps++
+		case RestorePlanStepStatusInProgress:
+			progress.InProgressSteps++
+		case RestorePlanStepStatusCompleted:
+			progress.CompletedSteps++
+		case RestorePlanStepStatusFailed:
+			progress.FailedSteps++
+		case RestorePlanStepStatusSkipped:
+			progress.SkippedSteps++
+		}
+	}
+
+	if progress.TotalSteps > 0 {
+		finishedSteps := progress.CompletedSteps + progress.FailedSteps + progress.SkippedSteps
+		progress.Percent = finishedSteps * 100 / progress.TotalSteps
+	}
+
+	return progress
+}
+
+// hasDependencyCycle removes steps without remaining dependencies until none are left, the
+// steps which are never removed are on a cycle
+func (p *RestorePlan) hasDependencyCycle() bool {
+	remainingDependencies := make(map[string]int, len(p.Steps))
+	dependents := make(map[string][]string, len(p.Steps))
+
+	for _, step := range p.Steps {
+		remainingDependencies[step.Key] = len(step.DependsOn)
+		for _, dependency := range step.DependsOn {
+			dependents[dependency] = append(dependents[dependency], step.Key)
+		}
+	}
+
+	ready := []string{}
+	for key, count := range remainingDependencies {
+		if count == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	removedSteps := 0
+	for len(ready) > 0 {
+		key := ready[0]
+		ready = ready[1:]
+		removedSteps++
+
+		for _, dependent := range dependents[key] {
+			remainingDependencies[dependent]--
+			if remainingDependencies[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	return removedSteps != len(p.Steps)
+}
@@ -0,0 +1,102 @@
+package restores_plans
+
+import (
+	"testing"
+
+	restores_core "databasus-backend/internal/features/restores/core"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validate_WithDependencyErrors_ReturnsError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		steps         []*RestorePlanStep
+		expectedError string
+	}{
+		{
+			name: "duplicated key",
+			steps: []*RestorePlanStep{
+				createTestStep("users", nil),
+				createTestStep("users", nil),
+			},
+			expectedError: "used more than once",
+		},
+		{
+			name: "unknown dependency",
+			steps: []*RestorePlanStep{
+				createTestStep("orders", []string{"users"}),
+			},
+			expectedError: "unknown step",
+		},
+		{
+			name: "self dependency",
+			steps: []*RestorePlanStep{
+				createTestStep("users", []string{"users"}),
+			},
+			expectedError: "depends on itself",
+		},
+		{
+			name: "cycle",
+			steps: []*RestorePlanStep{
+				createTestStep("users", nil),
+				createTestStep("orders", []string{"users", "payments"}),
+				createTestStep("payments", []string{"orders"}),
+			},
+			expectedError: "cycle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &RestorePlan{Name: "Disaster recovery", Steps: tc.steps}
+
+			err := plan.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
+
+func Test_Validate_WithDependencyChain_Accepted(t *testing.T) {
+	plan := &RestorePlan{
+		Name: "Disaster recovery",
+		Steps: []*RestorePlanStep{
+			createTestStep("orders", []string{"users", "payments"}),
+			createTestStep(" users ", nil),
+			createTestStep("payments", []string{"users"}),
+		},
+	}
+
+	assert.NoError(t, plan.Validate())
+	assert.Equal(t, "users", plan.Steps[1].Key)
+}
+
+func Test_GetProgress_CountsStepsByStatus(t *testing.T) {
+	plan := &RestorePlan{
+		Steps: []*RestorePlanStep{
+			{Status: RestorePlanStepStatusCompleted},
+			{Status: RestorePlanStepStatusFailed},
+			{Status: RestorePlanStepStatusInProgress},
+			{Status: RestorePlanStepStatusPending},
+		},
+	}
+
+	progress := plan.GetProgress()
+	assert.Equal(t, 4, progress.TotalSteps)
+	assert.Equal(t, 1, progress.CompletedSteps)
+	assert.Equal(t, 1, progress.FailedSteps)
+	assert.Equal(t, 1, progress.InProgressSteps)
+	assert.Equal(t, 1, progress.PendingSteps)
+	assert.Equal(t, 50, progress.Percent)
+}
+
+func createTestStep(key string, dependsOn []string) *RestorePlanStep {
+	return &RestorePlanStep{
+		Key:       key,
+		BackupID:  uuid.New(),
+		Target:    &restores_core.RestoreBackupRequest{},
+		DependsOn: dependsOn,
+	}
+}
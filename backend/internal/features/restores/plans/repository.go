@@ -0,0 +1,110 @@
+package restores_plans
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RestorePlanRepository struct{}
+
+// CreatePlan saves the plan with its steps in one transaction
+func (r *RestorePlanRepository) CreatePlan(plan *RestorePlan) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Steps").Create(plan).Error; err != nil {
+			return err
+		}
+
+		for _, step := range plan.Steps {
+			if err := tx.Create(step).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *RestorePlanRepository) SavePlan(plan *RestorePlan) error {
+	return storage.GetDb().Omit("Steps").Save(plan).Error
+}
+
+func (r *RestorePlanRepository) SaveStep(step *RestorePlanStep) error {
+	return storage.GetDb().Save(step).Error
+}
+
+func (r *RestorePlanRepository) FindPlanByID(id uuid.UUID) (*RestorePlan, error) {
+	var plan RestorePlan
+
+	if err := storage.
+		GetDb().
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("id = ?", id).
+		First(&plan).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+func (r *RestorePlanRepository) FindPlansByWorkspaceID(
+	workspaceID uuid.UUID,
+	limit int,
+) ([]*RestorePlan, error) {
+	var plans []*RestorePlan
+
+	if err := storage.
+		GetDb().
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&plans).Error; err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (r *RestorePlanRepository) FindPlansByStatus(
+	status RestorePlanStatus,
+) ([]*RestorePlan, error) {
+	var plans []*RestorePlan
+
+	if err := storage.
+		GetDb().
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("status = ?", status).
+		Find(&plans).Error; err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (r *RestorePlanRepository) FindStepsByStatus(
+	status RestorePlanStepStatus,
+) ([]*RestorePlanStep, error) {
+	var steps []*RestorePlanStep
+
+	if err := storage.
+		GetDb().
+		Where("status = ?", status).
+		Find(&steps).Error; err != nil {
+		return nil, err
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,516 @@
+package restores_plans
+
+import (
+	"databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/restores"
+	restores_core "databasus-backend/internal/features/restores/core"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const restorePlansLimit = 50
+
+type RestorePlanService struct {
+	restorePlanRepository *RestorePlanRepository
+	backupService         *backups.BackupService
+	restoreService        *restores.RestoreService
+	databaseService       *databases.DatabaseService
+	userService           *users_services.UserService
+	workspaceService      *workspaces_services.WorkspaceService
+	auditLogService       *audit_logs.AuditLogService
+	fieldEncryptor        encryption.FieldEncryptor
+	logger                *slog.Logger
+
+	// mu keeps the background service from starting steps of a plan while the plan is
+	// created or canceled
+	mu sync.Mutex
+}
+
+// CreateRestorePlan saves the plan and starts the steps without dependencies right away
+func (s *RestorePlanService) CreateRestorePlan(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	plan *RestorePlan,
+) (*RestorePlan, error) {
+	if err := s.checkCanManagePlans(user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	plan.ID = uuid.New()
+	plan.WorkspaceID = workspaceID
+	plan.Status = RestorePlanStatusInProgress
+	plan.CreatedByUserID = user.ID
+	plan.CreatedAt = now
+	plan.FinishedAt = nil
+
+	for i, step := range plan.Steps {
+		if err := s.prepareStep(workspaceID, step); err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Key, err)
+		}
+
+		step.ID = uuid.New()
+		step.PlanID = plan.ID
+		step.Position = i
+		step.Status = RestorePlanStepStatusPending
+		step.RestoreID = nil
+		step.FailMessage = nil
+		step.StartedAt = nil
+		step.FinishedAt = nil
+	}
+
+	if err := s.restorePlanRepository.CreatePlan(plan); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Restore plan '%s' started with %d steps", plan.Name, len(plan.Steps)),
+		&user.ID,
+		&workspaceID,
+	)
+
+	if err := s.advancePlan(plan, user, now); err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(plan), nil
+}
+
+func (s *RestorePlanService) GetRestorePlans(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*RestorePlan, error) {
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view restore plans")
+	}
+
+	plans, err := s.restorePlanRepository.FindPlansByWorkspaceID(workspaceID, restorePlansLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, plan := range plans {
+		plans[i] = s.toResponse(plan)
+	}
+
+	return plans, nil
+}
+
+func (s *RestorePlanService) GetRestorePlan(
+	user *users_models.User,
+	planID uuid.UUID,
+) (*RestorePlan, error) {
+	plan, err := s.restorePlanRepository.FindPlanByID(planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, errors.New("restore plan not found")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(plan.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view restore plans")
+	}
+
+	return s.toResponse(plan), nil
+}
+
+// CancelRestorePlan skips steps which did not start and cancels running restores
+func (s *RestorePlanService) CancelRestorePlan(
+	user *users_models.User,
+	planID uuid.UUID,
+) (*RestorePlan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, err := s.restorePlanRepository.FindPlanByID(planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, errors.New("restore plan not found")
+	}
+
+	if err := s.checkCanManagePlans(user, plan.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	if plan.Status != RestorePlanStatusInProgress {
+		return nil, errors.New("restore plan is not in progress")
+	}
+
+	now := time.Now().UTC()
+
+	for _, step := range plan.Steps {
+		switch step.Status {
+		case RestorePlanStepStatusPending:
+			s.finishStep(step, RestorePlanStepStatusSkipped, "restore plan was canceled", now)
+		case RestorePlanStepStatusInProgress:
+			if step.RestoreID == nil {
+				continue
+			}
+
+			if err := s.restoreService.CancelRestore(user, *step.RestoreID); err != nil {
+				s.logger.Error(
+					"failed to cancel restore of restore plan",
+					"planId", plan.ID,
+					"restoreId", step.RestoreID,
+					"error", err,
+				)
+			}
+		}
+	}
+
+	plan.Status = RestorePlanStatusCanceled
+	plan.FinishedAt = &now
+	if err := s.restorePlanRepository.SavePlan(plan); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Restore plan '%s' canceled", plan.Name),
+		&user.ID,
+		&plan.WorkspaceID,
+	)
+
+	return s.toResponse(plan), nil
+}
+
+// ProcessRestorePlans finishes steps whose restores are done and starts steps whose
+// dependencies completed
+func (s *RestorePlanService) ProcessRestorePlans(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.finishSteps(now); err != nil {
+		return err
+	}
+
+	plans, err := s.restorePlanRepository.FindPlansByStatus(RestorePlanStatusInProgress)
+	if err != nil {
+		return err
+	}
+
+	for _, plan := range plans {
+		creator := s.getActiveCreator(plan)
+
+		if err := s.advancePlan(plan, creator, now); err != nil {
+			s.logger.Error("failed to advance restore plan", "planId", plan.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// advancePlan skips steps whose dependencies did not complete and starts steps whose
+// dependencies did, up to the parallel limit. The plan finishes once every step finished
+func (s *RestorePlanService) advancePlan(
+	plan *RestorePlan,
+	creator *users_models.User,
+	now time.Time,
+) error {
+	runningSteps := 0
+	for _, step := range plan.Steps {
+		if step.Status == RestorePlanStepStatusInProgress {
+			runningSteps++
+		}
+	}
+
+	// Skipping a step may unblock skipping of steps listed before it, so repeat until the
+	// plan does not change
+	isChanged := true
+	for isChanged {
+		isChanged = false
+
+		for _, step := range plan.Steps {
+			if step.Status != RestorePlanStepStatusPending {
+				continue
+			}
+
+			isReady, failedDependency := getDependenciesState(plan, step)
+			if failedDependency != "" {
+				s.finishStep(
+					step,
+					RestorePlanStepStatusSkipped,
+					fmt.Sprintf("step %q did not complete", failedDependency),
+					now,
+				)
+				isChanged = true
+				continue
+			}
+
+			if !isReady {
+				continue
+			}
+
+			if plan.MaxParallelRestores > 0 && runningSteps >= plan.MaxParallelRestores {
+				continue
+			}
+
+			s.startStep(step, creator, now)
+			isChanged = true
+			if step.Status == RestorePlanStepStatusInProgress {
+				runningSteps++
+			}
+		}
+	}
+
+	for _, step := range plan.Steps {
+		if !step.Status.IsFinished() {
+			return nil
+		}
+	}
+
+	plan.Status = RestorePlanStatusCompleted
+	for _, step := range plan.Steps {
+		if step.Status != RestorePlanStepStatusCompleted {
+			plan.Status = RestorePlanStatusFailed
+			break
+		}
+	}
+	plan.FinishedAt = &now
+
+	return s.restorePlanRepository.SavePlan(plan)
+}
+
+func (s *RestorePlanService) startStep(
+	step *RestorePlanStep,
+	creator *users_models.User,
+	now time.Time,
+) {
+	if creator == nil {
+		s.finishStep(
+			step,
+			RestorePlanStepStatusFailed,
+			"creator of the restore plan is not active anymore",
+			now,
+		)
+		return
+	}
+
+	restore, err := s.restoreService.StartPlanRestore(creator, step.BackupID, *step.Target)
+	if err != nil {
+		s.finishStep(step, RestorePlanStepStatusFailed, err.Error(), now)
+		return
+	}
+
+	step.Status = RestorePlanStepStatusInProgress
+	step.RestoreID = &restore.ID
+	step.StartedAt = &now
+
+	if err := s.restorePlanRepository.SaveStep(step); err != nil {
+		s.logger.Error("failed to save restore plan step", "stepId", step.ID, "error", err)
+	}
+}
+
+func (s *RestorePlanService) finishSteps(now time.Time) error {
+	steps, err := s.restorePlanRepository.FindStepsByStatus(RestorePlanStepStatusInProgress)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		if step.RestoreID == nil {
+			s.finishStep(step, RestorePlanStepStatusFailed, "restore of the step was removed", now)
+			continue
+		}
+
+		restore, err := s.restoreService.GetRestoreByID(*step.RestoreID)
+		if err != nil {
+			s.finishStep(step, RestorePlanStepStatusFailed, "restore of the step was removed", now)
+			continue
+		}
+
+		switch restore.Status {
+		case restores_core.RestoreStatusInProgress:
+			continue
+		case restores_core.RestoreStatusCompleted:
+			s.finishStep(step, RestorePlanStepStatusCompleted, "", now)
+		case restores_core.RestoreStatusCanceled:
+			s.finishStep(step, RestorePlanStepStatusFailed, "restore of the step was canceled", now)
+		default:
+			failMessage := "restore of the step failed"
+			if restore.FailMessage != nil {
+				failMessage = *restore.FailMessage
+			}
+
+			s.finishStep(step, RestorePlanStepStatusFailed, failMessage, now)
+		}
+	}
+
+	return nil
+}
+
+func (s *RestorePlanService) finishStep(
+	step *RestorePlanStep,
+	status RestorePlanStepStatus,
+	failMessage string,
+	now time.Time,
+) {
+	step.Status = status
+	step.FinishedAt = &now
+	if failMessage != "" {
+		step.FailMessage = &failMessage
+	}
+
+	if err := s.restorePlanRepository.SaveStep(step); err != nil {
+		s.logger.Error("failed to save restore plan step", "stepId", step.ID, "error", err)
+	}
+}
+
+// prepareStep checks the backup belongs to the workspace of the plan and encrypts passwords
+// of the target, as targets are kept until the step starts
+func (s *RestorePlanService) prepareStep(workspaceID uuid.UUID, step *RestorePlanStep) error {
+	backup, err := s.backupService.GetBackup(step.BackupID)
+	if err != nil {
+		return errors.New("backup not found")
+	}
+
+	if backup.Status != backups_core.BackupStatusCompleted {
+		return errors.New("only completed backups can be restored")
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	if database.WorkspaceID == nil || *database.WorkspaceID != workspaceID {
+		return errors.New("backup does not belong to the workspace of the restore plan")
+	}
+
+	return encryptTarget(step.Target, database.ID, s.fieldEncryptor)
+}
+
+func (s *RestorePlanService) getActiveCreator(plan *RestorePlan) *users_models.User {
+	creator, err := s.userService.GetUserByID(plan.CreatedByUserID)
+	if err != nil || creator == nil || !creator.IsActiveUser() {
+		return nil
+	}
+
+	return creator
+}
+
+func (s *RestorePlanService) checkCanManagePlans(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to run restore plans")
+	}
+
+	return nil
+}
+
+func (s *RestorePlanService) toResponse(plan *RestorePlan) *RestorePlan {
+	for _, step := range plan.Steps {
+		step.HideSensitiveData()
+	}
+
+	plan.Progress = plan.GetProgress()
+
+	return plan
+}
+
+// getDependenciesState reports whether every dependency of the step completed, or the key of
+// a dependency which finished without completing
+func getDependenciesState(plan *RestorePlan, step *RestorePlanStep) (bool, string) {
+	isReady := true
+
+	for _, key := range step.DependsOn {
+		dependency := plan.FindStepByKey(key)
+		if dependency == nil {
+			return false, key
+		}
+
+		switch dependency.Status {
+		case RestorePlanStepStatusCompleted:
+			continue
+		case RestorePlanStepStatusFailed, RestorePlanStepStatusSkipped:
+			return false, key
+		default:
+			isReady = false
+		}
+	}
+
+	return isReady, ""
+}
+
+func encryptTarget(
+	target *restores_core.RestoreBackupRequest,
+	databaseID uuid.UUID,
+	fieldEncryptor encryption.FieldEncryptor,
+) error {
+	type sensitiveFieldsEncryptor interface {
+		EncryptSensitiveFields(uuid.UUID, encryption.FieldEncryptor) error
+	}
+
+	encryptors := []sensitiveFieldsEncryptor{}
+	if target.PostgresqlDatabase != nil {
+		encryptors = append(encryptors, target.PostgresqlDatabase)
+	}
+	if target.MysqlDatabase != nil {
+		encryptors = append(encryptors, target.MysqlDatabase)
+	}
+	if target.MariadbDatabase != nil {
+		encryptors = append(encryptors, target.MariadbDatabase)
+	}
+	if target.MongodbDatabase != nil {
+		encryptors = append(encryptors, target.MongodbDatabase)
+	}
+	if target.ElasticsearchDatabase != nil {
+		encryptors = append(encryptors, target.ElasticsearchDatabase)
+	}
+	if target.InfluxdbDatabase != nil {
+		encryptors = append(encryptors, target.InfluxdbDatabase)
+	}
+	if target.CassandraDatabase != nil {
+		encryptors = append(encryptors, target.CassandraDatabase)
+	}
+	if target.CockroachdbDatabase != nil {
+		encryptors = append(encryptors, target.CockroachdbDatabase)
+	}
+	if target.Neo4jDatabase != nil {
+		encryptors = append(encryptors, target.Neo4jDatabase)
+	}
+
+	for _, encryptor := range encryptors {
+		if err := encryptor.EncryptSensitiveFields(databaseID, fieldEncryptor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
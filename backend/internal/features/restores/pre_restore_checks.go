@@ -0,0 +1,374 @@
+package restores
+
+import (
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	restores_core "databasus-backend/internal/features/restores/core"
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/tools"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CheckRestoreWithAuth runs the pre-restore checks of the request without starting the
+// restore, so the report can be reviewed and overrides chosen first
+func (s *RestoreService) CheckRestoreWithAuth(
+	user *users_models.User,
+	backupID uuid.UUID,
+	requestDTO restores_core.RestoreBackupRequest,
+) (*restores_core.PreRestoreReport, error) {
+	backup, backupDatabase, err := s.getBackupToRestore(user, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateRestoreTarget(backupDatabase, requestDTO); err != nil {
+		return nil, err
+	}
+
+	return s.runPreRestoreChecks(backup, backupDatabase, requestDTO), nil
+}
+
+func (s *RestoreService) getBackupToRestore(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*backups_core.Backup, *databases.Database, error) {
+	backup, err := s.backupService.GetBackup(backupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, nil, errors.New("cannot restore backup for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(
+		*database.WorkspaceID,
+		user,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canAccess {
+		return nil, nil, errors.New("insufficient permissions to restore this backup")
+	}
+
+	backupDatabase, err := s.databaseService.GetDatabase(user, backup.DatabaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return backup, backupDatabase, nil
+}
+
+// runPreRestoreChecks expects versions of the target populated by validateRestoreTarget.
+// Checks not applicable to the database type are left out of the report
+func (s *RestoreService) runPreRestoreChecks(
+	backup *backups_core.Backup,
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) *restores_core.PreRestoreReport {
+	report := restores_core.NewPreRestoreReport()
+
+	s.checkVersion(report, backupDatabase, requestDTO)
+
+	if backupDatabase.Type == databases.DatabaseTypePostgres {
+		s.checkPostgresqlTarget(report, backupDatabase, requestDTO)
+	}
+
+	s.checkDiskSpace(report, backup, backupDatabase, requestDTO)
+
+	return report
+}
+
+func (s *RestoreService) checkVersion(
+	report *restores_core.PreRestoreReport,
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) {
+	var isBackupVersionHigher bool
+	var backupVersion, targetVersion, example string
+
+	switch backupDatabase.Type {
+	case databases.DatabaseTypePostgres:
+		backupVersion = string(backupDatabase.Postgresql.Version)
+		targetVersion = string(requestDTO.PostgresqlDatabase.Version)
+		isBackupVersionHigher = tools.IsBackupDbVersionHigherThanRestoreDbVersion(
+			backupDatabase.Postgresql.Version,
+			requestDTO.PostgresqlDatabase.Version,
+		)
+		example = "you can restore PG 15 backup to PG 15, 16 or higher. But cannot restore to 14 and lower"
+	case databases.DatabaseTypeMysql:
+		backupVersion = string(backupDatabase.Mysql.Version)
+		targetVersion = string(requestDTO.MysqlDatabase.Version)
+		isBackupVersionHigher = tools.IsMysqlBackupVersionHigherThanRestoreVersion(
+			backupDatabase.Mysql.Version,
+			requestDTO.MysqlDatabase.Version,
+		)
+		example = "you can restore MySQL 8.0 backup to MySQL 8.0, 8.4 or higher. But cannot restore to 5.7"
+	case databases.DatabaseTypeMariadb:
+		backupVersion = string(backupDatabase.Mariadb.Version)
+		targetVersion = string(requestDTO.MariadbDatabase.Version)
+		isBackupVersionHigher = tools.IsMariadbBackupVersionHigherThanRestoreVersion(
+			backupDatabase.Mariadb.Version,
+			requestDTO.MariadbDatabase.Version,
+		)
+		example = "you can restore MariaDB 10.11 backup to MariaDB 10.11, 11.4 or higher. But cannot restore to 10.6"
+	case databases.DatabaseTypeMongodb:
+		backupVersion = string(backupDatabase.Mongodb.Version)
+		targetVersion = string(requestDTO.MongodbDatabase.Version)
+		isBackupVersionHigher = tools.IsMongodbBackupVersionHigherThanRestoreVersion(
+			backupDatabase.Mongodb.Version,
+			requestDTO.MongodbDatabase.Version,
+		)
+		example = "you can restore MongoDB 6.0 backup to MongoDB 6.0, 7.0 or higher. But cannot restore to 5.0"
+	default:
+		return
+	}
+
+	if isBackupVersionHigher {
+		report.Add(
+			restores_core.PreRestoreCheckTypeVersion,
+			restores_core.PreRestoreCheckStatusFailed,
+			fmt.Sprintf(
+				"backup database version %s is higher than restore database version %s. "+
+					"Should be restored to the same version as the backup database or higher. "+
+					"For example, %s",
+				backupVersion,
+				targetVersion,
+				example,
+			),
+			requestDTO.OverrideChecks,
+		)
+		return
+	}
+
+	report.Add(
+		restores_core.PreRestoreCheckTypeVersion,
+		restores_core.PreRestoreCheckStatusPassed,
+		fmt.Sprintf("backup version %s, restore version %s", backupVersion, targetVersion),
+		requestDTO.OverrideChecks,
+	)
+}
+
+// checkPostgresqlTarget compares extensions and the encoding of the source database with
+// the target. The source is inspected as it is now, a source which is not reachable
+// anymore skips both checks
+func (s *RestoreService) checkPostgresqlTarget(
+	report *restores_core.PreRestoreReport,
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) {
+	source := backupDatabase.Postgresql
+	target := requestDTO.PostgresqlDatabase
+
+	if source.IsTimescaledb() {
+		if err := target.CheckTimescaledbRestoreTarget(
+			s.logger,
+			s.fieldEncryptor,
+			backupDatabase.ID,
+			source.TimescaledbVersion,
+		); err != nil {
+			report.Add(
+				restores_core.PreRestoreCheckTypeExtensions,
+				restores_core.PreRestoreCheckStatusFailed,
+				err.Error(),
+				requestDTO.OverrideChecks,
+			)
+			return
+		}
+	}
+
+	requirements, err := source.GetRestoreRequirements(
+		s.logger,
+		s.fieldEncryptor,
+		backupDatabase.ID,
+	)
+	if err != nil {
+		s.addSkippedPostgresqlChecks(report, "source database", err, requestDTO)
+		return
+	}
+
+	targetInfo, err := target.InspectRestoreTarget(
+		s.logger,
+		s.fieldEncryptor,
+		backupDatabase.ID,
+		requirements,
+	)
+	if err != nil {
+		s.addSkippedPostgresqlChecks(report, "target database", err, requestDTO)
+		return
+	}
+
+	switch {
+	case target.IsExcludeExtensions:
+		report.Add(
+			restores_core.PreRestoreCheckTypeExtensions,
+			restores_core.PreRestoreCheckStatusWarning,
+			"extensions are excluded from the restore, objects depending on them may fail",
+			requestDTO.OverrideChecks,
+		)
+	case len(targetInfo.MissingExtensions) > 0:
+		report.Add(
+			restores_core.PreRestoreCheckTypeExtensions,
+			restores_core.PreRestoreCheckStatusFailed,
+			fmt.Sprintf(
+				"extensions %s are not available on the target server",
+				strings.Join(targetInfo.MissingExtensions, ", "),
+			),
+			requestDTO.OverrideChecks,
+		)
+	default:
+		report.Add(
+			restores_core.PreRestoreCheckTypeExtensions,
+			restores_core.PreRestoreCheckStatusPassed,
+			fmt.Sprintf("%d extensions are available", len(requirements.Extensions)),
+			requestDTO.OverrideChecks,
+		)
+	}
+
+	sourceEncoding := requirements.Encoding
+	targetEncoding := targetInfo.Encoding
+
+	switch {
+	case sourceEncoding.Encoding != targetEncoding.Encoding:
+		report.Add(
+			restores_core.PreRestoreCheckTypeEncoding,
+			restores_core.PreRestoreCheckStatusFailed,
+			fmt.Sprintf(
+				"source encoding is %s, target database encoding is %s",
+				sourceEncoding.Encoding,
+				targetEncoding.Encoding,
+			),
+			requestDTO.OverrideChecks,
+		)
+	case sourceEncoding.Collate != targetEncoding.Collate ||
+		sourceEncoding.Ctype != targetEncoding.Ctype:
+		// data loads fine, but text indexes and ordering follow the target locale
+		report.Add(
+			restores_core.PreRestoreCheckTypeEncoding,
+			restores_core.PreRestoreCheckStatusWarning,
+			fmt.Sprintf(
+				"source locale is %s/%s, target database locale is %s/%s",
+				sourceEncoding.Collate,
+				sourceEncoding.Ctype,
+				targetEncoding.Collate,
+				targetEncoding.Ctype,
+			),
+			requestDTO.OverrideChecks,
+		)
+	default:
+		report.Add(
+			restores_core.PreRestoreCheckTypeEncoding,
+			restores_core.PreRestoreCheckStatusPassed,
+			fmt.Sprintf("encoding %s, locale %s", sourceEncoding.Encoding, sourceEncoding.Collate),
+			requestDTO.OverrideChecks,
+		)
+	}
+}
+
+func (s *RestoreService) addSkippedPostgresqlChecks(
+	report *restores_core.PreRestoreReport,
+	inspected string,
+	err error,
+	requestDTO restores_core.RestoreBackupRequest,
+) {
+	message := fmt.Sprintf("cannot inspect %s: %v", inspected, err)
+
+	for _, checkType := range []restores_core.PreRestoreCheckType{
+		restores_core.PreRestoreCheckTypeExtensions,
+		restores_core.PreRestoreCheckTypeEncoding,
+	} {
+		report.Add(
+			checkType,
+			restores_core.PreRestoreCheckStatusSkipped,
+			message,
+			requestDTO.OverrideChecks,
+		)
+	}
+}
+
+// checkDiskSpace applies to PostgreSQL when file-based restore is needed:
+// - CPU > 1 (parallel jobs require file)
+// - IsExcludeExtensions (TOC filtering requires file)
+// - TimescaleDB backups (the extension entry is filtered out of TOC)
+// Other databases and PostgreSQL with CPU=1 without extension exclusion stream directly
+func (s *RestoreService) checkDiskSpace(
+	report *restores_core.PreRestoreReport,
+	backup *backups_core.Backup,
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) {
+	if requestDTO.PostgresqlDatabase == nil {
+		return
+	}
+
+	needsFileBased := requestDTO.PostgresqlDatabase.CpuCount > 1 ||
+		requestDTO.PostgresqlDatabase.IsExcludeExtensions ||
+		(backupDatabase.Postgresql != nil && backupDatabase.Postgresql.IsTimescaledb())
+	if !needsFileBased {
+		return
+	}
+
+	diskUsage, err := s.diskService.GetDiskUsage()
+	if err != nil {
+		report.Add(
+			restores_core.PreRestoreCheckTypeDiskSpace,
+			restores_core.PreRestoreCheckStatusFailed,
+			fmt.Sprintf("failed to check disk space: %v", err),
+			requestDTO.OverrideChecks,
+		)
+		return
+	}
+
+	// Convert backup size from MB to bytes
+	backupSizeBytes := int64(backup.BackupSizeMb * 1024 * 1024)
+
+	// Calculate required space: backup size + 10% buffer
+	bufferBytes := int64(float64(backupSizeBytes) * 0.1)
+	requiredBytes := backupSizeBytes + bufferBytes
+
+	// Ensure minimum of 1 GB total (even if backup is small)
+	minRequiredBytes := int64(1024 * 1024 * 1024) // 1 GB
+	if requiredBytes < minRequiredBytes {
+		requiredBytes = minRequiredBytes
+	}
+
+	requiredGB := float64(requiredBytes) / (1024 * 1024 * 1024)
+	availableGB := float64(diskUsage.FreeSpaceBytes) / (1024 * 1024 * 1024)
+
+	// Check if there's enough free space
+	if diskUsage.FreeSpaceBytes < requiredBytes {
+		backupSizeGB := float64(backupSizeBytes) / (1024 * 1024 * 1024)
+		bufferSizeGB := float64(bufferBytes) / (1024 * 1024 * 1024)
+
+		report.Add(
+			restores_core.PreRestoreCheckTypeDiskSpace,
+			restores_core.PreRestoreCheckStatusFailed,
+			fmt.Sprintf(
+				"to restore this backup, %.1f GB (%.1f GB backup + %.1f GB buffer) is required, but only %.1f GB is available. Please free up disk space before restoring",
+				requiredGB,
+				backupSizeGB,
+				bufferSizeGB,
+				availableGB,
+			),
+			requestDTO.OverrideChecks,
+		)
+		return
+	}
+
+	report.Add(
+		restores_core.PreRestoreCheckTypeDiskSpace,
+		restores_core.PreRestoreCheckStatusPassed,
+		fmt.Sprintf("%.1f GB is required, %.1f GB is available", requiredGB, availableGB),
+		requestDTO.OverrideChecks,
+	)
+}
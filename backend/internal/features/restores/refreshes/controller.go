@@ -0,0 +1,228 @@
+package restores_refreshes
+
+import (
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RefreshController struct {
+	refreshService *RefreshService
+}
+
+func (c *RefreshController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/refresh-jobs/database/:databaseId", c.GetRefreshJobs)
+	router.POST("/refresh-jobs/database/:databaseId", c.CreateRefreshJob)
+	router.PUT("/refresh-jobs/:id", c.UpdateRefreshJob)
+	router.DELETE("/refresh-jobs/:id", c.DeleteRefreshJob)
+	router.POST("/refresh-jobs/:id/run", c.RunRefreshJob)
+	router.GET("/refresh-jobs/:id/runs", c.GetRefreshRuns)
+}
+
+// GetRefreshJobs
+// @Summary Get refresh jobs
+// @Description Get scheduled refreshes restoring backups of a database into other servers
+// @Tags refresh-jobs
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Success 200 {array} RefreshJob
+// @Failure 400
+// @Failure 401
+// @Router /refresh-jobs/database/{databaseId} [get]
+func (c *RefreshController) GetRefreshJobs(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	jobs, err := c.refreshService.GetRefreshJobs(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, jobs)
+}
+
+// CreateRefreshJob
+// @Summary Create a refresh job
+// @Description Schedule restores of the latest completed backup of a database into a target
+// @Tags refresh-jobs
+// @Accept json
+// @Produce json
+// @Param databaseId path string true "Database ID"
+// @Param request body RefreshJob true "Refresh job"
+// @Success 200 {object} RefreshJob
+// @Failure 400
+// @Failure 401
+// @Router /refresh-jobs/database/{databaseId} [post]
+func (c *RefreshController) CreateRefreshJob(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("databaseId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var job RefreshJob
+	if err := ctx.ShouldBindJSON(&job); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdJob, err := c.refreshService.CreateRefreshJob(user, databaseID, &job)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createdJob)
+}
+
+// UpdateRefreshJob
+// @Summary Update a refresh job
+// @Description Update a refresh job, empty target passwords keep the saved ones
+// @Tags refresh-jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "Refresh job ID"
+// @Param request body RefreshJob true "Refresh job"
+// @Success 200 {object} RefreshJob
+// @Failure 400
+// @Failure 401
+// @Router /refresh-jobs/{id} [put]
+func (c *RefreshController) UpdateRefreshJob(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid refresh job ID"})
+		return
+	}
+
+	var job RefreshJob
+	if err := ctx.ShouldBindJSON(&job); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedJob, err := c.refreshService.UpdateRefreshJob(user, jobID, &job)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedJob)
+}
+
+// DeleteRefreshJob
+// @Summary Delete a refresh job
+// @Description Delete a refresh job with its history
+// @Tags refresh-jobs
+// @Param id path string true "Refresh job ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /refresh-jobs/{id} [delete]
+func (c *RefreshController) DeleteRefreshJob(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid refresh job ID"})
+		return
+	}
+
+	if err := c.refreshService.DeleteRefreshJob(user, jobID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RunRefreshJob
+// @Summary Run a refresh job now
+// @Description Start a refresh from the latest completed backup without waiting for the schedule
+// @Tags refresh-jobs
+// @Produce json
+// @Param id path string true "Refresh job ID"
+// @Success 200 {object} RefreshRun
+// @Failure 400
+// @Failure 401
+// @Router /refresh-jobs/{id}/run [post]
+func (c *RefreshController) RunRefreshJob(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid refresh job ID"})
+		return
+	}
+
+	run, err := c.refreshService.RunRefreshJobNow(user, jobID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, run)
+}
+
+// GetRefreshRuns
+// @Summary Get refresh runs
+// @Description Get the latest runs of a refresh job
+// @Tags refresh-jobs
+// @Produce json
+// @Param id path string true "Refresh job ID"
+// @Success 200 {array} RefreshRun
+// @Failure 400
+// @Failure 401
+// @Router /refresh-jobs/{id}/runs [get]
+func (c *RefreshController) GetRefreshRuns(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid refresh job ID"})
+		return
+	}
+
+	runs, err := c.refreshService.GetRefreshRuns(user, jobID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
@@ -0,0 +1,223 @@
+package restores_refreshes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/intervals"
+	restores_core "databasus-backend/internal/features/restores/core"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	router := workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		databases.GetDatabaseController(),
+		GetRefreshController(),
+	)
+	return router
+}
+
+func Test_CreateRefreshJob_WhenTargetHasPassword_PasswordIsHidden(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	var createdJob RefreshJob
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/database/%s", database.ID.String()),
+		"Bearer "+owner.Token,
+		createTestRefreshJob("staging_db"),
+		http.StatusOK,
+		&createdJob,
+	)
+
+	assert.NotEqual(t, uuid.Nil, createdJob.ID)
+	assert.Equal(t, "", createdJob.Target.PostgresqlDatabase.Password)
+
+	var jobs []RefreshJob
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/database/%s", database.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&jobs,
+	)
+
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "", jobs[0].Target.PostgresqlDatabase.Password)
+	assert.Equal(t, "staging_db", *jobs[0].Target.PostgresqlDatabase.Database)
+
+	databases.RemoveTestDatabase(database)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_CreateRefreshJob_WhenTargetIsSourceDatabase_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/database/%s", database.ID.String()),
+		"Bearer "+owner.Token,
+		createTestRefreshJob(*databases.GetTestPostgresConfig().Database),
+		http.StatusBadRequest,
+	)
+
+	assert.Contains(t, string(testResp.Body), "must not be the source database")
+
+	databases.RemoveTestDatabase(database)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_CreateRefreshJob_WhenUserIsViewer_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/database/%s", database.ID.String()),
+		"Bearer "+viewer.Token,
+		createTestRefreshJob("staging_db"),
+		http.StatusBadRequest,
+	)
+
+	assert.Contains(t, string(testResp.Body), "insufficient permissions")
+
+	databases.RemoveTestDatabase(database)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_RunRefreshJob_WhenDatabaseHasNoBackups_RunIsRecordedAsFailed(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	var createdJob RefreshJob
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/database/%s", database.ID.String()),
+		"Bearer "+owner.Token,
+		createTestRefreshJob("staging_db"),
+		http.StatusOK,
+		&createdJob,
+	)
+
+	var run RefreshRun
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/%s/run", createdJob.ID.String()),
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&run,
+	)
+
+	assert.Equal(t, RefreshRunStatusFailed, run.Status)
+	assert.Nil(t, run.RestoreID)
+	assert.Contains(t, *run.FailMessage, "no completed backups")
+
+	var runs []RefreshRun
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/refresh-jobs/%s/runs", createdJob.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&runs,
+	)
+
+	assert.Len(t, runs, 1)
+	assert.Equal(t, run.ID, runs[0].ID)
+
+	databases.RemoveTestDatabase(database)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func createTestRefreshJob(targetDatabaseName string) RefreshJob {
+	target := databases.GetTestPostgresConfig()
+	target.Database = &targetDatabaseName
+
+	timeOfDay := "03:00"
+	weekday := 0
+
+	return RefreshJob{
+		Name:      "Weekly staging refresh",
+		IsEnabled: true,
+		Interval: &intervals.Interval{
+			Interval:  intervals.IntervalWeekly,
+			TimeOfDay: &timeOfDay,
+			Weekday:   &weekday,
+		},
+		Target: &restores_core.RestoreBackupRequest{
+			PostgresqlDatabase: target,
+		},
+		SendNotificationsOn: []RefreshNotificationType{NotificationRefreshFailed},
+	}
+}
+
+func createTestDatabaseViaAPI(
+	name string,
+	workspaceID uuid.UUID,
+	token string,
+	router *gin.Engine,
+) *databases.Database {
+	request := databases.Database{
+		WorkspaceID: &workspaceID,
+		Name:        name,
+		Type:        databases.DatabaseTypePostgres,
+		Postgresql:  databases.GetTestPostgresConfig(),
+	}
+
+	w := workspaces_testing.MakeAPIRequest(
+		router,
+		"POST",
+		"/api/v1/databases/create",
+		"Bearer "+token,
+		request,
+	)
+
+	if w.Code != http.StatusCreated {
+		panic("Failed to create database")
+	}
+
+	var database databases.Database
+	if err := json.Unmarshal(w.Body.Bytes(), &database); err != nil {
+		panic(err)
+	}
+	return &database
+}
@@ -0,0 +1,46 @@
+package restores_refreshes
+
+import (
+	"databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/masking"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/restores"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var refreshRepository = &RefreshRepository{}
+var refreshService = &RefreshService{
+	refreshRepository,
+	&backups_core.BackupRepository{},
+	restores.GetRestoreService(),
+	masking.GetMaskingService(),
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	notifiers.GetNotifierService(),
+	audit_logs.GetAuditLogService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var refreshController = &RefreshController{
+	refreshService,
+}
+var refreshBackgroundService = &RefreshBackgroundService{
+	refreshService: refreshService,
+	logger:         logger.GetLogger(),
+}
+
+func GetRefreshService() *RefreshService {
+	return refreshService
+}
+
+func GetRefreshController() *RefreshController {
+	return refreshController
+}
+
+func GetRefreshBackgroundService() *RefreshBackgroundService {
+	return refreshBackgroundService
+}
@@ -0,0 +1,16 @@
+package restores_refreshes
+
+type RefreshRunStatus string
+
+const (
+	RefreshRunStatusInProgress RefreshRunStatus = "IN_PROGRESS"
+	RefreshRunStatusCompleted  RefreshRunStatus = "COMPLETED"
+	RefreshRunStatusFailed     RefreshRunStatus = "FAILED"
+)
+
+type RefreshNotificationType string
+
+const (
+	NotificationRefreshFailed  RefreshNotificationType = "REFRESH_FAILED"
+	NotificationRefreshSuccess RefreshNotificationType = "REFRESH_SUCCESS"
+)
@@ -0,0 +1,143 @@
+package restores_refreshes
+
+import (
+	"databasus-backend/internal/features/intervals"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshJob restores the latest completed backup of the database into the target on schedule,
+// e.g. to keep a staging server in sync with production
+type RefreshJob struct {
+	ID         uuid.UUID `json:"id"         gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;not null"`
+	Name       string    `json:"name"       gorm:"column:name;type:text;not null"`
+	IsEnabled  bool      `json:"isEnabled"  gorm:"column:is_enabled;type:boolean;not null"`
+
+	IntervalID uuid.UUID           `json:"intervalId"         gorm:"column:interval_id;type:uuid;not null"`
+	Interval   *intervals.Interval `json:"interval,omitempty" gorm:"foreignKey:IntervalID"`
+
+	// Target is restored like a manual restore. Passwords are encrypted with the ID of the
+	// source database, restores decrypt them with it
+	Target     *restores_core.RestoreBackupRequest `json:"target" gorm:"-"`
+	TargetJSON string                              `json:"-"      gorm:"column:target;type:text;not null"`
+
+	// IsApplyMasking runs masking rules of the database on each refresh
+	IsApplyMasking bool `json:"isApplyMasking" gorm:"column:is_apply_masking;type:boolean;not null"`
+
+	SendNotificationsOn       []RefreshNotificationType `json:"sendNotificationsOn" gorm:"-"`
+	SendNotificationsOnString string                    `json:"-"                   gorm:"column:send_notifications_on;type:text;not null"`
+
+	LastRunAt *time.Time `json:"lastRunAt" gorm:"column:last_run_at"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (RefreshJob) TableName() string {
+	return "refresh_jobs"
+}
+
+func (j *RefreshJob) BeforeSave(tx *gorm.DB) error {
+	if j.Target != nil {
+		targetJSON, err := json.Marshal(j.Target)
+		if err != nil {
+			return err
+		}
+
+		j.TargetJSON = string(targetJSON)
+	}
+
+	notificationTypes := make([]string, len(j.SendNotificationsOn))
+	for i, notificationType := range j.SendNotificationsOn {
+		notificationTypes[i] = string(notificationType)
+	}
+	j.SendNotificationsOnString = strings.Join(notificationTypes, ",")
+
+	return nil
+}
+
+func (j *RefreshJob) AfterFind(tx *gorm.DB) error {
+	if j.TargetJSON != "" {
+		j.Target = &restores_core.RestoreBackupRequest{}
+		if err := json.Unmarshal([]byte(j.TargetJSON), j.Target); err != nil {
+			return err
+		}
+	}
+
+	j.SendNotificationsOn = []RefreshNotificationType{}
+	if j.SendNotificationsOnString != "" {
+		for _, notificationType := range strings.Split(j.SendNotificationsOnString, ",") {
+			j.SendNotificationsOn = append(
+				j.SendNotificationsOn,
+				RefreshNotificationType(notificationType),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (j *RefreshJob) Validate() error {
+	if strings.TrimSpace(j.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if j.Interval == nil {
+		return errors.New("refresh interval is required")
+	}
+
+	if err := j.Interval.Validate(); err != nil {
+		return err
+	}
+
+	if j.Target == nil {
+		return errors.New("refresh target is required")
+	}
+
+	for _, notificationType := range j.SendNotificationsOn {
+		if notificationType != NotificationRefreshFailed &&
+			notificationType != NotificationRefreshSuccess {
+			return errors.New("invalid notification type: " + string(notificationType))
+		}
+	}
+
+	return nil
+}
+
+func (j *RefreshJob) IsNotifyOn(notificationType RefreshNotificationType) bool {
+	return slices.Contains(j.SendNotificationsOn, notificationType)
+}
+
+func (j *RefreshJob) HideSensitiveData() {
+	if j.Target == nil {
+		return
+	}
+
+	j.Target.PostgresqlDatabase.HideSensitiveData()
+	j.Target.MysqlDatabase.HideSensitiveData()
+	j.Target.MariadbDatabase.HideSensitiveData()
+}
+
+// RefreshRun is one execution of a refresh job. The restore it started is tracked until it
+// finishes, runs which failed before the restore started have no restore
+type RefreshRun struct {
+	ID           uuid.UUID        `json:"id"           gorm:"column:id;type:uuid;primaryKey"`
+	RefreshJobID uuid.UUID        `json:"refreshJobId" gorm:"column:refresh_job_id;type:uuid;not null"`
+	BackupID     *uuid.UUID       `json:"backupId"     gorm:"column:backup_id;type:uuid"`
+	RestoreID    *uuid.UUID       `json:"restoreId"    gorm:"column:restore_id;type:uuid"`
+	Status       RefreshRunStatus `json:"status"       gorm:"column:status;type:text;not null"`
+	FailMessage  *string          `json:"failMessage"  gorm:"column:fail_message"`
+
+	StartedAt  time.Time  `json:"startedAt"  gorm:"column:started_at"`
+	FinishedAt *time.Time `json:"finishedAt" gorm:"column:finished_at"`
+}
+
+func (RefreshRun) TableName() string {
+	return "refresh_runs"
+}
@@ -0,0 +1,155 @@
+package restores_refreshes
+
+import (
+	"databasus-backend/internal/storage"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefreshRepository struct{}
+
+func (r *RefreshRepository) SaveJob(job *RefreshJob) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if job.Interval != nil {
+			if job.Interval.ID == uuid.Nil {
+				if err := tx.Create(job.Interval).Error; err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Save(job.Interval).Error; err != nil {
+					return err
+				}
+			}
+
+			job.IntervalID = job.Interval.ID
+		}
+
+		return tx.Omit("Interval").Save(job).Error
+	})
+}
+
+func (r *RefreshRepository) FindJobByID(id uuid.UUID) (*RefreshJob, error) {
+	var job RefreshJob
+
+	if err := storage.
+		GetDb().
+		Preload("Interval").
+		Where("id = ?", id).
+		First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *RefreshRepository) FindJobsByDatabaseID(databaseID uuid.UUID) ([]*RefreshJob, error) {
+	var jobs []*RefreshJob
+
+	if err := storage.
+		GetDb().
+		Preload("Interval").
+		Where("database_id = ?", databaseID).
+		Order("created_at ASC").
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *RefreshRepository) FindEnabledJobs() ([]*RefreshJob, error) {
+	var jobs []*RefreshJob
+
+	if err := storage.
+		GetDb().
+		Preload("Interval").
+		Where("is_enabled = ?", true).
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *RefreshRepository) FindEnabledJobsByDatabaseIDs(
+	databaseIDs []uuid.UUID,
+) ([]*RefreshJob, error) {
+	var jobs []*RefreshJob
+
+	if len(databaseIDs) == 0 {
+		return jobs, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Preload("Interval").
+		Where("is_enabled = ? AND database_id IN ?", true, databaseIDs).
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// DeleteJob removes the job with its interval, runs are removed by the database
+func (r *RefreshRepository) DeleteJob(job *RefreshJob) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&RefreshJob{}, "id = ?", job.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("DELETE FROM intervals WHERE id = ?", job.IntervalID).Error
+	})
+}
+
+func (r *RefreshRepository) SaveRun(run *RefreshRun) error {
+	return storage.GetDb().Save(run).Error
+}
+
+func (r *RefreshRepository) FindRunsByJobID(jobID uuid.UUID, limit int) ([]*RefreshRun, error) {
+	var runs []*RefreshRun
+
+	if err := storage.
+		GetDb().
+		Where("refresh_job_id = ?", jobID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *RefreshRepository) FindRunsByStatus(status RefreshRunStatus) ([]*RefreshRun, error) {
+	var runs []*RefreshRun
+
+	if err := storage.
+		GetDb().
+		Where("status = ?", status).
+		Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (r *RefreshRepository) IsRunInProgress(jobID uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := storage.
+		GetDb().
+		Model(&RefreshRun{}).
+		Where("refresh_job_id = ? AND status = ?", jobID, RefreshRunStatusInProgress).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,583 @@
+package restores_refreshes
+
+import (
+	"databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/masking"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/restores"
+	restores_core "databasus-backend/internal/features/restores/core"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/i18n"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const refreshRunsLimit = 50
+
+type RefreshService struct {
+	refreshRepository *RefreshRepository
+	backupRepository  *backups_core.BackupRepository
+	restoreService    *restores.RestoreService
+	maskingService    *masking.MaskingService
+	databaseService   *databases.DatabaseService
+	workspaceService  *workspaces_services.WorkspaceService
+	notifierService   *notifiers.NotifierService
+	auditLogService   *audit_logs.AuditLogService
+	fieldEncryptor    encryption.FieldEncryptor
+	logger            *slog.Logger
+}
+
+func (s *RefreshService) GetRefreshJobs(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) ([]*RefreshJob, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot get refresh jobs for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view refresh jobs")
+	}
+
+	jobs, err := s.refreshRepository.FindJobsByDatabaseID(database.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobs {
+		job.HideSensitiveData()
+	}
+
+	return jobs, nil
+}
+
+func (s *RefreshService) CreateRefreshJob(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	job *RefreshJob,
+) (*RefreshJob, error) {
+	database, err := s.getDatabaseToManage(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	job.ID = uuid.New()
+	job.DatabaseID = database.ID
+	job.LastRunAt = nil
+	job.CreatedAt = time.Now().UTC()
+	if job.Interval != nil {
+		job.Interval.ID = uuid.Nil
+	}
+
+	if err := s.validateJob(database, job, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshRepository.SaveJob(job); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Refresh job '%s' created for database: %s", job.Name, database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	job.HideSensitiveData()
+	return job, nil
+}
+
+func (s *RefreshService) UpdateRefreshJob(
+	user *users_models.User,
+	jobID uuid.UUID,
+	incoming *RefreshJob,
+) (*RefreshJob, error) {
+	job, database, err := s.getJobToManage(user, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if incoming.Interval != nil {
+		incoming.Interval.ID = job.IntervalID
+	}
+
+	if err := s.validateJob(database, incoming, job.Target); err != nil {
+		return nil, err
+	}
+
+	job.Name = incoming.Name
+	job.IsEnabled = incoming.IsEnabled
+	job.Interval = incoming.Interval
+	job.Target = incoming.Target
+	job.IsApplyMasking = incoming.IsApplyMasking
+	job.SendNotificationsOn = incoming.SendNotificationsOn
+
+	if err := s.refreshRepository.SaveJob(job); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Refresh job '%s' updated for database: %s", job.Name, database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	job.HideSensitiveData()
+	return job, nil
+}
+
+func (s *RefreshService) DeleteRefreshJob(user *users_models.User, jobID uuid.UUID) error {
+	job, database, err := s.getJobToManage(user, jobID)
+	if err != nil {
+		return err
+	}
+
+	isInProgress, err := s.refreshRepository.IsRunInProgress(job.ID)
+	if err != nil {
+		return err
+	}
+	if isInProgress {
+		return errors.New("refresh is in progress, refresh job cannot be removed")
+	}
+
+	if err := s.refreshRepository.DeleteJob(job); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Refresh job '%s' deleted for database: %s", job.Name, database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+func (s *RefreshService) RunRefreshJobNow(
+	user *users_models.User,
+	jobID uuid.UUID,
+) (*RefreshRun, error) {
+	job, database, err := s.getJobToManage(user, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	isInProgress, err := s.refreshRepository.IsRunInProgress(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if isInProgress {
+		return nil, errors.New("refresh is already in progress")
+	}
+
+	run, err := s.startRun(job, database, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Refresh job '%s' started manually for database: %s",
+			job.Name,
+			database.Name,
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return run, nil
+}
+
+func (s *RefreshService) GetRefreshRuns(
+	user *users_models.User,
+	jobID uuid.UUID,
+) ([]*RefreshRun, error) {
+	job, err := s.refreshRepository.FindJobByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, errors.New("refresh job not found")
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(job.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot get refresh runs for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to view refresh runs")
+	}
+
+	return s.refreshRepository.FindRunsByJobID(job.ID, refreshRunsLimit)
+}
+
+// ProcessRefreshes finishes runs whose restores are done and starts jobs which are due
+func (s *RefreshService) ProcessRefreshes(now time.Time) error {
+	if err := s.finishRuns(now); err != nil {
+		return err
+	}
+
+	jobs, err := s.refreshRepository.FindEnabledJobs()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Interval == nil || !job.Interval.ShouldTriggerBackup(now, job.LastRunAt) {
+			continue
+		}
+
+		isInProgress, err := s.refreshRepository.IsRunInProgress(job.ID)
+		if err != nil {
+			return err
+		}
+		if isInProgress {
+			continue
+		}
+
+		database, err := s.databaseService.GetDatabaseByID(job.DatabaseID)
+		if err != nil {
+			s.logger.Error("failed to get database of refresh job", "jobId", job.ID, "error", err)
+			continue
+		}
+
+		if _, err := s.startRun(job, database, now); err != nil {
+			s.logger.Error("failed to start refresh", "jobId", job.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// startRun restores the latest completed backup into the target of the job. Runs which
+// cannot start are recorded as failed, so they are visible in the history of the job
+func (s *RefreshService) startRun(
+	job *RefreshJob,
+	database *databases.Database,
+	now time.Time,
+) (*RefreshRun, error) {
+	job.LastRunAt = &now
+	if err := s.refreshRepository.SaveJob(job); err != nil {
+		return nil, err
+	}
+
+	run := &RefreshRun{
+		ID:           uuid.New(),
+		RefreshJobID: job.ID,
+		Status:       RefreshRunStatusInProgress,
+		StartedAt:    now,
+	}
+
+	restore, err := s.startRestore(job, run)
+	if err != nil {
+		s.failRun(job, database, run, err.Error(), now)
+		return run, nil
+	}
+
+	run.RestoreID = &restore.ID
+	if err := s.refreshRepository.SaveRun(run); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (s *RefreshService) startRestore(
+	job *RefreshJob,
+	run *RefreshRun,
+) (*restores_core.Restore, error) {
+	backups, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		job.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, errors.New("database has no completed backups to refresh from")
+	}
+
+	run.BackupID = &backups[0].ID
+
+	request := *job.Target
+	request.IsApplyMasking = job.IsApplyMasking
+
+	return s.restoreService.StartRefreshRestore(backups[0].ID, request)
+}
+
+func (s *RefreshService) finishRuns(now time.Time) error {
+	runs, err := s.refreshRepository.FindRunsByStatus(RefreshRunStatusInProgress)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		job, err := s.refreshRepository.FindJobByID(run.RefreshJobID)
+		if err != nil || job == nil {
+			continue
+		}
+
+		database, err := s.databaseService.GetDatabaseByID(job.DatabaseID)
+		if err != nil {
+			continue
+		}
+
+		if run.RestoreID == nil {
+			s.failRun(job, database, run, "restore of the refresh was removed", now)
+			continue
+		}
+
+		restore, err := s.restoreService.GetRestoreByID(*run.RestoreID)
+		if err != nil {
+			s.failRun(job, database, run, "restore of the refresh was removed", now)
+			continue
+		}
+
+		switch restore.Status {
+		case restores_core.RestoreStatusInProgress:
+			continue
+		case restores_core.RestoreStatusCompleted:
+			run.Status = RefreshRunStatusCompleted
+			run.FinishedAt = &now
+			if err := s.refreshRepository.SaveRun(run); err != nil {
+				return err
+			}
+
+			s.sendNotification(job, database, NotificationRefreshSuccess, nil)
+		case restores_core.RestoreStatusCanceled:
+			s.failRun(job, database, run, "restore of the refresh was canceled", now)
+		default:
+			failMessage := "restore of the refresh failed"
+			if restore.FailMessage != nil {
+				failMessage = *restore.FailMessage
+			}
+
+			s.failRun(job, database, run, failMessage, now)
+		}
+	}
+
+	return nil
+}
+
+func (s *RefreshService) failRun(
+	job *RefreshJob,
+	database *databases.Database,
+	run *RefreshRun,
+	failMessage string,
+	now time.Time,
+) {
+	run.Status = RefreshRunStatusFailed
+	run.FailMessage = &failMessage
+	run.FinishedAt = &now
+
+	if err := s.refreshRepository.SaveRun(run); err != nil {
+		s.logger.Error("failed to save refresh run", "runId", run.ID, "error", err)
+	}
+
+	s.sendNotification(job, database, NotificationRefreshFailed, &failMessage)
+}
+
+func (s *RefreshService) sendNotification(
+	job *RefreshJob,
+	database *databases.Database,
+	notificationType RefreshNotificationType,
+	failMessage *string,
+) {
+	if !job.IsNotifyOn(notificationType) || database.WorkspaceID == nil {
+		return
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(*database.WorkspaceID)
+	if err != nil {
+		return
+	}
+
+	for _, notifier := range database.Notifiers {
+		params := map[string]string{
+			"refresh":   job.Name,
+			"database":  database.Name,
+			"workspace": workspace.Name,
+		}
+
+		titleKey := i18n.MessageRefreshSuccessTitle
+		eventType := notifiers.NotificationEventRefreshSuccess
+		message := i18n.Translate(notifier.Locale, i18n.MessageRefreshSuccessMessage, params)
+		if notificationType == NotificationRefreshFailed {
+			titleKey = i18n.MessageRefreshFailedTitle
+			eventType = notifiers.NotificationEventRefreshFailed
+			message = *failMessage
+		}
+
+		s.notifierService.SendEventNotification(
+			&notifier,
+			eventType,
+			i18n.Translate(notifier.Locale, titleKey, params),
+			message,
+		)
+	}
+}
+
+// validateJob checks the job and encrypts passwords of its target. Passwords left empty
+// keep the ones of the existing target
+func (s *RefreshService) validateJob(
+	database *databases.Database,
+	job *RefreshJob,
+	existingTarget *restores_core.RestoreBackupRequest,
+) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+
+	if !masking.IsMaskingSupported(database.Type) {
+		return fmt.Errorf("scheduled refreshes are not supported for %s", database.Type)
+	}
+
+	if job.IsApplyMasking {
+		if err := s.maskingService.ValidateCanApplyMasking(database); err != nil {
+			return err
+		}
+	}
+
+	target := job.Target
+	if existingTarget == nil {
+		existingTarget = &restores_core.RestoreBackupRequest{}
+	}
+
+	switch database.Type {
+	case databases.DatabaseTypePostgres:
+		if target.PostgresqlDatabase == nil {
+			return errors.New("postgresql target is required for refresh")
+		}
+		if isSameServer(
+			database.Postgresql.Host, database.Postgresql.Port, database.Postgresql.Database,
+			target.PostgresqlDatabase.Host, target.PostgresqlDatabase.Port,
+			target.PostgresqlDatabase.Database,
+		) {
+			return errors.New("refresh target must not be the source database")
+		}
+		if target.PostgresqlDatabase.Password == "" && existingTarget.PostgresqlDatabase != nil {
+			target.PostgresqlDatabase.Password = existingTarget.PostgresqlDatabase.Password
+		}
+		return target.PostgresqlDatabase.EncryptSensitiveFields(database.ID, s.fieldEncryptor)
+	case databases.DatabaseTypeMysql:
+		if target.MysqlDatabase == nil {
+			return errors.New("mysql target is required for refresh")
+		}
+		if isSameServer(
+			database.Mysql.Host, database.Mysql.Port, database.Mysql.Database,
+			target.MysqlDatabase.Host, target.MysqlDatabase.Port, target.MysqlDatabase.Database,
+		) {
+			return errors.New("refresh target must not be the source database")
+		}
+		if target.MysqlDatabase.Password == "" && existingTarget.MysqlDatabase != nil {
+			target.MysqlDatabase.Password = existingTarget.MysqlDatabase.Password
+		}
+		return target.MysqlDatabase.EncryptSensitiveFields(database.ID, s.fieldEncryptor)
+	case databases.DatabaseTypeMariadb:
+		if target.MariadbDatabase == nil {
+			return errors.New("mariadb target is required for refresh")
+		}
+		if isSameServer(
+			database.Mariadb.Host, database.Mariadb.Port, database.Mariadb.Database,
+			target.MariadbDatabase.Host, target.MariadbDatabase.Port,
+			target.MariadbDatabase.Database,
+		) {
+			return errors.New("refresh target must not be the source database")
+		}
+		if target.MariadbDatabase.Password == "" && existingTarget.MariadbDatabase != nil {
+			target.MariadbDatabase.Password = existingTarget.MariadbDatabase.Password
+		}
+		return target.MariadbDatabase.EncryptSensitiveFields(database.ID, s.fieldEncryptor)
+	}
+
+	return nil
+}
+
+func (s *RefreshService) getDatabaseToManage(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*databases.Database, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot manage refresh jobs for database without workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to manage refresh jobs")
+	}
+
+	return database, nil
+}
+
+func (s *RefreshService) getJobToManage(
+	user *users_models.User,
+	jobID uuid.UUID,
+) (*RefreshJob, *databases.Database, error) {
+	job, err := s.refreshRepository.FindJobByID(jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job == nil {
+		return nil, nil, errors.New("refresh job not found")
+	}
+
+	database, err := s.getDatabaseToManage(user, job.DatabaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, database, nil
+}
+
+func isSameServer(
+	sourceHost string,
+	sourcePort int,
+	sourceDatabase *string,
+	targetHost string,
+	targetPort int,
+	targetDatabase *string,
+) bool {
+	if sourceHost != targetHost || sourcePort != targetPort {
+		return false
+	}
+
+	if sourceDatabase == nil || targetDatabase == nil {
+		return true
+	}
+
+	return *sourceDatabase == *targetDatabase
+}
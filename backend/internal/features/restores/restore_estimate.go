@@ -0,0 +1,166 @@
+package restores
+
+import (
+	"errors"
+	"time"
+
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/restores/restoring"
+	users_models "databasus-backend/internal/features/users/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// restoreEstimateSampleSize keeps the estimate close to the current setup, restores from
+	// months ago ran on other nodes and versions
+	restoreEstimateSampleSize = 20
+	// defaultRestoreThroughputMBs is used when history is empty and nodes report no
+	// throughput
+	defaultRestoreThroughputMBs = 10
+)
+
+// GetRestoreEstimate estimates how long restoring the backup takes if started now. It is
+// available to everyone able to restore the backup
+func (s *RestoreService) GetRestoreEstimate(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*restores_core.RestoreEstimate, error) {
+	backup, err := s.backupService.GetBackup(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot estimate restore for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to restore this backup")
+	}
+
+	storage, err := s.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &restores_core.RestoreEstimate{
+		BackupID:     backup.ID,
+		BackupSizeMb: backup.BackupSizeMb,
+	}
+
+	nodeThroughputMBs, err := s.addNodeLoad(estimate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.addThroughput(
+		estimate,
+		string(database.Type),
+		string(storage.Type),
+		nodeThroughputMBs,
+	); err != nil {
+		return nil, err
+	}
+
+	// Restores on a node share its bandwidth, so the restore gets its part of it
+	durationSeconds := backup.BackupSizeMb / estimate.ThroughputMBs *
+		float64(estimate.ActiveRestores+1)
+
+	estimate.EstimatedDurationMs = int64(durationSeconds * 1000)
+	estimate.EstimatedFinishAt = time.Now().UTC().
+		Add(time.Duration(estimate.EstimatedDurationMs) * time.Millisecond)
+
+	return estimate, nil
+}
+
+// addNodeLoad sets the load of the least busy node, the one the scheduler would pick. It
+// returns the network throughput of that node
+func (s *RestoreService) addNodeLoad(estimate *restores_core.RestoreEstimate) (int, error) {
+	nodes, err := s.restoreNodesRegistry.GetAvailableNodes()
+	if err != nil {
+		return 0, err
+	}
+
+	stats, err := s.restoreNodesRegistry.GetRestoreNodesStats()
+	if err != nil {
+		return 0, err
+	}
+
+	activeRestores := make(map[uuid.UUID]int)
+	for _, stat := range stats {
+		activeRestores[stat.ID] = stat.ActiveRestores
+	}
+
+	estimate.AvailableNodes = len(nodes)
+
+	var bestNode *restoring.RestoreNode
+	for i := range nodes {
+		node := &nodes[i]
+
+		if bestNode == nil || activeRestores[node.ID] < activeRestores[bestNode.ID] {
+			bestNode = node
+		}
+	}
+
+	if bestNode == nil {
+		return 0, nil
+	}
+
+	estimate.ActiveRestores = activeRestores[bestNode.ID]
+
+	return bestNode.ThroughputMBs, nil
+}
+
+// addThroughput prefers history of the same database and storage types, then history of the
+// database type, then the throughput of the node
+func (s *RestoreService) addThroughput(
+	estimate *restores_core.RestoreEstimate,
+	databaseType string,
+	storageType string,
+	nodeThroughputMBs int,
+) error {
+	candidates := []struct {
+		storageType string
+		source      restores_core.RestoreThroughputSource
+	}{
+		{storageType, restores_core.RestoreThroughputSourceEngineAndStorage},
+		{"", restores_core.RestoreThroughputSourceEngine},
+	}
+
+	for _, candidate := range candidates {
+		history, err := s.restoreRepository.GetThroughputHistory(
+			databaseType,
+			candidate.storageType,
+			restoreEstimateSampleSize,
+		)
+		if err != nil {
+			return err
+		}
+
+		if throughputMBs := history.GetThroughputMBs(); throughputMBs > 0 {
+			estimate.ThroughputMBs = throughputMBs
+			estimate.ThroughputSource = candidate.source
+			estimate.SampleRestoresCount = history.RestoresCount
+
+			return nil
+		}
+	}
+
+	estimate.ThroughputMBs = float64(nodeThroughputMBs)
+	if estimate.ThroughputMBs <= 0 {
+		estimate.ThroughputMBs = defaultRestoreThroughputMBs
+	}
+	estimate.ThroughputSource = restores_core.RestoreThroughputSourceNode
+
+	return nil
+}
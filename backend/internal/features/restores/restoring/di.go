@@ -10,6 +10,8 @@ import (
 	"databasus-backend/internal/features/backups/backups"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/masking"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/restores/usecases"
 	"databasus-backend/internal/features/storages"
@@ -46,11 +48,13 @@ var restorerNode = &RestorerNode{
 	restoreRepository:    restoreRepository,
 	backupConfigService:  backups_config.GetBackupConfigService(),
 	storageService:       storages.GetStorageService(),
+	signingService:       encryption_signing.GetSigningService(),
 	restoreNodesRegistry: restoreNodesRegistry,
 	logger:               logger.GetLogger(),
 	restoreBackupUsecase: usecases.GetRestoreBackupUsecase(),
 	cacheUtil:            restoreDatabaseCache,
 	restoreCancelManager: restoreCancelManager,
+	maskingService:       masking.GetMaskingService(),
 	lastHeartbeat:        time.Time{},
 	runOnce:              sync.Once{},
 	hasRun:               atomic.Bool{},
@@ -1,9 +1,15 @@
 package restoring
 
 import (
+	"databasus-backend/internal/features/databases/databases/cassandra"
+	"databasus-backend/internal/features/databases/databases/cockroachdb"
+	"databasus-backend/internal/features/databases/databases/elasticsearch"
+	"databasus-backend/internal/features/databases/databases/filesystem"
+	"databasus-backend/internal/features/databases/databases/influxdb"
 	"databasus-backend/internal/features/databases/databases/mariadb"
 	"databasus-backend/internal/features/databases/databases/mongodb"
 	"databasus-backend/internal/features/databases/databases/mysql"
+	"databasus-backend/internal/features/databases/databases/neo4j"
 	"databasus-backend/internal/features/databases/databases/postgresql"
 	"time"
 
@@ -11,10 +17,18 @@ import (
 )
 
 type RestoreDatabaseCache struct {
-	PostgresqlDatabase *postgresql.PostgresqlDatabase `json:"postgresqlDatabase,omitempty"`
-	MysqlDatabase      *mysql.MysqlDatabase           `json:"mysqlDatabase,omitempty"`
-	MariadbDatabase    *mariadb.MariadbDatabase       `json:"mariadbDatabase,omitempty"`
-	MongodbDatabase    *mongodb.MongodbDatabase       `json:"mongodbDatabase,omitempty"`
+	PostgresqlDatabase    *postgresql.PostgresqlDatabase       `json:"postgresqlDatabase,omitempty"`
+	MysqlDatabase         *mysql.MysqlDatabase                 `json:"mysqlDatabase,omitempty"`
+	MariadbDatabase       *mariadb.MariadbDatabase             `json:"mariadbDatabase,omitempty"`
+	MongodbDatabase       *mongodb.MongodbDatabase             `json:"mongodbDatabase,omitempty"`
+	FilesystemDatabase    *filesystem.FilesystemDatabase       `json:"filesystemDatabase,omitempty"`
+	ElasticsearchDatabase *elasticsearch.ElasticsearchDatabase `json:"elasticsearchDatabase,omitempty"`
+	InfluxdbDatabase      *influxdb.InfluxdbDatabase           `json:"influxdbDatabase,omitempty"`
+	CassandraDatabase     *cassandra.CassandraDatabase         `json:"cassandraDatabase,omitempty"`
+	CockroachdbDatabase   *cockroachdb.CockroachdbDatabase     `json:"cockroachdbDatabase,omitempty"`
+	Neo4jDatabase         *neo4j.Neo4jDatabase                 `json:"neo4jDatabase,omitempty"`
+
+	IsApplyMasking bool `json:"isApplyMasking,omitempty"`
 }
 
 type RestoreToNodeRelation struct {
@@ -16,11 +16,15 @@ import (
 	"databasus-backend/internal/features/backups/backups"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/masking"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/storages"
 	tasks_cancellation "databasus-backend/internal/features/tasks/cancellation"
+	task_watchdog "databasus-backend/internal/features/tasks/watchdog"
 	cache_utils "databasus-backend/internal/util/cache"
 	util_encryption "databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/errortracking"
 )
 
 const (
@@ -37,11 +41,13 @@ type RestorerNode struct {
 	restoreRepository    *restores_core.RestoreRepository
 	backupConfigService  *backups_config.BackupConfigService
 	storageService       *storages.StorageService
+	signingService       *encryption_signing.SigningService
 	restoreNodesRegistry *RestoreNodesRegistry
 	logger               *slog.Logger
 	restoreBackupUsecase restores_core.RestoreBackupUsecase
 	cacheUtil            *cache_utils.CacheUtil[RestoreDatabaseCache]
 	restoreCancelManager *tasks_cancellation.TaskCancelManager
+	maskingService       *masking.MaskingService
 
 	lastHeartbeat time.Time
 
@@ -192,6 +198,7 @@ func (n *RestorerNode) MakeRestore(restoreID uuid.UUID) {
 		n.logger.Error("Failed to get storage by ID", "error", err)
 		return
 	}
+	storage.BindFileName(backup.ID, backup.FileName)
 
 	start := time.Now().UTC()
 
@@ -199,14 +206,25 @@ func (n *RestorerNode) MakeRestore(restoreID uuid.UUID) {
 	ctx, cancel := context.WithCancel(context.Background())
 	n.restoreCancelManager.RegisterTask(restore.ID, cancel)
 	defer n.restoreCancelManager.UnregisterTask(restore.ID)
+	defer cancel()
+
+	// Restores do not report progress, so only their duration is limited
+	watchdog := task_watchdog.NewTaskWatchdog(backupConfig.GetRestoreTimeout(), 0)
+	go watchdog.Watch(ctx, cancel)
 
 	// Create restoring database from cached credentials
 	restoringToDB := &databases.Database{
-		Type:       database.Type,
-		Postgresql: dbCache.PostgresqlDatabase,
-		Mysql:      dbCache.MysqlDatabase,
-		Mariadb:    dbCache.MariadbDatabase,
-		Mongodb:    dbCache.MongodbDatabase,
+		Type:          database.Type,
+		Postgresql:    dbCache.PostgresqlDatabase,
+		Mysql:         dbCache.MysqlDatabase,
+		Mariadb:       dbCache.MariadbDatabase,
+		Mongodb:       dbCache.MongodbDatabase,
+		Filesystem:    dbCache.FilesystemDatabase,
+		Elasticsearch: dbCache.ElasticsearchDatabase,
+		Influxdb:      dbCache.InfluxdbDatabase,
+		Cassandra:     dbCache.CassandraDatabase,
+		Cockroachdb:   dbCache.CockroachdbDatabase,
+		Neo4j:         dbCache.Neo4jDatabase,
 	}
 
 	if err := restoringToDB.PopulateDbData(n.logger, n.fieldEncryptor); err != nil {
@@ -222,6 +240,26 @@ func (n *RestorerNode) MakeRestore(restoreID uuid.UUID) {
 		return
 	}
 
+	if err := n.verifyBackupSignature(ctx, storage, backup); err != nil {
+		n.logger.Error(
+			"Backup signature verification failed",
+			"restoreId", restore.ID,
+			"backupId", backup.ID,
+			"error", err,
+		)
+
+		errMsg := err.Error()
+		restore.FailMessage = &errMsg
+		restore.Status = restores_core.RestoreStatusFailed
+		restore.RestoreDurationMs = time.Since(start).Milliseconds()
+
+		if err := n.restoreRepository.Save(restore); err != nil {
+			n.logger.Error("Failed to save restore", "error", err)
+		}
+
+		return
+	}
+
 	isExcludeExtensions := false
 	if dbCache.PostgresqlDatabase != nil {
 		isExcludeExtensions = dbCache.PostgresqlDatabase.IsExcludeExtensions
@@ -239,6 +277,10 @@ func (n *RestorerNode) MakeRestore(restoreID uuid.UUID) {
 	)
 
 	if err != nil {
+		if abortErr := watchdog.GetAbortError(); abortErr != nil {
+			err = fmt.Errorf("restore aborted: %w", abortErr)
+		}
+
 		errMsg := err.Error()
 
 		// Check if restore was cancelled
@@ -275,6 +317,16 @@ func (n *RestorerNode) MakeRestore(restoreID uuid.UUID) {
 			"errorMessage", errMsg,
 		)
 
+		if !isShutdown {
+			errortracking.GetErrorTracker().CaptureError(err, map[string]string{
+				"restore_id":    restore.ID.String(),
+				"backup_id":     backup.ID.String(),
+				"database_id":   databaseID.String(),
+				"database_type": string(database.Type),
+				"storage_type":  string(storage.Type),
+			})
+		}
+
 		restore.FailMessage = &errMsg
 		restore.Status = restores_core.RestoreStatusFailed
 		restore.RestoreDurationMs = time.Since(start).Milliseconds()
@@ -286,6 +338,26 @@ func (n *RestorerNode) MakeRestore(restoreID uuid.UUID) {
 		return
 	}
 
+	if dbCache.IsApplyMasking {
+		if err := n.maskingService.ApplyMasking(ctx, database, restoringToDB); err != nil {
+			n.logger.Error("Restore masking failed", "restoreId", restore.ID, "error", err)
+
+			errMsg := fmt.Sprintf(
+				"backup is restored, but masking failed and the target may hold unmasked data: %v",
+				err,
+			)
+			restore.FailMessage = &errMsg
+			restore.Status = restores_core.RestoreStatusFailed
+			restore.RestoreDurationMs = time.Since(start).Milliseconds()
+
+			if err := n.restoreRepository.Save(restore); err != nil {
+				n.logger.Error("Failed to save restore", "error", err)
+			}
+
+			return
+		}
+	}
+
 	restore.Status = restores_core.RestoreStatusCompleted
 	restore.RestoreDurationMs = time.Since(start).Milliseconds()
 
@@ -139,10 +139,16 @@ func (s *RestoresScheduler) StartRestore(restoreID uuid.UUID, dbCache *RestoreDa
 
 		// Create cache DTO from restore (may be nil if not in DB)
 		dbCache = &RestoreDatabaseCache{
-			PostgresqlDatabase: restore.PostgresqlDatabase,
-			MysqlDatabase:      restore.MysqlDatabase,
-			MariadbDatabase:    restore.MariadbDatabase,
-			MongodbDatabase:    restore.MongodbDatabase,
+			PostgresqlDatabase:    restore.PostgresqlDatabase,
+			MysqlDatabase:         restore.MysqlDatabase,
+			MariadbDatabase:       restore.MariadbDatabase,
+			MongodbDatabase:       restore.MongodbDatabase,
+			FilesystemDatabase:    restore.FilesystemDatabase,
+			ElasticsearchDatabase: restore.ElasticsearchDatabase,
+			InfluxdbDatabase:      restore.InfluxdbDatabase,
+			CassandraDatabase:     restore.CassandraDatabase,
+			CockroachdbDatabase:   restore.CockroachdbDatabase,
+			Neo4jDatabase:         restore.Neo4jDatabase,
 		}
 	}
 
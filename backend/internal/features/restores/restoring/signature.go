@@ -0,0 +1,66 @@
+package restoring
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/storages"
+)
+
+// verifyBackupSignature checks the signature of the backup, then reads the whole file from
+// the storage and compares it with the signed checksum before anything is restored. The
+// file is read twice, but a changed file is rejected before it reaches the target.
+// Backups without signature, made before signing or not uploaded by Databasus, are restored
+// without the check
+func (n *RestorerNode) verifyBackupSignature(
+	ctx context.Context,
+	storage *storages.Storage,
+	backup *backups_core.Backup,
+) error {
+	if backup.Signature == nil || backup.Checksum == nil {
+		return nil
+	}
+
+	if err := n.signingService.Verify(backup.GetSignatureMessage(), *backup.Signature); err != nil {
+		return fmt.Errorf("backup signature is invalid, the backup record was changed: %w", err)
+	}
+
+	file, err := storage.GetFile(n.fieldEncryptor, backup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, &contextReader{ctx: ctx, reader: file}); err != nil {
+		return fmt.Errorf("failed to read backup file to verify its signature: %w", err)
+	}
+
+	if hex.EncodeToString(hash.Sum(nil)) != *backup.Checksum {
+		return errors.New(
+			"backup file in the storage does not match its signature, " +
+				"it was changed or replaced after the backup was made",
+		)
+	}
+
+	return nil
+}
+
+// contextReader stops reading once the restore is cancelled
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, fmt.Errorf("restore cancelled: %w", err)
+	}
+
+	return r.reader.Read(p)
+}
@@ -0,0 +1,63 @@
+package restoring
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+func Test_VerifyBackupSignature_WhenFileReplacedInStorage_Rejected(t *testing.T) {
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+
+	restorerNode := CreateTestRestorerNode()
+	backup := &backups_core.Backup{ID: uuid.New()}
+	defer func() { _ = storage.DeleteFile(encryption.GetFieldEncryptor(), backup.ID) }()
+
+	saveTestFile(t, storage, backup.ID, "original backup content")
+	backup.Checksum = storage.GetSavedContentHash(backup.ID)
+	assert.NotNil(t, backup.Checksum)
+
+	assert.NoError(t, restorerNode.verifyBackupSignature(context.Background(), storage, backup))
+
+	signature, err := restorerNode.signingService.Sign(backup.GetSignatureMessage())
+	assert.NoError(t, err)
+	backup.Signature = &signature
+
+	assert.NoError(t, restorerNode.verifyBackupSignature(context.Background(), storage, backup))
+
+	saveTestFile(t, storage, backup.ID, "replaced backup content")
+	err = restorerNode.verifyBackupSignature(context.Background(), storage, backup)
+	assert.ErrorContains(t, err, "does not match its signature")
+
+	changedChecksum := *storage.GetSavedContentHash(backup.ID)
+	backup.Checksum = &changedChecksum
+	err = restorerNode.verifyBackupSignature(context.Background(), storage, backup)
+	assert.ErrorContains(t, err, "backup signature is invalid")
+}
+
+func saveTestFile(t *testing.T, storage *storages.Storage, fileID uuid.UUID, content string) {
+	assert.NoError(t, storage.SaveFile(
+		context.Background(),
+		encryption.GetFieldEncryptor(),
+		logger.GetLogger(),
+		fileID,
+		bytes.NewReader([]byte(content)),
+	))
+}
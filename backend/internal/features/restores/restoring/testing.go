@@ -17,6 +17,8 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/databases/databases/postgresql"
+	encryption_signing "databasus-backend/internal/features/encryption/signing"
+	"databasus-backend/internal/features/masking"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/restores/usecases"
 	"databasus-backend/internal/features/storages"
@@ -47,11 +49,13 @@ func CreateTestRestorerNode() *RestorerNode {
 		restoreRepository:    restoreRepository,
 		backupConfigService:  backups_config.GetBackupConfigService(),
 		storageService:       storages.GetStorageService(),
+		signingService:       encryption_signing.GetSigningService(),
 		restoreNodesRegistry: restoreNodesRegistry,
 		logger:               logger.GetLogger(),
 		restoreBackupUsecase: usecases.GetRestoreBackupUsecase(),
 		cacheUtil:            restoreDatabaseCache,
 		restoreCancelManager: tasks_cancellation.GetTaskCancelManager(),
+		maskingService:       masking.GetMaskingService(),
 		lastHeartbeat:        time.Time{},
 		runOnce:              sync.Once{},
 		hasRun:               atomic.Bool{},
@@ -67,11 +71,13 @@ func CreateTestRestorerNodeWithUsecase(usecase restores_core.RestoreBackupUsecas
 		restoreRepository:    restoreRepository,
 		backupConfigService:  backups_config.GetBackupConfigService(),
 		storageService:       storages.GetStorageService(),
+		signingService:       encryption_signing.GetSigningService(),
 		restoreNodesRegistry: restoreNodesRegistry,
 		logger:               logger.GetLogger(),
 		restoreBackupUsecase: usecase,
 		cacheUtil:            restoreDatabaseCache,
 		restoreCancelManager: tasks_cancellation.GetTaskCancelManager(),
+		maskingService:       masking.GetMaskingService(),
 		lastHeartbeat:        time.Time{},
 		runOnce:              sync.Once{},
 		hasRun:               atomic.Bool{},
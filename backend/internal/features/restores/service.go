@@ -8,6 +8,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/disk"
+	"databasus-backend/internal/features/masking"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/restores/restoring"
 	"databasus-backend/internal/features/restores/usecases"
@@ -16,7 +17,6 @@ import (
 	users_models "databasus-backend/internal/features/users/models"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	"databasus-backend/internal/util/encryption"
-	"databasus-backend/internal/util/tools"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -38,6 +38,8 @@ type RestoreService struct {
 	fieldEncryptor       encryption.FieldEncryptor
 	diskService          *disk.DiskService
 	taskCancelManager    *tasks_cancellation.TaskCancelManager
+	maskingService       *masking.MaskingService
+	restoreNodesRegistry *restoring.RestoreNodesRegistry
 }
 
 func (s *RestoreService) OnBeforeBackupRemove(backup *backups_core.Backup) error {
@@ -98,36 +100,87 @@ func (s *RestoreService) RestoreBackupWithAuth(
 	backupID uuid.UUID,
 	requestDTO restores_core.RestoreBackupRequest,
 ) error {
-	backup, err := s.backupService.GetBackup(backupID)
+	backup, backupDatabase, err := s.getBackupToRestore(user, backupID)
 	if err != nil {
 		return err
 	}
 
-	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
-	if err != nil {
+	if _, err := s.startRestore(user, backup, backupDatabase, requestDTO); err != nil {
 		return err
 	}
 
-	if database.WorkspaceID == nil {
-		return errors.New("cannot restore backup for database without workspace")
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Database restored from backup %s for database: %s",
+			backupID.String(),
+			backupDatabase.Name,
+		),
+		&user.ID,
+		backupDatabase.WorkspaceID,
+	)
+
+	return nil
+}
+
+// StartRefreshRestore starts a restore of the backup for a scheduled refresh. Access to the
+// database is checked when the refresh is configured, so no user is required here
+func (s *RestoreService) StartRefreshRestore(
+	backupID uuid.UUID,
+	requestDTO restores_core.RestoreBackupRequest,
+) (*restores_core.Restore, error) {
+	backup, err := s.backupService.GetBackup(backupID)
+	if err != nil {
+		return nil, err
 	}
 
-	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(
-		*database.WorkspaceID,
-		user,
-	)
+	backupDatabase, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !canAccess {
-		return errors.New("insufficient permissions to restore this backup")
+
+	return s.startRestore(nil, backup, backupDatabase, requestDTO)
+}
+
+// StartPlanRestore starts a restore of a restore plan on behalf of the user who created the
+// plan, so later steps are checked against the permissions of that user when they start
+func (s *RestoreService) StartPlanRestore(
+	user *users_models.User,
+	backupID uuid.UUID,
+	requestDTO restores_core.RestoreBackupRequest,
+) (*restores_core.Restore, error) {
+	backup, backupDatabase, err := s.getBackupToRestore(user, backupID)
+	if err != nil {
+		return nil, err
 	}
 
-	backupDatabase, err := s.databaseService.GetDatabase(user, backup.DatabaseID)
+	restore, err := s.startRestore(user, backup, backupDatabase, requestDTO)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Database restored from backup %s by restore plan for database: %s",
+			backupID.String(),
+			backupDatabase.Name,
+		),
+		&user.ID,
+		backupDatabase.WorkspaceID,
+	)
+
+	return restore, nil
+}
+
+func (s *RestoreService) GetRestoreByID(restoreID uuid.UUID) (*restores_core.Restore, error) {
+	return s.restoreRepository.FindByID(restoreID)
+}
+
+func (s *RestoreService) startRestore(
+	user *users_models.User,
+	backup *backups_core.Backup,
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) (*restores_core.Restore, error) {
 	if config.GetEnv().IsCloud {
 		// in cloud mode we use only single thread mode,
 		// because otherwise we will exhaust local storage
@@ -135,45 +188,64 @@ func (s *RestoreService) RestoreBackupWithAuth(
 		requestDTO.PostgresqlDatabase.CpuCount = 1
 	}
 
-	if err := s.validateVersionCompatibility(backupDatabase, requestDTO); err != nil {
-		return err
+	if err := s.validateRestoreTarget(backupDatabase, requestDTO); err != nil {
+		return nil, err
 	}
 
-	// Validate disk space before starting restore
-	if err := s.validateDiskSpace(backup, requestDTO); err != nil {
-		return err
+	prodDatabase, err := s.checkTargetEnvironment(user, backupDatabase, requestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	report := s.runPreRestoreChecks(backup, backupDatabase, requestDTO)
+	if report.IsBlocked {
+		return nil, &restores_core.PreRestoreChecksError{Report: report}
 	}
 
 	// Validate no parallel restores for the same database
 	if err := s.validateNoParallelRestores(backup.DatabaseID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create restore record with the request configuration
 	restore := restores_core.Restore{
-		ID:                 uuid.New(),
-		Status:             restores_core.RestoreStatusInProgress,
-		BackupID:           backup.ID,
-		Backup:             backup,
-		CreatedAt:          time.Now().UTC(),
-		RestoreDurationMs:  0,
-		FailMessage:        nil,
-		PostgresqlDatabase: requestDTO.PostgresqlDatabase,
-		MysqlDatabase:      requestDTO.MysqlDatabase,
-		MariadbDatabase:    requestDTO.MariadbDatabase,
-		MongodbDatabase:    requestDTO.MongodbDatabase,
+		ID:                    uuid.New(),
+		Status:                restores_core.RestoreStatusInProgress,
+		BackupID:              backup.ID,
+		Backup:                backup,
+		CreatedAt:             time.Now().UTC(),
+		RestoreDurationMs:     0,
+		FailMessage:           nil,
+		PostgresqlDatabase:    requestDTO.PostgresqlDatabase,
+		MysqlDatabase:         requestDTO.MysqlDatabase,
+		MariadbDatabase:       requestDTO.MariadbDatabase,
+		MongodbDatabase:       requestDTO.MongodbDatabase,
+		FilesystemDatabase:    requestDTO.FilesystemDatabase,
+		ElasticsearchDatabase: requestDTO.ElasticsearchDatabase,
+		InfluxdbDatabase:      requestDTO.InfluxdbDatabase,
+		CassandraDatabase:     requestDTO.CassandraDatabase,
+		CockroachdbDatabase:   requestDTO.CockroachdbDatabase,
+		Neo4jDatabase:         requestDTO.Neo4jDatabase,
+		IsMasked:              requestDTO.IsApplyMasking,
 	}
 
 	if err := s.restoreRepository.Save(&restore); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Prepare database cache with credentials from the request
 	dbCache := &restoring.RestoreDatabaseCache{
-		PostgresqlDatabase: requestDTO.PostgresqlDatabase,
-		MysqlDatabase:      requestDTO.MysqlDatabase,
-		MariadbDatabase:    requestDTO.MariadbDatabase,
-		MongodbDatabase:    requestDTO.MongodbDatabase,
+		PostgresqlDatabase:    requestDTO.PostgresqlDatabase,
+		MysqlDatabase:         requestDTO.MysqlDatabase,
+		MariadbDatabase:       requestDTO.MariadbDatabase,
+		MongodbDatabase:       requestDTO.MongodbDatabase,
+		FilesystemDatabase:    requestDTO.FilesystemDatabase,
+		ElasticsearchDatabase: requestDTO.ElasticsearchDatabase,
+		InfluxdbDatabase:      requestDTO.InfluxdbDatabase,
+		CassandraDatabase:     requestDTO.CassandraDatabase,
+		CockroachdbDatabase:   requestDTO.CockroachdbDatabase,
+		Neo4jDatabase:         requestDTO.Neo4jDatabase,
+		IsApplyMasking:        requestDTO.IsApplyMasking,
 	}
 
 	// Trigger restore via scheduler
@@ -186,23 +258,29 @@ func (s *RestoreService) RestoreBackupWithAuth(
 		if saveErr := s.restoreRepository.Save(&restore); saveErr != nil {
 			s.logger.Error("Failed to save restore after scheduling error", "error", saveErr)
 		}
-		return err
+		return nil, err
 	}
 
-	s.auditLogService.WriteAuditLog(
-		fmt.Sprintf(
-			"Database restored from backup %s for database: %s",
-			backupID.String(),
-			database.Name,
-		),
-		&user.ID,
-		database.WorkspaceID,
-	)
+	if prodDatabase != nil {
+		s.auditLogService.WriteAuditLog(
+			fmt.Sprintf(
+				"Restore into PROD database %s confirmed, backup %s of database %s",
+				prodDatabase.Name,
+				backup.ID.String(),
+				backupDatabase.Name,
+			),
+			&user.ID,
+			prodDatabase.WorkspaceID,
+		)
+	}
 
-	return nil
+	return &restore, nil
 }
 
-func (s *RestoreService) validateVersionCompatibility(
+// validateRestoreTarget populates versions of the target and rejects target settings the
+// backup cannot be restored with. Checks which may be overridden are in the pre-restore
+// report
+func (s *RestoreService) validateRestoreTarget(
 	backupDatabase *databases.Database,
 	requestDTO restores_core.RestoreBackupRequest,
 ) error {
@@ -247,110 +325,123 @@ func (s *RestoreService) validateVersionCompatibility(
 			return err
 		}
 	}
+	if requestDTO.ElasticsearchDatabase != nil {
+		err := requestDTO.ElasticsearchDatabase.PopulateDbData(
+			s.logger,
+			s.fieldEncryptor,
+			backupDatabase.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if requestDTO.InfluxdbDatabase != nil {
+		err := requestDTO.InfluxdbDatabase.PopulateDbData(
+			s.logger,
+			s.fieldEncryptor,
+			backupDatabase.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if requestDTO.CockroachdbDatabase != nil {
+		err := requestDTO.CockroachdbDatabase.PopulateDbData(
+			s.logger,
+			s.fieldEncryptor,
+			backupDatabase.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if requestDTO.Neo4jDatabase != nil {
+		err := requestDTO.Neo4jDatabase.PopulateDbData(
+			s.logger,
+			s.fieldEncryptor,
+			backupDatabase.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
 
 	switch backupDatabase.Type {
 	case databases.DatabaseTypePostgres:
 		if requestDTO.PostgresqlDatabase == nil {
 			return errors.New("postgresql database configuration is required for restore")
 		}
-		if tools.IsBackupDbVersionHigherThanRestoreDbVersion(
-			backupDatabase.Postgresql.Version,
-			requestDTO.PostgresqlDatabase.Version,
-		) {
-			return errors.New(`backup database version is higher than restore database version. ` +
-				`Should be restored to the same version as the backup database or higher. ` +
-				`For example, you can restore PG 15 backup to PG 15, 16 or higher. But cannot restore to 14 and lower`)
-		}
 	case databases.DatabaseTypeMysql:
 		if requestDTO.MysqlDatabase == nil {
 			return errors.New("mysql database configuration is required for restore")
 		}
-		if tools.IsMysqlBackupVersionHigherThanRestoreVersion(
-			backupDatabase.Mysql.Version,
-			requestDTO.MysqlDatabase.Version,
-		) {
-			return errors.New(`backup database version is higher than restore database version. ` +
-				`Should be restored to the same version as the backup database or higher. ` +
-				`For example, you can restore MySQL 8.0 backup to MySQL 8.0, 8.4 or higher. But cannot restore to 5.7`)
-		}
 	case databases.DatabaseTypeMariadb:
 		if requestDTO.MariadbDatabase == nil {
 			return errors.New("mariadb database configuration is required for restore")
 		}
-		if tools.IsMariadbBackupVersionHigherThanRestoreVersion(
-			backupDatabase.Mariadb.Version,
-			requestDTO.MariadbDatabase.Version,
-		) {
-			return errors.New(`backup database version is higher than restore database version. ` +
-				`Should be restored to the same version as the backup database or higher. ` +
-				`For example, you can restore MariaDB 10.11 backup to MariaDB 10.11, 11.4 or higher. But cannot restore to 10.6`)
-		}
 	case databases.DatabaseTypeMongodb:
 		if requestDTO.MongodbDatabase == nil {
 			return errors.New("mongodb database configuration is required for restore")
 		}
-		if tools.IsMongodbBackupVersionHigherThanRestoreVersion(
-			backupDatabase.Mongodb.Version,
-			requestDTO.MongodbDatabase.Version,
-		) {
-			return errors.New(`backup database version is higher than restore database version. ` +
-				`Should be restored to the same version as the backup database or higher. ` +
-				`For example, you can restore MongoDB 6.0 backup to MongoDB 6.0, 7.0 or higher. But cannot restore to 5.0`)
+	case databases.DatabaseTypeFilesystem:
+		if requestDTO.FilesystemDatabase == nil {
+			return errors.New("filesystem target configuration is required for restore")
+		}
+		if config.GetEnv().IsCloud {
+			return errors.New("filesystem sources are not available in cloud mode")
+		}
+		if err := requestDTO.FilesystemDatabase.CheckPathAllowed(
+			config.GetEnv().FilesystemBackupRoots,
+		); err != nil {
+			return err
+		}
+	case databases.DatabaseTypeElasticsearch:
+		if requestDTO.ElasticsearchDatabase == nil {
+			return errors.New("elasticsearch cluster configuration is required for restore")
+		}
+		if err := backupDatabase.Elasticsearch.CheckRestoreCompatibility(
+			requestDTO.ElasticsearchDatabase,
+		); err != nil {
+			return err
+		}
+	case databases.DatabaseTypeInfluxdb:
+		if requestDTO.InfluxdbDatabase == nil {
+			return errors.New("influxdb server configuration is required for restore")
+		}
+		if err := backupDatabase.Influxdb.CheckRestoreCompatibility(
+			requestDTO.InfluxdbDatabase,
+		); err != nil {
+			return err
+		}
+	case databases.DatabaseTypeCassandra:
+		if requestDTO.CassandraDatabase == nil {
+			return errors.New("cassandra cluster configuration is required for restore")
+		}
+		if err := requestDTO.CassandraDatabase.ValidateRestoreTarget(); err != nil {
+			return err
+		}
+	case databases.DatabaseTypeCockroachdb:
+		if requestDTO.CockroachdbDatabase == nil {
+			return errors.New("cockroachdb cluster configuration is required for restore")
+		}
+		if err := backupDatabase.Cockroachdb.CheckRestoreCompatibility(
+			requestDTO.CockroachdbDatabase,
+		); err != nil {
+			return err
+		}
+	case databases.DatabaseTypeNeo4j:
+		if requestDTO.Neo4jDatabase == nil {
+			return errors.New("neo4j server configuration is required for restore")
+		}
+		if err := requestDTO.Neo4jDatabase.ValidateRestoreTarget(); err != nil {
+			return err
 		}
-	}
-	return nil
-}
-
-func (s *RestoreService) validateDiskSpace(
-	backup *backups_core.Backup,
-	requestDTO restores_core.RestoreBackupRequest,
-) error {
-	// Only validate disk space for PostgreSQL when file-based restore is needed:
-	// - CPU > 1 (parallel jobs require file)
-	// - IsExcludeExtensions (TOC filtering requires file)
-	// Other databases and PostgreSQL with CPU=1 without extension exclusion stream directly
-	if requestDTO.PostgresqlDatabase == nil {
-		return nil
-	}
-
-	needsFileBased := requestDTO.PostgresqlDatabase.CpuCount > 1 ||
-		requestDTO.PostgresqlDatabase.IsExcludeExtensions
-	if !needsFileBased {
-		return nil
-	}
-
-	diskUsage, err := s.diskService.GetDiskUsage()
-	if err != nil {
-		return fmt.Errorf("failed to check disk space: %w", err)
-	}
-
-	// Convert backup size from MB to bytes
-	backupSizeBytes := int64(backup.BackupSizeMb * 1024 * 1024)
-
-	// Calculate required space: backup size + 10% buffer
-	bufferBytes := int64(float64(backupSizeBytes) * 0.1)
-	requiredBytes := backupSizeBytes + bufferBytes
-
-	// Ensure minimum of 1 GB total (even if backup is small)
-	minRequiredBytes := int64(1024 * 1024 * 1024) // 1 GB
-	if requiredBytes < minRequiredBytes {
-		requiredBytes = minRequiredBytes
 	}
 
-	// Check if there's enough free space
-	if diskUsage.FreeSpaceBytes < requiredBytes {
-		backupSizeGB := float64(backupSizeBytes) / (1024 * 1024 * 1024)
-		bufferSizeGB := float64(bufferBytes) / (1024 * 1024 * 1024)
-		requiredGB := float64(requiredBytes) / (1024 * 1024 * 1024)
-		availableGB := float64(diskUsage.FreeSpaceBytes) / (1024 * 1024 * 1024)
-
-		return fmt.Errorf(
-			"to restore this backup, %.1f GB (%.1f GB backup + %.1f GB buffer) is required, but only %.1f GB is available. Please free up disk space before restoring",
-			requiredGB,
-			backupSizeGB,
-			bufferSizeGB,
-			availableGB,
-		)
+	if requestDTO.IsApplyMasking {
+		if err := s.maskingService.ValidateCanApplyMasking(backupDatabase); err != nil {
+			return err
+		}
 	}
 
 	return nil
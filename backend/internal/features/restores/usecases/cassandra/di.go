@@ -0,0 +1,15 @@
+package usecases_cassandra
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/logger"
+)
+
+var restoreCassandraBackupUsecase = &RestoreCassandraBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+}
+
+func GetRestoreCassandraBackupUsecase() *RestoreCassandraBackupUsecase {
+	return restoreCassandraBackupUsecase
+}
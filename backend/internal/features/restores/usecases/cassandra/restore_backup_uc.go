@@ -0,0 +1,365 @@
+package usecases_cassandra
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	cassandratypes "databasus-backend/internal/features/databases/databases/cassandra"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	util_encryption "databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+)
+
+const (
+	restoreTimeout          = 23 * time.Hour
+	defaultStagingDirectory = "/var/tmp"
+)
+
+// RestoreCassandraBackupUsecase uploads SSTables of each backed up node to a node of the
+// target cluster and loads them with sstableloader, which streams rows to the replicas
+// owning them. Keyspaces and tables must exist in the target cluster
+type RestoreCassandraBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+}
+
+func (uc *RestoreCassandraBackupUsecase) Execute(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	restoringToDB *databases.Database,
+	backupConfig *backups_config.BackupConfig,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	if originalDB.Type != databases.DatabaseTypeCassandra {
+		return errors.New("database type not supported")
+	}
+
+	uc.logger.Info(
+		"Restoring Cassandra backup via sstableloader",
+		"restoreId", restore.ID,
+		"backupId", backup.ID,
+	)
+
+	target := restoringToDB.Cassandra
+	if target == nil {
+		return fmt.Errorf("cassandra configuration is required for restore")
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, restoreTimeout)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parentCtx.Done():
+				cancel()
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	client, err := target.NewNodeClient(util_encryption.GetFieldEncryptor(), restoringToDB.ID)
+	if err != nil {
+		return err
+	}
+
+	restoreDir, err := os.MkdirTemp(config.GetEnv().TempFolder, "cassandra_restore_")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(restoreDir); err != nil {
+			uc.logger.Error("Failed to remove Cassandra restore directory", "error", err)
+		}
+	}()
+
+	restoreErr := uc.extractBackup(ctx, backup, storage, restoreDir)
+	if restoreErr == nil {
+		restoreErr = uc.loadSnapshots(ctx, client, target, restore, restoreDir)
+	}
+
+	// Check for cancellation
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fmt.Errorf("restore cancelled")
+		}
+	default:
+	}
+
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("restore cancelled due to shutdown")
+	}
+
+	return restoreErr
+}
+
+// loadSnapshots spreads backed up nodes over target nodes, each target node loads the
+// SSTables uploaded to it. Nodes which failed during the backup are skipped
+func (uc *RestoreCassandraBackupUsecase) loadSnapshots(
+	ctx context.Context,
+	client *cassandratypes.NodeClient,
+	target *cassandratypes.CassandraDatabase,
+	restore restores_core.Restore,
+	restoreDir string,
+) error {
+	manifest, err := readManifest(restoreDir)
+	if err != nil {
+		return err
+	}
+
+	sourceNodes := manifest.GetCompletedNodes()
+	if len(sourceNodes) < len(manifest.Nodes) {
+		uc.logger.Warn(
+			"Backup is partial, some nodes are not restored",
+			"completedNodes", len(sourceNodes),
+			"allNodes", len(manifest.Nodes),
+		)
+	}
+
+	stagingDirectory := target.StagingDirectory
+	if stagingDirectory == "" {
+		stagingDirectory = defaultStagingDirectory
+	}
+	stagingRoot := path.Join(stagingDirectory, "databasus-restore-"+restore.ID.String())
+
+	targetNodes := target.NodesList()
+
+	for index, sourceNode := range sourceNodes {
+		loaderNode := targetNodes[index%len(targetNodes)]
+		nodeDir := filepath.Join(restoreDir, cassandratypes.NodesDirectory, sourceNode)
+		stagingDir := path.Join(stagingRoot, strconv.Itoa(index))
+
+		err := uc.loadNode(ctx, client, loaderNode, targetNodes, nodeDir, stagingDir)
+
+		if removeErr := client.RemoveDirectory(
+			context.WithoutCancel(ctx),
+			loaderNode,
+			stagingRoot,
+		); removeErr != nil {
+			uc.logger.Error(
+				"Failed to remove staging directory",
+				"node", loaderNode,
+				"directory", stagingRoot,
+				"error", removeErr,
+			)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to restore SSTables of node %s: %w", sourceNode, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *RestoreCassandraBackupUsecase) loadNode(
+	ctx context.Context,
+	client *cassandratypes.NodeClient,
+	loaderNode string,
+	targetNodes []string,
+	nodeDir string,
+	stagingDir string,
+) error {
+	tables, err := listTables(nodeDir)
+	if err != nil {
+		return err
+	}
+
+	if len(tables) == 0 {
+		return nil
+	}
+
+	uc.logger.Info(
+		"Uploading SSTables to target node",
+		"node", loaderNode,
+		"tables", len(tables),
+	)
+
+	archiveReader, archiveWriter := io.Pipe()
+	go func() {
+		archiveErr := files_utils.WriteTarArchive(
+			ctx,
+			archiveWriter,
+			nodeDir,
+			allEntries{},
+			func(message string) {
+				uc.logger.Warn("SSTable skipped", "message", message)
+			},
+		)
+		_ = archiveWriter.CloseWithError(archiveErr)
+	}()
+
+	err = client.Upload(ctx, loaderNode, stagingDir, archiveReader)
+	_ = archiveReader.Close()
+	if err != nil {
+		return fmt.Errorf("failed to upload SSTables: %w", err)
+	}
+
+	for _, table := range tables {
+		uc.logger.Info("Loading SSTables", "node", loaderNode, "table", table)
+
+		if err := client.LoadTable(
+			ctx,
+			loaderNode,
+			path.Join(stagingDir, table),
+			targetNodes,
+		); err != nil {
+			return fmt.Errorf("failed to load table %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *RestoreCassandraBackupUsecase) extractBackup(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+	restoreDir string,
+) error {
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	rawReader, err := storage.GetFile(fieldEncryptor, backup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() {
+		if err := rawReader.Close(); err != nil {
+			uc.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	var inputReader io.Reader = rawReader
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		decryptReader, err := uc.setupDecryption(rawReader, backup)
+		if err != nil {
+			return fmt.Errorf("failed to setup decryption: %w", err)
+		}
+		inputReader = decryptReader
+	}
+
+	if err := files_utils.ExtractTarArchive(ctx, inputReader, restoreDir); err != nil {
+		return fmt.Errorf("failed to extract backup: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *RestoreCassandraBackupUsecase) setupDecryption(
+	reader io.Reader,
+	backup *backups_core.Backup,
+) (io.Reader, error) {
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		return nil, errors.New("encrypted backup missing salt or IV")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	decryptReader, err := encryption.NewDecryptionReader(
+		reader,
+		masterKey,
+		backup.ID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption reader: %w", err)
+	}
+
+	return decryptReader, nil
+}
+
+func readManifest(restoreDir string) (*cassandratypes.SnapshotManifest, error) {
+	content, err := os.ReadFile(filepath.Join(restoreDir, cassandratypes.ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("backup has no manifest: %w", err)
+	}
+
+	var manifest cassandratypes.SnapshotManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// listTables returns <keyspace>/<table> paths of the node directory, sstableloader takes
+// keyspace and table names from the last two directories
+func listTables(nodeDir string) ([]string, error) {
+	keyspaces, err := os.ReadDir(nodeDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tables := []string{}
+	for _, keyspace := range keyspaces {
+		if !keyspace.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(nodeDir, keyspace.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				tables = append(tables, keyspace.Name()+"/"+entry.Name())
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+// allEntries uploads the whole node directory
+type allEntries struct{}
+
+func (allEntries) IsExcluded(_ string) bool { return false }
+
+func (allEntries) IsIncluded(_ string) bool { return true }
@@ -0,0 +1,15 @@
+package usecases_cockroachdb
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/logger"
+)
+
+var restoreCockroachdbBackupUsecase = &RestoreCockroachdbBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+}
+
+func GetRestoreCockroachdbBackupUsecase() *RestoreCockroachdbBackupUsecase {
+	return restoreCockroachdbBackupUsecase
+}
@@ -0,0 +1,296 @@
+package usecases_cockroachdb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	cockroachdbtypes "databasus-backend/internal/features/databases/databases/cockroachdb"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	util_encryption "databasus-backend/internal/util/encryption"
+)
+
+const (
+	restoreTimeout  = 23 * time.Hour
+	jobPollInterval = 5 * time.Second
+	// jobCancelTimeout bounds cancelling the job of a cancelled restore
+	jobCancelTimeout = 1 * time.Minute
+	// maxManifestBytes guards against reading a wrong file of the storage into memory
+	maxManifestBytes = 16 * 1024 * 1024
+)
+
+// RestoreCockroachdbBackupUsecase runs RESTORE from the collection of the backup in the
+// backup storage. Nodes of the target cluster read the storage themselves
+type RestoreCockroachdbBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+}
+
+func (uc *RestoreCockroachdbBackupUsecase) Execute(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	restoringToDB *databases.Database,
+	backupConfig *backups_config.BackupConfig,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	if originalDB.Type != databases.DatabaseTypeCockroachdb {
+		return errors.New("database type not supported")
+	}
+
+	uc.logger.Info(
+		"Restoring CockroachDB backup via RESTORE statement",
+		"restoreId", restore.ID,
+		"backupId", backup.ID,
+	)
+
+	target := restoringToDB.Cockroachdb
+	if target == nil {
+		return fmt.Errorf("cockroachdb configuration is required for restore")
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, restoreTimeout)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parentCtx.Done():
+				cancel()
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	manifest, err := uc.readManifest(backup, storage)
+	if err != nil {
+		return err
+	}
+
+	restoreErr := uc.restoreCollection(ctx, target, restoringToDB, storage, manifest)
+
+	// Check for cancellation
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fmt.Errorf("restore cancelled")
+		}
+	default:
+	}
+
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("restore cancelled due to shutdown")
+	}
+
+	return restoreErr
+}
+
+func (uc *RestoreCockroachdbBackupUsecase) restoreCollection(
+	ctx context.Context,
+	target *cockroachdbtypes.CockroachdbDatabase,
+	restoringToDB *databases.Database,
+	storage *storages.Storage,
+	manifest *cockroachdbtypes.BackupManifest,
+) error {
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+
+	uri, err := getStorageURI(storage, fieldEncryptor, manifest.Directory)
+	if err != nil {
+		return err
+	}
+
+	client, err := target.NewJobClient(ctx, uc.logger, fieldEncryptor, restoringToDB.ID)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	jobID, err := client.StartRestore(ctx, cockroachdbtypes.BackupOptions{
+		URI:                  uri,
+		EncryptionPassphrase: manifest.EncryptionPassphrase,
+		Database:             manifest.Database,
+		NewDatabaseName:      target.RestoreDatabaseName,
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("CockroachDB restore job started", "jobId", jobID)
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := client.GetJob(ctx, jobID)
+		if err != nil {
+			if ctx.Err() != nil {
+				uc.cancelJob(target, restoringToDB, jobID)
+			}
+			return err
+		}
+
+		if job.IsFinished() {
+			if job.Status != cockroachdbtypes.JobStatusSucceeded {
+				return fmt.Errorf(
+					"restore job %d finished with status %s: %s",
+					jobID,
+					job.Status,
+					job.Error,
+				)
+			}
+
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.cancelJob(target, restoringToDB, jobID)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// cancelJob stops the restore job of a cancelled restore, over a new connection since
+// pgx closes the cancelled one
+func (uc *RestoreCockroachdbBackupUsecase) cancelJob(
+	target *cockroachdbtypes.CockroachdbDatabase,
+	restoringToDB *databases.Database,
+	jobID int64,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobCancelTimeout)
+	defer cancel()
+
+	client, err := target.NewJobClient(
+		ctx,
+		uc.logger,
+		util_encryption.GetFieldEncryptor(),
+		restoringToDB.ID,
+	)
+	if err == nil {
+		err = client.CancelJob(ctx, jobID)
+		client.Close()
+	}
+
+	if err != nil {
+		uc.logger.Error("Failed to cancel restore job", "jobId", jobID, "error", err)
+	}
+}
+
+func (uc *RestoreCockroachdbBackupUsecase) readManifest(
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) (*cockroachdbtypes.BackupManifest, error) {
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	rawReader, err := storage.GetFile(fieldEncryptor, backup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() {
+		if err := rawReader.Close(); err != nil {
+			uc.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	var inputReader io.Reader = rawReader
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		decryptReader, err := uc.setupDecryption(rawReader, backup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup decryption: %w", err)
+		}
+		inputReader = decryptReader
+	}
+
+	var manifest cockroachdbtypes.BackupManifest
+	if err := json.NewDecoder(io.LimitReader(inputReader, maxManifestBytes)).
+		Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to read backup manifest: %w", err)
+	}
+
+	if manifest.Directory == "" {
+		return nil, errors.New("backup file is not a CockroachDB backup manifest")
+	}
+
+	return &manifest, nil
+}
+
+func (uc *RestoreCockroachdbBackupUsecase) setupDecryption(
+	reader io.Reader,
+	backup *backups_core.Backup,
+) (io.Reader, error) {
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		return nil, errors.New("encrypted backup missing salt or IV")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	decryptReader, err := encryption.NewDecryptionReader(
+		reader,
+		masterKey,
+		backup.ID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption reader: %w", err)
+	}
+
+	return decryptReader, nil
+}
+
+// getStorageURI maps the storage to the URI of the collection. Only storages the nodes can
+// write to themselves are supported
+func getStorageURI(
+	storage *storages.Storage,
+	encryptor util_encryption.FieldEncryptor,
+	directory string,
+) (string, error) {
+	switch storage.Type {
+	case storages.StorageTypeS3:
+		return cockroachdbtypes.BuildS3URI(storage.S3Storage, encryptor, directory)
+	case storages.StorageTypeAzureBlob:
+		return cockroachdbtypes.BuildAzureURI(storage.AzureBlobStorage, encryptor, directory)
+	default:
+		return "", fmt.Errorf(
+			"CockroachDB writes backups into the storage itself, only S3 and Azure Blob "+
+				"storages are supported, not %s",
+			storage.Type,
+		)
+	}
+}
@@ -1,9 +1,15 @@
 package usecases
 
 import (
+	usecases_cassandra "databasus-backend/internal/features/restores/usecases/cassandra"
+	usecases_cockroachdb "databasus-backend/internal/features/restores/usecases/cockroachdb"
+	usecases_elasticsearch "databasus-backend/internal/features/restores/usecases/elasticsearch"
+	usecases_filesystem "databasus-backend/internal/features/restores/usecases/filesystem"
+	usecases_influxdb "databasus-backend/internal/features/restores/usecases/influxdb"
 	usecases_mariadb "databasus-backend/internal/features/restores/usecases/mariadb"
 	usecases_mongodb "databasus-backend/internal/features/restores/usecases/mongodb"
 	usecases_mysql "databasus-backend/internal/features/restores/usecases/mysql"
+	usecases_neo4j "databasus-backend/internal/features/restores/usecases/neo4j"
 	usecases_postgresql "databasus-backend/internal/features/restores/usecases/postgresql"
 )
 
@@ -12,6 +18,12 @@ var restoreBackupUsecase = &RestoreBackupUsecase{
 	usecases_mysql.GetRestoreMysqlBackupUsecase(),
 	usecases_mariadb.GetRestoreMariadbBackupUsecase(),
 	usecases_mongodb.GetRestoreMongodbBackupUsecase(),
+	usecases_filesystem.GetRestoreFilesystemBackupUsecase(),
+	usecases_elasticsearch.GetRestoreElasticsearchBackupUsecase(),
+	usecases_influxdb.GetRestoreInfluxdbBackupUsecase(),
+	usecases_cassandra.GetRestoreCassandraBackupUsecase(),
+	usecases_cockroachdb.GetRestoreCockroachdbBackupUsecase(),
+	usecases_neo4j.GetRestoreNeo4jBackupUsecase(),
 }
 
 func GetRestoreBackupUsecase() *RestoreBackupUsecase {
@@ -0,0 +1,15 @@
+package usecases_elasticsearch
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/logger"
+)
+
+var restoreElasticsearchBackupUsecase = &RestoreElasticsearchBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+}
+
+func GetRestoreElasticsearchBackupUsecase() *RestoreElasticsearchBackupUsecase {
+	return restoreElasticsearchBackupUsecase
+}
@@ -0,0 +1,271 @@
+package usecases_elasticsearch
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	elasticsearchtypes "databasus-backend/internal/features/databases/databases/elasticsearch"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	util_encryption "databasus-backend/internal/util/encryption"
+)
+
+const (
+	restoreTimeout = 23 * time.Hour
+	// maxManifestBytes guards against reading a wrong file of the storage into memory
+	maxManifestBytes = 16 * 1024 * 1024
+)
+
+// RestoreElasticsearchBackupUsecase restores a snapshot from the snapshot repository of
+// the target cluster. The target must have the repository of the source cluster
+// registered, its name may differ
+type RestoreElasticsearchBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+}
+
+func (uc *RestoreElasticsearchBackupUsecase) Execute(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	restoringToDB *databases.Database,
+	backupConfig *backups_config.BackupConfig,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	if originalDB.Type != databases.DatabaseTypeElasticsearch {
+		return errors.New("database type not supported")
+	}
+
+	uc.logger.Info(
+		"Restoring Elasticsearch backup via snapshot API",
+		"restoreId", restore.ID,
+		"backupId", backup.ID,
+	)
+
+	target := restoringToDB.Elasticsearch
+	if target == nil {
+		return fmt.Errorf("elasticsearch configuration is required for restore")
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, restoreTimeout)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parentCtx.Done():
+				cancel()
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	manifest, err := uc.readManifest(backup, storage)
+	if err != nil {
+		return err
+	}
+
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	client, err := target.NewSnapshotClient(fieldEncryptor, restoringToDB.ID)
+	if err != nil {
+		return err
+	}
+
+	restoreErr := uc.restoreSnapshot(ctx, client, target, manifest)
+
+	// Check for cancellation
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fmt.Errorf("restore cancelled")
+		}
+	default:
+	}
+
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("restore cancelled due to shutdown")
+	}
+
+	return restoreErr
+}
+
+func (uc *RestoreElasticsearchBackupUsecase) restoreSnapshot(
+	ctx context.Context,
+	client *elasticsearchtypes.SnapshotClient,
+	target *elasticsearchtypes.ElasticsearchDatabase,
+	manifest *elasticsearchtypes.SnapshotManifest,
+) error {
+	repository := target.Repository
+	if repository == "" {
+		repository = manifest.Repository
+	}
+
+	if _, err := client.GetSnapshot(ctx, repository, manifest.Snapshot); err != nil {
+		return fmt.Errorf(
+			"snapshot %s is not available in repository %s of the target cluster, "+
+				"register the repository of the source cluster there: %w",
+			manifest.Snapshot,
+			repository,
+			err,
+		)
+	}
+
+	indices, dataStreams := getRestoreTargets(manifest)
+	if len(indices) == 0 && len(dataStreams) == 0 {
+		return errors.New("snapshot contains no indices or data streams to restore")
+	}
+
+	isRenaming := target.RestoreIndexPrefix != ""
+
+	if target.IsDeleteExistingIndices && !isRenaming {
+		uc.logger.Info(
+			"Deleting existing indices before restore",
+			"indices", len(indices),
+			"dataStreams", len(dataStreams),
+		)
+
+		if err := client.DeleteDataStreams(ctx, dataStreams); err != nil {
+			return fmt.Errorf("failed to delete existing data streams: %w", err)
+		}
+
+		if err := client.DeleteIndices(ctx, indices); err != nil {
+			return fmt.Errorf("failed to delete existing indices: %w", err)
+		}
+	}
+
+	request := elasticsearchtypes.RestoreSnapshotRequest{
+		Indices:            strings.Join(append(dataStreams, indices...), ","),
+		IncludeGlobalState: manifest.IncludeGlobalState && target.IsIncludeGlobalState,
+		// aliases keep their names, they would point to both copies after renaming
+		IncludeAliases: !isRenaming,
+	}
+
+	if isRenaming {
+		request.RenamePattern = "(.+)"
+		request.RenameReplacement = target.RestoreIndexPrefix + "$1"
+	}
+
+	if err := client.RestoreSnapshot(ctx, repository, manifest.Snapshot, request); err != nil {
+		return fmt.Errorf("failed to restore snapshot %s: %w", manifest.Snapshot, err)
+	}
+
+	return nil
+}
+
+func (uc *RestoreElasticsearchBackupUsecase) readManifest(
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) (*elasticsearchtypes.SnapshotManifest, error) {
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	rawReader, err := storage.GetFile(fieldEncryptor, backup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() {
+		if err := rawReader.Close(); err != nil {
+			uc.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	var inputReader io.Reader = rawReader
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		decryptReader, err := uc.setupDecryption(rawReader, backup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup decryption: %w", err)
+		}
+		inputReader = decryptReader
+	}
+
+	var manifest elasticsearchtypes.SnapshotManifest
+	if err := json.NewDecoder(io.LimitReader(inputReader, maxManifestBytes)).
+		Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	if manifest.Snapshot == "" {
+		return nil, errors.New("backup file is not a snapshot manifest")
+	}
+
+	return &manifest, nil
+}
+
+func (uc *RestoreElasticsearchBackupUsecase) setupDecryption(
+	reader io.Reader,
+	backup *backups_core.Backup,
+) (io.Reader, error) {
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		return nil, errors.New("encrypted backup missing salt or IV")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	decryptReader, err := encryption.NewDecryptionReader(
+		reader,
+		masterKey,
+		backup.ID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption reader: %w", err)
+	}
+
+	return decryptReader, nil
+}
+
+// getRestoreTargets skips hidden and system indices, backing indices of data streams
+// are among them and come back with their data streams
+func getRestoreTargets(manifest *elasticsearchtypes.SnapshotManifest) ([]string, []string) {
+	indices := make([]string, 0, len(manifest.Indices))
+	for _, index := range manifest.Indices {
+		if !strings.HasPrefix(index, ".") {
+			indices = append(indices, index)
+		}
+	}
+
+	dataStreams := make([]string, 0, len(manifest.DataStreams))
+	for _, dataStream := range manifest.DataStreams {
+		if !strings.HasPrefix(dataStream, ".") {
+			dataStreams = append(dataStreams, dataStream)
+		}
+	}
+
+	return indices, dataStreams
+}
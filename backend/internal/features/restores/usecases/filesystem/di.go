@@ -0,0 +1,15 @@
+package usecases_filesystem
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/logger"
+)
+
+var restoreFilesystemBackupUsecase = &RestoreFilesystemBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+}
+
+func GetRestoreFilesystemBackupUsecase() *RestoreFilesystemBackupUsecase {
+	return restoreFilesystemBackupUsecase
+}
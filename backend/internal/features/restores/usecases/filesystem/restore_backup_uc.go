@@ -0,0 +1,225 @@
+package usecases_filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	util_encryption "databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+)
+
+const (
+	restoreTimeout = 23 * time.Hour
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type RestoreFilesystemBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+}
+
+func (uc *RestoreFilesystemBackupUsecase) Execute(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	restoringToDB *databases.Database,
+	backupConfig *backups_config.BackupConfig,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	if originalDB.Type != databases.DatabaseTypeFilesystem {
+		return errors.New("database type not supported")
+	}
+
+	uc.logger.Info(
+		"Restoring filesystem backup",
+		"restoreId", restore.ID,
+		"backupId", backup.ID,
+	)
+
+	target := restoringToDB.Filesystem
+	if target == nil {
+		return fmt.Errorf("filesystem configuration is required for restore")
+	}
+
+	if err := target.CheckPathAllowed(config.GetEnv().FilesystemBackupRoots); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(target.Path)
+	if err != nil {
+		return fmt.Errorf("target path is not accessible: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("target path %s is not a directory", target.Path)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, restoreTimeout)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parentCtx.Done():
+				cancel()
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	// Stream backup directly from storage
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	rawReader, err := storage.GetFile(fieldEncryptor, backup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() {
+		if err := rawReader.Close(); err != nil {
+			uc.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	var inputReader io.Reader = rawReader
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		decryptReader, err := uc.setupDecryption(rawReader, backup)
+		if err != nil {
+			return fmt.Errorf("failed to setup decryption: %w", err)
+		}
+		inputReader = decryptReader
+	}
+
+	archiveReader, closeArchive, err := uc.setupDecompression(inputReader)
+	if err != nil {
+		return fmt.Errorf("failed to setup decompression: %w", err)
+	}
+	defer closeArchive()
+
+	if target.IsRemoveExistingFiles {
+		if err := files_utils.CleanFolder(target.Path); err != nil {
+			return fmt.Errorf("failed to remove existing files: %w", err)
+		}
+	}
+
+	extractErr := files_utils.ExtractTarArchive(ctx, archiveReader, target.Path)
+
+	// Check for cancellation
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fmt.Errorf("restore cancelled")
+		}
+	default:
+	}
+
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("restore cancelled due to shutdown")
+	}
+
+	if extractErr != nil {
+		return fmt.Errorf("failed to extract archive: %w", extractErr)
+	}
+
+	return nil
+}
+
+// setupDecompression detects compression by magic bytes instead of the source config,
+// compression may have been changed after the backup was made
+func (uc *RestoreFilesystemBackupUsecase) setupDecompression(
+	reader io.Reader,
+) (io.Reader, func(), error) {
+	bufferedReader := bufio.NewReader(reader)
+
+	header, err := bufferedReader.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, zstdMagic):
+		decoder, err := zstd.NewReader(bufferedReader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return decoder, decoder.Close, nil
+	case bytes.HasPrefix(header, gzipMagic):
+		decoder, err := gzip.NewReader(bufferedReader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return decoder, func() { _ = decoder.Close() }, nil
+	default:
+		return bufferedReader, func() {}, nil
+	}
+}
+
+func (uc *RestoreFilesystemBackupUsecase) setupDecryption(
+	reader io.Reader,
+	backup *backups_core.Backup,
+) (io.Reader, error) {
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		return nil, errors.New("encrypted backup missing salt or IV")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	decryptReader, err := encryption.NewDecryptionReader(
+		reader,
+		masterKey,
+		backup.ID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption reader: %w", err)
+	}
+
+	return decryptReader, nil
+}
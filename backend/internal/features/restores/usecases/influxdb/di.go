@@ -0,0 +1,15 @@
+package usecases_influxdb
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/logger"
+)
+
+var restoreInfluxdbBackupUsecase = &RestoreInfluxdbBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+}
+
+func GetRestoreInfluxdbBackupUsecase() *RestoreInfluxdbBackupUsecase {
+	return restoreInfluxdbBackupUsecase
+}
@@ -0,0 +1,260 @@
+package usecases_influxdb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	influxdbtypes "databasus-backend/internal/features/databases/databases/influxdb"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	util_encryption "databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	"databasus-backend/internal/util/tools"
+)
+
+const (
+	restoreTimeout = 23 * time.Hour
+)
+
+// RestoreInfluxdbBackupUsecase extracts the backup directory into a temp folder and runs
+// `influx restore` on it
+type RestoreInfluxdbBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+}
+
+func (uc *RestoreInfluxdbBackupUsecase) Execute(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	restoringToDB *databases.Database,
+	backupConfig *backups_config.BackupConfig,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	if originalDB.Type != databases.DatabaseTypeInfluxdb {
+		return errors.New("database type not supported")
+	}
+
+	uc.logger.Info(
+		"Restoring InfluxDB backup via influx restore",
+		"restoreId", restore.ID,
+		"backupId", backup.ID,
+	)
+
+	target := restoringToDB.Influxdb
+	if target == nil {
+		return fmt.Errorf("influxdb configuration is required for restore")
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, restoreTimeout)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parentCtx.Done():
+				cancel()
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	restoreDir, err := os.MkdirTemp(config.GetEnv().TempFolder, "influx_restore_")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(restoreDir); err != nil {
+			uc.logger.Error("Failed to remove InfluxDB restore directory", "error", err)
+		}
+	}()
+
+	token, err := target.DecryptToken(util_encryption.GetFieldEncryptor(), restoringToDB.ID)
+	if err != nil {
+		return err
+	}
+
+	restoreErr := uc.extractBackup(ctx, backup, storage, restoreDir)
+	if restoreErr == nil {
+		restoreErr = uc.runInfluxRestore(ctx, originalDB.Influxdb, target, token, restoreDir)
+	}
+
+	// Check for cancellation
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fmt.Errorf("restore cancelled")
+		}
+	default:
+	}
+
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("restore cancelled due to shutdown")
+	}
+
+	return restoreErr
+}
+
+func (uc *RestoreInfluxdbBackupUsecase) extractBackup(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+	restoreDir string,
+) error {
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	rawReader, err := storage.GetFile(fieldEncryptor, backup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() {
+		if err := rawReader.Close(); err != nil {
+			uc.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	var inputReader io.Reader = rawReader
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		decryptReader, err := uc.setupDecryption(rawReader, backup)
+		if err != nil {
+			return fmt.Errorf("failed to setup decryption: %w", err)
+		}
+		inputReader = decryptReader
+	}
+
+	if err := files_utils.ExtractTarArchive(ctx, inputReader, restoreDir); err != nil {
+		return fmt.Errorf("failed to extract backup: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *RestoreInfluxdbBackupUsecase) runInfluxRestore(
+	ctx context.Context,
+	source *influxdbtypes.InfluxdbDatabase,
+	target *influxdbtypes.InfluxdbDatabase,
+	token string,
+	restoreDir string,
+) error {
+	influxBin := tools.GetInfluxdbExecutable(
+		tools.InfluxdbExecutableInflux,
+		config.GetEnv().EnvMode,
+		config.GetEnv().InfluxdbInstallDir,
+	)
+
+	args := buildInfluxRestoreArgs(source, target, restoreDir)
+
+	uc.logger.Info("Executing InfluxDB restore command", "command", influxBin, "args", args)
+
+	cmd := exec.CommandContext(ctx, influxBin, args...)
+
+	cmd.Env = os.Environ()
+	cmd.Env = append(cmd.Env,
+		"INFLUX_HOST="+target.URL,
+		"INFLUX_TOKEN="+token,
+		"INFLUX_CONFIGS_PATH="+os.DevNull,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf(
+			"%s restore failed: %w\noutput: %s",
+			filepath.Base(influxBin),
+			err,
+			output,
+		)
+	}
+
+	return nil
+}
+
+// buildInfluxRestoreArgs restores the bucket of a bucket backup, optionally renamed and
+// into another org, or all buckets of a full backup. Full restores replace everything
+func buildInfluxRestoreArgs(
+	source *influxdbtypes.InfluxdbDatabase,
+	target *influxdbtypes.InfluxdbDatabase,
+	restoreDir string,
+) []string {
+	args := []string{"restore", restoreDir}
+
+	switch {
+	case target.IsFullRestore:
+		args = append(args, "--full")
+	case source != nil && source.Bucket != "":
+		args = append(args, "--bucket", source.Bucket, "--org", source.Org)
+
+		if target.RestoreBucket != "" {
+			args = append(args, "--new-bucket", target.RestoreBucket)
+		}
+		if target.Org != "" && target.Org != source.Org {
+			args = append(args, "--new-org", target.Org)
+		}
+	}
+
+	if target.SkipTLSVerify {
+		args = append(args, "--skip-verify")
+	}
+
+	return args
+}
+
+func (uc *RestoreInfluxdbBackupUsecase) setupDecryption(
+	reader io.Reader,
+	backup *backups_core.Backup,
+) (io.Reader, error) {
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		return nil, errors.New("encrypted backup missing salt or IV")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	decryptReader, err := encryption.NewDecryptionReader(
+		reader,
+		masterKey,
+		backup.ID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption reader: %w", err)
+	}
+
+	return decryptReader, nil
+}
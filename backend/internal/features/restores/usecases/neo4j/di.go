@@ -0,0 +1,15 @@
+package usecases_neo4j
+
+import (
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/logger"
+)
+
+var restoreNeo4jBackupUsecase = &RestoreNeo4jBackupUsecase{
+	logger.GetLogger(),
+	encryption_secrets.GetSecretKeyService(),
+}
+
+func GetRestoreNeo4jBackupUsecase() *RestoreNeo4jBackupUsecase {
+	return restoreNeo4jBackupUsecase
+}
@@ -0,0 +1,372 @@
+package usecases_neo4j
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+
+	"databasus-backend/internal/config"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	neo4jtypes "databasus-backend/internal/features/databases/databases/neo4j"
+	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	restores_core "databasus-backend/internal/features/restores/core"
+	"databasus-backend/internal/features/storages"
+	util_encryption "databasus-backend/internal/util/encryption"
+)
+
+const (
+	restoreTimeout          = 23 * time.Hour
+	verifyTimeout           = 10 * time.Minute
+	cleanupTimeout          = 5 * time.Minute
+	defaultStagingDirectory = "/var/tmp"
+	archiveHeaderSize       = 512
+)
+
+// RestoreNeo4jBackupUsecase loads a dump into the stopped database, or uploads an
+// online backup to the host and runs `neo4j-admin database restore` on it. The format
+// is detected from the backup content. The restore is verified by waiting for the
+// database to come online and counting its nodes and relationships
+type RestoreNeo4jBackupUsecase struct {
+	logger           *slog.Logger
+	secretKeyService *encryption_secrets.SecretKeyService
+}
+
+func (uc *RestoreNeo4jBackupUsecase) Execute(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	restoringToDB *databases.Database,
+	backupConfig *backups_config.BackupConfig,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	if originalDB.Type != databases.DatabaseTypeNeo4j {
+		return errors.New("database type not supported")
+	}
+
+	uc.logger.Info(
+		"Restoring Neo4j backup via neo4j-admin",
+		"restoreId", restore.ID,
+		"backupId", backup.ID,
+	)
+
+	target := restoringToDB.Neo4j
+	if target == nil {
+		return fmt.Errorf("neo4j configuration is required for restore")
+	}
+
+	client, err := target.NewClient(util_encryption.GetFieldEncryptor(), restoringToDB.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, restoreTimeout)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parentCtx.Done():
+				cancel()
+				return
+			case <-ticker.C:
+				if config.IsShouldShutdown() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	database := getRestoreDatabase(originalDB.Neo4j, target)
+
+	restoreErr := uc.restoreBackup(ctx, client, target, database, restore, backup, storage)
+	if restoreErr == nil {
+		restoreErr = uc.verifyRestore(ctx, client, database)
+	}
+
+	// Check for cancellation
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return fmt.Errorf("restore cancelled")
+		}
+	default:
+	}
+
+	if config.IsShouldShutdown() {
+		return fmt.Errorf("restore cancelled due to shutdown")
+	}
+
+	return restoreErr
+}
+
+func (uc *RestoreNeo4jBackupUsecase) restoreBackup(
+	ctx context.Context,
+	client *neo4jtypes.Client,
+	target *neo4jtypes.Neo4jDatabase,
+	database string,
+	restore restores_core.Restore,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+) error {
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+	rawReader, err := storage.GetFile(fieldEncryptor, backup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup file from storage: %w", err)
+	}
+	defer func() {
+		if err := rawReader.Close(); err != nil {
+			uc.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	var inputReader io.Reader = rawReader
+
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		decryptReader, err := uc.setupDecryption(rawReader, backup)
+		if err != nil {
+			return fmt.Errorf("failed to setup decryption: %w", err)
+		}
+		inputReader = decryptReader
+	}
+
+	bufferedReader := bufio.NewReaderSize(inputReader, archiveHeaderSize)
+	header, err := bufferedReader.Peek(archiveHeaderSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	if neo4jtypes.IsOnlineBackupArchive(header) {
+		return uc.restoreOnlineBackup(ctx, client, target, database, restore, bufferedReader)
+	}
+
+	return uc.loadDump(ctx, client, target, database, bufferedReader)
+}
+
+// loadDump streams the dump from the storage straight into neo4j-admin on the host
+func (uc *RestoreNeo4jBackupUsecase) loadDump(
+	ctx context.Context,
+	client *neo4jtypes.Client,
+	target *neo4jtypes.Neo4jDatabase,
+	database string,
+	reader io.Reader,
+) error {
+	isExisting, err := uc.takeOffline(ctx, client, target, database)
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("Loading Neo4j dump", "database", database)
+
+	_, loadErr := client.Load(ctx, database, reader)
+	if loadErr != nil {
+		loadErr = fmt.Errorf("neo4j-admin database load failed: %w", loadErr)
+	}
+
+	return errors.Join(loadErr, uc.bringOnline(client, target, database, isExisting, loadErr))
+}
+
+// restoreOnlineBackup uploads the backup directory to the staging directory of the host,
+// neo4j-admin restores only from local paths
+func (uc *RestoreNeo4jBackupUsecase) restoreOnlineBackup(
+	ctx context.Context,
+	client *neo4jtypes.Client,
+	target *neo4jtypes.Neo4jDatabase,
+	database string,
+	restore restores_core.Restore,
+	archive io.Reader,
+) error {
+	stagingDirectory := target.StagingDirectory
+	if stagingDirectory == "" {
+		stagingDirectory = defaultStagingDirectory
+	}
+	directory := path.Join(stagingDirectory, "databasus-restore-"+restore.ID.String())
+
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		defer cancel()
+
+		if err := client.RemoveDirectory(cleanupCtx, directory); err != nil {
+			uc.logger.Error(
+				"Failed to remove Neo4j staging directory on the host",
+				"directory", directory,
+				"error", err,
+			)
+		}
+	}()
+
+	uc.logger.Info("Uploading Neo4j backup to the host", "directory", directory)
+	if err := client.Upload(ctx, directory, archive); err != nil {
+		return fmt.Errorf("failed to upload backup to the host: %w", err)
+	}
+
+	isExisting, err := uc.takeOffline(ctx, client, target, database)
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("Restoring Neo4j online backup", "database", database)
+
+	_, restoreErr := client.Restore(ctx, database, directory)
+	if restoreErr != nil {
+		restoreErr = fmt.Errorf("neo4j-admin database restore failed: %w", restoreErr)
+	}
+
+	return errors.Join(
+		restoreErr,
+		uc.bringOnline(client, target, database, isExisting, restoreErr),
+	)
+}
+
+// takeOffline stops the database on Enterprise Edition if it exists, a new database is
+// created after the restore. Community Edition stops the whole service. It returns
+// whether the database existed
+func (uc *RestoreNeo4jBackupUsecase) takeOffline(
+	ctx context.Context,
+	client *neo4jtypes.Client,
+	target *neo4jtypes.Neo4jDatabase,
+	database string,
+) (bool, error) {
+	if target.Edition != neo4jtypes.Neo4jEditionEnterprise {
+		if err := client.StopService(ctx); err != nil {
+			return false, fmt.Errorf("failed to stop service %s: %w", target.ServiceName, err)
+		}
+		return true, nil
+	}
+
+	_, err := client.GetDatabaseStatus(ctx, database)
+	if errors.Is(err, neo4jtypes.ErrDatabaseNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := client.StopDatabase(ctx, database); err != nil {
+		return false, fmt.Errorf("failed to stop database %s: %w", database, err)
+	}
+	return true, nil
+}
+
+// bringOnline starts the database again on its own context, so a cancelled restore does
+// not leave the server stopped. A new database is created only when the restore passed
+func (uc *RestoreNeo4jBackupUsecase) bringOnline(
+	client *neo4jtypes.Client,
+	target *neo4jtypes.Neo4jDatabase,
+	database string,
+	isExisting bool,
+	restoreErr error,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	var err error
+	switch {
+	case target.Edition != neo4jtypes.Neo4jEditionEnterprise:
+		err = client.StartService(ctx)
+	case isExisting:
+		err = client.StartDatabase(ctx, database)
+	case restoreErr == nil:
+		err = client.CreateDatabase(ctx, database)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to bring database %s online: %w", database, err)
+	}
+	return nil
+}
+
+// verifyRestore fails the restore unless the database comes online and answers queries,
+// counts are logged to compare them with the source
+func (uc *RestoreNeo4jBackupUsecase) verifyRestore(
+	ctx context.Context,
+	client *neo4jtypes.Client,
+	database string,
+) error {
+	if err := client.WaitForDatabase(ctx, database, verifyTimeout); err != nil {
+		return fmt.Errorf("restore verification failed: %w", err)
+	}
+
+	nodes, err := client.CountNodes(ctx, database)
+	if err != nil {
+		return fmt.Errorf("restore verification failed: %w", err)
+	}
+
+	relationships, err := client.CountRelationships(ctx, database)
+	if err != nil {
+		return fmt.Errorf("restore verification failed: %w", err)
+	}
+
+	uc.logger.Info(
+		"Neo4j restore verified",
+		"database", database,
+		"nodes", nodes,
+		"relationships", relationships,
+	)
+
+	return nil
+}
+
+// getRestoreDatabase restores under the requested name, the backed up name by default
+func getRestoreDatabase(source, target *neo4jtypes.Neo4jDatabase) string {
+	if target.RestoreDatabase != "" {
+		return target.RestoreDatabase
+	}
+
+	if source != nil && source.Database != "" {
+		return source.Database
+	}
+
+	return target.Database
+}
+
+func (uc *RestoreNeo4jBackupUsecase) setupDecryption(
+	reader io.Reader,
+	backup *backups_core.Backup,
+) (io.Reader, error) {
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		return nil, errors.New("encrypted backup missing salt or IV")
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
+	}
+
+	masterKey, err := uc.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	decryptReader, err := encryption.NewDecryptionReader(
+		reader,
+		masterKey,
+		backup.ID,
+		salt,
+		nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decryption reader: %w", err)
+	}
+
+	return decryptReader, nil
+}
@@ -79,6 +79,18 @@ func (uc *RestorePostgresqlBackupUsecase) Execute(
 		config.GetEnv().PostgresesInstallDir,
 	)
 
+	if originalDB.Postgresql != nil && originalDB.Postgresql.IsTimescaledb() {
+		return uc.restoreTimescaledb(
+			parentCtx,
+			originalDB,
+			pgBin,
+			backup,
+			storage,
+			pg,
+			isExcludeExtensions,
+		)
+	}
+
 	// All PostgreSQL backups are now custom format (-Fc)
 	return uc.restoreCustomType(
 		parentCtx,
@@ -91,6 +103,74 @@ func (uc *RestorePostgresqlBackupUsecase) Execute(
 	)
 }
 
+// restoreTimescaledb wraps pg_restore into timescaledb_pre_restore() and
+// timescaledb_post_restore(). The extension is created upfront with the version of the
+// backup, so its TOC entry is filtered out and the restore always goes via file
+func (uc *RestorePostgresqlBackupUsecase) restoreTimescaledb(
+	parentCtx context.Context,
+	originalDB *databases.Database,
+	pgBin string,
+	backup *backups_core.Backup,
+	storage *storages.Storage,
+	pg *pgtypes.PostgresqlDatabase,
+	isExcludeExtensions bool,
+) error {
+	timescaledbVersion := originalDB.Postgresql.TimescaledbVersion
+	recommendedCpuCount := originalDB.Postgresql.RecommendedRestoreCpuCount()
+
+	uc.logger.Info(
+		"Restoring TimescaleDB backup",
+		"backupId", backup.ID,
+		"timescaledbVersion", timescaledbVersion,
+		"chunksCount", originalDB.Postgresql.TimescaledbChunksCount,
+	)
+
+	if pg.CpuCount < recommendedCpuCount && !config.GetEnv().IsCloud {
+		uc.logger.Info(
+			"CPU count is lower than recommended for the chunks of the backup",
+			"cpuCount", pg.CpuCount,
+			"recommendedCpuCount", recommendedCpuCount,
+		)
+	}
+
+	fieldEncryptor := util_encryption.GetFieldEncryptor()
+
+	if err := pg.PrepareTimescaledbRestore(
+		parentCtx,
+		uc.logger,
+		fieldEncryptor,
+		originalDB.ID,
+		timescaledbVersion,
+	); err != nil {
+		return err
+	}
+
+	defer func() {
+		// parent context may be cancelled already, restoring mode must be left anyway
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		if err := pg.FinishTimescaledbRestore(
+			ctx,
+			uc.logger,
+			fieldEncryptor,
+			originalDB.ID,
+		); err != nil {
+			uc.logger.Error("Failed to finish TimescaleDB restore", "error", err)
+		}
+	}()
+
+	return uc.restoreViaFile(
+		parentCtx,
+		originalDB,
+		pgBin,
+		backup,
+		storage,
+		pg,
+		isExcludeExtensions,
+	)
+}
+
 // restoreCustomType restores a backup in custom type (-Fc)
 func (uc *RestorePostgresqlBackupUsecase) restoreCustomType(
 	parentCtx context.Context,
@@ -486,14 +566,17 @@ func (uc *RestorePostgresqlBackupUsecase) restoreFromStorage(
 	}
 	defer cleanupFunc()
 
+	isTimescaledb := database.Postgresql != nil && database.Postgresql.IsTimescaledb()
+
 	// If excluding extensions, generate filtered TOC list and use it
-	if isExcludeExtensions {
+	if isExcludeExtensions || isTimescaledb {
 		tocListFile, err := uc.generateFilteredTocList(
 			ctx,
 			pgBin,
 			tempBackupFile,
 			pgpassFile,
 			pgConfig,
+			isExcludeExtensions,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to generate filtered TOC list: %w", err)
@@ -902,12 +985,14 @@ func containsIgnoreCase(str, substr string) bool {
 
 // generateFilteredTocList generates a pg_restore TOC list file with extensions filtered out.
 // This is used when isExcludeExtensions is true to skip CREATE EXTENSION statements.
+// The timescaledb extension is always filtered out, it is created before pg_restore.
 func (uc *RestorePostgresqlBackupUsecase) generateFilteredTocList(
 	ctx context.Context,
 	pgBin string,
 	backupFile string,
 	pgpassFile string,
 	pgConfig *pgtypes.PostgresqlDatabase,
+	isExcludeExtensions bool,
 ) (string, error) {
 	uc.logger.Info("Generating filtered TOC list to exclude extensions", "backupFile", backupFile)
 
@@ -933,7 +1018,8 @@ func (uc *RestorePostgresqlBackupUsecase) generateFilteredTocList(
 		// Skip lines that contain " EXTENSION " - this catches both:
 		// - CREATE EXTENSION entries: "3420; 0 0 EXTENSION - uuid-ossp"
 		// - COMMENT ON EXTENSION entries: "3462; 0 0 COMMENT - EXTENSION "uuid-ossp""
-		if strings.Contains(upperLine, " EXTENSION ") {
+		if strings.Contains(upperLine, " EXTENSION ") &&
+			(isExcludeExtensions || strings.Contains(upperLine, "TIMESCALEDB")) {
 			uc.logger.Info("Excluding extension-related entry from restore", "tocLine", trimmedLine)
 			continue
 		}
@@ -8,18 +8,30 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	restores_core "databasus-backend/internal/features/restores/core"
+	usecases_cassandra "databasus-backend/internal/features/restores/usecases/cassandra"
+	usecases_cockroachdb "databasus-backend/internal/features/restores/usecases/cockroachdb"
+	usecases_elasticsearch "databasus-backend/internal/features/restores/usecases/elasticsearch"
+	usecases_filesystem "databasus-backend/internal/features/restores/usecases/filesystem"
+	usecases_influxdb "databasus-backend/internal/features/restores/usecases/influxdb"
 	usecases_mariadb "databasus-backend/internal/features/restores/usecases/mariadb"
 	usecases_mongodb "databasus-backend/internal/features/restores/usecases/mongodb"
 	usecases_mysql "databasus-backend/internal/features/restores/usecases/mysql"
+	usecases_neo4j "databasus-backend/internal/features/restores/usecases/neo4j"
 	usecases_postgresql "databasus-backend/internal/features/restores/usecases/postgresql"
 	"databasus-backend/internal/features/storages"
 )
 
 type RestoreBackupUsecase struct {
-	restorePostgresqlBackupUsecase *usecases_postgresql.RestorePostgresqlBackupUsecase
-	restoreMysqlBackupUsecase      *usecases_mysql.RestoreMysqlBackupUsecase
-	restoreMariadbBackupUsecase    *usecases_mariadb.RestoreMariadbBackupUsecase
-	restoreMongodbBackupUsecase    *usecases_mongodb.RestoreMongodbBackupUsecase
+	restorePostgresqlBackupUsecase    *usecases_postgresql.RestorePostgresqlBackupUsecase
+	restoreMysqlBackupUsecase         *usecases_mysql.RestoreMysqlBackupUsecase
+	restoreMariadbBackupUsecase       *usecases_mariadb.RestoreMariadbBackupUsecase
+	restoreMongodbBackupUsecase       *usecases_mongodb.RestoreMongodbBackupUsecase
+	restoreFilesystemBackupUsecase    *usecases_filesystem.RestoreFilesystemBackupUsecase
+	restoreElasticsearchBackupUsecase *usecases_elasticsearch.RestoreElasticsearchBackupUsecase
+	restoreInfluxdbBackupUsecase      *usecases_influxdb.RestoreInfluxdbBackupUsecase
+	restoreCassandraBackupUsecase     *usecases_cassandra.RestoreCassandraBackupUsecase
+	restoreCockroachdbBackupUsecase   *usecases_cockroachdb.RestoreCockroachdbBackupUsecase
+	restoreNeo4jBackupUsecase         *usecases_neo4j.RestoreNeo4jBackupUsecase
 }
 
 func (uc *RestoreBackupUsecase) Execute(
@@ -74,6 +86,66 @@ func (uc *RestoreBackupUsecase) Execute(
 			backup,
 			storage,
 		)
+	case databases.DatabaseTypeFilesystem:
+		return uc.restoreFilesystemBackupUsecase.Execute(
+			ctx,
+			originalDB,
+			restoringToDB,
+			backupConfig,
+			restore,
+			backup,
+			storage,
+		)
+	case databases.DatabaseTypeElasticsearch:
+		return uc.restoreElasticsearchBackupUsecase.Execute(
+			ctx,
+			originalDB,
+			restoringToDB,
+			backupConfig,
+			restore,
+			backup,
+			storage,
+		)
+	case databases.DatabaseTypeInfluxdb:
+		return uc.restoreInfluxdbBackupUsecase.Execute(
+			ctx,
+			originalDB,
+			restoringToDB,
+			backupConfig,
+			restore,
+			backup,
+			storage,
+		)
+	case databases.DatabaseTypeCassandra:
+		return uc.restoreCassandraBackupUsecase.Execute(
+			ctx,
+			originalDB,
+			restoringToDB,
+			backupConfig,
+			restore,
+			backup,
+			storage,
+		)
+	case databases.DatabaseTypeCockroachdb:
+		return uc.restoreCockroachdbBackupUsecase.Execute(
+			ctx,
+			originalDB,
+			restoringToDB,
+			backupConfig,
+			restore,
+			backup,
+			storage,
+		)
+	case databases.DatabaseTypeNeo4j:
+		return uc.restoreNeo4jBackupUsecase.Execute(
+			ctx,
+			originalDB,
+			restoringToDB,
+			backupConfig,
+			restore,
+			backup,
+			storage,
+		)
 	default:
 		return errors.New("database type not supported")
 	}
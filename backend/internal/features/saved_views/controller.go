@@ -0,0 +1,285 @@
+package saved_views
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SavedViewController struct {
+	savedViewService *SavedViewService
+}
+
+func (c *SavedViewController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/me/views", c.GetViews)
+	router.POST("/users/me/views", c.CreateView)
+	router.PUT("/users/me/views/:id", c.UpdateView)
+	router.DELETE("/users/me/views/:id", c.DeleteView)
+
+	router.GET("/users/me/favorites", c.GetFavorites)
+	router.POST("/users/me/favorites", c.AddFavorite)
+	router.DELETE("/users/me/favorites/:resourceId", c.RemoveFavorite)
+}
+
+// GetViews
+// @Summary Get saved views
+// @Description List own saved views of databases and storages in the workspace, by name
+// @Tags saved-views
+// @Produce json
+// @Param workspace_id query string true "Workspace ID"
+// @Success 200 {array} SavedView
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /users/me/views [get]
+func (c *SavedViewController) GetViews(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request GetWorkspaceItemsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	views, err := c.savedViewService.GetViews(user, request.WorkspaceID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, views)
+}
+
+// CreateView
+// @Summary Save a view
+// @Description Save named list filters of databases or storages of the workspace
+// @Tags saved-views
+// @Accept json
+// @Produce json
+// @Param request body SaveViewRequest true "View"
+// @Success 200 {object} SavedView
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /users/me/views [post]
+func (c *SavedViewController) CreateView(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request SaveViewRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view, err := c.savedViewService.CreateView(user, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, view)
+}
+
+// UpdateView
+// @Summary Update a saved view
+// @Description Change the name and filters of an own saved view
+// @Tags saved-views
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID"
+// @Param request body SaveViewRequest true "View"
+// @Success 200 {object} SavedView
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /users/me/views/{id} [put]
+func (c *SavedViewController) UpdateView(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	viewID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid view ID"})
+		return
+	}
+
+	var request SaveViewRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view, err := c.savedViewService.UpdateView(user, viewID, &request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, view)
+}
+
+// DeleteView
+// @Summary Delete a saved view
+// @Description Delete an own saved view
+// @Tags saved-views
+// @Param id path string true "View ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /users/me/views/{id} [delete]
+func (c *SavedViewController) DeleteView(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	viewID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid view ID"})
+		return
+	}
+
+	if err := c.savedViewService.DeleteView(user, viewID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "view deleted successfully"})
+}
+
+// GetFavorites
+// @Summary Get favorites
+// @Description List own favorite databases and storages of the workspace, oldest first
+// @Tags saved-views
+// @Produce json
+// @Param workspace_id query string true "Workspace ID"
+// @Success 200 {array} FavoriteDTO
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /users/me/favorites [get]
+func (c *SavedViewController) GetFavorites(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request GetWorkspaceItemsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	favorites, err := c.savedViewService.GetFavorites(user, request.WorkspaceID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, favorites)
+}
+
+// AddFavorite
+// @Summary Add a favorite
+// @Description Star a database or storage of the workspace
+// @Tags saved-views
+// @Accept json
+// @Param request body AddFavoriteRequest true "Favorite"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /users/me/favorites [post]
+func (c *SavedViewController) AddFavorite(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request AddFavoriteRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.savedViewService.AddFavorite(user, &request); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "favorite added successfully"})
+}
+
+// RemoveFavorite
+// @Summary Remove a favorite
+// @Description Unstar a database or storage of the workspace
+// @Tags saved-views
+// @Param resourceId path string true "Database or storage ID"
+// @Param workspace_id query string true "Workspace ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Router /users/me/favorites/{resourceId} [delete]
+func (c *SavedViewController) RemoveFavorite(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	resourceID, err := uuid.Parse(ctx.Param("resourceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid resource ID"})
+		return
+	}
+
+	var request GetWorkspaceItemsRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.savedViewService.RemoveFavorite(
+		user,
+		request.WorkspaceID,
+		resourceID,
+	); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "favorite removed successfully"})
+}
+
+func (c *SavedViewController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrInsufficientPermissionsToViewWorkspace) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if errors.Is(err, ErrViewNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,179 @@
+package saved_views
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetSavedViewController(),
+	)
+}
+
+func Test_SavedViews_WhenViewSaved_ListedAndChangedByOwnerOnly(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	request := SaveViewRequest{
+		WorkspaceID:  workspace.ID,
+		Name:         " Prod EU failing ",
+		ResourceType: ResourceTypeDatabase,
+		Filters:      map[string]string{"environment": "prod", "failedWithinHours": "24"},
+	}
+
+	var view SavedView
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/me/views",
+		"Bearer "+viewer.Token,
+		request,
+		http.StatusOK,
+		&view,
+	)
+	assert.Equal(t, "Prod EU failing", view.Name)
+
+	listURL := "/api/v1/users/me/views?workspace_id=" + workspace.ID.String()
+
+	var views []SavedView
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		listURL,
+		"Bearer "+viewer.Token,
+		http.StatusOK,
+		&views,
+	)
+	assert.Len(t, views, 1)
+	assert.Equal(t, "24", views[0].Filters["failedWithinHours"])
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		listURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&views,
+	)
+	assert.Empty(t, views)
+
+	test_utils.MakeGetRequest(t, router, listURL, "Bearer "+outsider.Token, http.StatusForbidden)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/users/me/views/"+view.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusNotFound,
+	)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/users/me/views/"+view.ID.String(),
+		"Bearer "+viewer.Token,
+		http.StatusOK,
+	)
+}
+
+func Test_Favorites_WhenResourcesStarred_ListedWithNames(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+	otherWorkspace := workspaces_testing.CreateTestWorkspace("Other Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(otherWorkspace, router)
+
+	testStorage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(testStorage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	for range 2 {
+		test_utils.MakePostRequest(
+			t,
+			router,
+			"/api/v1/users/me/favorites",
+			"Bearer "+owner.Token,
+			AddFavoriteRequest{
+				WorkspaceID:  workspace.ID,
+				ResourceType: ResourceTypeDatabase,
+				ResourceID:   database.ID,
+			},
+			http.StatusOK,
+		)
+	}
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/me/favorites",
+		"Bearer "+owner.Token,
+		AddFavoriteRequest{
+			WorkspaceID:  otherWorkspace.ID,
+			ResourceType: ResourceTypeStorage,
+			ResourceID:   testStorage.ID,
+		},
+		http.StatusBadRequest,
+	)
+
+	listURL := "/api/v1/users/me/favorites?workspace_id=" + workspace.ID.String()
+
+	var favorites []FavoriteDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		listURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&favorites,
+	)
+	assert.Len(t, favorites, 1)
+	assert.Equal(t, database.Name, favorites[0].Name)
+
+	test_utils.MakeDeleteRequest(
+		t,
+		router,
+		"/api/v1/users/me/favorites/"+database.ID.String()+"?workspace_id="+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+	)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		listURL,
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&favorites,
+	)
+	assert.Empty(t, favorites)
+}
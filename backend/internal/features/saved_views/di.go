@@ -0,0 +1,28 @@
+package saved_views
+
+import (
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var savedViewRepository = &SavedViewRepository{}
+var favoriteRepository = &FavoriteRepository{}
+var savedViewService = &SavedViewService{
+	savedViewRepository,
+	favoriteRepository,
+	databases.GetDatabaseService(),
+	storages.GetStorageService(),
+	workspaces_services.GetWorkspaceService(),
+}
+var savedViewController = &SavedViewController{
+	savedViewService,
+}
+
+func GetSavedViewService() *SavedViewService {
+	return savedViewService
+}
+
+func GetSavedViewController() *SavedViewController {
+	return savedViewController
+}
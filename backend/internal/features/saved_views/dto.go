@@ -0,0 +1,31 @@
+package saved_views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetWorkspaceItemsRequest struct {
+	WorkspaceID uuid.UUID `form:"workspace_id" binding:"required"`
+}
+
+type SaveViewRequest struct {
+	WorkspaceID  uuid.UUID         `json:"workspaceId"  binding:"required"`
+	Name         string            `json:"name"         binding:"required"`
+	ResourceType ResourceType      `json:"resourceType" binding:"required"`
+	Filters      map[string]string `json:"filters"`
+}
+
+type AddFavoriteRequest struct {
+	WorkspaceID  uuid.UUID    `json:"workspaceId"  binding:"required"`
+	ResourceType ResourceType `json:"resourceType" binding:"required"`
+	ResourceID   uuid.UUID    `json:"resourceId"   binding:"required"`
+}
+
+type FavoriteDTO struct {
+	ResourceType ResourceType `json:"resourceType"`
+	ResourceID   uuid.UUID    `json:"resourceId"`
+	Name         string       `json:"name"`
+	CreatedAt    time.Time    `json:"createdAt"`
+}
@@ -0,0 +1,29 @@
+package saved_views
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInsufficientPermissionsToViewWorkspace = errors.New(
+		"insufficient permissions to view this workspace",
+	)
+	ErrViewNotFound        = errors.New("saved view not found")
+	ErrInvalidResourceType = errors.New("resource type must be DATABASE or STORAGE")
+	ErrResourceNotFound    = errors.New("resource not found in this workspace")
+	ErrViewNameRequired    = errors.New("view name is required")
+	ErrViewNameTooLong     = fmt.Errorf(
+		"view name must be at most %d characters",
+		maxViewNameLength,
+	)
+	ErrTooManyFilters = fmt.Errorf("view can have at most %d filters", maxViewFilters)
+	ErrFilterTooLong  = fmt.Errorf(
+		"filter names and values must be at most %d characters",
+		maxFilterLength,
+	)
+	ErrTooManyViews = fmt.Errorf(
+		"at most %d views can be saved per workspace",
+		maxViewsPerWorkspace,
+	)
+)
@@ -0,0 +1,55 @@
+package saved_views
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ResourceType string
+
+const (
+	ResourceTypeDatabase ResourceType = "DATABASE"
+	ResourceTypeStorage  ResourceType = "STORAGE"
+)
+
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceTypeDatabase, ResourceTypeStorage:
+		return true
+	default:
+		return false
+	}
+}
+
+// SavedView is a named set of list filters of a user, such as {"environment": "prod",
+// "region": "eu", "failedWithinHours": "24"}. Filters are stored as given, the list pages
+// apply them
+type SavedView struct {
+	ID           uuid.UUID         `json:"id"           gorm:"column:id;type:uuid;primaryKey"`
+	UserID       uuid.UUID         `json:"userId"       gorm:"column:user_id;type:uuid;not null"`
+	WorkspaceID  uuid.UUID         `json:"workspaceId"  gorm:"column:workspace_id;type:uuid;not null"`
+	Name         string            `json:"name"         gorm:"column:name;type:text;not null"`
+	ResourceType ResourceType      `json:"resourceType" gorm:"column:resource_type;type:text;not null"`
+	Filters      map[string]string `json:"filters"      gorm:"column:filters;type:jsonb;serializer:json"`
+	CreatedAt    time.Time         `json:"createdAt"    gorm:"column:created_at;type:timestamptz;not null"`
+	UpdatedAt    time.Time         `json:"updatedAt"    gorm:"column:updated_at;type:timestamptz;not null"`
+}
+
+func (SavedView) TableName() string {
+	return "saved_views"
+}
+
+// Favorite is a database or storage starred by a user in a workspace. System storages
+// are starred per workspace they are shared with
+type Favorite struct {
+	UserID       uuid.UUID    `json:"userId"       gorm:"column:user_id;type:uuid;primaryKey"`
+	WorkspaceID  uuid.UUID    `json:"workspaceId"  gorm:"column:workspace_id;type:uuid;primaryKey"`
+	ResourceID   uuid.UUID    `json:"resourceId"   gorm:"column:resource_id;type:uuid;primaryKey"`
+	ResourceType ResourceType `json:"resourceType" gorm:"column:resource_type;type:text;not null"`
+	CreatedAt    time.Time    `json:"createdAt"    gorm:"column:created_at;type:timestamptz;not null"`
+}
+
+func (Favorite) TableName() string {
+	return "user_favorites"
+}
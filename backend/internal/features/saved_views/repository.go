@@ -0,0 +1,106 @@
+package saved_views
+
+import (
+	"errors"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedViewRepository struct{}
+
+func (r *SavedViewRepository) Save(view *SavedView) error {
+	if view.ID == uuid.Nil {
+		view.ID = uuid.New()
+	}
+
+	return storage.GetDb().Save(view).Error
+}
+
+// FindByIDAndUserID returns nil for views of other users, they are not disclosed
+func (r *SavedViewRepository) FindByIDAndUserID(
+	id uuid.UUID,
+	userID uuid.UUID,
+) (*SavedView, error) {
+	var view SavedView
+
+	err := storage.GetDb().Where("id = ? AND user_id = ?", id, userID).First(&view).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (r *SavedViewRepository) FindByUserAndWorkspace(
+	userID uuid.UUID,
+	workspaceID uuid.UUID,
+) ([]*SavedView, error) {
+	views := make([]*SavedView, 0)
+
+	err := storage.GetDb().
+		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Order("name ASC").
+		Find(&views).Error
+
+	return views, err
+}
+
+func (r *SavedViewRepository) CountByUserAndWorkspace(
+	userID uuid.UUID,
+	workspaceID uuid.UUID,
+) (int64, error) {
+	var count int64
+
+	err := storage.GetDb().Model(&SavedView{}).
+		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Count(&count).Error
+
+	return count, err
+}
+
+func (r *SavedViewRepository) Delete(id uuid.UUID) error {
+	return storage.GetDb().Where("id = ?", id).Delete(&SavedView{}).Error
+}
+
+type FavoriteRepository struct{}
+
+func (r *FavoriteRepository) Save(favorite *Favorite) error {
+	return storage.GetDb().Save(favorite).Error
+}
+
+// FindByUserAndWorkspace returns favorites oldest first
+func (r *FavoriteRepository) FindByUserAndWorkspace(
+	userID uuid.UUID,
+	workspaceID uuid.UUID,
+) ([]*Favorite, error) {
+	favorites := make([]*Favorite, 0)
+
+	err := storage.GetDb().
+		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
+		Order("created_at ASC").
+		Find(&favorites).Error
+
+	return favorites, err
+}
+
+func (r *FavoriteRepository) Delete(
+	userID uuid.UUID,
+	workspaceID uuid.UUID,
+	resourceID uuid.UUID,
+) error {
+	return storage.GetDb().
+		Where(
+			"user_id = ? AND workspace_id = ? AND resource_id = ?",
+			userID,
+			workspaceID,
+			resourceID,
+		).
+		Delete(&Favorite{}).Error
+}
@@ -0,0 +1,314 @@
+package saved_views
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxViewNameLength    = 255
+	maxViewFilters       = 50
+	maxFilterLength      = 1000
+	maxViewsPerWorkspace = 100
+)
+
+type SavedViewService struct {
+	savedViewRepository *SavedViewRepository
+	favoriteRepository  *FavoriteRepository
+	databaseService     *databases.DatabaseService
+	storageService      *storages.StorageService
+	workspaceService    *workspaces_services.WorkspaceService
+}
+
+func (s *SavedViewService) GetViews(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*SavedView, error) {
+	if err := s.checkWorkspaceAccess(user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	return s.savedViewRepository.FindByUserAndWorkspace(user.ID, workspaceID)
+}
+
+func (s *SavedViewService) CreateView(
+	user *users_models.User,
+	request *SaveViewRequest,
+) (*SavedView, error) {
+	name, err := validateView(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkWorkspaceAccess(user, request.WorkspaceID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.savedViewRepository.CountByUserAndWorkspace(user.ID, request.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxViewsPerWorkspace {
+		return nil, ErrTooManyViews
+	}
+
+	now := time.Now().UTC()
+	view := &SavedView{
+		UserID:       user.ID,
+		WorkspaceID:  request.WorkspaceID,
+		Name:         name,
+		ResourceType: request.ResourceType,
+		Filters:      request.Filters,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := s.savedViewRepository.Save(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// UpdateView changes the name and filters of an own view, it stays in its workspace
+func (s *SavedViewService) UpdateView(
+	user *users_models.User,
+	viewID uuid.UUID,
+	request *SaveViewRequest,
+) (*SavedView, error) {
+	name, err := validateView(request)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.savedViewRepository.FindByIDAndUserID(viewID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil {
+		return nil, ErrViewNotFound
+	}
+
+	view.Name = name
+	view.ResourceType = request.ResourceType
+	view.Filters = request.Filters
+	view.UpdatedAt = time.Now().UTC()
+
+	if err := s.savedViewRepository.Save(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *SavedViewService) DeleteView(user *users_models.User, viewID uuid.UUID) error {
+	view, err := s.savedViewRepository.FindByIDAndUserID(viewID, user.ID)
+	if err != nil {
+		return err
+	}
+	if view == nil {
+		return ErrViewNotFound
+	}
+
+	return s.savedViewRepository.Delete(view.ID)
+}
+
+// GetFavorites skips favorites whose resource was deleted or left the workspace
+func (s *SavedViewService) GetFavorites(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]FavoriteDTO, error) {
+	if err := s.checkWorkspaceAccess(user, workspaceID); err != nil {
+		return nil, err
+	}
+
+	favorites, err := s.favoriteRepository.FindByUserAndWorkspace(user.ID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := s.getResourceNames(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]FavoriteDTO, 0, len(favorites))
+	for _, favorite := range favorites {
+		name, ok := names[favorite.ResourceID]
+		if !ok {
+			continue
+		}
+
+		result = append(result, FavoriteDTO{
+			ResourceType: favorite.ResourceType,
+			ResourceID:   favorite.ResourceID,
+			Name:         name,
+			CreatedAt:    favorite.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// AddFavorite stars a database or storage visible in the workspace, starring it again
+// keeps the original date
+func (s *SavedViewService) AddFavorite(
+	user *users_models.User,
+	request *AddFavoriteRequest,
+) error {
+	if !request.ResourceType.IsValid() {
+		return ErrInvalidResourceType
+	}
+
+	if err := s.checkWorkspaceAccess(user, request.WorkspaceID); err != nil {
+		return err
+	}
+
+	isInWorkspace, err := s.isResourceInWorkspace(
+		request.ResourceType,
+		request.ResourceID,
+		request.WorkspaceID,
+	)
+	if err != nil {
+		return err
+	}
+	if !isInWorkspace {
+		return ErrResourceNotFound
+	}
+
+	favorites, err := s.favoriteRepository.FindByUserAndWorkspace(user.ID, request.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, favorite := range favorites {
+		if favorite.ResourceID == request.ResourceID {
+			return nil
+		}
+	}
+
+	return s.favoriteRepository.Save(&Favorite{
+		UserID:       user.ID,
+		WorkspaceID:  request.WorkspaceID,
+		ResourceID:   request.ResourceID,
+		ResourceType: request.ResourceType,
+		CreatedAt:    time.Now().UTC(),
+	})
+}
+
+func (s *SavedViewService) RemoveFavorite(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	resourceID uuid.UUID,
+) error {
+	return s.favoriteRepository.Delete(user.ID, workspaceID, resourceID)
+}
+
+func (s *SavedViewService) checkWorkspaceAccess(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) error {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canView {
+		return ErrInsufficientPermissionsToViewWorkspace
+	}
+
+	return nil
+}
+
+func (s *SavedViewService) isResourceInWorkspace(
+	resourceType ResourceType,
+	resourceID uuid.UUID,
+	workspaceID uuid.UUID,
+) (bool, error) {
+	switch resourceType {
+	case ResourceTypeDatabase:
+		database, err := s.databaseService.GetDatabaseByID(resourceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return database.WorkspaceID != nil && *database.WorkspaceID == workspaceID, nil
+	case ResourceTypeStorage:
+		workspaceStorages, err := s.storageService.GetWorkspaceStorages(workspaceID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, storage := range workspaceStorages {
+			if storage.ID == resourceID {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	default:
+		return false, ErrInvalidResourceType
+	}
+}
+
+// getResourceNames returns names of databases and storages visible in the workspace
+// by their IDs
+func (s *SavedViewService) getResourceNames(workspaceID uuid.UUID) (map[uuid.UUID]string, error) {
+	names := make(map[uuid.UUID]string)
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, database := range workspaceDatabases {
+		names[database.ID] = database.Name
+	}
+
+	workspaceStorages, err := s.storageService.GetWorkspaceStorages(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, storage := range workspaceStorages {
+		names[storage.ID] = storage.Name
+	}
+
+	return names, nil
+}
+
+func validateView(request *SaveViewRequest) (string, error) {
+	name := strings.TrimSpace(request.Name)
+
+	if name == "" {
+		return "", ErrViewNameRequired
+	}
+	if len(name) > maxViewNameLength {
+		return "", ErrViewNameTooLong
+	}
+
+	if !request.ResourceType.IsValid() {
+		return "", ErrInvalidResourceType
+	}
+
+	if len(request.Filters) > maxViewFilters {
+		return "", ErrTooManyFilters
+	}
+	for key, value := range request.Filters {
+		if len(key) > maxFilterLength || len(value) > maxFilterLength {
+			return "", ErrFilterTooLong
+		}
+	}
+
+	return name, nil
+}
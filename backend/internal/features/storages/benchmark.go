@@ -0,0 +1,115 @@
+package storages
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBenchmarkSizeMb = 16
+	maxBenchmarkSizeMb     = 512
+
+	bytesInMb = 1024 * 1024
+)
+
+// firstByteReader records when the first byte of a download was read
+type firstByteReader struct {
+	reader      io.Reader
+	firstByteAt time.Time
+}
+
+func (r *firstByteReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.firstByteAt.IsZero() {
+		r.firstByteAt = time.Now()
+	}
+
+	return n, err
+}
+
+// runBenchmark uploads a random test object, downloads it back and deletes it. Random data
+// keeps compression on the way from making the storage look faster than it is
+func runBenchmark(
+	ctx context.Context,
+	storage *Storage,
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	sizeMb int,
+) (*StorageBenchmarkResult, error) {
+	sizeBytes := int64(sizeMb) * bytesInMb
+	fileID := uuid.New()
+
+	result := &StorageBenchmarkResult{SizeMb: sizeMb}
+
+	if uploader, isMultipart := storage.getSpecificStorage().(MultipartUploader); isMultipart {
+		partSizeBytes := uploader.GetUploadPartSizeBytes()
+
+		result.PartSizeMb = float64(partSizeBytes) / bytesInMb
+		result.PartsCount = int((sizeBytes + partSizeBytes - 1) / partSizeBytes)
+		result.IsMultipart = result.PartsCount > 1
+	}
+
+	uploadStartedAt := time.Now()
+	if err := storage.getSpecificStorage().SaveFile(
+		ctx,
+		encryptor,
+		logger,
+		fileID,
+		io.LimitReader(rand.Reader, sizeBytes),
+	); err != nil {
+		return nil, fmt.Errorf("failed to upload test object: %w", err)
+	}
+	result.UploadDurationMs = time.Since(uploadStartedAt).Milliseconds()
+	result.UploadThroughputMBs = throughputMBs(sizeMb, result.UploadDurationMs)
+
+	defer func() {
+		deleteStartedAt := time.Now()
+		if err := storage.DeleteFile(encryptor, fileID); err != nil {
+			logger.Error("Failed to delete benchmark test object", "fileId", fileID, "error", err)
+			return
+		}
+		result.DeleteDurationMs = time.Since(deleteStartedAt).Milliseconds()
+	}()
+
+	downloadStartedAt := time.Now()
+	file, err := storage.GetFile(encryptor, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download test object: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := &firstByteReader{reader: file}
+	downloadedBytes, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download test object: %w", err)
+	}
+	if downloadedBytes != sizeBytes {
+		return nil, fmt.Errorf(
+			"downloaded test object has %d bytes, expected %d",
+			downloadedBytes,
+			sizeBytes,
+		)
+	}
+
+	result.DownloadDurationMs = time.Since(downloadStartedAt).Milliseconds()
+	result.DownloadThroughputMBs = throughputMBs(sizeMb, result.DownloadDurationMs)
+	if !reader.firstByteAt.IsZero() {
+		result.DownloadFirstByteMs = reader.firstByteAt.Sub(downloadStartedAt).Milliseconds()
+	}
+
+	return result, nil
+}
+
+func throughputMBs(sizeMb int, durationMs int64) float64 {
+	return float64(sizeMb) / (float64(max(durationMs, 1)) / 1000)
+}
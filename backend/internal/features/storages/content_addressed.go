@@ -0,0 +1,245 @@
+package storages
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const (
+	contentObjectsDirectory = "objects"
+	contentIndexDirectory   = "index"
+	contentRefsDirectory    = "refs"
+	contentStagingDirectory = "staging"
+
+	// contentCopyTimeout bounds moving a staged file to its content object
+	contentCopyTimeout = 6 * time.Hour
+
+	contentIndexVersion = 1
+)
+
+// ContentIndex is the metadata object of a file saved in the content-addressed layout.
+// It maps the file ID to the object holding its content, so files are found without the
+// database of Databasus, e.g. when reconciling a bucket
+type ContentIndex struct {
+	Version     int       `json:"version"`
+	FileID      uuid.UUID `json:"fileId"`
+	ContentHash string    `json:"contentHash"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// IsContentAddressedLayout reports whether files are stored under their content hash
+func (s *Storage) IsContentAddressedLayout() bool {
+	return s.IsContentAddressed && s.Type == StorageTypeS3 && s.S3Storage != nil
+}
+
+// GetSavedContentHash returns the sha256 of a file saved by this instance, or read by it in
+// the content-addressed layout, nil for other files
+func (s *Storage) GetSavedContentHash(fileID uuid.UUID) *string {
+	contentHash, ok := s.contentHashes[fileID]
+	if !ok {
+		return nil
+	}
+
+	return &contentHash
+}
+
+// saveContentAddressedFile uploads the file to a staging object while hashing it, then
+// moves it to objects/<sha256>. A file with the same content is stored once, only its
+// index and reference objects are added
+func (s *Storage) saveContentAddressedFile(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	fileID uuid.UUID,
+	file io.Reader,
+) error {
+	stagingName := contentStagingDirectory + "/" + fileID.String()
+	counter := &contentHashingReader{reader: file, hash: sha256.New()}
+
+	if err := s.S3Storage.SaveObject(ctx, encryptor, stagingName, counter); err != nil {
+		return err
+	}
+
+	defer func() { _ = s.S3Storage.DeleteObject(encryptor, stagingName) }()
+
+	contentHash := hex.EncodeToString(counter.hash.Sum(nil))
+	contentName := getContentObjectName(contentHash)
+
+	isContentExists, err := s.S3Storage.IsObjectExists(encryptor, contentName)
+	if err != nil {
+		return err
+	}
+
+	if !isContentExists {
+		copyCtx, cancel := context.WithTimeout(ctx, contentCopyTimeout)
+		defer cancel()
+
+		if err := s.S3Storage.CopyObject(copyCtx, encryptor, stagingName, contentName); err != nil {
+			return err
+		}
+	}
+
+	if err := s.S3Storage.SaveObject(
+		ctx,
+		encryptor,
+		getContentRefName(contentHash, fileID),
+		bytes.NewReader(nil),
+	); err != nil {
+		return err
+	}
+
+	index, err := json.Marshal(&ContentIndex{
+		Version:     contentIndexVersion,
+		FileID:      fileID,
+		ContentHash: contentHash,
+		SizeBytes:   counter.sizeBytes,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.S3Storage.SaveObject(
+		ctx,
+		encryptor,
+		getContentIndexName(fileID),
+		bytes.NewReader(index),
+	); err != nil {
+		return err
+	}
+
+	s.rememberContentHash(fileID, contentHash)
+
+	return nil
+}
+
+func (s *Storage) getContentAddressedFile(
+	encryptor encryption.FieldEncryptor,
+	fileID uuid.UUID,
+) (io.ReadCloser, error) {
+	index, err := s.getContentIndex(encryptor, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.S3Storage.GetObject(encryptor, getContentObjectName(index.ContentHash))
+}
+
+// deleteContentAddressedFile removes the index and reference of the file, the content
+// object is removed with the last file referencing it. Missing files are not an error
+func (s *Storage) deleteContentAddressedFile(
+	encryptor encryption.FieldEncryptor,
+	fileID uuid.UUID,
+) error {
+	isIndexExists, err := s.S3Storage.IsObjectExists(encryptor, getContentIndexName(fileID))
+	if err != nil || !isIndexExists {
+		return err
+	}
+
+	index, err := s.getContentIndex(encryptor, fileID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.S3Storage.DeleteObject(
+		encryptor,
+		getContentRefName(index.ContentHash, fileID),
+	); err != nil {
+		return err
+	}
+
+	// listing a single reference is enough to know whether the content is still used
+	refs, err := s.S3Storage.ListObjects(
+		encryptor,
+		contentRefsDirectory+"/"+index.ContentHash,
+		1,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(refs) == 0 {
+		if err := s.S3Storage.DeleteObject(
+			encryptor,
+			getContentObjectName(index.ContentHash),
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.S3Storage.DeleteObject(encryptor, getContentIndexName(fileID))
+}
+
+func (s *Storage) getContentIndex(
+	encryptor encryption.FieldEncryptor,
+	fileID uuid.UUID,
+) (*ContentIndex, error) {
+	indexReader, err := s.S3Storage.GetObject(encryptor, getContentIndexName(fileID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = indexReader.Close() }()
+
+	var index ContentIndex
+	if err := json.NewDecoder(indexReader).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to read content index of file %s: %w", fileID, err)
+	}
+
+	if index.ContentHash == "" || strings.Contains(index.ContentHash, "/") {
+		return nil, fmt.Errorf("content index of file %s has invalid hash", fileID)
+	}
+
+	s.rememberContentHash(fileID, index.ContentHash)
+
+	return &index, nil
+}
+
+func (s *Storage) rememberContentHash(fileID uuid.UUID, contentHash string) {
+	if s.contentHashes == nil {
+		s.contentHashes = make(map[uuid.UUID]string)
+	}
+
+	s.contentHashes[fileID] = contentHash
+}
+
+func getContentObjectName(contentHash string) string {
+	return contentObjectsDirectory + "/" + contentHash
+}
+
+func getContentIndexName(fileID uuid.UUID) string {
+	return contentIndexDirectory + "/" + fileID.String() + ".json"
+}
+
+func getContentRefName(contentHash string, fileID uuid.UUID) string {
+	return contentRefsDirectory + "/" + contentHash + "/" + fileID.String()
+}
+
+// contentHashingReader hashes and counts bytes of the uploaded file, SaveFile hashes every
+// file with it
+type contentHashingReader struct {
+	reader    io.Reader
+	hash      hash.Hash
+	sizeBytes int64
+}
+
+func (r *contentHashingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.sizeBytes += int64(n)
+		_, _ = r.hash.Write(p[:n])
+	}
+
+	return n, err
+}
@@ -2,9 +2,12 @@ package storages
 
 import (
 	"errors"
+	"slices"
 
+	"databasus-backend/internal/features/ownership"
 	users_middleware "databasus-backend/internal/features/users/middleware"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/etag"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -18,12 +21,14 @@ type StorageController struct {
 
 func (c *StorageController) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/storages", c.SaveStorage)
-	router.GET("/storages", c.GetStorages)
-	router.GET("/storages/:id", c.GetStorage)
-	router.DELETE("/storages/:id", c.DeleteStorage)
-	router.POST("/storages/:id/test", c.TestStorageConnection)
-	router.POST("/storages/:id/transfer", c.TransferStorageToWorkspace)
-	router.POST("/storages/direct-test", c.TestStorageConnectionDirect)
+	c.registerSharedRoutes(router)
+}
+
+// RegisterRoutesV2 registers /api/v2 routes, where create and update are split
+func (c *StorageController) RegisterRoutesV2(router *gin.RouterGroup) {
+	router.POST("/storages", c.CreateStorage)
+	router.PUT("/storages/:id", c.UpdateStorage)
+	c.registerSharedRoutes(router)
 }
 
 // SaveStorage
@@ -58,15 +63,84 @@ func (c *StorageController) SaveStorage(ctx *gin.Context) {
 	}
 
 	if err := c.storageService.SaveStorage(user, request.WorkspaceID, &request); err != nil {
-		if errors.Is(err, ErrInsufficientPermissionsToManageStorage) ||
-			errors.Is(err, ErrLocalStorageNotAllowedInCloudMode) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
-		}
+		c.respondSaveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// CreateStorage is POST /api/v2/storages. Unlike v1 SaveStorage it never updates
+func (c *StorageController) CreateStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request Storage
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.WorkspaceID == uuid.Nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "workspaceId is required"})
+		return
+	}
+
+	if request.ID != uuid.Nil {
+		ctx.JSON(
+			http.StatusBadRequest,
+			gin.H{"error": "id must be empty, use PUT /storages/{id} to update"},
+		)
+		return
+	}
+
+	if err := c.storageService.SaveStorage(user, request.WorkspaceID, &request); err != nil {
+		c.respondSaveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, request)
+}
+
+// UpdateStorage is PUT /api/v2/storages/:id, the ID is taken from the path
+func (c *StorageController) UpdateStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	var request Storage
+	if err := ctx.ShouldBindJSON(&request); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	if request.ID != uuid.Nil && request.ID != id {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id in body does not match path"})
+		return
+	}
+	request.ID = id
+
+	if request.WorkspaceID == uuid.Nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "workspaceId is required"})
+		return
+	}
+
+	if err := c.storageService.SaveStorage(user, request.WorkspaceID, &request); err != nil {
+		c.respondSaveError(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, request)
 }
 
@@ -77,7 +151,7 @@ func (c *StorageController) SaveStorage(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "JWT token"
 // @Param id path string true "Storage ID"
-// @Success 200 {object} Storage
+// @Success 200 {object} StorageResponse
 // @Failure 400
 // @Failure 401
 // @Failure 403
@@ -105,7 +179,7 @@ func (c *StorageController) GetStorage(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, storage)
+	etag.JSON(ctx, storage)
 }
 
 // GetStorages
@@ -115,7 +189,9 @@ func (c *StorageController) GetStorage(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "JWT token"
 // @Param workspace_id query string true "Workspace ID"
-// @Success 200 {array} Storage
+// @Param owner_user_id query string false "Only storages owned by the user"
+// @Param owner_team query string false "Only storages owned by the team"
+// @Success 200 {array} StorageResponse
 // @Failure 400
 // @Failure 401
 // @Failure 403
@@ -139,6 +215,12 @@ func (c *StorageController) GetStorages(ctx *gin.Context) {
 		return
 	}
 
+	var ownerFilter ownership.Filter
+	if err := ctx.ShouldBindQuery(&ownerFilter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	storages, err := c.storageService.GetStorages(user, workspaceID)
 	if err != nil {
 		if errors.Is(err, ErrInsufficientPermissionsToViewStorages) {
@@ -149,7 +231,11 @@ func (c *StorageController) GetStorages(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, storages)
+	storages = slices.DeleteFunc(storages, func(storage *StorageResponse) bool {
+		return !ownerFilter.Matches(storage.Ownership)
+	})
+
+	etag.JSON(ctx, storages)
 }
 
 // DeleteStorage
@@ -226,6 +312,96 @@ func (c *StorageController) TestStorageConnection(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "storage connection test successful"})
 }
 
+// BenchmarkStorage
+// @Summary Benchmark storage
+// @Description Upload, download and delete a test object of the given size, reporting throughput, latency and multipart behavior
+// @Tags storages
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Storage ID"
+// @Param request body BenchmarkStorageRequest true "Benchmark settings"
+// @Success 200 {object} StorageBenchmarkResult
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/{id}/benchmark [post]
+func (c *StorageController) BenchmarkStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	var request BenchmarkStorageRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.storageService.BenchmarkStorage(ctx.Request.Context(), user, id, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToBenchmarkStorage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// BrowseStorage
+// @Summary Browse storage files
+// @Description List files and folders right under the path of the storage with their size and modification time, at most 1000 entries. System and local storages can be browsed only by admins
+// @Tags storages
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Storage ID"
+// @Param path query string false "Folder relative to the root folder of the storage"
+// @Success 200 {object} BrowseStorageResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/{id}/browse [get]
+func (c *StorageController) BrowseStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	response, err := c.storageService.BrowseStorage(
+		ctx.Request.Context(),
+		user,
+		id,
+		ctx.Query("path"),
+	)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToBrowseStorage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
 // TransferStorageToWorkspace
 // @Summary Transfer storage to another workspace
 // @Description Transfer a storage from one workspace to another
@@ -282,6 +458,114 @@ func (c *StorageController) TransferStorageToWorkspace(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "storage transferred successfully"})
 }
 
+// GetDefaultStorage
+// @Summary Get default storage of a workspace
+// @Description Get the storage attached to new databases of the workspace, null if not set
+// @Tags storages
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} StorageResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/workspace/{workspaceId}/default [get]
+func (c *StorageController) GetDefaultStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	storage, err := c.storageService.GetDefaultStorage(user, workspaceID)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewStorages) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, storage)
+}
+
+// SetDefaultStorage
+// @Summary Set default storage of a workspace
+// @Description Set the storage attached to new databases of the workspace, an empty storageId clears it
+// @Tags storages
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body SetDefaultStorageRequest true "Default storage ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/workspace/{workspaceId}/default [put]
+func (c *StorageController) SetDefaultStorage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("workspaceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request SetDefaultStorageRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.storageService.SetDefaultStorage(user, workspaceID, &request); err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageStorage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "default storage updated successfully"})
+}
+
+// GetStoragePlugins
+// @Summary Get storage plugins
+// @Description Get names of storage plugins installed on this instance
+// @Tags storages
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Success 200 {array} string
+// @Failure 401
+// @Failure 500
+// @Router /storages/plugins [get]
+func (c *StorageController) GetStoragePlugins(ctx *gin.Context) {
+	if _, ok := users_middleware.GetUserFromContext(ctx); !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	plugins, err := c.storageService.GetAvailableStoragePlugins()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, plugins)
+}
+
 // TestStorageConnectionDirect
 // @Summary Test storage connection directly
 // @Description Test the connection to a storage object provided in the request
@@ -337,3 +621,27 @@ func (c *StorageController) TestStorageConnectionDirect(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "storage connection test successful"})
 }
+
+func (c *StorageController) registerSharedRoutes(router *gin.RouterGroup) {
+	router.GET("/storages", c.GetStorages)
+	router.GET("/storages/plugins", c.GetStoragePlugins)
+	router.GET("/storages/:id", c.GetStorage)
+	router.DELETE("/storages/:id", c.DeleteStorage)
+	router.POST("/storages/:id/test", c.TestStorageConnection)
+	router.POST("/storages/:id/benchmark", c.BenchmarkStorage)
+	router.GET("/storages/:id/browse", c.BrowseStorage)
+	router.POST("/storages/:id/transfer", c.TransferStorageToWorkspace)
+	router.POST("/storages/direct-test", c.TestStorageConnectionDirect)
+	router.GET("/storages/workspace/:workspaceId/default", c.GetDefaultStorage)
+	router.PUT("/storages/workspace/:workspaceId/default", c.SetDefaultStorage)
+}
+
+func (c *StorageController) respondSaveError(ctx *gin.Context, err error) {
+	if errors.Is(err, ErrInsufficientPermissionsToManageStorage) ||
+		errors.Is(err, ErrLocalStorageNotAllowedInCloudMode) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -1,15 +1,21 @@
 package storages
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"databasus-backend/internal/config"
 	audit_logs "databasus-backend/internal/features/audit_logs"
 	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
 	ftp_storage "databasus-backend/internal/features/storages/models/ftp"
+	gcs_storage "databasus-backend/internal/features/storages/models/gcs"
 	google_drive_storage "databasus-backend/internal/features/storages/models/google_drive"
 	local_storage "databasus-backend/internal/features/storages/models/local"
 	nas_storage "databasus-backend/internal/features/storages/models/nas"
@@ -23,6 +29,7 @@ import (
 	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
 	workspaces_repositories "databasus-backend/internal/features/workspaces/repositories"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/apiversion"
 	"databasus-backend/internal/util/encryption"
 	test_utils "databasus-backend/internal/util/testing"
 
@@ -127,6 +134,128 @@ func Test_UpdateExistingStorage_UpdatedStorageReturnedViaGet(t *testing.T) {
 	workspaces_testing.RemoveTestWorkspace(workspace, router)
 }
 
+func Test_CreateAndUpdateStorageViaV2_StorageUpdatedByPath(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	var createdStorage Storage
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v2/storages",
+		"Bearer "+owner.Token,
+		*createNewStorage(workspace.ID),
+		http.StatusCreated,
+		&createdStorage,
+	)
+	assert.NotEqual(t, uuid.Nil, createdStorage.ID)
+
+	updatedName := "Updated Storage " + uuid.New().String()
+	createdStorage.Name = updatedName
+	storageID := createdStorage.ID
+	createdStorage.ID = uuid.Nil
+
+	var updatedStorage Storage
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v2/storages/"+storageID.String(),
+		"Bearer "+owner.Token,
+		createdStorage,
+		http.StatusOK,
+		&updatedStorage,
+	)
+
+	assert.Equal(t, storageID, updatedStorage.ID)
+	assert.Equal(t, updatedName, updatedStorage.Name)
+
+	deleteStorage(t, router, storageID, owner.Token)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_CreateStorageViaV2_WhenIDIsSet_ReturnsErrorEnvelope(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	storage := createNewStorage(workspace.ID)
+	storage.ID = uuid.New()
+
+	response := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v2/storages",
+		"Bearer "+owner.Token,
+		*storage,
+		http.StatusBadRequest,
+	)
+
+	var envelope apiversion.ErrorEnvelope
+	assert.NoError(t, json.Unmarshal(response.Body, &envelope))
+	assert.Equal(t, "bad_request", envelope.Error.Code)
+	assert.Equal(t, http.StatusBadRequest, envelope.Error.Status)
+	assert.Contains(t, envelope.Error.Message, "id must be empty")
+
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_GetStorages_WhenStoragesAreNotChanged_ReturnsNotModified(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := createNewStorage(workspace.ID)
+
+	var savedStorage Storage
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+owner.Token,
+		*storage,
+		http.StatusOK,
+		&savedStorage,
+	)
+
+	storagesURL := fmt.Sprintf("/api/v1/storages?workspace_id=%s", workspace.ID.String())
+
+	response := test_utils.MakeGetRequest(t, router, storagesURL, "Bearer "+owner.Token, http.StatusOK)
+	etag := response.Headers.Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	notModifiedResponse := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         http.MethodGet,
+		URL:            storagesURL,
+		Headers:        map[string]string{"If-None-Match": etag},
+		AuthToken:      "Bearer " + owner.Token,
+		ExpectedStatus: http.StatusNotModified,
+	})
+	assert.Empty(t, notModifiedResponse.Body)
+
+	savedStorage.Name = "Updated Storage " + uuid.New().String()
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+owner.Token,
+		savedStorage,
+		http.StatusOK,
+	)
+
+	changedResponse := test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+		Method:         http.MethodGet,
+		URL:            storagesURL,
+		Headers:        map[string]string{"If-None-Match": etag},
+		AuthToken:      "Bearer " + owner.Token,
+		ExpectedStatus: http.StatusOK,
+	})
+	assert.NotEqual(t, etag, changedResponse.Headers.Get("ETag"))
+	assert.Contains(t, string(changedResponse.Body), savedStorage.Name)
+
+	deleteStorage(t, router, savedStorage.ID, owner.Token)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
 func Test_CreateSystemStorage_OnlyAdminCanCreate_MemberGetsForbidden(t *testing.T) {
 	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
 	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -372,6 +501,115 @@ func Test_TestExistingStorageConnection_ConnectionEstablished(t *testing.T) {
 	workspaces_testing.RemoveTestWorkspace(workspace, router)
 }
 
+func Test_BenchmarkStorage_TestObjectUploadedAndDownloaded(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := createNewStorage(workspace.ID)
+
+	var savedStorage Storage
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+owner.Token,
+		*storage,
+		http.StatusOK,
+		&savedStorage,
+	)
+
+	var result StorageBenchmarkResult
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/benchmark", savedStorage.ID.String()),
+		"Bearer "+owner.Token,
+		BenchmarkStorageRequest{SizeMb: 1},
+		http.StatusOK,
+		&result,
+	)
+
+	assert.Equal(t, 1, result.SizeMb)
+	assert.Greater(t, result.UploadThroughputMBs, 0.0)
+	assert.Greater(t, result.DownloadThroughputMBs, 0.0)
+	assert.False(t, result.IsMultipart)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/benchmark", savedStorage.ID.String()),
+		"Bearer "+owner.Token,
+		BenchmarkStorageRequest{SizeMb: maxBenchmarkSizeMb + 1},
+		http.StatusBadRequest,
+	)
+
+	deleteStorage(t, router, savedStorage.ID, owner.Token)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_BrowseLocalStorage_FilesListedOnlyForAdmin(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := createRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := createNewStorage(workspace.ID)
+
+	var savedStorage Storage
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+owner.Token,
+		*storage,
+		http.StatusOK,
+		&savedStorage,
+	)
+
+	directory := "browse-" + uuid.New().String()
+	directoryPath := filepath.Join(config.GetEnv().DataFolder, directory)
+	assert.NoError(t, os.MkdirAll(filepath.Join(directoryPath, "nested"), 0o755))
+	assert.NoError(
+		t,
+		os.WriteFile(filepath.Join(directoryPath, "backup.dump"), []byte("dump"), 0o644),
+	)
+	defer func() { _ = os.RemoveAll(directoryPath) }()
+
+	browseURL := fmt.Sprintf("/api/v1/storages/%s/browse?path=%s", savedStorage.ID, directory)
+
+	// local storages share the data folder with other workspaces
+	test_utils.MakeGetRequest(t, router, browseURL, "Bearer "+owner.Token, http.StatusForbidden)
+
+	var response BrowseStorageResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		browseURL,
+		"Bearer "+admin.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, directory, response.Path)
+	assert.False(t, response.IsTruncated)
+	assert.Len(t, response.Entries, 2)
+	assert.Equal(t, "nested", response.Entries[0].Name)
+	assert.True(t, response.Entries[0].IsDirectory)
+	assert.Equal(t, "backup.dump", response.Entries[1].Name)
+	assert.Equal(t, int64(4), response.Entries[1].SizeBytes)
+	assert.NotNil(t, response.Entries[1].ModifiedAt)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/browse?path=../", savedStorage.ID),
+		"Bearer "+admin.Token,
+		http.StatusBadRequest,
+	)
+
+	deleteStorage(t, router, savedStorage.ID, owner.Token)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
 func Test_WorkspaceRolePermissions(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -914,6 +1152,87 @@ func Test_GetStorages_SystemStorageIncludedForAllUsers(t *testing.T) {
 	workspaces_testing.RemoveTestWorkspace(workspaceB, router)
 }
 
+func Test_GetStorages_ScopedSystemStorageVisibleOnlyToListedWorkspaces(t *testing.T) {
+	router := createRouter()
+	GetStorageService().SetStorageDatabaseCounter(&mockStorageDatabaseCounter{})
+
+	ownerA := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	ownerB := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaceA := workspaces_testing.CreateTestWorkspace("Workspace A", ownerA, router)
+	workspaceB := workspaces_testing.CreateTestWorkspace("Workspace B", ownerB, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspaceA, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspaceB, router)
+
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	adminWorkspace := workspaces_testing.CreateTestWorkspace("Admin Workspace", admin, router)
+	defer workspaces_testing.RemoveTestWorkspace(adminWorkspace, router)
+
+	systemStorage := &Storage{
+		WorkspaceID:         adminWorkspace.ID,
+		Type:                StorageTypeLocal,
+		Name:                "Test Scoped System Storage " + uuid.New().String(),
+		IsSystem:            true,
+		VisibleWorkspaceIDs: []uuid.UUID{workspaceA.ID},
+		LocalStorage:        &local_storage.LocalStorage{},
+	}
+	var savedSystemStorage Storage
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+admin.Token,
+		*systemStorage,
+		http.StatusOK,
+		&savedSystemStorage,
+	)
+	defer deleteStorage(t, router, savedSystemStorage.ID, admin.Token)
+
+	isStorageListed := func(workspaceID uuid.UUID, token string) bool {
+		var storages []Storage
+		test_utils.MakeGetRequestAndUnmarshal(
+			t,
+			router,
+			fmt.Sprintf("/api/v1/storages?workspace_id=%s", workspaceID.String()),
+			"Bearer "+token,
+			http.StatusOK,
+			&storages,
+		)
+
+		for _, storage := range storages {
+			if storage.ID == savedSystemStorage.ID {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	assert.True(t, isStorageListed(workspaceA.ID, ownerA.Token))
+	assert.False(t, isStorageListed(workspaceB.ID, ownerB.Token))
+	assert.True(t, isStorageListed(adminWorkspace.ID, admin.Token))
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/storages/"+savedSystemStorage.ID.String(),
+		"Bearer "+ownerB.Token,
+		http.StatusForbidden,
+	)
+
+	// the list is for system storages only
+	privateStorage := createNewStorage(workspaceA.ID)
+	privateStorage.VisibleWorkspaceIDs = []uuid.UUID{workspaceB.ID}
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/storages",
+		"Bearer "+ownerA.Token,
+		*privateStorage,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), ErrVisibleWorkspacesOnlyForSystemStorage.Error())
+}
+
 func Test_GetSystemStorage_SensitiveDataHiddenForNonAdmin(t *testing.T) {
 	router := createRouter()
 	GetStorageService().SetStorageDatabaseCounter(&mockStorageDatabaseCounter{})
@@ -1418,6 +1737,54 @@ func Test_StorageSensitiveDataLifecycle_AllTypes(t *testing.T) {
 				assert.Equal(t, "", storage.GoogleDriveStorage.TokenJSON)
 			},
 		},
+		{
+			name:        "GCS Storage",
+			storageType: StorageTypeGCS,
+			createStorage: func(workspaceID uuid.UUID) *Storage {
+				return &Storage{
+					WorkspaceID: workspaceID,
+					Type:        StorageTypeGCS,
+					Name:        "Test GCS Storage",
+					GCSStorage: &gcs_storage.GCSStorage{
+						AuthMethod:        gcs_storage.AuthMethodServiceAccountKey,
+						ServiceAccountKey: "original-service-account-key",
+						Bucket:            "test-bucket",
+						Prefix:            "backups/",
+						StorageClass:      "NEARLINE",
+					},
+				}
+			},
+			updateStorage: func(workspaceID uuid.UUID, storageID uuid.UUID) *Storage {
+				return &Storage{
+					ID:          storageID,
+					WorkspaceID: workspaceID,
+					Type:        StorageTypeGCS,
+					Name:        "Updated GCS Storage",
+					GCSStorage: &gcs_storage.GCSStorage{
+						AuthMethod:        gcs_storage.AuthMethodServiceAccountKey,
+						ServiceAccountKey: "",
+						Bucket:            "updated-bucket",
+						Prefix:            "backups2/",
+						StorageClass:      "COLDLINE",
+					},
+				}
+			},
+			verifySensitiveData: func(t *testing.T, storage *Storage) {
+				assert.True(t, strings.HasPrefix(storage.GCSStorage.ServiceAccountKey, "enc:"),
+					"ServiceAccountKey should be encrypted with 'enc:' prefix")
+
+				encryptor := encryption.GetFieldEncryptor()
+				serviceAccountKey, err := encryptor.Decrypt(
+					storage.ID,
+					storage.GCSStorage.ServiceAccountKey,
+				)
+				assert.NoError(t, err)
+				assert.Equal(t, "original-service-account-key", serviceAccountKey)
+			},
+			verifyHiddenData: func(t *testing.T, storage *Storage) {
+				assert.Equal(t, "", storage.GCSStorage.ServiceAccountKey)
+			},
+		},
 		{
 			name:        "FTP Storage",
 			storageType: StorageTypeFTP,
@@ -2107,6 +2474,85 @@ func Test_DeleteWorkspace_WithOwnSystemStorage_ReturnsForbidden(t *testing.T) {
 	assert.Error(t, err, "Workspace should be deleted after storage was removed")
 }
 
+// Benchmark_GetStorages_WorkspaceWithManyMembers tracks the request latency of the storages
+// list, which is dominated by workspace permission checks. Run with:
+// go test ./internal/features/storages -run ^$ -bench GetStorages -benchtime 2000x
+func Benchmark_GetStorages_WorkspaceWithManyMembers(b *testing.B) {
+	const membersCount = 200
+
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := createRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Benchmark Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		member,
+		users_enums.WorkspaceRoleMember,
+		owner.Token,
+		router,
+	)
+
+	for range membersCount {
+		otherMember := users_testing.CreateTestUser(users_enums.UserRoleMember)
+		workspaces_testing.AddMemberToWorkspace(
+			workspace,
+			otherMember,
+			users_enums.WorkspaceRoleViewer,
+			owner.Token,
+			router,
+		)
+	}
+
+	url := fmt.Sprintf("/api/v1/storages?workspace_id=%s", workspace.ID.String())
+	membershipRepository := &workspaces_repositories.MembershipRepository{}
+
+	// both cases run in one process against the same data, so their p95 can be compared
+	b.Run("RoleCacheMiss", func(b *testing.B) {
+		benchmarkStoragesRequestP95(b, router, url, member.Token, func() {
+			membershipRepository.InvalidateWorkspaceRoleCacheForTests(workspace.ID, member.UserID)
+		})
+	})
+
+	b.Run("RoleCacheHit", func(b *testing.B) {
+		benchmarkStoragesRequestP95(b, router, url, member.Token, func() {})
+	})
+}
+
+func benchmarkStoragesRequestP95(
+	b *testing.B,
+	router *gin.Engine,
+	url string,
+	token string,
+	beforeRequest func(),
+) {
+	durations := make([]time.Duration, 0, b.N)
+
+	b.ResetTimer()
+
+	for range b.N {
+		b.StopTimer()
+		beforeRequest()
+		b.StartTimer()
+
+		start := time.Now()
+
+		w := workspaces_testing.MakeAPIRequest(router, "GET", url, "Bearer "+token, nil)
+		if w.Code != http.StatusOK {
+			b.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+
+		durations = append(durations, time.Since(start))
+	}
+
+	b.StopTimer()
+
+	slices.Sort(durations)
+	p95 := durations[(len(durations)*95)/100]
+	b.ReportMetric(float64(p95.Microseconds()), "p95-us")
+}
+
 func createRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -2120,6 +2566,11 @@ func createRouter() *gin.Engine {
 		workspaces_controllers.GetMembershipController().RegisterRoutes(routerGroup)
 	}
 
+	v2 := router.Group("/api/v2")
+	v2.Use(apiversion.WithErrorEnvelope())
+	v2.Use(users_middleware.AuthMiddleware(users_services.GetUserService()))
+	GetStorageController().RegisterRoutesV2(v2)
+
 	audit_logs.SetupDependencies()
 	SetupDependencies()
 	GetStorageService().SetStorageDatabaseCounter(&mockStorageDatabaseCounter{})
@@ -0,0 +1,144 @@
+package storages
+
+import (
+	"errors"
+	"fmt"
+
+	users_models "databasus-backend/internal/features/users/models"
+	db "databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WorkspaceDefaultStorage is the storage attached to new databases of the workspace. It
+// may be a system storage visible to the workspace
+type WorkspaceDefaultStorage struct {
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;primaryKey"`
+	StorageID   uuid.UUID `json:"storageId"   gorm:"column:storage_id;type:uuid;not null"`
+}
+
+func (WorkspaceDefaultStorage) TableName() string {
+	return "workspace_default_storages"
+}
+
+func (r *StorageRepository) FindDefaultStorageID(workspaceID uuid.UUID) (*uuid.UUID, error) {
+	var defaultStorage WorkspaceDefaultStorage
+
+	if err := db.GetDb().
+		Where("workspace_id = ?", workspaceID).
+		First(&defaultStorage).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &defaultStorage.StorageID, nil
+}
+
+func (r *StorageRepository) SaveDefaultStorage(defaultStorage *WorkspaceDefaultStorage) error {
+	return db.GetDb().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"storage_id"}),
+		}).
+		Create(defaultStorage).Error
+}
+
+func (r *StorageRepository) DeleteDefaultStorage(workspaceID uuid.UUID) error {
+	return db.GetDb().
+		Where("workspace_id = ?", workspaceID).
+		Delete(&WorkspaceDefaultStorage{}).Error
+}
+
+// GetDefaultStorage returns nil if the workspace has no default storage
+func (s *StorageService) GetDefaultStorage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*StorageResponse, error) {
+	canView, role, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewStorages
+	}
+
+	storage, err := s.GetWorkspaceDefaultStorage(workspaceID)
+	if err != nil || storage == nil {
+		return nil, err
+	}
+
+	return ToRedactedStorageResponse(storage, s.getRedactionLevel(user, storage, role)), nil
+}
+
+func (s *StorageService) SetDefaultStorage(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	request *SetDefaultStorageRequest,
+) error {
+	canManage, err := s.workspaceService.CanUserManageDBs(workspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrInsufficientPermissionsToManageStorage
+	}
+
+	if request.StorageID == nil {
+		if err := s.storageRepository.DeleteDefaultStorage(workspaceID); err != nil {
+			return err
+		}
+
+		s.auditLogService.WriteAuditLog("Default storage cleared", &user.ID, &workspaceID)
+
+		return nil
+	}
+
+	storage, err := s.storageRepository.FindByID(*request.StorageID)
+	if err != nil {
+		return err
+	}
+
+	if !storage.IsVisibleToWorkspace(workspaceID) {
+		return ErrStorageDoesNotBelongToWorkspace
+	}
+
+	if err := s.storageRepository.SaveDefaultStorage(&WorkspaceDefaultStorage{
+		WorkspaceID: workspaceID,
+		StorageID:   storage.ID,
+	}); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Default storage set: %s", storage.Name),
+		&user.ID,
+		&workspaceID,
+	)
+
+	return nil
+}
+
+// GetWorkspaceDefaultStorage returns nil if the workspace has no default storage or the
+// default is a system storage no longer visible to the workspace
+func (s *StorageService) GetWorkspaceDefaultStorage(workspaceID uuid.UUID) (*Storage, error) {
+	storageID, err := s.storageRepository.FindDefaultStorageID(workspaceID)
+	if err != nil || storageID == nil {
+		return nil, err
+	}
+
+	storage, err := s.storageRepository.FindByID(*storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !storage.IsVisibleToWorkspace(workspaceID) {
+		return nil, nil
+	}
+
+	return storage, nil
+}
@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
@@ -14,9 +15,11 @@ var storageRepository = &StorageRepository{}
 var storageService = &StorageService{
 	storageRepository,
 	workspaces_services.GetWorkspaceService(),
+	users_services.GetSettingsService(),
 	audit_logs.GetAuditLogService(),
 	encryption.GetFieldEncryptor(),
 	nil,
+	nil,
 }
 var storageController = &StorageController{
 	storageService,
@@ -1,7 +1,201 @@
 package storages
 
-import "github.com/google/uuid"
+import (
+	"maps"
+	"time"
+
+	"databasus-backend/internal/features/ownership"
+	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
+	ftp_storage "databasus-backend/internal/features/storages/models/ftp"
+	gcs_storage "databasus-backend/internal/features/storages/models/gcs"
+	google_drive_storage "databasus-backend/internal/features/storages/models/google_drive"
+	local_storage "databasus-backend/internal/features/storages/models/local"
+	nas_storage "databasus-backend/internal/features/storages/models/nas"
+	plugin_storage "databasus-backend/internal/features/storages/models/plugin"
+	rclone_storage "databasus-backend/internal/features/storages/models/rclone"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
+	sftp_storage "databasus-backend/internal/features/storages/models/sftp"
+	users_enums "databasus-backend/internal/features/users/enums"
+	files_utils "databasus-backend/internal/util/files"
+
+	"github.com/google/uuid"
+)
 
 type TransferStorageRequest struct {
 	TargetWorkspaceID uuid.UUID `json:"targetWorkspaceId" binding:"required"`
 }
+
+type SetDefaultStorageRequest struct {
+	// StorageID clears the default if empty
+	StorageID *uuid.UUID `json:"storageId"`
+}
+
+// BrowseStorageResponse lists folders first, IsTruncated is set when the directory has more
+// entries than returned
+type BrowseStorageResponse struct {
+	Path        string                       `json:"path"`
+	Entries     []files_utils.DirectoryEntry `json:"entries"`
+	IsTruncated bool                         `json:"isTruncated"`
+}
+
+type BenchmarkStorageRequest struct {
+	// SizeMb is the size of the test object, 16 MB when empty
+	SizeMb int `json:"sizeMb"`
+}
+
+// StorageBenchmarkResult has throughputs in MB/s. Multipart fields are set for storages
+// uploading in parts, other storages upload the object as one stream
+type StorageBenchmarkResult struct {
+	SizeMb int `json:"sizeMb"`
+
+	UploadDurationMs    int64   `json:"uploadDurationMs"`
+	UploadThroughputMBs float64 `json:"uploadThroughputMbs"`
+
+	DownloadFirstByteMs   int64   `json:"downloadFirstByteMs"`
+	DownloadDurationMs    int64   `json:"downloadDurationMs"`
+	DownloadThroughputMBs float64 `json:"downloadThroughputMbs"`
+
+	DeleteDurationMs int64 `json:"deleteDurationMs"`
+
+	IsMultipart bool    `json:"isMultipart"`
+	PartSizeMb  float64 `json:"partSizeMb"`
+	PartsCount  int     `json:"partsCount"`
+}
+
+// StorageResponse has the same JSON shape as Storage, but specific storages are copies.
+// Secrets are blanked on the copies, so a loaded model is never left with empty
+// credentials which could be saved back by a later call
+type StorageResponse struct {
+	ID            uuid.UUID   `json:"id"`
+	WorkspaceID   uuid.UUID   `json:"workspaceId"`
+	Type          StorageType `json:"type"`
+	Name          string      `json:"name"`
+	LastSaveError *string     `json:"lastSaveError"`
+	IsSystem      bool        `json:"isSystem"`
+
+	VisibleWorkspaceIDs []uuid.UUID `json:"visibleWorkspaceIds"`
+	FileNameTemplate    string      `json:"fileNameTemplate"`
+	IsContentAddressed  bool        `json:"isContentAddressed"`
+	CredentialsExpireAt *time.Time  `json:"credentialsExpireAt"`
+
+	ownership.Ownership
+
+	LocalStorage       *local_storage.LocalStorage              `json:"localStorage"`
+	S3Storage          *s3_storage.S3Storage                    `json:"s3Storage"`
+	GoogleDriveStorage *google_drive_storage.GoogleDriveStorage `json:"googleDriveStorage"`
+	NASStorage         *nas_storage.NASStorage                  `json:"nasStorage"`
+	AzureBlobStorage   *azure_blob_storage.AzureBlobStorage     `json:"azureBlobStorage"`
+	FTPStorage         *ftp_storage.FTPStorage                  `json:"ftpStorage"`
+	SFTPStorage        *sftp_storage.SFTPStorage                `json:"sftpStorage"`
+	RcloneStorage      *rclone_storage.RcloneStorage            `json:"rcloneStorage"`
+	PluginStorage      *plugin_storage.PluginStorage            `json:"pluginStorage"`
+	GCSStorage         *gcs_storage.GCSStorage                  `json:"gcsStorage"`
+}
+
+// ToStorageResponses maps storages in one pass. redactionLevel tells how much of system
+// storages shown to non-admins is returned, other storages get NONE
+func ToStorageResponses(
+	storages []*Storage,
+	redactionLevel func(storage *Storage) users_enums.StorageRedactionLevel,
+) []*StorageResponse {
+	responses := make([]*StorageResponse, 0, len(storages))
+
+	for _, storage := range storages {
+		responses = append(responses, ToRedactedStorageResponse(storage, redactionLevel(storage)))
+	}
+
+	return responses
+}
+
+// ToRedactedStorageResponse drops storage settings for FULL and the visible workspaces for
+// METADATA_ONLY. Secrets are hidden on every level
+func ToRedactedStorageResponse(
+	storage *Storage,
+	level users_enums.StorageRedactionLevel,
+) *StorageResponse {
+	switch level {
+	case users_enums.StorageRedactionLevelNone:
+		return ToStorageResponse(storage, false)
+	case users_enums.StorageRedactionLevelMetadataOnly:
+		response := ToStorageResponse(storage, false)
+		response.VisibleWorkspaceIDs = nil
+		return response
+	default:
+		return ToStorageResponse(storage, true)
+	}
+}
+
+func ToStorageResponse(storage *Storage, isSpecificDataHidden bool) *StorageResponse {
+	response := &StorageResponse{
+		ID:            storage.ID,
+		WorkspaceID:   storage.WorkspaceID,
+		Type:          storage.Type,
+		Name:          storage.Name,
+		LastSaveError: storage.LastSaveError,
+		IsSystem:      storage.IsSystem,
+
+		FileNameTemplate:   storage.FileNameTemplate,
+		IsContentAddressed: storage.IsContentAddressed,
+
+		CredentialsExpireAt: storage.CredentialsExpireAt,
+
+		Ownership: storage.Ownership,
+	}
+
+	// only admins manage the list, other users see the storage is shared with them only
+	if !isSpecificDataHidden {
+		response.VisibleWorkspaceIDs = storage.VisibleWorkspaceIDs
+	}
+
+	if isSpecificDataHidden {
+		return response
+	}
+
+	response.LocalStorage = copyPointer(storage.LocalStorage)
+	response.S3Storage = copyPointer(storage.S3Storage)
+	response.GoogleDriveStorage = copyPointer(storage.GoogleDriveStorage)
+	response.NASStorage = copyPointer(storage.NASStorage)
+	response.AzureBlobStorage = copyPointer(storage.AzureBlobStorage)
+	response.FTPStorage = copyPointer(storage.FTPStorage)
+	response.SFTPStorage = copyPointer(storage.SFTPStorage)
+	response.RcloneStorage = copyPointer(storage.RcloneStorage)
+	response.PluginStorage = copyPointer(storage.PluginStorage)
+	response.GCSStorage = copyPointer(storage.GCSStorage)
+
+	// The only reference field among storages, a shallow copy would share it with the model
+	if response.PluginStorage != nil {
+		response.PluginStorage.SecretConfig = maps.Clone(storage.PluginStorage.SecretConfig)
+	}
+
+	response.hideSensitiveData()
+
+	return response
+}
+
+func (r *StorageResponse) hideSensitiveData() {
+	copiedStorage := &Storage{
+		Type:               r.Type,
+		LocalStorage:       r.LocalStorage,
+		S3Storage:          r.S3Storage,
+		GoogleDriveStorage: r.GoogleDriveStorage,
+		NASStorage:         r.NASStorage,
+		AzureBlobStorage:   r.AzureBlobStorage,
+		FTPStorage:         r.FTPStorage,
+		SFTPStorage:        r.SFTPStorage,
+		RcloneStorage:      r.RcloneStorage,
+		PluginStorage:      r.PluginStorage,
+		GCSStorage:         r.GCSStorage,
+	}
+
+	copiedStorage.HideSensitiveData()
+}
+
+func copyPointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+
+	return &copied
+}
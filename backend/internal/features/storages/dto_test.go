@@ -0,0 +1,100 @@
+package storages
+
+import (
+	"testing"
+
+	plugin_storage "databasus-backend/internal/features/storages/models/plugin"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
+	users_enums "databasus-backend/internal/features/users/enums"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToStorageResponse_SecretsHiddenAndModelNotMutated(t *testing.T) {
+	storage := &Storage{
+		ID:   uuid.New(),
+		Type: StorageTypeS3,
+		Name: "S3",
+		S3Storage: &s3_storage.S3Storage{
+			S3Bucket:    "backups",
+			S3AccessKey: "enc:access",
+			S3SecretKey: "enc:secret",
+		},
+	}
+
+	response := ToStorageResponse(storage, false)
+
+	assert.Equal(t, "backups", response.S3Storage.S3Bucket)
+	assert.Empty(t, response.S3Storage.S3AccessKey)
+	assert.Empty(t, response.S3Storage.S3SecretKey)
+
+	assert.Equal(t, "enc:access", storage.S3Storage.S3AccessKey)
+	assert.Equal(t, "enc:secret", storage.S3Storage.S3SecretKey)
+}
+
+func Test_ToStorageResponse_WhenPluginStorage_SecretConfigOfModelNotMutated(t *testing.T) {
+	storage := &Storage{
+		ID:   uuid.New(),
+		Type: StorageTypePlugin,
+		Name: "Plugin",
+		PluginStorage: &plugin_storage.PluginStorage{
+			SecretConfig: map[string]string{"token": "enc:token"},
+		},
+	}
+
+	response := ToStorageResponse(storage, false)
+
+	assert.Empty(t, response.PluginStorage.SecretConfig["token"])
+	assert.Equal(t, "enc:token", storage.PluginStorage.SecretConfig["token"])
+}
+
+func Test_ToStorageResponses_WhenSpecificDataHidden_OnlyCommonFieldsReturned(t *testing.T) {
+	systemStorage := &Storage{
+		ID:        uuid.New(),
+		Type:      StorageTypeS3,
+		Name:      "System S3",
+		IsSystem:  true,
+		S3Storage: &s3_storage.S3Storage{S3Bucket: "system"},
+	}
+
+	responses := ToStorageResponses(
+		[]*Storage{systemStorage},
+		func(storage *Storage) users_enums.StorageRedactionLevel {
+			return users_enums.StorageRedactionLevelFull
+		},
+	)
+
+	assert.Len(t, responses, 1)
+	assert.Equal(t, systemStorage.Name, responses[0].Name)
+	assert.Nil(t, responses[0].S3Storage)
+	assert.NotNil(t, systemStorage.S3Storage)
+}
+
+func Test_ToRedactedStorageResponse_WhenMetadataOnly_SettingsReturnedWithoutSecrets(t *testing.T) {
+	systemStorage := &Storage{
+		ID:                  uuid.New(),
+		Type:                StorageTypeS3,
+		Name:                "System S3",
+		IsSystem:            true,
+		VisibleWorkspaceIDs: []uuid.UUID{uuid.New()},
+		S3Storage: &s3_storage.S3Storage{
+			S3Bucket:    "system",
+			S3Region:    "eu-central-1",
+			S3SecretKey: "enc:secret",
+		},
+	}
+
+	response := ToRedactedStorageResponse(
+		systemStorage,
+		users_enums.StorageRedactionLevelMetadataOnly,
+	)
+
+	assert.Equal(t, "system", response.S3Storage.S3Bucket)
+	assert.Equal(t, "eu-central-1", response.S3Storage.S3Region)
+	assert.Empty(t, response.S3Storage.S3SecretKey)
+	assert.Nil(t, response.VisibleWorkspaceIDs)
+
+	response = ToRedactedStorageResponse(systemStorage, users_enums.StorageRedactionLevelNone)
+	assert.Len(t, response.VisibleWorkspaceIDs, 1)
+}
@@ -11,4 +11,6 @@ const (
 	StorageTypeFTP         StorageType = "FTP"
 	StorageTypeSFTP        StorageType = "SFTP"
 	StorageTypeRclone      StorageType = "RCLONE"
+	StorageTypePlugin      StorageType = "PLUGIN"
+	StorageTypeGCS         StorageType = "GCS"
 )
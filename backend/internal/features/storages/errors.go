@@ -15,6 +15,12 @@ var (
 	ErrInsufficientPermissionsToTestStorage = errors.New(
 		"insufficient permissions to test storage in this workspace",
 	)
+	ErrInsufficientPermissionsToBenchmarkStorage = errors.New(
+		"insufficient permissions to benchmark storage in this workspace",
+	)
+	ErrInsufficientPermissionsToBrowseStorage = errors.New(
+		"insufficient permissions to browse files of this storage",
+	)
 	ErrInsufficientPermissionsInSourceWorkspace = errors.New(
 		"insufficient permissions to manage storage in source workspace",
 	)
@@ -27,6 +33,9 @@ var (
 	ErrStorageHasAttachedDatabases = errors.New(
 		"storage has attached databases and cannot be deleted",
 	)
+	ErrStorageReferencedByProd = errors.New(
+		"storage keeps backups of PROD databases and cannot be deleted",
+	)
 	ErrStorageHasAttachedDatabasesCannotTransfer = errors.New(
 		"storage has attached databases and cannot be transferred",
 	)
@@ -42,4 +51,19 @@ var (
 	ErrLocalStorageNotAllowedInCloudMode = errors.New(
 		"local storage can only be managed by administrators in cloud mode",
 	)
+	ErrVisibleWorkspacesOnlyForSystemStorage = errors.New(
+		"visible workspaces can be set only for system storages",
+	)
+	ErrFileNamingNotSupported = errors.New(
+		"file name templates are supported only by S3 storages",
+	)
+	ErrContentAddressingNotSupported = errors.New(
+		"content-addressed layout is supported only by S3 storages",
+	)
+	ErrFileListingNotSupported = errors.New(
+		"listing existing files is supported only by S3 storages",
+	)
+	ErrFileBrowsingNotSupported = errors.New(
+		"browsing files is not supported by this storage type",
+	)
 )
@@ -0,0 +1,58 @@
+package storages_impact
+
+import (
+	"errors"
+	"net/http"
+
+	"databasus-backend/internal/features/storages"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StorageImpactController struct {
+	storageImpactService *StorageImpactService
+}
+
+func (c *StorageImpactController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/storages/:id/impact", c.GetStorageImpact)
+}
+
+// GetStorageImpact
+// @Summary Preview storage deletion impact
+// @Description Get databases attached to the storage, count and size of backups stored there and scheduled jobs that break once it is deleted
+// @Tags storages
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Storage ID"
+// @Success 200 {object} StorageImpact
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/{id}/impact [get]
+func (c *StorageImpactController) GetStorageImpact(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	impact, err := c.storageImpactService.GetStorageImpact(user, id)
+	if err != nil {
+		if errors.Is(err, storages.ErrInsufficientPermissionsToManageStorage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impact)
+}
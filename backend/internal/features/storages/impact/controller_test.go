@@ -0,0 +1,87 @@
+package storages_impact
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/period"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetStorageImpactController(),
+	)
+}
+
+func Test_GetStorageImpact_WhenDatabaseAttached_ReportsBlockedDeletion(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	viewer := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace,
+		viewer,
+		users_enums.WorkspaceRoleViewer,
+		owner.Token,
+		router,
+	)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	backupConfig, err := backups_config.GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.StorePeriod = period.PeriodWeek
+	backupConfig.Storage = storage
+	backupConfig.StorageID = &storage.ID
+
+	_, err = backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	var impact StorageImpact
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/impact",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&impact,
+	)
+
+	assert.Len(t, impact.AttachedDatabases, 1)
+	assert.Equal(t, database.ID, impact.AttachedDatabases[0].ID)
+	assert.Equal(t, int64(0), impact.BackupsCount)
+	assert.Len(t, impact.ScheduledJobs, 1)
+	assert.Equal(t, ImpactedJobTypeBackupSchedule, impact.ScheduledJobs[0].Type)
+	assert.False(t, impact.IsDeletable)
+	assert.NotEmpty(t, impact.BlockingReasons)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/impact",
+		"Bearer "+viewer.Token,
+		http.StatusForbidden,
+	)
+}
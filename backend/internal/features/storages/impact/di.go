@@ -0,0 +1,32 @@
+package storages_impact
+
+import (
+	"databasus-backend/internal/features/backups/backups"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	restores_refreshes "databasus-backend/internal/features/restores/refreshes"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+var storageImpactService = &StorageImpactService{
+	&backups_core.BackupRepository{},
+	&restores_refreshes.RefreshRepository{},
+	storages.GetStorageService(),
+	backups_config.GetBackupConfigService(),
+	backups.GetBackupService(),
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+}
+var storageImpactController = &StorageImpactController{
+	storageImpactService,
+}
+
+func GetStorageImpactService() *StorageImpactService {
+	return storageImpactService
+}
+
+func GetStorageImpactController() *StorageImpactController {
+	return storageImpactController
+}
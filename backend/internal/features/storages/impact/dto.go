@@ -0,0 +1,43 @@
+package storages_impact
+
+import (
+	"github.com/google/uuid"
+)
+
+type ImpactedJobType string
+
+const (
+	ImpactedJobTypeBackupSchedule ImpactedJobType = "BACKUP_SCHEDULE"
+	ImpactedJobTypeRefresh        ImpactedJobType = "REFRESH"
+	ImpactedJobTypeMetadataBackup ImpactedJobType = "METADATA_BACKUP"
+)
+
+type ImpactedDatabase struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	WorkspaceID      *uuid.UUID `json:"workspaceId"`
+	IsBackupsEnabled bool       `json:"isBackupsEnabled"`
+}
+
+// ImpactedJob is a scheduled job failing once the storage is gone. DatabaseID is empty for
+// the metadata backup of the instance
+type ImpactedJob struct {
+	Type       ImpactedJobType `json:"type"`
+	Name       string          `json:"name"`
+	DatabaseID *uuid.UUID      `json:"databaseId,omitempty"`
+}
+
+// StorageImpact lists what deleting the storage breaks. BlockingReasons explains why
+// deletion is rejected, it is empty if the storage can be deleted
+type StorageImpact struct {
+	StorageID   uuid.UUID `json:"storageId"`
+	StorageName string    `json:"storageName"`
+
+	AttachedDatabases  []ImpactedDatabase `json:"attachedDatabases"`
+	BackupsCount       int64              `json:"backupsCount"`
+	BackupsTotalSizeMb float64            `json:"backupsTotalSizeMb"`
+	ScheduledJobs      []ImpactedJob      `json:"scheduledJobs"`
+
+	IsDeletable     bool     `json:"isDeletable"`
+	BlockingReasons []string `json:"blockingReasons"`
+}
@@ -0,0 +1,152 @@
+package storages_impact
+
+import (
+	"fmt"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/features/backups/backups"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	restores_refreshes "databasus-backend/internal/features/restores/refreshes"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+type StorageImpactService struct {
+	backupRepository    *backups_core.BackupRepository
+	refreshRepository   *restores_refreshes.RefreshRepository
+	storageService      *storages.StorageService
+	backupConfigService *backups_config.BackupConfigService
+	backupService       *backups.BackupService
+	databaseService     *databases.DatabaseService
+	workspaceService    *workspaces_services.WorkspaceService
+}
+
+// GetStorageImpact reports what deleting the storage would break. It is available to the
+// users allowed to delete the storage
+func (s *StorageImpactService) GetStorageImpact(
+	user *users_models.User,
+	storageID uuid.UUID,
+) (*StorageImpact, error) {
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(storage.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage || (storage.IsSystem && user.Role != users_enums.UserRoleAdmin) {
+		return nil, storages.ErrInsufficientPermissionsToManageStorage
+	}
+
+	impact := &StorageImpact{
+		StorageID:         storage.ID,
+		StorageName:       storage.Name,
+		AttachedDatabases: make([]ImpactedDatabase, 0),
+		ScheduledJobs:     make([]ImpactedJob, 0),
+		BlockingReasons:   make([]string, 0),
+	}
+
+	if err := s.addAttachedDatabases(impact); err != nil {
+		return nil, err
+	}
+
+	if impact.BackupsCount, err = s.backupRepository.CountByStorageID(storage.ID); err != nil {
+		return nil, err
+	}
+
+	impact.BackupsTotalSizeMb, err = s.backupRepository.GetTotalSizeByStorage(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.GetEnv().MetadataBackupStorageID == storage.ID.String() {
+		impact.ScheduledJobs = append(impact.ScheduledJobs, ImpactedJob{
+			Type: ImpactedJobTypeMetadataBackup,
+			Name: "Metadata backup of this instance",
+		})
+	}
+
+	if len(impact.AttachedDatabases) > 0 {
+		impact.BlockingReasons = append(
+			impact.BlockingReasons,
+			fmt.Sprintf(
+				"%d databases are attached, move them to another storage first",
+				len(impact.AttachedDatabases),
+			),
+		)
+	}
+
+	isReferencedByProd, err := s.backupService.IsStorageReferencedByProd(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+	if isReferencedByProd {
+		impact.BlockingReasons = append(
+			impact.BlockingReasons,
+			storages.ErrStorageReferencedByProd.Error(),
+		)
+	}
+
+	impact.IsDeletable = len(impact.BlockingReasons) == 0
+
+	return impact, nil
+}
+
+// addAttachedDatabases adds databases backing up to the storage with their backup
+// schedules and refresh jobs, which restore the latest backup of the database
+func (s *StorageImpactService) addAttachedDatabases(impact *StorageImpact) error {
+	databaseIDs, err := s.backupConfigService.GetStorageAttachedDatabasesIDs(impact.StorageID)
+	if err != nil {
+		return err
+	}
+
+	for _, databaseID := range databaseIDs {
+		database, err := s.databaseService.GetDatabaseByID(databaseID)
+		if err != nil {
+			return err
+		}
+
+		backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+		if err != nil {
+			return err
+		}
+
+		impact.AttachedDatabases = append(impact.AttachedDatabases, ImpactedDatabase{
+			ID:               database.ID,
+			Name:             database.Name,
+			WorkspaceID:      database.WorkspaceID,
+			IsBackupsEnabled: backupConfig.IsBackupsEnabled,
+		})
+
+		if backupConfig.IsBackupsEnabled {
+			impact.ScheduledJobs = append(impact.ScheduledJobs, ImpactedJob{
+				Type:       ImpactedJobTypeBackupSchedule,
+				Name:       "Backups of " + database.Name,
+				DatabaseID: &database.ID,
+			})
+		}
+	}
+
+	refreshJobs, err := s.refreshRepository.FindEnabledJobsByDatabaseIDs(databaseIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range refreshJobs {
+		impact.ScheduledJobs = append(impact.ScheduledJobs, ImpactedJob{
+			Type:       ImpactedJobTypeRefresh,
+			Name:       job.Name,
+			DatabaseID: &job.DatabaseID,
+		})
+	}
+
+	return nil
+}
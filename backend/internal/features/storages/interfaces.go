@@ -3,13 +3,16 @@ package storages
 import (
 	"context"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
 	"io"
 	"log/slog"
 
 	"github.com/google/uuid"
 )
 
-type StorageFileSaver interface {
+// StorageBackend is implemented by every storage type, including
+// out-of-process plugins (see pkg/storage_plugin)
+type StorageBackend interface {
 	SaveFile(
 		ctx context.Context,
 		encryptor encryption.FieldEncryptor,
@@ -31,6 +34,28 @@ type StorageFileSaver interface {
 	EncryptSensitiveData(encryptor encryption.FieldEncryptor) error
 }
 
+// MultipartUploader is implemented by storages uploading files in separate parts, the
+// benchmark reports how many parts a file of the given size takes
+type MultipartUploader interface {
+	GetUploadPartSizeBytes() int64
+}
+
+// DirectoryBrowser is implemented by storages listing their folders for the file browser,
+// directory is relative to the root folder of the storage and "" for the root folder
+type DirectoryBrowser interface {
+	BrowseDirectory(
+		ctx context.Context,
+		encryptor encryption.FieldEncryptor,
+		directory string,
+		limit int,
+	) ([]files_utils.DirectoryEntry, error)
+}
+
 type StorageDatabaseCounter interface {
 	GetStorageAttachedDatabasesIDs(storageID uuid.UUID) ([]uuid.UUID, error)
 }
+
+// StorageProdReferenceChecker reports whether the storage keeps backups of PROD databases
+type StorageProdReferenceChecker interface {
+	IsStorageReferencedByProd(storageID uuid.UUID) (bool, error)
+}
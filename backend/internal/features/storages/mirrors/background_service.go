@@ -0,0 +1,54 @@
+package storages_mirrors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// replicaRetryInterval spaces attempts of failed replicas, replicas of completed backups
+// are copied right away by the node which made the backup
+const replicaRetryInterval = 10 * time.Minute
+
+type StorageMirrorBackgroundService struct {
+	storageMirrorService *StorageMirrorService
+	logger               *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (s *StorageMirrorBackgroundService) Run(ctx context.Context) {
+	wasAlreadyRun := s.hasRun.Load()
+
+	s.runOnce.Do(func() {
+		s.hasRun.Store(true)
+
+		s.logger.Info("Starting storage mirror background service")
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(replicaRetryInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.storageMirrorService.RetryReplicas(); err != nil {
+					s.logger.Error("Failed to retry backup replicas", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", s))
+	}
+}
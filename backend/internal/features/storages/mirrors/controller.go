@@ -0,0 +1,226 @@
+package storages_mirrors
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StorageMirrorController struct {
+	storageMirrorService *StorageMirrorService
+}
+
+func (c *StorageMirrorController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/storage-mirror-groups", c.GetMirrorGroups)
+	router.POST("/storage-mirror-groups", c.CreateMirrorGroup)
+	router.PUT("/storage-mirror-groups/:id", c.UpdateMirrorGroup)
+	router.DELETE("/storage-mirror-groups/:id", c.DeleteMirrorGroup)
+	router.GET("/backups/:id/replicas", c.GetBackupReplicas)
+}
+
+// GetMirrorGroups
+// @Summary Get storage mirror groups
+// @Description Get groups of equivalent storages of the workspace
+// @Tags storages
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param workspace_id query string true "Workspace ID"
+// @Success 200 {array} StorageMirrorGroup
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storage-mirror-groups [get]
+func (c *StorageMirrorController) GetMirrorGroups(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceIDStr := ctx.Query("workspace_id")
+	if workspaceIDStr == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "workspace_id query parameter is required"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(workspaceIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace_id"})
+		return
+	}
+
+	groups, err := c.storageMirrorService.GetMirrorGroups(user, workspaceID)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewMirrorGroups) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
+// CreateMirrorGroup
+// @Summary Create storage mirror group
+// @Description Join equivalent storages. Backups are uploaded to the member with the lowest latency from the backuper node and replicated to the other members
+// @Tags storages
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param request body CreateMirrorGroupRequest true "Mirror group"
+// @Success 200 {object} StorageMirrorGroup
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storage-mirror-groups [post]
+func (c *StorageMirrorController) CreateMirrorGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CreateMirrorGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.storageMirrorService.CreateMirrorGroup(user, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageMirrorGroups) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, group)
+}
+
+// UpdateMirrorGroup
+// @Summary Update storage mirror group
+// @Description Rename the group and replace its storages
+// @Tags storages
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Mirror group ID"
+// @Param request body UpdateMirrorGroupRequest true "Mirror group"
+// @Success 200 {object} StorageMirrorGroup
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storage-mirror-groups/{id} [put]
+func (c *StorageMirrorController) UpdateMirrorGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid mirror group ID"})
+		return
+	}
+
+	var request UpdateMirrorGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.storageMirrorService.UpdateMirrorGroup(user, id, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageMirrorGroups) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, group)
+}
+
+// DeleteMirrorGroup
+// @Summary Delete storage mirror group
+// @Description Stop selecting and replicating between the storages, existing replicas are kept
+// @Tags storages
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Mirror group ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storage-mirror-groups/{id} [delete]
+func (c *StorageMirrorController) DeleteMirrorGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid mirror group ID"})
+		return
+	}
+
+	if err := c.storageMirrorService.DeleteMirrorGroup(user, id); err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageMirrorGroups) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetBackupReplicas
+// @Summary Get backup replicas
+// @Description Get copies of the backup on other members of the mirror group of its storage
+// @Tags backups
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Backup ID"
+// @Success 200 {array} BackupReplica
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /backups/{id}/replicas [get]
+func (c *StorageMirrorController) GetBackupReplicas(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	replicas, err := c.storageMirrorService.GetBackupReplicas(user, id)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewMirrorGroups) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replicas)
+}
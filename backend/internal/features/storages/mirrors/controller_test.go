@@ -0,0 +1,113 @@
+package storages_mirrors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetStorageMirrorController(),
+	)
+}
+
+func Test_CreateMirrorGroup_WithWorkspaceStorages_GroupListedAndStoragesExclusive(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	firstStorage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(firstStorage.ID)
+	secondStorage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(secondStorage.ID)
+
+	var group StorageMirrorGroup
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storage-mirror-groups",
+		"Bearer "+owner.Token,
+		CreateMirrorGroupRequest{
+			WorkspaceID: workspace.ID,
+			Name:        "EU and US buckets",
+			StorageIDs:  []uuid.UUID{firstStorage.ID, secondStorage.ID},
+		},
+		http.StatusOK,
+		&group,
+	)
+	defer func() { _ = storageMirrorRepository.DeleteGroup(group.ID) }()
+
+	assert.ElementsMatch(t, []uuid.UUID{firstStorage.ID, secondStorage.ID}, group.StorageIDs)
+
+	var groups []StorageMirrorGroup
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storage-mirror-groups?workspace_id="+workspace.ID.String(),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&groups,
+	)
+
+	assert.Len(t, groups, 1)
+	assert.Equal(t, group.ID, groups[0].ID)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/storage-mirror-groups",
+		"Bearer "+owner.Token,
+		CreateMirrorGroupRequest{
+			WorkspaceID: workspace.ID,
+			Name:        "Duplicate",
+			StorageIDs:  []uuid.UUID{firstStorage.ID, secondStorage.ID},
+		},
+		http.StatusBadRequest,
+	)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/storage-mirror-groups?workspace_id="+workspace.ID.String(),
+		"Bearer "+outsider.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_CreateMirrorGroup_WithSingleStorage_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/storage-mirror-groups",
+		"Bearer "+owner.Token,
+		CreateMirrorGroupRequest{
+			WorkspaceID: workspace.ID,
+			Name:        "Single",
+			StorageIDs:  []uuid.UUID{storage.ID, storage.ID},
+		},
+		http.StatusBadRequest,
+	)
+}
@@ -0,0 +1,79 @@
+package storages_mirrors
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var storageMirrorRepository = &StorageMirrorRepository{}
+var storageLatencyProbe = &StorageLatencyProbe{
+	cache_utils.NewCacheUtil[StorageLatency](
+		cache_utils.GetValkeyClient(),
+		"storage_latency:",
+	),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var storageMirrorService = &StorageMirrorService{
+	storageMirrorRepository,
+	&backups_core.BackupRepository{},
+	storages.GetStorageService(),
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	storageLatencyProbe,
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
+}
+var storageMirrorController = &StorageMirrorController{
+	storageMirrorService,
+}
+var storageMirrorBackgroundService = &StorageMirrorBackgroundService{
+	storageMirrorService: storageMirrorService,
+	logger:               logger.GetLogger(),
+}
+
+func GetStorageMirrorService() *StorageMirrorService {
+	return storageMirrorService
+}
+
+func GetStorageMirrorController() *StorageMirrorController {
+	return storageMirrorController
+}
+
+func GetStorageMirrorBackgroundService() *StorageMirrorBackgroundService {
+	return storageMirrorBackgroundService
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		backuping.GetBackuperNode().SetBackupStorageSelector(storageMirrorService)
+		backuping.GetBackuperNode().AddBackupCompletedListener(storageMirrorService)
+		backuping.GetBackupCleaner().AddBackupRemoveListener(storageMirrorService)
+		backups.GetBackupService().AddBackupRemoveListener(storageMirrorService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
@@ -0,0 +1,14 @@
+package storages_mirrors
+
+import "github.com/google/uuid"
+
+type CreateMirrorGroupRequest struct {
+	WorkspaceID uuid.UUID   `json:"workspaceId" binding:"required"`
+	Name        string      `json:"name"        binding:"required"`
+	StorageIDs  []uuid.UUID `json:"storageIds"  binding:"required"`
+}
+
+type UpdateMirrorGroupRequest struct {
+	Name       string      `json:"name"       binding:"required"`
+	StorageIDs []uuid.UUID `json:"storageIds" binding:"required"`
+}
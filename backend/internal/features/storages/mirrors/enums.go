@@ -0,0 +1,9 @@
+package storages_mirrors
+
+type ReplicaStatus string
+
+const (
+	ReplicaStatusPending   ReplicaStatus = "PENDING"
+	ReplicaStatusCompleted ReplicaStatus = "COMPLETED"
+	ReplicaStatusFailed    ReplicaStatus = "FAILED"
+)
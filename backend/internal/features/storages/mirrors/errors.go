@@ -0,0 +1,23 @@
+package storages_mirrors
+
+import "errors"
+
+var (
+	ErrInsufficientPermissionsToViewMirrorGroups = errors.New(
+		"insufficient permissions to view storage mirror groups",
+	)
+	ErrInsufficientPermissionsToManageMirrorGroups = errors.New(
+		"insufficient permissions to manage storage mirror groups",
+	)
+	ErrMirrorGroupNameRequired = errors.New("mirror group name is required")
+	ErrNotEnoughMirrorStorages = errors.New(
+		"a mirror group needs at least two different storages",
+	)
+	ErrMirrorStorageNotInWorkspace = errors.New(
+		"mirrored storages must belong to the workspace of the group",
+	)
+	ErrSystemStorageCannotBeMirrored = errors.New("system storages cannot be mirrored")
+	ErrStorageAlreadyMirrored        = errors.New(
+		"storage already belongs to another mirror group",
+	)
+)
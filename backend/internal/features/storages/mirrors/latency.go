@@ -0,0 +1,150 @@
+package storages_mirrors
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"databasus-backend/internal/features/storages"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// reachableLatencyTTL keeps measurements of a node-storage pair while latency between
+	// regions rarely changes, unreachable storages are probed again sooner
+	reachableLatencyTTL   = 15 * time.Minute
+	unreachableLatencyTTL = 2 * time.Minute
+
+	// latencyProbeTimeout bounds how long a backup waits for measurements, slower storages
+	// are not picked and are cached once their probe ends
+	latencyProbeTimeout = 30 * time.Second
+)
+
+// StorageLatency is the duration of a connection test of a storage from a backuper node
+type StorageLatency struct {
+	LatencyMs   int64     `json:"latencyMs"`
+	IsReachable bool      `json:"isReachable"`
+	MeasuredAt  time.Time `json:"measuredAt"`
+}
+
+type StorageLatencyProbe struct {
+	cache          *cache_utils.CacheUtil[StorageLatency]
+	fieldEncryptor encryption.FieldEncryptor
+	logger         *slog.Logger
+}
+
+// MeasureLatencies returns latencies of the storages from the node, measuring the ones
+// without a cached measurement in parallel. Storages not measured in time are left out
+func (p *StorageLatencyProbe) MeasureLatencies(
+	nodeID uuid.UUID,
+	storageList []*storages.Storage,
+) map[uuid.UUID]*StorageLatency {
+	latencies := make(map[uuid.UUID]*StorageLatency, len(storageList))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, storage := range storageList {
+		if latency := p.cache.Get(getLatencyCacheKey(nodeID, storage.ID)); latency != nil {
+			latencies[storage.ID] = latency
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			latency := p.measureLatency(nodeID, storage)
+
+			mu.Lock()
+			defer mu.Unlock()
+			latencies[storage.ID] = latency
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(latencyProbeTimeout):
+		p.logger.Warn("Storage latency probes timed out", "nodeId", nodeID)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := make(map[uuid.UUID]*StorageLatency, len(latencies))
+	for storageID, latency := range latencies {
+		result[storageID] = latency
+	}
+
+	return result
+}
+
+func (p *StorageLatencyProbe) measureLatency(
+	nodeID uuid.UUID,
+	storage *storages.Storage,
+) *StorageLatency {
+	start := time.Now()
+	err := storage.TestConnection(p.fieldEncryptor)
+
+	latency := &StorageLatency{
+		LatencyMs:   time.Since(start).Milliseconds(),
+		IsReachable: err == nil,
+		MeasuredAt:  time.Now().UTC(),
+	}
+
+	ttl := reachableLatencyTTL
+	if err != nil {
+		ttl = unreachableLatencyTTL
+		p.logger.Warn(
+			"Mirrored storage is unreachable from backuper node",
+			"nodeId", nodeID,
+			"storageId", storage.ID,
+			"error", err,
+		)
+	}
+
+	p.cache.SetWithExpiration(getLatencyCacheKey(nodeID, storage.ID), latency, ttl)
+
+	return latency
+}
+
+// selectFastestStorage picks the reachable storage with the lowest latency. The configured
+// storage wins ties and is kept when no storage is known to be reachable
+func selectFastestStorage(
+	configured *storages.Storage,
+	candidates []*storages.Storage,
+	latencies map[uuid.UUID]*StorageLatency,
+) *storages.Storage {
+	selected := configured
+	selectedLatency, ok := latencies[configured.ID]
+	if ok && !selectedLatency.IsReachable {
+		selectedLatency = nil
+	}
+
+	for _, candidate := range candidates {
+		latency, ok := latencies[candidate.ID]
+		if !ok || !latency.IsReachable || candidate.ID == configured.ID {
+			continue
+		}
+
+		if selectedLatency == nil || latency.LatencyMs < selectedLatency.LatencyMs {
+			selected = candidate
+			selectedLatency = latency
+		}
+	}
+
+	return selected
+}
+
+func getLatencyCacheKey(nodeID, storageID uuid.UUID) string {
+	return nodeID.String() + ":" + storageID.String()
+}
@@ -0,0 +1,57 @@
+package storages_mirrors
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/storages"
+)
+
+func Test_SelectFastestStorage_WithFasterMember_SelectsIt(t *testing.T) {
+	configured := &storages.Storage{ID: uuid.New()}
+	faster := &storages.Storage{ID: uuid.New()}
+	unreachable := &storages.Storage{ID: uuid.New()}
+
+	latencies := map[uuid.UUID]*StorageLatency{
+		configured.ID:  {LatencyMs: 200, IsReachable: true},
+		faster.ID:      {LatencyMs: 40, IsReachable: true},
+		unreachable.ID: {LatencyMs: 5, IsReachable: false},
+	}
+
+	selected := selectFastestStorage(
+		configured,
+		[]*storages.Storage{configured, faster, unreachable},
+		latencies,
+	)
+
+	assert.Equal(t, faster.ID, selected.ID)
+}
+
+func Test_SelectFastestStorage_WithEqualLatency_KeepsConfiguredStorage(t *testing.T) {
+	configured := &storages.Storage{ID: uuid.New()}
+	other := &storages.Storage{ID: uuid.New()}
+
+	latencies := map[uuid.UUID]*StorageLatency{
+		other.ID:      {LatencyMs: 50, IsReachable: true},
+		configured.ID: {LatencyMs: 50, IsReachable: true},
+	}
+
+	selected := selectFastestStorage(configured, []*storages.Storage{other, configured}, latencies)
+
+	assert.Equal(t, configured.ID, selected.ID)
+}
+
+func Test_SelectFastestStorage_WithoutReachableMembers_KeepsConfiguredStorage(t *testing.T) {
+	configured := &storages.Storage{ID: uuid.New()}
+	other := &storages.Storage{ID: uuid.New()}
+
+	latencies := map[uuid.UUID]*StorageLatency{
+		configured.ID: {LatencyMs: 10, IsReachable: false},
+	}
+
+	selected := selectFastestStorage(configured, []*storages.Storage{configured, other}, latencies)
+
+	assert.Equal(t, configured.ID, selected.ID)
+}
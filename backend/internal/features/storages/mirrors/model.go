@@ -0,0 +1,51 @@
+package storages_mirrors
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StorageMirrorGroup joins equivalent storages of a workspace. Backups of databases using
+// any member are uploaded to the member with the lowest latency from the backuper node and
+// replicated to the other members afterwards
+type StorageMirrorGroup struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Name        string    `json:"name"        gorm:"column:name;type:text;not null"`
+	CreatedAt   time.Time `json:"createdAt"   gorm:"column:created_at;not null"`
+
+	StorageIDs []uuid.UUID `json:"storageIds" gorm:"-"`
+}
+
+func (StorageMirrorGroup) TableName() string {
+	return "storage_mirror_groups"
+}
+
+// StorageMirrorMember is a storage of a group, a storage belongs to one group at most
+type StorageMirrorMember struct {
+	GroupID   uuid.UUID `gorm:"column:group_id;type:uuid;not null"`
+	StorageID uuid.UUID `gorm:"column:storage_id;type:uuid;primaryKey"`
+}
+
+func (StorageMirrorMember) TableName() string {
+	return "storage_mirror_members"
+}
+
+// BackupReplica is a copy of a completed backup on another member of the mirror group of
+// its storage. The file keeps the ID and name of the backup
+type BackupReplica struct {
+	ID            uuid.UUID     `json:"id"            gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	BackupID      uuid.UUID     `json:"backupId"      gorm:"column:backup_id;type:uuid;not null"`
+	StorageID     uuid.UUID     `json:"storageId"     gorm:"column:storage_id;type:uuid;not null"`
+	Status        ReplicaStatus `json:"status"        gorm:"column:status;type:text;not null"`
+	FailMessage   *string       `json:"failMessage"   gorm:"column:fail_message;type:text"`
+	AttemptsCount int           `json:"attemptsCount" gorm:"column:attempts_count;not null;default:0"`
+	CreatedAt     time.Time     `json:"createdAt"     gorm:"column:created_at;not null"`
+	UpdatedAt     time.Time     `json:"updatedAt"     gorm:"column:updated_at;not null"`
+	CompletedAt   *time.Time    `json:"completedAt"   gorm:"column:completed_at"`
+}
+
+func (BackupReplica) TableName() string {
+	return "backup_replicas"
+}
@@ -0,0 +1,149 @@
+package storages_mirrors
+
+import (
+	"errors"
+	"time"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StorageMirrorRepository struct{}
+
+// SaveGroup saves the group and replaces its members
+func (r *StorageMirrorRepository) SaveGroup(group *StorageMirrorGroup) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(group).Error; err != nil {
+			return err
+		}
+
+		if err := tx.
+			Where("group_id = ?", group.ID).
+			Delete(&StorageMirrorMember{}).
+			Error; err != nil {
+			return err
+		}
+
+		members := make([]*StorageMirrorMember, 0, len(group.StorageIDs))
+		for _, storageID := range group.StorageIDs {
+			members = append(members, &StorageMirrorMember{GroupID: group.ID, StorageID: storageID})
+		}
+
+		return tx.Create(members).Error
+	})
+}
+
+func (r *StorageMirrorRepository) DeleteGroup(groupID uuid.UUID) error {
+	return storage.GetDb().Delete(&StorageMirrorGroup{}, "id = ?", groupID).Error
+}
+
+func (r *StorageMirrorRepository) FindGroupByID(groupID uuid.UUID) (*StorageMirrorGroup, error) {
+	var group StorageMirrorGroup
+
+	if err := storage.GetDb().Where("id = ?", groupID).First(&group).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.loadStorageIDs(&group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// FindGroupByStorageID returns the group of the storage, nil when it is not mirrored
+func (r *StorageMirrorRepository) FindGroupByStorageID(
+	storageID uuid.UUID,
+) (*StorageMirrorGroup, error) {
+	var member StorageMirrorMember
+
+	err := storage.GetDb().Where("storage_id = ?", storageID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return r.FindGroupByID(member.GroupID)
+}
+
+func (r *StorageMirrorRepository) FindGroupsByWorkspaceID(
+	workspaceID uuid.UUID,
+) ([]*StorageMirrorGroup, error) {
+	var groups = make([]*StorageMirrorGroup, 0)
+
+	err := storage.GetDb().
+		Where("workspace_id = ?", workspaceID).
+		Order("name ASC").
+		Find(&groups).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if err := r.loadStorageIDs(group); err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+func (r *StorageMirrorRepository) SaveReplica(replica *BackupReplica) error {
+	replica.UpdatedAt = time.Now().UTC()
+
+	return storage.GetDb().Save(replica).Error
+}
+
+func (r *StorageMirrorRepository) FindReplicasByBackupID(
+	backupID uuid.UUID,
+) ([]*BackupReplica, error) {
+	var replicas = make([]*BackupReplica, 0)
+
+	err := storage.GetDb().
+		Where("backup_id = ?", backupID).
+		Order("created_at ASC").
+		Find(&replicas).
+		Error
+
+	return replicas, err
+}
+
+// FindReplicasToRetry returns failed replicas having attempts left and pending replicas
+// not updated since staleBefore, e.g. when the node copying them was restarted
+func (r *StorageMirrorRepository) FindReplicasToRetry(
+	maxAttempts int,
+	staleBefore time.Time,
+) ([]*BackupReplica, error) {
+	var replicas = make([]*BackupReplica, 0)
+
+	err := storage.GetDb().
+		Where(
+			"(status = ? AND attempts_count < ?) OR (status = ? AND updated_at < ?)",
+			ReplicaStatusFailed,
+			maxAttempts,
+			ReplicaStatusPending,
+			staleBefore,
+		).
+		Order("created_at ASC").
+		Find(&replicas).
+		Error
+
+	return replicas, err
+}
+
+func (r *StorageMirrorRepository) loadStorageIDs(group *StorageMirrorGroup) error {
+	group.StorageIDs = make([]uuid.UUID, 0)
+
+	return storage.GetDb().
+		Model(&StorageMirrorMember{}).
+		Where("group_id = ?", group.ID).
+		Order("storage_id ASC").
+		Pluck("storage_id", &group.StorageIDs).
+		Error
+}
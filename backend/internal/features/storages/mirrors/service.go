@@ -0,0 +1,477 @@
+package storages_mirrors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// replicationTimeout bounds copying a backup file to another member of the group
+	replicationTimeout = 6 * time.Hour
+
+	maxReplicaAttempts = 5
+)
+
+type StorageMirrorService struct {
+	storageMirrorRepository *StorageMirrorRepository
+	backupRepository        *backups_core.BackupRepository
+	storageService          *storages.StorageService
+	databaseService         *databases.DatabaseService
+	workspaceService        *workspaces_services.WorkspaceService
+	auditLogService         *audit_logs.AuditLogService
+	latencyProbe            *StorageLatencyProbe
+	fieldEncryptor          encryption.FieldEncryptor
+	logger                  *slog.Logger
+}
+
+func (s *StorageMirrorService) GetMirrorGroups(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]*StorageMirrorGroup, error) {
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewMirrorGroups
+	}
+
+	return s.storageMirrorRepository.FindGroupsByWorkspaceID(workspaceID)
+}
+
+func (s *StorageMirrorService) CreateMirrorGroup(
+	user *users_models.User,
+	request *CreateMirrorGroupRequest,
+) (*StorageMirrorGroup, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(request.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageMirrorGroups
+	}
+
+	group := &StorageMirrorGroup{
+		ID:          uuid.New(),
+		WorkspaceID: request.WorkspaceID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.applyGroupChanges(group, request.Name, request.StorageIDs); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Storage mirror group created: %s", group.Name),
+		&user.ID,
+		&group.WorkspaceID,
+	)
+
+	return group, nil
+}
+
+func (s *StorageMirrorService) UpdateMirrorGroup(
+	user *users_models.User,
+	groupID uuid.UUID,
+	request *UpdateMirrorGroupRequest,
+) (*StorageMirrorGroup, error) {
+	group, err := s.storageMirrorRepository.FindGroupByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(group.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToManageMirrorGroups
+	}
+
+	if err := s.applyGroupChanges(group, request.Name, request.StorageIDs); err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Storage mirror group updated: %s", group.Name),
+		&user.ID,
+		&group.WorkspaceID,
+	)
+
+	return group, nil
+}
+
+// DeleteMirrorGroup stops selecting and replicating between the storages, replicas made
+// before are kept
+func (s *StorageMirrorService) DeleteMirrorGroup(
+	user *users_models.User,
+	groupID uuid.UUID,
+) error {
+	group, err := s.storageMirrorRepository.FindGroupByID(groupID)
+	if err != nil {
+		return err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(group.WorkspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrInsufficientPermissionsToManageMirrorGroups
+	}
+
+	if err := s.storageMirrorRepository.DeleteGroup(group.ID); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Storage mirror group deleted: %s", group.Name),
+		&user.ID,
+		&group.WorkspaceID,
+	)
+
+	return nil
+}
+
+func (s *StorageMirrorService) GetBackupReplicas(
+	user *users_models.User,
+	backupID uuid.UUID,
+) ([]*BackupReplica, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, ErrInsufficientPermissionsToViewMirrorGroups
+	}
+
+	canView, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewMirrorGroups
+	}
+
+	return s.storageMirrorRepository.FindReplicasByBackupID(backup.ID)
+}
+
+// SelectBackupStorage picks the member of the mirror group of the storage with the lowest
+// latency from the backuper node. Databases behind agents upload from the agent, and
+// CockroachDB and Elasticsearch write to the storage from their own nodes, so they keep
+// the configured storage
+func (s *StorageMirrorService) SelectBackupStorage(
+	nodeID uuid.UUID,
+	database *databases.Database,
+	storage *storages.Storage,
+) *storages.Storage {
+	if database.IsBehindAgent() || !isMirroringSupported(database) {
+		return storage
+	}
+
+	members, err := s.getMirroredStorages(storage.ID)
+	if err != nil {
+		s.logger.Error("Failed to get mirrored storages", "storageId", storage.ID, "error", err)
+		return storage
+	}
+
+	if len(members) < 2 {
+		return storage
+	}
+
+	latencies := s.latencyProbe.MeasureLatencies(nodeID, members)
+	selected := selectFastestStorage(storage, members, latencies)
+
+	if selected.ID != storage.ID {
+		s.logger.Info(
+			"Selected closer mirrored storage for backup",
+			"databaseId", database.ID,
+			"configuredStorageId", storage.ID,
+			"selectedStorageId", selected.ID,
+		)
+	}
+
+	return selected
+}
+
+// OnBackupCompleted queues replicas of the backup on the other members of the group and
+// copies them in the background
+func (s *StorageMirrorService) OnBackupCompleted(backup *backups_core.Backup) {
+	if backup.Status != backups_core.BackupStatusCompleted {
+		return
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil || !isMirroringSupported(database) {
+		return
+	}
+
+	members, err := s.getMirroredStorages(backup.StorageID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get mirrored storages of backup",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return
+	}
+
+	replicas := make([]*BackupReplica, 0, len(members))
+	for _, member := range members {
+		if member.ID == backup.StorageID {
+			continue
+		}
+
+		replica := &BackupReplica{
+			ID:        uuid.New(),
+			BackupID:  backup.ID,
+			StorageID: member.ID,
+			Status:    ReplicaStatusPending,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		if err := s.storageMirrorRepository.SaveReplica(replica); err != nil {
+			s.logger.Error("Failed to save backup replica", "backupId", backup.ID, "error", err)
+			continue
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	if len(replicas) == 0 {
+		return
+	}
+
+	go func() {
+		for _, replica := range replicas {
+			s.replicate(backup, replica)
+		}
+	}()
+}
+
+// OnBeforeBackupRemove deletes replicas of the backup. It never blocks removal, like an
+// unreachable storage does not block removal of other backups
+func (s *StorageMirrorService) OnBeforeBackupRemove(backup *backups_core.Backup) error {
+	replicas, err := s.storageMirrorRepository.FindReplicasByBackupID(backup.ID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get replicas of removed backup",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return nil
+	}
+
+	for _, replica := range replicas {
+		storage, err := s.storageService.GetStorageByID(replica.StorageID)
+		if err != nil {
+			s.logger.Error(
+				"Failed to get storage of removed replica",
+				"replicaId", replica.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		storage.BindFileName(backup.ID, backup.FileName)
+		if err := storage.DeleteFile(s.fieldEncryptor, backup.ID); err != nil {
+			s.logger.Error(
+				"Failed to delete replica of removed backup",
+				"replicaId", replica.ID,
+				"storageId", storage.ID,
+				"error", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// RetryReplicas copies failed replicas again and replicas left pending by restarted nodes
+func (s *StorageMirrorService) RetryReplicas() error {
+	replicas, err := s.storageMirrorRepository.FindReplicasToRetry(
+		maxReplicaAttempts,
+		time.Now().UTC().Add(-replicationTimeout),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, replica := range replicas {
+		backup, err := s.backupRepository.FindByID(replica.BackupID)
+		if err != nil {
+			s.logger.Error("Failed to get backup of replica", "replicaId", replica.ID, "error", err)
+			continue
+		}
+
+		s.replicate(backup, replica)
+	}
+
+	return nil
+}
+
+func (s *StorageMirrorService) replicate(backup *backups_core.Backup, replica *BackupReplica) {
+	replica.Status = ReplicaStatusPending
+	replica.AttemptsCount++
+	if err := s.storageMirrorRepository.SaveReplica(replica); err != nil {
+		s.logger.Error("Failed to save backup replica", "replicaId", replica.ID, "error", err)
+		return
+	}
+
+	if err := s.copyBackupFile(backup, replica.StorageID); err != nil {
+		failMessage := err.Error()
+		replica.Status = ReplicaStatusFailed
+		replica.FailMessage = &failMessage
+
+		s.logger.Error(
+			"Failed to replicate backup",
+			"backupId", backup.ID,
+			"storageId", replica.StorageID,
+			"error", err,
+		)
+	} else {
+		completedAt := time.Now().UTC()
+		replica.Status = ReplicaStatusCompleted
+		replica.FailMessage = nil
+		replica.CompletedAt = &completedAt
+	}
+
+	if err := s.storageMirrorRepository.SaveReplica(replica); err != nil {
+		s.logger.Error("Failed to save backup replica", "replicaId", replica.ID, "error", err)
+	}
+}
+
+// copyBackupFile streams the file of the backup to the target storage as is, so replicas
+// restore with the encryption metadata of the backup
+func (s *StorageMirrorService) copyBackupFile(
+	backup *backups_core.Backup,
+	targetStorageID uuid.UUID,
+) error {
+	sourceStorage, err := s.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return fmt.Errorf("failed to get source storage: %w", err)
+	}
+
+	targetStorage, err := s.storageService.GetStorageByID(targetStorageID)
+	if err != nil {
+		return fmt.Errorf("failed to get target storage: %w", err)
+	}
+
+	sourceStorage.BindFileName(backup.ID, backup.FileName)
+	targetStorage.BindFileName(backup.ID, backup.FileName)
+
+	file, err := sourceStorage.GetFile(s.fieldEncryptor, backup.ID)
+	if err != nil {
+		return fmt.Errorf("failed to read backup file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+	defer cancel()
+
+	if err := targetStorage.SaveFile(ctx, s.fieldEncryptor, s.logger, backup.ID, file); err != nil {
+		return fmt.Errorf("failed to save backup file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *StorageMirrorService) getMirroredStorages(
+	storageID uuid.UUID,
+) ([]*storages.Storage, error) {
+	group, err := s.storageMirrorRepository.FindGroupByStorageID(storageID)
+	if err != nil || group == nil {
+		return nil, err
+	}
+
+	storagesByID, err := s.storageService.GetStoragesByIDs(group.StorageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*storages.Storage, 0, len(storagesByID))
+	for _, id := range group.StorageIDs {
+		if storage, ok := storagesByID[id]; ok {
+			members = append(members, storage)
+		}
+	}
+
+	return members, nil
+}
+
+func (s *StorageMirrorService) applyGroupChanges(
+	group *StorageMirrorGroup,
+	name string,
+	storageIDs []uuid.UUID,
+) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrMirrorGroupNameRequired
+	}
+
+	uniqueStorageIDs := make([]uuid.UUID, 0, len(storageIDs))
+	for _, storageID := range storageIDs {
+		if !slices.Contains(uniqueStorageIDs, storageID) {
+			uniqueStorageIDs = append(uniqueStorageIDs, storageID)
+		}
+	}
+
+	if len(uniqueStorageIDs) < 2 {
+		return ErrNotEnoughMirrorStorages
+	}
+
+	storagesByID, err := s.storageService.GetStoragesByIDs(uniqueStorageIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, storageID := range uniqueStorageIDs {
+		storage, ok := storagesByID[storageID]
+		if !ok || storage.WorkspaceID != group.WorkspaceID {
+			return ErrMirrorStorageNotInWorkspace
+		}
+
+		if storage.IsSystem {
+			return ErrSystemStorageCannotBeMirrored
+		}
+
+		existingGroup, err := s.storageMirrorRepository.FindGroupByStorageID(storageID)
+		if err != nil {
+			return err
+		}
+
+		if existingGroup != nil && existingGroup.ID != group.ID {
+			return ErrStorageAlreadyMirrored
+		}
+	}
+
+	group.Name = name
+	group.StorageIDs = uniqueStorageIDs
+
+	return s.storageMirrorRepository.SaveGroup(group)
+}
+
+func isMirroringSupported(database *databases.Database) bool {
+	return database.Type != databases.DatabaseTypeCockroachdb &&
+		database.Type != databases.DatabaseTypeElasticsearch
+}
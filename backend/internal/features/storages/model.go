@@ -2,18 +2,26 @@ package storages
 
 import (
 	"context"
+	"crypto/sha256"
+	"databasus-backend/internal/features/ownership"
 	azure_blob_storage "databasus-backend/internal/features/storages/models/azure_blob"
 	ftp_storage "databasus-backend/internal/features/storages/models/ftp"
+	gcs_storage "databasus-backend/internal/features/storages/models/gcs"
 	google_drive_storage "databasus-backend/internal/features/storages/models/google_drive"
 	local_storage "databasus-backend/internal/features/storages/models/local"
 	nas_storage "databasus-backend/internal/features/storages/models/nas"
+	plugin_storage "databasus-backend/internal/features/storages/models/plugin"
 	rclone_storage "databasus-backend/internal/features/storages/models/rclone"
 	s3_storage "databasus-backend/internal/features/storages/models/s3"
 	sftp_storage "databasus-backend/internal/features/storages/models/sftp"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	"encoding/hex"
 	"errors"
 	"io"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -26,6 +34,16 @@ type Storage struct {
 	LastSaveError *string     `json:"lastSaveError" gorm:"column:last_save_error;type:text"`
 	IsSystem      bool        `json:"isSystem"      gorm:"column:is_system;not null;default:false"`
 
+	ownership.Ownership
+
+	// VisibleWorkspaceIDs limits a system storage to these workspaces, empty means all
+	// workspaces. The workspace owning the storage always sees it
+	VisibleWorkspaceIDs []uuid.UUID `json:"visibleWorkspaceIds" gorm:"column:visible_workspace_ids;type:text;not null;default:'[]';serializer:json"`
+
+	// FileNameTemplate names backup files instead of their ID, see RenderFileName. Backup
+	// configs may override it
+	FileNameTemplate string `json:"fileNameTemplate" gorm:"column:file_name_template;type:text;not null;default:''"`
+
 	// specific storage
 	LocalStorage       *local_storage.LocalStorage              `json:"localStorage"       gorm:"foreignKey:StorageID"`
 	S3Storage          *s3_storage.S3Storage                    `json:"s3Storage"          gorm:"foreignKey:StorageID"`
@@ -35,6 +53,23 @@ type Storage struct {
 	FTPStorage         *ftp_storage.FTPStorage                  `json:"ftpStorage"         gorm:"foreignKey:StorageID"`
 	SFTPStorage        *sftp_storage.SFTPStorage                `json:"sftpStorage"        gorm:"foreignKey:StorageID"`
 	RcloneStorage      *rclone_storage.RcloneStorage            `json:"rcloneStorage"      gorm:"foreignKey:StorageID"`
+	PluginStorage      *plugin_storage.PluginStorage            `json:"pluginStorage"      gorm:"foreignKey:StorageID"`
+	GCSStorage         *gcs_storage.GCSStorage                  `json:"gcsStorage"         gorm:"foreignKey:StorageID"`
+
+	// IsContentAddressed stores files under their sha256 with an index object per file,
+	// see saveContentAddressedFile. Only S3 storages support it
+	IsContentAddressed bool `json:"isContentAddressed" gorm:"column:is_content_addressed;type:boolean;not null;default:false"`
+
+	// CredentialsExpireAt is when the keys of the storage have to be rotated, default
+	// notifiers of the workspace are reminded before it
+	CredentialsExpireAt *time.Time `json:"credentialsExpireAt" gorm:"column:credentials_expire_at;type:timestamptz"`
+
+	// ChangeReason is sent with edits and written to the audit log, it is required in
+	// workspaces with IsChangeReasonRequired. Not stored on the storage
+	ChangeReason string `json:"changeReason,omitempty" gorm:"-"`
+
+	fileNames     map[uuid.UUID]string
+	contentHashes map[uuid.UUID]string
 }
 
 func (s *Storage) SaveFile(
@@ -44,7 +79,22 @@ func (s *Storage) SaveFile(
 	fileID uuid.UUID,
 	file io.Reader,
 ) error {
-	err := s.getSpecificStorage().SaveFile(ctx, encryptor, logger, fileID, file)
+	var err error
+	if s.IsContentAddressedLayout() {
+		err = s.saveContentAddressedFile(ctx, encryptor, fileID, file)
+	} else {
+		counter := &contentHashingReader{reader: file, hash: sha256.New()}
+
+		if fileName, ok := s.getBoundFileName(fileID); ok {
+			err = s.S3Storage.SaveObject(ctx, encryptor, fileName, counter)
+		} else {
+			err = s.getSpecificStorage().SaveFile(ctx, encryptor, logger, fileID, counter)
+		}
+
+		if err == nil {
+			s.rememberContentHash(fileID, hex.EncodeToString(counter.hash.Sum(nil)))
+		}
+	}
 	if err != nil {
 		lastSaveError := err.Error()
 		s.LastSaveError = &lastSaveError
@@ -56,17 +106,117 @@ func (s *Storage) SaveFile(
 	return nil
 }
 
+// SaveNamedFile saves a file meant to be found without the database of Databasus, like
+// metadata manifests. S3 and GCS storages keep it under the name, other storages under the
+// file ID
+func (s *Storage) SaveNamedFile(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	fileID uuid.UUID,
+	name string,
+	file io.Reader,
+) error {
+	if s.Type == StorageTypeS3 && s.S3Storage != nil {
+		return s.S3Storage.SaveObject(ctx, encryptor, name, file)
+	}
+
+	if s.Type == StorageTypeGCS && s.GCSStorage != nil {
+		return s.GCSStorage.SaveObject(ctx, encryptor, name, file)
+	}
+
+	return s.getSpecificStorage().SaveFile(ctx, encryptor, logger, fileID, file)
+}
+
 func (s *Storage) GetFile(
 	encryptor encryption.FieldEncryptor,
 	fileID uuid.UUID,
 ) (io.ReadCloser, error) {
+	if s.IsContentAddressedLayout() {
+		return s.getContentAddressedFile(encryptor, fileID)
+	}
+
+	if fileName, ok := s.getBoundFileName(fileID); ok {
+		return s.S3Storage.GetObject(encryptor, fileName)
+	}
+
 	return s.getSpecificStorage().GetFile(encryptor, fileID)
 }
 
 func (s *Storage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid.UUID) error {
+	if s.IsContentAddressedLayout() {
+		return s.deleteContentAddressedFile(encryptor, fileID)
+	}
+
+	if fileName, ok := s.getBoundFileName(fileID); ok {
+		return s.S3Storage.DeleteObject(encryptor, fileName)
+	}
+
 	return s.getSpecificStorage().DeleteFile(encryptor, fileID)
 }
 
+// StoredFile is a file of the storage not necessarily written by Databasus, Name is
+// relative to the prefix of the storage
+type StoredFile struct {
+	Name       string    `json:"name"`
+	SizeBytes  int64     `json:"sizeBytes"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// ListFiles lists at most limit files under the directory, only S3 storages support it
+func (s *Storage) ListFiles(
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]StoredFile, error) {
+	if s.Type != StorageTypeS3 || s.S3Storage == nil {
+		return nil, ErrFileListingNotSupported
+	}
+
+	objects, err := s.S3Storage.ListObjects(encryptor, directory, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]StoredFile, 0, len(objects))
+	for _, object := range objects {
+		files = append(files, StoredFile{
+			Name:       object.Name,
+			SizeBytes:  object.SizeBytes,
+			ModifiedAt: object.ModifiedAt,
+		})
+	}
+
+	return files, nil
+}
+
+// BrowseDirectory lists at most limit files and folders right under the directory
+func (s *Storage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	browser, ok := s.getSpecificStorage().(DirectoryBrowser)
+	if !ok {
+		return nil, ErrFileBrowsingNotSupported
+	}
+
+	return browser.BrowseDirectory(ctx, encryptor, directory, limit)
+}
+
+// GetFileByName reads a file listed by ListFiles
+func (s *Storage) GetFileByName(
+	encryptor encryption.FieldEncryptor,
+	name string,
+) (io.ReadCloser, error) {
+	if s.Type != StorageTypeS3 || s.S3Storage == nil {
+		return nil, ErrFileListingNotSupported
+	}
+
+	return s.S3Storage.GetObject(encryptor, name)
+}
+
 func (s *Storage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.Type == "" {
 		return errors.New("storage type is required")
@@ -76,6 +226,24 @@ func (s *Storage) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("storage name is required")
 	}
 
+	if s.FileNameTemplate != "" && s.Type != StorageTypeS3 {
+		return ErrFileNamingNotSupported
+	}
+
+	if err := ValidateFileNameTemplate(s.FileNameTemplate); err != nil {
+		return err
+	}
+
+	if s.IsContentAddressed {
+		if s.Type != StorageTypeS3 {
+			return ErrContentAddressingNotSupported
+		}
+
+		if s.FileNameTemplate != "" {
+			return errors.New("content-addressed storages do not support file name templates")
+		}
+	}
+
 	return s.getSpecificStorage().Validate(encryptor)
 }
 
@@ -83,19 +251,21 @@ func (s *Storage) TestConnection(encryptor encryption.FieldEncryptor) error {
 	return s.getSpecificStorage().TestConnection(encryptor)
 }
 
-func (s *Storage) HideSensitiveData() {
-	s.getSpecificStorage().HideSensitiveData()
+// IsVisibleToWorkspace reports whether databases of the workspace may use the storage
+func (s *Storage) IsVisibleToWorkspace(workspaceID uuid.UUID) bool {
+	if s.WorkspaceID == workspaceID {
+		return true
+	}
+
+	if !s.IsSystem {
+		return false
+	}
+
+	return len(s.VisibleWorkspaceIDs) == 0 || slices.Contains(s.VisibleWorkspaceIDs, workspaceID)
 }
 
-func (s *Storage) HideAllData() {
-	s.LocalStorage = nil
-	s.S3Storage = nil
-	s.GoogleDriveStorage = nil
-	s.NASStorage = nil
-	s.AzureBlobStorage = nil
-	s.FTPStorage = nil
-	s.SFTPStorage = nil
-	s.RcloneStorage = nil
+func (s *Storage) HideSensitiveData() {
+	s.getSpecificStorage().HideSensitiveData()
 }
 
 func (s *Storage) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
@@ -106,6 +276,11 @@ func (s *Storage) Update(incoming *Storage) {
 	s.Name = incoming.Name
 	s.Type = incoming.Type
 	s.IsSystem = incoming.IsSystem
+	s.VisibleWorkspaceIDs = incoming.VisibleWorkspaceIDs
+	s.FileNameTemplate = incoming.FileNameTemplate
+	s.IsContentAddressed = incoming.IsContentAddressed
+	s.CredentialsExpireAt = incoming.CredentialsExpireAt
+	s.Ownership = incoming.Ownership
 
 	switch s.Type {
 	case StorageTypeLocal:
@@ -140,10 +315,18 @@ func (s *Storage) Update(incoming *Storage) {
 		if s.RcloneStorage != nil && incoming.RcloneStorage != nil {
 			s.RcloneStorage.Update(incoming.RcloneStorage)
 		}
+	case StorageTypePlugin:
+		if s.PluginStorage != nil && incoming.PluginStorage != nil {
+			s.PluginStorage.Update(incoming.PluginStorage)
+		}
+	case StorageTypeGCS:
+		if s.GCSStorage != nil && incoming.GCSStorage != nil {
+			s.GCSStorage.Update(incoming.GCSStorage)
+		}
 	}
 }
 
-func (s *Storage) getSpecificStorage() StorageFileSaver {
+func (s *Storage) getSpecificStorage() StorageBackend {
 	switch s.Type {
 	case StorageTypeLocal:
 		return s.LocalStorage
@@ -161,6 +344,10 @@ func (s *Storage) getSpecificStorage() StorageFileSaver {
 		return s.SFTPStorage
 	case StorageTypeRclone:
 		return s.RcloneStorage
+	case StorageTypePlugin:
+		return s.PluginStorage
+	case StorageTypeGCS:
+		return s.GCSStorage
 	default:
 		panic("invalid storage type: " + string(s.Type))
 	}
@@ -92,7 +92,7 @@ func Test_Storage_BasicOperations(t *testing.T) {
 	// Run tests
 	testCases := []struct {
 		name    string
-		storage StorageFileSaver
+		storage StorageBackend
 	}{
 		{
 			name:    "LocalStorage",
@@ -191,7 +191,7 @@ acl = private`, s3Container.accessKey, s3Container.secretKey, s3Container.endpoi
 		env.TestGoogleDriveTokenJSON != "" {
 		testCases = append(testCases, struct {
 			name    string
-			storage StorageFileSaver
+			storage StorageBackend
 		}{
 			name: "GoogleDriveStorage",
 			storage: &google_drive_storage.GoogleDriveStorage{
@@ -277,6 +277,69 @@ acl = private`, s3Container.accessKey, s3Container.secretKey, s3Container.endpoi
 	}
 }
 
+func Test_SaveFile_WhenStorageIsContentAddressed_SameContentStoredOnce(t *testing.T) {
+	validateEnvVariables(t)
+
+	s3Container, err := setupS3Container(context.Background())
+	require.NoError(t, err, "Failed to setup S3 container")
+
+	encryptor := encryption.GetFieldEncryptor()
+	storage := &Storage{
+		ID:                 uuid.New(),
+		Type:               StorageTypeS3,
+		Name:               "Content-addressed S3",
+		IsContentAddressed: true,
+		S3Storage: &s3_storage.S3Storage{
+			StorageID:   uuid.New(),
+			S3Bucket:    s3Container.bucketName,
+			S3Region:    s3Container.region,
+			S3AccessKey: s3Container.accessKey,
+			S3SecretKey: s3Container.secretKey,
+			S3Endpoint:  "http://" + s3Container.endpoint,
+			S3Prefix:    "content-addressed-" + uuid.New().String(),
+		},
+	}
+
+	fileData := []byte("content-addressed backup " + uuid.New().String())
+	firstFileID := uuid.New()
+	secondFileID := uuid.New()
+
+	for _, fileID := range []uuid.UUID{firstFileID, secondFileID} {
+		err := storage.SaveFile(
+			context.Background(),
+			encryptor,
+			logger.GetLogger(),
+			fileID,
+			bytes.NewReader(fileData),
+		)
+		require.NoError(t, err)
+	}
+
+	contentHash := storage.GetSavedContentHash(firstFileID)
+	require.NotNil(t, contentHash)
+	assert.Equal(t, *contentHash, *storage.GetSavedContentHash(secondFileID))
+
+	contentObjects, err := storage.ListFiles(encryptor, contentObjectsDirectory, 10)
+	require.NoError(t, err)
+	assert.Len(t, contentObjects, 1)
+
+	// the content stays while another file references it
+	require.NoError(t, storage.DeleteFile(encryptor, firstFileID))
+
+	file, err := storage.GetFile(encryptor, secondFileID)
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	_ = file.Close()
+	require.NoError(t, err)
+	assert.Equal(t, fileData, content)
+
+	require.NoError(t, storage.DeleteFile(encryptor, secondFileID))
+
+	contentObjects, err = storage.ListFiles(encryptor, contentObjectsDirectory, 10)
+	require.NoError(t, err)
+	assert.Empty(t, contentObjects)
+}
+
 func setupTestFile() (string, error) {
 	tempDir := os.TempDir()
 	testFilePath := filepath.Join(tempDir, "test_file.txt")
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	proxy_utils "databasus-backend/internal/util/proxy"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -18,6 +20,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	"github.com/google/uuid"
 )
 
@@ -27,6 +30,9 @@ const (
 	azureIdleConnTimeout     = 90 * time.Second
 	azureTLSHandshakeTimeout = 30 * time.Second
 	azureDeleteTimeout       = 30 * time.Second
+	// azureDirectoryDeleteTimeout bounds listing and deleting all blobs of a directory
+	azureDirectoryDeleteTimeout = 10 * time.Minute
+	azureListTimeout            = 2 * time.Minute
 
 	// Chunk size for block blob uploads - 16MB provides good balance between
 	// memory usage and upload efficiency. This creates backpressure to pg_dump
@@ -58,6 +64,9 @@ type AzureBlobStorage struct {
 	ContainerName    string     `json:"containerName"    gorm:"not null;type:text;column:container_name"`
 	Endpoint         string     `json:"endpoint"         gorm:"type:text;column:endpoint"`
 	Prefix           string     `json:"prefix"           gorm:"type:text;column:prefix"`
+
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this storage, see proxy_utils.GetProxyFunc
+	ProxyURL string `json:"proxyUrl" gorm:"not null;default:'';type:text;column:proxy_url"`
 }
 
 func (s *AzureBlobStorage) TableName() string {
@@ -207,6 +216,116 @@ func (s *AzureBlobStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileI
 	return nil
 }
 
+// DeleteDirectory removes all blobs under the directory of the prefix, for backups
+// written by database engines as many blobs
+func (s *AzureBlobStorage) DeleteDirectory(
+	encryptor encryption.FieldEncryptor,
+	directory string,
+) error {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), azureDirectoryDeleteTimeout)
+	defer cancel()
+
+	prefix := s.buildBlobName(strings.Trim(directory, "/") + "/")
+	pager := client.NewListBlobsFlatPager(s.ContainerName, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list blobs in Azure: %w", err)
+		}
+
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name == nil {
+				continue
+			}
+
+			if _, err := client.DeleteBlob(ctx, s.ContainerName, *blob.Name, nil); err != nil {
+				var respErr *azcore.ResponseError
+				if errors.As(err, &respErr) && respErr.StatusCode == 404 {
+					continue
+				}
+				return fmt.Errorf("failed to delete blob %s from Azure: %w", *blob.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BrowseDirectory lists blobs and virtual folders right under the directory of the prefix
+func (s *AzureBlobStorage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, azureListTimeout)
+	defer cancel()
+
+	prefix := s.buildBlobName("")
+	if directory != "" {
+		prefix = s.buildBlobName(directory + "/")
+	}
+
+	pager := client.ServiceClient().
+		NewContainerClient(s.ContainerName).
+		NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{Prefix: &prefix})
+
+	entries := make([]files_utils.DirectoryEntry, 0)
+	for pager.More() && len(entries) < limit {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs in Azure: %w", err)
+		}
+
+		for _, blobPrefix := range page.Segment.BlobPrefixes {
+			if blobPrefix.Name == nil || len(entries) >= limit {
+				continue
+			}
+
+			entries = append(entries, files_utils.DirectoryEntry{
+				Name:        strings.TrimSuffix(strings.TrimPrefix(*blobPrefix.Name, prefix), "/"),
+				IsDirectory: true,
+			})
+		}
+
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name == nil || len(entries) >= limit {
+				continue
+			}
+
+			entry := files_utils.DirectoryEntry{Name: strings.TrimPrefix(*blob.Name, prefix)}
+			if blob.Properties != nil {
+				if blob.Properties.ContentLength != nil {
+					entry.SizeBytes = *blob.Properties.ContentLength
+				}
+				entry.ModifiedAt = blob.Properties.LastModified
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// GetUploadPartSizeBytes is the size of blocks staged for block blobs
+func (s *AzureBlobStorage) GetUploadPartSizeBytes() int64 {
+	return azureChunkSize
+}
+
 func (s *AzureBlobStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.ContainerName == "" {
 		return errors.New("container name is required")
@@ -230,7 +349,7 @@ func (s *AzureBlobStorage) Validate(encryptor encryption.FieldEncryptor) error {
 		return fmt.Errorf("invalid auth method: %s", s.AuthMethod)
 	}
 
-	return nil
+	return proxy_utils.ValidateProxyURL(s.ProxyURL)
 }
 
 func (s *AzureBlobStorage) TestConnection(encryptor encryption.FieldEncryptor) error {
@@ -287,6 +406,7 @@ func (s *AzureBlobStorage) TestConnection(encryptor encryption.FieldEncryptor) e
 func (s *AzureBlobStorage) HideSensitiveData() {
 	s.ConnectionString = ""
 	s.AccountKey = ""
+	s.ProxyURL = proxy_utils.RedactProxyURL(s.ProxyURL)
 }
 
 func (s *AzureBlobStorage) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
@@ -313,6 +433,7 @@ func (s *AzureBlobStorage) Update(incoming *AzureBlobStorage) {
 	s.AuthMethod = incoming.AuthMethod
 	s.ContainerName = incoming.ContainerName
 	s.Endpoint = incoming.Endpoint
+	s.ProxyURL = proxy_utils.MergeRedactedProxyURL(s.ProxyURL, incoming.ProxyURL)
 
 	if incoming.ConnectionString != "" {
 		s.ConnectionString = incoming.ConnectionString
@@ -387,6 +508,7 @@ func (s *AzureBlobStorage) getClient(encryptor encryption.FieldEncryptor) (*azbl
 
 func (s *AzureBlobStorage) buildClientOptions() *azblob.ClientOptions {
 	transport := &http.Transport{
+		Proxy: proxy_utils.GetProxyFunc(s.ProxyURL),
 		DialContext: (&net.Dialer{
 			Timeout: azureConnectTimeout,
 		}).DialContext,
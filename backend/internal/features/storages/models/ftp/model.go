@@ -4,10 +4,13 @@ import (
 	"context"
 	"crypto/tls"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	network_utils "databasus-backend/internal/util/network"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"strings"
 	"time"
 
@@ -19,6 +22,7 @@ const (
 	ftpConnectTimeout     = 30 * time.Second
 	ftpTestConnectTimeout = 10 * time.Second
 	ftpDeleteTimeout      = 30 * time.Second
+	ftpListTimeout        = 2 * time.Minute
 	ftpChunkSize          = 16 * 1024 * 1024
 )
 
@@ -31,6 +35,12 @@ type FTPStorage struct {
 	Path          string    `json:"path"          gorm:"type:text;column:path"`
 	UseSSL        bool      `json:"useSsl"        gorm:"not null;default:false;column:use_ssl"`
 	SkipTLSVerify bool      `json:"skipTlsVerify" gorm:"not null;default:false;column:skip_tls_verify"`
+
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IPPreference network_utils.IPPreference `json:"ipPreference" gorm:"not null;type:text;default:'AUTO';column:ip_preference"`
+
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DNSOverride *network_utils.DNSOverride `json:"dnsOverride,omitempty" gorm:"column:dns_override;type:text;serializer:json"`
 }
 
 func (f *FTPStorage) TableName() string {
@@ -161,6 +171,52 @@ func (f *FTPStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid
 	return nil
 }
 
+// BrowseDirectory lists files and folders right under the directory of the path
+func (f *FTPStorage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, ftpListTimeout)
+	defer cancel()
+
+	conn, err := f.connectWithContext(ctx, encryptor, ftpConnectTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to FTP: %w", err)
+	}
+	defer func() {
+		_ = conn.Quit()
+	}()
+
+	ftpEntries, err := conn.List(strings.TrimSuffix(f.getFilePath(directory), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory on FTP: %w", err)
+	}
+
+	entries := make([]files_utils.DirectoryEntry, 0, min(len(ftpEntries), limit))
+	for _, ftpEntry := range ftpEntries {
+		if len(entries) >= limit {
+			break
+		}
+
+		if ftpEntry.Name == "." || ftpEntry.Name == ".." ||
+			ftpEntry.Type == ftp.EntryTypeLink {
+			continue
+		}
+
+		modifiedAt := ftpEntry.Time
+		entries = append(entries, files_utils.DirectoryEntry{
+			Name:        ftpEntry.Name,
+			IsDirectory: ftpEntry.Type == ftp.EntryTypeFolder,
+			SizeBytes:   int64(ftpEntry.Size),
+			ModifiedAt:  &modifiedAt,
+		})
+	}
+
+	return entries, nil
+}
+
 func (f *FTPStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if f.Host == "" {
 		return errors.New("FTP host is required")
@@ -175,7 +231,11 @@ func (f *FTPStorage) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("FTP port must be between 1 and 65535")
 	}
 
-	return nil
+	if err := f.IPPreference.Validate(); err != nil {
+		return err
+	}
+
+	return f.DNSOverride.Validate()
 }
 
 func (f *FTPStorage) TestConnection(encryptor encryption.FieldEncryptor) error {
@@ -222,6 +282,8 @@ func (f *FTPStorage) Update(incoming *FTPStorage) {
 	f.UseSSL = incoming.UseSSL
 	f.SkipTLSVerify = incoming.SkipTLSVerify
 	f.Path = incoming.Path
+	f.IPPreference = incoming.IPPreference
+	f.DNSOverride = incoming.DNSOverride
 
 	if incoming.Password != "" {
 		f.Password = incoming.Password
@@ -245,23 +307,30 @@ func (f *FTPStorage) connectWithContext(
 		return nil, fmt.Errorf("failed to decrypt FTP password: %w", err)
 	}
 
-	address := fmt.Sprintf("%s:%d", f.Host, f.Port)
-
 	dialCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// Addresses are resolved here rather than with a dial function, as the FTP client skips
+	// TLS of data connections opened by custom dial functions
+	dialer := f.DNSOverride.NewDialer(timeout)
+
+	addresses, err := network_utils.ResolveAddresses(
+		dialCtx,
+		dialer.Resolver,
+		f.DNSOverride.RewriteHost(f.Host),
+		f.Port,
+		f.IPPreference,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve FTP host: %w", err)
+	}
+
 	var conn *ftp.ServerConn
-	if f.UseSSL {
-		tlsConfig := &tls.Config{
-			ServerName:         f.Host,
-			InsecureSkipVerify: f.SkipTLSVerify,
+	for _, address := range addresses {
+		conn, err = f.dial(dialCtx, dialer, address)
+		if err == nil || dialCtx.Err() != nil {
+			break
 		}
-		conn, err = ftp.Dial(address,
-			ftp.DialWithContext(dialCtx),
-			ftp.DialWithExplicitTLS(tlsConfig),
-		)
-	} else {
-		conn, err = ftp.Dial(address, ftp.DialWithContext(dialCtx))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial FTP server: %w", err)
@@ -276,6 +345,27 @@ func (f *FTPStorage) connectWithContext(
 	return conn, nil
 }
 
+func (f *FTPStorage) dial(
+	ctx context.Context,
+	dialer *net.Dialer,
+	address string,
+) (*ftp.ServerConn, error) {
+	if !f.UseSSL {
+		return ftp.Dial(address, ftp.DialWithContext(ctx), ftp.DialWithDialer(*dialer))
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         network_utils.NormalizeHost(f.Host),
+		InsecureSkipVerify: f.SkipTLSVerify,
+	}
+
+	return ftp.Dial(address,
+		ftp.DialWithContext(ctx),
+		ftp.DialWithDialer(*dialer),
+		ftp.DialWithExplicitTLS(tlsConfig),
+	)
+}
+
 func (f *FTPStorage) ensureDirectory(conn *ftp.ServerConn, path string) error {
 	path = strings.TrimPrefix(path, "/")
 	path = strings.TrimSuffix(path, "/")
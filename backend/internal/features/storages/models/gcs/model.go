@@ -0,0 +1,424 @@
+package gcs_storage
+
+import (
+	"bytes"
+	"context"
+	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	gcs "google.golang.org/api/storage/v1"
+)
+
+const (
+	gcsConnectTimeout      = 30 * time.Second
+	gcsResponseTimeout     = 30 * time.Second
+	gcsIdleConnTimeout     = 90 * time.Second
+	gcsTLSHandshakeTimeout = 30 * time.Second
+	gcsDeleteTimeout       = 30 * time.Second
+	gcsTestTimeout         = 30 * time.Second
+	gcsListTimeout         = 2 * time.Minute
+
+	// Chunk size for resumable uploads - 16MB provides good balance between
+	// memory usage and upload efficiency. Each chunk is confirmed by GCS before
+	// the next one is read, which creates backpressure to pg_dump
+	gcsChunkSize = 16 * 1024 * 1024
+)
+
+type AuthMethod string
+
+const (
+	// AuthMethodServiceAccountKey uses the JSON key of a service account
+	AuthMethodServiceAccountKey AuthMethod = "SERVICE_ACCOUNT_KEY"
+	// AuthMethodWorkloadIdentity uses ambient credentials of the node, like GKE workload
+	// identity or the service account of a VM. No secret is stored
+	AuthMethodWorkloadIdentity AuthMethod = "WORKLOAD_IDENTITY"
+)
+
+var storageClasses = []string{"STANDARD", "NEARLINE", "COLDLINE", "ARCHIVE"}
+
+type GCSStorage struct {
+	StorageID         uuid.UUID  `json:"storageId"         gorm:"primaryKey;type:uuid;column:storage_id"`
+	AuthMethod        AuthMethod `json:"authMethod"        gorm:"not null;type:text;column:auth_method"`
+	ServiceAccountKey string     `json:"serviceAccountKey" gorm:"type:text;column:service_account_key"`
+	Bucket            string     `json:"bucket"            gorm:"not null;type:text;column:bucket"`
+	Prefix            string     `json:"prefix"            gorm:"type:text;column:prefix"`
+
+	// StorageClass of uploaded objects, empty uses the default class of the bucket
+	StorageClass string `json:"storageClass" gorm:"type:text;column:storage_class"`
+}
+
+func (s *GCSStorage) TableName() string {
+	return "gcs_storages"
+}
+
+func (s *GCSStorage) SaveFile(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	fileID uuid.UUID,
+	file io.Reader,
+) error {
+	return s.SaveObject(ctx, encryptor, fileID.String(), file)
+}
+
+// SaveObject uploads the file under the name relative to the prefix
+func (s *GCSStorage) SaveObject(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	name string,
+	file io.Reader,
+) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("upload cancelled before start: %w", ctx.Err())
+	default:
+	}
+
+	service, err := s.getService(ctx, encryptor)
+	if err != nil {
+		return err
+	}
+
+	object := &gcs.Object{
+		Name:         s.buildObjectName(name),
+		StorageClass: s.StorageClass,
+	}
+
+	_, err = service.Objects.Insert(s.Bucket, object).
+		Media(file, googleapi.ChunkSize(gcsChunkSize)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("upload cancelled: %w", ctx.Err())
+		default:
+		}
+
+		return fmt.Errorf("failed to upload object to GCS: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GCSStorage) GetFile(
+	encryptor encryption.FieldEncryptor,
+	fileID uuid.UUID,
+) (io.ReadCloser, error) {
+	ctx := context.Background()
+
+	service, err := s.getService(ctx, encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := service.Objects.Get(s.Bucket, s.buildObjectName(fileID.String())).
+		Context(ctx).
+		Download()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object from GCS: %w", err)
+	}
+
+	return response.Body, nil
+}
+
+func (s *GCSStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gcsDeleteTimeout)
+	defer cancel()
+
+	service, err := s.getService(ctx, encryptor)
+	if err != nil {
+		return err
+	}
+
+	err = service.Objects.Delete(s.Bucket, s.buildObjectName(fileID.String())).
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete object from GCS: %w", err)
+	}
+
+	return nil
+}
+
+// BrowseDirectory lists objects and prefixes right under the directory of the prefix
+func (s *GCSStorage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, gcsListTimeout)
+	defer cancel()
+
+	service, err := s.getService(ctx, encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := s.buildObjectName("")
+	if directory != "" {
+		prefix = s.buildObjectName(directory + "/")
+	}
+
+	entries := make([]files_utils.DirectoryEntry, 0)
+	pageToken := ""
+
+	for len(entries) < limit {
+		objects, err := service.Objects.List(s.Bucket).
+			Prefix(prefix).
+			Delimiter("/").
+			MaxResults(int64(limit)).
+			PageToken(pageToken).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects in GCS: %w", err)
+		}
+
+		for _, objectPrefix := range objects.Prefixes {
+			if len(entries) >= limit {
+				break
+			}
+
+			entries = append(entries, files_utils.DirectoryEntry{
+				Name:        strings.TrimSuffix(strings.TrimPrefix(objectPrefix, prefix), "/"),
+				IsDirectory: true,
+			})
+		}
+
+		for _, object := range objects.Items {
+			name := strings.TrimPrefix(object.Name, prefix)
+			if name == "" || len(entries) >= limit {
+				continue
+			}
+
+			entry := files_utils.DirectoryEntry{Name: name, SizeBytes: int64(object.Size)}
+			if updated, err := time.Parse(time.RFC3339, object.Updated); err == nil {
+				entry.ModifiedAt = &updated
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if objects.NextPageToken == "" {
+			break
+		}
+		pageToken = objects.NextPageToken
+	}
+
+	return entries, nil
+}
+
+// GetUploadPartSizeBytes is the size of chunks of resumable uploads
+func (s *GCSStorage) GetUploadPartSizeBytes() int64 {
+	return gcsChunkSize
+}
+
+func (s *GCSStorage) Validate(encryptor encryption.FieldEncryptor) error {
+	if s.Bucket == "" {
+		return errors.New("bucket is required")
+	}
+
+	if s.StorageClass != "" && !slices.Contains(storageClasses, s.StorageClass) {
+		return fmt.Errorf(
+			"invalid storage class: %s, expected one of %s",
+			s.StorageClass,
+			strings.Join(storageClasses, ", "),
+		)
+	}
+
+	switch s.AuthMethod {
+	case AuthMethodServiceAccountKey:
+		if s.ServiceAccountKey == "" {
+			return errors.New(
+				"service account key is required when using SERVICE_ACCOUNT_KEY auth method",
+			)
+		}
+	case AuthMethodWorkloadIdentity:
+	default:
+		return fmt.Errorf("invalid auth method: %s", s.AuthMethod)
+	}
+
+	return nil
+}
+
+func (s *GCSStorage) TestConnection(encryptor encryption.FieldEncryptor) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gcsTestTimeout)
+	defer cancel()
+
+	service, err := s.getService(ctx, encryptor)
+	if err != nil {
+		return err
+	}
+
+	if _, err := service.Buckets.Get(s.Bucket).Context(ctx).Do(); err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("bucket '%s' does not exist", s.Bucket)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return errors.New("failed to connect to Google Cloud Storage. Please check params")
+		}
+
+		return fmt.Errorf("failed to connect to Google Cloud Storage: %w", err)
+	}
+
+	testObjectName := uuid.New().String() + "-test"
+
+	if err := s.SaveObject(
+		ctx,
+		encryptor,
+		testObjectName,
+		bytes.NewReader([]byte("test connection")),
+	); err != nil {
+		return err
+	}
+
+	if err := service.Objects.Delete(s.Bucket, s.buildObjectName(testObjectName)).
+		Context(ctx).
+		Do(); err != nil {
+		return fmt.Errorf("failed to delete test object from GCS: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GCSStorage) HideSensitiveData() {
+	s.ServiceAccountKey = ""
+}
+
+func (s *GCSStorage) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	if s.ServiceAccountKey == "" {
+		return nil
+	}
+
+	var err error
+
+	s.ServiceAccountKey, err = encryptor.Encrypt(s.StorageID, s.ServiceAccountKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt GCS service account key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GCSStorage) Update(incoming *GCSStorage) {
+	s.AuthMethod = incoming.AuthMethod
+	s.Bucket = incoming.Bucket
+	s.StorageClass = incoming.StorageClass
+
+	if incoming.AuthMethod == AuthMethodWorkloadIdentity {
+		s.ServiceAccountKey = ""
+	} else if incoming.ServiceAccountKey != "" {
+		s.ServiceAccountKey = incoming.ServiceAccountKey
+	}
+
+	// we do not allow to change the prefix after creation,
+	// otherwise we will have to transfer all the data to the new prefix
+}
+
+func (s *GCSStorage) buildObjectName(fileName string) string {
+	if s.Prefix == "" {
+		return fileName
+	}
+
+	prefix := strings.TrimPrefix(s.Prefix, "/")
+
+	if !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+
+	return prefix + fileName
+}
+
+func (s *GCSStorage) getService(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+) (*gcs.Service, error) {
+	tokenSource, err := s.getTokenSource(ctx, encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := gcs.NewService(ctx, option.WithHTTPClient(buildHTTPClient(tokenSource)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *GCSStorage) getTokenSource(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+) (oauth2.TokenSource, error) {
+	switch s.AuthMethod {
+	case AuthMethodServiceAccountKey:
+		serviceAccountKey, err := encryptor.Decrypt(s.StorageID, s.ServiceAccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt GCS service account key: %w", err)
+		}
+
+		// JWTConfigFromJSON accepts only service account keys, so a pasted external
+		// credential configuration cannot make Databasus run its commands
+		config, err := google.JWTConfigFromJSON(
+			[]byte(serviceAccountKey),
+			gcs.DevstorageReadWriteScope,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GCS service account key: %w", err)
+		}
+
+		return config.TokenSource(ctx), nil
+	case AuthMethodWorkloadIdentity:
+		tokenSource, err := google.DefaultTokenSource(ctx, gcs.DevstorageReadWriteScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find ambient Google Cloud credentials: %w", err)
+		}
+
+		return tokenSource, nil
+	default:
+		return nil, fmt.Errorf("unsupported auth method: %s", s.AuthMethod)
+	}
+}
+
+func buildHTTPClient(tokenSource oauth2.TokenSource) *http.Client {
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: gcsConnectTimeout,
+		}).DialContext,
+		TLSHandshakeTimeout:   gcsTLSHandshakeTimeout,
+		ResponseHeaderTimeout: gcsResponseTimeout,
+		IdleConnTimeout:       gcsIdleConnTimeout,
+	}
+
+	return &http.Client{
+		Transport: &oauth2.Transport{
+			Source: tokenSource,
+			Base:   transport,
+		},
+	}
+}
+
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
@@ -199,6 +199,11 @@ func (s *GoogleDriveStorage) DeleteFile(
 	})
 }
 
+// GetUploadPartSizeBytes is the size of chunks of resumable uploads
+func (s *GoogleDriveStorage) GetUploadPartSizeBytes() int64 {
+	return gdChunkSize
+}
+
 func (s *GoogleDriveStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	switch {
 	case s.ClientID == "":
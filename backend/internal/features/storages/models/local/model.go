@@ -160,6 +160,52 @@ func (l *LocalStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uu
 	return nil
 }
 
+// BrowseDirectory lists files and folders right under the directory of the data folder.
+// The data folder is shared by all local storages
+func (l *LocalStorage) BrowseDirectory(
+	_ context.Context,
+	_ encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	root, err := os.OpenRoot(config.GetEnv().DataFolder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data folder: %w", err)
+	}
+	defer func() {
+		_ = root.Close()
+	}()
+
+	if directory == "" {
+		directory = "."
+	}
+
+	dir, err := root.Open(directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer func() {
+		_ = dir.Close()
+	}()
+
+	dirEntries, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+
+	fileInfos := make([]os.FileInfo, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		fileInfo, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		fileInfos = append(fileInfos, fileInfo)
+	}
+
+	return files_utils.ToDirectoryEntries(fileInfos, limit), nil
+}
+
 func (l *LocalStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	return nil
 }
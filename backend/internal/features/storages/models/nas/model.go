@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/tls"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	network_utils "databasus-backend/internal/util/network"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +21,7 @@ import (
 
 const (
 	nasDeleteTimeout = 30 * time.Second
+	nasListTimeout   = 2 * time.Minute
 
 	// Chunk size for NAS uploads - 16MB provides good balance between
 	// memory usage and upload efficiency. This creates backpressure to pg_dump
@@ -36,6 +39,12 @@ type NASStorage struct {
 	UseSSL    bool      `json:"useSsl"    gorm:"not null;default:false;column:use_ssl"`
 	Domain    string    `json:"domain"    gorm:"type:text;column:domain"`
 	Path      string    `json:"path"      gorm:"type:text;column:path"`
+
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IPPreference network_utils.IPPreference `json:"ipPreference" gorm:"not null;type:text;default:'AUTO';column:ip_preference"`
+
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DNSOverride *network_utils.DNSOverride `json:"dnsOverride,omitempty" gorm:"column:dns_override;type:text;serializer:json"`
 }
 
 func (n *NASStorage) TableName() string {
@@ -229,6 +238,40 @@ func (n *NASStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid
 	return nil
 }
 
+// BrowseDirectory lists files and folders right under the directory of the path
+func (n *NASStorage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, nasListTimeout)
+	defer cancel()
+
+	session, err := n.createSessionWithContext(ctx, encryptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NAS session: %w", err)
+	}
+	defer func() {
+		_ = session.Logoff()
+	}()
+
+	fs, err := session.Mount(n.Share)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mount share '%s': %w", n.Share, err)
+	}
+	defer func() {
+		_ = fs.Umount()
+	}()
+
+	fileInfos, err := fs.WithContext(ctx).ReadDir(strings.TrimSuffix(n.getFilePath(directory), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory on NAS: %w", err)
+	}
+
+	return files_utils.ToDirectoryEntries(fileInfos, limit), nil
+}
+
 func (n *NASStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if n.Host == "" {
 		return errors.New("NAS host is required")
@@ -246,7 +289,11 @@ func (n *NASStorage) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("NAS port must be between 1 and 65535")
 	}
 
-	return nil
+	if err := n.IPPreference.Validate(); err != nil {
+		return err
+	}
+
+	return n.DNSOverride.Validate()
 }
 
 func (n *NASStorage) TestConnection(encryptor encryption.FieldEncryptor) error {
@@ -301,6 +348,8 @@ func (n *NASStorage) Update(incoming *NASStorage) {
 	n.UseSSL = incoming.UseSSL
 	n.Domain = incoming.Domain
 	n.Path = incoming.Path
+	n.IPPreference = incoming.IPPreference
+	n.DNSOverride = incoming.DNSOverride
 
 	if incoming.Password != "" {
 		n.Password = incoming.Password
@@ -344,28 +393,35 @@ func (n *NASStorage) createSessionWithContext(
 }
 
 func (n *NASStorage) createConnectionWithContext(ctx context.Context) (net.Conn, error) {
-	address := net.JoinHostPort(n.Host, fmt.Sprintf("%d", n.Port))
+	address := network_utils.JoinHostPort(n.Host, n.Port)
 
-	dialer := &net.Dialer{
-		Timeout: 30 * time.Second,
+	dialer := n.DNSOverride.NewDialer(30 * time.Second)
+
+	conn, err := network_utils.DialContext(
+		ctx,
+		dialer,
+		n.IPPreference,
+		n.DNSOverride.RewriteAddress(address),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection to %s: %w", address, err)
 	}
 
 	if n.UseSSL {
 		tlsConfig := &tls.Config{
-			ServerName:         n.Host,
+			ServerName:         network_utils.NormalizeHost(n.Host),
 			InsecureSkipVerify: false,
 		}
-		conn, err := tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
-		if err != nil {
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
 			return nil, fmt.Errorf("failed to create SSL connection to %s: %w", address, err)
 		}
-		return conn, nil
-	}
 
-	conn, err := dialer.DialContext(ctx, "tcp", address)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create connection to %s: %w", address, err)
+		return tlsConn, nil
 	}
+
 	return conn, nil
 }
 
@@ -0,0 +1,234 @@
+package plugin_storage
+
+import (
+	"context"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/pkg/storage_plugin"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	pluginOperationTimeout = 30 * time.Second
+)
+
+// PluginStorage stores files via out-of-process storage plugin. Config is
+// shown to users as is, SecretConfig values are encrypted and hidden
+type PluginStorage struct {
+	StorageID    uuid.UUID         `json:"storageId"    gorm:"primaryKey;type:uuid;column:storage_id"`
+	PluginName   string            `json:"pluginName"   gorm:"not null;type:text;column:plugin_name"`
+	Config       map[string]string `json:"config"       gorm:"not null;type:text;column:config;serializer:json"`
+	SecretConfig map[string]string `json:"secretConfig" gorm:"not null;type:text;column:secret_config;serializer:json"`
+}
+
+func (p *PluginStorage) TableName() string {
+	return "plugin_storages"
+}
+
+func (p *PluginStorage) SaveFile(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	fileID uuid.UUID,
+	file io.Reader,
+) error {
+	logger.Info(
+		"Starting to save file to plugin storage",
+		"fileId", fileID.String(),
+		"plugin", p.PluginName,
+	)
+
+	client, pluginConfig, err := p.getClientAndConfig(encryptor)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SaveFile(ctx, pluginConfig, fileID.String(), file); err != nil {
+		select {
+		case <-ctx.Done():
+			logger.Info("Plugin storage upload cancelled", "fileId", fileID.String())
+			return ctx.Err()
+		default:
+			return fmt.Errorf("storage plugin %q failed to save file: %w", p.PluginName, err)
+		}
+	}
+
+	logger.Info("Successfully saved file to plugin storage", "fileId", fileID.String())
+	return nil
+}
+
+func (p *PluginStorage) GetFile(
+	encryptor encryption.FieldEncryptor,
+	fileID uuid.UUID,
+) (io.ReadCloser, error) {
+	client, pluginConfig, err := p.getClientAndConfig(encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	// downloads of large backups take longer than any fixed deadline, so only waiting on
+	// the plugin is limited: opening the file and each read must finish within the timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	stallTimer := time.AfterFunc(pluginOperationTimeout, cancel)
+
+	file, err := client.GetFile(ctx, pluginConfig, fileID.String())
+	stallTimer.Stop()
+
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("storage plugin %q failed to get file: %w", p.PluginName, err)
+	}
+
+	return &stallTimeoutReader{file: file, stallTimer: stallTimer, cancel: cancel}, nil
+}
+
+func (p *PluginStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid.UUID) error {
+	client, pluginConfig, err := p.getClientAndConfig(encryptor)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginOperationTimeout)
+	defer cancel()
+
+	if err := client.DeleteFile(ctx, pluginConfig, fileID.String()); err != nil {
+		return fmt.Errorf("storage plugin %q failed to delete file: %w", p.PluginName, err)
+	}
+
+	return nil
+}
+
+func (p *PluginStorage) Validate(encryptor encryption.FieldEncryptor) error {
+	if p.PluginName == "" {
+		return errors.New("storage plugin name is required")
+	}
+
+	if !pluginNameRegex.MatchString(p.PluginName) {
+		return errors.New("storage plugin name is invalid")
+	}
+
+	for key := range p.SecretConfig {
+		if _, isFound := p.Config[key]; isFound {
+			return fmt.Errorf("key %q is defined both in config and secret config", key)
+		}
+	}
+
+	client, pluginConfig, err := p.getClientAndConfig(encryptor)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginOperationTimeout)
+	defer cancel()
+
+	return client.Validate(ctx, pluginConfig)
+}
+
+func (p *PluginStorage) TestConnection(encryptor encryption.FieldEncryptor) error {
+	client, pluginConfig, err := p.getClientAndConfig(encryptor)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginOperationTimeout)
+	defer cancel()
+
+	if err := client.TestConnection(ctx, pluginConfig); err != nil {
+		return fmt.Errorf("storage plugin %q connection test failed: %w", p.PluginName, err)
+	}
+
+	return nil
+}
+
+// HideSensitiveData keeps secret keys, so UI can show which secrets are set
+func (p *PluginStorage) HideSensitiveData() {
+	for key := range p.SecretConfig {
+		p.SecretConfig[key] = ""
+	}
+}
+
+func (p *PluginStorage) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	for key, value := range p.SecretConfig {
+		if value == "" {
+			continue
+		}
+
+		encrypted, err := encryptor.Encrypt(p.StorageID, value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt plugin secret %q: %w", key, err)
+		}
+
+		p.SecretConfig[key] = encrypted
+	}
+
+	return nil
+}
+
+// Update keeps existing secret when incoming value is empty, because
+// secrets are hidden from API responses
+func (p *PluginStorage) Update(incoming *PluginStorage) {
+	p.PluginName = incoming.PluginName
+	p.Config = incoming.Config
+
+	secretConfig := make(map[string]string, len(incoming.SecretConfig))
+	for key, value := range incoming.SecretConfig {
+		if value == "" {
+			value = p.SecretConfig[key]
+		}
+
+		secretConfig[key] = value
+	}
+
+	p.SecretConfig = secretConfig
+}
+
+// stallTimeoutReader cancels the download when the plugin sends nothing for the timeout.
+// Time between reads is not counted, so a slow consumer does not abort it
+type stallTimeoutReader struct {
+	file       io.ReadCloser
+	stallTimer *time.Timer
+	cancel     context.CancelFunc
+}
+
+func (r *stallTimeoutReader) Read(p []byte) (int, error) {
+	r.stallTimer.Reset(pluginOperationTimeout)
+	defer r.stallTimer.Stop()
+
+	return r.file.Read(p)
+}
+
+func (r *stallTimeoutReader) Close() error {
+	r.stallTimer.Stop()
+	r.cancel()
+
+	return r.file.Close()
+}
+
+func (p *PluginStorage) getClientAndConfig(
+	encryptor encryption.FieldEncryptor,
+) (*storage_plugin.Client, map[string]string, error) {
+	pluginConfig := make(map[string]string, len(p.Config)+len(p.SecretConfig))
+	maps.Copy(pluginConfig, p.Config)
+
+	for key, value := range p.SecretConfig {
+		decrypted, err := encryptor.Decrypt(p.StorageID, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decrypt plugin secret %q: %w", key, err)
+		}
+
+		pluginConfig[key] = decrypted
+	}
+
+	client, err := getPluginClient(p.PluginName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, pluginConfig, nil
+}
@@ -0,0 +1,106 @@
+package plugin_storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/util/logger"
+	"databasus-backend/pkg/storage_plugin"
+)
+
+var pluginNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+var (
+	processesMu sync.Mutex
+	processes   = map[string]*storage_plugin.Process{}
+)
+
+// GetAvailablePlugins lists executables from STORAGE_PLUGINS_DIR, name of
+// executable is used as plugin name
+func GetAvailablePlugins() ([]string, error) {
+	pluginsDir := config.GetEnv().StoragePluginsDir
+	if pluginsDir == "" {
+		return []string{}, nil
+	}
+
+	entries, err := os.ReadDir(pluginsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read storage plugins dir: %w", err)
+	}
+
+	plugins := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !pluginNameRegex.MatchString(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0o111 == 0 {
+			continue
+		}
+
+		plugins = append(plugins, entry.Name())
+	}
+
+	slices.Sort(plugins)
+	return plugins, nil
+}
+
+// ShutdownPlugins stops all launched plugin processes
+func ShutdownPlugins() {
+	processesMu.Lock()
+	defer processesMu.Unlock()
+
+	for name, process := range processes {
+		process.Kill()
+		delete(processes, name)
+	}
+}
+
+// getPluginClient launches plugin on first use and relaunches it if
+// process crashed since previous call
+func getPluginClient(pluginName string) (*storage_plugin.Client, error) {
+	plugins, err := GetAvailablePlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(plugins, pluginName) {
+		return nil, fmt.Errorf("storage plugin %q is not installed", pluginName)
+	}
+
+	processesMu.Lock()
+	defer processesMu.Unlock()
+
+	if process, isFound := processes[pluginName]; isFound {
+		if !process.IsExited() {
+			return process.Client, nil
+		}
+
+		process.Kill()
+		delete(processes, pluginName)
+	}
+
+	executablePath := filepath.Join(config.GetEnv().StoragePluginsDir, pluginName)
+	pluginLogger := logger.GetLogger().With("storagePlugin", pluginName)
+
+	process, err := storage_plugin.Launch(executablePath, pluginLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch storage plugin %q: %w", pluginName, err)
+	}
+
+	processes[pluginName] = process
+	pluginLogger.Info("Storage plugin launched")
+
+	return process.Client, nil
+}
@@ -0,0 +1,133 @@
+package s3_storage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	assumeRoleSessionName = "databasus"
+	// imdsTimeout bounds calls to the metadata service, outside of AWS it does not answer
+	imdsTimeout = 5 * time.Second
+)
+
+// instanceProfileCredentials are shared by all storages, they are the credentials of the
+// server and are refreshed before they expire
+var instanceProfileCredentials = credentials.New(&credentials.IAM{
+	// the metadata service is local, it must not go through proxies of storages
+	Client: &http.Client{Timeout: imdsTimeout},
+})
+
+// assumedRoleCredentials caches credentials per role and base keys, so each client does
+// not call STS again while the session is valid
+var assumedRoleCredentials sync.Map
+
+// assumeRoleProvider assumes a role with base credentials fetched on each refresh, so
+// sessions keep renewing after temporary base credentials rotate
+type assumeRoleProvider struct {
+	credentials.Expiry
+
+	baseCredentials *credentials.Credentials
+	stsEndpoint     string
+	options         credentials.STSAssumeRoleOptions
+}
+
+func (p *assumeRoleProvider) RetrieveWithCredContext(
+	cc *credentials.CredContext,
+) (credentials.Value, error) {
+	baseValue, err := p.baseCredentials.GetWithContext(cc)
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("failed to get credentials to assume role: %w", err)
+	}
+
+	options := p.options
+	options.AccessKey = baseValue.AccessKeyID
+	options.SecretKey = baseValue.SecretAccessKey
+	options.SessionToken = baseValue.SessionToken
+
+	assumeRole := &credentials.STSAssumeRole{
+		STSEndpoint: p.stsEndpoint,
+		Options:     options,
+	}
+
+	value, err := assumeRole.RetrieveWithCredContext(cc)
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("failed to assume role %s: %w", options.RoleARN, err)
+	}
+
+	p.SetExpiration(value.Expiration, credentials.DefaultExpiryWindow)
+
+	return value, nil
+}
+
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	return p.RetrieveWithCredContext(nil)
+}
+
+func (s *S3Storage) buildCredentials(
+	accessKey string,
+	secretKey string,
+	endpoint string,
+	useSSL bool,
+) *credentials.Credentials {
+	switch s.getAuthMethod() {
+	case AuthMethodInstanceProfile:
+		return instanceProfileCredentials
+	case AuthMethodAssumeRole:
+		baseCredentials := instanceProfileCredentials
+		if accessKey != "" {
+			baseCredentials = credentials.NewStaticV4(accessKey, secretKey, "")
+		}
+
+		// the encrypted key changes with the key, so updated keys get a new session
+		cacheKey := strings.Join(
+			[]string{s.StorageID.String(), s.RoleARN, s.ExternalID, s.S3AccessKey},
+			"|",
+		)
+		if cached, isCached := assumedRoleCredentials.Load(cacheKey); isCached {
+			if cachedCredentials, ok := cached.(*credentials.Credentials); ok {
+				return cachedCredentials
+			}
+		}
+
+		assumedCredentials := credentials.New(&assumeRoleProvider{
+			baseCredentials: baseCredentials,
+			stsEndpoint:     s.getSTSEndpoint(endpoint, useSSL),
+			options: credentials.STSAssumeRoleOptions{
+				RoleARN:         s.RoleARN,
+				RoleSessionName: assumeRoleSessionName,
+				ExternalID:      s.ExternalID,
+				Location:        s.S3Region,
+			},
+		})
+
+		assumedRoleCredentials.Store(cacheKey, assumedCredentials)
+		return assumedCredentials
+	default:
+		return credentials.NewStaticV4(accessKey, secretKey, "")
+	}
+}
+
+// getSTSEndpoint uses STS of the region on AWS. S3 compatible servers with a custom
+// endpoint, like MinIO, serve AssumeRole on the same endpoint
+func (s *S3Storage) getSTSEndpoint(endpoint string, useSSL bool) string {
+	if s.S3Endpoint != "" {
+		scheme := "https"
+		if !useSSL {
+			scheme = "http"
+		}
+
+		return scheme + "://" + endpoint
+	}
+
+	if s.S3Region != "" {
+		return fmt.Sprintf("https://sts.%s.amazonaws.com", s.S3Region)
+	}
+
+	return credentials.DefaultSTSRoleEndpoint
+}
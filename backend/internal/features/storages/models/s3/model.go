@@ -6,6 +6,9 @@ import (
 	"crypto/md5"
 	"crypto/tls"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	network_utils "databasus-backend/internal/util/network"
+	proxy_utils "databasus-backend/internal/util/proxy"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -27,6 +30,11 @@ const (
 	s3IdleConnTimeout     = 90 * time.Second
 	s3TLSHandshakeTimeout = 30 * time.Second
 	s3DeleteTimeout       = 30 * time.Second
+	s3StatTimeout         = 30 * time.Second
+	// s3DirectoryDeleteTimeout bounds listing and deleting all objects of a directory
+	s3DirectoryDeleteTimeout = 10 * time.Minute
+	// s3ListTimeout bounds listing objects of a directory
+	s3ListTimeout = 10 * time.Minute
 
 	// Chunk size for multipart uploads - 16MB provides good balance between
 	// memory usage and upload efficiency. This creates backpressure to pg_dump
@@ -34,6 +42,19 @@ const (
 	multipartChunkSize = 16 * 1024 * 1024
 )
 
+type AuthMethod string
+
+const (
+	// AuthMethodStaticKeys signs requests with the access key and secret key of the storage
+	AuthMethodStaticKeys AuthMethod = "STATIC_KEYS"
+	// AuthMethodAssumeRole assumes RoleARN with STS. The access keys of the storage sign the
+	// AssumeRole call when set, otherwise the instance profile of the server does
+	AuthMethodAssumeRole AuthMethod = "ASSUME_ROLE"
+	// AuthMethodInstanceProfile uses ambient credentials of the server, like EC2 instance
+	// profiles, ECS task roles or EKS service accounts (IRSA). No secret is stored
+	AuthMethodInstanceProfile AuthMethod = "INSTANCE_PROFILE"
+)
+
 type S3Storage struct {
 	StorageID   uuid.UUID `json:"storageId"   gorm:"primaryKey;type:uuid;column:storage_id"`
 	S3Bucket    string    `json:"s3Bucket"    gorm:"not null;type:text;column:s3_bucket"`
@@ -42,9 +63,32 @@ type S3Storage struct {
 	S3SecretKey string    `json:"s3SecretKey" gorm:"not null;type:text;column:s3_secret_key"`
 	S3Endpoint  string    `json:"s3Endpoint"  gorm:"type:text;column:s3_endpoint"`
 
+	// AuthMethod picks where credentials come from, access keys are not needed for instance
+	// profiles and are optional when assuming a role. Empty is treated as static keys
+	AuthMethod AuthMethod `json:"authMethod" gorm:"not null;type:text;default:'STATIC_KEYS';column:auth_method"`
+	RoleARN    string     `json:"roleArn"    gorm:"not null;type:text;default:'';column:role_arn"`
+	ExternalID string     `json:"externalId" gorm:"not null;type:text;default:'';column:external_id"`
+
+	// ServerSideEncryption and ObjectLockMode are applied to every uploaded object. Empty
+	// values are treated as NONE and keep the defaults of the bucket
+	ServerSideEncryption    ServerSideEncryption `json:"serverSideEncryption"    gorm:"not null;type:text;default:'NONE';column:server_side_encryption"`
+	KMSKeyID                string               `json:"kmsKeyId"                gorm:"not null;type:text;default:'';column:kms_key_id"`
+	IsBucketKeyEnabled      bool                 `json:"isBucketKeyEnabled"      gorm:"not null;default:false;column:is_bucket_key_enabled"`
+	ObjectLockMode          ObjectLockMode       `json:"objectLockMode"          gorm:"not null;type:text;default:'NONE';column:object_lock_mode"`
+	ObjectLockRetentionDays int                  `json:"objectLockRetentionDays" gorm:"not null;default:0;column:object_lock_retention_days"`
+
 	S3Prefix                string `json:"s3Prefix"                gorm:"type:text;column:s3_prefix"`
 	S3UseVirtualHostedStyle bool   `json:"s3UseVirtualHostedStyle" gorm:"default:false;column:s3_use_virtual_hosted_style"`
 	SkipTLSVerify           bool   `json:"skipTLSVerify"           gorm:"default:false;column:skip_tls_verify"`
+
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this storage, see proxy_utils.GetProxyFunc
+	ProxyURL string `json:"proxyUrl" gorm:"not null;default:'';type:text;column:proxy_url"`
+
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IPPreference network_utils.IPPreference `json:"ipPreference" gorm:"not null;type:text;default:'AUTO';column:ip_preference"`
+
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DNSOverride *network_utils.DNSOverride `json:"dnsOverride,omitempty" gorm:"column:dns_override;type:text;serializer:json"`
 }
 
 func (s *S3Storage) TableName() string {
@@ -57,6 +101,17 @@ func (s *S3Storage) SaveFile(
 	logger *slog.Logger,
 	fileID uuid.UUID,
 	file io.Reader,
+) error {
+	return s.SaveObject(ctx, encryptor, fileID.String(), file)
+}
+
+// SaveObject writes an object by its key relative to the prefix, e.g. a backup named by
+// the naming template of the storage
+func (s *S3Storage) SaveObject(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	name string,
+	file io.Reader,
 ) error {
 	select {
 	case <-ctx.Done():
@@ -69,14 +124,14 @@ func (s *S3Storage) SaveFile(
 		return err
 	}
 
-	objectKey := s.buildObjectKey(fileID.String())
+	objectKey := s.buildObjectKey(name)
 
-	uploadID, err := coreClient.NewMultipartUpload(
-		ctx,
-		s.S3Bucket,
-		objectKey,
-		minio.PutObjectOptions{},
-	)
+	putOptions, err := s.getPutObjectOptions()
+	if err != nil {
+		return err
+	}
+
+	uploadID, err := coreClient.NewMultipartUpload(ctx, s.S3Bucket, objectKey, putOptions)
 	if err != nil {
 		return fmt.Errorf("failed to initiate multipart upload: %w", err)
 	}
@@ -150,15 +205,14 @@ func (s *S3Storage) SaveFile(
 		if err != nil {
 			return err
 		}
+		putOptions.SendContentMd5 = true
 		_, err = client.PutObject(
 			ctx,
 			s.S3Bucket,
 			objectKey,
 			bytes.NewReader([]byte{}),
 			0,
-			minio.PutObjectOptions{
-				SendContentMd5: true,
-			},
+			putOptions,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to upload empty file: %w", err)
@@ -222,12 +276,17 @@ func (s *S3Storage) GetFile(
 }
 
 func (s *S3Storage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid.UUID) error {
+	return s.DeleteObject(encryptor, fileID.String())
+}
+
+// DeleteObject removes an object by its key relative to the prefix
+func (s *S3Storage) DeleteObject(encryptor encryption.FieldEncryptor, name string) error {
 	client, err := s.getClient(encryptor)
 	if err != nil {
 		return err
 	}
 
-	objectKey := s.buildObjectKey(fileID.String())
+	objectKey := s.buildObjectKey(name)
 
 	ctx, cancel := context.WithTimeout(context.Background(), s3DeleteTimeout)
 	defer cancel()
@@ -245,18 +304,296 @@ func (s *S3Storage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uuid.
 	return nil
 }
 
+// DeleteDirectory removes all objects under the directory of the prefix, for backups
+// written by database engines as many objects
+func (s *S3Storage) DeleteDirectory(encryptor encryption.FieldEncryptor, directory string) error {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3DirectoryDeleteTimeout)
+	defer cancel()
+
+	var listErr error
+	objects := make(chan minio.ObjectInfo)
+
+	go func() {
+		defer close(objects)
+
+		for object := range client.ListObjects(ctx, s.S3Bucket, minio.ListObjectsOptions{
+			Prefix:    s.buildObjectKey(strings.Trim(directory, "/") + "/"),
+			Recursive: true,
+		}) {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+
+			select {
+			case objects <- object:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for removeErr := range client.RemoveObjects(
+		ctx,
+		s.S3Bucket,
+		objects,
+		minio.RemoveObjectsOptions{},
+	) {
+		return fmt.Errorf(
+			"failed to delete %s from S3: %w",
+			removeErr.ObjectName,
+			removeErr.Err,
+		)
+	}
+
+	if listErr != nil {
+		return fmt.Errorf("failed to list objects in S3: %w", listErr)
+	}
+
+	return nil
+}
+
+// S3Object is an object found under the prefix, Name is its key relative to the prefix
+type S3Object struct {
+	Name       string
+	SizeBytes  int64
+	ModifiedAt time.Time
+}
+
+// ListObjects lists objects under the directory of the prefix, e.g. dumps written by other
+// tools before Databasus. At most limit objects are returned
+func (s *S3Storage) ListObjects(
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]S3Object, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3ListTimeout)
+	defer cancel()
+
+	listPrefix := s.buildObjectKey("")
+	if directory = strings.Trim(directory, "/"); directory != "" {
+		listPrefix = s.buildObjectKey(directory + "/")
+	}
+
+	objects := make([]S3Object, 0)
+	for object := range client.ListObjects(ctx, s.S3Bucket, minio.ListObjectsOptions{
+		Prefix:    listPrefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects in S3: %w", object.Err)
+		}
+
+		if len(objects) >= limit {
+			break
+		}
+
+		objects = append(objects, S3Object{
+			Name:       strings.TrimPrefix(object.Key, s.buildObjectKey("")),
+			SizeBytes:  object.Size,
+			ModifiedAt: object.LastModified,
+		})
+	}
+
+	return objects, nil
+}
+
+// BrowseDirectory lists objects and common prefixes right under the directory of the prefix
+func (s *S3Storage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s3ListTimeout)
+	defer cancel()
+
+	listPrefix := s.buildObjectKey("")
+	if directory != "" {
+		listPrefix = s.buildObjectKey(directory + "/")
+	}
+
+	entries := make([]files_utils.DirectoryEntry, 0)
+	for object := range client.ListObjects(ctx, s.S3Bucket, minio.ListObjectsOptions{
+		Prefix: listPrefix,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects in S3: %w", object.Err)
+		}
+
+		if len(entries) >= limit {
+			break
+		}
+
+		name := strings.TrimPrefix(object.Key, listPrefix)
+		if name == "" {
+			continue
+		}
+
+		// common prefixes are returned as keys ending with a slash
+		if strings.HasSuffix(name, "/") {
+			entries = append(entries, files_utils.DirectoryEntry{
+				Name:        strings.TrimSuffix(name, "/"),
+				IsDirectory: true,
+			})
+			continue
+		}
+
+		modifiedAt := object.LastModified
+		entries = append(entries, files_utils.DirectoryEntry{
+			Name:       name,
+			SizeBytes:  object.Size,
+			ModifiedAt: &modifiedAt,
+		})
+	}
+
+	return entries, nil
+}
+
+// GetObject reads an object by its key relative to the prefix
+func (s *S3Storage) GetObject(
+	encryptor encryption.FieldEncryptor,
+	name string,
+) (io.ReadCloser, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := client.GetObject(
+		context.TODO(),
+		s.S3Bucket,
+		s.buildObjectKey(name),
+		minio.GetObjectOptions{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object from S3: %w", err)
+	}
+
+	if _, err := object.Stat(); err != nil {
+		_ = object.Close()
+		return nil, fmt.Errorf("object %s does not exist in S3: %w", name, err)
+	}
+
+	return object, nil
+}
+
+// IsObjectExists reports whether an object with the key relative to the prefix exists
+func (s *S3Storage) IsObjectExists(encryptor encryption.FieldEncryptor, name string) (bool, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3StatTimeout)
+	defer cancel()
+
+	_, err = client.StatObject(ctx, s.S3Bucket, s.buildObjectKey(name), minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to stat object in S3: %w", err)
+	}
+
+	return true, nil
+}
+
+// CopyObject copies an object inside the bucket without downloading it, objects larger
+// than a single copy allows are copied in parts
+func (s *S3Storage) CopyObject(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	sourceName string,
+	destinationName string,
+) error {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return err
+	}
+
+	destinationOptions, err := s.getCopyDestOptions(s.buildObjectKey(destinationName))
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.ComposeObject(
+		ctx,
+		destinationOptions,
+		minio.CopySrcOptions{Bucket: s.S3Bucket, Object: s.buildObjectKey(sourceName)},
+	); err != nil {
+		return fmt.Errorf("failed to copy object in S3: %w", err)
+	}
+
+	return nil
+}
+
+// GetUploadPartSizeBytes is the size of parts of multipart uploads
+func (s *S3Storage) GetUploadPartSizeBytes() int64 {
+	return multipartChunkSize
+}
+
 func (s *S3Storage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.S3Bucket == "" {
 		return errors.New("S3 bucket is required")
 	}
-	if s.S3AccessKey == "" {
-		return errors.New("S3 access key is required")
+
+	switch s.getAuthMethod() {
+	case AuthMethodStaticKeys:
+		if s.S3AccessKey == "" {
+			return errors.New("S3 access key is required")
+		}
+		if s.S3SecretKey == "" {
+			return errors.New("S3 secret key is required")
+		}
+	case AuthMethodAssumeRole:
+		if !strings.HasPrefix(s.RoleARN, "arn:") {
+			return errors.New("role ARN is required to assume a role")
+		}
+		if (s.S3AccessKey == "") != (s.S3SecretKey == "") {
+			return errors.New("S3 access key and secret key must be set together")
+		}
+	case AuthMethodInstanceProfile:
+		if s.S3AccessKey != "" || s.S3SecretKey != "" {
+			return errors.New("S3 access keys are not used with instance profile authentication")
+		}
+	default:
+		return fmt.Errorf("invalid auth method: %s", s.AuthMethod)
 	}
-	if s.S3SecretKey == "" {
-		return errors.New("S3 secret key is required")
+
+	if s.getAuthMethod() != AuthMethodAssumeRole && (s.RoleARN != "" || s.ExternalID != "") {
+		return errors.New("role ARN and external ID are used only to assume a role")
 	}
 
-	return nil
+	if err := s.validateObjectOptions(); err != nil {
+		return err
+	}
+
+	if err := proxy_utils.ValidateProxyURL(s.ProxyURL); err != nil {
+		return err
+	}
+
+	if err := s.IPPreference.Validate(); err != nil {
+		return err
+	}
+
+	return s.DNSOverride.Validate()
 }
 
 func (s *S3Storage) TestConnection(encryptor encryption.FieldEncryptor) error {
@@ -289,6 +626,20 @@ func (s *S3Storage) TestConnection(encryptor encryption.FieldEncryptor) error {
 	testData := []byte("test connection")
 	testReader := bytes.NewReader(testData)
 
+	if s.getObjectLockMode() != ObjectLockModeNone {
+		objectLock, _, _, _, err := client.GetObjectLockConfig(ctx, s.S3Bucket)
+		if err != nil || objectLock != "Enabled" {
+			return fmt.Errorf("object lock is not enabled on bucket '%s'", s.S3Bucket)
+		}
+	}
+
+	// The test file is encrypted like backups, but not locked, otherwise it could not be
+	// deleted until the retention ends
+	sse, err := s.getServerSide()
+	if err != nil {
+		return err
+	}
+
 	// Upload test file
 	_, err = client.PutObject(
 		ctx,
@@ -297,13 +648,31 @@ func (s *S3Storage) TestConnection(encryptor encryption.FieldEncryptor) error {
 		testReader,
 		int64(len(testData)),
 		minio.PutObjectOptions{
-			SendContentMd5: true,
+			SendContentMd5:       true,
+			ServerSideEncryption: sse,
 		},
 	)
 	if err != nil {
+		if sse != nil {
+			return fmt.Errorf("failed to upload encrypted test file to S3: %w", err)
+		}
 		return fmt.Errorf("failed to upload test file to S3: %w", err)
 	}
 
+	// some S3-compatible servers accept encryption headers and ignore them
+	if expectedSSE := s.getExpectedSSEHeader(); expectedSSE != "" {
+		info, err := client.StatObject(ctx, s.S3Bucket, testObjectKey, minio.StatObjectOptions{})
+		if err != nil {
+			_ = client.RemoveObject(ctx, s.S3Bucket, testObjectKey, minio.RemoveObjectOptions{})
+			return fmt.Errorf("failed to check encryption of test file: %w", err)
+		}
+
+		if info.Metadata.Get("X-Amz-Server-Side-Encryption") != expectedSSE {
+			_ = client.RemoveObject(ctx, s.S3Bucket, testObjectKey, minio.RemoveObjectOptions{})
+			return errors.New("S3 did not apply the server-side encryption to the test file")
+		}
+	}
+
 	// Delete test file
 	err = client.RemoveObject(
 		ctx,
@@ -321,6 +690,7 @@ func (s *S3Storage) TestConnection(encryptor encryption.FieldEncryptor) error {
 func (s *S3Storage) HideSensitiveData() {
 	s.S3AccessKey = ""
 	s.S3SecretKey = ""
+	s.ProxyURL = proxy_utils.RedactProxyURL(s.ProxyURL)
 }
 
 func (s *S3Storage) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
@@ -349,6 +719,23 @@ func (s *S3Storage) Update(incoming *S3Storage) {
 	s.S3Endpoint = incoming.S3Endpoint
 	s.S3UseVirtualHostedStyle = incoming.S3UseVirtualHostedStyle
 	s.SkipTLSVerify = incoming.SkipTLSVerify
+	s.ProxyURL = proxy_utils.MergeRedactedProxyURL(s.ProxyURL, incoming.ProxyURL)
+	s.IPPreference = incoming.IPPreference
+	s.DNSOverride = incoming.DNSOverride
+	s.AuthMethod = incoming.AuthMethod
+	s.RoleARN = incoming.RoleARN
+	s.ExternalID = incoming.ExternalID
+	s.ServerSideEncryption = incoming.ServerSideEncryption
+	s.KMSKeyID = incoming.KMSKeyID
+	s.IsBucketKeyEnabled = incoming.IsBucketKeyEnabled
+	s.ObjectLockMode = incoming.ObjectLockMode
+	s.ObjectLockRetentionDays = incoming.ObjectLockRetentionDays
+
+	// keys are kept when omitted, so switching to the instance profile drops them
+	if incoming.AuthMethod == AuthMethodInstanceProfile {
+		s.S3AccessKey = ""
+		s.S3SecretKey = ""
+	}
 
 	if incoming.S3AccessKey != "" {
 		s.S3AccessKey = incoming.S3AccessKey
@@ -362,6 +749,14 @@ func (s *S3Storage) Update(incoming *S3Storage) {
 	// otherwise we will have to transfer all the data to the new prefix
 }
 
+func (s *S3Storage) getAuthMethod() AuthMethod {
+	if s.AuthMethod == "" {
+		return AuthMethodStaticKeys
+	}
+
+	return s.AuthMethod
+}
+
 func (s *S3Storage) buildObjectKey(fileName string) string {
 	if s.S3Prefix == "" {
 		return fileName
@@ -378,15 +773,13 @@ func (s *S3Storage) buildObjectKey(fileName string) string {
 }
 
 func (s *S3Storage) getClient(encryptor encryption.FieldEncryptor) (*minio.Client, error) {
-	endpoint, useSSL, accessKey, secretKey, bucketLookup, transport, err := s.getClientParams(
-		encryptor,
-	)
+	endpoint, useSSL, creds, bucketLookup, transport, err := s.getClientParams(encryptor)
 	if err != nil {
 		return nil, err
 	}
 
 	minioClient, err := minio.New(endpoint, &minio.Options{
-		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Creds:        creds,
 		Secure:       useSSL,
 		Region:       s.S3Region,
 		BucketLookup: bucketLookup,
@@ -400,15 +793,13 @@ func (s *S3Storage) getClient(encryptor encryption.FieldEncryptor) (*minio.Clien
 }
 
 func (s *S3Storage) getCoreClient(encryptor encryption.FieldEncryptor) (*minio.Core, error) {
-	endpoint, useSSL, accessKey, secretKey, bucketLookup, transport, err := s.getClientParams(
-		encryptor,
-	)
+	endpoint, useSSL, creds, bucketLookup, transport, err := s.getClientParams(encryptor)
 	if err != nil {
 		return nil, err
 	}
 
 	coreClient, err := minio.NewCore(endpoint, &minio.Options{
-		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Creds:        creds,
 		Secure:       useSSL,
 		Region:       s.S3Region,
 		BucketLookup: bucketLookup,
@@ -423,7 +814,7 @@ func (s *S3Storage) getCoreClient(encryptor encryption.FieldEncryptor) (*minio.C
 
 func (s *S3Storage) getClientParams(
 	encryptor encryption.FieldEncryptor,
-) (endpoint string, useSSL bool, accessKey string, secretKey string, bucketLookup minio.BucketLookupType, transport *http.Transport, err error) {
+) (endpoint string, useSSL bool, creds *credentials.Credentials, bucketLookup minio.BucketLookupType, transport *http.Transport, err error) {
 	endpoint = s.S3Endpoint
 	useSSL = true
 
@@ -438,25 +829,36 @@ func (s *S3Storage) getClientParams(
 		endpoint = fmt.Sprintf("s3.%s.amazonaws.com", s.S3Region)
 	}
 
-	accessKey, err = encryptor.Decrypt(s.StorageID, s.S3AccessKey)
+	// the client expects IPv6 literals without a port in brackets, like in URLs
+	if ip := net.ParseIP(endpoint); ip != nil && ip.To4() == nil {
+		endpoint = "[" + endpoint + "]"
+	}
+
+	accessKey, err := encryptor.Decrypt(s.StorageID, s.S3AccessKey)
 	if err != nil {
-		return "", false, "", "", 0, nil, fmt.Errorf("failed to decrypt S3 access key: %w", err)
+		return "", false, nil, 0, nil, fmt.Errorf("failed to decrypt S3 access key: %w", err)
 	}
 
-	secretKey, err = encryptor.Decrypt(s.StorageID, s.S3SecretKey)
+	secretKey, err := encryptor.Decrypt(s.StorageID, s.S3SecretKey)
 	if err != nil {
-		return "", false, "", "", 0, nil, fmt.Errorf("failed to decrypt S3 secret key: %w", err)
+		return "", false, nil, 0, nil, fmt.Errorf("failed to decrypt S3 secret key: %w", err)
 	}
 
+	creds = s.buildCredentials(accessKey, secretKey, endpoint, useSSL)
+
 	bucketLookup = minio.BucketLookupAuto
 	if s.S3UseVirtualHostedStyle {
 		bucketLookup = minio.BucketLookupDNS
 	}
 
 	transport = &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout: s3ConnectTimeout,
-		}).DialContext,
+		Proxy: proxy_utils.GetProxyFunc(s.ProxyURL),
+		DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+			dialer := s.DNSOverride.NewDialer(s3ConnectTimeout)
+			address = s.DNSOverride.RewriteAddress(address)
+
+			return network_utils.DialContext(ctx, dialer, s.IPPreference, address)
+		},
 		TLSHandshakeTimeout:   s3TLSHandshakeTimeout,
 		ResponseHeaderTimeout: s3ResponseTimeout,
 		IdleConnTimeout:       s3IdleConnTimeout,
@@ -465,5 +867,5 @@ func (s *S3Storage) getClientParams(
 		},
 	}
 
-	return endpoint, useSSL, accessKey, secretKey, bucketLookup, transport, nil
+	return endpoint, useSSL, creds, bucketLookup, transport, nil
 }
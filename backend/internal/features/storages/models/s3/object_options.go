@@ -0,0 +1,180 @@
+package s3_storage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+const (
+	maxObjectLockRetentionDays = 36500
+
+	bucketKeyEnabledHeader = "X-Amz-Server-Side-Encryption-Bucket-Key-Enabled"
+)
+
+type ServerSideEncryption string
+
+const (
+	ServerSideEncryptionNone ServerSideEncryption = "NONE"
+	// ServerSideEncryptionS3 encrypts objects with keys managed by S3 (AES256)
+	ServerSideEncryptionS3 ServerSideEncryption = "SSE_S3"
+	// ServerSideEncryptionKMS encrypts objects with KMSKeyID, or the aws/s3 key when empty
+	ServerSideEncryptionKMS ServerSideEncryption = "SSE_KMS"
+)
+
+type ObjectLockMode string
+
+const (
+	ObjectLockModeNone ObjectLockMode = "NONE"
+	// ObjectLockModeGovernance can be bypassed by users with s3:BypassGovernanceRetention
+	ObjectLockModeGovernance ObjectLockMode = "GOVERNANCE"
+	// ObjectLockModeCompliance cannot be shortened or removed by anyone, the root user included
+	ObjectLockModeCompliance ObjectLockMode = "COMPLIANCE"
+)
+
+// kmsWithBucketKey asks S3 to use a bucket key, which cuts KMS requests of SSE-KMS.
+// minio does not send the header itself and prefixes user metadata with x-amz-meta-
+type kmsWithBucketKey struct {
+	encrypt.ServerSide
+}
+
+func (k kmsWithBucketKey) Marshal(h http.Header) {
+	k.ServerSide.Marshal(h)
+	h.Set(bucketKeyEnabledHeader, "true")
+}
+
+func (s *S3Storage) validateObjectOptions() error {
+	switch s.getServerSideEncryption() {
+	case ServerSideEncryptionNone, ServerSideEncryptionS3:
+		if s.KMSKeyID != "" {
+			return errors.New("KMS key ID is used only with SSE-KMS encryption")
+		}
+		if s.IsBucketKeyEnabled {
+			return errors.New("bucket key is used only with SSE-KMS encryption")
+		}
+	case ServerSideEncryptionKMS:
+	default:
+		return fmt.Errorf("invalid server-side encryption: %s", s.ServerSideEncryption)
+	}
+
+	switch s.getObjectLockMode() {
+	case ObjectLockModeNone:
+		if s.ObjectLockRetentionDays != 0 {
+			return errors.New("object lock retention days require an object lock mode")
+		}
+	case ObjectLockModeGovernance, ObjectLockModeCompliance:
+		if s.ObjectLockRetentionDays < 1 ||
+			s.ObjectLockRetentionDays > maxObjectLockRetentionDays {
+			return fmt.Errorf(
+				"object lock retention must be between 1 and %d days",
+				maxObjectLockRetentionDays,
+			)
+		}
+	default:
+		return fmt.Errorf("invalid object lock mode: %s", s.ObjectLockMode)
+	}
+
+	return nil
+}
+
+// getServerSide returns the encryption of uploaded objects, nil keeps the bucket default
+func (s *S3Storage) getServerSide() (encrypt.ServerSide, error) {
+	switch s.getServerSideEncryption() {
+	case ServerSideEncryptionS3:
+		return encrypt.NewSSE(), nil
+	case ServerSideEncryptionKMS:
+		sse, err := encrypt.NewSSEKMS(s.KMSKeyID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build SSE-KMS settings: %w", err)
+		}
+
+		if s.IsBucketKeyEnabled {
+			return kmsWithBucketKey{sse}, nil
+		}
+
+		return sse, nil
+	default:
+		return nil, nil
+	}
+}
+
+// getPutObjectOptions applies encryption and object lock retention to uploaded objects.
+// Retention is counted from the upload, so each backup stays locked for the same period
+func (s *S3Storage) getPutObjectOptions() (minio.PutObjectOptions, error) {
+	sse, err := s.getServerSide()
+	if err != nil {
+		return minio.PutObjectOptions{}, err
+	}
+
+	opts := minio.PutObjectOptions{ServerSideEncryption: sse}
+
+	if mode, ok := s.getRetentionMode(); ok {
+		opts.Mode = mode
+		opts.RetainUntilDate = s.getRetainUntilDate()
+	}
+
+	return opts, nil
+}
+
+func (s *S3Storage) getCopyDestOptions(objectKey string) (minio.CopyDestOptions, error) {
+	sse, err := s.getServerSide()
+	if err != nil {
+		return minio.CopyDestOptions{}, err
+	}
+
+	opts := minio.CopyDestOptions{Bucket: s.S3Bucket, Object: objectKey, Encryption: sse}
+
+	if mode, ok := s.getRetentionMode(); ok {
+		opts.Mode = mode
+		opts.RetainUntilDate = s.getRetainUntilDate()
+	}
+
+	return opts, nil
+}
+
+func (s *S3Storage) getRetentionMode() (minio.RetentionMode, bool) {
+	switch s.getObjectLockMode() {
+	case ObjectLockModeGovernance:
+		return minio.Governance, true
+	case ObjectLockModeCompliance:
+		return minio.Compliance, true
+	default:
+		return "", false
+	}
+}
+
+func (s *S3Storage) getRetainUntilDate() time.Time {
+	return time.Now().UTC().AddDate(0, 0, s.ObjectLockRetentionDays)
+}
+
+// getExpectedSSEHeader is the value of X-Amz-Server-Side-Encryption of encrypted objects
+func (s *S3Storage) getExpectedSSEHeader() string {
+	switch s.getServerSideEncryption() {
+	case ServerSideEncryptionS3:
+		return "AES256"
+	case ServerSideEncryptionKMS:
+		return "aws:kms"
+	default:
+		return ""
+	}
+}
+
+func (s *S3Storage) getServerSideEncryption() ServerSideEncryption {
+	if s.ServerSideEncryption == "" {
+		return ServerSideEncryptionNone
+	}
+
+	return s.ServerSideEncryption
+}
+
+func (s *S3Storage) getObjectLockMode() ObjectLockMode {
+	if s.ObjectLockMode == "" {
+		return ObjectLockModeNone
+	}
+
+	return s.ObjectLockMode
+}
@@ -3,11 +3,12 @@ package sftp_storage
 import (
 	"context"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	network_utils "databasus-backend/internal/util/network"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
-	"net"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ const (
 	sftpConnectTimeout     = 30 * time.Second
 	sftpTestConnectTimeout = 10 * time.Second
 	sftpDeleteTimeout      = 30 * time.Second
+	sftpListTimeout        = 2 * time.Minute
 )
 
 type SFTPStorage struct {
@@ -31,6 +33,12 @@ type SFTPStorage struct {
 	PrivateKey        string    `json:"privateKey"        gorm:"type:text;column:private_key"`
 	Path              string    `json:"path"              gorm:"type:text;column:path"`
 	SkipHostKeyVerify bool      `json:"skipHostKeyVerify" gorm:"not null;default:false;column:skip_host_key_verify"`
+
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IPPreference network_utils.IPPreference `json:"ipPreference" gorm:"not null;type:text;default:'AUTO';column:ip_preference"`
+
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DNSOverride *network_utils.DNSOverride `json:"dnsOverride,omitempty" gorm:"column:dns_override;type:text;serializer:json"`
 }
 
 func (s *SFTPStorage) TableName() string {
@@ -182,6 +190,38 @@ func (s *SFTPStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileID uui
 	return nil
 }
 
+// BrowseDirectory lists files and folders right under the directory of the path
+func (s *SFTPStorage) BrowseDirectory(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	directory string,
+	limit int,
+) ([]files_utils.DirectoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, sftpListTimeout)
+	defer cancel()
+
+	client, sshConn, err := s.connectWithContext(ctx, encryptor, sftpConnectTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SFTP: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+		_ = sshConn.Close()
+	}()
+
+	directoryPath := strings.TrimSuffix(s.getFilePath(directory), "/")
+	if directoryPath == "" {
+		directoryPath = "."
+	}
+
+	fileInfos, err := client.ReadDir(directoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory on SFTP: %w", err)
+	}
+
+	return files_utils.ToDirectoryEntries(fileInfos, limit), nil
+}
+
 func (s *SFTPStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.Host == "" {
 		return errors.New("SFTP host is required")
@@ -196,7 +236,11 @@ func (s *SFTPStorage) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("SFTP port must be between 1 and 65535")
 	}
 
-	return nil
+	if err := s.IPPreference.Validate(); err != nil {
+		return err
+	}
+
+	return s.DNSOverride.Validate()
 }
 
 func (s *SFTPStorage) TestConnection(encryptor encryption.FieldEncryptor) error {
@@ -252,6 +296,8 @@ func (s *SFTPStorage) Update(incoming *SFTPStorage) {
 	s.Username = incoming.Username
 	s.SkipHostKeyVerify = incoming.SkipHostKeyVerify
 	s.Path = incoming.Path
+	s.IPPreference = incoming.IPPreference
+	s.DNSOverride = incoming.DNSOverride
 
 	if incoming.Password != "" {
 		s.Password = incoming.Password
@@ -311,10 +357,15 @@ func (s *SFTPStorage) connectWithContext(
 		Timeout:         timeout,
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	address := network_utils.JoinHostPort(s.Host, s.Port)
 
-	dialer := net.Dialer{Timeout: timeout}
-	conn, err := dialer.DialContext(ctx, "tcp", address)
+	dialer := s.DNSOverride.NewDialer(timeout)
+	conn, err := network_utils.DialContext(
+		ctx,
+		dialer,
+		s.IPPreference,
+		s.DNSOverride.RewriteAddress(address),
+	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to dial SFTP server: %w", err)
 	}
@@ -0,0 +1,147 @@
+package storages
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxFileNameTemplateLength = 512
+
+var fileNameTemplateVariableRegex = regexp.MustCompile(`\{([a-zA-Z]+)\}`)
+
+var fileNameTemplateVariables = []string{
+	"workspace",
+	"database",
+	"engine",
+	"backupType",
+	"timestamp",
+	"year",
+	"month",
+	"day",
+	"id",
+}
+
+// FileNameVariables are substituted into naming templates like
+// "{workspace}/{database}/{year}/{month}/{engine}-{timestamp}.dump"
+type FileNameVariables struct {
+	Workspace  string
+	Database   string
+	Engine     string
+	BackupType string
+	CreatedAt  time.Time
+	FileID     uuid.UUID
+}
+
+// ValidateFileNameTemplate accepts an empty template, files are named by their ID then.
+// The template has to contain {id} or {timestamp}, so backups do not overwrite each other
+func ValidateFileNameTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+
+	if len(template) > maxFileNameTemplateLength {
+		return fmt.Errorf(
+			"file name template must be at most %d characters",
+			maxFileNameTemplateLength,
+		)
+	}
+
+	if strings.HasPrefix(template, "/") || strings.HasSuffix(template, "/") {
+		return errors.New("file name template must not start or end with /")
+	}
+
+	for _, segment := range strings.Split(template, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("file name template has invalid path segment %q", segment)
+		}
+	}
+
+	for _, match := range fileNameTemplateVariableRegex.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(fileNameTemplateVariables, match[1]) {
+			return fmt.Errorf("file name template has unknown variable {%s}", match[1])
+		}
+	}
+
+	if !strings.Contains(template, "{id}") && !strings.Contains(template, "{timestamp}") {
+		return errors.New("file name template must contain {id} or {timestamp}")
+	}
+
+	return nil
+}
+
+// RenderFileName substitutes the variables, slashes in names of workspaces and databases
+// are replaced so they do not add directories
+func RenderFileName(template string, variables FileNameVariables) string {
+	createdAt := variables.CreatedAt.UTC()
+
+	return strings.NewReplacer(
+		"{workspace}", sanitizeFileNamePart(variables.Workspace),
+		"{database}", sanitizeFileNamePart(variables.Database),
+		"{engine}", sanitizeFileNamePart(variables.Engine),
+		"{backupType}", sanitizeFileNamePart(variables.BackupType),
+		"{timestamp}", createdAt.Format("20060102T150405Z"),
+		"{year}", createdAt.Format("2006"),
+		"{month}", createdAt.Format("01"),
+		"{day}", createdAt.Format("02"),
+		"{id}", variables.FileID.String(),
+	).Replace(template)
+}
+
+// IsFileNamingSupported reports whether files can be saved under templated names, only
+// S3 storages support it as their keys may contain any path. Content-addressed storages
+// name files by their hash instead
+func (s *Storage) IsFileNamingSupported() bool {
+	return s.Type == StorageTypeS3 && s.S3Storage != nil && !s.IsContentAddressed
+}
+
+// BindFileName makes SaveFile, GetFile and DeleteFile of this instance use the name for
+// the file. Nothing changes for a nil name or a storage without naming support
+func (s *Storage) BindFileName(fileID uuid.UUID, fileName *string) {
+	if fileName == nil || !s.IsFileNamingSupported() {
+		return
+	}
+
+	if s.fileNames == nil {
+		s.fileNames = make(map[uuid.UUID]string)
+	}
+
+	s.fileNames[fileID] = *fileName
+}
+
+// GetStoredFileName returns the name the file has in the storage, relative to its prefix
+// or path. Content-addressed storages keep the content under its hash
+func (s *Storage) GetStoredFileName(
+	fileID uuid.UUID,
+	fileName *string,
+	contentHash *string,
+) string {
+	if s.IsContentAddressedLayout() && contentHash != nil {
+		return getContentObjectName(*contentHash)
+	}
+
+	if fileName != nil && s.IsFileNamingSupported() {
+		return *fileName
+	}
+
+	return fileID.String()
+}
+
+func (s *Storage) getBoundFileName(fileID uuid.UUID) (string, bool) {
+	fileName, ok := s.fileNames[fileID]
+	return fileName, ok
+}
+
+func sanitizeFileNamePart(part string) string {
+	part = strings.TrimSpace(part)
+	if part == "" {
+		return "unknown"
+	}
+
+	return strings.NewReplacer("/", "-", "\\", "-").Replace(part)
+}
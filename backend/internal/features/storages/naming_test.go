@@ -0,0 +1,44 @@
+package storages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateFileNameTemplate_WhenTemplateInvalid_ErrorReturned(t *testing.T) {
+	assert.NoError(t, ValidateFileNameTemplate(""))
+	assert.NoError(t, ValidateFileNameTemplate("{workspace}/{database}/{timestamp}.dump"))
+	assert.NoError(t, ValidateFileNameTemplate("backups/{year}/{month}/{day}/{id}"))
+
+	assert.Error(t, ValidateFileNameTemplate("/{database}/{id}"))
+	assert.Error(t, ValidateFileNameTemplate("{database}/{id}/"))
+	assert.Error(t, ValidateFileNameTemplate("{database}//{id}"))
+	assert.Error(t, ValidateFileNameTemplate("../{id}"))
+	assert.Error(t, ValidateFileNameTemplate("{database}/{host}/{id}"))
+	assert.Error(t, ValidateFileNameTemplate("{workspace}/{database}.dump"))
+}
+
+func Test_RenderFileName_WhenVariablesSet_NameRendered(t *testing.T) {
+	fileID := uuid.New()
+
+	fileName := RenderFileName(
+		"{workspace}/{database}/{year}/{month}/{day}/{engine}-{backupType}-{timestamp}-{id}",
+		FileNameVariables{
+			Workspace:  "Team / Payments",
+			Database:   "",
+			Engine:     "postgres",
+			BackupType: "manual",
+			CreatedAt:  time.Date(2026, 4, 10, 3, 4, 5, 0, time.UTC),
+			FileID:     fileID,
+		},
+	)
+
+	assert.Equal(
+		t,
+		"Team - Payments/unknown/2026/04/10/postgres-manual-20260410T030405Z-"+fileID.String(),
+		fileName,
+	)
+}
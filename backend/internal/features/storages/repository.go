@@ -1,6 +1,8 @@
 package storages
 
 import (
+	"time"
+
 	db "databasus-backend/internal/storage"
 
 	"github.com/google/uuid"
@@ -46,17 +48,25 @@ func (r *StorageRepository) Save(storage *Storage) (*Storage, error) {
 			if storage.RcloneStorage != nil {
 				storage.RcloneStorage.StorageID = storage.ID
 			}
+		case StorageTypePlugin:
+			if storage.PluginStorage != nil {
+				storage.PluginStorage.StorageID = storage.ID
+			}
+		case StorageTypeGCS:
+			if storage.GCSStorage != nil {
+				storage.GCSStorage.StorageID = storage.ID
+			}
 		}
 
 		if storage.ID == uuid.Nil {
 			if err := tx.Create(storage).
-				Omit("LocalStorage", "S3Storage", "GoogleDriveStorage", "NASStorage", "AzureBlobStorage", "FTPStorage", "SFTPStorage", "RcloneStorage").
+				Omit("LocalStorage", "S3Storage", "GoogleDriveStorage", "NASStorage", "AzureBlobStorage", "FTPStorage", "SFTPStorage", "RcloneStorage", "PluginStorage", "GCSStorage").
 				Error; err != nil {
 				return err
 			}
 		} else {
 			if err := tx.Save(storage).
-				Omit("LocalStorage", "S3Storage", "GoogleDriveStorage", "NASStorage", "AzureBlobStorage", "FTPStorage", "SFTPStorage", "RcloneStorage").
+				Omit("LocalStorage", "S3Storage", "GoogleDriveStorage", "NASStorage", "AzureBlobStorage", "FTPStorage", "SFTPStorage", "RcloneStorage", "PluginStorage", "GCSStorage").
 				Error; err != nil {
 				return err
 			}
@@ -119,6 +129,20 @@ func (r *StorageRepository) Save(storage *Storage) (*Storage, error) {
 					return err
 				}
 			}
+		case StorageTypePlugin:
+			if storage.PluginStorage != nil {
+				storage.PluginStorage.StorageID = storage.ID // Ensure ID is set
+				if err := tx.Save(storage.PluginStorage).Error; err != nil {
+					return err
+				}
+			}
+		case StorageTypeGCS:
+			if storage.GCSStorage != nil {
+				storage.GCSStorage.StorageID = storage.ID // Ensure ID is set
+				if err := tx.Save(storage.GCSStorage).Error; err != nil {
+					return err
+				}
+			}
 		}
 
 		return nil
@@ -134,16 +158,7 @@ func (r *StorageRepository) Save(storage *Storage) (*Storage, error) {
 func (r *StorageRepository) FindByID(id uuid.UUID) (*Storage, error) {
 	var s Storage
 
-	if err := db.
-		GetDb().
-		Preload("LocalStorage").
-		Preload("S3Storage").
-		Preload("GoogleDriveStorage").
-		Preload("NASStorage").
-		Preload("AzureBlobStorage").
-		Preload("FTPStorage").
-		Preload("SFTPStorage").
-		Preload("RcloneStorage").
+	if err := preloadSpecificStorages(db.GetDb()).
 		Where("id = ?", id).
 		First(&s).Error; err != nil {
 		return nil, err
@@ -155,16 +170,7 @@ func (r *StorageRepository) FindByID(id uuid.UUID) (*Storage, error) {
 func (r *StorageRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Storage, error) {
 	var storages []*Storage
 
-	if err := db.
-		GetDb().
-		Preload("LocalStorage").
-		Preload("S3Storage").
-		Preload("GoogleDriveStorage").
-		Preload("NASStorage").
-		Preload("AzureBlobStorage").
-		Preload("FTPStorage").
-		Preload("SFTPStorage").
-		Preload("RcloneStorage").
+	if err := preloadSpecificStorages(db.GetDb()).
 		Where("workspace_id = ? OR is_system = TRUE", workspaceID).
 		Order("name ASC").
 		Find(&storages).Error; err != nil {
@@ -174,6 +180,38 @@ func (r *StorageRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Storage
 	return storages, nil
 }
 
+// FindWithCredentialsExpiringBefore returns storages whose credentials expire before the
+// time, including already expired ones
+func (r *StorageRepository) FindWithCredentialsExpiringBefore(
+	expireBefore time.Time,
+) ([]*Storage, error) {
+	var storages []*Storage
+
+	if err := db.GetDb().
+		Where("credentials_expire_at IS NOT NULL AND credentials_expire_at < ?", expireBefore).
+		Order("credentials_expire_at ASC").
+		Find(&storages).Error; err != nil {
+		return nil, err
+	}
+
+	return storages, nil
+}
+
+func (r *StorageRepository) FindByIDs(ids []uuid.UUID) ([]*Storage, error) {
+	storages := make([]*Storage, 0, len(ids))
+	if len(ids) == 0 {
+		return storages, nil
+	}
+
+	if err := preloadSpecificStorages(db.GetDb()).
+		Where("id IN ?", ids).
+		Find(&storages).Error; err != nil {
+		return nil, err
+	}
+
+	return storages, nil
+}
+
 func (r *StorageRepository) Delete(s *Storage) error {
 	return db.GetDb().Transaction(func(tx *gorm.DB) error {
 		// Delete specific storage based on type
@@ -226,9 +264,38 @@ func (r *StorageRepository) Delete(s *Storage) error {
 					return err
 				}
 			}
+		case StorageTypePlugin:
+			if s.PluginStorage != nil {
+				if err := tx.Delete(s.PluginStorage).Error; err != nil {
+					return err
+				}
+			}
+		case StorageTypeGCS:
+			if s.GCSStorage != nil {
+				if err := tx.Delete(s.GCSStorage).Error; err != nil {
+					return err
+				}
+			}
 		}
 
 		// Delete the main storage
 		return tx.Delete(s).Error
 	})
 }
+
+// preloadSpecificStorages loads every type-specific table with one IN query per type.
+// The number of queries stays the same for 1 or 1000 storages, so listing methods must
+// use it instead of loading storages one by one
+func preloadSpecificStorages(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("LocalStorage").
+		Preload("S3Storage").
+		Preload("GoogleDriveStorage").
+		Preload("NASStorage").
+		Preload("AzureBlobStorage").
+		Preload("FTPStorage").
+		Preload("SFTPStorage").
+		Preload("RcloneStorage").
+		Preload("PluginStorage").
+		Preload("GCSStorage")
+}
@@ -0,0 +1,145 @@
+package storages
+
+import (
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const benchmarkStoragesCount = 200
+
+// Listing goes through preloads, so the number of queries does not depend on the number of
+// storages. Compare queries/op with Benchmark_FindByID_LoadingStoragesOneByOne:
+// go test ./internal/features/storages -run ^$ -bench FindBy
+func Benchmark_FindByWorkspaceID_With200Storages(b *testing.B) {
+	workspaceID, storageIDs := createBenchmarkStorages(b)
+	defer removeBenchmarkStorages(workspaceID, storageIDs)
+
+	repository := &StorageRepository{}
+	var queriesCount int64
+
+	b.ResetTimer()
+
+	for range b.N {
+		queriesCount += test_utils.CountQueries(func() {
+			if _, err := repository.FindByWorkspaceID(workspaceID); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+
+	b.ReportMetric(float64(queriesCount)/float64(b.N), "queries/op")
+}
+
+func Benchmark_FindByIDs_With200Storages(b *testing.B) {
+	workspaceID, storageIDs := createBenchmarkStorages(b)
+	defer removeBenchmarkStorages(workspaceID, storageIDs)
+
+	repository := &StorageRepository{}
+	var queriesCount int64
+
+	b.ResetTimer()
+
+	for range b.N {
+		queriesCount += test_utils.CountQueries(func() {
+			if _, err := repository.FindByIDs(storageIDs); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+
+	b.ReportMetric(float64(queriesCount)/float64(b.N), "queries/op")
+}
+
+// Baseline of the N+1 pattern replaced by FindByIDs
+func Benchmark_FindByID_LoadingStoragesOneByOne(b *testing.B) {
+	workspaceID, storageIDs := createBenchmarkStorages(b)
+	defer removeBenchmarkStorages(workspaceID, storageIDs)
+
+	repository := &StorageRepository{}
+	var queriesCount int64
+
+	b.ResetTimer()
+
+	for range b.N {
+		queriesCount += test_utils.CountQueries(func() {
+			for _, storageID := range storageIDs {
+				if _, err := repository.FindByID(storageID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	b.ReportMetric(float64(queriesCount)/float64(b.N), "queries/op")
+}
+
+func Test_FindStorages_WhenStoragesCountGrows_QueriesCountStaysTheSame(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Test Workspace", owner.UserID)
+	require.NoError(t, err)
+
+	storageIDs := []uuid.UUID{}
+	defer func() { removeBenchmarkStorages(workspace.ID, storageIDs) }()
+
+	repository := &StorageRepository{}
+	countQueries := func() (int64, int64) {
+		byWorkspaceCount := test_utils.CountQueries(func() {
+			storages, err := repository.FindByWorkspaceID(workspace.ID)
+			require.NoError(t, err)
+			require.Len(t, storages, len(storageIDs))
+		})
+
+		byIDsCount := test_utils.CountQueries(func() {
+			storages, err := repository.FindByIDs(storageIDs)
+			require.NoError(t, err)
+			require.Len(t, storages, len(storageIDs))
+		})
+
+		return byWorkspaceCount, byIDsCount
+	}
+
+	storageIDs = append(storageIDs, CreateTestStorage(workspace.ID).ID)
+	byWorkspaceForOne, byIDsForOne := countQueries()
+
+	for range 19 {
+		storageIDs = append(storageIDs, CreateTestStorage(workspace.ID).ID)
+	}
+	byWorkspaceForMany, byIDsForMany := countQueries()
+
+	assert.Equal(t, byWorkspaceForOne, byWorkspaceForMany)
+	assert.Equal(t, byIDsForOne, byIDsForMany)
+}
+
+func createBenchmarkStorages(b *testing.B) (uuid.UUID, []uuid.UUID) {
+	b.Helper()
+
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Benchmark Workspace", owner.UserID)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	storageIDs := make([]uuid.UUID, 0, benchmarkStoragesCount)
+
+	for range benchmarkStoragesCount {
+		storageIDs = append(storageIDs, CreateTestStorage(workspace.ID).ID)
+	}
+
+	return workspace.ID, storageIDs
+}
+
+func removeBenchmarkStorages(workspaceID uuid.UUID, storageIDs []uuid.UUID) {
+	for _, storageID := range storageIDs {
+		RemoveTestStorage(storageID)
+	}
+
+	_ = workspaces_testing.RemoveTestWorkspaceDirect(workspaceID)
+}
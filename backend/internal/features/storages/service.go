@@ -1,30 +1,48 @@
 package storages
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"databasus-backend/internal/config"
 	audit_logs "databasus-backend/internal/features/audit_logs"
+	plugin_storage "databasus-backend/internal/features/storages/models/plugin"
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
+	"databasus-backend/internal/util/logger"
 
 	"github.com/google/uuid"
 )
 
+// maxBrowseEntries bounds file browser listings, backup folders may hold many files
+const maxBrowseEntries = 1000
+
 type StorageService struct {
 	storageRepository      *StorageRepository
 	workspaceService       *workspaces_services.WorkspaceService
+	settingsService        *users_services.SettingsService
 	auditLogService        *audit_logs.AuditLogService
 	fieldEncryptor         encryption.FieldEncryptor
 	storageDatabaseCounter StorageDatabaseCounter
+	prodReferenceChecker   StorageProdReferenceChecker
 }
 
 func (s *StorageService) SetStorageDatabaseCounter(storageDatabaseCounter StorageDatabaseCounter) {
 	s.storageDatabaseCounter = storageDatabaseCounter
 }
 
+func (s *StorageService) SetStorageProdReferenceChecker(
+	prodReferenceChecker StorageProdReferenceChecker,
+) {
+	s.prodReferenceChecker = prodReferenceChecker
+}
+
 func (s *StorageService) OnBeforeWorkspaceDeletion(workspaceID uuid.UUID) error {
 	storages, err := s.storageRepository.FindByWorkspaceID(workspaceID)
 	if err != nil {
@@ -76,6 +94,10 @@ func (s *StorageService) SaveStorage(
 		return ErrInsufficientPermissionsToManageStorage
 	}
 
+	if err := s.validateVisibleWorkspaces(storage); err != nil {
+		return err
+	}
+
 	if isUpdate {
 		existingStorage, err := s.storageRepository.FindByID(storage.ID)
 		if err != nil {
@@ -90,8 +112,24 @@ func (s *StorageService) SaveStorage(
 			return ErrSystemStorageCannotBeMadePrivate
 		}
 
+		if err := s.workspaceService.ValidateChangeReason(
+			workspaceID,
+			storage.ChangeReason,
+		); err != nil {
+			return err
+		}
+
+		previousOwnership := existingStorage.Ownership
 		existingStorage.Update(storage)
 
+		if err := existingStorage.Ownership.ValidateUpdate(
+			previousOwnership,
+			workspaceID,
+			s.workspaceService,
+		); err != nil {
+			return err
+		}
+
 		if err := existingStorage.EncryptSensitiveData(s.fieldEncryptor); err != nil {
 			return err
 		}
@@ -105,14 +143,19 @@ func (s *StorageService) SaveStorage(
 			return err
 		}
 
-		s.auditLogService.WriteAuditLog(
+		s.auditLogService.WriteChangeAuditLog(
 			fmt.Sprintf("Storage updated: %s", existingStorage.Name),
+			storage.ChangeReason,
 			&user.ID,
 			&workspaceID,
 		)
 	} else {
 		storage.WorkspaceID = workspaceID
 
+		if err := storage.Ownership.Validate(workspaceID, s.workspaceService); err != nil {
+			return err
+		}
+
 		if err := storage.EncryptSensitiveData(s.fieldEncryptor); err != nil {
 			return err
 		}
@@ -166,6 +209,16 @@ func (s *StorageService) DeleteStorage(
 		return ErrStorageHasAttachedDatabases
 	}
 
+	if s.prodReferenceChecker != nil {
+		isReferencedByProd, err := s.prodReferenceChecker.IsStorageReferencedByProd(storage.ID)
+		if err != nil {
+			return err
+		}
+		if isReferencedByProd {
+			return ErrStorageReferencedByProd
+		}
+	}
+
 	err = s.storageRepository.Delete(storage)
 	if err != nil {
 		return err
@@ -183,36 +236,45 @@ func (s *StorageService) DeleteStorage(
 func (s *StorageService) GetStorage(
 	user *users_models.User,
 	id uuid.UUID,
-) (*Storage, error) {
+) (*StorageResponse, error) {
 	storage, err := s.storageRepository.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if !storage.IsSystem {
-		canView, _, err := s.workspaceService.CanUserAccessWorkspace(storage.WorkspaceID, user)
-		if err != nil {
-			return nil, err
-		}
-		if !canView {
-			return nil, ErrInsufficientPermissionsToViewStorage
-		}
+	canView, role, err := s.canUserViewStorage(user, storage)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrInsufficientPermissionsToViewStorage
 	}
 
-	storage.HideSensitiveData()
+	s.auditStorageRead(user, storage)
 
-	if storage.IsSystem && user.Role != users_enums.UserRoleAdmin {
-		storage.HideAllData()
+	return ToRedactedStorageResponse(storage, s.getRedactionLevel(user, storage, role)), nil
+}
+
+// auditStorageRead records reads by admins, who see the storage configuration unredacted
+func (s *StorageService) auditStorageRead(user *users_models.User, storage *Storage) {
+	canManage, err := s.workspaceService.CanUserManageWorkspace(storage.WorkspaceID, user)
+	if err != nil || !canManage {
+		return
 	}
 
-	return storage, nil
+	s.auditLogService.WriteReadAuditLog(
+		fmt.Sprintf("Storage viewed: %s", storage.Name),
+		storage.ID,
+		user.ID,
+		&storage.WorkspaceID,
+	)
 }
 
 func (s *StorageService) GetStorages(
 	user *users_models.User,
 	workspaceID uuid.UUID,
-) ([]*Storage, error) {
-	canView, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+) ([]*StorageResponse, error) {
+	canView, role, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
 	if err != nil {
 		return nil, err
 	}
@@ -225,15 +287,13 @@ func (s *StorageService) GetStorages(
 		return nil, err
 	}
 
-	for _, storage := range storages {
-		storage.HideSensitiveData()
+	storages = slices.DeleteFunc(storages, func(storage *Storage) bool {
+		return !storage.IsVisibleToWorkspace(workspaceID)
+	})
 
-		if storage.IsSystem && user.Role != users_enums.UserRoleAdmin {
-			storage.HideAllData()
-		}
-	}
-
-	return storages, nil
+	return ToStorageResponses(storages, func(storage *Storage) users_enums.StorageRedactionLevel {
+		return s.getRedactionLevel(user, storage, role)
+	}), nil
 }
 
 func (s *StorageService) TestStorageConnection(
@@ -269,6 +329,112 @@ func (s *StorageService) TestStorageConnection(
 	return nil
 }
 
+// BenchmarkStorage measures upload and download of a test object. It writes to the storage,
+// so it needs the same permissions as managing it
+func (s *StorageService) BenchmarkStorage(
+	ctx context.Context,
+	user *users_models.User,
+	storageID uuid.UUID,
+	request *BenchmarkStorageRequest,
+) (*StorageBenchmarkResult, error) {
+	sizeMb := request.SizeMb
+	if sizeMb == 0 {
+		sizeMb = defaultBenchmarkSizeMb
+	}
+	if sizeMb < 1 || sizeMb > maxBenchmarkSizeMb {
+		return nil, fmt.Errorf("size must be between 1 and %d MB", maxBenchmarkSizeMb)
+	}
+
+	storage, err := s.storageRepository.FindByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(storage.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrInsufficientPermissionsToBenchmarkStorage
+	}
+
+	if storage.IsSystem && user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrInsufficientPermissionsToBenchmarkStorage
+	}
+
+	result, err := runBenchmark(ctx, storage, s.fieldEncryptor, logger.GetLogger(), sizeMb)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Storage benchmarked: %s with %d MB", storage.Name, sizeMb),
+		&user.ID,
+		&storage.WorkspaceID,
+	)
+
+	return result, nil
+}
+
+// BrowseStorage lists files and folders of the storage, so users can check where backups
+// landed. System and local storages keep files of other workspaces, only admins browse them
+func (s *StorageService) BrowseStorage(
+	ctx context.Context,
+	user *users_models.User,
+	storageID uuid.UUID,
+	browsePath string,
+) (*BrowseStorageResponse, error) {
+	directory, err := files_utils.CleanBrowsePath(browsePath)
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := s.storageRepository.FindByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if storage.IsSystem || storage.Type == StorageTypeLocal {
+		if user.Role != users_enums.UserRoleAdmin {
+			return nil, ErrInsufficientPermissionsToBrowseStorage
+		}
+	} else {
+		canView, _, err := s.workspaceService.CanUserAccessWorkspace(storage.WorkspaceID, user)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, ErrInsufficientPermissionsToBrowseStorage
+		}
+	}
+
+	// one more entry than returned tells whether the listing is truncated
+	entries, err := storage.BrowseDirectory(ctx, s.fieldEncryptor, directory, maxBrowseEntries+1)
+	if err != nil {
+		return nil, err
+	}
+
+	isTruncated := len(entries) > maxBrowseEntries
+	if isTruncated {
+		entries = entries[:maxBrowseEntries]
+	}
+
+	files_utils.SortDirectoryEntries(entries)
+
+	s.auditLogService.WriteReadAuditLog(
+		fmt.Sprintf("Storage browsed: %s at /%s", storage.Name, directory),
+		storage.ID,
+		user.ID,
+		&storage.WorkspaceID,
+	)
+
+	return &BrowseStorageResponse{
+		Path:        directory,
+		Entries:     entries,
+		IsTruncated: isTruncated,
+	}, nil
+}
+
 func (s *StorageService) TestStorageConnectionDirect(
 	user *users_models.User,
 	storage *Storage,
@@ -310,6 +476,46 @@ func (s *StorageService) GetStorageByID(
 	return s.storageRepository.FindByID(id)
 }
 
+func (s *StorageService) GetStoragesByIDs(ids []uuid.UUID) (map[uuid.UUID]*Storage, error) {
+	storages, err := s.storageRepository.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	storagesByID := make(map[uuid.UUID]*Storage, len(storages))
+	for _, storage := range storages {
+		storagesByID[storage.ID] = storage
+	}
+
+	return storagesByID, nil
+}
+
+func (s *StorageService) GetStoragesWithCredentialsExpiringBefore(
+	expireBefore time.Time,
+) ([]*Storage, error) {
+	return s.storageRepository.FindWithCredentialsExpiringBefore(expireBefore)
+}
+
+// GetWorkspaceStorages returns storages of the workspace and system storages visible to it
+func (s *StorageService) GetWorkspaceStorages(workspaceID uuid.UUID) ([]*Storage, error) {
+	storages, err := s.storageRepository.FindByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(storages, func(storage *Storage) bool {
+		return !storage.IsVisibleToWorkspace(workspaceID)
+	}), nil
+}
+
+func (s *StorageService) GetAvailableStoragePlugins() ([]string, error) {
+	return plugin_storage.GetAvailablePlugins()
+}
+
+func (s *StorageService) ShutdownStoragePlugins() {
+	plugin_storage.ShutdownPlugins()
+}
+
 func (s *StorageService) TransferStorageToWorkspace(
 	user *users_models.User,
 	storageID uuid.UUID,
@@ -377,3 +583,76 @@ func (s *StorageService) TransferStorageToWorkspace(
 
 	return nil
 }
+
+// canUserViewStorage lets users see storages of their workspaces and system storages
+// visible to one of them
+// canUserViewStorage also returns the role of the user in the first workspace the storage
+// is visible through, nil for system storages visible to every workspace
+func (s *StorageService) canUserViewStorage(
+	user *users_models.User,
+	storage *Storage,
+) (bool, *users_enums.WorkspaceRole, error) {
+	if storage.IsSystem && len(storage.VisibleWorkspaceIDs) == 0 {
+		return true, nil, nil
+	}
+
+	workspaceIDs := []uuid.UUID{storage.WorkspaceID}
+	if storage.IsSystem {
+		workspaceIDs = append(workspaceIDs, storage.VisibleWorkspaceIDs...)
+	}
+
+	for _, workspaceID := range workspaceIDs {
+		canView, role, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+		if err != nil {
+			return false, nil, err
+		}
+		if canView {
+			return true, role, nil
+		}
+	}
+
+	return false, nil, nil
+}
+
+// validateVisibleWorkspaces rejects unknown workspaces, so a typo does not hide a system
+// storage from everyone
+func (s *StorageService) validateVisibleWorkspaces(storage *Storage) error {
+	if len(storage.VisibleWorkspaceIDs) == 0 {
+		return nil
+	}
+
+	if !storage.IsSystem {
+		return ErrVisibleWorkspacesOnlyForSystemStorage
+	}
+
+	for _, workspaceID := range storage.VisibleWorkspaceIDs {
+		if _, err := s.workspaceService.GetWorkspaceByID(workspaceID); err != nil {
+			return fmt.Errorf("visible workspace %s not found: %w", workspaceID, err)
+		}
+	}
+
+	return nil
+}
+
+// System storages are shared with visible workspaces, settings are shown to admins and to
+// workspace roles allowed by the redaction levels of users settings
+func (s *StorageService) getRedactionLevel(
+	user *users_models.User,
+	storage *Storage,
+	role *users_enums.WorkspaceRole,
+) users_enums.StorageRedactionLevel {
+	if !storage.IsSystem || user.Role == users_enums.UserRoleAdmin {
+		return users_enums.StorageRedactionLevelNone
+	}
+
+	if role == nil {
+		return users_enums.StorageRedactionLevelFull
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return users_enums.StorageRedactionLevelFull
+	}
+
+	return settings.GetSystemStorageRedactionLevel(*role)
+}
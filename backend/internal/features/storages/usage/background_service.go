@@ -0,0 +1,54 @@
+package storages_usage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// usageMeasureInterval keeps usage of storages fresh when backups are removed by retention,
+// completed backups measure their storage right away
+const usageMeasureInterval = 15 * time.Minute
+
+type StorageUsageBackgroundService struct {
+	storageUsageService *StorageUsageService
+	logger              *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (s *StorageUsageBackgroundService) Run(ctx context.Context) {
+	wasAlreadyRun := s.hasRun.Load()
+
+	s.runOnce.Do(func() {
+		s.hasRun.Store(true)
+
+		s.logger.Info("Starting storage usage background service")
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(usageMeasureInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.storageUsageService.MeasureAllStorages(); err != nil {
+					s.logger.Error("Failed to measure storages usage", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", s))
+	}
+}
@@ -0,0 +1,104 @@
+package storages_usage
+
+import (
+	"errors"
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StorageUsageController struct {
+	storageUsageService *StorageUsageService
+}
+
+func (c *StorageUsageController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/storages/:id/usage", c.GetStorageUsage)
+	router.PUT("/storages/:id/quota", c.UpdateStorageQuota)
+}
+
+// GetStorageUsage
+// @Summary Get storage usage
+// @Description Get bytes consumed by completed backups of the storage in total and per database, with the quota state
+// @Tags storages
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Storage ID"
+// @Success 200 {object} GetStorageUsageResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/{id}/usage [get]
+func (c *StorageUsageController) GetStorageUsage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	usage, err := c.storageUsageService.GetStorageUsage(user, id)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToViewUsage) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, usage)
+}
+
+// UpdateStorageQuota
+// @Summary Update storage quota
+// @Description Set soft and hard limits of bytes used by the storage, zero disables a limit. Reaching the hard limit blocks new backups to the storage
+// @Tags storages
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "JWT token"
+// @Param id path string true "Storage ID"
+// @Param request body UpdateStorageQuotaRequest true "Quota limits"
+// @Success 200 {object} StorageQuota
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /storages/{id}/quota [put]
+func (c *StorageUsageController) UpdateStorageQuota(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	var request UpdateStorageQuotaRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quota, err := c.storageUsageService.UpdateStorageQuota(user, id, &request)
+	if err != nil {
+		if errors.Is(err, ErrInsufficientPermissionsToManageQuota) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quota)
+}
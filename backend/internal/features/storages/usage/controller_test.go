@@ -0,0 +1,136 @@
+package storages_usage
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/features/backups/backups"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+)
+
+func createTestRouter() *gin.Engine {
+	return workspaces_testing.CreateTestRouter(
+		workspaces_controllers.GetWorkspaceController(),
+		workspaces_controllers.GetMembershipController(),
+		GetStorageUsageController(),
+	)
+}
+
+func Test_GetStorageUsage_WithBackups_ReportsUsagePerDatabase(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	backups.CreateTestBackup(database.ID, storage.ID)
+	backups.CreateTestBackup(database.ID, storage.ID)
+
+	var usage GetStorageUsageResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/usage",
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&usage,
+	)
+
+	assert.Equal(t, storage.ID, usage.StorageID)
+	assert.Equal(t, int64(2), usage.BackupsCount)
+	assert.Equal(t, int64(21*1024*1024), usage.BytesUsed)
+	assert.Len(t, usage.Databases, 1)
+	assert.Equal(t, database.ID, usage.Databases[0].DatabaseID)
+	assert.Equal(t, QuotaStateOk, usage.QuotaState)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/usage",
+		"Bearer "+outsider.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_UpdateStorageQuota_WhenHardLimitExceeded_BlocksNewBackups(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	defer workspaces_testing.RemoveTestWorkspace(workspace, router)
+
+	storage := storages.CreateTestStorage(workspace.ID)
+	defer storages.RemoveTestStorage(storage.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	defer notifiers.RemoveTestNotifier(notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	defer databases.RemoveTestDatabase(database)
+
+	backupConfig, err := backups_config.GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	backupConfig.Storage = storage
+	backupConfig.StorageID = &storage.ID
+
+	_, err = backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	backups.CreateTestBackup(database.ID, storage.ID)
+
+	var quota StorageQuota
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/quota",
+		"Bearer "+owner.Token,
+		UpdateStorageQuotaRequest{SoftLimitBytes: 1024, HardLimitBytes: 2048},
+		http.StatusOK,
+		&quota,
+	)
+
+	assert.Equal(t, QuotaStateHardExceeded, quota.State)
+	assert.ErrorIs(
+		t,
+		GetStorageUsageService().CheckCanStartBackup(database.ID),
+		ErrStorageHardQuotaExceeded,
+	)
+
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/quota",
+		"Bearer "+owner.Token,
+		UpdateStorageQuotaRequest{},
+		http.StatusOK,
+		&quota,
+	)
+
+	assert.Equal(t, QuotaStateOk, quota.State)
+	assert.NoError(t, GetStorageUsageService().CheckCanStartBackup(database.ID))
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/storages/"+storage.ID.String()+"/quota",
+		"Bearer "+owner.Token,
+		UpdateStorageQuotaRequest{SoftLimitBytes: 4096, HardLimitBytes: 2048},
+		http.StatusBadRequest,
+	)
+}
@@ -0,0 +1,66 @@
+package storages_usage
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var storageUsageRepository = &StorageUsageRepository{}
+var storageUsageService = &StorageUsageService{
+	storageUsageRepository,
+	storages.GetStorageService(),
+	databases.GetDatabaseService(),
+	backups_config.GetBackupConfigService(),
+	notifiers.GetNotifierService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var storageUsageController = &StorageUsageController{
+	storageUsageService,
+}
+var storageUsageBackgroundService = &StorageUsageBackgroundService{
+	storageUsageService: storageUsageService,
+	logger:              logger.GetLogger(),
+}
+
+func GetStorageUsageService() *StorageUsageService {
+	return storageUsageService
+}
+
+func GetStorageUsageController() *StorageUsageController {
+	return storageUsageController
+}
+
+func GetStorageUsageBackgroundService() *StorageUsageBackgroundService {
+	return storageUsageBackgroundService
+}
+
+var (
+	setupOnce sync.Once
+	isSetup   atomic.Bool
+)
+
+func SetupDependencies() {
+	wasAlreadySetup := isSetup.Load()
+
+	setupOnce.Do(func() {
+		backuping.GetBackupsScheduler().AddBackupQuotaChecker(storageUsageService)
+		backuping.GetBackuperNode().AddBackupCompletedListener(storageUsageService)
+
+		isSetup.Store(true)
+	})
+
+	if wasAlreadySetup {
+		logger.GetLogger().Warn("SetupDependencies called multiple times, ignoring subsequent call")
+	}
+}
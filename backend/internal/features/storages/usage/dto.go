@@ -0,0 +1,32 @@
+package storages_usage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UpdateStorageQuotaRequest struct {
+	SoftLimitBytes int64 `json:"softLimitBytes"`
+	HardLimitBytes int64 `json:"hardLimitBytes"`
+}
+
+type DatabaseUsageDTO struct {
+	DatabaseID   uuid.UUID `json:"databaseId"`
+	DatabaseName string    `json:"databaseName"`
+	BytesUsed    int64     `json:"bytesUsed"`
+	BackupsCount int64     `json:"backupsCount"`
+}
+
+// GetStorageUsageResponse is the latest measurement of the storage. Quota is empty when no
+// limits were configured
+type GetStorageUsageResponse struct {
+	StorageID    uuid.UUID          `json:"storageId"`
+	StorageName  string             `json:"storageName"`
+	BytesUsed    int64              `json:"bytesUsed"`
+	BackupsCount int64              `json:"backupsCount"`
+	MeasuredAt   time.Time          `json:"measuredAt"`
+	Databases    []DatabaseUsageDTO `json:"databases"`
+	Quota        *StorageQuota      `json:"quota,omitempty"`
+	QuotaState   QuotaState         `json:"quotaState"`
+}
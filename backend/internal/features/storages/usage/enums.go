@@ -0,0 +1,20 @@
+package storages_usage
+
+type QuotaState string
+
+const (
+	QuotaStateOk           QuotaState = "OK"
+	QuotaStateSoftExceeded QuotaState = "SOFT_EXCEEDED"
+	QuotaStateHardExceeded QuotaState = "HARD_EXCEEDED"
+)
+
+func (s QuotaState) severity() int {
+	switch s {
+	case QuotaStateSoftExceeded:
+		return 1
+	case QuotaStateHardExceeded:
+		return 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,18 @@
+package storages_usage
+
+import "errors"
+
+var (
+	ErrInsufficientPermissionsToViewUsage = errors.New(
+		"insufficient permissions to view storage usage",
+	)
+	ErrInsufficientPermissionsToManageQuota = errors.New(
+		"insufficient permissions to manage storage quota",
+	)
+	ErrInvalidQuotaLimits = errors.New(
+		"quota limits must not be negative and the soft limit must not exceed the hard limit",
+	)
+	ErrStorageHardQuotaExceeded = errors.New(
+		"hard quota of the storage is exceeded, free up space or raise the quota",
+	)
+)
@@ -0,0 +1,58 @@
+package storages_usage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StorageUsage is the latest measurement of bytes consumed by completed backups of a storage
+type StorageUsage struct {
+	StorageID    uuid.UUID `json:"storageId"    gorm:"column:storage_id;type:uuid;primaryKey"`
+	BytesUsed    int64     `json:"bytesUsed"    gorm:"column:bytes_used;not null;default:0"`
+	BackupsCount int64     `json:"backupsCount" gorm:"column:backups_count;not null;default:0"`
+	MeasuredAt   time.Time `json:"measuredAt"   gorm:"column:measured_at;not null"`
+}
+
+func (StorageUsage) TableName() string {
+	return "storage_usages"
+}
+
+// StorageDatabaseUsage is the share of a single database in the latest measurement
+type StorageDatabaseUsage struct {
+	StorageID    uuid.UUID `json:"storageId"    gorm:"column:storage_id;type:uuid;primaryKey"`
+	DatabaseID   uuid.UUID `json:"databaseId"   gorm:"column:database_id;type:uuid;primaryKey"`
+	BytesUsed    int64     `json:"bytesUsed"    gorm:"column:bytes_used;not null;default:0"`
+	BackupsCount int64     `json:"backupsCount" gorm:"column:backups_count;not null;default:0"`
+}
+
+func (StorageDatabaseUsage) TableName() string {
+	return "storage_database_usages"
+}
+
+// StorageQuota limits bytes used by a storage, zero disables a limit. Reaching the soft
+// limit only notifies, reaching the hard limit also blocks new backups to the storage.
+// State is the last evaluated state, so notifications are sent once per transition
+type StorageQuota struct {
+	StorageID      uuid.UUID  `json:"storageId"      gorm:"column:storage_id;type:uuid;primaryKey"`
+	SoftLimitBytes int64      `json:"softLimitBytes" gorm:"column:soft_limit_bytes;not null;default:0"`
+	HardLimitBytes int64      `json:"hardLimitBytes" gorm:"column:hard_limit_bytes;not null;default:0"`
+	State          QuotaState `json:"state"          gorm:"column:state;type:text;not null"`
+	UpdatedAt      time.Time  `json:"updatedAt"      gorm:"column:updated_at;not null"`
+}
+
+func (StorageQuota) TableName() string {
+	return "storage_quotas"
+}
+
+func (q *StorageQuota) GetState(bytesUsed int64) QuotaState {
+	if q.HardLimitBytes > 0 && bytesUsed >= q.HardLimitBytes {
+		return QuotaStateHardExceeded
+	}
+
+	if q.SoftLimitBytes > 0 && bytesUsed >= q.SoftLimitBytes {
+		return QuotaStateSoftExceeded
+	}
+
+	return QuotaStateOk
+}
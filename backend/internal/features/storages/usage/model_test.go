@@ -0,0 +1,28 @@
+package storages_usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetState_WithLimits_ReturnsMostSevereExceededLimit(t *testing.T) {
+	quota := &StorageQuota{SoftLimitBytes: 100, HardLimitBytes: 200}
+
+	assert.Equal(t, QuotaStateOk, quota.GetState(99))
+	assert.Equal(t, QuotaStateSoftExceeded, quota.GetState(100))
+	assert.Equal(t, QuotaStateHardExceeded, quota.GetState(200))
+}
+
+func Test_GetState_WithDisabledLimits_IsAlwaysOk(t *testing.T) {
+	quota := &StorageQuota{}
+
+	assert.Equal(t, QuotaStateOk, quota.GetState(1<<40))
+}
+
+func Test_GetState_WithOnlyHardLimit_SkipsSoftState(t *testing.T) {
+	quota := &StorageQuota{HardLimitBytes: 200}
+
+	assert.Equal(t, QuotaStateOk, quota.GetState(150))
+	assert.Equal(t, QuotaStateHardExceeded, quota.GetState(250))
+}
@@ -0,0 +1,158 @@
+package storages_usage
+
+import (
+	"errors"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StorageUsageRepository struct{}
+
+// CalculateDatabaseUsages aggregates completed backups of the storage per database
+func (r *StorageUsageRepository) CalculateDatabaseUsages(
+	storageID uuid.UUID,
+) ([]*StorageDatabaseUsage, error) {
+	var usages = make([]*StorageDatabaseUsage, 0)
+
+	sql := `
+		SELECT
+			storage_id,
+			database_id,
+			CAST(COALESCE(SUM(backup_size_mb), 0) * 1048576 AS BIGINT) AS bytes_used,
+			COUNT(id) AS backups_count
+		FROM backups
+		WHERE storage_id = ? AND status = ?
+		GROUP BY storage_id, database_id`
+
+	err := storage.GetDb().
+		Raw(sql, storageID, backups_core.BackupStatusCompleted).
+		Scan(&usages).
+		Error
+
+	return usages, err
+}
+
+// SaveUsage replaces the measurement of the storage, databases without backups left
+// are removed from it
+func (r *StorageUsageRepository) SaveUsage(
+	usage *StorageUsage,
+	databaseUsages []*StorageDatabaseUsage,
+) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "storage_id"}},
+				DoUpdates: clause.AssignmentColumns(
+					[]string{"bytes_used", "backups_count", "measured_at"},
+				),
+			}).
+			Create(usage).
+			Error; err != nil {
+			return err
+		}
+
+		if err := tx.
+			Where("storage_id = ?", usage.StorageID).
+			Delete(&StorageDatabaseUsage{}).
+			Error; err != nil {
+			return err
+		}
+
+		if len(databaseUsages) == 0 {
+			return nil
+		}
+
+		return tx.Create(databaseUsages).Error
+	})
+}
+
+func (r *StorageUsageRepository) FindUsageByStorageID(storageID uuid.UUID) (*StorageUsage, error) {
+	var usage StorageUsage
+
+	err := storage.GetDb().Where("storage_id = ?", storageID).First(&usage).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+func (r *StorageUsageRepository) FindDatabaseUsagesByStorageID(
+	storageID uuid.UUID,
+) ([]*StorageDatabaseUsage, error) {
+	var usages = make([]*StorageDatabaseUsage, 0)
+
+	err := storage.GetDb().
+		Where("storage_id = ?", storageID).
+		Order("bytes_used DESC").
+		Find(&usages).
+		Error
+
+	return usages, err
+}
+
+func (r *StorageUsageRepository) FindMeasuredStorageIDs() ([]uuid.UUID, error) {
+	var storageIDs = make([]uuid.UUID, 0)
+
+	err := storage.GetDb().Model(&StorageUsage{}).Pluck("storage_id", &storageIDs).Error
+
+	return storageIDs, err
+}
+
+func (r *StorageUsageRepository) SaveQuota(quota *StorageQuota) error {
+	return storage.GetDb().Save(quota).Error
+}
+
+func (r *StorageUsageRepository) FindQuotaByStorageID(storageID uuid.UUID) (*StorageQuota, error) {
+	var quota StorageQuota
+
+	err := storage.GetDb().Where("storage_id = ?", storageID).First(&quota).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &quota, nil
+}
+
+func (r *StorageUsageRepository) FindAllQuotas() ([]*StorageQuota, error) {
+	var quotas = make([]*StorageQuota, 0)
+
+	err := storage.GetDb().Find(&quotas).Error
+
+	return quotas, err
+}
+
+func (r *StorageUsageRepository) UpdateQuotaState(storageID uuid.UUID, state QuotaState) error {
+	return storage.GetDb().
+		Model(&StorageQuota{}).
+		Where("storage_id = ?", storageID).
+		Update("state", state).
+		Error
+}
+
+// FindStorageIDsWithBackups returns storages holding at least one completed backup
+func (r *StorageUsageRepository) FindStorageIDsWithBackups() ([]uuid.UUID, error) {
+	var storageIDs = make([]uuid.UUID, 0)
+
+	err := storage.GetDb().
+		Table("backups").
+		Where("status = ?", backups_core.BackupStatusCompleted).
+		Distinct("storage_id").
+		Pluck("storage_id", &storageIDs).
+		Error
+
+	return storageIDs, err
+}
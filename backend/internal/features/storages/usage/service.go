@@ -0,0 +1,370 @@
+package storages_usage
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/i18n"
+
+	"github.com/google/uuid"
+)
+
+type StorageUsageService struct {
+	storageUsageRepository *StorageUsageRepository
+	storageService         *storages.StorageService
+	databaseService        *databases.DatabaseService
+	backupConfigService    *backups_config.BackupConfigService
+	notifierService        *notifiers.NotifierService
+	workspaceService       *workspaces_services.WorkspaceService
+	auditLogService        *audit_logs.AuditLogService
+	logger                 *slog.Logger
+}
+
+// GetStorageUsage returns the latest measurement of the storage. Usage of system storages
+// lists databases of other workspaces, so only admins see it
+func (s *StorageUsageService) GetStorageUsage(
+	user *users_models.User,
+	storageID uuid.UUID,
+) (*GetStorageUsageResponse, error) {
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if storage.IsSystem {
+		if user.Role != users_enums.UserRoleAdmin {
+			return nil, ErrInsufficientPermissionsToViewUsage
+		}
+	} else {
+		canView, _, err := s.workspaceService.CanUserAccessWorkspace(storage.WorkspaceID, user)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, ErrInsufficientPermissionsToViewUsage
+		}
+	}
+
+	usage, err := s.storageUsageRepository.FindUsageByStorageID(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// storages are measured periodically, the first request does not wait for the next run
+	if usage == nil {
+		if usage, err = s.MeasureStorage(storage.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	databaseUsages, err := s.storageUsageRepository.FindDatabaseUsagesByStorageID(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota, err := s.storageUsageRepository.FindQuotaByStorageID(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStorageUsageResponse{
+		StorageID:    storage.ID,
+		StorageName:  storage.Name,
+		BytesUsed:    usage.BytesUsed,
+		BackupsCount: usage.BackupsCount,
+		MeasuredAt:   usage.MeasuredAt,
+		Databases:    make([]DatabaseUsageDTO, 0, len(databaseUsages)),
+		Quota:        quota,
+		QuotaState:   QuotaStateOk,
+	}
+
+	if quota != nil {
+		response.QuotaState = quota.GetState(usage.BytesUsed)
+	}
+
+	for _, databaseUsage := range databaseUsages {
+		database, err := s.databaseService.GetDatabaseByID(databaseUsage.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Databases = append(response.Databases, DatabaseUsageDTO{
+			DatabaseID:   database.ID,
+			DatabaseName: database.Name,
+			BytesUsed:    databaseUsage.BytesUsed,
+			BackupsCount: databaseUsage.BackupsCount,
+		})
+	}
+
+	return response, nil
+}
+
+// UpdateStorageQuota sets limits of the storage, zero disables a limit. It is available to
+// the users allowed to manage the storage
+func (s *StorageUsageService) UpdateStorageQuota(
+	user *users_models.User,
+	storageID uuid.UUID,
+	request *UpdateStorageQuotaRequest,
+) (*StorageQuota, error) {
+	if request.SoftLimitBytes < 0 || request.HardLimitBytes < 0 {
+		return nil, ErrInvalidQuotaLimits
+	}
+
+	if request.HardLimitBytes > 0 && request.SoftLimitBytes > request.HardLimitBytes {
+		return nil, ErrInvalidQuotaLimits
+	}
+
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(storage.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage || (storage.IsSystem && user.Role != users_enums.UserRoleAdmin) {
+		return nil, ErrInsufficientPermissionsToManageQuota
+	}
+
+	quota, err := s.storageUsageRepository.FindQuotaByStorageID(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quota == nil {
+		quota = &StorageQuota{StorageID: storage.ID, State: QuotaStateOk}
+	}
+
+	quota.SoftLimitBytes = request.SoftLimitBytes
+	quota.HardLimitBytes = request.HardLimitBytes
+	quota.UpdatedAt = time.Now().UTC()
+
+	if err := s.storageUsageRepository.SaveQuota(quota); err != nil {
+		return nil, err
+	}
+
+	usage, err := s.MeasureStorage(storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota.State = quota.GetState(usage.BytesUsed)
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Storage quota updated: %s (soft %s, hard %s)",
+			storage.Name,
+			formatBytes(quota.SoftLimitBytes),
+			formatBytes(quota.HardLimitBytes),
+		),
+		&user.ID,
+		&storage.WorkspaceID,
+	)
+
+	return quota, nil
+}
+
+// MeasureAllStorages measures storages holding backups and storages measured before, so
+// usage of emptied storages drops to zero
+func (s *StorageUsageService) MeasureAllStorages() error {
+	storageIDs, err := s.storageUsageRepository.FindStorageIDsWithBackups()
+	if err != nil {
+		return err
+	}
+
+	measuredStorageIDs, err := s.storageUsageRepository.FindMeasuredStorageIDs()
+	if err != nil {
+		return err
+	}
+
+	seenStorageIDs := make(map[uuid.UUID]bool, len(storageIDs)+len(measuredStorageIDs))
+	for _, storageID := range append(storageIDs, measuredStorageIDs...) {
+		if seenStorageIDs[storageID] {
+			continue
+		}
+		seenStorageIDs[storageID] = true
+
+		if _, err := s.MeasureStorage(storageID); err != nil {
+			s.logger.Error("Failed to measure storage usage", "storageId", storageID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// MeasureStorage aggregates completed backups of the storage and evaluates its quota,
+// notifying once the quota state gets worse
+func (s *StorageUsageService) MeasureStorage(storageID uuid.UUID) (*StorageUsage, error) {
+	databaseUsages, err := s.storageUsageRepository.CalculateDatabaseUsages(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &StorageUsage{
+		StorageID:  storageID,
+		MeasuredAt: time.Now().UTC(),
+	}
+
+	for _, databaseUsage := range databaseUsages {
+		usage.BytesUsed += databaseUsage.BytesUsed
+		usage.BackupsCount += databaseUsage.BackupsCount
+	}
+
+	if err := s.storageUsageRepository.SaveUsage(usage, databaseUsages); err != nil {
+		return nil, err
+	}
+
+	quota, err := s.storageUsageRepository.FindQuotaByStorageID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quota != nil {
+		s.evaluateQuota(quota, usage)
+	}
+
+	return usage, nil
+}
+
+// CheckCanStartBackup blocks backups to storages over their hard quota. The state is the
+// one of the latest measurement, storages are measured again after each backup
+func (s *StorageUsageService) CheckCanStartBackup(databaseID uuid.UUID) error {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return err
+	}
+
+	if backupConfig.StorageID == nil {
+		return nil
+	}
+
+	quota, err := s.storageUsageRepository.FindQuotaByStorageID(*backupConfig.StorageID)
+	if err != nil {
+		return err
+	}
+
+	if quota != nil && quota.State == QuotaStateHardExceeded {
+		return ErrStorageHardQuotaExceeded
+	}
+
+	return nil
+}
+
+func (s *StorageUsageService) OnBackupCompleted(backup *backups_core.Backup) {
+	if _, err := s.MeasureStorage(backup.StorageID); err != nil {
+		s.logger.Error(
+			"Failed to measure storage usage after backup",
+			"storageId", backup.StorageID,
+			"error", err,
+		)
+	}
+}
+
+func (s *StorageUsageService) evaluateQuota(quota *StorageQuota, usage *StorageUsage) {
+	state := quota.GetState(usage.BytesUsed)
+	if state == quota.State {
+		return
+	}
+
+	if err := s.storageUsageRepository.UpdateQuotaState(quota.StorageID, state); err != nil {
+		s.logger.Error(
+			"Failed to update storage quota state",
+			"storageId", quota.StorageID,
+			"error", err,
+		)
+		return
+	}
+
+	previousState := quota.State
+	quota.State = state
+
+	// recovering below a limit is not worth a notification
+	if state.severity() <= previousState.severity() {
+		return
+	}
+
+	s.notifyQuotaExceeded(quota, usage)
+}
+
+func (s *StorageUsageService) notifyQuotaExceeded(quota *StorageQuota, usage *StorageUsage) {
+	storage, err := s.storageService.GetStorageByID(quota.StorageID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get storage for quota notification",
+			"storageId", quota.StorageID,
+			"error", err,
+		)
+		return
+	}
+
+	defaultNotifiers, err := s.notifierService.GetWorkspaceDefaultNotifiers(storage.WorkspaceID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get default notifiers for quota notification",
+			"storageId", storage.ID,
+			"error", err,
+		)
+		return
+	}
+
+	if len(defaultNotifiers) == 0 {
+		return
+	}
+
+	workspace, err := s.workspaceService.GetWorkspaceByID(storage.WorkspaceID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get workspace for quota notification",
+			"storageId", storage.ID,
+			"error", err,
+		)
+		return
+	}
+
+	titleKey := i18n.MessageStorageQuotaSoftExceededTitle
+	messageKey := i18n.MessageStorageQuotaSoftExceededMessage
+	eventType := notifiers.NotificationEventStorageQuotaSoftExceeded
+	limitBytes := quota.SoftLimitBytes
+	if quota.State == QuotaStateHardExceeded {
+		titleKey = i18n.MessageStorageQuotaHardExceededTitle
+		messageKey = i18n.MessageStorageQuotaHardExceededMessage
+		eventType = notifiers.NotificationEventStorageQuotaHardExceeded
+		limitBytes = quota.HardLimitBytes
+	}
+
+	params := map[string]string{
+		"storage":   storage.Name,
+		"workspace": workspace.Name,
+		"used":      formatBytes(usage.BytesUsed),
+		"limit":     formatBytes(limitBytes),
+	}
+
+	for _, notifier := range defaultNotifiers {
+		s.notifierService.SendEventNotification(
+			notifier,
+			eventType,
+			i18n.Translate(notifier.Locale, titleKey, params),
+			i18n.Translate(notifier.Locale, messageKey, params),
+		)
+	}
+}
+
+func formatBytes(bytes int64) string {
+	sizeMb := float64(bytes) / (1024 * 1024)
+	if sizeMb < 1024 {
+		return fmt.Sprintf("%.2f MB", sizeMb)
+	}
+
+	return fmt.Sprintf("%.2f GB", sizeMb/1024)
+}
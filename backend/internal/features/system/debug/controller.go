@@ -0,0 +1,296 @@
+package system_debug
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"databasus-backend/internal/config"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DebugController struct {
+	debugService *DebugService
+}
+
+func (c *DebugController) RegisterRoutes(router *gin.RouterGroup) {
+	adminOnly := users_middleware.RequireRole(users_enums.UserRoleAdmin)
+
+	debug := router.Group("/system/debug", c.requireLocalhostIfConfigured, adminOnly)
+	debug.GET("/runtime", c.GetRuntimeStats)
+	debug.POST("/gc", c.RunGC)
+	debug.GET("/profiles", c.GetProfiles)
+	debug.GET("/profiles/:name", c.GetProfile)
+	debug.GET("/goroutines", c.GetGoroutines)
+	debug.GET("/cpu", c.GetCPUProfile)
+	debug.GET("/trace", c.GetTrace)
+}
+
+// GetRuntimeStats
+// @Summary Get runtime stats
+// @Description Get memory, GC and goroutine stats of the node serving the request
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} RuntimeStatsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/debug/runtime [get]
+func (c *DebugController) GetRuntimeStats(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.debugService.GetRuntimeStats(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RunGC
+// @Summary Force garbage collection
+// @Description Run GC, return freed memory to the OS and get runtime stats after it
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} RuntimeStatsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/debug/gc [post]
+func (c *DebugController) RunGC(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.debugService.RunGC(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetProfiles
+// @Summary List runtime profiles
+// @Description List pprof profiles available for download
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ProfilesResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/debug/profiles [get]
+func (c *DebugController) GetProfiles(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.debugService.GetProfiles(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetProfile
+// @Summary Download runtime profile
+// @Description Download a pprof profile for `go tool pprof`, or as text when debug is above 0
+// @Tags system
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param name path string true "Profile name, e.g. heap, allocs, goroutine, block, mutex"
+// @Param debug query int false "Text output level"
+// @Param gc query bool false "Run GC before heap profile"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /system/debug/profiles/{name} [get]
+func (c *DebugController) GetProfile(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request GetProfileRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := ctx.Param("name")
+	c.setProfileHeaders(ctx, name, request.Debug > 0)
+
+	err := c.debugService.WriteProfile(user, name, &request, ctx.Writer)
+	if err != nil {
+		c.handleStreamError(ctx, err)
+	}
+}
+
+// GetGoroutines
+// @Summary Dump goroutines
+// @Description Get stack traces of all goroutines in text format
+// @Tags system
+// @Produce plain
+// @Security BearerAuth
+// @Success 200 {string} string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/debug/goroutines [get]
+func (c *DebugController) GetGoroutines(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	c.setProfileHeaders(ctx, "goroutine", true)
+
+	// debug=2 prints full stacks in the same format as an unrecovered panic
+	err := c.debugService.WriteProfile(
+		user,
+		"goroutine",
+		&GetProfileRequest{Debug: 2},
+		ctx.Writer,
+	)
+	if err != nil {
+		c.handleStreamError(ctx, err)
+	}
+}
+
+// GetCPUProfile
+// @Summary Capture CPU profile
+// @Description Profile CPU for the given number of seconds (30 by default, 120 max)
+// @Tags system
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param seconds query int false "Profile duration in seconds"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/debug/cpu [get]
+func (c *DebugController) GetCPUProfile(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CaptureRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.setProfileHeaders(ctx, "cpu", false)
+
+	err := c.debugService.WriteCPUProfile(ctx.Request.Context(), user, &request, ctx.Writer)
+	if err != nil {
+		c.handleStreamError(ctx, err)
+	}
+}
+
+// GetTrace
+// @Summary Capture execution trace
+// @Description Capture execution trace for `go tool trace` (5 seconds by default, 120 max)
+// @Tags system
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param seconds query int false "Trace duration in seconds"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/debug/trace [get]
+func (c *DebugController) GetTrace(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CaptureRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.setProfileHeaders(ctx, "trace", false)
+
+	err := c.debugService.WriteTrace(ctx.Request.Context(), user, &request, ctx.Writer)
+	if err != nil {
+		c.handleStreamError(ctx, err)
+	}
+}
+
+// requireLocalhostIfConfigured hides the group from remote clients when only local access
+// is allowed. ClientIP follows forwarding headers of TRUSTED_PROXIES only, so clients
+// behind a local reverse proxy are not mistaken for loopback ones
+func (c *DebugController) requireLocalhostIfConfigured(ctx *gin.Context) {
+	if !config.GetEnv().IsDebugEndpointsLocalhostOnly {
+		ctx.Next()
+		return
+	}
+
+	clientIP := net.ParseIP(ctx.ClientIP())
+	if clientIP == nil || !clientIP.IsLoopback() {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Not found"})
+		return
+	}
+
+	ctx.Next()
+}
+
+func (c *DebugController) setProfileHeaders(ctx *gin.Context, name string, isText bool) {
+	if isText {
+		ctx.Header("Content-Type", "text/plain; charset=utf-8")
+		return
+	}
+
+	ctx.Header("Content-Type", "application/octet-stream")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.pprof\"", name))
+}
+
+// Profile headers are set before writing, so they are dropped when the error happens
+// before the first byte and the error can still be sent as JSON
+func (c *DebugController) handleStreamError(ctx *gin.Context, err error) {
+	if ctx.Writer.Written() {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.Writer.Header().Del("Content-Disposition")
+
+	c.handleError(ctx, err)
+}
+
+func (c *DebugController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrOnlyAdminsCanUseDebugEndpoints):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrProfileNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
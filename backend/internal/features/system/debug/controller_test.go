@@ -0,0 +1,73 @@
+package system_debug
+
+import (
+	"net/http"
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetRuntimeStats_WhenAdminRequests_StatsReturned(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetDebugController())
+
+	var response RuntimeStatsResponse
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/debug/runtime",
+		"Bearer "+admin.Token,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.NotEmpty(t, response.GoVersion)
+	assert.Positive(t, response.NumGoroutines)
+	assert.Positive(t, response.Memory.HeapAllocBytes)
+}
+
+func Test_GetRuntimeStats_WhenMemberRequests_ReturnsForbidden(t *testing.T) {
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetDebugController())
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/debug/runtime",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+}
+
+func Test_GetProfile_WhenProfileExists_ProfileReturned(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetDebugController())
+
+	response := test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/debug/profiles/goroutine?debug=1",
+		"Bearer "+admin.Token,
+		http.StatusOK,
+	)
+
+	assert.Contains(t, string(response.Body), "goroutine profile")
+}
+
+func Test_GetProfile_WhenProfileDoesNotExist_ReturnsNotFound(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetDebugController())
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/debug/profiles/unknown",
+		"Bearer "+admin.Token,
+		http.StatusNotFound,
+	)
+}
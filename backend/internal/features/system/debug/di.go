@@ -0,0 +1,21 @@
+package system_debug
+
+import (
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/util/logger"
+)
+
+var debugService = &DebugService{
+	audit_logs.GetAuditLogService(),
+	time.Now().UTC(),
+	logger.GetLogger(),
+}
+var debugController = &DebugController{
+	debugService,
+}
+
+func GetDebugController() *DebugController {
+	return debugController
+}
@@ -0,0 +1,53 @@
+package system_debug
+
+import "time"
+
+type RuntimeStatsResponse struct {
+	GoVersion     string      `json:"goVersion"`
+	Hostname      string      `json:"hostname"`
+	StartedAt     time.Time   `json:"startedAt"`
+	UptimeSeconds int64       `json:"uptimeSeconds"`
+	NumCPU        int         `json:"numCpu"`
+	GoMaxProcs    int         `json:"goMaxProcs"`
+	NumGoroutines int         `json:"numGoroutines"`
+	Memory        MemoryStats `json:"memory"`
+	GC            GCStats     `json:"gc"`
+}
+
+type MemoryStats struct {
+	HeapAllocBytes    uint64 `json:"heapAllocBytes"`
+	HeapInuseBytes    uint64 `json:"heapInuseBytes"`
+	HeapIdleBytes     uint64 `json:"heapIdleBytes"`
+	HeapReleasedBytes uint64 `json:"heapReleasedBytes"`
+	HeapObjects       uint64 `json:"heapObjects"`
+	StackInuseBytes   uint64 `json:"stackInuseBytes"`
+	SysBytes          uint64 `json:"sysBytes"`
+	TotalAllocBytes   uint64 `json:"totalAllocBytes"`
+}
+
+type GCStats struct {
+	NumGC        uint32     `json:"numGc"`
+	NextGCBytes  uint64     `json:"nextGcBytes"`
+	PauseTotalMs float64    `json:"pauseTotalMs"`
+	LastPauseMs  float64    `json:"lastPauseMs"`
+	LastGCAt     *time.Time `json:"lastGcAt"`
+	CPUFraction  float64    `json:"cpuFraction"`
+}
+
+type ProfilesResponse struct {
+	Profiles []ProfileInfo `json:"profiles"`
+}
+
+type ProfileInfo struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type GetProfileRequest struct {
+	Debug   int  `form:"debug"`
+	IsRunGC bool `form:"gc"`
+}
+
+type CaptureRequest struct {
+	Seconds int `form:"seconds"`
+}
@@ -0,0 +1,8 @@
+package system_debug
+
+import "errors"
+
+var (
+	ErrOnlyAdminsCanUseDebugEndpoints = errors.New("only administrators can use debug endpoints")
+	ErrProfileNotFound                = errors.New("profile not found")
+)
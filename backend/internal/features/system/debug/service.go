@@ -0,0 +1,219 @@
+package system_debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	runtime_debug "runtime/debug"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+)
+
+const (
+	defaultCPUProfileSeconds = 30
+	defaultTraceSeconds      = 5
+	maxCaptureSeconds        = 120
+)
+
+// DebugService exposes runtime/pprof of the node that serves the request. In a cluster every
+// node has its own profiles, so the request has to reach the node which is investigated
+type DebugService struct {
+	auditLogService *audit_logs.AuditLogService
+	startedAt       time.Time
+	logger          *slog.Logger
+}
+
+func (s *DebugService) GetRuntimeStats(user *users_models.User) (*RuntimeStatsResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanUseDebugEndpoints
+	}
+
+	return s.readRuntimeStats(), nil
+}
+
+// RunGC forces a collection and returns freed memory to the OS. Comparing stats before and
+// after tells whether heap growth is garbage or memory which is really retained
+func (s *DebugService) RunGC(user *users_models.User) (*RuntimeStatsResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanUseDebugEndpoints
+	}
+
+	runtime_debug.FreeOSMemory()
+
+	s.auditLogService.WriteAuditLog("Garbage collection forced via debug endpoint", &user.ID, nil)
+
+	return s.readRuntimeStats(), nil
+}
+
+func (s *DebugService) GetProfiles(user *users_models.User) (*ProfilesResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanUseDebugEndpoints
+	}
+
+	profiles := pprof.Profiles()
+	response := &ProfilesResponse{Profiles: make([]ProfileInfo, 0, len(profiles))}
+
+	for _, profile := range profiles {
+		response.Profiles = append(
+			response.Profiles,
+			ProfileInfo{Name: profile.Name(), Count: profile.Count()},
+		)
+	}
+
+	slices.SortFunc(response.Profiles, func(a, b ProfileInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return response, nil
+}
+
+// WriteProfile writes a named profile (heap, goroutine, allocs, block, mutex, threadcreate).
+// debug=0 gives the binary format for `go tool pprof`, debug>0 gives human readable text
+func (s *DebugService) WriteProfile(
+	user *users_models.User,
+	name string,
+	request *GetProfileRequest,
+	writer io.Writer,
+) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return ErrOnlyAdminsCanUseDebugEndpoints
+	}
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return ErrProfileNotFound
+	}
+
+	if name == "heap" && request.IsRunGC {
+		runtime.GC()
+	}
+
+	if name == "heap" || name == "allocs" {
+		s.auditLogService.WriteAuditLog(
+			fmt.Sprintf("Runtime profile %s downloaded", name),
+			&user.ID,
+			nil,
+		)
+	}
+
+	return profile.WriteTo(writer, request.Debug)
+}
+
+// WriteCPUProfile blocks for the requested duration or until the client disconnects. Only
+// one CPU profile can run in the process at a time, so a parallel request gets an error
+func (s *DebugService) WriteCPUProfile(
+	ctx context.Context,
+	user *users_models.User,
+	request *CaptureRequest,
+	writer io.Writer,
+) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return ErrOnlyAdminsCanUseDebugEndpoints
+	}
+
+	if err := pprof.StartCPUProfile(writer); err != nil {
+		return fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog("CPU profile captured via debug endpoint", &user.ID, nil)
+
+	s.waitCapture(ctx, getCaptureDuration(request.Seconds, defaultCPUProfileSeconds))
+	pprof.StopCPUProfile()
+
+	return nil
+}
+
+func (s *DebugService) WriteTrace(
+	ctx context.Context,
+	user *users_models.User,
+	request *CaptureRequest,
+	writer io.Writer,
+) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return ErrOnlyAdminsCanUseDebugEndpoints
+	}
+
+	if err := trace.Start(writer); err != nil {
+		return fmt.Errorf("failed to start execution trace: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog("Execution trace captured via debug endpoint", &user.ID, nil)
+
+	s.waitCapture(ctx, getCaptureDuration(request.Seconds, defaultTraceSeconds))
+	trace.Stop()
+
+	return nil
+}
+
+func (s *DebugService) readRuntimeStats() *RuntimeStatsResponse {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	hostname, _ := os.Hostname()
+
+	stats := &RuntimeStatsResponse{
+		GoVersion:     runtime.Version(),
+		Hostname:      hostname,
+		StartedAt:     s.startedAt,
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		NumCPU:        runtime.NumCPU(),
+		GoMaxProcs:    runtime.GOMAXPROCS(0),
+		NumGoroutines: runtime.NumGoroutine(),
+		Memory: MemoryStats{
+			HeapAllocBytes:    memStats.HeapAlloc,
+			HeapInuseBytes:    memStats.HeapInuse,
+			HeapIdleBytes:     memStats.HeapIdle,
+			HeapReleasedBytes: memStats.HeapReleased,
+			HeapObjects:       memStats.HeapObjects,
+			StackInuseBytes:   memStats.StackInuse,
+			SysBytes:          memStats.Sys,
+			TotalAllocBytes:   memStats.TotalAlloc,
+		},
+		GC: GCStats{
+			NumGC:        memStats.NumGC,
+			NextGCBytes:  memStats.NextGC,
+			PauseTotalMs: float64(memStats.PauseTotalNs) / float64(time.Millisecond),
+			CPUFraction:  memStats.GCCPUFraction,
+		},
+	}
+
+	if memStats.NumGC > 0 {
+		// PauseNs and PauseEnd are circular buffers indexed by the number of the GC
+		lastIndex := (memStats.NumGC + 255) % 256
+		lastGCAt := time.Unix(0, int64(memStats.PauseEnd[lastIndex])).UTC()
+
+		stats.GC.LastPauseMs = float64(memStats.PauseNs[lastIndex]) / float64(time.Millisecond)
+		stats.GC.LastGCAt = &lastGCAt
+	}
+
+	return stats
+}
+
+func (s *DebugService) waitCapture(ctx context.Context, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		s.logger.Info("Debug capture stopped early, client disconnected")
+	}
+}
+
+func getCaptureDuration(seconds int, defaultSeconds int) time.Duration {
+	if seconds <= 0 {
+		seconds = defaultSeconds
+	}
+
+	return time.Duration(min(seconds, maxCaptureSeconds)) * time.Second
+}
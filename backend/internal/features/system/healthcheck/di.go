@@ -3,12 +3,14 @@ package system_healthcheck
 import (
 	"databasus-backend/internal/features/backups/backups/backuping"
 	"databasus-backend/internal/features/disk"
+	system_leader "databasus-backend/internal/features/system/leader"
 )
 
 var healthcheckService = &HealthcheckService{
 	disk.GetDiskService(),
 	backuping.GetBackupsScheduler(),
 	backuping.GetBackuperNode(),
+	system_leader.GetLeaderElector(),
 }
 var healthcheckController = &HealthcheckController{
 	healthcheckService,
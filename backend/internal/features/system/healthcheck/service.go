@@ -5,9 +5,11 @@ import (
 	"databasus-backend/internal/config"
 	"databasus-backend/internal/features/backups/backups/backuping"
 	"databasus-backend/internal/features/disk"
+	system_leader "databasus-backend/internal/features/system/leader"
 	"databasus-backend/internal/storage"
 	cache_utils "databasus-backend/internal/util/cache"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -15,6 +17,7 @@ type HealthcheckService struct {
 	diskService             *disk.DiskService
 	backupBackgroundService *backuping.BackupsScheduler
 	backuperNode            *backuping.BackuperNode
+	leaderElector           *system_leader.LeaderElector
 }
 
 func (s *HealthcheckService) IsHealthy() error {
@@ -37,8 +40,10 @@ func (s *HealthcheckService) performHealthCheck() error {
 		return errors.New("cannot get disk usage")
 	}
 
-	if float64(diskUsage.UsedSpaceBytes) >= float64(diskUsage.TotalSpaceBytes)*0.95 {
-		return errors.New("more than 95% of the disk is used")
+	maxDiskUsagePercent := config.GetReloadableSettings().HealthcheckMaxDiskUsagePercent
+	if float64(diskUsage.UsedSpaceBytes) >=
+		float64(diskUsage.TotalSpaceBytes)*float64(maxDiskUsagePercent)/100 {
+		return fmt.Errorf("more than %d%% of the disk is used", maxDiskUsagePercent)
 	}
 
 	db := storage.GetDb()
@@ -48,7 +53,8 @@ func (s *HealthcheckService) performHealthCheck() error {
 		return errors.New("cannot connect to the database")
 	}
 
-	if config.GetEnv().IsPrimaryNode {
+	// Candidates waiting for leadership do not run the scheduler and are still healthy
+	if s.leaderElector.IsLeader() {
 		if !s.backupBackgroundService.IsSchedulerRunning() {
 			return errors.New("backups are not running for more than 5 minutes")
 		}
@@ -0,0 +1,26 @@
+package system_leader
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/logger"
+)
+
+var leaderElector = &LeaderElector{
+	client:          cache_utils.GetValkeyClient(),
+	logger:          logger.GetLogger(),
+	timeout:         cache_utils.DefaultCacheTimeout,
+	nodeID:          uuid.New(),
+	isLeader:        atomic.Bool{},
+	lastRenewedAtMs: atomic.Int64{},
+	runOnce:         sync.Once{},
+	hasRun:          atomic.Bool{},
+}
+
+func GetLeaderElector() *LeaderElector {
+	return leaderElector
+}
@@ -0,0 +1,239 @@
+package system_leader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valkey-io/valkey-go"
+
+	"databasus-backend/internal/config"
+)
+
+const leaderLockKey = "system:scheduler_leader"
+
+// Compare-and-act scripts, so a node whose lock already expired and was taken by another
+// node can neither extend nor delete the new owner's lock
+const (
+	renewLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then ` +
+		`return redis.call("PEXPIRE", KEYS[1], ARGV[2]) else return 0 end`
+	releaseLockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then ` +
+		`return redis.call("DEL", KEYS[1]) else return 0 end`
+)
+
+// LeaderElector lets several primary nodes run side by side while only one of them runs the
+// schedulers. The leader holds a Valkey lock with TTL and renews it; when it dies the lock
+// expires and another candidate takes over within one TTL.
+//
+// Important things to remember:
+//   - Background services can be started only once per process, so a node that loses
+//     leadership must exit and come back as a candidate (see onLost in Run)
+//   - Without IS_LEADER_ELECTION_ENABLED the primary node is always the leader
+type LeaderElector struct {
+	client  valkey.Client
+	logger  *slog.Logger
+	timeout time.Duration
+	nodeID  uuid.UUID
+
+	isLeader        atomic.Bool
+	lastRenewedAtMs atomic.Int64
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (e *LeaderElector) IsLeader() bool {
+	if !config.GetEnv().IsPrimaryNode {
+		return false
+	}
+
+	if !config.GetEnv().IsLeaderElectionEnabled {
+		return true
+	}
+
+	return e.isLeader.Load()
+}
+
+func (e *LeaderElector) GetNodeID() uuid.UUID {
+	return e.nodeID
+}
+
+// AcquireOnStartup lets the winner run migrations before serving requests. The lock is
+// renewed in background until Run takes over, because migrations may outlast the TTL
+func (e *LeaderElector) AcquireOnStartup() (bool, error) {
+	isAcquired, err := e.tryAcquire()
+	if err != nil || !isAcquired {
+		return isAcquired, err
+	}
+
+	go func() {
+		ticker := time.NewTicker(e.getLockTTL() / 3)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if e.hasRun.Load() {
+				return
+			}
+
+			if !e.renew() {
+				e.logger.Error("Leader lock expired during startup", "nodeId", e.nodeID)
+				e.isLeader.Store(false)
+				return
+			}
+		}
+	}()
+
+	return true, nil
+}
+
+// Run campaigns for leadership until ctx is cancelled. onElected is called once when this
+// node becomes the leader, onLost when the lock could not be renewed in time and another
+// node may already be running the schedulers
+func (e *LeaderElector) Run(ctx context.Context, onElected func(), onLost func()) {
+	wasAlreadyRun := e.hasRun.Load()
+
+	e.runOnce.Do(func() {
+		e.hasRun.Store(true)
+
+		e.logger.Info("Starting leader election", "nodeId", e.nodeID)
+
+		defer e.release()
+
+		if e.isLeader.Load() {
+			e.logger.Info("Node is the leader", "nodeId", e.nodeID)
+			onElected()
+		}
+
+		ticker := time.NewTicker(e.getLockTTL() / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if !e.isLeader.Load() {
+				isAcquired, err := e.tryAcquire()
+				if err != nil {
+					e.logger.Warn("Failed to campaign for leadership", "error", err)
+					continue
+				}
+
+				if isAcquired {
+					e.logger.Info("Node became the leader", "nodeId", e.nodeID)
+					onElected()
+				}
+
+				continue
+			}
+
+			if !e.renew() {
+				e.isLeader.Store(false)
+				e.logger.Error("Node lost leadership", "nodeId", e.nodeID)
+				onLost()
+				return
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", e))
+	}
+}
+
+func (e *LeaderElector) tryAcquire() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	err := e.client.Do(
+		ctx,
+		e.client.B().
+			Set().
+			Key(leaderLockKey).
+			Value(e.nodeID.String()).
+			Nx().
+			Px(e.getLockTTL()).
+			Build(),
+	).Error()
+	if valkey.IsValkeyNil(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire leader lock: %w", err)
+	}
+
+	e.lastRenewedAtMs.Store(time.Now().UTC().UnixMilli())
+	e.isLeader.Store(true)
+
+	return true, nil
+}
+
+// renew reports false only when the lock belongs to another node or could not be renewed
+// for a whole TTL. Short Valkey hiccups are tolerated because the lock is still ours
+func (e *LeaderElector) renew() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	renewed, err := e.client.Do(
+		ctx,
+		e.client.B().
+			Eval().
+			Script(renewLockScript).
+			Numkeys(1).
+			Key(leaderLockKey).
+			Arg(e.nodeID.String(), strconv.FormatInt(e.getLockTTL().Milliseconds(), 10)).
+			Build(),
+	).AsInt64()
+	if err != nil {
+		e.logger.Warn("Failed to renew leader lock", "error", err)
+
+		lastRenewedAt := time.UnixMilli(e.lastRenewedAtMs.Load())
+		return time.Since(lastRenewedAt) < e.getLockTTL()
+	}
+
+	if renewed == 0 {
+		return false
+	}
+
+	e.lastRenewedAtMs.Store(time.Now().UTC().UnixMilli())
+
+	return true
+}
+
+// release hands leadership over right away on graceful shutdown instead of after the TTL
+func (e *LeaderElector) release() {
+	if !e.isLeader.Load() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	err := e.client.Do(
+		ctx,
+		e.client.B().
+			Eval().
+			Script(releaseLockScript).
+			Numkeys(1).
+			Key(leaderLockKey).
+			Arg(e.nodeID.String()).
+			Build(),
+	).Error()
+	if err != nil {
+		e.logger.Warn("Failed to release leader lock", "error", err)
+		return
+	}
+
+	e.isLeader.Store(false)
+}
+
+func (e *LeaderElector) getLockTTL() time.Duration {
+	return time.Duration(config.GetEnv().LeaderLockTTLSeconds) * time.Second
+}
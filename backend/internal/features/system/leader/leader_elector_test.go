@@ -0,0 +1,59 @@
+package system_leader
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/logger"
+)
+
+func Test_TryAcquire_WhenLockIsHeld_OnlyOneNodeBecomesLeader(t *testing.T) {
+	firstElector := createTestLeaderElector()
+	secondElector := createTestLeaderElector()
+	defer firstElector.release()
+	defer secondElector.release()
+
+	isFirstAcquired, err := firstElector.tryAcquire()
+	require.NoError(t, err)
+	assert.True(t, isFirstAcquired)
+
+	isSecondAcquired, err := secondElector.tryAcquire()
+	require.NoError(t, err)
+	assert.False(t, isSecondAcquired)
+
+	assert.True(t, firstElector.renew())
+	assert.False(t, secondElector.renew())
+}
+
+func Test_Release_WhenLeaderReleases_OtherNodeTakesOver(t *testing.T) {
+	firstElector := createTestLeaderElector()
+	secondElector := createTestLeaderElector()
+	defer secondElector.release()
+
+	isFirstAcquired, err := firstElector.tryAcquire()
+	require.NoError(t, err)
+	require.True(t, isFirstAcquired)
+
+	firstElector.release()
+	assert.False(t, firstElector.isLeader.Load())
+
+	isSecondAcquired, err := secondElector.tryAcquire()
+	require.NoError(t, err)
+	assert.True(t, isSecondAcquired)
+
+	// Former leader must not be able to extend the lock of the new one
+	assert.False(t, firstElector.renew())
+}
+
+func createTestLeaderElector() *LeaderElector {
+	return &LeaderElector{
+		client:  cache_utils.GetValkeyClient(),
+		logger:  logger.GetLogger(),
+		timeout: cache_utils.DefaultCacheTimeout,
+		nodeID:  uuid.New(),
+	}
+}
@@ -0,0 +1,55 @@
+package system_metadata_backup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type MetadataBackupBackgroundService struct {
+	metadataBackupService *MetadataBackupService
+	logger                *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (s *MetadataBackupBackgroundService) Run(ctx context.Context) {
+	wasAlreadyRun := s.hasRun.Load()
+
+	s.runOnce.Do(func() {
+		s.hasRun.Store(true)
+
+		s.logger.Info("Starting metadata backup background service")
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := s.metadataBackupService.MarkInterruptedBackupsAsFailed(); err != nil {
+			s.logger.Error("Failed to mark interrupted metadata backups", "error", err)
+		}
+
+		ticker := time.NewTicker(1 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := s.metadataBackupService.RunScheduledBackup(ctx); err != nil {
+				s.logger.Error("Failed to run scheduled metadata backup", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", s))
+	}
+}
@@ -0,0 +1,86 @@
+package system_metadata_backup
+
+import (
+	"errors"
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MetadataBackupController struct {
+	metadataBackupService *MetadataBackupService
+}
+
+func (c *MetadataBackupController) RegisterRoutes(router *gin.RouterGroup) {
+	adminOnly := users_middleware.RequireRole(users_enums.UserRoleAdmin)
+
+	router.GET("/system/metadata-backups", adminOnly, c.GetBackups)
+	router.POST("/system/metadata-backups", adminOnly, c.MakeBackup)
+}
+
+// GetBackups
+// @Summary Get metadata backups
+// @Description Get self-backup settings and backups of the Databasus internal database
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} GetMetadataBackupsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/metadata-backups [get]
+func (c *MetadataBackupController) GetBackups(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.metadataBackupService.GetBackups(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// MakeBackup
+// @Summary Make metadata backup
+// @Description Start a backup of the Databasus internal database to the configured system storage
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /system/metadata-backups [post]
+func (c *MetadataBackupController) MakeBackup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := c.metadataBackupService.MakeBackupWithAuth(user); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "metadata backup started successfully"})
+}
+
+func (c *MetadataBackupController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrOnlyAdminsCanManageMetadataBackups):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrMetadataBackupAlreadyRunning):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
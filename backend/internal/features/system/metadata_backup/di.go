@@ -0,0 +1,42 @@
+package system_metadata_backup
+
+import (
+	"sync"
+	"sync/atomic"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
+)
+
+var metadataBackupRepository = &MetadataBackupRepository{}
+var metadataBackupService = &MetadataBackupService{
+	metadataBackupRepository: metadataBackupRepository,
+	storageService:           storages.GetStorageService(),
+	auditLogService:          audit_logs.GetAuditLogService(),
+	fieldEncryptor:           encryption.GetFieldEncryptor(),
+	logger:                   logger.GetLogger(),
+	isBackupRunning:          atomic.Bool{},
+}
+var metadataBackupController = &MetadataBackupController{
+	metadataBackupService,
+}
+var metadataBackupBackgroundService = &MetadataBackupBackgroundService{
+	metadataBackupService: metadataBackupService,
+	logger:                logger.GetLogger(),
+	runOnce:               sync.Once{},
+	hasRun:                atomic.Bool{},
+}
+
+func GetMetadataBackupService() *MetadataBackupService {
+	return metadataBackupService
+}
+
+func GetMetadataBackupController() *MetadataBackupController {
+	return metadataBackupController
+}
+
+func GetMetadataBackupBackgroundService() *MetadataBackupBackgroundService {
+	return metadataBackupBackgroundService
+}
@@ -0,0 +1,8 @@
+package system_metadata_backup
+
+type GetMetadataBackupsResponse struct {
+	IsEnabled     bool              `json:"isEnabled"`
+	IntervalHours int               `json:"intervalHours"`
+	KeepCount     int               `json:"keepCount"`
+	Backups       []*MetadataBackup `json:"backups"`
+}
@@ -0,0 +1,74 @@
+package system_metadata_backup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// buildPgEnv turns DATABASE_DSN into libpq environment variables for pg_dump and pg_restore.
+// Passing them via env keeps the password out of the process list, and comma separated
+// hosts keep multi-host DSNs working. targetSessionAttrs picks the node: a standby is
+// enough to dump from, while restoring must reach the primary
+func buildPgEnv(dsn string, targetSessionAttrs string) ([]string, error) {
+	connConfig, err := pgconn.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database DSN: %w", err)
+	}
+
+	hosts := []string{connConfig.Host}
+	ports := []string{strconv.Itoa(int(connConfig.Port))}
+	seenAddresses := map[string]bool{connConfig.Host + ":" + ports[0]: true}
+
+	isTLS := connConfig.TLSConfig != nil
+	isPlainFallback := false
+
+	// pgconn expands sslmode=prefer and multiple hosts into fallbacks with the same address
+	for _, fallback := range connConfig.Fallbacks {
+		port := strconv.Itoa(int(fallback.Port))
+
+		if fallback.TLSConfig == nil {
+			isPlainFallback = true
+		} else {
+			isTLS = true
+		}
+
+		address := fallback.Host + ":" + port
+		if seenAddresses[address] {
+			continue
+		}
+		seenAddresses[address] = true
+
+		hosts = append(hosts, fallback.Host)
+		ports = append(ports, port)
+	}
+
+	sslMode := "disable"
+	if isTLS {
+		sslMode = "require"
+		if isPlainFallback {
+			sslMode = "prefer"
+		}
+	}
+
+	pgEnv := []string{
+		"PGHOST=" + strings.Join(hosts, ","),
+		"PGPORT=" + strings.Join(ports, ","),
+		"PGUSER=" + connConfig.User,
+		"PGPASSWORD=" + connConfig.Password,
+		"PGDATABASE=" + connConfig.Database,
+		"PGSSLMODE=" + sslMode,
+		"PGTARGETSESSIONATTRS=" + targetSessionAttrs,
+	}
+
+	if connConfig.ConnectTimeout > 0 {
+		pgEnv = append(
+			pgEnv,
+			"PGCONNECT_TIMEOUT="+strconv.Itoa(int(connConfig.ConnectTimeout.Seconds())),
+		)
+	}
+
+	return pgEnv, nil
+}
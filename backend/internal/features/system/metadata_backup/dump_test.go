@@ -0,0 +1,55 @@
+package system_metadata_backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BuildPgEnv_SingleHostDsn_ReturnsConnectionVariables(t *testing.T) {
+	pgEnv, err := buildPgEnv(
+		"host=db port=5437 user=postgres password=s3cret dbname=databasus sslmode=disable",
+		"prefer-standby",
+	)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"PGHOST=db",
+		"PGPORT=5437",
+		"PGUSER=postgres",
+		"PGPASSWORD=s3cret",
+		"PGDATABASE=databasus",
+		"PGSSLMODE=disable",
+		"PGTARGETSESSIONATTRS=prefer-standby",
+	}, pgEnv)
+}
+
+func Test_BuildPgEnv_MultiHostDsn_JoinsHostsWithoutDuplicates(t *testing.T) {
+	pgEnv, err := buildPgEnv(
+		"postgres://postgres:pw@db1:5432,db2:5433/databasus?sslmode=prefer&connect_timeout=5",
+		"read-write",
+	)
+	require.NoError(t, err)
+
+	assert.Contains(t, pgEnv, "PGHOST=db1,db2")
+	assert.Contains(t, pgEnv, "PGPORT=5432,5433")
+	assert.Contains(t, pgEnv, "PGSSLMODE=prefer")
+	assert.Contains(t, pgEnv, "PGCONNECT_TIMEOUT=5")
+	assert.Contains(t, pgEnv, "PGTARGETSESSIONATTRS=read-write")
+}
+
+func Test_BuildPgEnv_RequireSslMode_ReturnsRequire(t *testing.T) {
+	pgEnv, err := buildPgEnv(
+		"host=db user=postgres password=pw dbname=databasus sslmode=require",
+		"read-write",
+	)
+	require.NoError(t, err)
+
+	assert.Contains(t, pgEnv, "PGSSLMODE=require")
+}
+
+func Test_BuildPgEnv_InvalidDsn_ReturnsError(t *testing.T) {
+	_, err := buildPgEnv("postgres://%zz", "read-write")
+	assert.Error(t, err)
+}
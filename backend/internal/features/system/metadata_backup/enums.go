@@ -0,0 +1,9 @@
+package system_metadata_backup
+
+type MetadataBackupStatus string
+
+const (
+	MetadataBackupStatusInProgress MetadataBackupStatus = "IN_PROGRESS"
+	MetadataBackupStatusCompleted  MetadataBackupStatus = "COMPLETED"
+	MetadataBackupStatusFailed     MetadataBackupStatus = "FAILED"
+)
@@ -0,0 +1,18 @@
+package system_metadata_backup
+
+import "errors"
+
+var (
+	ErrOnlyAdminsCanManageMetadataBackups = errors.New(
+		"only administrators can manage metadata backups",
+	)
+	ErrMetadataBackupNotConfigured = errors.New(
+		"metadata backup is not configured, set METADATA_BACKUP_STORAGE_ID",
+	)
+	ErrMetadataBackupStorageNotSystem = errors.New(
+		"metadata backup storage must be a system storage",
+	)
+	ErrMetadataBackupAlreadyRunning = errors.New(
+		"metadata backup is already running",
+	)
+)
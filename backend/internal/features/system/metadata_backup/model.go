@@ -0,0 +1,25 @@
+package system_metadata_backup
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MetadataBackup is a dump of the Databasus own database. Its ID is the file name in the
+// storage, which is what an operator looks for when restoring after the database is lost
+type MetadataBackup struct {
+	ID        uuid.UUID `json:"id"        gorm:"column:id;type:uuid;primaryKey"`
+	StorageID uuid.UUID `json:"storageId" gorm:"column:storage_id;type:uuid;not null"`
+
+	Status      MetadataBackupStatus `json:"status"      gorm:"column:status;type:text;not null"`
+	SizeBytes   int64                `json:"sizeBytes"   gorm:"column:size_bytes;not null;default:0"`
+	FailMessage *string              `json:"failMessage" gorm:"column:fail_message"`
+
+	CreatedAt   time.Time  `json:"createdAt"   gorm:"column:created_at"`
+	CompletedAt *time.Time `json:"completedAt" gorm:"column:completed_at"`
+}
+
+func (MetadataBackup) TableName() string {
+	return "metadata_backups"
+}
@@ -0,0 +1,78 @@
+package system_metadata_backup
+
+import (
+	"errors"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MetadataBackupRepository struct{}
+
+func (r *MetadataBackupRepository) Save(backup *MetadataBackup) error {
+	if backup.ID == uuid.Nil {
+		backup.ID = uuid.New()
+		return storage.GetDb().Create(backup).Error
+	}
+
+	return storage.GetDb().Save(backup).Error
+}
+
+func (r *MetadataBackupRepository) FindAll() ([]*MetadataBackup, error) {
+	var backups []*MetadataBackup
+
+	err := storage.GetDb().Order("created_at DESC").Find(&backups).Error
+
+	return backups, err
+}
+
+func (r *MetadataBackupRepository) FindLastCompleted() (*MetadataBackup, error) {
+	var backup MetadataBackup
+
+	err := storage.
+		GetDb().
+		Where("status = ?", MetadataBackupStatusCompleted).
+		Order("created_at DESC").
+		First(&backup).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &backup, nil
+}
+
+func (r *MetadataBackupRepository) FindCompletedOlderThanNewest(
+	keepCount int,
+) ([]*MetadataBackup, error) {
+	var backups []*MetadataBackup
+
+	err := storage.
+		GetDb().
+		Where("status = ?", MetadataBackupStatusCompleted).
+		Order("created_at DESC").
+		Offset(keepCount).
+		Find(&backups).Error
+
+	return backups, err
+}
+
+func (r *MetadataBackupRepository) MarkInProgressAsFailed(failMessage string) error {
+	return storage.
+		GetDb().
+		Model(&MetadataBackup{}).
+		Where("status = ?", MetadataBackupStatusInProgress).
+		Updates(map[string]any{
+			"status":       MetadataBackupStatusFailed,
+			"fail_message": failMessage,
+		}).Error
+}
+
+func (r *MetadataBackupRepository) DeleteByID(id uuid.UUID) error {
+	return storage.GetDb().Delete(&MetadataBackup{}, "id = ?", id).Error
+}
@@ -0,0 +1,325 @@
+package system_metadata_backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/tools"
+)
+
+type MetadataBackupService struct {
+	metadataBackupRepository *MetadataBackupRepository
+	storageService           *storages.StorageService
+	auditLogService          *audit_logs.AuditLogService
+	fieldEncryptor           encryption.FieldEncryptor
+	logger                   *slog.Logger
+
+	isBackupRunning atomic.Bool
+}
+
+func (s *MetadataBackupService) GetBackups(
+	user *users_models.User,
+) (*GetMetadataBackupsResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanManageMetadataBackups
+	}
+
+	backups, err := s.metadataBackupRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMetadataBackupsResponse{
+		IsEnabled:     config.GetEnv().MetadataBackupStorageID != "",
+		IntervalHours: config.GetEnv().MetadataBackupIntervalHours,
+		KeepCount:     config.GetEnv().MetadataBackupKeepCount,
+		Backups:       backups,
+	}, nil
+}
+
+// MakeBackupWithAuth starts a backup in background. The running flag is taken before
+// returning, so a second click gets an error instead of silently queuing another dump
+func (s *MetadataBackupService) MakeBackupWithAuth(user *users_models.User) error {
+	if user.Role != users_enums.UserRoleAdmin {
+		return ErrOnlyAdminsCanManageMetadataBackups
+	}
+
+	if config.GetEnv().MetadataBackupStorageID == "" {
+		return ErrMetadataBackupNotConfigured
+	}
+
+	if !s.isBackupRunning.CompareAndSwap(false, true) {
+		return ErrMetadataBackupAlreadyRunning
+	}
+
+	go func() {
+		defer s.isBackupRunning.Store(false)
+
+		if err := s.makeBackup(context.Background()); err != nil {
+			s.logger.Error("Failed to make metadata backup", "error", err)
+		}
+	}()
+
+	s.auditLogService.WriteAuditLog("Metadata backup started manually", &user.ID, nil)
+
+	return nil
+}
+
+// RunScheduledBackup makes a backup when the last completed one is older than the interval.
+// It is checked against DB rather than in memory so restarts do not shift the schedule
+func (s *MetadataBackupService) RunScheduledBackup(ctx context.Context) error {
+	lastBackup, err := s.metadataBackupRepository.FindLastCompleted()
+	if err != nil {
+		return err
+	}
+
+	interval := time.Duration(config.GetEnv().MetadataBackupIntervalHours) * time.Hour
+	if lastBackup != nil && time.Since(lastBackup.CreatedAt) < interval {
+		return nil
+	}
+
+	return s.MakeBackup(ctx)
+}
+
+func (s *MetadataBackupService) MakeBackup(ctx context.Context) error {
+	if config.GetEnv().MetadataBackupStorageID == "" {
+		return ErrMetadataBackupNotConfigured
+	}
+
+	if !s.isBackupRunning.CompareAndSwap(false, true) {
+		return ErrMetadataBackupAlreadyRunning
+	}
+	defer s.isBackupRunning.Store(false)
+
+	return s.makeBackup(ctx)
+}
+
+// RestoreFromFile loads a dump made by MakeBackup into DATABASE_DSN. It is meant for the
+// --restore-metadata-from flag on a fresh instance before migrations run, so the app
+// itself holds no connections while tables are replaced
+func (s *MetadataBackupService) RestoreFromFile(filePath string) error {
+	if _, err := os.Stat(filePath); err != nil {
+		return fmt.Errorf("failed to read metadata backup file: %w", err)
+	}
+
+	pgEnv, err := buildPgEnv(config.GetEnv().DatabaseDsn, "read-write")
+	if err != nil {
+		return err
+	}
+
+	connConfig, err := pgconn.ParseConfig(config.GetEnv().DatabaseDsn)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(
+		s.getExecutable(tools.PostgresqlExecutablePgRestore),
+		"--dbname="+connConfig.Database,
+		"--no-password",
+		"--clean",
+		"--if-exists",
+		"--no-owner",
+		"--no-privileges",
+		"--single-transaction",
+		"--exit-on-error",
+		filePath,
+	)
+	cmd.Env = append(os.Environ(), pgEnv...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("pg_restore failed: %w, output: %s", err, output)
+	}
+
+	return nil
+}
+
+func (s *MetadataBackupService) MarkInterruptedBackupsAsFailed() error {
+	return s.metadataBackupRepository.MarkInProgressAsFailed(
+		"backup was interrupted by application restart",
+	)
+}
+
+func (s *MetadataBackupService) makeBackup(ctx context.Context) error {
+	storage, err := s.getBackupStorage()
+	if err != nil {
+		return err
+	}
+
+	backup := &MetadataBackup{
+		StorageID: storage.ID,
+		Status:    MetadataBackupStatusInProgress,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.metadataBackupRepository.Save(backup); err != nil {
+		return err
+	}
+
+	sizeBytes, err := s.dumpToStorage(ctx, storage, backup.ID)
+	if err != nil {
+		failMessage := err.Error()
+		backup.Status = MetadataBackupStatusFailed
+		backup.FailMessage = &failMessage
+
+		if saveErr := s.metadataBackupRepository.Save(backup); saveErr != nil {
+			s.logger.Error("Failed to save failed metadata backup", "error", saveErr)
+		}
+
+		return err
+	}
+
+	completedAt := time.Now().UTC()
+	backup.Status = MetadataBackupStatusCompleted
+	backup.SizeBytes = sizeBytes
+	backup.CompletedAt = &completedAt
+
+	if err := s.metadataBackupRepository.Save(backup); err != nil {
+		return err
+	}
+
+	s.logger.Info("Metadata backup completed", "backupId", backup.ID, "sizeBytes", sizeBytes)
+
+	s.deleteOutdatedBackups(storage)
+
+	return nil
+}
+
+func (s *MetadataBackupService) dumpToStorage(
+	ctx context.Context,
+	storage *storages.Storage,
+	backupID uuid.UUID,
+) (int64, error) {
+	pgEnv, err := buildPgEnv(config.GetEnv().DatabaseDsn, "prefer-standby")
+	if err != nil {
+		return 0, err
+	}
+
+	// Cancelled when upload fails, otherwise pg_dump blocks forever on a full stdout pipe
+	dumpCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(
+		dumpCtx,
+		s.getExecutable(tools.PostgresqlExecutablePgDump),
+		"--format=custom",
+		"--no-password",
+	)
+	cmd.Env = append(os.Environ(), pgEnv...)
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start pg_dump: %w", err)
+	}
+
+	reader := &countingReader{reader: stdout}
+
+	saveErr := storage.SaveFile(dumpCtx, s.fieldEncryptor, s.logger, backupID, reader)
+	if saveErr != nil {
+		cancel()
+	}
+
+	waitErr := cmd.Wait()
+
+	if saveErr != nil {
+		return 0, fmt.Errorf("failed to upload metadata backup: %w", saveErr)
+	}
+
+	if waitErr != nil {
+		// The truncated dump is already uploaded and must not be mistaken for a valid one
+		if err := storage.DeleteFile(s.fieldEncryptor, backupID); err != nil {
+			s.logger.Warn("Failed to delete incomplete metadata backup", "error", err)
+		}
+
+		return 0, fmt.Errorf("pg_dump failed: %w, output: %s", waitErr, stderr.String())
+	}
+
+	return reader.bytesRead, nil
+}
+
+func (s *MetadataBackupService) deleteOutdatedBackups(storage *storages.Storage) {
+	outdatedBackups, err := s.metadataBackupRepository.FindCompletedOlderThanNewest(
+		config.GetEnv().MetadataBackupKeepCount,
+	)
+	if err != nil {
+		s.logger.Error("Failed to find outdated metadata backups", "error", err)
+		return
+	}
+
+	for _, backup := range outdatedBackups {
+		if err := storage.DeleteFile(s.fieldEncryptor, backup.ID); err != nil {
+			s.logger.Error(
+				"Failed to delete outdated metadata backup file",
+				"backupId", backup.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		if err := s.metadataBackupRepository.DeleteByID(backup.ID); err != nil {
+			s.logger.Error("Failed to delete outdated metadata backup", "error", err)
+		}
+	}
+}
+
+func (s *MetadataBackupService) getBackupStorage() (*storages.Storage, error) {
+	storageID, err := uuid.Parse(config.GetEnv().MetadataBackupStorageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid METADATA_BACKUP_STORAGE_ID: %w", err)
+	}
+
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata backup storage: %w", err)
+	}
+
+	if !storage.IsSystem {
+		return nil, ErrMetadataBackupStorageNotSystem
+	}
+
+	return storage, nil
+}
+
+// The newest client is used as pg_dump and pg_restore support all older server versions
+func (s *MetadataBackupService) getExecutable(executable tools.PostgresqlExecutable) string {
+	return tools.GetPostgresqlExecutable(
+		tools.PostgresqlVersion18,
+		executable,
+		config.GetEnv().EnvMode,
+		config.GetEnv().PostgresesInstallDir,
+	)
+}
+
+type countingReader struct {
+	reader    io.Reader
+	bytesRead int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytesRead += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,14 @@
+package system_migrations
+
+import (
+	"databasus-backend/internal/util/logger"
+)
+
+var migrationService = &MigrationService{
+	"./migrations",
+	logger.GetLogger(),
+}
+
+func GetMigrationService() *MigrationService {
+	return migrationService
+}
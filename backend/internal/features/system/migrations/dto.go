@@ -0,0 +1,8 @@
+package system_migrations
+
+type SchemaStatus struct {
+	SchemaVersion int64 `json:"schemaVersion"`
+	BinaryVersion int64 `json:"binaryVersion"`
+	// Breaking migrations applied to the schema that this binary does not know about
+	UnknownBreakingVersions []int64 `json:"unknownBreakingVersions"`
+}
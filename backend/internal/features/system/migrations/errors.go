@@ -0,0 +1,13 @@
+package system_migrations
+
+import "errors"
+
+var (
+	ErrSchemaHasPendingMigrations = errors.New(
+		"database schema is older than this binary, run migrations with --migrate-only first",
+	)
+	ErrSchemaIncompatibleWithBinary = errors.New(
+		"database schema has breaking migrations newer than this binary, upgrade the node",
+	)
+	ErrMigrationNotReversible = errors.New("migration has no down section")
+)
@@ -0,0 +1,101 @@
+package system_migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	gooseDownMarker = "-- +goose Down"
+	// Put this line into a migration that older binaries cannot run against (dropped or
+	// renamed columns they still use). Additive migrations must not have it, so nodes of the
+	// previous release keep working during a rolling upgrade
+	breakingMarker = "-- +databasus Breaking"
+)
+
+type MigrationFile struct {
+	Version    int64
+	Name       string
+	IsBreaking bool
+	HasDown    bool
+}
+
+func readMigrationFiles(dir string) ([]MigrationFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]MigrationFile, 0, len(paths))
+	seenVersions := make(map[int64]string, len(paths))
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", path, err)
+		}
+
+		file, err := parseMigrationFile(filepath.Base(path), string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		if existingName, isSeen := seenVersions[file.Version]; isSeen {
+			return nil, fmt.Errorf(
+				"migrations %s and %s have the same version",
+				existingName,
+				file.Name,
+			)
+		}
+		seenVersions[file.Version] = file.Name
+
+		files = append(files, file)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Version < files[j].Version })
+
+	return files, nil
+}
+
+func parseMigrationFile(name string, content string) (MigrationFile, error) {
+	versionPart, _, isFound := strings.Cut(name, "_")
+	if !isFound {
+		return MigrationFile{}, fmt.Errorf("migration %s has no version prefix", name)
+	}
+
+	version, err := strconv.ParseInt(versionPart, 10, 64)
+	if err != nil {
+		return MigrationFile{}, fmt.Errorf("migration %s has invalid version: %w", name, err)
+	}
+
+	file := MigrationFile{Version: version, Name: name}
+
+	upPart, downPart, isDownFound := strings.Cut(content, gooseDownMarker)
+	file.IsBreaking = strings.Contains(upPart, breakingMarker)
+	file.HasDown = isDownFound && hasStatements(downPart)
+
+	return file, nil
+}
+
+func hasStatements(sql string) bool {
+	for line := range strings.SplitSeq(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getLatestVersion(files []MigrationFile) int64 {
+	if len(files) == 0 {
+		return 0
+	}
+
+	return files[len(files)-1].Version
+}
@@ -0,0 +1,52 @@
+package system_migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadMigrationFiles_AllRepositoryMigrations_AreVersionedAndReversible(t *testing.T) {
+	files, err := readMigrationFiles("../../../../migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		assert.True(t, file.HasDown, "migration %s must have a down section", file.Name)
+	}
+}
+
+func Test_ParseMigrationFile_WithBreakingMarkerInUp_IsBreaking(t *testing.T) {
+	file, err := parseMigrationFile("20260101100000_drop_column.sql", `-- +goose Up
+-- +databasus Breaking
+ALTER TABLE users DROP COLUMN legacy;
+
+-- +goose Down
+ALTER TABLE users ADD COLUMN legacy TEXT;
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(20260101100000), file.Version)
+	assert.True(t, file.IsBreaking)
+	assert.True(t, file.HasDown)
+}
+
+func Test_ParseMigrationFile_WithOnlyCommentsInDown_IsNotReversible(t *testing.T) {
+	file, err := parseMigrationFile("20260101100000_add_table.sql", `-- +goose Up
+CREATE TABLE items (id UUID PRIMARY KEY);
+
+-- +goose Down
+-- +goose StatementBegin
+-- +goose StatementEnd
+`)
+	require.NoError(t, err)
+
+	assert.False(t, file.IsBreaking)
+	assert.False(t, file.HasDown)
+}
+
+func Test_ParseMigrationFile_WithoutVersionPrefix_ReturnsError(t *testing.T) {
+	_, err := parseMigrationFile("add_table.sql", "")
+	assert.Error(t, err)
+}
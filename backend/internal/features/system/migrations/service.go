@@ -0,0 +1,306 @@
+package system_migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/storage"
+)
+
+// Any constant works as long as nothing else in the database uses the same advisory lock
+const migrationsAdvisoryLockID = 7_316_480_251
+
+const (
+	// schemaWaitTimeout bounds how long a node waits for the leader or a --migrate-only job
+	// to apply migrations this binary expects
+	schemaWaitTimeout      = 15 * time.Minute
+	schemaWaitPollInterval = 5 * time.Second
+)
+
+// MigrationService applies goose migrations from the migrations folder and checks that the
+// binary can work with the schema it finds. Migrations run under a PostgreSQL advisory lock,
+// so several nodes or a --migrate-only job can start at the same time
+type MigrationService struct {
+	migrationsDir string
+	logger        *slog.Logger
+}
+
+func (s *MigrationService) Migrate() error {
+	files, err := s.readReversibleMigrationFiles()
+	if err != nil {
+		return err
+	}
+
+	return s.withMigrationsLock(func() error {
+		output, err := s.runGoose("up")
+		if err != nil {
+			return err
+		}
+
+		s.logger.Info("Database migrations completed successfully", "output", output)
+
+		return s.recordBreakingMigrations(files)
+	})
+}
+
+// RollbackTo reverts migrations down to the version (inclusive), which is needed to go back
+// to the previous release after a breaking migration was applied
+func (s *MigrationService) RollbackTo(version int64) error {
+	if _, err := s.readReversibleMigrationFiles(); err != nil {
+		return err
+	}
+
+	return s.withMigrationsLock(func() error {
+		output, err := s.runGoose("down-to", strconv.FormatInt(version, 10))
+		if err != nil {
+			return err
+		}
+
+		s.logger.Info("Database migrations rolled back successfully", "output", output)
+
+		// Rolling back far enough drops the markers table itself
+		isTableExists, err := s.isTableExists("schema_breaking_migrations")
+		if err != nil || !isTableExists {
+			return err
+		}
+
+		return storage.
+			GetDb().
+			Exec("DELETE FROM schema_breaking_migrations WHERE version > ?", version).
+			Error
+	})
+}
+
+func (s *MigrationService) GetSchemaStatus() (*SchemaStatus, error) {
+	files, err := readMigrationFiles(s.migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaVersion, err := s.getSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SchemaStatus{
+		SchemaVersion:           schemaVersion,
+		BinaryVersion:           getLatestVersion(files),
+		UnknownBreakingVersions: []int64{},
+	}
+
+	if status.SchemaVersion > status.BinaryVersion {
+		err := storage.
+			GetDb().
+			Raw(
+				"SELECT version FROM schema_breaking_migrations WHERE version > ? ORDER BY version",
+				status.BinaryVersion,
+			).
+			Scan(&status.UnknownBreakingVersions).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// CheckSchemaCompatibility is the pre-flight check every node runs before serving. A newer
+// schema is fine while it has only additive migrations: that is the rolling upgrade window
+// when nodes of the previous release still run next to already migrated database
+func (s *MigrationService) CheckSchemaCompatibility() error {
+	status, err := s.GetSchemaStatus()
+	if err != nil {
+		return err
+	}
+
+	if status.SchemaVersion < status.BinaryVersion {
+		return fmt.Errorf(
+			"%w (schema %d, binary %d)",
+			ErrSchemaHasPendingMigrations,
+			status.SchemaVersion,
+			status.BinaryVersion,
+		)
+	}
+
+	if len(status.UnknownBreakingVersions) > 0 {
+		return fmt.Errorf(
+			"%w (breaking versions %v)",
+			ErrSchemaIncompatibleWithBinary,
+			status.UnknownBreakingVersions,
+		)
+	}
+
+	if status.SchemaVersion > status.BinaryVersion {
+		s.logger.Warn(
+			"Database schema is newer than this binary, running in compatibility mode",
+			"schemaVersion", status.SchemaVersion,
+			"binaryVersion", status.BinaryVersion,
+		)
+	}
+
+	return nil
+}
+
+// WaitForSchemaCompatibility runs CheckSchemaCompatibility until the schema reaches the
+// version of this binary. Pending migrations are expected on nodes starting next to the
+// leader which is still migrating, unknown breaking migrations fail right away
+func (s *MigrationService) WaitForSchemaCompatibility() error {
+	deadline := time.Now().Add(schemaWaitTimeout)
+
+	for {
+		err := s.CheckSchemaCompatibility()
+		if err == nil || !errors.Is(err, ErrSchemaHasPendingMigrations) {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"timed out after %s waiting for migrations: %w",
+				schemaWaitTimeout,
+				err,
+			)
+		}
+
+		s.logger.Info("Waiting for database migrations to be applied", "reason", err)
+		time.Sleep(schemaWaitPollInterval)
+	}
+}
+
+func (s *MigrationService) readReversibleMigrationFiles() ([]MigrationFile, error) {
+	files, err := readMigrationFiles(s.migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if !file.HasDown {
+			return nil, fmt.Errorf("%w: %s", ErrMigrationNotReversible, file.Name)
+		}
+	}
+
+	return files, nil
+}
+
+// The lock is session level, so it is taken on a dedicated connection that stays open
+// while goose runs in a separate process
+func (s *MigrationService) withMigrationsLock(fn func() error) error {
+	sqlDB, err := storage.GetDb().DB()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	s.logger.Info("Waiting for migrations lock...")
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsAdvisoryLockID)
+	if err != nil {
+		return fmt.Errorf("failed to take migrations lock: %w", err)
+	}
+	defer s.releaseMigrationsLock(conn)
+
+	return fn()
+}
+
+func (s *MigrationService) releaseMigrationsLock(conn *sql.Conn) {
+	_, err := conn.ExecContext(
+		context.Background(),
+		"SELECT pg_advisory_unlock($1)",
+		migrationsAdvisoryLockID,
+	)
+	if err != nil {
+		s.logger.Error("Failed to release migrations lock", "error", err)
+	}
+}
+
+func (s *MigrationService) runGoose(args ...string) (string, error) {
+	cmd := exec.Command("goose", append([]string{"-dir", s.migrationsDir}, args...)...)
+	cmd.Env = append(
+		os.Environ(),
+		"GOOSE_DRIVER=postgres",
+		"GOOSE_DBSTRING="+config.GetEnv().DatabaseDsn,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("goose %s failed: %w, output: %s", args[0], err, output)
+	}
+
+	return string(output), nil
+}
+
+// Breaking markers are kept in the database because an older binary does not have the
+// newer migration files and cannot read the markers from disk
+func (s *MigrationService) recordBreakingMigrations(files []MigrationFile) error {
+	schemaVersion, err := s.getSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if !file.IsBreaking || file.Version > schemaVersion {
+			continue
+		}
+
+		err := storage.
+			GetDb().
+			Exec(
+				`INSERT INTO schema_breaking_migrations (version, name) VALUES (?, ?)
+				 ON CONFLICT (version) DO NOTHING`,
+				file.Version,
+				file.Name,
+			).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSchemaVersion mirrors goose: older goose releases recorded rollbacks as rows with
+// is_applied = false, so the newest row of each version decides whether it is applied
+func (s *MigrationService) getSchemaVersion() (int64, error) {
+	isTableExists, err := s.isTableExists("goose_db_version")
+	if err != nil {
+		return 0, err
+	}
+
+	if !isTableExists {
+		return 0, nil
+	}
+
+	var version int64
+
+	err = storage.GetDb().Raw(`
+		SELECT COALESCE(MAX(version_id), 0) FROM (
+			SELECT DISTINCT ON (version_id) version_id, is_applied
+			FROM goose_db_version
+			ORDER BY version_id, id DESC
+		) AS versions
+		WHERE is_applied`).Scan(&version).Error
+
+	return version, err
+}
+
+func (s *MigrationService) isTableExists(table string) (bool, error) {
+	var isExists bool
+
+	err := storage.GetDb().Raw("SELECT to_regclass(?) IS NOT NULL", table).Scan(&isExists).Error
+
+	return isExists, err
+}
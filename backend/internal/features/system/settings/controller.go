@@ -0,0 +1,84 @@
+package system_settings
+
+import (
+	"errors"
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsController struct {
+	settingsService *SettingsService
+}
+
+func (c *SettingsController) RegisterRoutes(router *gin.RouterGroup) {
+	adminOnly := users_middleware.RequireRole(users_enums.UserRoleAdmin)
+
+	router.GET("/system/config", adminOnly, c.GetSettings)
+	router.POST("/system/config/reload", adminOnly, c.ReloadSettings)
+}
+
+// GetSettings
+// @Summary Get reloadable settings
+// @Description Get settings that can be changed in .env and reloaded without restart
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ReloadableSettingsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/config [get]
+func (c *SettingsController) GetSettings(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.settingsService.GetSettings(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ReloadSettings
+// @Summary Reload settings
+// @Description Re-read reloadable settings from .env on all nodes without restarting them
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ReloadableSettingsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /system/config/reload [post]
+func (c *SettingsController) ReloadSettings(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := c.settingsService.ReloadSettingsWithAuth(user)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *SettingsController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrOnlyAdminsCanManageSettings):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,62 @@
+package system_settings
+
+import (
+	"net/http"
+	"testing"
+
+	"databasus-backend/internal/config"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReloadSettings_WhenAdminReloads_CurrentSettingsReturned(t *testing.T) {
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := workspaces_testing.CreateTestRouter(GetSettingsController())
+
+	var response ReloadableSettingsResponse
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/config/reload",
+		"Bearer "+admin.Token,
+		nil,
+		http.StatusOK,
+		&response,
+	)
+
+	settings := config.GetReloadableSettings()
+	assert.Equal(t, settings.LogLevel, response.LogLevel)
+	assert.Equal(t, settings.SignInRateLimitPerMinute, response.SignInRateLimitPerMinute)
+	assert.Equal(t, settings.SMTPPassword != "", response.IsSMTPPasswordSet)
+}
+
+func Test_ReloadSettings_WhenMemberReloads_ReturnsForbidden(t *testing.T) {
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetSettingsController())
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/system/config/reload",
+		"Bearer "+member.Token,
+		nil,
+		http.StatusForbidden,
+	)
+}
+
+func Test_GetSettings_WhenMemberRequests_ReturnsForbidden(t *testing.T) {
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	router := workspaces_testing.CreateTestRouter(GetSettingsController())
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		"/api/v1/system/config",
+		"Bearer "+member.Token,
+		http.StatusForbidden,
+	)
+}
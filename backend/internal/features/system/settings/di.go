@@ -0,0 +1,27 @@
+package system_settings
+
+import (
+	"github.com/google/uuid"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/logger"
+)
+
+var settingsService = &SettingsService{
+	uuid.New(),
+	cache_utils.NewPubSubManager(),
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+var settingsController = &SettingsController{
+	settingsService,
+}
+
+func GetSettingsService() *SettingsService {
+	return settingsService
+}
+
+func GetSettingsController() *SettingsController {
+	return settingsController
+}
@@ -0,0 +1,13 @@
+package system_settings
+
+type ReloadableSettingsResponse struct {
+	LogLevel                          string `json:"logLevel"`
+	SignInRateLimitPerMinute          int    `json:"signInRateLimitPerMinute"`
+	PasswordResetRateLimitPerHour     int    `json:"passwordResetRateLimitPerHour"`
+	PasswordResetTokenLifetimeMinutes int    `json:"passwordResetTokenLifetimeMinutes"`
+	HealthcheckMaxDiskUsagePercent    int    `json:"healthcheckMaxDiskUsagePercent"`
+	SMTPHost                          string `json:"smtpHost"`
+	SMTPPort                          int    `json:"smtpPort"`
+	SMTPUser                          string `json:"smtpUser"`
+	IsSMTPPasswordSet                 bool   `json:"isSmtpPasswordSet"`
+}
@@ -0,0 +1,5 @@
+package system_settings
+
+import "errors"
+
+var ErrOnlyAdminsCanManageSettings = errors.New("only administrators can manage settings")
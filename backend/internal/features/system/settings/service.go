@@ -0,0 +1,91 @@
+package system_settings
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	cache_utils "databasus-backend/internal/util/cache"
+)
+
+const settingsReloadChannel = "system:settings:reload"
+
+type SettingsService struct {
+	nodeID          uuid.UUID
+	pubsub          *cache_utils.PubSubManager
+	auditLogService *audit_logs.AuditLogService
+	logger          *slog.Logger
+}
+
+func (s *SettingsService) GetSettings(
+	user *users_models.User,
+) (*ReloadableSettingsResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanManageSettings
+	}
+
+	return toSettingsResponse(config.GetReloadableSettings()), nil
+}
+
+// ReloadSettingsWithAuth reloads settings on this node and asks other nodes to do the same,
+// so a cluster does not end up with different rate limits depending on the node
+func (s *SettingsService) ReloadSettingsWithAuth(
+	user *users_models.User,
+) (*ReloadableSettingsResponse, error) {
+	if user.Role != users_enums.UserRoleAdmin {
+		return nil, ErrOnlyAdminsCanManageSettings
+	}
+
+	settings, err := config.ReloadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cache_utils.DefaultCacheTimeout)
+	defer cancel()
+
+	if err := s.pubsub.Publish(ctx, settingsReloadChannel, s.nodeID.String()); err != nil {
+		s.logger.Warn("Failed to ask other nodes to reload settings", "error", err)
+	}
+
+	s.auditLogService.WriteAuditLog("Settings reloaded", &user.ID, nil)
+
+	return toSettingsResponse(settings), nil
+}
+
+// SubscribeForReloads makes the node reload settings when any node handles the reload
+// endpoint. Every node subscribes, not only the primary one
+func (s *SettingsService) SubscribeForReloads() error {
+	return s.pubsub.Subscribe(
+		context.Background(),
+		settingsReloadChannel,
+		func(senderNodeID string) {
+			if senderNodeID == s.nodeID.String() {
+				return
+			}
+
+			if _, err := config.ReloadSettings(); err != nil {
+				s.logger.Error("Failed to reload settings", "error", err)
+			}
+		},
+	)
+}
+
+func toSettingsResponse(settings *config.ReloadableSettings) *ReloadableSettingsResponse {
+	return &ReloadableSettingsResponse{
+		LogLevel:                          settings.LogLevel,
+		SignInRateLimitPerMinute:          settings.SignInRateLimitPerMinute,
+		PasswordResetRateLimitPerHour:     settings.PasswordResetRateLimitPerHour,
+		PasswordResetTokenLifetimeMinutes: settings.PasswordResetTokenLifetimeMinutes,
+		HealthcheckMaxDiskUsagePercent:    settings.HealthcheckMaxDiskUsagePercent,
+		SMTPHost:                          settings.SMTPHost,
+		SMTPPort:                          settings.SMTPPort,
+		SMTPUser:                          settings.SMTPUser,
+		IsSMTPPasswordSet:                 settings.SMTPPassword != "",
+	}
+}
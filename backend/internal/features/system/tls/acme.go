@@ -0,0 +1,476 @@
+package system_tls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	ACMEChallengeHTTP01 = "HTTP-01"
+	ACMEChallengeDNS01  = "DNS-01"
+
+	// accountKeyName matches the cache key of autocert, so both challenges share an account
+	accountKeyName = "acme_account+key"
+	// dnsCertificateName is where DNS-01 certificates are cached, apart from autocert ones
+	dnsCertificateName = "dns-01+certificate"
+
+	// renewBefore matches autocert, Let's Encrypt certificates are valid for 90 days
+	renewBefore = 30 * 24 * time.Hour
+	// renewalCheckInterval also retries failed renewals, long before the certificate expires
+	renewalCheckInterval = 12 * time.Hour
+	// dnsPropagationDelay gives DNS providers time to publish TXT records to all servers
+	dnsPropagationDelay = 30 * time.Second
+	obtainTimeout       = 10 * time.Minute
+	dnsHookTimeout      = 2 * time.Minute
+)
+
+type acmeSettings struct {
+	Domains        []string
+	Email          string
+	DirectoryURL   string
+	Challenge      string
+	DNSHookCommand string
+	CacheFolder    string
+}
+
+// acmeManager obtains and renews certificates of the API. HTTP-01 is handled by autocert,
+// DNS-01 is not supported by it, so those certificates are ordered here and kept in memory
+type acmeManager struct {
+	settings acmeSettings
+	cache    autocert.Cache
+	logger   *slog.Logger
+
+	autocertManager *autocert.Manager
+	certificate     atomic.Pointer[tls.Certificate]
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func newACMEManager(settings acmeSettings, logger *slog.Logger) *acmeManager {
+	manager := &acmeManager{
+		settings: settings,
+		cache:    autocert.DirCache(settings.CacheFolder),
+		logger:   logger,
+	}
+
+	if settings.Challenge == ACMEChallengeHTTP01 {
+		manager.autocertManager = &autocert.Manager{
+			Prompt:      autocert.AcceptTOS,
+			Cache:       manager.cache,
+			HostPolicy:  autocert.HostWhitelist(settings.Domains...),
+			RenewBefore: renewBefore,
+			Client:      &acme.Client{DirectoryURL: settings.DirectoryURL},
+			Email:       settings.Email,
+		}
+	}
+
+	return manager
+}
+
+func (m *acmeManager) TLSConfig() *tls.Config {
+	if m.autocertManager != nil {
+		tlsConfig := m.autocertManager.TLSConfig()
+		tlsConfig.MinVersion = tls.VersionTLS12
+
+		return tlsConfig
+	}
+
+	return &tls.Config{
+		GetCertificate: m.getCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// Run serves HTTP-01 challenges or renews DNS-01 certificates until ctx is done
+func (m *acmeManager) Run(ctx context.Context, httpPort int) {
+	wasAlreadyRun := m.hasRun.Load()
+
+	m.runOnce.Do(func() {
+		m.hasRun.Store(true)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if m.autocertManager != nil {
+			m.serveHTTPChallenges(ctx, httpPort)
+			return
+		}
+
+		m.renewDNSCertificates(ctx)
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", m))
+	}
+}
+
+func (m *acmeManager) renewDNSCertificates(ctx context.Context) {
+	if err := m.loadCachedCertificate(ctx); err != nil {
+		m.logger.Warn("Failed to load cached ACME certificate", "error", err)
+	}
+
+	ticker := time.NewTicker(renewalCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if m.isRenewalDue(time.Now()) {
+			if err := m.obtainDNSCertificate(ctx); err != nil {
+				m.logger.Error("Failed to obtain ACME certificate", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// serveHTTPChallenges answers the CA on port 80, other requests are redirected to HTTPS
+func (m *acmeManager) serveHTTPChallenges(ctx context.Context, httpPort int) {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", httpPort),
+		Handler:           m.autocertManager.HTTPHandler(nil),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	m.logger.Info("Serving ACME HTTP-01 challenges", "port", httpPort)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		m.logger.Error("Failed to serve ACME HTTP-01 challenges", "error", err)
+	}
+}
+
+func (m *acmeManager) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certificate := m.certificate.Load()
+	if certificate == nil {
+		return nil, errors.New("ACME certificate is not obtained yet")
+	}
+
+	return certificate, nil
+}
+
+func (m *acmeManager) isRenewalDue(now time.Time) bool {
+	certificate := m.certificate.Load()
+	if certificate == nil || certificate.Leaf == nil {
+		return true
+	}
+
+	return now.Add(renewBefore).After(certificate.Leaf.NotAfter)
+}
+
+func (m *acmeManager) obtainDNSCertificate(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, obtainTimeout)
+	defer cancel()
+
+	client, err := m.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(m.settings.Domains...))
+	if err != nil {
+		return fmt.Errorf("failed to create ACME order: %w", err)
+	}
+
+	for _, authorizationURL := range order.AuthzURLs {
+		if err := m.completeDNSAuthorization(ctx, client, authorizationURL); err != nil {
+			return err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return fmt.Errorf("ACME order was not authorized: %w", err)
+	}
+
+	certificateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		DNSNames: m.settings.Domains,
+	}, certificateKey)
+	if err != nil {
+		return err
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("failed to issue ACME certificate: %w", err)
+	}
+
+	certificate, err := buildCertificate(chain, certificateKey)
+	if err != nil {
+		return err
+	}
+
+	encodedCertificate, err := encodeCertificate(chain, certificateKey)
+	if err != nil {
+		return err
+	}
+
+	if err := m.cache.Put(ctx, dnsCertificateName, encodedCertificate); err != nil {
+		m.logger.Warn("Failed to cache ACME certificate", "error", err)
+	}
+
+	m.certificate.Store(certificate)
+	m.logger.Info(
+		"ACME certificate obtained",
+		"domains", m.settings.Domains,
+		"notAfter", certificate.Leaf.NotAfter,
+	)
+
+	return nil
+}
+
+func (m *acmeManager) completeDNSAuthorization(
+	ctx context.Context,
+	client *acme.Client,
+	authorizationURL string,
+) error {
+	authorization, err := client.GetAuthorization(ctx, authorizationURL)
+	if err != nil {
+		return err
+	}
+
+	if authorization.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authorization.Challenges {
+		if c.Type == "dns-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf(
+			"ACME CA offers no DNS-01 challenge for %s",
+			authorization.Identifier.Value,
+		)
+	}
+
+	record, err := client.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return err
+	}
+
+	// wildcard domains are authorized on their base domain
+	recordName := "_acme-challenge." + strings.TrimPrefix(authorization.Identifier.Value, "*.")
+
+	if err := m.runDNSHook(ctx, "present", recordName, record); err != nil {
+		return err
+	}
+	defer func() {
+		if err := m.runDNSHook(context.Background(), "cleanup", recordName, record); err != nil {
+			m.logger.Warn("Failed to clean up ACME DNS record", "record", recordName, "error", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(dnsPropagationDelay):
+	}
+
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("failed to accept ACME challenge: %w", err)
+	}
+
+	if _, err := client.WaitAuthorization(ctx, authorization.URI); err != nil {
+		return fmt.Errorf("ACME DNS-01 challenge of %s failed: %w", recordName, err)
+	}
+
+	return nil
+}
+
+// runDNSHook calls the hook like "<command> present _acme-challenge.example.com <value>",
+// the same contract as the exec provider of lego, so existing scripts can be reused
+func (m *acmeManager) runDNSHook(
+	ctx context.Context,
+	action string,
+	recordName string,
+	value string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, dnsHookTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(
+		ctx,
+		m.settings.DNSHookCommand,
+		action,
+		recordName+".",
+		value,
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf(
+			"ACME DNS hook %s failed: %w: %s",
+			action,
+			err,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return nil
+}
+
+func (m *acmeManager) getClient(ctx context.Context) (*acme.Client, error) {
+	accountKey, err := m.getAccountKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &acme.Client{Key: accountKey, DirectoryURL: m.settings.DirectoryURL}
+
+	account := &acme.Account{}
+	if m.settings.Email != "" {
+		account.Contact = []string{"mailto:" + m.settings.Email}
+	}
+
+	_, err = client.Register(ctx, account, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("failed to register ACME account: %w", err)
+	}
+
+	return client, nil
+}
+
+func (m *acmeManager) getAccountKey(ctx context.Context) (crypto.Signer, error) {
+	data, err := m.cache.Get(ctx, accountKeyName)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("cached ACME account key is not PEM encoded")
+		}
+
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	if !errors.Is(err, autocert.ErrCacheMiss) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	if err := m.cache.Put(ctx, accountKeyName, keyPEM); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (m *acmeManager) loadCachedCertificate(ctx context.Context) error {
+	data, err := m.cache.Get(ctx, dnsCertificateName)
+	if errors.Is(err, autocert.ErrCacheMiss) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return err
+	}
+
+	if !isDomainCovered(certificate.Leaf, m.settings.Domains) {
+		// ACME_DOMAINS changed, the certificate is ordered again
+		return nil
+	}
+
+	m.certificate.Store(&certificate)
+
+	return nil
+}
+
+func isDomainCovered(certificate *x509.Certificate, domains []string) bool {
+	for _, domain := range domains {
+		if !containsName(certificate.DNSNames, domain) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func buildCertificate(chain [][]byte, key crypto.Signer) (*tls.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("ACME CA returned no certificate")
+	}
+
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Certificate{Certificate: chain, PrivateKey: key, Leaf: leaf}, nil
+}
+
+// encodeCertificate keeps the key and chain in one PEM file like autocert does
+func encodeCertificate(chain [][]byte, key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	content := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	for _, certificate := range chain {
+		content = append(content, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: certificate,
+		})...)
+	}
+
+	return content, nil
+}
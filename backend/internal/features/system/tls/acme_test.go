@@ -0,0 +1,123 @@
+package system_tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"databasus-backend/internal/util/logger"
+)
+
+func Test_ObtainedCertificate_WhenCached_LoadedAfterRestart(t *testing.T) {
+	settings := acmeSettings{
+		Domains:     []string{"backups.example.com"},
+		Challenge:   ACMEChallengeDNS01,
+		CacheFolder: t.TempDir(),
+	}
+	manager := newACMEManager(settings, logger.GetLogger())
+	assert.True(t, manager.isRenewalDue(time.Now()))
+
+	chain, key := createTestChain(t, []string{"backups.example.com"}, 90*24*time.Hour)
+	encoded, err := encodeCertificate(chain, key)
+	assert.NoError(t, err)
+	assert.NoError(t, manager.cache.Put(context.Background(), dnsCertificateName, encoded))
+
+	restarted := newACMEManager(settings, logger.GetLogger())
+	assert.NoError(t, restarted.loadCachedCertificate(context.Background()))
+	assert.False(t, restarted.isRenewalDue(time.Now()))
+	assert.True(t, restarted.isRenewalDue(time.Now().Add(61*24*time.Hour)))
+
+	certificate, err := restarted.getCertificate(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, chain[0], certificate.Certificate[0])
+
+	settings.Domains = []string{"backups.example.com", "*.example.com"}
+	changedDomains := newACMEManager(settings, logger.GetLogger())
+	assert.NoError(t, changedDomains.loadCachedCertificate(context.Background()))
+	assert.True(t, changedDomains.isRenewalDue(time.Now()))
+}
+
+func Test_RunDNSHook_HookCalledWithRecord(t *testing.T) {
+	directory := t.TempDir()
+	outputPath := filepath.Join(directory, "output")
+	hookPath := filepath.Join(directory, "hook.sh")
+
+	err := os.WriteFile(
+		hookPath,
+		[]byte("#!/bin/sh\necho \"$1 $2 $3\" >> "+outputPath+"\n"),
+		0o755,
+	)
+	assert.NoError(t, err)
+
+	manager := newACMEManager(acmeSettings{
+		Domains:        []string{"example.com"},
+		Challenge:      ACMEChallengeDNS01,
+		DNSHookCommand: hookPath,
+		CacheFolder:    directory,
+	}, logger.GetLogger())
+
+	assert.NoError(t, manager.runDNSHook(
+		context.Background(),
+		"present",
+		"_acme-challenge.example.com",
+		"token",
+	))
+
+	output, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "present _acme-challenge.example.com. token\n", string(output))
+
+	manager.settings.DNSHookCommand = filepath.Join(directory, "missing.sh")
+	assert.Error(t, manager.runDNSHook(context.Background(), "cleanup", "example.com", "token"))
+}
+
+func createTestChain(
+	t *testing.T,
+	domains []string,
+	validity time.Duration,
+) ([][]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: domains[0]},
+		DNSNames:     domains,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validity),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	return [][]byte{der}, key
+}
+
+func Test_Run_WhenCalledTwice_Panics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	manager := newACMEManager(acmeSettings{
+		Domains:     []string{"backups.example.com"},
+		Challenge:   ACMEChallengeDNS01,
+		CacheFolder: t.TempDir(),
+	}, logger.GetLogger())
+
+	manager.Run(ctx, 0)
+	assert.Panics(t, func() { manager.Run(ctx, 0) })
+
+	service := &ServerTLSService{logger: logger.GetLogger()}
+
+	service.Run(ctx)
+	assert.Panics(t, func() { service.Run(ctx) })
+}
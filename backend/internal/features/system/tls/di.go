@@ -0,0 +1,13 @@
+package system_tls
+
+import (
+	"databasus-backend/internal/util/logger"
+)
+
+var serverTLSService = &ServerTLSService{
+	logger: logger.GetLogger(),
+}
+
+func GetServerTLSService() *ServerTLSService {
+	return serverTLSService
+}
@@ -0,0 +1,98 @@
+package system_tls
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/features/client_certificates"
+)
+
+type ServerTLSService struct {
+	logger *slog.Logger
+
+	acmeManager *acmeManager
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+// BuildServerTLSConfig returns the TLS config of the API server, nil when the API is served
+// over plain HTTP. Certificates come from API_TLS_CERT_FILE or an ACME CA. Client
+// certificates are optional with mutual TLS, so browsers keep using JWT while automation
+// presents a certificate issued by the client CA
+func (s *ServerTLSService) BuildServerTLSConfig() (*tls.Config, error) {
+	env := config.GetEnv()
+
+	var tlsConfig *tls.Config
+
+	switch {
+	case len(env.ACMEDomains) > 0:
+		domains := make([]string, 0, len(env.ACMEDomains))
+		for _, domain := range env.ACMEDomains {
+			if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+				domains = append(domains, domain)
+			}
+		}
+
+		s.acmeManager = newACMEManager(acmeSettings{
+			Domains:        domains,
+			Email:          env.ACMEEmail,
+			DirectoryURL:   env.ACMEDirectoryURL,
+			Challenge:      env.ACMEChallenge,
+			DNSHookCommand: env.ACMEDNSHookCommand,
+			CacheFolder:    env.ACMECacheFolder,
+		}, s.logger)
+
+		tlsConfig = s.acmeManager.TLSConfig()
+	case env.APITLSCertFile != "":
+		serverCertificate, err := tls.LoadX509KeyPair(env.APITLSCertFile, env.APITLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load API TLS certificate: %w", err)
+		}
+
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{serverCertificate},
+			MinVersion:   tls.VersionTLS12,
+		}
+	default:
+		return nil, nil
+	}
+
+	clientCAs, err := client_certificates.LoadClientCAPool()
+	if err != nil {
+		return nil, err
+	}
+
+	if clientCAs != nil {
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+// Run obtains and renews ACME certificates until ctx is done, it returns at once without
+// ACME. BuildServerTLSConfig must be called before
+func (s *ServerTLSService) Run(ctx context.Context) {
+	wasAlreadyRun := s.hasRun.Load()
+
+	s.runOnce.Do(func() {
+		s.hasRun.Store(true)
+
+		if s.acmeManager == nil || ctx.Err() != nil {
+			return
+		}
+
+		s.acmeManager.Run(ctx, config.GetEnv().ACMEHTTPPort)
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", s))
+	}
+}
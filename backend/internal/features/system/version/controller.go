@@ -0,0 +1,28 @@
+package system_version
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VersionController struct {
+	versionService *VersionService
+}
+
+func (c *VersionController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/system/version", c.GetVersion)
+}
+
+// GetVersion
+// @Summary Get version
+// @Description Get current version, latest available release and changelog of newer releases
+// @Tags system
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} GetVersionResponse
+// @Failure 401 {object} map[string]string
+// @Router /system/version [get]
+func (c *VersionController) GetVersion(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.versionService.GetVersion())
+}
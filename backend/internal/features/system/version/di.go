@@ -0,0 +1,46 @@
+package system_version
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"databasus-backend/internal/features/email"
+	users_services "databasus-backend/internal/features/users/services"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/logger"
+)
+
+var versionService = &VersionService{
+	userService:     users_services.GetUserService(),
+	brandingService: users_services.GetBrandingService(),
+	emailSender:     email.GetEmailSMTPSender(),
+	notifiedReleasesCache: cache_utils.NewCacheUtil[string](
+		cache_utils.GetValkeyClient(),
+		"version:notified_security_release:",
+	),
+	httpClient: &http.Client{Timeout: 10 * time.Second},
+	logger:     logger.GetLogger(),
+}
+var versionController = &VersionController{
+	versionService,
+}
+var versionBackgroundService = &VersionBackgroundService{
+	versionService: versionService,
+	logger:         logger.GetLogger(),
+	runOnce:        sync.Once{},
+	hasRun:         atomic.Bool{},
+}
+
+func GetVersionService() *VersionService {
+	return versionService
+}
+
+func GetVersionController() *VersionController {
+	return versionController
+}
+
+func GetVersionBackgroundService() *VersionBackgroundService {
+	return versionBackgroundService
+}
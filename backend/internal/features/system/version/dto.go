@@ -0,0 +1,24 @@
+package system_version
+
+import "time"
+
+type Release struct {
+	Version     string    `json:"version"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"publishedAt"`
+	Summary     string    `json:"summary"`
+	IsSecurity  bool      `json:"isSecurity"`
+}
+
+type GetVersionResponse struct {
+	CurrentVersion            string   `json:"currentVersion"`
+	LatestRelease             *Release `json:"latestRelease"`
+	IsUpdateAvailable         bool     `json:"isUpdateAvailable"`
+	IsSecurityUpdateAvailable bool     `json:"isSecurityUpdateAvailable"`
+	// Changelog contains releases newer than the current version, newest first
+	Changelog []Release  `json:"changelog"`
+	CheckedAt *time.Time `json:"checkedAt"`
+	// CheckError is set when last feed fetch failed and cached data is shown
+	CheckError string `json:"checkError,omitempty"`
+}
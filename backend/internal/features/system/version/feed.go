@@ -0,0 +1,158 @@
+package system_version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxFeedResponseBytes     = 5 << 20
+	maxChangelogSummaryRunes = 500
+	maxChangelogSummaryLines = 5
+)
+
+// feedRelease follows the GitHub releases API format, custom feeds
+// should return the same fields
+type feedRelease struct {
+	TagName      string    `json:"tag_name"`
+	Name         string    `json:"name"`
+	Body         string    `json:"body"`
+	HTMLURL      string    `json:"html_url"`
+	PublishedAt  time.Time `json:"published_at"`
+	IsDraft      bool      `json:"draft"`
+	IsPrerelease bool      `json:"prerelease"`
+}
+
+func fetchReleases(ctx context.Context, client *http.Client, feedURL string) ([]Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release feed request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch release feed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("release feed returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release feed: %w", err)
+	}
+
+	return parseReleases(body)
+}
+
+func parseReleases(body []byte) ([]Release, error) {
+	var feedReleases []feedRelease
+	if err := json.Unmarshal(body, &feedReleases); err != nil {
+		return nil, fmt.Errorf("failed to parse release feed: %w", err)
+	}
+
+	releases := make([]Release, 0, len(feedReleases))
+	for _, feedRelease := range feedReleases {
+		if feedRelease.IsDraft || feedRelease.IsPrerelease {
+			continue
+		}
+
+		if _, isParsed := parseVersion(feedRelease.TagName); !isParsed {
+			continue
+		}
+
+		releases = append(releases, Release{
+			Version:     feedRelease.TagName,
+			Name:        feedRelease.Name,
+			URL:         feedRelease.HTMLURL,
+			PublishedAt: feedRelease.PublishedAt,
+			Summary:     summarizeChangelog(feedRelease.Body),
+			IsSecurity:  isSecurityRelease(feedRelease.Name, feedRelease.Body),
+		})
+	}
+
+	return releases, nil
+}
+
+// compareVersions returns -1, 0 or 1. The second value is false when
+// any of versions is not semver (e.g. "dev" builds)
+func compareVersions(a, b string) (int, bool) {
+	aParts, isAParsed := parseVersion(a)
+	bParts, isBParsed := parseVersion(b)
+	if !isAParsed || !isBParsed {
+		return 0, false
+	}
+
+	for i := range aParts {
+		if aParts[i] != bParts[i] {
+			if aParts[i] < bParts[i] {
+				return -1, true
+			}
+
+			return 1, true
+		}
+	}
+
+	return 0, true
+}
+
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "-")
+
+	segments := strings.Split(version, ".")
+	if len(segments) == 0 || len(segments) > 3 {
+		return parts, false
+	}
+
+	for i, segment := range segments {
+		number, err := strconv.Atoi(segment)
+		if err != nil || number < 0 {
+			return parts, false
+		}
+
+		parts[i] = number
+	}
+
+	return parts, true
+}
+
+// isSecurityRelease relies on maintainers mentioning "security" in
+// release title or adding "[security]" marker to release notes
+func isSecurityRelease(name, body string) bool {
+	return strings.Contains(strings.ToLower(name), "security") ||
+		strings.Contains(strings.ToLower(body), "[security]")
+}
+
+func summarizeChangelog(body string) string {
+	lines := make([]string, 0, maxChangelogSummaryLines)
+	for line := range strings.SplitSeq(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		lines = append(lines, line)
+		if len(lines) == maxChangelogSummaryLines {
+			break
+		}
+	}
+
+	summary := []rune(strings.Join(lines, "\n"))
+	if len(summary) > maxChangelogSummaryRunes {
+		return string(summary[:maxChangelogSummaryRunes]) + "…"
+	}
+
+	return string(summary)
+}
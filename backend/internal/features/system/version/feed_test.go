@@ -0,0 +1,52 @@
+package system_version
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompareVersions_WithSemverVersions_ComparesNumerically(t *testing.T) {
+	comparison, isComparable := compareVersions("v2.10.0", "v2.9.3")
+	assert.True(t, isComparable)
+	assert.Equal(t, 1, comparison)
+
+	comparison, isComparable = compareVersions("2.1", "v2.1.0")
+	assert.True(t, isComparable)
+	assert.Equal(t, 0, comparison)
+
+	comparison, isComparable = compareVersions("v1.0.0-rc1", "v1.0.1")
+	assert.True(t, isComparable)
+	assert.Equal(t, -1, comparison)
+}
+
+func Test_CompareVersions_WithDevVersion_IsNotComparable(t *testing.T) {
+	_, isComparable := compareVersions("v2.0.0", "dev")
+	assert.False(t, isComparable)
+}
+
+func Test_ParseReleases_SkipsDraftsAndPrereleases_DetectsSecurityReleases(t *testing.T) {
+	body := []byte(`[
+		{"tag_name": "v2.1.0", "name": "v2.1.0", "body": "[security] Fix auth bypass", "draft": false},
+		{"tag_name": "v2.1.0-beta", "name": "beta", "body": "", "prerelease": true},
+		{"tag_name": "v2.2.0", "name": "draft", "body": "", "draft": true},
+		{"tag_name": "v2.0.0", "name": "Features", "body": "New storages", "draft": false}
+	]`)
+
+	releases, err := parseReleases(body)
+	assert.NoError(t, err)
+	assert.Len(t, releases, 2)
+
+	assert.Equal(t, "v2.1.0", releases[0].Version)
+	assert.True(t, releases[0].IsSecurity)
+	assert.Equal(t, "v2.0.0", releases[1].Version)
+	assert.False(t, releases[1].IsSecurity)
+}
+
+func Test_SummarizeChangelog_WithLongBody_IsTruncated(t *testing.T) {
+	summary := summarizeChangelog("\n\nfirst\n\nsecond\n" + strings.Repeat("x", 1000))
+
+	assert.True(t, strings.HasPrefix(summary, "first\nsecond\n"))
+	assert.Equal(t, maxChangelogSummaryRunes+1, len([]rune(summary)))
+}
@@ -0,0 +1,5 @@
+package system_version
+
+type EmailSender interface {
+	SendEmail(to, subject, body string) error
+}
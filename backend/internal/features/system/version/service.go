@@ -0,0 +1,243 @@
+package system_version
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"databasus-backend/internal/config"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/i18n"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	releasesCacheTTL        = 6 * time.Hour
+	failedCheckRetryTimeout = 15 * time.Minute
+	// notified releases are remembered long enough to not remind admins
+	// again after restarts, but still remind if instance is never updated
+	notifiedReleaseExpiry = 30 * 24 * time.Hour
+)
+
+type VersionService struct {
+	userService           *users_services.UserService
+	brandingService       *users_services.BrandingService
+	emailSender           EmailSender
+	notifiedReleasesCache *cache_utils.CacheUtil[string]
+	httpClient            *http.Client
+	logger                *slog.Logger
+
+	refreshGroup singleflight.Group
+	mu           sync.RWMutex
+	releases     []Release
+	checkedAt    *time.Time
+	checkError   string
+}
+
+func (s *VersionService) GetVersion() *GetVersionResponse {
+	s.refreshReleasesIfStale()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	currentVersion := config.GetEnv().AppVersion
+	response := &GetVersionResponse{
+		CurrentVersion: currentVersion,
+		Changelog:      []Release{},
+		CheckedAt:      s.checkedAt,
+		CheckError:     s.checkError,
+	}
+
+	if len(s.releases) == 0 {
+		return response
+	}
+
+	latestRelease := s.releases[0]
+	response.LatestRelease = &latestRelease
+
+	for _, release := range s.releases {
+		comparison, isComparable := compareVersions(release.Version, currentVersion)
+		if !isComparable || comparison <= 0 {
+			continue
+		}
+
+		response.Changelog = append(response.Changelog, release)
+		response.IsUpdateAvailable = true
+		if release.IsSecurity {
+			response.IsSecurityUpdateAvailable = true
+		}
+	}
+
+	return response
+}
+
+// NotifyAdminsAboutSecurityRelease emails active admins once per
+// security release newer than the running version
+func (s *VersionService) NotifyAdminsAboutSecurityRelease() error {
+	version := s.GetVersion()
+	if !version.IsSecurityUpdateAvailable {
+		return nil
+	}
+
+	var securityRelease *Release
+	for _, release := range version.Changelog {
+		if release.IsSecurity {
+			securityRelease = &release
+			break
+		}
+	}
+
+	if s.notifiedReleasesCache.Get(securityRelease.Version) != nil {
+		return nil
+	}
+
+	admins, err := s.userService.GetActiveAdmins()
+	if err != nil {
+		return fmt.Errorf("failed to get admins: %w", err)
+	}
+
+	for _, admin := range admins {
+		subject, body := s.buildSecurityReleaseEmail(admin, securityRelease, version.CurrentVersion)
+
+		if err := s.emailSender.SendEmail(admin.Email, subject, body); err != nil {
+			s.logger.Error(
+				"Failed to send security release email",
+				"email", admin.Email,
+				"version", securityRelease.Version,
+				"error", err,
+			)
+		}
+	}
+
+	notifiedAt := time.Now().UTC().Format(time.RFC3339)
+	s.notifiedReleasesCache.SetWithExpiration(
+		securityRelease.Version,
+		&notifiedAt,
+		notifiedReleaseExpiry,
+	)
+
+	s.logger.Info(
+		"Notified admins about security release",
+		"version", securityRelease.Version,
+		"adminsCount", len(admins),
+	)
+
+	return nil
+}
+
+func (s *VersionService) refreshReleasesIfStale() {
+	env := config.GetEnv()
+	if env.IsUpdateCheckDisabled {
+		return
+	}
+
+	s.refreshReleases(env.ReleaseFeedURL)
+}
+
+// refreshReleases fetches the feed without holding the lock, so readers keep getting
+// the previous releases meanwhile. Concurrent callers share one fetch
+func (s *VersionService) refreshReleases(feedURL string) {
+	if s.isReleasesFresh() {
+		return
+	}
+
+	_, _, _ = s.refreshGroup.Do(feedURL, func() (any, error) {
+		// another caller could finish the refresh right before this one started
+		if s.isReleasesFresh() {
+			return nil, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		releases, err := fetchReleases(ctx, s.httpClient, feedURL)
+		checkedAt := time.Now().UTC()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.checkedAt = &checkedAt
+
+		if err != nil {
+			s.logger.Warn("Failed to check for new releases", "error", err)
+			s.checkError = err.Error()
+			return nil, nil
+		}
+
+		s.releases = releases
+		s.checkError = ""
+
+		return nil, nil
+	})
+}
+
+func (s *VersionService) isReleasesFresh() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.checkedAt != nil && time.Since(*s.checkedAt) < s.getCacheTTL()
+}
+
+// getCacheTTL retries failed checks sooner, so temporary network issues
+// do not hide new releases for hours. Must be called under lock
+func (s *VersionService) getCacheTTL() time.Duration {
+	if s.checkError != "" {
+		return failedCheckRetryTimeout
+	}
+
+	return releasesCacheTTL
+}
+
+func (s *VersionService) buildSecurityReleaseEmail(
+	admin *users_models.User,
+	release *Release,
+	currentVersion string,
+) (string, string) {
+	branding := s.brandingService.GetBrandingOrDefault()
+	params := map[string]string{
+		"product": branding.ProductName,
+		"version": html.EscapeString(release.Version),
+		"current": html.EscapeString(currentVersion),
+	}
+
+	subject := i18n.Translate(admin.Locale, i18n.MessageEmailSecurityReleaseSubject, params)
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+</head>
+<body style="margin: 0; padding: 0; font-family: Arial, sans-serif; background-color: #f4f4f4;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; padding: 20px;">
+        %s
+        <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
+            %s
+        </p>
+        <p style="margin: 20px 0;">
+            <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: %s; color: white; text-decoration: none; border-radius: 4px;">
+                %s
+            </a>
+        </p>
+        %s
+    </div>
+</body>
+</html>
+`,
+		s.brandingService.BuildEmailHeader(branding),
+		i18n.Translate(admin.Locale, i18n.MessageEmailSecurityReleaseBody, params),
+		html.EscapeString(release.URL),
+		branding.AccentColor,
+		i18n.Translate(admin.Locale, i18n.MessageEmailSecurityReleaseReleaseNotes, nil),
+		s.brandingService.BuildEmailFooter(branding),
+	)
+
+	return subject, body
+}
@@ -0,0 +1,73 @@
+package system_version
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RefreshReleases_WhenFeedIsSlow_ReadersAreNotBlockedAndFeedFetchedOnce(t *testing.T) {
+	requestsCount := atomic.Int32{}
+	isFeedReleased := make(chan struct{})
+
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requestsCount.Add(1)
+		<-isFeedReleased
+
+		_, _ = w.Write([]byte(`[{"tag_name": "v2.0.0", "name": "v2.0.0", "body": ""}]`))
+	}))
+	defer feedServer.Close()
+
+	staleCheckedAt := time.Now().UTC().Add(-2 * releasesCacheTTL)
+	service := &VersionService{
+		httpClient: feedServer.Client(),
+		logger:     slog.Default(),
+		releases:   []Release{{Version: "v1.0.0"}},
+		checkedAt:  &staleCheckedAt,
+	}
+
+	refreshesGroup := sync.WaitGroup{}
+	for range 5 {
+		refreshesGroup.Add(1)
+		go func() {
+			defer refreshesGroup.Done()
+			service.refreshReleases(feedServer.URL)
+		}()
+	}
+
+	assert.Eventually(
+		t,
+		func() bool { return requestsCount.Load() == 1 },
+		time.Second,
+		time.Millisecond,
+	)
+
+	// the feed is still being fetched, previous releases are served meanwhile
+	isRead := make(chan string)
+	go func() {
+		service.mu.RLock()
+		defer service.mu.RUnlock()
+
+		isRead <- service.releases[0].Version
+	}()
+
+	select {
+	case version := <-isRead:
+		assert.Equal(t, "v1.0.0", version)
+	case <-time.After(time.Second):
+		t.Fatal("releases are locked while the feed is fetched")
+	}
+
+	close(isFeedReleased)
+	refreshesGroup.Wait()
+
+	assert.Equal(t, int32(1), requestsCount.Load())
+	assert.Equal(t, "v2.0.0", service.releases[0].Version)
+	assert.Empty(t, service.checkError)
+}
@@ -0,0 +1,92 @@
+package task_watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const checkInterval = 30 * time.Second
+
+var (
+	ErrTaskTimedOut = errors.New("task timed out")
+	ErrTaskHung     = errors.New("task hung")
+)
+
+// TaskWatchdog cancels a task running longer than its timeout or not reporting progress
+// for longer than its hang timeout. A zero timeout disables the check
+type TaskWatchdog struct {
+	timeout     time.Duration
+	hangTimeout time.Duration
+
+	startedAt      time.Time
+	lastProgressAt atomic.Int64
+	abortErr       atomic.Pointer[error]
+}
+
+func NewTaskWatchdog(timeout time.Duration, hangTimeout time.Duration) *TaskWatchdog {
+	now := time.Now().UTC()
+
+	watchdog := &TaskWatchdog{
+		timeout:     timeout,
+		hangTimeout: hangTimeout,
+		startedAt:   now,
+	}
+	watchdog.lastProgressAt.Store(now.UnixNano())
+
+	return watchdog
+}
+
+func (w *TaskWatchdog) ReportProgress() {
+	w.lastProgressAt.Store(time.Now().UTC().UnixNano())
+}
+
+// Watch checks the task until ctx is done and calls cancel once a limit is exceeded,
+// the reason is returned by GetAbortError afterwards
+func (w *TaskWatchdog) Watch(ctx context.Context, cancel context.CancelFunc) {
+	if w.timeout <= 0 && w.hangTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Check(time.Now().UTC()); err != nil {
+				w.abortErr.Store(&err)
+				cancel()
+
+				return
+			}
+		}
+	}
+}
+
+func (w *TaskWatchdog) Check(now time.Time) error {
+	if w.timeout > 0 && now.Sub(w.startedAt) > w.timeout {
+		return fmt.Errorf("%w after %s", ErrTaskTimedOut, w.timeout)
+	}
+
+	lastProgressAt := time.Unix(0, w.lastProgressAt.Load())
+	if w.hangTimeout > 0 && now.Sub(lastProgressAt) > w.hangTimeout {
+		return fmt.Errorf("%w: no progress in %s", ErrTaskHung, w.hangTimeout)
+	}
+
+	return nil
+}
+
+// GetAbortError returns nil if the watchdog did not cancel the task
+func (w *TaskWatchdog) GetAbortError() error {
+	abortErr := w.abortErr.Load()
+	if abortErr == nil {
+		return nil
+	}
+
+	return *abortErr
+}
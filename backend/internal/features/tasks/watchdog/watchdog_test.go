@@ -0,0 +1,31 @@
+package task_watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Check_WhenTimeoutExceeded_ReturnsTimedOutError(t *testing.T) {
+	watchdog := NewTaskWatchdog(time.Hour, 0)
+
+	assert.NoError(t, watchdog.Check(watchdog.startedAt.Add(59*time.Minute)))
+	assert.ErrorIs(t, watchdog.Check(watchdog.startedAt.Add(61*time.Minute)), ErrTaskTimedOut)
+}
+
+func Test_Check_WhenNoProgressReported_ReturnsHungError(t *testing.T) {
+	watchdog := NewTaskWatchdog(0, 10*time.Minute)
+
+	assert.ErrorIs(t, watchdog.Check(watchdog.startedAt.Add(11*time.Minute)), ErrTaskHung)
+
+	watchdog.ReportProgress()
+	assert.NoError(t, watchdog.Check(time.Now().UTC().Add(9*time.Minute)))
+}
+
+func Test_Check_WhenLimitsDisabled_NeverAborts(t *testing.T) {
+	watchdog := NewTaskWatchdog(0, 0)
+
+	assert.NoError(t, watchdog.Check(watchdog.startedAt.Add(30*24*time.Hour)))
+	assert.NoError(t, watchdog.GetAbortError())
+}
@@ -0,0 +1,83 @@
+package users_controllers
+
+import (
+	"net/http"
+
+	user_enums "databasus-backend/internal/features/users/enums"
+	user_middleware "databasus-backend/internal/features/users/middleware"
+	user_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BrandingController struct {
+	brandingService *users_services.BrandingService
+}
+
+// RegisterPublicRoutes exposes branding without auth, because it is
+// needed to render sign in page
+func (c *BrandingController) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.GET("/system/branding", c.GetBranding)
+}
+
+func (c *BrandingController) RegisterRoutes(router *gin.RouterGroup) {
+	router.PUT(
+		"/system/branding",
+		user_middleware.RequireRole(user_enums.UserRoleAdmin),
+		c.UpdateBranding,
+	)
+}
+
+// GetBranding
+// @Summary Get branding
+// @Description Get instance branding (product name, logo, accent color and support email)
+// @Tags settings
+// @Produce json
+// @Success 200 {object} users_models.BrandingSettings
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /system/branding [get]
+func (c *BrandingController) GetBranding(ctx *gin.Context) {
+	branding, err := c.brandingService.GetBranding()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get branding"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, branding)
+}
+
+// UpdateBranding
+// @Summary Update branding
+// @Description Update instance branding (admin only)
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body users_models.BrandingSettings true "Branding data"
+// @Success 200 {object} users_models.BrandingSettings
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Router /system/branding [put]
+func (c *BrandingController) UpdateBranding(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request user_models.BrandingSettings
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	branding, err := c.brandingService.UpdateBranding(request, user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, branding)
+}
@@ -0,0 +1,178 @@
+package users_controllers
+
+import (
+	"net/http"
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
+	users_testing "databasus-backend/internal/features/users/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetBranding_WithoutAuth_ReturnsBranding(t *testing.T) {
+	router := createBrandingTestRouter()
+
+	var response users_models.BrandingSettings
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/branding",
+		"",
+		http.StatusOK,
+		&response,
+	)
+
+	assert.NotEmpty(t, response.ProductName)
+	assert.NotEmpty(t, response.AccentColor)
+}
+
+func Test_UpdateBranding_WhenUserIsAdmin_BrandingUpdated(t *testing.T) {
+	router := createBrandingTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	defer resetBrandingToDefaults(t, router, admin.Token)
+
+	request := users_models.BrandingSettings{
+		ProductName:  "Acme Backups",
+		LogoURL:      "https://acme.example.com/logo.png",
+		AccentColor:  "#ff6600",
+		SupportEmail: "support@acme.example.com",
+	}
+
+	var response users_models.BrandingSettings
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/system/branding",
+		"Bearer "+admin.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(t, request.ProductName, response.ProductName)
+	assert.Equal(t, request.LogoURL, response.LogoURL)
+	assert.Equal(t, request.AccentColor, response.AccentColor)
+	assert.Equal(t, request.SupportEmail, response.SupportEmail)
+
+	signature := users_services.GetBrandingService().GetNotificationSignature()
+	assert.Contains(t, signature, "Acme Backups")
+	assert.Contains(t, signature, "support@acme.example.com")
+}
+
+func Test_BuildEmailHeaderAndFooter_WithHTMLInBranding_EscapesValues(t *testing.T) {
+	brandingService := users_services.GetBrandingService()
+	branding := &users_models.BrandingSettings{
+		ProductName:  `Acme"><script>alert(1)</script>`,
+		LogoURL:      `https://acme.example.com/logo.png?a=1&b="onerror="alert(1)`,
+		SupportEmail: `"<b>support</b>"@acme.example.com`,
+	}
+
+	header := brandingService.BuildEmailHeader(branding)
+	assert.Contains(t, header, `alt="Acme&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`)
+	assert.Contains(t, header, `src="https://acme.example.com/logo.png?a=1&amp;b=&#34;onerror=&#34;alert(1)"`)
+	assert.NotContains(t, header, "<script>")
+
+	footer := brandingService.BuildEmailFooter(branding)
+	assert.Contains(t, footer, "&#34;&lt;b&gt;support&lt;/b&gt;&#34;@acme.example.com")
+	assert.NotContains(t, footer, "<b>")
+}
+
+func Test_UpdateBranding_WhenUserIsMember_ReturnsForbidden(t *testing.T) {
+	router := createBrandingTestRouter()
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/system/branding",
+		"Bearer "+member.Token,
+		users_models.BrandingSettings{
+			ProductName: "Acme Backups",
+			AccentColor: "#ff6600",
+		},
+		http.StatusForbidden,
+	)
+}
+
+func Test_UpdateBranding_WithInvalidData_ReturnsBadRequest(t *testing.T) {
+	router := createBrandingTestRouter()
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	testCases := []struct {
+		name    string
+		request users_models.BrandingSettings
+	}{
+		{
+			"empty product name",
+			users_models.BrandingSettings{AccentColor: "#ff6600"},
+		},
+		{
+			"invalid accent color",
+			users_models.BrandingSettings{ProductName: "Acme", AccentColor: "orange"},
+		},
+		{
+			"invalid logo url",
+			users_models.BrandingSettings{
+				ProductName: "Acme",
+				AccentColor: "#ff6600",
+				LogoURL:     "javascript:alert(1)",
+			},
+		},
+		{
+			"invalid support email",
+			users_models.BrandingSettings{
+				ProductName:  "Acme",
+				AccentColor:  "#ff6600",
+				SupportEmail: "not-an-email",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			test_utils.MakePutRequest(
+				t,
+				router,
+				"/api/v1/system/branding",
+				"Bearer "+admin.Token,
+				tc.request,
+				http.StatusBadRequest,
+			)
+		})
+	}
+}
+
+func createBrandingTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	v1 := router.Group("/api/v1")
+	GetBrandingController().RegisterPublicRoutes(v1)
+
+	protected := v1.Group("").Use(users_middleware.AuthMiddleware(users_services.GetUserService()))
+	GetBrandingController().RegisterRoutes(protected.(*gin.RouterGroup))
+
+	users_services.GetBrandingService().SetAuditLogWriter(&AuditLogWriterStub{})
+
+	return router
+}
+
+func resetBrandingToDefaults(t *testing.T, router *gin.Engine, adminToken string) {
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/system/branding",
+		"Bearer "+adminToken,
+		users_models.BrandingSettings{
+			ProductName: users_models.DefaultBrandingProductName,
+			AccentColor: users_models.DefaultBrandingAccentColor,
+		},
+		http.StatusOK,
+	)
+}
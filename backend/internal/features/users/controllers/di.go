@@ -14,6 +14,10 @@ var settingsController = &SettingsController{
 	users_services.GetSettingsService(),
 }
 
+var brandingController = &BrandingController{
+	users_services.GetBrandingService(),
+}
+
 var managementController = &ManagementController{
 	users_services.GetManagementService(),
 }
@@ -29,3 +33,7 @@ func GetSettingsController() *SettingsController {
 func GetManagementController() *ManagementController {
 	return managementController
 }
+
+func GetBrandingController() *BrandingController {
+	return brandingController
+}
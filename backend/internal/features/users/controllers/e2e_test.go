@@ -259,3 +259,12 @@ func (a *AuditLogWriterStub) WriteAuditLog(
 ) {
 	// do nothing
 }
+
+func (a *AuditLogWriterStub) WriteClientAuditLog(
+	message string,
+	ipAddress string,
+	userID *uuid.UUID,
+	workspaceID *uuid.UUID,
+) {
+	// do nothing
+}
@@ -0,0 +1,131 @@
+package users_controllers
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	users_dto "databasus-backend/internal/features/users/dto"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_services "databasus-backend/internal/features/users/services"
+	users_testing "databasus-backend/internal/features/users/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var verificationTokenPattern = regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]+`)
+
+func Test_UpdateUserInfo_WhenSMTPConfigured_EmailChangedAfterVerification(t *testing.T) {
+	router := createUserTestRouter()
+	mockEmailSender := users_testing.NewMockEmailSender()
+	mockEmailSender.IsSMTPConfigured = true
+	users_services.GetUserService().SetEmailSender(mockEmailSender)
+	defer users_services.GetUserService().SetEmailSender(users_testing.NewMockEmailSender())
+
+	testUser := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	newEmail := "changed" + uuid.New().String() + "@example.com"
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/me",
+		"Bearer "+testUser.Token,
+		users_dto.UpdateUserInfoRequestDTO{Email: &newEmail},
+		http.StatusOK,
+	)
+
+	var profile users_dto.UserProfileResponseDTO
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/me",
+		"Bearer "+testUser.Token,
+		http.StatusOK,
+		&profile,
+	)
+	assert.Equal(t, testUser.Email, profile.Email)
+	assert.Equal(t, newEmail, *profile.PendingEmail)
+
+	assert.Len(t, mockEmailSender.SentEmails, 1)
+	assert.Equal(t, newEmail, mockEmailSender.SentEmails[0].To)
+
+	token := verificationTokenPattern.FindString(mockEmailSender.SentEmails[0].Body)
+	assert.NotEmpty(t, token)
+
+	request := users_dto.VerifyEmailRequestDTO{Token: token}
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/verify-email",
+		"",
+		request,
+		http.StatusOK,
+	)
+
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/me",
+		"Bearer "+testUser.Token,
+		http.StatusOK,
+		&profile,
+	)
+	assert.Equal(t, newEmail, profile.Email)
+	assert.True(t, profile.IsEmailVerified)
+	assert.Nil(t, profile.PendingEmail)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/verify-email",
+		"",
+		request,
+		http.StatusBadRequest,
+	)
+}
+
+func Test_SendVerificationEmail_WhenSentTooOften_ReturnsTooManyRequests(t *testing.T) {
+	router := createUserTestRouter()
+	mockEmailSender := users_testing.NewMockEmailSender()
+	users_services.GetUserService().SetEmailSender(mockEmailSender)
+
+	testUser := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	for range 3 {
+		test_utils.MakePostRequest(
+			t,
+			router,
+			"/api/v1/users/me/send-verification-email",
+			"Bearer "+testUser.Token,
+			nil,
+			http.StatusOK,
+		)
+	}
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/me/send-verification-email",
+		"Bearer "+testUser.Token,
+		nil,
+		http.StatusTooManyRequests,
+	)
+
+	assert.Len(t, mockEmailSender.SentEmails, 3)
+	assert.Equal(t, testUser.Email, mockEmailSender.SentEmails[0].To)
+}
+
+func Test_VerifyEmail_WithTamperedToken_ReturnsBadRequest(t *testing.T) {
+	router := createUserTestRouter()
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/verify-email",
+		"",
+		users_dto.VerifyEmailRequestDTO{Token: "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.invalid"},
+		http.StatusBadRequest,
+	)
+}
@@ -90,12 +90,13 @@ func (c *ManagementController) GetUsers(ctx *gin.Context) {
 	userProfiles := make([]user_dto.UserProfileResponseDTO, len(users))
 	for i, u := range users {
 		userProfiles[i] = user_dto.UserProfileResponseDTO{
-			ID:        u.ID,
-			Email:     u.Email,
-			Name:      u.Name,
-			Role:      u.Role,
-			IsActive:  u.IsActiveUser(),
-			CreatedAt: u.CreatedAt,
+			ID:              u.ID,
+			Email:           u.Email,
+			Name:            u.Name,
+			Role:            u.Role,
+			IsActive:        u.IsActiveUser(),
+			IsEmailVerified: u.IsEmailVerified,
+			CreatedAt:       u.CreatedAt,
 		}
 	}
 
@@ -140,12 +141,13 @@ func (c *ManagementController) GetUserProfile(ctx *gin.Context) {
 	}
 
 	profile := user_dto.UserProfileResponseDTO{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Role:      user.Role,
-		IsActive:  user.IsActiveUser(),
-		CreatedAt: user.CreatedAt,
+		ID:              user.ID,
+		Email:           user.Email,
+		Name:            user.Name,
+		Role:            user.Role,
+		IsActive:        user.IsActiveUser(),
+		IsEmailVerified: user.IsEmailVerified,
+		CreatedAt:       user.CreatedAt,
 	}
 
 	ctx.JSON(http.StatusOK, profile)
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"databasus-backend/internal/features/encryption/secrets"
 	users_dto "databasus-backend/internal/features/users/dto"
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
@@ -13,6 +14,7 @@ import (
 	"databasus-backend/internal/storage"
 	test_utils "databasus-backend/internal/util/testing"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
@@ -591,3 +593,102 @@ func extractCodeFromEmail(emailBody string) string {
 func isDigit(b byte) bool {
 	return b >= '0' && b <= '9'
 }
+
+func Test_ResetPasswordByToken_WithValidToken_PasswordResetOnce(t *testing.T) {
+	router := createUserTestRouter()
+	mockEmailSender := users_testing.NewMockEmailSender()
+	users_services.GetUserService().SetEmailSender(mockEmailSender)
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resetCode := &users_models.PasswordResetCode{
+		ID:         uuid.New(),
+		UserID:     user.UserID,
+		HashedCode: "unused",
+		ExpiresAt:  time.Now().UTC().Add(30 * time.Minute),
+		IsUsed:     false,
+		CreatedAt:  time.Now().UTC(),
+	}
+	storage.GetDb().Create(resetCode)
+
+	request := users_dto.ResetPasswordByTokenRequestDTO{
+		Token:       signResetPasswordToken(t, resetCode, "password_reset"),
+		NewPassword: "newpassword123",
+	}
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/reset-password-by-token",
+		"",
+		request,
+		http.StatusOK,
+	)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/signin",
+		"",
+		users_dto.SignInRequestDTO{Email: user.Email, Password: "newpassword123"},
+		http.StatusOK,
+	)
+
+	resp := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/reset-password-by-token",
+		"",
+		request,
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(resp.Body), "invalid or expired")
+}
+
+func Test_ResetPasswordByToken_WithTokenOfOtherPurpose_ReturnsBadRequest(t *testing.T) {
+	router := createUserTestRouter()
+	user := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	resetCode := &users_models.PasswordResetCode{
+		ID:         uuid.New(),
+		UserID:     user.UserID,
+		HashedCode: "unused",
+		ExpiresAt:  time.Now().UTC().Add(30 * time.Minute),
+		IsUsed:     false,
+		CreatedAt:  time.Now().UTC(),
+	}
+	storage.GetDb().Create(resetCode)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/users/reset-password-by-token",
+		"",
+		users_dto.ResetPasswordByTokenRequestDTO{
+			Token:       signResetPasswordToken(t, resetCode, "email_verification"),
+			NewPassword: "newpassword123",
+		},
+		http.StatusBadRequest,
+	)
+}
+
+// signResetPasswordToken builds the token of the reset link, as the link is
+// only sent when DATABASUS_URL is set
+func signResetPasswordToken(
+	t *testing.T,
+	resetCode *users_models.PasswordResetCode,
+	purpose string,
+) string {
+	secretKey, err := secrets.GetSecretKeyService().GetSecretKey()
+	assert.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":     resetCode.UserID.String(),
+		"jti":     resetCode.ID.String(),
+		"exp":     resetCode.ExpiresAt.Unix(),
+		"purpose": purpose,
+	}).SignedString([]byte(secretKey))
+	assert.NoError(t, err)
+
+	return token
+}
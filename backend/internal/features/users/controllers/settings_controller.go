@@ -22,6 +22,24 @@ func (c *SettingsController) RegisterRoutes(router *gin.RouterGroup) {
 		user_middleware.RequireRole(user_enums.UserRoleAdmin),
 		c.UpdateUsersSettings,
 	)
+
+	router.GET("/users/settings/security", c.GetSecurityPolicy)
+	router.PUT(
+		"/users/settings/security",
+		user_middleware.RequireRole(user_enums.UserRoleAdmin),
+		c.UpdateSecurityPolicy,
+	)
+
+	router.GET(
+		"/users/settings/headers",
+		user_middleware.RequireRole(user_enums.UserRoleAdmin),
+		c.GetSecurityHeaders,
+	)
+	router.PUT(
+		"/users/settings/headers",
+		user_middleware.RequireRole(user_enums.UserRoleAdmin),
+		c.UpdateSecurityHeaders,
+	)
 }
 
 // GetUsersSettings
@@ -79,3 +97,114 @@ func (c *SettingsController) UpdateUsersSettings(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, settings)
 }
+
+// GetSecurityPolicy
+// @Summary Get security policy
+// @Description Get session lifetime, password policy and allowed SSO domains of the instance
+// @Tags settings
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} users_models.SecurityPolicy
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/settings/security [get]
+func (c *SettingsController) GetSecurityPolicy(ctx *gin.Context) {
+	policy, err := c.settingsService.GetSecurityPolicy()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get security policy"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// UpdateSecurityPolicy
+// @Summary Update security policy
+// @Description Update session lifetime, password policy and allowed SSO domains (admin only)
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body users_models.SecurityPolicy true "Security policy"
+// @Success 200 {object} users_models.SecurityPolicy
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Router /users/settings/security [put]
+func (c *SettingsController) UpdateSecurityPolicy(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request user_models.SecurityPolicy
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	policy, err := c.settingsService.UpdateSecurityPolicy(request, user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// GetSecurityHeaders
+// @Summary Get security headers
+// @Description Get CORS origins, content security policy, HSTS and frame ancestors of the instance (admin only)
+// @Tags settings
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} users_models.SecurityHeaders
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/settings/headers [get]
+func (c *SettingsController) GetSecurityHeaders(ctx *gin.Context) {
+	headers, err := c.settingsService.GetSecurityHeaders()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get security headers"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, headers)
+}
+
+// UpdateSecurityHeaders
+// @Summary Update security headers
+// @Description Update CORS origins, content security policy, HSTS and frame ancestors applied to all responses (admin only)
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body users_models.SecurityHeaders true "Security headers"
+// @Success 200 {object} users_models.SecurityHeaders
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Router /users/settings/headers [put]
+func (c *SettingsController) UpdateSecurityHeaders(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request user_models.SecurityHeaders
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	headers, err := c.settingsService.UpdateSecurityHeaders(request, user)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, headers)
+}
@@ -2,13 +2,20 @@ package users_controllers
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"databasus-backend/internal/features/encryption/secrets"
 	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
 	users_models "databasus-backend/internal/features/users/models"
+	users_services "databasus-backend/internal/features/users/services"
 	users_testing "databasus-backend/internal/features/users/testing"
 	test_utils "databasus-backend/internal/util/testing"
 
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -122,6 +129,58 @@ func Test_UpdateUserSettings_WithPartialData_SettingsUpdated(t *testing.T) {
 	assert.True(t, response.IsMemberAllowedToCreateWorkspaces)
 }
 
+func Test_UpdateUserSettings_WithRedactionLevels_LevelsSavedAndInvalidRejected(t *testing.T) {
+	users_testing.ResetSettingsToDefaults()
+	defer users_testing.ResetSettingsToDefaults()
+	router := createSettingsTestRouter()
+
+	testUser := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	request := users_models.UsersSettings{
+		IsAllowExternalRegistrations:      true,
+		IsAllowMemberInvitations:          true,
+		IsMemberAllowedToCreateWorkspaces: true,
+		SystemStorageRedactionLevels: users_enums.StorageRedactionLevels{
+			users_enums.WorkspaceRoleAdmin: users_enums.StorageRedactionLevelMetadataOnly,
+		},
+	}
+
+	var response users_models.UsersSettings
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/settings",
+		"Bearer "+testUser.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Equal(
+		t,
+		users_enums.StorageRedactionLevelMetadataOnly,
+		response.GetSystemStorageRedactionLevel(users_enums.WorkspaceRoleAdmin),
+	)
+	assert.Equal(
+		t,
+		users_enums.StorageRedactionLevelFull,
+		response.GetSystemStorageRedactionLevel(users_enums.WorkspaceRoleViewer),
+	)
+
+	request.SystemStorageRedactionLevels = users_enums.StorageRedactionLevels{
+		users_enums.WorkspaceRoleAdmin: "PARTIAL",
+	}
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings",
+		"Bearer "+testUser.Token,
+		request,
+		http.StatusBadRequest,
+	)
+}
+
 func Test_UpdateUserSettings_WhenUserIsMember_ReturnsForbidden(t *testing.T) {
 	users_testing.ResetSettingsToDefaults()
 	router := createSettingsTestRouter()
@@ -180,3 +239,212 @@ func Test_UpdateUserSettings_WithoutAuth_ReturnsUnauthorized(t *testing.T) {
 		http.StatusUnauthorized,
 	)
 }
+
+func Test_UpdateSecurityPolicy_WhenUserIsAdmin_PolicySavedAndNormalized(t *testing.T) {
+	users_testing.ResetSettingsToDefaults()
+	defer users_testing.ResetSettingsToDefaults()
+	router := createSettingsTestRouter()
+
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	request := users_models.SecurityPolicy{
+		SessionLifetimeHours: 12,
+		AllowedSSODomains:    []string{" @Example.com ", "example.com", "corp.example.org"},
+	}
+
+	var response users_models.SecurityPolicy
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/settings/security",
+		"Bearer "+admin.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+	assert.Equal(t, 12, response.SessionLifetimeHours)
+	assert.Equal(t, []string{"example.com", "corp.example.org"}, response.AllowedSSODomains)
+
+	var memberResponse users_models.SecurityPolicy
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/settings/security",
+		"Bearer "+member.Token,
+		http.StatusOK,
+		&memberResponse,
+	)
+	assert.Equal(t, response, memberResponse)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings/security",
+		"Bearer "+member.Token,
+		request,
+		http.StatusForbidden,
+	)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings/security",
+		"Bearer "+admin.Token,
+		users_models.SecurityPolicy{PasswordMinLength: 4},
+		http.StatusBadRequest,
+	)
+
+	// saving other settings keeps the policy
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings",
+		"Bearer "+admin.Token,
+		users_models.UsersSettings{IsAllowExternalRegistrations: true},
+		http.StatusOK,
+	)
+
+	policy, err := users_services.GetSettingsService().GetSecurityPolicy()
+	assert.NoError(t, err)
+	assert.Equal(t, 12, policy.SessionLifetimeHours)
+}
+
+func Test_GetUserFromToken_WhenSessionOlderThanLifetime_ReturnsError(t *testing.T) {
+	users_testing.ResetSettingsToDefaults()
+	defer users_testing.ResetSettingsToDefaults()
+	createSettingsTestRouter()
+
+	testUser := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	user, err := users_services.GetUserService().GetUserByID(testUser.UserID)
+	assert.NoError(t, err)
+
+	secretKey, err := secrets.GetSecretKeyService().GetSecretKey()
+	assert.NoError(t, err)
+
+	oldToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":                  user.ID.String(),
+		"exp":                  time.Now().UTC().Add(24 * time.Hour).Unix(),
+		"iat":                  time.Now().UTC().Add(-2 * time.Hour).Unix(),
+		"role":                 string(user.Role),
+		"passwordCreationTime": user.PasswordCreationTime.Unix(),
+	}).SignedString([]byte(secretKey))
+	assert.NoError(t, err)
+
+	_, err = users_services.GetUserService().GetUserFromToken(oldToken)
+	assert.NoError(t, err)
+
+	admin, err := users_services.GetUserService().GetUserByID(
+		users_testing.CreateTestUser(users_enums.UserRoleAdmin).UserID,
+	)
+	assert.NoError(t, err)
+
+	_, err = users_services.GetSettingsService().UpdateSecurityPolicy(
+		users_models.SecurityPolicy{SessionLifetimeHours: 1},
+		admin,
+	)
+	assert.NoError(t, err)
+
+	_, err = users_services.GetUserService().GetUserFromToken(oldToken)
+	assert.Error(t, err)
+
+	_, err = users_services.GetUserService().GetUserFromToken(testUser.Token)
+	assert.NoError(t, err)
+}
+
+func Test_UpdateSecurityHeaders_WhenUserIsAdmin_HeadersAppliedToResponses(t *testing.T) {
+	users_testing.ResetSettingsToDefaults()
+	defer users_testing.ResetSettingsToDefaults()
+	router := createSettingsTestRouter()
+	router.Use(users_middleware.SecurityHeadersMiddleware(users_services.GetSettingsService()))
+	router.GET("/ping", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	request := users_models.SecurityHeaders{
+		CORSAllowedOrigins:    []string{"https://portal.example.com/", "https://portal.example.com"},
+		ContentSecurityPolicy: "default-src 'self'",
+		HSTSMaxAgeSeconds:     31536000,
+		FrameAncestors:        []string{"'self'", "https://wiki.example.com"},
+	}
+
+	var response users_models.SecurityHeaders
+	test_utils.MakePutRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/users/settings/headers",
+		"Bearer "+admin.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+	assert.Equal(t, []string{"https://portal.example.com"}, response.CORSAllowedOrigins)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings/headers",
+		"Bearer "+member.Token,
+		request,
+		http.StatusForbidden,
+	)
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings/headers",
+		"Bearer "+admin.Token,
+		users_models.SecurityHeaders{CORSAllowedOrigins: []string{"portal.example.com/path"}},
+		http.StatusBadRequest,
+	)
+
+	allowed := httptest.NewRecorder()
+	allowedRequest := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	allowedRequest.Header.Set("Origin", "https://portal.example.com")
+	router.ServeHTTP(allowed, allowedRequest)
+
+	assert.Equal(
+		t,
+		"https://portal.example.com",
+		allowed.Header().Get("Access-Control-Allow-Origin"),
+	)
+	assert.Equal(
+		t,
+		"default-src 'self'; frame-ancestors 'self' https://wiki.example.com",
+		allowed.Header().Get("Content-Security-Policy"),
+	)
+	assert.Equal(t, "max-age=31536000", allowed.Header().Get("Strict-Transport-Security"))
+
+	refused := httptest.NewRecorder()
+	refusedRequest := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	refusedRequest.Header.Set("Origin", "https://evil.example.com")
+	router.ServeHTTP(refused, refusedRequest)
+
+	assert.Empty(t, refused.Header().Get("Access-Control-Allow-Origin"))
+
+	test_utils.MakePutRequest(
+		t,
+		router,
+		"/api/v1/users/settings/headers",
+		"Bearer "+admin.Token,
+		users_models.SecurityHeaders{},
+		http.StatusOK,
+	)
+}
+
+func Test_SecurityPolicy_ValidatePassword_ChecksLengthAndComplexity(t *testing.T) {
+	policy := users_models.SecurityPolicy{
+		PasswordMinLength:            12,
+		IsPasswordComplexityRequired: true,
+	}
+
+	assert.Error(t, policy.ValidatePassword("Short1pass"))
+	assert.Error(t, policy.ValidatePassword("longpassword123"))
+	assert.Error(t, policy.ValidatePassword("LongPasswordOnly"))
+	assert.NoError(t, policy.ValidatePassword("LongPassword123"))
+
+	defaultPolicy := users_models.SecurityPolicy{}
+	assert.Error(t, defaultPolicy.ValidatePassword("1234567"))
+	assert.NoError(t, defaultPolicy.ValidatePassword("12345678"))
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const clientIPRateLimitMultiplier = 5
+
 type UserController struct {
 	userService *users_services.UserService
 	rateLimiter *cache_utils.RateLimiter
@@ -31,6 +33,8 @@ func (c *UserController) RegisterRoutes(router *gin.RouterGroup) {
 	// Password reset (no auth required)
 	router.POST("/users/send-reset-password-code", c.SendResetPasswordCode)
 	router.POST("/users/reset-password", c.ResetPassword)
+	router.POST("/users/reset-password-by-token", c.ResetPasswordByToken)
+	router.POST("/users/verify-email", c.VerifyEmail)
 
 	// OAuth callbacks
 	router.POST("/auth/github/callback", c.HandleGitHubOAuth)
@@ -42,6 +46,7 @@ func (c *UserController) RegisterProtectedRoutes(router *gin.RouterGroup) {
 	router.PUT("/users/me", c.UpdateUserInfo)
 	router.PUT("/users/change-password", c.ChangePassword)
 	router.POST("/users/invite", c.InviteUser)
+	router.POST("/users/me/send-verification-email", c.SendVerificationEmail)
 }
 
 // SignUp
@@ -88,8 +93,13 @@ func (c *UserController) SignIn(ctx *gin.Context) {
 		return
 	}
 
-	allowed, _ := c.rateLimiter.CheckLimit(request.Email, "signin", 10, 1*time.Minute)
-	if !allowed {
+	if c.isRateLimited(
+		ctx,
+		request.Email,
+		"signin",
+		config.GetReloadableSettings().SignInRateLimitPerMinute,
+		1*time.Minute,
+	) {
 		ctx.JSON(
 			http.StatusTooManyRequests,
 			gin.H{"error": "Rate limit exceeded. Please try again later."},
@@ -97,7 +107,10 @@ func (c *UserController) SignIn(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.userService.SignIn(&request)
+	response, err := c.userService.SignIn(&request, user_dto.SignInClientDTO{
+		IPAddress: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	})
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -363,13 +376,13 @@ func (c *UserController) SendResetPasswordCode(ctx *gin.Context) {
 		return
 	}
 
-	allowed, _ := c.rateLimiter.CheckLimit(
+	if c.isRateLimited(
+		ctx,
 		request.Email,
 		"reset-password",
-		3,
+		config.GetReloadableSettings().PasswordResetRateLimitPerHour,
 		1*time.Hour,
-	)
-	if !allowed {
+	) {
 		ctx.JSON(
 			http.StatusTooManyRequests,
 			gin.H{"error": "Rate limit exceeded. Please try again later."},
@@ -411,3 +424,116 @@ func (c *UserController) ResetPassword(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
 }
+
+// ResetPasswordByToken
+// @Summary Reset password with link token
+// @Description Reset user password using the token of the link sent via email
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body users_dto.ResetPasswordByTokenRequestDTO true "Reset password data"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /users/reset-password-by-token [post]
+func (c *UserController) ResetPasswordByToken(ctx *gin.Context) {
+	var request user_dto.ResetPasswordByTokenRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	err := c.userService.ResetPasswordByToken(request.Token, request.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// SendVerificationEmail
+// @Summary Send email verification link
+// @Description Send a verification link to the pending email of the current user, or to the current email when no change is pending
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /users/me/send-verification-email [post]
+func (c *UserController) SendVerificationEmail(ctx *gin.Context) {
+	user, ok := user_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := c.userService.SendVerificationEmail(user); err != nil {
+		if errors.Is(err, users_errors.ErrTooManyVerificationEmails) {
+			ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+}
+
+// VerifyEmail
+// @Summary Verify email
+// @Description Verify the email with the token of the link sent to it. A verified pending email becomes the email of the user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body users_dto.VerifyEmailRequestDTO true "Verification token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /users/verify-email [post]
+func (c *UserController) VerifyEmail(ctx *gin.Context) {
+	var request user_dto.VerifyEmailRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := c.userService.VerifyEmail(request.Token); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// isRateLimited limits attempts per email and per client IP, so one address cannot go
+// through many accounts. Several users may share an address behind NAT, so the IP gets
+// a larger allowance
+func (c *UserController) isRateLimited(
+	ctx *gin.Context,
+	email string,
+	endpoint string,
+	maxRequests int,
+	window time.Duration,
+) bool {
+	isEmailAllowed, _ := c.rateLimiter.CheckLimit(email, endpoint, maxRequests, window)
+	if !isEmailAllowed {
+		return true
+	}
+
+	// requests made in-process have no peer address to limit
+	clientIP := ctx.ClientIP()
+	if clientIP == "" {
+		return false
+	}
+
+	isIPAllowed, _ := c.rateLimiter.CheckLimit(
+		clientIP,
+		endpoint+"-ip",
+		maxRequests*clientIPRateLimitMultiplier,
+		window,
+	)
+
+	return !isIPAllowed
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"databasus-backend/internal/config"
 	users_dto "databasus-backend/internal/features/users/dto"
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_services "databasus-backend/internal/features/users/services"
@@ -1191,3 +1192,33 @@ func Test_SignIn_WithExcessiveAttempts_RateLimitEnforced(t *testing.T) {
 	)
 	assert.Contains(t, string(resp.Body), "Rate limit exceeded")
 }
+
+func Test_SignIn_WithAttemptsForManyEmailsFromOneIP_RateLimitEnforced(t *testing.T) {
+	router := createUserTestRouter()
+
+	addressID := uuid.New()
+	remoteAddr := fmt.Sprintf("198.18.%d.%d:40000", addressID[0], addressID[1])
+	ipLimit := config.GetReloadableSettings().SignInRateLimitPerMinute *
+		clientIPRateLimitMultiplier
+
+	signIn := func(expectedStatus int) *test_utils.TestResponse {
+		return test_utils.MakeRequest(t, router, test_utils.RequestOptions{
+			Method: http.MethodPost,
+			URL:    "/api/v1/users/signin",
+			Body: users_dto.SignInRequestDTO{
+				Email:    "ip-ratelimit" + uuid.New().String() + "@example.com",
+				Password: "testpassword123",
+			},
+			RemoteAddr:     remoteAddr,
+			ExpectedStatus: expectedStatus,
+		})
+	}
+
+	// every email is new, so only the limit of the client IP applies
+	for range ipLimit {
+		signIn(http.StatusBadRequest)
+	}
+
+	resp := signIn(http.StatusTooManyRequests)
+	assert.Contains(t, string(resp.Body), "Rate limit exceeded")
+}
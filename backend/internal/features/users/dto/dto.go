@@ -4,6 +4,8 @@ import (
 	"time"
 
 	users_enums "databasus-backend/internal/features/users/enums"
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/i18n"
 
 	"github.com/google/uuid"
 )
@@ -19,6 +21,12 @@ type SignInRequestDTO struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// SignInClientDTO describes where a sign-in came from
+type SignInClientDTO struct {
+	IPAddress string
+	UserAgent string
+}
+
 type SignInResponseDTO struct {
 	UserID uuid.UUID `json:"userId"`
 	Email  string    `json:"email"`
@@ -38,8 +46,9 @@ type ChangePasswordRequestDTO struct {
 }
 
 type UpdateUserInfoRequestDTO struct {
-	Name  *string `json:"name"`
-	Email *string `json:"email" binding:"omitempty,email"`
+	Name   *string      `json:"name"`
+	Email  *string      `json:"email"  binding:"omitempty,email"`
+	Locale *i18n.Locale `json:"locale"`
 }
 
 type InviteUserRequestDTO struct {
@@ -57,12 +66,17 @@ type InviteUserResponseDTO struct {
 }
 
 type UserProfileResponseDTO struct {
-	ID        uuid.UUID            `json:"id"`
-	Email     string               `json:"email"`
-	Name      string               `json:"name"`
-	Role      users_enums.UserRole `json:"role"`
-	IsActive  bool                 `json:"isActive"`
-	CreatedAt time.Time            `json:"createdAt"`
+	ID              uuid.UUID            `json:"id"`
+	Email           string               `json:"email"`
+	Name            string               `json:"name"`
+	Role            users_enums.UserRole `json:"role"`
+	IsActive        bool                 `json:"isActive"`
+	IsEmailVerified bool                 `json:"isEmailVerified"`
+	PendingEmail    *string              `json:"pendingEmail,omitempty"`
+	Locale          i18n.Locale          `json:"locale"`
+	CreatedAt       time.Time            `json:"createdAt"`
+
+	Preferences users_models.UserPreferences `json:"preferences"`
 }
 
 type ListUsersResponseDTO struct {
@@ -102,3 +116,12 @@ type ResetPasswordRequestDTO struct {
 	Code        string `json:"code"        binding:"required"`
 	NewPassword string `json:"newPassword" binding:"required,min=8"`
 }
+
+type ResetPasswordByTokenRequestDTO struct {
+	Token       string `json:"token"       binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8"`
+}
+
+type VerifyEmailRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,43 @@
+package users_enums
+
+// StorageRedactionLevel is how much of a system storage users below admin see
+type StorageRedactionLevel string
+
+const (
+	// StorageRedactionLevelFull shows only the name and type of the storage
+	StorageRedactionLevelFull StorageRedactionLevel = "FULL"
+	// StorageRedactionLevelMetadataOnly shows settings like bucket and region, but not
+	// credentials and the workspaces the storage is shared with
+	StorageRedactionLevelMetadataOnly StorageRedactionLevel = "METADATA_ONLY"
+	// StorageRedactionLevelNone shows the storage as admins see it. Credentials are never
+	// returned by the API
+	StorageRedactionLevelNone StorageRedactionLevel = "NONE"
+)
+
+// StorageRedactionLevels maps workspace roles to their redaction level
+type StorageRedactionLevels map[WorkspaceRole]StorageRedactionLevel
+
+func (l StorageRedactionLevel) IsValid() bool {
+	switch l {
+	case StorageRedactionLevelFull, StorageRedactionLevelMetadataOnly, StorageRedactionLevelNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsLessStrictThan orders levels from FULL to NONE
+func (l StorageRedactionLevel) IsLessStrictThan(other StorageRedactionLevel) bool {
+	return l.strictness() < other.strictness()
+}
+
+func (l StorageRedactionLevel) strictness() int {
+	switch l {
+	case StorageRedactionLevelNone:
+		return 0
+	case StorageRedactionLevelMetadataOnly:
+		return 1
+	default:
+		return 2
+	}
+}
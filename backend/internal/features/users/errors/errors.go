@@ -4,4 +4,9 @@ import "errors"
 
 var (
 	ErrInsufficientPermissionsToInviteUsers = errors.New("insufficient permissions to invite users")
+	ErrInvalidVerificationToken             = errors.New("invalid or expired verification link")
+	ErrEmailAlreadyVerified                 = errors.New("email is already verified")
+	ErrTooManyVerificationEmails            = errors.New(
+		"too many verification emails, please try again later",
+	)
 )
@@ -1,13 +1,43 @@
 package users_interfaces
 
 import (
+	"crypto/x509"
+
+	users_dto "databasus-backend/internal/features/users/dto"
+	users_models "databasus-backend/internal/features/users/models"
+
 	"github.com/google/uuid"
 )
 
 type AuditLogWriter interface {
 	WriteAuditLog(message string, userID *uuid.UUID, workspaceID *uuid.UUID)
+	WriteClientAuditLog(
+		message string,
+		ipAddress string,
+		userID *uuid.UUID,
+		workspaceID *uuid.UUID,
+	)
 }
 
 type EmailSender interface {
 	SendEmail(to, subject, body string) error
+	IsConfigured() bool
+}
+
+// SecurityEventListener is told about sign-ins and security policy changes, so admins and
+// affected users can be alerted. Calls should not block the request
+type SecurityEventListener interface {
+	OnSignInSucceeded(user *users_models.User, client users_dto.SignInClientDTO)
+	OnSignInFailed(user *users_models.User, client users_dto.SignInClientDTO)
+	OnSecurityPolicyChanged(
+		changedBy *users_models.User,
+		previous users_models.SecurityPolicy,
+		current users_models.SecurityPolicy,
+	)
+}
+
+// ClientCertificateAuthenticator maps verified TLS client certificates to users, so
+// automation authenticates with mutual TLS instead of a JWT
+type ClientCertificateAuthenticator interface {
+	FindUserIDByCertificate(certificate *x509.Certificate) (uuid.UUID, error)
 }
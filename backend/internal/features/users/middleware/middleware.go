@@ -4,15 +4,33 @@ import (
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
 	users_services "databasus-backend/internal/features/users/services"
+	"databasus-backend/internal/util/logger"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates JWT token and adds user to context
+// AuthMiddleware validates JWT token and adds user to context. Requests without a token
+// are authenticated by their TLS client certificate when mutual TLS verified one
 func AuthMiddleware(userService *users_services.UserService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := ctx.GetHeader("Authorization")
+		if token == "" && ctx.Request.TLS != nil && len(ctx.Request.TLS.VerifiedChains) > 0 {
+			certificate := ctx.Request.TLS.VerifiedChains[0][0]
+
+			user, err := userService.GetUserFromClientCertificate(certificate)
+			if err != nil {
+				ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid client certificate"})
+				ctx.Abort()
+				return
+			}
+
+			ctx.Set("user", user)
+			ctx.Next()
+			return
+		}
+
 		if token == "" {
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization token required"})
 			ctx.Abort()
@@ -62,6 +80,55 @@ func RequireRole(requiredRole users_enums.UserRole) gin.HandlerFunc {
 	}
 }
 
+// corsPreflightMaxAge is how long browsers may reuse a preflight response
+const corsPreflightMaxAge = 10 * 60
+
+// SecurityHeadersMiddleware applies CORS and security headers configured by admins. Preflight
+// requests of allowed origins are answered right away, requests of other origins get no CORS
+// headers and are refused by browsers
+func SecurityHeadersMiddleware(settingsService *users_services.SettingsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		headers, err := settingsService.GetSecurityHeaders()
+		if err != nil {
+			logger.GetLogger().Error("Failed to get security headers", "error", err)
+			ctx.Next()
+			return
+		}
+
+		if policy := headers.GetContentSecurityPolicy(); policy != "" {
+			ctx.Header("Content-Security-Policy", policy)
+		}
+
+		// browsers ignore HSTS received over plain HTTP, so TLS terminated by a proxy works too
+		if hsts := headers.GetStrictTransportSecurity(); hsts != "" {
+			ctx.Header("Strict-Transport-Security", hsts)
+		}
+
+		allowedOrigin := headers.GetAllowedOrigin(ctx.GetHeader("Origin"))
+		if allowedOrigin == "" {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("Access-Control-Allow-Origin", allowedOrigin)
+		ctx.Writer.Header().Add("Vary", "Origin")
+
+		if ctx.Request.Method == http.MethodOptions &&
+			ctx.GetHeader("Access-Control-Request-Method") != "" {
+			ctx.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS")
+			ctx.Header(
+				"Access-Control-Allow-Headers",
+				"Origin, Content-Type, Content-Length, Authorization, Accept, Accept-Language",
+			)
+			ctx.Header("Access-Control-Max-Age", strconv.Itoa(corsPreflightMaxAge))
+			ctx.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
 // GetUserFromContext helper function to extract user from gin context
 func GetUserFromContext(ctx *gin.Context) (*users_models.User, bool) {
 	userInterface, exists := ctx.Get("user")
@@ -0,0 +1,27 @@
+package users_models
+
+import "github.com/google/uuid"
+
+const (
+	DefaultBrandingProductName = "Databasus"
+	DefaultBrandingAccentColor = "#0d6efd"
+)
+
+type BrandingSettings struct {
+	ID           uuid.UUID `json:"id"           gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductName  string    `json:"productName"  gorm:"column:product_name;type:text;not null"`
+	LogoURL      string    `json:"logoUrl"      gorm:"column:logo_url;type:text;not null"`
+	AccentColor  string    `json:"accentColor"  gorm:"column:accent_color;type:text;not null"`
+	SupportEmail string    `json:"supportEmail" gorm:"column:support_email;type:text;not null"`
+}
+
+func (BrandingSettings) TableName() string {
+	return "branding_settings"
+}
+
+func (b *BrandingSettings) IsCustomized() bool {
+	return b.ProductName != DefaultBrandingProductName ||
+		b.LogoURL != "" ||
+		b.AccentColor != DefaultBrandingAccentColor ||
+		b.SupportEmail != ""
+}
@@ -0,0 +1,26 @@
+package users_models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken backs a signed verification link, so the link can be used once.
+// Email is the address being verified: the current email of the user or a pending one
+type EmailVerificationToken struct {
+	ID        uuid.UUID `json:"id"        gorm:"column:id"`
+	UserID    uuid.UUID `json:"userId"    gorm:"column:user_id"`
+	Email     string    `json:"email"     gorm:"column:email"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"column:expires_at"`
+	IsUsed    bool      `json:"isUsed"    gorm:"column:is_used"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
+
+func (t *EmailVerificationToken) IsValid() bool {
+	return !t.IsUsed && time.Now().UTC().Before(t.ExpiresAt)
+}
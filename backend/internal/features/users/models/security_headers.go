@@ -0,0 +1,145 @@
+package users_models
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxCORSAllowedOrigins = 100
+	maxFrameAncestors     = 100
+	maxHSTSMaxAgeSeconds  = 2 * 365 * 24 * 60 * 60
+)
+
+// SecurityHeaders are the CORS and security response headers of the instance, editable by
+// admins. Empty values send no header, so the defaults keep responses as they were
+type SecurityHeaders struct {
+	// CORSAllowedOrigins may call the API from browsers, e.g. "https://portal.example.com".
+	// "*" allows any origin
+	CORSAllowedOrigins []string `json:"corsAllowedOrigins"`
+	// ContentSecurityPolicy is sent as is, frame-ancestors is appended from FrameAncestors
+	ContentSecurityPolicy string `json:"contentSecurityPolicy"`
+	// HSTSMaxAgeSeconds enables Strict-Transport-Security, 0 disables it
+	HSTSMaxAgeSeconds         int  `json:"hstsMaxAgeSeconds"`
+	IsHSTSIncludingSubdomains bool `json:"isHstsIncludingSubdomains"`
+	// FrameAncestors may embed the UI, e.g. "'self'" or "https://wiki.example.com"
+	FrameAncestors []string `json:"frameAncestors"`
+}
+
+func (h *SecurityHeaders) Validate() error {
+	if len(h.CORSAllowedOrigins) > maxCORSAllowedOrigins {
+		return fmt.Errorf("at most %d CORS origins can be allowed", maxCORSAllowedOrigins)
+	}
+
+	origins := make([]string, 0, len(h.CORSAllowedOrigins))
+	for _, origin := range h.CORSAllowedOrigins {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if !isValidOrigin(origin) {
+			return fmt.Errorf("invalid CORS origin: %q", origin)
+		}
+
+		if !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	h.CORSAllowedOrigins = origins
+
+	h.ContentSecurityPolicy = strings.TrimSpace(h.ContentSecurityPolicy)
+	if strings.ContainsAny(h.ContentSecurityPolicy, "\r\n") {
+		return fmt.Errorf("content security policy must be a single line")
+	}
+	if strings.Contains(h.ContentSecurityPolicy, "frame-ancestors") {
+		return fmt.Errorf("set frame ancestors with frameAncestors instead of the policy")
+	}
+
+	if h.HSTSMaxAgeSeconds < 0 || h.HSTSMaxAgeSeconds > maxHSTSMaxAgeSeconds {
+		return fmt.Errorf("HSTS max age must be between 0 and %d seconds", maxHSTSMaxAgeSeconds)
+	}
+
+	if len(h.FrameAncestors) > maxFrameAncestors {
+		return fmt.Errorf("at most %d frame ancestors can be allowed", maxFrameAncestors)
+	}
+
+	ancestors := make([]string, 0, len(h.FrameAncestors))
+	for _, ancestor := range h.FrameAncestors {
+		ancestor = strings.TrimSpace(ancestor)
+		if ancestor == "" || strings.ContainsAny(ancestor, " ;,\r\n") {
+			return fmt.Errorf("invalid frame ancestor: %q", ancestor)
+		}
+
+		if !slices.Contains(ancestors, ancestor) {
+			ancestors = append(ancestors, ancestor)
+		}
+	}
+	h.FrameAncestors = ancestors
+
+	return nil
+}
+
+// GetAllowedOrigin returns the value of Access-Control-Allow-Origin for the request origin,
+// empty when the origin is not allowed
+func (h *SecurityHeaders) GetAllowedOrigin(origin string) string {
+	if origin == "" {
+		return ""
+	}
+
+	if slices.Contains(h.CORSAllowedOrigins, "*") {
+		return "*"
+	}
+
+	if slices.Contains(h.CORSAllowedOrigins, origin) {
+		return origin
+	}
+
+	return ""
+}
+
+// GetContentSecurityPolicy joins the policy with frame ancestors, empty disables the header
+func (h *SecurityHeaders) GetContentSecurityPolicy() string {
+	directives := make([]string, 0, 2)
+	if h.ContentSecurityPolicy != "" {
+		directives = append(directives, strings.TrimSuffix(h.ContentSecurityPolicy, ";"))
+	}
+
+	if len(h.FrameAncestors) > 0 {
+		directives = append(
+			directives,
+			"frame-ancestors "+strings.Join(h.FrameAncestors, " "),
+		)
+	}
+
+	return strings.Join(directives, "; ")
+}
+
+func (h *SecurityHeaders) GetStrictTransportSecurity() string {
+	if h.HSTSMaxAgeSeconds == 0 {
+		return ""
+	}
+
+	value := "max-age=" + strconv.Itoa(h.HSTSMaxAgeSeconds)
+	if h.IsHSTSIncludingSubdomains {
+		value += "; includeSubDomains"
+	}
+
+	return value
+}
+
+func isValidOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") &&
+		parsed.Host != "" &&
+		parsed.Path == "" &&
+		parsed.RawQuery == "" &&
+		parsed.User == nil
+}
@@ -0,0 +1,106 @@
+package users_models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	DefaultPasswordMinLength = 8
+	maxPasswordMinLength     = 128
+	maxSessionLifetimeHours  = 24 * 365 * 10
+	maxAllowedSSODomains     = 100
+)
+
+// SecurityPolicy is the instance-wide policy of sign-ins and passwords, editable by admins
+type SecurityPolicy struct {
+	// SessionLifetimeHours limits how long a sign-in lasts, 0 keeps sessions until sign out.
+	// Shortening it also ends older sessions
+	SessionLifetimeHours int `json:"sessionLifetimeHours"`
+	// PasswordMinLength is at least 8, 0 means 8
+	PasswordMinLength int `json:"passwordMinLength"`
+	// IsPasswordComplexityRequired requires upper and lower case letters and a digit
+	IsPasswordComplexityRequired bool `json:"isPasswordComplexityRequired"`
+	// AllowedSSODomains limits GitHub and Google sign-ins to these email domains, empty allows all
+	AllowedSSODomains []string `json:"allowedSsoDomains"`
+}
+
+func (p *SecurityPolicy) Validate() error {
+	if p.SessionLifetimeHours < 0 || p.SessionLifetimeHours > maxSessionLifetimeHours {
+		return fmt.Errorf(
+			"session lifetime must be between 0 and %d hours",
+			maxSessionLifetimeHours,
+		)
+	}
+
+	if p.PasswordMinLength != 0 &&
+		(p.PasswordMinLength < DefaultPasswordMinLength ||
+			p.PasswordMinLength > maxPasswordMinLength) {
+		return fmt.Errorf(
+			"password min length must be between %d and %d",
+			DefaultPasswordMinLength,
+			maxPasswordMinLength,
+		)
+	}
+
+	if len(p.AllowedSSODomains) > maxAllowedSSODomains {
+		return fmt.Errorf("at most %d SSO domains can be allowed", maxAllowedSSODomains)
+	}
+
+	domains := make([]string, 0, len(p.AllowedSSODomains))
+	for _, domain := range p.AllowedSSODomains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" || strings.ContainsAny(domain, "@ /") {
+			return fmt.Errorf("invalid SSO domain: %q", domain)
+		}
+
+		if !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	p.AllowedSSODomains = domains
+
+	return nil
+}
+
+// GetSessionLifetime returns 0 when sessions do not expire
+func (p *SecurityPolicy) GetSessionLifetime() time.Duration {
+	return time.Duration(p.SessionLifetimeHours) * time.Hour
+}
+
+func (p *SecurityPolicy) ValidatePassword(password string) error {
+	minLength := max(p.PasswordMinLength, DefaultPasswordMinLength)
+	if len([]rune(password)) < minLength {
+		return fmt.Errorf("password must be at least %d characters", minLength)
+	}
+
+	if !p.IsPasswordComplexityRequired {
+		return nil
+	}
+
+	hasUpper := strings.ContainsFunc(password, unicode.IsUpper)
+	hasLower := strings.ContainsFunc(password, unicode.IsLower)
+	hasDigit := strings.ContainsFunc(password, unicode.IsDigit)
+	if !hasUpper || !hasLower || !hasDigit {
+		return errors.New("password must contain upper and lower case letters and a digit")
+	}
+
+	return nil
+}
+
+func (p *SecurityPolicy) IsSSOEmailAllowed(email string) bool {
+	if len(p.AllowedSSODomains) == 0 {
+		return true
+	}
+
+	_, domain, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(p.AllowedSSODomains, domain)
+}
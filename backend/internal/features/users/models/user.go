@@ -2,6 +2,7 @@ package users_models
 
 import (
 	users_enums "databasus-backend/internal/features/users/enums"
+	"databasus-backend/internal/util/i18n"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,7 +18,16 @@ type User struct {
 	Status               users_enums.UserStatus `json:"status"`
 	GitHubOAuthID        *string                `json:"-"         gorm:"column:github_oauth_id"`
 	GoogleOAuthID        *string                `json:"-"         gorm:"column:google_oauth_id"`
+	Locale               i18n.Locale            `json:"locale"    gorm:"column:locale;default:en"`
 	CreatedAt            time.Time              `json:"createdAt"`
+
+	// IsEmailVerified is set once the user followed a verification link sent to the email,
+	// or signed in via OAuth provider confirming it
+	IsEmailVerified bool `json:"isEmailVerified" gorm:"column:is_email_verified"`
+	// PendingEmail is the new email of the user until it is verified
+	PendingEmail *string `json:"pendingEmail" gorm:"column:pending_email"`
+
+	Preferences UserPreferences `json:"preferences" gorm:"column:preferences;type:jsonb;serializer:json"`
 }
 
 func (User) TableName() string {
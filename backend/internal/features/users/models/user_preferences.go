@@ -0,0 +1,12 @@
+package users_models
+
+import "github.com/google/uuid"
+
+// UserPreferences are personal settings of the user, they do not affect other members
+type UserPreferences struct {
+	// Timezone is an IANA name like "Europe/Berlin" used to render schedules, empty for UTC
+	Timezone           string     `json:"timezone"`
+	DefaultWorkspaceID *uuid.UUID `json:"defaultWorkspaceId"`
+	// IsNotifyOnOwnedBackupFailures emails the user when a backup of a database they own fails
+	IsNotifyOnOwnedBackupFailures bool `json:"isNotifyOnOwnedBackupFailures"`
+}
@@ -1,6 +1,10 @@
 package users_models
 
-import "github.com/google/uuid"
+import (
+	users_enums "databasus-backend/internal/features/users/enums"
+
+	"github.com/google/uuid"
+)
 
 type UsersSettings struct {
 	ID uuid.UUID `json:"id"                                gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
@@ -10,8 +14,25 @@ type UsersSettings struct {
 	IsAllowMemberInvitations bool `json:"isAllowMemberInvitations"          gorm:"column:is_allow_member_invitations"`
 	// means that any user with role MEMBER can create their own workspaces
 	IsMemberAllowedToCreateWorkspaces bool `json:"isMemberAllowedToCreateWorkspaces" gorm:"column:is_member_allowed_to_create_workspaces"`
+	// how much of system storages users see by their workspace role, FULL for missing roles
+	SystemStorageRedactionLevels users_enums.StorageRedactionLevels `json:"systemStorageRedactionLevels" gorm:"column:system_storage_redaction_levels;type:text;serializer:json"`
+	// SecurityPolicy is changed with its own endpoint, so older clients saving settings keep it
+	SecurityPolicy SecurityPolicy `json:"-" gorm:"column:security_policy;type:jsonb;serializer:json"`
+	// SecurityHeaders are changed with their own endpoint as well
+	SecurityHeaders SecurityHeaders `json:"-" gorm:"column:security_headers;type:jsonb;serializer:json"`
 }
 
 func (UsersSettings) TableName() string {
 	return "users_settings"
 }
+
+func (s *UsersSettings) GetSystemStorageRedactionLevel(
+	role users_enums.WorkspaceRole,
+) users_enums.StorageRedactionLevel {
+	level, ok := s.SystemStorageRedactionLevels[role]
+	if !ok {
+		return users_enums.StorageRedactionLevelFull
+	}
+
+	return level
+}
@@ -0,0 +1,47 @@
+package users_repositories
+
+import (
+	user_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BrandingSettingsRepository struct{}
+
+func (r *BrandingSettingsRepository) GetSettings() (*user_models.BrandingSettings, error) {
+	var settings user_models.BrandingSettings
+
+	if err := storage.GetDb().First(&settings).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			defaultSettings := &user_models.BrandingSettings{
+				ID:           uuid.New(),
+				ProductName:  user_models.DefaultBrandingProductName,
+				LogoURL:      "",
+				AccentColor:  user_models.DefaultBrandingAccentColor,
+				SupportEmail: "",
+			}
+
+			if createErr := storage.GetDb().Create(defaultSettings).Error; createErr != nil {
+				return nil, createErr
+			}
+
+			return defaultSettings, nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *BrandingSettingsRepository) UpdateSettings(settings *user_models.BrandingSettings) error {
+	existingSettings, err := r.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	settings.ID = existingSettings.ID
+
+	return storage.GetDb().Save(settings).Error
+}
@@ -3,6 +3,8 @@ package users_repositories
 var userRepository = &UserRepository{}
 var usersSettingsRepository = &UsersSettingsRepository{}
 var passwordResetRepository = &PasswordResetRepository{}
+var brandingSettingsRepository = &BrandingSettingsRepository{}
+var emailVerificationRepository = &EmailVerificationRepository{}
 
 func GetUserRepository() *UserRepository {
 	return userRepository
@@ -15,3 +17,11 @@ func GetUsersSettingsRepository() *UsersSettingsRepository {
 func GetPasswordResetRepository() *PasswordResetRepository {
 	return passwordResetRepository
 }
+
+func GetBrandingSettingsRepository() *BrandingSettingsRepository {
+	return brandingSettingsRepository
+}
+
+func GetEmailVerificationRepository() *EmailVerificationRepository {
+	return emailVerificationRepository
+}
@@ -0,0 +1,61 @@
+package users_repositories
+
+import (
+	"time"
+
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+type EmailVerificationRepository struct{}
+
+func (r *EmailVerificationRepository) CreateToken(
+	token *users_models.EmailVerificationToken,
+) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+
+	return storage.GetDb().Create(token).Error
+}
+
+func (r *EmailVerificationRepository) GetTokenByID(
+	id uuid.UUID,
+) (*users_models.EmailVerificationToken, error) {
+	var token users_models.EmailVerificationToken
+
+	if err := storage.GetDb().Where("id = ?", id).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// MarkTokenAsUsed returns false when the token was already used by a
+// concurrent request
+func (r *EmailVerificationRepository) MarkTokenAsUsed(id uuid.UUID) (bool, error) {
+	result := storage.GetDb().Model(&users_models.EmailVerificationToken{}).
+		Where("id = ? AND is_used = ?", id, false).
+		Update("is_used", true)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *EmailVerificationRepository) CountRecentTokensByUserID(
+	userID uuid.UUID,
+	since time.Time,
+) (int64, error) {
+	var count int64
+
+	err := storage.GetDb().Model(&users_models.EmailVerificationToken{}).
+		Where("user_id = ? AND created_at > ?", userID, since).
+		Count(&count).Error
+
+	return count, err
+}
@@ -35,10 +35,30 @@ func (r *PasswordResetRepository) GetValidCodeByUserID(
 	return &code, nil
 }
 
-func (r *PasswordResetRepository) MarkCodeAsUsed(codeID uuid.UUID) error {
-	return storage.GetDb().Model(&users_models.PasswordResetCode{}).
-		Where("id = ?", codeID).
-		Update("is_used", true).Error
+func (r *PasswordResetRepository) GetCodeByID(
+	id uuid.UUID,
+) (*users_models.PasswordResetCode, error) {
+	var code users_models.PasswordResetCode
+
+	if err := storage.GetDb().Where("id = ?", id).First(&code).Error; err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+// MarkCodeAsUsed returns false when the code was already used, so concurrent
+// requests with the same code cannot both reset the password
+func (r *PasswordResetRepository) MarkCodeAsUsed(codeID uuid.UUID) (bool, error) {
+	result := storage.GetDb().Model(&users_models.PasswordResetCode{}).
+		Where("id = ? AND is_used = ?", codeID, false).
+		Update("is_used", true)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
 }
 
 func (r *PasswordResetRepository) DeleteExpiredCodes() error {
@@ -4,6 +4,7 @@ import (
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
 	"databasus-backend/internal/storage"
+	"databasus-backend/internal/util/i18n"
 	"fmt"
 	"time"
 
@@ -124,6 +125,20 @@ func (r *UserRepository) GetUsers(
 	return users, total, nil
 }
 
+func (r *UserRepository) GetActiveUsersByRole(
+	role users_enums.UserRole,
+) ([]*users_models.User, error) {
+	var users []*users_models.User
+
+	err := storage.GetDb().
+		Where("role = ? AND status = ?", role, users_enums.UserStatusActive).
+		Order("created_at ASC").
+		Find(&users).
+		Error
+
+	return users, err
+}
+
 func (r *UserRepository) UpdateUserStatus(userID uuid.UUID, status users_enums.UserStatus) error {
 	return storage.GetDb().Model(&users_models.User{}).
 		Where("id = ?", userID).
@@ -132,6 +147,14 @@ func (r *UserRepository) UpdateUserStatus(userID uuid.UUID, status users_enums.U
 		}).Error
 }
 
+func (r *UserRepository) UpdateUserLocale(userID uuid.UUID, locale i18n.Locale) error {
+	return storage.GetDb().Model(&users_models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"locale": locale,
+		}).Error
+}
+
 func (r *UserRepository) UpdateUserRole(userID uuid.UUID, role users_enums.UserRole) error {
 	return storage.GetDb().Model(&users_models.User{}).
 		Where("id = ?", userID).
@@ -164,6 +187,8 @@ func (r *UserRepository) UpdateUserInfo(userID uuid.UUID, name *string, email *s
 	}
 	if email != nil {
 		updates["email"] = *email
+		updates["is_email_verified"] = false
+		updates["pending_email"] = nil
 	}
 
 	if len(updates) == 0 {
@@ -199,9 +224,39 @@ func (r *UserRepository) GetUserByGoogleOAuthID(googleID string) (*users_models.
 	return &user, nil
 }
 
+// LinkOAuthID marks the email verified, accounts are linked by the email confirmed by the
+// provider
 func (r *UserRepository) LinkOAuthID(userID uuid.UUID, oauthColumn, oauthID string) error {
-	updates := map[string]any{oauthColumn: oauthID}
+	updates := map[string]any{oauthColumn: oauthID, "is_email_verified": true}
 	return storage.GetDb().Model(&users_models.User{}).
 		Where("id = ?", userID).
 		Updates(updates).Error
 }
+
+func (r *UserRepository) UpdatePendingEmail(userID uuid.UUID, pendingEmail *string) error {
+	return storage.GetDb().Model(&users_models.User{}).
+		Where("id = ?", userID).
+		Update("pending_email", pendingEmail).Error
+}
+
+// MarkEmailVerified sets the verified email as the email of the user, it differs from the
+// current one when a pending email change is verified
+func (r *UserRepository) MarkEmailVerified(userID uuid.UUID, email string) error {
+	return storage.GetDb().Model(&users_models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"email":             email,
+			"is_email_verified": true,
+			"pending_email":     nil,
+		}).Error
+}
+
+func (r *UserRepository) UpdateUserPreferences(
+	userID uuid.UUID,
+	preferences users_models.UserPreferences,
+) error {
+	return storage.GetDb().Model(&users_models.User{}).
+		Where("id = ?", userID).
+		Select("preferences").
+		Updates(&users_models.User{Preferences: preferences}).Error
+}
@@ -0,0 +1,165 @@
+package users_services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	users_interfaces "databasus-backend/internal/features/users/interfaces"
+	users_models "databasus-backend/internal/features/users/models"
+	users_repositories "databasus-backend/internal/features/users/repositories"
+)
+
+var accentColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type BrandingService struct {
+	brandingSettingsRepository *users_repositories.BrandingSettingsRepository
+	auditLogWriter             users_interfaces.AuditLogWriter
+}
+
+func (s *BrandingService) SetAuditLogWriter(writer users_interfaces.AuditLogWriter) {
+	s.auditLogWriter = writer
+}
+
+func (s *BrandingService) GetBranding() (*users_models.BrandingSettings, error) {
+	return s.brandingSettingsRepository.GetSettings()
+}
+
+// GetBrandingOrDefault is used while rendering emails and notifications,
+// where failing to load branding must not prevent sending
+func (s *BrandingService) GetBrandingOrDefault() *users_models.BrandingSettings {
+	branding, err := s.brandingSettingsRepository.GetSettings()
+	if err != nil {
+		return &users_models.BrandingSettings{
+			ProductName: users_models.DefaultBrandingProductName,
+			AccentColor: users_models.DefaultBrandingAccentColor,
+		}
+	}
+
+	return branding
+}
+
+func (s *BrandingService) UpdateBranding(
+	request users_models.BrandingSettings,
+	updatedBy *users_models.User,
+) (*users_models.BrandingSettings, error) {
+	if !updatedBy.CanUpdateSettings() {
+		return nil, fmt.Errorf("insufficient permissions to update branding")
+	}
+
+	request.ProductName = strings.TrimSpace(request.ProductName)
+	request.LogoURL = strings.TrimSpace(request.LogoURL)
+	request.AccentColor = strings.TrimSpace(request.AccentColor)
+	request.SupportEmail = strings.TrimSpace(request.SupportEmail)
+
+	if err := s.validateBranding(&request); err != nil {
+		return nil, err
+	}
+
+	existingSettings, err := s.brandingSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current branding: %w", err)
+	}
+
+	auditLogMessage := fmt.Sprintf(
+		"Branding updated: productName %q -> %q, logoUrl %q -> %q, accentColor %q -> %q, supportEmail %q -> %q",
+		existingSettings.ProductName, request.ProductName,
+		existingSettings.LogoURL, request.LogoURL,
+		existingSettings.AccentColor, request.AccentColor,
+		existingSettings.SupportEmail, request.SupportEmail,
+	)
+
+	existingSettings.ProductName = request.ProductName
+	existingSettings.LogoURL = request.LogoURL
+	existingSettings.AccentColor = request.AccentColor
+	existingSettings.SupportEmail = request.SupportEmail
+
+	if err := s.brandingSettingsRepository.UpdateSettings(existingSettings); err != nil {
+		return nil, fmt.Errorf("failed to update branding: %w", err)
+	}
+
+	if s.auditLogWriter != nil {
+		s.auditLogWriter.WriteAuditLog(auditLogMessage, &updatedBy.ID, nil)
+	}
+
+	return existingSettings, nil
+}
+
+// GetNotificationSignature returns a footer for notification messages.
+// It is empty for non-customized instances to keep messages unchanged
+func (s *BrandingService) GetNotificationSignature() string {
+	branding := s.GetBrandingOrDefault()
+	if !branding.IsCustomized() {
+		return ""
+	}
+
+	signature := "Sent by " + branding.ProductName
+	if branding.SupportEmail != "" {
+		signature += ". Support: " + branding.SupportEmail
+	}
+
+	return signature
+}
+
+// BuildEmailHeader renders a logo (if configured) above email content
+func (s *BrandingService) BuildEmailHeader(branding *users_models.BrandingSettings) string {
+	if branding.LogoURL == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		`<div style="margin-bottom: 20px;"><img src="%s" alt="%s" style="max-height: 48px;"></div>`,
+		html.EscapeString(branding.LogoURL),
+		html.EscapeString(branding.ProductName),
+	)
+}
+
+// BuildEmailFooter renders a support contact line (if configured)
+func (s *BrandingService) BuildEmailFooter(branding *users_models.BrandingSettings) string {
+	if branding.SupportEmail == "" {
+		return ""
+	}
+
+	// quoted local parts pass mail.ParseAddress, so the address may contain < or "
+	supportEmail := html.EscapeString(branding.SupportEmail)
+
+	return fmt.Sprintf(
+		`<p style="font-size: 12px; color: #999999; line-height: 1.6;">Need help? Contact <a href="mailto:%s">%s</a></p>`,
+		supportEmail,
+		supportEmail,
+	)
+}
+
+func (s *BrandingService) validateBranding(branding *users_models.BrandingSettings) error {
+	if branding.ProductName == "" {
+		return errors.New("product name is required")
+	}
+
+	if len(branding.ProductName) > 64 {
+		return errors.New("product name must not exceed 64 characters")
+	}
+
+	if branding.LogoURL != "" {
+		logoURL, err := url.Parse(branding.LogoURL)
+		if err != nil || (logoURL.Scheme != "https" && logoURL.Scheme != "http") ||
+			logoURL.Host == "" {
+			return errors.New("logo URL must be a valid http(s) URL")
+		}
+	}
+
+	if !accentColorRegex.MatchString(branding.AccentColor) {
+		return errors.New("accent color must be a hex color like #0d6efd")
+	}
+
+	if branding.SupportEmail != "" {
+		if _, err := mail.ParseAddress(branding.SupportEmail); err != nil {
+			return errors.New("support email is invalid")
+		}
+	}
+
+	return nil
+}
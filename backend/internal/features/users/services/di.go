@@ -13,10 +13,19 @@ var userService = &UserService{
 	nil,
 	email.GetEmailSMTPSender(),
 	users_repositories.GetPasswordResetRepository(),
+	brandingService,
+	users_repositories.GetEmailVerificationRepository(),
+	nil,
+	nil,
 }
 var settingsService = &SettingsService{
 	users_repositories.GetUsersSettingsRepository(),
 	nil,
+	nil,
+}
+var brandingService = &BrandingService{
+	users_repositories.GetBrandingSettingsRepository(),
+	nil,
 }
 var managementService = &UserManagementService{
 	users_repositories.GetUserRepository(),
@@ -34,3 +43,7 @@ func GetSettingsService() *SettingsService {
 func GetManagementService() *UserManagementService {
 	return managementService
 }
+
+func GetBrandingService() *BrandingService {
+	return brandingService
+}
@@ -0,0 +1,261 @@
+package users_services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	users_errors "databasus-backend/internal/features/users/errors"
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/i18n"
+)
+
+const (
+	emailVerificationPurpose     = "email_verification"
+	emailVerificationTokenTTL    = 24 * time.Hour
+	maxVerificationEmailsPerHour = 3
+)
+
+// SendVerificationEmail sends a link verifying the pending email of the user, or the current
+// one when no change is pending
+func (s *UserService) SendVerificationEmail(user *users_models.User) error {
+	email := user.Email
+	if user.PendingEmail != nil {
+		email = *user.PendingEmail
+	} else if user.IsEmailVerified {
+		return users_errors.ErrEmailAlreadyVerified
+	}
+
+	if email == "admin" {
+		return errors.New("admin email cannot be verified")
+	}
+
+	oneHourAgo := time.Now().UTC().Add(-1 * time.Hour)
+	recentCount, err := s.emailVerificationRepository.CountRecentTokensByUserID(
+		user.ID,
+		oneHourAgo,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	if recentCount >= maxVerificationEmailsPerHour {
+		return users_errors.ErrTooManyVerificationEmails
+	}
+
+	now := time.Now().UTC()
+	verificationToken := &users_models.EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Email:     email,
+		ExpiresAt: now.Add(emailVerificationTokenTTL),
+		IsUsed:    false,
+		CreatedAt: now,
+	}
+
+	signedToken, err := s.signVerificationToken(verificationToken)
+	if err != nil {
+		return err
+	}
+
+	if err := s.emailVerificationRepository.CreateToken(verificationToken); err != nil {
+		return fmt.Errorf("failed to create verification token: %w", err)
+	}
+
+	if s.emailSender != nil {
+		subject, body := s.buildVerificationEmail(user.Locale, email, signedToken)
+
+		if err := s.emailSender.SendEmail(email, subject, body); err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+	}
+
+	if s.auditLogWriter != nil {
+		s.auditLogWriter.WriteAuditLog(
+			fmt.Sprintf("Email verification sent to: %s", email),
+			&user.ID,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+// VerifyEmail verifies the address the token was sent to. For a pending email change it
+// becomes the email of the user, unless another user took it meanwhile
+func (s *UserService) VerifyEmail(signedToken string) error {
+	verificationToken, err := s.parseVerificationToken(signedToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetUserByID(verificationToken.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	isPendingEmail := user.PendingEmail != nil && *user.PendingEmail == verificationToken.Email
+	if verificationToken.Email != user.Email && !isPendingEmail {
+		return users_errors.ErrInvalidVerificationToken
+	}
+
+	if isPendingEmail {
+		existingUser, err := s.userRepository.GetUserByEmail(verificationToken.Email)
+		if err != nil {
+			return fmt.Errorf("failed to check email: %w", err)
+		}
+		if existingUser != nil && existingUser.ID != user.ID {
+			return errors.New("email is already taken by another user")
+		}
+	}
+
+	isMarked, err := s.emailVerificationRepository.MarkTokenAsUsed(verificationToken.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark token as used: %w", err)
+	}
+	if !isMarked {
+		return users_errors.ErrInvalidVerificationToken
+	}
+
+	if err := s.userRepository.MarkEmailVerified(user.ID, verificationToken.Email); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	if s.auditLogWriter != nil {
+		message := fmt.Sprintf("Email verified: %s", verificationToken.Email)
+		if isPendingEmail {
+			message = fmt.Sprintf(
+				"Email changed from %s to %s after verification",
+				user.Email,
+				verificationToken.Email,
+			)
+		}
+
+		s.auditLogWriter.WriteAuditLog(message, &user.ID, nil)
+	}
+
+	return nil
+}
+
+func (s *UserService) signVerificationToken(
+	verificationToken *users_models.EmailVerificationToken,
+) (string, error) {
+	return s.signPurposeToken(emailVerificationPurpose, jwt.MapClaims{
+		"sub":   verificationToken.UserID.String(),
+		"jti":   verificationToken.ID.String(),
+		"email": verificationToken.Email,
+		"exp":   verificationToken.ExpiresAt.Unix(),
+	})
+}
+
+func (s *UserService) parseVerificationToken(
+	signedToken string,
+) (*users_models.EmailVerificationToken, error) {
+	claims, err := s.parsePurposeToken(emailVerificationPurpose, signedToken)
+	if err != nil {
+		return nil, users_errors.ErrInvalidVerificationToken
+	}
+
+	recordID, _ := claims["jti"].(string)
+	userID, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+
+	tokenID, err := uuid.Parse(recordID)
+	if err != nil {
+		return nil, users_errors.ErrInvalidVerificationToken
+	}
+
+	verificationToken, err := s.emailVerificationRepository.GetTokenByID(tokenID)
+	if err != nil {
+		return nil, users_errors.ErrInvalidVerificationToken
+	}
+
+	if !verificationToken.IsValid() ||
+		verificationToken.UserID.String() != userID ||
+		verificationToken.Email != email {
+		return nil, users_errors.ErrInvalidVerificationToken
+	}
+
+	return verificationToken, nil
+}
+
+func (s *UserService) buildVerificationEmail(
+	locale i18n.Locale,
+	email string,
+	signedToken string,
+) (string, string) {
+	env := config.GetEnv()
+	branding := s.brandingService.GetBrandingOrDefault()
+	brandingParams := map[string]string{"product": branding.ProductName}
+
+	subject := i18n.Translate(locale, i18n.MessageEmailVerificationSubject, brandingParams)
+
+	verificationBlock := ""
+	if env.DatabasusURL != "" {
+		verificationBlock = fmt.Sprintf(`<p style="margin: 30px 0; text-align: center;">
+            <a href="%s/verify-email?token=%s" style="display: inline-block; padding: 12px 24px; background-color: %s; color: white; text-decoration: none; border-radius: 4px;">
+                %s
+            </a>
+        </p>`,
+			env.DatabasusURL,
+			url.QueryEscape(signedToken),
+			branding.AccentColor,
+			i18n.Translate(locale, i18n.MessageEmailVerificationButton, nil),
+		)
+	} else {
+		verificationBlock = fmt.Sprintf(`<p style="color: #666666; line-height: 1.6;">%s</p>
+        <div style="background-color: #f8f9fa; border: 2px solid #e9ecef; border-radius: 8px; padding: 20px; margin: 30px 0; word-break: break-all; font-family: monospace;">%s</div>`,
+			i18n.Translate(locale, i18n.MessageEmailVerificationPasteToken, brandingParams),
+			signedToken,
+		)
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+</head>
+<body style="margin: 0; padding: 0; font-family: Arial, sans-serif; background-color: #f4f4f4;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; padding: 20px;">
+        %s
+        <h2 style="color: #333333; margin-bottom: 20px;">%s</h2>
+        <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
+            %s
+        </p>
+        %s
+        <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
+            %s
+        </p>
+        <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
+            %s
+        </p>
+        <hr style="border: none; border-top: 1px solid #e9ecef; margin: 30px 0;">
+        <p style="color: #999999; font-size: 12px; line-height: 1.6;">
+            %s
+        </p>
+        %s
+    </div>
+</body>
+</html>
+`,
+		s.brandingService.BuildEmailHeader(branding),
+		i18n.Translate(locale, i18n.MessageEmailVerificationHeading, nil),
+		i18n.Translate(locale, i18n.MessageEmailVerificationIntro, map[string]string{
+			"email": email,
+		}),
+		verificationBlock,
+		i18n.Translate(locale, i18n.MessageEmailVerificationExpiration, nil),
+		i18n.Translate(locale, i18n.MessageEmailVerificationIgnore, nil),
+		i18n.Translate(locale, i18n.MessageEmailVerificationAutomated, brandingParams),
+		s.brandingService.BuildEmailFooter(branding),
+	)
+
+	return subject, body
+}
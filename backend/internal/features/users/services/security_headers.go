@@ -0,0 +1,100 @@
+package users_services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	users_models "databasus-backend/internal/features/users/models"
+	cache_utils "databasus-backend/internal/util/cache"
+)
+
+// Security headers are read by the middleware on every request. Updates invalidate the key
+// on all nodes, the TTL only bounds staleness when DB is changed by something else
+const securityHeadersCacheTTL = 30 * time.Second
+
+const securityHeadersCacheKey = "current"
+
+var (
+	securityHeadersCache     *cache_utils.CacheUtil[users_models.SecurityHeaders]
+	securityHeadersCacheOnce sync.Once
+)
+
+func getSecurityHeadersCache() *cache_utils.CacheUtil[users_models.SecurityHeaders] {
+	securityHeadersCacheOnce.Do(func() {
+		securityHeadersCache = cache_utils.NewCacheUtil[users_models.SecurityHeaders](
+			cache_utils.GetValkeyClient(),
+			"security_headers:",
+		)
+	})
+
+	return securityHeadersCache
+}
+
+func (s *SettingsService) GetSecurityHeaders() (*users_models.SecurityHeaders, error) {
+	if headers := getSecurityHeadersCache().Get(securityHeadersCacheKey); headers != nil {
+		return headers, nil
+	}
+
+	settings, err := s.userSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	getSecurityHeadersCache().SetWithExpiration(
+		securityHeadersCacheKey,
+		&settings.SecurityHeaders,
+		securityHeadersCacheTTL,
+	)
+
+	return &settings.SecurityHeaders, nil
+}
+
+func (s *SettingsService) UpdateSecurityHeaders(
+	request users_models.SecurityHeaders,
+	updatedBy *users_models.User,
+) (*users_models.SecurityHeaders, error) {
+	if !updatedBy.CanUpdateSettings() {
+		return nil, fmt.Errorf("insufficient permissions to update settings")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	existingSettings, err := s.userSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current settings: %w", err)
+	}
+
+	previous := existingSettings.SecurityHeaders
+	existingSettings.SecurityHeaders = request
+
+	if err := s.userSettingsRepository.UpdateSettings(existingSettings); err != nil {
+		return nil, fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	getSecurityHeadersCache().Invalidate(securityHeadersCacheKey)
+
+	s.auditLogWriter.WriteAuditLog(
+		fmt.Sprintf(
+			"Security headers changed: corsAllowedOrigins %v -> %v, "+
+				"contentSecurityPolicy %q -> %q, hstsMaxAgeSeconds %d -> %d, "+
+				"isHstsIncludingSubdomains %t -> %t, frameAncestors %v -> %v",
+			previous.CORSAllowedOrigins,
+			request.CORSAllowedOrigins,
+			previous.ContentSecurityPolicy,
+			request.ContentSecurityPolicy,
+			previous.HSTSMaxAgeSeconds,
+			request.HSTSMaxAgeSeconds,
+			previous.IsHSTSIncludingSubdomains,
+			request.IsHSTSIncludingSubdomains,
+			previous.FrameAncestors,
+			request.FrameAncestors,
+		),
+		&updatedBy.ID,
+		nil,
+	)
+
+	return &existingSettings.SecurityHeaders, nil
+}
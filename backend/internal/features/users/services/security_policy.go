@@ -0,0 +1,72 @@
+package users_services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var errSSODomainNotAllowed = errors.New("sign-in with this email domain is not allowed")
+
+func (s *UserService) validatePassword(password string) error {
+	policy, err := s.settingsService.GetSecurityPolicy()
+	if err != nil {
+		return fmt.Errorf("failed to get security policy: %w", err)
+	}
+
+	return policy.ValidatePassword(password)
+}
+
+// validateSessionAge ends sessions older than the session lifetime, including the ones
+// started before the lifetime was shortened
+func (s *UserService) validateSessionAge(claims jwt.MapClaims) error {
+	policy, err := s.settingsService.GetSecurityPolicy()
+	if err != nil {
+		return fmt.Errorf("failed to get security policy: %w", err)
+	}
+
+	lifetime := policy.GetSessionLifetime()
+	if lifetime == 0 {
+		return nil
+	}
+
+	issuedAtUnix, ok := claims["iat"].(float64)
+	if !ok {
+		return errors.New("invalid token claims: missing issue time")
+	}
+
+	if time.Since(time.Unix(int64(issuedAtUnix), 0)) > lifetime {
+		return errors.New("session has expired, please sign in again")
+	}
+
+	return nil
+}
+
+func (s *UserService) getSessionExpiration(now time.Time) (time.Time, error) {
+	policy, err := s.settingsService.GetSecurityPolicy()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get security policy: %w", err)
+	}
+
+	lifetime := policy.GetSessionLifetime()
+	if lifetime == 0 {
+		return now.Add(time.Hour * 24 * 365 * 10), nil
+	}
+
+	return now.Add(lifetime), nil
+}
+
+func (s *UserService) validateSSOEmail(email string) error {
+	policy, err := s.settingsService.GetSecurityPolicy()
+	if err != nil {
+		return fmt.Errorf("failed to get security policy: %w", err)
+	}
+
+	if !policy.IsSSOEmailAllowed(email) {
+		return errSSODomainNotAllowed
+	}
+
+	return nil
+}
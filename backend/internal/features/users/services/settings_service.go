@@ -2,21 +2,55 @@ package users_services
 
 import (
 	"fmt"
+	"maps"
+	"sync"
+	"time"
 
 	users_interfaces "databasus-backend/internal/features/users/interfaces"
 	users_models "databasus-backend/internal/features/users/models"
 	users_repositories "databasus-backend/internal/features/users/repositories"
+	cache_utils "databasus-backend/internal/util/cache"
 )
 
+// Security policy is read on every authenticated request to check the session age. Updates
+// invalidate the key on all nodes, the TTL only bounds staleness when DB is changed by
+// something else
+const securityPolicyCacheTTL = 30 * time.Second
+
+const securityPolicyCacheKey = "current"
+
+var (
+	securityPolicyCache     *cache_utils.CacheUtil[users_models.SecurityPolicy]
+	securityPolicyCacheOnce sync.Once
+)
+
+func getSecurityPolicyCache() *cache_utils.CacheUtil[users_models.SecurityPolicy] {
+	securityPolicyCacheOnce.Do(func() {
+		securityPolicyCache = cache_utils.NewCacheUtil[users_models.SecurityPolicy](
+			cache_utils.GetValkeyClient(),
+			"security_policy:",
+		)
+	})
+
+	return securityPolicyCache
+}
+
 type SettingsService struct {
 	userSettingsRepository *users_repositories.UsersSettingsRepository
 	auditLogWriter         users_interfaces.AuditLogWriter
+	securityEventListener  users_interfaces.SecurityEventListener
 }
 
 func (s *SettingsService) SetAuditLogWriter(writer users_interfaces.AuditLogWriter) {
 	s.auditLogWriter = writer
 }
 
+func (s *SettingsService) SetSecurityEventListener(
+	listener users_interfaces.SecurityEventListener,
+) {
+	s.securityEventListener = listener
+}
+
 func (s *SettingsService) GetSettings() (*users_models.UsersSettings, error) {
 	return s.userSettingsRepository.GetSettings()
 }
@@ -64,6 +98,20 @@ func (s *SettingsService) UpdateSettings(
 		existingSettings.IsMemberAllowedToCreateWorkspaces = request.IsMemberAllowedToCreateWorkspaces
 	}
 
+	// levels are kept when the request has none, older clients do not send them
+	redactionLevels := request.SystemStorageRedactionLevels
+	if redactionLevels != nil &&
+		!maps.Equal(redactionLevels, existingSettings.SystemStorageRedactionLevels) {
+		for role, level := range redactionLevels {
+			if !role.IsValid() || !level.IsValid() {
+				return nil, fmt.Errorf("invalid redaction level %s for role %s", level, role)
+			}
+		}
+
+		existingSettings.SystemStorageRedactionLevels = redactionLevels
+		auditLogMessages = append(auditLogMessages, "System storage redaction levels changed")
+	}
+
 	if err := s.userSettingsRepository.UpdateSettings(existingSettings); err != nil {
 		return nil, fmt.Errorf("failed to update settings: %w", err)
 	}
@@ -78,3 +126,73 @@ func (s *SettingsService) UpdateSettings(
 
 	return existingSettings, nil
 }
+
+func (s *SettingsService) GetSecurityPolicy() (*users_models.SecurityPolicy, error) {
+	if policy := getSecurityPolicyCache().Get(securityPolicyCacheKey); policy != nil {
+		return policy, nil
+	}
+
+	settings, err := s.userSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	getSecurityPolicyCache().SetWithExpiration(
+		securityPolicyCacheKey,
+		&settings.SecurityPolicy,
+		securityPolicyCacheTTL,
+	)
+
+	return &settings.SecurityPolicy, nil
+}
+
+func (s *SettingsService) UpdateSecurityPolicy(
+	request users_models.SecurityPolicy,
+	updatedBy *users_models.User,
+) (*users_models.SecurityPolicy, error) {
+	if !updatedBy.CanUpdateSettings() {
+		return nil, fmt.Errorf("insufficient permissions to update settings")
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	existingSettings, err := s.userSettingsRepository.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current settings: %w", err)
+	}
+
+	previous := existingSettings.SecurityPolicy
+	existingSettings.SecurityPolicy = request
+
+	if err := s.userSettingsRepository.UpdateSettings(existingSettings); err != nil {
+		return nil, fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	getSecurityPolicyCache().Invalidate(securityPolicyCacheKey)
+
+	s.auditLogWriter.WriteAuditLog(
+		fmt.Sprintf(
+			"Security policy changed: sessionLifetimeHours %d -> %d, "+
+				"passwordMinLength %d -> %d, isPasswordComplexityRequired %t -> %t, "+
+				"allowedSsoDomains %v -> %v",
+			previous.SessionLifetimeHours,
+			request.SessionLifetimeHours,
+			previous.PasswordMinLength,
+			request.PasswordMinLength,
+			previous.IsPasswordComplexityRequired,
+			request.IsPasswordComplexityRequired,
+			previous.AllowedSSODomains,
+			request.AllowedSSODomains,
+		),
+		&updatedBy.ID,
+		nil,
+	)
+
+	if s.securityEventListener != nil {
+		s.securityEventListener.OnSecurityPolicyChanged(updatedBy, previous, request)
+	}
+
+	return &existingSettings.SecurityPolicy, nil
+}
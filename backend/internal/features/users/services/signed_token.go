@@ -0,0 +1,56 @@
+package users_services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const passwordResetPurpose = "password_reset"
+
+var errInvalidSignedToken = errors.New("invalid signed token")
+
+// signPurposeToken signs claims of a link sent by email. The purpose keeps tokens of one
+// flow from being accepted by another, e.g. a verification link resetting a password
+func (s *UserService) signPurposeToken(purpose string, claims jwt.MapClaims) (string, error) {
+	secretKey, err := s.secretKeyService.GetSecretKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	claims["purpose"] = purpose
+
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).
+		SignedString([]byte(secretKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signedToken, nil
+}
+
+// parsePurposeToken checks the signature, expiry and purpose of the token
+func (s *UserService) parsePurposeToken(purpose string, signedToken string) (jwt.MapClaims, error) {
+	secretKey, err := s.secretKeyService.GetSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret key: %w", err)
+	}
+
+	parsedToken, err := jwt.Parse(signedToken, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secretKey), nil
+	})
+	if err != nil || !parsedToken.Valid {
+		return nil, errInvalidSignedToken
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != purpose {
+		return nil, errInvalidSignedToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,100 @@
+package users_services
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/google/uuid"
+
+	users_models "databasus-backend/internal/features/users/models"
+	"databasus-backend/internal/util/i18n"
+)
+
+func (s *UserService) UpdateUserPreferences(
+	userID uuid.UUID,
+	preferences users_models.UserPreferences,
+) error {
+	return s.userRepository.UpdateUserPreferences(userID, preferences)
+}
+
+// NotifyOwnerAboutBackupFailure emails the owner of the database if they opted in. The email
+// must be verified, so failures of sensitive resources never go to an unconfirmed address
+func (s *UserService) NotifyOwnerAboutBackupFailure(
+	ownerUserID uuid.UUID,
+	databaseName string,
+	workspaceName string,
+	errorMessage string,
+) error {
+	if !s.isEmailVerificationEnabled() {
+		return nil
+	}
+
+	owner, err := s.userRepository.GetUserByID(ownerUserID)
+	if err != nil {
+		return err
+	}
+
+	if !owner.IsActiveUser() || !owner.IsEmailVerified ||
+		!owner.Preferences.IsNotifyOnOwnedBackupFailures {
+		return nil
+	}
+
+	subject, body := s.buildOwnedBackupFailureEmail(
+		owner.Locale,
+		databaseName,
+		workspaceName,
+		errorMessage,
+	)
+
+	if err := s.emailSender.SendEmail(owner.Email, subject, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserService) buildOwnedBackupFailureEmail(
+	locale i18n.Locale,
+	databaseName string,
+	workspaceName string,
+	errorMessage string,
+) (string, string) {
+	branding := s.brandingService.GetBrandingOrDefault()
+
+	subject := i18n.Translate(locale, i18n.MessageBackupFailedTitle, map[string]string{
+		"database":  databaseName,
+		"workspace": workspaceName,
+	})
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+</head>
+<body style="margin: 0; padding: 0; font-family: Arial, sans-serif; background-color: #f4f4f4;">
+    <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; padding: 20px;">
+        %s
+        <h2 style="color: #333333; margin-bottom: 20px;">%s</h2>
+        <div style="background-color: #f8f9fa; border: 2px solid #e9ecef; border-radius: 8px; padding: 20px; margin: 30px 0; word-break: break-word; font-family: monospace; white-space: pre-wrap;">%s</div>
+        <hr style="border: none; border-top: 1px solid #e9ecef; margin: 30px 0;">
+        <p style="color: #999999; font-size: 12px; line-height: 1.6;">
+            %s
+        </p>
+        %s
+    </div>
+</body>
+</html>
+`,
+		s.brandingService.BuildEmailHeader(branding),
+		html.EscapeString(subject),
+		html.EscapeString(errorMessage),
+		i18n.Translate(locale, i18n.MessageEmailOwnedBackupFailedReason, map[string]string{
+			"product": branding.ProductName,
+		}),
+		s.brandingService.BuildEmailFooter(branding),
+	)
+
+	return subject, body
+}
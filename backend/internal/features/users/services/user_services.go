@@ -3,11 +3,14 @@ package users_services
 import (
 	"context"
 	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -25,6 +28,7 @@ import (
 	users_interfaces "databasus-backend/internal/features/users/interfaces"
 	users_models "databasus-backend/internal/features/users/models"
 	users_repositories "databasus-backend/internal/features/users/repositories"
+	"databasus-backend/internal/util/i18n"
 )
 
 type UserService struct {
@@ -34,16 +38,32 @@ type UserService struct {
 	auditLogWriter          users_interfaces.AuditLogWriter
 	emailSender             users_interfaces.EmailSender
 	passwordResetRepository *users_repositories.PasswordResetRepository
+	brandingService         *BrandingService
+
+	emailVerificationRepository *users_repositories.EmailVerificationRepository
+	securityEventListener       users_interfaces.SecurityEventListener
+
+	clientCertificateAuthenticator users_interfaces.ClientCertificateAuthenticator
 }
 
 func (s *UserService) SetAuditLogWriter(writer users_interfaces.AuditLogWriter) {
 	s.auditLogWriter = writer
 }
 
+func (s *UserService) SetSecurityEventListener(listener users_interfaces.SecurityEventListener) {
+	s.securityEventListener = listener
+}
+
 func (s *UserService) SetEmailSender(sender users_interfaces.EmailSender) {
 	s.emailSender = sender
 }
 
+func (s *UserService) SetClientCertificateAuthenticator(
+	authenticator users_interfaces.ClientCertificateAuthenticator,
+) {
+	s.clientCertificateAuthenticator = authenticator
+}
+
 func (s *UserService) SignUp(request *users_dto.SignUpRequestDTO) error {
 	existingUser, err := s.userRepository.GetUserByEmail(request.Email)
 	if err != nil {
@@ -54,6 +74,10 @@ func (s *UserService) SignUp(request *users_dto.SignUpRequestDTO) error {
 		return errors.New("user with this email already exists")
 	}
 
+	if err := s.validatePassword(request.Password); err != nil {
+		return err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
@@ -88,6 +112,8 @@ func (s *UserService) SignUp(request *users_dto.SignUpRequestDTO) error {
 			nil,
 		)
 
+		s.sendSignUpVerificationEmail(existingUser)
+
 		return nil
 	}
 
@@ -123,11 +149,30 @@ func (s *UserService) SignUp(request *users_dto.SignUpRequestDTO) error {
 		nil,
 	)
 
+	s.sendSignUpVerificationEmail(user)
+
 	return nil
 }
 
+// sendSignUpVerificationEmail does not fail the sign up, the user can resend the email
+// from the profile
+func (s *UserService) sendSignUpVerificationEmail(user *users_models.User) {
+	if !s.isEmailVerificationEnabled() {
+		return
+	}
+
+	_ = s.SendVerificationEmail(user)
+}
+
+// isEmailVerificationEnabled is false without SMTP, then emails change right away and stay
+// unverified
+func (s *UserService) isEmailVerificationEnabled() bool {
+	return s.emailSender != nil && s.emailSender.IsConfigured()
+}
+
 func (s *UserService) SignIn(
 	request *users_dto.SignInRequestDTO,
+	client users_dto.SignInClientDTO,
 ) (*users_dto.SignInResponseDTO, error) {
 	user, err := s.userRepository.GetUserByEmail(request.Email)
 	if err != nil {
@@ -159,6 +204,10 @@ func (s *UserService) SignIn(
 
 	err = bcrypt.CompareHashAndPassword([]byte(*user.HashedPassword), []byte(request.Password))
 	if err != nil {
+		if s.securityEventListener != nil {
+			s.securityEventListener.OnSignInFailed(user, client)
+		}
+
 		return nil, errors.New("password is incorrect")
 	}
 
@@ -167,8 +216,13 @@ func (s *UserService) SignIn(
 		return nil, err
 	}
 
-	s.auditLogWriter.WriteAuditLog(
+	if s.securityEventListener != nil {
+		s.securityEventListener.OnSignInSucceeded(user, client)
+	}
+
+	s.auditLogWriter.WriteClientAuditLog(
 		fmt.Sprintf("User signed in with email: %s", user.Email),
+		client.IPAddress,
 		&user.ID,
 		nil,
 	)
@@ -176,6 +230,32 @@ func (s *UserService) SignIn(
 	return response, nil
 }
 
+// GetUserFromClientCertificate returns the user a verified client certificate is registered
+// for. The certificate is expected to be verified against the client CA by the TLS server
+func (s *UserService) GetUserFromClientCertificate(
+	certificate *x509.Certificate,
+) (*users_models.User, error) {
+	if s.clientCertificateAuthenticator == nil {
+		return nil, errors.New("client certificate authentication is not configured")
+	}
+
+	userID, err := s.clientCertificateAuthenticator.FindUserIDByCertificate(certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActiveUser() {
+		return nil, errors.New("user account is deactivated")
+	}
+
+	return user, nil
+}
+
 func (s *UserService) GetUserFromToken(token string) (*users_models.User, error) {
 	secretKey, err := s.secretKeyService.GetSecretKey()
 	if err != nil {
@@ -227,6 +307,10 @@ func (s *UserService) GetUserFromToken(token string) (*users_models.User, error)
 			return nil, errors.New("invalid token claims: missing password creation time")
 		}
 
+		if err := s.validateSessionAge(claims); err != nil {
+			return nil, err
+		}
+
 		return user, nil
 	}
 
@@ -241,12 +325,16 @@ func (s *UserService) GenerateAccessToken(
 		return nil, fmt.Errorf("failed to get secret key: %w", err)
 	}
 
-	tenYearsExpiration := time.Now().UTC().Add(time.Hour * 24 * 365 * 10)
+	now := time.Now().UTC()
+	expiration, err := s.getSessionExpiration(now)
+	if err != nil {
+		return nil, err
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub":                  user.ID.String(),
-		"exp":                  tenYearsExpiration.Unix(),
-		"iat":                  time.Now().UTC().Unix(),
+		"exp":                  expiration.Unix(),
+		"iat":                  now.Unix(),
 		"role":                 string(user.Role),
 		"passwordCreationTime": user.PasswordCreationTime.Unix(),
 	})
@@ -294,6 +382,10 @@ func (s *UserService) SetRootAdminPassword(password string) error {
 		return errors.New("admin password is already set")
 	}
 
+	if err := s.validatePassword(password); err != nil {
+		return err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
@@ -324,6 +416,10 @@ func (s *UserService) ChangeUserPasswordByEmail(email string, newPassword string
 }
 
 func (s *UserService) ChangeUserPassword(userID uuid.UUID, newPassword string) error {
+	if err := s.validatePassword(newPassword); err != nil {
+		return err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("failed to hash new password: %w", err)
@@ -408,16 +504,24 @@ func (s *UserService) GetUserByEmail(email string) (*users_models.User, error) {
 	return s.userRepository.GetUserByEmail(email)
 }
 
+func (s *UserService) GetActiveAdmins() ([]*users_models.User, error) {
+	return s.userRepository.GetActiveUsersByRole(users_enums.UserRoleAdmin)
+}
+
 func (s *UserService) GetCurrentUserProfile(
 	user *users_models.User,
 ) *users_dto.UserProfileResponseDTO {
 	return &users_dto.UserProfileResponseDTO{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Role:      user.Role,
-		IsActive:  user.IsActiveUser(),
-		CreatedAt: user.CreatedAt,
+		ID:              user.ID,
+		Email:           user.Email,
+		Name:            user.Name,
+		Role:            user.Role,
+		IsActive:        user.IsActiveUser(),
+		IsEmailVerified: user.IsEmailVerified,
+		PendingEmail:    user.PendingEmail,
+		Locale:          i18n.NormalizeLocale(user.Locale),
+		CreatedAt:       user.CreatedAt,
+		Preferences:     user.Preferences,
 	}
 }
 
@@ -434,7 +538,10 @@ func (s *UserService) UpdateUserInfo(
 		return errors.New("admin email cannot be changed")
 	}
 
+	var newEmail *string
 	if request.Email != nil && *request.Email != user.Email {
+		newEmail = request.Email
+
 		existingUser, err := s.userRepository.GetUserByEmail(*request.Email)
 		if err != nil {
 			return fmt.Errorf("failed to check email: %w", err)
@@ -444,11 +551,42 @@ func (s *UserService) UpdateUserInfo(
 		}
 	}
 
-	if err := s.userRepository.UpdateUserInfo(userID, request.Name, request.Email); err != nil {
+	if request.Locale != nil && !i18n.IsSupportedLocale(*request.Locale) {
+		return fmt.Errorf("unsupported locale: %s", *request.Locale)
+	}
+
+	// A new email is kept pending until the link sent to it is followed
+	changedEmail := newEmail
+	if newEmail != nil && s.isEmailVerificationEnabled() {
+		changedEmail = nil
+	}
+
+	if err := s.userRepository.UpdateUserInfo(userID, request.Name, changedEmail); err != nil {
 		return fmt.Errorf("failed to update user info: %w", err)
 	}
 
+	if request.Email != nil && changedEmail == nil &&
+		(newEmail != nil || user.PendingEmail != nil) {
+		if err := s.userRepository.UpdatePendingEmail(userID, newEmail); err != nil {
+			return fmt.Errorf("failed to update pending email: %w", err)
+		}
+	}
+
+	if request.Locale != nil {
+		if err := s.userRepository.UpdateUserLocale(userID, *request.Locale); err != nil {
+			return fmt.Errorf("failed to update user locale: %w", err)
+		}
+	}
+
 	s.auditLogWriter.WriteAuditLog("User info updated", &userID, nil)
+
+	if newEmail != nil && changedEmail == nil {
+		user.PendingEmail = newEmail
+		if err := s.SendVerificationEmail(user); err != nil {
+			return fmt.Errorf("failed to send verification email: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -606,6 +744,10 @@ func (s *UserService) handleGoogleOAuthWithEndpoint(
 func (s *UserService) getOrCreateUserFromOAuth(
 	oauthID, email, name, provider string,
 ) (*users_dto.OAuthCallbackResponseDTO, error) {
+	if err := s.validateSSOEmail(email); err != nil {
+		return nil, err
+	}
+
 	var existingUser *users_models.User
 	var err error
 
@@ -723,6 +865,7 @@ func (s *UserService) getOrCreateUserFromOAuth(
 		GitHubOAuthID:        githubOAuthID,
 		GoogleOAuthID:        googleOAuthID,
 		CreatedAt:            time.Now().UTC(),
+		IsEmailVerified:      true,
 	}
 
 	if err := s.userRepository.CreateUser(newUser); err != nil {
@@ -858,23 +1001,42 @@ func (s *UserService) SendResetPasswordCode(email string) error {
 		return fmt.Errorf("failed to hash code: %w", err)
 	}
 
-	// Store in database with 1 hour expiration
+	lifetimeMinutes := config.GetReloadableSettings().PasswordResetTokenLifetimeMinutes
+
 	resetCode := &users_models.PasswordResetCode{
 		ID:         uuid.New(),
 		UserID:     user.ID,
 		HashedCode: string(hashedCode),
-		ExpiresAt:  time.Now().UTC().Add(1 * time.Hour),
+		ExpiresAt:  time.Now().UTC().Add(time.Duration(lifetimeMinutes) * time.Minute),
 		IsUsed:     false,
 		CreatedAt:  time.Now().UTC(),
 	}
 
+	// The link resets the password without typing the code, both use the same record
+	resetToken, err := s.signPurposeToken(passwordResetPurpose, jwt.MapClaims{
+		"sub": user.ID.String(),
+		"jti": resetCode.ID.String(),
+		"exp": resetCode.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
 	if err := s.passwordResetRepository.CreateResetCode(resetCode); err != nil {
 		return fmt.Errorf("failed to create reset code: %w", err)
 	}
 
 	// Send email with code
 	if s.emailSender != nil {
-		subject := "Password Reset Code"
+		branding := s.brandingService.GetBrandingOrDefault()
+
+		brandingParams := map[string]string{"product": branding.ProductName}
+
+		subject := i18n.Translate(
+			user.Locale,
+			i18n.MessageEmailPasswordResetSubject,
+			brandingParams,
+		)
 		body := fmt.Sprintf(`
 <!DOCTYPE html>
 <html>
@@ -884,27 +1046,45 @@ func (s *UserService) SendResetPasswordCode(email string) error {
 </head>
 <body style="margin: 0; padding: 0; font-family: Arial, sans-serif; background-color: #f4f4f4;">
     <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; padding: 20px;">
-        <h2 style="color: #333333; margin-bottom: 20px;">Password Reset Request</h2>
+        %s
+        <h2 style="color: #333333; margin-bottom: 20px;">%s</h2>
         <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
-            You have requested to reset your password. Please use the following code to complete the password reset process:
+            %s
         </p>
         <div style="background-color: #f8f9fa; border: 2px solid #e9ecef; border-radius: 8px; padding: 20px; text-align: center; margin: 30px 0;">
-            <h1 style="color: #2c3e50; font-size: 36px; margin: 0; letter-spacing: 8px; font-family: monospace;">%s</h1>
+            <h1 style="color: %s; font-size: 36px; margin: 0; letter-spacing: 8px; font-family: monospace;">%s</h1>
         </div>
+        %s
         <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
-            This code will expire in <strong>1 hour</strong>.
+            %s
         </p>
         <p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">
-            If you did not request a password reset, please ignore this email. Your password will remain unchanged.
+            %s
         </p>
         <hr style="border: none; border-top: 1px solid #e9ecef; margin: 30px 0;">
         <p style="color: #999999; font-size: 12px; line-height: 1.6;">
-            This is an automated message. Please do not reply to this email.
+            %s
         </p>
+        %s
     </div>
 </body>
 </html>
-`, code)
+`,
+			s.brandingService.BuildEmailHeader(branding),
+			i18n.Translate(user.Locale, i18n.MessageEmailPasswordResetHeading, nil),
+			i18n.Translate(user.Locale, i18n.MessageEmailPasswordResetIntro, nil),
+			branding.AccentColor,
+			code,
+			s.buildResetPasswordLink(user.Locale, resetToken, branding.AccentColor),
+			i18n.Translate(
+				user.Locale,
+				i18n.MessageEmailPasswordResetExpiration,
+				map[string]string{"minutes": strconv.Itoa(lifetimeMinutes)},
+			),
+			i18n.Translate(user.Locale, i18n.MessageEmailPasswordResetIgnore, nil),
+			i18n.Translate(user.Locale, i18n.MessageEmailPasswordResetAutomated, brandingParams),
+			s.brandingService.BuildEmailFooter(branding),
+		)
 
 		if err := s.emailSender.SendEmail(user.Email, subject, body); err != nil {
 			return fmt.Errorf("failed to send email: %w", err)
@@ -933,6 +1113,11 @@ func (s *UserService) ResetPassword(email, code, newPassword string) error {
 		return errors.New("user with this email does not exist")
 	}
 
+	// checked before the code is used up, so the user can retry with another password
+	if err := s.validatePassword(newPassword); err != nil {
+		return err
+	}
+
 	// Get valid reset code for user
 	resetCode, err := s.passwordResetRepository.GetValidCodeByUserID(user.ID)
 	if err != nil {
@@ -946,9 +1131,13 @@ func (s *UserService) ResetPassword(email, code, newPassword string) error {
 	}
 
 	// Mark code as used
-	if err := s.passwordResetRepository.MarkCodeAsUsed(resetCode.ID); err != nil {
+	isMarked, err := s.passwordResetRepository.MarkCodeAsUsed(resetCode.ID)
+	if err != nil {
 		return fmt.Errorf("failed to mark code as used: %w", err)
 	}
+	if !isMarked {
+		return errors.New("invalid or expired reset code")
+	}
 
 	// Update user password
 	if err := s.ChangeUserPassword(user.ID, newPassword); err != nil {
@@ -966,3 +1155,75 @@ func (s *UserService) ResetPassword(email, code, newPassword string) error {
 
 	return nil
 }
+
+// ResetPasswordByToken resets the password with the link of the reset email, it uses up the
+// emailed code as well
+func (s *UserService) ResetPasswordByToken(token, newPassword string) error {
+	if err := s.validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	claims, err := s.parsePurposeToken(passwordResetPurpose, token)
+	if err != nil {
+		return errors.New("invalid or expired reset link")
+	}
+
+	recordID, _ := claims["jti"].(string)
+	userID, _ := claims["sub"].(string)
+
+	codeID, err := uuid.Parse(recordID)
+	if err != nil {
+		return errors.New("invalid or expired reset link")
+	}
+
+	resetCode, err := s.passwordResetRepository.GetCodeByID(codeID)
+	if err != nil || !resetCode.IsValid() || resetCode.UserID.String() != userID {
+		return errors.New("invalid or expired reset link")
+	}
+
+	isMarked, err := s.passwordResetRepository.MarkCodeAsUsed(resetCode.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark code as used: %w", err)
+	}
+	if !isMarked {
+		return errors.New("invalid or expired reset link")
+	}
+
+	if err := s.ChangeUserPassword(resetCode.UserID, newPassword); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if s.auditLogWriter != nil {
+		s.auditLogWriter.WriteAuditLog(
+			"Password reset via email link",
+			&resetCode.UserID,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func (s *UserService) buildResetPasswordLink(
+	locale i18n.Locale,
+	resetToken string,
+	accentColor string,
+) string {
+	databasusURL := config.GetEnv().DatabasusURL
+	if databasusURL == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`<p style="color: #666666; line-height: 1.6; margin-bottom: 20px;">%s</p>
+        <p style="margin: 20px 0 30px; text-align: center;">
+            <a href="%s/reset-password?token=%s" style="display: inline-block; padding: 12px 24px; background-color: %s; color: white; text-decoration: none; border-radius: 4px;">
+                %s
+            </a>
+        </p>`,
+		i18n.Translate(locale, i18n.MessageEmailPasswordResetLinkIntro, nil),
+		databasusURL,
+		url.QueryEscape(resetToken),
+		accentColor,
+		i18n.Translate(locale, i18n.MessageEmailPasswordResetButton, nil),
+	)
+}
@@ -5,6 +5,8 @@ import "errors"
 type MockEmailSender struct {
 	SentEmails []EmailCall
 	ShouldFail bool
+	// IsSMTPConfigured enables flows skipped without SMTP, such as email change verification
+	IsSMTPConfigured bool
 }
 
 type EmailCall struct {
@@ -25,6 +27,10 @@ func (m *MockEmailSender) SendEmail(to, subject, body string) error {
 	return nil
 }
 
+func (m *MockEmailSender) IsConfigured() bool {
+	return m.IsSMTPConfigured
+}
+
 func NewMockEmailSender() *MockEmailSender {
 	return &MockEmailSender{
 		SentEmails: []EmailCall{},
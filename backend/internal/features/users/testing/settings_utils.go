@@ -1,7 +1,9 @@
 package users_testing
 
 import (
+	users_models "databasus-backend/internal/features/users/models"
 	users_repositories "databasus-backend/internal/features/users/repositories"
+	cache_utils "databasus-backend/internal/util/cache"
 )
 
 func EnableMemberInvitations() {
@@ -38,11 +40,16 @@ func ResetSettingsToDefaults() {
 	settings.IsAllowExternalRegistrations = true
 	settings.IsAllowMemberInvitations = true
 	settings.IsMemberAllowedToCreateWorkspaces = true
+	settings.SystemStorageRedactionLevels = nil
+	settings.SecurityPolicy = users_models.SecurityPolicy{}
+	settings.SecurityHeaders = users_models.SecurityHeaders{}
 
 	err = repository.UpdateSettings(settings)
 	if err != nil {
 		panic(err)
 	}
+
+	invalidateSecurityCaches()
 }
 
 func updateUsersSetting(column string, value bool) {
@@ -66,3 +73,14 @@ func updateUsersSetting(column string, value bool) {
 		panic(err)
 	}
 }
+
+// invalidateSecurityCaches drops the policy and headers cached by the settings service, the
+// keys mirror the ones in users_services because settings are changed directly in DB here
+func invalidateSecurityCaches() {
+	client := cache_utils.GetValkeyClient()
+
+	cache_utils.NewCacheUtil[users_models.SecurityPolicy](client, "security_policy:").
+		Invalidate("current")
+	cache_utils.NewCacheUtil[users_models.SecurityHeaders](client, "security_headers:").
+		Invalidate("current")
+}
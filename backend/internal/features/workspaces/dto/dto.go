@@ -3,6 +3,7 @@ package workspaces_dto
 import (
 	"time"
 
+	"databasus-backend/internal/features/environments"
 	users_enums "databasus-backend/internal/features/users/enums"
 
 	"github.com/google/uuid"
@@ -17,13 +18,15 @@ const (
 
 // Workspace DTOs
 type CreateWorkspaceRequestDTO struct {
-	Name string `json:"name" binding:"required,min=1,max=255"`
+	Name        string                   `json:"name"        binding:"required,min=1,max=255"`
+	Environment environments.Environment `json:"environment"`
 }
 
 type WorkspaceResponseDTO struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID          uuid.UUID                `json:"id"`
+	Name        string                   `json:"name"`
+	Environment environments.Environment `json:"environment"`
+	CreatedAt   time.Time                `json:"createdAt"`
 
 	// User's role in this workspace (populated when fetching for specific user)
 	UserRole *users_enums.WorkspaceRole `json:"userRole,omitempty"`
@@ -19,6 +19,9 @@ var (
 	ErrOnlyOwnerOrAdminCanDeleteWorkspace = errors.New(
 		"only workspace owner or admin can delete workspace",
 	)
+	ErrChangeReasonRequired = errors.New(
+		"workspace requires a change reason for edits",
+	)
 
 	// Membership errors
 	ErrInsufficientPermissionsToViewMembers = errors.New(
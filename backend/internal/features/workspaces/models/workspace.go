@@ -3,13 +3,20 @@ package workspaces_models
 import (
 	"time"
 
+	"databasus-backend/internal/features/environments"
+
 	"github.com/google/uuid"
 )
 
 type Workspace struct {
-	ID        uuid.UUID `json:"id"        gorm:"column:id"`
-	Name      string    `json:"name"      gorm:"column:name"`
-	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+	ID          uuid.UUID                `json:"id"          gorm:"column:id"`
+	Name        string                   `json:"name"        gorm:"column:name"`
+	Environment environments.Environment `json:"environment" gorm:"column:environment"`
+	CreatedAt   time.Time                `json:"createdAt"   gorm:"column:created_at"`
+
+	// IsChangeReasonRequired makes edits of storages, databases and notifiers of the
+	// workspace give a reason, e.g. for regulated environments
+	IsChangeReasonRequired bool `json:"isChangeReasonRequired" gorm:"column:is_change_reason_required"`
 }
 
 func (Workspace) TableName() string {
@@ -18,4 +25,6 @@ func (Workspace) TableName() string {
 
 func (p *Workspace) UpdateFromDTO(updateDTO *Workspace) {
 	p.Name = updateDTO.Name
+	p.Environment = updateDTO.Environment
+	p.IsChangeReasonRequired = updateDTO.IsChangeReasonRequired
 }
@@ -26,7 +26,13 @@ func (r *MembershipRepository) CreateMembership(
 		membership.CreatedAt = time.Now().UTC()
 	}
 
-	return storage.GetDb().Create(membership).Error
+	if err := storage.GetDb().Create(membership).Error; err != nil {
+		return err
+	}
+
+	invalidateCachedWorkspaceRole(membership.WorkspaceID, membership.UserID)
+
+	return nil
 }
 
 func (r *MembershipRepository) GetMembershipByUserAndWorkspace(
@@ -63,21 +69,39 @@ func (r *MembershipRepository) UpdateMemberRole(
 	userID, workspaceID uuid.UUID,
 	role users_enums.WorkspaceRole,
 ) error {
-	return storage.GetDb().
+	err := storage.GetDb().
 		Model(&workspaces_models.WorkspaceMembership{}).
 		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
 		Update("role", role).Error
+	if err != nil {
+		return err
+	}
+
+	invalidateCachedWorkspaceRole(workspaceID, userID)
+
+	return nil
 }
 
 func (r *MembershipRepository) RemoveMember(userID, workspaceID uuid.UUID) error {
-	return storage.GetDb().
+	err := storage.GetDb().
 		Where("user_id = ? AND workspace_id = ?", userID, workspaceID).
 		Delete(&workspaces_models.WorkspaceMembership{}).Error
+	if err != nil {
+		return err
+	}
+
+	invalidateCachedWorkspaceRole(workspaceID, userID)
+
+	return nil
 }
 
 func (r *MembershipRepository) GetUserWorkspaceRole(
 	workspaceID, userID uuid.UUID,
 ) (*users_enums.WorkspaceRole, error) {
+	if role, isCached := getCachedWorkspaceRole(workspaceID, userID); isCached {
+		return role, nil
+	}
+
 	var membership workspaces_models.WorkspaceMembership
 	err := storage.GetDb().
 		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
@@ -85,12 +109,15 @@ func (r *MembershipRepository) GetUserWorkspaceRole(
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			setCachedWorkspaceRole(workspaceID, userID, nil)
 			return nil, nil
 		}
 
 		return nil, err
 	}
 
+	setCachedWorkspaceRole(workspaceID, userID, &membership.Role)
+
 	return &membership.Role, nil
 }
 
@@ -123,7 +150,7 @@ func (r *MembershipRepository) GetWorkspacesWithRolesByUserID(
 
 	err := storage.GetDb().
 		Table("workspaces w").
-		Select("w.id, w.name, w.created_at, wm.role as user_role").
+		Select("w.id, w.name, w.environment, w.created_at, wm.role as user_role").
 		Joins("JOIN workspace_memberships wm ON w.id = wm.workspace_id").
 		Where("wm.user_id = ?", userID).
 		Order("w.name ASC").
@@ -131,3 +158,11 @@ func (r *MembershipRepository) GetWorkspacesWithRolesByUserID(
 
 	return results, err
 }
+
+// InvalidateWorkspaceRoleCacheForTests lets benchmarks measure permission checks that
+// miss the role cache
+func (r *MembershipRepository) InvalidateWorkspaceRoleCacheForTests(
+	workspaceID, userID uuid.UUID,
+) {
+	invalidateCachedWorkspaceRole(workspaceID, userID)
+}
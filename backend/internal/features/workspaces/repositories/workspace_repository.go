@@ -39,8 +39,28 @@ func (r *WorkspaceRepository) UpdateWorkspace(workspace *workspaces_models.Works
 	return storage.GetDb().Save(workspace).Error
 }
 
+// DeleteWorkspace also drops cached roles of members: memberships are removed by FK cascade
+// and a recreated workspace must not inherit them
 func (r *WorkspaceRepository) DeleteWorkspace(workspaceID uuid.UUID) error {
-	return storage.GetDb().Delete(&workspaces_models.Workspace{}, workspaceID).Error
+	var memberUserIDs []uuid.UUID
+
+	err := storage.GetDb().
+		Model(&workspaces_models.WorkspaceMembership{}).
+		Where("workspace_id = ?", workspaceID).
+		Pluck("user_id", &memberUserIDs).Error
+	if err != nil {
+		return err
+	}
+
+	if err := storage.GetDb().Delete(&workspaces_models.Workspace{}, workspaceID).Error; err != nil {
+		return err
+	}
+
+	for _, userID := range memberUserIDs {
+		invalidateCachedWorkspaceRole(workspaceID, userID)
+	}
+
+	return nil
 }
 
 func (r *WorkspaceRepository) GetAllWorkspaces() ([]*workspaces_models.Workspace, error) {
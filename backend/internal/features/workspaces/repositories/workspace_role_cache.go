@@ -0,0 +1,66 @@
+package workspaces_repositories
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	cache_utils "databasus-backend/internal/util/cache"
+)
+
+// Roles are read by permission checks on nearly every request. Writes invalidate the key
+// right away, the short TTL only bounds staleness when a read races with a write or when
+// memberships are changed by something other than this repository (e.g. FK cascades)
+const workspaceRoleCacheTTL = 30 * time.Second
+
+// cachedWorkspaceRole wraps the role, so "not a member" is cached as well and outsiders
+// probing a workspace do not reach DB on every request
+type cachedWorkspaceRole struct {
+	Role *users_enums.WorkspaceRole `json:"role"`
+}
+
+var (
+	workspaceRoleCache     *cache_utils.CacheUtil[cachedWorkspaceRole]
+	workspaceRoleCacheOnce sync.Once
+)
+
+func getWorkspaceRoleCache() *cache_utils.CacheUtil[cachedWorkspaceRole] {
+	workspaceRoleCacheOnce.Do(func() {
+		workspaceRoleCache = cache_utils.NewCacheUtil[cachedWorkspaceRole](
+			cache_utils.GetValkeyClient(),
+			"workspace_role:",
+		)
+	})
+
+	return workspaceRoleCache
+}
+
+func getCachedWorkspaceRole(workspaceID, userID uuid.UUID) (*users_enums.WorkspaceRole, bool) {
+	cached := getWorkspaceRoleCache().Get(getWorkspaceRoleCacheKey(workspaceID, userID))
+	if cached == nil {
+		return nil, false
+	}
+
+	return cached.Role, true
+}
+
+func setCachedWorkspaceRole(
+	workspaceID, userID uuid.UUID,
+	role *users_enums.WorkspaceRole,
+) {
+	getWorkspaceRoleCache().SetWithExpiration(
+		getWorkspaceRoleCacheKey(workspaceID, userID),
+		&cachedWorkspaceRole{Role: role},
+		workspaceRoleCacheTTL,
+	)
+}
+
+func invalidateCachedWorkspaceRole(workspaceID, userID uuid.UUID) {
+	getWorkspaceRoleCache().Invalidate(getWorkspaceRoleCacheKey(workspaceID, userID))
+}
+
+func getWorkspaceRoleCacheKey(workspaceID, userID uuid.UUID) string {
+	return workspaceID.String() + ":" + userID.String()
+}
@@ -30,6 +30,7 @@ var membershipService = &MembershipService{
 	users_services.GetSettingsService(),
 	email.GetEmailSMTPSender(),
 	logger.GetLogger(),
+	users_services.GetBrandingService(),
 }
 
 func GetWorkspaceService() *WorkspaceService {
@@ -15,6 +15,7 @@ import (
 	workspaces_interfaces "databasus-backend/internal/features/workspaces/interfaces"
 	workspaces_models "databasus-backend/internal/features/workspaces/models"
 	workspaces_repositories "databasus-backend/internal/features/workspaces/repositories"
+	"databasus-backend/internal/util/i18n"
 
 	"github.com/google/uuid"
 )
@@ -28,6 +29,7 @@ type MembershipService struct {
 	settingsService      *users_services.SettingsService
 	emailSender          workspaces_interfaces.EmailSender
 	logger               *slog.Logger
+	brandingService      *users_services.BrandingService
 }
 
 func (s *MembershipService) GetMembers(
@@ -99,9 +101,19 @@ func (s *MembershipService) AddMember(
 			return nil, err
 		}
 
-		// Send invitation email
-		subject := fmt.Sprintf("You've been invited to %s workspace", workspace.Name)
-		body := s.buildInvitationEmailHTML(workspace.Name, addedBy.Name, string(request.Role))
+		// Invited user has no account yet, so inviter's locale is the best guess
+		locale := addedBy.Locale
+		subject := i18n.Translate(
+			locale,
+			i18n.MessageEmailInvitationSubject,
+			map[string]string{"workspace": workspace.Name},
+		)
+		body := s.buildInvitationEmailHTML(
+			locale,
+			workspace.Name,
+			addedBy.Name,
+			string(request.Role),
+		)
 
 		if err := s.emailSender.SendEmail(request.Email, subject, body); err != nil {
 			s.logger.Error("Failed to send invitation email", "email", request.Email, "error", err)
@@ -360,20 +372,28 @@ func (s *MembershipService) validateCanManageMembership(
 }
 
 func (s *MembershipService) buildInvitationEmailHTML(
+	locale i18n.Locale,
 	workspaceName, inviterName, role string,
 ) string {
 	env := config.GetEnv()
+	branding := s.brandingService.GetBrandingOrDefault()
+	brandingParams := map[string]string{"product": branding.ProductName}
+
 	signUpLink := ""
 	if env.DatabasusURL != "" {
 		signUpLink = fmt.Sprintf(`<p style="margin: 20px 0;">
-			<a href="%s/sign-up" style="display: inline-block; padding: 12px 24px; background-color: #0d6efd; color: white; text-decoration: none; border-radius: 4px;">
-				Sign up
+			<a href="%s/sign-up" style="display: inline-block; padding: 12px 24px; background-color: %s; color: white; text-decoration: none; border-radius: 4px;">
+				%s
 			</a>
-		</p>`, env.DatabasusURL)
+		</p>`,
+			env.DatabasusURL,
+			branding.AccentColor,
+			i18n.Translate(locale, i18n.MessageEmailInvitationSignUp, nil),
+		)
 	} else {
-		signUpLink = `<p style="margin: 20px 0; color: #666;">
-			Please visit your Databasus instance to sign up and access the workspace.
-		</p>`
+		signUpLink = fmt.Sprintf(`<p style="margin: 20px 0; color: #666;">
+			%s
+		</p>`, i18n.Translate(locale, i18n.MessageEmailInvitationVisitInstance, brandingParams))
 	}
 
 	return fmt.Sprintf(`
@@ -385,10 +405,11 @@ func (s *MembershipService) buildInvitationEmailHTML(
 </head>
 <body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
 	<div style="background-color: #f8f9fa; border-radius: 8px; padding: 30px; margin: 20px 0;">
-		<h1 style="color: #0d6efd; margin-top: 0;">Workspace Invitation</h1>
+		%s
+		<h1 style="color: %s; margin-top: 0;">%s</h1>
 		
 		<p style="font-size: 16px; margin: 20px 0;">
-			<strong>%s</strong> has invited you to join the <strong>%s</strong> workspace as a <strong>%s</strong>.
+			%s
 		</p>
 		
 		%s
@@ -396,10 +417,23 @@ func (s *MembershipService) buildInvitationEmailHTML(
 		<hr style="border: none; border-top: 1px solid #dee2e6; margin: 30px 0;">
 		
 		<p style="font-size: 14px; color: #6c757d; margin: 0;">
-			This is an automated message from Databasus. If you didn't expect this invitation, you can safely ignore this email.
+			%s
 		</p>
+		%s
 	</div>
 </body>
 </html>
-	`, inviterName, workspaceName, role, signUpLink)
+	`,
+		s.brandingService.BuildEmailHeader(branding),
+		branding.AccentColor,
+		i18n.Translate(locale, i18n.MessageEmailInvitationHeading, nil),
+		i18n.Translate(locale, i18n.MessageEmailInvitationBody, map[string]string{
+			"inviter":   inviterName,
+			"workspace": workspaceName,
+			"role":      role,
+		}),
+		signUpLink,
+		i18n.Translate(locale, i18n.MessageEmailInvitationAutomated, brandingParams),
+		s.brandingService.BuildEmailFooter(branding),
+	)
 }
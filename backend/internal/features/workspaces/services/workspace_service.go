@@ -2,6 +2,7 @@ package workspaces_services
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
@@ -46,10 +47,15 @@ func (s *WorkspaceService) CreateWorkspace(
 		return nil, workspaces_errors.ErrInsufficientPermissionsToCreateWorkspaces
 	}
 
+	if err := request.Environment.Validate(); err != nil {
+		return nil, err
+	}
+
 	workspace := &workspaces_models.Workspace{
-		ID:        uuid.New(),
-		Name:      request.Name,
-		CreatedAt: time.Now().UTC(),
+		ID:          uuid.New(),
+		Name:        request.Name,
+		Environment: request.Environment,
+		CreatedAt:   time.Now().UTC(),
 	}
 
 	if err := s.workspaceRepository.CreateWorkspace(workspace); err != nil {
@@ -75,10 +81,11 @@ func (s *WorkspaceService) CreateWorkspace(
 
 	ownerRole := users_enums.WorkspaceRoleOwner
 	return &workspaces_dto.WorkspaceResponseDTO{
-		ID:        workspace.ID,
-		Name:      workspace.Name,
-		CreatedAt: workspace.CreatedAt,
-		UserRole:  &ownerRole,
+		ID:          workspace.ID,
+		Name:        workspace.Name,
+		Environment: workspace.Environment,
+		CreatedAt:   workspace.CreatedAt,
+		UserRole:    &ownerRole,
 	}, nil
 }
 
@@ -124,6 +131,10 @@ func (s *WorkspaceService) UpdateWorkspace(
 		return nil, workspaces_errors.ErrInsufficientPermissionsToUpdateWorkspace
 	}
 
+	if err := updateDTO.Environment.Validate(); err != nil {
+		return nil, err
+	}
+
 	existingWorkspace, err := s.workspaceRepository.GetWorkspaceByID(workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workspace: %w", err)
@@ -183,6 +194,20 @@ func (s *WorkspaceService) DeleteWorkspace(workspaceID uuid.UUID, user *users_mo
 	return nil
 }
 
+// ValidateChangeReason rejects edits without a reason in workspaces requiring one
+func (s *WorkspaceService) ValidateChangeReason(workspaceID uuid.UUID, changeReason string) error {
+	workspace, err := s.workspaceRepository.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	if workspace.IsChangeReasonRequired && strings.TrimSpace(changeReason) == "" {
+		return workspaces_errors.ErrChangeReasonRequired
+	}
+
+	return nil
+}
+
 func (s *WorkspaceService) GetUserWorkspaceRole(
 	workspaceID uuid.UUID,
 	userID uuid.UUID,
@@ -309,6 +334,12 @@ func (s *WorkspaceService) GetAllWorkspaces() ([]*workspaces_models.Workspace, e
 	return s.workspaceRepository.GetAllWorkspaces()
 }
 
+func (s *WorkspaceService) GetWorkspaceMembers(
+	workspaceID uuid.UUID,
+) ([]*workspaces_dto.WorkspaceMemberResponseDTO, error) {
+	return s.membershipRepository.GetWorkspaceMembers(workspaceID)
+}
+
 func (s *WorkspaceService) GetWorkspaceByID(
 	workspaceID uuid.UUID,
 ) (*workspaces_models.Workspace, error) {
@@ -0,0 +1,206 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// APIClient calls /api/v2 of Databasus as the configured user. v2 is used for split
+// create/update endpoints and structured errors
+type APIClient struct {
+	baseURL    string
+	email      string
+	password   string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("databasus API responded with %d: %s", e.StatusCode, e.Message)
+}
+
+// NewAPIClient expects URL of Databasus without API path, e.g. http://databasus:4005
+func NewAPIClient(baseURL, email, password string, httpClient *http.Client) *APIClient {
+	return &APIClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/api/v2",
+		email:      email,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// Create posts payload to the collection path and returns ID of the created object
+func (c *APIClient) Create(
+	ctx context.Context,
+	collectionPath string,
+	payload any,
+) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+
+	if err := c.Do(ctx, http.MethodPost, collectionPath, payload, &created); err != nil {
+		return "", err
+	}
+
+	if created.ID == "" {
+		return "", fmt.Errorf("POST %s response has no id", collectionPath)
+	}
+
+	return created.ID, nil
+}
+
+func (c *APIClient) Update(ctx context.Context, collectionPath, id string, payload any) error {
+	return c.Do(ctx, http.MethodPut, collectionPath+"/"+id, payload, nil)
+}
+
+// Delete treats missing objects as deleted, so a resource removed in UI does not
+// block deletion of the custom resource
+func (c *APIClient) Delete(ctx context.Context, collectionPath, id string) error {
+	err := c.Do(ctx, http.MethodDelete, collectionPath+"/"+id, nil, nil)
+	if isNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *APIClient) Do(ctx context.Context, method, path string, body, result any) error {
+	token, err := c.getToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.send(ctx, method, path, token, body, result)
+
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusUnauthorized {
+		// JWT expired, sign in again once
+		c.mu.Lock()
+		c.token = ""
+		c.mu.Unlock()
+
+		if token, err = c.getToken(ctx); err != nil {
+			return err
+		}
+
+		return c.send(ctx, method, path, token, body, result)
+	}
+
+	return err
+}
+
+func (c *APIClient) getToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	var signIn struct {
+		Token string `json:"token"`
+	}
+
+	credentials := map[string]string{"email": c.email, "password": c.password}
+	if err := c.send(ctx, http.MethodPost, "/users/signin", "", credentials, &signIn); err != nil {
+		return "", fmt.Errorf("failed to sign in to databasus as %s: %w", c.email, err)
+	}
+
+	c.token = signIn.Token
+
+	return c.token, nil
+}
+
+func (c *APIClient) send(
+	ctx context.Context,
+	method, path, token string,
+	body, result any,
+) error {
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		requestBody = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return readAPIError(response)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func readAPIError(response *http.Response) error {
+	apiError := &APIError{StatusCode: response.StatusCode}
+
+	errorBody, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+
+	var envelope struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(errorBody, &envelope) == nil && envelope.Error.Message != "" {
+		apiError.Code = envelope.Error.Code
+		apiError.Message = envelope.Error.Message
+	} else {
+		apiError.Message = strings.TrimSpace(string(errorBody))
+	}
+
+	return apiError
+}
+
+// isNotFound also matches 400 with gorm "record not found", services do not map missing
+// objects to 404
+func isNotFound(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+
+	return apiError.StatusCode == http.StatusNotFound ||
+		strings.Contains(strings.ToLower(apiError.Message), "not found")
+}
@@ -0,0 +1,223 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// KubeClient is a minimal Kubernetes REST client, the operator needs only list and
+// patch of its own resources and reading Secrets
+type KubeClient struct {
+	baseURL    string
+	tokenPath  string
+	httpClient *http.Client
+}
+
+// NewInClusterKubeClient uses the pod service account. The token is re-read on every
+// request, because projected tokens are rotated by kubelet
+func NewInClusterKubeClient() (*KubeClient, error) {
+	host := os.Getenv("KUBERNETES_SERVICE_HOST")
+	port := os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New(
+			"operator must run inside Kubernetes: KUBERNETES_SERVICE_HOST is empty",
+		)
+	}
+
+	caCert, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account CA: %w", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("service account CA is not a valid PEM certificate")
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+		},
+	}
+
+	return NewKubeClient(
+		"https://"+net.JoinHostPort(host, port),
+		serviceAccountDir+"/token",
+		httpClient,
+	), nil
+}
+
+func NewKubeClient(baseURL, tokenPath string, httpClient *http.Client) *KubeClient {
+	return &KubeClient{strings.TrimSuffix(baseURL, "/"), tokenPath, httpClient}
+}
+
+// ListResources lists custom resources of the plural in namespace, all namespaces if empty
+func (k *KubeClient) ListResources(
+	ctx context.Context,
+	namespace string,
+	plural string,
+) ([]Resource, error) {
+	path := fmt.Sprintf("/apis/%s/%s/%s", Group, Version, plural)
+	if namespace != "" {
+		path = fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s", Group, Version, namespace, plural)
+	}
+
+	var list struct {
+		Items []Resource `json:"items"`
+	}
+
+	if err := k.do(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// PatchFinalizers replaces finalizers. resourceVersion makes the patch fail on a
+// concurrent change instead of dropping finalizers added by someone else
+func (k *KubeClient) PatchFinalizers(
+	ctx context.Context,
+	plural string,
+	resource *Resource,
+	finalizers []string,
+) error {
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"finalizers":      finalizers,
+			"resourceVersion": resource.Metadata.ResourceVersion,
+		},
+	}
+
+	var updated Resource
+	if err := k.do(
+		ctx,
+		http.MethodPatch,
+		k.resourcePath(plural, resource),
+		patch,
+		&updated,
+	); err != nil {
+		return err
+	}
+
+	resource.Metadata = updated.Metadata
+
+	return nil
+}
+
+func (k *KubeClient) PatchStatus(
+	ctx context.Context,
+	plural string,
+	resource *Resource,
+	status ResourceStatus,
+) error {
+	path := k.resourcePath(plural, resource) + "/status"
+	return k.do(ctx, http.MethodPatch, path, map[string]any{"status": status}, nil)
+}
+
+func (k *KubeClient) GetSecretValue(
+	ctx context.Context,
+	namespace string,
+	name string,
+	key string,
+) (string, error) {
+	var secret struct {
+		Data map[string]string `json:"data"`
+	}
+
+	path := fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", namespace, name)
+	if err := k.do(ctx, http.MethodGet, path, nil, &secret); err != nil {
+		return "", err
+	}
+
+	encoded, isFound := secret.Data[key]
+	if !isFound {
+		return "", fmt.Errorf("secret %s/%s has no key %s", namespace, name, key)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("secret %s/%s key %s is not base64: %w", namespace, name, key, err)
+	}
+
+	return string(decoded), nil
+}
+
+func (k *KubeClient) resourcePath(plural string, resource *Resource) string {
+	return fmt.Sprintf(
+		"/apis/%s/%s/namespaces/%s/%s/%s",
+		Group,
+		Version,
+		resource.Metadata.Namespace,
+		plural,
+		resource.Metadata.Name,
+	)
+}
+
+func (k *KubeClient) do(ctx context.Context, method, path string, body, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		requestBody = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, k.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if method == http.MethodPatch {
+		request.Header.Set("Content-Type", "application/merge-patch+json")
+	}
+
+	if k.tokenPath != "" {
+		token, err := os.ReadFile(k.tokenPath)
+		if err != nil {
+			return fmt.Errorf("failed to read service account token: %w", err)
+		}
+
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	response, err := k.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf(
+			"kubernetes API %s %s responded with %d: %s",
+			method,
+			path,
+			response.StatusCode,
+			strings.TrimSpace(string(message)),
+		)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
@@ -0,0 +1,496 @@
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Reconciler syncs custom resources to Databasus by periodic full resync instead of
+// watches: resources are few, and a resync also retries failed syncs and re-applies
+// rotated secrets
+type Reconciler struct {
+	kube      *KubeClient
+	api       *APIClient
+	namespace string
+	logger    *slog.Logger
+}
+
+type resourceKind struct {
+	plural                string
+	apiPath               string
+	defaultDeletionPolicy DeletionPolicy
+	buildDesired          func(
+		r *Reconciler,
+		ctx context.Context,
+		resource *Resource,
+		readyIDs map[string]string,
+	) (*desiredState, error)
+}
+
+// desiredState is what gets applied and hashed. Backup is set only for DatabaseBackup
+type desiredState struct {
+	Payload map[string]any `json:"payload"`
+	Backup  map[string]any `json:"backup,omitempty"`
+}
+
+// resourceKinds are reconciled in this order, so DatabaseBackup references resolve to
+// storages and notifiers synced in the same pass
+var resourceKinds = []resourceKind{
+	{"storages", "/storages", DeletionPolicyDelete, (*Reconciler).buildStorage},
+	{"notifiers", "/notifiers", DeletionPolicyDelete, (*Reconciler).buildNotifier},
+	// deleting a database removes its backup history, so it is kept unless asked
+	{"databasebackups", "/databases", DeletionPolicyRetain, (*Reconciler).buildDatabaseBackup},
+}
+
+// NewReconciler watches namespace, all namespaces if it is empty
+func NewReconciler(
+	kube *KubeClient,
+	api *APIClient,
+	namespace string,
+	logger *slog.Logger,
+) *Reconciler {
+	return &Reconciler{kube, api, namespace, logger}
+}
+
+func (r *Reconciler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.ReconcileAll(ctx); err != nil {
+			r.logger.Error("Failed to reconcile resources", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileAll returns only list errors, per resource errors are written to its status
+func (r *Reconciler) ReconcileAll(ctx context.Context) error {
+	readyIDs := map[string]string{}
+
+	for _, kind := range resourceKinds {
+		resources, err := r.kube.ListResources(ctx, r.namespace, kind.plural)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", kind.plural, err)
+		}
+
+		for index := range resources {
+			resource := &resources[index]
+
+			if err := r.reconcile(ctx, kind, resource, readyIDs); err != nil {
+				r.logger.Error(
+					"Failed to reconcile resource",
+					"kind", kind.plural,
+					"namespace", resource.Metadata.Namespace,
+					"name", resource.Metadata.Name,
+					"error", err,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcile(
+	ctx context.Context,
+	kind resourceKind,
+	resource *Resource,
+	readyIDs map[string]string,
+) error {
+	if resource.Metadata.DeletionTimestamp != nil {
+		return r.finalize(ctx, kind, resource)
+	}
+
+	if !slices.Contains(resource.Metadata.Finalizers, finalizer) {
+		finalizers := append(slices.Clone(resource.Metadata.Finalizers), finalizer)
+		if err := r.kube.PatchFinalizers(ctx, kind.plural, resource, finalizers); err != nil {
+			return err
+		}
+	}
+
+	status := resource.Status
+	refKey := getRefKey(kind.plural, resource.Metadata.Namespace, resource.Metadata.Name)
+
+	desired, err := kind.buildDesired(r, ctx, resource, readyIDs)
+
+	var hash string
+	if err == nil {
+		hash, err = hashDesiredState(desired)
+	}
+
+	if err == nil {
+		isUnchanged := status.ID != "" && status.isReady() &&
+			status.PayloadHash == hash &&
+			status.ObservedGeneration == resource.Metadata.Generation
+
+		if isUnchanged {
+			readyIDs[refKey] = status.ID
+			return nil
+		}
+
+		err = r.apply(ctx, kind, &status, desired)
+	}
+
+	now := time.Now().UTC()
+
+	if err != nil {
+		status.setReady(false, "SyncFailed", err.Error(), now)
+	} else {
+		status.PayloadHash = hash
+		status.ObservedGeneration = resource.Metadata.Generation
+		status.LastSyncedAt = &now
+		status.setReady(true, "Synced", "", now)
+
+		readyIDs[refKey] = status.ID
+	}
+
+	if patchErr := r.kube.PatchStatus(ctx, kind.plural, resource, status); patchErr != nil {
+		return errors.Join(err, patchErr)
+	}
+
+	return err
+}
+
+func (r *Reconciler) apply(
+	ctx context.Context,
+	kind resourceKind,
+	status *ResourceStatus,
+	desired *desiredState,
+) error {
+	if status.ID == "" {
+		id, err := r.api.Create(ctx, kind.apiPath, desired.Payload)
+		if err != nil {
+			return err
+		}
+
+		// kept even if backup config below fails, so the next pass updates
+		// instead of creating a duplicate
+		status.ID = id
+	} else if err := r.api.Update(ctx, kind.apiPath, status.ID, desired.Payload); err != nil {
+		return err
+	}
+
+	if desired.Backup == nil {
+		return nil
+	}
+
+	// backup config is a full object; merge onto the current one, so fields omitted
+	// in spec (e.g. interval ID) keep their values
+	var current map[string]any
+	if err := r.api.Do(
+		ctx,
+		http.MethodGet,
+		"/backup-configs/database/"+status.ID,
+		nil,
+		&current,
+	); err != nil {
+		return fmt.Errorf("failed to get backup config: %w", err)
+	}
+
+	backupConfig := mergeObjects(current, desired.Backup)
+	backupConfig["databaseId"] = status.ID
+
+	if err := r.api.Do(
+		ctx,
+		http.MethodPost,
+		"/backup-configs/save",
+		backupConfig,
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to save backup config: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) finalize(
+	ctx context.Context,
+	kind resourceKind,
+	resource *Resource,
+) error {
+	if !slices.Contains(resource.Metadata.Finalizers, finalizer) {
+		return nil
+	}
+
+	var spec struct {
+		DeletionPolicy DeletionPolicy `json:"deletionPolicy"`
+	}
+	_ = json.Unmarshal(resource.Spec, &spec)
+
+	deletionPolicy := spec.DeletionPolicy
+	if deletionPolicy == "" {
+		deletionPolicy = kind.defaultDeletionPolicy
+	}
+
+	if deletionPolicy == DeletionPolicyDelete && resource.Status.ID != "" {
+		if err := r.api.Delete(ctx, kind.apiPath, resource.Status.ID); err != nil {
+			status := resource.Status
+			status.setReady(false, "DeleteFailed", err.Error(), time.Now().UTC())
+
+			return errors.Join(err, r.kube.PatchStatus(ctx, kind.plural, resource, status))
+		}
+	}
+
+	finalizers := slices.DeleteFunc(
+		slices.Clone(resource.Metadata.Finalizers),
+		func(f string) bool { return f == finalizer },
+	)
+
+	return r.kube.PatchFinalizers(ctx, kind.plural, resource, finalizers)
+}
+
+func (r *Reconciler) buildStorage(
+	ctx context.Context,
+	resource *Resource,
+	_ map[string]string,
+) (*desiredState, error) {
+	var spec StorageSpec
+	if err := json.Unmarshal(resource.Spec, &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	if spec.Storage == nil {
+		return nil, errors.New("spec.storage is required")
+	}
+
+	payload := buildPayload(spec.Storage, spec.WorkspaceID, resource.Metadata.Name)
+
+	values, err := r.resolveSecretRefs(
+		ctx,
+		resource.Metadata.Namespace,
+		map[string]any{"storage": payload},
+		spec.SecretRefs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desiredState{Payload: getObject(values, "storage")}, nil
+}
+
+func (r *Reconciler) buildNotifier(
+	ctx context.Context,
+	resource *Resource,
+	_ map[string]string,
+) (*desiredState, error) {
+	var spec NotifierSpec
+	if err := json.Unmarshal(resource.Spec, &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	if spec.Notifier == nil {
+		return nil, errors.New("spec.notifier is required")
+	}
+
+	payload := buildPayload(spec.Notifier, spec.WorkspaceID, resource.Metadata.Name)
+
+	values, err := r.resolveSecretRefs(
+		ctx,
+		resource.Metadata.Namespace,
+		map[string]any{"notifier": payload},
+		spec.SecretRefs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desiredState{Payload: getObject(values, "notifier")}, nil
+}
+
+func (r *Reconciler) buildDatabaseBackup(
+	ctx context.Context,
+	resource *Resource,
+	readyIDs map[string]string,
+) (*desiredState, error) {
+	var spec DatabaseBackupSpec
+	if err := json.Unmarshal(resource.Spec, &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	if spec.Database == nil {
+		return nil, errors.New("spec.database is required")
+	}
+
+	namespace := resource.Metadata.Namespace
+	payload := buildPayload(spec.Database, spec.WorkspaceID, resource.Metadata.Name)
+
+	if len(spec.NotifierRefs) > 0 {
+		notifiers := make([]any, 0, len(spec.NotifierRefs))
+
+		for _, notifierRef := range spec.NotifierRefs {
+			notifierID, isReady := readyIDs[getRefKey("notifiers", namespace, notifierRef)]
+			if !isReady {
+				return nil, fmt.Errorf("notifier %s is not ready", notifierRef)
+			}
+
+			notifiers = append(notifiers, map[string]any{"id": notifierID})
+		}
+
+		payload["notifiers"] = notifiers
+	}
+
+	backup := spec.Backup
+	if spec.StorageRef != "" {
+		storageID, isReady := readyIDs[getRefKey("storages", namespace, spec.StorageRef)]
+		if !isReady {
+			return nil, fmt.Errorf("storage %s is not ready", spec.StorageRef)
+		}
+
+		if backup == nil {
+			backup = map[string]any{}
+		}
+
+		backup["storage"] = map[string]any{"id": storageID}
+	}
+
+	values, err := r.resolveSecretRefs(
+		ctx,
+		namespace,
+		map[string]any{"database": payload, "backup": backup},
+		spec.SecretRefs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desiredState{
+		Payload: getObject(values, "database"),
+		Backup:  getObject(values, "backup"),
+	}, nil
+}
+
+// resolveSecretRefs sets Secret values into values by path. The first path segment must
+// be one of values keys
+func (r *Reconciler) resolveSecretRefs(
+	ctx context.Context,
+	namespace string,
+	values map[string]any,
+	secretRefs []SecretRef,
+) (map[string]any, error) {
+	for _, secretRef := range secretRefs {
+		segments := strings.Split(secretRef.Path, ".")
+		if _, isKnown := values[segments[0]]; !isKnown || len(segments) < 2 {
+			return nil, fmt.Errorf(
+				"secretRefs path %q must start with one of: %s",
+				secretRef.Path,
+				strings.Join(getSortedKeys(values), ", "),
+			)
+		}
+
+		secretValue, err := r.kube.GetSecretValue(
+			ctx,
+			namespace,
+			secretRef.SecretKeyRef.Name,
+			secretRef.SecretKeyRef.Key,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		setPath(values, segments, secretValue)
+	}
+
+	return values, nil
+}
+
+func buildPayload(spec map[string]any, workspaceID, name string) map[string]any {
+	payload := mergeObjects(nil, spec)
+
+	// ID belongs to status, a copied manifest must not update someone else's object
+	delete(payload, "id")
+	payload["workspaceId"] = workspaceID
+
+	if _, isSet := payload["name"]; !isSet {
+		payload["name"] = name
+	}
+
+	return payload
+}
+
+func hashDesiredState(desired *desiredState) (string, error) {
+	encoded, err := json.Marshal(desired)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// mergeObjects deep merges override onto a copy of base, nested objects are merged and
+// other values are replaced
+func mergeObjects(base, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(override))
+
+	for key, baseValue := range base {
+		merged[key] = baseValue
+	}
+
+	for key, overrideValue := range override {
+		overrideObject, isOverrideObject := overrideValue.(map[string]any)
+		baseObject, isBaseObject := merged[key].(map[string]any)
+
+		switch {
+		case isOverrideObject && isBaseObject:
+			merged[key] = mergeObjects(baseObject, overrideObject)
+		case isOverrideObject:
+			merged[key] = mergeObjects(nil, overrideObject)
+		default:
+			merged[key] = overrideValue
+		}
+	}
+
+	return merged
+}
+
+func setPath(values map[string]any, segments []string, value any) {
+	current := values
+
+	for _, segment := range segments[:len(segments)-1] {
+		next, isObject := current[segment].(map[string]any)
+		if !isObject {
+			next = map[string]any{}
+			current[segment] = next
+		}
+
+		current = next
+	}
+
+	current[segments[len(segments)-1]] = value
+}
+
+func getObject(values map[string]any, key string) map[string]any {
+	object, _ := values[key].(map[string]any)
+	return object
+}
+
+func getSortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func getRefKey(plural, namespace, name string) string {
+	return plural + "/" + namespace + "/" + name
+}
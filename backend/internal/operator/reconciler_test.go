@@ -0,0 +1,311 @@
+package operator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReconcileAll_WhenStorageIsNew_CreatesStorageWithSecretAndMarksReady(t *testing.T) {
+	kube := newFakeKube()
+	kube.addResource("storages", "backups", `{
+		"workspaceId": "ws-1",
+		"storage": {"type": "S3", "s3Storage": {"s3Bucket": "bucket"}},
+		"secretRefs": [{
+			"path": "storage.s3Storage.s3SecretKey",
+			"secretKeyRef": {"name": "s3", "key": "secret"}
+		}]
+	}`)
+	kube.secrets["s3/secret"] = "s3cr3t"
+
+	api := newFakeDatabasus()
+	reconciler := createTestReconciler(t, kube, api)
+
+	require.NoError(t, reconciler.ReconcileAll(context.Background()))
+
+	require.Len(t, api.calls, 1)
+	assert.Equal(t, "POST /api/v2/storages", api.calls[0].route)
+	assert.Equal(t, map[string]any{
+		"workspaceId": "ws-1",
+		"name":        "backups",
+		"type":        "S3",
+		"s3Storage":   map[string]any{"s3Bucket": "bucket", "s3SecretKey": "s3cr3t"},
+	}, api.calls[0].body)
+
+	storage := kube.getResource("storages", "backups")
+	assert.Equal(t, []string{finalizer}, storage.Metadata.Finalizers)
+	assert.Equal(t, "storages-1", storage.Status.ID)
+	assert.True(t, storage.Status.isReady())
+
+	require.NoError(t, reconciler.ReconcileAll(context.Background()))
+	assert.Len(t, api.calls, 1, "unchanged resource must not be applied again")
+
+	kube.secrets["s3/secret"] = "rotated"
+	require.NoError(t, reconciler.ReconcileAll(context.Background()))
+
+	require.Len(t, api.calls, 2)
+	assert.Equal(t, "PUT /api/v2/storages/storages-1", api.calls[1].route)
+}
+
+func Test_ReconcileAll_WhenDatabaseBackupReferencesStorage_AppliesBackupConfig(t *testing.T) {
+	kube := newFakeKube()
+	kube.addResource("databasebackups", "orders", `{
+		"workspaceId": "ws-1",
+		"database": {"type": "POSTGRES", "postgresql": {"host": "orders-db"}},
+		"backup": {"isBackupsEnabled": true, "backupInterval": {"interval": "HOURLY"}},
+		"storageRef": "backups",
+		"notifierRefs": ["slack"]
+	}`)
+	kube.addResource("storages", "backups", `{
+		"workspaceId": "ws-1",
+		"storage": {"type": "LOCAL", "localStorage": {}}
+	}`)
+	kube.addResource("notifiers", "slack", `{
+		"workspaceId": "ws-1",
+		"notifier": {"notifierType": "SLACK"}
+	}`)
+
+	api := newFakeDatabasus()
+	api.backupConfig = map[string]any{
+		"storePeriod":    "MONTH",
+		"backupInterval": map[string]any{"id": "interval-1", "interval": "DAILY"},
+	}
+	reconciler := createTestReconciler(t, kube, api)
+
+	require.NoError(t, reconciler.ReconcileAll(context.Background()))
+
+	routes := make([]string, 0, len(api.calls))
+	for _, call := range api.calls {
+		routes = append(routes, call.route)
+	}
+
+	assert.Equal(t, []string{
+		"POST /api/v2/storages",
+		"POST /api/v2/notifiers",
+		"POST /api/v2/databases",
+		"GET /api/v2/backup-configs/database/databases-3",
+		"POST /api/v2/backup-configs/save",
+	}, routes)
+
+	assert.Equal(
+		t,
+		[]any{map[string]any{"id": "notifiers-2"}},
+		api.calls[2].body["notifiers"],
+	)
+	assert.Equal(t, map[string]any{
+		"databaseId":       "databases-3",
+		"storePeriod":      "MONTH",
+		"isBackupsEnabled": true,
+		"backupInterval":   map[string]any{"id": "interval-1", "interval": "HOURLY"},
+		"storage":          map[string]any{"id": "storages-1"},
+	}, api.calls[4].body)
+
+	assert.True(t, kube.getResource("databasebackups", "orders").Status.isReady())
+}
+
+func Test_ReconcileAll_WhenReferencedStorageIsMissing_ReportsNotReady(t *testing.T) {
+	kube := newFakeKube()
+	kube.addResource("databasebackups", "orders", `{
+		"workspaceId": "ws-1",
+		"database": {"type": "POSTGRES"},
+		"storageRef": "missing"
+	}`)
+
+	api := newFakeDatabasus()
+	reconciler := createTestReconciler(t, kube, api)
+
+	require.NoError(t, reconciler.ReconcileAll(context.Background()))
+
+	assert.Empty(t, api.calls)
+
+	status := kube.getResource("databasebackups", "orders").Status
+	assert.False(t, status.isReady())
+	assert.Equal(t, "storage missing is not ready", status.Conditions[0].Message)
+}
+
+func Test_ReconcileAll_WhenResourceIsDeleted_AppliesDeletionPolicy(t *testing.T) {
+	kube := newFakeKube()
+	deletedAt := time.Now().UTC()
+
+	storage := kube.addResource("storages", "backups", `{"workspaceId": "ws-1", "storage": {}}`)
+	storage.Metadata.Finalizers = []string{finalizer}
+	storage.Metadata.DeletionTimestamp = &deletedAt
+	storage.Status.ID = "storage-id"
+
+	database := kube.addResource("databasebackups", "orders", `{"database": {}}`)
+	database.Metadata.Finalizers = []string{finalizer, "other"}
+	database.Metadata.DeletionTimestamp = &deletedAt
+	database.Status.ID = "database-id"
+
+	api := newFakeDatabasus()
+	reconciler := createTestReconciler(t, kube, api)
+
+	require.NoError(t, reconciler.ReconcileAll(context.Background()))
+
+	require.Len(t, api.calls, 1, "databases are retained by default")
+	assert.Equal(t, "DELETE /api/v2/storages/storage-id", api.calls[0].route)
+
+	assert.Empty(t, kube.getResource("storages", "backups").Metadata.Finalizers)
+	assert.Equal(
+		t,
+		[]string{"other"},
+		kube.getResource("databasebackups", "orders").Metadata.Finalizers,
+	)
+}
+
+type fakeKube struct {
+	mu        sync.Mutex
+	resources map[string]*Resource
+	secrets   map[string]string
+}
+
+type fakeCall struct {
+	route string
+	body  map[string]any
+}
+
+type fakeDatabasus struct {
+	mu           sync.Mutex
+	calls        []fakeCall
+	backupConfig map[string]any
+}
+
+func newFakeKube() *fakeKube {
+	return &fakeKube{resources: map[string]*Resource{}, secrets: map[string]string{}}
+}
+
+func newFakeDatabasus() *fakeDatabasus {
+	return &fakeDatabasus{}
+}
+
+func (k *fakeKube) addResource(plural, name, spec string) *Resource {
+	resource := &Resource{
+		Metadata: ResourceMetadata{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Generation:      1,
+		},
+		Spec: json.RawMessage(spec),
+	}
+
+	k.resources[plural+"/"+name] = resource
+
+	return resource
+}
+
+func (k *fakeKube) getResource(plural, name string) *Resource {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.resources[plural+"/"+name]
+}
+
+func (k *fakeKube) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+
+	if segments[0] == "api" {
+		// /api/v1/namespaces/{namespace}/secrets/{name}
+		data := map[string]string{}
+		for key, value := range k.secrets {
+			name, secretKey, _ := strings.Cut(key, "/")
+			if name == segments[5] {
+				data[secretKey] = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+		}
+
+		_ = json.NewEncoder(writer).Encode(map[string]any{"data": data})
+		return
+	}
+
+	// /apis/{group}/{version}/namespaces/{namespace}/{plural}[/{name}[/status]]
+	plural := segments[5]
+
+	if request.Method == http.MethodGet {
+		items := make([]Resource, 0)
+		for key, resource := range k.resources {
+			if strings.HasPrefix(key, plural+"/") {
+				items = append(items, *resource)
+			}
+		}
+
+		_ = json.NewEncoder(writer).Encode(map[string]any{"items": items})
+		return
+	}
+
+	resource := k.resources[plural+"/"+segments[6]]
+
+	var patch Resource
+	_ = json.NewDecoder(request.Body).Decode(&patch)
+
+	if len(segments) == 8 {
+		resource.Status = patch.Status
+	} else {
+		resource.Metadata.Finalizers = patch.Metadata.Finalizers
+	}
+
+	_ = json.NewEncoder(writer).Encode(resource)
+}
+
+func (d *fakeDatabasus) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if request.URL.Path == "/api/v2/users/signin" {
+		_ = json.NewEncoder(writer).Encode(map[string]string{"token": "jwt"})
+		return
+	}
+
+	if request.Header.Get("Authorization") != "Bearer jwt" {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, _ := io.ReadAll(request.Body)
+
+	call := fakeCall{route: request.Method + " " + request.URL.Path}
+	if len(body) > 0 {
+		_ = json.Unmarshal(body, &call.body)
+	}
+	d.calls = append(d.calls, call)
+
+	switch {
+	case strings.HasPrefix(request.URL.Path, "/api/v2/backup-configs/database/"):
+		_ = json.NewEncoder(writer).Encode(d.backupConfig)
+	case request.Method == http.MethodPost:
+		collection := strings.TrimPrefix(request.URL.Path, "/api/v2/")
+		_ = json.NewEncoder(writer).Encode(
+			map[string]string{"id": fmt.Sprintf("%s-%d", collection, len(d.calls))},
+		)
+	}
+}
+
+func createTestReconciler(t *testing.T, kube *fakeKube, api *fakeDatabasus) *Reconciler {
+	kubeServer := httptest.NewServer(kube)
+	t.Cleanup(kubeServer.Close)
+
+	apiServer := httptest.NewServer(api)
+	t.Cleanup(apiServer.Close)
+
+	return NewReconciler(
+		NewKubeClient(kubeServer.URL, "", kubeServer.Client()),
+		NewAPIClient(apiServer.URL, "operator@example.com", "password", apiServer.Client()),
+		"default",
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
@@ -0,0 +1,138 @@
+package operator
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	Group   = "databasus.com"
+	Version = "v1alpha1"
+
+	// finalizer keeps deleted custom resources until the Databasus object is removed
+	finalizer = "databasus.com/cleanup"
+
+	conditionReady = "Ready"
+)
+
+type DeletionPolicy string
+
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// Resource is any of Storage, Notifier and DatabaseBackup custom resources. Spec is
+// decoded per kind
+type Resource struct {
+	Metadata ResourceMetadata `json:"metadata"`
+	Spec     json.RawMessage  `json:"spec"`
+	Status   ResourceStatus   `json:"status"`
+}
+
+type ResourceMetadata struct {
+	Name              string     `json:"name"`
+	Namespace         string     `json:"namespace"`
+	ResourceVersion   string     `json:"resourceVersion"`
+	Generation        int64      `json:"generation"`
+	Finalizers        []string   `json:"finalizers,omitempty"`
+	DeletionTimestamp *time.Time `json:"deletionTimestamp,omitempty"`
+}
+
+type ResourceStatus struct {
+	// ID of the object in Databasus
+	ID                 string `json:"id,omitempty"`
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+	// PayloadHash is sha256 of the last applied payload including resolved secrets, so
+	// rotated secrets are re-applied without a spec change
+	PayloadHash  string      `json:"payloadHash,omitempty"`
+	LastSyncedAt *time.Time  `json:"lastSyncedAt,omitempty"`
+	Conditions   []Condition `json:"conditions,omitempty"`
+}
+
+type Condition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// SecretRef injects a Secret value into the payload. Path is dot separated and starts
+// from the spec object, e.g. storage.s3Storage.s3SecretKey
+type SecretRef struct {
+	Path         string            `json:"path"`
+	SecretKeyRef SecretKeySelector `json:"secretKeyRef"`
+}
+
+type SecretKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type StorageSpec struct {
+	WorkspaceID    string         `json:"workspaceId"`
+	Storage        map[string]any `json:"storage"`
+	SecretRefs     []SecretRef    `json:"secretRefs,omitempty"`
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+type NotifierSpec struct {
+	WorkspaceID    string         `json:"workspaceId"`
+	Notifier       map[string]any `json:"notifier"`
+	SecretRefs     []SecretRef    `json:"secretRefs,omitempty"`
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DatabaseBackupSpec describes a database and its backup config. StorageRef and
+// NotifierRefs are names of Storage and Notifier resources in the same namespace
+type DatabaseBackupSpec struct {
+	WorkspaceID    string         `json:"workspaceId"`
+	Database       map[string]any `json:"database"`
+	Backup         map[string]any `json:"backup,omitempty"`
+	StorageRef     string         `json:"storageRef,omitempty"`
+	NotifierRefs   []string       `json:"notifierRefs,omitempty"`
+	SecretRefs     []SecretRef    `json:"secretRefs,omitempty"`
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+func (s *ResourceStatus) isReady() bool {
+	for _, condition := range s.Conditions {
+		if condition.Type == conditionReady {
+			return condition.Status == "True"
+		}
+	}
+
+	return false
+}
+
+func (s *ResourceStatus) setReady(isReady bool, reason, message string, now time.Time) {
+	status := "False"
+	if isReady {
+		status = "True"
+	}
+
+	for index, condition := range s.Conditions {
+		if condition.Type != conditionReady {
+			continue
+		}
+
+		if condition.Status != status {
+			s.Conditions[index].LastTransitionTime = now
+		}
+
+		s.Conditions[index].Status = status
+		s.Conditions[index].Reason = reason
+		s.Conditions[index].Message = message
+
+		return
+	}
+
+	s.Conditions = append(s.Conditions, Condition{
+		Type:               conditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+}
@@ -1,11 +1,18 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"databasus-backend/internal/config"
 	"databasus-backend/internal/util/logger"
 	"os"
 	"sync"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
@@ -15,6 +22,7 @@ var log = logger.GetLogger()
 
 var (
 	db     *gorm.DB
+	readDb *gorm.DB
 	dbOnce sync.Once
 )
 
@@ -23,33 +31,111 @@ func GetDb() *gorm.DB {
 	return db
 }
 
+// GetReadDb returns the replica pool for queries that tolerate replication lag (listings,
+// history, stats). Without DATABASE_READ_DSN it is the main pool, so callers never branch
+func GetReadDb() *gorm.DB {
+	dbOnce.Do(loadDbs)
+	return readDb
+}
+
 func loadDbs() {
 	LoadMainDb()
+	loadReadDb()
 }
 
 func LoadMainDb() {
-	dbDsn := config.GetEnv().DatabaseDsn
-
 	log.Info("Connection to database...")
 
-	database, err := gorm.Open(postgres.Open(dbDsn), &gorm.Config{
-		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
-	})
+	database, err := openDb(
+		config.GetEnv().DatabaseDsn,
+		pgconn.ValidateConnectTargetSessionAttrsReadWrite,
+		true,
+	)
 	if err != nil {
 		log.Error("error on connecting to database", "error", err)
 		os.Exit(1)
 	}
 
-	sqlDB, err := database.DB()
+	db = database
+
+	log.Info("Main database connected successfully!")
+}
+
+func loadReadDb() {
+	readDsn := config.GetEnv().DatabaseReadDsn
+	if readDsn == "" {
+		readDb = db
+		return
+	}
+
+	log.Info("Connection to read database...")
+
+	database, err := openDb(readDsn, pgconn.ValidateConnectTargetSessionAttrsPreferStandby, false)
 	if err != nil {
-		log.Error("error getting underlying sql.DB", "error", err)
+		log.Error("error on connecting to read database", "error", err)
 		os.Exit(1)
 	}
 
-	sqlDB.SetMaxOpenConns(10)
-	sqlDB.SetMaxIdleConns(10)
+	readDb = database
 
-	db = database
+	log.Info("Read database connected successfully!")
+}
 
-	log.Info("Main database connected successfully!")
+// openDb accepts multi-host DSNs (host=a,b,c). When the DSN lists several hosts and does not
+// set target_session_attrs itself, defaultValidateConnect picks the right node, so after a
+// failover new connections land on the promoted primary without restarting the app
+func openDb(
+	dsn string,
+	defaultValidateConnect pgconn.ValidateConnectFunc,
+	isWritable bool,
+) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if connConfig.ValidateConnect == nil && len(connConfig.Fallbacks) > 0 {
+		connConfig.ValidateConnect = defaultValidateConnect
+	}
+
+	options := make([]stdlib.OptionOpenDB, 0, 1)
+	if isWritable {
+		options = append(options, stdlib.OptionResetSession(discardStandbyConnection))
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig, options...)
+	configurePool(sqlDB)
+
+	database, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sqlDB.Ping(); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func configurePool(sqlDB *sql.DB) {
+	env := config.GetEnv()
+
+	sqlDB.SetMaxOpenConns(env.DatabaseMaxOpenConns)
+	sqlDB.SetMaxIdleConns(env.DatabaseMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(env.DatabaseConnMaxLifetimeSeconds) * time.Second)
+	sqlDB.SetConnMaxIdleTime(time.Duration(env.DatabaseConnMaxIdleTimeSeconds) * time.Second)
+}
+
+// discardStandbyConnection drops pooled connections to a node that was demoted to a standby
+// during failover. PostgreSQL 14+ reports in_hot_standby without a round trip; on older
+// versions such connections are recycled by the connection max lifetime instead
+func discardStandbyConnection(_ context.Context, conn *pgx.Conn) error {
+	if conn.PgConn().ParameterStatus("in_hot_standby") == "on" {
+		return driver.ErrBadConn
+	}
+
+	return nil
 }
@@ -0,0 +1,200 @@
+package apiversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	V1Prefix = "/api/v1"
+	V2Prefix = "/api/v2"
+)
+
+// V1DeprecatedAt is the date /api/v2 was introduced, sent in Deprecation header of v1
+var V1DeprecatedAt = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+// ErrorEnvelope is the v2 error body. v1 answers with {"error": "message"}
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+// Deprecated marks v1 responses with Deprecation (RFC 9745), optional Sunset (RFC 8594)
+// and Link to the same path under /api/v2, so automations can log a warning before
+// v1 is removed. Zero sunset omits the Sunset header
+func Deprecated(sunset time.Time) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", V1DeprecatedAt.Unix())
+
+	return func(ctx *gin.Context) {
+		header := ctx.Writer.Header()
+		header.Set("Deprecation", deprecation)
+
+		if !sunset.IsZero() {
+			header.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if successorPath, isV1 := strings.CutPrefix(ctx.Request.URL.Path, V1Prefix); isV1 {
+			header.Add(
+				"Link",
+				fmt.Sprintf("<%s%s>; rel=\"successor-version\"", V2Prefix, successorPath),
+			)
+		}
+
+		ctx.Next()
+	}
+}
+
+// WithErrorEnvelope lets v2 reuse v1 handlers: JSON error responses shaped as
+// {"error": "message"} are rewritten into ErrorEnvelope, other responses are
+// passed through untouched without buffering
+func WithErrorEnvelope() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		writer := &errorEnvelopeWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+
+		ctx.Next()
+
+		writer.flush()
+	}
+}
+
+func GetErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case http.StatusTooManyRequests:
+		return "too_many_requests"
+	case http.StatusServiceUnavailable:
+		return "unavailable"
+	}
+
+	if status >= http.StatusInternalServerError {
+		return "internal_error"
+	}
+
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// errorEnvelopeWriter decides on the first write whether to buffer: gin sets status
+// before Content-Type is known, so WriteHeader only remembers it
+type errorEnvelopeWriter struct {
+	gin.ResponseWriter
+
+	status     int
+	isDecided  bool
+	isBuffered bool
+	buffer     bytes.Buffer
+}
+
+func (w *errorEnvelopeWriter) WriteHeader(status int) {
+	if !w.isDecided {
+		w.status = status
+	}
+}
+
+func (w *errorEnvelopeWriter) WriteHeaderNow() {
+	w.decide()
+
+	if !w.isBuffered {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *errorEnvelopeWriter) Write(data []byte) (int, error) {
+	w.decide()
+
+	if w.isBuffered {
+		return w.buffer.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorEnvelopeWriter) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+func (w *errorEnvelopeWriter) Status() int {
+	if w.status != 0 {
+		return w.status
+	}
+
+	return w.ResponseWriter.Status()
+}
+
+func (w *errorEnvelopeWriter) Written() bool {
+	return w.isDecided || w.ResponseWriter.Written()
+}
+
+func (w *errorEnvelopeWriter) decide() {
+	if w.isDecided {
+		return
+	}
+	w.isDecided = true
+
+	if w.status == 0 {
+		w.status = w.ResponseWriter.Status()
+	}
+
+	isJSON := strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+	if w.status >= http.StatusBadRequest && isJSON {
+		w.isBuffered = true
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *errorEnvelopeWriter) flush() {
+	if !w.isDecided {
+		if w.status != 0 {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		return
+	}
+
+	if !w.isBuffered {
+		return
+	}
+
+	body := w.buffer.Bytes()
+
+	var v1Error struct {
+		Error *string `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &v1Error); err == nil && v1Error.Error != nil {
+		if envelope, err := json.Marshal(ErrorEnvelope{ErrorBody{
+			Code:    GetErrorCode(w.status),
+			Message: *v1Error.Error,
+			Status:  w.status,
+		}}); err == nil {
+			body = envelope
+		}
+	}
+
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
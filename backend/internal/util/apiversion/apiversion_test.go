@@ -0,0 +1,105 @@
+package apiversion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Deprecated_WhenSunsetIsSet_SetsDeprecationSunsetAndSuccessorHeaders(t *testing.T) {
+	router := createTestRouter(V1Prefix, Deprecated(time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)))
+
+	response := makeRequest(router, "/api/v1/storages/42")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "@1791936000", response.Header().Get("Deprecation"))
+	assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", response.Header().Get("Sunset"))
+	assert.Equal(
+		t,
+		`</api/v2/storages/42>; rel="successor-version"`,
+		response.Header().Get("Link"),
+	)
+}
+
+func Test_Deprecated_WhenSunsetIsZero_OmitsSunsetHeader(t *testing.T) {
+	router := createTestRouter(V1Prefix, Deprecated(time.Time{}))
+
+	response := makeRequest(router, "/api/v1/storages/42")
+
+	assert.NotEmpty(t, response.Header().Get("Deprecation"))
+	assert.Empty(t, response.Header().Get("Sunset"))
+}
+
+func Test_WithErrorEnvelope_WhenHandlerReturnsV1Error_RewritesIntoEnvelope(t *testing.T) {
+	router := createTestRouter(V2Prefix, WithErrorEnvelope())
+
+	response := makeRequest(router, "/api/v2/forbidden")
+
+	assert.Equal(t, http.StatusForbidden, response.Code)
+
+	var envelope ErrorEnvelope
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &envelope))
+	assert.Equal(
+		t,
+		ErrorEnvelope{ErrorBody{Code: "forbidden", Message: "no access", Status: 403}},
+		envelope,
+	)
+}
+
+func Test_WithErrorEnvelope_WhenResponseIsNotV1Error_PassesThrough(t *testing.T) {
+	router := createTestRouter(V2Prefix, WithErrorEnvelope())
+
+	success := makeRequest(router, "/api/v2/storages/42")
+	assert.Equal(t, http.StatusOK, success.Code)
+	assert.JSONEq(t, `{"id":"42"}`, success.Body.String())
+
+	text := makeRequest(router, "/api/v2/text-error")
+	assert.Equal(t, http.StatusBadGateway, text.Code)
+	assert.Equal(t, "upstream failed", text.Body.String())
+
+	aborted := makeRequest(router, "/api/v2/aborted")
+	assert.Equal(t, http.StatusUnauthorized, aborted.Code)
+	assert.Empty(t, aborted.Body.String())
+}
+
+func Test_GetErrorCode_ReturnsSnakeCaseCodes(t *testing.T) {
+	assert.Equal(t, "bad_request", GetErrorCode(http.StatusBadRequest))
+	assert.Equal(t, "not_found", GetErrorCode(http.StatusNotFound))
+	assert.Equal(t, "internal_error", GetErrorCode(http.StatusBadGateway))
+	assert.Equal(t, "unprocessable_entity", GetErrorCode(http.StatusUnprocessableEntity))
+}
+
+func createTestRouter(prefix string, middleware gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	group := router.Group(prefix)
+	group.Use(middleware)
+
+	group.GET("/storages/:id", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"id": ctx.Param("id")})
+	})
+	group.GET("/forbidden", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "no access"})
+	})
+	group.GET("/text-error", func(ctx *gin.Context) {
+		ctx.String(http.StatusBadGateway, "upstream failed")
+	})
+	group.GET("/aborted", func(ctx *gin.Context) {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	})
+
+	return router
+}
+
+func makeRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder
+}
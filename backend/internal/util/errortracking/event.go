@@ -0,0 +1,87 @@
+package errortracking
+
+import (
+	"runtime"
+	"strings"
+)
+
+type trackerEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	Exception   exceptionList     `json:"exception"`
+}
+
+type exceptionList struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type       string     `json:"type"`
+	Value      string     `json:"value"`
+	Stacktrace stacktrace `json:"stacktrace"`
+}
+
+type stacktrace struct {
+	Frames []stackFrame `json:"frames"`
+}
+
+type stackFrame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// captureStacktrace returns frames oldest call first, as Sentry expects. skip drops the
+// frames of the tracker itself so reports point to the caller
+func captureStacktrace(skip int) stacktrace {
+	programCounters := make([]uintptr, 64)
+	count := runtime.Callers(skip+2, programCounters)
+
+	callFrames := runtime.CallersFrames(programCounters[:count])
+	frames := make([]stackFrame, 0, count)
+
+	for {
+		frame, isMore := callFrames.Next()
+
+		frames = append(frames, stackFrame{
+			Function: frame.Function,
+			Filename: trimSourcePath(frame.File),
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+			InApp:    strings.HasPrefix(frame.Function, "databasus-backend/"),
+		})
+
+		if !isMore {
+			break
+		}
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+
+	return stacktrace{Frames: frames}
+}
+
+func trimSourcePath(path string) string {
+	if index := strings.Index(path, "/internal/"); index >= 0 {
+		return path[index+1:]
+	}
+
+	if index := strings.Index(path, "/cmd/"); index >= 0 {
+		return path[index+1:]
+	}
+
+	return path
+}
@@ -0,0 +1,57 @@
+package errortracking
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware reports panics and 5xx responses of API handlers. It must be registered
+// after gin.Recovery: the panic is re-raised so Recovery still answers with 500. Headers
+// and bodies are never attached because they carry tokens and database credentials
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tracker := GetErrorTracker()
+		if !tracker.IsEnabled() {
+			c.Next()
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				tracker.CapturePanic(r, getRequestTags(c))
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		if c.Writer.Status() < http.StatusInternalServerError {
+			return
+		}
+
+		err := c.Errors.Last()
+		if err != nil {
+			tracker.CaptureError(err.Err, getRequestTags(c))
+			return
+		}
+
+		tracker.CaptureError(
+			fmt.Errorf(
+				"%s %s responded with %d",
+				c.Request.Method,
+				c.FullPath(),
+				c.Writer.Status(),
+			),
+			getRequestTags(c),
+		)
+	}
+}
+
+func getRequestTags(c *gin.Context) map[string]string {
+	return map[string]string{
+		"http.method": c.Request.Method,
+		"http.route":  c.FullPath(),
+	}
+}
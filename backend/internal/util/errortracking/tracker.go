@@ -0,0 +1,305 @@
+package errortracking
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"databasus-backend/internal/config"
+	"databasus-backend/internal/util/logger"
+)
+
+const (
+	eventQueueSize = 100
+	sendTimeout    = 10 * time.Second
+	clientName     = "databasus-errortracking/1.0"
+)
+
+// ErrorTracker sends errors and panics to Sentry or any service accepting Sentry envelopes
+// (GlitchTip, Bugsink). Events are queued and sent in background, so a slow or unreachable
+// tracker never delays a request or a backup. Without SENTRY_DSN every call is a no-op
+type ErrorTracker struct {
+	envelopeURL string
+	authHeader  string
+	dsn         string
+
+	release     string
+	environment string
+	serverName  string
+	nodeTags    map[string]string
+
+	httpClient *http.Client
+	queue      chan []byte
+	inFlight   sync.WaitGroup
+	logger     *slog.Logger
+}
+
+var (
+	errorTracker     *ErrorTracker
+	errorTrackerOnce sync.Once
+)
+
+func GetErrorTracker() *ErrorTracker {
+	errorTrackerOnce.Do(func() {
+		errorTracker = newErrorTracker(config.GetEnv())
+	})
+
+	return errorTracker
+}
+
+func (t *ErrorTracker) IsEnabled() bool {
+	return t != nil && t.envelopeURL != ""
+}
+
+func (t *ErrorTracker) CaptureError(err error, tags map[string]string) {
+	if !t.IsEnabled() || err == nil {
+		return
+	}
+
+	t.enqueue(t.newEvent("error", reflect.TypeOf(err).String(), err.Error(), tags, 1))
+}
+
+// CapturePanic must be called from the deferred function that recovered, so the stack
+// trace still contains the panicking frames
+func (t *ErrorTracker) CapturePanic(recovered any, tags map[string]string) {
+	if !t.IsEnabled() || recovered == nil {
+		return
+	}
+
+	t.enqueue(t.newEvent("fatal", "panic", fmt.Sprint(recovered), tags, 1))
+}
+
+// Flush waits for queued events to be sent, it is called on graceful shutdown
+func (t *ErrorTracker) Flush(timeout time.Duration) {
+	if !t.IsEnabled() {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		t.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.logger.Warn("Timed out flushing error tracker events")
+	}
+}
+
+func newErrorTracker(env config.EnvVariables) *ErrorTracker {
+	tracker := &ErrorTracker{
+		release:     env.AppVersion,
+		environment: env.SentryEnvironment,
+		nodeTags:    getNodeTags(env),
+		httpClient:  &http.Client{Timeout: sendTimeout},
+		queue:       make(chan []byte, eventQueueSize),
+		logger:      logger.GetLogger(),
+	}
+
+	if tracker.environment == "" {
+		tracker.environment = string(env.EnvMode)
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		tracker.serverName = hostname
+	}
+
+	if env.SentryDSN == "" {
+		return tracker
+	}
+
+	envelopeURL, publicKey, err := parseDSN(env.SentryDSN)
+	if err != nil {
+		tracker.logger.Error("Invalid SENTRY_DSN, error tracking is disabled", "error", err)
+		return tracker
+	}
+
+	tracker.dsn = env.SentryDSN
+	tracker.envelopeURL = envelopeURL
+	tracker.authHeader = fmt.Sprintf(
+		"Sentry sentry_version=7, sentry_client=%s, sentry_key=%s",
+		clientName,
+		publicKey,
+	)
+
+	go tracker.sendLoop()
+
+	tracker.logger.Info("Error tracking enabled", "environment", tracker.environment)
+
+	return tracker
+}
+
+// parseDSN turns https://<key>@<host>/<project> into the envelope endpoint of the project
+func parseDSN(dsn string) (string, string, error) {
+	parsedDSN, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", err
+	}
+
+	if parsedDSN.User == nil || parsedDSN.User.Username() == "" {
+		return "", "", fmt.Errorf("DSN has no public key")
+	}
+
+	pathParts := strings.Split(strings.Trim(parsedDSN.Path, "/"), "/")
+	projectID := pathParts[len(pathParts)-1]
+	if projectID == "" {
+		return "", "", fmt.Errorf("DSN has no project ID")
+	}
+
+	pathPrefix := strings.Join(pathParts[:len(pathParts)-1], "/")
+	if pathPrefix != "" {
+		pathPrefix = "/" + pathPrefix
+	}
+
+	envelopeURL := fmt.Sprintf(
+		"%s://%s%s/api/%s/envelope/",
+		parsedDSN.Scheme,
+		parsedDSN.Host,
+		pathPrefix,
+		projectID,
+	)
+
+	return envelopeURL, parsedDSN.User.Username(), nil
+}
+
+func (t *ErrorTracker) newEvent(
+	level string,
+	exceptionType string,
+	message string,
+	tags map[string]string,
+	skip int,
+) *trackerEvent {
+	eventTags := make(map[string]string, len(t.nodeTags)+len(tags))
+	for key, value := range t.nodeTags {
+		eventTags[key] = value
+	}
+	for key, value := range tags {
+		eventTags[key] = value
+	}
+
+	return &trackerEvent{
+		EventID:     strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       level,
+		Logger:      "databasus",
+		Release:     t.release,
+		Environment: t.environment,
+		ServerName:  t.serverName,
+		Tags:        eventTags,
+		Exception: exceptionList{Values: []exception{{
+			Type:       exceptionType,
+			Value:      message,
+			Stacktrace: captureStacktrace(skip + 1),
+		}}},
+	}
+}
+
+func (t *ErrorTracker) enqueue(event *trackerEvent) {
+	scrubEvent(event)
+
+	envelope, err := t.buildEnvelope(event)
+	if err != nil {
+		t.logger.Warn("Failed to build error tracker event", "error", err)
+		return
+	}
+
+	t.inFlight.Add(1)
+
+	select {
+	case t.queue <- envelope:
+	default:
+		t.inFlight.Done()
+		t.logger.Warn("Error tracker queue is full, dropping event")
+	}
+}
+
+func (t *ErrorTracker) buildEnvelope(event *trackerEvent) ([]byte, error) {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeHeader, err := json.Marshal(map[string]string{
+		"event_id": event.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+		"dsn":      t.dsn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	itemHeader, err := json.Marshal(map[string]any{"type": "event", "length": len(eventJSON)})
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope bytes.Buffer
+	envelope.Write(envelopeHeader)
+	envelope.WriteByte('\n')
+	envelope.Write(itemHeader)
+	envelope.WriteByte('\n')
+	envelope.Write(eventJSON)
+	envelope.WriteByte('\n')
+
+	return envelope.Bytes(), nil
+}
+
+func (t *ErrorTracker) sendLoop() {
+	for envelope := range t.queue {
+		if err := t.send(envelope); err != nil {
+			t.logger.Warn("Failed to send event to error tracker", "error", err)
+		}
+
+		t.inFlight.Done()
+	}
+}
+
+func (t *ErrorTracker) send(envelope []byte) error {
+	request, err := http.NewRequest(http.MethodPost, t.envelopeURL, bytes.NewReader(envelope))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/x-sentry-envelope")
+	request.Header.Set("X-Sentry-Auth", t.authHeader)
+
+	response, err := t.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("error tracker responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func getNodeTags(env config.EnvVariables) map[string]string {
+	roles := make([]string, 0, 2)
+	if env.IsPrimaryNode {
+		roles = append(roles, "primary")
+	}
+	if env.IsProcessingNode {
+		roles = append(roles, "processing")
+	}
+
+	return map[string]string{
+		"node_roles": strings.Join(roles, ","),
+		"is_cloud":   fmt.Sprintf("%t", env.IsCloud),
+	}
+}
@@ -0,0 +1,59 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSON answers like gin.Context.JSON, but tags the payload with a content hash. The
+// frontend polls lists every few seconds and the browser revalidates them with
+// If-None-Match, so unchanged payloads are answered with an empty 304
+func JSON(ctx *gin.Context, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag := computeETag(body)
+
+	// Payloads depend on the user (hidden secrets, roles), so shared caches must not keep
+	// them, and no-cache makes the browser revalidate on every request
+	ctx.Header("ETag", tag)
+	ctx.Header("Cache-Control", "private, no-cache")
+
+	if isMatching(ctx.GetHeader("If-None-Match"), tag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func computeETag(body []byte) string {
+	hash := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// isMatching uses the weak comparison required for If-None-Match, so a W/ prefix added by
+// a compressing proxy does not break revalidation
+func isMatching(ifNoneMatch string, tag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JSON_WhenIfNoneMatchIsMissing_ReturnsPayloadWithETag(t *testing.T) {
+	response := makeRequest(t, "")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `[{"name":"storage"}]`, response.Body.String())
+	assert.NotEmpty(t, response.Header().Get("ETag"))
+	assert.Equal(t, "private, no-cache", response.Header().Get("Cache-Control"))
+}
+
+func Test_JSON_WhenIfNoneMatchEqualsETag_ReturnsNotModified(t *testing.T) {
+	tag := makeRequest(t, "").Header().Get("ETag")
+
+	testCases := []struct {
+		name        string
+		ifNoneMatch string
+	}{
+		{name: "same tag", ifNoneMatch: tag},
+		{name: "weak tag", ifNoneMatch: "W/" + tag},
+		{name: "list of tags", ifNoneMatch: `"outdated", ` + tag},
+		{name: "any tag", ifNoneMatch: "*"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			response := makeRequest(t, testCase.ifNoneMatch)
+
+			assert.Equal(t, http.StatusNotModified, response.Code)
+			assert.Empty(t, response.Body.String())
+			assert.Equal(t, tag, response.Header().Get("ETag"))
+		})
+	}
+}
+
+func Test_JSON_WhenIfNoneMatchIsOutdated_ReturnsPayload(t *testing.T) {
+	response := makeRequest(t, `"outdated"`)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `[{"name":"storage"}]`, response.Body.String())
+}
+
+func makeRequest(t *testing.T, ifNoneMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/storages", func(ctx *gin.Context) {
+		JSON(ctx, []gin.H{{"name": "storage"}})
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/storages", nil)
+	if ifNoneMatch != "" {
+		request.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
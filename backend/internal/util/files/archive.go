@@ -0,0 +1,295 @@
+package files_utils
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveFilter selects entries by slash separated paths relative to the archived
+// directory. Excluded directories are skipped with their contents, directories that are
+// not included are still walked for included entries below them
+type ArchiveFilter interface {
+	IsExcluded(relativePath string) bool
+	IsIncluded(relativePath string) bool
+}
+
+// WriteTarArchive writes rootPath as a tar archive with paths relative to it. Symlinks
+// are archived as links, devices, sockets and pipes are skipped. Files changed or removed
+// during the walk do not fail the archive, they are reported to warn
+func WriteTarArchive(
+	ctx context.Context,
+	writer io.Writer,
+	rootPath string,
+	filter ArchiveFilter,
+	warn func(message string),
+) error {
+	tarWriter := tar.NewWriter(writer)
+
+	err := filepath.WalkDir(
+		rootPath,
+		func(fullPath string, entry fs.DirEntry, walkErr error) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if fullPath == rootPath {
+				return walkErr
+			}
+
+			relativePath, err := filepath.Rel(rootPath, fullPath)
+			if err != nil {
+				return err
+			}
+			relativePath = filepath.ToSlash(relativePath)
+
+			if walkErr != nil {
+				warn(fmt.Sprintf("skipped %s: %v", relativePath, walkErr))
+				return nil
+			}
+
+			if filter.IsExcluded(relativePath) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !filter.IsIncluded(relativePath) {
+				return nil
+			}
+
+			return writeTarEntry(tarWriter, fullPath, relativePath, entry, warn)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return tarWriter.Close()
+}
+
+// ExtractTarArchive extracts a tar archive into the existing directory targetPath.
+// Entries cannot escape it, neither by ".." nor through symlinks. Existing files are
+// overwritten, ownership is restored only when running as root
+func ExtractTarArchive(ctx context.Context, reader io.Reader, targetPath string) error {
+	root, err := os.OpenRoot(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to open target directory: %w", err)
+	}
+	defer func() { _ = root.Close() }()
+
+	isRestoringOwner := os.Geteuid() == 0
+	tarReader := tar.NewReader(reader)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("archive entry %q is outside of the target directory", header.Name)
+		}
+		name = filepath.FromSlash(name)
+
+		if err := ensureParentDirectories(root, name); err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = extractDirectory(root, name, header, isRestoringOwner)
+		case tar.TypeReg:
+			err = extractFile(root, name, header, tarReader, isRestoringOwner)
+		case tar.TypeSymlink:
+			err = extractSymlink(root, targetPath, name, header)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+	}
+}
+
+func writeTarEntry(
+	tarWriter *tar.Writer,
+	fullPath string,
+	relativePath string,
+	entry fs.DirEntry,
+	warn func(message string),
+) error {
+	info, err := entry.Info()
+	if errors.Is(err, fs.ErrNotExist) {
+		warn(fmt.Sprintf("skipped %s: removed during backup", relativePath))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	link := ""
+	switch {
+	case info.Mode().IsRegular(), info.IsDir():
+	case info.Mode()&fs.ModeSymlink != 0:
+		if link, err = os.Readlink(fullPath); err != nil {
+			warn(fmt.Sprintf("skipped %s: %v", relativePath, err))
+			return nil
+		}
+	default:
+		warn(fmt.Sprintf("skipped %s: %s files are not archived", relativePath, info.Mode().Type()))
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+
+	// PAX allows long names and files over 8 GB
+	header.Format = tar.FormatPAX
+	header.Name = relativePath
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	if !info.Mode().IsRegular() {
+		return tarWriter.WriteHeader(header)
+	}
+
+	file, err := os.Open(fullPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		warn(fmt.Sprintf("skipped %s: removed during backup", relativePath))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	copied, err := io.CopyN(tarWriter, file, header.Size)
+	if errors.Is(err, io.EOF) {
+		// The file was truncated after stat, the header promises its old size
+		warn(fmt.Sprintf("%s changed during backup, archived copy is incomplete", relativePath))
+		_, err = io.CopyN(tarWriter, zeroReader{}, header.Size-copied)
+	}
+
+	return err
+}
+
+func ensureParentDirectories(root *os.Root, name string) error {
+	parent := filepath.Dir(name)
+	if parent == "." {
+		return nil
+	}
+
+	current := ""
+	for _, part := range strings.Split(parent, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+
+		if err := root.Mkdir(current, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("failed to create directory %s: %w", current, err)
+		}
+	}
+
+	return nil
+}
+
+func extractDirectory(root *os.Root, name string, header *tar.Header, isRestoringOwner bool) error {
+	if err := root.Mkdir(name, 0o700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+
+	directory, err := root.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = directory.Close() }()
+
+	return restoreAttributes(directory, header, isRestoringOwner)
+}
+
+func extractFile(
+	root *os.Root,
+	name string,
+	header *tar.Header,
+	reader io.Reader,
+	isRestoringOwner bool,
+) error {
+	// A symlink in place of the file would redirect the write
+	if info, err := root.Lstat(name); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+		if err := root.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	file, err := root.OpenFile(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return err
+	}
+
+	if err := restoreAttributes(file, header, isRestoringOwner); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// extractSymlink checks the parent directory through root, so the link is created
+// inside targetPath even if the archive replaced a parent by a symlink
+func extractSymlink(root *os.Root, targetPath, name string, header *tar.Header) error {
+	if _, err := root.Stat(filepath.Dir(name)); err != nil {
+		return err
+	}
+
+	if _, err := root.Lstat(name); err == nil {
+		if err := root.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	return os.Symlink(header.Linkname, filepath.Join(targetPath, name))
+}
+
+func restoreAttributes(file *os.File, header *tar.Header, isRestoringOwner bool) error {
+	if isRestoringOwner {
+		if err := file.Chown(header.Uid, header.Gid); err != nil {
+			return err
+		}
+	}
+
+	return file.Chmod(fs.FileMode(header.Mode).Perm())
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,143 @@
+package files_utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WriteTarArchive_WhenExtracted_RestoresFilesAndSymlinks(t *testing.T) {
+	source := t.TempDir()
+	writeTestFile(t, filepath.Join(source, "config.yml"), "name: app", 0o640)
+	writeTestFile(t, filepath.Join(source, "data", "db.sqlite"), "sqlite", 0o600)
+	require.NoError(t, os.Symlink("data/db.sqlite", filepath.Join(source, "current")))
+
+	var archive bytes.Buffer
+	require.NoError(t, WriteTarArchive(
+		context.Background(),
+		&archive,
+		source,
+		testArchiveFilter{},
+		func(message string) { t.Errorf("unexpected warning: %s", message) },
+	))
+
+	target := t.TempDir()
+	require.NoError(t, ExtractTarArchive(context.Background(), &archive, target))
+
+	content, err := os.ReadFile(filepath.Join(target, "current"))
+	require.NoError(t, err)
+	assert.Equal(t, "sqlite", string(content))
+
+	info, err := os.Stat(filepath.Join(target, "config.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(target, "current"))
+	require.NoError(t, err)
+	assert.Equal(t, "data/db.sqlite", link)
+}
+
+func Test_WriteTarArchive_WhenFilterIsSet_SkipsExcludedAndNotIncludedEntries(t *testing.T) {
+	source := t.TempDir()
+	writeTestFile(t, filepath.Join(source, "app", "settings.conf"), "a", 0o644)
+	writeTestFile(t, filepath.Join(source, "app", "cache", "page.conf"), "b", 0o644)
+	writeTestFile(t, filepath.Join(source, "app", "readme.txt"), "c", 0o644)
+
+	var archive bytes.Buffer
+	require.NoError(t, WriteTarArchive(
+		context.Background(),
+		&archive,
+		source,
+		testArchiveFilter{excluded: "cache", included: "*.conf"},
+		func(string) {},
+	))
+
+	assert.Equal(t, []string{"app/settings.conf"}, readTarNames(t, &archive))
+}
+
+func Test_ExtractTarArchive_WhenEntryEscapesTarget_ReturnsError(t *testing.T) {
+	var archive bytes.Buffer
+	tarWriter := tar.NewWriter(&archive)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+		Name:     "../escaped",
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+	}))
+	require.NoError(t, tarWriter.Close())
+
+	target := t.TempDir()
+	err := ExtractTarArchive(context.Background(), &archive, target)
+
+	assert.ErrorContains(t, err, "outside of the target directory")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(target), "escaped"))
+}
+
+func Test_ExtractTarArchive_WhenSymlinkPointsOutside_DoesNotWriteThroughIt(t *testing.T) {
+	outside := t.TempDir()
+
+	var archive bytes.Buffer
+	tarWriter := tar.NewWriter(&archive)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+		Name:     "link",
+		Typeflag: tar.TypeSymlink,
+		Linkname: outside,
+	}))
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+		Name:     "link/owned",
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+	}))
+	require.NoError(t, tarWriter.Close())
+
+	err := ExtractTarArchive(context.Background(), &archive, t.TempDir())
+
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(outside, "owned"))
+}
+
+type testArchiveFilter struct {
+	excluded string
+	included string
+}
+
+func (f testArchiveFilter) IsExcluded(relativePath string) bool {
+	return f.excluded != "" && path.Base(relativePath) == f.excluded
+}
+
+func (f testArchiveFilter) IsIncluded(relativePath string) bool {
+	if f.included == "" {
+		return true
+	}
+
+	isMatched, _ := path.Match(f.included, path.Base(relativePath))
+	return isMatched
+}
+
+func writeTestFile(t *testing.T, filePath, content string, mode os.FileMode) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), mode))
+	require.NoError(t, os.Chmod(filePath, mode))
+}
+
+func readTarNames(t *testing.T, archive *bytes.Buffer) []string {
+	names := []string{}
+
+	tarReader := tar.NewReader(archive)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			break
+		}
+
+		names = append(names, header.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,80 @@
+package files_utils
+
+import (
+	"errors"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DirectoryEntry is a file or folder listed by a storage file browser, Name is relative to
+// the listed directory
+type DirectoryEntry struct {
+	Name        string     `json:"name"`
+	IsDirectory bool       `json:"isDirectory"`
+	SizeBytes   int64      `json:"sizeBytes"`
+	ModifiedAt  *time.Time `json:"modifiedAt"`
+}
+
+// CleanBrowsePath returns the path relative to the root folder of a storage without
+// leading and trailing slashes, "" is the root folder. Paths leaving the root are rejected
+func CleanBrowsePath(browsePath string) (string, error) {
+	browsePath = strings.ReplaceAll(strings.TrimSpace(browsePath), "\\", "/")
+
+	for _, part := range strings.Split(browsePath, "/") {
+		if part == ".." {
+			return "", errors.New("path must not leave the root folder of the storage")
+		}
+	}
+
+	cleaned := strings.Trim(path.Clean("/"+browsePath), "/")
+
+	return cleaned, nil
+}
+
+// SortDirectoryEntries lists folders first, both by name
+func SortDirectoryEntries(entries []DirectoryEntry) {
+	slices.SortFunc(entries, func(a, b DirectoryEntry) int {
+		if a.IsDirectory != b.IsDirectory {
+			if a.IsDirectory {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// ToDirectoryEntries converts at most limit entries of os.ReadDir-like listings, symlinks
+// are skipped as they may point outside the storage
+func ToDirectoryEntries(fileInfos []os.FileInfo, limit int) []DirectoryEntry {
+	entries := make([]DirectoryEntry, 0, min(len(fileInfos), limit))
+
+	for _, fileInfo := range fileInfos {
+		if len(entries) >= limit {
+			break
+		}
+
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			continue
+		}
+
+		modifiedAt := fileInfo.ModTime()
+		entry := DirectoryEntry{
+			Name:        fileInfo.Name(),
+			IsDirectory: fileInfo.IsDir(),
+			ModifiedAt:  &modifiedAt,
+		}
+		if !fileInfo.IsDir() {
+			entry.SizeBytes = fileInfo.Size()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
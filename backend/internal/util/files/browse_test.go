@@ -0,0 +1,45 @@
+package files_utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CleanBrowsePath_WhenPathLeavesRoot_Rejected(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":                "",
+		"/":               "",
+		" backups/ ":      "backups",
+		"/backups//2026/": "backups/2026",
+		"backups\\2026":   "backups/2026",
+		"./backups/.":     "backups",
+	} {
+		cleaned, err := CleanBrowsePath(input)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cleaned, input)
+	}
+
+	for _, input := range []string{"..", "../etc", "backups/../../etc", "backups\\..\\.."} {
+		_, err := CleanBrowsePath(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func Test_SortDirectoryEntries_FoldersListedFirst(t *testing.T) {
+	entries := []DirectoryEntry{
+		{Name: "b.dump"},
+		{Name: "z", IsDirectory: true},
+		{Name: "a.dump"},
+		{Name: "c", IsDirectory: true},
+	}
+
+	SortDirectoryEntries(entries)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+
+	assert.Equal(t, []string{"c", "z", "a.dump", "b.dump"}, names)
+}
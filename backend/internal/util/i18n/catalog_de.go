@@ -0,0 +1,90 @@
+package i18n
+
+var catalogDe = map[MessageKey]string{
+	MessageBackupFailedTitle:    `❌ Backup der Datenbank "{database}" fehlgeschlagen (Workspace "{workspace}")`,
+	MessageBackupSuccessTitle:   `✅ Backup der Datenbank "{database}" abgeschlossen (Workspace "{workspace}")`,
+	MessageBackupSuccessMessage: "Backup erfolgreich in {duration} abgeschlossen.\nKomprimierte Backup-Größe: {size}",
+
+	MessageRefreshFailedTitle:    `❌ Aktualisierung "{refresh}" der Datenbank "{database}" fehlgeschlagen (Workspace "{workspace}")`,
+	MessageRefreshSuccessTitle:   `✅ Aktualisierung "{refresh}" der Datenbank "{database}" abgeschlossen (Workspace "{workspace}")`,
+	MessageRefreshSuccessMessage: "Das neueste Backup wurde in das Ziel der Aktualisierung wiederhergestellt.",
+
+	MessageNotifierBrokenTitle:   `⚠️ Benachrichtigung "{notifier}" funktioniert nicht mehr (Workspace "{workspace}")`,
+	MessageNotifierBrokenMessage: "Die Benachrichtigung hat die Zustandsprüfung nicht bestanden: {error}",
+
+	MessageCredentialsExpiringTitle: `🔑 Zugangsdaten von "{name}" laufen in {days} Tag(en) ab (Workspace "{workspace}")`,
+	MessageCredentialsExpiringMessage: "Erneuern Sie die Zugangsdaten und aktualisieren Sie sie " +
+		"vor dem {date} in Databasus, sonst schlagen Backups fehl.",
+	MessageCredentialsExpiredTitle: `🔑 Zugangsdaten von "{name}" sind abgelaufen (Workspace "{workspace}")`,
+	MessageCredentialsExpiredMessage: "Die Zugangsdaten sind am {date} abgelaufen. " +
+		"Backups schlagen fehl, bis sie in Databasus aktualisiert werden.",
+
+	MessageStorageQuotaSoftExceededTitle: `💾 Speicher "{storage}" hat sein weiches Kontingent erreicht (Workspace "{workspace}")`,
+	MessageStorageQuotaSoftExceededMessage: "Backups belegen {used} von {limit} des weichen Kontingents. " +
+		"Geben Sie Speicherplatz frei oder erhöhen Sie das Kontingent, bevor das harte Kontingent erreicht wird.",
+	MessageStorageQuotaHardExceededTitle: `💾 Speicher "{storage}" hat sein hartes Kontingent erreicht (Workspace "{workspace}")`,
+	MessageStorageQuotaHardExceededMessage: "Backups belegen {used} von {limit} des harten Kontingents. " +
+		"Neue Backups in diesen Speicher sind blockiert, bis Platz frei wird oder das Kontingent erhöht wird.",
+
+	MessageSecurityNewDeviceTitle: `🔐 Neues Gerät hat sich als "{email}" angemeldet`,
+	MessageSecurityNewDeviceMessage: "{email} hat sich von einem neuen Gerät angemeldet " +
+		"({device}, IP {ip}). " +
+		"Wenn Sie das nicht waren, ändern Sie sofort Ihr Passwort.",
+	MessageSecurityFailedSignInsTitle: `🔐 {count} fehlgeschlagene Anmeldungen für "{email}"`,
+	MessageSecurityFailedSignInsMessage: "Es gab {count} Anmeldungen mit falschem Passwort in Folge, " +
+		"die letzte von IP {ip}. " +
+		"Wenn Sie das nicht waren, ändern Sie am besten Ihr Passwort.",
+	MessageSecurityPolicyChangedTitle:   `🔐 Sicherheitsrichtlinie von "{email}" geändert`,
+	MessageSecurityPolicyChangedMessage: "Geänderte Einstellungen: {changes}",
+
+	MessageTestEventNote: "🧪 Dies ist ein Testereignis zur Prüfung der Benachrichtigungen, es ist nichts passiert.",
+
+	MessageDatabaseOnlineTitle:        "✅ [{database}] DB ist online",
+	MessageDatabaseOnlineMessage:      "✅ [{database}] DB ist wieder online",
+	MessageDatabaseUnavailableTitle:   "❌ [{database}] DB ist nicht erreichbar",
+	MessageDatabaseUnavailableMessage: "❌ [{database}] DB ist derzeit nicht erreichbar",
+
+	MessageEmailPasswordResetSubject: "{product} Code zum Zurücksetzen des Passworts",
+	MessageEmailPasswordResetHeading: "Passwort zurücksetzen",
+	MessageEmailPasswordResetIntro: "Sie haben das Zurücksetzen Ihres Passworts angefordert. " +
+		"Bitte verwenden Sie den folgenden Code, um den Vorgang abzuschließen:",
+	MessageEmailPasswordResetExpiration: "Dieser Code und Link laufen in <strong>{minutes} Minuten</strong> ab.",
+	MessageEmailPasswordResetIgnore: "Wenn Sie kein neues Passwort angefordert haben, ignorieren Sie diese E-Mail. " +
+		"Ihr Passwort bleibt unverändert.",
+	MessageEmailPasswordResetAutomated: "Dies ist eine automatische Nachricht von {product}. " +
+		"Bitte antworten Sie nicht auf diese E-Mail.",
+	MessageEmailPasswordResetLinkIntro: "Oder öffnen Sie den folgenden Link, um ein neues Passwort festzulegen:",
+	MessageEmailPasswordResetButton:    "Passwort zurücksetzen",
+
+	MessageEmailVerificationSubject: "Bestätigen Sie Ihre {product} E-Mail-Adresse",
+	MessageEmailVerificationHeading: "E-Mail-Bestätigung",
+	MessageEmailVerificationIntro: "Bitte bestätigen Sie, dass <strong>{email}</strong> Ihre E-Mail-Adresse ist, " +
+		"um Benachrichtigungen und Einladungen zu erhalten.",
+	MessageEmailVerificationButton: "E-Mail bestätigen",
+	MessageEmailVerificationPasteToken: "Bitte öffnen Sie Ihre {product} Instanz und fügen Sie den folgenden " +
+		"Bestätigungstoken auf der Seite zur E-Mail-Bestätigung ein:",
+	MessageEmailVerificationExpiration: "Dieser Link läuft in <strong>24 Stunden</strong> ab.",
+	MessageEmailVerificationIgnore: "Wenn Sie sich nicht registriert oder Ihre E-Mail-Adresse nicht geändert haben, " +
+		"ignorieren Sie diese E-Mail. Ihre E-Mail-Adresse bleibt unverändert.",
+	MessageEmailVerificationAutomated: "Dies ist eine automatische Nachricht von {product}. " +
+		"Bitte antworten Sie nicht auf diese E-Mail.",
+
+	MessageEmailOwnedBackupFailedReason: "Sie erhalten diese E-Mail von {product} " +
+		" weil Sie diese Datenbank besitzen. " +
+		"Sie können sie in Ihren Einstellungen deaktivieren.",
+
+	MessageEmailInvitationSubject: "Sie wurden zum Workspace {workspace} eingeladen",
+	MessageEmailInvitationHeading: "Workspace-Einladung",
+	MessageEmailInvitationBody: "<strong>{inviter}</strong> hat Sie eingeladen, dem Workspace " +
+		"<strong>{workspace}</strong> als <strong>{role}</strong> beizutreten.",
+	MessageEmailInvitationSignUp: "Registrieren",
+	MessageEmailInvitationVisitInstance: "Bitte besuchen Sie Ihre {product}-Instanz, um sich zu registrieren " +
+		"und auf den Workspace zuzugreifen.",
+	MessageEmailInvitationAutomated: "Dies ist eine automatische Nachricht von {product}. " +
+		"Wenn Sie diese Einladung nicht erwartet haben, können Sie diese E-Mail ignorieren.",
+
+	MessageEmailSecurityReleaseSubject: "Sicherheitsupdate {version} für {product} verfügbar",
+	MessageEmailSecurityReleaseBody: "Das Sicherheitsrelease <strong>{version}</strong> ist verfügbar. " +
+		"Diese Instanz läuft mit <strong>{current}</strong>, bitte aktualisieren Sie so bald wie möglich.",
+	MessageEmailSecurityReleaseReleaseNotes: "Versionshinweise ansehen",
+}
@@ -0,0 +1,88 @@
+package i18n
+
+var catalogEn = map[MessageKey]string{
+	MessageBackupFailedTitle:    `❌ Backup failed for database "{database}" (workspace "{workspace}")`,
+	MessageBackupSuccessTitle:   `✅ Backup completed for database "{database}" (workspace "{workspace}")`,
+	MessageBackupSuccessMessage: "Backup completed successfully in {duration}.\nCompressed backup size: {size}",
+
+	MessageRefreshFailedTitle:    `❌ Refresh "{refresh}" failed for database "{database}" (workspace "{workspace}")`,
+	MessageRefreshSuccessTitle:   `✅ Refresh "{refresh}" completed for database "{database}" (workspace "{workspace}")`,
+	MessageRefreshSuccessMessage: "The latest backup was restored into the refresh target.",
+
+	MessageNotifierBrokenTitle:   `⚠️ Notifier "{notifier}" stopped working (workspace "{workspace}")`,
+	MessageNotifierBrokenMessage: "The notifier failed its health check: {error}",
+
+	MessageCredentialsExpiringTitle: `🔑 Credentials of "{name}" expire in {days} day(s) (workspace "{workspace}")`,
+	MessageCredentialsExpiringMessage: "Rotate the credentials and update them in Databasus " +
+		"before {date}, otherwise backups will start failing.",
+	MessageCredentialsExpiredTitle: `🔑 Credentials of "{name}" have expired (workspace "{workspace}")`,
+	MessageCredentialsExpiredMessage: "The credentials expired on {date}. " +
+		"Backups will fail until they are updated in Databasus.",
+
+	MessageStorageQuotaSoftExceededTitle: `💾 Storage "{storage}" reached its soft quota (workspace "{workspace}")`,
+	MessageStorageQuotaSoftExceededMessage: "Backups use {used} of the {limit} soft quota. " +
+		"Free up space or raise the quota before the hard quota is reached.",
+	MessageStorageQuotaHardExceededTitle: `💾 Storage "{storage}" reached its hard quota (workspace "{workspace}")`,
+	MessageStorageQuotaHardExceededMessage: "Backups use {used} of the {limit} hard quota. " +
+		"New backups to this storage are blocked until space is freed or the quota is raised.",
+
+	MessageSecurityNewDeviceTitle: `🔐 New device signed in as "{email}"`,
+	MessageSecurityNewDeviceMessage: "{email} signed in from a new device ({device}, IP {ip}). " +
+		"If it was not you, change your password right away.",
+	MessageSecurityFailedSignInsTitle: `🔐 {count} failed sign-ins for "{email}"`,
+	MessageSecurityFailedSignInsMessage: "There were {count} sign-ins with a wrong password in a row, " +
+		"the last one from IP {ip}. " +
+		"If it was not you, consider changing your password.",
+	MessageSecurityPolicyChangedTitle:   `🔐 Security policy changed by "{email}"`,
+	MessageSecurityPolicyChangedMessage: "Changed settings: {changes}",
+
+	MessageTestEventNote: "🧪 This is a test event to check notifier routing, nothing actually happened.",
+
+	MessageDatabaseOnlineTitle:        "✅ [{database}] DB is online",
+	MessageDatabaseOnlineMessage:      "✅ [{database}] DB is back online",
+	MessageDatabaseUnavailableTitle:   "❌ [{database}] DB is unavailable",
+	MessageDatabaseUnavailableMessage: "❌ [{database}] DB is currently unavailable",
+
+	MessageEmailPasswordResetSubject: "{product} Password Reset Code",
+	MessageEmailPasswordResetHeading: "Password Reset Request",
+	MessageEmailPasswordResetIntro: "You have requested to reset your password. " +
+		"Please use the following code to complete the password reset process:",
+	MessageEmailPasswordResetExpiration: "This code and link will expire in <strong>{minutes} minutes</strong>.",
+	MessageEmailPasswordResetIgnore: "If you did not request a password reset, please ignore this email. " +
+		"Your password will remain unchanged.",
+	MessageEmailPasswordResetAutomated: "This is an automated message from {product}. " +
+		"Please do not reply to this email.",
+	MessageEmailPasswordResetLinkIntro: "Or open the link below to choose a new password:",
+	MessageEmailPasswordResetButton:    "Reset password",
+
+	MessageEmailVerificationSubject: "Verify your {product} email",
+	MessageEmailVerificationHeading: "Email Verification",
+	MessageEmailVerificationIntro: "Please confirm that <strong>{email}</strong> is your email address " +
+		"to receive notifications and invitations.",
+	MessageEmailVerificationButton: "Verify email",
+	MessageEmailVerificationPasteToken: "Please visit your {product} instance and paste the following " +
+		"verification token on the email verification page:",
+	MessageEmailVerificationExpiration: "This link will expire in <strong>24 hours</strong>.",
+	MessageEmailVerificationIgnore: "If you did not sign up or change your email, please ignore this email. " +
+		"Your email will remain unchanged.",
+	MessageEmailVerificationAutomated: "This is an automated message from {product}. " +
+		"Please do not reply to this email.",
+
+	MessageEmailOwnedBackupFailedReason: "You receive this email from {product} " +
+		"because you own this database. " +
+		"You can turn it off in your preferences.",
+
+	MessageEmailInvitationSubject: "You've been invited to {workspace} workspace",
+	MessageEmailInvitationHeading: "Workspace Invitation",
+	MessageEmailInvitationBody: "<strong>{inviter}</strong> has invited you to join the " +
+		"<strong>{workspace}</strong> workspace as a <strong>{role}</strong>.",
+	MessageEmailInvitationSignUp:        "Sign up",
+	MessageEmailInvitationVisitInstance: "Please visit your {product} instance to sign up and access the workspace.",
+	MessageEmailInvitationAutomated: "This is an automated message from {product}. " +
+		"If you didn't expect this invitation, you can safely ignore this email.",
+
+	MessageEmailSecurityReleaseSubject: "Security update {version} is available for {product}",
+	MessageEmailSecurityReleaseBody: "Security release <strong>{version}</strong> is available. " +
+		"This instance runs <strong>{current}</strong>, please update as soon as possible.",
+	MessageEmailSecurityReleaseReleaseNotes: "View release notes",
+}
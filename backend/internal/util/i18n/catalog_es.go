@@ -0,0 +1,90 @@
+package i18n
+
+var catalogEs = map[MessageKey]string{
+	MessageBackupFailedTitle:    `❌ Falló la copia de seguridad de la base de datos "{database}" (espacio de trabajo "{workspace}")`,
+	MessageBackupSuccessTitle:   `✅ Copia de seguridad completada para la base de datos "{database}" (espacio de trabajo "{workspace}")`,
+	MessageBackupSuccessMessage: "Copia de seguridad completada correctamente en {duration}.\nTamaño comprimido: {size}",
+
+	MessageRefreshFailedTitle:    `❌ Falló la actualización "{refresh}" de la base de datos "{database}" (espacio de trabajo "{workspace}")`,
+	MessageRefreshSuccessTitle:   `✅ Actualización "{refresh}" completada para la base de datos "{database}" (espacio de trabajo "{workspace}")`,
+	MessageRefreshSuccessMessage: "La última copia de seguridad se restauró en el destino de la actualización.",
+
+	MessageNotifierBrokenTitle:   `⚠️ El notificador "{notifier}" dejó de funcionar (espacio de trabajo "{workspace}")`,
+	MessageNotifierBrokenMessage: "El notificador no superó la comprobación de estado: {error}",
+
+	MessageCredentialsExpiringTitle: `🔑 Las credenciales de "{name}" caducan en {days} día(s) (espacio de trabajo "{workspace}")`,
+	MessageCredentialsExpiringMessage: "Rote las credenciales y actualícelas en Databasus " +
+		"antes del {date}, de lo contrario las copias de seguridad empezarán a fallar.",
+	MessageCredentialsExpiredTitle: `🔑 Las credenciales de "{name}" han caducado (espacio de trabajo "{workspace}")`,
+	MessageCredentialsExpiredMessage: "Las credenciales caducaron el {date}. " +
+		"Las copias de seguridad fallarán hasta que se actualicen en Databasus.",
+
+	MessageStorageQuotaSoftExceededTitle: `💾 El almacenamiento "{storage}" alcanzó su cuota blanda (espacio de trabajo "{workspace}")`,
+	MessageStorageQuotaSoftExceededMessage: "Las copias de seguridad usan {used} de la cuota blanda de {limit}. " +
+		"Libere espacio o aumente la cuota antes de alcanzar la cuota dura.",
+	MessageStorageQuotaHardExceededTitle: `💾 El almacenamiento "{storage}" alcanzó su cuota dura (espacio de trabajo "{workspace}")`,
+	MessageStorageQuotaHardExceededMessage: "Las copias de seguridad usan {used} de la cuota dura de {limit}. " +
+		"Las nuevas copias en este almacenamiento están bloqueadas hasta liberar espacio o aumentar la cuota.",
+
+	MessageSecurityNewDeviceTitle: `🔐 Nuevo dispositivo ha iniciado sesión como "{email}"`,
+	MessageSecurityNewDeviceMessage: "{email} inició sesión desde un nuevo dispositivo " +
+		"({device}, IP {ip}). " +
+		"Si no fuiste tú, cambia tu contraseña de inmediato.",
+	MessageSecurityFailedSignInsTitle: `🔐 {count} inicios de sesión fallidos para "{email}"`,
+	MessageSecurityFailedSignInsMessage: "Hubo {count} inicios de sesión seguidos con una contraseña incorrecta, " +
+		"el último desde la IP {ip}. " +
+		"Si no fuiste tú, considera cambiar tu contraseña.",
+	MessageSecurityPolicyChangedTitle:   `🔐 Política de seguridad cambiada por "{email}"`,
+	MessageSecurityPolicyChangedMessage: "Ajustes cambiados: {changes}",
+
+	MessageTestEventNote: "🧪 Este es un evento de prueba para comprobar el notificador, no ocurrió nada en realidad.",
+
+	MessageDatabaseOnlineTitle:        "✅ [{database}] La BD está en línea",
+	MessageDatabaseOnlineMessage:      "✅ [{database}] La BD vuelve a estar en línea",
+	MessageDatabaseUnavailableTitle:   "❌ [{database}] La BD no está disponible",
+	MessageDatabaseUnavailableMessage: "❌ [{database}] La BD no está disponible en este momento",
+
+	MessageEmailPasswordResetSubject: "Código de restablecimiento de contraseña de {product}",
+	MessageEmailPasswordResetHeading: "Solicitud de restablecimiento de contraseña",
+	MessageEmailPasswordResetIntro: "Has solicitado restablecer tu contraseña. " +
+		"Usa el siguiente código para completar el proceso:",
+	MessageEmailPasswordResetExpiration: "Este código y enlace caducarán en <strong>{minutes} minutos</strong>.",
+	MessageEmailPasswordResetIgnore: "Si no solicitaste restablecer la contraseña, ignora este correo. " +
+		"Tu contraseña no cambiará.",
+	MessageEmailPasswordResetAutomated: "Este es un mensaje automático de {product}. " +
+		"Por favor, no respondas a este correo.",
+	MessageEmailPasswordResetLinkIntro: "O abre el siguiente enlace para elegir una nueva contraseña:",
+	MessageEmailPasswordResetButton:    "Restablecer contraseña",
+
+	MessageEmailVerificationSubject: "Verifica tu correo de {product}",
+	MessageEmailVerificationHeading: "Verificación de correo",
+	MessageEmailVerificationIntro: "Confirma que <strong>{email}</strong> es tu dirección de correo " +
+		"para recibir notificaciones e invitaciones.",
+	MessageEmailVerificationButton: "Verificar correo",
+	MessageEmailVerificationPasteToken: "Visita tu instancia de {product} y pega el siguiente " +
+		"token de verificación en la página de verificación de correo:",
+	MessageEmailVerificationExpiration: "Este enlace caducará en <strong>24 horas</strong>.",
+	MessageEmailVerificationIgnore: "Si no te registraste ni cambiaste tu correo, ignora este mensaje. " +
+		"Tu correo no cambiará.",
+	MessageEmailVerificationAutomated: "Este es un mensaje automático de {product}. " +
+		"Por favor, no respondas a este correo.",
+
+	MessageEmailOwnedBackupFailedReason: "Recibes este correo de {product} " +
+		"porque eres el propietario de esta base de datos. " +
+		"Puedes desactivarlo en tus preferencias.",
+
+	MessageEmailInvitationSubject: "Te han invitado al espacio de trabajo {workspace}",
+	MessageEmailInvitationHeading: "Invitación al espacio de trabajo",
+	MessageEmailInvitationBody: "<strong>{inviter}</strong> te ha invitado a unirte al espacio de trabajo " +
+		"<strong>{workspace}</strong> como <strong>{role}</strong>.",
+	MessageEmailInvitationSignUp: "Registrarse",
+	MessageEmailInvitationVisitInstance: "Visita tu instancia de {product} para registrarte " +
+		"y acceder al espacio de trabajo.",
+	MessageEmailInvitationAutomated: "Este es un mensaje automático de {product}. " +
+		"Si no esperabas esta invitación, puedes ignorar este correo.",
+
+	MessageEmailSecurityReleaseSubject: "Actualización de seguridad {version} disponible para {product}",
+	MessageEmailSecurityReleaseBody: "La versión de seguridad <strong>{version}</strong> está disponible. " +
+		"Esta instancia ejecuta <strong>{current}</strong>, actualiza lo antes posible.",
+	MessageEmailSecurityReleaseReleaseNotes: "Ver notas de la versión",
+}
@@ -0,0 +1,90 @@
+package i18n
+
+var catalogFr = map[MessageKey]string{
+	MessageBackupFailedTitle:    `❌ Échec de la sauvegarde de la base de données "{database}" (espace de travail "{workspace}")`,
+	MessageBackupSuccessTitle:   `✅ Sauvegarde terminée pour la base de données "{database}" (espace de travail "{workspace}")`,
+	MessageBackupSuccessMessage: "Sauvegarde terminée avec succès en {duration}.\nTaille compressée de la sauvegarde : {size}",
+
+	MessageRefreshFailedTitle:    `❌ Échec de l'actualisation "{refresh}" de la base de données "{database}" (espace de travail "{workspace}")`,
+	MessageRefreshSuccessTitle:   `✅ Actualisation "{refresh}" terminée pour la base de données "{database}" (espace de travail "{workspace}")`,
+	MessageRefreshSuccessMessage: "La dernière sauvegarde a été restaurée dans la cible de l'actualisation.",
+
+	MessageNotifierBrokenTitle:   `⚠️ Le notificateur "{notifier}" ne fonctionne plus (espace de travail "{workspace}")`,
+	MessageNotifierBrokenMessage: "Le notificateur a échoué à la vérification d'état : {error}",
+
+	MessageCredentialsExpiringTitle: `🔑 Les identifiants de "{name}" expirent dans {days} jour(s) (espace de travail "{workspace}")`,
+	MessageCredentialsExpiringMessage: "Renouvelez les identifiants et mettez-les à jour dans Databasus " +
+		"avant le {date}, sinon les sauvegardes échoueront.",
+	MessageCredentialsExpiredTitle: `🔑 Les identifiants de "{name}" ont expiré (espace de travail "{workspace}")`,
+	MessageCredentialsExpiredMessage: "Les identifiants ont expiré le {date}. " +
+		"Les sauvegardes échoueront tant qu'ils ne seront pas mis à jour dans Databasus.",
+
+	MessageStorageQuotaSoftExceededTitle: `💾 Le stockage "{storage}" a atteint son quota souple (espace de travail "{workspace}")`,
+	MessageStorageQuotaSoftExceededMessage: "Les sauvegardes utilisent {used} sur le quota souple de {limit}. " +
+		"Libérez de l'espace ou augmentez le quota avant d'atteindre le quota strict.",
+	MessageStorageQuotaHardExceededTitle: `💾 Le stockage "{storage}" a atteint son quota strict (espace de travail "{workspace}")`,
+	MessageStorageQuotaHardExceededMessage: "Les sauvegardes utilisent {used} sur le quota strict de {limit}. " +
+		"Les nouvelles sauvegardes vers ce stockage sont bloquées jusqu'à libérer de l'espace ou augmenter le quota.",
+
+	MessageSecurityNewDeviceTitle: `🔐 Nouvel appareil connecté en tant que "{email}"`,
+	MessageSecurityNewDeviceMessage: "{email} s'est connecté depuis un nouvel appareil " +
+		"({device}, IP {ip}). " +
+		"Si ce n'était pas vous, changez votre mot de passe immédiatement.",
+	MessageSecurityFailedSignInsTitle: `🔐 {count} connexions échouées pour "{email}"`,
+	MessageSecurityFailedSignInsMessage: "Il y a eu {count} connexions avec un mauvais mot de passe d'affilée, " +
+		"la dernière depuis l'IP {ip}. " +
+		"Si ce n'était pas vous, pensez à changer votre mot de passe.",
+	MessageSecurityPolicyChangedTitle:   `🔐 Politique de sécurité modifiée par "{email}"`,
+	MessageSecurityPolicyChangedMessage: "Paramètres modifiés : {changes}",
+
+	MessageTestEventNote: "🧪 Ceci est un événement de test pour vérifier le notificateur, rien ne s'est réellement produit.",
+
+	MessageDatabaseOnlineTitle:        "✅ [{database}] La BD est en ligne",
+	MessageDatabaseOnlineMessage:      "✅ [{database}] La BD est de nouveau en ligne",
+	MessageDatabaseUnavailableTitle:   "❌ [{database}] La BD est indisponible",
+	MessageDatabaseUnavailableMessage: "❌ [{database}] La BD est actuellement indisponible",
+
+	MessageEmailPasswordResetSubject: "Code de réinitialisation du mot de passe {product}",
+	MessageEmailPasswordResetHeading: "Demande de réinitialisation du mot de passe",
+	MessageEmailPasswordResetIntro: "Vous avez demandé la réinitialisation de votre mot de passe. " +
+		"Veuillez utiliser le code suivant pour terminer la procédure :",
+	MessageEmailPasswordResetExpiration: "Ce code et ce lien expireront dans <strong>{minutes} minutes</strong>.",
+	MessageEmailPasswordResetIgnore: "Si vous n'êtes pas à l'origine de cette demande, ignorez cet e-mail. " +
+		"Votre mot de passe restera inchangé.",
+	MessageEmailPasswordResetAutomated: "Ceci est un message automatique de {product}. " +
+		"Merci de ne pas répondre à cet e-mail.",
+	MessageEmailPasswordResetLinkIntro: "Ou ouvrez le lien ci-dessous pour choisir un nouveau mot de passe :",
+	MessageEmailPasswordResetButton:    "Réinitialiser le mot de passe",
+
+	MessageEmailVerificationSubject: "Vérifiez votre adresse e-mail {product}",
+	MessageEmailVerificationHeading: "Vérification de l'adresse e-mail",
+	MessageEmailVerificationIntro: "Veuillez confirmer que <strong>{email}</strong> est votre adresse e-mail " +
+		"pour recevoir les notifications et les invitations.",
+	MessageEmailVerificationButton: "Vérifier l'adresse e-mail",
+	MessageEmailVerificationPasteToken: "Veuillez ouvrir votre instance {product} et coller le jeton " +
+		"de vérification suivant sur la page de vérification de l'adresse e-mail :",
+	MessageEmailVerificationExpiration: "Ce lien expirera dans <strong>24 heures</strong>.",
+	MessageEmailVerificationIgnore: "Si vous ne vous êtes pas inscrit et n'avez pas changé votre adresse e-mail, " +
+		"ignorez cet e-mail. Votre adresse restera inchangée.",
+	MessageEmailVerificationAutomated: "Ceci est un message automatique de {product}. " +
+		"Merci de ne pas répondre à cet e-mail.",
+
+	MessageEmailOwnedBackupFailedReason: "Vous recevez cet e-mail de {product} " +
+		"car vous êtes propriétaire de cette base de données. " +
+		"Vous pouvez le désactiver dans vos préférences.",
+
+	MessageEmailInvitationSubject: "Vous avez été invité à l'espace de travail {workspace}",
+	MessageEmailInvitationHeading: "Invitation à un espace de travail",
+	MessageEmailInvitationBody: "<strong>{inviter}</strong> vous a invité à rejoindre l'espace de travail " +
+		"<strong>{workspace}</strong> en tant que <strong>{role}</strong>.",
+	MessageEmailInvitationSignUp: "S'inscrire",
+	MessageEmailInvitationVisitInstance: "Rendez-vous sur votre instance {product} pour vous inscrire " +
+		"et accéder à l'espace de travail.",
+	MessageEmailInvitationAutomated: "Ceci est un message automatique de {product}. " +
+		"Si vous n'attendiez pas cette invitation, vous pouvez ignorer cet e-mail.",
+
+	MessageEmailSecurityReleaseSubject: "Mise à jour de sécurité {version} disponible pour {product}",
+	MessageEmailSecurityReleaseBody: "La version de sécurité <strong>{version}</strong> est disponible. " +
+		"Cette instance exécute <strong>{current}</strong>, veuillez mettre à jour dès que possible.",
+	MessageEmailSecurityReleaseReleaseNotes: "Voir les notes de version",
+}
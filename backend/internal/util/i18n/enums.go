@@ -0,0 +1,82 @@
+package i18n
+
+type Locale string
+
+const (
+	LocaleEn Locale = "en"
+	LocaleEs Locale = "es"
+	LocaleDe Locale = "de"
+	LocaleFr Locale = "fr"
+)
+
+const DefaultLocale = LocaleEn
+
+type MessageKey string
+
+const (
+	MessageBackupFailedTitle    MessageKey = "backup_failed_title"
+	MessageBackupSuccessTitle   MessageKey = "backup_success_title"
+	MessageBackupSuccessMessage MessageKey = "backup_success_message"
+
+	MessageRefreshFailedTitle    MessageKey = "refresh_failed_title"
+	MessageRefreshSuccessTitle   MessageKey = "refresh_success_title"
+	MessageRefreshSuccessMessage MessageKey = "refresh_success_message"
+
+	MessageNotifierBrokenTitle   MessageKey = "notifier_broken_title"
+	MessageNotifierBrokenMessage MessageKey = "notifier_broken_message"
+
+	MessageCredentialsExpiringTitle   MessageKey = "credentials_expiring_title"
+	MessageCredentialsExpiringMessage MessageKey = "credentials_expiring_message"
+	MessageCredentialsExpiredTitle    MessageKey = "credentials_expired_title"
+	MessageCredentialsExpiredMessage  MessageKey = "credentials_expired_message"
+
+	MessageStorageQuotaSoftExceededTitle   MessageKey = "storage_quota_soft_exceeded_title"
+	MessageStorageQuotaSoftExceededMessage MessageKey = "storage_quota_soft_exceeded_message"
+	MessageStorageQuotaHardExceededTitle   MessageKey = "storage_quota_hard_exceeded_title"
+	MessageStorageQuotaHardExceededMessage MessageKey = "storage_quota_hard_exceeded_message"
+
+	MessageSecurityNewDeviceTitle       MessageKey = "security_new_device_title"
+	MessageSecurityNewDeviceMessage     MessageKey = "security_new_device_message"
+	MessageSecurityFailedSignInsTitle   MessageKey = "security_failed_sign_ins_title"
+	MessageSecurityFailedSignInsMessage MessageKey = "security_failed_sign_ins_message"
+	MessageSecurityPolicyChangedTitle   MessageKey = "security_policy_changed_title"
+	MessageSecurityPolicyChangedMessage MessageKey = "security_policy_changed_message"
+
+	MessageTestEventNote MessageKey = "test_event_note"
+
+	MessageDatabaseOnlineTitle        MessageKey = "database_online_title"
+	MessageDatabaseOnlineMessage      MessageKey = "database_online_message"
+	MessageDatabaseUnavailableTitle   MessageKey = "database_unavailable_title"
+	MessageDatabaseUnavailableMessage MessageKey = "database_unavailable_message"
+
+	MessageEmailPasswordResetSubject    MessageKey = "email_password_reset_subject"
+	MessageEmailPasswordResetHeading    MessageKey = "email_password_reset_heading"
+	MessageEmailPasswordResetIntro      MessageKey = "email_password_reset_intro"
+	MessageEmailPasswordResetExpiration MessageKey = "email_password_reset_expiration"
+	MessageEmailPasswordResetIgnore     MessageKey = "email_password_reset_ignore"
+	MessageEmailPasswordResetAutomated  MessageKey = "email_password_reset_automated"
+	MessageEmailPasswordResetLinkIntro  MessageKey = "email_password_reset_link_intro"
+	MessageEmailPasswordResetButton     MessageKey = "email_password_reset_button"
+
+	MessageEmailVerificationSubject    MessageKey = "email_verification_subject"
+	MessageEmailVerificationHeading    MessageKey = "email_verification_heading"
+	MessageEmailVerificationIntro      MessageKey = "email_verification_intro"
+	MessageEmailVerificationButton     MessageKey = "email_verification_button"
+	MessageEmailVerificationPasteToken MessageKey = "email_verification_paste_token"
+	MessageEmailVerificationExpiration MessageKey = "email_verification_expiration"
+	MessageEmailVerificationIgnore     MessageKey = "email_verification_ignore"
+	MessageEmailVerificationAutomated  MessageKey = "email_verification_automated"
+
+	MessageEmailOwnedBackupFailedReason MessageKey = "email_owned_backup_failed_reason"
+
+	MessageEmailInvitationSubject       MessageKey = "email_invitation_subject"
+	MessageEmailInvitationHeading       MessageKey = "email_invitation_heading"
+	MessageEmailInvitationBody          MessageKey = "email_invitation_body"
+	MessageEmailInvitationSignUp        MessageKey = "email_invitation_sign_up"
+	MessageEmailInvitationVisitInstance MessageKey = "email_invitation_visit_instance"
+	MessageEmailInvitationAutomated     MessageKey = "email_invitation_automated"
+
+	MessageEmailSecurityReleaseSubject      MessageKey = "email_security_release_subject"
+	MessageEmailSecurityReleaseBody         MessageKey = "email_security_release_body"
+	MessageEmailSecurityReleaseReleaseNotes MessageKey = "email_security_release_release_notes"
+)
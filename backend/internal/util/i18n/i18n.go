@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TemplateOverrideSource provides templates customized by admins. It
+// returns false when there is no override for the locale and key
+type TemplateOverrideSource interface {
+	GetTemplateOverride(locale Locale, key MessageKey) (string, bool)
+}
+
+var catalogs = map[Locale]map[MessageKey]string{
+	LocaleEn: catalogEn,
+	LocaleEs: catalogEs,
+	LocaleDe: catalogDe,
+	LocaleFr: catalogFr,
+}
+
+var (
+	overrideSource   TemplateOverrideSource
+	overrideSourceMu sync.RWMutex
+)
+
+func SetTemplateOverrideSource(source TemplateOverrideSource) {
+	overrideSourceMu.Lock()
+	defer overrideSourceMu.Unlock()
+
+	overrideSource = source
+}
+
+func GetSupportedLocales() []Locale {
+	return []Locale{LocaleEn, LocaleEs, LocaleDe, LocaleFr}
+}
+
+func GetMessageKeys() []MessageKey {
+	keys := make([]MessageKey, 0, len(catalogEn))
+	for key := range catalogEn {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+func IsSupportedLocale(locale Locale) bool {
+	_, isFound := catalogs[locale]
+	return isFound
+}
+
+func IsKnownMessageKey(key MessageKey) bool {
+	_, isFound := catalogEn[key]
+	return isFound
+}
+
+// NormalizeLocale turns empty or unsupported values into the default
+// locale, so stale values in DB never break sending
+func NormalizeLocale(locale Locale) Locale {
+	locale = Locale(strings.ToLower(strings.TrimSpace(string(locale))))
+	if !IsSupportedLocale(locale) {
+		return DefaultLocale
+	}
+
+	return locale
+}
+
+// GetDefaultTemplate returns the built-in template ignoring overrides,
+// falling back to English when the locale has no translation for a key
+func GetDefaultTemplate(locale Locale, key MessageKey) string {
+	if template, isFound := catalogs[NormalizeLocale(locale)][key]; isFound {
+		return template
+	}
+
+	return catalogEn[key]
+}
+
+// Translate renders a message, placeholders have "{name}" format
+func Translate(locale Locale, key MessageKey, params map[string]string) string {
+	locale = NormalizeLocale(locale)
+
+	template := GetDefaultTemplate(locale, key)
+	if override, isFound := getTemplateOverride(locale, key); isFound {
+		template = override
+	}
+
+	return Render(template, params)
+}
+
+func Render(template string, params map[string]string) string {
+	if len(params) == 0 {
+		return template
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+func getTemplateOverride(locale Locale, key MessageKey) (string, bool) {
+	overrideSourceMu.RLock()
+	defer overrideSourceMu.RUnlock()
+
+	if overrideSource == nil {
+		return "", false
+	}
+
+	return overrideSource.GetTemplateOverride(locale, key)
+}
@@ -0,0 +1,59 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type overrideSourceStub struct {
+	overrides map[Locale]map[MessageKey]string
+}
+
+func (s *overrideSourceStub) GetTemplateOverride(locale Locale, key MessageKey) (string, bool) {
+	template, isFound := s.overrides[locale][key]
+	return template, isFound
+}
+
+func Test_Catalogs_AllLocales_ContainEveryMessageKey(t *testing.T) {
+	for _, locale := range GetSupportedLocales() {
+		for _, key := range GetMessageKeys() {
+			_, isFound := catalogs[locale][key]
+			assert.True(t, isFound, "locale %s misses key %s", locale, key)
+		}
+	}
+}
+
+func Test_Translate_WithUnsupportedLocale_FallsBackToEnglish(t *testing.T) {
+	message := Translate(
+		Locale("xx"),
+		MessageDatabaseOnlineTitle,
+		map[string]string{"database": "orders"},
+	)
+
+	assert.Equal(t, "✅ [orders] DB is online", message)
+}
+
+func Test_Translate_WithLocale_RendersTranslatedTemplate(t *testing.T) {
+	message := Translate(
+		LocaleDe,
+		MessageDatabaseOnlineTitle,
+		map[string]string{"database": "orders"},
+	)
+
+	assert.Equal(t, "✅ [orders] DB ist online", message)
+}
+
+func Test_Translate_WithOverride_UsesOverrideOnlyForItsLocale(t *testing.T) {
+	SetTemplateOverrideSource(&overrideSourceStub{
+		overrides: map[Locale]map[MessageKey]string{
+			LocaleFr: {MessageDatabaseOnlineTitle: "[{database}] disponible"},
+		},
+	})
+	defer SetTemplateOverrideSource(nil)
+
+	params := map[string]string{"database": "orders"}
+
+	assert.Equal(t, "[orders] disponible", Translate(LocaleFr, MessageDatabaseOnlineTitle, params))
+	assert.Equal(t, "✅ [orders] DB is online", Translate(LocaleEn, MessageDatabaseOnlineTitle, params))
+}
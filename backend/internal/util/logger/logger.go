@@ -17,6 +17,7 @@ var (
 	once               sync.Once
 	shutdownOnce       sync.Once
 	envLoadOnce        sync.Once
+	logLevel           = new(slog.LevelVar)
 )
 
 // GetLogger returns a singleton slog.Logger that logs to the console
@@ -24,7 +25,7 @@ func GetLogger() *slog.Logger {
 	once.Do(func() {
 		// Create stdout handler
 		stdoutHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
+			Level: logLevel,
 			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 				if a.Key == slog.TimeKey {
 					a.Value = slog.StringValue(time.Now().Format("2006/01/02 15:04:05"))
@@ -56,6 +57,11 @@ func GetLogger() *slog.Logger {
 	return loggerInstance
 }
 
+// SetLevel changes the level of the existing logger, so it can be reloaded without restart
+func SetLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
 // ShutdownVictoriaLogs gracefully shuts down the VictoriaLogs writer
 func ShutdownVictoriaLogs(timeout time.Duration) {
 	shutdownOnce.Do(func() {
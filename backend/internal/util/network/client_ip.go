@@ -0,0 +1,13 @@
+package network_utils
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigureClientIP makes ClientIP follow forwarding headers of the trusted proxies only.
+// Without them the peer address is the client, gin trusts everyone by default
+func ConfigureClientIP(ginApp *gin.Engine, trustedProxies []string, clientIPHeaders []string) error {
+	ginApp.RemoteIPHeaders = clientIPHeaders
+
+	return ginApp.SetTrustedProxies(trustedProxies)
+}
@@ -0,0 +1,58 @@
+package network_utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClientIP_WhenRequestFromUntrustedProxy_ForwardedHeaderIgnored(t *testing.T) {
+	router := createClientIPTestRouter(t, []string{"10.0.0.0/8"})
+
+	assert.Equal(t, "203.0.113.7", requestClientIP(router, "203.0.113.7:51000", "198.51.100.1"))
+}
+
+func Test_ClientIP_WhenRequestFromTrustedProxy_ForwardedHeaderHonored(t *testing.T) {
+	router := createClientIPTestRouter(t, []string{"10.0.0.0/8"})
+
+	assert.Equal(t, "198.51.100.1", requestClientIP(router, "10.0.0.5:51000", "198.51.100.1"))
+}
+
+func Test_ClientIP_WhenNoProxiesConfigured_ForwardedHeaderIgnored(t *testing.T) {
+	router := createClientIPTestRouter(t, nil)
+
+	assert.Equal(t, "10.0.0.5", requestClientIP(router, "10.0.0.5:51000", "198.51.100.1"))
+}
+
+func createClientIPTestRouter(t *testing.T, trustedProxies []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	err := ConfigureClientIP(
+		router,
+		trustedProxies,
+		[]string{"X-Forwarded-For", "X-Real-IP"},
+	)
+	require.NoError(t, err)
+
+	router.GET("/ip", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, ctx.ClientIP())
+	})
+
+	return router
+}
+
+func requestClientIP(router *gin.Engine, remoteAddr string, forwardedFor string) string {
+	request := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	request.RemoteAddr = remoteAddr
+	request.Header.Set("X-Forwarded-For", forwardedFor)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder.Body.String()
+}
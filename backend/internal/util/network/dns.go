@@ -0,0 +1,141 @@
+package network_utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const dnsServerPort = "53"
+
+// DNSOverride resolves hosts of a storage or database with other records than the system
+// resolver, for split-horizon DNS where backup nodes resolve names unlike app servers
+type DNSOverride struct {
+	// Hosts maps host names to IP addresses like /etc/hosts, they win over Server
+	Hosts map[string]string `json:"hosts,omitempty"`
+	// Server is a DNS server like 10.0.0.53 or 10.0.0.53:5353 asked for other hosts
+	Server string `json:"server,omitempty"`
+}
+
+func (o *DNSOverride) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if len(o.Hosts) == 0 && o.Server == "" {
+		return errors.New("DNS override needs host overrides or a DNS server")
+	}
+
+	for host, ip := range o.Hosts {
+		if strings.TrimSpace(host) == "" {
+			return errors.New("host of DNS override is required")
+		}
+
+		if net.ParseIP(NormalizeHost(ip)) == nil {
+			return fmt.Errorf("DNS override of %s is not an IP address: %s", host, ip)
+		}
+	}
+
+	if o.Server != "" {
+		if _, err := o.getServerAddress(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewDialer returns a dialer asking the DNS server of the override, if any, for hosts
+func (o *DNSOverride) NewDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout:  timeout,
+		Resolver: o.getResolver(),
+	}
+}
+
+// RewriteHost returns the IP address of hosts overridden in Hosts, other hosts are kept
+func (o *DNSOverride) RewriteHost(host string) string {
+	host = NormalizeHost(host)
+
+	if o == nil {
+		return host
+	}
+
+	for overriddenHost, ip := range o.Hosts {
+		if strings.EqualFold(NormalizeHost(overriddenHost), host) {
+			return NormalizeHost(ip)
+		}
+	}
+
+	return host
+}
+
+// RewriteAddress is RewriteHost for host:port addresses
+func (o *DNSOverride) RewriteAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	return net.JoinHostPort(o.RewriteHost(host), port)
+}
+
+// ResolveHost returns the IP address the host resolves to with the override, for clients
+// doing their own lookups like database tools. Hosts are kept without an override
+func (o *DNSOverride) ResolveHost(ctx context.Context, host string) (string, error) {
+	host = o.RewriteHost(host)
+
+	if o == nil || o.Server == "" || net.ParseIP(host) != nil {
+		return host, nil
+	}
+
+	ips, err := o.getResolver().LookupIPAddr(ctx, host)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s with DNS server %s: %w", host, o.Server, err)
+	}
+	if len(ips) == 0 {
+		return "", fmt.Errorf("no addresses found for %s", host)
+	}
+
+	return ips[0].String(), nil
+}
+
+func (o *DNSOverride) getResolver() *net.Resolver {
+	if o == nil || o.Server == "" {
+		return nil
+	}
+
+	serverAddress, err := o.getServerAddress()
+	if err != nil {
+		return nil
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			dialer := &net.Dialer{}
+			return dialer.DialContext(ctx, network, serverAddress)
+		},
+	}
+}
+
+func (o *DNSOverride) getServerAddress() (string, error) {
+	server := strings.TrimSpace(o.Server)
+
+	if ip := net.ParseIP(NormalizeHost(server)); ip != nil {
+		return net.JoinHostPort(ip.String(), dnsServerPort), nil
+	}
+
+	host, port, err := net.SplitHostPort(server)
+	if err != nil || net.ParseIP(host) == nil {
+		return "", fmt.Errorf(
+			"DNS server must be an IP address with an optional port, e.g. 10.0.0.53:53, got %s",
+			o.Server,
+		)
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,47 @@
+package network_utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RewriteAddress_WhenHostOverridden_IPAddressUsed(t *testing.T) {
+	override := &DNSOverride{
+		Hosts: map[string]string{
+			"MinIO.corp.example.com": "10.0.0.7",
+			"nas.corp.example.com":   "[fd00::12]",
+		},
+	}
+
+	assert.Equal(t, "10.0.0.7:9000", override.RewriteAddress("minio.corp.example.com:9000"))
+	assert.Equal(t, "[fd00::12]:445", override.RewriteAddress("nas.corp.example.com:445"))
+	assert.Equal(t, "other.example.com:21", override.RewriteAddress("other.example.com:21"))
+
+	var noOverride *DNSOverride
+	assert.Equal(t, "minio.corp.example.com", noOverride.RewriteHost("minio.corp.example.com"))
+	assert.Nil(t, noOverride.NewDialer(0).Resolver)
+}
+
+func Test_ResolveHost_WhenHostOverridden_DNSServerNotAsked(t *testing.T) {
+	// the DNS server is unreachable, so the test fails if the override is not used
+	override := &DNSOverride{
+		Hosts:  map[string]string{"db.corp.example.com": "10.0.0.9"},
+		Server: "127.0.0.1:1",
+	}
+
+	host, err := override.ResolveHost(context.Background(), "db.corp.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.9", host)
+}
+
+func Test_DNSOverride_Validate_WhenInvalid_Rejected(t *testing.T) {
+	assert.NoError(t, (&DNSOverride{Server: "10.0.0.53"}).Validate())
+	assert.NoError(t, (&DNSOverride{Server: "[fd00::53]:5353"}).Validate())
+	assert.NoError(t, (&DNSOverride{Hosts: map[string]string{"db": "fd00::5"}}).Validate())
+
+	assert.Error(t, (&DNSOverride{}).Validate())
+	assert.Error(t, (&DNSOverride{Server: "dns.example.com"}).Validate())
+	assert.Error(t, (&DNSOverride{Hosts: map[string]string{"db": "db.example.com"}}).Validate())
+}
@@ -0,0 +1,257 @@
+package network_utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type IPPreference string
+
+const (
+	// IPPreferenceAuto lets the dialer race IPv6 and IPv4 addresses (happy eyeballs)
+	IPPreferenceAuto IPPreference = "AUTO"
+	IPPreferenceIPv4 IPPreference = "IPV4"
+	IPPreferenceIPv6 IPPreference = "IPV6"
+)
+
+// fallbackDelay is how long addresses of the preferred family get before addresses of the
+// other family are dialed too, it matches the default of net.Dialer
+const fallbackDelay = 300 * time.Millisecond
+
+func (p IPPreference) Validate() error {
+	switch p {
+	case "", IPPreferenceAuto, IPPreferenceIPv4, IPPreferenceIPv6:
+		return nil
+	default:
+		return fmt.Errorf("invalid IP preference: %s, expected AUTO, IPV4 or IPV6", p)
+	}
+}
+
+// NormalizeHost strips brackets from IPv6 literals like [2001:db8::1], users often copy
+// them from URLs and they break net.JoinHostPort
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1]
+	}
+
+	return host
+}
+
+// JoinHostPort builds an address dialable with IPv6 literals, unlike fmt.Sprintf("%s:%d")
+func JoinHostPort(host string, port int) string {
+	return net.JoinHostPort(NormalizeHost(host), strconv.Itoa(port))
+}
+
+// ResolveAddresses returns the addresses to dial in order, addresses of the preferred family
+// first. With IPPreferenceAuto the host is kept, so the dialer races both families itself.
+// A nil resolver is the system one
+func ResolveAddresses(
+	ctx context.Context,
+	resolver *net.Resolver,
+	host string,
+	port int,
+	preference IPPreference,
+) ([]string, error) {
+	host = NormalizeHost(host)
+
+	if !preference.isFamilyPreferred() || net.ParseIP(host) != nil {
+		return []string{JoinHostPort(host, port)}, nil
+	}
+
+	primaries, fallbacks, err := resolveByFamily(ctx, resolver, host, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(primaries)+len(fallbacks))
+	for _, ip := range slices.Concat(primaries, fallbacks) {
+		addresses = append(addresses, JoinHostPort(ip, port))
+	}
+
+	return addresses, nil
+}
+
+// DialContext opens a TCP connection preferring the family of the preference. Addresses of
+// the other family are dialed when the preferred ones fail or are slow, and when the host
+// has no address of the preferred family
+func DialContext(
+	ctx context.Context,
+	dialer *net.Dialer,
+	preference IPPreference,
+	address string,
+) (net.Conn, error) {
+	if !preference.isFamilyPreferred() {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+
+	primaries, fallbacks, err := resolveByFamily(ctx, dialer.Resolver, host, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, ip := range primaries {
+		primaries[i] = net.JoinHostPort(ip, port)
+	}
+	for i, ip := range fallbacks {
+		fallbacks[i] = net.JoinHostPort(ip, port)
+	}
+
+	if len(primaries) == 0 {
+		return dialSerial(ctx, dialer, fallbacks)
+	}
+	if len(fallbacks) == 0 {
+		return dialSerial(ctx, dialer, primaries)
+	}
+
+	return dialParallel(ctx, dialer, primaries, fallbacks)
+}
+
+func (p IPPreference) isFamilyPreferred() bool {
+	return p == IPPreferenceIPv4 || p == IPPreferenceIPv6
+}
+
+func resolveByFamily(
+	ctx context.Context,
+	resolver *net.Resolver,
+	host string,
+	preference IPPreference,
+) ([]string, []string, error) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	ips, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var primaries, fallbacks []string
+	for _, ip := range ips {
+		isIPv4 := ip.IP.To4() != nil
+
+		if isIPv4 == (preference == IPPreferenceIPv4) {
+			primaries = append(primaries, ip.String())
+		} else {
+			fallbacks = append(fallbacks, ip.String())
+		}
+	}
+
+	if len(primaries) == 0 && len(fallbacks) == 0 {
+		return nil, nil, fmt.Errorf("no addresses found for %s", host)
+	}
+
+	return primaries, fallbacks, nil
+}
+
+func dialSerial(ctx context.Context, dialer *net.Dialer, addresses []string) (net.Conn, error) {
+	var firstErr error
+
+	for _, address := range addresses {
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			return conn, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = errors.New("no addresses to dial")
+	}
+
+	return nil, firstErr
+}
+
+// dialParallel starts the fallback addresses when the primary ones fail or did not connect
+// within fallbackDelay, the first connection wins and the other one is closed
+func dialParallel(
+	ctx context.Context,
+	dialer *net.Dialer,
+	primaries []string,
+	fallbacks []string,
+) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+
+	results := make(chan dialResult, 2)
+	startDialing := func(addresses []string) {
+		go func() {
+			conn, err := dialSerial(ctx, dialer, addresses)
+			results <- dialResult{conn, err}
+		}()
+	}
+
+	startDialing(primaries)
+	pending := 1
+	isFallbackStarted := false
+
+	fallbackTimer := time.NewTimer(fallbackDelay)
+	defer fallbackTimer.Stop()
+
+	var firstErr error
+
+	for {
+		select {
+		case <-fallbackTimer.C:
+			if !isFallbackStarted {
+				isFallbackStarted = true
+				pending++
+				startDialing(fallbacks)
+			}
+		case result := <-results:
+			pending--
+
+			if result.err == nil {
+				if pending > 0 {
+					go func() {
+						if other := <-results; other.conn != nil {
+							_ = other.conn.Close()
+						}
+					}()
+				}
+
+				return result.conn, nil
+			}
+
+			if firstErr == nil {
+				firstErr = result.err
+			}
+
+			if !isFallbackStarted {
+				isFallbackStarted = true
+				pending++
+				startDialing(fallbacks)
+			} else if pending == 0 {
+				return nil, firstErr
+			}
+		}
+	}
+}
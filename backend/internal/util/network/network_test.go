@@ -0,0 +1,102 @@
+package network_utils
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JoinHostPort_WhenHostIsIPv6Literal_AddressBracketed(t *testing.T) {
+	assert.Equal(t, "[2001:db8::1]:21", JoinHostPort("2001:db8::1", 21))
+	assert.Equal(t, "[2001:db8::1]:21", JoinHostPort("[2001:db8::1]", 21))
+	assert.Equal(t, "nas.local:445", JoinHostPort(" nas.local ", 445))
+	assert.Equal(t, "10.0.0.5:22", JoinHostPort("10.0.0.5", 22))
+}
+
+func Test_ResolveAddresses_WhenHostIsIPLiteral_HostKept(t *testing.T) {
+	addresses, err := ResolveAddresses(
+		context.Background(),
+		nil,
+		"[2001:db8::1]",
+		21,
+		IPPreferenceIPv4,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[2001:db8::1]:21"}, addresses)
+
+	addresses, err = ResolveAddresses(context.Background(), nil, "localhost", 21, IPPreferenceAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"localhost:21"}, addresses)
+}
+
+func Test_DialContext_WhenPreferredFamilyUnavailable_OtherFamilyUsed(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	go acceptConnections(listener)
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NoError(t, err)
+
+	conn, err := DialContext(
+		context.Background(),
+		&net.Dialer{Timeout: 5 * time.Second},
+		IPPreferenceIPv6,
+		net.JoinHostPort("localhost", port),
+	)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	defer func() { _ = conn.Close() }()
+
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+}
+
+func Test_DialParallel_WhenPrimaryAddressesFail_FallbackConnected(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	go acceptConnections(listener)
+
+	closedListener, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+	closedAddress := closedListener.Addr().String()
+	_ = closedListener.Close()
+
+	conn, err := dialParallel(
+		context.Background(),
+		&net.Dialer{Timeout: 5 * time.Second},
+		[]string{closedAddress},
+		[]string{listener.Addr().String()},
+	)
+	assert.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	defer func() { _ = conn.Close() }()
+
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+}
+
+func Test_IPPreference_Validate_WhenUnknown_Rejected(t *testing.T) {
+	assert.NoError(t, IPPreference("").Validate())
+	assert.NoError(t, IPPreferenceIPv6.Validate())
+	assert.Error(t, IPPreference("IPV5").Validate())
+}
+
+func acceptConnections(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		_ = conn.Close()
+	}
+}
@@ -0,0 +1,262 @@
+package proxy_utils
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+var supportedSchemes = []string{"http", "https", "socks5", "socks5h"}
+
+var defaultPorts = map[string]string{
+	"http":    "80",
+	"https":   "443",
+	"socks5":  "1080",
+	"socks5h": "1080",
+}
+
+// globalProxy holds OUTBOUND_PROXY_URL and OUTBOUND_NO_PROXY, nil until ConfigureGlobalProxy
+var globalProxy atomic.Pointer[httpproxy.Config]
+
+// ConfigureGlobalProxy sets the proxy used by storages and notifiers without an override.
+// It is called once on start, so this package does not load the config itself
+func ConfigureGlobalProxy(proxyURL string, noProxy []string) {
+	if proxyURL == "" {
+		globalProxy.Store(nil)
+		return
+	}
+
+	globalProxy.Store(&httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    strings.Join(noProxy, ","),
+	})
+}
+
+// ValidateProxyURL checks a proxy override of a storage or notifier, empty means no override
+func ValidateProxyURL(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+
+	_, err := parseProxyURL(rawURL)
+	return err
+}
+
+// GetProxyFunc returns the proxy of an http.Transport. The override of a storage or
+// notifier is used for every request, otherwise OUTBOUND_PROXY_URL is used for hosts not
+// in OUTBOUND_NO_PROXY. Without both, HTTPS_PROXY and other standard variables apply
+func GetProxyFunc(overrideURL string) func(*http.Request) (*url.URL, error) {
+	return func(request *http.Request) (*url.URL, error) {
+		if overrideURL == "" && globalProxy.Load() == nil {
+			return http.ProxyFromEnvironment(request)
+		}
+
+		return resolveProxyURL(overrideURL, request.URL)
+	}
+}
+
+// NewHTTPClient is a client with default transport settings connecting through the proxy
+func NewHTTPClient(overrideURL string, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = GetProxyFunc(overrideURL)
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// DialContext opens a TCP connection through the proxy for protocols other than HTTP, like
+// SMTP. HTTP proxies are asked to tunnel the connection with CONNECT. Connections are
+// direct when no proxy applies, standard proxy variables are not used here
+func DialContext(
+	ctx context.Context,
+	dialer *net.Dialer,
+	overrideURL string,
+	address string,
+) (net.Conn, error) {
+	proxyURL, err := resolveProxyURL(overrideURL, &url.URL{Scheme: "https", Host: address})
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL == nil {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+
+	if proxyURL.Scheme == "socks5" || proxyURL.Scheme == "socks5h" {
+		return dialSOCKS5(ctx, dialer, proxyURL, address)
+	}
+
+	return dialConnect(ctx, dialer, proxyURL, address)
+}
+
+// RedactProxyURL hides the password of the proxy before the URL is returned to clients
+func RedactProxyURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return proxyURL.Redacted()
+}
+
+// MergeRedactedProxyURL keeps the current URL when the incoming one is its redacted form
+// sent back unchanged, so editing other fields does not lose the proxy password
+func MergeRedactedProxyURL(current string, incoming string) string {
+	if current != "" && incoming != current && incoming == RedactProxyURL(current) {
+		return current
+	}
+
+	return incoming
+}
+
+func resolveProxyURL(overrideURL string, target *url.URL) (*url.URL, error) {
+	if overrideURL != "" {
+		return parseProxyURL(overrideURL)
+	}
+
+	proxyConfig := globalProxy.Load()
+	if proxyConfig == nil {
+		return nil, nil
+	}
+
+	return proxyConfig.ProxyFunc()(target)
+}
+
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, errors.New("proxy URL is invalid, expected e.g. http://proxy.example.com:3128")
+	}
+
+	if !slices.Contains(supportedSchemes, proxyURL.Scheme) {
+		return nil, fmt.Errorf(
+			"unsupported proxy scheme %q, expected http, https or socks5",
+			proxyURL.Scheme,
+		)
+	}
+
+	return proxyURL, nil
+}
+
+func getProxyAddress(proxyURL *url.URL) string {
+	if proxyURL.Port() != "" {
+		return proxyURL.Host
+	}
+
+	return net.JoinHostPort(proxyURL.Hostname(), defaultPorts[proxyURL.Scheme])
+}
+
+func dialSOCKS5(
+	ctx context.Context,
+	dialer *net.Dialer,
+	proxyURL *url.URL,
+	address string,
+) (net.Conn, error) {
+	var auth *proxy.Auth
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+	}
+
+	socksDialer, err := proxy.SOCKS5("tcp", getProxyAddress(proxyURL), auth, dialer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SOCKS5 dialer: %w", err)
+	}
+
+	conn, err := socksDialer.(proxy.ContextDialer).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect through SOCKS5 proxy: %w", err)
+	}
+
+	return conn, nil
+}
+
+func dialConnect(
+	ctx context.Context,
+	dialer *net.Dialer,
+	proxyURL *url.URL,
+	address string,
+) (net.Conn, error) {
+	conn, err := dialer.DialContext(ctx, "tcp", getProxyAddress(proxyURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+
+	if dialer.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(dialer.Timeout))
+	}
+
+	if proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("TLS handshake with proxy failed: %w", err)
+		}
+
+		conn = tlsConn
+	}
+
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString(
+			[]byte(proxyURL.User.Username() + ":" + password),
+		)
+		request.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := request.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT to proxy: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response of proxy: %w", err)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy refused to connect to %s: %s", address, response.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	// servers like SMTP speak first, their greeting may already be read into the buffer
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
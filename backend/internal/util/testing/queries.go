@@ -0,0 +1,43 @@
+package testing
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+
+	"databasus-backend/internal/storage"
+)
+
+var (
+	queriesCount             atomic.Int64
+	registerQueryCounterOnce sync.Once
+)
+
+// CountQueries returns how many SELECT statements fn sent to DB. It is meant for benchmarks
+// guarding against N+1 queries, so fn must not run in parallel with other DB code
+func CountQueries(fn func()) int64 {
+	registerQueryCounterOnce.Do(registerQueryCounter)
+
+	before := queriesCount.Load()
+	fn()
+
+	return queriesCount.Load() - before
+}
+
+func registerQueryCounter() {
+	countQuery := func(_ *gorm.DB) { queriesCount.Add(1) }
+	callback := storage.GetDb().Callback()
+
+	if err := callback.Query().
+		After("gorm:query").
+		Register("test_utils:count_query", countQuery); err != nil {
+		panic(err)
+	}
+
+	if err := callback.Row().
+		After("gorm:row").
+		Register("test_utils:count_row", countQuery); err != nil {
+		panic(err)
+	}
+}
@@ -17,6 +17,7 @@ type RequestOptions struct {
 	Body           interface{}
 	Headers        map[string]string
 	AuthToken      string
+	RemoteAddr     string
 	ExpectedStatus int
 }
 
@@ -149,6 +150,10 @@ func MakeRequest(t *testing.T, router *gin.Engine, options RequestOptions) *Test
 		req.Header.Set(key, value)
 	}
 
+	if options.RemoteAddr != "" {
+		req.RemoteAddr = options.RemoteAddr
+	}
+
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
@@ -27,8 +27,9 @@ const (
 type PostgresqlExecutable string
 
 const (
-	PostgresqlExecutablePgDump PostgresqlExecutable = "pg_dump"
-	PostgresqlExecutablePsql   PostgresqlExecutable = "psql"
+	PostgresqlExecutablePgDump    PostgresqlExecutable = "pg_dump"
+	PostgresqlExecutablePgRestore PostgresqlExecutable = "pg_restore"
+	PostgresqlExecutablePsql      PostgresqlExecutable = "psql"
 )
 
 func GetPostgresqlVersionEnum(version string) PostgresqlVersion {
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	env_utils "databasus-backend/internal/util/env"
+)
+
+type InfluxdbExecutable string
+
+const (
+	InfluxdbExecutableInflux InfluxdbExecutable = "influx"
+)
+
+// GetInfluxdbExecutable returns the full path to the influx CLI. One CLI version backs
+// up and restores all InfluxDB 2.x servers
+func GetInfluxdbExecutable(
+	executable InfluxdbExecutable,
+	envMode env_utils.EnvMode,
+	influxdbInstallDir string,
+) string {
+	basePath := getInfluxdbBasePath(envMode, influxdbInstallDir)
+	executableName := string(executable)
+
+	if runtime.GOOS == "windows" {
+		executableName += ".exe"
+	}
+
+	return filepath.Join(basePath, executableName)
+}
+
+// VerifyInfluxdbInstallation verifies that the influx CLI is installed
+func VerifyInfluxdbInstallation(
+	logger *slog.Logger,
+	envMode env_utils.EnvMode,
+	influxdbInstallDir string,
+	isShowLogs bool,
+) {
+	cmdPath := GetInfluxdbExecutable(InfluxdbExecutableInflux, envMode, influxdbInstallDir)
+
+	if isShowLogs {
+		logger.Info("Verifying InfluxDB CLI installation", "path", cmdPath)
+	}
+
+	if _, err := os.Stat(cmdPath); os.IsNotExist(err) {
+		if envMode == env_utils.EnvModeDevelopment {
+			logger.Warn(
+				"InfluxDB CLI not found. InfluxDB support will be disabled. Read ./tools/readme.md for details",
+				"path",
+				cmdPath,
+			)
+		} else {
+			logger.Warn(
+				"InfluxDB CLI not found. InfluxDB support will be disabled.",
+				"path", cmdPath,
+			)
+		}
+		return
+	}
+
+	if isShowLogs {
+		logger.Info("InfluxDB CLI verification completed!")
+	}
+}
+
+func getInfluxdbBasePath(
+	envMode env_utils.EnvMode,
+	influxdbInstallDir string,
+) string {
+	if envMode == env_utils.EnvModeDevelopment {
+		return filepath.Join(influxdbInstallDir, "bin")
+	}
+	// Production: single CLI version in /usr/local/influxdb-client/bin
+	return "/usr/local/influxdb-client/bin"
+}
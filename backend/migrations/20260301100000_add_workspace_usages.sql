@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE workspace_usages (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id      UUID NOT NULL,
+    period_start      TIMESTAMPTZ NOT NULL,
+    bytes_transferred BIGINT NOT NULL DEFAULT 0,
+    bytes_stored      BIGINT NOT NULL DEFAULT 0,
+    jobs_count        BIGINT NOT NULL DEFAULT 0,
+    node_minutes      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE workspace_usages
+    ADD CONSTRAINT fk_workspace_usages_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE workspace_usages
+    ADD CONSTRAINT uq_workspace_usages_workspace_period
+    UNIQUE (workspace_id, period_start);
+
+CREATE INDEX idx_workspace_usages_period_start ON workspace_usages (period_start);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_workspace_usages_period_start;
+DROP TABLE IF EXISTS workspace_usages;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE workspace_subscriptions (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id           UUID NOT NULL,
+    plan_tier              TEXT NOT NULL,
+    status                 TEXT NOT NULL,
+    stripe_customer_id     TEXT NOT NULL,
+    stripe_subscription_id TEXT NOT NULL,
+    current_period_end     TIMESTAMPTZ,
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE workspace_subscriptions
+    ADD CONSTRAINT fk_workspace_subscriptions_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE workspace_subscriptions
+    ADD CONSTRAINT uq_workspace_subscriptions_workspace_id
+    UNIQUE (workspace_id);
+
+CREATE INDEX idx_workspace_subscriptions_stripe_subscription_id
+    ON workspace_subscriptions (stripe_subscription_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_workspace_subscriptions_stripe_subscription_id;
+DROP TABLE IF EXISTS workspace_subscriptions;
+
+-- +goose StatementEnd
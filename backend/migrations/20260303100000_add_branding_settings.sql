@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE branding_settings (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_name  TEXT NOT NULL DEFAULT 'Databasus',
+    logo_url      TEXT NOT NULL DEFAULT '',
+    accent_color  TEXT NOT NULL DEFAULT '#0d6efd',
+    support_email TEXT NOT NULL DEFAULT ''
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS branding_settings;
+
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE users
+    ADD COLUMN locale TEXT NOT NULL DEFAULT 'en';
+
+ALTER TABLE notifiers
+    ADD COLUMN locale TEXT NOT NULL DEFAULT 'en';
+
+CREATE TABLE message_template_overrides (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    locale      TEXT NOT NULL,
+    message_key TEXT NOT NULL,
+    template    TEXT NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE message_template_overrides
+    ADD CONSTRAINT uq_message_template_overrides_locale_key
+    UNIQUE (locale, message_key);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS message_template_overrides;
+
+ALTER TABLE notifiers
+    DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locale;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE workspace_feature_flags (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    flag         TEXT NOT NULL,
+    is_enabled   BOOLEAN NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE workspace_feature_flags
+    ADD CONSTRAINT fk_workspace_feature_flags_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE workspace_feature_flags
+    ADD CONSTRAINT uq_workspace_feature_flags_workspace_flag
+    UNIQUE (workspace_id, flag);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS workspace_feature_flags;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE plugin_storages (
+    storage_id    UUID PRIMARY KEY,
+    plugin_name   TEXT NOT NULL,
+    config        TEXT NOT NULL DEFAULT '{}',
+    secret_config TEXT NOT NULL DEFAULT '{}'
+);
+
+ALTER TABLE plugin_storages
+    ADD CONSTRAINT fk_plugin_storages_storage
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS plugin_storages;
+
+-- +goose StatementEnd
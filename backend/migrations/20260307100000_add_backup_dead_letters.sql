@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_dead_letters (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    backup_id           UUID,
+    database_id         UUID NOT NULL,
+    fail_message        TEXT NOT NULL,
+    attempts            TEXT NOT NULL DEFAULT '[]',
+    attempts_count      INT NOT NULL DEFAULT 0,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    requeued_at         TIMESTAMPTZ,
+    requeued_by_user_id UUID
+);
+
+ALTER TABLE backup_dead_letters
+    ADD CONSTRAINT fk_backup_dead_letters_backup_id
+    FOREIGN KEY (backup_id)
+    REFERENCES backups (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE backup_dead_letters
+    ADD CONSTRAINT fk_backup_dead_letters_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_dead_letters
+    ADD CONSTRAINT fk_backup_dead_letters_requeued_by_user_id
+    FOREIGN KEY (requeued_by_user_id)
+    REFERENCES users (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE backup_dead_letters
+    ADD CONSTRAINT uq_backup_dead_letters_backup_id
+    UNIQUE (backup_id);
+
+CREATE INDEX idx_backup_dead_letters_database_id_requeued_at
+    ON backup_dead_letters (database_id, requeued_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_backup_dead_letters_database_id_requeued_at;
+DROP TABLE IF EXISTS backup_dead_letters;
+
+-- +goose StatementEnd
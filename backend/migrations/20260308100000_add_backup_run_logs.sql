@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_run_logs (
+    backup_id        UUID PRIMARY KEY,
+    phases           TEXT NOT NULL DEFAULT '[]',
+    tool_output_tail TEXT NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_run_logs
+    ADD CONSTRAINT fk_backup_run_logs_backup_id
+    FOREIGN KEY (backup_id)
+    REFERENCES backups (id)
+    ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS backup_run_logs;
+
+-- +goose StatementEnd
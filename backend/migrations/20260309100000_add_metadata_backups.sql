@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE metadata_backups (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    storage_id   UUID NOT NULL,
+    status       TEXT NOT NULL,
+    size_bytes   BIGINT NOT NULL DEFAULT 0,
+    fail_message TEXT,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+ALTER TABLE metadata_backups
+    ADD CONSTRAINT fk_metadata_backups_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_metadata_backups_status_created_at
+    ON metadata_backups (status, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_metadata_backups_status_created_at;
+DROP TABLE IF EXISTS metadata_backups;
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE agents (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id  UUID NOT NULL,
+    name          TEXT NOT NULL,
+    token_hash    TEXT NOT NULL,
+    version       TEXT,
+    last_seen_at  TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE agents
+    ADD CONSTRAINT fk_agents_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_agents_token_hash ON agents (token_hash);
+CREATE INDEX idx_agents_workspace_id ON agents (workspace_id);
+
+ALTER TABLE databases ADD COLUMN agent_id UUID;
+
+-- Agents in use cannot be removed, otherwise backups would silently switch to direct
+-- connections that cannot reach the database
+ALTER TABLE databases
+    ADD CONSTRAINT fk_databases_agent_id
+    FOREIGN KEY (agent_id)
+    REFERENCES agents (id)
+    ON DELETE RESTRICT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE databases DROP CONSTRAINT IF EXISTS fk_databases_agent_id;
+ALTER TABLE databases DROP COLUMN IF EXISTS agent_id;
+
+DROP TABLE IF EXISTS agents;
+
+-- +goose StatementEnd
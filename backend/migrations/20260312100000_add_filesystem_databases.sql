@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE filesystem_databases (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id       UUID REFERENCES databases(id) ON DELETE CASCADE,
+    path              TEXT NOT NULL,
+    include_globs     TEXT NOT NULL DEFAULT '',
+    exclude_globs     TEXT NOT NULL DEFAULT '',
+    compression       TEXT NOT NULL DEFAULT 'ZSTD',
+    snapshot_mode     TEXT NOT NULL DEFAULT 'NONE',
+    lvm_snapshot_size TEXT NOT NULL DEFAULT ''
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_filesystem_databases_database_id ON filesystem_databases(database_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_filesystem_databases_database_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS filesystem_databases;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE elasticsearch_databases (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id             UUID REFERENCES databases(id) ON DELETE CASCADE,
+    flavor                  TEXT NOT NULL DEFAULT 'ELASTICSEARCH',
+    version                 TEXT NOT NULL DEFAULT '',
+    url                     TEXT NOT NULL,
+    username                TEXT NOT NULL DEFAULT '',
+    password                TEXT NOT NULL DEFAULT '',
+    skip_tls_verify         BOOLEAN NOT NULL DEFAULT FALSE,
+    repository              TEXT NOT NULL,
+    indices                 TEXT NOT NULL DEFAULT '*',
+    is_include_global_state BOOLEAN NOT NULL DEFAULT FALSE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_elasticsearch_databases_database_id ON elasticsearch_databases(database_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_elasticsearch_databases_database_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS elasticsearch_databases;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE influxdb_databases (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id     UUID REFERENCES databases(id) ON DELETE CASCADE,
+    version         TEXT NOT NULL DEFAULT '',
+    url             TEXT NOT NULL,
+    token           TEXT NOT NULL,
+    org             TEXT NOT NULL DEFAULT '',
+    bucket          TEXT NOT NULL DEFAULT '',
+    skip_tls_verify BOOLEAN NOT NULL DEFAULT FALSE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_influxdb_databases_database_id ON influxdb_databases(database_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE postgresql_databases
+    ADD COLUMN timescaledb_version TEXT NOT NULL DEFAULT '',
+    ADD COLUMN timescaledb_chunks_count INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE postgresql_databases
+    DROP COLUMN IF EXISTS timescaledb_chunks_count,
+    DROP COLUMN IF EXISTS timescaledb_version;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_influxdb_databases_database_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS influxdb_databases;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cassandra_databases (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id             UUID REFERENCES databases(id) ON DELETE CASCADE,
+    version                 TEXT NOT NULL DEFAULT '',
+    nodes                   TEXT NOT NULL,
+    ssh_port                INT NOT NULL DEFAULT 22,
+    ssh_username            TEXT NOT NULL,
+    ssh_password            TEXT NOT NULL DEFAULT '',
+    ssh_private_key         TEXT NOT NULL DEFAULT '',
+    is_use_sudo             BOOLEAN NOT NULL DEFAULT FALSE,
+    jmx_username            TEXT NOT NULL DEFAULT '',
+    jmx_password            TEXT NOT NULL DEFAULT '',
+    data_directory          TEXT NOT NULL DEFAULT '/var/lib/cassandra/data',
+    keyspaces               TEXT NOT NULL DEFAULT '',
+    is_allow_partial_backup BOOLEAN NOT NULL DEFAULT FALSE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_cassandra_databases_database_id ON cassandra_databases(database_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_cassandra_databases_database_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cassandra_databases;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cockroachdb_databases (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id UUID REFERENCES databases(id) ON DELETE CASCADE,
+    version     TEXT NOT NULL DEFAULT '',
+    host        TEXT NOT NULL,
+    port        INT NOT NULL DEFAULT 26257,
+    username    TEXT NOT NULL,
+    password    TEXT NOT NULL DEFAULT '',
+    is_https    BOOLEAN NOT NULL DEFAULT FALSE,
+    database    TEXT NOT NULL DEFAULT ''
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_cockroachdb_databases_database_id ON cockroachdb_databases(database_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_cockroachdb_databases_database_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cockroachdb_databases;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE neo4j_databases (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id     UUID REFERENCES databases(id) ON DELETE CASCADE,
+    version         TEXT NOT NULL DEFAULT '',
+    edition         TEXT NOT NULL DEFAULT '',
+    url             TEXT NOT NULL,
+    username        TEXT NOT NULL,
+    password        TEXT NOT NULL,
+    skip_tls_verify BOOLEAN NOT NULL DEFAULT FALSE,
+    database        TEXT NOT NULL DEFAULT 'neo4j',
+    backup_mode     TEXT NOT NULL DEFAULT 'DUMP',
+    ssh_host        TEXT NOT NULL DEFAULT '',
+    ssh_port        INT NOT NULL DEFAULT 22,
+    ssh_username    TEXT NOT NULL,
+    ssh_password    TEXT NOT NULL DEFAULT '',
+    ssh_private_key TEXT NOT NULL DEFAULT '',
+    is_use_sudo     BOOLEAN NOT NULL DEFAULT FALSE,
+    service_name    TEXT NOT NULL DEFAULT 'neo4j'
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idx_neo4j_databases_database_id ON neo4j_databases(database_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_neo4j_databases_database_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS neo4j_databases;
+-- +goose StatementEnd
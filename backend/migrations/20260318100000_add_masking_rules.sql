@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE masking_rules (
+    id          UUID        NOT NULL DEFAULT gen_random_uuid(),
+    database_id UUID        NOT NULL,
+    schema_name TEXT        NOT NULL DEFAULT '',
+    table_name  TEXT        NOT NULL,
+    column_name TEXT        NOT NULL,
+    strategy    TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE masking_rules
+    ADD CONSTRAINT pk_masking_rules
+    PRIMARY KEY (id);
+
+ALTER TABLE masking_rules
+    ADD CONSTRAINT fk_masking_rules_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE masking_rules
+    ADD CONSTRAINT uk_masking_rules_database_column
+    UNIQUE (database_id, schema_name, table_name, column_name);
+
+ALTER TABLE restores
+    ADD COLUMN is_masked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE restores DROP COLUMN IF EXISTS is_masked;
+
+ALTER TABLE masking_rules DROP CONSTRAINT IF EXISTS uk_masking_rules_database_column;
+ALTER TABLE masking_rules DROP CONSTRAINT IF EXISTS fk_masking_rules_database_id;
+ALTER TABLE masking_rules DROP CONSTRAINT IF EXISTS pk_masking_rules;
+
+DROP TABLE IF EXISTS masking_rules;
+
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_share_links (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    backup_id          UUID        NOT NULL,
+    created_by_user_id UUID        NOT NULL,
+    recipient_user_id  UUID        NOT NULL,
+    note               TEXT        NOT NULL DEFAULT '',
+    max_downloads      INT         NOT NULL DEFAULT 0,
+    download_count     INT         NOT NULL DEFAULT 0,
+    last_downloaded_at TIMESTAMPTZ,
+    expires_at         TIMESTAMPTZ NOT NULL,
+    revoked_at         TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_share_links
+    ADD CONSTRAINT fk_backup_share_links_backup_id
+    FOREIGN KEY (backup_id)
+    REFERENCES backups (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_share_links
+    ADD CONSTRAINT fk_backup_share_links_created_by_user_id
+    FOREIGN KEY (created_by_user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_share_links
+    ADD CONSTRAINT fk_backup_share_links_recipient_user_id
+    FOREIGN KEY (recipient_user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE download_tokens
+    ADD COLUMN share_link_id UUID;
+
+ALTER TABLE download_tokens
+    ADD CONSTRAINT fk_download_tokens_share_link_id
+    FOREIGN KEY (share_link_id)
+    REFERENCES backup_share_links (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_backup_share_links_backup_id ON backup_share_links (backup_id);
+CREATE INDEX idx_backup_share_links_recipient_user_id ON backup_share_links (recipient_user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_backup_share_links_recipient_user_id;
+DROP INDEX IF EXISTS idx_backup_share_links_backup_id;
+
+ALTER TABLE download_tokens DROP CONSTRAINT IF EXISTS fk_download_tokens_share_link_id;
+ALTER TABLE download_tokens DROP COLUMN IF EXISTS share_link_id;
+
+ALTER TABLE backup_share_links DROP CONSTRAINT IF EXISTS fk_backup_share_links_recipient_user_id;
+ALTER TABLE backup_share_links DROP CONSTRAINT IF EXISTS fk_backup_share_links_created_by_user_id;
+ALTER TABLE backup_share_links DROP CONSTRAINT IF EXISTS fk_backup_share_links_backup_id;
+
+DROP TABLE IF EXISTS backup_share_links;
+
+-- +goose StatementEnd
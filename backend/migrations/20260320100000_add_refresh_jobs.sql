@@ -0,0 +1,77 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE refresh_jobs (
+    id                    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    database_id           UUID        NOT NULL,
+    name                  TEXT        NOT NULL,
+    is_enabled            BOOLEAN     NOT NULL DEFAULT TRUE,
+    interval_id           UUID        NOT NULL,
+    target                TEXT        NOT NULL,
+    is_apply_masking      BOOLEAN     NOT NULL DEFAULT TRUE,
+    send_notifications_on TEXT        NOT NULL DEFAULT '',
+    last_run_at           TIMESTAMPTZ,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE refresh_runs (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    refresh_job_id UUID        NOT NULL,
+    backup_id      UUID,
+    restore_id     UUID,
+    status         TEXT        NOT NULL,
+    fail_message   TEXT,
+    started_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at    TIMESTAMPTZ
+);
+
+ALTER TABLE refresh_jobs
+    ADD CONSTRAINT fk_refresh_jobs_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE refresh_jobs
+    ADD CONSTRAINT fk_refresh_jobs_interval_id
+    FOREIGN KEY (interval_id)
+    REFERENCES intervals (id);
+
+ALTER TABLE refresh_runs
+    ADD CONSTRAINT fk_refresh_runs_refresh_job_id
+    FOREIGN KEY (refresh_job_id)
+    REFERENCES refresh_jobs (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE refresh_runs
+    ADD CONSTRAINT fk_refresh_runs_backup_id
+    FOREIGN KEY (backup_id)
+    REFERENCES backups (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE refresh_runs
+    ADD CONSTRAINT fk_refresh_runs_restore_id
+    FOREIGN KEY (restore_id)
+    REFERENCES restores (id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_refresh_jobs_database_id ON refresh_jobs (database_id);
+CREATE INDEX idx_refresh_runs_job_started_at ON refresh_runs (refresh_job_id, started_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_refresh_runs_job_started_at;
+DROP INDEX IF EXISTS idx_refresh_jobs_database_id;
+
+ALTER TABLE refresh_runs DROP CONSTRAINT IF EXISTS fk_refresh_runs_restore_id;
+ALTER TABLE refresh_runs DROP CONSTRAINT IF EXISTS fk_refresh_runs_backup_id;
+ALTER TABLE refresh_runs DROP CONSTRAINT IF EXISTS fk_refresh_runs_refresh_job_id;
+ALTER TABLE refresh_jobs DROP CONSTRAINT IF EXISTS fk_refresh_jobs_interval_id;
+ALTER TABLE refresh_jobs DROP CONSTRAINT IF EXISTS fk_refresh_jobs_database_id;
+
+DROP TABLE IF EXISTS refresh_runs;
+DROP TABLE IF EXISTS refresh_jobs;
+
+-- +goose StatementEnd
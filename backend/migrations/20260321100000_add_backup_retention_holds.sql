@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backups ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE backup_configs ADD COLUMN retention_holds TEXT NOT NULL DEFAULT '[]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backup_configs DROP COLUMN IF EXISTS retention_holds;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE backups DROP COLUMN IF EXISTS tags;
+-- +goose StatementEnd
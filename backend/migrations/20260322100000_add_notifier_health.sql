@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notifiers
+    ADD COLUMN health_status TEXT NOT NULL DEFAULT 'UNKNOWN',
+    ADD COLUMN health_check_error TEXT,
+    ADD COLUMN last_health_check_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notifiers
+    DROP COLUMN last_health_check_at,
+    DROP COLUMN health_check_error,
+    DROP COLUMN health_status;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE email_notifiers
+    ADD COLUMN tls_policy TEXT NOT NULL DEFAULT '',
+    ADD COLUMN custom_ca_cert TEXT NOT NULL DEFAULT '',
+    ADD COLUMN min_tls_version TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE email_notifiers
+    DROP COLUMN min_tls_version,
+    DROP COLUMN custom_ca_cert,
+    DROP COLUMN tls_policy;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notifiers ADD COLUMN is_workspace_default BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE databases ADD COLUMN is_default_notifiers_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE databases DROP COLUMN IF EXISTS is_default_notifiers_opt_out;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE notifiers DROP COLUMN IF EXISTS is_workspace_default;
+-- +goose StatementEnd
@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE broadcasts (
+    id                 UUID        NOT NULL DEFAULT gen_random_uuid(),
+    title              TEXT        NOT NULL,
+    message            TEXT        NOT NULL,
+    is_send_emails     BOOLEAN     NOT NULL DEFAULT FALSE,
+    status             TEXT        NOT NULL,
+    created_by_user_id UUID        NOT NULL,
+    workspace_ids      TEXT        NOT NULL DEFAULT '[]',
+    sent_count         INT         NOT NULL DEFAULT 0,
+    failed_count       INT         NOT NULL DEFAULT 0,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at        TIMESTAMPTZ
+);
+
+CREATE TABLE broadcast_deliveries (
+    id           UUID        NOT NULL DEFAULT gen_random_uuid(),
+    broadcast_id UUID        NOT NULL,
+    workspace_id UUID,
+    channel      TEXT        NOT NULL,
+    notifier_id  UUID,
+    recipient    TEXT        NOT NULL,
+    status       TEXT        NOT NULL,
+    error        TEXT,
+    sent_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE broadcasts
+    ADD CONSTRAINT pk_broadcasts
+    PRIMARY KEY (id);
+
+ALTER TABLE broadcast_deliveries
+    ADD CONSTRAINT pk_broadcast_deliveries
+    PRIMARY KEY (id);
+
+ALTER TABLE broadcast_deliveries
+    ADD CONSTRAINT fk_broadcast_deliveries_broadcast_id
+    FOREIGN KEY (broadcast_id)
+    REFERENCES broadcasts (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE broadcast_deliveries
+    ADD CONSTRAINT fk_broadcast_deliveries_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE broadcast_deliveries
+    ADD CONSTRAINT fk_broadcast_deliveries_notifier_id
+    FOREIGN KEY (notifier_id)
+    REFERENCES notifiers (id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_broadcasts_created_at ON broadcasts (created_at DESC);
+CREATE INDEX idx_broadcast_deliveries_broadcast_id ON broadcast_deliveries (broadcast_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_broadcast_deliveries_broadcast_id;
+DROP INDEX IF EXISTS idx_broadcasts_created_at;
+
+ALTER TABLE broadcast_deliveries DROP CONSTRAINT IF EXISTS fk_broadcast_deliveries_notifier_id;
+ALTER TABLE broadcast_deliveries DROP CONSTRAINT IF EXISTS fk_broadcast_deliveries_workspace_id;
+ALTER TABLE broadcast_deliveries DROP CONSTRAINT IF EXISTS fk_broadcast_deliveries_broadcast_id;
+ALTER TABLE broadcast_deliveries DROP CONSTRAINT IF EXISTS pk_broadcast_deliveries;
+ALTER TABLE broadcasts DROP CONSTRAINT IF EXISTS pk_broadcasts;
+
+DROP TABLE IF EXISTS broadcast_deliveries;
+DROP TABLE IF EXISTS broadcasts;
+
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pagerduty_notifiers (
+    notifier_id    UUID PRIMARY KEY,
+    routing_key    TEXT NOT NULL,
+    webhook_secret TEXT
+);
+
+ALTER TABLE pagerduty_notifiers
+    ADD CONSTRAINT fk_pagerduty_notifiers_notifier
+    FOREIGN KEY (notifier_id)
+    REFERENCES notifiers (id)
+    ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;
+
+CREATE TABLE opsgenie_notifiers (
+    notifier_id    UUID PRIMARY KEY,
+    api_key        TEXT NOT NULL,
+    region         TEXT NOT NULL DEFAULT 'US',
+    webhook_secret TEXT
+);
+
+ALTER TABLE opsgenie_notifiers
+    ADD CONSTRAINT fk_opsgenie_notifiers_notifier
+    FOREIGN KEY (notifier_id)
+    REFERENCES notifiers (id)
+    ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;
+
+CREATE TABLE notifier_incidents (
+    id                UUID PRIMARY KEY,
+    notifier_id       UUID NOT NULL,
+    dedup_key         TEXT NOT NULL,
+    title             TEXT NOT NULL,
+    status            TEXT NOT NULL,
+    trigger_count     INT NOT NULL,
+    last_triggered_at TIMESTAMPTZ NOT NULL,
+    acknowledged_by   TEXT,
+    acknowledged_at   TIMESTAMPTZ,
+    resolved_at       TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE notifier_incidents
+    ADD CONSTRAINT fk_notifier_incidents_notifier
+    FOREIGN KEY (notifier_id)
+    REFERENCES notifiers (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_notifier_incidents_notifier_id_dedup_key
+    ON notifier_incidents (notifier_id, dedup_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_notifier_incidents_notifier_id_dedup_key;
+DROP TABLE IF EXISTS notifier_incidents;
+DROP TABLE IF EXISTS opsgenie_notifiers;
+DROP TABLE IF EXISTS pagerduty_notifiers;
+-- +goose StatementEnd
@@ -0,0 +1,86 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE ticket_integrations (
+    id                           UUID        NOT NULL DEFAULT gen_random_uuid(),
+    workspace_id                 UUID        NOT NULL,
+    provider                     TEXT        NOT NULL,
+    is_enabled                   BOOLEAN     NOT NULL DEFAULT TRUE,
+    failures_threshold           INT         NOT NULL,
+    window_hours                 INT         NOT NULL,
+    summary_template             TEXT        NOT NULL,
+    description_template         TEXT        NOT NULL,
+    jira_url                     TEXT,
+    jira_email                   TEXT,
+    jira_api_token               TEXT,
+    jira_project_key             TEXT,
+    jira_issue_type              TEXT,
+    service_now_url              TEXT,
+    service_now_username         TEXT,
+    service_now_password         TEXT,
+    service_now_assignment_group TEXT,
+    created_at                   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE backup_failure_tickets (
+    id             UUID        NOT NULL DEFAULT gen_random_uuid(),
+    integration_id UUID        NOT NULL,
+    database_id    UUID        NOT NULL,
+    external_key   TEXT        NOT NULL,
+    external_url   TEXT        NOT NULL,
+    failures_count INT         NOT NULL,
+    last_backup_id UUID        NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE ticket_integrations
+    ADD CONSTRAINT pk_ticket_integrations
+    PRIMARY KEY (id);
+
+ALTER TABLE ticket_integrations
+    ADD CONSTRAINT fk_ticket_integrations_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE ticket_integrations
+    ADD CONSTRAINT uk_ticket_integrations_workspace_id
+    UNIQUE (workspace_id);
+
+ALTER TABLE backup_failure_tickets
+    ADD CONSTRAINT pk_backup_failure_tickets
+    PRIMARY KEY (id);
+
+ALTER TABLE backup_failure_tickets
+    ADD CONSTRAINT fk_backup_failure_tickets_integration_id
+    FOREIGN KEY (integration_id)
+    REFERENCES ticket_integrations (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_failure_tickets
+    ADD CONSTRAINT fk_backup_failure_tickets_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_backup_failure_tickets_integration_id_database_id
+    ON backup_failure_tickets (integration_id, database_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_backup_failure_tickets_integration_id_database_id;
+
+ALTER TABLE backup_failure_tickets DROP CONSTRAINT IF EXISTS fk_backup_failure_tickets_database_id;
+ALTER TABLE backup_failure_tickets DROP CONSTRAINT IF EXISTS fk_backup_failure_tickets_integration_id;
+ALTER TABLE backup_failure_tickets DROP CONSTRAINT IF EXISTS pk_backup_failure_tickets;
+ALTER TABLE ticket_integrations DROP CONSTRAINT IF EXISTS uk_ticket_integrations_workspace_id;
+ALTER TABLE ticket_integrations DROP CONSTRAINT IF EXISTS fk_ticket_integrations_workspace_id;
+ALTER TABLE ticket_integrations DROP CONSTRAINT IF EXISTS pk_ticket_integrations;
+
+DROP TABLE IF EXISTS backup_failure_tickets;
+DROP TABLE IF EXISTS ticket_integrations;
+
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE status_pages (
+    id               UUID        NOT NULL DEFAULT gen_random_uuid(),
+    workspace_id     UUID        NOT NULL,
+    title            TEXT        NOT NULL,
+    is_anonymized    BOOLEAN     NOT NULL DEFAULT FALSE,
+    database_labels  TEXT        NOT NULL DEFAULT '{}',
+    token_hash       TEXT        NOT NULL,
+    token_rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE status_pages
+    ADD CONSTRAINT pk_status_pages
+    PRIMARY KEY (id);
+
+ALTER TABLE status_pages
+    ADD CONSTRAINT fk_status_pages_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE status_pages
+    ADD CONSTRAINT uk_status_pages_workspace_id
+    UNIQUE (workspace_id);
+
+ALTER TABLE status_pages
+    ADD CONSTRAINT uk_status_pages_token_hash
+    UNIQUE (token_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE status_pages DROP CONSTRAINT IF EXISTS uk_status_pages_token_hash;
+ALTER TABLE status_pages DROP CONSTRAINT IF EXISTS uk_status_pages_workspace_id;
+ALTER TABLE status_pages DROP CONSTRAINT IF EXISTS fk_status_pages_workspace_id;
+ALTER TABLE status_pages DROP CONSTRAINT IF EXISTS pk_status_pages;
+
+DROP TABLE IF EXISTS status_pages;
+
+-- +goose StatementEnd
@@ -0,0 +1,80 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE grafana_integrations (
+    id            UUID        NOT NULL DEFAULT gen_random_uuid(),
+    workspace_id  UUID        NOT NULL,
+    is_enabled    BOOLEAN     NOT NULL DEFAULT TRUE,
+    url           TEXT        NOT NULL,
+    api_token     TEXT        NOT NULL,
+    dashboard_uid TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE grafana_backup_annotations (
+    id                    UUID        NOT NULL DEFAULT gen_random_uuid(),
+    integration_id        UUID        NOT NULL,
+    backup_id             UUID        NOT NULL,
+    grafana_annotation_id BIGINT      NOT NULL,
+    is_finished           BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE grafana_integrations
+    ADD CONSTRAINT pk_grafana_integrations
+    PRIMARY KEY (id);
+
+ALTER TABLE grafana_integrations
+    ADD CONSTRAINT fk_grafana_integrations_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE grafana_integrations
+    ADD CONSTRAINT uk_grafana_integrations_workspace_id
+    UNIQUE (workspace_id);
+
+ALTER TABLE grafana_backup_annotations
+    ADD CONSTRAINT pk_grafana_backup_annotations
+    PRIMARY KEY (id);
+
+ALTER TABLE grafana_backup_annotations
+    ADD CONSTRAINT fk_grafana_backup_annotations_integration_id
+    FOREIGN KEY (integration_id)
+    REFERENCES grafana_integrations (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE grafana_backup_annotations
+    ADD CONSTRAINT fk_grafana_backup_annotations_backup_id
+    FOREIGN KEY (backup_id)
+    REFERENCES backups (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE grafana_backup_annotations
+    ADD CONSTRAINT uk_grafana_backup_annotations_integration_id_backup_id
+    UNIQUE (integration_id, backup_id);
+
+CREATE INDEX idx_grafana_backup_annotations_backup_id ON grafana_backup_annotations (backup_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_grafana_backup_annotations_backup_id;
+
+ALTER TABLE grafana_backup_annotations
+    DROP CONSTRAINT IF EXISTS uk_grafana_backup_annotations_integration_id_backup_id;
+ALTER TABLE grafana_backup_annotations
+    DROP CONSTRAINT IF EXISTS fk_grafana_backup_annotations_backup_id;
+ALTER TABLE grafana_backup_annotations
+    DROP CONSTRAINT IF EXISTS fk_grafana_backup_annotations_integration_id;
+ALTER TABLE grafana_backup_annotations DROP CONSTRAINT IF EXISTS pk_grafana_backup_annotations;
+ALTER TABLE grafana_integrations DROP CONSTRAINT IF EXISTS uk_grafana_integrations_workspace_id;
+ALTER TABLE grafana_integrations DROP CONSTRAINT IF EXISTS fk_grafana_integrations_workspace_id;
+ALTER TABLE grafana_integrations DROP CONSTRAINT IF EXISTS pk_grafana_integrations;
+
+DROP TABLE IF EXISTS grafana_backup_annotations;
+DROP TABLE IF EXISTS grafana_integrations;
+
+-- +goose StatementEnd
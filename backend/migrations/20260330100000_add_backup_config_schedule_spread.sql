@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backup_configs ADD COLUMN schedule_spread_minutes INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backup_configs DROP COLUMN IF EXISTS schedule_spread_minutes;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_concurrency_groups (
+    id                     UUID        NOT NULL DEFAULT gen_random_uuid(),
+    workspace_id           UUID        NOT NULL,
+    name                   TEXT        NOT NULL,
+    max_concurrent_backups INT         NOT NULL,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_concurrency_groups
+    ADD CONSTRAINT pk_backup_concurrency_groups
+    PRIMARY KEY (id);
+
+ALTER TABLE backup_concurrency_groups
+    ADD CONSTRAINT fk_backup_concurrency_groups_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_configs
+    ADD COLUMN concurrency_group_id UUID;
+
+ALTER TABLE backup_configs
+    ADD CONSTRAINT fk_backup_configs_concurrency_group_id
+    FOREIGN KEY (concurrency_group_id)
+    REFERENCES backup_concurrency_groups (id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_backup_concurrency_groups_workspace_id
+    ON backup_concurrency_groups (workspace_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_backup_concurrency_groups_workspace_id;
+
+ALTER TABLE backup_configs DROP CONSTRAINT IF EXISTS fk_backup_configs_concurrency_group_id;
+ALTER TABLE backup_configs DROP COLUMN IF EXISTS concurrency_group_id;
+
+ALTER TABLE backup_concurrency_groups
+    DROP CONSTRAINT IF EXISTS fk_backup_concurrency_groups_workspace_id;
+ALTER TABLE backup_concurrency_groups DROP CONSTRAINT IF EXISTS pk_backup_concurrency_groups;
+
+DROP TABLE IF EXISTS backup_concurrency_groups;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backup_configs
+    ADD COLUMN backup_timeout_minutes INT NOT NULL DEFAULT 0,
+    ADD COLUMN restore_timeout_minutes INT NOT NULL DEFAULT 0,
+    ADD COLUMN hang_timeout_minutes INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS backup_timeout_minutes,
+    DROP COLUMN IF EXISTS restore_timeout_minutes,
+    DROP COLUMN IF EXISTS hang_timeout_minutes;
+-- +goose StatementEnd
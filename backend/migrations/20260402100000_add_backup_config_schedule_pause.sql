@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backup_configs
+    ADD COLUMN is_schedule_paused BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN schedule_paused_until TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS is_schedule_paused,
+    DROP COLUMN IF EXISTS schedule_paused_until;
+-- +goose StatementEnd
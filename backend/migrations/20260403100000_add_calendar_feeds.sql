@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE calendar_feeds (
+    id               UUID        NOT NULL DEFAULT gen_random_uuid(),
+    workspace_id     UUID        NOT NULL,
+    token_hash       TEXT        NOT NULL,
+    token_rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE calendar_feeds
+    ADD CONSTRAINT pk_calendar_feeds
+    PRIMARY KEY (id);
+
+ALTER TABLE calendar_feeds
+    ADD CONSTRAINT fk_calendar_feeds_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE calendar_feeds
+    ADD CONSTRAINT uk_calendar_feeds_workspace_id
+    UNIQUE (workspace_id);
+
+ALTER TABLE calendar_feeds
+    ADD CONSTRAINT uk_calendar_feeds_token_hash
+    UNIQUE (token_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE calendar_feeds DROP CONSTRAINT IF EXISTS uk_calendar_feeds_token_hash;
+ALTER TABLE calendar_feeds DROP CONSTRAINT IF EXISTS uk_calendar_feeds_workspace_id;
+ALTER TABLE calendar_feeds DROP CONSTRAINT IF EXISTS fk_calendar_feeds_workspace_id;
+ALTER TABLE calendar_feeds DROP CONSTRAINT IF EXISTS pk_calendar_feeds;
+
+DROP TABLE IF EXISTS calendar_feeds;
+
+-- +goose StatementEnd
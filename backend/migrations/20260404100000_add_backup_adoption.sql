@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backups
+    ADD COLUMN checksum TEXT,
+    ADD COLUMN adopted_from_file TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backups
+    DROP COLUMN IF EXISTS checksum,
+    DROP COLUMN IF EXISTS adopted_from_file;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workspaces
+    ADD COLUMN environment TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE databases
+    ADD COLUMN environment TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE databases
+    DROP COLUMN IF EXISTS environment;
+
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS environment;
+-- +goose StatementEnd
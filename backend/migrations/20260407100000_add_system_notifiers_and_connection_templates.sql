@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notifiers
+    ADD COLUMN is_system BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE connection_templates (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    type        TEXT NOT NULL,
+    connection  TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS connection_templates;
+
+ALTER TABLE notifiers
+    DROP COLUMN IF EXISTS is_system;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE workspace_default_storages (
+    workspace_id UUID NOT NULL,
+    storage_id   UUID NOT NULL
+);
+
+ALTER TABLE workspace_default_storages
+    ADD CONSTRAINT pk_workspace_default_storages
+    PRIMARY KEY (workspace_id);
+
+ALTER TABLE workspace_default_storages
+    ADD CONSTRAINT fk_workspace_default_storages_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE workspace_default_storages
+    ADD CONSTRAINT fk_workspace_default_storages_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_workspace_default_storages_storage_id
+    ON workspace_default_storages (storage_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_workspace_default_storages_storage_id;
+
+ALTER TABLE workspace_default_storages
+    DROP CONSTRAINT IF EXISTS fk_workspace_default_storages_storage_id;
+ALTER TABLE workspace_default_storages
+    DROP CONSTRAINT IF EXISTS fk_workspace_default_storages_workspace_id;
+ALTER TABLE workspace_default_storages DROP CONSTRAINT IF EXISTS pk_workspace_default_storages;
+
+DROP TABLE IF EXISTS workspace_default_storages;
+
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE database_transfers (
+    id                     UUID        NOT NULL DEFAULT gen_random_uuid(),
+    database_id            UUID        NOT NULL,
+    source_workspace_id    UUID        NOT NULL,
+    target_workspace_id    UUID        NOT NULL,
+    source_storage_id      UUID,
+    target_storage_id      UUID,
+    transferred_by_user_id UUID        NOT NULL,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE database_transfers
+    ADD CONSTRAINT pk_database_transfers
+    PRIMARY KEY (id);
+
+ALTER TABLE database_transfers
+    ADD CONSTRAINT fk_database_transfers_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_database_transfers_database_id
+    ON database_transfers (database_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_database_transfers_database_id;
+
+ALTER TABLE database_transfers DROP CONSTRAINT IF EXISTS fk_database_transfers_database_id;
+ALTER TABLE database_transfers DROP CONSTRAINT IF EXISTS pk_database_transfers;
+
+DROP TABLE IF EXISTS database_transfers;
+
+-- +goose StatementEnd
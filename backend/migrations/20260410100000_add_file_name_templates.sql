@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE storages
+    ADD COLUMN file_name_template TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE backup_configs
+    ADD COLUMN file_name_template TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE backups
+    ADD COLUMN is_manual BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN file_name TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backups
+    DROP COLUMN IF EXISTS file_name,
+    DROP COLUMN IF EXISTS is_manual;
+
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS file_name_template;
+
+ALTER TABLE storages
+    DROP COLUMN IF EXISTS file_name_template;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE databases
+    ADD COLUMN credentials_expire_at TIMESTAMPTZ;
+
+ALTER TABLE storages
+    ADD COLUMN credentials_expire_at TIMESTAMPTZ;
+
+CREATE TABLE credential_reminders (
+    resource_id   UUID PRIMARY KEY,
+    expire_at     TIMESTAMPTZ NOT NULL,
+    reminder_days INT NOT NULL,
+    sent_at       TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS credential_reminders;
+
+ALTER TABLE storages
+    DROP COLUMN IF EXISTS credentials_expire_at;
+
+ALTER TABLE databases
+    DROP COLUMN IF EXISTS credentials_expire_at;
+-- +goose StatementEnd
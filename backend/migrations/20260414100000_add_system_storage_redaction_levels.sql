@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users_settings
+    ADD COLUMN system_storage_redaction_levels TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users_settings
+    DROP COLUMN IF EXISTS system_storage_redaction_levels;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE resource_comments (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    resource_type TEXT        NOT NULL,
+    resource_id   UUID        NOT NULL,
+    workspace_id  UUID        NOT NULL,
+    author_id     UUID        NOT NULL,
+    text          TEXT        NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE resource_comments
+    ADD CONSTRAINT fk_resource_comments_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE resource_comments
+    ADD CONSTRAINT fk_resource_comments_author_id
+    FOREIGN KEY (author_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_resource_comments_resource
+    ON resource_comments (resource_type, resource_id, created_at);
+
+ALTER TABLE audit_logs
+    ADD COLUMN change_reason TEXT;
+
+ALTER TABLE workspaces
+    ADD COLUMN is_change_reason_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS is_change_reason_required;
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS change_reason;
+
+DROP INDEX IF EXISTS idx_resource_comments_resource;
+
+ALTER TABLE resource_comments DROP CONSTRAINT IF EXISTS fk_resource_comments_author_id;
+ALTER TABLE resource_comments DROP CONSTRAINT IF EXISTS fk_resource_comments_workspace_id;
+
+DROP TABLE IF EXISTS resource_comments;
+
+-- +goose StatementEnd
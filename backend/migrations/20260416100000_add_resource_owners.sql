@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE databases
+    ADD COLUMN owner_user_id UUID,
+    ADD COLUMN owner_team    TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE storages
+    ADD COLUMN owner_user_id UUID,
+    ADD COLUMN owner_team    TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE storages
+    DROP COLUMN IF EXISTS owner_team,
+    DROP COLUMN IF EXISTS owner_user_id;
+
+ALTER TABLE databases
+    DROP COLUMN IF EXISTS owner_team,
+    DROP COLUMN IF EXISTS owner_user_id;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE saved_views (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       UUID        NOT NULL,
+    workspace_id  UUID        NOT NULL,
+    name          TEXT        NOT NULL,
+    resource_type TEXT        NOT NULL,
+    filters       JSONB,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_favorites (
+    user_id       UUID        NOT NULL,
+    workspace_id  UUID        NOT NULL,
+    resource_id   UUID        NOT NULL,
+    resource_type TEXT        NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, workspace_id, resource_id)
+);
+
+ALTER TABLE saved_views
+    ADD CONSTRAINT fk_saved_views_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE saved_views
+    ADD CONSTRAINT fk_saved_views_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE user_favorites
+    ADD CONSTRAINT fk_user_favorites_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE user_favorites
+    ADD CONSTRAINT fk_user_favorites_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_saved_views_user_workspace ON saved_views (user_id, workspace_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_saved_views_user_workspace;
+
+ALTER TABLE user_favorites DROP CONSTRAINT IF EXISTS fk_user_favorites_workspace_id;
+ALTER TABLE user_favorites DROP CONSTRAINT IF EXISTS fk_user_favorites_user_id;
+ALTER TABLE saved_views DROP CONSTRAINT IF EXISTS fk_saved_views_workspace_id;
+ALTER TABLE saved_views DROP CONSTRAINT IF EXISTS fk_saved_views_user_id;
+
+DROP TABLE IF EXISTS user_favorites;
+DROP TABLE IF EXISTS saved_views;
+
+-- +goose StatementEnd
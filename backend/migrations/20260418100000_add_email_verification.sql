@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE users
+    ADD COLUMN is_email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN pending_email     TEXT;
+
+-- emails of OAuth accounts are confirmed by the provider
+UPDATE users
+SET is_email_verified = TRUE
+WHERE github_oauth_id IS NOT NULL OR google_oauth_id IS NOT NULL;
+
+CREATE TABLE email_verification_tokens (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL,
+    email      TEXT        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    is_used    BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE email_verification_tokens
+    ADD CONSTRAINT fk_email_verification_tokens_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_email_verification_tokens_user_id
+    ON email_verification_tokens (user_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_id;
+
+ALTER TABLE email_verification_tokens
+    DROP CONSTRAINT IF EXISTS fk_email_verification_tokens_user_id;
+
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS pending_email,
+    DROP COLUMN IF EXISTS is_email_verified;
+
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users_settings
+    ADD COLUMN security_policy JSONB NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users_settings
+    DROP COLUMN IF EXISTS security_policy;
+-- +goose StatementEnd
@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE security_event_settings (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    notifier_id             UUID,
+    event_types             TEXT    NOT NULL DEFAULT '[]',
+    is_email_affected_users BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE user_known_devices (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID        NOT NULL,
+    device_hash     TEXT        NOT NULL,
+    user_agent      TEXT        NOT NULL,
+    last_ip_address TEXT        NOT NULL,
+    first_seen_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE sign_in_failure_streaks (
+    user_id        UUID PRIMARY KEY,
+    failed_count   INT         NOT NULL,
+    last_failed_at TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE security_event_settings
+    ADD CONSTRAINT fk_security_event_settings_notifier_id
+    FOREIGN KEY (notifier_id)
+    REFERENCES notifiers (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE user_known_devices
+    ADD CONSTRAINT fk_user_known_devices_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE user_known_devices
+    ADD CONSTRAINT uk_user_known_devices_user_device_hash
+    UNIQUE (user_id, device_hash);
+
+ALTER TABLE sign_in_failure_streaks
+    ADD CONSTRAINT fk_sign_in_failure_streaks_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE sign_in_failure_streaks DROP CONSTRAINT IF EXISTS fk_sign_in_failure_streaks_user_id;
+ALTER TABLE user_known_devices DROP CONSTRAINT IF EXISTS uk_user_known_devices_user_device_hash;
+ALTER TABLE user_known_devices DROP CONSTRAINT IF EXISTS fk_user_known_devices_user_id;
+ALTER TABLE security_event_settings DROP CONSTRAINT IF EXISTS fk_security_event_settings_notifier_id;
+
+DROP TABLE IF EXISTS sign_in_failure_streaks;
+DROP TABLE IF EXISTS user_known_devices;
+DROP TABLE IF EXISTS security_event_settings;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE audit_logs
+    ADD COLUMN read_resource_id UUID,
+    ADD COLUMN read_count       INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_audit_logs_read_resource_id_user_id_created_at
+    ON audit_logs (read_resource_id, user_id, created_at)
+    WHERE read_resource_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_logs_read_resource_id_user_id_created_at;
+
+ALTER TABLE audit_logs
+    DROP COLUMN read_count,
+    DROP COLUMN read_resource_id;
+-- +goose StatementEnd
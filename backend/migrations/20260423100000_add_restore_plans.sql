@@ -0,0 +1,88 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE restore_plans (
+    id                    UUID        NOT NULL DEFAULT gen_random_uuid(),
+    workspace_id          UUID        NOT NULL,
+    name                  TEXT        NOT NULL,
+    status                TEXT        NOT NULL,
+    max_parallel_restores INT         NOT NULL DEFAULT 0,
+    created_by_user_id    UUID        NOT NULL,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at           TIMESTAMPTZ
+);
+
+CREATE TABLE restore_plan_steps (
+    id           UUID NOT NULL DEFAULT gen_random_uuid(),
+    plan_id      UUID NOT NULL,
+    position     INT  NOT NULL,
+    key          TEXT NOT NULL,
+    backup_id    UUID NOT NULL,
+    target       TEXT NOT NULL,
+    depends_on   TEXT NOT NULL DEFAULT '',
+    status       TEXT NOT NULL,
+    restore_id   UUID,
+    fail_message TEXT,
+    started_at   TIMESTAMPTZ,
+    finished_at  TIMESTAMPTZ
+);
+
+ALTER TABLE restore_plans
+    ADD CONSTRAINT pk_restore_plans
+    PRIMARY KEY (id);
+
+ALTER TABLE restore_plans
+    ADD CONSTRAINT fk_restore_plans_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE restore_plans
+    ADD CONSTRAINT fk_restore_plans_created_by_user_id
+    FOREIGN KEY (created_by_user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE restore_plan_steps
+    ADD CONSTRAINT pk_restore_plan_steps
+    PRIMARY KEY (id);
+
+ALTER TABLE restore_plan_steps
+    ADD CONSTRAINT fk_restore_plan_steps_plan_id
+    FOREIGN KEY (plan_id)
+    REFERENCES restore_plans (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE restore_plan_steps
+    ADD CONSTRAINT fk_restore_plan_steps_restore_id
+    FOREIGN KEY (restore_id)
+    REFERENCES restores (id)
+    ON DELETE SET NULL;
+
+ALTER TABLE restore_plan_steps
+    ADD CONSTRAINT uk_restore_plan_steps_plan_id_key
+    UNIQUE (plan_id, key);
+
+CREATE INDEX idx_restore_plans_workspace_created_at ON restore_plans (workspace_id, created_at DESC);
+CREATE INDEX idx_restore_plan_steps_status ON restore_plan_steps (status);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_restore_plan_steps_status;
+DROP INDEX IF EXISTS idx_restore_plans_workspace_created_at;
+
+ALTER TABLE restore_plan_steps DROP CONSTRAINT IF EXISTS uk_restore_plan_steps_plan_id_key;
+ALTER TABLE restore_plan_steps DROP CONSTRAINT IF EXISTS fk_restore_plan_steps_restore_id;
+ALTER TABLE restore_plan_steps DROP CONSTRAINT IF EXISTS fk_restore_plan_steps_plan_id;
+ALTER TABLE restore_plan_steps DROP CONSTRAINT IF EXISTS pk_restore_plan_steps;
+ALTER TABLE restore_plans DROP CONSTRAINT IF EXISTS fk_restore_plans_created_by_user_id;
+ALTER TABLE restore_plans DROP CONSTRAINT IF EXISTS fk_restore_plans_workspace_id;
+ALTER TABLE restore_plans DROP CONSTRAINT IF EXISTS pk_restore_plans;
+
+DROP TABLE IF EXISTS restore_plan_steps;
+DROP TABLE IF EXISTS restore_plans;
+
+-- +goose StatementEnd
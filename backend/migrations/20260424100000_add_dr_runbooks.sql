@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE dr_runbooks (
+    workspace_id   UUID        NOT NULL,
+    workspace_name TEXT        NOT NULL,
+    databases      TEXT        NOT NULL DEFAULT '[]',
+    generated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE dr_runbooks
+    ADD CONSTRAINT pk_dr_runbooks
+    PRIMARY KEY (workspace_id);
+
+ALTER TABLE dr_runbooks
+    ADD CONSTRAINT fk_dr_runbooks_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE dr_runbooks DROP CONSTRAINT IF EXISTS fk_dr_runbooks_workspace_id;
+ALTER TABLE dr_runbooks DROP CONSTRAINT IF EXISTS pk_dr_runbooks;
+
+DROP TABLE IF EXISTS dr_runbooks;
+
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_manifest_exports (
+    storage_id   UUID        NOT NULL,
+    content_hash TEXT        NOT NULL,
+    exported_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_manifest_exports
+    ADD CONSTRAINT pk_backup_manifest_exports
+    PRIMARY KEY (storage_id);
+
+ALTER TABLE backup_manifest_exports
+    ADD CONSTRAINT fk_backup_manifest_exports_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE backup_manifest_exports DROP CONSTRAINT IF EXISTS fk_backup_manifest_exports_storage_id;
+ALTER TABLE backup_manifest_exports DROP CONSTRAINT IF EXISTS pk_backup_manifest_exports;
+
+DROP TABLE IF EXISTS backup_manifest_exports;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE gcs_storages (
+    storage_id          UUID PRIMARY KEY,
+    auth_method         TEXT NOT NULL,
+    service_account_key TEXT,
+    bucket              TEXT NOT NULL,
+    prefix              TEXT,
+    storage_class       TEXT
+);
+
+ALTER TABLE gcs_storages
+    ADD CONSTRAINT fk_gcs_storages_storage
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS gcs_storages;
+
+-- +goose StatementEnd
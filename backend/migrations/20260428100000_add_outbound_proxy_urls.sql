@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE s3_storages
+    ADD COLUMN proxy_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE azure_blob_storages
+    ADD COLUMN proxy_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE webhook_notifiers
+    ADD COLUMN proxy_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE slack_notifiers
+    ADD COLUMN proxy_url TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE email_notifiers
+    ADD COLUMN proxy_url TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE email_notifiers
+    DROP COLUMN IF EXISTS proxy_url;
+
+ALTER TABLE slack_notifiers
+    DROP COLUMN IF EXISTS proxy_url;
+
+ALTER TABLE webhook_notifiers
+    DROP COLUMN IF EXISTS proxy_url;
+
+ALTER TABLE azure_blob_storages
+    DROP COLUMN IF EXISTS proxy_url;
+
+ALTER TABLE s3_storages
+    DROP COLUMN IF EXISTS proxy_url;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE s3_storages
+    ADD COLUMN ip_preference TEXT NOT NULL DEFAULT 'AUTO';
+
+ALTER TABLE ftp_storages
+    ADD COLUMN ip_preference TEXT NOT NULL DEFAULT 'AUTO';
+
+ALTER TABLE sftp_storages
+    ADD COLUMN ip_preference TEXT NOT NULL DEFAULT 'AUTO';
+
+ALTER TABLE nas_storages
+    ADD COLUMN ip_preference TEXT NOT NULL DEFAULT 'AUTO';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE nas_storages
+    DROP COLUMN IF EXISTS ip_preference;
+
+ALTER TABLE sftp_storages
+    DROP COLUMN IF EXISTS ip_preference;
+
+ALTER TABLE ftp_storages
+    DROP COLUMN IF EXISTS ip_preference;
+
+ALTER TABLE s3_storages
+    DROP COLUMN IF EXISTS ip_preference;
+
+-- +goose StatementEnd
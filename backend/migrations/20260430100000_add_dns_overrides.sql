@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE databases
+    ADD COLUMN dns_override TEXT;
+
+ALTER TABLE s3_storages
+    ADD COLUMN dns_override TEXT;
+
+ALTER TABLE ftp_storages
+    ADD COLUMN dns_override TEXT;
+
+ALTER TABLE sftp_storages
+    ADD COLUMN dns_override TEXT;
+
+ALTER TABLE nas_storages
+    ADD COLUMN dns_override TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE nas_storages
+    DROP COLUMN IF EXISTS dns_override;
+
+ALTER TABLE sftp_storages
+    DROP COLUMN IF EXISTS dns_override;
+
+ALTER TABLE ftp_storages
+    DROP COLUMN IF EXISTS dns_override;
+
+ALTER TABLE s3_storages
+    DROP COLUMN IF EXISTS dns_override;
+
+ALTER TABLE databases
+    DROP COLUMN IF EXISTS dns_override;
+
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE client_certificates (
+    id           UUID        NOT NULL DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL,
+    name         TEXT        NOT NULL,
+    fingerprint  TEXT        NOT NULL,
+    subject      TEXT        NOT NULL,
+    not_after    TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ
+);
+
+ALTER TABLE client_certificates
+    ADD CONSTRAINT pk_client_certificates
+    PRIMARY KEY (id);
+
+ALTER TABLE client_certificates
+    ADD CONSTRAINT fk_client_certificates_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE client_certificates
+    ADD CONSTRAINT uk_client_certificates_fingerprint
+    UNIQUE (fingerprint);
+
+CREATE INDEX idx_client_certificates_user_id ON client_certificates (user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_client_certificates_user_id;
+
+ALTER TABLE client_certificates DROP CONSTRAINT IF EXISTS uk_client_certificates_fingerprint;
+ALTER TABLE client_certificates DROP CONSTRAINT IF EXISTS fk_client_certificates_user_id;
+ALTER TABLE client_certificates DROP CONSTRAINT IF EXISTS pk_client_certificates;
+
+DROP TABLE IF EXISTS client_certificates;
+
+-- +goose StatementEnd
@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE storage_usages (
+    storage_id    UUID PRIMARY KEY,
+    bytes_used    BIGINT      NOT NULL DEFAULT 0,
+    backups_count BIGINT      NOT NULL DEFAULT 0,
+    measured_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE storage_database_usages (
+    storage_id    UUID   NOT NULL,
+    database_id   UUID   NOT NULL,
+    bytes_used    BIGINT NOT NULL DEFAULT 0,
+    backups_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (storage_id, database_id)
+);
+
+CREATE TABLE storage_quotas (
+    storage_id       UUID PRIMARY KEY,
+    soft_limit_bytes BIGINT      NOT NULL DEFAULT 0,
+    hard_limit_bytes BIGINT      NOT NULL DEFAULT 0,
+    state            TEXT        NOT NULL DEFAULT 'OK',
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE storage_usages
+    ADD CONSTRAINT fk_storage_usages_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE storage_database_usages
+    ADD CONSTRAINT fk_storage_database_usages_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE storage_database_usages
+    ADD CONSTRAINT fk_storage_database_usages_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE storage_quotas
+    ADD CONSTRAINT fk_storage_quotas_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_storage_database_usages_database_id ON storage_database_usages (database_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_storage_database_usages_database_id;
+
+ALTER TABLE storage_quotas DROP CONSTRAINT IF EXISTS fk_storage_quotas_storage_id;
+ALTER TABLE storage_database_usages DROP CONSTRAINT IF EXISTS fk_storage_database_usages_database_id;
+ALTER TABLE storage_database_usages DROP CONSTRAINT IF EXISTS fk_storage_database_usages_storage_id;
+ALTER TABLE storage_usages DROP CONSTRAINT IF EXISTS fk_storage_usages_storage_id;
+
+DROP TABLE IF EXISTS storage_quotas;
+DROP TABLE IF EXISTS storage_database_usages;
+DROP TABLE IF EXISTS storage_usages;
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_triggers (
+    id                UUID        NOT NULL DEFAULT gen_random_uuid(),
+    database_id       UUID        NOT NULL,
+    token_hash        TEXT        NOT NULL,
+    token_rotated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_triggered_at TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_triggers
+    ADD CONSTRAINT pk_backup_triggers
+    PRIMARY KEY (id);
+
+ALTER TABLE backup_triggers
+    ADD CONSTRAINT fk_backup_triggers_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_triggers
+    ADD CONSTRAINT uk_backup_triggers_database_id
+    UNIQUE (database_id);
+
+ALTER TABLE backup_triggers
+    ADD CONSTRAINT uk_backup_triggers_token_hash
+    UNIQUE (token_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE backup_triggers DROP CONSTRAINT IF EXISTS uk_backup_triggers_token_hash;
+ALTER TABLE backup_triggers DROP CONSTRAINT IF EXISTS uk_backup_triggers_database_id;
+ALTER TABLE backup_triggers DROP CONSTRAINT IF EXISTS fk_backup_triggers_database_id;
+ALTER TABLE backup_triggers DROP CONSTRAINT IF EXISTS pk_backup_triggers;
+
+DROP TABLE IF EXISTS backup_triggers;
+
+-- +goose StatementEnd
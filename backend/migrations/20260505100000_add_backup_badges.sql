@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE backup_badges (
+    id               UUID        NOT NULL DEFAULT gen_random_uuid(),
+    database_id      UUID        NOT NULL,
+    token_hash       TEXT        NOT NULL,
+    token_rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_badges
+    ADD CONSTRAINT pk_backup_badges
+    PRIMARY KEY (id);
+
+ALTER TABLE backup_badges
+    ADD CONSTRAINT fk_backup_badges_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_badges
+    ADD CONSTRAINT uk_backup_badges_database_id
+    UNIQUE (database_id);
+
+ALTER TABLE backup_badges
+    ADD CONSTRAINT uk_backup_badges_token_hash
+    UNIQUE (token_hash);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE backup_badges DROP CONSTRAINT IF EXISTS uk_backup_badges_token_hash;
+ALTER TABLE backup_badges DROP CONSTRAINT IF EXISTS uk_backup_badges_database_id;
+ALTER TABLE backup_badges DROP CONSTRAINT IF EXISTS fk_backup_badges_database_id;
+ALTER TABLE backup_badges DROP CONSTRAINT IF EXISTS pk_backup_badges;
+
+DROP TABLE IF EXISTS backup_badges;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE s3_storages
+    ADD COLUMN auth_method TEXT NOT NULL DEFAULT 'STATIC_KEYS',
+    ADD COLUMN role_arn    TEXT NOT NULL DEFAULT '',
+    ADD COLUMN external_id TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE s3_storages
+    DROP COLUMN IF EXISTS external_id,
+    DROP COLUMN IF EXISTS role_arn,
+    DROP COLUMN IF EXISTS auth_method;
+
+-- +goose StatementEnd
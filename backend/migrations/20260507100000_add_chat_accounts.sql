@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE chat_accounts (
+    id           UUID        NOT NULL DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL,
+    platform     TEXT        NOT NULL,
+    team_id      TEXT        NOT NULL,
+    chat_user_id TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE chat_accounts
+    ADD CONSTRAINT pk_chat_accounts
+    PRIMARY KEY (id);
+
+ALTER TABLE chat_accounts
+    ADD CONSTRAINT fk_chat_accounts_user_id
+    FOREIGN KEY (user_id)
+    REFERENCES users (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE chat_accounts
+    ADD CONSTRAINT uk_chat_accounts_platform_team_chat_user
+    UNIQUE (platform, team_id, chat_user_id);
+
+CREATE INDEX idx_chat_accounts_user_id ON chat_accounts (user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_chat_accounts_user_id;
+
+ALTER TABLE chat_accounts DROP CONSTRAINT IF EXISTS uk_chat_accounts_platform_team_chat_user;
+ALTER TABLE chat_accounts DROP CONSTRAINT IF EXISTS fk_chat_accounts_user_id;
+ALTER TABLE chat_accounts DROP CONSTRAINT IF EXISTS pk_chat_accounts;
+
+DROP TABLE IF EXISTS chat_accounts;
+
+-- +goose StatementEnd
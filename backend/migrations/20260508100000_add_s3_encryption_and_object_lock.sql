@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE s3_storages
+    ADD COLUMN server_side_encryption     TEXT    NOT NULL DEFAULT 'NONE',
+    ADD COLUMN kms_key_id                 TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN is_bucket_key_enabled      BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN object_lock_mode           TEXT    NOT NULL DEFAULT 'NONE',
+    ADD COLUMN object_lock_retention_days INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE s3_storages
+    DROP COLUMN IF EXISTS object_lock_retention_days,
+    DROP COLUMN IF EXISTS object_lock_mode,
+    DROP COLUMN IF EXISTS is_bucket_key_enabled,
+    DROP COLUMN IF EXISTS kms_key_id,
+    DROP COLUMN IF EXISTS server_side_encryption;
+
+-- +goose StatementEnd
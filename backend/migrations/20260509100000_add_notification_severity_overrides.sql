@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE notification_severity_overrides (
+    workspace_id UUID        NOT NULL,
+    event_type   TEXT        NOT NULL,
+    severity     TEXT        NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE notification_severity_overrides
+    ADD CONSTRAINT pk_notification_severity_overrides
+    PRIMARY KEY (workspace_id, event_type);
+
+ALTER TABLE notification_severity_overrides
+    ADD CONSTRAINT fk_notification_severity_overrides_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE notification_severity_overrides
+    DROP CONSTRAINT IF EXISTS fk_notification_severity_overrides_workspace_id;
+ALTER TABLE notification_severity_overrides
+    DROP CONSTRAINT IF EXISTS pk_notification_severity_overrides;
+
+DROP TABLE IF EXISTS notification_severity_overrides;
+
+-- +goose StatementEnd
@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE storage_mirror_groups (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID        NOT NULL,
+    name         TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE storage_mirror_members (
+    storage_id UUID PRIMARY KEY,
+    group_id   UUID NOT NULL
+);
+
+CREATE TABLE backup_replicas (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    backup_id      UUID        NOT NULL,
+    storage_id     UUID        NOT NULL,
+    status         TEXT        NOT NULL,
+    fail_message   TEXT,
+    attempts_count INT         NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at   TIMESTAMPTZ
+);
+
+ALTER TABLE storage_mirror_groups
+    ADD CONSTRAINT fk_storage_mirror_groups_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE storage_mirror_members
+    ADD CONSTRAINT fk_storage_mirror_members_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE storage_mirror_members
+    ADD CONSTRAINT fk_storage_mirror_members_group_id
+    FOREIGN KEY (group_id)
+    REFERENCES storage_mirror_groups (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_replicas
+    ADD CONSTRAINT fk_backup_replicas_backup_id
+    FOREIGN KEY (backup_id)
+    REFERENCES backups (id)
+    ON DELETE CASCADE;
+
+ALTER TABLE backup_replicas
+    ADD CONSTRAINT fk_backup_replicas_storage_id
+    FOREIGN KEY (storage_id)
+    REFERENCES storages (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_storage_mirror_groups_workspace_id ON storage_mirror_groups (workspace_id);
+CREATE INDEX idx_storage_mirror_members_group_id ON storage_mirror_members (group_id);
+CREATE INDEX idx_backup_replicas_backup_id ON backup_replicas (backup_id);
+CREATE INDEX idx_backup_replicas_status ON backup_replicas (status);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_backup_replicas_status;
+DROP INDEX IF EXISTS idx_backup_replicas_backup_id;
+DROP INDEX IF EXISTS idx_storage_mirror_members_group_id;
+DROP INDEX IF EXISTS idx_storage_mirror_groups_workspace_id;
+
+ALTER TABLE backup_replicas DROP CONSTRAINT IF EXISTS fk_backup_replicas_storage_id;
+ALTER TABLE backup_replicas DROP CONSTRAINT IF EXISTS fk_backup_replicas_backup_id;
+ALTER TABLE storage_mirror_members DROP CONSTRAINT IF EXISTS fk_storage_mirror_members_group_id;
+ALTER TABLE storage_mirror_members DROP CONSTRAINT IF EXISTS fk_storage_mirror_members_storage_id;
+ALTER TABLE storage_mirror_groups DROP CONSTRAINT IF EXISTS fk_storage_mirror_groups_workspace_id;
+
+DROP TABLE IF EXISTS backup_replicas;
+DROP TABLE IF EXISTS storage_mirror_members;
+DROP TABLE IF EXISTS storage_mirror_groups;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE workspace_subscriptions
+    ADD COLUMN last_stripe_event_at TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE workspace_subscriptions
+    DROP COLUMN IF EXISTS last_stripe_event_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE audit_logs
+    ADD COLUMN ip_address TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS ip_address;
+
+-- +goose StatementEnd
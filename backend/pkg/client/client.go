@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Client calls Databasus REST API. Operation methods are generated from the OpenAPI
+// document into operations.gen.go by `make openapi`, this file holds the transport
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient expects URL with API version, e.g. https://databasus.example.com/api/v1
+func NewClient(baseURL, token string) *Client {
+	return &Client{strings.TrimSuffix(baseURL, "/"), token, http.DefaultClient}
+}
+
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	return c
+}
+
+// APIError is returned for non 2xx responses, Message is taken from the error envelope
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("databasus API responded with %d: %s", e.StatusCode, e.Message)
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	jsonBody    any
+	body        io.Reader
+	contentType string
+}
+
+func (c *Client) do(ctx context.Context, req request, result any) error {
+	responseBody, err := c.stream(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = responseBody.Close() }()
+
+	if result == nil {
+		_, err = io.Copy(io.Discard, responseBody)
+		return err
+	}
+
+	return json.NewDecoder(responseBody).Decode(result)
+}
+
+func (c *Client) stream(ctx context.Context, req request) (io.ReadCloser, error) {
+	body := req.body
+	contentType := req.contentType
+
+	if req.jsonBody != nil {
+		encoded, err := json.Marshal(req.jsonBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+
+		body = bytes.NewReader(encoded)
+		contentType = "application/json"
+	}
+
+	requestURL := c.baseURL + req.path
+	if len(req.query) > 0 {
+		requestURL += "?" + req.query.Encode()
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, req.method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		httpRequest.Header.Set("Content-Type", contentType)
+	}
+
+	if c.token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		defer func() { _ = response.Body.Close() }()
+		return nil, readAPIError(response)
+	}
+
+	return response.Body, nil
+}
+
+func readAPIError(response *http.Response) error {
+	apiError := &APIError{StatusCode: response.StatusCode}
+
+	errorBody, err := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	if err != nil {
+		apiError.Message = err.Error()
+		return apiError
+	}
+
+	var envelope struct {
+		Error string `json:"error"`
+	}
+
+	if json.Unmarshal(errorBody, &envelope) == nil && envelope.Error != "" {
+		apiError.Message = envelope.Error
+	} else {
+		apiError.Message = strings.TrimSpace(string(errorBody))
+	}
+
+	return apiError
+}
+
+func buildPath(template string, pathParams map[string]any) string {
+	for name, pathValue := range pathParams {
+		template = strings.ReplaceAll(
+			template,
+			"{"+name+"}",
+			url.PathEscape(fmt.Sprint(pathValue)),
+		)
+	}
+
+	return template
+}
+
+// setQuery skips nil optional parameters, so generated methods do not need a branch
+// per parameter
+func setQuery(query url.Values, name string, queryValue any) {
+	reflected := reflect.ValueOf(queryValue)
+	if !reflected.IsValid() {
+		return
+	}
+
+	if reflected.Kind() == reflect.Pointer {
+		if reflected.IsNil() {
+			return
+		}
+
+		reflected = reflected.Elem()
+	}
+
+	if reflected.Kind() == reflect.Slice {
+		for index := range reflected.Len() {
+			query.Add(name, fmt.Sprint(reflected.Index(index).Interface()))
+		}
+
+		return
+	}
+
+	query.Set(name, fmt.Sprint(reflected.Interface()))
+}
@@ -0,0 +1,5313 @@
+// Code generated by cmd/openapi from the OpenAPI document. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+)
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type AgentsAgent struct {
+	CreatedAt   *string `json:"createdAt,omitempty"`
+	Id          *string `json:"id,omitempty"`
+	IsConnected *bool   `json:"isConnected,omitempty"`
+	LastSeenAt  *string `json:"lastSeenAt,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Version     *string `json:"version,omitempty"`
+	WorkspaceId *string `json:"workspaceId,omitempty"`
+}
+
+type AgentsAgentJob struct {
+	Args     []string `json:"args,omitempty"`
+	BackupId *string  `json:"backupId,omitempty"`
+	Env      []string `json:"env,omitempty"`
+	Tool     *string  `json:"tool,omitempty"`
+}
+
+type AgentsCompleteAgentJobRequest struct {
+	// Error is empty when the dump tool exited successfully
+	Error      *string `json:"error,omitempty"`
+	ToolOutput *string `json:"toolOutput,omitempty"`
+}
+
+type AgentsCreateAgentRequest struct {
+	Name        string `json:"name"`
+	WorkspaceId string `json:"workspaceId"`
+}
+
+type AgentsCreateAgentResponse struct {
+	Agent *AgentsAgent `json:"agent,omitempty"`
+	// Token is returned only once, use it as DATABASUS_AGENT_TOKEN of the agent
+	Token *string `json:"token,omitempty"`
+}
+
+type AuditLogsAuditLogDTO struct {
+	ChangeReason  *string `json:"changeReason,omitempty"`
+	CreatedAt     *string `json:"createdAt,omitempty"`
+	Id            *string `json:"id,omitempty"`
+	Message       *string `json:"message,omitempty"`
+	ReadCount     *int64  `json:"readCount,omitempty"`
+	UserEmail     *string `json:"userEmail,omitempty"`
+	UserId        *string `json:"userId,omitempty"`
+	UserName      *string `json:"userName,omitempty"`
+	WorkspaceId   *string `json:"workspaceId,omitempty"`
+	WorkspaceName *string `json:"workspaceName,omitempty"`
+}
+
+type AuditLogsGetAuditLogsResponse struct {
+	AuditLogs []*AuditLogsAuditLogDTO `json:"auditLogs,omitempty"`
+	Limit     *int64                  `json:"limit,omitempty"`
+	Offset    *int64                  `json:"offset,omitempty"`
+	Total     *int64                  `json:"total,omitempty"`
+}
+
+type AzureBlobStorageAuthMethod string
+
+const (
+	AzureBlobStorageAuthMethodConnectionString AzureBlobStorageAuthMethod = "CONNECTION_STRING"
+	AzureBlobStorageAuthMethodAccountKey       AzureBlobStorageAuthMethod = "ACCOUNT_KEY"
+)
+
+type AzureBlobStorageAzureBlobStorage struct {
+	AccountKey       *string                     `json:"accountKey,omitempty"`
+	AccountName      *string                     `json:"accountName,omitempty"`
+	AuthMethod       *AzureBlobStorageAuthMethod `json:"authMethod,omitempty"`
+	ConnectionString *string                     `json:"connectionString,omitempty"`
+	ContainerName    *string                     `json:"containerName,omitempty"`
+	Endpoint         *string                     `json:"endpoint,omitempty"`
+	Prefix           *string                     `json:"prefix,omitempty"`
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this storage, see proxy_utils.GetProxyFunc
+	ProxyUrl  *string `json:"proxyUrl,omitempty"`
+	StorageId *string `json:"storageId,omitempty"`
+}
+
+type BackupingBackupLogEntry struct {
+	IsEnd *bool   `json:"isEnd,omitempty"`
+	Line  *string `json:"line,omitempty"`
+	Seq   *int64  `json:"seq,omitempty"`
+	Time  *string `json:"time,omitempty"`
+}
+
+type BackupingScheduleLoadBucket struct {
+	BackupsPerNode  *float64 `json:"backupsPerNode,omitempty"`
+	RunningBackups  *int64   `json:"runningBackups,omitempty"`
+	StartingBackups *int64   `json:"startingBackups,omitempty"`
+	StartsAt        *string  `json:"startsAt,omitempty"`
+}
+
+type BackupingScheduleLoadProjection struct {
+	Buckets            []*BackupingScheduleLoadBucket `json:"buckets,omitempty"`
+	From               *string                        `json:"from,omitempty"`
+	NodesCount         *int64                         `json:"nodesCount,omitempty"`
+	PeakRunningBackups *int64                         `json:"peakRunningBackups,omitempty"`
+	To                 *string                        `json:"to,omitempty"`
+}
+
+type BackupsGetBackupsResponse struct {
+	Backups []*BackupsCoreBackup `json:"backups,omitempty"`
+	Limit   *int64               `json:"limit,omitempty"`
+	Offset  *int64               `json:"offset,omitempty"`
+	Total   *int64               `json:"total,omitempty"`
+}
+
+type BackupsGetStorageBackupsResponse struct {
+	Backups     []*BackupsCoreBackup              `json:"backups,omitempty"`
+	Databases   []*BackupsCoreDatabaseBackupsSize `json:"databases,omitempty"`
+	Limit       *int64                            `json:"limit,omitempty"`
+	Offset      *int64                            `json:"offset,omitempty"`
+	Total       *int64                            `json:"total,omitempty"`
+	TotalSizeMb *float64                          `json:"totalSizeMb,omitempty"`
+}
+
+type BackupsMakeBackupRequest struct {
+	DatabaseId string `json:"database_id"`
+	// Tags are matched by retention holds of the backup config, e.g. "release:v1.2"
+	Tags []string `json:"tags,omitempty"`
+}
+
+type BackupsAdoptionAdoptBackupsRequest struct {
+	Directory *string `json:"directory,omitempty"`
+	// Pattern is matched against names relative to the directory, e.g. "orders-*.dump"
+	Pattern string `json:"pattern"`
+	// StorageID defaults to the storage of the backup config of the database
+	StorageId *string `json:"storageId,omitempty"`
+}
+
+type BackupsAdoptionAdoptBackupsResponse struct {
+	Backups []*BackupsCoreBackup `json:"backups,omitempty"`
+}
+
+type BackupsAdoptionScanBackupsResponse struct {
+	Files     []*BackupsAdoptionScannedFile `json:"files,omitempty"`
+	StorageId *string                       `json:"storageId,omitempty"`
+}
+
+type BackupsAdoptionScannedFile struct {
+	IsAdopted  *bool   `json:"isAdopted,omitempty"`
+	ModifiedAt *string `json:"modifiedAt,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	SizeBytes  *int64  `json:"sizeBytes,omitempty"`
+}
+
+type BackupsBadgesBackupBadge struct {
+	CreatedAt      *string `json:"createdAt,omitempty"`
+	DatabaseId     *string `json:"databaseId,omitempty"`
+	Id             *string `json:"id,omitempty"`
+	TokenRotatedAt *string `json:"tokenRotatedAt,omitempty"`
+}
+
+type BackupsBadgesBackupBadgeTokenResponse struct {
+	BackupBadge *BackupsBadgesBackupBadge `json:"backupBadge,omitempty"`
+	Token       *string                   `json:"token,omitempty"`
+}
+
+type BackupsCalendarsCalendarFeed struct {
+	CreatedAt      *string `json:"createdAt,omitempty"`
+	Id             *string `json:"id,omitempty"`
+	TokenRotatedAt *string `json:"tokenRotatedAt,omitempty"`
+	WorkspaceId    *string `json:"workspaceId,omitempty"`
+}
+
+type BackupsCalendarsCalendarFeedTokenResponse struct {
+	CalendarFeed *BackupsCalendarsCalendarFeed `json:"calendarFeed,omitempty"`
+	Token        *string                       `json:"token,omitempty"`
+}
+
+type BackupsConfigAssignDefaultStorageRequest struct {
+	// IsReassignAttached also moves databases attached to another storage, deleting their
+	// backups
+	IsReassignAttached *bool `json:"isReassignAttached,omitempty"`
+}
+
+type BackupsConfigAssignDefaultStorageResponse struct {
+	AssignedDatabasesCount *int64 `json:"assignedDatabasesCount,omitempty"`
+}
+
+type BackupsConfigBackupConfig struct {
+	BackupInterval   *IntervalsInterval `json:"backupInterval,omitempty"`
+	BackupIntervalId *string            `json:"backupIntervalId,omitempty"`
+	// Timeouts terminate the backup or restore process, HangTimeoutMinutes aborts and retries
+	// backups with no bytes progressed for that long. 0 = global default from env
+	BackupTimeoutMinutes *int64 `json:"backupTimeoutMinutes,omitempty"`
+	// ConcurrencyGroupID limits scheduled backups running at once with other databases of
+	// the group
+	ConcurrencyGroupId *string                        `json:"concurrencyGroupId,omitempty"`
+	DatabaseId         *string                        `json:"databaseId,omitempty"`
+	Encryption         *BackupsConfigBackupEncryption `json:"encryption,omitempty"`
+	// FileNameTemplate names backup files of the database, it overrides the template of the
+	// storage. Used only on storages supporting file naming
+	FileNameTemplate   *string `json:"fileNameTemplate,omitempty"`
+	HangTimeoutMinutes *int64  `json:"hangTimeoutMinutes,omitempty"`
+	IsBackupsEnabled   *bool   `json:"isBackupsEnabled,omitempty"`
+	IsRetryIfFailed    *bool   `json:"isRetryIfFailed,omitempty"`
+	// Paused schedules start no scheduled backups or retries until resumed, or until
+	// SchedulePausedUntil if set. Managed by pause endpoints only, saving the config keeps it
+	IsSchedulePaused *bool `json:"isSchedulePaused,omitempty"`
+	// MaxBackupSizeMB limits individual backup size. 0 = unlimited.
+	MaxBackupSizeMb *int64 `json:"maxBackupSizeMb,omitempty"`
+	// MaxBackupsTotalSizeMB limits total size of all backups. 0 = unlimited.
+	MaxBackupsTotalSizeMb *int64                        `json:"maxBackupsTotalSizeMb,omitempty"`
+	MaxFailedTriesCount   *int64                        `json:"maxFailedTriesCount,omitempty"`
+	RestoreTimeoutMinutes *int64                        `json:"restoreTimeoutMinutes,omitempty"`
+	RetentionHolds        []*BackupsConfigRetentionHold `json:"retentionHolds,omitempty"`
+	SchedulePausedUntil   *string                       `json:"schedulePausedUntil,omitempty"`
+	// ScheduleSpreadMinutes delays scheduled backups by a stable offset within the window,
+	// so databases sharing a time of day do not start at once. 0 = no delay
+	ScheduleSpreadMinutes *int64                                 `json:"scheduleSpreadMinutes,omitempty"`
+	SendNotificationsOn   []*BackupsConfigBackupNotificationType `json:"sendNotificationsOn,omitempty"`
+	Storage               *StoragesStorage                       `json:"storage,omitempty"`
+	StorageId             *string                                `json:"storageId,omitempty"`
+	StorePeriod           *PeriodPeriod                          `json:"storePeriod,omitempty"`
+}
+
+type BackupsConfigBackupEncryption string
+
+const (
+	BackupsConfigBackupEncryptionNone      BackupsConfigBackupEncryption = "NONE"
+	BackupsConfigBackupEncryptionEncrypted BackupsConfigBackupEncryption = "ENCRYPTED"
+)
+
+type BackupsConfigBackupNotificationType string
+
+const (
+	BackupsConfigBackupNotificationTypeBackupFailed  BackupsConfigBackupNotificationType = "BACKUP_FAILED"
+	BackupsConfigBackupNotificationTypeBackupSuccess BackupsConfigBackupNotificationType = "BACKUP_SUCCESS"
+)
+
+type BackupsConfigConcurrencyGroup struct {
+	CreatedAt            *string `json:"createdAt,omitempty"`
+	Id                   *string `json:"id,omitempty"`
+	MaxConcurrentBackups *int64  `json:"maxConcurrentBackups,omitempty"`
+	Name                 *string `json:"name,omitempty"`
+	WorkspaceId          *string `json:"workspaceId,omitempty"`
+}
+
+type BackupsConfigDatabaseTransfer struct {
+	CreatedAt           *string `json:"createdAt,omitempty"`
+	DatabaseId          *string `json:"databaseId,omitempty"`
+	Id                  *string `json:"id,omitempty"`
+	SourceStorageId     *string `json:"sourceStorageId,omitempty"`
+	SourceWorkspaceId   *string `json:"sourceWorkspaceId,omitempty"`
+	TargetStorageId     *string `json:"targetStorageId,omitempty"`
+	TargetWorkspaceId   *string `json:"targetWorkspaceId,omitempty"`
+	TransferredByUserId *string `json:"transferredByUserId,omitempty"`
+}
+
+type BackupsConfigPauseScheduleRequest struct {
+	// PausedUntil resumes the schedule automatically, the pause lasts until resumed if empty
+	PausedUntil *string `json:"pausedUntil,omitempty"`
+	Reason      *string `json:"reason,omitempty"`
+}
+
+type BackupsConfigRetentionHold struct {
+	KeepPeriod *PeriodPeriod `json:"keepPeriod,omitempty"`
+	TagPattern *string       `json:"tagPattern,omitempty"`
+}
+
+type BackupsConfigTransferDatabaseRequest struct {
+	IsTransferWithNotifiers *bool    `json:"isTransferWithNotifiers,omitempty"`
+	IsTransferWithStorage   *bool    `json:"isTransferWithStorage,omitempty"`
+	IsUseEquivalentStorage  *bool    `json:"isUseEquivalentStorage,omitempty"`
+	TargetNotifierIds       []string `json:"targetNotifierIds,omitempty"`
+	TargetStorageId         *string  `json:"targetStorageId,omitempty"`
+	TargetWorkspaceId       string   `json:"targetWorkspaceId"`
+}
+
+type BackupsCoreBackup struct {
+	// AdoptedFromFile is the name of the existing dump the backup was copied from
+	AdoptedFromFile  *string  `json:"adoptedFromFile,omitempty"`
+	BackupDurationMs *int64   `json:"backupDurationMs,omitempty"`
+	BackupSizeMb     *float64 `json:"backupSizeMb,omitempty"`
+	// Checksum is sha256 of the file, it is set for backups whose file is uploaded by
+	// Databasus. Engines writing into storages themselves, like CockroachDB, leave it empty
+	Checksum    *string                        `json:"checksum,omitempty"`
+	CreatedAt   *string                        `json:"createdAt,omitempty"`
+	DatabaseId  *string                        `json:"databaseId,omitempty"`
+	Encryption  *BackupsConfigBackupEncryption `json:"encryption,omitempty"`
+	FailMessage *string                        `json:"failMessage,omitempty"`
+	// FileName is the name of the file in the storage rendered from a naming template, the
+	// file is named by the backup ID if empty
+	FileName *string `json:"fileName,omitempty"`
+	Id       *string `json:"id,omitempty"`
+	// IsManual is set for backups started by users, scheduled backups and retries are not
+	IsManual    *bool `json:"isManual,omitempty"`
+	IsSkipRetry *bool `json:"isSkipRetry,omitempty"`
+	// Signature is the base64 Ed25519 signature of GetSignatureMessage by the signing key
+	// of the instance, restores check it to detect files changed in the storage
+	Signature *string                  `json:"signature,omitempty"`
+	Status    *BackupsCoreBackupStatus `json:"status,omitempty"`
+	StorageId *string                  `json:"storageId,omitempty"`
+	// Tags are set when the backup is started and matched by retention holds of the config
+	Tags []string `json:"tags,omitempty"`
+}
+
+type BackupsCoreBackupDeadLetter struct {
+	Attempts         []*BackupsCoreBackupDeadLetterAttempt `json:"attempts,omitempty"`
+	AttemptsCount    *int64                                `json:"attemptsCount,omitempty"`
+	BackupId         *string                               `json:"backupId,omitempty"`
+	CreatedAt        *string                               `json:"createdAt,omitempty"`
+	DatabaseId       *string                               `json:"databaseId,omitempty"`
+	FailMessage      *string                               `json:"failMessage,omitempty"`
+	Id               *string                               `json:"id,omitempty"`
+	RequeuedAt       *string                               `json:"requeuedAt,omitempty"`
+	RequeuedByUserId *string                               `json:"requeuedByUserId,omitempty"`
+}
+
+type BackupsCoreBackupDeadLetterAttempt struct {
+	BackupId    *string `json:"backupId,omitempty"`
+	CreatedAt   *string `json:"createdAt,omitempty"`
+	FailMessage *string `json:"failMessage,omitempty"`
+}
+
+type BackupsCoreBackupRunLog struct {
+	BackupId       *string                    `json:"backupId,omitempty"`
+	CreatedAt      *string                    `json:"createdAt,omitempty"`
+	Phases         []*CommonBackupPhaseTiming `json:"phases,omitempty"`
+	ToolOutputTail *string                    `json:"toolOutputTail,omitempty"`
+}
+
+type BackupsCoreBackupStatus string
+
+const (
+	BackupsCoreBackupStatusInProgress BackupsCoreBackupStatus = "IN_PROGRESS"
+	BackupsCoreBackupStatusCompleted  BackupsCoreBackupStatus = "COMPLETED"
+	BackupsCoreBackupStatusFailed     BackupsCoreBackupStatus = "FAILED"
+	BackupsCoreBackupStatusCanceled   BackupsCoreBackupStatus = "CANCELED"
+)
+
+type BackupsCoreDatabaseBackupsSize struct {
+	BackupsCount *int64   `json:"backupsCount,omitempty"`
+	DatabaseId   *string  `json:"databaseId,omitempty"`
+	TotalSizeMb  *float64 `json:"totalSizeMb,omitempty"`
+}
+
+type BackupsDownloadBackupShareLink struct {
+	BackupId         *string `json:"backupId,omitempty"`
+	CreatedAt        *string `json:"createdAt,omitempty"`
+	CreatedByUserId  *string `json:"createdByUserId,omitempty"`
+	DownloadCount    *int64  `json:"downloadCount,omitempty"`
+	ExpiresAt        *string `json:"expiresAt,omitempty"`
+	Id               *string `json:"id,omitempty"`
+	LastDownloadedAt *string `json:"lastDownloadedAt,omitempty"`
+	// MaxDownloads limits downloads of the link, 0 allows any count until it expires
+	MaxDownloads    *int64  `json:"maxDownloads,omitempty"`
+	Note            *string `json:"note,omitempty"`
+	RecipientUserId *string `json:"recipientUserId,omitempty"`
+	RevokedAt       *string `json:"revokedAt,omitempty"`
+}
+
+type BackupsDownloadCreateShareLinkRequest struct {
+	// ExpiresInHours defaults to 24, links live for 7 days at most
+	ExpiresInHours  *int64  `json:"expiresInHours,omitempty"`
+	MaxDownloads    *int64  `json:"maxDownloads,omitempty"`
+	Note            *string `json:"note,omitempty"`
+	RecipientUserId string  `json:"recipientUserId"`
+}
+
+type BackupsDownloadGenerateDownloadTokenResponse struct {
+	BackupId *string `json:"backupId,omitempty"`
+	Filename *string `json:"filename,omitempty"`
+	Token    *string `json:"token,omitempty"`
+}
+
+type BackupsGrafanaGrafanaIntegration struct {
+	// APIToken is a token of a service account with the annotations writer permission
+	ApiToken     *string `json:"apiToken,omitempty"`
+	CreatedAt    *string `json:"createdAt,omitempty"`
+	DashboardUid *string `json:"dashboardUid,omitempty"`
+	Id           *string `json:"id,omitempty"`
+	IsEnabled    *bool   `json:"isEnabled,omitempty"`
+	Url          *string `json:"url,omitempty"`
+	WorkspaceId  *string `json:"workspaceId,omitempty"`
+}
+
+type BackupsRunbooksDRRunbook struct {
+	Databases     []*BackupsRunbooksRunbookDatabase `json:"databases,omitempty"`
+	GeneratedAt   *string                           `json:"generatedAt,omitempty"`
+	WorkspaceId   *string                           `json:"workspaceId,omitempty"`
+	WorkspaceName *string                           `json:"workspaceName,omitempty"`
+}
+
+type BackupsRunbooksRunbookBackup struct {
+	Checksum   *string  `json:"checksum,omitempty"`
+	CreatedAt  *string  `json:"createdAt,omitempty"`
+	Encryption *string  `json:"encryption,omitempty"`
+	FileName   *string  `json:"fileName,omitempty"`
+	Id         *string  `json:"id,omitempty"`
+	SizeMb     *float64 `json:"sizeMb,omitempty"`
+}
+
+type BackupsRunbooksRunbookDatabase struct {
+	DatabaseId *string `json:"databaseId,omitempty"`
+	// LatestBackup is nil when the database has no completed backups yet
+	LatestBackup *BackupsRunbooksRunbookBackup `json:"latestBackup,omitempty"`
+	Name         *string                       `json:"name,omitempty"`
+	// RequiredKeys are placeholders used by the commands, their values are never written
+	// to the runbook
+	RequiredKeys    []*BackupsRunbooksRunbookKey   `json:"requiredKeys,omitempty"`
+	RestoreCommands []string                       `json:"restoreCommands,omitempty"`
+	Storage         *BackupsRunbooksRunbookStorage `json:"storage,omitempty"`
+	Type            *string                        `json:"type,omitempty"`
+}
+
+type BackupsRunbooksRunbookKey struct {
+	Description *string `json:"description,omitempty"`
+	Placeholder *string `json:"placeholder,omitempty"`
+}
+
+type BackupsRunbooksRunbookStorage struct {
+	Id       *string           `json:"id,omitempty"`
+	Location map[string]string `json:"location,omitempty"`
+	Name     *string           `json:"name,omitempty"`
+	Type     *string           `json:"type,omitempty"`
+}
+
+type BackupsStatusPagesDatabaseHealth string
+
+const (
+	BackupsStatusPagesDatabaseHealthHealthy   BackupsStatusPagesDatabaseHealth = "HEALTHY"
+	BackupsStatusPagesDatabaseHealthFailing   BackupsStatusPagesDatabaseHealth = "FAILING"
+	BackupsStatusPagesDatabaseHealthNoBackups BackupsStatusPagesDatabaseHealth = "NO_BACKUPS"
+)
+
+type BackupsStatusPagesPublicDatabaseStatus struct {
+	FailureStreak         *int64                            `json:"failureStreak,omitempty"`
+	Health                *BackupsStatusPagesDatabaseHealth `json:"health,omitempty"`
+	Label                 *string                           `json:"label,omitempty"`
+	LastSuccessAgeSeconds *int64                            `json:"lastSuccessAgeSeconds,omitempty"`
+	LastSuccessAt         *string                           `json:"lastSuccessAt,omitempty"`
+	SuccessStreak         *int64                            `json:"successStreak,omitempty"`
+}
+
+type BackupsStatusPagesPublicStatusPage struct {
+	Databases   []*BackupsStatusPagesPublicDatabaseStatus `json:"databases,omitempty"`
+	GeneratedAt *string                                   `json:"generatedAt,omitempty"`
+	Title       *string                                   `json:"title,omitempty"`
+}
+
+type BackupsStatusPagesSaveStatusPageRequest struct {
+	DatabaseLabels map[string]string `json:"databaseLabels,omitempty"`
+	IsAnonymized   *bool             `json:"isAnonymized,omitempty"`
+	Title          string            `json:"title"`
+}
+
+type BackupsStatusPagesStatusPage struct {
+	CreatedAt *string `json:"createdAt,omitempty"`
+	// DatabaseLabels replace names of databases on the page, the page shows only labelled
+	// databases if there are labels
+	DatabaseLabels map[string]string `json:"databaseLabels,omitempty"`
+	Id             *string           `json:"id,omitempty"`
+	IsAnonymized   *bool             `json:"isAnonymized,omitempty"`
+	Title          *string           `json:"title,omitempty"`
+	TokenRotatedAt *string           `json:"tokenRotatedAt,omitempty"`
+	WorkspaceId    *string           `json:"workspaceId,omitempty"`
+}
+
+type BackupsStatusPagesStatusPageTokenResponse struct {
+	StatusPage *BackupsStatusPagesStatusPage `json:"statusPage,omitempty"`
+	Token      *string                       `json:"token,omitempty"`
+}
+
+type BackupsTriggersBackupTrigger struct {
+	CreatedAt       *string `json:"createdAt,omitempty"`
+	DatabaseId      *string `json:"databaseId,omitempty"`
+	Id              *string `json:"id,omitempty"`
+	LastTriggeredAt *string `json:"lastTriggeredAt,omitempty"`
+	TokenRotatedAt  *string `json:"tokenRotatedAt,omitempty"`
+}
+
+type BackupsTriggersBackupTriggerTokenResponse struct {
+	BackupTrigger *BackupsTriggersBackupTrigger `json:"backupTrigger,omitempty"`
+	Token         *string                       `json:"token,omitempty"`
+}
+
+type BackupsTriggersCheckDeploymentGateRequest struct {
+	IsTriggeringIfStale *bool  `json:"isTriggeringIfStale,omitempty"`
+	MaxAgeMinutes       *int64 `json:"maxAgeMinutes,omitempty"`
+	WaitTimeoutSeconds  *int64 `json:"waitTimeoutSeconds,omitempty"`
+}
+
+type BackupsTriggersDeploymentGateResponse struct {
+	DatabaseId                 *string `json:"databaseId,omitempty"`
+	DatabaseName               *string `json:"databaseName,omitempty"`
+	IsBackupTriggered          *bool   `json:"isBackupTriggered,omitempty"`
+	IsPassed                   *bool   `json:"isPassed,omitempty"`
+	LastBackupAt               *string `json:"lastBackupAt,omitempty"`
+	LastBackupId               *string `json:"lastBackupId,omitempty"`
+	MaxAgeMinutes              *int64  `json:"maxAgeMinutes,omitempty"`
+	TriggeredBackupFailMessage *string `json:"triggeredBackupFailMessage,omitempty"`
+}
+
+type BackupsTriggersTriggerBackupRequest struct {
+	Reason *string  `json:"reason,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+type BillingSubscriptionsGetWorkspaceSubscriptionResponse struct {
+	CurrentPeriodEnd *string                                 `json:"currentPeriodEnd,omitempty"`
+	PlanTier         *BillingSubscriptionsPlanTier           `json:"planTier,omitempty"`
+	Quota            *BillingSubscriptionsPlanQuota          `json:"quota,omitempty"`
+	Status           *BillingSubscriptionsSubscriptionStatus `json:"status,omitempty"`
+	WorkspaceId      *string                                 `json:"workspaceId,omitempty"`
+}
+
+type BillingSubscriptionsPlanQuota struct {
+	MaxBackupSizeMb *int64 `json:"maxBackupSizeMb,omitempty"`
+	// 0 means unlimited for all numeric limits
+	MaxDatabases   *int64        `json:"maxDatabases,omitempty"`
+	MaxStorageGb   *int64        `json:"maxStorageGb,omitempty"`
+	MaxStorePeriod *PeriodPeriod `json:"maxStorePeriod,omitempty"`
+	// NodePriority orders due backups of the scheduler, higher priorities are started
+	// first and get the least busy nodes
+	NodePriority *int64                        `json:"nodePriority,omitempty"`
+	Tier         *BillingSubscriptionsPlanTier `json:"tier,omitempty"`
+}
+
+type BillingSubscriptionsPlanTier string
+
+const (
+	BillingSubscriptionsPlanTierFree     BillingSubscriptionsPlanTier = "FREE"
+	BillingSubscriptionsPlanTierStarter  BillingSubscriptionsPlanTier = "STARTER"
+	BillingSubscriptionsPlanTierPro      BillingSubscriptionsPlanTier = "PRO"
+	BillingSubscriptionsPlanTierBusiness BillingSubscriptionsPlanTier = "BUSINESS"
+)
+
+type BillingSubscriptionsSubscriptionStatus string
+
+const (
+	BillingSubscriptionsSubscriptionStatusActive     BillingSubscriptionsSubscriptionStatus = "ACTIVE"
+	BillingSubscriptionsSubscriptionStatusTrialing   BillingSubscriptionsSubscriptionStatus = "TRIALING"
+	BillingSubscriptionsSubscriptionStatusPastDue    BillingSubscriptionsSubscriptionStatus = "PAST_DUE"
+	BillingSubscriptionsSubscriptionStatusIncomplete BillingSubscriptionsSubscriptionStatus = "INCOMPLETE"
+	BillingSubscriptionsSubscriptionStatusCanceled   BillingSubscriptionsSubscriptionStatus = "CANCELED"
+)
+
+type BillingUsageExportUsageRecordsResponse struct {
+	From    *string                       `json:"from,omitempty"`
+	Records []*BillingUsageUsageRecordDTO `json:"records,omitempty"`
+	To      *string                       `json:"to,omitempty"`
+}
+
+type BillingUsageGetWorkspaceUsageResponse struct {
+	Days        []*BillingUsageWorkspaceUsage `json:"days,omitempty"`
+	From        *string                       `json:"from,omitempty"`
+	To          *string                       `json:"to,omitempty"`
+	Total       *BillingUsageUsageTotalsDTO   `json:"total,omitempty"`
+	WorkspaceId *string                       `json:"workspaceId,omitempty"`
+}
+
+type BillingUsageUsageMetric string
+
+const (
+	BillingUsageUsageMetricBytesTransferred BillingUsageUsageMetric = "BYTES_TRANSFERRED"
+	BillingUsageUsageMetricBytesStored      BillingUsageUsageMetric = "BYTES_STORED"
+	BillingUsageUsageMetricJobsCount        BillingUsageUsageMetric = "JOBS_COUNT"
+	BillingUsageUsageMetricNodeMinutes      BillingUsageUsageMetric = "NODE_MINUTES"
+)
+
+type BillingUsageUsageRecordDTO struct {
+	Identifier  *string                  `json:"identifier,omitempty"`
+	Metric      *BillingUsageUsageMetric `json:"metric,omitempty"`
+	PeriodEnd   *string                  `json:"periodEnd,omitempty"`
+	PeriodStart *string                  `json:"periodStart,omitempty"`
+	Quantity    *float64                 `json:"quantity,omitempty"`
+	Timestamp   *int64                   `json:"timestamp,omitempty"`
+	WorkspaceId *string                  `json:"workspaceId,omitempty"`
+}
+
+type BillingUsageUsageTotalsDTO struct {
+	BytesTransferred *int64   `json:"bytesTransferred,omitempty"`
+	JobsCount        *int64   `json:"jobsCount,omitempty"`
+	NodeMinutes      *float64 `json:"nodeMinutes,omitempty"`
+	// stored bytes are a gauge, so the total is the
+	// peak value within the period instead of a sum
+	PeakBytesStored *int64 `json:"peakBytesStored,omitempty"`
+}
+
+type BillingUsageWorkspaceUsage struct {
+	BytesStored      *int64   `json:"bytesStored,omitempty"`
+	BytesTransferred *int64   `json:"bytesTransferred,omitempty"`
+	Id               *string  `json:"id,omitempty"`
+	JobsCount        *int64   `json:"jobsCount,omitempty"`
+	NodeMinutes      *float64 `json:"nodeMinutes,omitempty"`
+	PeriodStart      *string  `json:"periodStart,omitempty"`
+	UpdatedAt        *string  `json:"updatedAt,omitempty"`
+	WorkspaceId      *string  `json:"workspaceId,omitempty"`
+}
+
+type CassandraCassandraDatabase struct {
+	CqlPassword *string `json:"cqlPassword,omitempty"`
+	// restore only: CQL credentials of sstableloader
+	CqlUsername   *string `json:"cqlUsername,omitempty"`
+	DataDirectory *string `json:"dataDirectory,omitempty"`
+	DatabaseId    *string `json:"databaseId,omitempty"`
+	Id            *string `json:"id,omitempty"`
+	// IsAllowPartialBackup keeps backups with some nodes failed. With a replication
+	// factor above 1 the other nodes may still hold all data
+	IsAllowPartialBackup *bool `json:"isAllowPartialBackup,omitempty"`
+	// IsUseSudo runs nodetool and reads SSTables with `sudo -n`, data directories usually
+	// belong to the cassandra user
+	IsUseSudo   *bool   `json:"isUseSudo,omitempty"`
+	JmxPassword *string `json:"jmxPassword,omitempty"`
+	// JMX credentials of nodetool, empty when JMX authentication is off
+	JmxUsername *string `json:"jmxUsername,omitempty"`
+	// Keyspaces is a comma separated list, empty means all keyspaces
+	Keyspaces *string `json:"keyspaces,omitempty"`
+	// Nodes is a comma separated list of node hosts, SSH and nodetool run on each of them
+	Nodes         *string `json:"nodes,omitempty"`
+	SshPassword   *string `json:"sshPassword,omitempty"`
+	SshPort       *int64  `json:"sshPort,omitempty"`
+	SshPrivateKey *string `json:"sshPrivateKey,omitempty"`
+	SshUsername   *string `json:"sshUsername,omitempty"`
+	// restore only: directory on target nodes where SSTables are uploaded before loading
+	StagingDirectory *string `json:"stagingDirectory,omitempty"`
+	// Version is detected on connection, from the first node
+	Version *string `json:"version,omitempty"`
+}
+
+type ChatopsChatAccount struct {
+	ChatUserId *string              `json:"chatUserId,omitempty"`
+	CreatedAt  *string              `json:"createdAt,omitempty"`
+	Id         *string              `json:"id,omitempty"`
+	Platform   *ChatopsChatPlatform `json:"platform,omitempty"`
+	TeamId     *string              `json:"teamId,omitempty"`
+	UserId     *string              `json:"userId,omitempty"`
+}
+
+type ChatopsChatPlatform string
+
+const (
+	ChatopsChatPlatformSlack      ChatopsChatPlatform = "SLACK"
+	ChatopsChatPlatformMattermost ChatopsChatPlatform = "MATTERMOST"
+)
+
+type ChatopsLinkCodeResponse struct {
+	Code      *string `json:"code,omitempty"`
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+}
+
+type ChatopsSlashCommandResponse struct {
+	ResponseType *string `json:"response_type,omitempty"`
+	Text         *string `json:"text,omitempty"`
+}
+
+type ClientCertificatesClientCertificate struct {
+	CreatedAt *string `json:"createdAt,omitempty"`
+	// Fingerprint is the SHA-256 of the DER encoded certificate in hex. Certificates are
+	// matched by it, so a renewed certificate is registered again
+	Fingerprint *string `json:"fingerprint,omitempty"`
+	Id          *string `json:"id,omitempty"`
+	LastUsedAt  *string `json:"lastUsedAt,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	NotAfter    *string `json:"notAfter,omitempty"`
+	Subject     *string `json:"subject,omitempty"`
+	UserId      *string `json:"userId,omitempty"`
+}
+
+type ClientCertificatesRegisterClientCertificateRequest struct {
+	// Certificate is the PEM encoded client certificate, without its private key
+	Certificate string `json:"certificate"`
+	Name        string `json:"name"`
+	UserId      string `json:"userId"`
+}
+
+type CockroachdbCockroachdbDatabase struct {
+	// Database is backed up alone, empty means a full cluster backup
+	Database   *string `json:"database,omitempty"`
+	DatabaseId *string `json:"databaseId,omitempty"`
+	Host       *string `json:"host,omitempty"`
+	Id         *string `json:"id,omitempty"`
+	IsHttps    *bool   `json:"isHttps,omitempty"`
+	Password   *string `json:"password,omitempty"`
+	Port       *int64  `json:"port,omitempty"`
+	// restore only: restore the database of a database backup under this name
+	RestoreDatabaseName *string `json:"restoreDatabaseName,omitempty"`
+	Username            *string `json:"username,omitempty"`
+	// Version is detected on connection
+	Version *string `json:"version,omitempty"`
+}
+
+type CommentsComment struct {
+	AuthorId     *string               `json:"authorId,omitempty"`
+	CreatedAt    *string               `json:"createdAt,omitempty"`
+	Id           *string               `json:"id,omitempty"`
+	ResourceId   *string               `json:"resourceId,omitempty"`
+	ResourceType *CommentsResourceType `json:"resourceType,omitempty"`
+	Text         *string               `json:"text,omitempty"`
+	UpdatedAt    *string               `json:"updatedAt,omitempty"`
+	WorkspaceId  *string               `json:"workspaceId,omitempty"`
+}
+
+type CommentsCommentDTO struct {
+	AuthorEmail  *string               `json:"authorEmail,omitempty"`
+	AuthorId     *string               `json:"authorId,omitempty"`
+	AuthorName   *string               `json:"authorName,omitempty"`
+	CreatedAt    *string               `json:"createdAt,omitempty"`
+	Id           *string               `json:"id,omitempty"`
+	ResourceId   *string               `json:"resourceId,omitempty"`
+	ResourceType *CommentsResourceType `json:"resourceType,omitempty"`
+	Text         *string               `json:"text,omitempty"`
+	UpdatedAt    *string               `json:"updatedAt,omitempty"`
+}
+
+type CommentsCreateCommentRequest struct {
+	ResourceId   string                `json:"resourceId"`
+	ResourceType *CommentsResourceType `json:"resourceType"`
+	Text         string                `json:"text"`
+}
+
+type CommentsResourceType string
+
+const (
+	CommentsResourceTypeDatabase CommentsResourceType = "DATABASE"
+	CommentsResourceTypeStorage  CommentsResourceType = "STORAGE"
+	CommentsResourceTypeNotifier CommentsResourceType = "NOTIFIER"
+)
+
+type CommentsUpdateCommentRequest struct {
+	Text string `json:"text"`
+}
+
+type CommonBackupPhase string
+
+const (
+	CommonBackupPhaseConnect  CommonBackupPhase = "CONNECT"
+	CommonBackupPhaseDump     CommonBackupPhase = "DUMP"
+	CommonBackupPhaseCompress CommonBackupPhase = "COMPRESS"
+	CommonBackupPhaseEncrypt  CommonBackupPhase = "ENCRYPT"
+	CommonBackupPhaseUpload   CommonBackupPhase = "UPLOAD"
+)
+
+type CommonBackupPhaseTiming struct {
+	Bytes      *int64             `json:"bytes,omitempty"`
+	DurationMs *int64             `json:"durationMs,omitempty"`
+	IsFinished *bool              `json:"isFinished,omitempty"`
+	Phase      *CommonBackupPhase `json:"phase,omitempty"`
+	StartedAt  *string            `json:"startedAt,omitempty"`
+}
+
+type CredentialExpiryCredentialType string
+
+const (
+	CredentialExpiryCredentialTypeDatabase CredentialExpiryCredentialType = "DATABASE"
+	CredentialExpiryCredentialTypeStorage  CredentialExpiryCredentialType = "STORAGE"
+)
+
+type CredentialExpiryExpiringCredential struct {
+	DaysLeft    *int64  `json:"daysLeft,omitempty"`
+	ExpireAt    *string `json:"expireAt,omitempty"`
+	Id          *string `json:"id,omitempty"`
+	IsExpired   *bool   `json:"isExpired,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Type        any     `json:"type,omitempty"`
+	WorkspaceId *string `json:"workspaceId,omitempty"`
+}
+
+type CredentialExpiryExpiringCredentialsResponse struct {
+	Credentials []*CredentialExpiryExpiringCredential `json:"credentials,omitempty"`
+	WithinDays  *int64                                `json:"withinDays,omitempty"`
+}
+
+type DatabasesCreateReadOnlyUserResponse struct {
+	Password *string `json:"password,omitempty"`
+	Username *string `json:"username,omitempty"`
+}
+
+type DatabasesCredentialSource struct {
+	Type           any     `json:"type,omitempty"`
+	VaultAddress   *string `json:"vaultAddress,omitempty"`
+	VaultNamespace *string `json:"vaultNamespace,omitempty"`
+	VaultPath      *string `json:"vaultPath,omitempty"`
+	VaultToken     *string `json:"vaultToken,omitempty"`
+	// WebhookSecret is sent as a bearer token
+	WebhookSecret *string `json:"webhookSecret,omitempty"`
+	WebhookUrl    *string `json:"webhookUrl,omitempty"`
+}
+
+type DatabasesCredentialSourceType string
+
+const (
+	DatabasesCredentialSourceTypeWebhook DatabasesCredentialSourceType = "WEBHOOK"
+	DatabasesCredentialSourceTypeVault   DatabasesCredentialSourceType = "VAULT"
+)
+
+type DatabasesDatabase struct {
+	// AgentID is set for databases reachable only from a private network. Backups are
+	// dumped by the agent there instead of by backup nodes
+	AgentId   *string                     `json:"agentId,omitempty"`
+	Cassandra *CassandraCassandraDatabase `json:"cassandra,omitempty"`
+	// ChangeReason is sent with edits and written to the audit log, it is required in
+	// workspaces with IsChangeReasonRequired. Not stored on the database
+	ChangeReason *string                         `json:"changeReason,omitempty"`
+	Cockroachdb  *CockroachdbCockroachdbDatabase `json:"cockroachdb,omitempty"`
+	// CredentialSource is set when credentials are fetched before connecting, the password
+	// of the connection is not stored then
+	CredentialSource *DatabasesCredentialSource `json:"credentialSource,omitempty"`
+	// CredentialsExpireAt is when the password of the connection has to be rotated,
+	// notifiers of the database are reminded before it
+	CredentialsExpireAt *string `json:"credentialsExpireAt,omitempty"`
+	// DNSOverride resolves the host of the connection with overrides or another DNS server,
+	// for nodes resolving names unlike app servers
+	DnsOverride   *NetworkUtilsDNSOverride            `json:"dnsOverride,omitempty"`
+	Elasticsearch *ElasticsearchElasticsearchDatabase `json:"elasticsearch,omitempty"`
+	// Environment is empty when the database takes the environment of its workspace
+	Environment  *EnvironmentsEnvironment      `json:"environment,omitempty"`
+	Filesystem   *FilesystemFilesystemDatabase `json:"filesystem,omitempty"`
+	HealthStatus *DatabasesHealthStatus        `json:"healthStatus,omitempty"`
+	Id           *string                       `json:"id,omitempty"`
+	Influxdb     *InfluxdbInfluxdbDatabase     `json:"influxdb,omitempty"`
+	// IsDefaultNotifiersOptOut keeps default notifiers of the workspace from being attached
+	IsDefaultNotifiersOptOut *bool   `json:"isDefaultNotifiersOptOut,omitempty"`
+	LastBackupErrorMessage   *string `json:"lastBackupErrorMessage,omitempty"`
+	// these fields are not reliable, but
+	// they are used for pretty UI
+	LastBackupTime *string                       `json:"lastBackupTime,omitempty"`
+	Mariadb        *MariadbMariadbDatabase       `json:"mariadb,omitempty"`
+	Mongodb        *MongodbMongodbDatabase       `json:"mongodb,omitempty"`
+	Mysql          *MysqlMysqlDatabase           `json:"mysql,omitempty"`
+	Name           *string                       `json:"name,omitempty"`
+	Neo4j          *Neo4jNeo4jDatabase           `json:"neo4j,omitempty"`
+	Notifiers      []*NotifiersNotifier          `json:"notifiers,omitempty"`
+	OwnerTeam      *string                       `json:"ownerTeam,omitempty"`
+	OwnerUserId    *string                       `json:"ownerUserId,omitempty"`
+	Postgresql     *PostgresqlPostgresqlDatabase `json:"postgresql,omitempty"`
+	Type           any                           `json:"type,omitempty"`
+	// WorkspaceID can be null when a database is created via restore operation
+	// outside the context of any workspace
+	WorkspaceId *string `json:"workspaceId,omitempty"`
+}
+
+type DatabasesDatabaseType string
+
+const (
+	DatabasesDatabaseTypePostgres      DatabasesDatabaseType = "POSTGRES"
+	DatabasesDatabaseTypeMysql         DatabasesDatabaseType = "MYSQL"
+	DatabasesDatabaseTypeMariadb       DatabasesDatabaseType = "MARIADB"
+	DatabasesDatabaseTypeMongodb       DatabasesDatabaseType = "MONGODB"
+	DatabasesDatabaseTypeFilesystem    DatabasesDatabaseType = "FILESYSTEM"
+	DatabasesDatabaseTypeElasticsearch DatabasesDatabaseType = "ELASTICSEARCH"
+	DatabasesDatabaseTypeInfluxdb      DatabasesDatabaseType = "INFLUXDB"
+	DatabasesDatabaseTypeCassandra     DatabasesDatabaseType = "CASSANDRA"
+	DatabasesDatabaseTypeCockroachdb   DatabasesDatabaseType = "COCKROACHDB"
+	DatabasesDatabaseTypeNeo4j         DatabasesDatabaseType = "NEO4J"
+)
+
+type DatabasesHealthStatus string
+
+const (
+	DatabasesHealthStatusAvailable   DatabasesHealthStatus = "AVAILABLE"
+	DatabasesHealthStatusUnavailable DatabasesHealthStatus = "UNAVAILABLE"
+)
+
+type DatabasesIsReadOnlyResponse struct {
+	IsReadOnly *bool    `json:"isReadOnly,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+}
+
+type DatabasesTemplatesConnectionTemplate struct {
+	// Connection keeps only the specific database of the type, with secrets encrypted like
+	// on databases
+	Connection  *DatabasesDatabase `json:"connection,omitempty"`
+	CreatedAt   *string            `json:"createdAt,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Id          *string            `json:"id,omitempty"`
+	Name        *string            `json:"name,omitempty"`
+	Type        any                `json:"type,omitempty"`
+}
+
+type DatabasesTemplatesConnectionTemplateResponse struct {
+	Connection  *DatabasesDatabase `json:"connection,omitempty"`
+	CreatedAt   *string            `json:"createdAt,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Id          *string            `json:"id,omitempty"`
+	Name        *string            `json:"name,omitempty"`
+	Type        any                `json:"type,omitempty"`
+}
+
+type DatabasesTemplatesCreateDatabaseFromTemplateRequest struct {
+	Name        string `json:"name"`
+	WorkspaceId string `json:"workspaceId"`
+}
+
+type DiscordNotifierDiscordNotifier struct {
+	ChannelWebhookUrl *string `json:"channelWebhookUrl,omitempty"`
+	NotifierId        *string `json:"notifierId,omitempty"`
+}
+
+type DiskDiskUsage struct {
+	FreeSpaceBytes  *int64        `json:"freeSpaceBytes,omitempty"`
+	Platform        *DiskPlatform `json:"platform,omitempty"`
+	TotalSpaceBytes *int64        `json:"totalSpaceBytes,omitempty"`
+	UsedSpaceBytes  *int64        `json:"usedSpaceBytes,omitempty"`
+}
+
+type DiskPlatform string
+
+const (
+	DiskPlatformLinux   DiskPlatform = "linux"
+	DiskPlatformWindows DiskPlatform = "windows"
+)
+
+type ElasticsearchElasticsearchDatabase struct {
+	DatabaseId *string `json:"databaseId,omitempty"`
+	// Flavor and Version are detected on connection
+	Flavor *ElasticsearchElasticsearchFlavor `json:"flavor,omitempty"`
+	Id     *string                           `json:"id,omitempty"`
+	// Indices is a comma separated list of indices, data streams and patterns
+	Indices *string `json:"indices,omitempty"`
+	// restore only: delete indices of the snapshot before restoring them in place
+	IsDeleteExistingIndices *bool   `json:"isDeleteExistingIndices,omitempty"`
+	IsIncludeGlobalState    *bool   `json:"isIncludeGlobalState,omitempty"`
+	Password                *string `json:"password,omitempty"`
+	// Repository is a snapshot repository registered in the cluster
+	Repository *string `json:"repository,omitempty"`
+	// restore only: prefix for restored index names, they are restored under their
+	// original names when empty
+	RestoreIndexPrefix *string `json:"restoreIndexPrefix,omitempty"`
+	SkipTlsVerify      *bool   `json:"skipTlsVerify,omitempty"`
+	Url                *string `json:"url,omitempty"`
+	Username           *string `json:"username,omitempty"`
+	Version            *string `json:"version,omitempty"`
+}
+
+type ElasticsearchElasticsearchFlavor string
+
+const (
+	ElasticsearchElasticsearchFlavorElasticsearch ElasticsearchElasticsearchFlavor = "ELASTICSEARCH"
+	ElasticsearchElasticsearchFlavorOpensearch    ElasticsearchElasticsearchFlavor = "OPENSEARCH"
+)
+
+type EmailNotifierEmailNotifier struct {
+	CustomCaCert  *string                  `json:"customCaCert,omitempty"`
+	From          *string                  `json:"from,omitempty"`
+	MinTlsVersion *EmailNotifierTLSVersion `json:"minTlsVersion,omitempty"`
+	NotifierId    *string                  `json:"notifierId,omitempty"`
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this notifier. HTTP proxies have to allow
+	// CONNECT to the SMTP port, see proxy_utils.DialContext
+	ProxyUrl     *string `json:"proxyUrl,omitempty"`
+	SmtpHost     *string `json:"smtpHost,omitempty"`
+	SmtpPassword *string `json:"smtpPassword,omitempty"`
+	SmtpPort     *int64  `json:"smtpPort,omitempty"`
+	SmtpUser     *string `json:"smtpUser,omitempty"`
+	TargetEmail  *string `json:"targetEmail,omitempty"`
+	// Empty TLS policy is opportunistic, as for notifiers created before policies. Custom CA
+	// is a PEM bundle trusted in addition to system CAs
+	TlsPolicy *EmailNotifierTLSPolicy `json:"tlsPolicy,omitempty"`
+}
+
+type EmailNotifierTLSPolicy string
+
+const (
+	EmailNotifierTLSPolicyOpportunistic    EmailNotifierTLSPolicy = "OPPORTUNISTIC"
+	EmailNotifierTLSPolicyRequired         EmailNotifierTLSPolicy = "REQUIRED"
+	EmailNotifierTLSPolicyPlaintextAllowed EmailNotifierTLSPolicy = "PLAINTEXT_ALLOWED"
+)
+
+type EmailNotifierTLSVersion string
+
+const (
+	EmailNotifierTLSVersionTls10 EmailNotifierTLSVersion = "TLS1.0"
+	EmailNotifierTLSVersionTls11 EmailNotifierTLSVersion = "TLS1.1"
+	EmailNotifierTLSVersionTls12 EmailNotifierTLSVersion = "TLS1.2"
+	EmailNotifierTLSVersionTls13 EmailNotifierTLSVersion = "TLS1.3"
+)
+
+type EnvironmentsEnvironment string
+
+const (
+	EnvironmentsEnvironmentProd    EnvironmentsEnvironment = "PROD"
+	EnvironmentsEnvironmentStaging EnvironmentsEnvironment = "STAGING"
+	EnvironmentsEnvironmentDev     EnvironmentsEnvironment = "DEV"
+)
+
+type FeatureFlagsFeatureFlag string
+
+const (
+	FeatureFlagsFeatureFlagPitrBeta FeatureFlagsFeatureFlag = "pitr_beta"
+)
+
+type FeatureFlagsFeatureFlagDTO struct {
+	Flag      *FeatureFlagsFeatureFlag `json:"flag,omitempty"`
+	IsEnabled *bool                    `json:"isEnabled,omitempty"`
+	// IsOverridden is true when value comes from workspace override
+	// instead of instance configuration
+	IsOverridden *bool `json:"isOverridden,omitempty"`
+}
+
+type FeatureFlagsGetFeaturesResponse struct {
+	Features []*FeatureFlagsFeatureFlagDTO `json:"features,omitempty"`
+}
+
+type FeatureFlagsSetWorkspaceOverrideRequest struct {
+	Flag      *FeatureFlagsFeatureFlag `json:"flag"`
+	IsEnabled *bool                    `json:"isEnabled,omitempty"`
+}
+
+type FeatureFlagsWorkspaceFeatureFlag struct {
+	Flag        *FeatureFlagsFeatureFlag `json:"flag,omitempty"`
+	Id          *string                  `json:"id,omitempty"`
+	IsEnabled   *bool                    `json:"isEnabled,omitempty"`
+	UpdatedAt   *string                  `json:"updatedAt,omitempty"`
+	WorkspaceId *string                  `json:"workspaceId,omitempty"`
+}
+
+type FilesUtilsDirectoryEntry struct {
+	IsDirectory *bool   `json:"isDirectory,omitempty"`
+	ModifiedAt  *string `json:"modifiedAt,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	SizeBytes   *int64  `json:"sizeBytes,omitempty"`
+}
+
+type FilesystemFilesystemCompression string
+
+const (
+	FilesystemFilesystemCompressionZstd FilesystemFilesystemCompression = "ZSTD"
+	FilesystemFilesystemCompressionGzip FilesystemFilesystemCompression = "GZIP"
+	FilesystemFilesystemCompressionNone FilesystemFilesystemCompression = "NONE"
+)
+
+type FilesystemFilesystemDatabase struct {
+	Compression  *FilesystemFilesystemCompression `json:"compression,omitempty"`
+	DatabaseId   *string                          `json:"databaseId,omitempty"`
+	ExcludeGlobs []string                         `json:"excludeGlobs,omitempty"`
+	Id           *string                          `json:"id,omitempty"`
+	// backup settings. Globs are matched against paths relative to Path, a glob without
+	// "/" matches the name in any directory. Globs are stored newline separated, they
+	// may contain commas
+	IncludeGlobs []string `json:"includeGlobs,omitempty"`
+	// restore settings (not saved to DB)
+	IsRemoveExistingFiles *bool `json:"isRemoveExistingFiles,omitempty"`
+	// LvmSnapshotSize is copy-on-write space of the snapshot in lvcreate format, e.g. 1G.
+	// The snapshot becomes invalid if more data is changed during the backup
+	LvmSnapshotSize *string                           `json:"lvmSnapshotSize,omitempty"`
+	Path            *string                           `json:"path,omitempty"`
+	SnapshotMode    *FilesystemFilesystemSnapshotMode `json:"snapshotMode,omitempty"`
+}
+
+type FilesystemFilesystemSnapshotMode string
+
+const (
+	FilesystemFilesystemSnapshotModeNone  FilesystemFilesystemSnapshotMode = "NONE"
+	FilesystemFilesystemSnapshotModeLvm   FilesystemFilesystemSnapshotMode = "LVM"
+	FilesystemFilesystemSnapshotModeBtrfs FilesystemFilesystemSnapshotMode = "BTRFS"
+)
+
+type FtpStorageFTPStorage struct {
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DnsOverride *NetworkUtilsDNSOverride `json:"dnsOverride,omitempty"`
+	Host        *string                  `json:"host,omitempty"`
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IpPreference  *NetworkUtilsIPPreference `json:"ipPreference,omitempty"`
+	Password      *string                   `json:"password,omitempty"`
+	Path          *string                   `json:"path,omitempty"`
+	Port          *int64                    `json:"port,omitempty"`
+	SkipTlsVerify *bool                     `json:"skipTlsVerify,omitempty"`
+	StorageId     *string                   `json:"storageId,omitempty"`
+	UseSsl        *bool                     `json:"useSsl,omitempty"`
+	Username      *string                   `json:"username,omitempty"`
+}
+
+type GcsStorageAuthMethod string
+
+const (
+	GcsStorageAuthMethodServiceAccountKey GcsStorageAuthMethod = "SERVICE_ACCOUNT_KEY"
+	GcsStorageAuthMethodWorkloadIdentity  GcsStorageAuthMethod = "WORKLOAD_IDENTITY"
+)
+
+type GcsStorageGCSStorage struct {
+	AuthMethod        *GcsStorageAuthMethod `json:"authMethod,omitempty"`
+	Bucket            *string               `json:"bucket,omitempty"`
+	Prefix            *string               `json:"prefix,omitempty"`
+	ServiceAccountKey *string               `json:"serviceAccountKey,omitempty"`
+	// StorageClass of uploaded objects, empty uses the default class of the bucket
+	StorageClass *string `json:"storageClass,omitempty"`
+	StorageId    *string `json:"storageId,omitempty"`
+}
+
+type GoogleDriveStorageGoogleDriveStorage struct {
+	ClientId     *string `json:"clientId,omitempty"`
+	ClientSecret *string `json:"clientSecret,omitempty"`
+	StorageId    *string `json:"storageId,omitempty"`
+	TokenJson    *string `json:"tokenJson,omitempty"`
+}
+
+type GraphqlGraphQLError struct {
+	Message *string `json:"message,omitempty"`
+	Path    []any   `json:"path,omitempty"`
+}
+
+type GraphqlGraphQLRequest struct {
+	OperationName *string        `json:"operationName,omitempty"`
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type GraphqlGraphQLResponse struct {
+	Data   map[string]any         `json:"data,omitempty"`
+	Errors []*GraphqlGraphQLError `json:"errors,omitempty"`
+}
+
+type HealthcheckAttemptHealthcheckAttempt struct {
+	CreatedAt  *string                `json:"createdAt,omitempty"`
+	DatabaseId *string                `json:"databaseId,omitempty"`
+	Id         *string                `json:"id,omitempty"`
+	Status     *DatabasesHealthStatus `json:"status,omitempty"`
+}
+
+type HealthcheckConfigHealthcheckConfig struct {
+	AttemptsBeforeConcideredAsDown    *int64  `json:"attemptsBeforeConcideredAsDown,omitempty"`
+	DatabaseId                        *string `json:"databaseId,omitempty"`
+	IntervalMinutes                   *int64  `json:"intervalMinutes,omitempty"`
+	IsHealthcheckEnabled              *bool   `json:"isHealthcheckEnabled,omitempty"`
+	IsSentNotificationWhenUnavailable *bool   `json:"isSentNotificationWhenUnavailable,omitempty"`
+	StoreAttemptsDays                 *int64  `json:"storeAttemptsDays,omitempty"`
+}
+
+type HealthcheckConfigHealthcheckConfigDTO struct {
+	AttemptsBeforeConcideredAsDown    *int64  `json:"attemptsBeforeConcideredAsDown,omitempty"`
+	DatabaseId                        *string `json:"databaseId,omitempty"`
+	IntervalMinutes                   *int64  `json:"intervalMinutes,omitempty"`
+	IsHealthcheckEnabled              *bool   `json:"isHealthcheckEnabled,omitempty"`
+	IsSentNotificationWhenUnavailable *bool   `json:"isSentNotificationWhenUnavailable,omitempty"`
+	StoreAttemptsDays                 *int64  `json:"storeAttemptsDays,omitempty"`
+}
+
+type I18nLocale string
+
+const (
+	I18nLocaleEn I18nLocale = "en"
+	I18nLocaleEs I18nLocale = "es"
+	I18nLocaleDe I18nLocale = "de"
+	I18nLocaleFr I18nLocale = "fr"
+)
+
+type I18nMessageKey string
+
+const (
+	I18nMessageKeyBackupFailedTitle                I18nMessageKey = "backup_failed_title"
+	I18nMessageKeyBackupSuccessTitle               I18nMessageKey = "backup_success_title"
+	I18nMessageKeyBackupSuccessMessage             I18nMessageKey = "backup_success_message"
+	I18nMessageKeyRefreshFailedTitle               I18nMessageKey = "refresh_failed_title"
+	I18nMessageKeyRefreshSuccessTitle              I18nMessageKey = "refresh_success_title"
+	I18nMessageKeyRefreshSuccessMessage            I18nMessageKey = "refresh_success_message"
+	I18nMessageKeyNotifierBrokenTitle              I18nMessageKey = "notifier_broken_title"
+	I18nMessageKeyNotifierBrokenMessage            I18nMessageKey = "notifier_broken_message"
+	I18nMessageKeyCredentialsExpiringTitle         I18nMessageKey = "credentials_expiring_title"
+	I18nMessageKeyCredentialsExpiringMessage       I18nMessageKey = "credentials_expiring_message"
+	I18nMessageKeyCredentialsExpiredTitle          I18nMessageKey = "credentials_expired_title"
+	I18nMessageKeyCredentialsExpiredMessage        I18nMessageKey = "credentials_expired_message"
+	I18nMessageKeyStorageQuotaSoftExceededTitle    I18nMessageKey = "storage_quota_soft_exceeded_title"
+	I18nMessageKeyStorageQuotaSoftExceededMessage  I18nMessageKey = "storage_quota_soft_exceeded_message"
+	I18nMessageKeyStorageQuotaHardExceededTitle    I18nMessageKey = "storage_quota_hard_exceeded_title"
+	I18nMessageKeyStorageQuotaHardExceededMessage  I18nMessageKey = "storage_quota_hard_exceeded_message"
+	I18nMessageKeySecurityNewDeviceTitle           I18nMessageKey = "security_new_device_title"
+	I18nMessageKeySecurityNewDeviceMessage         I18nMessageKey = "security_new_device_message"
+	I18nMessageKeySecurityFailedSignInsTitle       I18nMessageKey = "security_failed_sign_ins_title"
+	I18nMessageKeySecurityFailedSignInsMessage     I18nMessageKey = "security_failed_sign_ins_message"
+	I18nMessageKeySecurityPolicyChangedTitle       I18nMessageKey = "security_policy_changed_title"
+	I18nMessageKeySecurityPolicyChangedMessage     I18nMessageKey = "security_policy_changed_message"
+	I18nMessageKeyTestEventNote                    I18nMessageKey = "test_event_note"
+	I18nMessageKeyDatabaseOnlineTitle              I18nMessageKey = "database_online_title"
+	I18nMessageKeyDatabaseOnlineMessage            I18nMessageKey = "database_online_message"
+	I18nMessageKeyDatabaseUnavailableTitle         I18nMessageKey = "database_unavailable_title"
+	I18nMessageKeyDatabaseUnavailableMessage       I18nMessageKey = "database_unavailable_message"
+	I18nMessageKeyEmailPasswordResetSubject        I18nMessageKey = "email_password_reset_subject"
+	I18nMessageKeyEmailPasswordResetHeading        I18nMessageKey = "email_password_reset_heading"
+	I18nMessageKeyEmailPasswordResetIntro          I18nMessageKey = "email_password_reset_intro"
+	I18nMessageKeyEmailPasswordResetExpiration     I18nMessageKey = "email_password_reset_expiration"
+	I18nMessageKeyEmailPasswordResetIgnore         I18nMessageKey = "email_password_reset_ignore"
+	I18nMessageKeyEmailPasswordResetAutomated      I18nMessageKey = "email_password_reset_automated"
+	I18nMessageKeyEmailPasswordResetLinkIntro      I18nMessageKey = "email_password_reset_link_intro"
+	I18nMessageKeyEmailPasswordResetButton         I18nMessageKey = "email_password_reset_button"
+	I18nMessageKeyEmailVerificationSubject         I18nMessageKey = "email_verification_subject"
+	I18nMessageKeyEmailVerificationHeading         I18nMessageKey = "email_verification_heading"
+	I18nMessageKeyEmailVerificationIntro           I18nMessageKey = "email_verification_intro"
+	I18nMessageKeyEmailVerificationButton          I18nMessageKey = "email_verification_button"
+	I18nMessageKeyEmailVerificationPasteToken      I18nMessageKey = "email_verification_paste_token"
+	I18nMessageKeyEmailVerificationExpiration      I18nMessageKey = "email_verification_expiration"
+	I18nMessageKeyEmailVerificationIgnore          I18nMessageKey = "email_verification_ignore"
+	I18nMessageKeyEmailVerificationAutomated       I18nMessageKey = "email_verification_automated"
+	I18nMessageKeyEmailOwnedBackupFailedReason     I18nMessageKey = "email_owned_backup_failed_reason"
+	I18nMessageKeyEmailInvitationSubject           I18nMessageKey = "email_invitation_subject"
+	I18nMessageKeyEmailInvitationHeading           I18nMessageKey = "email_invitation_heading"
+	I18nMessageKeyEmailInvitationBody              I18nMessageKey = "email_invitation_body"
+	I18nMessageKeyEmailInvitationSignUp            I18nMessageKey = "email_invitation_sign_up"
+	I18nMessageKeyEmailInvitationVisitInstance     I18nMessageKey = "email_invitation_visit_instance"
+	I18nMessageKeyEmailInvitationAutomated         I18nMessageKey = "email_invitation_automated"
+	I18nMessageKeyEmailSecurityReleaseSubject      I18nMessageKey = "email_security_release_subject"
+	I18nMessageKeyEmailSecurityReleaseBody         I18nMessageKey = "email_security_release_body"
+	I18nMessageKeyEmailSecurityReleaseReleaseNotes I18nMessageKey = "email_security_release_release_notes"
+)
+
+type ImportsImportPlan struct {
+	Databases []*ImportsPlannedDatabase `json:"databases,omitempty"`
+	Warnings  []string                  `json:"warnings,omitempty"`
+}
+
+type ImportsImportResult struct {
+	Databases []*ImportsImportedDatabase `json:"databases,omitempty"`
+}
+
+type ImportsImportSource string
+
+const (
+	ImportsImportSourcePgbackrest ImportsImportSource = "PGBACKREST"
+	ImportsImportSourceWalg       ImportsImportSource = "WALG"
+	ImportsImportSourceBarman     ImportsImportSource = "BARMAN"
+)
+
+type ImportsImportedDatabase struct {
+	DatabaseId *string `json:"databaseId,omitempty"`
+	Error      *string `json:"error,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	StorageId  *string `json:"storageId,omitempty"`
+}
+
+type ImportsParseImportRequest struct {
+	Config string `json:"config"`
+	// Crontab with backup commands of the tool, schedules and WAL-G retention are read
+	// from it
+	Crontab *string              `json:"crontab,omitempty"`
+	Source  *ImportsImportSource `json:"source"`
+}
+
+type ImportsPlannedDatabase struct {
+	CronExpression *string `json:"cronExpression,omitempty"`
+	Database       *string `json:"database,omitempty"`
+	Host           *string `json:"host,omitempty"`
+	Name           *string `json:"name,omitempty"`
+	// Password is empty when the tool relies on .pgpass or peer auth
+	Password    *string          `json:"password,omitempty"`
+	Port        *int64           `json:"port,omitempty"`
+	Storage     *StoragesStorage `json:"storage,omitempty"`
+	StorePeriod *PeriodPeriod    `json:"storePeriod,omitempty"`
+	Username    *string          `json:"username,omitempty"`
+	Warnings    []string         `json:"warnings,omitempty"`
+}
+
+type InfluxdbInfluxdbDatabase struct {
+	Bucket     *string `json:"bucket,omitempty"`
+	DatabaseId *string `json:"databaseId,omitempty"`
+	Id         *string `json:"id,omitempty"`
+	// restore only: replace all data and metadata of the server, tokens included
+	IsFullRestore *bool   `json:"isFullRestore,omitempty"`
+	Org           *string `json:"org,omitempty"`
+	// restore only: restore the bucket of a bucket backup under this name
+	RestoreBucket *string `json:"restoreBucket,omitempty"`
+	SkipTlsVerify *bool   `json:"skipTlsVerify,omitempty"`
+	// Token must be an operator token for full backups, an all access token is enough
+	// with Bucket
+	Token *string `json:"token,omitempty"`
+	Url   *string `json:"url,omitempty"`
+	// Version is detected on connection
+	Version *string `json:"version,omitempty"`
+}
+
+type IntervalsInterval struct {
+	// only for CRON
+	CronExpression *string `json:"cronExpression,omitempty"`
+	// only for MONTHLY
+	DayOfMonth *int64                 `json:"dayOfMonth,omitempty"`
+	Id         *string                `json:"id,omitempty"`
+	Interval   *IntervalsIntervalType `json:"interval,omitempty"`
+	TimeOfDay  *string                `json:"timeOfDay,omitempty"`
+	// only for WEEKLY
+	Weekday *int64 `json:"weekday,omitempty"`
+}
+
+type IntervalsIntervalType string
+
+const (
+	IntervalsIntervalTypeHourly  IntervalsIntervalType = "HOURLY"
+	IntervalsIntervalTypeDaily   IntervalsIntervalType = "DAILY"
+	IntervalsIntervalTypeWeekly  IntervalsIntervalType = "WEEKLY"
+	IntervalsIntervalTypeMonthly IntervalsIntervalType = "MONTHLY"
+	IntervalsIntervalTypeCron    IntervalsIntervalType = "CRON"
+)
+
+type LocalStorageLocalStorage struct {
+	StorageId *string `json:"storageId,omitempty"`
+}
+
+type LocalizationGetMessagesResponse struct {
+	Locale           *I18nLocale                        `json:"locale,omitempty"`
+	Messages         []*LocalizationLocalizedMessageDTO `json:"messages,omitempty"`
+	SupportedLocales []*I18nLocale                      `json:"supportedLocales,omitempty"`
+}
+
+type LocalizationLocalizedMessageDTO struct {
+	DefaultTemplate *string         `json:"defaultTemplate,omitempty"`
+	Key             *I18nMessageKey `json:"key,omitempty"`
+	Override        *string         `json:"override,omitempty"`
+}
+
+type LocalizationMessageTemplateOverride struct {
+	Id         *string         `json:"id,omitempty"`
+	Locale     *I18nLocale     `json:"locale,omitempty"`
+	MessageKey *I18nMessageKey `json:"messageKey,omitempty"`
+	Template   *string         `json:"template,omitempty"`
+	UpdatedAt  *string         `json:"updatedAt,omitempty"`
+}
+
+type LocalizationUpsertOverrideRequest struct {
+	Key      *I18nMessageKey `json:"key"`
+	Locale   *I18nLocale     `json:"locale"`
+	Template string          `json:"template"`
+}
+
+type MariadbMariadbDatabase struct {
+	Database   *string              `json:"database,omitempty"`
+	DatabaseId *string              `json:"databaseId,omitempty"`
+	Host       *string              `json:"host,omitempty"`
+	Id         *string              `json:"id,omitempty"`
+	IsHttps    *bool                `json:"isHttps,omitempty"`
+	Password   *string              `json:"password,omitempty"`
+	Port       *int64               `json:"port,omitempty"`
+	Privileges *string              `json:"privileges,omitempty"`
+	Username   *string              `json:"username,omitempty"`
+	Version    *ToolsMariadbVersion `json:"version,omitempty"`
+}
+
+type MaskingMaskingRule struct {
+	Column     *string `json:"column,omitempty"`
+	CreatedAt  *string `json:"createdAt,omitempty"`
+	DatabaseId *string `json:"databaseId,omitempty"`
+	Id         *string `json:"id,omitempty"`
+	// Schema is the PostgreSQL schema, public when empty. MySQL and MariaDB mask tables of
+	// the restored database when empty
+	Schema   *string                 `json:"schema,omitempty"`
+	Strategy *MaskingMaskingStrategy `json:"strategy,omitempty"`
+	Table    *string                 `json:"table,omitempty"`
+}
+
+type MaskingMaskingStrategy string
+
+const (
+	MaskingMaskingStrategyHash      MaskingMaskingStrategy = "HASH"
+	MaskingMaskingStrategyEmail     MaskingMaskingStrategy = "EMAIL"
+	MaskingMaskingStrategyNull      MaskingMaskingStrategy = "NULL"
+	MaskingMaskingStrategyRedact    MaskingMaskingStrategy = "REDACT"
+	MaskingMaskingStrategyKeepLast4 MaskingMaskingStrategy = "KEEP_LAST_4"
+)
+
+type MaskingSaveMaskingRulesRequest struct {
+	Rules []*MaskingMaskingRule `json:"rules,omitempty"`
+}
+
+type MongodbMongodbDatabase struct {
+	AuthDatabase *string              `json:"authDatabase,omitempty"`
+	CpuCount     *int64               `json:"cpuCount,omitempty"`
+	Database     *string              `json:"database,omitempty"`
+	DatabaseId   *string              `json:"databaseId,omitempty"`
+	Host         *string              `json:"host,omitempty"`
+	Id           *string              `json:"id,omitempty"`
+	IsHttps      *bool                `json:"isHttps,omitempty"`
+	IsSrv        *bool                `json:"isSrv,omitempty"`
+	Password     *string              `json:"password,omitempty"`
+	Port         *int64               `json:"port,omitempty"`
+	Username     *string              `json:"username,omitempty"`
+	Version      *ToolsMongodbVersion `json:"version,omitempty"`
+}
+
+type MysqlMysqlDatabase struct {
+	Database   *string            `json:"database,omitempty"`
+	DatabaseId *string            `json:"databaseId,omitempty"`
+	Host       *string            `json:"host,omitempty"`
+	Id         *string            `json:"id,omitempty"`
+	IsHttps    *bool              `json:"isHttps,omitempty"`
+	Password   *string            `json:"password,omitempty"`
+	Port       *int64             `json:"port,omitempty"`
+	Privileges *string            `json:"privileges,omitempty"`
+	Username   *string            `json:"username,omitempty"`
+	Version    *ToolsMysqlVersion `json:"version,omitempty"`
+}
+
+type NasStorageNASStorage struct {
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DnsOverride *NetworkUtilsDNSOverride `json:"dnsOverride,omitempty"`
+	Domain      *string                  `json:"domain,omitempty"`
+	Host        *string                  `json:"host,omitempty"`
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IpPreference *NetworkUtilsIPPreference `json:"ipPreference,omitempty"`
+	Password     *string                   `json:"password,omitempty"`
+	Path         *string                   `json:"path,omitempty"`
+	Port         *int64                    `json:"port,omitempty"`
+	Share        *string                   `json:"share,omitempty"`
+	StorageId    *string                   `json:"storageId,omitempty"`
+	UseSsl       *bool                     `json:"useSsl,omitempty"`
+	Username     *string                   `json:"username,omitempty"`
+}
+
+type Neo4jNeo4jBackupMode string
+
+const (
+	Neo4jNeo4jBackupModeDump         Neo4jNeo4jBackupMode = "DUMP"
+	Neo4jNeo4jBackupModeOnlineBackup Neo4jNeo4jBackupMode = "ONLINE_BACKUP"
+)
+
+type Neo4jNeo4jDatabase struct {
+	BackupMode *Neo4jNeo4jBackupMode `json:"backupMode,omitempty"`
+	Database   *string               `json:"database,omitempty"`
+	DatabaseId *string               `json:"databaseId,omitempty"`
+	Edition    *Neo4jNeo4jEdition    `json:"edition,omitempty"`
+	Id         *string               `json:"id,omitempty"`
+	// IsUseSudo runs neo4j-admin as the neo4j user and the service commands as root with
+	// `sudo -n`, otherwise the SSH user must own the Neo4j data directory
+	IsUseSudo *bool   `json:"isUseSudo,omitempty"`
+	Password  *string `json:"password,omitempty"`
+	// restore only: restore into this database, the backed up name when empty
+	RestoreDatabase *string `json:"restoreDatabase,omitempty"`
+	// ServiceName is stopped during dumps of Community Edition, it cannot stop a database
+	// alone
+	ServiceName   *string `json:"serviceName,omitempty"`
+	SkipTlsVerify *bool   `json:"skipTlsVerify,omitempty"`
+	// SshHost runs neo4j-admin, the host of the URL when empty
+	SshHost       *string `json:"sshHost,omitempty"`
+	SshPassword   *string `json:"sshPassword,omitempty"`
+	SshPort       *int64  `json:"sshPort,omitempty"`
+	SshPrivateKey *string `json:"sshPrivateKey,omitempty"`
+	SshUsername   *string `json:"sshUsername,omitempty"`
+	// restore only: directory on the host where online backups are uploaded to restore them
+	StagingDirectory *string `json:"stagingDirectory,omitempty"`
+	// URL is the HTTP API, e.g. http://neo4j.internal:7474
+	Url      *string `json:"url,omitempty"`
+	Username *string `json:"username,omitempty"`
+	// Version and Edition are detected on connection
+	Version *string `json:"version,omitempty"`
+}
+
+type Neo4jNeo4jEdition string
+
+const (
+	Neo4jNeo4jEditionCommunity  Neo4jNeo4jEdition = "community"
+	Neo4jNeo4jEditionEnterprise Neo4jNeo4jEdition = "enterprise"
+)
+
+type NetworkUtilsDNSOverride struct {
+	// Hosts maps host names to IP addresses like /etc/hosts, they win over Server
+	Hosts map[string]string `json:"hosts,omitempty"`
+	// Server is a DNS server like 10.0.0.53 or 10.0.0.53:5353 asked for other hosts
+	Server *string `json:"server,omitempty"`
+}
+
+type NetworkUtilsIPPreference string
+
+const (
+	NetworkUtilsIPPreferenceAuto NetworkUtilsIPPreference = "AUTO"
+	NetworkUtilsIPPreferenceIpv4 NetworkUtilsIPPreference = "IPV4"
+	NetworkUtilsIPPreferenceIpv6 NetworkUtilsIPPreference = "IPV6"
+)
+
+type NotifierIncidentsSeverity string
+
+const (
+	NotifierIncidentsSeverityInfo     NotifierIncidentsSeverity = "INFO"
+	NotifierIncidentsSeverityWarning  NotifierIncidentsSeverity = "WARNING"
+	NotifierIncidentsSeverityError    NotifierIncidentsSeverity = "ERROR"
+	NotifierIncidentsSeverityCritical NotifierIncidentsSeverity = "CRITICAL"
+)
+
+type NotifiersEventSeverityResponse struct {
+	DefaultSeverity *NotifierIncidentsSeverity      `json:"defaultSeverity,omitempty"`
+	Description     *string                         `json:"description,omitempty"`
+	EventType       *NotifiersNotificationEventType `json:"eventType,omitempty"`
+	IsOverridden    *bool                           `json:"isOverridden,omitempty"`
+	Severity        *NotifierIncidentsSeverity      `json:"severity,omitempty"`
+}
+
+type NotifiersNotificationEvent struct {
+	DefaultSeverity *NotifierIncidentsSeverity `json:"defaultSeverity,omitempty"`
+	Description     *string                    `json:"description,omitempty"`
+	Type            any                        `json:"type,omitempty"`
+}
+
+type NotifiersNotificationEventType string
+
+const (
+	NotifiersNotificationEventTypeBackupFailed             NotifiersNotificationEventType = "backup_failed"
+	NotifiersNotificationEventTypeBackupSuccess            NotifiersNotificationEventType = "backup_success"
+	NotifiersNotificationEventTypeRefreshFailed            NotifiersNotificationEventType = "refresh_failed"
+	NotifiersNotificationEventTypeRefreshSuccess           NotifiersNotificationEventType = "refresh_success"
+	NotifiersNotificationEventTypeDatabaseUnavailable      NotifiersNotificationEventType = "database_unavailable"
+	NotifiersNotificationEventTypeDatabaseOnline           NotifiersNotificationEventType = "database_online"
+	NotifiersNotificationEventTypeNotifierBroken           NotifiersNotificationEventType = "notifier_broken"
+	NotifiersNotificationEventTypeCredentialsExpiring      NotifiersNotificationEventType = "credentials_expiring"
+	NotifiersNotificationEventTypeCredentialsExpired       NotifiersNotificationEventType = "credentials_expired"
+	NotifiersNotificationEventTypeStorageQuotaSoftExceeded NotifiersNotificationEventType = "storage_quota_soft_exceeded"
+	NotifiersNotificationEventTypeStorageQuotaHardExceeded NotifiersNotificationEventType = "storage_quota_hard_exceeded"
+	NotifiersNotificationEventTypeSecurityNewDeviceSignIn  NotifiersNotificationEventType = "security_new_device_sign_in"
+	NotifiersNotificationEventTypeSecurityFailedSignIns    NotifiersNotificationEventType = "security_failed_sign_ins"
+	NotifiersNotificationEventTypeSecurityPolicyChanged    NotifiersNotificationEventType = "security_policy_changed"
+)
+
+type NotifiersNotificationSeverityOverride struct {
+	EventType   *NotifiersNotificationEventType `json:"eventType,omitempty"`
+	Severity    *NotifierIncidentsSeverity      `json:"severity,omitempty"`
+	UpdatedAt   *string                         `json:"updatedAt,omitempty"`
+	WorkspaceId *string                         `json:"workspaceId,omitempty"`
+}
+
+type NotifiersNotifier struct {
+	// ChangeReason is sent with edits and written to the audit log, it is required in
+	// workspaces with IsChangeReasonRequired. Not stored on the notifier
+	ChangeReason     *string                         `json:"changeReason,omitempty"`
+	DiscordNotifier  *DiscordNotifierDiscordNotifier `json:"discordNotifier,omitempty"`
+	EmailNotifier    *EmailNotifierEmailNotifier     `json:"emailNotifier,omitempty"`
+	HealthCheckError *string                         `json:"healthCheckError,omitempty"`
+	HealthStatus     *NotifiersNotifierHealthStatus  `json:"healthStatus,omitempty"`
+	Id               *string                         `json:"id,omitempty"`
+	// IsSystem notifiers are defined by admins and shared read-only with every workspace,
+	// e.g. a central ops channel
+	IsSystem *bool `json:"isSystem,omitempty"`
+	// IsWorkspaceDefault notifiers are attached to each database created in the workspace,
+	// unless the database opts out of default notifiers
+	IsWorkspaceDefault *bool                               `json:"isWorkspaceDefault,omitempty"`
+	LastHealthCheckAt  *string                             `json:"lastHealthCheckAt,omitempty"`
+	LastSendError      *string                             `json:"lastSendError,omitempty"`
+	Locale             *I18nLocale                         `json:"locale,omitempty"`
+	Name               *string                             `json:"name,omitempty"`
+	NotifierType       *NotifiersNotifierType              `json:"notifierType,omitempty"`
+	OpsgenieNotifier   *OpsgenieNotifierOpsgenieNotifier   `json:"opsgenieNotifier,omitempty"`
+	PagerDutyNotifier  *PagerdutyNotifierPagerDutyNotifier `json:"pagerDutyNotifier,omitempty"`
+	SlackNotifier      *SlackNotifierSlackNotifier         `json:"slackNotifier,omitempty"`
+	TeamsNotifier      *TeamsNotifierTeamsNotifier         `json:"teamsNotifier,omitempty"`
+	// specific notifier
+	TelegramNotifier *TelegramNotifierTelegramNotifier `json:"telegramNotifier,omitempty"`
+	WebhookNotifier  *WebhookNotifierWebhookNotifier   `json:"webhookNotifier,omitempty"`
+	WorkspaceId      *string                           `json:"workspaceId,omitempty"`
+}
+
+type NotifiersNotifierHealthStatus string
+
+const (
+	NotifiersNotifierHealthStatusUnknown NotifiersNotifierHealthStatus = "UNKNOWN"
+	NotifiersNotifierHealthStatusHealthy NotifiersNotifierHealthStatus = "HEALTHY"
+	NotifiersNotifierHealthStatusBroken  NotifiersNotifierHealthStatus = "BROKEN"
+)
+
+type NotifiersNotifierIncident struct {
+	AcknowledgedAt  *string                          `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy  *string                          `json:"acknowledgedBy,omitempty"`
+	CreatedAt       *string                          `json:"createdAt,omitempty"`
+	DedupKey        *string                          `json:"dedupKey,omitempty"`
+	Id              *string                          `json:"id,omitempty"`
+	LastTriggeredAt *string                          `json:"lastTriggeredAt,omitempty"`
+	NotifierId      *string                          `json:"notifierId,omitempty"`
+	ResolvedAt      *string                          `json:"resolvedAt,omitempty"`
+	Status          *NotifiersNotifierIncidentStatus `json:"status,omitempty"`
+	Title           *string                          `json:"title,omitempty"`
+	// TriggerCount includes repeats which were not sent, because the incident was
+	// acknowledged
+	TriggerCount *int64 `json:"triggerCount,omitempty"`
+}
+
+type NotifiersNotifierIncidentStatus string
+
+const (
+	NotifiersNotifierIncidentStatusTriggered    NotifiersNotifierIncidentStatus = "TRIGGERED"
+	NotifiersNotifierIncidentStatusAcknowledged NotifiersNotifierIncidentStatus = "ACKNOWLEDGED"
+	NotifiersNotifierIncidentStatusResolved     NotifiersNotifierIncidentStatus = "RESOLVED"
+)
+
+type NotifiersNotifierResponse struct {
+	DiscordNotifier    *DiscordNotifierDiscordNotifier     `json:"discordNotifier,omitempty"`
+	EmailNotifier      *EmailNotifierEmailNotifier         `json:"emailNotifier,omitempty"`
+	HealthCheckError   *string                             `json:"healthCheckError,omitempty"`
+	HealthStatus       *NotifiersNotifierHealthStatus      `json:"healthStatus,omitempty"`
+	Id                 *string                             `json:"id,omitempty"`
+	IsSystem           *bool                               `json:"isSystem,omitempty"`
+	IsWorkspaceDefault *bool                               `json:"isWorkspaceDefault,omitempty"`
+	LastHealthCheckAt  *string                             `json:"lastHealthCheckAt,omitempty"`
+	LastSendError      *string                             `json:"lastSendError,omitempty"`
+	Locale             *I18nLocale                         `json:"locale,omitempty"`
+	Name               *string                             `json:"name,omitempty"`
+	NotifierType       *NotifiersNotifierType              `json:"notifierType,omitempty"`
+	OpsgenieNotifier   *OpsgenieNotifierOpsgenieNotifier   `json:"opsgenieNotifier,omitempty"`
+	PagerDutyNotifier  *PagerdutyNotifierPagerDutyNotifier `json:"pagerDutyNotifier,omitempty"`
+	SlackNotifier      *SlackNotifierSlackNotifier         `json:"slackNotifier,omitempty"`
+	TeamsNotifier      *TeamsNotifierTeamsNotifier         `json:"teamsNotifier,omitempty"`
+	TelegramNotifier   *TelegramNotifierTelegramNotifier   `json:"telegramNotifier,omitempty"`
+	WebhookNotifier    *WebhookNotifierWebhookNotifier     `json:"webhookNotifier,omitempty"`
+	WorkspaceId        *string                             `json:"workspaceId,omitempty"`
+}
+
+type NotifiersNotifierType string
+
+const (
+	NotifiersNotifierTypeEmail     NotifiersNotifierType = "EMAIL"
+	NotifiersNotifierTypeTelegram  NotifiersNotifierType = "TELEGRAM"
+	NotifiersNotifierTypeWebhook   NotifiersNotifierType = "WEBHOOK"
+	NotifiersNotifierTypeSlack     NotifiersNotifierType = "SLACK"
+	NotifiersNotifierTypeDiscord   NotifiersNotifierType = "DISCORD"
+	NotifiersNotifierTypeTeams     NotifiersNotifierType = "TEAMS"
+	NotifiersNotifierTypePagerduty NotifiersNotifierType = "PAGERDUTY"
+	NotifiersNotifierTypeOpsgenie  NotifiersNotifierType = "OPSGENIE"
+)
+
+type NotifiersTransferNotifierRequest struct {
+	TargetWorkspaceId string `json:"targetWorkspaceId"`
+}
+
+type NotifiersUpdateEventSeverityRequest struct {
+	Severity *NotifierIncidentsSeverity `json:"severity"`
+}
+
+type NotifiersBroadcastsBroadcast struct {
+	CreatedAt       *string                             `json:"createdAt,omitempty"`
+	CreatedByUserId *string                             `json:"createdByUserId,omitempty"`
+	FailedCount     *int64                              `json:"failedCount,omitempty"`
+	FinishedAt      *string                             `json:"finishedAt,omitempty"`
+	Id              *string                             `json:"id,omitempty"`
+	IsSendEmails    *bool                               `json:"isSendEmails,omitempty"`
+	Message         *string                             `json:"message,omitempty"`
+	SentCount       *int64                              `json:"sentCount,omitempty"`
+	Status          *NotifiersBroadcastsBroadcastStatus `json:"status,omitempty"`
+	Title           *string                             `json:"title,omitempty"`
+	// WorkspaceIDs limits the broadcast to these workspaces, empty means all workspaces
+	WorkspaceIds []string `json:"workspaceIds,omitempty"`
+}
+
+type NotifiersBroadcastsBroadcastDelivery struct {
+	BroadcastId *string                             `json:"broadcastId,omitempty"`
+	Channel     *NotifiersBroadcastsDeliveryChannel `json:"channel,omitempty"`
+	Error       *string                             `json:"error,omitempty"`
+	Id          *string                             `json:"id,omitempty"`
+	NotifierId  *string                             `json:"notifierId,omitempty"`
+	Recipient   *string                             `json:"recipient,omitempty"`
+	SentAt      *string                             `json:"sentAt,omitempty"`
+	Status      *NotifiersBroadcastsDeliveryStatus  `json:"status,omitempty"`
+	WorkspaceId *string                             `json:"workspaceId,omitempty"`
+}
+
+type NotifiersBroadcastsBroadcastStatus string
+
+const (
+	NotifiersBroadcastsBroadcastStatusInProgress NotifiersBroadcastsBroadcastStatus = "IN_PROGRESS"
+	NotifiersBroadcastsBroadcastStatusCompleted  NotifiersBroadcastsBroadcastStatus = "COMPLETED"
+)
+
+type NotifiersBroadcastsCreateBroadcastRequest struct {
+	IsSendEmails *bool    `json:"isSendEmails,omitempty"`
+	Message      string   `json:"message"`
+	Title        string   `json:"title"`
+	WorkspaceIds []string `json:"workspaceIds,omitempty"`
+}
+
+type NotifiersBroadcastsDeliveryChannel string
+
+const (
+	NotifiersBroadcastsDeliveryChannelNotifier NotifiersBroadcastsDeliveryChannel = "NOTIFIER"
+	NotifiersBroadcastsDeliveryChannelEmail    NotifiersBroadcastsDeliveryChannel = "EMAIL"
+)
+
+type NotifiersBroadcastsDeliveryStatus string
+
+const (
+	NotifiersBroadcastsDeliveryStatusSent   NotifiersBroadcastsDeliveryStatus = "SENT"
+	NotifiersBroadcastsDeliveryStatusFailed NotifiersBroadcastsDeliveryStatus = "FAILED"
+)
+
+type NotifiersSecurityEventsSecurityEventSettings struct {
+	// EventTypes are sent to the notifier, empty means all of them
+	EventTypes           []*NotifiersSecurityEventsSecurityEventType `json:"eventTypes,omitempty"`
+	IsEmailAffectedUsers *bool                                       `json:"isEmailAffectedUsers,omitempty"`
+	NotifierId           *string                                     `json:"notifierId,omitempty"`
+}
+
+type NotifiersSecurityEventsSecurityEventType string
+
+const (
+	NotifiersSecurityEventsSecurityEventTypeNewDeviceSignIn       NotifiersSecurityEventsSecurityEventType = "NEW_DEVICE_SIGN_IN"
+	NotifiersSecurityEventsSecurityEventTypeFailedSignInStreak    NotifiersSecurityEventsSecurityEventType = "FAILED_SIGN_IN_STREAK"
+	NotifiersSecurityEventsSecurityEventTypeSecurityPolicyChanged NotifiersSecurityEventsSecurityEventType = "SECURITY_POLICY_CHANGED"
+)
+
+type NotifiersTicketsTicket struct {
+	CreatedAt     *string `json:"createdAt,omitempty"`
+	DatabaseId    *string `json:"databaseId,omitempty"`
+	ExternalKey   *string `json:"externalKey,omitempty"`
+	ExternalUrl   *string `json:"externalUrl,omitempty"`
+	FailuresCount *int64  `json:"failuresCount,omitempty"`
+	Id            *string `json:"id,omitempty"`
+	IntegrationId *string `json:"integrationId,omitempty"`
+	LastBackupId  *string `json:"lastBackupId,omitempty"`
+}
+
+type NotifiersTicketsTicketIntegration struct {
+	CreatedAt           *string `json:"createdAt,omitempty"`
+	DescriptionTemplate *string `json:"descriptionTemplate,omitempty"`
+	FailuresThreshold   *int64  `json:"failuresThreshold,omitempty"`
+	Id                  *string `json:"id,omitempty"`
+	IsEnabled           *bool   `json:"isEnabled,omitempty"`
+	JiraApiToken        *string `json:"jiraApiToken,omitempty"`
+	JiraEmail           *string `json:"jiraEmail,omitempty"`
+	JiraIssueType       *string `json:"jiraIssueType,omitempty"`
+	JiraProjectKey      *string `json:"jiraProjectKey,omitempty"`
+	// Jira Cloud, the API token is used with basic auth of the account email
+	JiraUrl                   *string                         `json:"jiraUrl,omitempty"`
+	Provider                  *NotifiersTicketsTicketProvider `json:"provider,omitempty"`
+	ServiceNowAssignmentGroup *string                         `json:"serviceNowAssignmentGroup,omitempty"`
+	ServiceNowPassword        *string                         `json:"serviceNowPassword,omitempty"`
+	// ServiceNow, tickets are opened as incidents
+	ServiceNowUrl      *string `json:"serviceNowUrl,omitempty"`
+	ServiceNowUsername *string `json:"serviceNowUsername,omitempty"`
+	SummaryTemplate    *string `json:"summaryTemplate,omitempty"`
+	WindowHours        *int64  `json:"windowHours,omitempty"`
+	WorkspaceId        *string `json:"workspaceId,omitempty"`
+}
+
+type NotifiersTicketsTicketProvider string
+
+const (
+	NotifiersTicketsTicketProviderJira       NotifiersTicketsTicketProvider = "JIRA"
+	NotifiersTicketsTicketProviderServicenow NotifiersTicketsTicketProvider = "SERVICENOW"
+)
+
+type OpsgenieNotifierOpsgenieNotifier struct {
+	ApiKey     *string                         `json:"apiKey,omitempty"`
+	NotifierId *string                         `json:"notifierId,omitempty"`
+	Region     *OpsgenieNotifierOpsgenieRegion `json:"region,omitempty"`
+	// WebhookSecret is compared with the header sent by the webhook integration, alert
+	// state is synced back only when it is set
+	WebhookSecret *string `json:"webhookSecret,omitempty"`
+}
+
+type OpsgenieNotifierOpsgenieRegion string
+
+const (
+	OpsgenieNotifierOpsgenieRegionUs OpsgenieNotifierOpsgenieRegion = "US"
+	OpsgenieNotifierOpsgenieRegionEu OpsgenieNotifierOpsgenieRegion = "EU"
+)
+
+type OwnershipOrphansOrphanedResource struct {
+	Id          *string `json:"id,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	OwnerTeam   *string `json:"ownerTeam,omitempty"`
+	OwnerUserId *string `json:"ownerUserId,omitempty"`
+	Type        any     `json:"type,omitempty"`
+}
+
+type OwnershipOrphansOrphanedResourcesResponse struct {
+	Resources []*OwnershipOrphansOrphanedResource `json:"resources,omitempty"`
+}
+
+type OwnershipOrphansResourceType string
+
+const (
+	OwnershipOrphansResourceTypeDatabase OwnershipOrphansResourceType = "DATABASE"
+	OwnershipOrphansResourceTypeStorage  OwnershipOrphansResourceType = "STORAGE"
+)
+
+type PagerdutyNotifierPagerDutyNotifier struct {
+	NotifierId *string `json:"notifierId,omitempty"`
+	RoutingKey *string `json:"routingKey,omitempty"`
+	// WebhookSecret is the signing secret of the V3 webhook subscription, incident state is
+	// synced back only when it is set
+	WebhookSecret *string `json:"webhookSecret,omitempty"`
+}
+
+type PeriodPeriod string
+
+const (
+	PeriodPeriodDay     PeriodPeriod = "DAY"
+	PeriodPeriodWeek    PeriodPeriod = "WEEK"
+	PeriodPeriodMonth   PeriodPeriod = "MONTH"
+	PeriodPeriod3Month  PeriodPeriod = "3_MONTH"
+	PeriodPeriod6Month  PeriodPeriod = "6_MONTH"
+	PeriodPeriodYear    PeriodPeriod = "YEAR"
+	PeriodPeriod2Years  PeriodPeriod = "2_YEARS"
+	PeriodPeriod3Years  PeriodPeriod = "3_YEARS"
+	PeriodPeriod4Years  PeriodPeriod = "4_YEARS"
+	PeriodPeriod5Years  PeriodPeriod = "5_YEARS"
+	PeriodPeriodForever PeriodPeriod = "FOREVER"
+)
+
+type PlansDatabasePlan struct {
+	DatabaseId            *string       `json:"databaseId,omitempty"`
+	MaxBackupSizeMb       *int64        `json:"maxBackupSizeMb,omitempty"`
+	MaxBackupsTotalSizeMb *int64        `json:"maxBackupsTotalSizeMb,omitempty"`
+	MaxStoragePeriod      *PeriodPeriod `json:"maxStoragePeriod,omitempty"`
+}
+
+type PluginStoragePluginStorage struct {
+	Config       map[string]string `json:"config,omitempty"`
+	PluginName   *string           `json:"pluginName,omitempty"`
+	SecretConfig map[string]string `json:"secretConfig,omitempty"`
+	StorageId    *string           `json:"storageId,omitempty"`
+}
+
+type PostgresqlPostgresqlDatabase struct {
+	CpuCount   *int64  `json:"cpuCount,omitempty"`
+	Database   *string `json:"database,omitempty"`
+	DatabaseId *string `json:"databaseId,omitempty"`
+	// connection data
+	Host *string `json:"host,omitempty"`
+	Id   *string `json:"id,omitempty"`
+	// backup settings
+	IncludeSchemas []string `json:"includeSchemas,omitempty"`
+	// restore settings (not saved to DB)
+	IsExcludeExtensions    *bool   `json:"isExcludeExtensions,omitempty"`
+	IsHttps                *bool   `json:"isHttps,omitempty"`
+	Password               *string `json:"password,omitempty"`
+	Port                   *int64  `json:"port,omitempty"`
+	RecommendedCpuCount    *int64  `json:"recommendedCpuCount,omitempty"`
+	TimescaledbChunksCount *int64  `json:"timescaledbChunksCount,omitempty"`
+	// TimescaleDB extension data, detected together with the version
+	TimescaledbVersion *string                 `json:"timescaledbVersion,omitempty"`
+	Username           *string                 `json:"username,omitempty"`
+	Version            *ToolsPostgresqlVersion `json:"version,omitempty"`
+}
+
+type RcloneStorageRcloneStorage struct {
+	ConfigContent *string `json:"configContent,omitempty"`
+	RemotePath    *string `json:"remotePath,omitempty"`
+	StorageId     *string `json:"storageId,omitempty"`
+}
+
+type RestoresCorePreRestoreCheck struct {
+	// IsOverridden is set for failed checks listed in overrideChecks of the request
+	IsOverridden *bool                              `json:"isOverridden,omitempty"`
+	Message      *string                            `json:"message,omitempty"`
+	Status       *RestoresCorePreRestoreCheckStatus `json:"status,omitempty"`
+	Type         any                                `json:"type,omitempty"`
+}
+
+type RestoresCorePreRestoreCheckStatus string
+
+const (
+	RestoresCorePreRestoreCheckStatusPassed  RestoresCorePreRestoreCheckStatus = "PASSED"
+	RestoresCorePreRestoreCheckStatusWarning RestoresCorePreRestoreCheckStatus = "WARNING"
+	RestoresCorePreRestoreCheckStatusFailed  RestoresCorePreRestoreCheckStatus = "FAILED"
+	RestoresCorePreRestoreCheckStatusSkipped RestoresCorePreRestoreCheckStatus = "SKIPPED"
+)
+
+type RestoresCorePreRestoreCheckType string
+
+const (
+	RestoresCorePreRestoreCheckTypeVersion    RestoresCorePreRestoreCheckType = "VERSION"
+	RestoresCorePreRestoreCheckTypeExtensions RestoresCorePreRestoreCheckType = "EXTENSIONS"
+	RestoresCorePreRestoreCheckTypeEncoding   RestoresCorePreRestoreCheckType = "ENCODING"
+	RestoresCorePreRestoreCheckTypeDiskSpace  RestoresCorePreRestoreCheckType = "DISK_SPACE"
+)
+
+type RestoresCorePreRestoreReport struct {
+	Checks    []*RestoresCorePreRestoreCheck `json:"checks,omitempty"`
+	IsBlocked *bool                          `json:"isBlocked,omitempty"`
+}
+
+type RestoresCoreRestore struct {
+	Backup                *BackupsCoreBackup                  `json:"backup,omitempty"`
+	BackupId              *string                             `json:"backupId,omitempty"`
+	CassandraDatabase     *CassandraCassandraDatabase         `json:"cassandraDatabase,omitempty"`
+	CockroachdbDatabase   *CockroachdbCockroachdbDatabase     `json:"cockroachdbDatabase,omitempty"`
+	CreatedAt             *string                             `json:"createdAt,omitempty"`
+	ElasticsearchDatabase *ElasticsearchElasticsearchDatabase `json:"elasticsearchDatabase,omitempty"`
+	FailMessage           *string                             `json:"failMessage,omitempty"`
+	FilesystemDatabase    *FilesystemFilesystemDatabase       `json:"filesystemDatabase,omitempty"`
+	Id                    *string                             `json:"id,omitempty"`
+	InfluxdbDatabase      *InfluxdbInfluxdbDatabase           `json:"influxdbDatabase,omitempty"`
+	// IsMasked restores complete only after masking rules of the database are applied
+	IsMasked           *bool                         `json:"isMasked,omitempty"`
+	MariadbDatabase    *MariadbMariadbDatabase       `json:"mariadbDatabase,omitempty"`
+	MongodbDatabase    *MongodbMongodbDatabase       `json:"mongodbDatabase,omitempty"`
+	MysqlDatabase      *MysqlMysqlDatabase           `json:"mysqlDatabase,omitempty"`
+	Neo4jDatabase      *Neo4jNeo4jDatabase           `json:"neo4jDatabase,omitempty"`
+	PostgresqlDatabase *PostgresqlPostgresqlDatabase `json:"postgresqlDatabase,omitempty"`
+	RestoreDurationMs  *int64                        `json:"restoreDurationMs,omitempty"`
+	Status             *RestoresCoreRestoreStatus    `json:"status,omitempty"`
+}
+
+type RestoresCoreRestoreBackupRequest struct {
+	CassandraDatabase     *CassandraCassandraDatabase         `json:"cassandraDatabase,omitempty"`
+	CockroachdbDatabase   *CockroachdbCockroachdbDatabase     `json:"cockroachdbDatabase,omitempty"`
+	ElasticsearchDatabase *ElasticsearchElasticsearchDatabase `json:"elasticsearchDatabase,omitempty"`
+	FilesystemDatabase    *FilesystemFilesystemDatabase       `json:"filesystemDatabase,omitempty"`
+	InfluxdbDatabase      *InfluxdbInfluxdbDatabase           `json:"influxdbDatabase,omitempty"`
+	// IsApplyMasking runs masking rules of the database on the restored data, the restore
+	// fails when they cannot be applied
+	IsApplyMasking *bool `json:"isApplyMasking,omitempty"`
+	// IsProdRestoreConfirmed is required when the target is on the server of a PROD database
+	IsProdRestoreConfirmed *bool                   `json:"isProdRestoreConfirmed,omitempty"`
+	MariadbDatabase        *MariadbMariadbDatabase `json:"mariadbDatabase,omitempty"`
+	MongodbDatabase        *MongodbMongodbDatabase `json:"mongodbDatabase,omitempty"`
+	MysqlDatabase          *MysqlMysqlDatabase     `json:"mysqlDatabase,omitempty"`
+	Neo4jDatabase          *Neo4jNeo4jDatabase     `json:"neo4jDatabase,omitempty"`
+	// OverrideChecks lets the restore start despite failed pre-restore checks of these types
+	OverrideChecks     []*RestoresCorePreRestoreCheckType `json:"overrideChecks,omitempty"`
+	PostgresqlDatabase *PostgresqlPostgresqlDatabase      `json:"postgresqlDatabase,omitempty"`
+}
+
+type RestoresCoreRestoreEstimate struct {
+	ActiveRestores      *int64                               `json:"activeRestores,omitempty"`
+	AvailableNodes      *int64                               `json:"availableNodes,omitempty"`
+	BackupId            *string                              `json:"backupId,omitempty"`
+	BackupSizeMb        *float64                             `json:"backupSizeMb,omitempty"`
+	EstimatedDurationMs *int64                               `json:"estimatedDurationMs,omitempty"`
+	EstimatedFinishAt   *string                              `json:"estimatedFinishAt,omitempty"`
+	SampleRestoresCount *int64                               `json:"sampleRestoresCount,omitempty"`
+	ThroughputMBs       *float64                             `json:"throughputMBs,omitempty"`
+	ThroughputSource    *RestoresCoreRestoreThroughputSource `json:"throughputSource,omitempty"`
+}
+
+type RestoresCoreRestoreStatus string
+
+const (
+	RestoresCoreRestoreStatusInProgress RestoresCoreRestoreStatus = "IN_PROGRESS"
+	RestoresCoreRestoreStatusCompleted  RestoresCoreRestoreStatus = "COMPLETED"
+	RestoresCoreRestoreStatusFailed     RestoresCoreRestoreStatus = "FAILED"
+	RestoresCoreRestoreStatusCanceled   RestoresCoreRestoreStatus = "CANCELED"
+)
+
+type RestoresCoreRestoreThroughputSource string
+
+const (
+	RestoresCoreRestoreThroughputSourceEngineAndStorageHistory RestoresCoreRestoreThroughputSource = "ENGINE_AND_STORAGE_HISTORY"
+	RestoresCoreRestoreThroughputSourceEngineHistory           RestoresCoreRestoreThroughputSource = "ENGINE_HISTORY"
+	RestoresCoreRestoreThroughputSourceNodeThroughput          RestoresCoreRestoreThroughputSource = "NODE_THROUGHPUT"
+)
+
+type RestoresPlansRestorePlan struct {
+	CreatedAt *string `json:"createdAt,omitempty"`
+	// CreatedByUserID is the user restores of the plan are started for, so steps starting
+	// later are checked against the permissions of that user
+	CreatedByUserId *string `json:"createdByUserId,omitempty"`
+	FinishedAt      *string `json:"finishedAt,omitempty"`
+	Id              *string `json:"id,omitempty"`
+	// MaxParallelRestores limits steps restoring at the same time, 0 starts every step whose
+	// dependencies completed
+	MaxParallelRestores *int64                            `json:"maxParallelRestores,omitempty"`
+	Name                *string                           `json:"name,omitempty"`
+	Progress            *RestoresPlansRestorePlanProgress `json:"progress,omitempty"`
+	Status              *RestoresPlansRestorePlanStatus   `json:"status,omitempty"`
+	Steps               []*RestoresPlansRestorePlanStep   `json:"steps,omitempty"`
+	WorkspaceId         *string                           `json:"workspaceId,omitempty"`
+}
+
+type RestoresPlansRestorePlanProgress struct {
+	CompletedSteps  *int64 `json:"completedSteps,omitempty"`
+	FailedSteps     *int64 `json:"failedSteps,omitempty"`
+	InProgressSteps *int64 `json:"inProgressSteps,omitempty"`
+	PendingSteps    *int64 `json:"pendingSteps,omitempty"`
+	Percent         *int64 `json:"percent,omitempty"`
+	SkippedSteps    *int64 `json:"skippedSteps,omitempty"`
+	TotalSteps      *int64 `json:"totalSteps,omitempty"`
+}
+
+type RestoresPlansRestorePlanStatus string
+
+const (
+	RestoresPlansRestorePlanStatusInProgress RestoresPlansRestorePlanStatus = "IN_PROGRESS"
+	RestoresPlansRestorePlanStatusCompleted  RestoresPlansRestorePlanStatus = "COMPLETED"
+	RestoresPlansRestorePlanStatusFailed     RestoresPlansRestorePlanStatus = "FAILED"
+	RestoresPlansRestorePlanStatusCanceled   RestoresPlansRestorePlanStatus = "CANCELED"
+)
+
+type RestoresPlansRestorePlanStep struct {
+	BackupId    *string                             `json:"backupId,omitempty"`
+	DependsOn   []string                            `json:"dependsOn,omitempty"`
+	FailMessage *string                             `json:"failMessage,omitempty"`
+	FinishedAt  *string                             `json:"finishedAt,omitempty"`
+	Id          *string                             `json:"id,omitempty"`
+	Key         *string                             `json:"key,omitempty"`
+	PlanId      *string                             `json:"planId,omitempty"`
+	Position    *int64                              `json:"position,omitempty"`
+	RestoreId   *string                             `json:"restoreId,omitempty"`
+	StartedAt   *string                             `json:"startedAt,omitempty"`
+	Status      *RestoresPlansRestorePlanStepStatus `json:"status,omitempty"`
+	// Target is restored like a manual restore. Passwords are encrypted with the ID of the
+	// database of the backup, restores decrypt them with it
+	Target *RestoresCoreRestoreBackupRequest `json:"target,omitempty"`
+}
+
+type RestoresPlansRestorePlanStepStatus string
+
+const (
+	RestoresPlansRestorePlanStepStatusPending    RestoresPlansRestorePlanStepStatus = "PENDING"
+	RestoresPlansRestorePlanStepStatusInProgress RestoresPlansRestorePlanStepStatus = "IN_PROGRESS"
+	RestoresPlansRestorePlanStepStatusCompleted  RestoresPlansRestorePlanStepStatus = "COMPLETED"
+	RestoresPlansRestorePlanStepStatusFailed     RestoresPlansRestorePlanStepStatus = "FAILED"
+	RestoresPlansRestorePlanStepStatusSkipped    RestoresPlansRestorePlanStepStatus = "SKIPPED"
+)
+
+type RestoresRefreshesRefreshJob struct {
+	CreatedAt  *string            `json:"createdAt,omitempty"`
+	DatabaseId *string            `json:"databaseId,omitempty"`
+	Id         *string            `json:"id,omitempty"`
+	Interval   *IntervalsInterval `json:"interval,omitempty"`
+	IntervalId *string            `json:"intervalId,omitempty"`
+	// IsApplyMasking runs masking rules of the database on each refresh
+	IsApplyMasking      *bool                                       `json:"isApplyMasking,omitempty"`
+	IsEnabled           *bool                                       `json:"isEnabled,omitempty"`
+	LastRunAt           *string                                     `json:"lastRunAt,omitempty"`
+	Name                *string                                     `json:"name,omitempty"`
+	SendNotificationsOn []*RestoresRefreshesRefreshNotificationType `json:"sendNotificationsOn,omitempty"`
+	// Target is restored like a manual restore. Passwords are encrypted with the ID of the
+	// source database, restores decrypt them with it
+	Target *RestoresCoreRestoreBackupRequest `json:"target,omitempty"`
+}
+
+type RestoresRefreshesRefreshNotificationType string
+
+const (
+	RestoresRefreshesRefreshNotificationTypeRefreshFailed  RestoresRefreshesRefreshNotificationType = "REFRESH_FAILED"
+	RestoresRefreshesRefreshNotificationTypeRefreshSuccess RestoresRefreshesRefreshNotificationType = "REFRESH_SUCCESS"
+)
+
+type RestoresRefreshesRefreshRun struct {
+	BackupId     *string                            `json:"backupId,omitempty"`
+	FailMessage  *string                            `json:"failMessage,omitempty"`
+	FinishedAt   *string                            `json:"finishedAt,omitempty"`
+	Id           *string                            `json:"id,omitempty"`
+	RefreshJobId *string                            `json:"refreshJobId,omitempty"`
+	RestoreId    *string                            `json:"restoreId,omitempty"`
+	StartedAt    *string                            `json:"startedAt,omitempty"`
+	Status       *RestoresRefreshesRefreshRunStatus `json:"status,omitempty"`
+}
+
+type RestoresRefreshesRefreshRunStatus string
+
+const (
+	RestoresRefreshesRefreshRunStatusInProgress RestoresRefreshesRefreshRunStatus = "IN_PROGRESS"
+	RestoresRefreshesRefreshRunStatusCompleted  RestoresRefreshesRefreshRunStatus = "COMPLETED"
+	RestoresRefreshesRefreshRunStatusFailed     RestoresRefreshesRefreshRunStatus = "FAILED"
+)
+
+type S3StorageAuthMethod string
+
+const (
+	S3StorageAuthMethodStaticKeys      S3StorageAuthMethod = "STATIC_KEYS"
+	S3StorageAuthMethodAssumeRole      S3StorageAuthMethod = "ASSUME_ROLE"
+	S3StorageAuthMethodInstanceProfile S3StorageAuthMethod = "INSTANCE_PROFILE"
+)
+
+type S3StorageObjectLockMode string
+
+const (
+	S3StorageObjectLockModeNone       S3StorageObjectLockMode = "NONE"
+	S3StorageObjectLockModeGovernance S3StorageObjectLockMode = "GOVERNANCE"
+	S3StorageObjectLockModeCompliance S3StorageObjectLockMode = "COMPLIANCE"
+)
+
+type S3StorageS3Storage struct {
+	// AuthMethod picks where credentials come from, access keys are not needed for instance
+	// profiles and are optional when assuming a role. Empty is treated as static keys
+	AuthMethod *S3StorageAuthMethod `json:"authMethod,omitempty"`
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DnsOverride *NetworkUtilsDNSOverride `json:"dnsOverride,omitempty"`
+	ExternalId  *string                  `json:"externalId,omitempty"`
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IpPreference            *NetworkUtilsIPPreference `json:"ipPreference,omitempty"`
+	IsBucketKeyEnabled      *bool                     `json:"isBucketKeyEnabled,omitempty"`
+	KmsKeyId                *string                   `json:"kmsKeyId,omitempty"`
+	ObjectLockMode          *S3StorageObjectLockMode  `json:"objectLockMode,omitempty"`
+	ObjectLockRetentionDays *int64                    `json:"objectLockRetentionDays,omitempty"`
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this storage, see proxy_utils.GetProxyFunc
+	ProxyUrl                *string `json:"proxyUrl,omitempty"`
+	RoleArn                 *string `json:"roleArn,omitempty"`
+	S3AccessKey             *string `json:"s3AccessKey,omitempty"`
+	S3Bucket                *string `json:"s3Bucket,omitempty"`
+	S3Endpoint              *string `json:"s3Endpoint,omitempty"`
+	S3Prefix                *string `json:"s3Prefix,omitempty"`
+	S3Region                *string `json:"s3Region,omitempty"`
+	S3SecretKey             *string `json:"s3SecretKey,omitempty"`
+	S3UseVirtualHostedStyle *bool   `json:"s3UseVirtualHostedStyle,omitempty"`
+	// ServerSideEncryption and ObjectLockMode are applied to every uploaded object. Empty
+	// values are treated as NONE and keep the defaults of the bucket
+	ServerSideEncryption *S3StorageServerSideEncryption `json:"serverSideEncryption,omitempty"`
+	SkipTLSVerify        *bool                          `json:"skipTLSVerify,omitempty"`
+	StorageId            *string                        `json:"storageId,omitempty"`
+}
+
+type S3StorageServerSideEncryption string
+
+const (
+	S3StorageServerSideEncryptionNone   S3StorageServerSideEncryption = "NONE"
+	S3StorageServerSideEncryptionSseS3  S3StorageServerSideEncryption = "SSE_S3"
+	S3StorageServerSideEncryptionSseKms S3StorageServerSideEncryption = "SSE_KMS"
+)
+
+type SavedViewsAddFavoriteRequest struct {
+	ResourceId   string                  `json:"resourceId"`
+	ResourceType *SavedViewsResourceType `json:"resourceType"`
+	WorkspaceId  string                  `json:"workspaceId"`
+}
+
+type SavedViewsFavoriteDTO struct {
+	CreatedAt    *string                 `json:"createdAt,omitempty"`
+	Name         *string                 `json:"name,omitempty"`
+	ResourceId   *string                 `json:"resourceId,omitempty"`
+	ResourceType *SavedViewsResourceType `json:"resourceType,omitempty"`
+}
+
+type SavedViewsResourceType string
+
+const (
+	SavedViewsResourceTypeDatabase SavedViewsResourceType = "DATABASE"
+	SavedViewsResourceTypeStorage  SavedViewsResourceType = "STORAGE"
+)
+
+type SavedViewsSaveViewRequest struct {
+	Filters      map[string]string       `json:"filters,omitempty"`
+	Name         string                  `json:"name"`
+	ResourceType *SavedViewsResourceType `json:"resourceType"`
+	WorkspaceId  string                  `json:"workspaceId"`
+}
+
+type SavedViewsSavedView struct {
+	CreatedAt    *string                 `json:"createdAt,omitempty"`
+	Filters      map[string]string       `json:"filters,omitempty"`
+	Id           *string                 `json:"id,omitempty"`
+	Name         *string                 `json:"name,omitempty"`
+	ResourceType *SavedViewsResourceType `json:"resourceType,omitempty"`
+	UpdatedAt    *string                 `json:"updatedAt,omitempty"`
+	UserId       *string                 `json:"userId,omitempty"`
+	WorkspaceId  *string                 `json:"workspaceId,omitempty"`
+}
+
+type SftpStorageSFTPStorage struct {
+	// DNSOverride resolves the host with overrides or another DNS server than the system one
+	DnsOverride *NetworkUtilsDNSOverride `json:"dnsOverride,omitempty"`
+	Host        *string                  `json:"host,omitempty"`
+	// IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses
+	IpPreference      *NetworkUtilsIPPreference `json:"ipPreference,omitempty"`
+	Password          *string                   `json:"password,omitempty"`
+	Path              *string                   `json:"path,omitempty"`
+	Port              *int64                    `json:"port,omitempty"`
+	PrivateKey        *string                   `json:"privateKey,omitempty"`
+	SkipHostKeyVerify *bool                     `json:"skipHostKeyVerify,omitempty"`
+	StorageId         *string                   `json:"storageId,omitempty"`
+	Username          *string                   `json:"username,omitempty"`
+}
+
+type SigningPublicKeyResponse struct {
+	Algorithm    *string `json:"algorithm,omitempty"`
+	PublicKey    *string `json:"publicKey,omitempty"`
+	PublicKeyPem *string `json:"publicKeyPem,omitempty"`
+}
+
+type SlackNotifierSlackNotifier struct {
+	BotToken   *string `json:"botToken,omitempty"`
+	NotifierId *string `json:"notifierId,omitempty"`
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this notifier, see proxy_utils.GetProxyFunc
+	ProxyUrl     *string `json:"proxyUrl,omitempty"`
+	TargetChatId *string `json:"targetChatId,omitempty"`
+}
+
+type StoragesBenchmarkStorageRequest struct {
+	// SizeMb is the size of the test object, 16 MB when empty
+	SizeMb *int64 `json:"sizeMb,omitempty"`
+}
+
+type StoragesBrowseStorageResponse struct {
+	Entries     []*FilesUtilsDirectoryEntry `json:"entries,omitempty"`
+	IsTruncated *bool                       `json:"isTruncated,omitempty"`
+	Path        *string                     `json:"path,omitempty"`
+}
+
+type StoragesSetDefaultStorageRequest struct {
+	// StorageID clears the default if empty
+	StorageId *string `json:"storageId,omitempty"`
+}
+
+type StoragesStorage struct {
+	AzureBlobStorage *AzureBlobStorageAzureBlobStorage `json:"azureBlobStorage,omitempty"`
+	// ChangeReason is sent with edits and written to the audit log, it is required in
+	// workspaces with IsChangeReasonRequired. Not stored on the storage
+	ChangeReason *string `json:"changeReason,omitempty"`
+	// CredentialsExpireAt is when the keys of the storage have to be rotated, default
+	// notifiers of the workspace are reminded before it
+	CredentialsExpireAt *string `json:"credentialsExpireAt,omitempty"`
+	// FileNameTemplate names backup files instead of their ID, see RenderFileName. Backup
+	// configs may override it
+	FileNameTemplate   *string                               `json:"fileNameTemplate,omitempty"`
+	FtpStorage         *FtpStorageFTPStorage                 `json:"ftpStorage,omitempty"`
+	GcsStorage         *GcsStorageGCSStorage                 `json:"gcsStorage,omitempty"`
+	GoogleDriveStorage *GoogleDriveStorageGoogleDriveStorage `json:"googleDriveStorage,omitempty"`
+	Id                 *string                               `json:"id,omitempty"`
+	// IsContentAddressed stores files under their sha256 with an index object per file,
+	// see saveContentAddressedFile. Only S3 storages support it
+	IsContentAddressed *bool   `json:"isContentAddressed,omitempty"`
+	IsSystem           *bool   `json:"isSystem,omitempty"`
+	LastSaveError      *string `json:"lastSaveError,omitempty"`
+	// specific storage
+	LocalStorage  *LocalStorageLocalStorage   `json:"localStorage,omitempty"`
+	Name          *string                     `json:"name,omitempty"`
+	NasStorage    *NasStorageNASStorage       `json:"nasStorage,omitempty"`
+	OwnerTeam     *string                     `json:"ownerTeam,omitempty"`
+	OwnerUserId   *string                     `json:"ownerUserId,omitempty"`
+	PluginStorage *PluginStoragePluginStorage `json:"pluginStorage,omitempty"`
+	RcloneStorage *RcloneStorageRcloneStorage `json:"rcloneStorage,omitempty"`
+	S3Storage     *S3StorageS3Storage         `json:"s3Storage,omitempty"`
+	SftpStorage   *SftpStorageSFTPStorage     `json:"sftpStorage,omitempty"`
+	Type          any                         `json:"type,omitempty"`
+	// VisibleWorkspaceIDs limits a system storage to these workspaces, empty means all
+	// workspaces. The workspace owning the storage always sees it
+	VisibleWorkspaceIds []string `json:"visibleWorkspaceIds,omitempty"`
+	WorkspaceId         *string  `json:"workspaceId,omitempty"`
+}
+
+type StoragesStorageBenchmarkResult struct {
+	DeleteDurationMs      *int64   `json:"deleteDurationMs,omitempty"`
+	DownloadDurationMs    *int64   `json:"downloadDurationMs,omitempty"`
+	DownloadFirstByteMs   *int64   `json:"downloadFirstByteMs,omitempty"`
+	DownloadThroughputMbs *float64 `json:"downloadThroughputMbs,omitempty"`
+	IsMultipart           *bool    `json:"isMultipart,omitempty"`
+	PartSizeMb            *float64 `json:"partSizeMb,omitempty"`
+	PartsCount            *int64   `json:"partsCount,omitempty"`
+	SizeMb                *int64   `json:"sizeMb,omitempty"`
+	UploadDurationMs      *int64   `json:"uploadDurationMs,omitempty"`
+	UploadThroughputMbs   *float64 `json:"uploadThroughputMbs,omitempty"`
+}
+
+type StoragesStorageResponse struct {
+	AzureBlobStorage    *AzureBlobStorageAzureBlobStorage     `json:"azureBlobStorage,omitempty"`
+	CredentialsExpireAt *string                               `json:"credentialsExpireAt,omitempty"`
+	FileNameTemplate    *string                               `json:"fileNameTemplate,omitempty"`
+	FtpStorage          *FtpStorageFTPStorage                 `json:"ftpStorage,omitempty"`
+	GcsStorage          *GcsStorageGCSStorage                 `json:"gcsStorage,omitempty"`
+	GoogleDriveStorage  *GoogleDriveStorageGoogleDriveStorage `json:"googleDriveStorage,omitempty"`
+	Id                  *string                               `json:"id,omitempty"`
+	IsContentAddressed  *bool                                 `json:"isContentAddressed,omitempty"`
+	IsSystem            *bool                                 `json:"isSystem,omitempty"`
+	LastSaveError       *string                               `json:"lastSaveError,omitempty"`
+	LocalStorage        *LocalStorageLocalStorage             `json:"localStorage,omitempty"`
+	Name                *string                               `json:"name,omitempty"`
+	NasStorage          *NasStorageNASStorage                 `json:"nasStorage,omitempty"`
+	OwnerTeam           *string                               `json:"ownerTeam,omitempty"`
+	OwnerUserId         *string                               `json:"ownerUserId,omitempty"`
+	PluginStorage       *PluginStoragePluginStorage           `json:"pluginStorage,omitempty"`
+	RcloneStorage       *RcloneStorageRcloneStorage           `json:"rcloneStorage,omitempty"`
+	S3Storage           *S3StorageS3Storage                   `json:"s3Storage,omitempty"`
+	SftpStorage         *SftpStorageSFTPStorage               `json:"sftpStorage,omitempty"`
+	Type                any                                   `json:"type,omitempty"`
+	VisibleWorkspaceIds []string                              `json:"visibleWorkspaceIds,omitempty"`
+	WorkspaceId         *string                               `json:"workspaceId,omitempty"`
+}
+
+type StoragesStorageType string
+
+const (
+	StoragesStorageTypeLocal       StoragesStorageType = "LOCAL"
+	StoragesStorageTypeS3          StoragesStorageType = "S3"
+	StoragesStorageTypeGoogleDrive StoragesStorageType = "GOOGLE_DRIVE"
+	StoragesStorageTypeNas         StoragesStorageType = "NAS"
+	StoragesStorageTypeAzureBlob   StoragesStorageType = "AZURE_BLOB"
+	StoragesStorageTypeFtp         StoragesStorageType = "FTP"
+	StoragesStorageTypeSftp        StoragesStorageType = "SFTP"
+	StoragesStorageTypeRclone      StoragesStorageType = "RCLONE"
+	StoragesStorageTypePlugin      StoragesStorageType = "PLUGIN"
+	StoragesStorageTypeGcs         StoragesStorageType = "GCS"
+)
+
+type StoragesTransferStorageRequest struct {
+	TargetWorkspaceId string `json:"targetWorkspaceId"`
+}
+
+type StoragesImpactImpactedDatabase struct {
+	Id               *string `json:"id,omitempty"`
+	IsBackupsEnabled *bool   `json:"isBackupsEnabled,omitempty"`
+	Name             *string `json:"name,omitempty"`
+	WorkspaceId      *string `json:"workspaceId,omitempty"`
+}
+
+type StoragesImpactImpactedJob struct {
+	DatabaseId *string `json:"databaseId,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Type       any     `json:"type,omitempty"`
+}
+
+type StoragesImpactImpactedJobType string
+
+const (
+	StoragesImpactImpactedJobTypeBackupSchedule StoragesImpactImpactedJobType = "BACKUP_SCHEDULE"
+	StoragesImpactImpactedJobTypeRefresh        StoragesImpactImpactedJobType = "REFRESH"
+	StoragesImpactImpactedJobTypeMetadataBackup StoragesImpactImpactedJobType = "METADATA_BACKUP"
+)
+
+type StoragesImpactStorageImpact struct {
+	AttachedDatabases  []*StoragesImpactImpactedDatabase `json:"attachedDatabases,omitempty"`
+	BackupsCount       *int64                            `json:"backupsCount,omitempty"`
+	BackupsTotalSizeMb *float64                          `json:"backupsTotalSizeMb,omitempty"`
+	BlockingReasons    []string                          `json:"blockingReasons,omitempty"`
+	IsDeletable        *bool                             `json:"isDeletable,omitempty"`
+	ScheduledJobs      []*StoragesImpactImpactedJob      `json:"scheduledJobs,omitempty"`
+	StorageId          *string                           `json:"storageId,omitempty"`
+	StorageName        *string                           `json:"storageName,omitempty"`
+}
+
+type StoragesMirrorsBackupReplica struct {
+	AttemptsCount *int64                        `json:"attemptsCount,omitempty"`
+	BackupId      *string                       `json:"backupId,omitempty"`
+	CompletedAt   *string                       `json:"completedAt,omitempty"`
+	CreatedAt     *string                       `json:"createdAt,omitempty"`
+	FailMessage   *string                       `json:"failMessage,omitempty"`
+	Id            *string                       `json:"id,omitempty"`
+	Status        *StoragesMirrorsReplicaStatus `json:"status,omitempty"`
+	StorageId     *string                       `json:"storageId,omitempty"`
+	UpdatedAt     *string                       `json:"updatedAt,omitempty"`
+}
+
+type StoragesMirrorsCreateMirrorGroupRequest struct {
+	Name        string   `json:"name"`
+	StorageIds  []string `json:"storageIds"`
+	WorkspaceId string   `json:"workspaceId"`
+}
+
+type StoragesMirrorsReplicaStatus string
+
+const (
+	StoragesMirrorsReplicaStatusPending   StoragesMirrorsReplicaStatus = "PENDING"
+	StoragesMirrorsReplicaStatusCompleted StoragesMirrorsReplicaStatus = "COMPLETED"
+	StoragesMirrorsReplicaStatusFailed    StoragesMirrorsReplicaStatus = "FAILED"
+)
+
+type StoragesMirrorsStorageMirrorGroup struct {
+	CreatedAt   *string  `json:"createdAt,omitempty"`
+	Id          *string  `json:"id,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+	StorageIds  []string `json:"storageIds,omitempty"`
+	WorkspaceId *string  `json:"workspaceId,omitempty"`
+}
+
+type StoragesMirrorsUpdateMirrorGroupRequest struct {
+	Name       string   `json:"name"`
+	StorageIds []string `json:"storageIds"`
+}
+
+type StoragesUsageDatabaseUsageDTO struct {
+	BackupsCount *int64  `json:"backupsCount,omitempty"`
+	BytesUsed    *int64  `json:"bytesUsed,omitempty"`
+	DatabaseId   *string `json:"databaseId,omitempty"`
+	DatabaseName *string `json:"databaseName,omitempty"`
+}
+
+type StoragesUsageGetStorageUsageResponse struct {
+	BackupsCount *int64                           `json:"backupsCount,omitempty"`
+	BytesUsed    *int64                           `json:"bytesUsed,omitempty"`
+	Databases    []*StoragesUsageDatabaseUsageDTO `json:"databases,omitempty"`
+	MeasuredAt   *string                          `json:"measuredAt,omitempty"`
+	Quota        *StoragesUsageStorageQuota       `json:"quota,omitempty"`
+	QuotaState   *StoragesUsageQuotaState         `json:"quotaState,omitempty"`
+	StorageId    *string                          `json:"storageId,omitempty"`
+	StorageName  *string                          `json:"storageName,omitempty"`
+}
+
+type StoragesUsageQuotaState string
+
+const (
+	StoragesUsageQuotaStateOk           StoragesUsageQuotaState = "OK"
+	StoragesUsageQuotaStateSoftExceeded StoragesUsageQuotaState = "SOFT_EXCEEDED"
+	StoragesUsageQuotaStateHardExceeded StoragesUsageQuotaState = "HARD_EXCEEDED"
+)
+
+type StoragesUsageStorageQuota struct {
+	HardLimitBytes *int64                   `json:"hardLimitBytes,omitempty"`
+	SoftLimitBytes *int64                   `json:"softLimitBytes,omitempty"`
+	State          *StoragesUsageQuotaState `json:"state,omitempty"`
+	StorageId      *string                  `json:"storageId,omitempty"`
+	UpdatedAt      *string                  `json:"updatedAt,omitempty"`
+}
+
+type StoragesUsageUpdateStorageQuotaRequest struct {
+	HardLimitBytes *int64 `json:"hardLimitBytes,omitempty"`
+	SoftLimitBytes *int64 `json:"softLimitBytes,omitempty"`
+}
+
+type SystemDebugGCStats struct {
+	CpuFraction  *float64 `json:"cpuFraction,omitempty"`
+	LastGcAt     *string  `json:"lastGcAt,omitempty"`
+	LastPauseMs  *float64 `json:"lastPauseMs,omitempty"`
+	NextGcBytes  *int64   `json:"nextGcBytes,omitempty"`
+	NumGc        *int64   `json:"numGc,omitempty"`
+	PauseTotalMs *float64 `json:"pauseTotalMs,omitempty"`
+}
+
+type SystemDebugMemoryStats struct {
+	HeapAllocBytes    *int64 `json:"heapAllocBytes,omitempty"`
+	HeapIdleBytes     *int64 `json:"heapIdleBytes,omitempty"`
+	HeapInuseBytes    *int64 `json:"heapInuseBytes,omitempty"`
+	HeapObjects       *int64 `json:"heapObjects,omitempty"`
+	HeapReleasedBytes *int64 `json:"heapReleasedBytes,omitempty"`
+	StackInuseBytes   *int64 `json:"stackInuseBytes,omitempty"`
+	SysBytes          *int64 `json:"sysBytes,omitempty"`
+	TotalAllocBytes   *int64 `json:"totalAllocBytes,omitempty"`
+}
+
+type SystemDebugProfileInfo struct {
+	Count *int64  `json:"count,omitempty"`
+	Name  *string `json:"name,omitempty"`
+}
+
+type SystemDebugProfilesResponse struct {
+	Profiles []*SystemDebugProfileInfo `json:"profiles,omitempty"`
+}
+
+type SystemDebugRuntimeStatsResponse struct {
+	Gc            *SystemDebugGCStats     `json:"gc,omitempty"`
+	GoMaxProcs    *int64                  `json:"goMaxProcs,omitempty"`
+	GoVersion     *string                 `json:"goVersion,omitempty"`
+	Hostname      *string                 `json:"hostname,omitempty"`
+	Memory        *SystemDebugMemoryStats `json:"memory,omitempty"`
+	NumCpu        *int64                  `json:"numCpu,omitempty"`
+	NumGoroutines *int64                  `json:"numGoroutines,omitempty"`
+	StartedAt     *string                 `json:"startedAt,omitempty"`
+	UptimeSeconds *int64                  `json:"uptimeSeconds,omitempty"`
+}
+
+type SystemHealthcheckHealthcheckResponse struct {
+	Status *string `json:"status,omitempty"`
+}
+
+type SystemMetadataBackupGetMetadataBackupsResponse struct {
+	Backups       []*SystemMetadataBackupMetadataBackup `json:"backups,omitempty"`
+	IntervalHours *int64                                `json:"intervalHours,omitempty"`
+	IsEnabled     *bool                                 `json:"isEnabled,omitempty"`
+	KeepCount     *int64                                `json:"keepCount,omitempty"`
+}
+
+type SystemMetadataBackupMetadataBackup struct {
+	CompletedAt *string                                   `json:"completedAt,omitempty"`
+	CreatedAt   *string                                   `json:"createdAt,omitempty"`
+	FailMessage *string                                   `json:"failMessage,omitempty"`
+	Id          *string                                   `json:"id,omitempty"`
+	SizeBytes   *int64                                    `json:"sizeBytes,omitempty"`
+	Status      *SystemMetadataBackupMetadataBackupStatus `json:"status,omitempty"`
+	StorageId   *string                                   `json:"storageId,omitempty"`
+}
+
+type SystemMetadataBackupMetadataBackupStatus string
+
+const (
+	SystemMetadataBackupMetadataBackupStatusInProgress SystemMetadataBackupMetadataBackupStatus = "IN_PROGRESS"
+	SystemMetadataBackupMetadataBackupStatusCompleted  SystemMetadataBackupMetadataBackupStatus = "COMPLETED"
+	SystemMetadataBackupMetadataBackupStatusFailed     SystemMetadataBackupMetadataBackupStatus = "FAILED"
+)
+
+type SystemSettingsReloadableSettingsResponse struct {
+	HealthcheckMaxDiskUsagePercent    *int64  `json:"healthcheckMaxDiskUsagePercent,omitempty"`
+	IsSmtpPasswordSet                 *bool   `json:"isSmtpPasswordSet,omitempty"`
+	LogLevel                          *string `json:"logLevel,omitempty"`
+	PasswordResetRateLimitPerHour     *int64  `json:"passwordResetRateLimitPerHour,omitempty"`
+	PasswordResetTokenLifetimeMinutes *int64  `json:"passwordResetTokenLifetimeMinutes,omitempty"`
+	SignInRateLimitPerMinute          *int64  `json:"signInRateLimitPerMinute,omitempty"`
+	SmtpHost                          *string `json:"smtpHost,omitempty"`
+	SmtpPort                          *int64  `json:"smtpPort,omitempty"`
+	SmtpUser                          *string `json:"smtpUser,omitempty"`
+}
+
+type SystemVersionGetVersionResponse struct {
+	// Changelog contains releases newer than the current version, newest first
+	Changelog []*SystemVersionRelease `json:"changelog,omitempty"`
+	// CheckError is set when last feed fetch failed and cached data is shown
+	CheckError                *string               `json:"checkError,omitempty"`
+	CheckedAt                 *string               `json:"checkedAt,omitempty"`
+	CurrentVersion            *string               `json:"currentVersion,omitempty"`
+	IsSecurityUpdateAvailable *bool                 `json:"isSecurityUpdateAvailable,omitempty"`
+	IsUpdateAvailable         *bool                 `json:"isUpdateAvailable,omitempty"`
+	LatestRelease             *SystemVersionRelease `json:"latestRelease,omitempty"`
+}
+
+type SystemVersionRelease struct {
+	IsSecurity  *bool   `json:"isSecurity,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	PublishedAt *string `json:"publishedAt,omitempty"`
+	Summary     *string `json:"summary,omitempty"`
+	Url         *string `json:"url,omitempty"`
+	Version     *string `json:"version,omitempty"`
+}
+
+type TeamsNotifierTeamsNotifier struct {
+	NotifierId       *string `json:"notifierId,omitempty"`
+	PowerAutomateUrl *string `json:"powerAutomateUrl,omitempty"`
+}
+
+type TelegramNotifierTelegramNotifier struct {
+	BotToken     *string `json:"botToken,omitempty"`
+	NotifierId   *string `json:"notifierId,omitempty"`
+	TargetChatId *string `json:"targetChatId,omitempty"`
+	ThreadId     *int64  `json:"threadId,omitempty"`
+}
+
+type ToolsMariadbVersion string
+
+const (
+	ToolsMariadbVersion55   ToolsMariadbVersion = "5.5"
+	ToolsMariadbVersion101  ToolsMariadbVersion = "10.1"
+	ToolsMariadbVersion102  ToolsMariadbVersion = "10.2"
+	ToolsMariadbVersion103  ToolsMariadbVersion = "10.3"
+	ToolsMariadbVersion104  ToolsMariadbVersion = "10.4"
+	ToolsMariadbVersion105  ToolsMariadbVersion = "10.5"
+	ToolsMariadbVersion106  ToolsMariadbVersion = "10.6"
+	ToolsMariadbVersion1011 ToolsMariadbVersion = "10.11"
+	ToolsMariadbVersion114  ToolsMariadbVersion = "11.4"
+	ToolsMariadbVersion118  ToolsMariadbVersion = "11.8"
+	ToolsMariadbVersion120  ToolsMariadbVersion = "12.0"
+)
+
+type ToolsMongodbVersion string
+
+const (
+	ToolsMongodbVersion4 ToolsMongodbVersion = "4"
+	ToolsMongodbVersion5 ToolsMongodbVersion = "5"
+	ToolsMongodbVersion6 ToolsMongodbVersion = "6"
+	ToolsMongodbVersion7 ToolsMongodbVersion = "7"
+	ToolsMongodbVersion8 ToolsMongodbVersion = "8"
+)
+
+type ToolsMysqlVersion string
+
+const (
+	ToolsMysqlVersion57 ToolsMysqlVersion = "5.7"
+	ToolsMysqlVersion80 ToolsMysqlVersion = "8.0"
+	ToolsMysqlVersion84 ToolsMysqlVersion = "8.4"
+	ToolsMysqlVersion9  ToolsMysqlVersion = "9"
+)
+
+type ToolsPostgresqlVersion string
+
+const (
+	ToolsPostgresqlVersion12 ToolsPostgresqlVersion = "12"
+	ToolsPostgresqlVersion13 ToolsPostgresqlVersion = "13"
+	ToolsPostgresqlVersion14 ToolsPostgresqlVersion = "14"
+	ToolsPostgresqlVersion15 ToolsPostgresqlVersion = "15"
+	ToolsPostgresqlVersion16 ToolsPostgresqlVersion = "16"
+	ToolsPostgresqlVersion17 ToolsPostgresqlVersion = "17"
+	ToolsPostgresqlVersion18 ToolsPostgresqlVersion = "18"
+)
+
+type UsersDtoChangePasswordRequestDTO struct {
+	NewPassword string `json:"newPassword"`
+}
+
+type UsersDtoChangeUserRoleRequestDTO struct {
+	Role *UsersEnumsUserRole `json:"role"`
+}
+
+type UsersDtoInviteUserRequestDTO struct {
+	Email                 string                   `json:"email"`
+	IntendedWorkspaceId   *string                  `json:"intendedWorkspaceId,omitempty"`
+	IntendedWorkspaceRole *UsersEnumsWorkspaceRole `json:"intendedWorkspaceRole,omitempty"`
+}
+
+type UsersDtoInviteUserResponseDTO struct {
+	CreatedAt             *string                  `json:"createdAt,omitempty"`
+	Email                 *string                  `json:"email,omitempty"`
+	Id                    *string                  `json:"id,omitempty"`
+	IntendedWorkspaceId   *string                  `json:"intendedWorkspaceId,omitempty"`
+	IntendedWorkspaceRole *UsersEnumsWorkspaceRole `json:"intendedWorkspaceRole,omitempty"`
+}
+
+type UsersDtoListUsersResponseDTO struct {
+	Total *int64                            `json:"total,omitempty"`
+	Users []*UsersDtoUserProfileResponseDTO `json:"users,omitempty"`
+}
+
+type UsersDtoOAuthCallbackRequestDTO struct {
+	Code        string `json:"code"`
+	RedirectUri string `json:"redirectUri"`
+}
+
+type UsersDtoOAuthCallbackResponseDTO struct {
+	Email     *string `json:"email,omitempty"`
+	IsNewUser *bool   `json:"isNewUser,omitempty"`
+	Token     *string `json:"token,omitempty"`
+	UserId    *string `json:"userId,omitempty"`
+}
+
+type UsersDtoResetPasswordByTokenRequestDTO struct {
+	NewPassword string `json:"newPassword"`
+	Token       string `json:"token"`
+}
+
+type UsersDtoResetPasswordRequestDTO struct {
+	Code        string `json:"code"`
+	Email       string `json:"email"`
+	NewPassword string `json:"newPassword"`
+}
+
+type UsersDtoSendResetPasswordCodeRequestDTO struct {
+	Email string `json:"email"`
+}
+
+type UsersDtoSignInRequestDTO struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type UsersDtoSignInResponseDTO struct {
+	Email  *string `json:"email,omitempty"`
+	Token  *string `json:"token,omitempty"`
+	UserId *string `json:"userId,omitempty"`
+}
+
+type UsersDtoSignUpRequestDTO struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type UsersDtoUpdateUserInfoRequestDTO struct {
+	Email  *string     `json:"email,omitempty"`
+	Locale *I18nLocale `json:"locale,omitempty"`
+	Name   *string     `json:"name,omitempty"`
+}
+
+type UsersDtoUserProfileResponseDTO struct {
+	CreatedAt       *string                     `json:"createdAt,omitempty"`
+	Email           *string                     `json:"email,omitempty"`
+	Id              *string                     `json:"id,omitempty"`
+	IsActive        *bool                       `json:"isActive,omitempty"`
+	IsEmailVerified *bool                       `json:"isEmailVerified,omitempty"`
+	Locale          *I18nLocale                 `json:"locale,omitempty"`
+	Name            *string                     `json:"name,omitempty"`
+	PendingEmail    *string                     `json:"pendingEmail,omitempty"`
+	Preferences     *UsersModelsUserPreferences `json:"preferences,omitempty"`
+	Role            *UsersEnumsUserRole         `json:"role,omitempty"`
+}
+
+type UsersDtoVerifyEmailRequestDTO struct {
+	Token string `json:"token"`
+}
+
+type UsersEnumsStorageRedactionLevel string
+
+const (
+	UsersEnumsStorageRedactionLevelFull         UsersEnumsStorageRedactionLevel = "FULL"
+	UsersEnumsStorageRedactionLevelMetadataOnly UsersEnumsStorageRedactionLevel = "METADATA_ONLY"
+	UsersEnumsStorageRedactionLevelNone         UsersEnumsStorageRedactionLevel = "NONE"
+)
+
+type UsersEnumsStorageRedactionLevels = map[string]*UsersEnumsStorageRedactionLevel
+
+type UsersEnumsUserRole string
+
+const (
+	UsersEnumsUserRoleAdmin  UsersEnumsUserRole = "ADMIN"
+	UsersEnumsUserRoleMember UsersEnumsUserRole = "MEMBER"
+)
+
+type UsersEnumsWorkspaceRole string
+
+const (
+	UsersEnumsWorkspaceRoleWorkspaceOwner  UsersEnumsWorkspaceRole = "WORKSPACE_OWNER"
+	UsersEnumsWorkspaceRoleWorkspaceAdmin  UsersEnumsWorkspaceRole = "WORKSPACE_ADMIN"
+	UsersEnumsWorkspaceRoleWorkspaceMember UsersEnumsWorkspaceRole = "WORKSPACE_MEMBER"
+	UsersEnumsWorkspaceRoleWorkspaceViewer UsersEnumsWorkspaceRole = "WORKSPACE_VIEWER"
+)
+
+type UsersModelsBrandingSettings struct {
+	AccentColor  *string `json:"accentColor,omitempty"`
+	Id           *string `json:"id,omitempty"`
+	LogoUrl      *string `json:"logoUrl,omitempty"`
+	ProductName  *string `json:"productName,omitempty"`
+	SupportEmail *string `json:"supportEmail,omitempty"`
+}
+
+type UsersModelsSecurityHeaders struct {
+	// ContentSecurityPolicy is sent as is, frame-ancestors is appended from FrameAncestors
+	ContentSecurityPolicy *string `json:"contentSecurityPolicy,omitempty"`
+	// CORSAllowedOrigins may call the API from browsers, e.g. "https://portal.example.com".
+	// "*" allows any origin
+	CorsAllowedOrigins []string `json:"corsAllowedOrigins,omitempty"`
+	// FrameAncestors may embed the UI, e.g. "'self'" or "https://wiki.example.com"
+	FrameAncestors []string `json:"frameAncestors,omitempty"`
+	// HSTSMaxAgeSeconds enables Strict-Transport-Security, 0 disables it
+	HstsMaxAgeSeconds         *int64 `json:"hstsMaxAgeSeconds,omitempty"`
+	IsHstsIncludingSubdomains *bool  `json:"isHstsIncludingSubdomains,omitempty"`
+}
+
+type UsersModelsSecurityPolicy struct {
+	// AllowedSSODomains limits GitHub and Google sign-ins to these email domains, empty allows all
+	AllowedSsoDomains []string `json:"allowedSsoDomains,omitempty"`
+	// IsPasswordComplexityRequired requires upper and lower case letters and a digit
+	IsPasswordComplexityRequired *bool `json:"isPasswordComplexityRequired,omitempty"`
+	// PasswordMinLength is at least 8, 0 means 8
+	PasswordMinLength *int64 `json:"passwordMinLength,omitempty"`
+	// SessionLifetimeHours limits how long a sign-in lasts, 0 keeps sessions until sign out.
+	// Shortening it also ends older sessions
+	SessionLifetimeHours *int64 `json:"sessionLifetimeHours,omitempty"`
+}
+
+type UsersModelsUserPreferences struct {
+	DefaultWorkspaceId *string `json:"defaultWorkspaceId,omitempty"`
+	// IsNotifyOnOwnedBackupFailures emails the user when a backup of a database they own fails
+	IsNotifyOnOwnedBackupFailures *bool `json:"isNotifyOnOwnedBackupFailures,omitempty"`
+	// Timezone is an IANA name like "Europe/Berlin" used to render schedules, empty for UTC
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+type UsersModelsUsersSettings struct {
+	Id *string `json:"id,omitempty"`
+	// means that any user can register via sign up form without invitation
+	IsAllowExternalRegistrations *bool `json:"isAllowExternalRegistrations,omitempty"`
+	// means that any user with role MEMBER can invite other users
+	IsAllowMemberInvitations *bool `json:"isAllowMemberInvitations,omitempty"`
+	// means that any user with role MEMBER can create their own workspaces
+	IsMemberAllowedToCreateWorkspaces *bool `json:"isMemberAllowedToCreateWorkspaces,omitempty"`
+	// how much of system storages users see by their workspace role, FULL for missing roles
+	SystemStorageRedactionLevels *UsersEnumsStorageRedactionLevels `json:"systemStorageRedactionLevels,omitempty"`
+}
+
+type WebhookNotifierWebhookHeader struct {
+	Key   *string `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type WebhookNotifierWebhookMethod string
+
+const (
+	WebhookNotifierWebhookMethodPost WebhookNotifierWebhookMethod = "POST"
+	WebhookNotifierWebhookMethodGet  WebhookNotifierWebhookMethod = "GET"
+)
+
+type WebhookNotifierWebhookNotifier struct {
+	BodyTemplate *string                         `json:"bodyTemplate,omitempty"`
+	Headers      []*WebhookNotifierWebhookHeader `json:"headers,omitempty"`
+	NotifierId   *string                         `json:"notifierId,omitempty"`
+	// ProxyURL overrides OUTBOUND_PROXY_URL for this notifier, see proxy_utils.GetProxyFunc
+	ProxyUrl      *string                       `json:"proxyUrl,omitempty"`
+	WebhookMethod *WebhookNotifierWebhookMethod `json:"webhookMethod,omitempty"`
+	WebhookUrl    *string                       `json:"webhookUrl,omitempty"`
+}
+
+type WorkspacesDtoAddMemberRequestDTO struct {
+	Email string                   `json:"email"`
+	Role  *UsersEnumsWorkspaceRole `json:"role"`
+}
+
+type WorkspacesDtoAddMemberResponseDTO struct {
+	Status *WorkspacesDtoAddMemberStatus `json:"status,omitempty"`
+}
+
+type WorkspacesDtoAddMemberStatus string
+
+const (
+	WorkspacesDtoAddMemberStatusInvited WorkspacesDtoAddMemberStatus = "INVITED"
+	WorkspacesDtoAddMemberStatusAdded   WorkspacesDtoAddMemberStatus = "ADDED"
+)
+
+type WorkspacesDtoChangeMemberRoleRequestDTO struct {
+	Role *UsersEnumsWorkspaceRole `json:"role"`
+}
+
+type WorkspacesDtoCreateWorkspaceRequestDTO struct {
+	Environment *EnvironmentsEnvironment `json:"environment,omitempty"`
+	Name        string                   `json:"name"`
+}
+
+type WorkspacesDtoGetMembersResponseDTO struct {
+	Members []*WorkspacesDtoWorkspaceMemberResponseDTO `json:"members,omitempty"`
+}
+
+type WorkspacesDtoListWorkspacesResponseDTO struct {
+	Workspaces []*WorkspacesDtoWorkspaceResponseDTO `json:"workspaces,omitempty"`
+}
+
+type WorkspacesDtoTransferOwnershipRequestDTO struct {
+	NewOwnerEmail string `json:"newOwnerEmail"`
+}
+
+type WorkspacesDtoWorkspaceMemberResponseDTO struct {
+	CreatedAt *string `json:"createdAt,omitempty"`
+	// Populated from user join
+	Email *string `json:"email,omitempty"`
+	Id    *string `json:"id,omitempty"`
+	// Populated from user join
+	Name   *string                  `json:"name,omitempty"`
+	Role   *UsersEnumsWorkspaceRole `json:"role,omitempty"`
+	UserId *string                  `json:"userId,omitempty"`
+}
+
+type WorkspacesDtoWorkspaceResponseDTO struct {
+	CreatedAt   *string                  `json:"createdAt,omitempty"`
+	Environment *EnvironmentsEnvironment `json:"environment,omitempty"`
+	Id          *string                  `json:"id,omitempty"`
+	Name        *string                  `json:"name,omitempty"`
+	// User's role in this workspace (populated when fetching for specific user)
+	UserRole *UsersEnumsWorkspaceRole `json:"userRole,omitempty"`
+}
+
+type WorkspacesModelsWorkspace struct {
+	CreatedAt   *string                  `json:"createdAt,omitempty"`
+	Environment *EnvironmentsEnvironment `json:"environment,omitempty"`
+	Id          *string                  `json:"id,omitempty"`
+	// IsChangeReasonRequired makes edits of storages, databases and notifiers of the
+	// workspace give a reason, e.g. for regulated environments
+	IsChangeReasonRequired *bool   `json:"isChangeReasonRequired,omitempty"`
+	Name                   *string `json:"name,omitempty"`
+}
+
+// DeleteAgentsById calls DELETE /agents/{id}. Delete an agent
+func (c *Client) DeleteAgentsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/agents/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteBackupBadgesDatabaseByDatabaseId calls DELETE /backup-badges/database/{databaseId}. Delete backup badge
+func (c *Client) DeleteBackupBadgesDatabaseByDatabaseId(ctx context.Context, databaseId string) error {
+	req := request{method: "DELETE", path: buildPath("/backup-badges/database/{databaseId}", map[string]any{"databaseId": databaseId})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteBackupConfigsConcurrencyGroupsById calls DELETE /backup-configs/concurrency-groups/{id}. Delete concurrency group
+func (c *Client) DeleteBackupConfigsConcurrencyGroupsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/backup-configs/concurrency-groups/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteBackupTriggersDatabaseByDatabaseId calls DELETE /backup-triggers/database/{databaseId}. Delete backup trigger
+func (c *Client) DeleteBackupTriggersDatabaseByDatabaseId(ctx context.Context, databaseId string) error {
+	req := request{method: "DELETE", path: buildPath("/backup-triggers/database/{databaseId}", map[string]any{"databaseId": databaseId})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteBackupsById calls DELETE /backups/{id}. Delete a backup
+func (c *Client) DeleteBackupsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/backups/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteBackupsShareLinksByShareLinkId calls DELETE /backups/share-links/{shareLinkId}. Revoke a share link
+func (c *Client) DeleteBackupsShareLinksByShareLinkId(ctx context.Context, shareLinkId string) error {
+	req := request{method: "DELETE", path: buildPath("/backups/share-links/{shareLinkId}", map[string]any{"shareLinkId": shareLinkId})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteCalendarFeedsWorkspaceByWorkspaceId calls DELETE /calendar-feeds/workspace/{workspaceId}. Delete calendar feed
+func (c *Client) DeleteCalendarFeedsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) error {
+	req := request{method: "DELETE", path: buildPath("/calendar-feeds/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteChatopsAccountsById calls DELETE /chatops/accounts/{id}. Unlink chat account
+func (c *Client) DeleteChatopsAccountsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/chatops/accounts/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteClientCertificatesById calls DELETE /client-certificates/{id}. Delete client certificate
+func (c *Client) DeleteClientCertificatesById(ctx context.Context, id string) (map[string]string, error) {
+	req := request{method: "DELETE", path: buildPath("/client-certificates/{id}", map[string]any{"id": id})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteCommentsById calls DELETE /comments/{id}. Delete a comment
+func (c *Client) DeleteCommentsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/comments/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteConnectionTemplatesById calls DELETE /connection-templates/{id}. Delete a connection template
+func (c *Client) DeleteConnectionTemplatesById(ctx context.Context, id string) (map[string]string, error) {
+	req := request{method: "DELETE", path: buildPath("/connection-templates/{id}", map[string]any{"id": id})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteDatabasesById calls DELETE /databases/{id}. Delete a database
+func (c *Client) DeleteDatabasesById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/databases/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteFeaturesWorkspacesByWorkspaceIdByFlag calls DELETE /features/workspaces/{workspaceId}/{flag}. Remove feature flag override
+func (c *Client) DeleteFeaturesWorkspacesByWorkspaceIdByFlag(ctx context.Context, workspaceId string, flag string) (map[string]string, error) {
+	req := request{method: "DELETE", path: buildPath("/features/workspaces/{workspaceId}/{flag}", map[string]any{"workspaceId": workspaceId, "flag": flag})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteGrafanaIntegrationsWorkspaceByWorkspaceId calls DELETE /grafana-integrations/workspace/{workspaceId}. Delete Grafana integration
+func (c *Client) DeleteGrafanaIntegrationsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) error {
+	req := request{method: "DELETE", path: buildPath("/grafana-integrations/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteLocalizationOverridesByLocaleByKey calls DELETE /localization/overrides/{locale}/{key}. Reset message template
+func (c *Client) DeleteLocalizationOverridesByLocaleByKey(ctx context.Context, locale string, key string) (map[string]string, error) {
+	req := request{method: "DELETE", path: buildPath("/localization/overrides/{locale}/{key}", map[string]any{"locale": locale, "key": key})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteNotifiersById calls DELETE /notifiers/{id}. Delete a notifier
+func (c *Client) DeleteNotifiersById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/notifiers/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteNotifiersEventsWorkspaceByWorkspaceIdByEventType calls DELETE /notifiers/events/workspace/{workspaceId}/{eventType}. Reset event severity
+func (c *Client) DeleteNotifiersEventsWorkspaceByWorkspaceIdByEventType(ctx context.Context, workspaceId string, eventType string) error {
+	req := request{method: "DELETE", path: buildPath("/notifiers/events/workspace/{workspaceId}/{eventType}", map[string]any{"workspaceId": workspaceId, "eventType": eventType})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteRefreshJobsById calls DELETE /refresh-jobs/{id}. Delete a refresh job
+func (c *Client) DeleteRefreshJobsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/refresh-jobs/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteStatusPagesWorkspaceByWorkspaceId calls DELETE /status-pages/workspace/{workspaceId}. Delete status page
+func (c *Client) DeleteStatusPagesWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) error {
+	req := request{method: "DELETE", path: buildPath("/status-pages/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteStorageMirrorGroupsById calls DELETE /storage-mirror-groups/{id}. Delete storage mirror group
+func (c *Client) DeleteStorageMirrorGroupsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/storage-mirror-groups/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteStoragesById calls DELETE /storages/{id}. Delete a storage
+func (c *Client) DeleteStoragesById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/storages/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteTicketIntegrationsWorkspaceByWorkspaceId calls DELETE /ticket-integrations/workspace/{workspaceId}. Delete ticket integration
+func (c *Client) DeleteTicketIntegrationsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) error {
+	req := request{method: "DELETE", path: buildPath("/ticket-integrations/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	return c.do(ctx, req, nil)
+}
+
+type DeleteUsersMeFavoritesByResourceIdParams struct {
+	WorkspaceId string
+}
+
+// DeleteUsersMeFavoritesByResourceId calls DELETE /users/me/favorites/{resourceId}. Remove a favorite
+func (c *Client) DeleteUsersMeFavoritesByResourceId(ctx context.Context, resourceId string, params *DeleteUsersMeFavoritesByResourceIdParams) error {
+	req := request{method: "DELETE", path: buildPath("/users/me/favorites/{resourceId}", map[string]any{"resourceId": resourceId})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteUsersMeViewsById calls DELETE /users/me/views/{id}. Delete a saved view
+func (c *Client) DeleteUsersMeViewsById(ctx context.Context, id string) error {
+	req := request{method: "DELETE", path: buildPath("/users/me/views/{id}", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// DeleteWorkspacesById calls DELETE /workspaces/{id}. Delete workspace
+func (c *Client) DeleteWorkspacesById(ctx context.Context, id string) (map[string]string, error) {
+	req := request{method: "DELETE", path: buildPath("/workspaces/{id}", map[string]any{"id": id})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteWorkspacesMembershipsByIdMembersByUserId calls DELETE /workspaces/memberships/{id}/members/{userId}. Remove member from workspace
+func (c *Client) DeleteWorkspacesMembershipsByIdMembersByUserId(ctx context.Context, id string, userId string) (map[string]string, error) {
+	req := request{method: "DELETE", path: buildPath("/workspaces/memberships/{id}/members/{userId}", map[string]any{"id": id, "userId": userId})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetAgentsParams struct {
+	WorkspaceId string
+}
+
+// GetAgents calls GET /agents. Get agents
+func (c *Client) GetAgents(ctx context.Context, params *GetAgentsParams) ([]*AgentsAgent, error) {
+	req := request{method: "GET", path: "/agents"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	var result []*AgentsAgent
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAgentsConnect calls GET /agents/connect. Receive backup jobs (agent)
+func (c *Client) GetAgentsConnect(ctx context.Context) (io.ReadCloser, error) {
+	req := request{method: "GET", path: "/agents/connect"}
+	return c.stream(ctx, req)
+}
+
+type GetAuditLogsGlobalParams struct {
+	Limit      *int64
+	Offset     *int64
+	BeforeDate *time.Time
+}
+
+// GetAuditLogsGlobal calls GET /audit-logs/global. Get global audit logs (ADMIN only)
+func (c *Client) GetAuditLogsGlobal(ctx context.Context, params *GetAuditLogsGlobalParams) (*AuditLogsGetAuditLogsResponse, error) {
+	req := request{method: "GET", path: "/audit-logs/global"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "limit", params.Limit)
+		setQuery(req.query, "offset", params.Offset)
+		setQuery(req.query, "beforeDate", params.BeforeDate)
+	}
+	result := new(AuditLogsGetAuditLogsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetAuditLogsUsersByUserIdParams struct {
+	Limit      *int64
+	Offset     *int64
+	BeforeDate *time.Time
+}
+
+// GetAuditLogsUsersByUserId calls GET /audit-logs/users/{userId}. Get user audit logs
+func (c *Client) GetAuditLogsUsersByUserId(ctx context.Context, userId string, params *GetAuditLogsUsersByUserIdParams) (*AuditLogsGetAuditLogsResponse, error) {
+	req := request{method: "GET", path: buildPath("/audit-logs/users/{userId}", map[string]any{"userId": userId})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "limit", params.Limit)
+		setQuery(req.query, "offset", params.Offset)
+		setQuery(req.query, "beforeDate", params.BeforeDate)
+	}
+	result := new(AuditLogsGetAuditLogsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupBadgesDatabaseByDatabaseId calls GET /backup-badges/database/{databaseId}. Get backup badge
+func (c *Client) GetBackupBadgesDatabaseByDatabaseId(ctx context.Context, databaseId string) (*BackupsBadgesBackupBadge, error) {
+	req := request{method: "GET", path: buildPath("/backup-badges/database/{databaseId}", map[string]any{"databaseId": databaseId})}
+	result := new(BackupsBadgesBackupBadge)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBackupBadgesPublicByTokenParams struct {
+	MaxAgeHours *int64
+	Label       *string
+}
+
+// GetBackupBadgesPublicByToken calls GET /backup-badges/public/{token}. Get public backup badge
+func (c *Client) GetBackupBadgesPublicByToken(ctx context.Context, token string, params *GetBackupBadgesPublicByTokenParams) (io.ReadCloser, error) {
+	req := request{method: "GET", path: buildPath("/backup-badges/public/{token}", map[string]any{"token": token})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "maxAgeHours", params.MaxAgeHours)
+		setQuery(req.query, "label", params.Label)
+	}
+	return c.stream(ctx, req)
+}
+
+// GetBackupConfigsConcurrencyGroupsWorkspaceByWorkspaceId calls GET /backup-configs/concurrency-groups/workspace/{workspaceId}. Get concurrency groups
+func (c *Client) GetBackupConfigsConcurrencyGroupsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) ([]*BackupsConfigConcurrencyGroup, error) {
+	req := request{method: "GET", path: buildPath("/backup-configs/concurrency-groups/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	var result []*BackupsConfigConcurrencyGroup
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupConfigsDatabaseById calls GET /backup-configs/database/{id}. Get backup configuration by database ID
+func (c *Client) GetBackupConfigsDatabaseById(ctx context.Context, id string) (*BackupsConfigBackupConfig, error) {
+	req := request{method: "GET", path: buildPath("/backup-configs/database/{id}", map[string]any{"id": id})}
+	result := new(BackupsConfigBackupConfig)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupConfigsDatabaseByIdPlan calls GET /backup-configs/database/{id}/plan. Get database plan by database ID
+func (c *Client) GetBackupConfigsDatabaseByIdPlan(ctx context.Context, id string) (*PlansDatabasePlan, error) {
+	req := request{method: "GET", path: buildPath("/backup-configs/database/{id}/plan", map[string]any{"id": id})}
+	result := new(PlansDatabasePlan)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupConfigsDatabaseByIdTransfers calls GET /backup-configs/database/{id}/transfers. Get transfer history of database
+func (c *Client) GetBackupConfigsDatabaseByIdTransfers(ctx context.Context, id string) ([]*BackupsConfigDatabaseTransfer, error) {
+	req := request{method: "GET", path: buildPath("/backup-configs/database/{id}/transfers", map[string]any{"id": id})}
+	var result []*BackupsConfigDatabaseTransfer
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupConfigsStorageByIdDatabasesCount calls GET /backup-configs/storage/{id}/databases-count. Count databases using a storage
+func (c *Client) GetBackupConfigsStorageByIdDatabasesCount(ctx context.Context, id string) (map[string]int64, error) {
+	req := request{method: "GET", path: buildPath("/backup-configs/storage/{id}/databases-count", map[string]any{"id": id})}
+	var result map[string]int64
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupConfigsStorageByIdIsUsing calls GET /backup-configs/storage/{id}/is-using. Check if storage is being used
+func (c *Client) GetBackupConfigsStorageByIdIsUsing(ctx context.Context, id string) (map[string]bool, error) {
+	req := request{method: "GET", path: buildPath("/backup-configs/storage/{id}/is-using", map[string]any{"id": id})}
+	var result map[string]bool
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupTriggersDatabaseByDatabaseId calls GET /backup-triggers/database/{databaseId}. Get backup trigger
+func (c *Client) GetBackupTriggersDatabaseByDatabaseId(ctx context.Context, databaseId string) (*BackupsTriggersBackupTrigger, error) {
+	req := request{method: "GET", path: buildPath("/backup-triggers/database/{databaseId}", map[string]any{"databaseId": databaseId})}
+	result := new(BackupsTriggersBackupTrigger)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBackupsParams struct {
+	DatabaseId string
+	Limit      *int64
+	Offset     *int64
+}
+
+// GetBackups calls GET /backups. Get backups for a database
+func (c *Client) GetBackups(ctx context.Context, params *GetBackupsParams) (*BackupsGetBackupsResponse, error) {
+	req := request{method: "GET", path: "/backups"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "database_id", params.DatabaseId)
+		setQuery(req.query, "limit", params.Limit)
+		setQuery(req.query, "offset", params.Offset)
+	}
+	result := new(BackupsGetBackupsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBackupsByIdFileParams struct {
+	Token string
+}
+
+// GetBackupsByIdFile calls GET /backups/{id}/file. Download a backup file
+func (c *Client) GetBackupsByIdFile(ctx context.Context, id string, params *GetBackupsByIdFileParams) (io.ReadCloser, error) {
+	req := request{method: "GET", path: buildPath("/backups/{id}/file", map[string]any{"id": id})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "token", params.Token)
+	}
+	return c.stream(ctx, req)
+}
+
+// GetBackupsByIdLog calls GET /backups/{id}/log. Get run log of a backup
+func (c *Client) GetBackupsByIdLog(ctx context.Context, id string) (*BackupsCoreBackupRunLog, error) {
+	req := request{method: "GET", path: buildPath("/backups/{id}/log", map[string]any{"id": id})}
+	result := new(BackupsCoreBackupRunLog)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupsByIdReplicas calls GET /backups/{id}/replicas. Get backup replicas
+func (c *Client) GetBackupsByIdReplicas(ctx context.Context, id string) ([]*StoragesMirrorsBackupReplica, error) {
+	req := request{method: "GET", path: buildPath("/backups/{id}/replicas", map[string]any{"id": id})}
+	var result []*StoragesMirrorsBackupReplica
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupsByIdRestoreEstimate calls GET /backups/{id}/restore-estimate. Estimate restore duration of a backup
+func (c *Client) GetBackupsByIdRestoreEstimate(ctx context.Context, id string) (*RestoresCoreRestoreEstimate, error) {
+	req := request{method: "GET", path: buildPath("/backups/{id}/restore-estimate", map[string]any{"id": id})}
+	result := new(RestoresCoreRestoreEstimate)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupsByIdShareLinks calls GET /backups/{id}/share-links. Get share links of a backup
+func (c *Client) GetBackupsByIdShareLinks(ctx context.Context, id string) ([]*BackupsDownloadBackupShareLink, error) {
+	req := request{method: "GET", path: buildPath("/backups/{id}/share-links", map[string]any{"id": id})}
+	var result []*BackupsDownloadBackupShareLink
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBackupsDeadLettersParams struct {
+	WorkspaceId string
+}
+
+// GetBackupsDeadLetters calls GET /backups/dead-letters. Get backups that exhausted retries
+func (c *Client) GetBackupsDeadLetters(ctx context.Context, params *GetBackupsDeadLettersParams) ([]*BackupsCoreBackupDeadLetter, error) {
+	req := request{method: "GET", path: "/backups/dead-letters"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	var result []*BackupsCoreBackupDeadLetter
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBackupsJobsByIdLogsParams struct {
+	Follow *bool
+}
+
+// GetBackupsJobsByIdLogs calls GET /backups/jobs/{id}/logs. Stream logs of a backup job
+func (c *Client) GetBackupsJobsByIdLogs(ctx context.Context, id string, params *GetBackupsJobsByIdLogsParams) (io.ReadCloser, error) {
+	req := request{method: "GET", path: buildPath("/backups/jobs/{id}/logs", map[string]any{"id": id})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "follow", params.Follow)
+	}
+	return c.stream(ctx, req)
+}
+
+// GetBackupsScheduleProjection calls GET /backups/schedule-projection. Get projected load of backup nodes
+func (c *Client) GetBackupsScheduleProjection(ctx context.Context) (*BackupingScheduleLoadProjection, error) {
+	req := request{method: "GET", path: "/backups/schedule-projection"}
+	result := new(BackupingScheduleLoadProjection)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBackupsShareLinksReceived calls GET /backups/share-links/received. Get backups shared with the current user
+func (c *Client) GetBackupsShareLinksReceived(ctx context.Context) ([]*BackupsDownloadBackupShareLink, error) {
+	req := request{method: "GET", path: "/backups/share-links/received"}
+	var result []*BackupsDownloadBackupShareLink
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBillingPlans calls GET /billing/plans. Get plans
+func (c *Client) GetBillingPlans(ctx context.Context) ([]*BillingSubscriptionsPlanQuota, error) {
+	req := request{method: "GET", path: "/billing/plans"}
+	var result []*BillingSubscriptionsPlanQuota
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBillingSubscriptionsWorkspacesByWorkspaceId calls GET /billing/subscriptions/workspaces/{workspaceId}. Get workspace subscription
+func (c *Client) GetBillingSubscriptionsWorkspacesByWorkspaceId(ctx context.Context, workspaceId string) (*BillingSubscriptionsGetWorkspaceSubscriptionResponse, error) {
+	req := request{method: "GET", path: buildPath("/billing/subscriptions/workspaces/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	result := new(BillingSubscriptionsGetWorkspaceSubscriptionResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBillingUsageRecordsParams struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// GetBillingUsageRecords calls GET /billing/usage/records. Export usage records (ADMIN only)
+func (c *Client) GetBillingUsageRecords(ctx context.Context, params *GetBillingUsageRecordsParams) (*BillingUsageExportUsageRecordsResponse, error) {
+	req := request{method: "GET", path: "/billing/usage/records"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "from", params.From)
+		setQuery(req.query, "to", params.To)
+	}
+	result := new(BillingUsageExportUsageRecordsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetBillingUsageWorkspacesByWorkspaceIdParams struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// GetBillingUsageWorkspacesByWorkspaceId calls GET /billing/usage/workspaces/{workspaceId}. Get workspace usage
+func (c *Client) GetBillingUsageWorkspacesByWorkspaceId(ctx context.Context, workspaceId string, params *GetBillingUsageWorkspacesByWorkspaceIdParams) (*BillingUsageGetWorkspaceUsageResponse, error) {
+	req := request{method: "GET", path: buildPath("/billing/usage/workspaces/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "from", params.From)
+		setQuery(req.query, "to", params.To)
+	}
+	result := new(BillingUsageGetWorkspaceUsageResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBroadcasts calls GET /broadcasts. Get broadcasts
+func (c *Client) GetBroadcasts(ctx context.Context) ([]*NotifiersBroadcastsBroadcast, error) {
+	req := request{method: "GET", path: "/broadcasts"}
+	var result []*NotifiersBroadcastsBroadcast
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBroadcastsByIdDeliveries calls GET /broadcasts/{id}/deliveries. Get broadcast deliveries
+func (c *Client) GetBroadcastsByIdDeliveries(ctx context.Context, id string) ([]*NotifiersBroadcastsBroadcastDelivery, error) {
+	req := request{method: "GET", path: buildPath("/broadcasts/{id}/deliveries", map[string]any{"id": id})}
+	var result []*NotifiersBroadcastsBroadcastDelivery
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCalendarFeedsPublicByToken calls GET /calendar-feeds/public/{token}. Get public calendar
+func (c *Client) GetCalendarFeedsPublicByToken(ctx context.Context, token string) (io.ReadCloser, error) {
+	req := request{method: "GET", path: buildPath("/calendar-feeds/public/{token}", map[string]any{"token": token})}
+	return c.stream(ctx, req)
+}
+
+// GetCalendarFeedsWorkspaceByWorkspaceId calls GET /calendar-feeds/workspace/{workspaceId}. Get calendar feed
+func (c *Client) GetCalendarFeedsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) (*BackupsCalendarsCalendarFeed, error) {
+	req := request{method: "GET", path: buildPath("/calendar-feeds/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	result := new(BackupsCalendarsCalendarFeed)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetChatopsAccounts calls GET /chatops/accounts. Get linked chat accounts
+func (c *Client) GetChatopsAccounts(ctx context.Context) ([]*ChatopsChatAccount, error) {
+	req := request{method: "GET", path: "/chatops/accounts"}
+	var result []*ChatopsChatAccount
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetClientCertificates calls GET /client-certificates. Get client certificates
+func (c *Client) GetClientCertificates(ctx context.Context) ([]*ClientCertificatesClientCertificate, error) {
+	req := request{method: "GET", path: "/client-certificates"}
+	var result []*ClientCertificatesClientCertificate
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetCommentsParams struct {
+	ResourceType string
+	ResourceId   string
+}
+
+// GetComments calls GET /comments. Get comments of a resource
+func (c *Client) GetComments(ctx context.Context, params *GetCommentsParams) ([]*CommentsCommentDTO, error) {
+	req := request{method: "GET", path: "/comments"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "resourceType", params.ResourceType)
+		setQuery(req.query, "resourceId", params.ResourceId)
+	}
+	var result []*CommentsCommentDTO
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetConnectionTemplates calls GET /connection-templates. Get connection templates
+func (c *Client) GetConnectionTemplates(ctx context.Context) ([]*DatabasesTemplatesConnectionTemplateResponse, error) {
+	req := request{method: "GET", path: "/connection-templates"}
+	var result []*DatabasesTemplatesConnectionTemplateResponse
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetCredentialExpiryWorkspaceByWorkspaceIdParams struct {
+	Days *int64
+}
+
+// GetCredentialExpiryWorkspaceByWorkspaceId calls GET /credential-expiry/workspace/{workspaceId}. Get expiring credentials
+func (c *Client) GetCredentialExpiryWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, params *GetCredentialExpiryWorkspaceByWorkspaceIdParams) (*CredentialExpiryExpiringCredentialsResponse, error) {
+	req := request{method: "GET", path: buildPath("/credential-expiry/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "days", params.Days)
+	}
+	result := new(CredentialExpiryExpiringCredentialsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetDatabasesParams struct {
+	WorkspaceId string
+	OwnerUserId *string
+	OwnerTeam   *string
+}
+
+// GetDatabases calls GET /databases. Get databases by workspace
+func (c *Client) GetDatabases(ctx context.Context, params *GetDatabasesParams) ([]*DatabasesDatabase, error) {
+	req := request{method: "GET", path: "/databases"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+		setQuery(req.query, "owner_user_id", params.OwnerUserId)
+		setQuery(req.query, "owner_team", params.OwnerTeam)
+	}
+	var result []*DatabasesDatabase
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDatabasesById calls GET /databases/{id}. Get a database
+func (c *Client) GetDatabasesById(ctx context.Context, id string) (*DatabasesDatabase, error) {
+	req := request{method: "GET", path: buildPath("/databases/{id}", map[string]any{"id": id})}
+	result := new(DatabasesDatabase)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDatabasesNotifierByIdDatabasesCount calls GET /databases/notifier/{id}/databases-count. Count databases using a notifier
+func (c *Client) GetDatabasesNotifierByIdDatabasesCount(ctx context.Context, id string) (map[string]int64, error) {
+	req := request{method: "GET", path: buildPath("/databases/notifier/{id}/databases-count", map[string]any{"id": id})}
+	var result map[string]int64
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDatabasesNotifierByIdIsUsing calls GET /databases/notifier/{id}/is-using. Check if notifier is being used
+func (c *Client) GetDatabasesNotifierByIdIsUsing(ctx context.Context, id string) (map[string]bool, error) {
+	req := request{method: "GET", path: buildPath("/databases/notifier/{id}/is-using", map[string]any{"id": id})}
+	var result map[string]bool
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDiskUsage calls GET /disk/usage. Get disk usage information
+func (c *Client) GetDiskUsage(ctx context.Context) (*DiskDiskUsage, error) {
+	req := request{method: "GET", path: "/disk/usage"}
+	result := new(DiskDiskUsage)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetFeaturesParams struct {
+	WorkspaceId *string
+}
+
+// GetFeatures calls GET /features. Get feature flags
+func (c *Client) GetFeatures(ctx context.Context, params *GetFeaturesParams) (*FeatureFlagsGetFeaturesResponse, error) {
+	req := request{method: "GET", path: "/features"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspaceId", params.WorkspaceId)
+	}
+	result := new(FeatureFlagsGetFeaturesResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetGrafanaIntegrationsWorkspaceByWorkspaceId calls GET /grafana-integrations/workspace/{workspaceId}. Get Grafana integration
+func (c *Client) GetGrafanaIntegrationsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) (*BackupsGrafanaGrafanaIntegration, error) {
+	req := request{method: "GET", path: buildPath("/grafana-integrations/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	result := new(BackupsGrafanaGrafanaIntegration)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetGrafanaIntegrationsWorkspaceByWorkspaceIdDashboard calls GET /grafana-integrations/workspace/{workspaceId}/dashboard. Get Grafana dashboard
+func (c *Client) GetGrafanaIntegrationsWorkspaceByWorkspaceIdDashboard(ctx context.Context, workspaceId string) (map[string]any, error) {
+	req := request{method: "GET", path: buildPath("/grafana-integrations/workspace/{workspaceId}/dashboard", map[string]any{"workspaceId": workspaceId})}
+	var result map[string]any
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetHealthcheckAttemptsByDatabaseIdParams struct {
+	AfterDate *string
+}
+
+// GetHealthcheckAttemptsByDatabaseId calls GET /healthcheck-attempts/{databaseId}. Get healthcheck attempts by database
+func (c *Client) GetHealthcheckAttemptsByDatabaseId(ctx context.Context, databaseId string, params *GetHealthcheckAttemptsByDatabaseIdParams) ([]*HealthcheckAttemptHealthcheckAttempt, error) {
+	req := request{method: "GET", path: buildPath("/healthcheck-attempts/{databaseId}", map[string]any{"databaseId": databaseId})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "afterDate", params.AfterDate)
+	}
+	var result []*HealthcheckAttemptHealthcheckAttempt
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetHealthcheckConfigByDatabaseId calls GET /healthcheck-config/{databaseId}. Get healthcheck configuration
+func (c *Client) GetHealthcheckConfigByDatabaseId(ctx context.Context, databaseId string) (*HealthcheckConfigHealthcheckConfig, error) {
+	req := request{method: "GET", path: buildPath("/healthcheck-config/{databaseId}", map[string]any{"databaseId": databaseId})}
+	result := new(HealthcheckConfigHealthcheckConfig)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetLocalizationMessagesParams struct {
+	Locale *string
+}
+
+// GetLocalizationMessages calls GET /localization/messages. Get message templates
+func (c *Client) GetLocalizationMessages(ctx context.Context, params *GetLocalizationMessagesParams) (*LocalizationGetMessagesResponse, error) {
+	req := request{method: "GET", path: "/localization/messages"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "locale", params.Locale)
+	}
+	result := new(LocalizationGetMessagesResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetMaskingRulesByDatabaseId calls GET /masking-rules/{databaseId}. Get masking rules
+func (c *Client) GetMaskingRulesByDatabaseId(ctx context.Context, databaseId string) ([]*MaskingMaskingRule, error) {
+	req := request{method: "GET", path: buildPath("/masking-rules/{databaseId}", map[string]any{"databaseId": databaseId})}
+	var result []*MaskingMaskingRule
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetNotifiersParams struct {
+	WorkspaceId string
+}
+
+// GetNotifiers calls GET /notifiers. Get all notifiers
+func (c *Client) GetNotifiers(ctx context.Context, params *GetNotifiersParams) ([]*NotifiersNotifierResponse, error) {
+	req := request{method: "GET", path: "/notifiers"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	var result []*NotifiersNotifierResponse
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNotifiersById calls GET /notifiers/{id}. Get a notifier by ID
+func (c *Client) GetNotifiersById(ctx context.Context, id string) (*NotifiersNotifierResponse, error) {
+	req := request{method: "GET", path: buildPath("/notifiers/{id}", map[string]any{"id": id})}
+	result := new(NotifiersNotifierResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNotifiersByIdIncidents calls GET /notifiers/{id}/incidents. Get notifier incidents
+func (c *Client) GetNotifiersByIdIncidents(ctx context.Context, id string) ([]*NotifiersNotifierIncident, error) {
+	req := request{method: "GET", path: buildPath("/notifiers/{id}/incidents", map[string]any{"id": id})}
+	var result []*NotifiersNotifierIncident
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNotifiersEvents calls GET /notifiers/events. Get notification events
+func (c *Client) GetNotifiersEvents(ctx context.Context) ([]*NotifiersNotificationEvent, error) {
+	req := request{method: "GET", path: "/notifiers/events"}
+	var result []*NotifiersNotificationEvent
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNotifiersEventsWorkspaceByWorkspaceId calls GET /notifiers/events/workspace/{workspaceId}. Get event severities of a workspace
+func (c *Client) GetNotifiersEventsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) ([]*NotifiersEventSeverityResponse, error) {
+	req := request{method: "GET", path: buildPath("/notifiers/events/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	var result []*NotifiersEventSeverityResponse
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetOwnershipWorkspaceByWorkspaceIdOrphaned calls GET /ownership/workspace/{workspaceId}/orphaned. Get orphaned resources
+func (c *Client) GetOwnershipWorkspaceByWorkspaceIdOrphaned(ctx context.Context, workspaceId string) (*OwnershipOrphansOrphanedResourcesResponse, error) {
+	req := request{method: "GET", path: buildPath("/ownership/workspace/{workspaceId}/orphaned", map[string]any{"workspaceId": workspaceId})}
+	result := new(OwnershipOrphansOrphanedResourcesResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetRefreshJobsByIdRuns calls GET /refresh-jobs/{id}/runs. Get refresh runs
+func (c *Client) GetRefreshJobsByIdRuns(ctx context.Context, id string) ([]*RestoresRefreshesRefreshRun, error) {
+	req := request{method: "GET", path: buildPath("/refresh-jobs/{id}/runs", map[string]any{"id": id})}
+	var result []*RestoresRefreshesRefreshRun
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetRefreshJobsDatabaseByDatabaseId calls GET /refresh-jobs/database/{databaseId}. Get refresh jobs
+func (c *Client) GetRefreshJobsDatabaseByDatabaseId(ctx context.Context, databaseId string) ([]*RestoresRefreshesRefreshJob, error) {
+	req := request{method: "GET", path: buildPath("/refresh-jobs/database/{databaseId}", map[string]any{"databaseId": databaseId})}
+	var result []*RestoresRefreshesRefreshJob
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetRestorePlansById calls GET /restore-plans/{id}. Get a restore plan
+func (c *Client) GetRestorePlansById(ctx context.Context, id string) (*RestoresPlansRestorePlan, error) {
+	req := request{method: "GET", path: buildPath("/restore-plans/{id}", map[string]any{"id": id})}
+	result := new(RestoresPlansRestorePlan)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetRestorePlansWorkspaceByWorkspaceId calls GET /restore-plans/workspace/{workspaceId}. Get restore plans
+func (c *Client) GetRestorePlansWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) ([]*RestoresPlansRestorePlan, error) {
+	req := request{method: "GET", path: buildPath("/restore-plans/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	var result []*RestoresPlansRestorePlan
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetRestoresByBackupId calls GET /restores/{backupId}. Get restores for a backup
+func (c *Client) GetRestoresByBackupId(ctx context.Context, backupId string) ([]*RestoresCoreRestore, error) {
+	req := request{method: "GET", path: buildPath("/restores/{backupId}", map[string]any{"backupId": backupId})}
+	var result []*RestoresCoreRestore
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetRunbooksWorkspaceByWorkspaceIdParams struct {
+	Format *string
+}
+
+// GetRunbooksWorkspaceByWorkspaceId calls GET /runbooks/workspace/{workspaceId}. Get disaster recovery runbook
+func (c *Client) GetRunbooksWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, params *GetRunbooksWorkspaceByWorkspaceIdParams) (*BackupsRunbooksDRRunbook, error) {
+	req := request{method: "GET", path: buildPath("/runbooks/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "format", params.Format)
+	}
+	result := new(BackupsRunbooksDRRunbook)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSecurityEventsSettings calls GET /security-events/settings. Get security event settings
+func (c *Client) GetSecurityEventsSettings(ctx context.Context) (*NotifiersSecurityEventsSecurityEventSettings, error) {
+	req := request{method: "GET", path: "/security-events/settings"}
+	result := new(NotifiersSecurityEventsSecurityEventSettings)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStatusPagesPublicByToken calls GET /status-pages/public/{token}. Get public status page
+func (c *Client) GetStatusPagesPublicByToken(ctx context.Context, token string) (*BackupsStatusPagesPublicStatusPage, error) {
+	req := request{method: "GET", path: buildPath("/status-pages/public/{token}", map[string]any{"token": token})}
+	result := new(BackupsStatusPagesPublicStatusPage)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStatusPagesWorkspaceByWorkspaceId calls GET /status-pages/workspace/{workspaceId}. Get status page
+func (c *Client) GetStatusPagesWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) (*BackupsStatusPagesStatusPage, error) {
+	req := request{method: "GET", path: buildPath("/status-pages/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	result := new(BackupsStatusPagesStatusPage)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetStorageMirrorGroupsParams struct {
+	WorkspaceId string
+}
+
+// GetStorageMirrorGroups calls GET /storage-mirror-groups. Get storage mirror groups
+func (c *Client) GetStorageMirrorGroups(ctx context.Context, params *GetStorageMirrorGroupsParams) ([]*StoragesMirrorsStorageMirrorGroup, error) {
+	req := request{method: "GET", path: "/storage-mirror-groups"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	var result []*StoragesMirrorsStorageMirrorGroup
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetStoragesParams struct {
+	WorkspaceId string
+	OwnerUserId *string
+	OwnerTeam   *string
+}
+
+// GetStorages calls GET /storages. Get all storages
+func (c *Client) GetStorages(ctx context.Context, params *GetStoragesParams) ([]*StoragesStorageResponse, error) {
+	req := request{method: "GET", path: "/storages"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+		setQuery(req.query, "owner_user_id", params.OwnerUserId)
+		setQuery(req.query, "owner_team", params.OwnerTeam)
+	}
+	var result []*StoragesStorageResponse
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStoragesById calls GET /storages/{id}. Get a storage by ID
+func (c *Client) GetStoragesById(ctx context.Context, id string) (*StoragesStorageResponse, error) {
+	req := request{method: "GET", path: buildPath("/storages/{id}", map[string]any{"id": id})}
+	result := new(StoragesStorageResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetStoragesByIdBackupsParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+// GetStoragesByIdBackups calls GET /storages/{id}/backups. Get backups of a storage
+func (c *Client) GetStoragesByIdBackups(ctx context.Context, id string, params *GetStoragesByIdBackupsParams) (*BackupsGetStorageBackupsResponse, error) {
+	req := request{method: "GET", path: buildPath("/storages/{id}/backups", map[string]any{"id": id})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "limit", params.Limit)
+		setQuery(req.query, "offset", params.Offset)
+	}
+	result := new(BackupsGetStorageBackupsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetStoragesByIdBrowseParams struct {
+	Path *string
+}
+
+// GetStoragesByIdBrowse calls GET /storages/{id}/browse. Browse storage files
+func (c *Client) GetStoragesByIdBrowse(ctx context.Context, id string, params *GetStoragesByIdBrowseParams) (*StoragesBrowseStorageResponse, error) {
+	req := request{method: "GET", path: buildPath("/storages/{id}/browse", map[string]any{"id": id})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "path", params.Path)
+	}
+	result := new(StoragesBrowseStorageResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStoragesByIdImpact calls GET /storages/{id}/impact. Preview storage deletion impact
+func (c *Client) GetStoragesByIdImpact(ctx context.Context, id string) (*StoragesImpactStorageImpact, error) {
+	req := request{method: "GET", path: buildPath("/storages/{id}/impact", map[string]any{"id": id})}
+	result := new(StoragesImpactStorageImpact)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStoragesByIdUsage calls GET /storages/{id}/usage. Get storage usage
+func (c *Client) GetStoragesByIdUsage(ctx context.Context, id string) (*StoragesUsageGetStorageUsageResponse, error) {
+	req := request{method: "GET", path: buildPath("/storages/{id}/usage", map[string]any{"id": id})}
+	result := new(StoragesUsageGetStorageUsageResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStoragesPlugins calls GET /storages/plugins. Get storage plugins
+func (c *Client) GetStoragesPlugins(ctx context.Context) ([]string, error) {
+	req := request{method: "GET", path: "/storages/plugins"}
+	var result []string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStoragesWorkspaceByWorkspaceIdDefault calls GET /storages/workspace/{workspaceId}/default. Get default storage of a workspace
+func (c *Client) GetStoragesWorkspaceByWorkspaceIdDefault(ctx context.Context, workspaceId string) (*StoragesStorageResponse, error) {
+	req := request{method: "GET", path: buildPath("/storages/workspace/{workspaceId}/default", map[string]any{"workspaceId": workspaceId})}
+	result := new(StoragesStorageResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSystemBranding calls GET /system/branding. Get branding
+func (c *Client) GetSystemBranding(ctx context.Context) (*UsersModelsBrandingSettings, error) {
+	req := request{method: "GET", path: "/system/branding"}
+	result := new(UsersModelsBrandingSettings)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSystemConfig calls GET /system/config. Get reloadable settings
+func (c *Client) GetSystemConfig(ctx context.Context) (*SystemSettingsReloadableSettingsResponse, error) {
+	req := request{method: "GET", path: "/system/config"}
+	result := new(SystemSettingsReloadableSettingsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetSystemDebugCpuParams struct {
+	Seconds *int64
+}
+
+// GetSystemDebugCpu calls GET /system/debug/cpu. Capture CPU profile
+func (c *Client) GetSystemDebugCpu(ctx context.Context, params *GetSystemDebugCpuParams) (io.ReadCloser, error) {
+	req := request{method: "GET", path: "/system/debug/cpu"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "seconds", params.Seconds)
+	}
+	return c.stream(ctx, req)
+}
+
+// GetSystemDebugGoroutines calls GET /system/debug/goroutines. Dump goroutines
+func (c *Client) GetSystemDebugGoroutines(ctx context.Context) (io.ReadCloser, error) {
+	req := request{method: "GET", path: "/system/debug/goroutines"}
+	return c.stream(ctx, req)
+}
+
+// GetSystemDebugProfiles calls GET /system/debug/profiles. List runtime profiles
+func (c *Client) GetSystemDebugProfiles(ctx context.Context) (*SystemDebugProfilesResponse, error) {
+	req := request{method: "GET", path: "/system/debug/profiles"}
+	result := new(SystemDebugProfilesResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetSystemDebugProfilesByNameParams struct {
+	Debug *int64
+	Gc    *bool
+}
+
+// GetSystemDebugProfilesByName calls GET /system/debug/profiles/{name}. Download runtime profile
+func (c *Client) GetSystemDebugProfilesByName(ctx context.Context, name string, params *GetSystemDebugProfilesByNameParams) (io.ReadCloser, error) {
+	req := request{method: "GET", path: buildPath("/system/debug/profiles/{name}", map[string]any{"name": name})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "debug", params.Debug)
+		setQuery(req.query, "gc", params.Gc)
+	}
+	return c.stream(ctx, req)
+}
+
+// GetSystemDebugRuntime calls GET /system/debug/runtime. Get runtime stats
+func (c *Client) GetSystemDebugRuntime(ctx context.Context) (*SystemDebugRuntimeStatsResponse, error) {
+	req := request{method: "GET", path: "/system/debug/runtime"}
+	result := new(SystemDebugRuntimeStatsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetSystemDebugTraceParams struct {
+	Seconds *int64
+}
+
+// GetSystemDebugTrace calls GET /system/debug/trace. Capture execution trace
+func (c *Client) GetSystemDebugTrace(ctx context.Context, params *GetSystemDebugTraceParams) (io.ReadCloser, error) {
+	req := request{method: "GET", path: "/system/debug/trace"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "seconds", params.Seconds)
+	}
+	return c.stream(ctx, req)
+}
+
+// GetSystemHealth calls GET /system/health. Check system health
+func (c *Client) GetSystemHealth(ctx context.Context) (*SystemHealthcheckHealthcheckResponse, error) {
+	req := request{method: "GET", path: "/system/health"}
+	result := new(SystemHealthcheckHealthcheckResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSystemMetadataBackups calls GET /system/metadata-backups. Get metadata backups
+func (c *Client) GetSystemMetadataBackups(ctx context.Context) (*SystemMetadataBackupGetMetadataBackupsResponse, error) {
+	req := request{method: "GET", path: "/system/metadata-backups"}
+	result := new(SystemMetadataBackupGetMetadataBackupsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSystemSigningKey calls GET /system/signing-key. Get signing public key
+func (c *Client) GetSystemSigningKey(ctx context.Context) (*SigningPublicKeyResponse, error) {
+	req := request{method: "GET", path: "/system/signing-key"}
+	result := new(SigningPublicKeyResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSystemVersion calls GET /system/version. Get version
+func (c *Client) GetSystemVersion(ctx context.Context) (*SystemVersionGetVersionResponse, error) {
+	req := request{method: "GET", path: "/system/version"}
+	result := new(SystemVersionGetVersionResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTicketIntegrationsWorkspaceByWorkspaceId calls GET /ticket-integrations/workspace/{workspaceId}. Get ticket integration
+func (c *Client) GetTicketIntegrationsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string) (*NotifiersTicketsTicketIntegration, error) {
+	req := request{method: "GET", path: buildPath("/ticket-integrations/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	result := new(NotifiersTicketsTicketIntegration)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTicketIntegrationsWorkspaceByWorkspaceIdTickets calls GET /ticket-integrations/workspace/{workspaceId}/tickets. Get tickets
+func (c *Client) GetTicketIntegrationsWorkspaceByWorkspaceIdTickets(ctx context.Context, workspaceId string) ([]*NotifiersTicketsTicket, error) {
+	req := request{method: "GET", path: buildPath("/ticket-integrations/workspace/{workspaceId}/tickets", map[string]any{"workspaceId": workspaceId})}
+	var result []*NotifiersTicketsTicket
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetUsersParams struct {
+	Limit      *int64
+	Offset     *int64
+	BeforeDate *time.Time
+	Query      *string
+}
+
+// GetUsers calls GET /users. List users
+func (c *Client) GetUsers(ctx context.Context, params *GetUsersParams) (*UsersDtoListUsersResponseDTO, error) {
+	req := request{method: "GET", path: "/users"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "limit", params.Limit)
+		setQuery(req.query, "offset", params.Offset)
+		setQuery(req.query, "beforeDate", params.BeforeDate)
+		setQuery(req.query, "query", params.Query)
+	}
+	result := new(UsersDtoListUsersResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUsersById calls GET /users/{id}. Get user profile
+func (c *Client) GetUsersById(ctx context.Context, id string) (*UsersDtoUserProfileResponseDTO, error) {
+	req := request{method: "GET", path: buildPath("/users/{id}", map[string]any{"id": id})}
+	result := new(UsersDtoUserProfileResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUsersMe calls GET /users/me. Get current user profile
+func (c *Client) GetUsersMe(ctx context.Context) (*UsersDtoUserProfileResponseDTO, error) {
+	req := request{method: "GET", path: "/users/me"}
+	result := new(UsersDtoUserProfileResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetUsersMeFavoritesParams struct {
+	WorkspaceId string
+}
+
+// GetUsersMeFavorites calls GET /users/me/favorites. Get favorites
+func (c *Client) GetUsersMeFavorites(ctx context.Context, params *GetUsersMeFavoritesParams) ([]*SavedViewsFavoriteDTO, error) {
+	req := request{method: "GET", path: "/users/me/favorites"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	var result []*SavedViewsFavoriteDTO
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUsersMePreferences calls GET /users/me/preferences. Get own preferences
+func (c *Client) GetUsersMePreferences(ctx context.Context) (*UsersModelsUserPreferences, error) {
+	req := request{method: "GET", path: "/users/me/preferences"}
+	result := new(UsersModelsUserPreferences)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetUsersMeViewsParams struct {
+	WorkspaceId string
+}
+
+// GetUsersMeViews calls GET /users/me/views. Get saved views
+func (c *Client) GetUsersMeViews(ctx context.Context, params *GetUsersMeViewsParams) ([]*SavedViewsSavedView, error) {
+	req := request{method: "GET", path: "/users/me/views"}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "workspace_id", params.WorkspaceId)
+	}
+	var result []*SavedViewsSavedView
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUsersSettings calls GET /users/settings. Get users settings
+func (c *Client) GetUsersSettings(ctx context.Context) (*UsersModelsUsersSettings, error) {
+	req := request{method: "GET", path: "/users/settings"}
+	result := new(UsersModelsUsersSettings)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUsersSettingsHeaders calls GET /users/settings/headers. Get security headers
+func (c *Client) GetUsersSettingsHeaders(ctx context.Context) (*UsersModelsSecurityHeaders, error) {
+	req := request{method: "GET", path: "/users/settings/headers"}
+	result := new(UsersModelsSecurityHeaders)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUsersSettingsSecurity calls GET /users/settings/security. Get security policy
+func (c *Client) GetUsersSettingsSecurity(ctx context.Context) (*UsersModelsSecurityPolicy, error) {
+	req := request{method: "GET", path: "/users/settings/security"}
+	result := new(UsersModelsSecurityPolicy)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetWorkspaces calls GET /workspaces. List user's workspaces
+func (c *Client) GetWorkspaces(ctx context.Context) (*WorkspacesDtoListWorkspacesResponseDTO, error) {
+	req := request{method: "GET", path: "/workspaces"}
+	result := new(WorkspacesDtoListWorkspacesResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetWorkspacesById calls GET /workspaces/{id}. Get workspace details
+func (c *Client) GetWorkspacesById(ctx context.Context, id string) (*WorkspacesModelsWorkspace, error) {
+	req := request{method: "GET", path: buildPath("/workspaces/{id}", map[string]any{"id": id})}
+	result := new(WorkspacesModelsWorkspace)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type GetWorkspacesByIdAuditLogsParams struct {
+	Limit      *int64
+	Offset     *int64
+	BeforeDate *time.Time
+}
+
+// GetWorkspacesByIdAuditLogs calls GET /workspaces/{id}/audit-logs. Get workspace audit logs
+func (c *Client) GetWorkspacesByIdAuditLogs(ctx context.Context, id string, params *GetWorkspacesByIdAuditLogsParams) (*AuditLogsGetAuditLogsResponse, error) {
+	req := request{method: "GET", path: buildPath("/workspaces/{id}/audit-logs", map[string]any{"id": id})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "limit", params.Limit)
+		setQuery(req.query, "offset", params.Offset)
+		setQuery(req.query, "beforeDate", params.BeforeDate)
+	}
+	result := new(AuditLogsGetAuditLogsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetWorkspacesMembershipsByIdMembers calls GET /workspaces/memberships/{id}/members. List workspace members
+func (c *Client) GetWorkspacesMembershipsByIdMembers(ctx context.Context, id string) (*WorkspacesDtoGetMembersResponseDTO, error) {
+	req := request{method: "GET", path: buildPath("/workspaces/memberships/{id}/members", map[string]any{"id": id})}
+	result := new(WorkspacesDtoGetMembersResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostAgents calls POST /agents. Create an agent
+func (c *Client) PostAgents(ctx context.Context, body *AgentsCreateAgentRequest) (*AgentsCreateAgentResponse, error) {
+	req := request{method: "POST", path: "/agents"}
+	req.jsonBody = body
+	result := new(AgentsCreateAgentResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostAgentsJobsByBackupIdComplete calls POST /agents/jobs/{backupId}/complete. Complete a job (agent)
+func (c *Client) PostAgentsJobsByBackupIdComplete(ctx context.Context, backupId string, body *AgentsCompleteAgentJobRequest) error {
+	req := request{method: "POST", path: buildPath("/agents/jobs/{backupId}/complete", map[string]any{"backupId": backupId})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostAgentsJobsByBackupIdUpload calls POST /agents/jobs/{backupId}/upload. Upload a dump (agent)
+func (c *Client) PostAgentsJobsByBackupIdUpload(ctx context.Context, backupId string) error {
+	req := request{method: "POST", path: buildPath("/agents/jobs/{backupId}/upload", map[string]any{"backupId": backupId})}
+	return c.do(ctx, req, nil)
+}
+
+// PostAuthGithubCallback calls POST /auth/github/callback. Handle GitHub OAuth callback
+func (c *Client) PostAuthGithubCallback(ctx context.Context, body *UsersDtoOAuthCallbackRequestDTO) (*UsersDtoOAuthCallbackResponseDTO, error) {
+	req := request{method: "POST", path: "/auth/github/callback"}
+	req.jsonBody = body
+	result := new(UsersDtoOAuthCallbackResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostAuthGoogleCallback calls POST /auth/google/callback. Handle Google OAuth callback
+func (c *Client) PostAuthGoogleCallback(ctx context.Context, body *UsersDtoOAuthCallbackRequestDTO) (*UsersDtoOAuthCallbackResponseDTO, error) {
+	req := request{method: "POST", path: "/auth/google/callback"}
+	req.jsonBody = body
+	result := new(UsersDtoOAuthCallbackResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupBadgesDatabaseByDatabaseIdToken calls POST /backup-badges/database/{databaseId}/token. Generate backup badge token
+func (c *Client) PostBackupBadgesDatabaseByDatabaseIdToken(ctx context.Context, databaseId string) (*BackupsBadgesBackupBadgeTokenResponse, error) {
+	req := request{method: "POST", path: buildPath("/backup-badges/database/{databaseId}/token", map[string]any{"databaseId": databaseId})}
+	result := new(BackupsBadgesBackupBadgeTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupConfigsConcurrencyGroupsWorkspaceByWorkspaceId calls POST /backup-configs/concurrency-groups/workspace/{workspaceId}. Create concurrency group
+func (c *Client) PostBackupConfigsConcurrencyGroupsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, body *BackupsConfigConcurrencyGroup) (*BackupsConfigConcurrencyGroup, error) {
+	req := request{method: "POST", path: buildPath("/backup-configs/concurrency-groups/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(BackupsConfigConcurrencyGroup)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupConfigsDatabaseByIdPause calls POST /backup-configs/database/{id}/pause. Pause backup schedule of database
+func (c *Client) PostBackupConfigsDatabaseByIdPause(ctx context.Context, id string, body *BackupsConfigPauseScheduleRequest) error {
+	req := request{method: "POST", path: buildPath("/backup-configs/database/{id}/pause", map[string]any{"id": id})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostBackupConfigsDatabaseByIdResume calls POST /backup-configs/database/{id}/resume. Resume backup schedule of database
+func (c *Client) PostBackupConfigsDatabaseByIdResume(ctx context.Context, id string) error {
+	req := request{method: "POST", path: buildPath("/backup-configs/database/{id}/resume", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// PostBackupConfigsDatabaseByIdTransfer calls POST /backup-configs/database/{id}/transfer. Transfer database to another workspace
+func (c *Client) PostBackupConfigsDatabaseByIdTransfer(ctx context.Context, id string, body *BackupsConfigTransferDatabaseRequest) (map[string]string, error) {
+	req := request{method: "POST", path: buildPath("/backup-configs/database/{id}/transfer", map[string]any{"id": id})}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupConfigsSave calls POST /backup-configs/save. Save backup configuration
+func (c *Client) PostBackupConfigsSave(ctx context.Context, body *BackupsConfigBackupConfig) (*BackupsConfigBackupConfig, error) {
+	req := request{method: "POST", path: "/backup-configs/save"}
+	req.jsonBody = body
+	result := new(BackupsConfigBackupConfig)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupConfigsWorkspaceByWorkspaceIdAssignDefaultStorage calls POST /backup-configs/workspace/{workspaceId}/assign-default-storage. Assign default storage to workspace databases
+func (c *Client) PostBackupConfigsWorkspaceByWorkspaceIdAssignDefaultStorage(ctx context.Context, workspaceId string, body *BackupsConfigAssignDefaultStorageRequest) (*BackupsConfigAssignDefaultStorageResponse, error) {
+	req := request{method: "POST", path: buildPath("/backup-configs/workspace/{workspaceId}/assign-default-storage", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(BackupsConfigAssignDefaultStorageResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupConfigsWorkspaceByWorkspaceIdPause calls POST /backup-configs/workspace/{workspaceId}/pause. Pause backup schedules of workspace
+func (c *Client) PostBackupConfigsWorkspaceByWorkspaceIdPause(ctx context.Context, workspaceId string, body *BackupsConfigPauseScheduleRequest) error {
+	req := request{method: "POST", path: buildPath("/backup-configs/workspace/{workspaceId}/pause", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostBackupConfigsWorkspaceByWorkspaceIdResume calls POST /backup-configs/workspace/{workspaceId}/resume. Resume backup schedules of workspace
+func (c *Client) PostBackupConfigsWorkspaceByWorkspaceIdResume(ctx context.Context, workspaceId string) error {
+	req := request{method: "POST", path: buildPath("/backup-configs/workspace/{workspaceId}/resume", map[string]any{"workspaceId": workspaceId})}
+	return c.do(ctx, req, nil)
+}
+
+// PostBackupTriggersDatabaseByDatabaseIdToken calls POST /backup-triggers/database/{databaseId}/token. Generate backup trigger token
+func (c *Client) PostBackupTriggersDatabaseByDatabaseIdToken(ctx context.Context, databaseId string) (*BackupsTriggersBackupTriggerTokenResponse, error) {
+	req := request{method: "POST", path: buildPath("/backup-triggers/database/{databaseId}/token", map[string]any{"databaseId": databaseId})}
+	result := new(BackupsTriggersBackupTriggerTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupTriggersPublicByToken calls POST /backup-triggers/public/{token}. Trigger backup
+func (c *Client) PostBackupTriggersPublicByToken(ctx context.Context, token string, body *BackupsTriggersTriggerBackupRequest) error {
+	req := request{method: "POST", path: buildPath("/backup-triggers/public/{token}", map[string]any{"token": token})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostBackupTriggersPublicByTokenGate calls POST /backup-triggers/public/{token}/gate. Check deployment gate
+func (c *Client) PostBackupTriggersPublicByTokenGate(ctx context.Context, token string, body *BackupsTriggersCheckDeploymentGateRequest) (*BackupsTriggersDeploymentGateResponse, error) {
+	req := request{method: "POST", path: buildPath("/backup-triggers/public/{token}/gate", map[string]any{"token": token})}
+	req.jsonBody = body
+	result := new(BackupsTriggersDeploymentGateResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackups calls POST /backups. Create a backup
+func (c *Client) PostBackups(ctx context.Context, body *BackupsMakeBackupRequest) (map[string]string, error) {
+	req := request{method: "POST", path: "/backups"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupsAdoptionDatabaseByDatabaseIdAdopt calls POST /backups/adoption/database/{databaseId}/adopt. Adopt existing backups
+func (c *Client) PostBackupsAdoptionDatabaseByDatabaseIdAdopt(ctx context.Context, databaseId string, body *BackupsAdoptionAdoptBackupsRequest) (*BackupsAdoptionAdoptBackupsResponse, error) {
+	req := request{method: "POST", path: buildPath("/backups/adoption/database/{databaseId}/adopt", map[string]any{"databaseId": databaseId})}
+	req.jsonBody = body
+	result := new(BackupsAdoptionAdoptBackupsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupsAdoptionDatabaseByDatabaseIdScan calls POST /backups/adoption/database/{databaseId}/scan. Scan storage for existing backups
+func (c *Client) PostBackupsAdoptionDatabaseByDatabaseIdScan(ctx context.Context, databaseId string, body *BackupsAdoptionAdoptBackupsRequest) (*BackupsAdoptionScanBackupsResponse, error) {
+	req := request{method: "POST", path: buildPath("/backups/adoption/database/{databaseId}/scan", map[string]any{"databaseId": databaseId})}
+	req.jsonBody = body
+	result := new(BackupsAdoptionScanBackupsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupsByIdCancel calls POST /backups/{id}/cancel. Cancel an in-progress backup
+func (c *Client) PostBackupsByIdCancel(ctx context.Context, id string) error {
+	req := request{method: "POST", path: buildPath("/backups/{id}/cancel", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// PostBackupsByIdDownloadToken calls POST /backups/{id}/download-token. Generate short-lived download token
+func (c *Client) PostBackupsByIdDownloadToken(ctx context.Context, id string) (*BackupsDownloadGenerateDownloadTokenResponse, error) {
+	req := request{method: "POST", path: buildPath("/backups/{id}/download-token", map[string]any{"id": id})}
+	result := new(BackupsDownloadGenerateDownloadTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupsByIdShareLinks calls POST /backups/{id}/share-links. Share a backup with a workspace member
+func (c *Client) PostBackupsByIdShareLinks(ctx context.Context, id string, body *BackupsDownloadCreateShareLinkRequest) (*BackupsDownloadBackupShareLink, error) {
+	req := request{method: "POST", path: buildPath("/backups/{id}/share-links", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(BackupsDownloadBackupShareLink)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupsDeadLettersByIdRequeue calls POST /backups/dead-letters/{id}/requeue. Requeue a backup that exhausted retries
+func (c *Client) PostBackupsDeadLettersByIdRequeue(ctx context.Context, id string) (map[string]string, error) {
+	req := request{method: "POST", path: buildPath("/backups/dead-letters/{id}/requeue", map[string]any{"id": id})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBackupsShareLinksByShareLinkIdDownloadToken calls POST /backups/share-links/{shareLinkId}/download-token. Generate download token of a share link
+func (c *Client) PostBackupsShareLinksByShareLinkIdDownloadToken(ctx context.Context, shareLinkId string) (*BackupsDownloadGenerateDownloadTokenResponse, error) {
+	req := request{method: "POST", path: buildPath("/backups/share-links/{shareLinkId}/download-token", map[string]any{"shareLinkId": shareLinkId})}
+	result := new(BackupsDownloadGenerateDownloadTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostBillingStripeWebhook calls POST /billing/stripe/webhook. Handle Stripe webhook
+func (c *Client) PostBillingStripeWebhook(ctx context.Context) error {
+	req := request{method: "POST", path: "/billing/stripe/webhook"}
+	return c.do(ctx, req, nil)
+}
+
+// PostBroadcasts calls POST /broadcasts. Send a broadcast
+func (c *Client) PostBroadcasts(ctx context.Context, body *NotifiersBroadcastsCreateBroadcastRequest) (*NotifiersBroadcastsBroadcast, error) {
+	req := request{method: "POST", path: "/broadcasts"}
+	req.jsonBody = body
+	result := new(NotifiersBroadcastsBroadcast)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostCalendarFeedsWorkspaceByWorkspaceIdToken calls POST /calendar-feeds/workspace/{workspaceId}/token. Generate calendar feed token
+func (c *Client) PostCalendarFeedsWorkspaceByWorkspaceIdToken(ctx context.Context, workspaceId string) (*BackupsCalendarsCalendarFeedTokenResponse, error) {
+	req := request{method: "POST", path: buildPath("/calendar-feeds/workspace/{workspaceId}/token", map[string]any{"workspaceId": workspaceId})}
+	result := new(BackupsCalendarsCalendarFeedTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostChatopsLinkCode calls POST /chatops/link-code. Generate chat link code
+func (c *Client) PostChatopsLinkCode(ctx context.Context) (*ChatopsLinkCodeResponse, error) {
+	req := request{method: "POST", path: "/chatops/link-code"}
+	result := new(ChatopsLinkCodeResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostChatopsMattermostCommands calls POST /chatops/mattermost/commands. Handle Mattermost slash command
+func (c *Client) PostChatopsMattermostCommands(ctx context.Context) (*ChatopsSlashCommandResponse, error) {
+	req := request{method: "POST", path: "/chatops/mattermost/commands"}
+	result := new(ChatopsSlashCommandResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostChatopsSlackCommands calls POST /chatops/slack/commands. Handle Slack slash command
+func (c *Client) PostChatopsSlackCommands(ctx context.Context) (*ChatopsSlashCommandResponse, error) {
+	req := request{method: "POST", path: "/chatops/slack/commands"}
+	result := new(ChatopsSlashCommandResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostClientCertificates calls POST /client-certificates. Register client certificate
+func (c *Client) PostClientCertificates(ctx context.Context, body *ClientCertificatesRegisterClientCertificateRequest) (*ClientCertificatesClientCertificate, error) {
+	req := request{method: "POST", path: "/client-certificates"}
+	req.jsonBody = body
+	result := new(ClientCertificatesClientCertificate)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostComments calls POST /comments. Add a comment to a resource
+func (c *Client) PostComments(ctx context.Context, body *CommentsCreateCommentRequest) (*CommentsComment, error) {
+	req := request{method: "POST", path: "/comments"}
+	req.jsonBody = body
+	result := new(CommentsComment)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostConnectionTemplates calls POST /connection-templates. Save a connection template
+func (c *Client) PostConnectionTemplates(ctx context.Context, body *DatabasesTemplatesConnectionTemplate) (*DatabasesTemplatesConnectionTemplateResponse, error) {
+	req := request{method: "POST", path: "/connection-templates"}
+	req.jsonBody = body
+	result := new(DatabasesTemplatesConnectionTemplateResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostConnectionTemplatesByIdDatabases calls POST /connection-templates/{id}/databases. Create a database from a connection template
+func (c *Client) PostConnectionTemplatesByIdDatabases(ctx context.Context, id string, body *DatabasesTemplatesCreateDatabaseFromTemplateRequest) (*DatabasesDatabase, error) {
+	req := request{method: "POST", path: buildPath("/connection-templates/{id}/databases", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(DatabasesDatabase)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostDatabasesByIdCopy calls POST /databases/{id}/copy. Copy a database
+func (c *Client) PostDatabasesByIdCopy(ctx context.Context, id string) (*DatabasesDatabase, error) {
+	req := request{method: "POST", path: buildPath("/databases/{id}/copy", map[string]any{"id": id})}
+	result := new(DatabasesDatabase)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostDatabasesByIdTestConnection calls POST /databases/{id}/test-connection. Test database connection
+func (c *Client) PostDatabasesByIdTestConnection(ctx context.Context, id string) error {
+	req := request{method: "POST", path: buildPath("/databases/{id}/test-connection", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// PostDatabasesCreate calls POST /databases/create. Create a new database
+func (c *Client) PostDatabasesCreate(ctx context.Context, body *DatabasesDatabase) (*DatabasesDatabase, error) {
+	req := request{method: "POST", path: "/databases/create"}
+	req.jsonBody = body
+	result := new(DatabasesDatabase)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostDatabasesCreateReadonlyUser calls POST /databases/create-readonly-user. Create read-only database user
+func (c *Client) PostDatabasesCreateReadonlyUser(ctx context.Context, body *DatabasesDatabase) (*DatabasesCreateReadOnlyUserResponse, error) {
+	req := request{method: "POST", path: "/databases/create-readonly-user"}
+	req.jsonBody = body
+	result := new(DatabasesCreateReadOnlyUserResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostDatabasesIsReadonly calls POST /databases/is-readonly. Check if database user is read-only
+func (c *Client) PostDatabasesIsReadonly(ctx context.Context, body *DatabasesDatabase) (*DatabasesIsReadOnlyResponse, error) {
+	req := request{method: "POST", path: "/databases/is-readonly"}
+	req.jsonBody = body
+	result := new(DatabasesIsReadOnlyResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostDatabasesNotifierByIdAttachToAll calls POST /databases/notifier/{id}/attach-to-all. Attach a notifier to all databases
+func (c *Client) PostDatabasesNotifierByIdAttachToAll(ctx context.Context, id string) (map[string]int64, error) {
+	req := request{method: "POST", path: buildPath("/databases/notifier/{id}/attach-to-all", map[string]any{"id": id})}
+	var result map[string]int64
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostDatabasesTestConnectionDirect calls POST /databases/test-connection-direct. Test database connection directly
+func (c *Client) PostDatabasesTestConnectionDirect(ctx context.Context, body *DatabasesDatabase) error {
+	req := request{method: "POST", path: "/databases/test-connection-direct"}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostDatabasesUpdate calls POST /databases/update. Update a database
+func (c *Client) PostDatabasesUpdate(ctx context.Context, body *DatabasesDatabase) (*DatabasesDatabase, error) {
+	req := request{method: "POST", path: "/databases/update"}
+	req.jsonBody = body
+	result := new(DatabasesDatabase)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostGraphql calls POST /graphql. Run GraphQL query
+func (c *Client) PostGraphql(ctx context.Context, body *GraphqlGraphQLRequest) (*GraphqlGraphQLResponse, error) {
+	req := request{method: "POST", path: "/graphql"}
+	req.jsonBody = body
+	result := new(GraphqlGraphQLResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostHealthcheckConfig calls POST /healthcheck-config. Save healthcheck configuration
+func (c *Client) PostHealthcheckConfig(ctx context.Context, body *HealthcheckConfigHealthcheckConfigDTO) (map[string]string, error) {
+	req := request{method: "POST", path: "/healthcheck-config"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostImportsParse calls POST /imports/parse. Parse config of backup tool
+func (c *Client) PostImportsParse(ctx context.Context, body *ImportsParseImportRequest) (*ImportsImportPlan, error) {
+	req := request{method: "POST", path: "/imports/parse"}
+	req.jsonBody = body
+	result := new(ImportsImportPlan)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostImportsWorkspaceByWorkspaceId calls POST /imports/workspace/{workspaceId}. Import databases
+func (c *Client) PostImportsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, body *ImportsImportPlan) (*ImportsImportResult, error) {
+	req := request{method: "POST", path: buildPath("/imports/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(ImportsImportResult)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostNotifiers calls POST /notifiers. Save a notifier
+func (c *Client) PostNotifiers(ctx context.Context, body *NotifiersNotifier) (*NotifiersNotifier, error) {
+	req := request{method: "POST", path: "/notifiers"}
+	req.jsonBody = body
+	result := new(NotifiersNotifier)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostNotifiersByIdIncidentWebhook calls POST /notifiers/{id}/incident-webhook. Handle incident webhook
+func (c *Client) PostNotifiersByIdIncidentWebhook(ctx context.Context, id string) error {
+	req := request{method: "POST", path: buildPath("/notifiers/{id}/incident-webhook", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// PostNotifiersByIdTest calls POST /notifiers/{id}/test. Send test notification
+func (c *Client) PostNotifiersByIdTest(ctx context.Context, id string) error {
+	req := request{method: "POST", path: buildPath("/notifiers/{id}/test", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+type PostNotifiersByIdTestEventParams struct {
+	Type string
+}
+
+// PostNotifiersByIdTestEvent calls POST /notifiers/{id}/test-event. Send test event
+func (c *Client) PostNotifiersByIdTestEvent(ctx context.Context, id string, params *PostNotifiersByIdTestEventParams) error {
+	req := request{method: "POST", path: buildPath("/notifiers/{id}/test-event", map[string]any{"id": id})}
+	req.query = url.Values{}
+	if params != nil {
+		setQuery(req.query, "type", params.Type)
+	}
+	return c.do(ctx, req, nil)
+}
+
+// PostNotifiersByIdTransfer calls POST /notifiers/{id}/transfer. Transfer notifier to another workspace
+func (c *Client) PostNotifiersByIdTransfer(ctx context.Context, id string, body *NotifiersTransferNotifierRequest) error {
+	req := request{method: "POST", path: buildPath("/notifiers/{id}/transfer", map[string]any{"id": id})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostNotifiersDirectTest calls POST /notifiers/direct-test. Send test notification directly
+func (c *Client) PostNotifiersDirectTest(ctx context.Context, body *NotifiersNotifier) error {
+	req := request{method: "POST", path: "/notifiers/direct-test"}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostRefreshJobsByIdRun calls POST /refresh-jobs/{id}/run. Run a refresh job now
+func (c *Client) PostRefreshJobsByIdRun(ctx context.Context, id string) (*RestoresRefreshesRefreshRun, error) {
+	req := request{method: "POST", path: buildPath("/refresh-jobs/{id}/run", map[string]any{"id": id})}
+	result := new(RestoresRefreshesRefreshRun)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostRefreshJobsDatabaseByDatabaseId calls POST /refresh-jobs/database/{databaseId}. Create a refresh job
+func (c *Client) PostRefreshJobsDatabaseByDatabaseId(ctx context.Context, databaseId string, body *RestoresRefreshesRefreshJob) (*RestoresRefreshesRefreshJob, error) {
+	req := request{method: "POST", path: buildPath("/refresh-jobs/database/{databaseId}", map[string]any{"databaseId": databaseId})}
+	req.jsonBody = body
+	result := new(RestoresRefreshesRefreshJob)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostRestorePlansByIdCancel calls POST /restore-plans/{id}/cancel. Cancel a restore plan
+func (c *Client) PostRestorePlansByIdCancel(ctx context.Context, id string) (*RestoresPlansRestorePlan, error) {
+	req := request{method: "POST", path: buildPath("/restore-plans/{id}/cancel", map[string]any{"id": id})}
+	result := new(RestoresPlansRestorePlan)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostRestorePlansWorkspaceByWorkspaceId calls POST /restore-plans/workspace/{workspaceId}. Start a restore plan
+func (c *Client) PostRestorePlansWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, body *RestoresPlansRestorePlan) (*RestoresPlansRestorePlan, error) {
+	req := request{method: "POST", path: buildPath("/restore-plans/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(RestoresPlansRestorePlan)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostRestoresByBackupIdCheck calls POST /restores/{backupId}/check. Check a restore of a backup
+func (c *Client) PostRestoresByBackupIdCheck(ctx context.Context, backupId string) (*RestoresCorePreRestoreReport, error) {
+	req := request{method: "POST", path: buildPath("/restores/{backupId}/check", map[string]any{"backupId": backupId})}
+	result := new(RestoresCorePreRestoreReport)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostRestoresByBackupIdRestore calls POST /restores/{backupId}/restore. Restore a backup
+func (c *Client) PostRestoresByBackupIdRestore(ctx context.Context, backupId string) (map[string]string, error) {
+	req := request{method: "POST", path: buildPath("/restores/{backupId}/restore", map[string]any{"backupId": backupId})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostRestoresCancelByRestoreId calls POST /restores/cancel/{restoreId}. Cancel an in-progress restore
+func (c *Client) PostRestoresCancelByRestoreId(ctx context.Context, restoreId string) error {
+	req := request{method: "POST", path: buildPath("/restores/cancel/{restoreId}", map[string]any{"restoreId": restoreId})}
+	return c.do(ctx, req, nil)
+}
+
+// PostStatusPagesWorkspaceByWorkspaceIdRotateToken calls POST /status-pages/workspace/{workspaceId}/rotate-token. Rotate status page token
+func (c *Client) PostStatusPagesWorkspaceByWorkspaceIdRotateToken(ctx context.Context, workspaceId string) (*BackupsStatusPagesStatusPageTokenResponse, error) {
+	req := request{method: "POST", path: buildPath("/status-pages/workspace/{workspaceId}/rotate-token", map[string]any{"workspaceId": workspaceId})}
+	result := new(BackupsStatusPagesStatusPageTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostStorageMirrorGroups calls POST /storage-mirror-groups. Create storage mirror group
+func (c *Client) PostStorageMirrorGroups(ctx context.Context, body *StoragesMirrorsCreateMirrorGroupRequest) (*StoragesMirrorsStorageMirrorGroup, error) {
+	req := request{method: "POST", path: "/storage-mirror-groups"}
+	req.jsonBody = body
+	result := new(StoragesMirrorsStorageMirrorGroup)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostStorages calls POST /storages. Save a storage
+func (c *Client) PostStorages(ctx context.Context, body *StoragesStorage) (*StoragesStorage, error) {
+	req := request{method: "POST", path: "/storages"}
+	req.jsonBody = body
+	result := new(StoragesStorage)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostStoragesByIdBenchmark calls POST /storages/{id}/benchmark. Benchmark storage
+func (c *Client) PostStoragesByIdBenchmark(ctx context.Context, id string, body *StoragesBenchmarkStorageRequest) (*StoragesStorageBenchmarkResult, error) {
+	req := request{method: "POST", path: buildPath("/storages/{id}/benchmark", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(StoragesStorageBenchmarkResult)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostStoragesByIdTest calls POST /storages/{id}/test. Test storage connection
+func (c *Client) PostStoragesByIdTest(ctx context.Context, id string) error {
+	req := request{method: "POST", path: buildPath("/storages/{id}/test", map[string]any{"id": id})}
+	return c.do(ctx, req, nil)
+}
+
+// PostStoragesByIdTransfer calls POST /storages/{id}/transfer. Transfer storage to another workspace
+func (c *Client) PostStoragesByIdTransfer(ctx context.Context, id string, body *StoragesTransferStorageRequest) error {
+	req := request{method: "POST", path: buildPath("/storages/{id}/transfer", map[string]any{"id": id})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostStoragesDirectTest calls POST /storages/direct-test. Test storage connection directly
+func (c *Client) PostStoragesDirectTest(ctx context.Context, body *StoragesStorage) error {
+	req := request{method: "POST", path: "/storages/direct-test"}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostSystemConfigReload calls POST /system/config/reload. Reload settings
+func (c *Client) PostSystemConfigReload(ctx context.Context) (*SystemSettingsReloadableSettingsResponse, error) {
+	req := request{method: "POST", path: "/system/config/reload"}
+	result := new(SystemSettingsReloadableSettingsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostSystemDebugGc calls POST /system/debug/gc. Force garbage collection
+func (c *Client) PostSystemDebugGc(ctx context.Context) (*SystemDebugRuntimeStatsResponse, error) {
+	req := request{method: "POST", path: "/system/debug/gc"}
+	result := new(SystemDebugRuntimeStatsResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostSystemMetadataBackups calls POST /system/metadata-backups. Make metadata backup
+func (c *Client) PostSystemMetadataBackups(ctx context.Context) (map[string]string, error) {
+	req := request{method: "POST", path: "/system/metadata-backups"}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersByIdActivate calls POST /users/{id}/activate. Activate user
+func (c *Client) PostUsersByIdActivate(ctx context.Context, id string) (map[string]string, error) {
+	req := request{method: "POST", path: buildPath("/users/{id}/activate", map[string]any{"id": id})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersByIdDeactivate calls POST /users/{id}/deactivate. Deactivate user
+func (c *Client) PostUsersByIdDeactivate(ctx context.Context, id string) (map[string]string, error) {
+	req := request{method: "POST", path: buildPath("/users/{id}/deactivate", map[string]any{"id": id})}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersInvite calls POST /users/invite. Invite a new user
+func (c *Client) PostUsersInvite(ctx context.Context, body *UsersDtoInviteUserRequestDTO) (*UsersDtoInviteUserResponseDTO, error) {
+	req := request{method: "POST", path: "/users/invite"}
+	req.jsonBody = body
+	result := new(UsersDtoInviteUserResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersMeFavorites calls POST /users/me/favorites. Add a favorite
+func (c *Client) PostUsersMeFavorites(ctx context.Context, body *SavedViewsAddFavoriteRequest) error {
+	req := request{method: "POST", path: "/users/me/favorites"}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostUsersMeSendVerificationEmail calls POST /users/me/send-verification-email. Send email verification link
+func (c *Client) PostUsersMeSendVerificationEmail(ctx context.Context) (map[string]string, error) {
+	req := request{method: "POST", path: "/users/me/send-verification-email"}
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersMeViews calls POST /users/me/views. Save a view
+func (c *Client) PostUsersMeViews(ctx context.Context, body *SavedViewsSaveViewRequest) (*SavedViewsSavedView, error) {
+	req := request{method: "POST", path: "/users/me/views"}
+	req.jsonBody = body
+	result := new(SavedViewsSavedView)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersResetPassword calls POST /users/reset-password. Reset password with code
+func (c *Client) PostUsersResetPassword(ctx context.Context, body *UsersDtoResetPasswordRequestDTO) (map[string]string, error) {
+	req := request{method: "POST", path: "/users/reset-password"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersResetPasswordByToken calls POST /users/reset-password-by-token. Reset password with link token
+func (c *Client) PostUsersResetPasswordByToken(ctx context.Context, body *UsersDtoResetPasswordByTokenRequestDTO) (map[string]string, error) {
+	req := request{method: "POST", path: "/users/reset-password-by-token"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersSendResetPasswordCode calls POST /users/send-reset-password-code. Send password reset code
+func (c *Client) PostUsersSendResetPasswordCode(ctx context.Context, body *UsersDtoSendResetPasswordCodeRequestDTO) (map[string]string, error) {
+	req := request{method: "POST", path: "/users/send-reset-password-code"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersSignin calls POST /users/signin. Authenticate a user
+func (c *Client) PostUsersSignin(ctx context.Context, body *UsersDtoSignInRequestDTO) (*UsersDtoSignInResponseDTO, error) {
+	req := request{method: "POST", path: "/users/signin"}
+	req.jsonBody = body
+	result := new(UsersDtoSignInResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostUsersSignup calls POST /users/signup. Register a new user
+func (c *Client) PostUsersSignup(ctx context.Context, body *UsersDtoSignUpRequestDTO) error {
+	req := request{method: "POST", path: "/users/signup"}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PostUsersVerifyEmail calls POST /users/verify-email. Verify email
+func (c *Client) PostUsersVerifyEmail(ctx context.Context, body *UsersDtoVerifyEmailRequestDTO) (map[string]string, error) {
+	req := request{method: "POST", path: "/users/verify-email"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostWorkspaces calls POST /workspaces. Create a new workspace
+func (c *Client) PostWorkspaces(ctx context.Context, body *WorkspacesDtoCreateWorkspaceRequestDTO) (*WorkspacesDtoWorkspaceResponseDTO, error) {
+	req := request{method: "POST", path: "/workspaces"}
+	req.jsonBody = body
+	result := new(WorkspacesDtoWorkspaceResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostWorkspacesMembershipsByIdMembers calls POST /workspaces/memberships/{id}/members. Add member to workspace (supports both existing and new users)
+func (c *Client) PostWorkspacesMembershipsByIdMembers(ctx context.Context, id string, body *WorkspacesDtoAddMemberRequestDTO) (*WorkspacesDtoAddMemberResponseDTO, error) {
+	req := request{method: "POST", path: buildPath("/workspaces/memberships/{id}/members", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(WorkspacesDtoAddMemberResponseDTO)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostWorkspacesMembershipsByIdTransferOwnership calls POST /workspaces/memberships/{id}/transfer-ownership. Transfer workspace ownership
+func (c *Client) PostWorkspacesMembershipsByIdTransferOwnership(ctx context.Context, id string, body *WorkspacesDtoTransferOwnershipRequestDTO) (map[string]string, error) {
+	req := request{method: "POST", path: buildPath("/workspaces/memberships/{id}/transfer-ownership", map[string]any{"id": id})}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutBackupConfigsConcurrencyGroupsById calls PUT /backup-configs/concurrency-groups/{id}. Update concurrency group
+func (c *Client) PutBackupConfigsConcurrencyGroupsById(ctx context.Context, id string, body *BackupsConfigConcurrencyGroup) (*BackupsConfigConcurrencyGroup, error) {
+	req := request{method: "PUT", path: buildPath("/backup-configs/concurrency-groups/{id}", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(BackupsConfigConcurrencyGroup)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutCommentsById calls PUT /comments/{id}. Update a comment
+func (c *Client) PutCommentsById(ctx context.Context, id string, body *CommentsUpdateCommentRequest) (*CommentsComment, error) {
+	req := request{method: "PUT", path: buildPath("/comments/{id}", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(CommentsComment)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutFeaturesWorkspacesByWorkspaceId calls PUT /features/workspaces/{workspaceId}. Override feature flag for workspace
+func (c *Client) PutFeaturesWorkspacesByWorkspaceId(ctx context.Context, workspaceId string, body *FeatureFlagsSetWorkspaceOverrideRequest) (*FeatureFlagsWorkspaceFeatureFlag, error) {
+	req := request{method: "PUT", path: buildPath("/features/workspaces/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(FeatureFlagsWorkspaceFeatureFlag)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutGrafanaIntegrationsWorkspaceByWorkspaceId calls PUT /grafana-integrations/workspace/{workspaceId}. Save Grafana integration
+func (c *Client) PutGrafanaIntegrationsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, body *BackupsGrafanaGrafanaIntegration) (*BackupsGrafanaGrafanaIntegration, error) {
+	req := request{method: "PUT", path: buildPath("/grafana-integrations/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(BackupsGrafanaGrafanaIntegration)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutLocalizationOverrides calls PUT /localization/overrides. Override message template
+func (c *Client) PutLocalizationOverrides(ctx context.Context, body *LocalizationUpsertOverrideRequest) (*LocalizationMessageTemplateOverride, error) {
+	req := request{method: "PUT", path: "/localization/overrides"}
+	req.jsonBody = body
+	result := new(LocalizationMessageTemplateOverride)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutMaskingRulesByDatabaseId calls PUT /masking-rules/{databaseId}. Save masking rules
+func (c *Client) PutMaskingRulesByDatabaseId(ctx context.Context, databaseId string, body *MaskingSaveMaskingRulesRequest) ([]*MaskingMaskingRule, error) {
+	req := request{method: "PUT", path: buildPath("/masking-rules/{databaseId}", map[string]any{"databaseId": databaseId})}
+	req.jsonBody = body
+	var result []*MaskingMaskingRule
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutNotifiersEventsWorkspaceByWorkspaceIdByEventType calls PUT /notifiers/events/workspace/{workspaceId}/{eventType}. Override event severity
+func (c *Client) PutNotifiersEventsWorkspaceByWorkspaceIdByEventType(ctx context.Context, workspaceId string, eventType string, body *NotifiersUpdateEventSeverityRequest) (*NotifiersNotificationSeverityOverride, error) {
+	req := request{method: "PUT", path: buildPath("/notifiers/events/workspace/{workspaceId}/{eventType}", map[string]any{"workspaceId": workspaceId, "eventType": eventType})}
+	req.jsonBody = body
+	result := new(NotifiersNotificationSeverityOverride)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutRefreshJobsById calls PUT /refresh-jobs/{id}. Update a refresh job
+func (c *Client) PutRefreshJobsById(ctx context.Context, id string, body *RestoresRefreshesRefreshJob) (*RestoresRefreshesRefreshJob, error) {
+	req := request{method: "PUT", path: buildPath("/refresh-jobs/{id}", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(RestoresRefreshesRefreshJob)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutSecurityEventsSettings calls PUT /security-events/settings. Update security event settings
+func (c *Client) PutSecurityEventsSettings(ctx context.Context, body *NotifiersSecurityEventsSecurityEventSettings) (*NotifiersSecurityEventsSecurityEventSettings, error) {
+	req := request{method: "PUT", path: "/security-events/settings"}
+	req.jsonBody = body
+	result := new(NotifiersSecurityEventsSecurityEventSettings)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutStatusPagesWorkspaceByWorkspaceId calls PUT /status-pages/workspace/{workspaceId}. Save status page
+func (c *Client) PutStatusPagesWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, body *BackupsStatusPagesSaveStatusPageRequest) (*BackupsStatusPagesStatusPageTokenResponse, error) {
+	req := request{method: "PUT", path: buildPath("/status-pages/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(BackupsStatusPagesStatusPageTokenResponse)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutStorageMirrorGroupsById calls PUT /storage-mirror-groups/{id}. Update storage mirror group
+func (c *Client) PutStorageMirrorGroupsById(ctx context.Context, id string, body *StoragesMirrorsUpdateMirrorGroupRequest) (*StoragesMirrorsStorageMirrorGroup, error) {
+	req := request{method: "PUT", path: buildPath("/storage-mirror-groups/{id}", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(StoragesMirrorsStorageMirrorGroup)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutStoragesByIdQuota calls PUT /storages/{id}/quota. Update storage quota
+func (c *Client) PutStoragesByIdQuota(ctx context.Context, id string, body *StoragesUsageUpdateStorageQuotaRequest) (*StoragesUsageStorageQuota, error) {
+	req := request{method: "PUT", path: buildPath("/storages/{id}/quota", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(StoragesUsageStorageQuota)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutStoragesWorkspaceByWorkspaceIdDefault calls PUT /storages/workspace/{workspaceId}/default. Set default storage of a workspace
+func (c *Client) PutStoragesWorkspaceByWorkspaceIdDefault(ctx context.Context, workspaceId string, body *StoragesSetDefaultStorageRequest) error {
+	req := request{method: "PUT", path: buildPath("/storages/workspace/{workspaceId}/default", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	return c.do(ctx, req, nil)
+}
+
+// PutSystemBranding calls PUT /system/branding. Update branding
+func (c *Client) PutSystemBranding(ctx context.Context, body *UsersModelsBrandingSettings) (*UsersModelsBrandingSettings, error) {
+	req := request{method: "PUT", path: "/system/branding"}
+	req.jsonBody = body
+	result := new(UsersModelsBrandingSettings)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutTicketIntegrationsWorkspaceByWorkspaceId calls PUT /ticket-integrations/workspace/{workspaceId}. Save ticket integration
+func (c *Client) PutTicketIntegrationsWorkspaceByWorkspaceId(ctx context.Context, workspaceId string, body *NotifiersTicketsTicketIntegration) (*NotifiersTicketsTicketIntegration, error) {
+	req := request{method: "PUT", path: buildPath("/ticket-integrations/workspace/{workspaceId}", map[string]any{"workspaceId": workspaceId})}
+	req.jsonBody = body
+	result := new(NotifiersTicketsTicketIntegration)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersByIdRole calls PUT /users/{id}/role. Change user role
+func (c *Client) PutUsersByIdRole(ctx context.Context, id string, body *UsersDtoChangeUserRoleRequestDTO) (map[string]string, error) {
+	req := request{method: "PUT", path: buildPath("/users/{id}/role", map[string]any{"id": id})}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersChangePassword calls PUT /users/change-password. Change user password
+func (c *Client) PutUsersChangePassword(ctx context.Context, body *UsersDtoChangePasswordRequestDTO) (map[string]string, error) {
+	req := request{method: "PUT", path: "/users/change-password"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersMe calls PUT /users/me. Update current user information
+func (c *Client) PutUsersMe(ctx context.Context, body *UsersDtoUpdateUserInfoRequestDTO) (map[string]string, error) {
+	req := request{method: "PUT", path: "/users/me"}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersMePreferences calls PUT /users/me/preferences. Update own preferences
+func (c *Client) PutUsersMePreferences(ctx context.Context, body *UsersModelsUserPreferences) (*UsersModelsUserPreferences, error) {
+	req := request{method: "PUT", path: "/users/me/preferences"}
+	req.jsonBody = body
+	result := new(UsersModelsUserPreferences)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersMeViewsById calls PUT /users/me/views/{id}. Update a saved view
+func (c *Client) PutUsersMeViewsById(ctx context.Context, id string, body *SavedViewsSaveViewRequest) (*SavedViewsSavedView, error) {
+	req := request{method: "PUT", path: buildPath("/users/me/views/{id}", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(SavedViewsSavedView)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersSettings calls PUT /users/settings. Update users settings
+func (c *Client) PutUsersSettings(ctx context.Context, body *UsersModelsUsersSettings) (*UsersModelsUsersSettings, error) {
+	req := request{method: "PUT", path: "/users/settings"}
+	req.jsonBody = body
+	result := new(UsersModelsUsersSettings)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersSettingsHeaders calls PUT /users/settings/headers. Update security headers
+func (c *Client) PutUsersSettingsHeaders(ctx context.Context, body *UsersModelsSecurityHeaders) (*UsersModelsSecurityHeaders, error) {
+	req := request{method: "PUT", path: "/users/settings/headers"}
+	req.jsonBody = body
+	result := new(UsersModelsSecurityHeaders)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutUsersSettingsSecurity calls PUT /users/settings/security. Update security policy
+func (c *Client) PutUsersSettingsSecurity(ctx context.Context, body *UsersModelsSecurityPolicy) (*UsersModelsSecurityPolicy, error) {
+	req := request{method: "PUT", path: "/users/settings/security"}
+	req.jsonBody = body
+	result := new(UsersModelsSecurityPolicy)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutWorkspacesById calls PUT /workspaces/{id}. Update workspace settings
+func (c *Client) PutWorkspacesById(ctx context.Context, id string, body *WorkspacesModelsWorkspace) (*WorkspacesModelsWorkspace, error) {
+	req := request{method: "PUT", path: buildPath("/workspaces/{id}", map[string]any{"id": id})}
+	req.jsonBody = body
+	result := new(WorkspacesModelsWorkspace)
+	if err := c.do(ctx, req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PutWorkspacesMembershipsByIdMembersByUserIdRole calls PUT /workspaces/memberships/{id}/members/{userId}/role. Change member role
+func (c *Client) PutWorkspacesMembershipsByIdMembersByUserIdRole(ctx context.Context, id string, userId string, body *WorkspacesDtoChangeMemberRoleRequestDTO) (map[string]string, error) {
+	req := request{method: "PUT", path: buildPath("/workspaces/memberships/{id}/members/{userId}/role", map[string]any{"id": id, "userId": userId})}
+	req.jsonBody = body
+	var result map[string]string
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
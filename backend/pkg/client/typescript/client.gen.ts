@@ -0,0 +1,3795 @@
+// Code generated by cmd/openapi from the OpenAPI document. DO NOT EDIT.
+
+/* eslint-disable */
+
+export interface ErrorResponse {
+  error: string;
+}
+
+export interface AgentsAgent {
+  createdAt?: string;
+  id?: string;
+  isConnected?: boolean;
+  lastSeenAt?: string;
+  name?: string;
+  version?: string;
+  workspaceId?: string;
+}
+
+export interface AgentsAgentJob {
+  args?: Array<string>;
+  backupId?: string;
+  env?: Array<string>;
+  tool?: string;
+}
+
+export interface AgentsCompleteAgentJobRequest {
+  /** Error is empty when the dump tool exited successfully */
+  error?: string;
+  toolOutput?: string;
+}
+
+export interface AgentsCreateAgentRequest {
+  name: string;
+  workspaceId: string;
+}
+
+export interface AgentsCreateAgentResponse {
+  agent?: AgentsAgent;
+  /** Token is returned only once, use it as DATABASUS_AGENT_TOKEN of the agent */
+  token?: string;
+}
+
+export interface AuditLogsAuditLogDTO {
+  changeReason?: string;
+  createdAt?: string;
+  id?: string;
+  message?: string;
+  readCount?: number;
+  userEmail?: string;
+  userId?: string;
+  userName?: string;
+  workspaceId?: string;
+  workspaceName?: string;
+}
+
+export interface AuditLogsGetAuditLogsResponse {
+  auditLogs?: Array<AuditLogsAuditLogDTO>;
+  limit?: number;
+  offset?: number;
+  total?: number;
+}
+
+export type AzureBlobStorageAuthMethod = "CONNECTION_STRING" | "ACCOUNT_KEY";
+
+export interface AzureBlobStorageAzureBlobStorage {
+  accountKey?: string;
+  accountName?: string;
+  authMethod?: AzureBlobStorageAuthMethod;
+  connectionString?: string;
+  containerName?: string;
+  endpoint?: string;
+  prefix?: string;
+  /** ProxyURL overrides OUTBOUND_PROXY_URL for this storage, see proxy_utils.GetProxyFunc */
+  proxyUrl?: string;
+  storageId?: string;
+}
+
+export interface BackupingBackupLogEntry {
+  isEnd?: boolean;
+  line?: string;
+  seq?: number;
+  time?: string;
+}
+
+export interface BackupingScheduleLoadBucket {
+  backupsPerNode?: number;
+  runningBackups?: number;
+  startingBackups?: number;
+  startsAt?: string;
+}
+
+export interface BackupingScheduleLoadProjection {
+  buckets?: Array<BackupingScheduleLoadBucket>;
+  from?: string;
+  nodesCount?: number;
+  peakRunningBackups?: number;
+  to?: string;
+}
+
+export interface BackupsGetBackupsResponse {
+  backups?: Array<BackupsCoreBackup>;
+  limit?: number;
+  offset?: number;
+  total?: number;
+}
+
+export interface BackupsGetStorageBackupsResponse {
+  backups?: Array<BackupsCoreBackup>;
+  databases?: Array<BackupsCoreDatabaseBackupsSize>;
+  limit?: number;
+  offset?: number;
+  total?: number;
+  totalSizeMb?: number;
+}
+
+export interface BackupsMakeBackupRequest {
+  database_id: string;
+  /** Tags are matched by retention holds of the backup config, e.g. "release:v1.2" */
+  tags?: Array<string>;
+}
+
+export interface BackupsAdoptionAdoptBackupsRequest {
+  directory?: string;
+  /** Pattern is matched against names relative to the directory, e.g. "orders-*.dump" */
+  pattern: string;
+  /** StorageID defaults to the storage of the backup config of the database */
+  storageId?: string;
+}
+
+export interface BackupsAdoptionAdoptBackupsResponse {
+  backups?: Array<BackupsCoreBackup>;
+}
+
+export interface BackupsAdoptionScanBackupsResponse {
+  files?: Array<BackupsAdoptionScannedFile>;
+  storageId?: string;
+}
+
+export interface BackupsAdoptionScannedFile {
+  isAdopted?: boolean;
+  modifiedAt?: string;
+  name?: string;
+  sizeBytes?: number;
+}
+
+export interface BackupsBadgesBackupBadge {
+  createdAt?: string;
+  databaseId?: string;
+  id?: string;
+  tokenRotatedAt?: string;
+}
+
+export interface BackupsBadgesBackupBadgeTokenResponse {
+  backupBadge?: BackupsBadgesBackupBadge;
+  token?: string;
+}
+
+export interface BackupsCalendarsCalendarFeed {
+  createdAt?: string;
+  id?: string;
+  tokenRotatedAt?: string;
+  workspaceId?: string;
+}
+
+export interface BackupsCalendarsCalendarFeedTokenResponse {
+  calendarFeed?: BackupsCalendarsCalendarFeed;
+  token?: string;
+}
+
+export interface BackupsConfigAssignDefaultStorageRequest {
+  /**
+   * IsReassignAttached also moves databases attached to another storage, deleting their
+   * backups
+   */
+  isReassignAttached?: boolean;
+}
+
+export interface BackupsConfigAssignDefaultStorageResponse {
+  assignedDatabasesCount?: number;
+}
+
+export interface BackupsConfigBackupConfig {
+  backupInterval?: IntervalsInterval;
+  backupIntervalId?: string;
+  /**
+   * Timeouts terminate the backup or restore process, HangTimeoutMinutes aborts and retries
+   * backups with no bytes progressed for that long. 0 = global default from env
+   */
+  backupTimeoutMinutes?: number;
+  /**
+   * ConcurrencyGroupID limits scheduled backups running at once with other databases of
+   * the group
+   */
+  concurrencyGroupId?: string;
+  databaseId?: string;
+  encryption?: BackupsConfigBackupEncryption;
+  /**
+   * FileNameTemplate names backup files of the database, it overrides the template of the
+   * storage. Used only on storages supporting file naming
+   */
+  fileNameTemplate?: string;
+  hangTimeoutMinutes?: number;
+  isBackupsEnabled?: boolean;
+  isRetryIfFailed?: boolean;
+  /**
+   * Paused schedules start no scheduled backups or retries until resumed, or until
+   * SchedulePausedUntil if set. Managed by pause endpoints only, saving the config keeps it
+   */
+  isSchedulePaused?: boolean;
+  /** MaxBackupSizeMB limits individual backup size. 0 = unlimited. */
+  maxBackupSizeMb?: number;
+  /** MaxBackupsTotalSizeMB limits total size of all backups. 0 = unlimited. */
+  maxBackupsTotalSizeMb?: number;
+  maxFailedTriesCount?: number;
+  restoreTimeoutMinutes?: number;
+  retentionHolds?: Array<BackupsConfigRetentionHold>;
+  schedulePausedUntil?: string;
+  /**
+   * ScheduleSpreadMinutes delays scheduled backups by a stable offset within the window,
+   * so databases sharing a time of day do not start at once. 0 = no delay
+   */
+  scheduleSpreadMinutes?: number;
+  sendNotificationsOn?: Array<BackupsConfigBackupNotificationType>;
+  storage?: StoragesStorage;
+  storageId?: string;
+  storePeriod?: PeriodPeriod;
+}
+
+export type BackupsConfigBackupEncryption = "NONE" | "ENCRYPTED";
+
+export type BackupsConfigBackupNotificationType = "BACKUP_FAILED" | "BACKUP_SUCCESS";
+
+export interface BackupsConfigConcurrencyGroup {
+  createdAt?: string;
+  id?: string;
+  maxConcurrentBackups?: number;
+  name?: string;
+  workspaceId?: string;
+}
+
+export interface BackupsConfigDatabaseTransfer {
+  createdAt?: string;
+  databaseId?: string;
+  id?: string;
+  sourceStorageId?: string;
+  sourceWorkspaceId?: string;
+  targetStorageId?: string;
+  targetWorkspaceId?: string;
+  transferredByUserId?: string;
+}
+
+export interface BackupsConfigPauseScheduleRequest {
+  /** PausedUntil resumes the schedule automatically, the pause lasts until resumed if empty */
+  pausedUntil?: string;
+  reason?: string;
+}
+
+export interface BackupsConfigRetentionHold {
+  keepPeriod?: PeriodPeriod;
+  tagPattern?: string;
+}
+
+export interface BackupsConfigTransferDatabaseRequest {
+  isTransferWithNotifiers?: boolean;
+  isTransferWithStorage?: boolean;
+  isUseEquivalentStorage?: boolean;
+  targetNotifierIds?: Array<string>;
+  targetStorageId?: string;
+  targetWorkspaceId: string;
+}
+
+export interface BackupsCoreBackup {
+  /** AdoptedFromFile is the name of the existing dump the backup was copied from */
+  adoptedFromFile?: string;
+  backupDurationMs?: number;
+  backupSizeMb?: number;
+  /**
+   * Checksum is sha256 of the file, it is set for backups whose file is uploaded by
+   * Databasus. Engines writing into storages themselves, like CockroachDB, leave it empty
+   */
+  checksum?: string;
+  createdAt?: string;
+  databaseId?: string;
+  encryption?: BackupsConfigBackupEncryption;
+  failMessage?: string;
+  /**
+   * FileName is the name of the file in the storage rendered from a naming template, the
+   * file is named by the backup ID if empty
+   */
+  fileName?: string;
+  id?: string;
+  /** IsManual is set for backups started by users, scheduled backups and retries are not */
+  isManual?: boolean;
+  isSkipRetry?: boolean;
+  /**
+   * Signature is the base64 Ed25519 signature of GetSignatureMessage by the signing key
+   * of the instance, restores check it to detect files changed in the storage
+   */
+  signature?: string;
+  status?: BackupsCoreBackupStatus;
+  storageId?: string;
+  /** Tags are set when the backup is started and matched by retention holds of the config */
+  tags?: Array<string>;
+}
+
+export interface BackupsCoreBackupDeadLetter {
+  attempts?: Array<BackupsCoreBackupDeadLetterAttempt>;
+  attemptsCount?: number;
+  backupId?: string;
+  createdAt?: string;
+  databaseId?: string;
+  failMessage?: string;
+  id?: string;
+  requeuedAt?: string;
+  requeuedByUserId?: string;
+}
+
+export interface BackupsCoreBackupDeadLetterAttempt {
+  backupId?: string;
+  createdAt?: string;
+  failMessage?: string;
+}
+
+export interface BackupsCoreBackupRunLog {
+  backupId?: string;
+  createdAt?: string;
+  phases?: Array<CommonBackupPhaseTiming>;
+  toolOutputTail?: string;
+}
+
+export type BackupsCoreBackupStatus = "IN_PROGRESS" | "COMPLETED" | "FAILED" | "CANCELED";
+
+export interface BackupsCoreDatabaseBackupsSize {
+  backupsCount?: number;
+  databaseId?: string;
+  totalSizeMb?: number;
+}
+
+export interface BackupsDownloadBackupShareLink {
+  backupId?: string;
+  createdAt?: string;
+  createdByUserId?: string;
+  downloadCount?: number;
+  expiresAt?: string;
+  id?: string;
+  lastDownloadedAt?: string;
+  /** MaxDownloads limits downloads of the link, 0 allows any count until it expires */
+  maxDownloads?: number;
+  note?: string;
+  recipientUserId?: string;
+  revokedAt?: string;
+}
+
+export interface BackupsDownloadCreateShareLinkRequest {
+  /** ExpiresInHours defaults to 24, links live for 7 days at most */
+  expiresInHours?: number;
+  maxDownloads?: number;
+  note?: string;
+  recipientUserId: string;
+}
+
+export interface BackupsDownloadGenerateDownloadTokenResponse {
+  backupId?: string;
+  filename?: string;
+  token?: string;
+}
+
+export interface BackupsGrafanaGrafanaIntegration {
+  /** APIToken is a token of a service account with the annotations writer permission */
+  apiToken?: string;
+  createdAt?: string;
+  dashboardUid?: string;
+  id?: string;
+  isEnabled?: boolean;
+  url?: string;
+  workspaceId?: string;
+}
+
+export interface BackupsRunbooksDRRunbook {
+  databases?: Array<BackupsRunbooksRunbookDatabase>;
+  generatedAt?: string;
+  workspaceId?: string;
+  workspaceName?: string;
+}
+
+export interface BackupsRunbooksRunbookBackup {
+  checksum?: string;
+  createdAt?: string;
+  encryption?: string;
+  fileName?: string;
+  id?: string;
+  sizeMb?: number;
+}
+
+export interface BackupsRunbooksRunbookDatabase {
+  databaseId?: string;
+  /** LatestBackup is nil when the database has no completed backups yet */
+  latestBackup?: BackupsRunbooksRunbookBackup;
+  name?: string;
+  /**
+   * RequiredKeys are placeholders used by the commands, their values are never written
+   * to the runbook
+   */
+  requiredKeys?: Array<BackupsRunbooksRunbookKey>;
+  restoreCommands?: Array<string>;
+  storage?: BackupsRunbooksRunbookStorage;
+  type?: string;
+}
+
+export interface BackupsRunbooksRunbookKey {
+  description?: string;
+  placeholder?: string;
+}
+
+export interface BackupsRunbooksRunbookStorage {
+  id?: string;
+  location?: Record<string, string>;
+  name?: string;
+  type?: string;
+}
+
+export type BackupsStatusPagesDatabaseHealth = "HEALTHY" | "FAILING" | "NO_BACKUPS";
+
+export interface BackupsStatusPagesPublicDatabaseStatus {
+  failureStreak?: number;
+  health?: BackupsStatusPagesDatabaseHealth;
+  label?: string;
+  lastSuccessAgeSeconds?: number;
+  lastSuccessAt?: string;
+  successStreak?: number;
+}
+
+export interface BackupsStatusPagesPublicStatusPage {
+  databases?: Array<BackupsStatusPagesPublicDatabaseStatus>;
+  generatedAt?: string;
+  title?: string;
+}
+
+export interface BackupsStatusPagesSaveStatusPageRequest {
+  databaseLabels?: Record<string, string>;
+  isAnonymized?: boolean;
+  title: string;
+}
+
+export interface BackupsStatusPagesStatusPage {
+  createdAt?: string;
+  /**
+   * DatabaseLabels replace names of databases on the page, the page shows only labelled
+   * databases if there are labels
+   */
+  databaseLabels?: Record<string, string>;
+  id?: string;
+  isAnonymized?: boolean;
+  title?: string;
+  tokenRotatedAt?: string;
+  workspaceId?: string;
+}
+
+export interface BackupsStatusPagesStatusPageTokenResponse {
+  statusPage?: BackupsStatusPagesStatusPage;
+  token?: string;
+}
+
+export interface BackupsTriggersBackupTrigger {
+  createdAt?: string;
+  databaseId?: string;
+  id?: string;
+  lastTriggeredAt?: string;
+  tokenRotatedAt?: string;
+}
+
+export interface BackupsTriggersBackupTriggerTokenResponse {
+  backupTrigger?: BackupsTriggersBackupTrigger;
+  token?: string;
+}
+
+export interface BackupsTriggersCheckDeploymentGateRequest {
+  isTriggeringIfStale?: boolean;
+  maxAgeMinutes?: number;
+  waitTimeoutSeconds?: number;
+}
+
+export interface BackupsTriggersDeploymentGateResponse {
+  databaseId?: string;
+  databaseName?: string;
+  isBackupTriggered?: boolean;
+  isPassed?: boolean;
+  lastBackupAt?: string;
+  lastBackupId?: string;
+  maxAgeMinutes?: number;
+  triggeredBackupFailMessage?: string;
+}
+
+export interface BackupsTriggersTriggerBackupRequest {
+  reason?: string;
+  tags?: Array<string>;
+}
+
+export interface BillingSubscriptionsGetWorkspaceSubscriptionResponse {
+  currentPeriodEnd?: string;
+  planTier?: BillingSubscriptionsPlanTier;
+  quota?: BillingSubscriptionsPlanQuota;
+  status?: BillingSubscriptionsSubscriptionStatus;
+  workspaceId?: string;
+}
+
+export interface BillingSubscriptionsPlanQuota {
+  maxBackupSizeMb?: number;
+  /** 0 means unlimited for all numeric limits */
+  maxDatabases?: number;
+  maxStorageGb?: number;
+  maxStorePeriod?: PeriodPeriod;
+  /**
+   * NodePriority orders due backups of the scheduler, higher priorities are started
+   * first and get the least busy nodes
+   */
+  nodePriority?: number;
+  tier?: BillingSubscriptionsPlanTier;
+}
+
+export type BillingSubscriptionsPlanTier = "FREE" | "STARTER" | "PRO" | "BUSINESS";
+
+export type BillingSubscriptionsSubscriptionStatus = "ACTIVE" | "TRIALING" | "PAST_DUE" | "INCOMPLETE" | "CANCELED";
+
+export interface BillingUsageExportUsageRecordsResponse {
+  from?: string;
+  records?: Array<BillingUsageUsageRecordDTO>;
+  to?: string;
+}
+
+export interface BillingUsageGetWorkspaceUsageResponse {
+  days?: Array<BillingUsageWorkspaceUsage>;
+  from?: string;
+  to?: string;
+  total?: BillingUsageUsageTotalsDTO;
+  workspaceId?: string;
+}
+
+export type BillingUsageUsageMetric = "BYTES_TRANSFERRED" | "BYTES_STORED" | "JOBS_COUNT" | "NODE_MINUTES";
+
+export interface BillingUsageUsageRecordDTO {
+  identifier?: string;
+  metric?: BillingUsageUsageMetric;
+  periodEnd?: string;
+  periodStart?: string;
+  quantity?: number;
+  timestamp?: number;
+  workspaceId?: string;
+}
+
+export interface BillingUsageUsageTotalsDTO {
+  bytesTransferred?: number;
+  jobsCount?: number;
+  nodeMinutes?: number;
+  /**
+   * stored bytes are a gauge, so the total is the
+   * peak value within the period instead of a sum
+   */
+  peakBytesStored?: number;
+}
+
+export interface BillingUsageWorkspaceUsage {
+  bytesStored?: number;
+  bytesTransferred?: number;
+  id?: string;
+  jobsCount?: number;
+  nodeMinutes?: number;
+  periodStart?: string;
+  updatedAt?: string;
+  workspaceId?: string;
+}
+
+export interface CassandraCassandraDatabase {
+  cqlPassword?: string;
+  /** restore only: CQL credentials of sstableloader */
+  cqlUsername?: string;
+  dataDirectory?: string;
+  databaseId?: string;
+  id?: string;
+  /**
+   * IsAllowPartialBackup keeps backups with some nodes failed. With a replication
+   * factor above 1 the other nodes may still hold all data
+   */
+  isAllowPartialBackup?: boolean;
+  /**
+   * IsUseSudo runs nodetool and reads SSTables with `sudo -n`, data directories usually
+   * belong to the cassandra user
+   */
+  isUseSudo?: boolean;
+  jmxPassword?: string;
+  /** JMX credentials of nodetool, empty when JMX authentication is off */
+  jmxUsername?: string;
+  /** Keyspaces is a comma separated list, empty means all keyspaces */
+  keyspaces?: string;
+  /** Nodes is a comma separated list of node hosts, SSH and nodetool run on each of them */
+  nodes?: string;
+  sshPassword?: string;
+  sshPort?: number;
+  sshPrivateKey?: string;
+  sshUsername?: string;
+  /** restore only: directory on target nodes where SSTables are uploaded before loading */
+  stagingDirectory?: string;
+  /** Version is detected on connection, from the first node */
+  version?: string;
+}
+
+export interface ChatopsChatAccount {
+  chatUserId?: string;
+  createdAt?: string;
+  id?: string;
+  platform?: ChatopsChatPlatform;
+  teamId?: string;
+  userId?: string;
+}
+
+export type ChatopsChatPlatform = "SLACK" | "MATTERMOST";
+
+export interface ChatopsLinkCodeResponse {
+  code?: string;
+  expiresAt?: string;
+}
+
+export interface ChatopsSlashCommandResponse {
+  response_type?: string;
+  text?: string;
+}
+
+export interface ClientCertificatesClientCertificate {
+  createdAt?: string;
+  /**
+   * Fingerprint is the SHA-256 of the DER encoded certificate in hex. Certificates are
+   * matched by it, so a renewed certificate is registered again
+   */
+  fingerprint?: string;
+  id?: string;
+  lastUsedAt?: string;
+  name?: string;
+  notAfter?: string;
+  subject?: string;
+  userId?: string;
+}
+
+export interface ClientCertificatesRegisterClientCertificateRequest {
+  /** Certificate is the PEM encoded client certificate, without its private key */
+  certificate: string;
+  name: string;
+  userId: string;
+}
+
+export interface CockroachdbCockroachdbDatabase {
+  /** Database is backed up alone, empty means a full cluster backup */
+  database?: string;
+  databaseId?: string;
+  host?: string;
+  id?: string;
+  isHttps?: boolean;
+  password?: string;
+  port?: number;
+  /** restore only: restore the database of a database backup under this name */
+  restoreDatabaseName?: string;
+  username?: string;
+  /** Version is detected on connection */
+  version?: string;
+}
+
+export interface CommentsComment {
+  authorId?: string;
+  createdAt?: string;
+  id?: string;
+  resourceId?: string;
+  resourceType?: CommentsResourceType;
+  text?: string;
+  updatedAt?: string;
+  workspaceId?: string;
+}
+
+export interface CommentsCommentDTO {
+  authorEmail?: string;
+  authorId?: string;
+  authorName?: string;
+  createdAt?: string;
+  id?: string;
+  resourceId?: string;
+  resourceType?: CommentsResourceType;
+  text?: string;
+  updatedAt?: string;
+}
+
+export interface CommentsCreateCommentRequest {
+  resourceId: string;
+  resourceType: CommentsResourceType;
+  text: string;
+}
+
+export type CommentsResourceType = "DATABASE" | "STORAGE" | "NOTIFIER";
+
+export interface CommentsUpdateCommentRequest {
+  text: string;
+}
+
+export type CommonBackupPhase = "CONNECT" | "DUMP" | "COMPRESS" | "ENCRYPT" | "UPLOAD";
+
+export interface CommonBackupPhaseTiming {
+  bytes?: number;
+  durationMs?: number;
+  isFinished?: boolean;
+  phase?: CommonBackupPhase;
+  startedAt?: string;
+}
+
+export type CredentialExpiryCredentialType = "DATABASE" | "STORAGE";
+
+export interface CredentialExpiryExpiringCredential {
+  daysLeft?: number;
+  expireAt?: string;
+  id?: string;
+  isExpired?: boolean;
+  name?: string;
+  type?: unknown;
+  workspaceId?: string;
+}
+
+export interface CredentialExpiryExpiringCredentialsResponse {
+  credentials?: Array<CredentialExpiryExpiringCredential>;
+  withinDays?: number;
+}
+
+export interface DatabasesCreateReadOnlyUserResponse {
+  password?: string;
+  username?: string;
+}
+
+export interface DatabasesCredentialSource {
+  type?: unknown;
+  vaultAddress?: string;
+  vaultNamespace?: string;
+  vaultPath?: string;
+  vaultToken?: string;
+  /** WebhookSecret is sent as a bearer token */
+  webhookSecret?: string;
+  webhookUrl?: string;
+}
+
+export type DatabasesCredentialSourceType = "WEBHOOK" | "VAULT";
+
+export interface DatabasesDatabase {
+  /**
+   * AgentID is set for databases reachable only from a private network. Backups are
+   * dumped by the agent there instead of by backup nodes
+   */
+  agentId?: string;
+  cassandra?: CassandraCassandraDatabase;
+  /**
+   * ChangeReason is sent with edits and written to the audit log, it is required in
+   * workspaces with IsChangeReasonRequired. Not stored on the database
+   */
+  changeReason?: string;
+  cockroachdb?: CockroachdbCockroachdbDatabase;
+  /**
+   * CredentialSource is set when credentials are fetched before connecting, the password
+   * of the connection is not stored then
+   */
+  credentialSource?: DatabasesCredentialSource;
+  /**
+   * CredentialsExpireAt is when the password of the connection has to be rotated,
+   * notifiers of the database are reminded before it
+   */
+  credentialsExpireAt?: string;
+  /**
+   * DNSOverride resolves the host of the connection with overrides or another DNS server,
+   * for nodes resolving names unlike app servers
+   */
+  dnsOverride?: NetworkUtilsDNSOverride;
+  elasticsearch?: ElasticsearchElasticsearchDatabase;
+  /** Environment is empty when the database takes the environment of its workspace */
+  environment?: EnvironmentsEnvironment;
+  filesystem?: FilesystemFilesystemDatabase;
+  healthStatus?: DatabasesHealthStatus;
+  id?: string;
+  influxdb?: InfluxdbInfluxdbDatabase;
+  /** IsDefaultNotifiersOptOut keeps default notifiers of the workspace from being attached */
+  isDefaultNotifiersOptOut?: boolean;
+  lastBackupErrorMessage?: string;
+  /**
+   * these fields are not reliable, but
+   * they are used for pretty UI
+   */
+  lastBackupTime?: string;
+  mariadb?: MariadbMariadbDatabase;
+  mongodb?: MongodbMongodbDatabase;
+  mysql?: MysqlMysqlDatabase;
+  name?: string;
+  neo4j?: Neo4jNeo4jDatabase;
+  notifiers?: Array<NotifiersNotifier>;
+  ownerTeam?: string;
+  ownerUserId?: string;
+  postgresql?: PostgresqlPostgresqlDatabase;
+  type?: unknown;
+  /**
+   * WorkspaceID can be null when a database is created via restore operation
+   * outside the context of any workspace
+   */
+  workspaceId?: string;
+}
+
+export type DatabasesDatabaseType = "POSTGRES" | "MYSQL" | "MARIADB" | "MONGODB" | "FILESYSTEM" | "ELASTICSEARCH" | "INFLUXDB" | "CASSANDRA" | "COCKROACHDB" | "NEO4J";
+
+export type DatabasesHealthStatus = "AVAILABLE" | "UNAVAILABLE";
+
+export interface DatabasesIsReadOnlyResponse {
+  isReadOnly?: boolean;
+  privileges?: Array<string>;
+}
+
+export interface DatabasesTemplatesConnectionTemplate {
+  /**
+   * Connection keeps only the specific database of the type, with secrets encrypted like
+   * on databases
+   */
+  connection?: DatabasesDatabase;
+  createdAt?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  type?: unknown;
+}
+
+export interface DatabasesTemplatesConnectionTemplateResponse {
+  connection?: DatabasesDatabase;
+  createdAt?: string;
+  description?: string;
+  id?: string;
+  name?: string;
+  type?: unknown;
+}
+
+export interface DatabasesTemplatesCreateDatabaseFromTemplateRequest {
+  name: string;
+  workspaceId: string;
+}
+
+export interface DiscordNotifierDiscordNotifier {
+  channelWebhookUrl?: string;
+  notifierId?: string;
+}
+
+export interface DiskDiskUsage {
+  freeSpaceBytes?: number;
+  platform?: DiskPlatform;
+  totalSpaceBytes?: number;
+  usedSpaceBytes?: number;
+}
+
+export type DiskPlatform = "linux" | "windows";
+
+export interface ElasticsearchElasticsearchDatabase {
+  databaseId?: string;
+  /** Flavor and Version are detected on connection */
+  flavor?: ElasticsearchElasticsearchFlavor;
+  id?: string;
+  /** Indices is a comma separated list of indices, data streams and patterns */
+  indices?: string;
+  /** restore only: delete indices of the snapshot before restoring them in place */
+  isDeleteExistingIndices?: boolean;
+  isIncludeGlobalState?: boolean;
+  password?: string;
+  /** Repository is a snapshot repository registered in the cluster */
+  repository?: string;
+  /**
+   * restore only: prefix for restored index names, they are restored under their
+   * original names when empty
+   */
+  restoreIndexPrefix?: string;
+  skipTlsVerify?: boolean;
+  url?: string;
+  username?: string;
+  version?: string;
+}
+
+export type ElasticsearchElasticsearchFlavor = "ELASTICSEARCH" | "OPENSEARCH";
+
+export interface EmailNotifierEmailNotifier {
+  customCaCert?: string;
+  from?: string;
+  minTlsVersion?: EmailNotifierTLSVersion;
+  notifierId?: string;
+  /**
+   * ProxyURL overrides OUTBOUND_PROXY_URL for this notifier. HTTP proxies have to allow
+   * CONNECT to the SMTP port, see proxy_utils.DialContext
+   */
+  proxyUrl?: string;
+  smtpHost?: string;
+  smtpPassword?: string;
+  smtpPort?: number;
+  smtpUser?: string;
+  targetEmail?: string;
+  /**
+   * Empty TLS policy is opportunistic, as for notifiers created before policies. Custom CA
+   * is a PEM bundle trusted in addition to system CAs
+   */
+  tlsPolicy?: EmailNotifierTLSPolicy;
+}
+
+export type EmailNotifierTLSPolicy = "OPPORTUNISTIC" | "REQUIRED" | "PLAINTEXT_ALLOWED";
+
+export type EmailNotifierTLSVersion = "TLS1.0" | "TLS1.1" | "TLS1.2" | "TLS1.3";
+
+export type EnvironmentsEnvironment = "" | "PROD" | "STAGING" | "DEV";
+
+export type FeatureFlagsFeatureFlag = "pitr_beta";
+
+export interface FeatureFlagsFeatureFlagDTO {
+  flag?: FeatureFlagsFeatureFlag;
+  isEnabled?: boolean;
+  /**
+   * IsOverridden is true when value comes from workspace override
+   * instead of instance configuration
+   */
+  isOverridden?: boolean;
+}
+
+export interface FeatureFlagsGetFeaturesResponse {
+  features?: Array<FeatureFlagsFeatureFlagDTO>;
+}
+
+export interface FeatureFlagsSetWorkspaceOverrideRequest {
+  flag: FeatureFlagsFeatureFlag;
+  isEnabled?: boolean;
+}
+
+export interface FeatureFlagsWorkspaceFeatureFlag {
+  flag?: FeatureFlagsFeatureFlag;
+  id?: string;
+  isEnabled?: boolean;
+  updatedAt?: string;
+  workspaceId?: string;
+}
+
+export interface FilesUtilsDirectoryEntry {
+  isDirectory?: boolean;
+  modifiedAt?: string;
+  name?: string;
+  sizeBytes?: number;
+}
+
+export type FilesystemFilesystemCompression = "ZSTD" | "GZIP" | "NONE";
+
+export interface FilesystemFilesystemDatabase {
+  compression?: FilesystemFilesystemCompression;
+  databaseId?: string;
+  excludeGlobs?: Array<string>;
+  id?: string;
+  /**
+   * backup settings. Globs are matched against paths relative to Path, a glob without
+   * "/" matches the name in any directory. Globs are stored newline separated, they
+   * may contain commas
+   */
+  includeGlobs?: Array<string>;
+  /** restore settings (not saved to DB) */
+  isRemoveExistingFiles?: boolean;
+  /**
+   * LvmSnapshotSize is copy-on-write space of the snapshot in lvcreate format, e.g. 1G.
+   * The snapshot becomes invalid if more data is changed during the backup
+   */
+  lvmSnapshotSize?: string;
+  path?: string;
+  snapshotMode?: FilesystemFilesystemSnapshotMode;
+}
+
+export type FilesystemFilesystemSnapshotMode = "NONE" | "LVM" | "BTRFS";
+
+export interface FtpStorageFTPStorage {
+  /** DNSOverride resolves the host with overrides or another DNS server than the system one */
+  dnsOverride?: NetworkUtilsDNSOverride;
+  host?: string;
+  /** IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses */
+  ipPreference?: NetworkUtilsIPPreference;
+  password?: string;
+  path?: string;
+  port?: number;
+  skipTlsVerify?: boolean;
+  storageId?: string;
+  useSsl?: boolean;
+  username?: string;
+}
+
+export type GcsStorageAuthMethod = "SERVICE_ACCOUNT_KEY" | "WORKLOAD_IDENTITY";
+
+export interface GcsStorageGCSStorage {
+  authMethod?: GcsStorageAuthMethod;
+  bucket?: string;
+  prefix?: string;
+  serviceAccountKey?: string;
+  /** StorageClass of uploaded objects, empty uses the default class of the bucket */
+  storageClass?: string;
+  storageId?: string;
+}
+
+export interface GoogleDriveStorageGoogleDriveStorage {
+  clientId?: string;
+  clientSecret?: string;
+  storageId?: string;
+  tokenJson?: string;
+}
+
+export interface GraphqlGraphQLError {
+  message?: string;
+  path?: Array<unknown>;
+}
+
+export interface GraphqlGraphQLRequest {
+  operationName?: string;
+  query: string;
+  variables?: Record<string, unknown>;
+}
+
+export interface GraphqlGraphQLResponse {
+  data?: Record<string, unknown>;
+  errors?: Array<GraphqlGraphQLError>;
+}
+
+export interface HealthcheckAttemptHealthcheckAttempt {
+  createdAt?: string;
+  databaseId?: string;
+  id?: string;
+  status?: DatabasesHealthStatus;
+}
+
+export interface HealthcheckConfigHealthcheckConfig {
+  attemptsBeforeConcideredAsDown?: number;
+  databaseId?: string;
+  intervalMinutes?: number;
+  isHealthcheckEnabled?: boolean;
+  isSentNotificationWhenUnavailable?: boolean;
+  storeAttemptsDays?: number;
+}
+
+export interface HealthcheckConfigHealthcheckConfigDTO {
+  attemptsBeforeConcideredAsDown?: number;
+  databaseId?: string;
+  intervalMinutes?: number;
+  isHealthcheckEnabled?: boolean;
+  isSentNotificationWhenUnavailable?: boolean;
+  storeAttemptsDays?: number;
+}
+
+export type I18nLocale = "en" | "es" | "de" | "fr" | "en";
+
+export type I18nMessageKey = "backup_failed_title" | "backup_success_title" | "backup_success_message" | "refresh_failed_title" | "refresh_success_title" | "refresh_success_message" | "notifier_broken_title" | "notifier_broken_message" | "credentials_expiring_title" | "credentials_expiring_message" | "credentials_expired_title" | "credentials_expired_message" | "storage_quota_soft_exceeded_title" | "storage_quota_soft_exceeded_message" | "storage_quota_hard_exceeded_title" | "storage_quota_hard_exceeded_message" | "security_new_device_title" | "security_new_device_message" | "security_failed_sign_ins_title" | "security_failed_sign_ins_message" | "security_policy_changed_title" | "security_policy_changed_message" | "test_event_note" | "database_online_title" | "database_online_message" | "database_unavailable_title" | "database_unavailable_message" | "email_password_reset_subject" | "email_password_reset_heading" | "email_password_reset_intro" | "email_password_reset_expiration" | "email_password_reset_ignore" | "email_password_reset_automated" | "email_password_reset_link_intro" | "email_password_reset_button" | "email_verification_subject" | "email_verification_heading" | "email_verification_intro" | "email_verification_button" | "email_verification_paste_token" | "email_verification_expiration" | "email_verification_ignore" | "email_verification_automated" | "email_owned_backup_failed_reason" | "email_invitation_subject" | "email_invitation_heading" | "email_invitation_body" | "email_invitation_sign_up" | "email_invitation_visit_instance" | "email_invitation_automated" | "email_security_release_subject" | "email_security_release_body" | "email_security_release_release_notes";
+
+export interface ImportsImportPlan {
+  databases?: Array<ImportsPlannedDatabase>;
+  warnings?: Array<string>;
+}
+
+export interface ImportsImportResult {
+  databases?: Array<ImportsImportedDatabase>;
+}
+
+export type ImportsImportSource = "PGBACKREST" | "WALG" | "BARMAN";
+
+export interface ImportsImportedDatabase {
+  databaseId?: string;
+  error?: string;
+  name?: string;
+  storageId?: string;
+}
+
+export interface ImportsParseImportRequest {
+  config: string;
+  /**
+   * Crontab with backup commands of the tool, schedules and WAL-G retention are read
+   * from it
+   */
+  crontab?: string;
+  source: ImportsImportSource;
+}
+
+export interface ImportsPlannedDatabase {
+  cronExpression?: string;
+  database?: string;
+  host?: string;
+  name?: string;
+  /** Password is empty when the tool relies on .pgpass or peer auth */
+  password?: string;
+  port?: number;
+  storage?: StoragesStorage;
+  storePeriod?: PeriodPeriod;
+  username?: string;
+  warnings?: Array<string>;
+}
+
+export interface InfluxdbInfluxdbDatabase {
+  bucket?: string;
+  databaseId?: string;
+  id?: string;
+  /** restore only: replace all data and metadata of the server, tokens included */
+  isFullRestore?: boolean;
+  org?: string;
+  /** restore only: restore the bucket of a bucket backup under this name */
+  restoreBucket?: string;
+  skipTlsVerify?: boolean;
+  /**
+   * Token must be an operator token for full backups, an all access token is enough
+   * with Bucket
+   */
+  token?: string;
+  url?: string;
+  /** Version is detected on connection */
+  version?: string;
+}
+
+export interface IntervalsInterval {
+  /** only for CRON */
+  cronExpression?: string;
+  /** only for MONTHLY */
+  dayOfMonth?: number;
+  id?: string;
+  interval?: IntervalsIntervalType;
+  timeOfDay?: string;
+  /** only for WEEKLY */
+  weekday?: number;
+}
+
+export type IntervalsIntervalType = "HOURLY" | "DAILY" | "WEEKLY" | "MONTHLY" | "CRON";
+
+export interface LocalStorageLocalStorage {
+  storageId?: string;
+}
+
+export interface LocalizationGetMessagesResponse {
+  locale?: I18nLocale;
+  messages?: Array<LocalizationLocalizedMessageDTO>;
+  supportedLocales?: Array<I18nLocale>;
+}
+
+export interface LocalizationLocalizedMessageDTO {
+  defaultTemplate?: string;
+  key?: I18nMessageKey;
+  override?: string;
+}
+
+export interface LocalizationMessageTemplateOverride {
+  id?: string;
+  locale?: I18nLocale;
+  messageKey?: I18nMessageKey;
+  template?: string;
+  updatedAt?: string;
+}
+
+export interface LocalizationUpsertOverrideRequest {
+  key: I18nMessageKey;
+  locale: I18nLocale;
+  template: string;
+}
+
+export interface MariadbMariadbDatabase {
+  database?: string;
+  databaseId?: string;
+  host?: string;
+  id?: string;
+  isHttps?: boolean;
+  password?: string;
+  port?: number;
+  privileges?: string;
+  username?: string;
+  version?: ToolsMariadbVersion;
+}
+
+export interface MaskingMaskingRule {
+  column?: string;
+  createdAt?: string;
+  databaseId?: string;
+  id?: string;
+  /**
+   * Schema is the PostgreSQL schema, public when empty. MySQL and MariaDB mask tables of
+   * the restored database when empty
+   */
+  schema?: string;
+  strategy?: MaskingMaskingStrategy;
+  table?: string;
+}
+
+export type MaskingMaskingStrategy = "HASH" | "EMAIL" | "NULL" | "REDACT" | "KEEP_LAST_4";
+
+export interface MaskingSaveMaskingRulesRequest {
+  rules?: Array<MaskingMaskingRule>;
+}
+
+export interface MongodbMongodbDatabase {
+  authDatabase?: string;
+  cpuCount?: number;
+  database?: string;
+  databaseId?: string;
+  host?: string;
+  id?: string;
+  isHttps?: boolean;
+  isSrv?: boolean;
+  password?: string;
+  port?: number;
+  username?: string;
+  version?: ToolsMongodbVersion;
+}
+
+export interface MysqlMysqlDatabase {
+  database?: string;
+  databaseId?: string;
+  host?: string;
+  id?: string;
+  isHttps?: boolean;
+  password?: string;
+  port?: number;
+  privileges?: string;
+  username?: string;
+  version?: ToolsMysqlVersion;
+}
+
+export interface NasStorageNASStorage {
+  /** DNSOverride resolves the host with overrides or another DNS server than the system one */
+  dnsOverride?: NetworkUtilsDNSOverride;
+  domain?: string;
+  host?: string;
+  /** IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses */
+  ipPreference?: NetworkUtilsIPPreference;
+  password?: string;
+  path?: string;
+  port?: number;
+  share?: string;
+  storageId?: string;
+  useSsl?: boolean;
+  username?: string;
+}
+
+export type Neo4jNeo4jBackupMode = "DUMP" | "ONLINE_BACKUP";
+
+export interface Neo4jNeo4jDatabase {
+  backupMode?: Neo4jNeo4jBackupMode;
+  database?: string;
+  databaseId?: string;
+  edition?: Neo4jNeo4jEdition;
+  id?: string;
+  /**
+   * IsUseSudo runs neo4j-admin as the neo4j user and the service commands as root with
+   * `sudo -n`, otherwise the SSH user must own the Neo4j data directory
+   */
+  isUseSudo?: boolean;
+  password?: string;
+  /** restore only: restore into this database, the backed up name when empty */
+  restoreDatabase?: string;
+  /**
+   * ServiceName is stopped during dumps of Community Edition, it cannot stop a database
+   * alone
+   */
+  serviceName?: string;
+  skipTlsVerify?: boolean;
+  /** SshHost runs neo4j-admin, the host of the URL when empty */
+  sshHost?: string;
+  sshPassword?: string;
+  sshPort?: number;
+  sshPrivateKey?: string;
+  sshUsername?: string;
+  /** restore only: directory on the host where online backups are uploaded to restore them */
+  stagingDirectory?: string;
+  /** URL is the HTTP API, e.g. http://neo4j.internal:7474 */
+  url?: string;
+  username?: string;
+  /** Version and Edition are detected on connection */
+  version?: string;
+}
+
+export type Neo4jNeo4jEdition = "community" | "enterprise";
+
+export interface NetworkUtilsDNSOverride {
+  /** Hosts maps host names to IP addresses like /etc/hosts, they win over Server */
+  hosts?: Record<string, string>;
+  /** Server is a DNS server like 10.0.0.53 or 10.0.0.53:5353 asked for other hosts */
+  server?: string;
+}
+
+export type NetworkUtilsIPPreference = "AUTO" | "IPV4" | "IPV6";
+
+export type NotifierIncidentsSeverity = "INFO" | "WARNING" | "ERROR" | "CRITICAL";
+
+export interface NotifiersEventSeverityResponse {
+  defaultSeverity?: NotifierIncidentsSeverity;
+  description?: string;
+  eventType?: NotifiersNotificationEventType;
+  isOverridden?: boolean;
+  severity?: NotifierIncidentsSeverity;
+}
+
+export interface NotifiersNotificationEvent {
+  defaultSeverity?: NotifierIncidentsSeverity;
+  description?: string;
+  type?: unknown;
+}
+
+export type NotifiersNotificationEventType = "backup_failed" | "backup_success" | "refresh_failed" | "refresh_success" | "database_unavailable" | "database_online" | "notifier_broken" | "credentials_expiring" | "credentials_expired" | "storage_quota_soft_exceeded" | "storage_quota_hard_exceeded" | "security_new_device_sign_in" | "security_failed_sign_ins" | "security_policy_changed";
+
+export interface NotifiersNotificationSeverityOverride {
+  eventType?: NotifiersNotificationEventType;
+  severity?: NotifierIncidentsSeverity;
+  updatedAt?: string;
+  workspaceId?: string;
+}
+
+export interface NotifiersNotifier {
+  /**
+   * ChangeReason is sent with edits and written to the audit log, it is required in
+   * workspaces with IsChangeReasonRequired. Not stored on the notifier
+   */
+  changeReason?: string;
+  discordNotifier?: DiscordNotifierDiscordNotifier;
+  emailNotifier?: EmailNotifierEmailNotifier;
+  healthCheckError?: string;
+  healthStatus?: NotifiersNotifierHealthStatus;
+  id?: string;
+  /**
+   * IsSystem notifiers are defined by admins and shared read-only with every workspace,
+   * e.g. a central ops channel
+   */
+  isSystem?: boolean;
+  /**
+   * IsWorkspaceDefault notifiers are attached to each database created in the workspace,
+   * unless the database opts out of default notifiers
+   */
+  isWorkspaceDefault?: boolean;
+  lastHealthCheckAt?: string;
+  lastSendError?: string;
+  locale?: I18nLocale;
+  name?: string;
+  notifierType?: NotifiersNotifierType;
+  opsgenieNotifier?: OpsgenieNotifierOpsgenieNotifier;
+  pagerDutyNotifier?: PagerdutyNotifierPagerDutyNotifier;
+  slackNotifier?: SlackNotifierSlackNotifier;
+  teamsNotifier?: TeamsNotifierTeamsNotifier;
+  /** specific notifier */
+  telegramNotifier?: TelegramNotifierTelegramNotifier;
+  webhookNotifier?: WebhookNotifierWebhookNotifier;
+  workspaceId?: string;
+}
+
+export type NotifiersNotifierHealthStatus = "UNKNOWN" | "HEALTHY" | "BROKEN";
+
+export interface NotifiersNotifierIncident {
+  acknowledgedAt?: string;
+  acknowledgedBy?: string;
+  createdAt?: string;
+  dedupKey?: string;
+  id?: string;
+  lastTriggeredAt?: string;
+  notifierId?: string;
+  resolvedAt?: string;
+  status?: NotifiersNotifierIncidentStatus;
+  title?: string;
+  /**
+   * TriggerCount includes repeats which were not sent, because the incident was
+   * acknowledged
+   */
+  triggerCount?: number;
+}
+
+export type NotifiersNotifierIncidentStatus = "TRIGGERED" | "ACKNOWLEDGED" | "RESOLVED";
+
+export interface NotifiersNotifierResponse {
+  discordNotifier?: DiscordNotifierDiscordNotifier;
+  emailNotifier?: EmailNotifierEmailNotifier;
+  healthCheckError?: string;
+  healthStatus?: NotifiersNotifierHealthStatus;
+  id?: string;
+  isSystem?: boolean;
+  isWorkspaceDefault?: boolean;
+  lastHealthCheckAt?: string;
+  lastSendError?: string;
+  locale?: I18nLocale;
+  name?: string;
+  notifierType?: NotifiersNotifierType;
+  opsgenieNotifier?: OpsgenieNotifierOpsgenieNotifier;
+  pagerDutyNotifier?: PagerdutyNotifierPagerDutyNotifier;
+  slackNotifier?: SlackNotifierSlackNotifier;
+  teamsNotifier?: TeamsNotifierTeamsNotifier;
+  telegramNotifier?: TelegramNotifierTelegramNotifier;
+  webhookNotifier?: WebhookNotifierWebhookNotifier;
+  workspaceId?: string;
+}
+
+export type NotifiersNotifierType = "EMAIL" | "TELEGRAM" | "WEBHOOK" | "SLACK" | "DISCORD" | "TEAMS" | "PAGERDUTY" | "OPSGENIE";
+
+export interface NotifiersTransferNotifierRequest {
+  targetWorkspaceId: string;
+}
+
+export interface NotifiersUpdateEventSeverityRequest {
+  severity: NotifierIncidentsSeverity;
+}
+
+export interface NotifiersBroadcastsBroadcast {
+  createdAt?: string;
+  createdByUserId?: string;
+  failedCount?: number;
+  finishedAt?: string;
+  id?: string;
+  isSendEmails?: boolean;
+  message?: string;
+  sentCount?: number;
+  status?: NotifiersBroadcastsBroadcastStatus;
+  title?: string;
+  /** WorkspaceIDs limits the broadcast to these workspaces, empty means all workspaces */
+  workspaceIds?: Array<string>;
+}
+
+export interface NotifiersBroadcastsBroadcastDelivery {
+  broadcastId?: string;
+  channel?: NotifiersBroadcastsDeliveryChannel;
+  error?: string;
+  id?: string;
+  notifierId?: string;
+  recipient?: string;
+  sentAt?: string;
+  status?: NotifiersBroadcastsDeliveryStatus;
+  workspaceId?: string;
+}
+
+export type NotifiersBroadcastsBroadcastStatus = "IN_PROGRESS" | "COMPLETED";
+
+export interface NotifiersBroadcastsCreateBroadcastRequest {
+  isSendEmails?: boolean;
+  message: string;
+  title: string;
+  workspaceIds?: Array<string>;
+}
+
+export type NotifiersBroadcastsDeliveryChannel = "NOTIFIER" | "EMAIL";
+
+export type NotifiersBroadcastsDeliveryStatus = "SENT" | "FAILED";
+
+export interface NotifiersSecurityEventsSecurityEventSettings {
+  /** EventTypes are sent to the notifier, empty means all of them */
+  eventTypes?: Array<NotifiersSecurityEventsSecurityEventType>;
+  isEmailAffectedUsers?: boolean;
+  notifierId?: string;
+}
+
+export type NotifiersSecurityEventsSecurityEventType = "NEW_DEVICE_SIGN_IN" | "FAILED_SIGN_IN_STREAK" | "SECURITY_POLICY_CHANGED";
+
+export interface NotifiersTicketsTicket {
+  createdAt?: string;
+  databaseId?: string;
+  externalKey?: string;
+  externalUrl?: string;
+  failuresCount?: number;
+  id?: string;
+  integrationId?: string;
+  lastBackupId?: string;
+}
+
+export interface NotifiersTicketsTicketIntegration {
+  createdAt?: string;
+  descriptionTemplate?: string;
+  failuresThreshold?: number;
+  id?: string;
+  isEnabled?: boolean;
+  jiraApiToken?: string;
+  jiraEmail?: string;
+  jiraIssueType?: string;
+  jiraProjectKey?: string;
+  /** Jira Cloud, the API token is used with basic auth of the account email */
+  jiraUrl?: string;
+  provider?: NotifiersTicketsTicketProvider;
+  serviceNowAssignmentGroup?: string;
+  serviceNowPassword?: string;
+  /** ServiceNow, tickets are opened as incidents */
+  serviceNowUrl?: string;
+  serviceNowUsername?: string;
+  summaryTemplate?: string;
+  windowHours?: number;
+  workspaceId?: string;
+}
+
+export type NotifiersTicketsTicketProvider = "JIRA" | "SERVICENOW";
+
+export interface OpsgenieNotifierOpsgenieNotifier {
+  apiKey?: string;
+  notifierId?: string;
+  region?: OpsgenieNotifierOpsgenieRegion;
+  /**
+   * WebhookSecret is compared with the header sent by the webhook integration, alert
+   * state is synced back only when it is set
+   */
+  webhookSecret?: string;
+}
+
+export type OpsgenieNotifierOpsgenieRegion = "US" | "EU";
+
+export interface OwnershipOrphansOrphanedResource {
+  id?: string;
+  name?: string;
+  ownerTeam?: string;
+  ownerUserId?: string;
+  type?: unknown;
+}
+
+export interface OwnershipOrphansOrphanedResourcesResponse {
+  resources?: Array<OwnershipOrphansOrphanedResource>;
+}
+
+export type OwnershipOrphansResourceType = "DATABASE" | "STORAGE";
+
+export interface PagerdutyNotifierPagerDutyNotifier {
+  notifierId?: string;
+  routingKey?: string;
+  /**
+   * WebhookSecret is the signing secret of the V3 webhook subscription, incident state is
+   * synced back only when it is set
+   */
+  webhookSecret?: string;
+}
+
+export type PeriodPeriod = "DAY" | "WEEK" | "MONTH" | "3_MONTH" | "6_MONTH" | "YEAR" | "2_YEARS" | "3_YEARS" | "4_YEARS" | "5_YEARS" | "FOREVER";
+
+export interface PlansDatabasePlan {
+  databaseId?: string;
+  maxBackupSizeMb?: number;
+  maxBackupsTotalSizeMb?: number;
+  maxStoragePeriod?: PeriodPeriod;
+}
+
+export interface PluginStoragePluginStorage {
+  config?: Record<string, string>;
+  pluginName?: string;
+  secretConfig?: Record<string, string>;
+  storageId?: string;
+}
+
+export interface PostgresqlPostgresqlDatabase {
+  cpuCount?: number;
+  database?: string;
+  databaseId?: string;
+  /** connection data */
+  host?: string;
+  id?: string;
+  /** backup settings */
+  includeSchemas?: Array<string>;
+  /** restore settings (not saved to DB) */
+  isExcludeExtensions?: boolean;
+  isHttps?: boolean;
+  password?: string;
+  port?: number;
+  recommendedCpuCount?: number;
+  timescaledbChunksCount?: number;
+  /** TimescaleDB extension data, detected together with the version */
+  timescaledbVersion?: string;
+  username?: string;
+  version?: ToolsPostgresqlVersion;
+}
+
+export interface RcloneStorageRcloneStorage {
+  configContent?: string;
+  remotePath?: string;
+  storageId?: string;
+}
+
+export interface RestoresCorePreRestoreCheck {
+  /** IsOverridden is set for failed checks listed in overrideChecks of the request */
+  isOverridden?: boolean;
+  message?: string;
+  status?: RestoresCorePreRestoreCheckStatus;
+  type?: unknown;
+}
+
+export type RestoresCorePreRestoreCheckStatus = "PASSED" | "WARNING" | "FAILED" | "SKIPPED";
+
+export type RestoresCorePreRestoreCheckType = "VERSION" | "EXTENSIONS" | "ENCODING" | "DISK_SPACE";
+
+export interface RestoresCorePreRestoreReport {
+  checks?: Array<RestoresCorePreRestoreCheck>;
+  isBlocked?: boolean;
+}
+
+export interface RestoresCoreRestore {
+  backup?: BackupsCoreBackup;
+  backupId?: string;
+  cassandraDatabase?: CassandraCassandraDatabase;
+  cockroachdbDatabase?: CockroachdbCockroachdbDatabase;
+  createdAt?: string;
+  elasticsearchDatabase?: ElasticsearchElasticsearchDatabase;
+  failMessage?: string;
+  filesystemDatabase?: FilesystemFilesystemDatabase;
+  id?: string;
+  influxdbDatabase?: InfluxdbInfluxdbDatabase;
+  /** IsMasked restores complete only after masking rules of the database are applied */
+  isMasked?: boolean;
+  mariadbDatabase?: MariadbMariadbDatabase;
+  mongodbDatabase?: MongodbMongodbDatabase;
+  mysqlDatabase?: MysqlMysqlDatabase;
+  neo4jDatabase?: Neo4jNeo4jDatabase;
+  postgresqlDatabase?: PostgresqlPostgresqlDatabase;
+  restoreDurationMs?: number;
+  status?: RestoresCoreRestoreStatus;
+}
+
+export interface RestoresCoreRestoreBackupRequest {
+  cassandraDatabase?: CassandraCassandraDatabase;
+  cockroachdbDatabase?: CockroachdbCockroachdbDatabase;
+  elasticsearchDatabase?: ElasticsearchElasticsearchDatabase;
+  filesystemDatabase?: FilesystemFilesystemDatabase;
+  influxdbDatabase?: InfluxdbInfluxdbDatabase;
+  /**
+   * IsApplyMasking runs masking rules of the database on the restored data, the restore
+   * fails when they cannot be applied
+   */
+  isApplyMasking?: boolean;
+  /** IsProdRestoreConfirmed is required when the target is on the server of a PROD database */
+  isProdRestoreConfirmed?: boolean;
+  mariadbDatabase?: MariadbMariadbDatabase;
+  mongodbDatabase?: MongodbMongodbDatabase;
+  mysqlDatabase?: MysqlMysqlDatabase;
+  neo4jDatabase?: Neo4jNeo4jDatabase;
+  /** OverrideChecks lets the restore start despite failed pre-restore checks of these types */
+  overrideChecks?: Array<RestoresCorePreRestoreCheckType>;
+  postgresqlDatabase?: PostgresqlPostgresqlDatabase;
+}
+
+export interface RestoresCoreRestoreEstimate {
+  activeRestores?: number;
+  availableNodes?: number;
+  backupId?: string;
+  backupSizeMb?: number;
+  estimatedDurationMs?: number;
+  estimatedFinishAt?: string;
+  sampleRestoresCount?: number;
+  throughputMBs?: number;
+  throughputSource?: RestoresCoreRestoreThroughputSource;
+}
+
+export type RestoresCoreRestoreStatus = "IN_PROGRESS" | "COMPLETED" | "FAILED" | "CANCELED";
+
+export type RestoresCoreRestoreThroughputSource = "ENGINE_AND_STORAGE_HISTORY" | "ENGINE_HISTORY" | "NODE_THROUGHPUT";
+
+export interface RestoresPlansRestorePlan {
+  createdAt?: string;
+  /**
+   * CreatedByUserID is the user restores of the plan are started for, so steps starting
+   * later are checked against the permissions of that user
+   */
+  createdByUserId?: string;
+  finishedAt?: string;
+  id?: string;
+  /**
+   * MaxParallelRestores limits steps restoring at the same time, 0 starts every step whose
+   * dependencies completed
+   */
+  maxParallelRestores?: number;
+  name?: string;
+  progress?: RestoresPlansRestorePlanProgress;
+  status?: RestoresPlansRestorePlanStatus;
+  steps?: Array<RestoresPlansRestorePlanStep>;
+  workspaceId?: string;
+}
+
+export interface RestoresPlansRestorePlanProgress {
+  completedSteps?: number;
+  failedSteps?: number;
+  inProgressSteps?: number;
+  pendingSteps?: number;
+  percent?: number;
+  skippedSteps?: number;
+  totalSteps?: number;
+}
+
+export type RestoresPlansRestorePlanStatus = "IN_PROGRESS" | "COMPLETED" | "FAILED" | "CANCELED";
+
+export interface RestoresPlansRestorePlanStep {
+  backupId?: string;
+  dependsOn?: Array<string>;
+  failMessage?: string;
+  finishedAt?: string;
+  id?: string;
+  key?: string;
+  planId?: string;
+  position?: number;
+  restoreId?: string;
+  startedAt?: string;
+  status?: RestoresPlansRestorePlanStepStatus;
+  /**
+   * Target is restored like a manual restore. Passwords are encrypted with the ID of the
+   * database of the backup, restores decrypt them with it
+   */
+  target?: RestoresCoreRestoreBackupRequest;
+}
+
+export type RestoresPlansRestorePlanStepStatus = "PENDING" | "IN_PROGRESS" | "COMPLETED" | "FAILED" | "SKIPPED";
+
+export interface RestoresRefreshesRefreshJob {
+  createdAt?: string;
+  databaseId?: string;
+  id?: string;
+  interval?: IntervalsInterval;
+  intervalId?: string;
+  /** IsApplyMasking runs masking rules of the database on each refresh */
+  isApplyMasking?: boolean;
+  isEnabled?: boolean;
+  lastRunAt?: string;
+  name?: string;
+  sendNotificationsOn?: Array<RestoresRefreshesRefreshNotificationType>;
+  /**
+   * Target is restored like a manual restore. Passwords are encrypted with the ID of the
+   * source database, restores decrypt them with it
+   */
+  target?: RestoresCoreRestoreBackupRequest;
+}
+
+export type RestoresRefreshesRefreshNotificationType = "REFRESH_FAILED" | "REFRESH_SUCCESS";
+
+export interface RestoresRefreshesRefreshRun {
+  backupId?: string;
+  failMessage?: string;
+  finishedAt?: string;
+  id?: string;
+  refreshJobId?: string;
+  restoreId?: string;
+  startedAt?: string;
+  status?: RestoresRefreshesRefreshRunStatus;
+}
+
+export type RestoresRefreshesRefreshRunStatus = "IN_PROGRESS" | "COMPLETED" | "FAILED";
+
+export type S3StorageAuthMethod = "STATIC_KEYS" | "ASSUME_ROLE" | "INSTANCE_PROFILE";
+
+export type S3StorageObjectLockMode = "NONE" | "GOVERNANCE" | "COMPLIANCE";
+
+export interface S3StorageS3Storage {
+  /**
+   * AuthMethod picks where credentials come from, access keys are not needed for instance
+   * profiles and are optional when assuming a role. Empty is treated as static keys
+   */
+  authMethod?: S3StorageAuthMethod;
+  /** DNSOverride resolves the host with overrides or another DNS server than the system one */
+  dnsOverride?: NetworkUtilsDNSOverride;
+  externalId?: string;
+  /** IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses */
+  ipPreference?: NetworkUtilsIPPreference;
+  isBucketKeyEnabled?: boolean;
+  kmsKeyId?: string;
+  objectLockMode?: S3StorageObjectLockMode;
+  objectLockRetentionDays?: number;
+  /** ProxyURL overrides OUTBOUND_PROXY_URL for this storage, see proxy_utils.GetProxyFunc */
+  proxyUrl?: string;
+  roleArn?: string;
+  s3AccessKey?: string;
+  s3Bucket?: string;
+  s3Endpoint?: string;
+  s3Prefix?: string;
+  s3Region?: string;
+  s3SecretKey?: string;
+  s3UseVirtualHostedStyle?: boolean;
+  /**
+   * ServerSideEncryption and ObjectLockMode are applied to every uploaded object. Empty
+   * values are treated as NONE and keep the defaults of the bucket
+   */
+  serverSideEncryption?: S3StorageServerSideEncryption;
+  skipTLSVerify?: boolean;
+  storageId?: string;
+}
+
+export type S3StorageServerSideEncryption = "NONE" | "SSE_S3" | "SSE_KMS";
+
+export interface SavedViewsAddFavoriteRequest {
+  resourceId: string;
+  resourceType: SavedViewsResourceType;
+  workspaceId: string;
+}
+
+export interface SavedViewsFavoriteDTO {
+  createdAt?: string;
+  name?: string;
+  resourceId?: string;
+  resourceType?: SavedViewsResourceType;
+}
+
+export type SavedViewsResourceType = "DATABASE" | "STORAGE";
+
+export interface SavedViewsSaveViewRequest {
+  filters?: Record<string, string>;
+  name: string;
+  resourceType: SavedViewsResourceType;
+  workspaceId: string;
+}
+
+export interface SavedViewsSavedView {
+  createdAt?: string;
+  filters?: Record<string, string>;
+  id?: string;
+  name?: string;
+  resourceType?: SavedViewsResourceType;
+  updatedAt?: string;
+  userId?: string;
+  workspaceId?: string;
+}
+
+export interface SftpStorageSFTPStorage {
+  /** DNSOverride resolves the host with overrides or another DNS server than the system one */
+  dnsOverride?: NetworkUtilsDNSOverride;
+  host?: string;
+  /** IPPreference picks the address family tried first for hosts with IPv4 and IPv6 addresses */
+  ipPreference?: NetworkUtilsIPPreference;
+  password?: string;
+  path?: string;
+  port?: number;
+  privateKey?: string;
+  skipHostKeyVerify?: boolean;
+  storageId?: string;
+  username?: string;
+}
+
+export interface SigningPublicKeyResponse {
+  algorithm?: string;
+  publicKey?: string;
+  publicKeyPem?: string;
+}
+
+export interface SlackNotifierSlackNotifier {
+  botToken?: string;
+  notifierId?: string;
+  /** ProxyURL overrides OUTBOUND_PROXY_URL for this notifier, see proxy_utils.GetProxyFunc */
+  proxyUrl?: string;
+  targetChatId?: string;
+}
+
+export interface StoragesBenchmarkStorageRequest {
+  /** SizeMb is the size of the test object, 16 MB when empty */
+  sizeMb?: number;
+}
+
+export interface StoragesBrowseStorageResponse {
+  entries?: Array<FilesUtilsDirectoryEntry>;
+  isTruncated?: boolean;
+  path?: string;
+}
+
+export interface StoragesSetDefaultStorageRequest {
+  /** StorageID clears the default if empty */
+  storageId?: string;
+}
+
+export interface StoragesStorage {
+  azureBlobStorage?: AzureBlobStorageAzureBlobStorage;
+  /**
+   * ChangeReason is sent with edits and written to the audit log, it is required in
+   * workspaces with IsChangeReasonRequired. Not stored on the storage
+   */
+  changeReason?: string;
+  /**
+   * CredentialsExpireAt is when the keys of the storage have to be rotated, default
+   * notifiers of the workspace are reminded before it
+   */
+  credentialsExpireAt?: string;
+  /**
+   * FileNameTemplate names backup files instead of their ID, see RenderFileName. Backup
+   * configs may override it
+   */
+  fileNameTemplate?: string;
+  ftpStorage?: FtpStorageFTPStorage;
+  gcsStorage?: GcsStorageGCSStorage;
+  googleDriveStorage?: GoogleDriveStorageGoogleDriveStorage;
+  id?: string;
+  /**
+   * IsContentAddressed stores files under their sha256 with an index object per file,
+   * see saveContentAddressedFile. Only S3 storages support it
+   */
+  isContentAddressed?: boolean;
+  isSystem?: boolean;
+  lastSaveError?: string;
+  /** specific storage */
+  localStorage?: LocalStorageLocalStorage;
+  name?: string;
+  nasStorage?: NasStorageNASStorage;
+  ownerTeam?: string;
+  ownerUserId?: string;
+  pluginStorage?: PluginStoragePluginStorage;
+  rcloneStorage?: RcloneStorageRcloneStorage;
+  s3Storage?: S3StorageS3Storage;
+  sftpStorage?: SftpStorageSFTPStorage;
+  type?: unknown;
+  /**
+   * VisibleWorkspaceIDs limits a system storage to these workspaces, empty means all
+   * workspaces. The workspace owning the storage always sees it
+   */
+  visibleWorkspaceIds?: Array<string>;
+  workspaceId?: string;
+}
+
+export interface StoragesStorageBenchmarkResult {
+  deleteDurationMs?: number;
+  downloadDurationMs?: number;
+  downloadFirstByteMs?: number;
+  downloadThroughputMbs?: number;
+  isMultipart?: boolean;
+  partSizeMb?: number;
+  partsCount?: number;
+  sizeMb?: number;
+  uploadDurationMs?: number;
+  uploadThroughputMbs?: number;
+}
+
+export interface StoragesStorageResponse {
+  azureBlobStorage?: AzureBlobStorageAzureBlobStorage;
+  credentialsExpireAt?: string;
+  fileNameTemplate?: string;
+  ftpStorage?: FtpStorageFTPStorage;
+  gcsStorage?: GcsStorageGCSStorage;
+  googleDriveStorage?: GoogleDriveStorageGoogleDriveStorage;
+  id?: string;
+  isContentAddressed?: boolean;
+  isSystem?: boolean;
+  lastSaveError?: string;
+  localStorage?: LocalStorageLocalStorage;
+  name?: string;
+  nasStorage?: NasStorageNASStorage;
+  ownerTeam?: string;
+  ownerUserId?: string;
+  pluginStorage?: PluginStoragePluginStorage;
+  rcloneStorage?: RcloneStorageRcloneStorage;
+  s3Storage?: S3StorageS3Storage;
+  sftpStorage?: SftpStorageSFTPStorage;
+  type?: unknown;
+  visibleWorkspaceIds?: Array<string>;
+  workspaceId?: string;
+}
+
+export type StoragesStorageType = "LOCAL" | "S3" | "GOOGLE_DRIVE" | "NAS" | "AZURE_BLOB" | "FTP" | "SFTP" | "RCLONE" | "PLUGIN" | "GCS";
+
+export interface StoragesTransferStorageRequest {
+  targetWorkspaceId: string;
+}
+
+export interface StoragesImpactImpactedDatabase {
+  id?: string;
+  isBackupsEnabled?: boolean;
+  name?: string;
+  workspaceId?: string;
+}
+
+export interface StoragesImpactImpactedJob {
+  databaseId?: string;
+  name?: string;
+  type?: unknown;
+}
+
+export type StoragesImpactImpactedJobType = "BACKUP_SCHEDULE" | "REFRESH" | "METADATA_BACKUP";
+
+export interface StoragesImpactStorageImpact {
+  attachedDatabases?: Array<StoragesImpactImpactedDatabase>;
+  backupsCount?: number;
+  backupsTotalSizeMb?: number;
+  blockingReasons?: Array<string>;
+  isDeletable?: boolean;
+  scheduledJobs?: Array<StoragesImpactImpactedJob>;
+  storageId?: string;
+  storageName?: string;
+}
+
+export interface StoragesMirrorsBackupReplica {
+  attemptsCount?: number;
+  backupId?: string;
+  completedAt?: string;
+  createdAt?: string;
+  failMessage?: string;
+  id?: string;
+  status?: StoragesMirrorsReplicaStatus;
+  storageId?: string;
+  updatedAt?: string;
+}
+
+export interface StoragesMirrorsCreateMirrorGroupRequest {
+  name: string;
+  storageIds: Array<string>;
+  workspaceId: string;
+}
+
+export type StoragesMirrorsReplicaStatus = "PENDING" | "COMPLETED" | "FAILED";
+
+export interface StoragesMirrorsStorageMirrorGroup {
+  createdAt?: string;
+  id?: string;
+  name?: string;
+  storageIds?: Array<string>;
+  workspaceId?: string;
+}
+
+export interface StoragesMirrorsUpdateMirrorGroupRequest {
+  name: string;
+  storageIds: Array<string>;
+}
+
+export interface StoragesUsageDatabaseUsageDTO {
+  backupsCount?: number;
+  bytesUsed?: number;
+  databaseId?: string;
+  databaseName?: string;
+}
+
+export interface StoragesUsageGetStorageUsageResponse {
+  backupsCount?: number;
+  bytesUsed?: number;
+  databases?: Array<StoragesUsageDatabaseUsageDTO>;
+  measuredAt?: string;
+  quota?: StoragesUsageStorageQuota;
+  quotaState?: StoragesUsageQuotaState;
+  storageId?: string;
+  storageName?: string;
+}
+
+export type StoragesUsageQuotaState = "OK" | "SOFT_EXCEEDED" | "HARD_EXCEEDED";
+
+export interface StoragesUsageStorageQuota {
+  hardLimitBytes?: number;
+  softLimitBytes?: number;
+  state?: StoragesUsageQuotaState;
+  storageId?: string;
+  updatedAt?: string;
+}
+
+export interface StoragesUsageUpdateStorageQuotaRequest {
+  hardLimitBytes?: number;
+  softLimitBytes?: number;
+}
+
+export interface SystemDebugGCStats {
+  cpuFraction?: number;
+  lastGcAt?: string;
+  lastPauseMs?: number;
+  nextGcBytes?: number;
+  numGc?: number;
+  pauseTotalMs?: number;
+}
+
+export interface SystemDebugMemoryStats {
+  heapAllocBytes?: number;
+  heapIdleBytes?: number;
+  heapInuseBytes?: number;
+  heapObjects?: number;
+  heapReleasedBytes?: number;
+  stackInuseBytes?: number;
+  sysBytes?: number;
+  totalAllocBytes?: number;
+}
+
+export interface SystemDebugProfileInfo {
+  count?: number;
+  name?: string;
+}
+
+export interface SystemDebugProfilesResponse {
+  profiles?: Array<SystemDebugProfileInfo>;
+}
+
+export interface SystemDebugRuntimeStatsResponse {
+  gc?: SystemDebugGCStats;
+  goMaxProcs?: number;
+  goVersion?: string;
+  hostname?: string;
+  memory?: SystemDebugMemoryStats;
+  numCpu?: number;
+  numGoroutines?: number;
+  startedAt?: string;
+  uptimeSeconds?: number;
+}
+
+export interface SystemHealthcheckHealthcheckResponse {
+  status?: string;
+}
+
+export interface SystemMetadataBackupGetMetadataBackupsResponse {
+  backups?: Array<SystemMetadataBackupMetadataBackup>;
+  intervalHours?: number;
+  isEnabled?: boolean;
+  keepCount?: number;
+}
+
+export interface SystemMetadataBackupMetadataBackup {
+  completedAt?: string;
+  createdAt?: string;
+  failMessage?: string;
+  id?: string;
+  sizeBytes?: number;
+  status?: SystemMetadataBackupMetadataBackupStatus;
+  storageId?: string;
+}
+
+export type SystemMetadataBackupMetadataBackupStatus = "IN_PROGRESS" | "COMPLETED" | "FAILED";
+
+export interface SystemSettingsReloadableSettingsResponse {
+  healthcheckMaxDiskUsagePercent?: number;
+  isSmtpPasswordSet?: boolean;
+  logLevel?: string;
+  passwordResetRateLimitPerHour?: number;
+  passwordResetTokenLifetimeMinutes?: number;
+  signInRateLimitPerMinute?: number;
+  smtpHost?: string;
+  smtpPort?: number;
+  smtpUser?: string;
+}
+
+export interface SystemVersionGetVersionResponse {
+  /** Changelog contains releases newer than the current version, newest first */
+  changelog?: Array<SystemVersionRelease>;
+  /** CheckError is set when last feed fetch failed and cached data is shown */
+  checkError?: string;
+  checkedAt?: string;
+  currentVersion?: string;
+  isSecurityUpdateAvailable?: boolean;
+  isUpdateAvailable?: boolean;
+  latestRelease?: SystemVersionRelease;
+}
+
+export interface SystemVersionRelease {
+  isSecurity?: boolean;
+  name?: string;
+  publishedAt?: string;
+  summary?: string;
+  url?: string;
+  version?: string;
+}
+
+export interface TeamsNotifierTeamsNotifier {
+  notifierId?: string;
+  powerAutomateUrl?: string;
+}
+
+export interface TelegramNotifierTelegramNotifier {
+  botToken?: string;
+  notifierId?: string;
+  targetChatId?: string;
+  threadId?: number;
+}
+
+export type ToolsMariadbVersion = "5.5" | "10.1" | "10.2" | "10.3" | "10.4" | "10.5" | "10.6" | "10.11" | "11.4" | "11.8" | "12.0";
+
+export type ToolsMongodbVersion = "4" | "5" | "6" | "7" | "8";
+
+export type ToolsMysqlVersion = "5.7" | "8.0" | "8.4" | "9";
+
+export type ToolsPostgresqlVersion = "12" | "13" | "14" | "15" | "16" | "17" | "18";
+
+export interface UsersDtoChangePasswordRequestDTO {
+  newPassword: string;
+}
+
+export interface UsersDtoChangeUserRoleRequestDTO {
+  role: UsersEnumsUserRole;
+}
+
+export interface UsersDtoInviteUserRequestDTO {
+  email: string;
+  intendedWorkspaceId?: string;
+  intendedWorkspaceRole?: UsersEnumsWorkspaceRole;
+}
+
+export interface UsersDtoInviteUserResponseDTO {
+  createdAt?: string;
+  email?: string;
+  id?: string;
+  intendedWorkspaceId?: string;
+  intendedWorkspaceRole?: UsersEnumsWorkspaceRole;
+}
+
+export interface UsersDtoListUsersResponseDTO {
+  total?: number;
+  users?: Array<UsersDtoUserProfileResponseDTO>;
+}
+
+export interface UsersDtoOAuthCallbackRequestDTO {
+  code: string;
+  redirectUri: string;
+}
+
+export interface UsersDtoOAuthCallbackResponseDTO {
+  email?: string;
+  isNewUser?: boolean;
+  token?: string;
+  userId?: string;
+}
+
+export interface UsersDtoResetPasswordByTokenRequestDTO {
+  newPassword: string;
+  token: string;
+}
+
+export interface UsersDtoResetPasswordRequestDTO {
+  code: string;
+  email: string;
+  newPassword: string;
+}
+
+export interface UsersDtoSendResetPasswordCodeRequestDTO {
+  email: string;
+}
+
+export interface UsersDtoSignInRequestDTO {
+  email: string;
+  password: string;
+}
+
+export interface UsersDtoSignInResponseDTO {
+  email?: string;
+  token?: string;
+  userId?: string;
+}
+
+export interface UsersDtoSignUpRequestDTO {
+  email: string;
+  name: string;
+  password: string;
+}
+
+export interface UsersDtoUpdateUserInfoRequestDTO {
+  email?: string;
+  locale?: I18nLocale;
+  name?: string;
+}
+
+export interface UsersDtoUserProfileResponseDTO {
+  createdAt?: string;
+  email?: string;
+  id?: string;
+  isActive?: boolean;
+  isEmailVerified?: boolean;
+  locale?: I18nLocale;
+  name?: string;
+  pendingEmail?: string;
+  preferences?: UsersModelsUserPreferences;
+  role?: UsersEnumsUserRole;
+}
+
+export interface UsersDtoVerifyEmailRequestDTO {
+  token: string;
+}
+
+export type UsersEnumsStorageRedactionLevel = "FULL" | "METADATA_ONLY" | "NONE";
+
+export type UsersEnumsStorageRedactionLevels = Record<string, UsersEnumsStorageRedactionLevel>;
+
+export type UsersEnumsUserRole = "ADMIN" | "MEMBER";
+
+export type UsersEnumsWorkspaceRole = "WORKSPACE_OWNER" | "WORKSPACE_ADMIN" | "WORKSPACE_MEMBER" | "WORKSPACE_VIEWER";
+
+export interface UsersModelsBrandingSettings {
+  accentColor?: string;
+  id?: string;
+  logoUrl?: string;
+  productName?: string;
+  supportEmail?: string;
+}
+
+export interface UsersModelsSecurityHeaders {
+  /** ContentSecurityPolicy is sent as is, frame-ancestors is appended from FrameAncestors */
+  contentSecurityPolicy?: string;
+  /**
+   * CORSAllowedOrigins may call the API from browsers, e.g. "https://portal.example.com".
+   * "*" allows any origin
+   */
+  corsAllowedOrigins?: Array<string>;
+  /** FrameAncestors may embed the UI, e.g. "'self'" or "https://wiki.example.com" */
+  frameAncestors?: Array<string>;
+  /** HSTSMaxAgeSeconds enables Strict-Transport-Security, 0 disables it */
+  hstsMaxAgeSeconds?: number;
+  isHstsIncludingSubdomains?: boolean;
+}
+
+export interface UsersModelsSecurityPolicy {
+  /** AllowedSSODomains limits GitHub and Google sign-ins to these email domains, empty allows all */
+  allowedSsoDomains?: Array<string>;
+  /** IsPasswordComplexityRequired requires upper and lower case letters and a digit */
+  isPasswordComplexityRequired?: boolean;
+  /** PasswordMinLength is at least 8, 0 means 8 */
+  passwordMinLength?: number;
+  /**
+   * SessionLifetimeHours limits how long a sign-in lasts, 0 keeps sessions until sign out.
+   * Shortening it also ends older sessions
+   */
+  sessionLifetimeHours?: number;
+}
+
+export interface UsersModelsUserPreferences {
+  defaultWorkspaceId?: string;
+  /** IsNotifyOnOwnedBackupFailures emails the user when a backup of a database they own fails */
+  isNotifyOnOwnedBackupFailures?: boolean;
+  /** Timezone is an IANA name like "Europe/Berlin" used to render schedules, empty for UTC */
+  timezone?: string;
+}
+
+export interface UsersModelsUsersSettings {
+  id?: string;
+  /** means that any user can register via sign up form without invitation */
+  isAllowExternalRegistrations?: boolean;
+  /** means that any user with role MEMBER can invite other users */
+  isAllowMemberInvitations?: boolean;
+  /** means that any user with role MEMBER can create their own workspaces */
+  isMemberAllowedToCreateWorkspaces?: boolean;
+  /** how much of system storages users see by their workspace role, FULL for missing roles */
+  systemStorageRedactionLevels?: UsersEnumsStorageRedactionLevels;
+}
+
+export interface WebhookNotifierWebhookHeader {
+  key?: string;
+  value?: string;
+}
+
+export type WebhookNotifierWebhookMethod = "POST" | "GET";
+
+export interface WebhookNotifierWebhookNotifier {
+  bodyTemplate?: string;
+  headers?: Array<WebhookNotifierWebhookHeader>;
+  notifierId?: string;
+  /** ProxyURL overrides OUTBOUND_PROXY_URL for this notifier, see proxy_utils.GetProxyFunc */
+  proxyUrl?: string;
+  webhookMethod?: WebhookNotifierWebhookMethod;
+  webhookUrl?: string;
+}
+
+export interface WorkspacesDtoAddMemberRequestDTO {
+  email: string;
+  role: UsersEnumsWorkspaceRole;
+}
+
+export interface WorkspacesDtoAddMemberResponseDTO {
+  status?: WorkspacesDtoAddMemberStatus;
+}
+
+export type WorkspacesDtoAddMemberStatus = "INVITED" | "ADDED";
+
+export interface WorkspacesDtoChangeMemberRoleRequestDTO {
+  role: UsersEnumsWorkspaceRole;
+}
+
+export interface WorkspacesDtoCreateWorkspaceRequestDTO {
+  environment?: EnvironmentsEnvironment;
+  name: string;
+}
+
+export interface WorkspacesDtoGetMembersResponseDTO {
+  members?: Array<WorkspacesDtoWorkspaceMemberResponseDTO>;
+}
+
+export interface WorkspacesDtoListWorkspacesResponseDTO {
+  workspaces?: Array<WorkspacesDtoWorkspaceResponseDTO>;
+}
+
+export interface WorkspacesDtoTransferOwnershipRequestDTO {
+  newOwnerEmail: string;
+}
+
+export interface WorkspacesDtoWorkspaceMemberResponseDTO {
+  createdAt?: string;
+  /** Populated from user join */
+  email?: string;
+  id?: string;
+  /** Populated from user join */
+  name?: string;
+  role?: UsersEnumsWorkspaceRole;
+  userId?: string;
+}
+
+export interface WorkspacesDtoWorkspaceResponseDTO {
+  createdAt?: string;
+  environment?: EnvironmentsEnvironment;
+  id?: string;
+  name?: string;
+  /** User's role in this workspace (populated when fetching for specific user) */
+  userRole?: UsersEnumsWorkspaceRole;
+}
+
+export interface WorkspacesModelsWorkspace {
+  createdAt?: string;
+  environment?: EnvironmentsEnvironment;
+  id?: string;
+  /**
+   * IsChangeReasonRequired makes edits of storages, databases and notifiers of the
+   * workspace give a reason, e.g. for regulated environments
+   */
+  isChangeReasonRequired?: boolean;
+  name?: string;
+}
+
+export interface DeleteUsersMeFavoritesByResourceIdParams {
+  workspace_id: string;
+}
+
+export interface GetAgentsParams {
+  workspace_id: string;
+}
+
+export interface GetAuditLogsGlobalParams {
+  limit?: number;
+  offset?: number;
+  beforeDate?: string;
+}
+
+export interface GetAuditLogsUsersByUserIdParams {
+  limit?: number;
+  offset?: number;
+  beforeDate?: string;
+}
+
+export interface GetBackupBadgesPublicByTokenParams {
+  maxAgeHours?: number;
+  label?: string;
+}
+
+export interface GetBackupsParams {
+  database_id: string;
+  limit?: number;
+  offset?: number;
+}
+
+export interface GetBackupsByIdFileParams {
+  token: string;
+}
+
+export interface GetBackupsDeadLettersParams {
+  workspace_id: string;
+}
+
+export interface GetBackupsJobsByIdLogsParams {
+  follow?: boolean;
+}
+
+export interface GetBillingUsageRecordsParams {
+  from?: string;
+  to?: string;
+}
+
+export interface GetBillingUsageWorkspacesByWorkspaceIdParams {
+  from?: string;
+  to?: string;
+}
+
+export interface GetCommentsParams {
+  resourceType: string;
+  resourceId: string;
+}
+
+export interface GetCredentialExpiryWorkspaceByWorkspaceIdParams {
+  days?: number;
+}
+
+export interface GetDatabasesParams {
+  workspace_id: string;
+  owner_user_id?: string;
+  owner_team?: string;
+}
+
+export interface GetFeaturesParams {
+  workspaceId?: string;
+}
+
+export interface GetHealthcheckAttemptsByDatabaseIdParams {
+  afterDate?: string;
+}
+
+export interface GetLocalizationMessagesParams {
+  locale?: string;
+}
+
+export interface GetNotifiersParams {
+  workspace_id: string;
+}
+
+export interface GetRunbooksWorkspaceByWorkspaceIdParams {
+  format?: string;
+}
+
+export interface GetStorageMirrorGroupsParams {
+  workspace_id: string;
+}
+
+export interface GetStoragesParams {
+  workspace_id: string;
+  owner_user_id?: string;
+  owner_team?: string;
+}
+
+export interface GetStoragesByIdBackupsParams {
+  limit?: number;
+  offset?: number;
+}
+
+export interface GetStoragesByIdBrowseParams {
+  path?: string;
+}
+
+export interface GetSystemDebugCpuParams {
+  seconds?: number;
+}
+
+export interface GetSystemDebugProfilesByNameParams {
+  debug?: number;
+  gc?: boolean;
+}
+
+export interface GetSystemDebugTraceParams {
+  seconds?: number;
+}
+
+export interface GetUsersParams {
+  limit?: number;
+  offset?: number;
+  beforeDate?: string;
+  query?: string;
+}
+
+export interface GetUsersMeFavoritesParams {
+  workspace_id: string;
+}
+
+export interface GetUsersMeViewsParams {
+  workspace_id: string;
+}
+
+export interface GetWorkspacesByIdAuditLogsParams {
+  limit?: number;
+  offset?: number;
+  beforeDate?: string;
+}
+
+export interface PostNotifiersByIdTestEventParams {
+  type: "backup_failed" | "backup_success" | "refresh_failed" | "refresh_success" | "database_unavailable" | "database_online" | "notifier_broken";
+}
+
+export class DatabasusApiError extends Error {
+  constructor(
+    public readonly status: number,
+    message: string,
+  ) {
+    super(message);
+  }
+}
+
+export interface DatabasusClientOptions {
+  // URL with API version, e.g. https://databasus.example.com/api/v1
+  baseUrl: string;
+  token?: string;
+  fetch?: typeof fetch;
+}
+
+type QueryValue = string | number | boolean | undefined | null | Array<string | number | boolean>;
+
+export class DatabasusClient {
+  private readonly baseUrl: string;
+  private readonly fetchImpl: typeof fetch;
+
+  constructor(private readonly options: DatabasusClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/$/, '');
+    this.fetchImpl = options.fetch ?? fetch.bind(globalThis);
+  }
+
+  private async send(
+    method: string,
+    path: string,
+    query?: Record<string, QueryValue>,
+    body?: unknown,
+    contentType?: string,
+  ): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [name, value] of Object.entries(query ?? {})) {
+      if (value === undefined || value === null) continue;
+      for (const item of Array.isArray(value) ? value : [value]) {
+        url.searchParams.append(name, String(item));
+      }
+    }
+
+    const headers: Record<string, string> = {};
+    if (this.options.token) headers.Authorization = 'Bearer ' + this.options.token;
+
+    let requestBody: BodyInit | undefined;
+    if (body !== undefined && contentType === undefined) {
+      headers['Content-Type'] = 'application/json';
+      requestBody = JSON.stringify(body);
+    } else if (body !== undefined) {
+      if (contentType !== 'multipart/form-data') headers['Content-Type'] = contentType!;
+      requestBody = body as BodyInit;
+    }
+
+    const response = await this.fetchImpl(url, { method, headers, body: requestBody });
+    if (!response.ok) {
+      const text = await response.text();
+      let message = text;
+      try {
+        message = JSON.parse(text).error ?? text;
+      } catch {
+        // not a JSON error envelope
+      }
+      throw new DatabasusApiError(response.status, message);
+    }
+
+    return response;
+  }
+
+  private async request<T>(
+    method: string,
+    path: string,
+    query?: Record<string, QueryValue>,
+    body?: unknown,
+    contentType?: string,
+  ): Promise<T> {
+    const response = await this.send(method, path, query, body, contentType);
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  /** Delete an agent (DELETE /agents/{id}) */
+  deleteAgentsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/agents/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete backup badge (DELETE /backup-badges/database/{databaseId}) */
+  deleteBackupBadgesDatabaseByDatabaseId(databaseId: string): Promise<void> {
+    return this.request<void>("DELETE", `/backup-badges/database/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Delete concurrency group (DELETE /backup-configs/concurrency-groups/{id}) */
+  deleteBackupConfigsConcurrencyGroupsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/backup-configs/concurrency-groups/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete backup trigger (DELETE /backup-triggers/database/{databaseId}) */
+  deleteBackupTriggersDatabaseByDatabaseId(databaseId: string): Promise<void> {
+    return this.request<void>("DELETE", `/backup-triggers/database/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Delete a backup (DELETE /backups/{id}) */
+  deleteBackupsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/backups/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Revoke a share link (DELETE /backups/share-links/{shareLinkId}) */
+  deleteBackupsShareLinksByShareLinkId(shareLinkId: string): Promise<void> {
+    return this.request<void>("DELETE", `/backups/share-links/${encodeURIComponent(String(shareLinkId))}`, undefined, undefined);
+  }
+
+  /** Delete calendar feed (DELETE /calendar-feeds/workspace/{workspaceId}) */
+  deleteCalendarFeedsWorkspaceByWorkspaceId(workspaceId: string): Promise<void> {
+    return this.request<void>("DELETE", `/calendar-feeds/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Unlink chat account (DELETE /chatops/accounts/{id}) */
+  deleteChatopsAccountsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/chatops/accounts/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete client certificate (DELETE /client-certificates/{id}) */
+  deleteClientCertificatesById(id: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("DELETE", `/client-certificates/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete a comment (DELETE /comments/{id}) */
+  deleteCommentsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/comments/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete a connection template (DELETE /connection-templates/{id}) */
+  deleteConnectionTemplatesById(id: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("DELETE", `/connection-templates/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete a database (DELETE /databases/{id}) */
+  deleteDatabasesById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/databases/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Remove feature flag override (DELETE /features/workspaces/{workspaceId}/{flag}) */
+  deleteFeaturesWorkspacesByWorkspaceIdByFlag(workspaceId: string, flag: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("DELETE", `/features/workspaces/${encodeURIComponent(String(workspaceId))}/${encodeURIComponent(String(flag))}`, undefined, undefined);
+  }
+
+  /** Delete Grafana integration (DELETE /grafana-integrations/workspace/{workspaceId}) */
+  deleteGrafanaIntegrationsWorkspaceByWorkspaceId(workspaceId: string): Promise<void> {
+    return this.request<void>("DELETE", `/grafana-integrations/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Reset message template (DELETE /localization/overrides/{locale}/{key}) */
+  deleteLocalizationOverridesByLocaleByKey(locale: string, key: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("DELETE", `/localization/overrides/${encodeURIComponent(String(locale))}/${encodeURIComponent(String(key))}`, undefined, undefined);
+  }
+
+  /** Delete a notifier (DELETE /notifiers/{id}) */
+  deleteNotifiersById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/notifiers/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Reset event severity (DELETE /notifiers/events/workspace/{workspaceId}/{eventType}) */
+  deleteNotifiersEventsWorkspaceByWorkspaceIdByEventType(workspaceId: string, eventType: string): Promise<void> {
+    return this.request<void>("DELETE", `/notifiers/events/workspace/${encodeURIComponent(String(workspaceId))}/${encodeURIComponent(String(eventType))}`, undefined, undefined);
+  }
+
+  /** Delete a refresh job (DELETE /refresh-jobs/{id}) */
+  deleteRefreshJobsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/refresh-jobs/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete status page (DELETE /status-pages/workspace/{workspaceId}) */
+  deleteStatusPagesWorkspaceByWorkspaceId(workspaceId: string): Promise<void> {
+    return this.request<void>("DELETE", `/status-pages/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Delete storage mirror group (DELETE /storage-mirror-groups/{id}) */
+  deleteStorageMirrorGroupsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/storage-mirror-groups/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete a storage (DELETE /storages/{id}) */
+  deleteStoragesById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/storages/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete ticket integration (DELETE /ticket-integrations/workspace/{workspaceId}) */
+  deleteTicketIntegrationsWorkspaceByWorkspaceId(workspaceId: string): Promise<void> {
+    return this.request<void>("DELETE", `/ticket-integrations/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Remove a favorite (DELETE /users/me/favorites/{resourceId}) */
+  deleteUsersMeFavoritesByResourceId(resourceId: string, params?: DeleteUsersMeFavoritesByResourceIdParams): Promise<void> {
+    return this.request<void>("DELETE", `/users/me/favorites/${encodeURIComponent(String(resourceId))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Delete a saved view (DELETE /users/me/views/{id}) */
+  deleteUsersMeViewsById(id: string): Promise<void> {
+    return this.request<void>("DELETE", `/users/me/views/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Delete workspace (DELETE /workspaces/{id}) */
+  deleteWorkspacesById(id: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("DELETE", `/workspaces/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Remove member from workspace (DELETE /workspaces/memberships/{id}/members/{userId}) */
+  deleteWorkspacesMembershipsByIdMembersByUserId(id: string, userId: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("DELETE", `/workspaces/memberships/${encodeURIComponent(String(id))}/members/${encodeURIComponent(String(userId))}`, undefined, undefined);
+  }
+
+  /** Get agents (GET /agents) */
+  getAgents(params?: GetAgentsParams): Promise<Array<AgentsAgent>> {
+    return this.request<Array<AgentsAgent>>("GET", "/agents", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Receive backup jobs (agent) (GET /agents/connect) */
+  async getAgentsConnect(): Promise<Blob> {
+    const response = await this.send("GET", "/agents/connect", undefined, undefined);
+    return response.blob();
+  }
+
+  /** Get global audit logs (ADMIN only) (GET /audit-logs/global) */
+  getAuditLogsGlobal(params?: GetAuditLogsGlobalParams): Promise<AuditLogsGetAuditLogsResponse> {
+    return this.request<AuditLogsGetAuditLogsResponse>("GET", "/audit-logs/global", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get user audit logs (GET /audit-logs/users/{userId}) */
+  getAuditLogsUsersByUserId(userId: string, params?: GetAuditLogsUsersByUserIdParams): Promise<AuditLogsGetAuditLogsResponse> {
+    return this.request<AuditLogsGetAuditLogsResponse>("GET", `/audit-logs/users/${encodeURIComponent(String(userId))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get backup badge (GET /backup-badges/database/{databaseId}) */
+  getBackupBadgesDatabaseByDatabaseId(databaseId: string): Promise<BackupsBadgesBackupBadge> {
+    return this.request<BackupsBadgesBackupBadge>("GET", `/backup-badges/database/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Get public backup badge (GET /backup-badges/public/{token}) */
+  async getBackupBadgesPublicByToken(token: string, params?: GetBackupBadgesPublicByTokenParams): Promise<Blob> {
+    const response = await this.send("GET", `/backup-badges/public/${encodeURIComponent(String(token))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+    return response.blob();
+  }
+
+  /** Get concurrency groups (GET /backup-configs/concurrency-groups/workspace/{workspaceId}) */
+  getBackupConfigsConcurrencyGroupsWorkspaceByWorkspaceId(workspaceId: string): Promise<Array<BackupsConfigConcurrencyGroup>> {
+    return this.request<Array<BackupsConfigConcurrencyGroup>>("GET", `/backup-configs/concurrency-groups/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get backup configuration by database ID (GET /backup-configs/database/{id}) */
+  getBackupConfigsDatabaseById(id: string): Promise<BackupsConfigBackupConfig> {
+    return this.request<BackupsConfigBackupConfig>("GET", `/backup-configs/database/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Get database plan by database ID (GET /backup-configs/database/{id}/plan) */
+  getBackupConfigsDatabaseByIdPlan(id: string): Promise<PlansDatabasePlan> {
+    return this.request<PlansDatabasePlan>("GET", `/backup-configs/database/${encodeURIComponent(String(id))}/plan`, undefined, undefined);
+  }
+
+  /** Get transfer history of database (GET /backup-configs/database/{id}/transfers) */
+  getBackupConfigsDatabaseByIdTransfers(id: string): Promise<Array<BackupsConfigDatabaseTransfer>> {
+    return this.request<Array<BackupsConfigDatabaseTransfer>>("GET", `/backup-configs/database/${encodeURIComponent(String(id))}/transfers`, undefined, undefined);
+  }
+
+  /** Count databases using a storage (GET /backup-configs/storage/{id}/databases-count) */
+  getBackupConfigsStorageByIdDatabasesCount(id: string): Promise<Record<string, number>> {
+    return this.request<Record<string, number>>("GET", `/backup-configs/storage/${encodeURIComponent(String(id))}/databases-count`, undefined, undefined);
+  }
+
+  /** Check if storage is being used (GET /backup-configs/storage/{id}/is-using) */
+  getBackupConfigsStorageByIdIsUsing(id: string): Promise<Record<string, boolean>> {
+    return this.request<Record<string, boolean>>("GET", `/backup-configs/storage/${encodeURIComponent(String(id))}/is-using`, undefined, undefined);
+  }
+
+  /** Get backup trigger (GET /backup-triggers/database/{databaseId}) */
+  getBackupTriggersDatabaseByDatabaseId(databaseId: string): Promise<BackupsTriggersBackupTrigger> {
+    return this.request<BackupsTriggersBackupTrigger>("GET", `/backup-triggers/database/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Get backups for a database (GET /backups) */
+  getBackups(params?: GetBackupsParams): Promise<BackupsGetBackupsResponse> {
+    return this.request<BackupsGetBackupsResponse>("GET", "/backups", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Download a backup file (GET /backups/{id}/file) */
+  async getBackupsByIdFile(id: string, params?: GetBackupsByIdFileParams): Promise<Blob> {
+    const response = await this.send("GET", `/backups/${encodeURIComponent(String(id))}/file`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+    return response.blob();
+  }
+
+  /** Get run log of a backup (GET /backups/{id}/log) */
+  getBackupsByIdLog(id: string): Promise<BackupsCoreBackupRunLog> {
+    return this.request<BackupsCoreBackupRunLog>("GET", `/backups/${encodeURIComponent(String(id))}/log`, undefined, undefined);
+  }
+
+  /** Get backup replicas (GET /backups/{id}/replicas) */
+  getBackupsByIdReplicas(id: string): Promise<Array<StoragesMirrorsBackupReplica>> {
+    return this.request<Array<StoragesMirrorsBackupReplica>>("GET", `/backups/${encodeURIComponent(String(id))}/replicas`, undefined, undefined);
+  }
+
+  /** Estimate restore duration of a backup (GET /backups/{id}/restore-estimate) */
+  getBackupsByIdRestoreEstimate(id: string): Promise<RestoresCoreRestoreEstimate> {
+    return this.request<RestoresCoreRestoreEstimate>("GET", `/backups/${encodeURIComponent(String(id))}/restore-estimate`, undefined, undefined);
+  }
+
+  /** Get share links of a backup (GET /backups/{id}/share-links) */
+  getBackupsByIdShareLinks(id: string): Promise<Array<BackupsDownloadBackupShareLink>> {
+    return this.request<Array<BackupsDownloadBackupShareLink>>("GET", `/backups/${encodeURIComponent(String(id))}/share-links`, undefined, undefined);
+  }
+
+  /** Get backups that exhausted retries (GET /backups/dead-letters) */
+  getBackupsDeadLetters(params?: GetBackupsDeadLettersParams): Promise<Array<BackupsCoreBackupDeadLetter>> {
+    return this.request<Array<BackupsCoreBackupDeadLetter>>("GET", "/backups/dead-letters", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Stream logs of a backup job (GET /backups/jobs/{id}/logs) */
+  async getBackupsJobsByIdLogs(id: string, params?: GetBackupsJobsByIdLogsParams): Promise<Blob> {
+    const response = await this.send("GET", `/backups/jobs/${encodeURIComponent(String(id))}/logs`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+    return response.blob();
+  }
+
+  /** Get projected load of backup nodes (GET /backups/schedule-projection) */
+  getBackupsScheduleProjection(): Promise<BackupingScheduleLoadProjection> {
+    return this.request<BackupingScheduleLoadProjection>("GET", "/backups/schedule-projection", undefined, undefined);
+  }
+
+  /** Get backups shared with the current user (GET /backups/share-links/received) */
+  getBackupsShareLinksReceived(): Promise<Array<BackupsDownloadBackupShareLink>> {
+    return this.request<Array<BackupsDownloadBackupShareLink>>("GET", "/backups/share-links/received", undefined, undefined);
+  }
+
+  /** Get plans (GET /billing/plans) */
+  getBillingPlans(): Promise<Array<BillingSubscriptionsPlanQuota>> {
+    return this.request<Array<BillingSubscriptionsPlanQuota>>("GET", "/billing/plans", undefined, undefined);
+  }
+
+  /** Get workspace subscription (GET /billing/subscriptions/workspaces/{workspaceId}) */
+  getBillingSubscriptionsWorkspacesByWorkspaceId(workspaceId: string): Promise<BillingSubscriptionsGetWorkspaceSubscriptionResponse> {
+    return this.request<BillingSubscriptionsGetWorkspaceSubscriptionResponse>("GET", `/billing/subscriptions/workspaces/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Export usage records (ADMIN only) (GET /billing/usage/records) */
+  getBillingUsageRecords(params?: GetBillingUsageRecordsParams): Promise<BillingUsageExportUsageRecordsResponse> {
+    return this.request<BillingUsageExportUsageRecordsResponse>("GET", "/billing/usage/records", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get workspace usage (GET /billing/usage/workspaces/{workspaceId}) */
+  getBillingUsageWorkspacesByWorkspaceId(workspaceId: string, params?: GetBillingUsageWorkspacesByWorkspaceIdParams): Promise<BillingUsageGetWorkspaceUsageResponse> {
+    return this.request<BillingUsageGetWorkspaceUsageResponse>("GET", `/billing/usage/workspaces/${encodeURIComponent(String(workspaceId))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get broadcasts (GET /broadcasts) */
+  getBroadcasts(): Promise<Array<NotifiersBroadcastsBroadcast>> {
+    return this.request<Array<NotifiersBroadcastsBroadcast>>("GET", "/broadcasts", undefined, undefined);
+  }
+
+  /** Get broadcast deliveries (GET /broadcasts/{id}/deliveries) */
+  getBroadcastsByIdDeliveries(id: string): Promise<Array<NotifiersBroadcastsBroadcastDelivery>> {
+    return this.request<Array<NotifiersBroadcastsBroadcastDelivery>>("GET", `/broadcasts/${encodeURIComponent(String(id))}/deliveries`, undefined, undefined);
+  }
+
+  /** Get public calendar (GET /calendar-feeds/public/{token}) */
+  async getCalendarFeedsPublicByToken(token: string): Promise<Blob> {
+    const response = await this.send("GET", `/calendar-feeds/public/${encodeURIComponent(String(token))}`, undefined, undefined);
+    return response.blob();
+  }
+
+  /** Get calendar feed (GET /calendar-feeds/workspace/{workspaceId}) */
+  getCalendarFeedsWorkspaceByWorkspaceId(workspaceId: string): Promise<BackupsCalendarsCalendarFeed> {
+    return this.request<BackupsCalendarsCalendarFeed>("GET", `/calendar-feeds/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get linked chat accounts (GET /chatops/accounts) */
+  getChatopsAccounts(): Promise<Array<ChatopsChatAccount>> {
+    return this.request<Array<ChatopsChatAccount>>("GET", "/chatops/accounts", undefined, undefined);
+  }
+
+  /** Get client certificates (GET /client-certificates) */
+  getClientCertificates(): Promise<Array<ClientCertificatesClientCertificate>> {
+    return this.request<Array<ClientCertificatesClientCertificate>>("GET", "/client-certificates", undefined, undefined);
+  }
+
+  /** Get comments of a resource (GET /comments) */
+  getComments(params?: GetCommentsParams): Promise<Array<CommentsCommentDTO>> {
+    return this.request<Array<CommentsCommentDTO>>("GET", "/comments", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get connection templates (GET /connection-templates) */
+  getConnectionTemplates(): Promise<Array<DatabasesTemplatesConnectionTemplateResponse>> {
+    return this.request<Array<DatabasesTemplatesConnectionTemplateResponse>>("GET", "/connection-templates", undefined, undefined);
+  }
+
+  /** Get expiring credentials (GET /credential-expiry/workspace/{workspaceId}) */
+  getCredentialExpiryWorkspaceByWorkspaceId(workspaceId: string, params?: GetCredentialExpiryWorkspaceByWorkspaceIdParams): Promise<CredentialExpiryExpiringCredentialsResponse> {
+    return this.request<CredentialExpiryExpiringCredentialsResponse>("GET", `/credential-expiry/workspace/${encodeURIComponent(String(workspaceId))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get databases by workspace (GET /databases) */
+  getDatabases(params?: GetDatabasesParams): Promise<Array<DatabasesDatabase>> {
+    return this.request<Array<DatabasesDatabase>>("GET", "/databases", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get a database (GET /databases/{id}) */
+  getDatabasesById(id: string): Promise<DatabasesDatabase> {
+    return this.request<DatabasesDatabase>("GET", `/databases/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Count databases using a notifier (GET /databases/notifier/{id}/databases-count) */
+  getDatabasesNotifierByIdDatabasesCount(id: string): Promise<Record<string, number>> {
+    return this.request<Record<string, number>>("GET", `/databases/notifier/${encodeURIComponent(String(id))}/databases-count`, undefined, undefined);
+  }
+
+  /** Check if notifier is being used (GET /databases/notifier/{id}/is-using) */
+  getDatabasesNotifierByIdIsUsing(id: string): Promise<Record<string, boolean>> {
+    return this.request<Record<string, boolean>>("GET", `/databases/notifier/${encodeURIComponent(String(id))}/is-using`, undefined, undefined);
+  }
+
+  /** Get disk usage information (GET /disk/usage) */
+  getDiskUsage(): Promise<DiskDiskUsage> {
+    return this.request<DiskDiskUsage>("GET", "/disk/usage", undefined, undefined);
+  }
+
+  /** Get feature flags (GET /features) */
+  getFeatures(params?: GetFeaturesParams): Promise<FeatureFlagsGetFeaturesResponse> {
+    return this.request<FeatureFlagsGetFeaturesResponse>("GET", "/features", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get Grafana integration (GET /grafana-integrations/workspace/{workspaceId}) */
+  getGrafanaIntegrationsWorkspaceByWorkspaceId(workspaceId: string): Promise<BackupsGrafanaGrafanaIntegration> {
+    return this.request<BackupsGrafanaGrafanaIntegration>("GET", `/grafana-integrations/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get Grafana dashboard (GET /grafana-integrations/workspace/{workspaceId}/dashboard) */
+  getGrafanaIntegrationsWorkspaceByWorkspaceIdDashboard(workspaceId: string): Promise<Record<string, unknown>> {
+    return this.request<Record<string, unknown>>("GET", `/grafana-integrations/workspace/${encodeURIComponent(String(workspaceId))}/dashboard`, undefined, undefined);
+  }
+
+  /** Get healthcheck attempts by database (GET /healthcheck-attempts/{databaseId}) */
+  getHealthcheckAttemptsByDatabaseId(databaseId: string, params?: GetHealthcheckAttemptsByDatabaseIdParams): Promise<Array<HealthcheckAttemptHealthcheckAttempt>> {
+    return this.request<Array<HealthcheckAttemptHealthcheckAttempt>>("GET", `/healthcheck-attempts/${encodeURIComponent(String(databaseId))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get healthcheck configuration (GET /healthcheck-config/{databaseId}) */
+  getHealthcheckConfigByDatabaseId(databaseId: string): Promise<HealthcheckConfigHealthcheckConfig> {
+    return this.request<HealthcheckConfigHealthcheckConfig>("GET", `/healthcheck-config/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Get message templates (GET /localization/messages) */
+  getLocalizationMessages(params?: GetLocalizationMessagesParams): Promise<LocalizationGetMessagesResponse> {
+    return this.request<LocalizationGetMessagesResponse>("GET", "/localization/messages", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get masking rules (GET /masking-rules/{databaseId}) */
+  getMaskingRulesByDatabaseId(databaseId: string): Promise<Array<MaskingMaskingRule>> {
+    return this.request<Array<MaskingMaskingRule>>("GET", `/masking-rules/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Get all notifiers (GET /notifiers) */
+  getNotifiers(params?: GetNotifiersParams): Promise<Array<NotifiersNotifierResponse>> {
+    return this.request<Array<NotifiersNotifierResponse>>("GET", "/notifiers", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get a notifier by ID (GET /notifiers/{id}) */
+  getNotifiersById(id: string): Promise<NotifiersNotifierResponse> {
+    return this.request<NotifiersNotifierResponse>("GET", `/notifiers/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Get notifier incidents (GET /notifiers/{id}/incidents) */
+  getNotifiersByIdIncidents(id: string): Promise<Array<NotifiersNotifierIncident>> {
+    return this.request<Array<NotifiersNotifierIncident>>("GET", `/notifiers/${encodeURIComponent(String(id))}/incidents`, undefined, undefined);
+  }
+
+  /** Get notification events (GET /notifiers/events) */
+  getNotifiersEvents(): Promise<Array<NotifiersNotificationEvent>> {
+    return this.request<Array<NotifiersNotificationEvent>>("GET", "/notifiers/events", undefined, undefined);
+  }
+
+  /** Get event severities of a workspace (GET /notifiers/events/workspace/{workspaceId}) */
+  getNotifiersEventsWorkspaceByWorkspaceId(workspaceId: string): Promise<Array<NotifiersEventSeverityResponse>> {
+    return this.request<Array<NotifiersEventSeverityResponse>>("GET", `/notifiers/events/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get orphaned resources (GET /ownership/workspace/{workspaceId}/orphaned) */
+  getOwnershipWorkspaceByWorkspaceIdOrphaned(workspaceId: string): Promise<OwnershipOrphansOrphanedResourcesResponse> {
+    return this.request<OwnershipOrphansOrphanedResourcesResponse>("GET", `/ownership/workspace/${encodeURIComponent(String(workspaceId))}/orphaned`, undefined, undefined);
+  }
+
+  /** Get refresh runs (GET /refresh-jobs/{id}/runs) */
+  getRefreshJobsByIdRuns(id: string): Promise<Array<RestoresRefreshesRefreshRun>> {
+    return this.request<Array<RestoresRefreshesRefreshRun>>("GET", `/refresh-jobs/${encodeURIComponent(String(id))}/runs`, undefined, undefined);
+  }
+
+  /** Get refresh jobs (GET /refresh-jobs/database/{databaseId}) */
+  getRefreshJobsDatabaseByDatabaseId(databaseId: string): Promise<Array<RestoresRefreshesRefreshJob>> {
+    return this.request<Array<RestoresRefreshesRefreshJob>>("GET", `/refresh-jobs/database/${encodeURIComponent(String(databaseId))}`, undefined, undefined);
+  }
+
+  /** Get a restore plan (GET /restore-plans/{id}) */
+  getRestorePlansById(id: string): Promise<RestoresPlansRestorePlan> {
+    return this.request<RestoresPlansRestorePlan>("GET", `/restore-plans/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Get restore plans (GET /restore-plans/workspace/{workspaceId}) */
+  getRestorePlansWorkspaceByWorkspaceId(workspaceId: string): Promise<Array<RestoresPlansRestorePlan>> {
+    return this.request<Array<RestoresPlansRestorePlan>>("GET", `/restore-plans/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get restores for a backup (GET /restores/{backupId}) */
+  getRestoresByBackupId(backupId: string): Promise<Array<RestoresCoreRestore>> {
+    return this.request<Array<RestoresCoreRestore>>("GET", `/restores/${encodeURIComponent(String(backupId))}`, undefined, undefined);
+  }
+
+  /** Get disaster recovery runbook (GET /runbooks/workspace/{workspaceId}) */
+  getRunbooksWorkspaceByWorkspaceId(workspaceId: string, params?: GetRunbooksWorkspaceByWorkspaceIdParams): Promise<BackupsRunbooksDRRunbook> {
+    return this.request<BackupsRunbooksDRRunbook>("GET", `/runbooks/workspace/${encodeURIComponent(String(workspaceId))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get security event settings (GET /security-events/settings) */
+  getSecurityEventsSettings(): Promise<NotifiersSecurityEventsSecurityEventSettings> {
+    return this.request<NotifiersSecurityEventsSecurityEventSettings>("GET", "/security-events/settings", undefined, undefined);
+  }
+
+  /** Get public status page (GET /status-pages/public/{token}) */
+  getStatusPagesPublicByToken(token: string): Promise<BackupsStatusPagesPublicStatusPage> {
+    return this.request<BackupsStatusPagesPublicStatusPage>("GET", `/status-pages/public/${encodeURIComponent(String(token))}`, undefined, undefined);
+  }
+
+  /** Get status page (GET /status-pages/workspace/{workspaceId}) */
+  getStatusPagesWorkspaceByWorkspaceId(workspaceId: string): Promise<BackupsStatusPagesStatusPage> {
+    return this.request<BackupsStatusPagesStatusPage>("GET", `/status-pages/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get storage mirror groups (GET /storage-mirror-groups) */
+  getStorageMirrorGroups(params?: GetStorageMirrorGroupsParams): Promise<Array<StoragesMirrorsStorageMirrorGroup>> {
+    return this.request<Array<StoragesMirrorsStorageMirrorGroup>>("GET", "/storage-mirror-groups", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get all storages (GET /storages) */
+  getStorages(params?: GetStoragesParams): Promise<Array<StoragesStorageResponse>> {
+    return this.request<Array<StoragesStorageResponse>>("GET", "/storages", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get a storage by ID (GET /storages/{id}) */
+  getStoragesById(id: string): Promise<StoragesStorageResponse> {
+    return this.request<StoragesStorageResponse>("GET", `/storages/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Get backups of a storage (GET /storages/{id}/backups) */
+  getStoragesByIdBackups(id: string, params?: GetStoragesByIdBackupsParams): Promise<BackupsGetStorageBackupsResponse> {
+    return this.request<BackupsGetStorageBackupsResponse>("GET", `/storages/${encodeURIComponent(String(id))}/backups`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Browse storage files (GET /storages/{id}/browse) */
+  getStoragesByIdBrowse(id: string, params?: GetStoragesByIdBrowseParams): Promise<StoragesBrowseStorageResponse> {
+    return this.request<StoragesBrowseStorageResponse>("GET", `/storages/${encodeURIComponent(String(id))}/browse`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Preview storage deletion impact (GET /storages/{id}/impact) */
+  getStoragesByIdImpact(id: string): Promise<StoragesImpactStorageImpact> {
+    return this.request<StoragesImpactStorageImpact>("GET", `/storages/${encodeURIComponent(String(id))}/impact`, undefined, undefined);
+  }
+
+  /** Get storage usage (GET /storages/{id}/usage) */
+  getStoragesByIdUsage(id: string): Promise<StoragesUsageGetStorageUsageResponse> {
+    return this.request<StoragesUsageGetStorageUsageResponse>("GET", `/storages/${encodeURIComponent(String(id))}/usage`, undefined, undefined);
+  }
+
+  /** Get storage plugins (GET /storages/plugins) */
+  getStoragesPlugins(): Promise<Array<string>> {
+    return this.request<Array<string>>("GET", "/storages/plugins", undefined, undefined);
+  }
+
+  /** Get default storage of a workspace (GET /storages/workspace/{workspaceId}/default) */
+  getStoragesWorkspaceByWorkspaceIdDefault(workspaceId: string): Promise<StoragesStorageResponse> {
+    return this.request<StoragesStorageResponse>("GET", `/storages/workspace/${encodeURIComponent(String(workspaceId))}/default`, undefined, undefined);
+  }
+
+  /** Get branding (GET /system/branding) */
+  getSystemBranding(): Promise<UsersModelsBrandingSettings> {
+    return this.request<UsersModelsBrandingSettings>("GET", "/system/branding", undefined, undefined);
+  }
+
+  /** Get reloadable settings (GET /system/config) */
+  getSystemConfig(): Promise<SystemSettingsReloadableSettingsResponse> {
+    return this.request<SystemSettingsReloadableSettingsResponse>("GET", "/system/config", undefined, undefined);
+  }
+
+  /** Capture CPU profile (GET /system/debug/cpu) */
+  async getSystemDebugCpu(params?: GetSystemDebugCpuParams): Promise<Blob> {
+    const response = await this.send("GET", "/system/debug/cpu", params as unknown as Record<string, QueryValue> | undefined, undefined);
+    return response.blob();
+  }
+
+  /** Dump goroutines (GET /system/debug/goroutines) */
+  async getSystemDebugGoroutines(): Promise<Blob> {
+    const response = await this.send("GET", "/system/debug/goroutines", undefined, undefined);
+    return response.blob();
+  }
+
+  /** List runtime profiles (GET /system/debug/profiles) */
+  getSystemDebugProfiles(): Promise<SystemDebugProfilesResponse> {
+    return this.request<SystemDebugProfilesResponse>("GET", "/system/debug/profiles", undefined, undefined);
+  }
+
+  /** Download runtime profile (GET /system/debug/profiles/{name}) */
+  async getSystemDebugProfilesByName(name: string, params?: GetSystemDebugProfilesByNameParams): Promise<Blob> {
+    const response = await this.send("GET", `/system/debug/profiles/${encodeURIComponent(String(name))}`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+    return response.blob();
+  }
+
+  /** Get runtime stats (GET /system/debug/runtime) */
+  getSystemDebugRuntime(): Promise<SystemDebugRuntimeStatsResponse> {
+    return this.request<SystemDebugRuntimeStatsResponse>("GET", "/system/debug/runtime", undefined, undefined);
+  }
+
+  /** Capture execution trace (GET /system/debug/trace) */
+  async getSystemDebugTrace(params?: GetSystemDebugTraceParams): Promise<Blob> {
+    const response = await this.send("GET", "/system/debug/trace", params as unknown as Record<string, QueryValue> | undefined, undefined);
+    return response.blob();
+  }
+
+  /** Check system health (GET /system/health) */
+  getSystemHealth(): Promise<SystemHealthcheckHealthcheckResponse> {
+    return this.request<SystemHealthcheckHealthcheckResponse>("GET", "/system/health", undefined, undefined);
+  }
+
+  /** Get metadata backups (GET /system/metadata-backups) */
+  getSystemMetadataBackups(): Promise<SystemMetadataBackupGetMetadataBackupsResponse> {
+    return this.request<SystemMetadataBackupGetMetadataBackupsResponse>("GET", "/system/metadata-backups", undefined, undefined);
+  }
+
+  /** Get signing public key (GET /system/signing-key) */
+  getSystemSigningKey(): Promise<SigningPublicKeyResponse> {
+    return this.request<SigningPublicKeyResponse>("GET", "/system/signing-key", undefined, undefined);
+  }
+
+  /** Get version (GET /system/version) */
+  getSystemVersion(): Promise<SystemVersionGetVersionResponse> {
+    return this.request<SystemVersionGetVersionResponse>("GET", "/system/version", undefined, undefined);
+  }
+
+  /** Get ticket integration (GET /ticket-integrations/workspace/{workspaceId}) */
+  getTicketIntegrationsWorkspaceByWorkspaceId(workspaceId: string): Promise<NotifiersTicketsTicketIntegration> {
+    return this.request<NotifiersTicketsTicketIntegration>("GET", `/ticket-integrations/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, undefined);
+  }
+
+  /** Get tickets (GET /ticket-integrations/workspace/{workspaceId}/tickets) */
+  getTicketIntegrationsWorkspaceByWorkspaceIdTickets(workspaceId: string): Promise<Array<NotifiersTicketsTicket>> {
+    return this.request<Array<NotifiersTicketsTicket>>("GET", `/ticket-integrations/workspace/${encodeURIComponent(String(workspaceId))}/tickets`, undefined, undefined);
+  }
+
+  /** List users (GET /users) */
+  getUsers(params?: GetUsersParams): Promise<UsersDtoListUsersResponseDTO> {
+    return this.request<UsersDtoListUsersResponseDTO>("GET", "/users", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get user profile (GET /users/{id}) */
+  getUsersById(id: string): Promise<UsersDtoUserProfileResponseDTO> {
+    return this.request<UsersDtoUserProfileResponseDTO>("GET", `/users/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Get current user profile (GET /users/me) */
+  getUsersMe(): Promise<UsersDtoUserProfileResponseDTO> {
+    return this.request<UsersDtoUserProfileResponseDTO>("GET", "/users/me", undefined, undefined);
+  }
+
+  /** Get favorites (GET /users/me/favorites) */
+  getUsersMeFavorites(params?: GetUsersMeFavoritesParams): Promise<Array<SavedViewsFavoriteDTO>> {
+    return this.request<Array<SavedViewsFavoriteDTO>>("GET", "/users/me/favorites", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get own preferences (GET /users/me/preferences) */
+  getUsersMePreferences(): Promise<UsersModelsUserPreferences> {
+    return this.request<UsersModelsUserPreferences>("GET", "/users/me/preferences", undefined, undefined);
+  }
+
+  /** Get saved views (GET /users/me/views) */
+  getUsersMeViews(params?: GetUsersMeViewsParams): Promise<Array<SavedViewsSavedView>> {
+    return this.request<Array<SavedViewsSavedView>>("GET", "/users/me/views", params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Get users settings (GET /users/settings) */
+  getUsersSettings(): Promise<UsersModelsUsersSettings> {
+    return this.request<UsersModelsUsersSettings>("GET", "/users/settings", undefined, undefined);
+  }
+
+  /** Get security headers (GET /users/settings/headers) */
+  getUsersSettingsHeaders(): Promise<UsersModelsSecurityHeaders> {
+    return this.request<UsersModelsSecurityHeaders>("GET", "/users/settings/headers", undefined, undefined);
+  }
+
+  /** Get security policy (GET /users/settings/security) */
+  getUsersSettingsSecurity(): Promise<UsersModelsSecurityPolicy> {
+    return this.request<UsersModelsSecurityPolicy>("GET", "/users/settings/security", undefined, undefined);
+  }
+
+  /** List user's workspaces (GET /workspaces) */
+  getWorkspaces(): Promise<WorkspacesDtoListWorkspacesResponseDTO> {
+    return this.request<WorkspacesDtoListWorkspacesResponseDTO>("GET", "/workspaces", undefined, undefined);
+  }
+
+  /** Get workspace details (GET /workspaces/{id}) */
+  getWorkspacesById(id: string): Promise<WorkspacesModelsWorkspace> {
+    return this.request<WorkspacesModelsWorkspace>("GET", `/workspaces/${encodeURIComponent(String(id))}`, undefined, undefined);
+  }
+
+  /** Get workspace audit logs (GET /workspaces/{id}/audit-logs) */
+  getWorkspacesByIdAuditLogs(id: string, params?: GetWorkspacesByIdAuditLogsParams): Promise<AuditLogsGetAuditLogsResponse> {
+    return this.request<AuditLogsGetAuditLogsResponse>("GET", `/workspaces/${encodeURIComponent(String(id))}/audit-logs`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** List workspace members (GET /workspaces/memberships/{id}/members) */
+  getWorkspacesMembershipsByIdMembers(id: string): Promise<WorkspacesDtoGetMembersResponseDTO> {
+    return this.request<WorkspacesDtoGetMembersResponseDTO>("GET", `/workspaces/memberships/${encodeURIComponent(String(id))}/members`, undefined, undefined);
+  }
+
+  /** Create an agent (POST /agents) */
+  postAgents(body: AgentsCreateAgentRequest): Promise<AgentsCreateAgentResponse> {
+    return this.request<AgentsCreateAgentResponse>("POST", "/agents", undefined, body);
+  }
+
+  /** Complete a job (agent) (POST /agents/jobs/{backupId}/complete) */
+  postAgentsJobsByBackupIdComplete(backupId: string, body: AgentsCompleteAgentJobRequest): Promise<void> {
+    return this.request<void>("POST", `/agents/jobs/${encodeURIComponent(String(backupId))}/complete`, undefined, body);
+  }
+
+  /** Upload a dump (agent) (POST /agents/jobs/{backupId}/upload) */
+  postAgentsJobsByBackupIdUpload(backupId: string): Promise<void> {
+    return this.request<void>("POST", `/agents/jobs/${encodeURIComponent(String(backupId))}/upload`, undefined, undefined);
+  }
+
+  /** Handle GitHub OAuth callback (POST /auth/github/callback) */
+  postAuthGithubCallback(body: UsersDtoOAuthCallbackRequestDTO): Promise<UsersDtoOAuthCallbackResponseDTO> {
+    return this.request<UsersDtoOAuthCallbackResponseDTO>("POST", "/auth/github/callback", undefined, body);
+  }
+
+  /** Handle Google OAuth callback (POST /auth/google/callback) */
+  postAuthGoogleCallback(body: UsersDtoOAuthCallbackRequestDTO): Promise<UsersDtoOAuthCallbackResponseDTO> {
+    return this.request<UsersDtoOAuthCallbackResponseDTO>("POST", "/auth/google/callback", undefined, body);
+  }
+
+  /** Generate backup badge token (POST /backup-badges/database/{databaseId}/token) */
+  postBackupBadgesDatabaseByDatabaseIdToken(databaseId: string): Promise<BackupsBadgesBackupBadgeTokenResponse> {
+    return this.request<BackupsBadgesBackupBadgeTokenResponse>("POST", `/backup-badges/database/${encodeURIComponent(String(databaseId))}/token`, undefined, undefined);
+  }
+
+  /** Create concurrency group (POST /backup-configs/concurrency-groups/workspace/{workspaceId}) */
+  postBackupConfigsConcurrencyGroupsWorkspaceByWorkspaceId(workspaceId: string, body: BackupsConfigConcurrencyGroup): Promise<BackupsConfigConcurrencyGroup> {
+    return this.request<BackupsConfigConcurrencyGroup>("POST", `/backup-configs/concurrency-groups/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Pause backup schedule of database (POST /backup-configs/database/{id}/pause) */
+  postBackupConfigsDatabaseByIdPause(id: string, body: BackupsConfigPauseScheduleRequest): Promise<void> {
+    return this.request<void>("POST", `/backup-configs/database/${encodeURIComponent(String(id))}/pause`, undefined, body);
+  }
+
+  /** Resume backup schedule of database (POST /backup-configs/database/{id}/resume) */
+  postBackupConfigsDatabaseByIdResume(id: string): Promise<void> {
+    return this.request<void>("POST", `/backup-configs/database/${encodeURIComponent(String(id))}/resume`, undefined, undefined);
+  }
+
+  /** Transfer database to another workspace (POST /backup-configs/database/{id}/transfer) */
+  postBackupConfigsDatabaseByIdTransfer(id: string, body: BackupsConfigTransferDatabaseRequest): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", `/backup-configs/database/${encodeURIComponent(String(id))}/transfer`, undefined, body);
+  }
+
+  /** Save backup configuration (POST /backup-configs/save) */
+  postBackupConfigsSave(body: BackupsConfigBackupConfig): Promise<BackupsConfigBackupConfig> {
+    return this.request<BackupsConfigBackupConfig>("POST", "/backup-configs/save", undefined, body);
+  }
+
+  /** Assign default storage to workspace databases (POST /backup-configs/workspace/{workspaceId}/assign-default-storage) */
+  postBackupConfigsWorkspaceByWorkspaceIdAssignDefaultStorage(workspaceId: string, body: BackupsConfigAssignDefaultStorageRequest): Promise<BackupsConfigAssignDefaultStorageResponse> {
+    return this.request<BackupsConfigAssignDefaultStorageResponse>("POST", `/backup-configs/workspace/${encodeURIComponent(String(workspaceId))}/assign-default-storage`, undefined, body);
+  }
+
+  /** Pause backup schedules of workspace (POST /backup-configs/workspace/{workspaceId}/pause) */
+  postBackupConfigsWorkspaceByWorkspaceIdPause(workspaceId: string, body: BackupsConfigPauseScheduleRequest): Promise<void> {
+    return this.request<void>("POST", `/backup-configs/workspace/${encodeURIComponent(String(workspaceId))}/pause`, undefined, body);
+  }
+
+  /** Resume backup schedules of workspace (POST /backup-configs/workspace/{workspaceId}/resume) */
+  postBackupConfigsWorkspaceByWorkspaceIdResume(workspaceId: string): Promise<void> {
+    return this.request<void>("POST", `/backup-configs/workspace/${encodeURIComponent(String(workspaceId))}/resume`, undefined, undefined);
+  }
+
+  /** Generate backup trigger token (POST /backup-triggers/database/{databaseId}/token) */
+  postBackupTriggersDatabaseByDatabaseIdToken(databaseId: string): Promise<BackupsTriggersBackupTriggerTokenResponse> {
+    return this.request<BackupsTriggersBackupTriggerTokenResponse>("POST", `/backup-triggers/database/${encodeURIComponent(String(databaseId))}/token`, undefined, undefined);
+  }
+
+  /** Trigger backup (POST /backup-triggers/public/{token}) */
+  postBackupTriggersPublicByToken(token: string, body: BackupsTriggersTriggerBackupRequest): Promise<void> {
+    return this.request<void>("POST", `/backup-triggers/public/${encodeURIComponent(String(token))}`, undefined, body);
+  }
+
+  /** Check deployment gate (POST /backup-triggers/public/{token}/gate) */
+  postBackupTriggersPublicByTokenGate(token: string, body: BackupsTriggersCheckDeploymentGateRequest): Promise<BackupsTriggersDeploymentGateResponse> {
+    return this.request<BackupsTriggersDeploymentGateResponse>("POST", `/backup-triggers/public/${encodeURIComponent(String(token))}/gate`, undefined, body);
+  }
+
+  /** Create a backup (POST /backups) */
+  postBackups(body: BackupsMakeBackupRequest): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/backups", undefined, body);
+  }
+
+  /** Adopt existing backups (POST /backups/adoption/database/{databaseId}/adopt) */
+  postBackupsAdoptionDatabaseByDatabaseIdAdopt(databaseId: string, body: BackupsAdoptionAdoptBackupsRequest): Promise<BackupsAdoptionAdoptBackupsResponse> {
+    return this.request<BackupsAdoptionAdoptBackupsResponse>("POST", `/backups/adoption/database/${encodeURIComponent(String(databaseId))}/adopt`, undefined, body);
+  }
+
+  /** Scan storage for existing backups (POST /backups/adoption/database/{databaseId}/scan) */
+  postBackupsAdoptionDatabaseByDatabaseIdScan(databaseId: string, body: BackupsAdoptionAdoptBackupsRequest): Promise<BackupsAdoptionScanBackupsResponse> {
+    return this.request<BackupsAdoptionScanBackupsResponse>("POST", `/backups/adoption/database/${encodeURIComponent(String(databaseId))}/scan`, undefined, body);
+  }
+
+  /** Cancel an in-progress backup (POST /backups/{id}/cancel) */
+  postBackupsByIdCancel(id: string): Promise<void> {
+    return this.request<void>("POST", `/backups/${encodeURIComponent(String(id))}/cancel`, undefined, undefined);
+  }
+
+  /** Generate short-lived download token (POST /backups/{id}/download-token) */
+  postBackupsByIdDownloadToken(id: string): Promise<BackupsDownloadGenerateDownloadTokenResponse> {
+    return this.request<BackupsDownloadGenerateDownloadTokenResponse>("POST", `/backups/${encodeURIComponent(String(id))}/download-token`, undefined, undefined);
+  }
+
+  /** Share a backup with a workspace member (POST /backups/{id}/share-links) */
+  postBackupsByIdShareLinks(id: string, body: BackupsDownloadCreateShareLinkRequest): Promise<BackupsDownloadBackupShareLink> {
+    return this.request<BackupsDownloadBackupShareLink>("POST", `/backups/${encodeURIComponent(String(id))}/share-links`, undefined, body);
+  }
+
+  /** Requeue a backup that exhausted retries (POST /backups/dead-letters/{id}/requeue) */
+  postBackupsDeadLettersByIdRequeue(id: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", `/backups/dead-letters/${encodeURIComponent(String(id))}/requeue`, undefined, undefined);
+  }
+
+  /** Generate download token of a share link (POST /backups/share-links/{shareLinkId}/download-token) */
+  postBackupsShareLinksByShareLinkIdDownloadToken(shareLinkId: string): Promise<BackupsDownloadGenerateDownloadTokenResponse> {
+    return this.request<BackupsDownloadGenerateDownloadTokenResponse>("POST", `/backups/share-links/${encodeURIComponent(String(shareLinkId))}/download-token`, undefined, undefined);
+  }
+
+  /** Handle Stripe webhook (POST /billing/stripe/webhook) */
+  postBillingStripeWebhook(): Promise<void> {
+    return this.request<void>("POST", "/billing/stripe/webhook", undefined, undefined);
+  }
+
+  /** Send a broadcast (POST /broadcasts) */
+  postBroadcasts(body: NotifiersBroadcastsCreateBroadcastRequest): Promise<NotifiersBroadcastsBroadcast> {
+    return this.request<NotifiersBroadcastsBroadcast>("POST", "/broadcasts", undefined, body);
+  }
+
+  /** Generate calendar feed token (POST /calendar-feeds/workspace/{workspaceId}/token) */
+  postCalendarFeedsWorkspaceByWorkspaceIdToken(workspaceId: string): Promise<BackupsCalendarsCalendarFeedTokenResponse> {
+    return this.request<BackupsCalendarsCalendarFeedTokenResponse>("POST", `/calendar-feeds/workspace/${encodeURIComponent(String(workspaceId))}/token`, undefined, undefined);
+  }
+
+  /** Generate chat link code (POST /chatops/link-code) */
+  postChatopsLinkCode(): Promise<ChatopsLinkCodeResponse> {
+    return this.request<ChatopsLinkCodeResponse>("POST", "/chatops/link-code", undefined, undefined);
+  }
+
+  /** Handle Mattermost slash command (POST /chatops/mattermost/commands) */
+  postChatopsMattermostCommands(): Promise<ChatopsSlashCommandResponse> {
+    return this.request<ChatopsSlashCommandResponse>("POST", "/chatops/mattermost/commands", undefined, undefined);
+  }
+
+  /** Handle Slack slash command (POST /chatops/slack/commands) */
+  postChatopsSlackCommands(): Promise<ChatopsSlashCommandResponse> {
+    return this.request<ChatopsSlashCommandResponse>("POST", "/chatops/slack/commands", undefined, undefined);
+  }
+
+  /** Register client certificate (POST /client-certificates) */
+  postClientCertificates(body: ClientCertificatesRegisterClientCertificateRequest): Promise<ClientCertificatesClientCertificate> {
+    return this.request<ClientCertificatesClientCertificate>("POST", "/client-certificates", undefined, body);
+  }
+
+  /** Add a comment to a resource (POST /comments) */
+  postComments(body: CommentsCreateCommentRequest): Promise<CommentsComment> {
+    return this.request<CommentsComment>("POST", "/comments", undefined, body);
+  }
+
+  /** Save a connection template (POST /connection-templates) */
+  postConnectionTemplates(body: DatabasesTemplatesConnectionTemplate): Promise<DatabasesTemplatesConnectionTemplateResponse> {
+    return this.request<DatabasesTemplatesConnectionTemplateResponse>("POST", "/connection-templates", undefined, body);
+  }
+
+  /** Create a database from a connection template (POST /connection-templates/{id}/databases) */
+  postConnectionTemplatesByIdDatabases(id: string, body: DatabasesTemplatesCreateDatabaseFromTemplateRequest): Promise<DatabasesDatabase> {
+    return this.request<DatabasesDatabase>("POST", `/connection-templates/${encodeURIComponent(String(id))}/databases`, undefined, body);
+  }
+
+  /** Copy a database (POST /databases/{id}/copy) */
+  postDatabasesByIdCopy(id: string): Promise<DatabasesDatabase> {
+    return this.request<DatabasesDatabase>("POST", `/databases/${encodeURIComponent(String(id))}/copy`, undefined, undefined);
+  }
+
+  /** Test database connection (POST /databases/{id}/test-connection) */
+  postDatabasesByIdTestConnection(id: string): Promise<void> {
+    return this.request<void>("POST", `/databases/${encodeURIComponent(String(id))}/test-connection`, undefined, undefined);
+  }
+
+  /** Create a new database (POST /databases/create) */
+  postDatabasesCreate(body: DatabasesDatabase): Promise<DatabasesDatabase> {
+    return this.request<DatabasesDatabase>("POST", "/databases/create", undefined, body);
+  }
+
+  /** Create read-only database user (POST /databases/create-readonly-user) */
+  postDatabasesCreateReadonlyUser(body: DatabasesDatabase): Promise<DatabasesCreateReadOnlyUserResponse> {
+    return this.request<DatabasesCreateReadOnlyUserResponse>("POST", "/databases/create-readonly-user", undefined, body);
+  }
+
+  /** Check if database user is read-only (POST /databases/is-readonly) */
+  postDatabasesIsReadonly(body: DatabasesDatabase): Promise<DatabasesIsReadOnlyResponse> {
+    return this.request<DatabasesIsReadOnlyResponse>("POST", "/databases/is-readonly", undefined, body);
+  }
+
+  /** Attach a notifier to all databases (POST /databases/notifier/{id}/attach-to-all) */
+  postDatabasesNotifierByIdAttachToAll(id: string): Promise<Record<string, number>> {
+    return this.request<Record<string, number>>("POST", `/databases/notifier/${encodeURIComponent(String(id))}/attach-to-all`, undefined, undefined);
+  }
+
+  /** Test database connection directly (POST /databases/test-connection-direct) */
+  postDatabasesTestConnectionDirect(body: DatabasesDatabase): Promise<void> {
+    return this.request<void>("POST", "/databases/test-connection-direct", undefined, body);
+  }
+
+  /** Update a database (POST /databases/update) */
+  postDatabasesUpdate(body: DatabasesDatabase): Promise<DatabasesDatabase> {
+    return this.request<DatabasesDatabase>("POST", "/databases/update", undefined, body);
+  }
+
+  /** Run GraphQL query (POST /graphql) */
+  postGraphql(body: GraphqlGraphQLRequest): Promise<GraphqlGraphQLResponse> {
+    return this.request<GraphqlGraphQLResponse>("POST", "/graphql", undefined, body);
+  }
+
+  /** Save healthcheck configuration (POST /healthcheck-config) */
+  postHealthcheckConfig(body: HealthcheckConfigHealthcheckConfigDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/healthcheck-config", undefined, body);
+  }
+
+  /** Parse config of backup tool (POST /imports/parse) */
+  postImportsParse(body: ImportsParseImportRequest): Promise<ImportsImportPlan> {
+    return this.request<ImportsImportPlan>("POST", "/imports/parse", undefined, body);
+  }
+
+  /** Import databases (POST /imports/workspace/{workspaceId}) */
+  postImportsWorkspaceByWorkspaceId(workspaceId: string, body: ImportsImportPlan): Promise<ImportsImportResult> {
+    return this.request<ImportsImportResult>("POST", `/imports/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Save a notifier (POST /notifiers) */
+  postNotifiers(body: NotifiersNotifier): Promise<NotifiersNotifier> {
+    return this.request<NotifiersNotifier>("POST", "/notifiers", undefined, body);
+  }
+
+  /** Handle incident webhook (POST /notifiers/{id}/incident-webhook) */
+  postNotifiersByIdIncidentWebhook(id: string): Promise<void> {
+    return this.request<void>("POST", `/notifiers/${encodeURIComponent(String(id))}/incident-webhook`, undefined, undefined);
+  }
+
+  /** Send test notification (POST /notifiers/{id}/test) */
+  postNotifiersByIdTest(id: string): Promise<void> {
+    return this.request<void>("POST", `/notifiers/${encodeURIComponent(String(id))}/test`, undefined, undefined);
+  }
+
+  /** Send test event (POST /notifiers/{id}/test-event) */
+  postNotifiersByIdTestEvent(id: string, params?: PostNotifiersByIdTestEventParams): Promise<void> {
+    return this.request<void>("POST", `/notifiers/${encodeURIComponent(String(id))}/test-event`, params as unknown as Record<string, QueryValue> | undefined, undefined);
+  }
+
+  /** Transfer notifier to another workspace (POST /notifiers/{id}/transfer) */
+  postNotifiersByIdTransfer(id: string, body: NotifiersTransferNotifierRequest): Promise<void> {
+    return this.request<void>("POST", `/notifiers/${encodeURIComponent(String(id))}/transfer`, undefined, body);
+  }
+
+  /** Send test notification directly (POST /notifiers/direct-test) */
+  postNotifiersDirectTest(body: NotifiersNotifier): Promise<void> {
+    return this.request<void>("POST", "/notifiers/direct-test", undefined, body);
+  }
+
+  /** Run a refresh job now (POST /refresh-jobs/{id}/run) */
+  postRefreshJobsByIdRun(id: string): Promise<RestoresRefreshesRefreshRun> {
+    return this.request<RestoresRefreshesRefreshRun>("POST", `/refresh-jobs/${encodeURIComponent(String(id))}/run`, undefined, undefined);
+  }
+
+  /** Create a refresh job (POST /refresh-jobs/database/{databaseId}) */
+  postRefreshJobsDatabaseByDatabaseId(databaseId: string, body: RestoresRefreshesRefreshJob): Promise<RestoresRefreshesRefreshJob> {
+    return this.request<RestoresRefreshesRefreshJob>("POST", `/refresh-jobs/database/${encodeURIComponent(String(databaseId))}`, undefined, body);
+  }
+
+  /** Cancel a restore plan (POST /restore-plans/{id}/cancel) */
+  postRestorePlansByIdCancel(id: string): Promise<RestoresPlansRestorePlan> {
+    return this.request<RestoresPlansRestorePlan>("POST", `/restore-plans/${encodeURIComponent(String(id))}/cancel`, undefined, undefined);
+  }
+
+  /** Start a restore plan (POST /restore-plans/workspace/{workspaceId}) */
+  postRestorePlansWorkspaceByWorkspaceId(workspaceId: string, body: RestoresPlansRestorePlan): Promise<RestoresPlansRestorePlan> {
+    return this.request<RestoresPlansRestorePlan>("POST", `/restore-plans/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Check a restore of a backup (POST /restores/{backupId}/check) */
+  postRestoresByBackupIdCheck(backupId: string): Promise<RestoresCorePreRestoreReport> {
+    return this.request<RestoresCorePreRestoreReport>("POST", `/restores/${encodeURIComponent(String(backupId))}/check`, undefined, undefined);
+  }
+
+  /** Restore a backup (POST /restores/{backupId}/restore) */
+  postRestoresByBackupIdRestore(backupId: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", `/restores/${encodeURIComponent(String(backupId))}/restore`, undefined, undefined);
+  }
+
+  /** Cancel an in-progress restore (POST /restores/cancel/{restoreId}) */
+  postRestoresCancelByRestoreId(restoreId: string): Promise<void> {
+    return this.request<void>("POST", `/restores/cancel/${encodeURIComponent(String(restoreId))}`, undefined, undefined);
+  }
+
+  /** Rotate status page token (POST /status-pages/workspace/{workspaceId}/rotate-token) */
+  postStatusPagesWorkspaceByWorkspaceIdRotateToken(workspaceId: string): Promise<BackupsStatusPagesStatusPageTokenResponse> {
+    return this.request<BackupsStatusPagesStatusPageTokenResponse>("POST", `/status-pages/workspace/${encodeURIComponent(String(workspaceId))}/rotate-token`, undefined, undefined);
+  }
+
+  /** Create storage mirror group (POST /storage-mirror-groups) */
+  postStorageMirrorGroups(body: StoragesMirrorsCreateMirrorGroupRequest): Promise<StoragesMirrorsStorageMirrorGroup> {
+    return this.request<StoragesMirrorsStorageMirrorGroup>("POST", "/storage-mirror-groups", undefined, body);
+  }
+
+  /** Save a storage (POST /storages) */
+  postStorages(body: StoragesStorage): Promise<StoragesStorage> {
+    return this.request<StoragesStorage>("POST", "/storages", undefined, body);
+  }
+
+  /** Benchmark storage (POST /storages/{id}/benchmark) */
+  postStoragesByIdBenchmark(id: string, body: StoragesBenchmarkStorageRequest): Promise<StoragesStorageBenchmarkResult> {
+    return this.request<StoragesStorageBenchmarkResult>("POST", `/storages/${encodeURIComponent(String(id))}/benchmark`, undefined, body);
+  }
+
+  /** Test storage connection (POST /storages/{id}/test) */
+  postStoragesByIdTest(id: string): Promise<void> {
+    return this.request<void>("POST", `/storages/${encodeURIComponent(String(id))}/test`, undefined, undefined);
+  }
+
+  /** Transfer storage to another workspace (POST /storages/{id}/transfer) */
+  postStoragesByIdTransfer(id: string, body: StoragesTransferStorageRequest): Promise<void> {
+    return this.request<void>("POST", `/storages/${encodeURIComponent(String(id))}/transfer`, undefined, body);
+  }
+
+  /** Test storage connection directly (POST /storages/direct-test) */
+  postStoragesDirectTest(body: StoragesStorage): Promise<void> {
+    return this.request<void>("POST", "/storages/direct-test", undefined, body);
+  }
+
+  /** Reload settings (POST /system/config/reload) */
+  postSystemConfigReload(): Promise<SystemSettingsReloadableSettingsResponse> {
+    return this.request<SystemSettingsReloadableSettingsResponse>("POST", "/system/config/reload", undefined, undefined);
+  }
+
+  /** Force garbage collection (POST /system/debug/gc) */
+  postSystemDebugGc(): Promise<SystemDebugRuntimeStatsResponse> {
+    return this.request<SystemDebugRuntimeStatsResponse>("POST", "/system/debug/gc", undefined, undefined);
+  }
+
+  /** Make metadata backup (POST /system/metadata-backups) */
+  postSystemMetadataBackups(): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/system/metadata-backups", undefined, undefined);
+  }
+
+  /** Activate user (POST /users/{id}/activate) */
+  postUsersByIdActivate(id: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", `/users/${encodeURIComponent(String(id))}/activate`, undefined, undefined);
+  }
+
+  /** Deactivate user (POST /users/{id}/deactivate) */
+  postUsersByIdDeactivate(id: string): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", `/users/${encodeURIComponent(String(id))}/deactivate`, undefined, undefined);
+  }
+
+  /** Invite a new user (POST /users/invite) */
+  postUsersInvite(body: UsersDtoInviteUserRequestDTO): Promise<UsersDtoInviteUserResponseDTO> {
+    return this.request<UsersDtoInviteUserResponseDTO>("POST", "/users/invite", undefined, body);
+  }
+
+  /** Add a favorite (POST /users/me/favorites) */
+  postUsersMeFavorites(body: SavedViewsAddFavoriteRequest): Promise<void> {
+    return this.request<void>("POST", "/users/me/favorites", undefined, body);
+  }
+
+  /** Send email verification link (POST /users/me/send-verification-email) */
+  postUsersMeSendVerificationEmail(): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/users/me/send-verification-email", undefined, undefined);
+  }
+
+  /** Save a view (POST /users/me/views) */
+  postUsersMeViews(body: SavedViewsSaveViewRequest): Promise<SavedViewsSavedView> {
+    return this.request<SavedViewsSavedView>("POST", "/users/me/views", undefined, body);
+  }
+
+  /** Reset password with code (POST /users/reset-password) */
+  postUsersResetPassword(body: UsersDtoResetPasswordRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/users/reset-password", undefined, body);
+  }
+
+  /** Reset password with link token (POST /users/reset-password-by-token) */
+  postUsersResetPasswordByToken(body: UsersDtoResetPasswordByTokenRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/users/reset-password-by-token", undefined, body);
+  }
+
+  /** Send password reset code (POST /users/send-reset-password-code) */
+  postUsersSendResetPasswordCode(body: UsersDtoSendResetPasswordCodeRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/users/send-reset-password-code", undefined, body);
+  }
+
+  /** Authenticate a user (POST /users/signin) */
+  postUsersSignin(body: UsersDtoSignInRequestDTO): Promise<UsersDtoSignInResponseDTO> {
+    return this.request<UsersDtoSignInResponseDTO>("POST", "/users/signin", undefined, body);
+  }
+
+  /** Register a new user (POST /users/signup) */
+  postUsersSignup(body: UsersDtoSignUpRequestDTO): Promise<void> {
+    return this.request<void>("POST", "/users/signup", undefined, body);
+  }
+
+  /** Verify email (POST /users/verify-email) */
+  postUsersVerifyEmail(body: UsersDtoVerifyEmailRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", "/users/verify-email", undefined, body);
+  }
+
+  /** Create a new workspace (POST /workspaces) */
+  postWorkspaces(body: WorkspacesDtoCreateWorkspaceRequestDTO): Promise<WorkspacesDtoWorkspaceResponseDTO> {
+    return this.request<WorkspacesDtoWorkspaceResponseDTO>("POST", "/workspaces", undefined, body);
+  }
+
+  /** Add member to workspace (supports both existing and new users) (POST /workspaces/memberships/{id}/members) */
+  postWorkspacesMembershipsByIdMembers(id: string, body: WorkspacesDtoAddMemberRequestDTO): Promise<WorkspacesDtoAddMemberResponseDTO> {
+    return this.request<WorkspacesDtoAddMemberResponseDTO>("POST", `/workspaces/memberships/${encodeURIComponent(String(id))}/members`, undefined, body);
+  }
+
+  /** Transfer workspace ownership (POST /workspaces/memberships/{id}/transfer-ownership) */
+  postWorkspacesMembershipsByIdTransferOwnership(id: string, body: WorkspacesDtoTransferOwnershipRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("POST", `/workspaces/memberships/${encodeURIComponent(String(id))}/transfer-ownership`, undefined, body);
+  }
+
+  /** Update concurrency group (PUT /backup-configs/concurrency-groups/{id}) */
+  putBackupConfigsConcurrencyGroupsById(id: string, body: BackupsConfigConcurrencyGroup): Promise<BackupsConfigConcurrencyGroup> {
+    return this.request<BackupsConfigConcurrencyGroup>("PUT", `/backup-configs/concurrency-groups/${encodeURIComponent(String(id))}`, undefined, body);
+  }
+
+  /** Update a comment (PUT /comments/{id}) */
+  putCommentsById(id: string, body: CommentsUpdateCommentRequest): Promise<CommentsComment> {
+    return this.request<CommentsComment>("PUT", `/comments/${encodeURIComponent(String(id))}`, undefined, body);
+  }
+
+  /** Override feature flag for workspace (PUT /features/workspaces/{workspaceId}) */
+  putFeaturesWorkspacesByWorkspaceId(workspaceId: string, body: FeatureFlagsSetWorkspaceOverrideRequest): Promise<FeatureFlagsWorkspaceFeatureFlag> {
+    return this.request<FeatureFlagsWorkspaceFeatureFlag>("PUT", `/features/workspaces/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Save Grafana integration (PUT /grafana-integrations/workspace/{workspaceId}) */
+  putGrafanaIntegrationsWorkspaceByWorkspaceId(workspaceId: string, body: BackupsGrafanaGrafanaIntegration): Promise<BackupsGrafanaGrafanaIntegration> {
+    return this.request<BackupsGrafanaGrafanaIntegration>("PUT", `/grafana-integrations/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Override message template (PUT /localization/overrides) */
+  putLocalizationOverrides(body: LocalizationUpsertOverrideRequest): Promise<LocalizationMessageTemplateOverride> {
+    return this.request<LocalizationMessageTemplateOverride>("PUT", "/localization/overrides", undefined, body);
+  }
+
+  /** Save masking rules (PUT /masking-rules/{databaseId}) */
+  putMaskingRulesByDatabaseId(databaseId: string, body: MaskingSaveMaskingRulesRequest): Promise<Array<MaskingMaskingRule>> {
+    return this.request<Array<MaskingMaskingRule>>("PUT", `/masking-rules/${encodeURIComponent(String(databaseId))}`, undefined, body);
+  }
+
+  /** Override event severity (PUT /notifiers/events/workspace/{workspaceId}/{eventType}) */
+  putNotifiersEventsWorkspaceByWorkspaceIdByEventType(workspaceId: string, eventType: string, body: NotifiersUpdateEventSeverityRequest): Promise<NotifiersNotificationSeverityOverride> {
+    return this.request<NotifiersNotificationSeverityOverride>("PUT", `/notifiers/events/workspace/${encodeURIComponent(String(workspaceId))}/${encodeURIComponent(String(eventType))}`, undefined, body);
+  }
+
+  /** Update a refresh job (PUT /refresh-jobs/{id}) */
+  putRefreshJobsById(id: string, body: RestoresRefreshesRefreshJob): Promise<RestoresRefreshesRefreshJob> {
+    return this.request<RestoresRefreshesRefreshJob>("PUT", `/refresh-jobs/${encodeURIComponent(String(id))}`, undefined, body);
+  }
+
+  /** Update security event settings (PUT /security-events/settings) */
+  putSecurityEventsSettings(body: NotifiersSecurityEventsSecurityEventSettings): Promise<NotifiersSecurityEventsSecurityEventSettings> {
+    return this.request<NotifiersSecurityEventsSecurityEventSettings>("PUT", "/security-events/settings", undefined, body);
+  }
+
+  /** Save status page (PUT /status-pages/workspace/{workspaceId}) */
+  putStatusPagesWorkspaceByWorkspaceId(workspaceId: string, body: BackupsStatusPagesSaveStatusPageRequest): Promise<BackupsStatusPagesStatusPageTokenResponse> {
+    return this.request<BackupsStatusPagesStatusPageTokenResponse>("PUT", `/status-pages/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Update storage mirror group (PUT /storage-mirror-groups/{id}) */
+  putStorageMirrorGroupsById(id: string, body: StoragesMirrorsUpdateMirrorGroupRequest): Promise<StoragesMirrorsStorageMirrorGroup> {
+    return this.request<StoragesMirrorsStorageMirrorGroup>("PUT", `/storage-mirror-groups/${encodeURIComponent(String(id))}`, undefined, body);
+  }
+
+  /** Update storage quota (PUT /storages/{id}/quota) */
+  putStoragesByIdQuota(id: string, body: StoragesUsageUpdateStorageQuotaRequest): Promise<StoragesUsageStorageQuota> {
+    return this.request<StoragesUsageStorageQuota>("PUT", `/storages/${encodeURIComponent(String(id))}/quota`, undefined, body);
+  }
+
+  /** Set default storage of a workspace (PUT /storages/workspace/{workspaceId}/default) */
+  putStoragesWorkspaceByWorkspaceIdDefault(workspaceId: string, body: StoragesSetDefaultStorageRequest): Promise<void> {
+    return this.request<void>("PUT", `/storages/workspace/${encodeURIComponent(String(workspaceId))}/default`, undefined, body);
+  }
+
+  /** Update branding (PUT /system/branding) */
+  putSystemBranding(body: UsersModelsBrandingSettings): Promise<UsersModelsBrandingSettings> {
+    return this.request<UsersModelsBrandingSettings>("PUT", "/system/branding", undefined, body);
+  }
+
+  /** Save ticket integration (PUT /ticket-integrations/workspace/{workspaceId}) */
+  putTicketIntegrationsWorkspaceByWorkspaceId(workspaceId: string, body: NotifiersTicketsTicketIntegration): Promise<NotifiersTicketsTicketIntegration> {
+    return this.request<NotifiersTicketsTicketIntegration>("PUT", `/ticket-integrations/workspace/${encodeURIComponent(String(workspaceId))}`, undefined, body);
+  }
+
+  /** Change user role (PUT /users/{id}/role) */
+  putUsersByIdRole(id: string, body: UsersDtoChangeUserRoleRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("PUT", `/users/${encodeURIComponent(String(id))}/role`, undefined, body);
+  }
+
+  /** Change user password (PUT /users/change-password) */
+  putUsersChangePassword(body: UsersDtoChangePasswordRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("PUT", "/users/change-password", undefined, body);
+  }
+
+  /** Update current user information (PUT /users/me) */
+  putUsersMe(body: UsersDtoUpdateUserInfoRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("PUT", "/users/me", undefined, body);
+  }
+
+  /** Update own preferences (PUT /users/me/preferences) */
+  putUsersMePreferences(body: UsersModelsUserPreferences): Promise<UsersModelsUserPreferences> {
+    return this.request<UsersModelsUserPreferences>("PUT", "/users/me/preferences", undefined, body);
+  }
+
+  /** Update a saved view (PUT /users/me/views/{id}) */
+  putUsersMeViewsById(id: string, body: SavedViewsSaveViewRequest): Promise<SavedViewsSavedView> {
+    return this.request<SavedViewsSavedView>("PUT", `/users/me/views/${encodeURIComponent(String(id))}`, undefined, body);
+  }
+
+  /** Update users settings (PUT /users/settings) */
+  putUsersSettings(body: UsersModelsUsersSettings): Promise<UsersModelsUsersSettings> {
+    return this.request<UsersModelsUsersSettings>("PUT", "/users/settings", undefined, body);
+  }
+
+  /** Update security headers (PUT /users/settings/headers) */
+  putUsersSettingsHeaders(body: UsersModelsSecurityHeaders): Promise<UsersModelsSecurityHeaders> {
+    return this.request<UsersModelsSecurityHeaders>("PUT", "/users/settings/headers", undefined, body);
+  }
+
+  /** Update security policy (PUT /users/settings/security) */
+  putUsersSettingsSecurity(body: UsersModelsSecurityPolicy): Promise<UsersModelsSecurityPolicy> {
+    return this.request<UsersModelsSecurityPolicy>("PUT", "/users/settings/security", undefined, body);
+  }
+
+  /** Update workspace settings (PUT /workspaces/{id}) */
+  putWorkspacesById(id: string, body: WorkspacesModelsWorkspace): Promise<WorkspacesModelsWorkspace> {
+    return this.request<WorkspacesModelsWorkspace>("PUT", `/workspaces/${encodeURIComponent(String(id))}`, undefined, body);
+  }
+
+  /** Change member role (PUT /workspaces/memberships/{id}/members/{userId}/role) */
+  putWorkspacesMembershipsByIdMembersByUserIdRole(id: string, userId: string, body: WorkspacesDtoChangeMemberRoleRequestDTO): Promise<Record<string, string>> {
+    return this.request<Record<string, string>>("PUT", `/workspaces/memberships/${encodeURIComponent(String(id))}/members/${encodeURIComponent(String(userId))}/role`, undefined, body);
+  }
+}
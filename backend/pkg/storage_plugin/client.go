@@ -0,0 +1,205 @@
+package storage_plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client is used by Databasus to call plugin over gRPC
+type Client struct {
+	conn      *grpc.ClientConn
+	authToken string
+}
+
+func NewClient(address, authToken string) (*Client, error) {
+	conn, err := grpc.NewClient(
+		address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin: %w", err)
+	}
+
+	return &Client{conn, authToken}, nil
+}
+
+func (c *Client) Validate(ctx context.Context, config map[string]string) error {
+	err := c.conn.Invoke(
+		c.withAuth(ctx),
+		getMethodName("Validate"),
+		&configRequest{Config: config},
+		&empty{},
+	)
+
+	return fromStatusError(err)
+}
+
+func (c *Client) TestConnection(ctx context.Context, config map[string]string) error {
+	err := c.conn.Invoke(
+		c.withAuth(ctx),
+		getMethodName("TestConnection"),
+		&configRequest{Config: config},
+		&empty{},
+	)
+
+	return fromStatusError(err)
+}
+
+func (c *Client) DeleteFile(ctx context.Context, config map[string]string, fileID string) error {
+	err := c.conn.Invoke(
+		c.withAuth(ctx),
+		getMethodName("DeleteFile"),
+		&fileRequest{Config: config, FileID: fileID},
+		&empty{},
+	)
+
+	return fromStatusError(err)
+}
+
+func (c *Client) SaveFile(
+	ctx context.Context,
+	config map[string]string,
+	fileID string,
+	file io.Reader,
+) error {
+	stream, err := c.conn.NewStream(
+		c.withAuth(ctx),
+		&serviceDesc.Streams[0],
+		getMethodName("SaveFile"),
+	)
+	if err != nil {
+		return fromStatusError(err)
+	}
+
+	header := &fileRequest{Config: config, FileID: fileID}
+	if err := stream.SendMsg(&fileChunk{Header: header}); err != nil {
+		return c.getStreamError(stream, err)
+	}
+
+	buffer := make([]byte, fileChunkSize)
+	for {
+		n, readErr := file.Read(buffer)
+		if n > 0 {
+			if err := stream.SendMsg(&fileChunk{Data: buffer[:n]}); err != nil {
+				return c.getStreamError(stream, err)
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read file: %w", readErr)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return fromStatusError(err)
+	}
+
+	return fromStatusError(stream.RecvMsg(&empty{}))
+}
+
+func (c *Client) GetFile(
+	ctx context.Context,
+	config map[string]string,
+	fileID string,
+) (io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(c.withAuth(ctx))
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], getMethodName("GetFile"))
+	if err != nil {
+		cancel()
+		return nil, fromStatusError(err)
+	}
+
+	if err := stream.SendMsg(&fileRequest{Config: config, FileID: fileID}); err != nil {
+		cancel()
+		return nil, fromStatusError(err)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		cancel()
+		return nil, fromStatusError(err)
+	}
+
+	// first chunk is received eagerly, so "file not found" is reported
+	// here instead of on first read
+	reader := &fileReader{stream: stream, cancel: cancel}
+	if err := reader.receiveChunk(); err != nil && !errors.Is(err, io.EOF) {
+		cancel()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) withAuth(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authHeaderKey, c.authToken)
+}
+
+// getStreamError returns plugin error instead of generic EOF when
+// plugin aborted upload
+func (c *Client) getStreamError(stream grpc.ClientStream, sendErr error) error {
+	if errors.Is(sendErr, io.EOF) {
+		return fromStatusError(stream.RecvMsg(&empty{}))
+	}
+
+	return fromStatusError(sendErr)
+}
+
+type fileReader struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+	buffer []byte
+	err    error
+}
+
+func (r *fileReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		if err := r.receiveChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+
+	return n, nil
+}
+
+func (r *fileReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+func (r *fileReader) receiveChunk() error {
+	var chunk fileChunk
+	if err := r.stream.RecvMsg(&chunk); err != nil {
+		if errors.Is(err, io.EOF) {
+			r.err = io.EOF
+			return io.EOF
+		}
+
+		r.err = fromStatusError(err)
+		return r.err
+	}
+
+	r.buffer = chunk.Data
+	return nil
+}
@@ -0,0 +1,167 @@
+package storage_plugin
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const handshakeTimeout = 10 * time.Second
+
+// Process is a running plugin launched by Databasus
+type Process struct {
+	Client *Client
+
+	cmd      *exec.Cmd
+	isExited atomic.Bool
+}
+
+// Launch starts plugin executable and waits for handshake line
+func Launch(executablePath string, logger *slog.Logger) (*Process, error) {
+	authToken, err := generateAuthToken()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(executablePath)
+	cmd.Env = append(
+		os.Environ(),
+		MagicCookieKey+"="+MagicCookieValue,
+		AuthTokenKey+"="+authToken,
+	)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin stdout: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plugin stderr: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	process := &Process{cmd: cmd}
+
+	go forwardOutput(stderr, logger, "stderr")
+	go func() {
+		_ = cmd.Wait()
+		process.isExited.Store(true)
+		logger.Info("Storage plugin exited", "path", executablePath)
+	}()
+
+	stdoutReader := bufio.NewReader(stdout)
+
+	address, err := readHandshake(stdoutReader)
+	if err != nil {
+		process.Kill()
+		return nil, err
+	}
+
+	// plugin may print debug output after handshake
+	go forwardOutput(stdoutReader, logger, "stdout")
+
+	client, err := NewClient(address, authToken)
+	if err != nil {
+		process.Kill()
+		return nil, err
+	}
+
+	process.Client = client
+
+	return process, nil
+}
+
+func (p *Process) IsExited() bool {
+	return p.isExited.Load()
+}
+
+func (p *Process) Kill() {
+	if p.Client != nil {
+		_ = p.Client.Close()
+	}
+
+	if p.cmd.Process != nil && !p.IsExited() {
+		_ = p.cmd.Process.Kill()
+	}
+}
+
+func readHandshake(stdout *bufio.Reader) (string, error) {
+	lines := make(chan string, 1)
+	errs := make(chan error, 1)
+
+	go func() {
+		line, err := stdout.ReadString('\n')
+		if err != nil {
+			errs <- fmt.Errorf("plugin exited before handshake: %w", err)
+			return
+		}
+
+		lines <- line
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+
+	select {
+	case line := <-lines:
+		return parseHandshake(line)
+	case err := <-errs:
+		return "", err
+	case <-ctx.Done():
+		return "", errors.New("timed out waiting for plugin handshake")
+	}
+}
+
+// parseHandshake parses "<protocol version>|tcp|<host:port>" line
+func parseHandshake(line string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid plugin handshake: %q", line)
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil || version != ProtocolVersion {
+		return "", fmt.Errorf(
+			"unsupported plugin protocol version %s, expected %d",
+			parts[0],
+			ProtocolVersion,
+		)
+	}
+
+	if parts[1] != "tcp" {
+		return "", fmt.Errorf("unsupported plugin network %q", parts[1])
+	}
+
+	return parts[2], nil
+}
+
+func forwardOutput(output io.Reader, logger *slog.Logger, stream string) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		logger.Info("Storage plugin output", "stream", stream, "line", scanner.Text())
+	}
+}
+
+func generateAuthToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate plugin token: %w", err)
+	}
+
+	return hex.EncodeToString(token), nil
+}
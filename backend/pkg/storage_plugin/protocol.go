@@ -0,0 +1,244 @@
+// Package storage_plugin implements protocol between Databasus and
+// out-of-process storage plugins. Plugin is an executable launched by
+// Databasus, it serves gRPC on localhost and reports its address in a
+// handshake line printed to stdout (similar to hashicorp/go-plugin)
+package storage_plugin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ProtocolVersion = 1
+
+	// MagicCookieKey and MagicCookieValue protect from running plugin
+	// binary by hand, it is not a security measure
+	MagicCookieKey   = "DATABASUS_STORAGE_PLUGIN_COOKIE"
+	MagicCookieValue = "8c4a2f61e9d04b7a93f1c5e27d6b0a48"
+
+	// AuthTokenKey is a random token generated by Databasus for each
+	// launch, plugin rejects calls from other local processes without it
+	AuthTokenKey = "DATABASUS_STORAGE_PLUGIN_TOKEN"
+
+	serviceName   = "databasus.storage.v1.StorageBackend"
+	authHeaderKey = "x-databasus-plugin-token"
+	fileChunkSize = 1 << 20
+)
+
+type configRequest struct {
+	Config map[string]string `json:"config"`
+}
+
+type fileRequest struct {
+	Config map[string]string `json:"config"`
+	FileID string            `json:"fileId"`
+}
+
+type empty struct{}
+
+// fileChunk is encoded as binary to not pay base64 overhead on backup
+// data. First chunk of upload carries header with config and file ID
+type fileChunk struct {
+	Header *fileRequest
+	Data   []byte
+}
+
+func (c *fileChunk) MarshalBinary() ([]byte, error) {
+	header := []byte{}
+	if c.Header != nil {
+		encodedHeader, err := json.Marshal(c.Header)
+		if err != nil {
+			return nil, err
+		}
+
+		header = encodedHeader
+	}
+
+	result := make([]byte, 4+len(header)+len(c.Data))
+	binary.BigEndian.PutUint32(result, uint32(len(header)))
+	copy(result[4:], header)
+	copy(result[4+len(header):], c.Data)
+
+	return result, nil
+}
+
+func (c *fileChunk) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errors.New("file chunk is too short")
+	}
+
+	headerLength := int(binary.BigEndian.Uint32(data))
+	if 4+headerLength > len(data) {
+		return errors.New("file chunk header is truncated")
+	}
+
+	c.Header = nil
+	if headerLength > 0 {
+		c.Header = &fileRequest{}
+		if err := json.Unmarshal(data[4:4+headerLength], c.Header); err != nil {
+			return fmt.Errorf("failed to decode file chunk header: %w", err)
+		}
+	}
+
+	c.Data = append([]byte(nil), data[4+headerLength:]...)
+
+	return nil
+}
+
+// codec replaces protobuf, so protocol does not require generated code
+// and plugins can be written in any language with gRPC support
+type codec struct{}
+
+func (codec) Name() string {
+	return "databasus-json"
+}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	if chunk, ok := v.(*fileChunk); ok {
+		return chunk.MarshalBinary()
+	}
+
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	if chunk, ok := v.(*fileChunk); ok {
+		return chunk.UnmarshalBinary(data)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Backend)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Validate", Handler: handleValidate},
+		{MethodName: "TestConnection", Handler: handleTestConnection},
+		{MethodName: "DeleteFile", Handler: handleDeleteFile},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "SaveFile", Handler: handleSaveFile, ClientStreams: true},
+		{StreamName: "GetFile", Handler: handleGetFile, ServerStreams: true},
+	},
+}
+
+func getMethodName(name string) string {
+	return "/" + serviceName + "/" + name
+}
+
+func handleValidate(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	var request configRequest
+	if err := dec(&request); err != nil {
+		return nil, err
+	}
+
+	return invokeUnary(ctx, &request, "Validate", interceptor, func(ctx context.Context) error {
+		return srv.(Backend).Validate(ctx, request.Config)
+	})
+}
+
+func handleTestConnection(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	var request configRequest
+	if err := dec(&request); err != nil {
+		return nil, err
+	}
+
+	return invokeUnary(ctx, &request, "TestConnection", interceptor, func(ctx context.Context) error {
+		return srv.(Backend).TestConnection(ctx, request.Config)
+	})
+}
+
+func handleDeleteFile(
+	srv any,
+	ctx context.Context,
+	dec func(any) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	var request fileRequest
+	if err := dec(&request); err != nil {
+		return nil, err
+	}
+
+	return invokeUnary(ctx, &request, "DeleteFile", interceptor, func(ctx context.Context) error {
+		return srv.(Backend).DeleteFile(ctx, request.Config, request.FileID)
+	})
+}
+
+// invokeUnary runs call through server interceptor, gRPC delegates this
+// to method handlers instead of doing it itself
+func invokeUnary(
+	ctx context.Context,
+	request any,
+	methodName string,
+	interceptor grpc.UnaryServerInterceptor,
+	call func(ctx context.Context) error,
+) (any, error) {
+	handler := func(ctx context.Context, _ any) (any, error) {
+		if err := call(ctx); err != nil {
+			return nil, toStatusError(err)
+		}
+
+		return &empty{}, nil
+	}
+
+	if interceptor == nil {
+		return handler(ctx, request)
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: getMethodName(methodName)}
+	return interceptor(ctx, request, info, handler)
+}
+
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// fromStatusError strips gRPC prefix, so users see plugin message as is
+func fromStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if statusErr, ok := status.FromError(err); ok {
+		return errors.New(statusErr.Message())
+	}
+
+	return err
+}
+
+func checkAuthToken(ctx context.Context, expectedToken string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	tokens := md.Get(authHeaderKey)
+	if len(tokens) == 0 ||
+		subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(expectedToken)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid plugin token")
+	}
+
+	return nil
+}
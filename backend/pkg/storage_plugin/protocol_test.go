@@ -0,0 +1,137 @@
+package storage_plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryBackend struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (b *memoryBackend) Validate(_ context.Context, config map[string]string) error {
+	if config["bucket"] == "" {
+		return errors.New("bucket is required")
+	}
+
+	return nil
+}
+
+func (b *memoryBackend) TestConnection(_ context.Context, _ map[string]string) error {
+	return nil
+}
+
+func (b *memoryBackend) SaveFile(
+	_ context.Context,
+	_ map[string]string,
+	fileID string,
+	file io.Reader,
+) error {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.files[fileID] = content
+
+	return nil
+}
+
+func (b *memoryBackend) GetFile(
+	_ context.Context,
+	_ map[string]string,
+	fileID string,
+) (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	content, isFound := b.files[fileID]
+	if !isFound {
+		return nil, errors.New("file not found")
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (b *memoryBackend) DeleteFile(_ context.Context, _ map[string]string, fileID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.files, fileID)
+
+	return nil
+}
+
+func Test_Client_SaveAndGetFile_ReturnsSameContent(t *testing.T) {
+	client := startTestServer(t, "token")
+	content := bytes.Repeat([]byte("backup"), fileChunkSize)
+
+	err := client.SaveFile(context.Background(), nil, "file-1", bytes.NewReader(content))
+	assert.NoError(t, err)
+
+	file, err := client.GetFile(context.Background(), nil, "file-1")
+	assert.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	receivedContent, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, content, receivedContent)
+
+	assert.NoError(t, client.DeleteFile(context.Background(), nil, "file-1"))
+
+	_, err = client.GetFile(context.Background(), nil, "file-1")
+	assert.EqualError(t, err, "file not found")
+}
+
+func Test_Client_Validate_ReturnsPluginErrorMessage(t *testing.T) {
+	client := startTestServer(t, "token")
+
+	err := client.Validate(context.Background(), map[string]string{})
+	assert.EqualError(t, err, "bucket is required")
+
+	assert.NoError(t, client.Validate(context.Background(), map[string]string{"bucket": "b"}))
+}
+
+func Test_Client_WithWrongToken_IsRejected(t *testing.T) {
+	client := startTestServer(t, "token")
+	client.authToken = "wrong"
+
+	err := client.TestConnection(context.Background(), nil)
+	assert.EqualError(t, err, "invalid plugin token")
+}
+
+func Test_ParseHandshake_WithUnsupportedVersion_ReturnsError(t *testing.T) {
+	address, err := parseHandshake("1|tcp|127.0.0.1:1234\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:1234", address)
+
+	_, err = parseHandshake("2|tcp|127.0.0.1:1234")
+	assert.Error(t, err)
+}
+
+func startTestServer(t *testing.T, authToken string) *Client {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	server := NewServer(&memoryBackend{files: map[string][]byte{}}, authToken)
+	go func() { _ = server.Serve(listener) }()
+
+	client, err := NewClient(listener.Addr().String(), authToken)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = client.Close()
+		server.Stop()
+	})
+
+	return client
+}
@@ -0,0 +1,179 @@
+package storage_plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+)
+
+// Backend is implemented by plugin authors. Config contains values entered
+// by user in storage settings, secret values are already decrypted
+type Backend interface {
+	Validate(ctx context.Context, config map[string]string) error
+	TestConnection(ctx context.Context, config map[string]string) error
+	SaveFile(ctx context.Context, config map[string]string, fileID string, file io.Reader) error
+	GetFile(ctx context.Context, config map[string]string, fileID string) (io.ReadCloser, error)
+	DeleteFile(ctx context.Context, config map[string]string, fileID string) error
+}
+
+// Serve is called from plugin main function. It blocks until Databasus
+// stops the plugin process
+func Serve(backend Backend) {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		_, _ = fmt.Fprintln(
+			os.Stderr,
+			"This binary is a Databasus storage plugin and is not meant to be executed directly. "+
+				"Put it into STORAGE_PLUGINS_DIR of Databasus instead",
+		)
+		os.Exit(1)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to listen: %v\n", err)
+		os.Exit(1)
+	}
+
+	server := NewServer(backend, os.Getenv(AuthTokenKey))
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+
+		server.GracefulStop()
+	}()
+
+	// handshake line must be the first line written to stdout
+	_, _ = fmt.Fprintf(os.Stdout, "%d|tcp|%s\n", ProtocolVersion, listener.Addr().String())
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		_, _ = fmt.Fprintf(os.Stderr, "plugin server stopped: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// NewServer creates gRPC server for the backend. It is exported to let
+// plugin authors test their backend without launching a process
+func NewServer(backend Backend, authToken string) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(codec{}),
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req any,
+			_ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			if err := checkAuthToken(ctx, authToken); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(
+			srv any,
+			stream grpc.ServerStream,
+			_ *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			if err := checkAuthToken(stream.Context(), authToken); err != nil {
+				return err
+			}
+
+			return handler(srv, stream)
+		}),
+	)
+
+	server.RegisterService(&serviceDesc, backend)
+
+	return server
+}
+
+func handleSaveFile(srv any, stream grpc.ServerStream) error {
+	var firstChunk fileChunk
+	if err := stream.RecvMsg(&firstChunk); err != nil {
+		return err
+	}
+
+	if firstChunk.Header == nil {
+		return toStatusError(errors.New("first upload chunk must contain header"))
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		if _, err := writer.Write(firstChunk.Data); err != nil {
+			return
+		}
+
+		for {
+			var chunk fileChunk
+			err := stream.RecvMsg(&chunk)
+			if errors.Is(err, io.EOF) {
+				_ = writer.Close()
+				return
+			}
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+
+			if _, err := writer.Write(chunk.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	err := srv.(Backend).SaveFile(
+		stream.Context(),
+		firstChunk.Header.Config,
+		firstChunk.Header.FileID,
+		reader,
+	)
+
+	// unblocks receiving goroutine if backend stopped reading early
+	_ = reader.CloseWithError(errors.New("upload finished"))
+
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	return stream.SendMsg(&empty{})
+}
+
+func handleGetFile(srv any, stream grpc.ServerStream) error {
+	var request fileRequest
+	if err := stream.RecvMsg(&request); err != nil {
+		return err
+	}
+
+	file, err := srv.(Backend).GetFile(stream.Context(), request.Config, request.FileID)
+	if err != nil {
+		return toStatusError(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	buffer := make([]byte, fileChunkSize)
+	for {
+		n, readErr := file.Read(buffer)
+		if n > 0 {
+			if err := stream.SendMsg(&fileChunk{Data: buffer[:n]}); err != nil {
+				return err
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return toStatusError(readErr)
+		}
+	}
+}
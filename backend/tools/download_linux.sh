@@ -300,6 +300,44 @@ fi
 
 echo
 
+# ========== InfluxDB CLI Installation ==========
+echo "========================================"
+echo "Installing InfluxDB CLI (single latest version)..."
+echo "========================================"
+
+INFLUXDB_DIR="$(pwd)/influxdb"
+mkdir -p "$INFLUXDB_DIR/bin"
+
+if [ -f "$INFLUXDB_DIR/bin/influx" ]; then
+    echo "InfluxDB CLI already installed, skipping..."
+else
+    ARCH=$(uname -m)
+    if [ "$ARCH" = "x86_64" ]; then
+        INFLUX_ARCH="amd64"
+    elif [ "$ARCH" = "aarch64" ]; then
+        INFLUX_ARCH="arm64"
+    else
+        echo "Warning: Unsupported architecture $ARCH for InfluxDB CLI"
+        INFLUX_ARCH=""
+    fi
+
+    if [ -n "$INFLUX_ARCH" ]; then
+        INFLUX_URL="https://dl.influxdata.com/influxdb/releases/influxdb2-client-2.7.5-linux-$INFLUX_ARCH.tar.gz"
+
+        echo "Downloading InfluxDB CLI..."
+        if wget -q "$INFLUX_URL" -O /tmp/influxdb-client.tar.gz; then
+            tar -xzf /tmp/influxdb-client.tar.gz -C "$INFLUXDB_DIR/bin" ./influx
+            chmod +x "$INFLUXDB_DIR/bin/influx"
+            echo "InfluxDB CLI installed successfully"
+        else
+            echo "Warning: Could not download InfluxDB CLI"
+        fi
+        rm -f /tmp/influxdb-client.tar.gz
+    fi
+fi
+
+echo
+
 echo "========================================"
 echo "Installation completed!"
 echo "========================================"
@@ -308,6 +346,7 @@ echo "PostgreSQL client tools are available in: $POSTGRES_DIR"
 echo "MySQL client tools are available in: $MYSQL_DIR"
 echo "MariaDB client tools are available in: $MARIADB_DIR"
 echo "MongoDB Database Tools are available in: $MONGODB_DIR"
+echo "InfluxDB CLI is available in: $INFLUXDB_DIR"
 echo
 
 # List installed PostgreSQL versions
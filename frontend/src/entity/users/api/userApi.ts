@@ -8,6 +8,7 @@ import type { InviteUserResponse } from '../model/InviteUserResponse';
 import type { IsAdminHasPasswordResponse } from '../model/IsAdminHasPasswordResponse';
 import type { OAuthCallbackRequest } from '../model/OAuthCallbackRequest';
 import type { OAuthCallbackResponse } from '../model/OAuthCallbackResponse';
+import type { ResetPasswordByTokenRequest } from '../model/ResetPasswordByTokenRequest';
 import type { ResetPasswordRequest } from '../model/ResetPasswordRequest';
 import type { SendResetPasswordCodeRequest } from '../model/SendResetPasswordCodeRequest';
 import type { SetAdminPasswordRequest } from '../model/SetAdminPasswordRequest';
@@ -16,6 +17,7 @@ import type { SignInResponse } from '../model/SignInResponse';
 import type { SignUpRequest } from '../model/SignUpRequest';
 import type { UpdateUserInfoRequest } from '../model/UpdateUserInfoRequest';
 import type { UserProfile } from '../model/UserProfile';
+import type { VerifyEmailRequest } from '../model/VerifyEmailRequest';
 
 const listeners: (() => void)[] = [];
 
@@ -154,6 +156,24 @@ export const userApi = {
     );
   },
 
+  async resetPasswordByToken(request: ResetPasswordByTokenRequest): Promise<{ message: string }> {
+    const requestOptions: RequestOptions = new RequestOptions();
+    requestOptions.setBody(JSON.stringify(request));
+    return apiHelper.fetchPostJson(
+      `${getApplicationServer()}/api/v1/users/reset-password-by-token`,
+      requestOptions,
+    );
+  },
+
+  async verifyEmail(request: VerifyEmailRequest): Promise<{ message: string }> {
+    const requestOptions: RequestOptions = new RequestOptions();
+    requestOptions.setBody(JSON.stringify(request));
+    return apiHelper.fetchPostJson(
+      `${getApplicationServer()}/api/v1/users/verify-email`,
+      requestOptions,
+    );
+  },
+
   isAuthorized: (): boolean => !!accessTokenHelper.getAccessToken(),
 
   logout: () => {
@@ -20,5 +20,7 @@ export type { ChangeUserRoleRequest } from './model/ChangeUserRoleRequest';
 export type { UsersSettings } from './model/UsersSettings';
 export type { SendResetPasswordCodeRequest } from './model/SendResetPasswordCodeRequest';
 export type { ResetPasswordRequest } from './model/ResetPasswordRequest';
+export type { ResetPasswordByTokenRequest } from './model/ResetPasswordByTokenRequest';
+export type { VerifyEmailRequest } from './model/VerifyEmailRequest';
 export { UserRole } from './model/UserRole';
 export { WorkspaceRole } from './model/WorkspaceRole';
@@ -0,0 +1,4 @@
+export interface ResetPasswordByTokenRequest {
+  token: string;
+  newPassword: string;
+}
@@ -0,0 +1,3 @@
+export interface VerifyEmailRequest {
+  token: string;
+}
@@ -2,6 +2,7 @@ export { AdminPasswordComponent } from './ui/AdminPasswordComponent';
 export { AuthNavbarComponent } from './ui/AuthNavbarComponent';
 export { ProfileComponent } from './ui/ProfileComponent';
 export { RequestResetPasswordComponent } from './ui/RequestResetPasswordComponent';
+export { ResetPasswordByTokenComponent } from './ui/ResetPasswordByTokenComponent';
 export { ResetPasswordComponent } from './ui/ResetPasswordComponent';
 export { SignInComponent } from './ui/SignInComponent';
 export { SignUpComponent } from './ui/SignUpComponent';
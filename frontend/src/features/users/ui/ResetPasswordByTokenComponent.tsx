@@ -0,0 +1,150 @@
+import { EyeInvisibleOutlined, EyeTwoTone } from '@ant-design/icons';
+import { App, Button, Input } from 'antd';
+import { type JSX, useState } from 'react';
+
+import { userApi } from '../../../entity/users';
+import { StringUtils } from '../../../shared/lib';
+
+interface ResetPasswordByTokenComponentProps {
+  token: string;
+  onSwitchToSignIn: () => void;
+}
+
+export function ResetPasswordByTokenComponent({
+  token,
+  onSwitchToSignIn,
+}: ResetPasswordByTokenComponentProps): JSX.Element {
+  const { message } = App.useApp();
+  const [newPassword, setNewPassword] = useState('');
+  const [confirmPassword, setConfirmPassword] = useState('');
+  const [passwordVisible, setPasswordVisible] = useState(false);
+  const [confirmPasswordVisible, setConfirmPasswordVisible] = useState(false);
+
+  const [isLoading, setLoading] = useState(false);
+
+  const [passwordError, setPasswordError] = useState(false);
+  const [confirmPasswordError, setConfirmPasswordError] = useState(false);
+
+  const [error, setError] = useState('');
+
+  const validateFields = (): boolean => {
+    let isValid = true;
+
+    if (!newPassword) {
+      setPasswordError(true);
+      isValid = false;
+    } else if (newPassword.length < 8) {
+      setPasswordError(true);
+      message.error('Password must be at least 8 characters long');
+      isValid = false;
+    } else {
+      setPasswordError(false);
+    }
+
+    if (!confirmPassword) {
+      setConfirmPasswordError(true);
+      isValid = false;
+    } else if (newPassword !== confirmPassword) {
+      setConfirmPasswordError(true);
+      message.error('Passwords do not match');
+      isValid = false;
+    } else {
+      setConfirmPasswordError(false);
+    }
+
+    return isValid;
+  };
+
+  const onResetPassword = async () => {
+    setError('');
+
+    if (validateFields()) {
+      setLoading(true);
+
+      try {
+        await userApi.resetPasswordByToken({
+          token,
+          newPassword,
+        });
+
+        message.success('Password reset successfully! Redirecting to sign in...');
+
+        // Redirect to sign in after successful reset
+        setTimeout(() => {
+          onSwitchToSignIn();
+        }, 2000);
+      } catch (e) {
+        setError(StringUtils.capitalizeFirstLetter((e as Error).message));
+      }
+
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="w-full max-w-[300px]">
+      <div className="mb-5 text-center text-2xl font-bold">Reset Password</div>
+
+      <div className="mb-4 text-center text-sm text-gray-600 dark:text-gray-400">
+        Enter your new password.
+      </div>
+
+      <div className="my-1 text-xs font-semibold">New Password</div>
+      <Input.Password
+        placeholder="********"
+        value={newPassword}
+        onChange={(e) => {
+          setPasswordError(false);
+          setNewPassword(e.currentTarget.value);
+        }}
+        status={passwordError ? 'error' : undefined}
+        iconRender={(visible) => (visible ? <EyeTwoTone /> : <EyeInvisibleOutlined />)}
+        visibilityToggle={{ visible: passwordVisible, onVisibleChange: setPasswordVisible }}
+      />
+
+      <div className="my-1 text-xs font-semibold">Confirm Password</div>
+      <Input.Password
+        placeholder="********"
+        value={confirmPassword}
+        status={confirmPasswordError ? 'error' : undefined}
+        onChange={(e) => {
+          setConfirmPasswordError(false);
+          setConfirmPassword(e.currentTarget.value);
+        }}
+        iconRender={(visible) => (visible ? <EyeTwoTone /> : <EyeInvisibleOutlined />)}
+        visibilityToggle={{
+          visible: confirmPasswordVisible,
+          onVisibleChange: setConfirmPasswordVisible,
+        }}
+      />
+
+      <div className="mt-3" />
+
+      <Button
+        disabled={isLoading}
+        loading={isLoading}
+        className="w-full"
+        onClick={() => {
+          onResetPassword();
+        }}
+        type="primary"
+      >
+        Reset password
+      </Button>
+
+      {error && (
+        <div className="mt-3 flex justify-center text-center text-sm text-red-600">{error}</div>
+      )}
+
+      <div className="mt-4 text-center text-sm text-gray-600 dark:text-gray-400">
+        <button
+          type="button"
+          onClick={onSwitchToSignIn}
+          className="cursor-pointer font-medium text-blue-600 hover:text-blue-700 dark:!text-blue-500"
+        >
+          Back to sign in
+        </button>
+      </div>
+    </div>
+  );
+}
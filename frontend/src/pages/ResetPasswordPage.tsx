@@ -0,0 +1,40 @@
+import { useNavigate, useSearchParams } from 'react-router';
+
+import { AuthNavbarComponent, ResetPasswordByTokenComponent } from '../features/users';
+import { useScreenHeight } from '../shared/hooks';
+
+export function ResetPasswordPage() {
+  const [searchParams] = useSearchParams();
+  const navigate = useNavigate();
+  const screenHeight = useScreenHeight();
+
+  const token = searchParams.get('token');
+
+  return (
+    <div className="h-full dark:bg-gray-900" style={{ height: screenHeight }}>
+      <AuthNavbarComponent />
+
+      <div className="mt-10 flex justify-center sm:mt-[10vh]">
+        {token ? (
+          <ResetPasswordByTokenComponent token={token} onSwitchToSignIn={() => navigate('/')} />
+        ) : (
+          <div>
+            <div className="mb-4 text-center text-xl font-semibold text-red-600">
+              Invalid Reset Link
+            </div>
+            <div className="text-center text-sm text-gray-600">Reset token not found</div>
+            <div className="mt-6 text-center">
+              <button
+                type="button"
+                onClick={() => navigate('/')}
+                className="cursor-pointer font-medium text-blue-600 hover:text-blue-700"
+              >
+                Return to sign in
+              </button>
+            </div>
+          </div>
+        )}
+      </div>
+    </div>
+  );
+}
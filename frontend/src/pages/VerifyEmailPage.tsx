@@ -0,0 +1,84 @@
+import { LoadingOutlined } from '@ant-design/icons';
+import { Spin } from 'antd';
+import { useEffect, useRef, useState } from 'react';
+import { useNavigate, useSearchParams } from 'react-router';
+
+import { userApi } from '../entity/users';
+
+export function VerifyEmailPage() {
+  const [searchParams] = useSearchParams();
+  const navigate = useNavigate();
+  const [error, setError] = useState<string>('');
+  const [isVerified, setIsVerified] = useState(false);
+
+  // the token is single-use, so StrictMode must not send it twice
+  const isRequestSentRef = useRef(false);
+
+  useEffect(() => {
+    if (isRequestSentRef.current) {
+      return;
+    }
+    isRequestSentRef.current = true;
+
+    const verifyEmail = async () => {
+      const token = searchParams.get('token');
+
+      if (!token) {
+        setError('Verification token not found');
+        return;
+      }
+
+      try {
+        await userApi.verifyEmail({ token });
+        setIsVerified(true);
+      } catch (e) {
+        setError((e as Error).message || 'Email verification failed');
+      }
+    };
+
+    verifyEmail();
+  }, [searchParams]);
+
+  if (!error && !isVerified) {
+    return (
+      <div className="flex h-screen w-screen flex-col items-center justify-center">
+        <div className="flex flex-col items-center">
+          <Spin indicator={<LoadingOutlined spin />} size="large" />
+          <div className="mt-4 text-gray-600">Verifying email...</div>
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="flex h-screen w-screen flex-col items-center justify-center">
+      <div>
+        {error ? (
+          <>
+            <div className="mb-4 text-center text-xl font-semibold text-red-600">
+              Verification Failed
+            </div>
+            <div className="text-center text-sm text-gray-600">{error}</div>
+          </>
+        ) : (
+          <>
+            <div className="mb-4 text-center text-xl font-semibold">Email Verified</div>
+            <div className="text-center text-sm text-gray-600">
+              Your email address has been verified
+            </div>
+          </>
+        )}
+
+        <div className="mt-6 text-center">
+          <button
+            type="button"
+            onClick={() => navigate('/')}
+            className="cursor-pointer font-medium text-blue-600 hover:text-blue-700"
+          >
+            Continue
+          </button>
+        </div>
+      </div>
+    </div>
+  );
+}